@@ -0,0 +1,541 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/env"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+)
+
+const (
+	// standbyStateFile is the file of a standby tablet directory which holds
+	// the state of the standby.
+	standbyStateFile = "standby.json"
+
+	standbyPoolHandler      = "/standby_pool"
+	standbyPoolClaimHandler = "/standby_pool/claim"
+)
+
+var (
+	standbyPoolSize            int
+	standbyPoolRefreshInterval = 15 * time.Minute
+
+	standbyPoolOperations = stats.NewCountersWithMultiLabels(
+		"StandbyPoolOperations",
+		"Number of standbys created and refreshed by the standby pool.",
+		[]string{"operation", "result"},
+	)
+	standbyPoolClaims = stats.NewCounter(
+		"StandbyPoolClaims",
+		"Number of standbys claimed from the standby pool.",
+	)
+)
+
+// standby is a tablet directory restored from the most recent backup of the
+// shard, and caught up with its primary, whose mysqld is shut down. A tablet
+// started with the UID of the standby reuses its data instead of restoring it.
+type standby struct {
+	Keyspace    string
+	Shard       string
+	TabletUID   uint32
+	TabletDir   string
+	Position    string
+	RestoredAt  time.Time
+	RefreshedAt time.Time
+
+	// refreshing is true while the mysqld of the standby is running.
+	refreshing bool
+}
+
+// standbyPool maintains standbyPoolSize standbys of the shard, which it
+// catches up with the primary every standbyPoolRefreshInterval.
+type standbyPool struct {
+	topoServer *topo.Server
+	tmc        tmclient.TabletManagerClient
+
+	// wakeup triggers a maintenance of the pool, e.g. after a claim.
+	wakeup chan struct{}
+
+	mu       sync.Mutex
+	standbys map[uint32]*standby
+}
+
+func newStandbyPool(topoServer *topo.Server) *standbyPool {
+	pool := &standbyPool{
+		topoServer: topoServer,
+		tmc:        tmclient.NewTabletManagerClient(),
+		wakeup:     make(chan struct{}, 1),
+		standbys:   make(map[uint32]*standby),
+	}
+	stats.NewGaugesFuncWithMultiLabels(
+		"StandbyPoolStandbys",
+		"Number of standbys of the standby pool, by state.",
+		[]string{"state"},
+		pool.countByState,
+	)
+	return pool
+}
+
+// runStandbyPool maintains the standby pool until ctx is done.
+func runStandbyPool(ctx context.Context, topoServer *topo.Server) error {
+	pool := newStandbyPool(topoServer)
+	defer pool.tmc.Close()
+	if err := pool.load(); err != nil {
+		return err
+	}
+	servenv.HTTPHandleFunc(standbyPoolHandler, pool.handleList)
+	servenv.HTTPHandleFunc(standbyPoolClaimHandler, pool.handleClaim)
+
+	log.Infof("Maintaining %d standbys for %v/%v, refreshed every %v", standbyPoolSize, initKeyspace, initShard, standbyPoolRefreshInterval)
+	for {
+		pool.maintain(ctx)
+
+		// Check often enough for the standbys to be refreshed on time.
+		select {
+		case <-ctx.Done():
+			log.Info("Exiting.")
+			return nil
+		case <-pool.wakeup:
+		case <-time.After(min(time.Minute, standbyPoolRefreshInterval)):
+		}
+	}
+}
+
+// load finds the standbys of the shard left by a previous run.
+func (pool *standbyPool) load() error {
+	paths, err := filepath.Glob(filepath.Join(env.VtDataRoot(), "vt_*", standbyStateFile))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sb := &standby{}
+		if err := json.Unmarshal(data, sb); err != nil {
+			return fmt.Errorf("can't parse standby state %v: %v", path, err)
+		}
+		if sb.Keyspace != initKeyspace || sb.Shard != initShard {
+			continue
+		}
+		log.Infof("Found standby %d at position %v, refreshed at %v", sb.TabletUID, sb.Position, sb.RefreshedAt)
+		pool.standbys[sb.TabletUID] = sb
+	}
+	return nil
+}
+
+// maintain creates the missing standbys, and refreshes the stale ones. A
+// standby which can't be refreshed is removed, and restored again.
+func (pool *standbyPool) maintain(ctx context.Context) {
+	for pool.size() < standbyPoolSize {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := pool.create(ctx); err != nil {
+			log.Errorf("Failed to create a standby: %v", err)
+			standbyPoolOperations.Add([]string{"create", "failure"}, 1)
+			return
+		}
+		standbyPoolOperations.Add([]string{"create", "success"}, 1)
+	}
+
+	for _, sb := range pool.stale() {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := pool.refresh(ctx, sb); err != nil {
+			if ctx.Err() != nil {
+				// The standby is refreshed again by the next run.
+				return
+			}
+			log.Errorf("Failed to refresh standby %d, removing it: %v", sb.TabletUID, err)
+			standbyPoolOperations.Add([]string{"refresh", "failure"}, 1)
+			pool.remove(sb)
+			continue
+		}
+		standbyPoolOperations.Add([]string{"refresh", "success"}, 1)
+	}
+}
+
+func (pool *standbyPool) size() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return len(pool.standbys)
+}
+
+// stale returns the standbys due for a refresh, the least recently refreshed
+// first.
+func (pool *standbyPool) stale() []*standby {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	var standbys []*standby
+	for _, sb := range pool.standbys {
+		if time.Since(sb.RefreshedAt) >= standbyPoolRefreshInterval {
+			standbys = append(standbys, sb)
+		}
+	}
+	slices.SortFunc(standbys, func(a, b *standby) int {
+		return a.RefreshedAt.Compare(b.RefreshedAt)
+	})
+	return standbys
+}
+
+// create restores a new standby from the most recent backup.
+func (pool *standbyPool) create(ctx context.Context) (err error) {
+	uid, err := randomTabletUID()
+	if err != nil {
+		return err
+	}
+	tabletDir := mysqlctl.TabletDir(uid)
+	defer func() {
+		if err != nil {
+			log.Infof("Removing standby tablet directory: %v", tabletDir)
+			if err := os.RemoveAll(tabletDir); err != nil {
+				log.Warningf("Failed to remove standby tablet directory: %v", err)
+			}
+		}
+	}()
+
+	log.Infof("Creating standby %d in %v", uid, tabletDir)
+	mysqld, mycnf, err := mysqlctl.CreateMysqldAndMycnf(uid, mysqlSocket, mysqlPort, collationEnv)
+	if err != nil {
+		return fmt.Errorf("failed to initialize mysql config: %v", err)
+	}
+	initCtx, initCancel := context.WithTimeout(ctx, mysqlTimeout)
+	defer initCancel()
+	if err := mysqld.Init(initCtx, mycnf, initDBSQLFile); err != nil {
+		return fmt.Errorf("failed to initialize mysql data dir and start mysqld: %v", err)
+	}
+	defer shutdownStandbyMysqld(mysqld, mycnf)
+
+	dbName := initDbNameOverride
+	if dbName == "" {
+		dbName = fmt.Sprintf("vt_%s", initKeyspace)
+	}
+	backupManifest, err := mysqlctl.Restore(ctx, mysqlctl.RestoreParams{
+		Cnf:                  mycnf,
+		Mysqld:               mysqld,
+		Logger:               logutil.NewConsoleLogger(),
+		Concurrency:          concurrency,
+		DeleteBeforeRestore:  true,
+		DbName:               dbName,
+		Keyspace:             initKeyspace,
+		Shard:                initShard,
+		Stats:                backupstats.RestoreStats(),
+		MysqlShutdownTimeout: mysqlShutdownTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("can't restore from backup: %v", err)
+	}
+	restoredAt := time.Now()
+	if err := resetReplication(ctx, backupManifest.Position, mysqld); err != nil {
+		return fmt.Errorf("error resetting replication: %v", err)
+	}
+	pos, err := pool.catchUp(ctx, mysqld)
+	if err != nil {
+		return err
+	}
+
+	sb := &standby{
+		Keyspace:    initKeyspace,
+		Shard:       initShard,
+		TabletUID:   uid,
+		TabletDir:   tabletDir,
+		Position:    replication.EncodePosition(pos),
+		RestoredAt:  restoredAt,
+		RefreshedAt: time.Now(),
+	}
+	if err := sb.save(); err != nil {
+		return err
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.standbys[uid] = sb
+	log.Infof("Standby %d is ready at position %v", uid, sb.Position)
+	return nil
+}
+
+// refresh starts the mysqld of the standby, and catches it up with the
+// primary.
+func (pool *standbyPool) refresh(ctx context.Context, sb *standby) error {
+	if !pool.startRefresh(sb) {
+		// The standby was claimed in the meantime.
+		return nil
+	}
+	defer pool.endRefresh(sb)
+
+	log.Infof("Refreshing standby %d from position %v", sb.TabletUID, sb.Position)
+	mysqld, mycnf, err := mysqlctl.OpenMysqldAndMycnf(sb.TabletUID, collationEnv)
+	if err != nil {
+		return err
+	}
+	startCtx, startCancel := context.WithTimeout(ctx, mysqlTimeout)
+	defer startCancel()
+	if err := mysqld.Start(startCtx, mycnf); err != nil {
+		return fmt.Errorf("failed to start mysqld: %v", err)
+	}
+	defer shutdownStandbyMysqld(mysqld, mycnf)
+
+	pos, err := pool.catchUp(ctx, mysqld)
+	if err != nil {
+		return err
+	}
+	pool.mu.Lock()
+	sb.Position = replication.EncodePosition(pos)
+	sb.RefreshedAt = time.Now()
+	pool.mu.Unlock()
+	return sb.save()
+}
+
+func (pool *standbyPool) startRefresh(sb *standby) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.standbys[sb.TabletUID] != sb {
+		return false
+	}
+	sb.refreshing = true
+	return true
+}
+
+func (pool *standbyPool) endRefresh(sb *standby) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	sb.refreshing = false
+}
+
+// catchUp replicates from the primary until the position of the primary when
+// it is called, and returns the position it stopped at.
+func (pool *standbyPool) catchUp(ctx context.Context, mysqld mysqlctl.MysqlDaemon) (replication.Position, error) {
+	if err := startReplication(ctx, mysqld, pool.topoServer); err != nil {
+		return replication.Position{}, fmt.Errorf("error starting replication: %v", err)
+	}
+	var primaryPos replication.Position
+	err := retryOnError(ctx, func() error {
+		opCtx, opCancel := context.WithTimeout(ctx, operationTimeout)
+		defer opCancel()
+		pos, err := getPrimaryPosition(opCtx, pool.tmc, pool.topoServer)
+		if err != nil {
+			return fmt.Errorf("can't get the primary replication position: %v", err)
+		}
+		primaryPos = pos
+		return nil
+	})
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("can't get the primary replication position after all retries: %v", err)
+	}
+
+	lastErr := vterrors.NewLastError("standby catch up", timeoutWaitingForReplicationStatus)
+	for {
+		if !lastErr.ShouldRetry() {
+			return replication.Position{}, fmt.Errorf("timeout waiting for replication status after %.0f seconds", timeoutWaitingForReplicationStatus.Seconds())
+		}
+		select {
+		case <-ctx.Done():
+			return replication.Position{}, fmt.Errorf("error in replication catch up: %v", ctx.Err())
+		case <-time.After(time.Second):
+		}
+
+		status, err := mysqld.ReplicationStatus(ctx)
+		if err != nil {
+			lastErr.Record(err)
+			log.Warningf("Error getting replication status: %v", err)
+			continue
+		}
+		if status.Position.AtLeast(primaryPos) {
+			break
+		}
+		if !status.Healthy() {
+			lastErr.Record(errors.New("replication has stopped"))
+			log.Warning("Replication of the standby has stopped. Trying to restart replication.")
+			if err := startReplication(ctx, mysqld, pool.topoServer); err != nil {
+				log.Warningf("Failed to restart replication: %v", err)
+			}
+		}
+	}
+
+	if err := mysqld.StopReplication(ctx, nil); err != nil {
+		return replication.Position{}, fmt.Errorf("can't stop replication: %v", err)
+	}
+	status, err := mysqld.ReplicationStatus(ctx)
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("can't get replication status: %v", err)
+	}
+	return status.Position, nil
+}
+
+// claim removes a standby from the pool and returns it, so a tablet can be
+// started with its UID. If uid is 0, the most recently refreshed standby is
+// claimed.
+func (pool *standbyPool) claim(uid uint32) (*standby, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	var sb *standby
+	if uid != 0 {
+		sb = pool.standbys[uid]
+		if sb == nil {
+			return nil, fmt.Errorf("no standby %d", uid)
+		}
+		if sb.refreshing {
+			return nil, fmt.Errorf("standby %d is being refreshed", uid)
+		}
+	} else {
+		for _, candidate := range pool.standbys {
+			if !candidate.refreshing && (sb == nil || candidate.RefreshedAt.After(sb.RefreshedAt)) {
+				sb = candidate
+			}
+		}
+		if sb == nil {
+			return nil, errors.New("no standby available")
+		}
+	}
+	// The state file is removed so the standby is not picked up again by a
+	// later run.
+	if err := os.Remove(filepath.Join(sb.TabletDir, standbyStateFile)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	delete(pool.standbys, sb.TabletUID)
+	standbyPoolClaims.Add(1)
+	select {
+	case pool.wakeup <- struct{}{}:
+	default:
+	}
+	log.Infof("Standby %d was claimed at position %v", sb.TabletUID, sb.Position)
+	return sb, nil
+}
+
+// remove removes a standby and its tablet directory.
+func (pool *standbyPool) remove(sb *standby) {
+	pool.mu.Lock()
+	if pool.standbys[sb.TabletUID] != sb {
+		pool.mu.Unlock()
+		return
+	}
+	delete(pool.standbys, sb.TabletUID)
+	pool.mu.Unlock()
+
+	if err := os.RemoveAll(sb.TabletDir); err != nil {
+		log.Warningf("Failed to remove standby tablet directory: %v", err)
+	}
+}
+
+func (pool *standbyPool) countByState() map[string]int64 {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	counts := map[string]int64{"ready": 0, "refreshing": 0}
+	for _, sb := range pool.standbys {
+		if sb.refreshing {
+			counts["refreshing"]++
+		} else {
+			counts["ready"]++
+		}
+	}
+	return counts
+}
+
+func (pool *standbyPool) handleList(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	pool.mu.Lock()
+	standbys := make([]standby, 0, len(pool.standbys))
+	for _, sb := range pool.standbys {
+		standbys = append(standbys, *sb)
+	}
+	pool.mu.Unlock()
+	slices.SortFunc(standbys, func(a, b standby) int {
+		return b.RefreshedAt.Compare(a.RefreshedAt)
+	})
+	writeStandbyPoolJSON(w, standbys)
+}
+
+func (pool *standbyPool) handleClaim(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "claiming a standby requires a POST", http.StatusMethodNotAllowed)
+		return
+	}
+	var uid uint64
+	if value := r.FormValue("uid"); value != "" {
+		var err error
+		if uid, err = strconv.ParseUint(value, 10, 32); err != nil {
+			http.Error(w, fmt.Sprintf("invalid uid %q: %v", value, err), http.StatusBadRequest)
+			return
+		}
+	}
+	sb, err := pool.claim(uint32(uid))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeStandbyPoolJSON(w, sb)
+}
+
+func writeStandbyPoolJSON(w http.ResponseWriter, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// save writes the state file of the standby.
+func (sb *standby) save() error {
+	data, err := json.MarshalIndent(sb, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(sb.TabletDir, standbyStateFile), data, 0o644)
+}
+
+func shutdownStandbyMysqld(mysqld *mysqlctl.Mysqld, mycnf *mysqlctl.Mycnf) {
+	// Use a background context so mysqld is shut down even if the pool is
+	// stopping.
+	ctx, cancel := context.WithTimeout(context.Background(), mysqlShutdownTimeout+10*time.Second)
+	defer cancel()
+	if err := mysqld.Shutdown(ctx, mycnf, true, mysqlShutdownTimeout); err != nil {
+		log.Errorf("failed to shutdown mysqld: %v", err)
+	}
+}
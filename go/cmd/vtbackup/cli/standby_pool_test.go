@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/mysqlctl"
+)
+
+// setupStandbyPoolTest makes the standbys of the test live in a temporary
+// VTDATAROOT, for the shard ks/0.
+func setupStandbyPoolTest(t *testing.T) *standbyPool {
+	t.Setenv("VTDATAROOT", t.TempDir())
+	oldKeyspace, oldShard, oldInterval := initKeyspace, initShard, standbyPoolRefreshInterval
+	initKeyspace, initShard, standbyPoolRefreshInterval = "ks", "0", time.Hour
+	t.Cleanup(func() {
+		initKeyspace, initShard, standbyPoolRefreshInterval = oldKeyspace, oldShard, oldInterval
+	})
+	return &standbyPool{
+		wakeup:   make(chan struct{}, 1),
+		standbys: make(map[uint32]*standby),
+	}
+}
+
+// newTestStandby saves a standby of keyspace/shard, refreshed age ago, in its
+// tablet directory.
+func newTestStandby(t *testing.T, uid uint32, keyspace, shard string, age time.Duration) *standby {
+	sb := &standby{
+		Keyspace:    keyspace,
+		Shard:       shard,
+		TabletUID:   uid,
+		TabletDir:   mysqlctl.TabletDir(uid),
+		Position:    "MySQL56/00000000-0000-0000-0000-000000000001:1-10",
+		RestoredAt:  time.Now().Add(-age - time.Hour).UTC().Round(0),
+		RefreshedAt: time.Now().Add(-age).UTC().Round(0),
+	}
+	require.NoError(t, os.MkdirAll(sb.TabletDir, 0o755))
+	require.NoError(t, sb.save())
+	return sb
+}
+
+// addTestStandbys adds standbys of ks/0 to the pool, refreshed the given ages
+// ago, with the UIDs 1, 2, ...
+func addTestStandbys(t *testing.T, pool *standbyPool, ages ...time.Duration) {
+	for i, age := range ages {
+		uid := uint32(i + 1)
+		pool.standbys[uid] = newTestStandby(t, uid, "ks", "0", age)
+	}
+}
+
+func TestStandbyPoolLoad(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	sb1 := newTestStandby(t, 1, "ks", "0", time.Minute)
+	sb2 := newTestStandby(t, 2, "ks", "0", 2*time.Hour)
+	// The standbys of other shards are ignored.
+	newTestStandby(t, 3, "ks", "80-", time.Minute)
+	newTestStandby(t, 4, "other", "0", time.Minute)
+	// So are the tablet directories without a state file.
+	require.NoError(t, os.MkdirAll(mysqlctl.TabletDir(5), 0o755))
+
+	require.NoError(t, pool.load())
+	assert.Equal(t, map[uint32]*standby{1: sb1, 2: sb2}, pool.standbys)
+	// The standby left stale by the previous run is refreshed first.
+	assert.Equal(t, []*standby{sb2}, pool.stale())
+
+	// A corrupted state file fails the load.
+	require.NoError(t, os.MkdirAll(mysqlctl.TabletDir(6), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(mysqlctl.TabletDir(6), standbyStateFile), []byte("{"), 0o644))
+	pool = &standbyPool{standbys: make(map[uint32]*standby)}
+	require.ErrorContains(t, pool.load(), "can't parse standby state")
+}
+
+func TestStandbyPoolStale(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	assert.Empty(t, pool.stale())
+
+	addTestStandbys(t, pool, time.Minute, 3*time.Hour, 59*time.Minute, time.Hour+time.Minute, 2*time.Hour)
+	var uids []uint32
+	for _, sb := range pool.stale() {
+		uids = append(uids, sb.TabletUID)
+	}
+	// The least recently refreshed first.
+	assert.Equal(t, []uint32{2, 5, 4}, uids)
+
+	standbyPoolRefreshInterval = 0
+	assert.Len(t, pool.stale(), 5)
+}
+
+func TestStandbyPoolClaim(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	_, err := pool.claim(0)
+	require.EqualError(t, err, "no standby available")
+
+	addTestStandbys(t, pool, time.Hour, time.Minute, 2*time.Minute)
+	_, err = pool.claim(4)
+	require.EqualError(t, err, "no standby 4")
+
+	// A standby can't be claimed while it is refreshed.
+	require.True(t, pool.startRefresh(pool.standbys[2]))
+	_, err = pool.claim(2)
+	require.EqualError(t, err, "standby 2 is being refreshed")
+	assert.Equal(t, map[string]int64{"ready": 2, "refreshing": 1}, pool.countByState())
+
+	// Without a UID, the most recently refreshed standby which is not
+	// refreshed is claimed.
+	sb, err := pool.claim(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, sb.TabletUID)
+	assert.NoFileExists(t, filepath.Join(sb.TabletDir, standbyStateFile))
+	assert.DirExists(t, sb.TabletDir)
+	assert.NotContains(t, pool.standbys, uint32(3))
+	select {
+	case <-pool.wakeup:
+	default:
+		assert.Fail(t, "the pool was not woken up by the claim")
+	}
+
+	pool.endRefresh(pool.standbys[2])
+	sb, err = pool.claim(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, sb.TabletUID)
+	// The refresh of a claimed standby does not start.
+	assert.False(t, pool.startRefresh(sb))
+
+	// The claimed standbys are not loaded again.
+	loaded := &standbyPool{standbys: make(map[uint32]*standby)}
+	require.NoError(t, loaded.load())
+	assert.Equal(t, []uint32{1}, standbyUIDs(loaded))
+	assert.Equal(t, map[string]int64{"ready": 1, "refreshing": 0}, pool.countByState())
+}
+
+func standbyUIDs(pool *standbyPool) []uint32 {
+	var uids []uint32
+	for uid := range pool.standbys {
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+func TestStandbyPoolConcurrentClaims(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	ages := make([]time.Duration, 10)
+	for i := range ages {
+		ages[i] = time.Duration(i) * time.Minute
+	}
+	addTestStandbys(t, pool, ages...)
+
+	var (
+		mu      sync.Mutex
+		claimed = make(map[uint32]int)
+		failed  int
+		wg      sync.WaitGroup
+	)
+	for i := range 30 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Some of the claims ask for a standby which is claimed by
+			// another.
+			uid := uint32(0)
+			if i%3 == 0 {
+				uid = uint32(i%10 + 1)
+			}
+			sb, err := pool.claim(uid)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				return
+			}
+			claimed[sb.TabletUID]++
+		}()
+	}
+	wg.Wait()
+
+	// Every standby is claimed exactly once.
+	assert.Len(t, claimed, 10)
+	for uid, count := range claimed {
+		assert.Equal(t, 1, count, "standby %d claimed %d times", uid, count)
+	}
+	assert.Equal(t, 20, failed)
+	assert.Empty(t, pool.standbys)
+	assert.Equal(t, map[string]int64{"ready": 0, "refreshing": 0}, pool.countByState())
+}
+
+func TestStandbyPoolRemove(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	addTestStandbys(t, pool, time.Minute, time.Minute)
+	sb1, sb2 := pool.standbys[1], pool.standbys[2]
+
+	pool.remove(sb1)
+	assert.NoDirExists(t, sb1.TabletDir)
+	assert.Equal(t, []uint32{2}, standbyUIDs(pool))
+
+	// A standby claimed in the meantime is left alone.
+	_, err := pool.claim(2)
+	require.NoError(t, err)
+	pool.remove(sb2)
+	assert.DirExists(t, sb2.TabletDir)
+}
+
+func TestStandbyPoolHandleClaim(t *testing.T) {
+	pool := setupStandbyPoolTest(t)
+	addTestStandbys(t, pool, time.Minute, time.Hour)
+
+	claim := func(method, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, standbyPoolClaimHandler+query, nil)
+		pool.handleClaim(w, r)
+		return w
+	}
+
+	w := claim(http.MethodGet, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Len(t, pool.standbys, 2)
+
+	w = claim(http.MethodPost, "?uid=abc")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `invalid uid "abc"`)
+
+	w = claim(http.MethodPost, "?uid=4294967296")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = claim(http.MethodPost, "?uid=3")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "no standby 3", strings.TrimSpace(w.Body.String()))
+
+	w = claim(http.MethodPost, "?uid=2")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var sb standby
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sb))
+	assert.EqualValues(t, 2, sb.TabletUID)
+	assert.Equal(t, mysqlctl.TabletDir(2), sb.TabletDir)
+
+	w = claim(http.MethodPost, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sb))
+	assert.EqualValues(t, 1, sb.TabletUID)
+
+	w = claim(http.MethodPost, "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "no standby available", strings.TrimSpace(w.Body.String()))
+}
@@ -185,7 +185,15 @@ to the handling of the query path.
 The command-line parameters to vtbackup specify a policy for when a new backup
 is needed, and when old backups should be removed. If the existing backups
 already satisfy the policy, then vtbackup will do nothing and return success
-immediately.`,
+immediately.
+
+With --standby-pool-size, vtbackup instead keeps running and maintains a pool of
+standby tablet directories for the shard, each restored from the most recent
+backup and caught up with the primary every --standby-pool-refresh-interval,
+with its mysqld shut down in between. The standbys are listed at /standby_pool,
+and a POST to /standby_pool/claim removes one from the pool and returns its
+tablet UID: a tablet started with this UID on the same host reuses the standby
+data instead of restoring a backup. The pool is then refilled.`,
 		Version: servenv.AppVersion.String(),
 		Args:    cobra.NoArgs,
 		PreRunE: servenv.CobraPreRunE,
@@ -225,6 +233,10 @@ func init() {
 	Main.Flags().DurationVar(&keepAliveTimeout, "keep-alive-timeout", keepAliveTimeout, "Wait until timeout elapses after a successful backup before shutting down.")
 	Main.Flags().BoolVar(&disableRedoLog, "disable-redo-log", disableRedoLog, "Disable InnoDB redo log during replication-from-primary phase of backup.")
 
+	// standby pool flags
+	Main.Flags().IntVar(&standbyPoolSize, "standby-pool-size", standbyPoolSize, "If positive, vtbackup does not take a backup, and instead keeps running to maintain this many standby tablet directories for the shard, restored from the most recent backup and regularly caught up with the primary.")
+	Main.Flags().DurationVar(&standbyPoolRefreshInterval, "standby-pool-refresh-interval", standbyPoolRefreshInterval, "How often the standbys of the standby pool catch up with the primary.")
+
 	acl.RegisterFlags(Main.Flags())
 
 	collationEnv = collations.NewEnvironment(servenv.MySQLServerVersion())
@@ -282,6 +294,10 @@ func run(cc *cobra.Command, args []string) error {
 		}
 	}
 
+	if standbyPoolSize > 0 {
+		return runStandbyPool(ctx, topoServer)
+	}
+
 	// Try to take a backup, if it's been long enough since the last one.
 	// Skip pruning if backup wasn't fully successful. We don't want to be
 	// deleting things if the backup process is not healthy.
@@ -319,13 +335,13 @@ func takeBackup(ctx, backgroundCtx context.Context, topoServer *topo.Server, bac
 	// directory is unique if multiple vtbackup instances are launched for the
 	// same shard, at exactly the same second, pointed at the same backup
 	// storage location.
-	bigN, err := rand.Int(rand.Reader, big.NewInt(math.MaxUint32))
+	uid, err := randomTabletUID()
 	if err != nil {
-		return fmt.Errorf("can't generate random tablet UID: %v", err)
+		return err
 	}
 	tabletAlias := &topodatapb.TabletAlias{
 		Cell: "vtbackup",
-		Uid:  uint32(bigN.Uint64()),
+		Uid:  uid,
 	}
 
 	// Clean up our temporary data dir if we exit for any reason, to make sure
@@ -651,6 +667,15 @@ func takeBackup(ctx, backgroundCtx context.Context, topoServer *topo.Server, bac
 	return nil
 }
 
+// randomTabletUID generates a random tablet UID.
+func randomTabletUID() (uint32, error) {
+	bigN, err := rand.Int(rand.Reader, big.NewInt(math.MaxUint32))
+	if err != nil {
+		return 0, fmt.Errorf("can't generate random tablet UID: %v", err)
+	}
+	return uint32(bigN.Uint64()), nil
+}
+
 func resetReplication(ctx context.Context, pos replication.Position, mysqld mysqlctl.MysqlDaemon) error {
 	if err := mysqld.StopReplication(ctx, nil); err != nil {
 		return vterrors.Wrap(err, "failed to stop replication")
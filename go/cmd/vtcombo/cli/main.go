@@ -31,6 +31,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/flagutil"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/stats"
@@ -80,6 +81,9 @@ In particular, it contains:
 	plannerName           string
 	vschemaPersistenceDir string
 
+	cellLatencies           flagutil.StringMapValue
+	enableFaultInjectionAPI bool
+
 	tpb               vttestpb.VTTestTopology
 	ts                *topo.Server
 	resilientServer   *srvtopo.ResilientServer
@@ -117,6 +121,9 @@ func init() {
 		"this is neither a perfect nor a production solution for vschema persistence. Consider using the --external_topo_server flag if "+
 		"you require a more complete solution. This flag is ignored if --external_topo_server is set.")
 
+	Main.Flags().Var(&cellLatencies, "cell-latencies", "Comma separated list of cell:latency pairs. The latency is added to every request from vtgate to the tablets of the cell, to simulate remote cells.")
+	Main.Flags().BoolVar(&enableFaultInjectionAPI, "enable-fault-injection-api", enableFaultInjectionAPI, "Enable the HTTP API under "+vtcombo.FaultInjectionAPIPath+" to inject failures into the cluster: kill primaries, partition cells, stall replication and change cell latencies.")
+
 	Main.Flags().Var(vttest.TextTopoData(&tpb), "proto_topo", "vttest proto definition of the topology, encoded in compact text format. See vttest.proto for more information.")
 	Main.Flags().Var(vttest.JSONTopoData(&tpb), "json_topo", "vttest proto definition of the topology, encoded in json format. See vttest.proto for more information.")
 
@@ -307,6 +314,19 @@ func run(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
+	latencies := make(map[string]time.Duration, len(cellLatencies))
+	for cell, value := range cellLatencies {
+		latency, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid latency for cell %v in --cell-latencies: %w", cell, err)
+		}
+		latencies[cell] = latency
+	}
+	vtcombo.SetCellLatencies(latencies)
+	if enableFaultInjectionAPI {
+		vtcombo.RegisterFaultInjectionAPI()
+	}
+
 	// vtgate configuration and init
 
 	resilientServer = srvtopo.NewResilientServer(ctx, ts, srvTopoCounts)
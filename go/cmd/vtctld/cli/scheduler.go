@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtctl/localvtctldclient"
+	"vitess.io/vitess/go/vt/vtctl/scheduler"
+)
+
+var (
+	enableScheduledJobs        bool
+	scheduledJobsCheckInterval = time.Minute
+	scheduledJobsHistorySize   = 10
+)
+
+func init() {
+	Main.Flags().BoolVar(&enableScheduledJobs, "enable-scheduled-jobs", enableScheduledJobs, "Run the recurring jobs scheduled with vtctldclient SetScheduledJob, like backups, VDiffs and schema migration cleanups. Every vtctld of the cluster can run them, and each occurrence of a job is run by a single vtctld.")
+	Main.Flags().DurationVar(&scheduledJobsCheckInterval, "scheduled-jobs-check-interval", scheduledJobsCheckInterval, "How often the scheduled jobs are checked for due runs.")
+	Main.Flags().IntVar(&scheduledJobsHistorySize, "scheduled-jobs-history-size", scheduledJobsHistorySize, "Number of the most recent runs of each scheduled job kept in the topo.")
+}
+
+func initScheduler() {
+	if !enableScheduledJobs {
+		return
+	}
+	interval := scheduledJobsCheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	client := localvtctldclient.New(grpcvtctldserver.NewVtctldServer(env, ts))
+	s := scheduler.New(ts, client, interval, scheduledJobsHistorySize)
+	s.Open()
+	servenv.OnClose(s.Close)
+}
@@ -52,6 +52,20 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandBackupShard,
 	}
+	// CloneTablet makes a CloneTablet gRPC call to a vtctld.
+	CloneTablet = &cobra.Command{
+		Use:   "CloneTablet [--donor <tablet_alias>] [--max-bandwidth <MiB/s>] [--allow-primary-donor] <tablet_alias>",
+		Short: "Replaces the data of the given tablet with a copy of the data of a donor tablet of its shard, made with the MySQL clone plugin, as an alternative to restoring a backup.",
+		Long: `Replaces the data of the given tablet with a copy of the data of a donor tablet of its shard, made with the MySQL clone plugin, as an alternative to restoring a backup.
+
+Unless --donor is specified, the donor is the REPLICA, RDONLY, or SPARE tablet of the shard with the lowest replication lag, preferring the tablets in the cell of the given tablet.
+If no replica-type tablet can be found, the primary is the donor if --allow-primary-donor is specified.
+
+The clone plugin must be loaded on the donor, and the replication user must have the BACKUP_ADMIN privilege on it. Requires MySQL 8.0.17 or newer.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandCloneTablet,
+	}
 	// GetBackups makes a GetBackups gRPC call to a vtctld.
 	GetBackups = &cobra.Command{
 		Use:                   "GetBackups [--limit <limit>] [--json] <keyspace/shard>",
@@ -117,7 +131,7 @@ func commandBackup(cmd *cobra.Command, args []string) error {
 		resp, err := stream.Recv()
 		switch err {
 		case nil:
-			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
 		case io.EOF:
 			return nil
 		default:
@@ -159,7 +173,7 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 		resp, err := stream.Recv()
 		switch err {
 		case nil:
-			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
 		case io.EOF:
 			return nil
 		default:
@@ -168,6 +182,46 @@ func commandBackupShard(cmd *cobra.Command, args []string) error {
 	}
 }
 
+var cloneTabletOptions = struct {
+	Donor             string
+	MaxBandwidth      int64
+	AllowPrimaryDonor bool
+}{}
+
+func commandCloneTablet(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	req := &vtctldatapb.CloneTabletRequest{
+		TabletAlias:       alias,
+		MaxBandwidth:      cloneTabletOptions.MaxBandwidth,
+		AllowPrimaryDonor: cloneTabletOptions.AllowPrimaryDonor,
+	}
+	if cloneTabletOptions.Donor != "" {
+		req.DonorAlias, err = topoproto.ParseTabletAlias(cloneTabletOptions.Donor)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.CloneTablet(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
 var getBackupsOptions = struct {
 	Limit      uint32
 	OutputJSON bool
@@ -196,7 +250,7 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		fmt.Printf("%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 		return nil
 	}
 
@@ -205,7 +259,7 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 		names[i] = b.Name
 	}
 
-	fmt.Printf("%s\n", strings.Join(names, "\n"))
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Join(names, "\n"))
 
 	return nil
 }
@@ -282,7 +336,7 @@ func commandRestoreFromBackup(cmd *cobra.Command, args []string) error {
 		resp, err := stream.Recv()
 		switch err {
 		case nil:
-			fmt.Printf("%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s/%s (%s): %v\n", resp.Keyspace, resp.Shard, topoproto.TabletAliasString(resp.TabletAlias), resp.Event)
 		case io.EOF:
 			return nil
 		default:
@@ -308,6 +362,11 @@ func init() {
 	BackupShard.Flags().DurationVar(&backupShardOptions.MysqlShutdownTimeout, "mysql-shutdown-timeout", mysqlctl.DefaultShutdownTimeout, "Timeout to use when MySQL is being shut down.")
 	Root.AddCommand(BackupShard)
 
+	CloneTablet.Flags().StringVar(&cloneTabletOptions.Donor, "donor", "", "Alias of the tablet whose data is cloned. Omit to select the donor automatically.")
+	CloneTablet.Flags().Int64Var(&cloneTabletOptions.MaxBandwidth, "max-bandwidth", 0, "Maximum bandwidth of the clone, in MiB per second. 0 means no limit.")
+	CloneTablet.Flags().BoolVar(&cloneTabletOptions.AllowPrimaryDonor, "allow-primary-donor", false, "Allow the primary of the shard to be the donor. WARNING: cloning adds load to the donor.")
+	Root.AddCommand(CloneTablet)
+
 	GetBackups.Flags().Uint32VarP(&getBackupsOptions.Limit, "limit", "l", 0, "Retrieve only the most recent N backups.")
 	GetBackups.Flags().BoolVarP(&getBackupsOptions.OutputJSON, "json", "j", false, "Output backup info in JSON format rather than a list of backups.")
 	Root.AddCommand(GetBackups)
@@ -19,10 +19,12 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -73,6 +75,23 @@ the same group (alias).`,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandDeleteCellsAlias,
 	}
+	// DrainCell makes a DrainCell gRPC call to a vtctld.
+	DrainCell = &cobra.Command{
+		Use:   "DrainCell [--step <percent>] [--step-interval <duration>] [--undrain] <cell>",
+		Short: "Drains the cell before it is shut down.",
+		Long: `Drains the cell before it is shut down.
+
+Primaries can no longer be promoted in the cell as soon as the drain starts,
+and vtgates progressively shift the reads served by the tablets of the cell to
+the tablets of other cells. Once all the reads are shifted, the primaries still
+in the cell are reported: they have to be reparented to other cells before the
+cell is safe to shut down. Running the command again on a drained cell reports
+them again.`,
+		Example:               `DrainCell --step 25 --step-interval 5m zone1`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDrainCell,
+	}
 	// GetCellInfoNames makes a GetCellInfoNames gRPC call to a vtctld.
 	GetCellInfoNames = &cobra.Command{
 		Use:                   "GetCellInfoNames",
@@ -133,7 +152,7 @@ func commandAddCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Created cell: %s\n", cell)
+	fmt.Fprintf(cmd.OutOrStdout(), "Created cell: %s\n", cell)
 	return nil
 }
 
@@ -151,7 +170,7 @@ func commandAddCellsAlias(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Created cells alias: %s (cells = %v)\n", alias, addCellsAliasOptions.Cells)
+	fmt.Fprintf(cmd.OutOrStdout(), "Created cells alias: %s (cells = %v)\n", alias, addCellsAliasOptions.Cells)
 	return nil
 }
 
@@ -171,7 +190,7 @@ func commandDeleteCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Deleted cell %s\n", cell)
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted cell %s\n", cell)
 	return nil
 }
 
@@ -186,7 +205,36 @@ func commandDeleteCellsAlias(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Delete cells alias %s\n", alias)
+	fmt.Fprintf(cmd.OutOrStdout(), "Delete cells alias %s\n", alias)
+	return nil
+}
+
+var drainCellOptions = struct {
+	Step         int32
+	StepInterval time.Duration
+	Undrain      bool
+}{}
+
+func commandDrainCell(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.DrainCell(commandCtx, &vtctldatapb.DrainCellRequest{
+		Cell:         cmd.Flags().Arg(0),
+		Step:         drainCellOptions.Step,
+		StepInterval: protoutil.DurationToProto(drainCellOptions.StepInterval),
+		Undrain:      drainCellOptions.Undrain,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+
 	return nil
 }
 
@@ -198,7 +246,7 @@ func commandGetCellInfoNames(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", strings.Join(resp.Names, "\n"))
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Join(resp.Names, "\n"))
 
 	return nil
 }
@@ -218,7 +266,7 @@ func commandGetCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -236,7 +284,7 @@ func commandGetCellsAliases(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -260,7 +308,7 @@ func commandUpdateCellInfo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Updated cell %s. New CellInfo:\n%s\n", resp.Name, data)
+	fmt.Fprintf(cmd.OutOrStdout(), "Updated cell %s. New CellInfo:\n%s\n", resp.Name, data)
 	return nil
 }
 
@@ -283,7 +331,7 @@ func commandUpdateCellsAlias(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Updated cells alias %s. New CellsAlias:\n%s\n", resp.Name, data)
+	fmt.Fprintf(cmd.OutOrStdout(), "Updated cells alias %s. New CellsAlias:\n%s\n", resp.Name, data)
 	return nil
 }
 
@@ -300,6 +348,11 @@ func init() {
 	Root.AddCommand(DeleteCellInfo)
 	Root.AddCommand(DeleteCellsAlias)
 
+	DrainCell.Flags().Int32Var(&drainCellOptions.Step, "step", 0, "The percentage of the reads of the cell to shift to other cells at each step of the drain. If 0, all the reads are shifted at once.")
+	DrainCell.Flags().DurationVar(&drainCellOptions.StepInterval, "step-interval", time.Minute, "How long to wait between the steps of the drain.")
+	DrainCell.Flags().BoolVar(&drainCellOptions.Undrain, "undrain", false, "Ends the drain of the cell, sending reads to its tablets and allowing primaries to be promoted in it again.")
+	Root.AddCommand(DrainCell)
+
 	Root.AddCommand(GetCellInfoNames)
 	Root.AddCommand(GetCellInfo)
 	Root.AddCommand(GetCellsAliases)
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/dynamicflags"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// Config is the parent command of the commands managing the dynamic
+	// config of the cluster.
+	Config = &cobra.Command{
+		Use:   "Config <command>",
+		Short: "Manages the overrides of the dynamic flags of the vtgates and vttablets.",
+		Long: `Manages the overrides of the dynamic flags of the vtgates and vttablets.
+
+The overrides are saved in the global topo, cluster-wide or in a keyspace, and applied by the vtgates and vttablets started with --enable-dynamic-config. The overrides of a keyspace take precedence over the cluster-wide ones, and only the vttablet flags can be overridden in a keyspace. The original value of a flag is restored when its override is removed.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+	}
+	// ConfigFlags lists the flags that can be overridden.
+	ConfigFlags = &cobra.Command{
+		Use:                   "flags",
+		Short:                 "Lists the flags that can be overridden.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandConfigFlags,
+		Annotations: map[string]string{
+			skipClientCreationKey: "true",
+		},
+	}
+	// ConfigGet makes a GetDynamicConfig gRPC call to a vtctld.
+	ConfigGet = &cobra.Command{
+		Use:                   "get [--keyspace <keyspace>]",
+		Short:                 "Displays the overrides, cluster-wide or in a keyspace, with their most recent changes.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandConfigGet,
+	}
+	// ConfigSet makes a SetDynamicConfig gRPC call to a vtctld.
+	ConfigSet = &cobra.Command{
+		Use:   "set [--keyspace <keyspace>] <flag>=<value> [<flag>=<value> ...]",
+		Short: "Overrides the values of flags, cluster-wide or in a keyspace.",
+		Example: `vtctldclient Config set transaction_mode=single discovery_low_replication_lag=10s
+vtctldclient Config set --keyspace commerce queryserver-config-pool-size=32 queryserver-config-query-timeout=15s`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandConfigSet,
+	}
+	// ConfigUnset makes a SetDynamicConfig gRPC call to a vtctld.
+	ConfigUnset = &cobra.Command{
+		Use:                   "unset [--keyspace <keyspace>] <flag> [<flag> ...]",
+		Short:                 "Removes the overrides of flags, cluster-wide or in a keyspace.",
+		Example:               "vtctldclient Config unset --keyspace commerce queryserver-config-pool-size",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandConfigUnset,
+	}
+)
+
+var configOptions = struct {
+	Keyspace string
+}{}
+
+func commandConfigFlags(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "FLAG\tCOMPONENT\tTYPE\tPER KEYSPACE\tDESCRIPTION")
+	for _, f := range dynamicflags.Flags() {
+		typ := string(f.Type)
+		if f.Type == dynamicflags.Enum {
+			typ = strings.Join(f.Values, "|")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", f.Name, f.Component, typ, f.PerKeyspace(), f.Help)
+	}
+	return w.Flush()
+}
+
+func commandConfigGet(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetDynamicConfig(commandCtx, &vtctldatapb.GetDynamicConfigRequest{
+		Keyspace: configOptions.Keyspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+func commandConfigSet(cmd *cobra.Command, args []string) error {
+	values := make(map[string]string, len(args))
+	for _, arg := range cmd.Flags().Args() {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid argument %q, it must be <flag>=<value>", arg)
+		}
+		values[name] = value
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetDynamicConfig(commandCtx, &vtctldatapb.SetDynamicConfigRequest{
+		Keyspace: configOptions.Keyspace,
+		Values:   values,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+func commandConfigUnset(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetDynamicConfig(commandCtx, &vtctldatapb.SetDynamicConfigRequest{
+		Keyspace: configOptions.Keyspace,
+		Unset:    cmd.Flags().Args(),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+func init() {
+	Config.PersistentFlags().StringVar(&configOptions.Keyspace, "keyspace", "", "Keyspace of the overrides. The cluster-wide overrides are used if empty.")
+	Config.AddCommand(ConfigFlags)
+	Config.AddCommand(ConfigGet)
+	Config.AddCommand(ConfigSet)
+	Config.AddCommand(ConfigUnset)
+	Root.AddCommand(Config)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// repairMethods are the values of the --method flag of RepairErrantGTIDs.
+var repairMethods = map[string]vtctldatapb.RepairErrantGTIDsRequest_Method{
+	"inject-empty-transactions": vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS,
+	"restore":                   vtctldatapb.RepairErrantGTIDsRequest_RESTORE,
+}
+
+var (
+	// FindErrantGTIDs finds the errant GTIDs of the replicas of a shard.
+	FindErrantGTIDs = &cobra.Command{
+		Use:   "FindErrantGTIDs [--explain] [--max-binlog-events <n>] [--max-gtids <n>] <keyspace/shard>",
+		Short: "Outputs the errant GTIDs of each replica of the shard, i.e. the GTIDs which the replica executed but the primary did not.",
+		Long: `Outputs the errant GTIDs of each replica of the shard, i.e. the GTIDs which the replica executed but the primary did not.
+
+With --explain, the events of each errant transaction are read from the binary logs of the replica,
+which shows what the transaction did and the ID of the server which originally committed it.
+Errant GTIDs whose binary log was purged are listed without events, and at most --max-gtids errant
+GTIDs of a replica are read; the others are listed as unexplained.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandFindErrantGTIDs,
+	}
+	// RepairErrantGTIDs repairs the errant GTIDs of a replica.
+	RepairErrantGTIDs = &cobra.Command{
+		Use:   "RepairErrantGTIDs --method {inject-empty-transactions|restore} [--max-gtids <n>] [--dry-run] <tablet alias>",
+		Short: "Repairs the errant GTIDs of a replica, either by injecting empty transactions for them on the primary or by restoring the replica from a backup.",
+		Long: `Repairs the errant GTIDs of a replica, either by injecting empty transactions for them on the primary or by restoring the replica from a backup.
+
+--method inject-empty-transactions commits an empty transaction on the shard primary for each errant GTID.
+The GTIDs are then no longer errant, but the changes of the errant transactions stay on the replica:
+only use it once FindErrantGTIDs --explain showed that they can be ignored.
+--method restore resets the replica and restores it from the latest backup of the shard, which discards
+the errant transactions.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRepairErrantGTIDs,
+	}
+)
+
+var findErrantGTIDsOptions = struct {
+	Explain         bool
+	MaxBinlogEvents int64
+	MaxGTIDs        int64
+}{}
+
+func commandFindErrantGTIDs(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.FindErrantGTIDs(commandCtx, &vtctldatapb.FindErrantGTIDsRequest{
+		Keyspace:        keyspace,
+		Shard:           shard,
+		Explain:         findErrantGTIDsOptions.Explain,
+		MaxBinlogEvents: findErrantGTIDsOptions.MaxBinlogEvents,
+		MaxGtids:        findErrantGTIDsOptions.MaxGTIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var repairErrantGTIDsOptions = struct {
+	Method   string
+	MaxGTIDs int64
+	DryRun   bool
+}{}
+
+func commandRepairErrantGTIDs(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+	method, ok := repairMethods[repairErrantGTIDsOptions.Method]
+	if !ok {
+		return fmt.Errorf("--method must be one of inject-empty-transactions or restore")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RepairErrantGTIDs(commandCtx, &vtctldatapb.RepairErrantGTIDsRequest{
+		TabletAlias: alias,
+		Method:      method,
+		MaxGtids:    repairErrantGTIDsOptions.MaxGTIDs,
+		DryRun:      repairErrantGTIDsOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	aliasStr := topoproto.TabletAliasString(alias)
+	if resp.ErrantGtids == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Tablet %s has no errant GTIDs.\n", aliasStr)
+		return nil
+	}
+
+	switch method {
+	case vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS:
+		if repairErrantGTIDsOptions.DryRun {
+			fmt.Fprintf(cmd.OutOrStdout(), "Would run on primary %s:\n%s\n", topoproto.TabletAliasString(resp.Primary), strings.Join(resp.Queries, ";\n"))
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Injected empty transactions for errant GTIDs %s of %s on primary %s.\n", resp.ErrantGtids, aliasStr, topoproto.TabletAliasString(resp.Primary))
+	case vtctldatapb.RepairErrantGTIDsRequest_RESTORE:
+		if repairErrantGTIDsOptions.DryRun {
+			fmt.Fprintf(cmd.OutOrStdout(), "Would restore %s from the latest backup to discard errant GTIDs %s.\n", aliasStr, resp.ErrantGtids)
+			return nil
+		}
+		for _, event := range resp.Events {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %v\n", aliasStr, event)
+		}
+	}
+	return nil
+}
+
+func init() {
+	FindErrantGTIDs.Flags().BoolVar(&findErrantGTIDsOptions.Explain, "explain", false, "Read the binary log events of the errant transactions from the replicas.")
+	FindErrantGTIDs.Flags().Int64Var(&findErrantGTIDsOptions.MaxBinlogEvents, "max-binlog-events", 1_000_000, "The maximum number of events to read from a binary log of a replica with --explain.")
+	FindErrantGTIDs.Flags().Int64Var(&findErrantGTIDsOptions.MaxGTIDs, "max-gtids", 1_000, "The maximum number of errant GTIDs of a replica whose transactions are read with --explain.")
+	Root.AddCommand(FindErrantGTIDs)
+
+	RepairErrantGTIDs.Flags().StringVar(&repairErrantGTIDsOptions.Method, "method", "", "How to repair the errant GTIDs: inject-empty-transactions or restore.")
+	RepairErrantGTIDs.Flags().Int64Var(&repairErrantGTIDsOptions.MaxGTIDs, "max-gtids", 1_000, "The maximum number of errant GTIDs to inject empty transactions for.")
+	RepairErrantGTIDs.Flags().BoolVar(&repairErrantGTIDsOptions.DryRun, "dry-run", false, "Only output what would be done to repair the errant GTIDs.")
+	Root.AddCommand(RepairErrantGTIDs)
+}
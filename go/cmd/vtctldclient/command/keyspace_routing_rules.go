@@ -93,16 +93,16 @@ func commandApplyKeyspaceRoutingRules(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		fmt.Printf("[DRY RUN] Would have saved new KeyspaceRoutingRules object:\n%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "[DRY RUN] Would have saved new KeyspaceRoutingRules object:\n%s\n", data)
 
 		if opts.SkipRebuild {
-			fmt.Println("[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+			fmt.Fprintln(cmd.OutOrStdout(), "[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
 		} else {
-			fmt.Print("[DRY RUN] Would have rebuilt the VSchema graph")
+			fmt.Fprint(cmd.OutOrStdout(), "[DRY RUN] Would have rebuilt the VSchema graph")
 			if len(opts.Cells) == 0 {
-				fmt.Print(" in all cells\n")
+				fmt.Fprint(cmd.OutOrStdout(), " in all cells\n")
 			} else {
-				fmt.Printf(" in the following cells: %s.\n", strings.Join(applyKeyspaceRoutingRulesOptions.Cells, ", "))
+				fmt.Fprintf(cmd.OutOrStdout(), " in the following cells: %s.\n", strings.Join(applyKeyspaceRoutingRulesOptions.Cells, ", "))
 			}
 		}
 		return nil
@@ -121,7 +121,7 @@ func commandApplyKeyspaceRoutingRules(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s\n", respJSON)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", respJSON)
 	return nil
 }
 
@@ -138,7 +138,7 @@ func commandGetKeyspaceRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
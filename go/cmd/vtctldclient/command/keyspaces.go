@@ -201,7 +201,7 @@ func commandCreateKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Successfully created keyspace %s. Result:\n%s\n", name, data)
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully created keyspace %s. Result:\n%s\n", name, data)
 
 	return nil
 }
@@ -225,7 +225,7 @@ func commandDeleteKeyspace(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("DeleteKeyspace(%v) error: %w; please check the topo", ks, err)
 	}
 
-	fmt.Printf("Successfully deleted keyspace %v.\n", ks)
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted keyspace %v.\n", ks)
 
 	return nil
 }
@@ -247,7 +247,7 @@ func commandFindAllShardsInKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -267,7 +267,7 @@ func commandGetKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -285,7 +285,7 @@ func commandGetKeyspaces(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -312,7 +312,7 @@ func commandRemoveKeyspaceCell(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Successfully removed keyspace %s from cell %s\n", keyspace, cell)
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed keyspace %s from cell %s\n", keyspace, cell)
 
 	return nil
 }
@@ -338,7 +338,7 @@ func commandSetKeyspaceDurabilityPolicy(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -359,7 +359,7 @@ func commandValidateVersionKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyMaintenanceWindows makes an ApplyMaintenanceWindows gRPC call to a vtctld.
+	ApplyMaintenanceWindows = &cobra.Command{
+		Use:   "ApplyMaintenanceWindows {--windows WINDOWS | --windows-file WINDOWS_FILE} [--dry-run]",
+		Short: "Replaces the maintenance windows that cut-overs, traffic switches and opted-in VTOrc recoveries are held to.",
+		Long: `Replaces the maintenance windows that cut-overs, traffic switches and opted-in VTOrc recoveries are held to.
+
+Each window opens at every occurrence of its cron schedule (in UTC) and stays open for its duration.
+A window that lists keyspaces only applies to those keyspaces, and a keyspace with windows of its own
+ignores the global windows. Applying an empty set of windows removes them, and automation is then never held.`,
+		Example:               `ApplyMaintenanceWindows --windows '{"windows": [{"name": "nightly", "schedule": "0 2 * * *", "duration": "7200s"}]}'`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyMaintenanceWindows,
+	}
+	// GetMaintenanceWindows makes a GetMaintenanceWindows gRPC call to a vtctld.
+	GetMaintenanceWindows = &cobra.Command{
+		Use:                   "GetMaintenanceWindows",
+		Short:                 "Displays the maintenance windows.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetMaintenanceWindows,
+	}
+)
+
+var applyMaintenanceWindowsOptions = struct {
+	Windows         string
+	WindowsFilePath string
+	DryRun          bool
+}{}
+
+func commandApplyMaintenanceWindows(cmd *cobra.Command, args []string) error {
+	if applyMaintenanceWindowsOptions.Windows != "" && applyMaintenanceWindowsOptions.WindowsFilePath != "" {
+		return fmt.Errorf("cannot pass both --windows (=%s) and --windows-file (=%s)", applyMaintenanceWindowsOptions.Windows, applyMaintenanceWindowsOptions.WindowsFilePath)
+	}
+
+	if applyMaintenanceWindowsOptions.Windows == "" && applyMaintenanceWindowsOptions.WindowsFilePath == "" {
+		return errors.New("must pass exactly one of --windows or --windows-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var windowsBytes []byte
+	if applyMaintenanceWindowsOptions.WindowsFilePath != "" {
+		data, err := os.ReadFile(applyMaintenanceWindowsOptions.WindowsFilePath)
+		if err != nil {
+			return err
+		}
+
+		windowsBytes = data
+	} else {
+		windowsBytes = []byte(applyMaintenanceWindowsOptions.Windows)
+	}
+
+	mw := &topodatapb.MaintenanceWindows{}
+	if err := json2.UnmarshalPB(windowsBytes, mw); err != nil {
+		return err
+	}
+
+	if err := topoproto.ValidateMaintenanceWindows(mw); err != nil {
+		return err
+	}
+
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(mw)
+	if err != nil {
+		return err
+	}
+
+	if applyMaintenanceWindowsOptions.DryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "[DRY RUN] Would have saved new MaintenanceWindows object:\n%s\n", data)
+		return nil
+	}
+
+	resp, err := client.ApplyMaintenanceWindows(commandCtx, &vtctldatapb.ApplyMaintenanceWindowsRequest{
+		MaintenanceWindows: mw,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err = cli.MarshalJSON(resp.MaintenanceWindows)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "New MaintenanceWindows object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	return nil
+}
+
+func commandGetMaintenanceWindows(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetMaintenanceWindows(commandCtx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.MaintenanceWindows)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyMaintenanceWindows.Flags().StringVarP(&applyMaintenanceWindowsOptions.Windows, "windows", "w", "", "Maintenance windows, specified as a string.")
+	ApplyMaintenanceWindows.Flags().StringVarP(&applyMaintenanceWindowsOptions.WindowsFilePath, "windows-file", "f", "", "Path to a file containing maintenance windows specified as JSON.")
+	ApplyMaintenanceWindows.Flags().BoolVarP(&applyMaintenanceWindowsOptions.DryRun, "dry-run", "d", false, "Validate the specified maintenance windows, but do not actually apply them to the topo.")
+	Root.AddCommand(ApplyMaintenanceWindows)
+
+	Root.AddCommand(GetMaintenanceWindows)
+}
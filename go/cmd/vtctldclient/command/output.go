@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/vtctl/recordingvtctldclient"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+)
+
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputProto = "proto"
+)
+
+var (
+	outputFormat = outputText
+
+	// recordResponse, when set by Run, is called with the responses of the
+	// command instead of writing them to the output of the command.
+	recordResponse func(msg proto.Message)
+
+	// structuredOutput holds the state of a command run with a structured
+	// output, between PersistentPreRunE and PersistentPostRunE.
+	structuredOutput struct {
+		cmd *cobra.Command
+		err error
+	}
+)
+
+// startStructuredOutput returns a client that records the responses of c when
+// cmd runs with a structured output or is run by Run. The responses are then
+// written to the output of cmd, and the text output the command writes to
+// cmd.OutOrStdout() is discarded, until stopStructuredOutput is called.
+func startStructuredOutput(cmd *cobra.Command, c vtctldclient.VtctldClient) (vtctldclient.VtctldClient, error) {
+	record := recordResponse
+	switch outputFormat {
+	case outputText:
+		if record == nil {
+			return c, nil
+		}
+	case outputJSON, outputProto:
+	default:
+		return c, fmt.Errorf("invalid --output %q, it must be one of %s, %s or %s", outputFormat, outputText, outputJSON, outputProto)
+	}
+	if c == nil {
+		// The command does not call the vtctld.
+		return c, nil
+	}
+
+	structuredOutput.cmd, structuredOutput.err = cmd, nil
+	if record == nil {
+		w := cmd.OutOrStdout()
+		format := outputFormat
+		record = func(msg proto.Message) {
+			if structuredOutput.err == nil {
+				structuredOutput.err = writeResponse(w, format, msg)
+			}
+		}
+	}
+	cmd.SetOut(io.Discard)
+
+	return recordingvtctldclient.New(c, record), nil
+}
+
+// stopStructuredOutput restores the output of the command, and returns the
+// first error that occurred while writing its responses.
+func stopStructuredOutput() error {
+	if structuredOutput.cmd == nil {
+		return nil
+	}
+	structuredOutput.cmd.SetOut(nil)
+	err := structuredOutput.err
+	structuredOutput.cmd, structuredOutput.err = nil, nil
+	return err
+}
+
+// writeResponse writes msg to w wrapped in a google.protobuf.Any, so that the
+// readers of the output know the type of each response: as a single line of
+// JSON with the json format, or as a size-delimited message with the proto
+// format.
+func writeResponse(w io.Writer, format string, msg proto.Message) error {
+	anyMsg, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+
+	if format == outputProto {
+		_, err = protodelim.MarshalTo(w, anyMsg)
+		return err
+	}
+
+	marshalOptions := cli.DefaultMarshalOptions
+	marshalOptions.Multiline = false
+	marshalOptions.Indent = ""
+	data, err := marshalOptions.Marshal(anyMsg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+var runMu sync.Mutex
+
+// Run runs the vtctldclient command with the args, which do not include the
+// program name, and returns the responses of the RPCs it made to the vtctld,
+// including each message received from its streaming RPCs, instead of writing
+// its output to stdout. It lets Go programs automate vtctldclient commands
+// without parsing their text output.
+//
+// The flags are reset to their defaults before running the command. The
+// commands are run one at a time, since they share the state of the CLI.
+func Run(ctx context.Context, args ...string) ([]proto.Message, error) {
+	runMu.Lock()
+	defer runMu.Unlock()
+
+	var responses []proto.Message
+	var mu sync.Mutex
+	recordResponse = func(msg proto.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		responses = append(responses, msg)
+	}
+	defer func() {
+		recordResponse = nil
+		stopStructuredOutput()
+		Root.SetArgs(nil)
+		setContext(Root, context.Background())
+	}()
+
+	if err := resetFlags(Root); err != nil {
+		return nil, err
+	}
+	// cobra only sets the context of the subcommands that do not have one
+	// yet, so it would otherwise keep the context of the first command run.
+	setContext(Root, ctx)
+	Root.SetArgs(args)
+	if err := Root.Execute(); err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return responses, nil
+}
+
+// resetFlags sets the flags of cmd and of its subcommands that were changed
+// back to their defaults.
+func resetFlags(cmd *cobra.Command) error {
+	var err error
+	reset := func(f *pflag.Flag) {
+		if !f.Changed || err != nil {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(f.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			err = sv.Replace(values)
+		} else {
+			err = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	if err != nil {
+		return fmt.Errorf("failed to reset the flags of %s: %w", cmd.CommandPath(), err)
+	}
+
+	for _, sub := range cmd.Commands() {
+		if err := resetFlags(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setContext sets the context of cmd and of its subcommands.
+func setContext(cmd *cobra.Command, ctx context.Context) {
+	cmd.SetContext(ctx)
+	for _, sub := range cmd.Commands() {
+		setContext(sub, ctx)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/command"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestOutput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ts, factory := memorytopo.NewServerAndFactory(ctx, "zone1", "zone2")
+	defer ts.Close()
+	topo.RegisterFactory("outputtest", factory)
+	origProtocol := command.VtctldClientProtocol
+	defer func() {
+		command.VtctldClientProtocol = origProtocol
+	}()
+	command.VtctldClientProtocol = "local"
+	baseArgs := []string{"--server", "internal", "--topo-implementation", "outputtest"}
+	want := &vtctldatapb.GetCellInfoNamesResponse{Names: []string{"zone1", "zone2"}}
+
+	t.Run("Run", func(t *testing.T) {
+		responses, err := command.Run(ctx, append(baseArgs, "GetCellInfoNames")...)
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		utils.MustMatch(t, want, responses[0])
+
+		responses, err = command.Run(ctx, append(baseArgs, "--compact", "GetCellInfoNames")...)
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		utils.MustMatch(t, want, responses[0])
+
+		_, err = command.Run(ctx, append(baseArgs, "--output", "yaml", "GetCellInfoNames")...)
+		require.ErrorContains(t, err, `invalid --output "yaml"`)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		command.Root.SetOut(&out)
+		command.Root.SetArgs(append(baseArgs, "--output", "text", "GetCellInfoNames"))
+		err := command.Root.Execute()
+		command.Root.SetArgs(nil)
+		command.Root.SetOut(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "zone1\nzone2\n", out.String())
+	})
+
+	for _, format := range []string{"json", "proto"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			stdout := os.Stdout
+			command.Root.SetOut(&out)
+			command.Root.SetArgs(append(baseArgs, "--output", format, "GetCellInfoNames"))
+			err := command.Root.Execute()
+			command.Root.SetArgs(nil)
+			command.Root.SetOut(nil)
+			require.NoError(t, err)
+			assert.Same(t, stdout, os.Stdout, "stdout is left alone")
+
+			r := bufio.NewReader(&out)
+			anyMsg := &anypb.Any{}
+			if format == "json" {
+				line, err := r.ReadBytes('\n')
+				require.NoError(t, err)
+				require.NoError(t, protojson.Unmarshal(line, anyMsg))
+			} else {
+				require.NoError(t, protodelim.UnmarshalFrom(r, anyMsg))
+			}
+			got, err := anyMsg.UnmarshalNew()
+			require.NoError(t, err)
+			utils.MustMatch(t, want, got)
+			_, err = r.ReadByte()
+			assert.Error(t, err, "only the response is written")
+		})
+	}
+}
@@ -74,7 +74,7 @@ func commandGetPermissions(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s\n", p)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", p)
 
 	return nil
 }
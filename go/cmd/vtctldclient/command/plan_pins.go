@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyPlanPins makes an ApplyPlanPins gRPC call to a vtctld.
+	ApplyPlanPins = &cobra.Command{
+		Use:   "ApplyPlanPins {--pins PINS | --pins-file PINS_FILE} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Applies the provided plan pins.",
+		Long: `Applies the provided plan pins.
+
+A plan pin pins the plan of the queries of a digest, as reported by SHOW VITESS_QUERY_DIGESTS,
+to the JSON plan description output by VEXPLAIN PLAN for one of these queries, for example:
+
+{"pins": [{"digest": "8c3e4c7a5b6d2f10", "plan": "{\"OperatorType\": \"Route\", \"Variant\": \"EqualUnique\", ...}"}]}
+
+When a vtgate plans a query of a pinned digest and the new plan differs from the pinned one,
+it reports the difference and keeps using the plan it last built matching the pinned one, if any.
+
+The pins replace all the current plan pins. To unpin a digest, apply the pins without it.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyPlanPins,
+	}
+	// GetPlanPins makes a GetPlanPins gRPC call to a vtctld.
+	GetPlanPins = &cobra.Command{
+		Use:                   "GetPlanPins",
+		Short:                 "Displays the currently active plan pins as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetPlanPins,
+	}
+)
+
+var applyPlanPinsOptions = struct {
+	Pins         string
+	PinsFilePath string
+	Cells        []string
+	SkipRebuild  bool
+	DryRun       bool
+}{}
+
+func commandApplyPlanPins(cmd *cobra.Command, args []string) error {
+	if applyPlanPinsOptions.Pins != "" && applyPlanPinsOptions.PinsFilePath != "" {
+		return fmt.Errorf("cannot pass both --pins (=%s) and --pins-file (=%s)", applyPlanPinsOptions.Pins, applyPlanPinsOptions.PinsFilePath)
+	}
+
+	if applyPlanPinsOptions.Pins == "" && applyPlanPinsOptions.PinsFilePath == "" {
+		return errors.New("must pass exactly one of --pins or --pins-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var pinsBytes []byte
+	if applyPlanPinsOptions.PinsFilePath != "" {
+		data, err := os.ReadFile(applyPlanPinsOptions.PinsFilePath)
+		if err != nil {
+			return err
+		}
+
+		pinsBytes = data
+	} else {
+		pinsBytes = []byte(applyPlanPinsOptions.Pins)
+	}
+
+	pins := &vschemapb.PlanPins{}
+	if err := json2.UnmarshalPB(pinsBytes, pins); err != nil {
+		return err
+	}
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(pins)
+	if err != nil {
+		return err
+	}
+
+	if applyPlanPinsOptions.DryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "[DRY RUN] Would have saved new PlanPins object:\n%s\n", data)
+
+		if applyPlanPinsOptions.SkipRebuild {
+			fmt.Fprintln(cmd.OutOrStdout(), "[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+		} else {
+			fmt.Fprint(cmd.OutOrStdout(), "[DRY RUN] Would have rebuilt the VSchema graph")
+			if len(applyPlanPinsOptions.Cells) == 0 {
+				fmt.Fprint(cmd.OutOrStdout(), " in all cells\n")
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), " in the following cells: %s.\n", strings.Join(applyPlanPinsOptions.Cells, ", "))
+			}
+		}
+
+		return nil
+	}
+
+	_, err = client.ApplyPlanPins(commandCtx, &vtctldatapb.ApplyPlanPinsRequest{
+		PlanPins:     pins,
+		SkipRebuild:  applyPlanPinsOptions.SkipRebuild,
+		RebuildCells: applyPlanPinsOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "New PlanPins object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyPlanPinsOptions.SkipRebuild {
+		fmt.Fprintln(cmd.OutOrStdout(), "Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func commandGetPlanPins(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetPlanPins(commandCtx, &vtctldatapb.GetPlanPinsRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.PlanPins)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyPlanPins.Flags().StringVarP(&applyPlanPinsOptions.Pins, "pins", "p", "", "Plan pins, specified as a string")
+	ApplyPlanPins.Flags().StringVarP(&applyPlanPinsOptions.PinsFilePath, "pins-file", "f", "", "Path to a file containing plan pins specified as JSON")
+	ApplyPlanPins.Flags().StringSliceVarP(&applyPlanPinsOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyPlanPins.Flags().BoolVar(&applyPlanPinsOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyPlanPins.Flags().BoolVarP(&applyPlanPinsOptions.DryRun, "dry-run", "d", false, "Validate the specified plan pins and note actions that would be taken, but do not actually apply the pins to the topo.")
+	Root.AddCommand(ApplyPlanPins)
+
+	Root.AddCommand(GetPlanPins)
+}
@@ -31,7 +31,7 @@ import (
 var (
 	// ExecuteFetchAsApp makes an ExecuteFetchAsApp gRPC call to a vtctld.
 	ExecuteFetchAsApp = &cobra.Command{
-		Use:                   "ExecuteFetchAsApp [--max-rows <max-rows>] [--json|-j] [--use-pool] <tablet-alias> <query>",
+		Use:                   "ExecuteFetchAsApp [--max-rows <max-rows>] [--json|-j] [--use-pool] [--force] <tablet-alias> <query>",
 		Short:                 "Executes the given query as the App user on the remote tablet.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(2),
@@ -39,7 +39,7 @@ var (
 	}
 	// ExecuteFetchAsDBA makes an ExecuteFetchAsDBA gRPC call to a vtctld.
 	ExecuteFetchAsDBA = &cobra.Command{
-		Use:                   "ExecuteFetchAsDBA [--max-rows <max-rows>] [--json|-j] [--disable-binlogs] [--reload-schema] <tablet alias> <query>",
+		Use:                   "ExecuteFetchAsDBA [--max-rows <max-rows>] [--json|-j] [--disable-binlogs] [--reload-schema] [--force] <tablet alias> <query>",
 		Short:                 "Executes the given query as the DBA user on the remote tablet.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(2),
@@ -48,7 +48,7 @@ var (
 	}
 	// ExecuteMultiFetchAsDBA makes an ExecuteMultiFetchAsDBA gRPC call to a vtctld.
 	ExecuteMultiFetchAsDBA = &cobra.Command{
-		Use:                   "ExecuteMultiFetchAsDBA [--max-rows <max-rows>] [--json|-j] [--disable-binlogs] [--reload-schema] <tablet alias> <sql>",
+		Use:                   "ExecuteMultiFetchAsDBA [--max-rows <max-rows>] [--json|-j] [--disable-binlogs] [--reload-schema] [--force] <tablet alias> <sql>",
 		Short:                 "Executes given multiple queries as the DBA user on the remote tablet.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(2),
@@ -61,6 +61,7 @@ var executeFetchAsAppOptions = struct {
 	MaxRows int64
 	UsePool bool
 	JSON    bool
+	Force   bool
 }{
 	MaxRows: 10_000,
 }
@@ -80,6 +81,7 @@ func commandExecuteFetchAsApp(cmd *cobra.Command, args []string) error {
 		Query:       query,
 		MaxRows:     executeFetchAsAppOptions.MaxRows,
 		UsePool:     executeFetchAsAppOptions.UsePool,
+		Force:       executeFetchAsAppOptions.Force,
 	})
 	if err != nil {
 		return err
@@ -93,7 +95,7 @@ func commandExecuteFetchAsApp(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		fmt.Printf("%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	default:
 		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
 	}
@@ -106,6 +108,7 @@ var executeFetchAsDBAOptions = struct {
 	DisableBinlogs bool
 	ReloadSchema   bool
 	JSON           bool
+	Force          bool
 }{
 	MaxRows: 10_000,
 }
@@ -126,6 +129,7 @@ func commandExecuteFetchAsDBA(cmd *cobra.Command, args []string) error {
 		MaxRows:        executeFetchAsDBAOptions.MaxRows,
 		DisableBinlogs: executeFetchAsDBAOptions.DisableBinlogs,
 		ReloadSchema:   executeFetchAsDBAOptions.ReloadSchema,
+		Force:          executeFetchAsDBAOptions.Force,
 	})
 	if err != nil {
 		return err
@@ -139,7 +143,7 @@ func commandExecuteFetchAsDBA(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		fmt.Printf("%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	default:
 		cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
 	}
@@ -152,6 +156,7 @@ var executeMultiFetchAsDBAOptions = struct {
 	DisableBinlogs bool
 	ReloadSchema   bool
 	JSON           bool
+	Force          bool
 }{
 	MaxRows: 10_000,
 }
@@ -172,6 +177,7 @@ func commandExecuteMultiFetchAsDBA(cmd *cobra.Command, args []string) error {
 		MaxRows:        executeMultiFetchAsDBAOptions.MaxRows,
 		DisableBinlogs: executeMultiFetchAsDBAOptions.DisableBinlogs,
 		ReloadSchema:   executeMultiFetchAsDBAOptions.ReloadSchema,
+		Force:          executeMultiFetchAsDBAOptions.Force,
 	})
 	if err != nil {
 		return err
@@ -189,7 +195,7 @@ func commandExecuteMultiFetchAsDBA(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		fmt.Printf("%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	default:
 		for _, qr := range qrs {
 			cli.WriteQueryResultTable(cmd.OutOrStdout(), qr)
@@ -202,17 +208,20 @@ func init() {
 	ExecuteFetchAsApp.Flags().Int64Var(&executeFetchAsAppOptions.MaxRows, "max-rows", 10_000, "The maximum number of rows to fetch from the remote tablet.")
 	ExecuteFetchAsApp.Flags().BoolVar(&executeFetchAsAppOptions.UsePool, "use-pool", false, "Use the tablet connection pool instead of creating a fresh connection.")
 	ExecuteFetchAsApp.Flags().BoolVarP(&executeFetchAsAppOptions.JSON, "json", "j", false, "Output the results in JSON instead of a human-readable table.")
+	ExecuteFetchAsApp.Flags().BoolVar(&executeFetchAsAppOptions.Force, "force", false, "Executes the statements denied by the --execute-fetch-denied-statements flag of vtctld.")
 	Root.AddCommand(ExecuteFetchAsApp)
 
 	ExecuteFetchAsDBA.Flags().Int64Var(&executeFetchAsDBAOptions.MaxRows, "max-rows", 10_000, "The maximum number of rows to fetch from the remote tablet.")
 	ExecuteFetchAsDBA.Flags().BoolVar(&executeFetchAsDBAOptions.DisableBinlogs, "disable-binlogs", false, "Disables binary logging during the query.")
 	ExecuteFetchAsDBA.Flags().BoolVar(&executeFetchAsDBAOptions.ReloadSchema, "reload-schema", false, "Instructs the tablet to reload its schema after executing the query.")
 	ExecuteFetchAsDBA.Flags().BoolVarP(&executeFetchAsDBAOptions.JSON, "json", "j", false, "Output the results in JSON instead of a human-readable table.")
+	ExecuteFetchAsDBA.Flags().BoolVar(&executeFetchAsDBAOptions.Force, "force", false, "Executes the statements denied by the --execute-fetch-denied-statements flag of vtctld.")
 	Root.AddCommand(ExecuteFetchAsDBA)
 
 	ExecuteMultiFetchAsDBA.Flags().Int64Var(&executeMultiFetchAsDBAOptions.MaxRows, "max-rows", 10_000, "The maximum number of rows to fetch from the remote tablet.")
 	ExecuteMultiFetchAsDBA.Flags().BoolVar(&executeMultiFetchAsDBAOptions.DisableBinlogs, "disable-binlogs", false, "Disables binary logging during the query.")
 	ExecuteMultiFetchAsDBA.Flags().BoolVar(&executeMultiFetchAsDBAOptions.ReloadSchema, "reload-schema", false, "Instructs the tablet to reload its schema after executing the query.")
 	ExecuteMultiFetchAsDBA.Flags().BoolVarP(&executeMultiFetchAsDBAOptions.JSON, "json", "j", false, "Output the results in JSON instead of a human-readable table.")
+	ExecuteMultiFetchAsDBA.Flags().BoolVar(&executeMultiFetchAsDBAOptions.Force, "force", false, "Executes the statements denied by the --execute-fetch-denied-statements flag of vtctld.")
 	Root.AddCommand(ExecuteMultiFetchAsDBA)
 }
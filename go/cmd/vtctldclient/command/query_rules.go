@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// AddQueryRule makes an AddQueryRule gRPC call to a vtctld.
+	AddQueryRule = &cobra.Command{
+		Use:   "AddQueryRule {--rule RULE | --rule-file RULE_FILE} [--replace] [--dry-run] [--sample-query QUERY ...] <keyspace>",
+		Short: "Adds a query rule to the tablets of a keyspace.",
+		Long: `Adds a query rule to the tablets of a keyspace.
+
+The rule is given in the JSON format of the vttablet query rules, for example to deny the deletes of table t1:
+
+{"Name": "deny_t1_deletes", "Description": "t1 is append-only", "Plans": ["Delete"], "TableNames": ["t1"], "Action": "FAIL"}
+
+The rule is validated, and added after the current rules of the keyspace. A query is applied the action of the
+first rule it matches. The tablets of the keyspace apply the change within seconds.
+
+The sample queries are matched against the rules of the keyspace, including the new one, and the rules they match
+are reported. With --dry-run, the rule is only validated and matched against the sample queries.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandAddQueryRule,
+	}
+	// DeleteQueryRule makes a DeleteQueryRule gRPC call to a vtctld.
+	DeleteQueryRule = &cobra.Command{
+		Use:                   "DeleteQueryRule <keyspace> <name>",
+		Short:                 "Deletes a query rule of the tablets of a keyspace.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(2),
+		RunE:                  commandDeleteQueryRule,
+	}
+	// GetQueryRules makes a GetQueryRules gRPC call to a vtctld.
+	GetQueryRules = &cobra.Command{
+		Use:   "GetQueryRules [--sample-query QUERY ...] <keyspace>",
+		Short: "Displays the query rules of the tablets of a keyspace.",
+		Long: `Displays the query rules of the tablets of a keyspace.
+
+The sample queries are matched against the rules, and the rules they match are reported.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetQueryRules,
+	}
+)
+
+// queryRulesOutput is the output of the query rules commands.
+type queryRulesOutput struct {
+	Rules   json.RawMessage               `json:"rules"`
+	Matches []*vtctldatapb.QueryRuleMatch `json:"matches,omitempty"`
+}
+
+func printQueryRules(cmd *cobra.Command, rules string, matches []*vtctldatapb.QueryRuleMatch) error {
+	data, err := json.MarshalIndent(&queryRulesOutput{
+		Rules:   json.RawMessage(rules),
+		Matches: matches,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var addQueryRuleOptions = struct {
+	Rule          string
+	RuleFilePath  string
+	Replace       bool
+	DryRun        bool
+	SampleQueries []string
+}{}
+
+func commandAddQueryRule(cmd *cobra.Command, args []string) error {
+	if addQueryRuleOptions.Rule != "" && addQueryRuleOptions.RuleFilePath != "" {
+		return fmt.Errorf("cannot pass both --rule (=%s) and --rule-file (=%s)", addQueryRuleOptions.Rule, addQueryRuleOptions.RuleFilePath)
+	}
+
+	if addQueryRuleOptions.Rule == "" && addQueryRuleOptions.RuleFilePath == "" {
+		return errors.New("must pass exactly one of --rule or --rule-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	rule := addQueryRuleOptions.Rule
+	if addQueryRuleOptions.RuleFilePath != "" {
+		data, err := os.ReadFile(addQueryRuleOptions.RuleFilePath)
+		if err != nil {
+			return err
+		}
+
+		rule = string(data)
+	}
+
+	resp, err := client.AddQueryRule(commandCtx, &vtctldatapb.AddQueryRuleRequest{
+		Keyspace:      cmd.Flags().Arg(0),
+		Rule:          rule,
+		Replace:       addQueryRuleOptions.Replace,
+		DryRun:        addQueryRuleOptions.DryRun,
+		SampleQueries: addQueryRuleOptions.SampleQueries,
+	})
+	if err != nil {
+		return err
+	}
+
+	if addQueryRuleOptions.DryRun {
+		fmt.Fprintln(cmd.OutOrStdout(), "[DRY RUN] Would have saved the following query rules:")
+	}
+
+	return printQueryRules(cmd, resp.Rules, resp.Matches)
+}
+
+func commandDeleteQueryRule(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	keyspace, name := cmd.Flags().Arg(0), cmd.Flags().Arg(1)
+	_, err := client.DeleteQueryRule(commandCtx, &vtctldatapb.DeleteQueryRuleRequest{
+		Keyspace: keyspace,
+		Name:     name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted query rule %s of keyspace %s.\n", name, keyspace)
+	return nil
+}
+
+var getQueryRulesOptions = struct {
+	SampleQueries []string
+}{}
+
+func commandGetQueryRules(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetQueryRules(commandCtx, &vtctldatapb.GetQueryRulesRequest{
+		Keyspace:      cmd.Flags().Arg(0),
+		SampleQueries: getQueryRulesOptions.SampleQueries,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printQueryRules(cmd, resp.Rules, resp.Matches)
+}
+
+func init() {
+	AddQueryRule.Flags().StringVarP(&addQueryRuleOptions.Rule, "rule", "r", "", "Query rule, specified as a JSON string.")
+	AddQueryRule.Flags().StringVarP(&addQueryRuleOptions.RuleFilePath, "rule-file", "f", "", "Path to a file containing the query rule, specified as JSON.")
+	AddQueryRule.Flags().BoolVar(&addQueryRuleOptions.Replace, "replace", false, "Replace the rule of the same name, keeping its position. Otherwise, adding a rule whose name is taken fails.")
+	AddQueryRule.Flags().BoolVarP(&addQueryRuleOptions.DryRun, "dry-run", "d", false, "Validate the rule and match the sample queries against it, but do not save it.")
+	AddQueryRule.Flags().StringArrayVar(&addQueryRuleOptions.SampleQueries, "sample-query", nil, "Query to match against the rules of the keyspace. Can be repeated.")
+	Root.AddCommand(AddQueryRule)
+
+	Root.AddCommand(DeleteQueryRule)
+
+	GetQueryRules.Flags().StringArrayVar(&getQueryRulesOptions.SampleQueries, "sample-query", nil, "Query to match against the rules of the keyspace. Can be repeated.")
+	Root.AddCommand(GetQueryRules)
+}
@@ -150,7 +150,7 @@ func commandEmergencyReparentShard(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, event := range resp.Events {
-		fmt.Println(logutil.EventString(event))
+		fmt.Fprintln(cmd.OutOrStdout(), logutil.EventString(event))
 	}
 
 	return nil
@@ -251,7 +251,7 @@ func commandPlannedReparentShard(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, event := range resp.Events {
-		fmt.Println(logutil.EventString(event))
+		fmt.Fprintln(cmd.OutOrStdout(), logutil.EventString(event))
 	}
 
 	return nil
@@ -275,7 +275,7 @@ func commandReparentTablet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -298,7 +298,7 @@ func commandTabletExternallyReparented(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
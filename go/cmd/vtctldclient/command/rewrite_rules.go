@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyRewriteRules makes an ApplyRewriteRules gRPC call to a vtctld.
+	ApplyRewriteRules = &cobra.Command{
+		Use:   "ApplyRewriteRules {--rules RULES | --rules-file RULES_FILE} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Applies the provided query rewrite rules.",
+		Long: `Applies the provided query rewrite rules.
+
+The vtgates rewrite the queries with the rules, in order, before planning them. A rule matches either
+a pattern, a SQL statement in which the :name placeholders match any expression, or a regex matching
+the text of the queries, for example:
+
+{"rules": [
+  {"name": "orders_hint", "pattern": "select * from orders where customer_id = :id",
+   "replacement": "select * from orders force index (customer_id) where customer_id = :id"},
+  {"name": "legacy_users", "regex": "\\blegacy_users\\b", "replacement": "users"},
+  {"name": "order_by_null", "regex": " order by null$", "replacement": "", "dry_run": true}
+]}
+
+A rule with dry_run set only counts and logs the queries it would rewrite. The QueryRewriteRuleHits
+metric of the vtgates counts the queries matched by each rule.
+
+The rules replace all the current rewrite rules. To remove a rule, apply the rules without it.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyRewriteRules,
+	}
+	// GetRewriteRules makes a GetRewriteRules gRPC call to a vtctld.
+	GetRewriteRules = &cobra.Command{
+		Use:                   "GetRewriteRules",
+		Short:                 "Displays the currently active query rewrite rules as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetRewriteRules,
+	}
+)
+
+var applyRewriteRulesOptions = struct {
+	Rules         string
+	RulesFilePath string
+	Cells         []string
+	SkipRebuild   bool
+	DryRun        bool
+}{}
+
+func commandApplyRewriteRules(cmd *cobra.Command, args []string) error {
+	if applyRewriteRulesOptions.Rules != "" && applyRewriteRulesOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyRewriteRulesOptions.Rules, applyRewriteRulesOptions.RulesFilePath)
+	}
+
+	if applyRewriteRulesOptions.Rules == "" && applyRewriteRulesOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules or --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var rulesBytes []byte
+	if applyRewriteRulesOptions.RulesFilePath != "" {
+		data, err := os.ReadFile(applyRewriteRulesOptions.RulesFilePath)
+		if err != nil {
+			return err
+		}
+
+		rulesBytes = data
+	} else {
+		rulesBytes = []byte(applyRewriteRulesOptions.Rules)
+	}
+
+	rules := &vschemapb.RewriteRules{}
+	if err := json2.UnmarshalPB(rulesBytes, rules); err != nil {
+		return err
+	}
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(rules)
+	if err != nil {
+		return err
+	}
+
+	if applyRewriteRulesOptions.DryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "[DRY RUN] Would have saved new RewriteRules object:\n%s\n", data)
+
+		if applyRewriteRulesOptions.SkipRebuild {
+			fmt.Fprintln(cmd.OutOrStdout(), "[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+		} else {
+			fmt.Fprint(cmd.OutOrStdout(), "[DRY RUN] Would have rebuilt the VSchema graph")
+			if len(applyRewriteRulesOptions.Cells) == 0 {
+				fmt.Fprint(cmd.OutOrStdout(), " in all cells\n")
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), " in the following cells: %s.\n", strings.Join(applyRewriteRulesOptions.Cells, ", "))
+			}
+		}
+
+		return nil
+	}
+
+	_, err = client.ApplyRewriteRules(commandCtx, &vtctldatapb.ApplyRewriteRulesRequest{
+		RewriteRules: rules,
+		SkipRebuild:  applyRewriteRulesOptions.SkipRebuild,
+		RebuildCells: applyRewriteRulesOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "New RewriteRules object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyRewriteRulesOptions.SkipRebuild {
+		fmt.Fprintln(cmd.OutOrStdout(), "Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func commandGetRewriteRules(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetRewriteRules(commandCtx, &vtctldatapb.GetRewriteRulesRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.RewriteRules)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyRewriteRules.Flags().StringVarP(&applyRewriteRulesOptions.Rules, "rules", "r", "", "Query rewrite rules, specified as a string")
+	ApplyRewriteRules.Flags().StringVarP(&applyRewriteRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing query rewrite rules specified as JSON")
+	ApplyRewriteRules.Flags().StringSliceVarP(&applyRewriteRulesOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyRewriteRules.Flags().BoolVar(&applyRewriteRulesOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyRewriteRules.Flags().BoolVarP(&applyRewriteRulesOptions.DryRun, "dry-run", "d", false, "Validate the specified rewrite rules and note actions that would be taken, but do not actually apply the rules to the topo.")
+	Root.AddCommand(ApplyRewriteRules)
+
+	Root.AddCommand(GetRewriteRules)
+}
@@ -116,6 +116,9 @@ connect directly to the topo server(s).`, useInternalVtctld),
 			logutil.PurgeLogs()
 			traceCloser = trace.StartTracing("vtctldclient")
 			client, err = getClientForCommand(cmd)
+			if err == nil {
+				client, err = startStructuredOutput(cmd, client)
+			}
 			ctx := cmd.Context()
 			if ctx == nil {
 				ctx = cmd.Context()
@@ -133,8 +136,11 @@ connect directly to the topo server(s).`, useInternalVtctld),
 		// PersistentPreRun.
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) (err error) {
 			commandCancel()
+			err = stopStructuredOutput()
 			if client != nil {
-				err = client.Close()
+				if closeErr := client.Close(); err == nil {
+					err = closeErr
+				}
 			}
 			// Execute any registered onTerm functions.
 			for _, f := range onTerm {
@@ -225,6 +231,7 @@ func init() {
 	Root.PersistentFlags().StringVar(&server, "server", "", "server to use for the connection (required)")
 	Root.PersistentFlags().DurationVar(&actionTimeout, "action_timeout", time.Hour, "timeout to use for the command")
 	Root.PersistentFlags().BoolVar(&compactOutput, "compact", false, "use compact format for otherwise verbose outputs")
+	Root.PersistentFlags().StringVar(&outputFormat, "output", outputFormat, "output format of the command: text, json for the responses of the vtctld as JSON lines of google.protobuf.Any, or proto for the responses as size-delimited google.protobuf.Any messages")
 	Root.PersistentFlags().StringVar(&topoOptions.implementation, "topo-implementation", topoOptions.implementation, "the topology implementation to use")
 	Root.PersistentFlags().StringSliceVar(&topoOptions.globalServerAddresses, "topo-global-server-address", topoOptions.globalServerAddresses, "the address of the global topology server(s)")
 	Root.PersistentFlags().StringVar(&topoOptions.globalRoot, "topo-global-root", topoOptions.globalRoot, "the path of the global topology data in the global topology server")
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// DeleteScheduledJob makes a DeleteScheduledJob gRPC call to a vtctld.
+	DeleteScheduledJob = &cobra.Command{
+		Use:                   "DeleteScheduledJob <name>",
+		Short:                 "Deletes a job scheduled in vtctld.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandDeleteScheduledJob,
+	}
+	// GetScheduledJobs makes a GetScheduledJobs gRPC call to a vtctld.
+	GetScheduledJobs = &cobra.Command{
+		Use:                   "GetScheduledJobs [<name>]",
+		Short:                 "Displays the jobs scheduled in vtctld, or the specified one, with their most recent runs.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MaximumNArgs(1),
+		RunE:                  commandGetScheduledJobs,
+	}
+	// SetScheduledJob makes a SetScheduledJob gRPC call to a vtctld.
+	SetScheduledJob = &cobra.Command{
+		Use:   "SetScheduledJob --type {backup|vdiff|purge-schema-migrations} --schedule <cron> [--jitter <duration>] [--concurrency <n>] [--timeout <duration>] [--disabled] [--keyspaces <keyspace>,...] [--workflows <keyspace>.<workflow>,...] [--allow-primary] [--upgrade-safe] <name>",
+		Short: "Creates or updates a job that vtctld runs on a recurring schedule.",
+		Long: `Creates or updates a job that vtctld runs on a recurring schedule.
+
+The jobs are run by the vtctlds started with --enable-scheduled-jobs. The job types are:
+  - backup: backs up every shard of the --keyspaces, or of all the keyspaces.
+  - vdiff: creates a VDiff of each of the --workflows.
+  - purge-schema-migrations: marks the artifacts of the completed schema migrations of the --keyspaces, or of all the keyspaces, ready for cleanup.
+
+The schedule is a cron expression in UTC with 5 fields: minute, hour, day of month, month and day of week. The @hourly, @daily, @weekly and @monthly shortcuts are also accepted.`,
+		Example: `vtctldclient SetScheduledJob --type backup --schedule "0 2 * * *" --jitter 30m --concurrency 4 nightly-backups
+vtctldclient SetScheduledJob --type vdiff --schedule "0 6 * * 0" --workflows customer.commerce2customer weekly-vdiff`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetScheduledJob,
+	}
+)
+
+func commandDeleteScheduledJob(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	_, err := client.DeleteScheduledJob(commandCtx, &vtctldatapb.DeleteScheduledJobRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted scheduled job %s\n", cmd.Flags().Arg(0))
+	return nil
+}
+
+func commandGetScheduledJobs(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetScheduledJobs(commandCtx, &vtctldatapb.GetScheduledJobsRequest{
+		Name: cmd.Flags().Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var setScheduledJobOptions = struct {
+	Type         string
+	Schedule     string
+	Jitter       time.Duration
+	Concurrency  int32
+	Timeout      time.Duration
+	Disabled     bool
+	Keyspaces    []string
+	Workflows    []string
+	AllowPrimary bool
+	UpgradeSafe  bool
+}{}
+
+func commandSetScheduledJob(cmd *cobra.Command, args []string) error {
+	job := &vtctldatapb.ScheduledJob{
+		Name:        cmd.Flags().Arg(0),
+		Schedule:    setScheduledJobOptions.Schedule,
+		Concurrency: setScheduledJobOptions.Concurrency,
+		Disabled:    setScheduledJobOptions.Disabled,
+	}
+	if setScheduledJobOptions.Jitter > 0 {
+		job.Jitter = protoutil.DurationToProto(setScheduledJobOptions.Jitter)
+	}
+	if setScheduledJobOptions.Timeout > 0 {
+		job.Timeout = protoutil.DurationToProto(setScheduledJobOptions.Timeout)
+	}
+
+	switch setScheduledJobOptions.Type {
+	case "backup":
+		job.Job = &vtctldatapb.ScheduledJob_Backup{Backup: &vtctldatapb.ScheduledJob_BackupJob{
+			Keyspaces:    setScheduledJobOptions.Keyspaces,
+			AllowPrimary: setScheduledJobOptions.AllowPrimary,
+			UpgradeSafe:  setScheduledJobOptions.UpgradeSafe,
+		}}
+	case "vdiff":
+		job.Job = &vtctldatapb.ScheduledJob_Vdiff{Vdiff: &vtctldatapb.ScheduledJob_VDiffJob{
+			Workflows: setScheduledJobOptions.Workflows,
+		}}
+	case "purge-schema-migrations":
+		job.Job = &vtctldatapb.ScheduledJob_PurgeSchemaMigrations{PurgeSchemaMigrations: &vtctldatapb.ScheduledJob_PurgeSchemaMigrationsJob{
+			Keyspaces: setScheduledJobOptions.Keyspaces,
+		}}
+	default:
+		return fmt.Errorf("invalid --type %q, it must be one of backup, vdiff or purge-schema-migrations", setScheduledJobOptions.Type)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetScheduledJob(commandCtx, &vtctldatapb.SetScheduledJobRequest{
+		Job: job,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Job)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+func init() {
+	Root.AddCommand(DeleteScheduledJob)
+	Root.AddCommand(GetScheduledJobs)
+
+	SetScheduledJob.Flags().StringVar(&setScheduledJobOptions.Type, "type", "", "Type of the job: backup, vdiff or purge-schema-migrations.")
+	SetScheduledJob.Flags().StringVar(&setScheduledJobOptions.Schedule, "schedule", "", "Cron expression in UTC with 5 fields: minute, hour, day of month, month and day of week, or one of @hourly, @daily, @weekly and @monthly.")
+	SetScheduledJob.Flags().DurationVar(&setScheduledJobOptions.Jitter, "jitter", 0, "Delay each run by a random duration up to this value.")
+	SetScheduledJob.Flags().Int32Var(&setScheduledJobOptions.Concurrency, "concurrency", 1, "Number of shards, workflows or keyspaces processed at the same time by a run of the job.")
+	SetScheduledJob.Flags().DurationVar(&setScheduledJobOptions.Timeout, "timeout", 0, "Cancel a run of the job that takes longer than this duration. The default is 24h.")
+	SetScheduledJob.Flags().BoolVar(&setScheduledJobOptions.Disabled, "disabled", false, "Do not run the job until it is set again without --disabled.")
+	SetScheduledJob.Flags().StringSliceVar(&setScheduledJobOptions.Keyspaces, "keyspaces", nil, "Keyspaces of a backup or purge-schema-migrations job. All the keyspaces are processed if empty.")
+	SetScheduledJob.Flags().StringSliceVar(&setScheduledJobOptions.Workflows, "workflows", nil, "Workflows of a vdiff job, as <keyspace>.<workflow>.")
+	SetScheduledJob.Flags().BoolVar(&setScheduledJobOptions.AllowPrimary, "allow-primary", false, "Allow the backups of a backup job to occur on a PRIMARY tablet. See Backup for warnings and caveats.")
+	SetScheduledJob.Flags().BoolVar(&setScheduledJobOptions.UpgradeSafe, "upgrade-safe", false, "Take the backups of a backup job with innodb_fast_shutdown=0, so that they can be used for an upgrade.")
+	SetScheduledJob.MarkFlagRequired("type")
+	SetScheduledJob.MarkFlagRequired("schedule")
+	Root.AddCommand(SetScheduledJob)
+}
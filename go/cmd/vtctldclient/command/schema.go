@@ -187,7 +187,7 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Println(strings.Join(resp.UuidList, "\n"))
+	fmt.Fprintln(cmd.OutOrStdout(), strings.Join(resp.UuidList, "\n"))
 	return nil
 }
 
@@ -287,7 +287,7 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 			names[i] = td.Name
 		}
 
-		fmt.Printf("%s\n", strings.Join(names, "\n"))
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Join(names, "\n"))
 
 		return nil
 	}
@@ -297,7 +297,7 @@ func commandGetSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -405,7 +405,7 @@ func commandValidateSchemaKeyspace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -435,7 +435,7 @@ func commandValidateSchemaShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -112,7 +112,7 @@ func commandGetSrvKeyspaceNames(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -135,7 +135,7 @@ func commandGetSrvKeyspaces(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -157,7 +157,7 @@ func commandGetSrvVSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -184,7 +184,7 @@ func commandGetSrvVSchemas(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -226,7 +226,7 @@ func commandRebuildVSchemaGraph(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Println("RebuildVSchemaGraph: ok")
+	fmt.Fprintln(cmd.OutOrStdout(), "RebuildVSchemaGraph: ok")
 
 	return nil
 }
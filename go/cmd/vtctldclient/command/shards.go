@@ -19,10 +19,12 @@ package command
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -78,7 +80,7 @@ that shard.`,
 				return err
 			}
 
-			fmt.Printf("%s\n", data)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 			return nil
 		},
 		Annotations: map[string]string{
@@ -141,6 +143,21 @@ useful after a MoveTables has finished to remove serving restrictions.`,
 		Args:                  cobra.ExactArgs(2),
 		RunE:                  commandSetShardTabletControl,
 	}
+	// SetShardWriteFence makes a SetShardWriteFence gRPC call to a vtctld.
+	SetShardWriteFence = &cobra.Command{
+		Use:   "SetShardWriteFence [--reason=<reason>] [--buffer-duration=<duration>] [--remove] <keyspace/shard>",
+		Short: "Makes vtgate reject the writes to a shard, independently of the read_only state of its MySQL servers. Only use this for an emergency maintenance.",
+		Long: `Makes vtgate reject the writes to a shard, independently of the read_only state of its MySQL servers.
+
+Only use this for an emergency maintenance. The fence is stored in the SrvKeyspace of every cell,
+and the writes to the shard fail with the given reason until the fence is removed with --remove.
+
+With --buffer-duration, vtgate holds the writes for up to the given duration before rejecting
+them, and executes them if the fence is removed meanwhile.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetShardWriteFence,
+	}
 	// ShardReplicationAdd makse a ShardReplicationAdd gRPC request to a vtctld.
 	ShardReplicationAdd = &cobra.Command{
 		Use:                   "ShardReplicationAdd <keyspace/shard> <tablet alias>",
@@ -233,7 +250,7 @@ func commandCreateShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -263,7 +280,7 @@ func commandDeleteShards(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%w: while deleting %d shards; please inspect the topo", err, len(shards))
 	}
 
-	fmt.Printf("Successfully deleted %d shards\n", len(shards))
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted %d shards\n", len(shards))
 
 	return nil
 }
@@ -289,7 +306,7 @@ func commandGetShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -318,7 +335,7 @@ func commandGetShardReplication(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 
@@ -351,7 +368,7 @@ func commandRemoveShardCell(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Successfully removed cell %v from shard %s/%s\n", cell, keyspace, shard)
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully removed cell %v from shard %s/%s\n", cell, keyspace, shard)
 
 	return nil
 }
@@ -383,7 +400,7 @@ func commandSetShardIsPrimaryServing(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -425,7 +442,44 @@ func commandSetShardTabletControl(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var setShardWriteFenceOptions = struct {
+	Reason         string
+	BufferDuration time.Duration
+	Remove         bool
+}{}
+
+func commandSetShardWriteFence(cmd *cobra.Command, args []string) error {
+	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
+	if err != nil {
+		return fmt.Errorf("cannot parse keyspace/shard: %w", err)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.SetShardWriteFenceRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Reason:   setShardWriteFenceOptions.Reason,
+		Remove:   setShardWriteFenceOptions.Remove,
+	}
+	if setShardWriteFenceOptions.BufferDuration > 0 {
+		req.BufferDuration = protoutil.DurationToProto(setShardWriteFenceOptions.BufferDuration)
+	}
+	resp, err := client.SetShardWriteFence(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Shard)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -470,9 +524,9 @@ func commandShardReplicationFix(cmd *cobra.Command, args []string) error {
 
 	switch resp.Error {
 	case nil:
-		fmt.Println("All nodes in the replication graph are valid.")
+		fmt.Fprintln(cmd.OutOrStdout(), "All nodes in the replication graph are valid.")
 	default:
-		fmt.Printf("%s has been fixed for %s.\n", topoproto.ShardReplicationErrorTypeString(resp.Error.Type), topoproto.TabletAliasString(resp.Error.TabletAlias))
+		fmt.Fprintf(cmd.OutOrStdout(), "%s has been fixed for %s.\n", topoproto.ShardReplicationErrorTypeString(resp.Error.Type), topoproto.TabletAliasString(resp.Error.TabletAlias))
 	}
 
 	return nil
@@ -504,7 +558,7 @@ func commandShardReplicationPositions(cmd *cobra.Command, args []string) error {
 			line = cli.MarshalTabletAWK(rt.Tablet) + fmt.Sprintf(" %v %v", rt.Status.Position, rt.Status.ReplicationLagSeconds)
 		}
 
-		fmt.Println(line)
+		fmt.Fprintln(cmd.OutOrStdout(), line)
 	}
 
 	return nil
@@ -577,14 +631,14 @@ func commandSourceShardAdd(cmd *cobra.Command, args []string) error {
 
 	switch resp.Shard {
 	case nil:
-		fmt.Printf("SourceShard with uid %v already exists for %s/%s, not adding it.\n", uid, ks, shard)
+		fmt.Fprintf(cmd.OutOrStdout(), "SourceShard with uid %v already exists for %s/%s, not adding it.\n", uid, ks, shard)
 	default:
 		data, err := cli.MarshalJSON(resp.Shard)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Updated shard record:\n%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated shard record:\n%s\n", data)
 	}
 
 	return nil
@@ -614,14 +668,14 @@ func commandSourceShardDelete(cmd *cobra.Command, args []string) error {
 
 	switch resp.Shard {
 	case nil:
-		fmt.Printf("No SourceShard with uid %v.\n", uid)
+		fmt.Fprintf(cmd.OutOrStdout(), "No SourceShard with uid %v.\n", uid)
 	default:
 		data, err := cli.MarshalJSON(resp.Shard)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Updated shard record:\n%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated shard record:\n%s\n", data)
 	}
 	return nil
 }
@@ -647,7 +701,7 @@ func commandValidateVersionShard(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -677,6 +731,11 @@ func init() {
 	SetShardTabletControl.Flags().BoolVar(&setShardTabletControlOptions.DisableQueryService, "disable-query-service", false, "Adds or removes the DisableQueryService field in the SrvKeyspace record (Reshard) for the specified cells (using all cells by deefault). This flag requires --denied-tables and --remove to be unset; if either is set, this flag is ignored.")
 	Root.AddCommand(SetShardTabletControl)
 
+	SetShardWriteFence.Flags().StringVar(&setShardWriteFenceOptions.Reason, "reason", "", "The reason of the fence, returned in the errors of the rejected writes. Required unless --remove is set.")
+	SetShardWriteFence.Flags().DurationVar(&setShardWriteFenceOptions.BufferDuration, "buffer-duration", 0, "How long vtgate holds the writes to the shard before rejecting them, in case the fence is removed meanwhile. The writes are rejected immediately by default.")
+	SetShardWriteFence.Flags().BoolVarP(&setShardWriteFenceOptions.Remove, "remove", "r", false, "Removes the write fence of the shard.")
+	Root.AddCommand(SetShardWriteFence)
+
 	Root.AddCommand(ShardReplicationAdd)
 	Root.AddCommand(ShardReplicationFix)
 	Root.AddCommand(ShardReplicationPositions)
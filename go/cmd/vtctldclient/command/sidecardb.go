@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sidecardb"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// GetSidecarSchemaState reads the state of the sidecar database schema of a tablet.
+	GetSidecarSchemaState = &cobra.Command{
+		Use:   "GetSidecarSchemaState <tablet alias>",
+		Short: "Outputs the version of the sidecar database schema of the tablet, whether it is pinned, and the features it enables.",
+		Long: `Outputs the version of the sidecar database schema of the tablet, whether it is pinned, and the features it enables.
+
+The latest version is the one of this vtctldclient binary.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetSidecarSchemaState,
+	}
+	// SetSidecarSchemaVersion rolls back or unpins the sidecar database schema of a primary tablet.
+	SetSidecarSchemaVersion = &cobra.Command{
+		Use:   "SetSidecarSchemaVersion {--version <version> | --unpin} <primary tablet alias>",
+		Short: "Rolls back the sidecar database schema of the shard to a version and pins it there, or unpins it.",
+		Long: `Rolls back the sidecar database schema of the shard to a version and pins it there, or unpins it.
+
+Before a downgrade, the schema is rolled back to the version of the older release, using the
+vtctldclient binary of the newer release. The tablets do not upgrade a pinned schema; once
+unpinned, they upgrade it again when they next initialize the sidecar database, e.g. on restart.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetSidecarSchemaVersion,
+	}
+)
+
+// readSidecarSchemaState returns the tablet, the identifier of its sidecar
+// database, and the state of its schema.
+func readSidecarSchemaState(alias *topodatapb.TabletAlias) (*topodatapb.Tablet, string, sidecardb.State, error) {
+	tabletResp, err := client.GetTablet(commandCtx, &vtctldatapb.GetTabletRequest{TabletAlias: alias})
+	if err != nil {
+		return nil, "", sidecardb.State{}, err
+	}
+	keyspaceResp, err := client.GetKeyspace(commandCtx, &vtctldatapb.GetKeyspaceRequest{Keyspace: tabletResp.Tablet.Keyspace})
+	if err != nil {
+		return nil, "", sidecardb.State{}, err
+	}
+	dbName := keyspaceResp.Keyspace.Keyspace.SidecarDbName
+	if dbName == "" {
+		dbName = sidecar.DefaultName
+	}
+	dbIdent := sqlparser.String(sqlparser.NewIdentifierCS(dbName))
+
+	resp, err := client.ExecuteFetchAsDBA(commandCtx, &vtctldatapb.ExecuteFetchAsDBARequest{
+		TabletAlias: alias,
+		Query:       sidecardb.ReadStateQuery(dbIdent),
+		MaxRows:     1,
+	})
+	if err != nil {
+		return nil, "", sidecardb.State{}, err
+	}
+	state, err := sidecardb.ParseState(sqltypes.Proto3ToResult(resp.Result))
+	return tabletResp.Tablet, dbIdent, state, err
+}
+
+func commandGetSidecarSchemaState(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	_, _, state, err := readSidecarSchemaState(alias)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(struct {
+		sidecardb.State
+		LatestVersion int             `json:"latest_version"`
+		Features      map[string]bool `json:"features"`
+	}{
+		State:         state,
+		LatestVersion: sidecardb.LatestVersion(),
+		Features:      sidecardb.Features(state.Version),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var setSidecarSchemaVersionOptions = struct {
+	Version int
+	Unpin   bool
+}{}
+
+func commandSetSidecarSchemaVersion(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+	if setSidecarSchemaVersionOptions.Unpin == cmd.Flags().Changed("version") {
+		return fmt.Errorf("exactly one of --version or --unpin must be specified")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	tablet, dbIdent, state, err := readSidecarSchemaState(alias)
+	if err != nil {
+		return err
+	}
+	if tablet.Type != topodatapb.TabletType_PRIMARY {
+		return fmt.Errorf("tablet %s is a %s tablet: the sidecar database schema is changed on the primary", topoproto.TabletAliasString(alias), topoproto.TabletTypeLString(tablet.Type))
+	}
+
+	var queries []string
+	if setSidecarSchemaVersionOptions.Unpin {
+		queries = []string{sidecardb.UnpinQuery(dbIdent, state)}
+	} else {
+		if queries, err = sidecardb.RollbackQueries(dbIdent, state, setSidecarSchemaVersionOptions.Version); err != nil {
+			return err
+		}
+	}
+
+	// The statements run on one connection, so that they see the use
+	// statement of RollbackQueries.
+	_, err = client.ExecuteMultiFetchAsDBA(commandCtx, &vtctldatapb.ExecuteMultiFetchAsDBARequest{
+		TabletAlias:  alias,
+		Sql:          strings.Join(queries, ";"),
+		MaxRows:      1,
+		ReloadSchema: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if setSidecarSchemaVersionOptions.Unpin {
+		fmt.Fprintf(cmd.OutOrStdout(), "Unpinned the sidecar database schema of %s at version %d.\n", topoproto.TabletAliasString(alias), state.Version)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Rolled back the sidecar database schema of %s to version %d.\n", topoproto.TabletAliasString(alias), setSidecarSchemaVersionOptions.Version)
+	}
+	return nil
+}
+
+func init() {
+	Root.AddCommand(GetSidecarSchemaState)
+
+	SetSidecarSchemaVersion.Flags().IntVar(&setSidecarSchemaVersionOptions.Version, "version", 0, "The version to roll the sidecar database schema back to.")
+	SetSidecarSchemaVersion.Flags().BoolVar(&setSidecarSchemaVersionOptions.Unpin, "unpin", false, "Unpin the sidecar database schema, so that the tablets upgrade it again.")
+	Root.AddCommand(SetSidecarSchemaVersion)
+}
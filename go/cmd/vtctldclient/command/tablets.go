@@ -243,8 +243,8 @@ func commandChangeTabletTags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("- %v\n", cli.MarshalMapAWK(resp.BeforeTags))
-	fmt.Printf("+ %v\n", cli.MarshalMapAWK(resp.AfterTags))
+	fmt.Fprintf(cmd.OutOrStdout(), "- %v\n", cli.MarshalMapAWK(resp.BeforeTags))
+	fmt.Fprintf(cmd.OutOrStdout(), "+ %v\n", cli.MarshalMapAWK(resp.AfterTags))
 
 	return nil
 }
@@ -279,11 +279,11 @@ func commandChangeTabletType(cmd *cobra.Command, args []string) error {
 	}
 
 	if resp.WasDryRun {
-		fmt.Println("--- DRY RUN ---")
+		fmt.Fprintln(cmd.OutOrStdout(), "--- DRY RUN ---")
 	}
 
-	fmt.Printf("- %v\n", cli.MarshalTabletAWK(resp.BeforeTablet))
-	fmt.Printf("+ %v\n", cli.MarshalTabletAWK(resp.AfterTablet))
+	fmt.Fprintf(cmd.OutOrStdout(), "- %v\n", cli.MarshalTabletAWK(resp.BeforeTablet))
+	fmt.Fprintf(cmd.OutOrStdout(), "+ %v\n", cli.MarshalTabletAWK(resp.AfterTablet))
 
 	return nil
 }
@@ -309,7 +309,7 @@ func commandDeleteTablets(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%w: while deleting %d tablets; please inspect the topo", err, len(aliases))
 	}
 
-	fmt.Printf("Successfully deleted %d tablets\n", len(aliases))
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully deleted %d tablets\n", len(aliases))
 
 	return nil
 }
@@ -344,7 +344,7 @@ func commandExecuteHook(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	return nil
 }
 
@@ -367,7 +367,7 @@ func commandGetFullStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -391,7 +391,7 @@ func commandGetTablet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -459,7 +459,7 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 	switch format {
 	case "awk":
 		for _, t := range resp.Tablets {
-			fmt.Println(cli.MarshalTabletAWK(t))
+			fmt.Fprintln(cmd.OutOrStdout(), cli.MarshalTabletAWK(t))
 		}
 	case "json":
 		data, err := cli.MarshalJSON(resp.Tablets)
@@ -467,7 +467,7 @@ func commandGetTablets(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		fmt.Printf("%s\n", data)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 	}
 
 	return nil
@@ -488,7 +488,7 @@ func commandGetTabletVersion(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Println(resp.Version)
+	fmt.Fprintln(cmd.OutOrStdout(), resp.Version)
 	return nil
 }
 
@@ -521,7 +521,7 @@ func commandRefreshState(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Refreshed state on %s\n", topoproto.TabletAliasString(alias))
+	fmt.Fprintf(cmd.OutOrStdout(), "Refreshed state on %s\n", topoproto.TabletAliasString(alias))
 	return nil
 }
 
@@ -556,7 +556,7 @@ func commandRefreshStateByShard(cmd *cobra.Command, args []string) error {
 		msg.WriteString("State refresh was partial; some tablets in the shard may not have succeeded.\n")
 	}
 
-	fmt.Print(msg.String())
+	fmt.Fprint(cmd.OutOrStdout(), msg.String())
 	return nil
 }
 
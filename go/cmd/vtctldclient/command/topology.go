@@ -24,12 +24,24 @@ import (
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
 	"vitess.io/vitess/go/vt/topo"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 var (
+	// ExportTopology makes an ExportTopology gRPC call to a vtctld.
+	ExportTopology = &cobra.Command{
+		Use:   "ExportTopology [<file>]",
+		Short: "Exports the global topology into an archive, written to the file or to stdout.",
+		Long: `Exports the global topology into an archive, written to the file or to stdout.
+
+The archive is a versioned JSON document with the cells, cells aliases, keyspaces with their throttler configs, vschemas, shards, routing rules and scheduled jobs of the cluster. It can be imported into another topology server with ImportTopology, to recover the control plane of the cluster.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MaximumNArgs(1),
+		RunE:                  commandExportTopology,
+	}
 	// GetTopologyPath makes a GetTopologyPath gRPC call to a vtctld.
 	GetTopologyPath = &cobra.Command{
 		Use:                   "GetTopologyPath <path>",
@@ -39,6 +51,23 @@ var (
 		RunE:                  commandGetTopologyPath,
 	}
 
+	// ImportTopology makes an ImportTopology gRPC call to a vtctld.
+	ImportTopology = &cobra.Command{
+		Use:   "ImportTopology [--on-conflict {fail|skip|overwrite}] [--dry-run] <file>",
+		Short: "Imports an archive written by ExportTopology into the global topology.",
+		Long: `Imports an archive written by ExportTopology into the global topology.
+
+By default, nothing is imported if any record of the archive already exists in the topology. Use --on-conflict=skip to keep the existing records, or --on-conflict=overwrite to replace them.
+
+The serving graph of the cells is not part of the archive. Rebuild it after the import with RebuildKeyspaceGraph and RebuildVSchemaGraph.`,
+		Example: `vtctldclient --server internal --topo-implementation etcd2 --topo-global-server-address new-etcd:2379 ImportTopology topology.json
+vtctldclient --server internal RebuildVSchemaGraph
+vtctldclient --server internal RebuildKeyspaceGraph commerce customer`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandImportTopology,
+	}
+
 	// WriteTopologyPath writes the contents of a local file to a path
 	// in the topology server.
 	WriteTopologyPath = &cobra.Command{
@@ -56,6 +85,74 @@ var (
 	}
 )
 
+func commandExportTopology(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ExportTopology(commandCtx, &vtctldatapb.ExportTopologyRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Archive)
+	if err != nil {
+		return err
+	}
+
+	if file := cmd.Flags().Arg(0); file != "" {
+		if err := os.WriteFile(file, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write file %s: %v", file, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported the topology to %s\n", file)
+		return nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
+var importTopologyOptions = struct {
+	OnConflict string
+	DryRun     bool
+}{
+	OnConflict: "fail",
+}
+
+func commandImportTopology(cmd *cobra.Command, args []string) error {
+	onConflict, ok := vtctldatapb.ImportTopologyRequest_ConflictPolicy_value[strings.ToUpper(importTopologyOptions.OnConflict)]
+	if !ok {
+		return fmt.Errorf("invalid --on-conflict %q, it must be one of fail, skip or overwrite", importTopologyOptions.OnConflict)
+	}
+
+	file := cmd.Flags().Arg(0)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+	archive := &vtctldatapb.TopologyArchive{}
+	if err := json2.UnmarshalPB(data, archive); err != nil {
+		return fmt.Errorf("failed to parse the archive in %s: %v", file, err)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.ImportTopology(commandCtx, &vtctldatapb.ImportTopologyRequest{
+		Archive:    archive,
+		OnConflict: vtctldatapb.ImportTopologyRequest_ConflictPolicy(onConflict),
+		DryRun:     importTopologyOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err = cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+	return nil
+}
+
 var getTopologyPathOptions = struct {
 	// The version of the key/path to get. If not specified, the latest/current
 	// version is returned.
@@ -82,7 +179,7 @@ func commandGetTopologyPath(cmd *cobra.Command, args []string) error {
 		if resp.GetCell() == nil || resp.GetCell().GetData() == "" {
 			return fmt.Errorf("no data found for path %s", path)
 		}
-		fmt.Println(resp.GetCell().GetData())
+		fmt.Fprintln(cmd.OutOrStdout(), resp.GetCell().GetData())
 		return nil
 	}
 
@@ -91,7 +188,7 @@ func commandGetTopologyPath(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -127,6 +224,12 @@ func commandWriteTopologyPath(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	Root.AddCommand(ExportTopology)
+
+	ImportTopology.Flags().StringVar(&importTopologyOptions.OnConflict, "on-conflict", importTopologyOptions.OnConflict, "What to do with the records of the archive that already exist in the topology: fail to import anything, skip them, or overwrite them.")
+	ImportTopology.Flags().BoolVar(&importTopologyOptions.DryRun, "dry-run", false, "Output the records that would be created, overwritten or skipped, without importing them.")
+	Root.AddCommand(ImportTopology)
+
 	GetTopologyPath.Flags().Int64Var(&getTopologyPathOptions.version, "version", getTopologyPathOptions.version, "The version of the path's key to get. If not specified, the latest version is returned.")
 	GetTopologyPath.Flags().BoolVar(&getTopologyPathOptions.dataAsJSON, "data-as-json", getTopologyPathOptions.dataAsJSON, "If true, only the data is output and it is in JSON format rather than prototext.")
 	Root.AddCommand(GetTopologyPath)
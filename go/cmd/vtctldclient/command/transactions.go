@@ -107,16 +107,16 @@ func commandGetUnresolvedTransactions(cmd *cobra.Command, args []string) error {
 			AbandonAge: unresolvedTransactionsOptions.AbandonAge,
 		})
 	if err != nil {
-		prettyPrintError(err)
+		prettyPrintError(cmd, err)
 		return err
 	}
 
 	data, err := cli.MarshalJSON(resp.Transactions)
 	if err != nil {
-		prettyPrintError(err)
+		prettyPrintError(cmd, err)
 		return err
 	}
-	fmt.Println(string(data))
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
 	return nil
 }
 
@@ -138,7 +138,7 @@ func commandConcludeTransaction(cmd *cobra.Command, args []string) (err error) {
 	}
 
 	data, _ := cli.MarshalJSON(output)
-	fmt.Println(string(data))
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
 
 	return err
 }
@@ -152,15 +152,15 @@ func commandGetTransactionInfo(cmd *cobra.Command, args []string) error {
 		})
 
 	if err != nil || rts == nil {
-		prettyPrintError(err)
+		prettyPrintError(cmd, err)
 		return err
 	}
 
-	fmt.Println(string(rts.String()))
+	fmt.Fprintln(cmd.OutOrStdout(), string(rts.String()))
 	return nil
 }
 
-func prettyPrintError(err error) {
+func prettyPrintError(cmd *cobra.Command, err error) {
 	if err == nil {
 		return
 	}
@@ -170,7 +170,7 @@ func prettyPrintError(err error) {
 		Error: err.Error(),
 	}
 	data, _ := cli.MarshalJSON(st)
-	fmt.Println(string(data))
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
 }
 
 func init() {
@@ -72,11 +72,11 @@ func commandValidate(cmd *cobra.Command, args []string) error {
 
 	buf := &strings.Builder{}
 	if err := consumeValidationResults(resp, buf); err != nil {
-		fmt.Printf("Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
+		fmt.Fprintf(cmd.OutOrStdout(), "Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
 		return err
 	}
 
-	fmt.Println("Validation complete; no issues found.")
+	fmt.Fprintln(cmd.OutOrStdout(), "Validation complete; no issues found.")
 	return nil
 }
 
@@ -98,11 +98,11 @@ func commandValidateKeyspace(cmd *cobra.Command, args []string) error {
 
 	buf := &strings.Builder{}
 	if err := consumeKeyspaceValidationResults(keyspace, resp, buf); err != nil {
-		fmt.Printf("Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
+		fmt.Fprintf(cmd.OutOrStdout(), "Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
 		return err
 	}
 
-	fmt.Printf("Validation of %s complete; no issues found.\n", keyspace)
+	fmt.Fprintf(cmd.OutOrStdout(), "Validation of %s complete; no issues found.\n", keyspace)
 	return nil
 }
 
@@ -129,11 +129,11 @@ func commandValidateShard(cmd *cobra.Command, args []string) error {
 
 	buf := &strings.Builder{}
 	if err := consumeShardValidationResults(keyspace, shard, resp, buf); err != nil {
-		fmt.Printf("Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
+		fmt.Fprintf(cmd.OutOrStdout(), "Validation results:\n%s", buf.String() /* note: this should have a trailing newline already */)
 		return err
 	}
 
-	fmt.Printf("Validation of %s/%s complete; no issues found.\n", keyspace, shard)
+	fmt.Fprintf(cmd.OutOrStdout(), "Validation of %s/%s complete; no issues found.\n", keyspace, shard)
 	return nil
 }
 
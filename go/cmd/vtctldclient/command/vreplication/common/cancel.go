@@ -88,7 +88,7 @@ func commandCancel(cmd *cobra.Command, args []string) error {
 	} else {
 		output = []byte(resp.Summary + "\n")
 	}
-	fmt.Printf("%s\n", output)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", output)
 
 	return nil
 }
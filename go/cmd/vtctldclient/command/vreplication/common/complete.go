@@ -18,6 +18,7 @@ var CompleteOptions = struct {
 	DryRun               bool
 	Shards               []string
 	IgnoreSourceKeyspace bool
+	DeleteBatchSize      int64
 }{}
 
 func GetCompleteCommand(opts *SubCommandsOpts) *cobra.Command {
@@ -49,6 +50,7 @@ func commandComplete(cmd *cobra.Command, args []string) error {
 		RenameTables:         CompleteOptions.RenameTables,
 		DryRun:               CompleteOptions.DryRun,
 		IgnoreSourceKeyspace: CompleteOptions.IgnoreSourceKeyspace,
+		DeleteBatchSize:      CompleteOptions.DeleteBatchSize,
 	}
 	resp, err := GetClient().MoveTablesComplete(GetCommandCtx(), req)
 	if err != nil {
@@ -72,7 +74,7 @@ func commandComplete(cmd *cobra.Command, args []string) error {
 		}
 		output = tout.Bytes()
 	}
-	fmt.Println(string(output))
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
 
 	return nil
 }
@@ -83,7 +83,7 @@ func commandMirrorTraffic(cmd *cobra.Command, args []string) error {
 		tout.WriteString(fmt.Sprintf("Current State: %s\n", resp.CurrentState))
 		output = tout.Bytes()
 	}
-	fmt.Printf("%s\n", output)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", output)
 
 	return nil
 }
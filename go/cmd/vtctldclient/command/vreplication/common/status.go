@@ -60,7 +60,7 @@ func commandStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err = OutputStatusResponse(resp, format); err != nil {
+	if err = OutputStatusResponse(cmd, resp, format); err != nil {
 		return err
 	}
 
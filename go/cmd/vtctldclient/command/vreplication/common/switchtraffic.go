@@ -102,6 +102,9 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 		EnableReverseReplication:  SwitchTrafficOptions.EnableReverseReplication,
 		InitializeTargetSequences: SwitchTrafficOptions.InitializeTargetSequences,
 		Direction:                 int32(SwitchTrafficOptions.Direction),
+		VerifyConsistency:         SwitchTrafficOptions.VerifyConsistency,
+		ConsistencySampleSize:     SwitchTrafficOptions.ConsistencySampleSize,
+		IgnoreMaintenanceWindows:  SwitchTrafficOptions.IgnoreMaintenanceWindows,
 	}
 	resp, err := GetClient().WorkflowSwitchTraffic(GetCommandCtx(), req)
 	if err != nil {
@@ -124,10 +127,16 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 		} else {
 			tout.WriteString(fmt.Sprintf("Start State: %s\n", resp.StartState))
 			tout.WriteString(fmt.Sprintf("Current State: %s\n", resp.CurrentState))
+			if len(resp.ConsistencyFenceSteps) > 0 {
+				tout.WriteString("Consistency Fence:\n")
+				for _, step := range resp.ConsistencyFenceSteps {
+					tout.WriteString(fmt.Sprintf("  %s: %s\n", step.Name, step.State))
+				}
+			}
 		}
 		output = tout.Bytes()
 	}
-	fmt.Printf("%s\n", output)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", output)
 
 	return nil
 }
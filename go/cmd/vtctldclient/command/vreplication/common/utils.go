@@ -258,7 +258,7 @@ func GetTabletSelectionPreference(cmd *cobra.Command) tabletmanagerdatapb.Tablet
 	return tsp
 }
 
-func OutputStatusResponse(resp *vtctldatapb.WorkflowStatusResponse, format string) error {
+func OutputStatusResponse(cmd *cobra.Command, resp *vtctldatapb.WorkflowStatusResponse, format string) error {
 	var output []byte
 	var err error
 	if format == "json" {
@@ -281,7 +281,7 @@ func OutputStatusResponse(resp *vtctldatapb.WorkflowStatusResponse, format strin
 		tout.WriteString(resp.TrafficState)
 		output = tout.Bytes()
 	}
-	fmt.Println(string(output))
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
 	return nil
 }
 
@@ -322,6 +322,9 @@ var SwitchTrafficOptions = struct {
 	InitializeTargetSequences bool
 	Shards                    []string
 	Force                     bool
+	VerifyConsistency         bool
+	ConsistencySampleSize     int64
+	IgnoreMaintenanceWindows  bool
 }{}
 
 func AddCommonSwitchTrafficFlags(cmd *cobra.Command, initializeTargetSequences bool) {
@@ -332,6 +335,9 @@ func AddCommonSwitchTrafficFlags(cmd *cobra.Command, initializeTargetSequences b
 	cmd.Flags().BoolVar(&SwitchTrafficOptions.EnableReverseReplication, "enable-reverse-replication", true, "Setup replication going back to the original source keyspace to support rolling back the traffic cutover.")
 	cmd.Flags().BoolVar(&SwitchTrafficOptions.DryRun, "dry-run", false, "Print the actions that would be taken and report any known errors that would have occurred.")
 	cmd.Flags().BoolVar(&SwitchTrafficOptions.Force, "force", false, "Force the traffic switch even if some potentially non-critical actions cannot be performed; for example the tablet refresh fails on some tablets in the keyspace. WARNING: this should be used with extreme caution and only in emergency situations!")
+	cmd.Flags().BoolVar(&SwitchTrafficOptions.VerifyConsistency, "verify-consistency", false, "Before switching writes, and once the writes on the source are stopped and VReplication caught up, verify that the target streams reached the source positions and compare the row counts and a sample of the rows of each table on the source and the target. The traffic switch is rolled back if they differ.")
+	cmd.Flags().Int64Var(&SwitchTrafficOptions.ConsistencySampleSize, "consistency-sample-size", workflow.DefaultConsistencySampleSize, "Number of rows of each table compared when using --verify-consistency.")
+	cmd.Flags().BoolVar(&SwitchTrafficOptions.IgnoreMaintenanceWindows, "ignore-maintenance-windows", false, "Switch the writes even if no maintenance window is open for the target keyspace.")
 	if initializeTargetSequences {
 		cmd.Flags().BoolVar(&SwitchTrafficOptions.InitializeTargetSequences, "initialize-target-sequences", false, "When moving tables from an unsharded keyspace to a sharded keyspace, initialize any sequences that are being used on the target when switching writes. If the sequence table is not found, and the sequence table reference was fully qualified OR a value was specified for --global-keyspace, then we will attempt to create the sequence table in that keyspace.")
 	}
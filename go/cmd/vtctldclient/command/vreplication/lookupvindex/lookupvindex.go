@@ -350,7 +350,7 @@ func commandCancel(cmd *cobra.Command, args []string) error {
 
 	output := fmt.Sprintf("LookupVindex %s left in place and the %s VReplication wokflow has been deleted",
 		baseOptions.Name, baseOptions.Name)
-	fmt.Println(output)
+	fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
@@ -374,7 +374,7 @@ func commandComplete(cmd *cobra.Command, args []string) error {
 	}
 
 	output := fmt.Sprintf("LookupVindex %s has been completed and the VReplication workflow has been deleted.", baseOptions.Name)
-	fmt.Println(output)
+	fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
@@ -399,7 +399,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 
 	output := fmt.Sprintf("LookupVindex %s created in the %s keyspace and the %s VReplication wokflow scheduled on the %s shards, use show to view progress",
 		baseOptions.Name, createOptions.Keyspace, baseOptions.Name, baseOptions.TableKeyspace)
-	fmt.Println(output)
+	fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
@@ -430,7 +430,7 @@ func commandExternalize(cmd *cobra.Command, args []string) error {
 	} else if resp.WorkflowDeleted {
 		output = output + " and the VReplication workflow has been deleted."
 	}
-	fmt.Println(output)
+	fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
@@ -454,7 +454,7 @@ func commandInternalize(cmd *cobra.Command, args []string) error {
 	}
 
 	output := fmt.Sprintf("LookupVindex %s has been internalized and the VReplication workflow has been started.", baseOptions.Name)
-	fmt.Println(output)
+	fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
@@ -476,7 +476,7 @@ func commandShow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
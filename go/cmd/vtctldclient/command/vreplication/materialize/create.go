@@ -140,9 +140,9 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 			Status: "success",
 		}
 		jsonText, _ := cli.MarshalJSONPretty(resp)
-		fmt.Println(string(jsonText))
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonText))
 	} else {
-		fmt.Printf("Materialization workflow %s successfully created in the %s keyspace. Use show to view the status.\n",
+		fmt.Fprintf(cmd.OutOrStdout(), "Materialization workflow %s successfully created in the %s keyspace. Use show to view the status.\n",
 			common.BaseOptions.Workflow, common.BaseOptions.TargetKeyspace)
 	}
 
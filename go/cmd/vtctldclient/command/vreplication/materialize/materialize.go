@@ -73,7 +73,7 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Table(s) %s added to the workflow %s. Use show to view the status.\n",
+	fmt.Fprintf(cmd.OutOrStdout(), "Table(s) %s added to the workflow %s. Use show to view the status.\n",
 		strings.Join(updateOptions.AddReferenceTables, ", "), common.BaseOptions.Workflow)
 	return nil
 }
@@ -69,7 +69,7 @@ func commandRegister(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Mount %s registered successfully\n", req.Name)
+	fmt.Fprintf(cmd.OutOrStdout(), "Mount %s registered successfully\n", req.Name)
 	return nil
 }
 
@@ -93,7 +93,7 @@ func commandUnregister(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Mount %s unregistered successfully\n", req.Name)
+	fmt.Fprintf(cmd.OutOrStdout(), "Mount %s unregistered successfully\n", req.Name)
 	return nil
 }
 
@@ -121,7 +121,7 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s\n", string(data))
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", string(data))
 	return nil
 }
 
@@ -148,7 +148,7 @@ func commandList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s\n", string(data))
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", string(data))
 	return nil
 }
 
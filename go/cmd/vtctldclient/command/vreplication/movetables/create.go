@@ -100,7 +100,7 @@ var (
 			}
 			createOptions.WorkflowOptions.ShardedAutoIncrementHandling = vtctldatapb.ShardedAutoIncrementHandling(val)
 			if val == int32(vtctldatapb.ShardedAutoIncrementHandling_REPLACE) && createOptions.WorkflowOptions.GlobalKeyspace == "" {
-				fmt.Println("WARNING: no global-keyspace value provided so all sequence table references not fully qualified must be created manually before switching traffic")
+				fmt.Fprintln(cmd.OutOrStdout(), "WARNING: no global-keyspace value provided so all sequence table references not fully qualified must be created manually before switching traffic")
 			}
 
 			return nil
@@ -148,7 +148,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if err = common.OutputStatusResponse(resp, format); err != nil {
+	if err = common.OutputStatusResponse(cmd, resp, format); err != nil {
 		return err
 	}
 	return nil
@@ -34,9 +34,10 @@ var (
 
 	// reshardCreate makes a ReshardCreate gRPC call to a vtctld.
 	reshardCreate = &cobra.Command{
-		Use:                   "create",
-		Short:                 "Create and optionally run a Reshard VReplication workflow.",
-		Example:               `vtctldclient --server localhost:15999 reshard --workflow customer2customer --target-keyspace customer create --source-shards="0" --target-shards="-80,80-" --cells zone1 --cells zone2 --tablet-types replica`,
+		Use:   "create",
+		Short: "Create and optionally run a Reshard VReplication workflow.",
+		Example: `vtctldclient --server localhost:15999 reshard --workflow customer2customer --target-keyspace customer create --source-shards="0" --target-shards="-80,80-" --cells zone1 --cells zone2 --tablet-types replica
+vtctldclient --server localhost:15999 reshard --workflow customer2customer --target-keyspace customer create --source-shards="-40,40-80"`,
 		SilenceUsage:          true,
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"Create"},
@@ -86,7 +87,7 @@ func commandReshardCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	if err = common.OutputStatusResponse(resp, format); err != nil {
+	if err = common.OutputStatusResponse(cmd, resp, format); err != nil {
 		return err
 	}
 	return nil
@@ -95,7 +96,7 @@ func commandReshardCreate(cmd *cobra.Command, args []string) error {
 func registerCreateCommand(root *cobra.Command) {
 	common.AddCommonCreateFlags(reshardCreate)
 	reshardCreate.Flags().StringSliceVar(&reshardCreateOptions.sourceShards, "source-shards", nil, "Source shards.")
-	reshardCreate.Flags().StringSliceVar(&reshardCreateOptions.targetShards, "target-shards", nil, "Target shards.")
+	reshardCreate.Flags().StringSliceVar(&reshardCreateOptions.targetShards, "target-shards", nil, "Target shards. If not specified, the source shards are merged into the existing shard covering their keyranges.")
 	reshardCreate.Flags().BoolVar(&reshardCreateOptions.skipSchemaCopy, "skip-schema-copy", false, "Skip copying the schema from the source shards to the target shards.")
 	root.AddCommand(reshardCreate)
 }
@@ -344,7 +344,7 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		} else {
 			data = []byte(fmt.Sprintf("VDiff %s scheduled on target shards, use show to view progress", resp.UUID))
 		}
-		fmt.Println(string(data))
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
 	}
 
 	return nil
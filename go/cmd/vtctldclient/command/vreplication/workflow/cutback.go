@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	cutBackOptions = struct {
+		MaxReplicationLagAllowed time.Duration
+		Timeout                  time.Duration
+		DryRun                   bool
+	}{}
+
+	// cutBack makes a WorkflowCutBack gRPC call to a vtctld.
+	cutBack = &cobra.Command{
+		Use:                   "cutback",
+		Short:                 "Switch all the traffic of a workflow back to its source keyspace once its reverse workflow is healthy.",
+		Long:                  "Switch all the traffic of a workflow whose writes were switched back to its source keyspace, like ReverseTraffic does for all tablet types. The reverse workflow must first pass preflight checks: it must exist, all of its streams must be running on all of the source shards, and its lag must be below --max-replication-lag-allowed.",
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer cutback --workflow commerce2customer`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"CutBack"},
+		Args:                  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if cutBackOptions.Timeout.Seconds() < 1 {
+				return fmt.Errorf("timeout value must be at least 1 second")
+			}
+			return nil
+		},
+		RunE: commandCutBack,
+	}
+)
+
+func commandCutBack(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowCutBackRequest{
+		Keyspace:                 baseOptions.Keyspace,
+		Workflow:                 baseOptions.Workflow,
+		MaxReplicationLagAllowed: protoutil.DurationToProto(cutBackOptions.MaxReplicationLagAllowed),
+		Timeout:                  protoutil.DurationToProto(cutBackOptions.Timeout),
+		DryRun:                   cutBackOptions.DryRun,
+	}
+	resp, err := common.GetClient().WorkflowCutBack(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+
+	return nil
+}
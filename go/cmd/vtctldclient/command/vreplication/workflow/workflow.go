@@ -22,6 +22,7 @@ import (
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/movetables"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 )
 
 var (
@@ -57,6 +58,13 @@ func registerCommands(root *cobra.Command) {
 	getWorkflows.Flags().BoolVarP(&getWorkflowsOptions.ShowAll, "show-all", "a", false, "Show all workflows instead of just active workflows.")
 	root.AddCommand(getWorkflows) // Yes this is supposed to be root as GetWorkflows is a top-level command.
 
+	cutBack.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow whose traffic you want to switch back.")
+	cutBack.MarkFlagRequired("workflow")
+	cutBack.Flags().DurationVar(&cutBackOptions.MaxReplicationLagAllowed, "max-replication-lag-allowed", common.MaxReplicationLagDefault, "Allow traffic to be switched back only if the lag of the reverse workflow is below this.")
+	cutBack.Flags().DurationVar(&cutBackOptions.Timeout, "timeout", workflow.DefaultTimeout, "Specifies the maximum time to wait, in seconds, for the reverse workflow to catch up on primary tablets. The traffic switch will be cancelled on timeout.")
+	cutBack.Flags().BoolVar(&cutBackOptions.DryRun, "dry-run", false, "Run the preflight checks and print the actions that would be taken.")
+	base.AddCommand(cutBack)
+
 	delete.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to delete.")
 	delete.MarkFlagRequired("workflow")
 	delete.Flags().BoolVar(&deleteOptions.KeepData, "keep-data", false, "Keep the partially copied table data from the workflow in the target keyspace.")
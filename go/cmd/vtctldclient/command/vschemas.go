@@ -119,10 +119,10 @@ func commandApplyVSchema(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("New VSchema object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", vsData)
+	fmt.Fprintf(cmd.OutOrStdout(), "New VSchema object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", vsData)
 	for vdxName, ups := range res.UnknownVindexParams {
 		for _, param := range ups.Params {
-			fmt.Printf("Unknown parameter in vindex %s: %s\n", vdxName, param)
+			fmt.Fprintf(cmd.OutOrStdout(), "Unknown parameter in vindex %s: %s\n", vdxName, param)
 		}
 	}
 	return nil
@@ -145,7 +145,7 @@ func commandGetVSchema(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s\n", data)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
 
 	return nil
 }
@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/exit"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/dynamicflags"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
@@ -184,6 +185,12 @@ func run(cmd *cobra.Command, args []string) error {
 	// pass nil for HealthCheck and it will be created
 	vtg := vtgate.Init(ctx, env, nil, resilientServer, cell, tabletTypes, plannerVersion)
 
+	if dynamicflags.Enabled() {
+		dynamicConfigWatcher := dynamicflags.NewWatcher(ts, dynamicflags.VTGate, "")
+		dynamicConfigWatcher.Open()
+		servenv.OnClose(dynamicConfigWatcher.Close)
+	}
+
 	servenv.OnRun(func() {
 		// Flags are parsed now. Parse the template using the actual flag value and overwrite the current template.
 		discovery.ParseTabletURLTemplateFromFlag()
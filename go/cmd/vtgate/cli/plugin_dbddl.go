@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+
+	// Imports and registers the gRPC vtctld client.
+	_ "vitess.io/vitess/go/vt/vtctl/grpcvtctldclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var dbDDLVtctldServer string
+
+// vtctldDBDDL is the "vtctld" dbddl plugin, that creates and deletes the
+// keyspaces of CREATE/DROP DATABASE with the vtctld of --dbddl-vtctld-server.
+//
+// It only manages the keyspaces and their shards in the topology, the tablets
+// serving them are provisioned by the deployment. The tablets of a snapshot
+// keyspace restore the backups of the base keyspace up to its snapshot time
+// when they start.
+type vtctldDBDDL struct{}
+
+// CreateDatabase implements the engine.DBDDLPlugin interface
+func (vtctldDBDDL) CreateDatabase(ctx context.Context, name string) error {
+	return withVtctld(ctx, func(client vtctldclient.VtctldClient) error {
+		_, err := client.CreateKeyspace(ctx, &vtctldatapb.CreateKeyspaceRequest{
+			Name: name,
+			Type: topodatapb.KeyspaceType_NORMAL,
+		})
+		return err
+	})
+}
+
+// DropDatabase implements the engine.DBDDLPlugin interface
+func (vtctldDBDDL) DropDatabase(ctx context.Context, name string) error {
+	return withVtctld(ctx, func(client vtctldclient.VtctldClient) error {
+		_, err := client.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+			Keyspace:  name,
+			Recursive: true,
+		})
+		return err
+	})
+}
+
+// CreateSnapshotDatabase implements the engine.DBDDLSnapshotPlugin interface.
+// It creates a SNAPSHOT keyspace of baseName, with the same shards.
+func (vtctldDBDDL) CreateSnapshotDatabase(ctx context.Context, name string, baseName string, recoveryTime time.Time) error {
+	return withVtctld(ctx, func(client vtctldclient.VtctldClient) error {
+		shards, err := client.FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{Keyspace: baseName})
+		if err != nil {
+			return err
+		}
+		_, err = client.CreateKeyspace(ctx, &vtctldatapb.CreateKeyspaceRequest{
+			Name:         name,
+			Type:         topodatapb.KeyspaceType_SNAPSHOT,
+			BaseKeyspace: baseName,
+			SnapshotTime: protoutil.TimeToProto(recoveryTime),
+		})
+		if err != nil {
+			return err
+		}
+		for shard := range shards.Shards {
+			_, err = client.CreateShard(ctx, &vtctldatapb.CreateShardRequest{
+				Keyspace:  name,
+				ShardName: shard,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func withVtctld(ctx context.Context, f func(client vtctldclient.VtctldClient) error) error {
+	if dbDDLVtctldServer == "" {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "--dbddl-vtctld-server is required by the vtctld dbddl plugin")
+	}
+	client, err := vtctldclient.New(ctx, "grpc", dbDDLVtctldServer)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return f(client)
+}
+
+func init() {
+	Main.Flags().StringVar(&dbDDLVtctldServer, "dbddl-vtctld-server", dbDDLVtctldServer, "Address of the vtctld gRPC server used by the vtctld dbddl plugin (--dbddl_plugin=vtctld) to create and drop the keyspaces of CREATE/DROP DATABASE.")
+
+	engine.DBDDLRegister("vtctld", vtctldDBDDL{})
+}
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/binlog"
 	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/dynamicflags"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/servenv"
@@ -37,6 +38,7 @@ import (
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/binlogserver"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/semisyncmonitor"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
@@ -181,6 +183,18 @@ func run(cmd *cobra.Command, args []string) error {
 		tm.Close()
 	})
 
+	if dynamicflags.Enabled() {
+		tabletserver.RegisterDynamicConfig(qsc)
+		dynamicConfigWatcher := dynamicflags.NewWatcher(ts, dynamicflags.VTTablet, tablet.Keyspace)
+		dynamicConfigWatcher.Open()
+		servenv.OnClose(dynamicConfigWatcher.Close)
+	}
+
+	// The binlog server needs the database name set by tm.Start.
+	if bs := binlogserver.Init(env, tm.DBConfigs); bs != nil {
+		servenv.OnTermSync(bs.Close)
+	}
+
 	servenv.RunDefault()
 
 	return nil
@@ -32,6 +32,7 @@ import (
 	"google.golang.org/protobuf/encoding/prototext"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/flagutil"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/vttest"
@@ -56,6 +57,7 @@ var (
 	seed            vttest.SeedConfig
 	topo            topoFlags
 	doCreateTCPUser bool
+	cellLatencies   flagutil.StringMapValue
 )
 
 func (t *topoFlags) buildTopology() (*vttestpb.VTTestTopology, error) {
@@ -196,6 +198,15 @@ func New() (cmd *cobra.Command) {
 	cmd.Flags().IntVar(&topo.rdonly, "rdonly_count", 1,
 		"Rdonly tablets per shard")
 
+	cmd.Flags().Var(&cellLatencies, "cell-latencies",
+		"Comma separated list of cell:latency pairs, e.g. 'cell2:20ms,cell3:50ms'."+
+			" The latency is added to every request from vtgate to the tablets of"+
+			" the cell, to simulate remote cells.")
+	cmd.Flags().BoolVar(&config.EnableFaultInjectionAPI, "enable-fault-injection-api", false,
+		"Enable the vtcombo HTTP API under /debug/vttest/faults to inject failures"+
+			" into the cluster: kill primaries, partition cells, stall replication"+
+			" and change cell latencies.")
+
 	cmd.Flags().StringVar(&config.Charset, "charset", "utf8mb4", "MySQL charset")
 
 	cmd.Flags().StringVar(&config.PlannerVersion, "planner-version", "", "Sets the default planner to use when the session has not changed it. Valid values are: Gen4, Gen4Greedy, Gen4Left2Right")
@@ -280,6 +291,18 @@ func newEnv() (env *vttest.LocalTestEnv, err error) {
 		config.Topology = &topology
 	}
 
+	if len(cellLatencies) > 0 {
+		config.CellLatencies = make(map[string]time.Duration, len(cellLatencies))
+		for cell, value := range cellLatencies {
+			var latency time.Duration
+			latency, err = time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid latency for cell %v in --cell-latencies: %w", cell, err)
+			}
+			config.CellLatencies[cell] = latency
+		}
+	}
+
 	if doSeed {
 		config.Seed = &seed
 	}
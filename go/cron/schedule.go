@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cron parses the cron expressions of the recurring schedules, like the
+// schedules of the jobs run by vtctld and of the maintenance windows.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression. It is evaluated in UTC.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record whether the day of month and the day of week
+	// are unrestricted, as a day matches either of them when both are
+	// restricted, like in cron.
+	domStar, dowStar bool
+}
+
+var scheduleShortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// scheduleField is the range of the values of a field of a cron expression.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 7 is also Sunday.
+	{"day of week", 0, 7},
+}
+
+// ParseSchedule parses a cron expression with 5 fields: minute, hour, day of
+// month, month and day of week. Each field is a comma-separated list of
+// values, ranges (1-5) and steps (*/15 or 1-30/2), or * for all the values.
+// The @hourly, @daily, @weekly and @monthly shortcuts are also accepted.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		full, ok := scheduleShortcuts[expr]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule shortcut %s", expr)
+		}
+		expr = full
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(scheduleFields) {
+		return nil, fmt.Errorf("schedule %q must have %d fields: minute, hour, day of month, month and day of week", expr, len(scheduleFields))
+	}
+
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseScheduleField(part, scheduleFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+func parseScheduleField(text string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rng, step, hasStep := strings.Cut(item, "/")
+		start, end := field.min, field.max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = parseScheduleValue(first, field); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseScheduleValue(last, field); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("invalid %s range %s", field.name, rng)
+				}
+			} else if hasStep {
+				// 5/15 is 5-max/15.
+				end = field.max
+			}
+		}
+		inc := 1
+		if hasStep {
+			var err error
+			if inc, err = strconv.Atoi(step); err != nil || inc <= 0 {
+				return 0, fmt.Errorf("invalid %s step %s", field.name, step)
+			}
+		}
+		for v := start; v <= end; v += inc {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseScheduleValue(text string, field scheduleField) (int, error) {
+	v, err := strconv.Atoi(text)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("invalid %s %q, it must be between %d and %d", field.name, text, field.min, field.max)
+	}
+	return v, nil
+}
+
+// maxScheduleSearch bounds the search of the next occurrence of a schedule
+// that never matches, like February 30th.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first occurrence of the schedule strictly after t, or the
+// zero time if the schedule never matches.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Covers tells whether t falls within d of an occurrence of the schedule,
+// that is whether an occurrence o of the schedule satisfies o <= t < o+d.
+func (s *Schedule) Covers(t time.Time, d time.Duration) bool {
+	next := s.Next(t.Add(-d))
+	return !next.IsZero() && !next.After(t)
+}
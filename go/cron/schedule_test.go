@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@yearly",
+	} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday, January 15th 2025.
+	from := time.Date(2025, 1, 15, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2025, 1, 16, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)},
+		{"0,20,40 10 * * *", time.Date(2025, 1, 15, 10, 40, 0, 0, time.UTC)},
+		// Sunday, as 0 and as 7.
+		{"@weekly", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 6 * * 7", time.Date(2025, 1, 19, 6, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// The day matches the day of month or the day of week when both are
+		// restricted.
+		{"0 0 1 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, schedule.Next(from), tt.expr)
+	}
+}
+
+func TestScheduleCovers(t *testing.T) {
+	// Wednesday.
+	at := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		duration time.Duration
+		want     bool
+	}{
+		{"0 10 * * *", time.Hour, true},
+		{"0 10 * * *", 30 * time.Minute, false},
+		{"0 10 * * *", 31 * time.Minute, true},
+		{"30 10 * * *", time.Minute, true},
+		{"31 10 * * *", time.Hour, false},
+		{"0 22 * * 2", 14 * time.Hour, true},
+		{"0 22 * * 2", 12 * time.Hour, false},
+		{"0 0 * * 6,0", 48 * time.Hour, false},
+		{"0 0 30 2 *", 24 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, schedule.Covers(at, tt.duration), "%s for %v", tt.expr, tt.duration)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hll implements HyperLogLog sketches, which estimate the number of
+// distinct values of a set with a fixed amount of memory. Sketches are
+// computed by the tablets on the rows of their shard, sent to vtgate as
+// binary values, and merged there to estimate the number of distinct values
+// of all the shards.
+package hll
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	// precision is the number of bits of the hashes selecting a register.
+	// A sketch has 2^precision registers and a standard error of about
+	// 1.04/sqrt(2^precision), that is 0.8%.
+	precision = 14
+	registers = 1 << precision
+
+	// The encodings of the marshaled sketches. The registers of a sparse
+	// sketch are encoded as index deltas and values, for the non-empty
+	// registers only, while a dense sketch encodes all of them.
+	sparseEncoding = 1
+	denseEncoding  = 2
+)
+
+var errCorrupt = errors.New("hll: corrupt sketch")
+
+// Sketch is a HyperLogLog sketch. The zero value is an empty sketch.
+type Sketch struct {
+	// regs are the registers, allocated on the first value added.
+	regs []uint8
+}
+
+// Add adds a value to the sketch, given its 64 bits hash.
+func (s *Sketch) Add(hash uint64) {
+	s.alloc()
+	idx := hash >> (64 - precision)
+	// The rank is the position of the first set bit after the index bits,
+	// which is at most 64-precision+1.
+	rank := uint8(bits.LeadingZeros64(hash<<precision|1<<(precision-1))) + 1
+	if rank > s.regs[idx] {
+		s.regs[idx] = rank
+	}
+}
+
+// Merge adds all the values of the other sketch to the sketch.
+func (s *Sketch) Merge(other *Sketch) {
+	if other.regs == nil {
+		return
+	}
+	s.alloc()
+	for i, rank := range other.regs {
+		if rank > s.regs[i] {
+			s.regs[i] = rank
+		}
+	}
+}
+
+// MergeBinary adds all the values of a marshaled sketch to the sketch.
+func (s *Sketch) MergeBinary(data []byte) error {
+	if len(data) < 2 || data[1] != precision {
+		return errCorrupt
+	}
+	encoding, data := data[0], data[2:]
+	if encoding == sparseEncoding && len(data) == 0 {
+		return nil
+	}
+	s.alloc()
+	switch encoding {
+	case sparseEncoding:
+		idx := -1
+		for len(data) > 0 {
+			delta, n := binary.Uvarint(data)
+			if n <= 0 || len(data) < n+1 {
+				return errCorrupt
+			}
+			idx += int(delta)
+			if idx >= registers {
+				return errCorrupt
+			}
+			if rank := data[n]; rank > s.regs[idx] {
+				s.regs[idx] = rank
+			}
+			data = data[n+1:]
+		}
+	case denseEncoding:
+		if len(data) != registers {
+			return errCorrupt
+		}
+		for i, rank := range data {
+			if rank > s.regs[i] {
+				s.regs[i] = rank
+			}
+		}
+	default:
+		return errCorrupt
+	}
+	return nil
+}
+
+// Reset empties the sketch, keeping its registers allocated.
+func (s *Sketch) Reset() {
+	clear(s.regs)
+}
+
+// Marshal returns the binary encoding of the sketch, which is the most
+// compact of its sparse and dense encodings.
+func (s *Sketch) Marshal() []byte {
+	var nonEmpty int
+	for _, rank := range s.regs {
+		if rank != 0 {
+			nonEmpty++
+		}
+	}
+	// A sparse register takes up to 3 bytes, a dense register 1 byte.
+	if nonEmpty*3 >= registers {
+		data := make([]byte, 2, 2+registers)
+		data[0], data[1] = denseEncoding, precision
+		return append(data, s.regs...)
+	}
+	data := make([]byte, 2, 2+nonEmpty*3)
+	data[0], data[1] = sparseEncoding, precision
+	last := -1
+	for i, rank := range s.regs {
+		if rank != 0 {
+			data = binary.AppendUvarint(data, uint64(i-last))
+			data = append(data, rank)
+			last = i
+		}
+	}
+	return data
+}
+
+// Unmarshal returns the sketch of a binary encoding returned by Marshal.
+func Unmarshal(data []byte) (*Sketch, error) {
+	s := &Sketch{}
+	if err := s.MergeBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Estimate returns the estimated number of distinct values added to the
+// sketch.
+func (s *Sketch) Estimate() uint64 {
+	if s.regs == nil {
+		return 0
+	}
+	var sum float64
+	var zeros int
+	for _, rank := range s.regs {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+	const m = float64(registers)
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	// The raw estimate is biased for the small cardinalities, which are
+	// better estimated by linear counting of the empty registers.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+func (s *Sketch) alloc() {
+	if s.regs == nil {
+		s.regs = make([]uint8, registers)
+	}
+}
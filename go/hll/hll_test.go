@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hll
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, count := range []int{0, 1, 10, 1000, 50000, 1000000} {
+		var s Sketch
+		for range count {
+			hash := r.Uint64()
+			// Adding the same value again doesn't change the estimate.
+			s.Add(hash)
+			s.Add(hash)
+		}
+		estimate := float64(s.Estimate())
+		assert.InDelta(t, count, estimate, math.Max(0.02*float64(count), 1), "count: %d", count)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	var all, merged Sketch
+	for range 4 {
+		var shard Sketch
+		for range 20000 {
+			hash := r.Uint64()
+			shard.Add(hash)
+			all.Add(hash)
+		}
+		// The shards have overlapping values.
+		for range 1000 {
+			hash := r.Uint64()
+			shard.Add(hash)
+			all.Add(hash)
+			merged.Add(hash)
+		}
+		require.NoError(t, merged.MergeBinary(shard.Marshal()))
+	}
+	assert.Equal(t, all.Estimate(), merged.Estimate())
+
+	var empty Sketch
+	merged.Merge(&empty)
+	assert.Equal(t, all.Estimate(), merged.Estimate())
+	empty.Merge(&all)
+	assert.Equal(t, all.Estimate(), empty.Estimate())
+
+	merged.Reset()
+	assert.Zero(t, merged.Estimate())
+	assert.Equal(t, []byte{sparseEncoding, precision}, merged.Marshal())
+}
+
+func TestMarshal(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for _, count := range []int{0, 100, 100000} {
+		var s Sketch
+		for range count {
+			s.Add(r.Uint64())
+		}
+		data := s.Marshal()
+		if count < registers {
+			assert.Equal(t, byte(sparseEncoding), data[0], "count: %d", count)
+			assert.Less(t, len(data), registers, "count: %d", count)
+		} else {
+			assert.Equal(t, byte(denseEncoding), data[0], "count: %d", count)
+		}
+
+		unmarshaled, err := Unmarshal(data)
+		require.NoError(t, err)
+		assert.Equal(t, s.Estimate(), unmarshaled.Estimate())
+		assert.Equal(t, data, unmarshaled.Marshal())
+	}
+
+	for _, data := range [][]byte{
+		nil,
+		{sparseEncoding},
+		{sparseEncoding, precision + 1},
+		{sparseEncoding, precision, 1},
+		{sparseEncoding, precision, 0xff, 0xff, 0xff, 1},
+		{denseEncoding, precision, 1, 2, 3},
+		{3, precision},
+	} {
+		_, err := Unmarshal(data)
+		assert.ErrorIs(t, err, errCorrupt, "data: %v", data)
+	}
+}
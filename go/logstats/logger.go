@@ -40,6 +40,7 @@ type logbv struct {
 type Logger struct {
 	b     []byte
 	bvars []logbv
+	keys  []string
 	n     int
 	json  bool
 }
@@ -188,6 +189,26 @@ func (log *Logger) Strings(strs []string) {
 	log.b = append(log.b, ']')
 }
 
+// StringMap logs the map as a JSON object, sorted by key.
+func (log *Logger) StringMap(m map[string]string) {
+	log.keys = log.keys[:0]
+	for k := range m {
+		log.keys = append(log.keys, k)
+	}
+	slices.Sort(log.keys)
+
+	log.b = append(log.b, '{')
+	for i, k := range log.keys {
+		if i > 0 {
+			log.b = append(log.b, ',', ' ')
+		}
+		log.b = strconv.AppendQuote(log.b, k)
+		log.b = append(log.b, ':', ' ')
+		log.b = strconv.AppendQuote(log.b, m[k])
+	}
+	log.b = append(log.b, '}')
+}
+
 func (log *Logger) Flush(w io.Writer) (err error) {
 	if log.json {
 		log.b = append(log.b, '}')
@@ -197,6 +218,8 @@ func (log *Logger) Flush(w io.Writer) (err error) {
 
 	clear(log.bvars)
 	log.bvars = log.bvars[:0]
+	clear(log.keys)
+	log.keys = log.keys[:0]
 	log.b = log.b[:0]
 	log.n = 0
 
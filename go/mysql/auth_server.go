@@ -22,7 +22,9 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/subtle"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"net"
 	"sync"
 
@@ -62,6 +64,22 @@ type AuthServer interface {
 	DefaultAuthMethodDescription() AuthMethodDescription
 }
 
+// MultiFactorAuthServer can be implemented by an AuthServer to ask users for
+// additional authentication factors once their first factor authenticated
+// them, like the multi-factor authentication of MySQL. The server then
+// advertises CapabilityClientMultiFactorAuthentication, and refuses the
+// clients which do not support it if they have additional factors.
+type MultiFactorAuthServer interface {
+	AuthServer
+
+	// AdditionalAuthFactors returns the auth methods of the additional
+	// factors of the user, in order, given the user data returned by its
+	// first factor. The server asks the client for each of them with an
+	// AuthNextFactorPacket, and each of them must authenticate the user.
+	// The user data of the connection stays the one of the first factor.
+	AdditionalAuthFactors(conn *Conn, user string, userData Getter) []AuthMethod
+}
+
 // AuthMethod interface for concrete auth method implementations.
 // When building an auth server, you usually don't implement these yourself
 // but the helper methods to build AuthMethod instances should be used.
@@ -226,16 +244,11 @@ func NewMysqlDialogAuthMethod(layer PlainTextStorage, validator UserValidator, m
 // be called if the return of the first layer indicates the full auth dance is
 // needed.
 //
-// Right now we only support caching_sha2_password over TLS or a Unix socket.
-//
-// If TLS is not enabled, the client needs to encrypt it with the public
-// key of the server. In that case, Vitess is already configured with
-// a certificate anyway, so we recommend to use TLS if you want to use
-// caching_sha2_password in that case instead of allowing the plain
-// text fallback path here.
-//
-// This might change in the future if there's a good argument and implementation
-// for allowing the plain text path here as well.
+// The full auth dance sends the plain text password over TLS or a Unix socket.
+// On other connections, the client encrypts it with the public key of the
+// RSA key pair of the Listener, CachingSha2PrivateKey, which it asks for
+// first. caching_sha2_password is not used on such connections if the
+// Listener has no RSA key.
 func NewSha2CachingAuthMethod(layer1 CachingStorage, layer2 PlainTextStorage, validator UserValidator) AuthMethod {
 	authMethod := mysqlCachingSha2AuthMethod{
 		cache:     layer1,
@@ -500,7 +513,7 @@ func (n *mysqlCachingSha2AuthMethod) Name() AuthMethodDescription {
 }
 
 func (n *mysqlCachingSha2AuthMethod) HandleUser(conn *Conn, user string) bool {
-	if !conn.TLSEnabled() && !conn.IsUnixSocket() {
+	if !conn.TLSEnabled() && !conn.IsUnixSocket() && conn.cachingSha2PrivateKey() == nil {
 		return false
 	}
 	return n.validator.HandleUser(user)
@@ -546,16 +559,25 @@ func (n *mysqlCachingSha2AuthMethod) HandleAuthPluginData(c *Conn, user string,
 		}
 		return result, nil
 	case AuthNeedMoreData:
-		if !c.TLSEnabled() && !c.IsUnixSocket() {
+		secure := c.TLSEnabled() || c.IsUnixSocket()
+		key := c.cachingSha2PrivateKey()
+		if !secure && key == nil {
 			return nil, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 		}
 
 		data, pos := c.startEphemeralPacketWithHeader(2)
 		pos = writeByte(data, pos, AuthMoreDataPacket)
 		writeByte(data, pos, CachingSha2FullAuth)
-		c.writeEphemeralPacket()
+		if err := c.writeEphemeralPacket(); err != nil {
+			return nil, err
+		}
 
-		password, err := readPacketPasswordString(c)
+		var password string
+		if secure {
+			password, err = readPacketPasswordString(c)
+		} else {
+			password, err = readPacketEncryptedPasswordString(c, salt, key)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -620,6 +642,57 @@ func negotiateAuthMethod(conn *Conn, as AuthServer, user string, requestedAuth A
 	return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "unknown auth method requested: %s", string(requestedAuth))
 }
 
+// cachingSha2PrivateKey returns the RSA key of the caching_sha2_password full
+// authentication of the Listener of the connection, or nil if it has none.
+func (c *Conn) cachingSha2PrivateKey() *rsa.PrivateKey {
+	if c.listener == nil {
+		return nil
+	}
+	return c.listener.CachingSha2PrivateKey.Load()
+}
+
+// authenticateAdditionalFactors asks the client for the additional
+// authentication factors of the user, in order, with an AuthNextFactorPacket
+// for each of them. It returns the error of the first factor which does not
+// authenticate the user.
+func (c *Conn) authenticateAdditionalFactors(user string, factors []AuthMethod, allowClearTextWithoutTLS bool, remoteAddr net.Addr) error {
+	if len(factors) == 0 {
+		return nil
+	}
+	if c.Capabilities&CapabilityClientMultiFactorAuthentication == 0 {
+		return sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v': the client does not support multi-factor authentication", user)
+	}
+
+	for _, factor := range factors {
+		if !allowClearTextWithoutTLS && !c.TLSEnabled() && !factor.AllowClearTextWithoutTLS() {
+			return sqlerror.NewSQLErrorf(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
+		}
+
+		pluginData, err := factor.AuthPluginData()
+		if err != nil {
+			return err
+		}
+
+		length := 1 + len(factor.Name()) + 1 + len(pluginData)
+		data, pos := c.startEphemeralPacketWithHeader(length)
+		pos = writeByte(data, pos, AuthNextFactorPacket)
+		pos = writeNullString(data, pos, string(factor.Name()))
+		copy(data[pos:], pluginData)
+		if err := c.writeEphemeralPacket(); err != nil {
+			return err
+		}
+
+		authResponse, err := c.ReadPacket()
+		if err != nil {
+			return err
+		}
+		if _, err := factor.HandleAuthPluginData(c, user, pluginData, authResponse, remoteAddr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func readPacketPasswordString(c *Conn) (string, error) {
 	// Read a packet, the password is the payload, as a
 	// zero terminated string.
@@ -632,3 +705,66 @@ func readPacketPasswordString(c *Conn) (string, error) {
 	}
 	return string(data[:len(data)-1]), nil
 }
+
+// readPacketEncryptedPasswordString reads the password of the
+// caching_sha2_password full authentication on a connection without TLS. The
+// client asks for the public key of the server first, unless it has it
+// already, and sends the zero terminated password XOR the salt, encrypted with
+// the public key.
+func readPacketEncryptedPasswordString(c *Conn, salt []byte, key *rsa.PrivateKey) (string, error) {
+	data, err := c.ReadPacket()
+	if err != nil {
+		return "", err
+	}
+	if len(data) == 1 && data[0] == CachingSha2RequestPublicKey {
+		pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			return "", err
+		}
+		pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+
+		out, pos := c.startEphemeralPacketWithHeader(1 + len(pemKey))
+		pos = writeByte(out, pos, AuthMoreDataPacket)
+		copy(out[pos:], pemKey)
+		if err := c.writeEphemeralPacket(); err != nil {
+			return "", err
+		}
+
+		if data, err = c.ReadPacket(); err != nil {
+			return "", err
+		}
+	}
+
+	password, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, key, data, nil)
+	if err != nil {
+		return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "cannot decrypt password: %v", err)
+	}
+	for i := range password {
+		password[i] ^= salt[i%len(salt)]
+	}
+	if len(password) == 0 || password[len(password)-1] != 0 {
+		return "", vterrors.Errorf(vtrpc.Code_INTERNAL, "received invalid encrypted password, datalen=%v", len(password))
+	}
+	return string(password[:len(password)-1]), nil
+}
+
+// ParseCachingSha2PrivateKey parses an RSA private key in PEM format, either
+// PKCS #1 or PKCS #8, to be used as the Listener.CachingSha2PrivateKey.
+func ParseCachingSha2PrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "cannot parse private key: %v", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
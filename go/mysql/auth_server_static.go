@@ -115,7 +115,7 @@ func NewAuthServerStatic(file, jsonConfig string, reloadInterval time.Duration)
 		entries:        make(map[string][]*AuthServerStaticEntry),
 	}
 
-	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a)}
+	a.methods = []AuthMethod{NewMysqlNativeAuthMethod(a, a), NewSha2CachingAuthMethod(a, a, a)}
 
 	a.reload()
 	a.installSignalHandlers()
@@ -170,7 +170,7 @@ func (a *AuthServerStatic) UserEntryWithPassword(conn *Conn, user string, passwo
 
 	for _, entry := range entries {
 		// Validate the password.
-		if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
+		if MatchSourceHost(remoteAddr, entry.SourceHost) && entry.matchesPassword(password) {
 			return &StaticUserData{entry.UserData, entry.Groups}, nil
 		}
 	}
@@ -221,6 +221,7 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 		return &StaticUserData{}, AuthRejected, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 	}
 
+	needMoreData := false
 	for _, entry := range entries {
 		if entry.CachingSha2Password != "" {
 			hash, err := DecodePasswordHex(entry.CachingSha2Password)
@@ -233,6 +234,13 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
 			}
 
+		} else if entry.Password == "" && entry.MysqlNativePassword != "" {
+			// The mysql_native_password hash cannot verify the auth
+			// response, but it can verify the plain text password of
+			// the full authentication.
+			if MatchSourceHost(remoteAddr, entry.SourceHost) {
+				needMoreData = true
+			}
 		} else {
 			computedAuthResponse := ScrambleCachingSha2Password(salt, []byte(entry.Password))
 
@@ -242,9 +250,37 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 			}
 		}
 	}
+	if needMoreData {
+		return &StaticUserData{}, AuthNeedMoreData, nil
+	}
 	return &StaticUserData{}, AuthRejected, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 }
 
+// matchesPassword returns whether the plain text password is the one of the
+// entry, which is verified against the hashes of the entry if it has no plain
+// text password.
+func (entry *AuthServerStaticEntry) matchesPassword(password string) bool {
+	var verify func(reply, salt, hash []byte) bool
+	var scramble func(salt, password []byte) []byte
+	var hexHash string
+	switch {
+	case entry.Password == "" && entry.CachingSha2Password != "":
+		verify, scramble, hexHash = VerifyHashedCachingSha2Password, ScrambleCachingSha2Password, entry.CachingSha2Password
+	case entry.Password == "" && entry.MysqlNativePassword != "":
+		verify, scramble, hexHash = VerifyHashedMysqlNativePassword, ScrambleMysqlNativePassword, entry.MysqlNativePassword
+	default:
+		return subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1
+	}
+
+	hash, err := DecodePasswordHex(hexHash)
+	if err != nil {
+		return false
+	}
+	// Any salt verifies the password, by scrambling it like a client.
+	salt := make([]byte, 20)
+	return verify(scramble(salt, []byte(password)), salt, hash)
+}
+
 // AuthMethods returns the AuthMethod instances this auth server can handle.
 func (a *AuthServerStatic) AuthMethods() []AuthMethod {
 	return a.methods
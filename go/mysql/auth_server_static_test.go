@@ -326,3 +326,55 @@ func TestStaticCachingSha2Passwords(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticCachingSha2FullAuth(t *testing.T) {
+	_ = utils.LeakCheckContext(t)
+	jsonConfig := `
+{
+	"user01": [{ "Password": "user01" }],
+	"user02": [{
+		"CachingSha2Password": "*d2a47945c740b8ddc53f575733003b68961290d5224a4aedfdb57c8726bb3979"
+	}],
+	"user03": [{
+		"MysqlNativePassword": "*B3AD996B12F211BEA47A7C666CC136FB26DC96AF"
+	}]
+}`
+
+	auth := NewAuthServerStatic("", jsonConfig, 0)
+	defer auth.close()
+	ip := net.ParseIP("127.0.0.1")
+	addr := &net.IPAddr{IP: ip, Zone: ""}
+
+	// A mysql_native_password hash cannot verify the scrambled password,
+	// so the full authentication is requested.
+	salt, err := newSalt()
+	require.NoError(t, err)
+	_, status, err := auth.UserEntryWithCacheHash(nil, salt, "user03", ScrambleCachingSha2Password(salt, []byte("user02")), addr)
+	require.NoError(t, err)
+	require.Equal(t, AuthNeedMoreData, status)
+
+	tests := []struct {
+		user     string
+		password string
+		success  bool
+	}{
+		{"user01", "user01", true},
+		{"user01", "password", false},
+		{"user02", "user02", true},
+		{"user02", "password", false},
+		{"user03", "user02", true},
+		{"user03", "password", false},
+		{"user03", "", false},
+		{"userXX", "", false},
+	}
+	for _, c := range tests {
+		t.Run(fmt.Sprintf("%s-%s", c.user, c.password), func(t *testing.T) {
+			_, err := auth.UserEntryWithPassword(nil, c.user, c.password, addr)
+			if c.success {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
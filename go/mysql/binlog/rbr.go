@@ -554,13 +554,9 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 			// When the field is a DECIMAL using a scale of 0, e.g.
 			// DECIMAL(5,0), a binlogged value of 0 is almost treated
 			// like the NULL byte and we get a 0 byte length value.
-			// In this case let's return the correct value of 0.
-			if txt.Len() == 0 {
-				txt.WriteRune('0')
-			}
-
+			// The correct value of 0 is then returned by trimDecimalZeros.
 			return sqltypes.MakeTrusted(querypb.Type_DECIMAL,
-				txt.Bytes()), l, nil
+				trimDecimalZeros(txt.Bytes())), l, nil
 		}
 		txt.WriteByte('.')
 
@@ -575,8 +571,6 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 		switch dig2bytes[frac0x] {
 		case 0:
 			// Nothing to do
-			return sqltypes.MakeTrusted(querypb.Type_DECIMAL,
-				txt.Bytes()), l, nil
 		case 1:
 			// one byte, 1 or 2 digits
 			val = uint32(d[pos])
@@ -617,21 +611,7 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 			}
 		}
 
-		// remove preceding 0s from the integral part, otherwise we get "000000000001.23" instead of "1.23"
-		trimPrecedingZeroes := func(b []byte) []byte {
-			s := string(b)
-			isNegative := false
-			if s[0] == '-' {
-				isNegative = true
-				s = s[1:]
-			}
-			s = strings.TrimLeft(s, "0")
-			if isNegative {
-				s = fmt.Sprintf("-%s", s)
-			}
-			return []byte(s)
-		}
-		return sqltypes.MakeTrusted(querypb.Type_DECIMAL, trimPrecedingZeroes(txt.Bytes())), l, nil
+		return sqltypes.MakeTrusted(querypb.Type_DECIMAL, trimDecimalZeros(txt.Bytes())), l, nil
 
 	case TypeEnum:
 		switch metadata & 0xff {
@@ -793,3 +773,23 @@ func CellValue(data []byte, pos int, typ byte, metadata uint16, field *querypb.F
 		return sqltypes.NULL, 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unsupported type %v", typ)
 	}
 }
+
+// trimDecimalZeros removes the preceding 0s of the integral part of a decimal,
+// which are written by the groups of 9 digits, otherwise we get
+// "000000000001.23" instead of "1.23". As in MySQL, a 0 is kept before the
+// decimal point, so that we get "0.23" and "-0.23" instead of ".23" and "-.23".
+func trimDecimalZeros(b []byte) []byte {
+	negative := len(b) > 0 && b[0] == '-'
+	digits := b
+	if negative {
+		digits = b[1:]
+	}
+	digits = bytes.TrimLeft(digits, "0")
+	if len(digits) == 0 || digits[0] == '.' {
+		digits = append([]byte{'0'}, digits...)
+	}
+	if negative {
+		return append([]byte{'-'}, digits...)
+	}
+	return digits
+}
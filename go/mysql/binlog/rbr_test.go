@@ -474,6 +474,42 @@ func TestCellLengthAndData(t *testing.T) {
 		data:     []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0, 0x01, 0x0a},
 		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
 			[]byte("1.10")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 20<<8 | 9, // DECIMAL(20,9), without leftover fractional digits
+		data:     []byte{0x80, 0x00, 0x00, 0x00, 0x01, 0x1D, 0xCD, 0x65, 0x00},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("1.500000000")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 65<<8 | 0, // DECIMAL(65,0)
+		data:     []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("5")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 65<<8 | 0, // DECIMAL(65,0)
+		data:     []byte{0x1C, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00, 0xC4, 0x65, 0x36, 0x00},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("-99999999999999999999999999999999999999999999999999999999999999999")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 65<<8 | 30, // DECIMAL(65,30)
+		data:     []byte{0x80, 0xBC, 0x61, 0x4E, 0x35, 0xB7, 0xBF, 0x87, 0x35, 0x0E, 0x34, 0xC0, 0x2F, 0x07, 0x5F, 0x79, 0x07, 0x5B, 0xCD, 0x15, 0x00, 0xBC, 0x61, 0x4E, 0x35, 0xB7, 0xBF, 0x87, 0x03, 0x7A},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("12345678901234567890123456789012345.123456789012345678901234567890")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 5<<8 | 2, // DECIMAL(5,2)
+		data:     []byte{0x80, 0x00, 0x32},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("0.50")),
+	}, {
+		typ:      TypeNewDecimal,
+		metadata: 5<<8 | 2, // DECIMAL(5,2)
+		data:     []byte{0x7F, 0xFF, 0xCD},
+		out: sqltypes.MakeTrusted(querypb.Type_DECIMAL,
+			[]byte("-0.50")),
 	}, {
 		typ:      TypeBlob,
 		metadata: 1,
@@ -234,6 +234,13 @@ type TableMap struct {
 	// array position needs to be mapped to the ordered list of
 	// text based columns in the table.
 	ColumnCollationIDs []collations.ID
+
+	// EnumSetValues contains the string values of the ENUM and SET
+	// columns, by column index, when they are included in the optional
+	// metadata, i.e. with binlog_row_metadata=FULL. Unlike the values
+	// in the current schema of the table, these are the values of the
+	// columns when the event was written.
+	EnumSetValues map[int][]string
 }
 
 // Rows contains data from a {WRITE,UPDATE,DELETE}_ROWS_EVENT.
@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"hash/crc32"
 
+	"vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/replication"
 )
 
@@ -332,6 +333,7 @@ func NewTableMapEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, tm *T
 	}
 
 	metadataLength := metadataTotalLength(tm.Types)
+	optionalMetadata := enumSetValuesMetadata(tm)
 
 	length := 6 + // table_id
 		2 + // flags
@@ -345,7 +347,8 @@ func NewTableMapEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, tm *T
 		len(tm.Types) +
 		lenEncIntSize(uint64(metadataLength)) + // lenenc-str column-meta-def
 		metadataLength +
-		len(tm.CanBeNull.data)
+		len(tm.CanBeNull.data) +
+		len(optionalMetadata)
 	data := make([]byte, length)
 
 	data[0] = byte(tableID)
@@ -374,6 +377,7 @@ func NewTableMapEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, tm *T
 	}
 
 	pos += copy(data[pos:], tm.CanBeNull.data)
+	pos += copy(data[pos:], optionalMetadata)
 	if pos != len(data) {
 		panic("bad encoding")
 	}
@@ -382,6 +386,42 @@ func NewTableMapEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, tm *T
 	return NewMariadbBinlogEvent(ev)
 }
 
+// enumSetValuesMetadata returns the optional metadata fields of the string
+// values of the ENUM and SET columns of the table, if any.
+func enumSetValuesMetadata(tm *TableMap) []byte {
+	if len(tm.EnumSetValues) == 0 {
+		return nil
+	}
+	var metadata []byte
+	for _, field := range []struct {
+		fieldType uint8
+		realType  byte
+	}{{tableMapSetStrValue, binlog.TypeSet}, {tableMapEnumStrValue, binlog.TypeEnum}} {
+		var value []byte
+		for _, c := range enumSetColumns(tm, field.realType) {
+			values := tm.EnumSetValues[c]
+			value = appendLenEncInt(value, uint64(len(values)))
+			for _, v := range values {
+				value = appendLenEncInt(value, uint64(len(v)))
+				value = append(value, v...)
+			}
+		}
+		if len(value) == 0 {
+			continue
+		}
+		metadata = append(metadata, field.fieldType)
+		metadata = appendLenEncInt(metadata, uint64(len(value)))
+		metadata = append(metadata, value...)
+	}
+	return metadata
+}
+
+func appendLenEncInt(data []byte, i uint64) []byte {
+	buf := make([]byte, lenEncIntSize(i))
+	writeLenEncInt(buf, 0, i)
+	return append(data, buf...)
+}
+
 // NewWriteRowsEvent returns a WriteRows event. Uses v2.
 func NewWriteRowsEvent(f BinlogFormat, s *FakeBinlogStream, tableID uint64, rows Rows) BinlogEvent {
 	return newRowsEvent(f, s, eWriteRowsEventV2, tableID, rows)
@@ -246,6 +246,46 @@ func TestTableMapEvent(t *testing.T) {
 
 }
 
+func TestTableMapEventEnumSetValues(t *testing.T) {
+	f := NewMySQL56BinlogFormat()
+	s := NewFakeBinlogStream()
+
+	tm := &TableMap{
+		Flags:    0x8090,
+		Database: "my_database",
+		Name:     "my_table",
+		Types: []byte{
+			binlog.TypeLongLong,
+			binlog.TypeString,
+			binlog.TypeString,
+			binlog.TypeString,
+			binlog.TypeString,
+		},
+		CanBeNull: NewServerBitmap(5),
+		Metadata: []uint16{
+			0,
+			uint16(binlog.TypeEnum)<<8 | 1,
+			uint16(binlog.TypeString)<<8 | 40,
+			uint16(binlog.TypeSet)<<8 | 8,
+			uint16(binlog.TypeEnum)<<8 | 2,
+		},
+		ColumnCollationIDs: []collations.ID{},
+		EnumSetValues: map[int][]string{
+			1: {"red", "green", "blue"},
+			3: {"a", "b", "c"},
+			4: {"", "x,y", "\u00e9t\u00e9"},
+		},
+	}
+
+	event := NewTableMapEvent(f, s, 0x102030405060, tm)
+	event, _, err := event.StripChecksum(f)
+	require.NoError(t, err)
+
+	gotTm, err := event.TableMap(f)
+	require.NoError(t, err)
+	require.Equal(t, tm, gotTm)
+}
+
 func TestLargeTableMapEvent(t *testing.T) {
 	f := NewMySQL56BinlogFormat()
 	s := NewFakeBinlogStream()
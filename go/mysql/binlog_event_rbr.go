@@ -120,10 +120,9 @@ func (ev binlogEvent) TableMap(f BinlogFormat) (*TableMap, error) {
 	result.CanBeNull, read = newBitmap(data, pos, int(columnCount))
 	pos = read
 
-	// Read any text based column collation values provided in the optional metadata.
-	// The binlog_row_metadata only contains this info for text based columns.
-	var err error
-	if result.ColumnCollationIDs, err = readColumnCollationIDs(data, pos, int(columnCount)); err != nil {
+	// Read any text based column collation values, and ENUM and SET column
+	// string values, provided in the optional metadata.
+	if err := readOptionalMetadata(result, data, pos); err != nil {
 		return nil, err
 	}
 
@@ -222,30 +221,36 @@ func metadataWrite(data []byte, pos int, typ byte, value uint16) int {
 	}
 }
 
-// readColumnCollationIDs reads from the optional metadata that exists.
+// readOptionalMetadata reads from the optional metadata that exists.
 // See: https://github.com/mysql/mysql-server/blob/8.0/libbinlogevents/include/rows_event.h
 // What's included depends on the server configuration:
 // https://dev.mysql.com/doc/refman/en/replication-options-binary-log.html#sysvar_binlog_row_metadata
 // and the table definition.
-// We only care about any collation IDs in the optional metadata and
-// this info is provided in all binlog_row_metadata formats. Note that
-// this info is only provided for text based columns.
-func readColumnCollationIDs(data []byte, pos, count int) ([]collations.ID, error) {
-	collationIDs := make([]collations.ID, 0, count)
+// We only care about:
+//   - The collation IDs, which are provided in all binlog_row_metadata
+//     formats. Note that this info is only provided for text based columns.
+//   - The string values of the ENUM and SET columns, which are only provided
+//     with binlog_row_metadata=FULL.
+func readOptionalMetadata(tm *TableMap, data []byte, pos int) error {
+	collationIDs := make([]collations.ID, 0, len(tm.Types))
 	for pos < len(data) {
 		fieldType := uint8(data[pos])
 		pos++
 
 		fieldLen, read, ok := readLenEncInt(data, pos)
 		if !ok {
-			return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "error reading optional metadata field length")
+			return vterrors.New(vtrpcpb.Code_INTERNAL, "error reading optional metadata field length")
 		}
 		pos = read
+		if pos+int(fieldLen) > len(data) {
+			return vterrors.New(vtrpcpb.Code_INTERNAL, "error reading optional metadata field value")
+		}
 
 		fieldVal := data[pos : pos+int(fieldLen)]
 		pos += int(fieldLen)
 
-		if fieldType == tableMapDefaultCharset || fieldType == tableMapColumnCharset { // It's one or the other
+		switch fieldType {
+		case tableMapDefaultCharset, tableMapColumnCharset: // It's one or the other
 			for i := uint64(0); i < fieldLen; i++ {
 				v := uint16(fieldVal[i])
 				if v == readTwoByteCollationID { // The ID is the subsequent 2 bytes
@@ -254,9 +259,57 @@ func readColumnCollationIDs(data []byte, pos, count int) ([]collations.ID, error
 				}
 				collationIDs = append(collationIDs, collations.ID(v))
 			}
+		case tableMapEnumStrValue:
+			if err := readEnumSetValues(tm, fieldVal, binlog.TypeEnum); err != nil {
+				return err
+			}
+		case tableMapSetStrValue:
+			if err := readEnumSetValues(tm, fieldVal, binlog.TypeSet); err != nil {
+				return err
+			}
+		}
+	}
+	tm.ColumnCollationIDs = collationIDs
+	return nil
+}
+
+// readEnumSetValues reads the string values of the ENUM or SET columns of the
+// table, in the order of the columns, from the optional metadata field.
+func readEnumSetValues(tm *TableMap, data []byte, realType byte) error {
+	pos := 0
+	for _, c := range enumSetColumns(tm, realType) {
+		count, read, ok := readLenEncInt(data, pos)
+		if !ok {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading the number of string values of column %d", c)
+		}
+		pos = read
+		values := make([]string, 0, count)
+		for range count {
+			value, read, ok := readLenEncStringAsBytes(data, pos)
+			if !ok {
+				return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error reading the string values of column %d", c)
+			}
+			pos = read
+			values = append(values, string(value))
+		}
+		if tm.EnumSetValues == nil {
+			tm.EnumSetValues = make(map[int][]string)
+		}
+		tm.EnumSetValues[c] = values
+	}
+	return nil
+}
+
+// enumSetColumns returns the indexes of the ENUM or SET columns of the table,
+// which are logged as strings with their real type in the metadata.
+func enumSetColumns(tm *TableMap, realType byte) []int {
+	var cols []int
+	for c, typ := range tm.Types {
+		if typ == binlog.TypeString && byte(tm.Metadata[c]>>8) == realType {
+			cols = append(cols, c)
 		}
 	}
-	return collationIDs, nil
+	return cols
 }
 
 // Rows implements BinlogEvent.TableMap().
@@ -154,6 +154,16 @@ func TestMySQLVersionCapableOf(t *testing.T) {
 			capability: TransactionalGtidExecutedFlavorCapability,
 			isCapable:  false,
 		},
+		{
+			version:    "8.0.16",
+			capability: ClonePluginCapability,
+			isCapable:  false,
+		},
+		{
+			version:    "8.0.17",
+			capability: ClonePluginCapability,
+			isCapable:  true,
+		},
 		{
 			version:    "8.0.13",
 			capability: InnoDBParallelReadThreadsCapability,
@@ -174,7 +174,7 @@ func Connect(ctx context.Context, params *ConnParams) (*Conn, error) {
 // Ping implements mysql ping command.
 func (c *Conn) Ping() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComPing
 
@@ -229,6 +229,21 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 		c.Capabilities = capabilities & (CapabilityClientDeprecateEOF)
 	}
 
+	// Ask for the compressed protocol if the server supports the
+	// algorithm. The connection is not compressed otherwise.
+	switch {
+	case params.Compression == CompressionZstd && capabilities&CapabilityClientZstdCompressionAlgorithm != 0:
+		c.Capabilities |= CapabilityClientZstdCompressionAlgorithm
+	case params.Compression == CompressionZlib && capabilities&CapabilityClientCompress != 0:
+		c.Capabilities |= CapabilityClientCompress
+	}
+
+	// Answer the additional authentication factors if we have their
+	// passwords.
+	if params.Pass2 != "" && capabilities&CapabilityClientMultiFactorAuthentication != 0 {
+		c.Capabilities |= CapabilityClientMultiFactorAuthentication
+	}
+
 	// Handle switch to SSL if necessary.
 	if params.SslEnabled() {
 		// If client asked for SSL, but server doesn't support it,
@@ -305,6 +320,9 @@ func (c *Conn) clientHandshake(params *ConnParams) error {
 	if err := c.handleAuthResponse(params); err != nil {
 		return err
 	}
+	if algorithm := c.negotiatedCompression(); algorithm != "" {
+		c.enableCompression(algorithm, params.ZstdCompressionLevel)
+	}
 
 	// If the server didn't support DbName in its handshake, set
 	// it now. This is what the 'mysql' client does.
@@ -479,7 +497,11 @@ func (c *Conn) writeSSLRequest(capabilities uint32, characterSet uint8, params *
 		// CapabilityClientSessionTrack, we also support it.
 		c.Capabilities&CapabilityClientSessionTrack |
 		// Pass-through ClientFoundRows flag.
-		CapabilityClientFoundRows&uint32(params.Flags)
+		CapabilityClientFoundRows&uint32(params.Flags) |
+		// The compressed protocol, if we asked for it.
+		c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) |
+		// The multi-factor authentication, if we asked for it.
+		c.Capabilities&CapabilityClientMultiFactorAuthentication
 
 	length :=
 		4 + // Client capability flags.
@@ -537,7 +559,11 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		CapabilityClientFoundRows&uint32(params.Flags) |
 		// If the server supported
 		// CapabilityClientSessionTrack, we also support it.
-		c.Capabilities&CapabilityClientSessionTrack
+		c.Capabilities&CapabilityClientSessionTrack |
+		// The compressed protocol, if we asked for it.
+		c.Capabilities&(CapabilityClientCompress|CapabilityClientZstdCompressionAlgorithm) |
+		// The multi-factor authentication, if we asked for it.
+		c.Capabilities&CapabilityClientMultiFactorAuthentication
 
 	// FIXME(alainjobart) add multi statement.
 
@@ -564,6 +590,15 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 		length++
 	}
 
+	// The level of the zstd compression.
+	zstdLevel := params.ZstdCompressionLevel
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		if zstdLevel == 0 {
+			zstdLevel = DefaultZstdCompressionLevel
+		}
+		length++
+	}
+
 	data, pos := c.startEphemeralPacketWithHeader(length)
 
 	// Client capability flags.
@@ -600,6 +635,10 @@ func (c *Conn) writeHandshakeResponse41(capabilities uint32, scrambledPassword [
 	// Assume native client during response
 	pos = writeNullString(data, pos, string(c.authPluginName))
 
+	if capabilityFlags&CapabilityClientZstdCompressionAlgorithm != 0 {
+		pos = writeByte(data, pos, byte(zstdLevel))
+	}
+
 	// Sanity-check the length.
 	if pos != len(data) {
 		return sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "writeHandshakeResponse41: only packed %v bytes, out of %v allocated", pos, len(data))
@@ -633,6 +672,11 @@ func (c *Conn) handleAuthResponse(params *ConnParams) error {
 		if err := c.handleAuthMoreDataPacket(response[1], params); err != nil {
 			return err
 		}
+	case AuthNextFactorPacket:
+		// Server is asking for the next authentication factor
+		if err := c.handleAuthNextFactorPacket(params, response); err != nil {
+			return err
+		}
 	case ErrPacket:
 		return ParseErrorPacket(response)
 	default:
@@ -676,6 +720,17 @@ func (c *Conn) handleAuthSwitchPacket(params *ConnParams, response []byte) error
 	return c.handleAuthResponse(params)
 }
 
+// handleAuthNextFactorPacket answers the next authentication factor with the
+// password of that factor, like an auth switch with that password.
+func (c *Conn) handleAuthNextFactorPacket(params *ConnParams, response []byte) error {
+	if params.Pass2 == "" {
+		return sqlerror.NewSQLErrorf(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "server asked for an additional authentication factor, but no password is set for it")
+	}
+	factorParams := *params
+	factorParams.Pass, factorParams.Pass2, factorParams.Pass3 = params.Pass2, params.Pass3, ""
+	return c.handleAuthSwitchPacket(&factorParams, response)
+}
+
 // handleAuthMoreDataPacket handles response of CachingSha2Password authentication and sends full password to the
 // server if requested
 func (c *Conn) handleAuthMoreDataPacket(data byte, params *ConnParams) error {
@@ -734,7 +789,7 @@ func parseAuthSwitchRequest(data []byte) (AuthMethodDescription, []byte, error)
 func (c *Conn) requestPublicKey() (rsaKey *rsa.PublicKey, err error) {
 	// get public key from server
 	data, pos := c.startEphemeralPacketWithHeader(1)
-	data[pos] = 0x02
+	data[pos] = CachingSha2RequestPublicKey
 	if err := c.writeEphemeralPacket(); err != nil {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "error sending public key request packet: %v", err)
 	}
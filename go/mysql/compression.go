@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the compressed protocol, negotiated with
+// CapabilityClientCompress for zlib or CapabilityClientZstdCompressionAlgorithm
+// for zstd. Once the handshake is done, the packets of both sides are carried
+// in compressed packets, which have a header of their own:
+// - the length of their payload, 3 bytes.
+// - their sequence, 1 byte. It is reset with the sequence of the packets at
+//   the start of each command.
+// - the length of their payload once uncompressed, 3 bytes. It is 0 if the
+//   payload is not compressed.
+// The payload of a compressed packet can carry many packets, or part of one.
+
+const (
+	// CompressionZlib is the zlib algorithm of the compressed protocol.
+	CompressionZlib = "zlib"
+
+	// CompressionZstd is the zstd algorithm of the compressed protocol.
+	CompressionZstd = "zstd"
+
+	// DefaultZstdCompressionLevel is the level of the zstd compression of
+	// the connections which do not ask for one.
+	DefaultZstdCompressionLevel = 3
+
+	// compressedHeaderSize is the size of the header of a compressed packet.
+	compressedHeaderSize = 7
+
+	// minCompressLength is the MIN_COMPRESS_LENGTH of MySQL: the payloads
+	// shorter than it are not compressed.
+	minCompressLength = 50
+)
+
+// zstdEncoders are the zstd encoders of the connections, by level. The
+// encoders are safe for concurrent use with EncodeAll.
+var zstdEncoders sync.Map
+
+// zstdDecoder decodes the zstd payloads of all the connections. It is safe
+// for concurrent use with DecodeAll.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxPacketSize))
+})
+
+func zstdEncoder(level int) (*zstd.Encoder, error) {
+	if enc, ok := zstdEncoders.Load(level); ok {
+		return enc.(*zstd.Encoder), nil
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)), zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := zstdEncoders.LoadOrStore(level, enc)
+	return actual.(*zstd.Encoder), nil
+}
+
+// compressedConn carries the packets of a connection in compressed packets.
+// It reads the compressed packets from r and writes them to w.
+type compressedConn struct {
+	algorithm string
+	zstdLevel int
+
+	r io.Reader
+	w io.Writer
+
+	// sequence is the sequence of the next compressed packet.
+	sequence uint8
+	header   [compressedHeaderSize]byte
+
+	// payload is the payload of the last compressed packet read, once
+	// uncompressed, and pos the position of the next byte to read in it.
+	payload []byte
+	pos     int
+
+	// readBuf and uncompressedBuf are the buffers of the payloads read,
+	// and writeBuf the one of the compressed packets written.
+	readBuf         []byte
+	uncompressedBuf []byte
+	writeBuf        []byte
+
+	zlibReader io.ReadCloser
+	zlibWriter *zlib.Writer
+}
+
+// enableCompression makes the connection use the compressed protocol with
+// the given algorithm, once the handshake is done.
+func (c *Conn) enableCompression(algorithm string, zstdLevel int) {
+	if zstdLevel == 0 {
+		zstdLevel = DefaultZstdCompressionLevel
+	}
+	c.compressed = &compressedConn{
+		algorithm: algorithm,
+		zstdLevel: zstdLevel,
+		r:         c.getReader(),
+		w:         c.conn,
+	}
+}
+
+// Compression returns the algorithm of the compressed protocol used by the
+// connection, or an empty string if it is not compressed.
+func (c *Conn) Compression() string {
+	if c.compressed == nil {
+		return ""
+	}
+	return c.compressed.algorithm
+}
+
+// negotiatedCompression returns the algorithm of the compressed protocol
+// negotiated by the handshake, zstd if the client asked for both, or an empty
+// string if the connection is not compressed.
+func (c *Conn) negotiatedCompression() string {
+	switch {
+	case c.Capabilities&CapabilityClientZstdCompressionAlgorithm != 0:
+		return CompressionZstd
+	case c.Capabilities&CapabilityClientCompress != 0:
+		return CompressionZlib
+	}
+	return ""
+}
+
+// resetSequence resets the sequence of the packets, and of the compressed
+// packets if the connection is compressed, at the start of a new command.
+func (c *Conn) resetSequence() {
+	c.sequence = 0
+	if c.compressed != nil {
+		c.compressed.sequence = 0
+	}
+}
+
+// Read is part of the io.Reader interface. It reads the packets carried by
+// the compressed packets.
+func (cc *compressedConn) Read(p []byte) (int, error) {
+	for cc.pos == len(cc.payload) {
+		if err := cc.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cc.payload[cc.pos:])
+	cc.pos += n
+	return n, nil
+}
+
+func (cc *compressedConn) readCompressedPacket() error {
+	// io.EOF is returned as is, like by readHeaderFrom, for the server not to
+	// log the clients which disconnect.
+	if _, err := io.ReadFull(cc.r, cc.header[:]); err != nil {
+		return err
+	}
+	if sequence := cc.header[3]; sequence != cc.sequence {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid compressed sequence, expected %v got %v", cc.sequence, sequence)
+	}
+	cc.sequence++
+
+	length := int(uint32(cc.header[0]) | uint32(cc.header[1])<<8 | uint32(cc.header[2])<<16)
+	uncompressedLength := int(uint32(cc.header[4]) | uint32(cc.header[5])<<8 | uint32(cc.header[6])<<16)
+	cc.readBuf = slices.Grow(cc.readBuf[:0], length)[:length]
+	if _, err := io.ReadFull(cc.r, cc.readBuf); err != nil {
+		return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", length)
+	}
+	cc.pos = 0
+	if uncompressedLength == 0 {
+		cc.payload = cc.readBuf
+		return nil
+	}
+
+	var err error
+	switch cc.algorithm {
+	case CompressionZstd:
+		var dec *zstd.Decoder
+		if dec, err = zstdDecoder(); err == nil {
+			cc.uncompressedBuf, err = dec.DecodeAll(cc.readBuf, cc.uncompressedBuf[:0])
+		}
+	default:
+		cc.uncompressedBuf = slices.Grow(cc.uncompressedBuf[:0], uncompressedLength)[:uncompressedLength]
+		if cc.zlibReader == nil {
+			cc.zlibReader, err = zlib.NewReader(bytes.NewReader(cc.readBuf))
+		} else {
+			err = cc.zlibReader.(zlib.Resetter).Reset(bytes.NewReader(cc.readBuf), nil)
+		}
+		if err == nil {
+			_, err = io.ReadFull(cc.zlibReader, cc.uncompressedBuf)
+		}
+	}
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot uncompress %s packet", cc.algorithm)
+	}
+	if len(cc.uncompressedBuf) != uncompressedLength {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "uncompressed packet of length %v, expected %v", len(cc.uncompressedBuf), uncompressedLength)
+	}
+	cc.payload = cc.uncompressedBuf
+	return nil
+}
+
+// Write is part of the io.Writer interface. It writes the packets in
+// compressed packets, one for each MaxPacketSize bytes of p.
+func (cc *compressedConn) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		n := min(len(p)-written, MaxPacketSize)
+		if err := cc.writeCompressedPacket(p[written : written+n]); err != nil {
+			return written, err
+		}
+		written += n
+	}
+	return written, nil
+}
+
+func (cc *compressedConn) writeCompressedPacket(data []byte) error {
+	var header [compressedHeaderSize]byte
+	buf := append(cc.writeBuf[:0], header[:]...)
+	uncompressedLength := 0
+	if len(data) >= minCompressLength {
+		compressed, err := cc.compress(buf, data)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot compress %s packet", cc.algorithm)
+		}
+		// The payload is sent as is if it does not shrink.
+		if len(compressed)-compressedHeaderSize < len(data) {
+			buf = compressed
+			uncompressedLength = len(data)
+		}
+	}
+	if uncompressedLength == 0 {
+		buf = append(buf[:compressedHeaderSize], data...)
+	}
+	cc.writeBuf = buf
+
+	length := len(buf) - compressedHeaderSize
+	buf[0] = byte(length)
+	buf[1] = byte(length >> 8)
+	buf[2] = byte(length >> 16)
+	buf[3] = cc.sequence
+	buf[4] = byte(uncompressedLength)
+	buf[5] = byte(uncompressedLength >> 8)
+	buf[6] = byte(uncompressedLength >> 16)
+	if n, err := cc.w.Write(buf); err != nil {
+		return vterrors.Wrapf(err, "Write(compressed packet) failed")
+	} else if n != len(buf) {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(compressed packet) returned a short write: %v < %v", n, len(buf))
+	}
+	cc.sequence++
+	return nil
+}
+
+// compress appends the compressed data to dst.
+func (cc *compressedConn) compress(dst, data []byte) ([]byte, error) {
+	if cc.algorithm == CompressionZstd {
+		enc, err := zstdEncoder(cc.zstdLevel)
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(data, dst), nil
+	}
+
+	out := bytes.NewBuffer(dst)
+	if cc.zlibWriter == nil {
+		cc.zlibWriter = zlib.NewWriter(out)
+	} else {
+		cc.zlibWriter.Reset(out)
+	}
+	if _, err := cc.zlibWriter.Write(data); err != nil {
+		return nil, err
+	}
+	if err := cc.zlibWriter.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestCompressedPackets(t *testing.T) {
+	for _, algorithm := range []string{CompressionZlib, CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			listener, sConn, cConn := createSocketPair(t)
+			defer func() {
+				listener.Close()
+				sConn.Close()
+				cConn.Close()
+			}()
+			sConn.enableCompression(algorithm, 0)
+			cConn.enableCompression(algorithm, 0)
+
+			// Short packets are sent as is, others compressed, and the
+			// packets of MaxPacketSize or more span many compressed
+			// packets.
+			for _, data := range [][]byte{
+				{},
+				[]byte("short"),
+				bytes.Repeat([]byte("compressed"), 1000),
+				bytes.Repeat([]byte{0xab}, MaxPacketSize),
+				bytes.Repeat([]byte("large"), MaxPacketSize/4),
+			} {
+				verifyPacketCommsSpecific(t, cConn, data, useWritePacket, sConn.ReadPacket)
+				verifyPacketCommsSpecific(t, cConn, data, useWriteEphemeralPacketBuffered, sConn.ReadPacket)
+				verifyPacketCommsSpecific(t, cConn, data, useWriteEphemeralPacketDirect, sConn.readEphemeralPacket)
+				sConn.recycleReadPacket()
+			}
+		})
+	}
+}
+
+func TestCompressedPacketsSequence(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.enableCompression(CompressionZlib, 0)
+	cConn.enableCompression(CompressionZlib, 0)
+
+	// The server expects the compressed packets of a new command.
+	cConn.compressed.sequence = 3
+	go cConn.writePacket(append(make([]byte, packetHeaderSize), "select 1"...))
+	_, err := sConn.ReadPacket()
+	assert.ErrorContains(t, err, "invalid compressed sequence, expected 0 got 3")
+}
+
+func TestServerCompression(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name:    "name",
+			Type:    querypb.Type_VARCHAR,
+			Charset: uint32(collations.CollationUtf8mb4ID),
+		}},
+	}
+	for i := range 10000 {
+		result.Rows = append(result.Rows, []sqltypes.Value{
+			sqltypes.MakeTrusted(querypb.Type_VARCHAR, []byte(fmt.Sprintf("a rather long name for the row number %d", i))),
+		})
+	}
+	th := &testHandler{result: result}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["user1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	tcases := []struct {
+		listenerCompression bool
+		compression         string
+		zstdLevel           int
+		want                string
+	}{
+		{false, "", 0, ""},
+		{false, CompressionZstd, 0, ""},
+		{true, "", 0, ""},
+		{true, CompressionZlib, 0, CompressionZlib},
+		{true, CompressionZstd, 0, CompressionZstd},
+		{true, CompressionZstd, 19, CompressionZstd},
+	}
+	for _, tcase := range tcases {
+		t.Run(fmt.Sprintf("%v/%s/%d", tcase.listenerCompression, tcase.compression, tcase.zstdLevel), func(t *testing.T) {
+			l.Compression.Store(tcase.listenerCompression)
+			params := *params
+			params.Compression = tcase.compression
+			params.ZstdCompressionLevel = tcase.zstdLevel
+
+			c, err := Connect(ctx, &params)
+			require.NoError(t, err)
+			defer c.Close()
+			assert.Equal(t, tcase.want, c.Compression())
+
+			// Many commands, each with a result spanning many
+			// compressed packets.
+			for range 3 {
+				qr, err := c.ExecuteFetch("select name from t", 100000, true)
+				require.NoError(t, err)
+				assert.True(t, result.Equal(qr))
+			}
+
+			// The server uses the level asked for by the client.
+			serverConn := th.LastConn()
+			assert.Equal(t, tcase.want, serverConn.Compression())
+			if tcase.want == CompressionZstd {
+				assert.Equal(t, max(tcase.zstdLevel, DefaultZstdCompressionLevel), serverConn.compressed.zstdLevel)
+			}
+			if tcase.want != "" {
+				assert.EqualValues(t, 1, connCompressed.Counts()[tcase.want])
+			}
+
+			c.Close()
+			if tcase.want != "" {
+				assert.Eventually(t, func() bool {
+					return connCompressed.Counts()[tcase.want] == 0
+				}, time.Second, 10*time.Millisecond)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package mysql
 
 import (
 	"bufio"
+	"container/list"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -143,11 +144,23 @@ type Conn struct {
 	// It is set during the initial handshake.
 	UserData Getter
 
+	// ConnectAttributes are the connection attributes sent by the client
+	// during the initial handshake, like program_name or _client_version.
+	// It is only used by the server, and nil if the client sent none.
+	ConnectAttributes map[string]string
+
 	bufferedReader *bufio.Reader
 	flushTimer     *time.Timer
 	flushDelay     time.Duration
 	header         [packetHeaderSize]byte
 
+	// compressed carries the packets of the connection in compressed
+	// packets, once the handshake negotiated the compressed protocol.
+	compressed *compressedConn
+	// zstdCompressionLevel is the level of the zstd compression asked for by
+	// the client in its handshake, if any.
+	zstdCompressionLevel int
+
 	// Keep track of how and of the buffer we allocated for an
 	// ephemeral packet on the read and write sides.
 	// These fields are used by:
@@ -165,6 +178,16 @@ type Conn struct {
 
 	// PrepareData is the map to use a prepared statement.
 	PrepareData map[uint32]*PrepareData
+	// cursor is the read-only cursor opened by the last COM_STMT_EXECUTE,
+	// if its rows were not all fetched yet.
+	cursor *cursor
+	// preparedLRU orders the prepared statements from the most to the least
+	// recently used, to evict the latter when the connection reaches the
+	// MaxPreparedStatements of its listener.
+	preparedLRU *list.List
+	// queryAttributes are the query attributes of the COM_QUERY being
+	// executed, if the client sent any.
+	queryAttributes map[string]string
 
 	// protects the bufferedWriter and bufferedReader
 	bufMu sync.Mutex
@@ -174,8 +197,8 @@ type Conn struct {
 	// the client and the server, and currently in use.
 	// It is set during the initial handshake.
 	//
-	// It is only used for CapabilityClientDeprecateEOF
-	// and CapabilityClientFoundRows.
+	// It is only used for CapabilityClientDeprecateEOF,
+	// CapabilityClientFoundRows and CapabilityClientQueryAttributes.
 	Capabilities uint32
 
 	// closed is set to true when Close() is called on the connection.
@@ -218,6 +241,11 @@ type Conn struct {
 	// See: ConnParams.EnableQueryInfo
 	enableQueryInfo bool
 
+	// idleSince is when the server connection was done with its last
+	// command, or with the handshake, in unix nanoseconds. It is 0 while the
+	// connection runs a command.
+	idleSince atomic.Int64
+
 	// keepAliveOn marks when keep alive is active on the connection.
 	// This is currently used for testing.
 	keepAliveOn bool
@@ -230,6 +258,9 @@ type Conn struct {
 	// this is used to mark the connection to be closed so that the command phase for the connection can be stopped and
 	// the connection gets closed.
 	closing bool
+	// idleCloseErr is the error sent to the client of a server connection
+	// closed by CloseIfIdle, once it stops waiting for the next command.
+	idleCloseErr *sqlerror.SQLError
 
 	truncateErrLen int
 }
@@ -242,6 +273,19 @@ type PrepareData struct {
 	BindVars    map[string]*querypb.BindVariable
 	StatementID uint32
 	ParamsCount uint16
+	// Cursor is set when the statement is executed to open a read-only
+	// cursor: the handler should stream the rows of the result, which are
+	// fetched by the client with COM_STMT_FETCH.
+	Cursor bool
+	// QueryAttributes are the query attributes sent by the client with
+	// the execution of the statement, if any.
+	QueryAttributes map[string]string
+
+	// elem is the element of the statement in the preparedLRU of its connection.
+	elem *list.Element
+	// longDataErr is the error of a COM_STMT_SEND_LONG_DATA, which has no
+	// response: it is returned by the next COM_STMT_EXECUTE.
+	longDataErr error
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -338,7 +382,7 @@ func (c *Conn) startWriterBuffering() {
 	defer c.bufMu.Unlock()
 
 	c.bufferedWriter = writersPool.Get().(*bufio.Writer)
-	c.bufferedWriter.Reset(c.conn)
+	c.bufferedWriter.Reset(c.getWriter())
 }
 
 // endWriterBuffering must be called to terminate startWriteBuffering.
@@ -386,14 +430,27 @@ func (c *Conn) startFlushTimer() {
 }
 
 // getReader returns reader for connection. It can be *bufio.Reader or net.Conn
-// depending on which buffer size was passed to newServerConn.
+// depending on which buffer size was passed to newServerConn, or the reader of
+// the compressed packets if the connection is compressed.
 func (c *Conn) getReader() io.Reader {
+	if c.compressed != nil {
+		return c.compressed
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader
 	}
 	return c.conn
 }
 
+// getWriter returns the unbuffered writer for connection, which is the writer
+// of the compressed packets if the connection is compressed.
+func (c *Conn) getWriter() io.Writer {
+	if c.compressed != nil {
+		return c.compressed
+	}
+	return c.conn
+}
+
 func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	// Note io.ReadFull will return two different types of errors:
 	// 1. if the socket is already closed, and the go runtime knows it,
@@ -415,7 +472,12 @@ func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	}
 
 	sequence := c.header[3]
-	if sequence != c.sequence {
+	if c.compressed != nil {
+		// MySQL syncs the sequence of the packets with the one of the
+		// compressed packets, which is checked instead, when a packet
+		// spans many compressed packets.
+		c.sequence = sequence
+	} else if sequence != c.sequence {
 		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
 	}
 
@@ -626,7 +688,7 @@ func (c *Conn) writePacket(data []byte) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.getWriter()
 	}
 
 	var header [packetHeaderSize]byte
@@ -730,7 +792,7 @@ func (c *Conn) recycleWritePacket() {
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) writeComQuit() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComQuit
@@ -803,7 +865,12 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	length += 4 // status_flags + warnings
 
 	hasSessionTrack := c.Capabilities&CapabilityClientSessionTrack == CapabilityClientSessionTrack
-	hasGtidData := hasSessionTrack && packetOk.statusFlags&ServerSessionStateChanged == ServerSessionStateChanged
+	statusFlags := packetOk.statusFlags
+	if hasSessionTrack && packetOk.sessionStateData != "" {
+		// The session state changes are the GTIDs tracked by the session.
+		statusFlags |= ServerSessionStateChanged
+	}
+	hasGtidData := hasSessionTrack && statusFlags&ServerSessionStateChanged == ServerSessionStateChanged
 
 	var gtidData []byte
 
@@ -822,7 +889,7 @@ func (c *Conn) writeOKPacketWithHeader(packetOk *PacketOK, headerType byte) erro
 	data.writeByte(headerType) // header - OK or EOF
 	data.writeLenEncInt(packetOk.affectedRows)
 	data.writeLenEncInt(packetOk.lastInsertID)
-	data.writeUint16(packetOk.statusFlags)
+	data.writeUint16(statusFlags)
 	data.writeUint16(packetOk.warnings)
 	if hasSessionTrack {
 		data.writeLenEncString(packetOk.info)
@@ -903,8 +970,11 @@ func (c *Conn) writeEOFPacket(flags uint16, warnings uint16) error {
 // handleNextCommand is called in the server loop to process
 // incoming packets.
 func (c *Conn) handleNextCommand(handler Handler) bool {
-	c.sequence = 0
+	c.resetSequence()
 	data, err := c.readEphemeralPacket()
+	if c.closeIdle() {
+		return false
+	}
 	if err != nil {
 		// Don't log EOF errors. They cause too much spam.
 		if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
@@ -920,6 +990,10 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		return false
 	}
 
+	if c.cursor != nil && c.closesCursor(data) {
+		c.closeCursor()
+	}
+
 	switch data[0] {
 	case ComQuit:
 		c.recycleReadPacket()
@@ -948,10 +1022,12 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		stmtID, ok := c.parseComStmtClose(data)
 		c.recycleReadPacket()
 		if ok {
-			delete(c.PrepareData, stmtID)
+			c.removePrepareData(stmtID)
 		}
 	case ComStmtReset:
 		return c.handleComStmtReset(data)
+	case ComStmtFetch:
+		return c.handleComStmtFetch(data)
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
@@ -1048,36 +1124,30 @@ func (c *Conn) handleComResetConnection(handler Handler) {
 	c.recycleReadPacket()
 	handler.ComResetConnection(c)
 	// Reset prepared statements
-	c.PrepareData = make(map[uint32]*PrepareData)
+	c.clearPrepareData()
 	err := c.writeOKPacket(&PacketOK{})
 	if err != nil {
 		c.writeErrorPacketFromError(err)
 	}
 }
 
+// handleComStmtReset discards the parameter values sent with
+// COM_STMT_SEND_LONG_DATA since the last execution of the statement.
 func (c *Conn) handleComStmtReset(data []byte) bool {
 	stmtID, ok := c.parseComStmtReset(data)
 	c.recycleReadPacket()
 	if !ok {
-		log.Error("Got unhandled packet from client %v, returning error: %v", c.ConnectionID, data)
-		if !c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "error handling packet: %v", data) {
-			return false
-		}
+		log.Errorf("Got unhandled packet from client %v, returning error: %v", c.ConnectionID, data)
+		return c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "error handling packet: %v", data)
 	}
 
-	prepare, ok := c.PrepareData[stmtID]
+	prepare, ok := c.getPrepareData(stmtID)
 	if !ok {
-		log.Error("Commands were executed in an improper order from client %v, packet: %v", c.ConnectionID, data)
-		if !c.writeErrorAndLog(sqlerror.CRCommandsOutOfSync, sqlerror.SSNetError, "commands were executed in an improper order: %v", data) {
-			return false
-		}
+		return c.writeErrorAndLog(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%d) given to mysqld_stmt_reset", stmtID)
 	}
 
-	if prepare.BindVars != nil {
-		for k := range prepare.BindVars {
-			prepare.BindVars[k] = nil
-		}
-	}
+	prepare.BindVars = make(map[string]*querypb.BindVariable, prepare.ParamsCount)
+	prepare.longDataErr = nil
 
 	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
 		log.Error("Error writing ComStmtReset OK packet to client %v: %v", c.ConnectionID, err)
@@ -1086,25 +1156,31 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 	return true
 }
 
+// handleComStmtSendLongData appends a chunk to the value of a parameter of a
+// prepared statement. The command has no response, even on errors: those are
+// returned by the next COM_STMT_EXECUTE of the statement, and the command is
+// ignored if the statement is unknown, as MySQL does.
 func (c *Conn) handleComStmtSendLongData(data []byte) bool {
 	stmtID, paramID, chunk, ok := c.parseComStmtSendLongData(data)
 	c.recycleReadPacket()
 	if !ok {
-		err := fmt.Errorf("error parsing statement send long data from client %v, returning error: %v", c.ConnectionID, data)
-		return c.writeErrorPacketFromErrorAndLog(err)
+		log.Errorf("Error parsing statement send long data from client %v: %v", c.ConnectionID, data)
+		return true
 	}
 
-	prepare, ok := c.PrepareData[stmtID]
+	prepare, ok := c.getPrepareData(stmtID)
 	if !ok {
-		err := fmt.Errorf("got wrong statement id from client %v, statement ID(%v) is not found from record", c.ConnectionID, stmtID)
-		return c.writeErrorPacketFromErrorAndLog(err)
+		log.Warningf("Got send long data from client %v for unknown statement ID %v, ignoring it", c.ConnectionID, stmtID)
+		return true
 	}
 
 	if prepare.BindVars == nil ||
 		prepare.ParamsCount == uint16(0) ||
 		paramID >= prepare.ParamsCount {
-		err := fmt.Errorf("invalid parameter Number from client %v, statement: %v", c.ConnectionID, prepare.PrepareStmt)
-		return c.writeErrorPacketFromErrorAndLog(err)
+		if prepare.longDataErr == nil {
+			prepare.longDataErr = sqlerror.NewSQLErrorf(sqlerror.ERWrongArguments, sqlerror.SSUnknownSQLState, "Incorrect arguments to mysqld_stmt_send_long_data: parameter %d of statement %d", paramID, stmtID)
+		}
+		return true
 	}
 
 	key := fmt.Sprintf("v%d", paramID+1)
@@ -1125,25 +1201,34 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		}
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, cursorType, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
 
-	if stmtID != uint32(0) {
+	prepare, _ := c.getPrepareData(stmtID)
+	if prepare != nil {
 		defer func() {
 			// Allocate a new bindvar map every time since VTGate.Execute() mutates it.
-			prepare := c.PrepareData[stmtID]
 			prepare.BindVars = make(map[string]*querypb.BindVariable, prepare.ParamsCount)
+			prepare.QueryAttributes = nil
+			prepare.longDataErr = nil
 		}()
+		if err == nil {
+			err = prepare.longDataErr
+		}
 	}
 
 	if err != nil {
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
+	if cursorType&CursorTypeReadOnly != 0 {
+		defer timings.Record(queryTimingKey, queryStart)
+		return c.openCursor(handler, prepare)
+	}
+
 	receivedResult := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
-	prepare := c.PrepareData[stmtID]
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1245,7 +1330,7 @@ func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 		ParamsType:  make([]int32, paramsCount),
 		BindVars:    make(map[string]*querypb.BindVariable, paramsCount),
 	}
-	c.PrepareData[c.StatementID] = prepare
+	c.addPrepareData(prepare)
 
 	if err := c.writePrepare(fld, prepare); err != nil {
 		log.Error("Error writing prepare data to client %v: %v", c.ConnectionID, err)
@@ -1254,6 +1339,61 @@ func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 	return true
 }
 
+// addPrepareData registers a new prepared statement of the connection. The
+// least recently used statements are evicted if the connection exceeds the
+// MaxPreparedStatements of its listener.
+func (c *Conn) addPrepareData(prepare *PrepareData) {
+	if c.preparedLRU == nil {
+		c.preparedLRU = list.New()
+	}
+	c.PrepareData[prepare.StatementID] = prepare
+	prepare.elem = c.preparedLRU.PushFront(prepare)
+	preparedStmtCount.Add(1)
+
+	if c.listener == nil {
+		return
+	}
+	maxStmts := c.listener.MaxPreparedStatements.Load()
+	for maxStmts > 0 && int64(c.preparedLRU.Len()) > maxStmts {
+		evicted := c.preparedLRU.Back().Value.(*PrepareData)
+		c.removePrepareData(evicted.StatementID)
+		preparedStmtEvicted.Add(1)
+	}
+}
+
+// getPrepareData returns the prepared statement with the ID, and marks it as
+// the most recently used.
+func (c *Conn) getPrepareData(stmtID uint32) (*PrepareData, bool) {
+	prepare, ok := c.PrepareData[stmtID]
+	if ok && prepare.elem != nil {
+		c.preparedLRU.MoveToFront(prepare.elem)
+	}
+	return prepare, ok
+}
+
+// removePrepareData removes the prepared statement with the ID, if any.
+func (c *Conn) removePrepareData(stmtID uint32) {
+	prepare, ok := c.PrepareData[stmtID]
+	if !ok {
+		return
+	}
+	delete(c.PrepareData, stmtID)
+	if prepare.elem != nil {
+		c.preparedLRU.Remove(prepare.elem)
+		prepare.elem = nil
+		preparedStmtCount.Add(-1)
+	}
+}
+
+// clearPrepareData removes all the prepared statements of the connection.
+func (c *Conn) clearPrepareData() {
+	if c.preparedLRU != nil {
+		preparedStmtCount.Add(-int64(c.preparedLRU.Len()))
+		c.preparedLRU.Init()
+	}
+	c.PrepareData = make(map[uint32]*PrepareData)
+}
+
 func (c *Conn) handleComSetOption(data []byte) bool {
 	operation, ok := c.parseComSetOption(data)
 	c.recycleReadPacket()
@@ -1311,8 +1451,14 @@ func (c *Conn) handleComQueryMulti(handler Handler, data []byte) (kontinue bool)
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+	defer func() {
+		c.queryAttributes = nil
+	}()
 
 	res := c.execQueryMulti(query, handler)
 	if res != execSuccess {
@@ -1334,13 +1480,31 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 	needsEndPacket := false
 	callbackCalled := false
 	var res = execSuccess
+	// moreResults are the result sets following the result of a query, for
+	// the statements returning several of them such as a CALL. They are sent
+	// once the result of the query is, with moreAfter telling whether more
+	// results follow them.
+	var moreResults []*sqltypes.Result
+	moreAfter := false
+	writeMoreResults := func() error {
+		for i, qr := range moreResults {
+			if err := c.writeResultSet(qr, moreAfter || i < len(moreResults)-1, handler.WarningCount(c)); err != nil {
+				return err
+			}
+		}
+		moreResults = nil
+		return nil
+	}
+
+	// setEnded is set when the last packet ended a result set followed by
+	// another one of the same query, which is how the result sets of a CALL
+	// are delimited when they are streamed.
+	setEnded := false
 
 	err := handler.ComQueryMulti(c, query, func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error {
 		callbackCalled = true
-		flag := c.StatusFlags
-		if more {
-			flag |= ServerMoreResultsExists
-		}
+		newSet := firstPacket || setEnded
+		setEnded = false
 
 		// firstPacket tells us that this is the start of a new query result.
 		// If we haven't sent a last packet yet, we should send the end result packet.
@@ -1350,6 +1514,15 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 				return err
 			}
 		}
+		if firstPacket {
+			if err := writeMoreResults(); err != nil {
+				log.Errorf("Error writing result to %s: %v", c, err)
+				return err
+			}
+			if qr.QueryResult != nil {
+				moreResults, moreAfter = qr.QueryResult.MoreResults, more
+			}
+		}
 
 		// We receive execution errors in a query as part of the QueryResponse.
 		// We check for those errors and send a error packet. If we are unable
@@ -1362,7 +1535,13 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 			return nil
 		}
 
-		if firstPacket {
+		ended := qr.QueryResult.IsMoreResultsExists()
+		flag := c.StatusFlags
+		if more || len(moreResults) > 0 || ended {
+			flag |= ServerMoreResultsExists
+		}
+
+		if newSet {
 			// The first packet signifies the start of a new query result.
 			// So we reset the needsEndPacket variable to signify we haven't sent the last
 			// packet for this query.
@@ -1384,6 +1563,7 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 					sessionStateData: qr.QueryResult.SessionStateChanges,
 				}
 				needsEndPacket = false
+				setEnded = ended
 				return c.writeOKPacket(&ok)
 			}
 
@@ -1392,7 +1572,15 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 			}
 		}
 
-		return c.writeRows(qr.QueryResult)
+		if err := c.writeRows(qr.QueryResult); err != nil {
+			return err
+		}
+		if ended {
+			needsEndPacket = false
+			setEnded = true
+			return c.writeEndResult(true, 0, 0, handler.WarningCount(c))
+		}
+		return nil
 	})
 
 	// If callback was not called, we expect an error.
@@ -1424,11 +1612,15 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 
 	// If we haven't sent the final packet for the last query, we should send that too.
 	if needsEndPacket {
-		if err := c.writeEndResult(false, 0, 0, handler.WarningCount(c)); err != nil {
+		if err := c.writeEndResult(len(moreResults) > 0, 0, 0, handler.WarningCount(c)); err != nil {
 			log.Errorf("Error writing result to %s: %v", c, err)
 			return connErr
 		}
 	}
+	if err := writeMoreResults(); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return connErr
+	}
 
 	return execSuccess
 }
@@ -1445,11 +1637,16 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+	defer func() {
+		c.queryAttributes = nil
+	}()
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = handler.Env().Parser().SplitStatementToPieces(query)
 		if err != nil {
@@ -1483,22 +1680,35 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 	callbackCalled := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
+	// fieldsSent is set while the rows of a result set are being sent, once
+	// its fields are.
+	fieldsSent := false
+	// moreResults are the result sets following the first one, for the
+	// statements returning several of them such as a CALL.
+	var moreResults []*sqltypes.Result
 
 	err := handler.ComQuery(c, query, func(qr *sqltypes.Result) error {
-		flag := c.StatusFlags
-		if more {
-			flag |= ServerMoreResultsExists
-		}
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
 			return io.EOF
 		}
-
 		if !callbackCalled {
-			callbackCalled = true
+			moreResults = qr.MoreResults
+		}
+		callbackCalled = true
 
+		// A packet with SERVER_MORE_RESULTS_EXISTS ends a result set followed
+		// by another one, which is how the result sets of a CALL are
+		// delimited when they are streamed.
+		ended := qr.IsMoreResultsExists()
+		flag := c.StatusFlags
+		if more || len(moreResults) > 0 || ended {
+			flag |= ServerMoreResultsExists
+		}
+
+		if !fieldsSent {
 			if len(qr.Fields) == 0 {
-				sendFinished = true
+				sendFinished = !ended
 
 				// A successful callback with no fields means that this was a
 				// DML or other write-only operation.
@@ -1519,9 +1729,17 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 			if err := c.writeFields(qr); err != nil {
 				return err
 			}
+			fieldsSent = true
 		}
 
-		return c.writeRows(qr)
+		if err := c.writeRows(qr); err != nil {
+			return err
+		}
+		if ended {
+			fieldsSent = false
+			return c.writeEndResult(true, 0, 0, handler.WarningCount(c))
+		}
+		return nil
 	})
 
 	// If callback was not called, we expect an error.
@@ -1542,11 +1760,18 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 		return connErr
 	}
 
-	// Send the end packet only sendFinished is false (results were streamed).
+	// Send the end packet only if the rows of a result set were streamed.
 	// In this case the affectedRows and lastInsertID are always 0 since it
 	// was a read operation.
-	if !sendFinished {
-		if err := c.writeEndResult(more, 0, 0, handler.WarningCount(c)); err != nil {
+	if fieldsSent {
+		if err := c.writeEndResult(more || len(moreResults) > 0, 0, 0, handler.WarningCount(c)); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return connErr
+		}
+	}
+
+	for i, qr := range moreResults {
+		if err := c.writeResultSet(qr, more || i < len(moreResults)-1, handler.WarningCount(c)); err != nil {
 			log.Errorf("Error writing result to %s: %v", c, err)
 			return connErr
 		}
@@ -1555,6 +1780,31 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 	return execSuccess
 }
 
+// writeResultSet writes a whole result set: an OK packet if the result has
+// no fields, or the fields, rows and end packet otherwise.
+func (c *Conn) writeResultSet(qr *sqltypes.Result, more bool, warnings uint16) error {
+	if len(qr.Fields) == 0 {
+		flag := c.StatusFlags
+		if more {
+			flag |= ServerMoreResultsExists
+		}
+		return c.writeOKPacket(&PacketOK{
+			affectedRows:     qr.RowsAffected,
+			lastInsertID:     qr.InsertID,
+			statusFlags:      flag,
+			warnings:         warnings,
+			sessionStateData: qr.SessionStateChanges,
+		})
+	}
+	if err := c.writeFields(qr); err != nil {
+		return err
+	}
+	if err := c.writeRows(qr); err != nil {
+		return err
+	}
+	return c.writeEndResult(more, 0, 0, warnings)
+}
+
 //
 // Packet parsing methods, for generic packets.
 //
@@ -1745,6 +1995,12 @@ func (c *Conn) TLSEnabled() bool {
 	return c.Capabilities&CapabilityClientSSL > 0
 }
 
+// QueryAttributes returns the query attributes sent by the client with the
+// COM_QUERY being executed, if any.
+func (c *Conn) QueryAttributes() map[string]string {
+	return c.queryAttributes
+}
+
 // IsUnixSocket returns true if the server connection is over a Unix socket.
 func (c *Conn) IsUnixSocket() bool {
 	_, ok := c.listener.listener.(*net.UnixListener)
@@ -1792,6 +2048,53 @@ func (c *Conn) IsMarkedForClose() bool {
 	return c.closing
 }
 
+// CloseIfIdle closes the server connection if it is waiting for the next
+// command of its client and closeErr returns an error for how long it has
+// been waiting. The wait is interrupted and the connection sends the error to
+// its client before it is closed, from its own goroutine: a command which
+// arrives in the meantime is not executed, but answered with the error. It
+// returns whether the connection is closed.
+func (c *Conn) CloseIfIdle(closeErr func(idle time.Duration) *sqlerror.SQLError) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	since := c.idleSince.Load()
+	if since == 0 || c.idleCloseErr != nil {
+		return false
+	}
+	err := closeErr(time.Since(time.Unix(0, since)))
+	if err == nil {
+		return false
+	}
+	c.idleCloseErr = err
+	c.closing = true
+	if err := c.conn.SetReadDeadline(time.Now()); err != nil {
+		// The connection is closed at its next command.
+		log.Warningf("Failed to interrupt the wait for the next command of %s: %v", c, err)
+	}
+	return true
+}
+
+// closeIdle is called by the server connection once it stops waiting for the
+// next command. It sends the error of CloseIfIdle to the client and returns
+// true if the connection is closed, and otherwise marks the connection as
+// running a command.
+func (c *Conn) closeIdle() bool {
+	c.mu.Lock()
+	err := c.idleCloseErr
+	if err == nil {
+		c.idleSince.Store(0)
+	}
+	c.mu.Unlock()
+	if err == nil {
+		return false
+	}
+	if c.currentEphemeralPolicy == ephemeralRead {
+		c.recycleReadPacket()
+	}
+	c.writeErrorPacketFromErrorAndLog(err)
+	return true
+}
+
 func (c *Conn) IsShuttingDown() bool {
 	return c.listener.shutdown.Load()
 }
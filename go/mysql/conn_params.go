@@ -64,6 +64,21 @@ type ConnParams struct {
 	// FlushDelay is the delay after which buffered response will be flushed to the client.
 	FlushDelay time.Duration
 
+	// Compression is the algorithm of the compressed protocol to use once
+	// the handshake is done: CompressionZlib or CompressionZstd. The
+	// connection is not compressed if it is empty, or if the server does
+	// not support the algorithm.
+	Compression string
+	// ZstdCompressionLevel is the level of the zstd compression, from 1 to
+	// 22. DefaultZstdCompressionLevel is used if it is 0.
+	ZstdCompressionLevel int
+
+	// Pass2 and Pass3 are the passwords of the second and third
+	// authentication factors, if the server asks for them, like the
+	// --password2 and --password3 of the mysql client.
+	Pass2 string
+	Pass3 string
+
 	TruncateErrLen int
 }
 
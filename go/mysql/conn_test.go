@@ -24,17 +24,20 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"net"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
@@ -283,6 +286,25 @@ func TestBasicPackets(t *testing.T) {
 	assert.EqualValues(89, packetOk.warnings)
 	assert.EqualValues("foo-bar", packetOk.sessionStateData)
 
+	// Write OK packet with tracked GTIDs, the session state changed flag is
+	// set for them.
+	ok = PacketOK{
+		affectedRows:     1,
+		statusFlags:      ServerStatusAutocommit,
+		sessionStateData: "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:1-44",
+	}
+	err = sConn.writeOKPacket(&ok)
+	require.NoError(err)
+
+	data, err = cConn.ReadPacket()
+	require.NoError(err)
+	require.NotEmpty(data)
+
+	err = cConn.parseOKPacket(&packetOk, data)
+	require.NoError(err)
+	assert.EqualValues(ServerStatusAutocommit|ServerSessionStateChanged, packetOk.statusFlags)
+	assert.EqualValues("e2f7c9a0-6b1d-11ee-8c99-0242ac120002:1-44", packetOk.sessionStateData)
+
 	// Write OK packet with EOF header, read it, compare.
 	ok = PacketOK{
 		affectedRows: 12,
@@ -929,6 +951,58 @@ func TestMultiStatement(t *testing.T) {
 	}
 }
 
+func TestQueryWithMoreResults(t *testing.T) {
+	origMysqlMultiQuery := mysqlMultiQuery
+	defer func() {
+		mysqlMultiQuery = origMysqlMultiQuery
+	}()
+	for _, b := range []bool{true, false} {
+		// The result sets are either all in the result of the call, or
+		// streamed one after the other.
+		for _, call := range []string{"call proc()", "stream call proc()"} {
+			t.Run(fmt.Sprintf("MultiQueryProtocol: %v, %s", b, call), func(t *testing.T) {
+				mysqlMultiQuery = b
+				listener, sConn, cConn := createSocketPair(t)
+				sConn.Capabilities |= CapabilityClientMultiStatements
+				defer func() {
+					listener.Close()
+					sConn.Close()
+					cConn.Close()
+				}()
+
+				err := cConn.WriteComQuery(call + ";select 1")
+				require.NoError(t, err)
+
+				handler := &testRun{}
+				res := sConn.handleNextCommand(handler)
+				require.True(t, res, "we should not break the connection in case of no errors")
+
+				// The call returns three result sets, the last of which holds its status.
+				data, more, _, err := cConn.ReadQueryResult(100, true)
+				require.NoError(t, err)
+				require.True(t, more)
+				require.True(t, data.Equal(selectRowsResult))
+
+				data, more, _, err = cConn.ReadQueryResult(100, true)
+				require.NoError(t, err)
+				require.True(t, more)
+				require.True(t, data.Equal(selectRowsResult))
+
+				data, more, _, err = cConn.ReadQueryResult(100, true)
+				require.NoError(t, err)
+				require.True(t, more)
+				require.EqualValues(t, 1, data.RowsAffected)
+
+				// The result of the second query follows.
+				data, more, _, err = cConn.ReadQueryResult(100, true)
+				require.NoError(t, err)
+				require.False(t, more)
+				require.True(t, data.Equal(selectRowsResult))
+			})
+		}
+	}
+}
+
 func TestMultiStatementOnSplitError(t *testing.T) {
 	origMysqlMultiQuery := mysqlMultiQuery
 	defer func() {
@@ -989,6 +1063,50 @@ func TestInitDbAgainstWrongDbDoesNotDropConnection(t *testing.T) {
 	require.EqualValues(t, data[0], ErrPacket) // we should see the error here
 }
 
+func TestCloseIfIdle(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	idleErr := sqlerror.NewSQLError(sqlerror.ERClientInteractionTimeout, sqlerror.SSUnknownSQLState, "idle")
+	closeErr := func(time.Duration) *sqlerror.SQLError { return idleErr }
+	// this handler will return an error if the query is executed.
+	handler := &testRun{err: fmt.Errorf("execution failed")}
+
+	// A connection running a command is not closed, nor is an idle
+	// connection for which closeErr returns no error.
+	require.False(t, sConn.CloseIfIdle(closeErr))
+	sConn.idleSince.Store(time.Now().UnixNano())
+	require.False(t, sConn.CloseIfIdle(func(time.Duration) *sqlerror.SQLError { return nil }))
+
+	// The connection waiting for the next command stops waiting, and sends
+	// the error to its client.
+	res := make(chan bool)
+	go func() {
+		res <- sConn.handleNextCommand(handler)
+	}()
+	require.True(t, sConn.CloseIfIdle(closeErr))
+	require.False(t, sConn.CloseIfIdle(closeErr), "the connection is closed once")
+	require.False(t, <-res)
+	assert.True(t, sConn.IsMarkedForClose())
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualError(t, ParseErrorPacket(data), "idle (errno 4031) (sqlstate HY000)")
+
+	// A command which arrives once the connection is closed is not executed,
+	// and its client reads the error instead of its result. The command is not
+	// read, so the error is sent like the one of a connection which waits.
+	require.NoError(t, cConn.WriteComQuery("error"))
+	require.False(t, sConn.handleNextCommand(handler))
+	cConn.resetSequence()
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualError(t, ParseErrorPacket(data), "idle (errno 4031) (sqlstate HY000)")
+}
+
 func TestConnectionErrorWhileWritingComQuery(t *testing.T) {
 	origMysqlMultiQuery := mysqlMultiQuery
 	defer func() {
@@ -1014,7 +1132,7 @@ func TestConnectionErrorWhileWritingComQuery(t *testing.T) {
 	}
 }
 
-func TestConnectionErrorWhileWritingComStmtSendLongData(t *testing.T) {
+func TestComStmtSendLongDataUnknownStatement(t *testing.T) {
 	// Set the conn for the server connection to the simulated connection which always returns an error on writing
 	sConn := newConn(testConn{
 		writeToPass: []bool{false, true},
@@ -1023,10 +1141,10 @@ func TestConnectionErrorWhileWritingComStmtSendLongData(t *testing.T) {
 			0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x20, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x20, 0x31},
 	}, DefaultFlushDelay, 0)
 
-	// this handler will return an error on the first run, and fail the test if it's run more times
+	// COM_STMT_SEND_LONG_DATA has no response, the command is ignored
 	handler := &testRun{err: fmt.Errorf("not used")}
 	res := sConn.handleNextCommand(handler)
-	require.False(t, res, "we should beak the connection in case of error writing error packet")
+	require.True(t, res, "no packet should be written for an unknown statement")
 }
 
 func TestConnectionErrorWhileWritingComPrepare(t *testing.T) {
@@ -1152,6 +1270,236 @@ func createSendLongDataPacket(stmtID uint32, paramID uint16, data []byte) []byte
 	return packet
 }
 
+func TestComStmtResetAndSendLongData(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	handler := &testRun{paramCounts: 1}
+
+	// Prepare a statement, and read the OK, parameter and EOF packets.
+	require.NoError(t, cConn.writePacket(preparePacket(t, "select * from test where id = ?")))
+	require.True(t, sConn.handleNextCommand(handler))
+	for range 3 {
+		_, err := cConn.ReadPacket()
+		require.NoError(t, err)
+	}
+	stmtID := sConn.StatementID
+
+	// The long data is discarded by COM_STMT_RESET.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createSendLongDataPacket(stmtID, 0, []byte("long data"))))
+	require.True(t, sConn.handleNextCommand(handler))
+	require.Contains(t, sConn.PrepareData[stmtID].BindVars, "v1")
+
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createStmtIDPacket(ComStmtReset, stmtID)))
+	require.True(t, sConn.handleNextCommand(handler))
+	resp, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, resp[0])
+	require.Empty(t, sConn.PrepareData[stmtID].BindVars)
+
+	// The error of a COM_STMT_SEND_LONG_DATA is returned by the next COM_STMT_EXECUTE.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createSendLongDataPacket(stmtID, 5, []byte("long data"))))
+	require.True(t, sConn.handleNextCommand(handler))
+
+	cConn.sequence = 0
+	execute := createStmtIDPacket(ComStmtExecute, stmtID)
+	// flags, iteration count, NULL bitmap, new params bound, VAR_STRING parameter 'a'
+	execute = append(execute, 0, 1, 0, 0, 0, 0, 1, 0xfd, 0, 1, 'a')
+	require.NoError(t, cConn.writePacket(execute))
+	require.True(t, sConn.handleNextCommand(handler))
+	resp, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(resp))
+	require.EqualValues(t, sqlerror.ERWrongArguments, ParseErrorPacket(resp).(*sqlerror.SQLError).Number())
+	require.Nil(t, sConn.PrepareData[stmtID].longDataErr)
+
+	// An unknown statement cannot be reset.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createStmtIDPacket(ComStmtReset, stmtID+1)))
+	require.True(t, sConn.handleNextCommand(handler))
+	resp, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(resp))
+	require.EqualValues(t, sqlerror.ERUnknownStmtHandler, ParseErrorPacket(resp).(*sqlerror.SQLError).Number())
+}
+
+func TestPrepareDataEviction(t *testing.T) {
+	sConn := newConn(testConn{}, DefaultFlushDelay, 0)
+	sConn.PrepareData = map[uint32]*PrepareData{}
+	sConn.listener = &Listener{}
+	sConn.listener.MaxPreparedStatements.Store(2)
+
+	count := preparedStmtCount.Get()
+	evicted := preparedStmtEvicted.Get()
+	for id := range uint32(3) {
+		sConn.addPrepareData(&PrepareData{StatementID: id + 1})
+		if id == 1 {
+			// Statement 1 becomes the most recently used.
+			_, ok := sConn.getPrepareData(1)
+			require.True(t, ok)
+		}
+	}
+
+	// Statement 2 was the least recently used.
+	require.Len(t, sConn.PrepareData, 2)
+	require.Contains(t, sConn.PrepareData, uint32(1))
+	require.Contains(t, sConn.PrepareData, uint32(3))
+	assert.EqualValues(t, 1, preparedStmtEvicted.Get()-evicted)
+	assert.EqualValues(t, 2, preparedStmtCount.Get()-count)
+
+	sConn.removePrepareData(1)
+	assert.EqualValues(t, 1, preparedStmtCount.Get()-count)
+	sConn.clearPrepareData()
+	assert.Empty(t, sConn.PrepareData)
+	assert.EqualValues(t, 0, preparedStmtCount.Get()-count)
+}
+
+func createStmtIDPacket(command byte, stmtID uint32) []byte {
+	packet := []byte{0, 0, 0, 0, command}
+	return binary.LittleEndian.AppendUint32(packet, stmtID)
+}
+
+func TestComStmtFetch(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.listener = &Listener{}
+	handler := &cursorRun{}
+
+	// Prepare a statement, and read the OK packet.
+	require.NoError(t, cConn.writePacket(preparePacket(t, "select id from test")))
+	require.True(t, sConn.handleNextCommand(handler))
+	_, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	stmtID := sConn.StatementID
+
+	// The statement has no open cursor yet.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createStmtFetchPacket(stmtID, 2)))
+	require.True(t, sConn.handleNextCommand(handler))
+	resp, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, isErrorPacket(resp))
+	require.EqualValues(t, sqlerror.ERStmtHasNoOpenCursor, ParseErrorPacket(resp).(*sqlerror.SQLError).Number())
+
+	// Execute the statement with a read-only cursor: only the fields are written.
+	openCursor := func() {
+		cConn.sequence = 0
+		execute := createStmtIDPacket(ComStmtExecute, stmtID)
+		// flags, iteration count
+		execute = append(execute, CursorTypeReadOnly, 1, 0, 0, 0)
+		require.NoError(t, cConn.writePacket(execute))
+		require.True(t, sConn.handleNextCommand(handler))
+		require.True(t, handler.cursor.Load())
+
+		for _, expected := range []byte{1, 3} { // column count, column definition
+			resp, err := cConn.ReadPacket()
+			require.NoError(t, err)
+			require.EqualValues(t, expected, resp[0])
+		}
+		resp, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.True(t, cConn.isEOFPacket(resp))
+		_, flags, err := parseEOFPacket(resp)
+		require.NoError(t, err)
+		require.NotZero(t, flags&ServerStatusCursorExists)
+	}
+	// fetch fetches the rows, and returns their number and the status flags.
+	fetch := func(numRows uint32) (int, uint16) {
+		cConn.sequence = 0
+		require.NoError(t, cConn.writePacket(createStmtFetchPacket(stmtID, numRows)))
+		require.True(t, sConn.handleNextCommand(handler))
+		for rows := 0; ; rows++ {
+			resp, err := cConn.ReadPacket()
+			require.NoError(t, err)
+			if cConn.isEOFPacket(resp) {
+				_, flags, err := parseEOFPacket(resp)
+				require.NoError(t, err)
+				return rows, flags
+			}
+			require.EqualValues(t, 0, resp[0], "not a binary row")
+		}
+	}
+
+	// The 5 rows are streamed 2 at a time.
+	openCursor()
+	rows, flags := fetch(3)
+	require.Equal(t, 3, rows)
+	require.NotZero(t, flags&ServerStatusCursorExists)
+	require.Zero(t, flags&ServerStatusLastRowSent)
+	rows, flags = fetch(3)
+	require.Equal(t, 2, rows)
+	require.NotZero(t, flags&ServerStatusLastRowSent)
+	require.Nil(t, sConn.cursor)
+
+	// Another command closes the cursor, and aborts its stream.
+	openCursor()
+	rows, _ = fetch(1)
+	require.Equal(t, 1, rows)
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket([]byte{0, 0, 0, 0, ComPing}))
+	require.True(t, sConn.handleNextCommand(handler))
+	_, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.NotNil(t, sConn.cursor)
+
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(createStmtIDPacket(ComStmtReset, stmtID)))
+	require.True(t, sConn.handleNextCommand(handler))
+	resp, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, resp[0])
+	require.Nil(t, sConn.cursor)
+	require.ErrorIs(t, handler.err.Load().(error), io.EOF)
+}
+
+func createStmtFetchPacket(stmtID uint32, numRows uint32) []byte {
+	packet := createStmtIDPacket(ComStmtFetch, stmtID)
+	return binary.LittleEndian.AppendUint32(packet, numRows)
+}
+
+// cursorRun streams 5 rows, 2 at a time.
+type cursorRun struct {
+	testRun
+	cursor atomic.Bool
+	err    atomic.Value
+}
+
+func (t *cursorRun) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	t.cursor.Store(prepare.Cursor)
+	err := t.stream(callback)
+	if err != nil {
+		t.err.Store(err)
+	}
+	return err
+}
+
+func (t *cursorRun) stream(callback func(*sqltypes.Result) error) error {
+	fields := []*querypb.Field{{Name: "id", Type: querypb.Type_INT64, Charset: collations.CollationBinaryID}}
+	if err := callback(&sqltypes.Result{Fields: fields}); err != nil {
+		return err
+	}
+	for i := 0; i < 5; i += 2 {
+		qr := &sqltypes.Result{}
+		for id := i; id < min(i+2, 5); id++ {
+			qr.Rows = append(qr.Rows, sqltypes.Row{sqltypes.NewInt64(int64(id))})
+		}
+		if err := callback(qr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type testRun struct {
 	UnimplementedHandler
 	paramCounts uint16
@@ -1191,6 +1539,32 @@ func (t testRun) ComQuery(c *Conn, query string, callback func(*sqltypes.Result)
 		return nil
 	}
 
+	if strings.Contains(query, "stream call") {
+		// The result sets are streamed, each but the last ending with
+		// SERVER_MORE_RESULTS_EXISTS.
+		for _, qr := range []*sqltypes.Result{
+			selectRowsResult.Metadata(),
+			{Rows: selectRowsResult.Rows, StatusFlags: sqltypes.ServerMoreResultsExists},
+			selectRowsResult.Metadata(),
+			{Rows: selectRowsResult.Rows, StatusFlags: sqltypes.ServerMoreResultsExists},
+			{RowsAffected: 1},
+		} {
+			if err := callback(qr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if strings.Contains(query, "call") {
+		callback(&sqltypes.Result{
+			Fields:      selectRowsResult.Fields,
+			Rows:        selectRowsResult.Rows,
+			MoreResults: []*sqltypes.Result{selectRowsResult, {RowsAffected: 1}},
+		})
+		return nil
+	}
+
 	if strings.Contains(query, "twice") {
 		callback(selectRowsResult)
 	}
@@ -1233,3 +1607,57 @@ func (t testRun) Env() *vtenv.Environment {
 }
 
 var _ Handler = (*testRun)(nil)
+
+func TestLocalInfile(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Nothing is sent if the client did not enable CLIENT_LOCAL_FILES.
+	_, err := sConn.LocalInfile("data.csv")
+	require.EqualValues(t, sqlerror.ERClientLocalFilesDisabled, err.(*sqlerror.SQLError).Number())
+
+	sConn.Capabilities |= CapabilityClientLocalFiles
+	// sendFile answers the request with the file, in packets.
+	sendFile := func(packets ...string) {
+		resp, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		require.EqualValues(t, LocalInfilePacket, resp[0])
+		require.Equal(t, "data.csv", string(resp[1:]))
+		for _, packet := range append(packets, "") {
+			require.NoError(t, cConn.writePacket(append(make([]byte, packetHeaderSize), packet...)))
+		}
+	}
+
+	// The file is read across its packets.
+	sConn.startWriterBuffering()
+	r, err := sConn.LocalInfile("data.csv")
+	require.NoError(t, err)
+	go sendFile("1,a\n2,", "b\n", "3,c\n")
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "1,a\n2,b\n3,c\n", string(data))
+	require.NoError(t, r.Close())
+	require.NoError(t, sConn.endWriterBuffering())
+
+	// Close reads the rest of the file, up to the next command.
+	r, err = sConn.LocalInfile("data.csv")
+	require.NoError(t, err)
+	go func() {
+		sendFile("1,a\n", "2,b\n")
+		cConn.sequence = 0
+		cConn.writePacket([]byte{0, 0, 0, 0, ComPing})
+	}()
+	buf := make([]byte, 2)
+	n, err := r.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "1,", string(buf[:n]))
+	require.NoError(t, r.Close())
+	sConn.sequence = 0
+	data, err = sConn.ReadPacket()
+	require.NoError(t, err)
+	require.Equal(t, []byte{ComPing}, data)
+}
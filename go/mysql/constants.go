@@ -76,15 +76,18 @@ const (
 	// CLIENT_NO_SCHEMA 1 << 4
 	// Do not permit database.table.column. We do permit it.
 
-	// CLIENT_COMPRESS 1 << 5
-	// We do not support compression. CPU is usually our bottleneck.
+	// CapabilityClientCompress is CLIENT_COMPRESS.
+	// Use the compressed protocol with zlib. Only advertised when
+	// enabled on the Listener, or asked for in the ConnParams.
+	CapabilityClientCompress = 1 << 5
 
 	// CLIENT_ODBC 1 << 6
 	// No special behavior since 3.22.
 
-	// CLIENT_LOCAL_FILES 1 << 7
+	// CapabilityClientLocalFiles is CLIENT_LOCAL_FILES.
 	// Client can use LOCAL INFILE request of LOAD DATA|XML.
-	// We do not set it.
+	// Only advertised when enabled on the Listener.
+	CapabilityClientLocalFiles = 1 << 7
 
 	// CLIENT_IGNORE_SPACE 1 << 8
 	// Parser can ignore spaces before '('.
@@ -148,6 +151,40 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CLIENT_OPTIONAL_RESULTSET_METADATA 1 << 25
+	// Not supported.
+
+	// CapabilityClientZstdCompressionAlgorithm is
+	// CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	// Use the compressed protocol with zstd. Only advertised when
+	// enabled on the Listener, or asked for in the ConnParams.
+	CapabilityClientZstdCompressionAlgorithm = 1 << 26
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES.
+	// The client can send query attributes with COM_QUERY and
+	// COM_STMT_EXECUTE. Only advertised when enabled on the Listener.
+	CapabilityClientQueryAttributes = 1 << 27
+
+	// CapabilityClientMultiFactorAuthentication is
+	// CLIENT_MULTI_FACTOR_AUTHENTICATION.
+	// The client answers the AuthNextFactorPacket of the additional
+	// authentication factors. Only advertised by the server when its
+	// AuthServer is a MultiFactorAuthServer, and by the client when its
+	// ConnParams have a Pass2.
+	CapabilityClientMultiFactorAuthentication = 1 << 28
+)
+
+// Cursor types of COM_STMT_EXECUTE.
+const (
+	// CursorTypeReadOnly is CURSOR_TYPE_READ_ONLY: the rows of the result
+	// are fetched with COM_STMT_FETCH.
+	CursorTypeReadOnly byte = 0x01
+
+	// ParameterCountAvailable is PARAMETER_COUNT_AVAILABLE: the parameter
+	// count is sent in COM_STMT_EXECUTE even if the statement has none,
+	// because the client sends query attributes.
+	ParameterCountAvailable byte = 0x08
 )
 
 // Status flags. They are returned by the server in a few cases.
@@ -257,6 +294,9 @@ const (
 
 	// NullValue is the encoded value of NULL.
 	NullValue = 0xfb
+
+	// LocalInfilePacket is the header of the LOCAL INFILE request.
+	LocalInfilePacket = 0xfb
 )
 
 // Auth packet types
@@ -264,6 +304,13 @@ const (
 	// AuthMoreDataPacket is sent when server requires more data to authenticate
 	AuthMoreDataPacket = 0x01
 
+	// AuthNextFactorPacket is sent when server requires the next authentication factor
+	AuthNextFactorPacket = 0x02
+
+	// CachingSha2RequestPublicKey is sent by the client to ask for the public key of the server
+	// to encrypt the password with in CachingSha2Password "full" authentication without TLS
+	CachingSha2RequestPublicKey = 0x02
+
 	// CachingSha2FastAuth is sent before OKPacket when server authenticates using cache
 	CachingSha2FastAuth = 0x03
 
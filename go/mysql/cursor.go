@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"errors"
+	"io"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// cursor is a read-only cursor opened by a COM_STMT_EXECUTE. The handler
+// streams the result in a goroutine, which is paused until the client fetches
+// the rows with COM_STMT_FETCH, so that the rows are never buffered beyond the
+// chunk being fetched.
+//
+// A connection has at most one open cursor, which most commands close, see
+// closesCursor: the handler is not safe for concurrent use on a connection.
+type cursor struct {
+	stmtID uint32
+	fields []*querypb.Field
+	// statusFlags are the status flags of the connection when the cursor was
+	// opened. The handler may change the flags of the connection once the
+	// stream ended, which is only read after that.
+	statusFlags uint16
+
+	// results receives the results streamed by the handler.
+	results chan *sqltypes.Result
+	// rows are the rows received but not fetched yet.
+	rows []sqltypes.Row
+	// closed is closed to abort the stream.
+	closed chan struct{}
+	// done is closed once the handler returned, with err its error.
+	done chan struct{}
+	err  error
+}
+
+// next returns the next result of the stream, or false if it ended.
+func (cur *cursor) next() (*sqltypes.Result, bool) {
+	select {
+	case qr := <-cur.results:
+		return qr, true
+	case <-cur.done:
+		return nil, false
+	}
+}
+
+// openCursor executes the statement with the handler, and opens a cursor on
+// its result, whose fields are written to the client. No cursor is opened if
+// the statement does not return rows, and its result is written as usual.
+func (c *Conn) openCursor(handler Handler, prepare *PrepareData) bool {
+	cur := &cursor{
+		stmtID:      prepare.StatementID,
+		statusFlags: c.StatusFlags,
+		results:     make(chan *sqltypes.Result),
+		closed:      make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	// The bind variables of the statement are reset once this returns.
+	stmt := &PrepareData{
+		ParamsType:      prepare.ParamsType,
+		ColumnNames:     prepare.ColumnNames,
+		PrepareStmt:     prepare.PrepareStmt,
+		BindVars:        prepare.BindVars,
+		StatementID:     prepare.StatementID,
+		ParamsCount:     prepare.ParamsCount,
+		Cursor:          true,
+		QueryAttributes: prepare.QueryAttributes,
+	}
+	go func() {
+		defer close(cur.done)
+		cur.err = handler.ComStmtExecute(c, stmt, func(qr *sqltypes.Result) error {
+			select {
+			case cur.results <- qr:
+				return nil
+			case <-cur.closed:
+				return io.EOF
+			}
+		})
+	}()
+
+	qr, ok := cur.next()
+	if !ok {
+		err := cur.err
+		if err == nil || err == io.EOF {
+			err = sqlerror.NewSQLErrorFromError(errors.New("unexpected: query ended without no results and no error"))
+		}
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	if len(qr.Fields) == 0 {
+		// The statement does not return rows, wait for the handler to return.
+		for ok {
+			_, ok = cur.next()
+		}
+		if cur.err != nil {
+			return c.writeErrorPacketFromErrorAndLog(cur.err)
+		}
+		if err := c.writeOKPacket(&PacketOK{
+			affectedRows:     qr.RowsAffected,
+			lastInsertID:     qr.InsertID,
+			statusFlags:      c.StatusFlags,
+			sessionStateData: qr.SessionStateChanges,
+		}); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+		return true
+	}
+
+	cur.fields = qr.Fields
+	cur.rows = qr.Rows
+	c.cursor = cur
+
+	if err := c.sendColumnCount(uint64(len(cur.fields))); err != nil {
+		log.Errorf("Error writing fields to %s: %v", c, err)
+		return false
+	}
+	for _, field := range cur.fields {
+		if err := c.writeColumnDefinition(field); err != nil {
+			log.Errorf("Error writing fields to %s: %v", c, err)
+			return false
+		}
+	}
+	if err := c.writeCursorEnd(cur.statusFlags|ServerStatusCursorExists, 0); err != nil {
+		log.Errorf("Error writing fields to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
+// handleComStmtFetch writes the next rows of the open cursor of a statement.
+// The cursor is closed once all its rows were fetched.
+func (c *Conn) handleComStmtFetch(data []byte) (kontinue bool) {
+	c.startWriterBuffering()
+	defer func() {
+		if err := c.endWriterBuffering(); err != nil {
+			log.Errorf("conn %v: flush() failed: %v", c.ID(), err)
+			kontinue = false
+		}
+	}()
+
+	stmtID, numRows, ok := c.parseComStmtFetch(data)
+	c.recycleReadPacket()
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "error parsing statement fetch from client %v", c.ConnectionID)
+	}
+
+	cur := c.cursor
+	if cur == nil || cur.stmtID != stmtID {
+		return c.writeErrorAndLog(sqlerror.ERStmtHasNoOpenCursor, sqlerror.SSUnknownSQLState, "The statement (%d) has no open cursor.", stmtID)
+	}
+
+	result := &sqltypes.Result{Fields: cur.fields}
+	ended := false
+	for {
+		if len(cur.rows) == 0 {
+			// Read ahead, to tell the client whether the last row was sent.
+			qr, ok := cur.next()
+			if !ok {
+				ended = true
+				break
+			}
+			cur.rows = qr.Rows
+			continue
+		}
+		if len(result.Rows) == int(numRows) {
+			break
+		}
+		n := min(len(cur.rows), int(numRows)-len(result.Rows))
+		result.Rows = append(result.Rows, cur.rows[:n]...)
+		cur.rows = cur.rows[n:]
+	}
+
+	flags := cur.statusFlags | ServerStatusCursorExists
+	if ended {
+		c.cursor = nil
+		if cur.err != nil && cur.err != io.EOF {
+			return c.writeErrorPacketFromErrorAndLog(cur.err)
+		}
+		flags = c.StatusFlags | ServerStatusLastRowSent
+	}
+
+	if err := c.writeBinaryRows(result); err != nil {
+		log.Errorf("Error writing rows to %s: %v", c, err)
+		return false
+	}
+	if err := c.writeCursorEnd(flags, 0); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
+// writeCursorEnd writes the packet ending the fields or the rows of a cursor,
+// which carries the status of the cursor.
+func (c *Conn) writeCursorEnd(flags uint16, warnings uint16) error {
+	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
+		return c.writeEOFPacket(flags, warnings)
+	}
+	return c.writeOKPacketWithEOFHeader(&PacketOK{
+		statusFlags: flags,
+		warnings:    warnings,
+	})
+}
+
+// closesCursor tells whether the command closes the open cursor of the
+// connection. All the commands close it, but COM_STMT_FETCH,
+// COM_STMT_SEND_LONG_DATA, COM_PING, and COM_STMT_CLOSE and COM_STMT_RESET
+// for another statement.
+func (c *Conn) closesCursor(data []byte) bool {
+	switch data[0] {
+	case ComStmtFetch, ComStmtSendLongData, ComPing:
+		return false
+	case ComStmtClose, ComStmtReset:
+		stmtID, _, ok := readUint32(data, 1)
+		return !ok || stmtID == c.cursor.stmtID
+	}
+	return true
+}
+
+// closeCursor closes the open cursor of the connection, if any, and waits
+// for its stream to end.
+func (c *Conn) closeCursor() {
+	cur := c.cursor
+	if cur == nil {
+		return
+	}
+	c.cursor = nil
+	close(cur.closed)
+	c.CancelCtx()
+	<-cur.done
+}
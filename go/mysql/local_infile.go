@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"io"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+)
+
+// LocalInfile asks the client for the file of the LOAD DATA LOCAL INFILE
+// statement being executed by the handler, and returns a reader of its
+// content, which the client sends as packets ending with an empty one.
+//
+// The reader must be closed before the result of the statement is written:
+// Close reads what is left of the file, so the connection is ready for the
+// next packet even if the handler stopped reading early.
+//
+// An error is returned if the client or the Listener did not enable
+// CLIENT_LOCAL_FILES, in which case nothing is sent to the client.
+func (c *Conn) LocalInfile(filename string) (io.ReadCloser, error) {
+	if c.Capabilities&CapabilityClientLocalFiles == 0 {
+		return nil, sqlerror.NewSQLError(sqlerror.ERClientLocalFilesDisabled, sqlerror.SSClientError, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + len(filename))
+	data[pos] = LocalInfilePacket
+	copy(data[pos+1:], filename)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	// The client only answers once it got the request, which may be
+	// buffered.
+	if err := c.flush(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	return &localInfileReader{c: c}, nil
+}
+
+// flush writes the buffered packets, if the writes are buffered.
+func (c *Conn) flush() error {
+	c.bufMu.Lock()
+	defer c.bufMu.Unlock()
+
+	if c.bufferedWriter == nil {
+		return nil
+	}
+	return c.bufferedWriter.Flush()
+}
+
+// localInfileReader reads the packets of a file sent by the client for
+// LOAD DATA LOCAL INFILE.
+type localInfileReader struct {
+	c *Conn
+	// data is what is left to read of the last packet.
+	data []byte
+	// err is io.EOF once the empty packet ending the file is read.
+	err error
+}
+
+// Read is part of the io.Reader interface.
+func (r *localInfileReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.next()
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// next reads the next packet of the file.
+func (r *localInfileReader) next() {
+	data, err := r.c.readPacket()
+	switch {
+	case err != nil:
+		r.err = sqlerror.NewSQLErrorf(sqlerror.CRServerLost, sqlerror.SSUnknownSQLState, "%v", err)
+	case len(data) == 0:
+		r.err = io.EOF
+	default:
+		r.data = data
+	}
+}
+
+// Close is part of the io.Closer interface. It reads the rest of the file.
+func (r *localInfileReader) Close() error {
+	r.data = nil
+	for r.err == nil {
+		r.next()
+		r.data = nil
+	}
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
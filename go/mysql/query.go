@@ -58,7 +58,7 @@ const (
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) WriteComQuery(query string) error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
 	data[pos] = ComQuery
@@ -580,8 +580,99 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+// parseComQuery returns the query of a COM_QUERY packet. The query
+// attributes that precede it, if the client sends any, are stored in
+// c.queryAttributes.
+func (c *Conn) parseComQuery(data []byte) (string, error) {
+	payload := data[1:]
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(payload), nil
+	}
+
+	paramsCount, pos, ok := readLenEncInt(payload, 0)
+	if !ok {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+	}
+	// The parameter set count is always 1.
+	_, pos, ok = readLenEncInt(payload, pos)
+	if !ok {
+		return "", sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter set count failed")
+	}
+	if paramsCount > 0 {
+		var err error
+		c.queryAttributes, pos, err = c.parseQueryAttributes(payload, pos, int(paramsCount))
+		if err != nil {
+			return "", err
+		}
+	}
+	return string(payload[pos:]), nil
+}
+
+// parseQueryAttributes parses the query attributes of a COM_QUERY packet:
+// their NULL-bitmap, their types and names, and then their values.
+func (c *Conn) parseQueryAttributes(payload []byte, pos int, count int) (map[string]string, int, error) {
+	bitMap, pos, ok := readBytes(payload, pos, (count+7)/8)
+	if !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+	}
+	// The new params bind flag is always set.
+	_, pos, ok = readByte(payload, pos)
+	if !ok {
+		return nil, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading new params bind flag failed")
+	}
+
+	types := make([]querypb.Type, count)
+	names := make([]string, count)
+	for i := range count {
+		var err error
+		types[i], names[i], pos, err = c.parseParameterTypeAndName(payload, pos, true)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	attributes := make(map[string]string, count)
+	for i := range count {
+		if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
+			continue
+		}
+		var val sqltypes.Value
+		val, pos, ok = c.parseStmtArgs(payload, types[i], pos)
+		if !ok {
+			return nil, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", types[i])
+		}
+		attributes[names[i]] = val.ToString()
+	}
+	return attributes, pos, nil
+}
+
+// parseParameterTypeAndName parses the type and flags of a parameter, and
+// its name if the client sends query attributes.
+func (c *Conn) parseParameterTypeAndName(payload []byte, pos int, withName bool) (querypb.Type, string, int, error) {
+	mysqlType, pos, ok := readByte(payload, pos)
+	if !ok {
+		return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
+	}
+
+	flags, pos, ok := readByte(payload, pos)
+	if !ok {
+		return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+	}
+
+	// convert MySQL type to internal type.
+	valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+	if err != nil {
+		return 0, "", 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+	}
+
+	var name string
+	if withName {
+		name, pos, ok = readLenEncString(payload, pos)
+		if !ok {
+			return 0, "", 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter name failed")
+		}
+	}
+	return valType, name, pos, nil
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -605,7 +696,7 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 	}
 	prepare, ok := prepareData[stmtID]
 	if !ok {
-		return 0, 0, sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "statement ID is not found from record")
+		return 0, 0, sqlerror.NewSQLErrorf(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%d) given to mysqld_stmt_execute", stmtID)
 	}
 
 	// cursor type flags
@@ -623,34 +714,49 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
 	}
 
-	if prepare.ParamsCount > 0 {
-		bitMap, pos, ok = readBytes(payload, pos, (int(prepare.ParamsCount)+7)/8)
+	// With query attributes, the parameter count is sent, and the
+	// attributes are sent as parameters after the ones of the statement.
+	paramsCount := int(prepare.ParamsCount)
+	withAttributes := c.Capabilities&CapabilityClientQueryAttributes != 0
+	if withAttributes && (paramsCount > 0 || cursorType&ParameterCountAvailable != 0) {
+		var count uint64
+		count, pos, ok = readLenEncInt(payload, pos)
+		if !ok {
+			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+		}
+		if count < uint64(paramsCount) {
+			return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "parameter count %d is lower than the %d parameters of the statement", count, paramsCount)
+		}
+		paramsCount = int(count)
+	}
+	cursorType &^= ParameterCountAvailable
+
+	if paramsCount > 0 {
+		bitMap, pos, ok = readBytes(payload, pos, (paramsCount+7)/8)
 		if !ok {
 			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
 		}
 	}
 
+	// The types of the attributes are only known if sent with the execution,
+	// the attributes are ignored otherwise.
+	var attributeTypes []querypb.Type
+	var attributeNames []string
 	newParamsBoundFlag, pos, ok := readByte(payload, pos)
 	if ok && newParamsBoundFlag == 0x01 {
-		var mysqlType, flags byte
-		for i := range prepare.ParamsCount {
-			mysqlType, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
-			}
-
-			flags, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
-			}
-
-			// convert MySQL type to internal type.
-			valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+		for i := range paramsCount {
+			valType, name, newPos, err := c.parseParameterTypeAndName(payload, pos, withAttributes)
 			if err != nil {
-				return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+				return stmtID, 0, err
 			}
+			pos = newPos
 
-			prepare.ParamsType[i] = int32(valType)
+			if i < int(prepare.ParamsCount) {
+				prepare.ParamsType[i] = int32(valType)
+				continue
+			}
+			attributeTypes = append(attributeTypes, valType)
+			attributeNames = append(attributeNames, name)
 		}
 	}
 
@@ -675,6 +781,23 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 		prepare.BindVars[parameterID] = sqltypes.ValueBindVariable(val)
 	}
 
+	if len(attributeTypes) > 0 {
+		prepare.QueryAttributes = make(map[string]string, len(attributeTypes))
+		for j, typ := range attributeTypes {
+			i := int(prepare.ParamsCount) + j
+			if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
+				continue
+			}
+
+			var val sqltypes.Value
+			val, pos, ok = c.parseStmtArgs(payload, typ, pos)
+			if !ok {
+				return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", typ)
+			}
+			prepare.QueryAttributes[attributeNames[j]] = val.ToString()
+		}
+	}
+
 	return stmtID, cursorType, nil
 }
 
@@ -957,6 +1080,15 @@ func (c *Conn) parseComStmtReset(data []byte) (uint32, bool) {
 	return val, ok
 }
 
+func (c *Conn) parseComStmtFetch(data []byte) (uint32, uint32, bool) {
+	stmtID, pos, ok := readUint32(data, 1)
+	if !ok {
+		return 0, 0, false
+	}
+	numRows, _, ok := readUint32(data, pos)
+	return stmtID, numRows, ok
+}
+
 func (c *Conn) parseComInitDB(data []byte) string {
 	return string(data[1:])
 }
@@ -250,6 +250,105 @@ func TestComStmtExecute(t *testing.T) {
 
 }
 
+func TestComQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	// Without the capability, the packet is the query.
+	query, err := sConn.parseComQuery([]byte{ComQuery, 's', 'e', 'l', 'e', 'c', 't', ' ', '1'})
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, sConn.QueryAttributes())
+
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+	query, err = sConn.parseComQuery([]byte{ComQuery, 0, 1, 's', 'e', 'l', 'e', 'c', 't', ' ', '1'})
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, sConn.QueryAttributes())
+
+	// Three attributes: a string, an integer and a NULL.
+	data := []byte{
+		ComQuery, 3, 1, 0x04, 0x01,
+		0xfd, 0x00, 8, 't', 'r', 'a', 'c', 'e', '_', 'i', 'd',
+		0x01, 0x80, 5, 'r', 'e', 't', 'r', 'y',
+		0x06, 0x00, 4, 'n', 'o', 'n', 'e',
+		3, 'a', 'b', 'c',
+		2,
+		's', 'e', 'l', 'e', 'c', 't', ' ', '1',
+	}
+	query, err = sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Equal(t, map[string]string{"trace_id": "abc", "retry": "2"}, sConn.QueryAttributes())
+
+	_, err = sConn.parseComQuery(data[:10])
+	assert.ErrorContains(t, err, "reading parameter name failed")
+}
+
+func TestComStmtExecuteQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	prepareDataMap := map[uint32]*PrepareData{
+		1: {
+			StatementID: 1,
+			ParamsCount: 1,
+			ParamsType:  make([]int32, 1),
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+		2: {
+			StatementID: 2,
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+	}
+
+	// One parameter of the statement, then one attribute.
+	data := []byte{
+		ComStmtExecute, 1, 0, 0, 0, 0, 1, 0, 0, 0,
+		2, 0x00, 0x01,
+		0x01, 0x80, 0,
+		0xfd, 0x00, 8, 't', 'r', 'a', 'c', 'e', '_', 'i', 'd',
+		7,
+		3, 'a', 'b', 'c',
+	}
+	stmtID, cursorType, err := sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stmtID)
+	assert.Zero(t, cursorType)
+	prepare := prepareDataMap[1]
+	assert.EqualValues(t, querypb.Type_INT8, prepare.ParamsType[0])
+	assert.Equal(t, sqltypes.Int64BindVariable(7), prepare.BindVars["v1"])
+	assert.Equal(t, map[string]string{"trace_id": "abc"}, prepare.QueryAttributes)
+
+	// A statement without parameters has its attributes sent along with
+	// the PARAMETER_COUNT_AVAILABLE flag.
+	data = []byte{
+		ComStmtExecute, 2, 0, 0, 0, ParameterCountAvailable | CursorTypeReadOnly, 1, 0, 0, 0,
+		1, 0x00, 0x01,
+		0xfd, 0x00, 8, 't', 'r', 'a', 'c', 'e', '_', 'i', 'd',
+		3, 'd', 'e', 'f',
+	}
+	stmtID, cursorType, err = sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, stmtID)
+	assert.Equal(t, CursorTypeReadOnly, cursorType)
+	assert.Equal(t, map[string]string{"trace_id": "def"}, prepareDataMap[2].QueryAttributes)
+
+	// The parameter count cannot be lower than the one of the statement.
+	data = []byte{ComStmtExecute, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0}
+	_, _, err = sConn.parseComStmtExecute(prepareDataMap, data)
+	assert.ErrorContains(t, err, "parameter count 0 is lower than the 1 parameters of the statement")
+}
+
 func TestComStmtExecuteUpdStmt(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
@@ -203,6 +203,43 @@ func (set Mysql56GTIDSet) Last() string {
 	return buf.String()
 }
 
+// GTIDs returns the individual GTIDs of the set, sorted by SID and then by
+// sequence number. The caller should check the size of the set with
+// GTIDCount first, since every GTID of every interval is returned.
+func (set Mysql56GTIDSet) GTIDs() []Mysql56GTID {
+	var gtids []Mysql56GTID
+	for _, sid := range set.SIDs() {
+		for _, iv := range set[sid] {
+			for seq := iv.start; seq <= iv.end; seq++ {
+				gtids = append(gtids, Mysql56GTID{Server: sid, Sequence: seq})
+			}
+		}
+	}
+	return gtids
+}
+
+// Split returns the first n GTIDs of the set, in the order of GTIDs, and the
+// other GTIDs of the set, without enumerating them.
+func (set Mysql56GTIDSet) Split(n int64) (head, tail Mysql56GTIDSet) {
+	head, tail = make(Mysql56GTIDSet), make(Mysql56GTIDSet)
+	for _, sid := range set.SIDs() {
+		for _, iv := range set[sid] {
+			switch count := iv.end - iv.start + 1; {
+			case n >= count:
+				head[sid] = append(head[sid], iv)
+				n -= count
+			case n > 0:
+				head[sid] = append(head[sid], interval{start: iv.start, end: iv.start + n - 1})
+				tail[sid] = append(tail[sid], interval{start: iv.start + n, end: iv.end})
+				n = 0
+			default:
+				tail[sid] = append(tail[sid], iv)
+			}
+		}
+	}
+	return head, tail
+}
+
 // Flavor implements GTIDSet.
 func (Mysql56GTIDSet) Flavor() string { return Mysql56FlavorID }
 
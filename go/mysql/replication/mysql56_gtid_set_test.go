@@ -751,6 +751,46 @@ func TestGTIDCount(t *testing.T) {
 	}
 }
 
+func TestMysql56GTIDSetGTIDs(t *testing.T) {
+	sid1 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	sid2 := SID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 255}
+
+	set, err := ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0eff:7,00010203-0405-0607-0809-0a0b0c0d0e0f:1-3:5")
+	require.NoError(t, err)
+	want := []Mysql56GTID{
+		{Server: sid1, Sequence: 1},
+		{Server: sid1, Sequence: 2},
+		{Server: sid1, Sequence: 3},
+		{Server: sid1, Sequence: 5},
+		{Server: sid2, Sequence: 7},
+	}
+	assert.Equal(t, want, set.GTIDs())
+
+	assert.Empty(t, Mysql56GTIDSet{}.GTIDs())
+}
+
+func TestMysql56GTIDSetSplit(t *testing.T) {
+	set, err := ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0eff:7-1000000,00010203-0405-0607-0809-0a0b0c0d0e0f:1-3:5")
+	require.NoError(t, err)
+
+	tests := []struct {
+		n    int64
+		head string
+		tail string
+	}{
+		{0, "", set.String()},
+		{2, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-2", "00010203-0405-0607-0809-0a0b0c0d0e0f:3:5,00010203-0405-0607-0809-0a0b0c0d0eff:7-1000000"},
+		{4, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-3:5", "00010203-0405-0607-0809-0a0b0c0d0eff:7-1000000"},
+		{6, "00010203-0405-0607-0809-0a0b0c0d0e0f:1-3:5,00010203-0405-0607-0809-0a0b0c0d0eff:7-8", "00010203-0405-0607-0809-0a0b0c0d0eff:9-1000000"},
+		{2000000, set.String(), ""},
+	}
+	for _, tt := range tests {
+		head, tail := set.Split(tt.n)
+		assert.Equal(t, tt.head, head.String(), "head of %d", tt.n)
+		assert.Equal(t, tt.tail, tail.String(), "tail of %d", tt.n)
+	}
+}
+
 func TestErrantGTIDsOnReplica(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"fmt"
+)
+
+// PostgresLSNFlavorID is the string identifier for the positions of
+// PostgreSQL sources, which are Log Sequence Numbers of their WAL.
+const PostgresLSNFlavorID = "PostgresLSN"
+
+// parsePostgresLSN is registered as a GTID parser.
+func parsePostgresLSN(s string) (GTID, error) {
+	var hi, lo uint32
+	var rest string
+	if n, _ := fmt.Sscanf(s, "%X/%X%s", &hi, &lo, &rest); n != 2 {
+		return nil, fmt.Errorf("invalid PostgresLSN GTID (%v): expecting hi/lo in hexadecimal", s)
+	}
+	return PostgresLSN(uint64(hi)<<32 | uint64(lo)), nil
+}
+
+// ParsePostgresLSNSet is registered as a GTIDSet parser.
+func ParsePostgresLSNSet(s string) (GTIDSet, error) {
+	gtid, err := parsePostgresLSN(s)
+	if err != nil {
+		return nil, err
+	}
+	return gtid.(PostgresLSN), nil
+}
+
+// PostgresLSN implements GTID and GTIDSet. Since the WAL of PostgreSQL is
+// linear, a position is a single LSN, which contains all the transactions
+// committed before it.
+type PostgresLSN uint64
+
+// String implements GTID.String(). It uses the format of PostgreSQL.
+func (lsn PostgresLSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
+}
+
+// Flavor implements GTID.Flavor().
+func (lsn PostgresLSN) Flavor() string {
+	return PostgresLSNFlavorID
+}
+
+// SequenceDomain implements GTID.SequenceDomain().
+func (lsn PostgresLSN) SequenceDomain() any {
+	return nil
+}
+
+// SourceServer implements GTID.SourceServer().
+func (lsn PostgresLSN) SourceServer() any {
+	return nil
+}
+
+// SequenceNumber implements GTID.SequenceNumber().
+func (lsn PostgresLSN) SequenceNumber() any {
+	return uint64(lsn)
+}
+
+// GTIDSet implements GTID.GTIDSet().
+func (lsn PostgresLSN) GTIDSet() GTIDSet {
+	return lsn
+}
+
+// ContainsGTID implements GTIDSet.ContainsGTID().
+func (lsn PostgresLSN) ContainsGTID(other GTID) bool {
+	if other == nil {
+		return true
+	}
+	lsnOther, ok := other.(PostgresLSN)
+	if !ok {
+		return false
+	}
+	return lsnOther <= lsn
+}
+
+// Contains implements GTIDSet.Contains().
+func (lsn PostgresLSN) Contains(other GTIDSet) bool {
+	if other == nil {
+		return false
+	}
+	lsnOther, ok := other.(PostgresLSN)
+	if !ok {
+		return false
+	}
+	return lsn.ContainsGTID(lsnOther)
+}
+
+// Equal implements GTIDSet.Equal().
+func (lsn PostgresLSN) Equal(other GTIDSet) bool {
+	lsnOther, ok := other.(PostgresLSN)
+	if !ok {
+		return false
+	}
+	return lsn == lsnOther
+}
+
+// AddGTID implements GTIDSet.AddGTID().
+func (lsn PostgresLSN) AddGTID(other GTID) GTIDSet {
+	lsnOther, ok := other.(PostgresLSN)
+	if !ok || lsnOther < lsn {
+		return lsn
+	}
+	return lsnOther
+}
+
+// Union implements GTIDSet.Union().
+func (lsn PostgresLSN) Union(other GTIDSet) GTIDSet {
+	lsnOther, ok := other.(PostgresLSN)
+	if !ok || lsnOther < lsn {
+		return lsn
+	}
+	return lsnOther
+}
+
+// Last implements GTIDSet.Last().
+func (lsn PostgresLSN) Last() string {
+	return lsn.String()
+}
+
+func init() {
+	gtidParsers[PostgresLSNFlavorID] = parsePostgresLSN
+	gtidSetParsers[PostgresLSNFlavorID] = ParsePostgresLSNSet
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePostgresLSN(t *testing.T) {
+	testcases := []struct {
+		input string
+		want  PostgresLSN
+		err   bool
+	}{
+		{input: "0/0", want: 0},
+		{input: "16/B374D848", want: 0x16B374D848},
+		{input: "FFFFFFFF/FFFFFFFF", want: 0xFFFFFFFFFFFFFFFF},
+		{input: "16/b374d848", want: 0x16B374D848},
+		{input: "16", err: true},
+		{input: "16/B374D848/1", err: true},
+		{input: "mysql-bin.000001:4", err: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parsePostgresLSN(tc.input)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPostgresLSNPosition(t *testing.T) {
+	pos, err := DecodePosition("PostgresLSN/16/B374D848")
+	require.NoError(t, err)
+	assert.Equal(t, "PostgresLSN/16/B374D848", EncodePosition(pos))
+
+	earlier, err := DecodePosition("PostgresLSN/16/B374D000")
+	require.NoError(t, err)
+	assert.True(t, pos.AtLeast(earlier))
+	assert.False(t, earlier.AtLeast(pos))
+	assert.True(t, pos.AtLeast(pos))
+	assert.True(t, pos.Equal(pos))
+	assert.False(t, pos.Equal(earlier))
+
+	assert.Equal(t, pos.GTIDSet, earlier.GTIDSet.Union(pos.GTIDSet))
+	assert.Equal(t, pos.GTIDSet, pos.GTIDSet.AddGTID(PostgresLSN(0x16B374D000)))
+	assert.False(t, pos.GTIDSet.Contains(FilePosGTID{File: "mysql-bin.000001", Pos: 4}))
+}
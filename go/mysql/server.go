@@ -18,10 +18,13 @@ package mysql
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/tls"
 	"io"
 	"net"
+	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -65,8 +68,13 @@ var (
 	connRefuse = stats.NewCounter("MysqlServerConnRefused", "Connections refused by MySQL server")
 	connSlow   = stats.NewCounter("MysqlServerConnSlow", "Connections that took more than the configured mysql_slow_connect_warn_threshold to establish")
 
+	preparedStmtCount   = stats.NewGauge("MysqlServerPreparedStatements", "Prepared statements of the active MySQL server connections")
+	preparedStmtEvicted = stats.NewCounter("MysqlServerPreparedStatementsEvicted", "Prepared statements evicted because their connection reached its maximum number of prepared statements")
+
 	connCountByTLSVer = stats.NewGaugesWithSingleLabel("MysqlServerConnCountByTLSVer", "Active MySQL server connections by TLS version", "tls")
 	connCountPerUser  = stats.NewGaugesWithSingleLabel("MysqlServerConnCountPerUser", "Active MySQL server connections per user", "count")
+	connCompressed    = stats.NewGaugesWithSingleLabel("MysqlServerConnCountByCompression", "Active MySQL server connections using the compressed protocol, by algorithm", "algorithm")
+	connRefusePerUser = stats.NewCountersWithSingleLabel("MysqlServerConnRefusedPerUser", "Connections refused by MySQL server because their user reached the maximum number of connections per user", "user")
 	_                 = stats.NewGaugeFunc("MysqlServerConnCountUnauthenticated", "Active MySQL server connections that haven't authenticated yet", func() int64 {
 		totalUsers := int64(0)
 		for _, v := range connCountPerUser.Counts() {
@@ -176,6 +184,12 @@ type Listener struct {
 	// atomic value stores *tls.Config
 	TLSConfig atomic.Value
 
+	// CachingSha2PrivateKey is the RSA key of the caching_sha2_password
+	// full authentication on the connections without TLS. The clients ask
+	// for its public key to encrypt their password with it. Without it,
+	// caching_sha2_password needs TLS or a Unix socket.
+	CachingSha2PrivateKey atomic.Pointer[rsa.PrivateKey]
+
 	// AllowClearTextWithoutTLS needs to be set for the
 	// mysql_clear_password authentication method to be accepted
 	// by the server when TLS is not in use.
@@ -185,6 +199,31 @@ type Listener struct {
 	// beyond which a warning is logged to identify the slow connection
 	SlowConnectWarnThreshold atomic.Int64
 
+	// MaxPreparedStatements if non-zero specifies the maximum number of
+	// prepared statements of a connection. The least recently used statement
+	// is evicted when a connection prepares one more.
+	MaxPreparedStatements atomic.Int64
+
+	// QueryAttributes needs to be set for the server to accept the
+	// query attributes of the clients, which are then available to
+	// the handler with Conn.QueryAttributes and PrepareData.QueryAttributes.
+	QueryAttributes atomic.Bool
+
+	// MaxConnectionsPerUser if non-zero specifies the maximum number of
+	// connections of a user, across the listeners. The connections of a
+	// user beyond it are refused after the authentication.
+	MaxConnectionsPerUser atomic.Int64
+
+	// Compression needs to be set for the server to advertise the
+	// compressed protocol, with zlib and zstd, which the clients which ask
+	// for it use once the handshake is done.
+	Compression atomic.Bool
+
+	// LocalInfile needs to be set for the server to advertise
+	// CLIENT_LOCAL_FILES, and to let the handler read the files of
+	// LOAD DATA LOCAL INFILE from the clients with Conn.LocalInfile.
+	LocalInfile atomic.Bool
+
 	// The following parameters are changed by the Accept routine.
 
 	// Incrementing ID for connection id.
@@ -383,18 +422,21 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 			c.returnReader()
 		}
 
+		c.clearPrepareData()
 		conn.Close()
 	}()
 
 	// Tell the handler about the connection coming and going.
 	l.handler.NewConnection(c)
 	defer l.handler.ConnectionClosed(c)
+	// The stream of an open cursor must end before the connection is closed.
+	defer c.closeCursor()
 
 	// Adjust the count of open connections
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil, l.QueryAttributes.Load(), l.Compression.Load(), l.LocalInfile.Load())
 	if err != nil {
 		if err != io.EOF {
 			log.Errorf("Cannot send HandshakeV10 packet to %s: %v", c, err)
@@ -511,11 +553,27 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		c.writeErrorPacketFromError(err)
 		return
 	}
+	if mfa, ok := l.authServer.(MultiFactorAuthServer); ok {
+		factors := mfa.AdditionalAuthFactors(c, user, userData)
+		if err := c.authenticateAdditionalFactors(user, factors, l.AllowClearTextWithoutTLS.Load(), conn.RemoteAddr()); err != nil {
+			log.Warningf("Error authenticating user %s with its additional factors: %v", user, err)
+			c.writeErrorPacketFromError(err)
+			return
+		}
+	}
 
 	c.User = user
 	c.UserData = userData
 
 	if c.User != "" {
+		maxConns := l.MaxConnectionsPerUser.Load()
+		if !acquireUserConnection(c.User, maxConns) {
+			connRefusePerUser.Add(c.User, 1)
+			log.Warningf("Refusing connection from %s: user %s has %d connections", c, c.User, maxConns)
+			c.writeErrorPacket(sqlerror.ERTooManyUserConnections, sqlerror.SSUnknownSQLState, "User '%s' has exceeded the 'max_user_connections' resource (current value: %d)", c.User, maxConns)
+			return
+		}
+		defer releaseUserConnection(c.User)
 		connCountPerUser.Add(c.User, 1)
 		defer connCountPerUser.Add(c.User, -1)
 	}
@@ -536,6 +594,11 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		log.Errorf("Cannot write OK packet to %s: %v", c, err)
 		return
 	}
+	if algorithm := c.negotiatedCompression(); algorithm != "" {
+		c.enableCompression(algorithm, c.zstdCompressionLevel)
+		connCompressed.Add(algorithm, 1)
+		defer connCompressed.Add(algorithm, -1)
+	}
 
 	// Record how long we took to establish the connection
 	timings.Record(connectTimingKey, acceptTime)
@@ -552,6 +615,7 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	l.handler.ConnectionReady(c)
 
 	for {
+		c.idleSince.Store(time.Now().UnixNano())
 		kontinue := c.handleNextCommand(l.handler)
 		// before going for next command check if the connection should be closed or not.
 		if !kontinue || c.IsMarkedForClose() {
@@ -560,11 +624,58 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	}
 }
 
+// userConnections counts the connections of each user across the listeners,
+// for their MaxConnectionsPerUser.
+var userConnections = struct {
+	mu    sync.Mutex
+	count map[string]int64
+}{count: make(map[string]int64)}
+
+// acquireUserConnection counts a connection of the user, unless the user
+// already has maxConns connections. maxConns is not limited if it is 0.
+func acquireUserConnection(user string, maxConns int64) bool {
+	userConnections.mu.Lock()
+	defer userConnections.mu.Unlock()
+	if maxConns > 0 && userConnections.count[user] >= maxConns {
+		return false
+	}
+	userConnections.count[user]++
+	return true
+}
+
+func releaseUserConnection(user string) {
+	userConnections.mu.Lock()
+	defer userConnections.mu.Unlock()
+	userConnections.count[user]--
+	if userConnections.count[user] <= 0 {
+		delete(userConnections.count, user)
+	}
+}
+
 // Close stops the listener, which prevents accept of any new connections. Existing connections won't be closed.
 func (l *Listener) Close() {
 	l.listener.Close()
 }
 
+// File returns a copy of the file of the listening socket, to pass the
+// socket to another process. The socket file of a unix listener is then not
+// removed when the listener is closed, as the other process still uses it.
+func (l *Listener) File() (*os.File, error) {
+	listener := l.listener
+	if proxyListener, ok := listener.(*proxyproto.Listener); ok {
+		listener = proxyListener.Listener
+	}
+	switch listener := listener.(type) {
+	case *net.TCPListener:
+		return listener.File()
+	case *net.UnixListener:
+		listener.SetUnlinkOnClose(false)
+		return listener.File()
+	default:
+		return nil, vterrors.Errorf(vtrpc.Code_UNIMPLEMENTED, "cannot get the file of a %T listener", listener)
+	}
+}
+
 // Shutdown closes listener and fails any Ping requests from existing connections.
 // This can be used for graceful shutdown, to let clients know that they should reconnect to another server.
 func (l *Listener) Shutdown() {
@@ -575,7 +686,7 @@ func (l *Listener) Shutdown() {
 
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
 // It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool) ([]byte, error) {
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS, enableQueryAttributes, enableCompression, enableLocalInfile bool) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -588,10 +699,23 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		CapabilityClientSessionTrack
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
+	if enableQueryAttributes {
+		capabilities |= CapabilityClientQueryAttributes
+	}
+	if enableCompression {
+		capabilities |= CapabilityClientCompress | CapabilityClientZstdCompressionAlgorithm
+	}
+	if enableLocalInfile {
+		capabilities |= CapabilityClientLocalFiles
+	}
+	if _, ok := authServer.(MultiFactorAuthServer); ok {
+		capabilities |= CapabilityClientMultiFactorAuthentication
+	}
 
 	// Grab the default auth method. This can only be either
 	// mysql_native_password or caching_sha2_password. Both
@@ -709,7 +833,10 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 	// later in the protocol. If we re-received the handshake packet
 	// after SSL negotiation, do not overwrite capabilities.
 	if firstTime {
-		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows)
+		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows | CapabilityClientSessionTrack)
+		if l.QueryAttributes.Load() {
+			c.Capabilities |= clientFlags & CapabilityClientQueryAttributes
+		}
 	}
 
 	// set connection capability for executing multi statements
@@ -717,6 +844,22 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 		c.Capabilities |= CapabilityClientMultiStatements
 	}
 
+	// The compressed protocol the client asks for is used once the
+	// handshake is done.
+	if l.Compression.Load() {
+		c.Capabilities |= clientFlags & (CapabilityClientCompress | CapabilityClientZstdCompressionAlgorithm)
+	}
+
+	// The client sends the files of LOAD DATA LOCAL INFILE.
+	if l.LocalInfile.Load() {
+		c.Capabilities |= clientFlags & CapabilityClientLocalFiles
+	}
+
+	// The client answers the additional authentication factors.
+	if _, ok := l.authServer.(MultiFactorAuthServer); ok {
+		c.Capabilities |= clientFlags & CapabilityClientMultiFactorAuthentication
+	}
+
 	// Max packet size. Don't do anything with this now.
 	// See doc.go for more information.
 	_, pos, ok = readUint32(data, pos)
@@ -809,8 +952,19 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 
 	// Decode connection attributes send by the client
 	if clientFlags&CapabilityClientConnAttr != 0 {
-		if _, _, err := parseConnAttrs(data, pos); err != nil {
+		attrs, attrsPos, err := parseConnAttrs(data, pos)
+		if err != nil {
 			log.Warningf("Decode connection attributes send by the client: %v", err)
+			attrsPos = len(data)
+		}
+		c.ConnectAttributes = attrs
+		pos = attrsPos
+	}
+
+	// The level of the zstd compression the client asks for, if any.
+	if c.Capabilities&CapabilityClientZstdCompressionAlgorithm != 0 {
+		if level, _, ok := readByte(data, pos); ok {
+			c.zstdCompressionLevel = int(level)
 		}
 	}
 
@@ -18,6 +18,8 @@ package mysql
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"fmt"
 	"net"
@@ -537,6 +539,54 @@ func TestConnCounts(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 }
 
+func TestMaxConnectionsPerUser(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	user := "maxConnectionsUser1"
+	passwd := "password1"
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries[user] = []*AuthServerStaticEntry{{
+		Password: passwd,
+		UserData: "userData1",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	l.MaxConnectionsPerUser.Store(1)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: user,
+		Pass:  passwd,
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+
+	// The second connection of the user is refused.
+	_, err = Connect(ctx, params)
+	assert.ErrorContains(t, err, "User 'maxConnectionsUser1' has exceeded the 'max_user_connections' resource (current value: 1)")
+	var sqlErr *sqlerror.SQLError
+	require.ErrorAs(t, err, &sqlErr)
+	assert.Equal(t, sqlerror.ERTooManyUserConnections, sqlErr.Number())
+	assert.EqualValues(t, 1, connRefusePerUser.Counts()[user])
+
+	// The user connects again once its connection is closed.
+	c.Close()
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		c, err := Connect(ctx, params)
+		if assert.NoError(t, err) {
+			c.Close()
+		}
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
 func checkCountsForUser(t assert.TestingT, user string, expected int64) {
 	connCounts := connCountPerUser.Counts()
 
@@ -1212,6 +1262,121 @@ func TestCachingSha2PasswordAuthWithoutTLS(t *testing.T) {
 	}
 }
 
+func TestCachingSha2PasswordAuthWithRSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		authServer *AuthServerStatic
+	}{
+		{"fast", NewAuthServerStaticWithAuthMethodDescription("", "", 0, CachingSha2Password)},
+		{"full", newAuthServerAlwaysFallback("", "", 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := utils.LeakCheckContext(t)
+			th := &testHandler{}
+
+			authServer := tt.authServer
+			authServer.entries["user1"] = []*AuthServerStaticEntry{
+				{Password: "password1"},
+			}
+			defer authServer.close()
+
+			l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+			require.NoError(t, err, "NewListener failed: %v", err)
+			l.CachingSha2PrivateKey.Store(privateKey)
+			host, port := getHostPort(t, l.Addr())
+			params := &ConnParams{
+				Host:    host,
+				Port:    port,
+				Uname:   "user1",
+				Pass:    "password1",
+				SslMode: vttls.Disabled,
+			}
+			go l.Accept()
+			defer cleanupListener(ctx, l, params)
+
+			conn, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			// Run a 'select rows' command with results.
+			result, err := conn.ExecuteFetch("select rows", 10000, true)
+			require.NoError(t, err)
+			utils.MustMatch(t, result, selectRowsResult)
+
+			// A wrong password over the RSA exchange is rejected.
+			params.Pass = "password2"
+			_, err = Connect(ctx, params)
+			require.ErrorContains(t, err, "Access denied for user 'user1'")
+		})
+	}
+}
+
+// authServerMultiFactor is an AuthServerStatic that requires a second
+// factor, checked with mysql_native_password against a second set of
+// entries.
+type authServerMultiFactor struct {
+	*AuthServerStatic
+	factor2 *AuthServerStatic
+}
+
+func (a *authServerMultiFactor) AdditionalAuthFactors(conn *Conn, user string, userData Getter) []AuthMethod {
+	return []AuthMethod{NewMysqlNativeAuthMethod(a.factor2, a.factor2)}
+}
+
+func TestMultiFactorAuth(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	authServer := &authServerMultiFactor{
+		AuthServerStatic: NewAuthServerStatic("", "", 0),
+		factor2:          NewAuthServerStatic("", "", 0),
+	}
+	authServer.entries["user1"] = []*AuthServerStaticEntry{
+		{Password: "password1"},
+	}
+	authServer.factor2.entries["user1"] = []*AuthServerStaticEntry{
+		{Password: "password2"},
+	}
+	defer authServer.close()
+	defer authServer.factor2.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err, "NewListener failed: %v", err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "user1",
+		Pass:  "password1",
+		Pass2: "password2",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	conn, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	result, err := conn.ExecuteFetch("select rows", 10000, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, result, selectRowsResult)
+
+	// A wrong second factor is rejected.
+	params.Pass2 = "password1"
+	_, err = Connect(ctx, params)
+	require.ErrorContains(t, err, "Access denied for user 'user1'")
+
+	// A client without a second factor does not advertise the
+	// capability and is rejected.
+	params.Pass2 = ""
+	_, err = Connect(ctx, params)
+	require.ErrorContains(t, err, "Access denied for user 'user1'")
+}
+
 func checkCountForTLSVer(t *testing.T, version string, expected int64) {
 	connCounts := connCountByTLSVer.Counts()
 	count, ok := connCounts[version]
@@ -1245,6 +1410,7 @@ func TestErrorCodes(t *testing.T) {
 
 	client, err := Connect(ctx, params)
 	require.NoError(t, err)
+	defer client.Close()
 
 	// Test that the right mysql errno/sqlstate are returned for various
 	// internal vitess errors
@@ -1427,6 +1593,7 @@ func TestListenerShutdown(t *testing.T) {
 
 	conn, err := Connect(ctx, params)
 	require.NoError(t, err)
+	defer conn.Close()
 
 	err = conn.Ping()
 	require.NoError(t, err)
@@ -1475,6 +1642,29 @@ func TestParseConnAttrs(t *testing.T) {
 	}
 }
 
+func TestParseClientHandshakePacketConnAttrs(t *testing.T) {
+	l := &Listener{}
+	c := &Conn{}
+
+	attrs := []byte{0x0c}
+	attrs = append(attrs, "program_name"...)
+	attrs = append(attrs, 0x06)
+	attrs = append(attrs, "orders"...)
+
+	data := make([]byte, 4+4+1+23)
+	writeUint32(data, 0, CapabilityClientProtocol41|CapabilityClientSecureConnection|CapabilityClientPluginAuth|CapabilityClientConnAttr)
+	data = append(data, "user\x00"...)
+	data = append(data, 0x00)
+	data = append(data, MysqlNativePassword+"\x00"...)
+	data = append(data, byte(len(attrs)))
+	data = append(data, attrs...)
+
+	user, _, _, err := l.parseClientHandshakePacket(c, true, data)
+	require.NoError(t, err)
+	require.Equal(t, "user", user)
+	require.Equal(t, map[string]string{"program_name": "orders"}, c.ConnectAttributes)
+}
+
 func TestServerFlush(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 	mysqlServerFlushDelay := 10 * time.Millisecond
@@ -1550,3 +1740,27 @@ func TestTcpKeepAlive(t *testing.T) {
 	err = setTcpConnProperties(th.lastConn.conn.(*net.TCPConn), 0)
 	require.ErrorContains(t, err, "unable to enable keepalive on tcp connection")
 }
+
+func TestListenerFile(t *testing.T) {
+	th := &testHandler{}
+	authServer := NewAuthServerStatic("", "", 0)
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	file, err := l.File()
+	require.NoError(t, err)
+	defer file.Close()
+
+	// The socket keeps listening after the listener is closed, for the process
+	// the file is passed to.
+	l.Close()
+	inherited, err := net.FileListener(file)
+	require.NoError(t, err)
+	defer inherited.Close()
+	assert.Equal(t, l.Addr().String(), inherited.Addr().String())
+
+	conn, err := net.Dial("tcp", inherited.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+}
@@ -67,7 +67,8 @@ const (
 	ERLockWaitTimeout = ErrorCode(1205)
 
 	// unavailable
-	ERServerShutdown = ErrorCode(1053)
+	ERServerShutdown           = ErrorCode(1053)
+	ERClientInteractionTimeout = ErrorCode(4031)
 
 	// not found
 	ERDbDropExists          = ErrorCode(1008)
@@ -121,6 +122,7 @@ const (
 	ERSPDoesNotExist                = ErrorCode(1305)
 	ERNoDefaultForField             = ErrorCode(1364)
 	ErSPNotVarArg                   = ErrorCode(1414)
+	ERStmtHasNoOpenCursor           = ErrorCode(1421)
 	ERRowIsReferenced2              = ErrorCode(1451)
 	ErNoReferencedRow2              = ErrorCode(1452)
 	ERInnodbIndexCorrupt            = ErrorCode(1817)
@@ -300,6 +302,12 @@ const (
 
 	// server not available
 	ERServerIsntAvailable = ErrorCode(3168)
+
+	// clone
+	ERCloneRestartServerFailed = ErrorCode(3707)
+
+	// load data local infile
+	ERClientLocalFilesDisabled = ErrorCode(3948)
 )
 
 // HandlerErrorCode is for errors thrown by the handler, and which are then embedded in other errors.
@@ -233,6 +233,7 @@ var stateToMysqlCode = map[vterrors.State]mysqlCode{
 	vterrors.WrongValueCountOnRow:                {num: ERWrongValueCountOnRow, state: SSWrongValueCountOnRow},
 	vterrors.WrongArguments:                      {num: ERWrongArguments, state: SSUnknownSQLState},
 	vterrors.ViewWrongList:                       {num: ERViewWrongList, state: SSUnknownSQLState},
+	vterrors.FieldSpecifiedTwice:                 {num: ERFieldSpecifiedTwice, state: SSClientError},
 	vterrors.UnknownStmtHandler:                  {num: ERUnknownStmtHandler, state: SSUnknownSQLState},
 	vterrors.KeyDoesNotExist:                     {num: ERKeyDoesNotExist, state: SSClientError},
 	vterrors.UnknownTimeZone:                     {num: ERUnknownTimeZone, state: SSUnknownSQLState},
@@ -262,6 +263,7 @@ var stateToMysqlCode = map[vterrors.State]mysqlCode{
 	vterrors.BadNullError:                        {num: ERBadNullError, state: SSConstraintViolation},
 	vterrors.InvalidGroupFuncUse:                 {num: ERInvalidGroupFuncUse, state: SSUnknownSQLState},
 	vterrors.VectorConversion:                    {num: ERVectorConversion, state: SSUnknownSQLState},
+	vterrors.ClientLocalFilesDisabled:            {num: ERClientLocalFilesDisabled, state: SSClientError},
 	vterrors.CTERecursiveRequiresSingleReference: {num: ERCTERecursiveRequiresSingleReference, state: SSUnknownSQLState},
 	vterrors.CTERecursiveRequiresUnion:           {num: ERCTERecursiveRequiresUnion, state: SSUnknownSQLState},
 	vterrors.CTERecursiveForbidsAggregation:      {num: ERCTERecursiveForbidsAggregation, state: SSUnknownSQLState},
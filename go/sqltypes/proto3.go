@@ -107,6 +107,8 @@ func ResultToProto3(qr *Result) *querypb.QueryResult {
 		Rows:                RowsToProto3(qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		StatusFlags:         uint32(qr.StatusFlags),
+		MoreResults:         ResultsToProto3(qr.MoreResults),
 	}
 }
 
@@ -124,6 +126,8 @@ func Proto3ToResult(qr *querypb.QueryResult) *Result {
 		Rows:                proto3ToRows(qr.Fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		StatusFlags:         uint16(qr.StatusFlags),
+		MoreResults:         Proto3ToResults(qr.MoreResults),
 	}
 }
 
@@ -142,6 +146,7 @@ func CustomProto3ToResult(fields []*querypb.Field, qr *querypb.QueryResult) *Res
 		Rows:                proto3ToRows(fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		StatusFlags:         uint16(qr.StatusFlags),
 	}
 }
 
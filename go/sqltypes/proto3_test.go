@@ -85,6 +85,44 @@ func TestResult(t *testing.T) {
 	}
 }
 
+func TestResultMoreResults(t *testing.T) {
+	fields := []*querypb.Field{{
+		Name: "col1",
+		Type: Int64,
+	}}
+	sqlResult := &Result{
+		Fields: fields,
+		Rows:   [][]Value{{TestValue(Int64, "1")}},
+		MoreResults: []*Result{{
+			Fields: fields,
+			Rows:   [][]Value{{TestValue(Int64, "2")}},
+		}, {
+			RowsAffected: 1,
+			StatusFlags:  ServerStatusInTrans,
+		}},
+	}
+	p3Result := &querypb.QueryResult{
+		Fields: fields,
+		Rows:   []*querypb.Row{{Lengths: []int64{1}, Values: []byte("1")}},
+		MoreResults: []*querypb.QueryResult{{
+			Fields: fields,
+			Rows:   []*querypb.Row{{Lengths: []int64{1}, Values: []byte("2")}},
+		}, {
+			RowsAffected: 1,
+			StatusFlags:  ServerStatusInTrans,
+		}},
+	}
+	p3converted := ResultToProto3(sqlResult)
+	if !proto.Equal(p3converted, p3Result) {
+		t.Errorf("P3:\n%v, want\n%v", p3converted, p3Result)
+	}
+
+	reverse := Proto3ToResult(p3Result)
+	if !reverse.Equal(sqlResult) {
+		t.Errorf("reverse:\n%#v, want\n%#v", reverse, sqlResult)
+	}
+}
+
 func TestResults(t *testing.T) {
 	fields1 := []*querypb.Field{{
 		Name: "col1",
@@ -36,6 +36,10 @@ type Result struct {
 	SessionStateChanges string           `json:"session_state_changes"`
 	StatusFlags         uint16           `json:"status_flags"`
 	Info                string           `json:"info"`
+	// MoreResults are the result sets following this one, for the
+	// statements returning several of them such as a CALL. The last one
+	// holds the status of the statement.
+	MoreResults []*Result `json:"more_results,omitempty"`
 }
 
 //goland:noinspection GoUnusedConst
@@ -119,6 +123,12 @@ func (result *Result) Copy() *Result {
 			out.Rows = append(out.Rows, CopyRow(r))
 		}
 	}
+	if result.MoreResults != nil {
+		out.MoreResults = make([]*Result, 0, len(result.MoreResults))
+		for _, r := range result.MoreResults {
+			out.MoreResults = append(out.MoreResults, r.Copy())
+		}
+	}
 	return out
 }
 
@@ -131,8 +141,29 @@ func (result *Result) ShallowCopy() *Result {
 		RowsAffected:        result.RowsAffected,
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
+		StatusFlags:         result.StatusFlags,
 		Rows:                result.Rows,
+		MoreResults:         result.MoreResults,
+	}
+}
+
+// ResultSets returns the result sets of the result: the result itself,
+// without its MoreResults, followed by its MoreResults. All of them but the
+// last have SERVER_MORE_RESULTS_EXISTS set in their status flags, which is
+// how the result sets are delimited when they are streamed.
+func (result *Result) ResultSets() []*Result {
+	first := result.ShallowCopy()
+	first.MoreResults = nil
+	sets := append([]*Result{first}, result.MoreResults...)
+	for i, set := range sets[:len(sets)-1] {
+		if !set.IsMoreResultsExists() {
+			set = set.ShallowCopy()
+			set.MoreResults = nil
+			set.StatusFlags |= ServerMoreResultsExists
+			sets[i] = set
+		}
 	}
+	return sets
 }
 
 // Metadata creates a shallow copy of Result without the rows useful
@@ -208,14 +239,15 @@ func (result *Result) Equal(other *Result) bool {
 		return false
 	}
 
-	// Compare Fields, RowsAffected, InsertID, Rows.
+	// Compare Fields, RowsAffected, InsertID, Rows, MoreResults.
 	return FieldsEqual(result.Fields, other.Fields) &&
 		result.RowsAffected == other.RowsAffected &&
 		result.InsertID == other.InsertID &&
 		result.InsertIDChanged == other.InsertIDChanged &&
 		slices.EqualFunc(result.Rows, other.Rows, func(a, b Row) bool {
 			return RowEqual(a, b)
-		})
+		}) &&
+		ResultsEqual(result.MoreResults, other.MoreResults)
 }
 
 // ResultsEqual compares two arrays of Result.
@@ -348,6 +380,7 @@ func (result *Result) AppendResult(src *Result) {
 		result.Fields = src.Fields
 	}
 	result.Rows = append(result.Rows, src.Rows...)
+	result.MoreResults = append(result.MoreResults, src.MoreResults...)
 }
 
 // Named returns a NamedResult based on this struct
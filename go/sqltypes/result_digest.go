@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqltypes
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// ResultDigest is an order independent summary of the rows of one or more
+// results. It is used to compare the results of the same query on two
+// different sources, without buffering the rows.
+type ResultDigest struct {
+	Rows int
+	Sum  uint64
+}
+
+// Add adds the rows of the result to the digest. The digest does not depend
+// on the order of the rows, as results without an explicit ordering can be
+// returned in any order by different sources.
+func (d *ResultDigest) Add(qr *Result) {
+	if qr == nil {
+		return
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	for _, row := range qr.Rows {
+		h := fnv.New64a()
+		for _, v := range row {
+			if v.IsNull() {
+				_, _ = h.Write([]byte{0})
+				continue
+			}
+			// Only the raw value is hashed, so that the same value with a
+			// different type (e.g. INT vs BIGINT) is considered a match.
+			_, _ = h.Write([]byte{1})
+			_, _ = h.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(v.Raw())))])
+			_, _ = h.Write(v.Raw())
+		}
+		d.Rows++
+		d.Sum += h.Sum64()
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqltypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultDigest(t *testing.T) {
+	fields := MakeTestFields("id|name", "int64|varchar")
+	digest := func(results ...*Result) ResultDigest {
+		var d ResultDigest
+		for _, qr := range results {
+			d.Add(qr)
+		}
+		return d
+	}
+
+	ordered := digest(MakeTestResult(fields, "1|a", "2|b"))
+	require.Equal(t, 2, ordered.Rows)
+	// The order of the rows and the way they are split into results do not matter.
+	require.Equal(t, ordered, digest(MakeTestResult(fields, "2|b"), MakeTestResult(fields, "1|a")))
+	// The type of the values does not matter.
+	require.Equal(t, ordered, digest(MakeTestResult(MakeTestFields("id|name", "int32|varbinary"), "1|a", "2|b")))
+
+	require.NotEqual(t, ordered, digest(MakeTestResult(fields, "1|a", "2|c")))
+	require.NotEqual(t, ordered, digest(MakeTestResult(fields, "1|ab", "2|")))
+	require.NotEqual(t, ordered, digest(MakeTestResult(fields, "1|a", "2|null")))
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 )
@@ -69,6 +70,9 @@ func TestCopy(t *testing.T) {
 			{TestValue(Int64, "2"), MakeTrusted(VarChar, nil)},
 			{TestValue(Int64, "3"), TestValue(VarChar, "")},
 		},
+		MoreResults: []*Result{{
+			RowsAffected: 1,
+		}},
 	}
 	out := in.Copy()
 	assertEqualResults(t, in, out)
@@ -384,6 +388,7 @@ func TestShallowCopy(t *testing.T) {
 				MakeTrusted(querypb.Type_VARCHAR, []byte("name")),
 			},
 		},
+		StatusFlags: ServerStatusInTrans,
 	}
 
 	res := result.ShallowCopy()
@@ -502,6 +507,47 @@ func TestStatusFlags(t *testing.T) {
 	assert.True(t, result.IsInTransaction())
 }
 
+func TestResultSets(t *testing.T) {
+	fields := []*querypb.Field{{
+		Name: "col1",
+		Type: Int64,
+	}}
+	result := &Result{
+		Fields: fields,
+		Rows:   [][]Value{{TestValue(Int64, "1")}},
+		MoreResults: []*Result{{
+			Fields: fields,
+			Rows:   [][]Value{{TestValue(Int64, "2")}},
+		}, {
+			RowsAffected: 1,
+			StatusFlags:  ServerStatusInTrans,
+		}},
+	}
+
+	sets := result.ResultSets()
+	require.Len(t, sets, 3)
+	assert.Equal(t, &Result{
+		Fields:      fields,
+		Rows:        [][]Value{{TestValue(Int64, "1")}},
+		StatusFlags: ServerMoreResultsExists,
+	}, sets[0])
+	assert.Equal(t, &Result{
+		Fields:      fields,
+		Rows:        [][]Value{{TestValue(Int64, "2")}},
+		StatusFlags: ServerMoreResultsExists,
+	}, sets[1])
+	assert.Equal(t, &Result{
+		RowsAffected: 1,
+		StatusFlags:  ServerStatusInTrans,
+	}, sets[2])
+	// The result itself is left untouched.
+	assert.Zero(t, result.StatusFlags)
+	assert.Zero(t, result.MoreResults[0].StatusFlags)
+
+	sets = (&Result{RowsAffected: 1}).ResultSets()
+	assert.Equal(t, []*Result{{RowsAffected: 1}}, sets)
+}
+
 func TestIncludeFieldsOrDefault(t *testing.T) {
 	// Should return default if nil is passed
 	r := IncludeFieldsOrDefault(nil)
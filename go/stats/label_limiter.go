@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"strings"
+	"sync"
+)
+
+// OtherLabelValue is the label value under which a LabelLimiter accounts
+// the values it does not keep.
+const OtherLabelValue = "other"
+
+// LabelLimiter bounds the cardinality of a label of a metric, e.g. a table
+// name. If it has an allowlist, only the values of the allowlist are kept.
+// Otherwise, the first maxValues distinct values are kept, or all of them if
+// maxValues is not positive. The other values are replaced by OtherLabelValue.
+//
+// An entry of the allowlist ending with ".*" keeps all the values with that
+// prefix, e.g. "commerce.*" keeps all the tables of the commerce keyspace.
+// The empty value is always kept.
+type LabelLimiter struct {
+	allowlist map[string]bool
+	prefixes  []string
+	maxValues int
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// NewLabelLimiter returns a LabelLimiter with the given allowlist, or keeping
+// the first maxValues distinct values if the allowlist is empty.
+func NewLabelLimiter(allowlist []string, maxValues int) *LabelLimiter {
+	l := &LabelLimiter{
+		maxValues: maxValues,
+		seen:      make(map[string]bool),
+	}
+	for _, value := range allowlist {
+		if prefix, ok := strings.CutSuffix(value, "*"); ok && strings.HasSuffix(prefix, ".") {
+			l.prefixes = append(l.prefixes, prefix)
+			continue
+		}
+		if l.allowlist == nil {
+			l.allowlist = make(map[string]bool)
+		}
+		l.allowlist[value] = true
+	}
+	return l
+}
+
+// Value returns the label value to use for the value.
+func (l *LabelLimiter) Value(value string) string {
+	if l == nil || value == "" {
+		return value
+	}
+	if l.allowlist != nil || l.prefixes != nil {
+		if l.allowlist[value] {
+			return value
+		}
+		for _, prefix := range l.prefixes {
+			if strings.HasPrefix(value, prefix) {
+				return value
+			}
+		}
+		return OtherLabelValue
+	}
+	if l.maxValues <= 0 {
+		return value
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen[value] {
+		return value
+	}
+	if len(l.seen) >= l.maxValues {
+		return OtherLabelValue
+	}
+	l.seen[value] = true
+	return value
+}
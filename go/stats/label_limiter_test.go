@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelLimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		maxValues int
+		values    []string
+		want      []string
+	}{
+		{
+			name:   "unlimited",
+			values: []string{"a", "b", "c"},
+			want:   []string{"a", "b", "c"},
+		}, {
+			name:      "first values",
+			maxValues: 2,
+			values:    []string{"a", "b", "a", "c", "", "b"},
+			want:      []string{"a", "b", "a", "other", "", "b"},
+		}, {
+			name:      "allowlist",
+			allowlist: []string{"ks.a", "commerce.*"},
+			maxValues: 100,
+			values:    []string{"ks.a", "ks.b", "commerce.orders", "commerce", ""},
+			want:      []string{"ks.a", "other", "commerce.orders", "other", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLabelLimiter(tt.allowlist, tt.maxValues)
+			var got []string
+			for _, value := range tt.values {
+				got = append(got, l.Value(value))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	var l *LabelLimiter
+	assert.Equal(t, "a", l.Value("a"))
+}
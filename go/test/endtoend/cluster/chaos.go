@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// availabilityCheckInterval is the interval between two queries of
+// RequireAvailability.
+const availabilityCheckInterval = 100 * time.Millisecond
+
+// Fault is a fault injected into the cluster by one of the chaos operations,
+// such as KillTablet or PartitionCell. It stays in effect until it is undone.
+type Fault struct {
+	description string
+
+	mu     sync.Mutex
+	undone bool
+	undo   func() error
+	timer  *time.Timer
+}
+
+func newFault(description string, undo func() error) *Fault {
+	log.Infof("Injected fault: %s", description)
+	return &Fault{description: description, undo: undo}
+}
+
+// Undo reverts the fault. Only the first call has an effect, so Undo can
+// safely be deferred or registered with t.Cleanup in addition to being
+// called explicitly.
+func (f *Fault) Undo() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.undone {
+		return nil
+	}
+	f.undone = true
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	log.Infof("Undoing fault: %s", f.description)
+	return f.undo()
+}
+
+// String returns a description of the fault.
+func (f *Fault) String() string {
+	return f.description
+}
+
+// undoAfter undoes the fault once d has elapsed.
+func (f *Fault) undoAfter(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.timer = time.AfterFunc(d, func() {
+		if err := f.Undo(); err != nil {
+			log.Errorf("Failed to undo fault %s: %v", f.description, err)
+		}
+	})
+}
+
+// KillTablet kills the vttablet process of the tablet, leaving its MySQL
+// running. Undo starts the vttablet process again.
+func (cluster *LocalProcessCluster) KillTablet(tablet *Vttablet) (*Fault, error) {
+	if err := tablet.VttabletProcess.Kill(); err != nil && !isKilled(err) {
+		return nil, fmt.Errorf("cannot kill tablet %s: %w", tablet.Alias, err)
+	}
+	return newFault(fmt.Sprintf("kill tablet %s", tablet.Alias), func() error {
+		return tablet.VttabletProcess.Setup()
+	}), nil
+}
+
+// isKilled returns true if err is the error returned by the Wait of a
+// process killed with SIGKILL.
+func isKilled(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "signal: killed")
+}
+
+// PartitionCell makes the vttablets of the cell unreachable by freezing
+// their processes: requests to them hang until Undo is called. The MySQL
+// instances of the cell, and hence replication, are not affected.
+func (cluster *LocalProcessCluster) PartitionCell(cell string) (*Fault, error) {
+	var paused []*Vttablet
+	resume := func() error {
+		var errs []error
+		for _, tablet := range paused {
+			if err := tablet.VttabletProcess.resume(); err != nil {
+				errs = append(errs, fmt.Errorf("cannot resume tablet %s: %w", tablet.Alias, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, tablet := range cluster.tabletsInCell(cell) {
+		if err := tablet.VttabletProcess.pause(); err != nil {
+			return nil, errors.Join(fmt.Errorf("cannot pause tablet %s: %w", tablet.Alias, err), resume())
+		}
+		paused = append(paused, tablet)
+	}
+	if len(paused) == 0 {
+		return nil, fmt.Errorf("no running tablet found in cell %s", cell)
+	}
+	return newFault(fmt.Sprintf("partition cell %s", cell), resume), nil
+}
+
+func (cluster *LocalProcessCluster) tabletsInCell(cell string) []*Vttablet {
+	var tablets []*Vttablet
+	for _, keyspace := range cluster.Keyspaces {
+		for _, shard := range keyspace.Shards {
+			for _, tablet := range shard.Vttablets {
+				if tablet.Cell == cell && tablet.VttabletProcess != nil && !tablet.VttabletProcess.IsShutdown() {
+					tablets = append(tablets, tablet)
+				}
+			}
+		}
+	}
+	return tablets
+}
+
+// StallReplication stops replication on all the replicas of the shard. If
+// duration is positive, replication is restarted automatically once it has
+// elapsed, otherwise it stays stopped until Undo is called.
+//
+// VTOrc repairs stopped replication, so tests which expect replication to
+// stay stalled should disable VTOrc recoveries with DisableVTOrcRecoveries.
+func (cluster *LocalProcessCluster) StallReplication(shard *Shard, duration time.Duration) (*Fault, error) {
+	var stopped []*Vttablet
+	restart := func() error {
+		var errs []error
+		for _, tablet := range stopped {
+			if err := cluster.VtctldClientProcess.ExecuteCommand("StartReplication", tablet.Alias); err != nil {
+				errs = append(errs, fmt.Errorf("cannot start replication on %s: %w", tablet.Alias, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, tablet := range shard.Vttablets {
+		if tablet.VttabletProcess.GetTabletType() == "primary" {
+			continue
+		}
+		if err := cluster.VtctldClientProcess.ExecuteCommand("StopReplication", tablet.Alias); err != nil {
+			return nil, errors.Join(fmt.Errorf("cannot stop replication on %s: %w", tablet.Alias, err), restart())
+		}
+		stopped = append(stopped, tablet)
+	}
+	if len(stopped) == 0 {
+		return nil, fmt.Errorf("no replica found in shard %s", shard.Name)
+	}
+
+	fault := newFault(fmt.Sprintf("stall replication of shard %s", shard.Name), restart)
+	if duration > 0 {
+		fault.undoAfter(duration)
+	}
+	return fault, nil
+}
+
+// FillDisk makes the disk of the tablet look full to its disk health
+// monitor: writes to the directory configured with --disk-write-dir fail
+// until Undo is called, so the tablet reports its disk as stalled. The tablet
+// must have been started with --disk-write-dir. MySQL is not affected.
+func (cluster *LocalProcessCluster) FillDisk(tablet *Vttablet) (*Fault, error) {
+	dir := diskWriteDir(tablet.VttabletProcess.ExtraArgs)
+	if dir == "" {
+		return nil, fmt.Errorf("tablet %s was not started with --disk-write-dir", tablet.Alias)
+	}
+
+	// Replacing the directory by a regular file makes all writes to it fail,
+	// even when the tests run as root.
+	moved := dir + ".full"
+	if err := os.Rename(dir, moved); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(dir, nil, 0o400); err != nil {
+		return nil, errors.Join(err, os.Rename(moved, dir))
+	}
+	return newFault(fmt.Sprintf("fill disk of tablet %s", tablet.Alias), func() error {
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+		return os.Rename(moved, dir)
+	}), nil
+}
+
+// diskWriteDir returns the value of the --disk-write-dir flag in args.
+func diskWriteDir(args []string) string {
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--disk-write-dir="):
+			return strings.TrimPrefix(arg, "--disk-write-dir=")
+		case arg == "--disk-write-dir" && i+1 < len(args):
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// AvailabilityReport summarizes the queries executed by MeasureAvailability.
+type AvailabilityReport struct {
+	Attempts int
+	Failures int
+	// Errors counts the failed queries by error message.
+	Errors map[string]int
+}
+
+// SuccessRate returns the fraction of the queries which succeeded.
+func (r *AvailabilityReport) SuccessRate() float64 {
+	if r.Attempts == 0 {
+		return 0
+	}
+	return float64(r.Attempts-r.Failures) / float64(r.Attempts)
+}
+
+// MeasureAvailability executes query through vtgate against keyspace every
+// interval, for duration or until ctx is done, and reports how many of the
+// queries failed. Broken connections are re-established, and failing to
+// connect counts as a failed query.
+func (cluster *LocalProcessCluster) MeasureAvailability(ctx context.Context, keyspace, query string, duration, interval time.Duration) *AvailabilityReport {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	report := &AvailabilityReport{Errors: make(map[string]int)}
+	vtParams := cluster.GetVTParams(keyspace)
+	var conn *mysql.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report.Attempts++
+		err := func() (err error) {
+			if conn == nil || conn.IsClosed() {
+				if conn, err = mysql.Connect(ctx, &vtParams); err != nil {
+					conn = nil
+					return err
+				}
+			}
+			_, err = conn.ExecuteFetch(query, -1, false)
+			return err
+		}()
+		if err != nil {
+			report.Failures++
+			report.Errors[err.Error()]++
+		}
+
+		select {
+		case <-ctx.Done():
+			return report
+		case <-ticker.C:
+		}
+	}
+}
+
+// RequireAvailability executes query through vtgate against keyspace for
+// duration, and fails the test if less than minSuccessRate of the queries
+// succeeded. It is typically called while a fault is injected, to verify
+// that the cluster keeps serving queries:
+//
+//	fault, err := clusterInstance.KillTablet(shard.Replica())
+//	require.NoError(t, err)
+//	defer fault.Undo()
+//	clusterInstance.RequireAvailability(t, keyspace, "select * from t", 10*time.Second, 0.99)
+func (cluster *LocalProcessCluster) RequireAvailability(t testing.TB, keyspace, query string, duration time.Duration, minSuccessRate float64) *AvailabilityReport {
+	t.Helper()
+	report := cluster.MeasureAvailability(context.Background(), keyspace, query, duration, availabilityCheckInterval)
+	if !assert.GreaterOrEqual(t, report.SuccessRate(), minSuccessRate, "%d of %d queries failed: %v", report.Failures, report.Attempts, report.Errors) {
+		t.FailNow()
+	}
+	return report
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/yaml2"
+)
+
+// ClusterSpec declaratively describes the topology of a test cluster: its
+// keyspaces, shards, tablets, schemas, vschemas and seed data. A spec can be
+// built in Go or loaded from a YAML or JSON file with LoadClusterSpec, and
+// started with StartClusterFromSpec:
+//
+//	cell: zone1
+//	vtgateExtraArgs: ["--enable-views"]
+//	keyspaces:
+//	- name: customer
+//	  shards: ["-80", "80-"]
+//	  replicaCount: 1
+//	  schemaFile: schema.sql
+//	  vschemaFile: vschema.json
+//	  seedFile: seed.sql
+type ClusterSpec struct {
+	// Cell is the cell of all processes. It defaults to DefaultCell.
+	Cell string `json:"cell,omitempty"`
+	// Hostname is the hostname of all processes. It defaults to localhost.
+	Hostname string `json:"hostname,omitempty"`
+
+	VtGateExtraArgs   []string `json:"vtgateExtraArgs,omitempty"`
+	VtTabletExtraArgs []string `json:"vttabletExtraArgs,omitempty"`
+	VtctldExtraArgs   []string `json:"vtctldExtraArgs,omitempty"`
+
+	// Keyspaces are started in order.
+	Keyspaces []KeyspaceSpec `json:"keyspaces"`
+}
+
+// KeyspaceSpec describes a keyspace of a ClusterSpec.
+type KeyspaceSpec struct {
+	Name string `json:"name"`
+	// Shards are the names of the shards. They default to a single "0" shard.
+	Shards []string `json:"shards,omitempty"`
+	// ReplicaCount is the number of replicas of each shard, excluding the
+	// primary and the rdonly tablet.
+	ReplicaCount int `json:"replicaCount,omitempty"`
+	// Rdonly adds an rdonly tablet to each shard.
+	Rdonly bool `json:"rdonly,omitempty"`
+
+	DurabilityPolicy string `json:"durabilityPolicy,omitempty"`
+	SidecarDBName    string `json:"sidecarDBName,omitempty"`
+
+	// SchemaSQL is applied to the keyspace after its tablets are started.
+	// SchemaFile is read into SchemaSQL when SchemaSQL is empty.
+	SchemaSQL  string `json:"schemaSQL,omitempty"`
+	SchemaFile string `json:"schemaFile,omitempty"`
+
+	// VSchema is the JSON vschema of the keyspace. VSchemaFile is read into
+	// VSchema when VSchema is empty.
+	VSchema     string `json:"vschema,omitempty"`
+	VSchemaFile string `json:"vschemaFile,omitempty"`
+
+	// SeedSQL is executed through vtgate, targeting the keyspace, once
+	// vtgate is up. SeedFile is read into SeedSQL when SeedSQL is empty.
+	SeedSQL  string `json:"seedSQL,omitempty"`
+	SeedFile string `json:"seedFile,omitempty"`
+}
+
+// ParseClusterSpec parses a YAML or JSON cluster spec. Relative file paths
+// in the spec are resolved against dir.
+func ParseClusterSpec(data []byte, dir string) (*ClusterSpec, error) {
+	spec := &ClusterSpec{}
+	if err := yaml2.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("cannot parse cluster spec: %w", err)
+	}
+	if err := spec.load(dir); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// LoadClusterSpec reads and parses the YAML or JSON cluster spec at path.
+// Relative file paths in the spec are resolved against the directory of
+// the spec.
+func LoadClusterSpec(path string) (*ClusterSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClusterSpec(data, filepath.Dir(path))
+}
+
+// load validates the spec, fills in its defaults and reads the files it
+// refers to.
+func (spec *ClusterSpec) load(dir string) error {
+	if spec.Cell == "" {
+		spec.Cell = DefaultCell
+	}
+	if spec.Hostname == "" {
+		spec.Hostname = "localhost"
+	}
+	if len(spec.Keyspaces) == 0 {
+		return fmt.Errorf("cluster spec has no keyspaces")
+	}
+
+	readFile := func(dst *string, name string) error {
+		if *dst != "" || name == "" {
+			return nil
+		}
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		*dst = string(data)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(spec.Keyspaces))
+	for i := range spec.Keyspaces {
+		ks := &spec.Keyspaces[i]
+		if ks.Name == "" {
+			return fmt.Errorf("keyspace %d of cluster spec has no name", i)
+		}
+		if seen[ks.Name] {
+			return fmt.Errorf("keyspace %s is defined more than once in cluster spec", ks.Name)
+		}
+		seen[ks.Name] = true
+		if len(ks.Shards) == 0 {
+			ks.Shards = []string{"0"}
+		}
+		if ks.ReplicaCount < 0 {
+			return fmt.Errorf("keyspace %s has a negative replica count", ks.Name)
+		}
+		if err := readFile(&ks.SchemaSQL, ks.SchemaFile); err != nil {
+			return fmt.Errorf("cannot read schema of keyspace %s: %w", ks.Name, err)
+		}
+		if err := readFile(&ks.VSchema, ks.VSchemaFile); err != nil {
+			return fmt.Errorf("cannot read vschema of keyspace %s: %w", ks.Name, err)
+		}
+		if err := readFile(&ks.SeedSQL, ks.SeedFile); err != nil {
+			return fmt.Errorf("cannot read seed data of keyspace %s: %w", ks.Name, err)
+		}
+	}
+	return nil
+}
+
+// StartClusterFromSpec starts a cluster with the topology described by spec:
+// the topo server, the keyspaces with their tablets, schemas and vschemas,
+// and vtgate. The seed data of the keyspaces is loaded through vtgate once
+// everything is up. Relative file paths in a spec built in Go are resolved
+// against the working directory.
+//
+// The caller is responsible for tearing the returned cluster down. If the
+// cluster fails to start, it is torn down before the error is returned.
+func StartClusterFromSpec(spec *ClusterSpec) (*LocalProcessCluster, error) {
+	if err := spec.load("."); err != nil {
+		return nil, err
+	}
+
+	cluster := NewCluster(spec.Cell, spec.Hostname)
+	if err := cluster.startFromSpec(spec); err != nil {
+		cluster.Teardown()
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func (cluster *LocalProcessCluster) startFromSpec(spec *ClusterSpec) error {
+	cluster.VtGateExtraArgs = append(cluster.VtGateExtraArgs, spec.VtGateExtraArgs...)
+	cluster.VtTabletExtraArgs = append(cluster.VtTabletExtraArgs, spec.VtTabletExtraArgs...)
+	cluster.VtctldExtraArgs = append(cluster.VtctldExtraArgs, spec.VtctldExtraArgs...)
+
+	if err := cluster.StartTopo(); err != nil {
+		return fmt.Errorf("cannot start topo: %w", err)
+	}
+
+	for _, ks := range spec.Keyspaces {
+		keyspace := Keyspace{
+			Name:             ks.Name,
+			SchemaSQL:        ks.SchemaSQL,
+			VSchema:          ks.VSchema,
+			DurabilityPolicy: ks.DurabilityPolicy,
+			SidecarDBName:    ks.SidecarDBName,
+		}
+		if err := cluster.StartKeyspace(keyspace, ks.Shards, ks.ReplicaCount, ks.Rdonly); err != nil {
+			return fmt.Errorf("cannot start keyspace %s: %w", ks.Name, err)
+		}
+	}
+
+	if err := cluster.StartVtgate(); err != nil {
+		return fmt.Errorf("cannot start vtgate: %w", err)
+	}
+
+	for _, ks := range spec.Keyspaces {
+		if err := cluster.seedKeyspace(ks.Name, ks.SeedSQL); err != nil {
+			return fmt.Errorf("cannot seed keyspace %s: %w", ks.Name, err)
+		}
+	}
+	return nil
+}
+
+// seedKeyspace executes the statements of seedSQL through vtgate.
+func (cluster *LocalProcessCluster) seedKeyspace(keyspace, seedSQL string) error {
+	if seedSQL == "" {
+		return nil
+	}
+	stmts, err := sqlparser.NewTestParser().SplitStatementToPieces(seedSQL)
+	if err != nil {
+		return err
+	}
+
+	vtParams := cluster.GetVTParams(keyspace)
+	conn, err := mysql.Connect(context.Background(), &vtParams)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	log.Infof("Seeding keyspace %s with %d statements", keyspace, len(stmts))
+	for _, stmt := range stmts {
+		if _, err := conn.ExecuteFetch(stmt, 0, false); err != nil {
+			return fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClusterSpec(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.sql"), []byte("create table t1(id bigint primary key)"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vschema.json"), []byte(`{"sharded": true}`), 0o644))
+	absSeed := filepath.Join(t.TempDir(), "seed.sql")
+	require.NoError(t, os.WriteFile(absSeed, []byte("insert into t1(id) values (1)"), 0o644))
+
+	testCases := []struct {
+		name    string
+		spec    string
+		want    *ClusterSpec
+		wantErr string
+	}{{
+		name: "defaults",
+		spec: "keyspaces:\n- name: ks\n",
+		want: &ClusterSpec{
+			Cell:      DefaultCell,
+			Hostname:  "localhost",
+			Keyspaces: []KeyspaceSpec{{Name: "ks", Shards: []string{"0"}}},
+		},
+	}, {
+		name: "yaml",
+		spec: `
+cell: zone2
+hostname: 127.0.0.1
+vtgateExtraArgs: ["--enable-views"]
+keyspaces:
+- name: customer
+  shards: ["-80", "80-"]
+  replicaCount: 1
+  rdonly: true
+  durabilityPolicy: semi_sync
+  schemaFile: schema.sql
+  vschemaFile: vschema.json
+  seedFile: ` + absSeed + `
+- name: commerce
+  schemaSQL: create table t2(id bigint primary key)
+  schemaFile: missing.sql
+`,
+		want: &ClusterSpec{
+			Cell:            "zone2",
+			Hostname:        "127.0.0.1",
+			VtGateExtraArgs: []string{"--enable-views"},
+			Keyspaces: []KeyspaceSpec{{
+				Name:             "customer",
+				Shards:           []string{"-80", "80-"},
+				ReplicaCount:     1,
+				Rdonly:           true,
+				DurabilityPolicy: "semi_sync",
+				SchemaSQL:        "create table t1(id bigint primary key)",
+				SchemaFile:       "schema.sql",
+				VSchema:          `{"sharded": true}`,
+				VSchemaFile:      "vschema.json",
+				SeedSQL:          "insert into t1(id) values (1)",
+				SeedFile:         absSeed,
+			}, {
+				// The inline schema wins over the file, which is not read.
+				Name:       "commerce",
+				Shards:     []string{"0"},
+				SchemaSQL:  "create table t2(id bigint primary key)",
+				SchemaFile: "missing.sql",
+			}},
+		},
+	}, {
+		name: "json",
+		spec: `{"keyspaces": [{"name": "ks", "shards": ["-"], "vschema": "{}"}]}`,
+		want: &ClusterSpec{
+			Cell:      DefaultCell,
+			Hostname:  "localhost",
+			Keyspaces: []KeyspaceSpec{{Name: "ks", Shards: []string{"-"}, VSchema: "{}"}},
+		},
+	}, {
+		name:    "invalid yaml",
+		spec:    "keyspaces: [",
+		wantErr: "cannot parse cluster spec",
+	}, {
+		name:    "wrong type",
+		spec:    "keyspaces:\n- name: ks\n  replicaCount: one\n",
+		wantErr: "cannot parse cluster spec",
+	}, {
+		name:    "no keyspaces",
+		spec:    "cell: zone1\n",
+		wantErr: "cluster spec has no keyspaces",
+	}, {
+		name:    "keyspace without a name",
+		spec:    "keyspaces:\n- name: ks\n- shards: [\"0\"]\n",
+		wantErr: "keyspace 1 of cluster spec has no name",
+	}, {
+		name:    "duplicate keyspace",
+		spec:    "keyspaces:\n- name: ks\n- name: ks\n",
+		wantErr: "keyspace ks is defined more than once in cluster spec",
+	}, {
+		name:    "negative replica count",
+		spec:    "keyspaces:\n- name: ks\n  replicaCount: -1\n",
+		wantErr: "keyspace ks has a negative replica count",
+	}, {
+		name:    "missing schema file",
+		spec:    "keyspaces:\n- name: ks\n  schemaFile: missing.sql\n",
+		wantErr: "cannot read schema of keyspace ks",
+	}, {
+		name:    "missing vschema file",
+		spec:    "keyspaces:\n- name: ks\n  vschemaFile: missing.json\n",
+		wantErr: "cannot read vschema of keyspace ks",
+	}, {
+		name:    "missing seed file",
+		spec:    "keyspaces:\n- name: ks\n  seedFile: missing.sql\n",
+		wantErr: "cannot read seed data of keyspace ks",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := ParseClusterSpec([]byte(tc.spec), dir)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, spec)
+		})
+	}
+}
+
+func TestLoadClusterSpec(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.sql"), []byte("create table t1(id bigint primary key)"), 0o644))
+	path := filepath.Join(dir, "cluster.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("keyspaces:\n- name: ks\n  schemaFile: schema.sql\n"), 0o644))
+
+	// The files of the spec are relative to its directory.
+	spec, err := LoadClusterSpec(path)
+	require.NoError(t, err)
+	require.Len(t, spec.Keyspaces, 1)
+	assert.Equal(t, "create table t1(id bigint primary key)", spec.Keyspaces[0].SchemaSQL)
+
+	_, err = LoadClusterSpec(filepath.Join(dir, "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
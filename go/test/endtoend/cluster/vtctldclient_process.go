@@ -30,6 +30,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -291,6 +292,21 @@ func (vtctldclient *VtctldClientProcess) GetKeyspace(keyspace string) (*vtctldat
 	return &ks, nil
 }
 
+// GetVSchema executes the vtctldclient command to get the vschema of a keyspace, and parses the response.
+func (vtctldclient *VtctldClientProcess) GetVSchema(keyspace string) (*vschemapb.Keyspace, error) {
+	data, err := vtctldclient.ExecuteCommandWithOutput("GetVSchema", keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var vschema vschemapb.Keyspace
+	err = json2.UnmarshalPB([]byte(data), &vschema)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to parse vschema output: %s", data)
+	}
+	return &vschema, nil
+}
+
 // GetShard executes the vtctldclient command to get a shard, and parses the response.
 func (vtctldclient *VtctldClientProcess) GetShard(keyspace string, shard string) (*vtctldatapb.Shard, error) {
 	data, err := vtctldclient.ExecuteCommandWithOutput("GetShard", fmt.Sprintf("%s/%s", keyspace, shard))
@@ -18,9 +18,28 @@ limitations under the License.
 
 package cluster
 
-import "syscall"
+import (
+	"errors"
+	"syscall"
+)
 
 // ToggleProfiling enables or disables the configured CPU profiler on this vttablet
 func (vttablet *VttabletProcess) ToggleProfiling() error {
 	return vttablet.proc.Process.Signal(syscall.SIGUSR1)
 }
+
+// pause freezes the vttablet process until resume is called.
+func (vttablet *VttabletProcess) pause() error {
+	if vttablet.proc == nil {
+		return errors.New("vttablet is not running")
+	}
+	return vttablet.proc.Process.Signal(syscall.SIGSTOP)
+}
+
+// resume resumes a vttablet process frozen by pause.
+func (vttablet *VttabletProcess) resume() error {
+	if vttablet.proc == nil {
+		return errors.New("vttablet is not running")
+	}
+	return vttablet.proc.Process.Signal(syscall.SIGCONT)
+}
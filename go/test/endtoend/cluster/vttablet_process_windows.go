@@ -26,3 +26,11 @@ import (
 func (vttablet *VttabletProcess) ToggleProfiling() error {
 	return errors.New("not implemented")
 }
+
+func (vttablet *VttabletProcess) pause() error {
+	return errors.New("not implemented")
+}
+
+func (vttablet *VttabletProcess) resume() error {
+	return errors.New("not implemented")
+}
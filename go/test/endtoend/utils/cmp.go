@@ -19,6 +19,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -38,8 +39,23 @@ type TestingT interface {
 type MySQLCompare struct {
 	t                 TestingT
 	MySQLConn, VtConn *mysql.Conn
+
+	// CompareSessionState makes the methods which execute the same query on both
+	// Vitess and MySQL also compare the session state of the two connections after
+	// the query, since a query can corrupt the session state of vtgate without
+	// affecting its own result set.
+	CompareSessionState bool
+
+	// vtParams and mysqlParams are used to open the connections on which
+	// ExecPrepared prepares its statements.
+	vtParams, mysqlParams mysql.ConnParams
 }
 
+// sessionStateQuery selects the parts of the session state which are compared when
+// CompareSessionState is set. It is a single query, so found_rows() and row_count()
+// still refer to the query executed by the test.
+const sessionStateQuery = "select @@autocommit, @@sql_mode, last_insert_id(), found_rows(), row_count()"
+
 func NewMySQLCompare(t TestingT, vtParams, mysqlParams mysql.ConnParams) (MySQLCompare, error) {
 	ctx := context.Background()
 	vtConn, err := mysql.Connect(ctx, &vtParams)
@@ -53,9 +69,11 @@ func NewMySQLCompare(t TestingT, vtParams, mysqlParams mysql.ConnParams) (MySQLC
 	}
 
 	return MySQLCompare{
-		t:         t,
-		MySQLConn: mysqlConn,
-		VtConn:    vtConn,
+		t:           t,
+		MySQLConn:   mysqlConn,
+		VtConn:      vtConn,
+		vtParams:    vtParams,
+		mysqlParams: mysqlParams,
 	}, nil
 }
 
@@ -212,10 +230,33 @@ func (mcmp *MySQLCompare) Exec(query string) *sqltypes.Result {
 
 	mysqlQr, err := mcmp.MySQLConn.ExecuteFetch(query, 1000, true)
 	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+	mcmp.compareSessionState(query)
 	CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{})
 	return vtQr
 }
 
+// compareSessionState compares the session state of the Vitess and MySQL connections
+// after the given query was executed on both of them, if CompareSessionState is set.
+func (mcmp *MySQLCompare) compareSessionState(query string) {
+	mcmp.t.Helper()
+	if !mcmp.CompareSessionState {
+		return
+	}
+	vtQr, err := mcmp.VtConn.ExecuteFetch(sessionStateQuery, 1, true)
+	require.NoError(mcmp.t, err, "[Vitess Error] for query: "+sessionStateQuery)
+	mysqlQr, err := mcmp.MySQLConn.ExecuteFetch(sessionStateQuery, 1, true)
+	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+sessionStateQuery)
+
+	// The values are compared as strings, since Vitess and MySQL do not always
+	// agree on the types of the session functions.
+	for i, field := range mysqlQr.Fields {
+		vtValue, mysqlValue := vtQr.Rows[0][i].ToString(), mysqlQr.Rows[0][i].ToString()
+		if vtValue != mysqlValue {
+			mcmp.t.Errorf("Session state diverged after query: %s\n%s: Vitess has %q, MySQL has %q", query, field.Name, vtValue, mysqlValue)
+		}
+	}
+}
+
 // ExecMulti executes the given queries against both Vitess and MySQL and compares
 // the result sets. If there is a mismatch, the difference will be printed and the
 // test will fail. If the query produces an error in either Vitess or MySQL, the test
@@ -311,10 +352,111 @@ func (mcmp *MySQLCompare) ExecAssert(query string) *sqltypes.Result {
 
 	mysqlQr, err := mcmp.MySQLConn.ExecuteFetch(query, 1000, true)
 	assert.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+	mcmp.compareSessionState(query)
 	CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{})
 	return vtQr
 }
 
+// AssertDMLMatches executes the given DML against both Vitess and MySQL and compares
+// its secondary effects: the number of rows affected, the last insert id and the
+// number of warnings. The rows of the table modified by the DML are then selected
+// from both sides, ordered by primary key, and compared as well, so that differences
+// in the written rows are not missed. The DML must modify a single table which has
+// a primary key.
+// The result of Vitess is returned to the caller.
+func (mcmp *MySQLCompare) AssertDMLMatches(dml string) *sqltypes.Result {
+	mcmp.t.Helper()
+	table, err := dmlTable(dml)
+	require.NoError(mcmp.t, err)
+
+	vtQr, vtWarnings, err := mcmp.VtConn.ExecuteFetchWithWarningCount(dml, 1000, true)
+	require.NoError(mcmp.t, err, "[Vitess Error] for query: "+dml)
+
+	mysqlQr, mysqlWarnings, err := mcmp.MySQLConn.ExecuteFetchWithWarningCount(dml, 1000, true)
+	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+dml)
+
+	assert.Equal(mcmp.t, mysqlQr.RowsAffected, vtQr.RowsAffected, "Vitess and MySQL have different rows affected for query: %s", dml)
+	assert.Equal(mcmp.t, mysqlQr.InsertID, vtQr.InsertID, "Vitess and MySQL have different insert ids for query: %s", dml)
+	assert.Equal(mcmp.t, mysqlWarnings, vtWarnings, "Vitess and MySQL have different warning counts for query: %s", dml)
+	mcmp.compareSessionState(dml)
+
+	pkQr, err := mcmp.MySQLConn.ExecuteFetch(fmt.Sprintf("select column_name from information_schema.key_column_usage "+
+		"where table_schema = database() and table_name = '%s' and constraint_name = 'PRIMARY' order by ordinal_position",
+		table.Name.String()), 100, false)
+	require.NoError(mcmp.t, err)
+	require.NotEmpty(mcmp.t, pkQr.Rows, "table %s has no primary key", sqlparser.String(table))
+	var pk []string
+	for _, row := range pkQr.Rows {
+		pk = append(pk, sqlparser.String(sqlparser.NewIdentifierCI(row[0].ToString())))
+	}
+	mcmp.Exec(fmt.Sprintf("select * from %s order by %s", sqlparser.String(table), strings.Join(pk, ", ")))
+	return vtQr
+}
+
+// dmlTable returns the table modified by the given single table DML.
+func dmlTable(dml string) (sqlparser.TableName, error) {
+	stmt, err := sqlparser.NewTestParser().Parse(dml)
+	if err != nil {
+		return sqlparser.TableName{}, err
+	}
+
+	var tableExprs []sqlparser.TableExpr
+	switch stmt := stmt.(type) {
+	case *sqlparser.Insert:
+		return stmt.Table.TableName()
+	case *sqlparser.Update:
+		tableExprs = stmt.TableExprs
+	case *sqlparser.Delete:
+		switch len(stmt.Targets) {
+		case 0:
+			tableExprs = stmt.TableExprs
+		case 1:
+			return deleteTarget(stmt.Targets[0], stmt.TableExprs), nil
+		default:
+			return sqlparser.TableName{}, fmt.Errorf("DML modifies more than one table: %s", dml)
+		}
+	default:
+		return sqlparser.TableName{}, fmt.Errorf("not a DML statement: %s", dml)
+	}
+	if len(tableExprs) != 1 {
+		return sqlparser.TableName{}, fmt.Errorf("DML does not modify a single table: %s", dml)
+	}
+	aliased, ok := tableExprs[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return sqlparser.TableName{}, fmt.Errorf("DML does not modify a single table: %s", dml)
+	}
+	table, ok := aliased.Expr.(sqlparser.TableName)
+	if !ok {
+		return sqlparser.TableName{}, fmt.Errorf("DML does not modify a single table: %s", dml)
+	}
+	return table, nil
+}
+
+// deleteTarget returns the table of the target of a multi-table DELETE,
+// which may be the alias of one of its tables.
+func deleteTarget(target sqlparser.TableName, tableExprs []sqlparser.TableExpr) sqlparser.TableName {
+	if !target.Qualifier.IsEmpty() {
+		return target
+	}
+	for _, tableExpr := range tableExprs {
+		var table sqlparser.TableName
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			aliased, ok := node.(*sqlparser.AliasedTableExpr)
+			if !ok || aliased.As.String() != target.Name.String() {
+				return true, nil
+			}
+			if name, ok := aliased.Expr.(sqlparser.TableName); ok {
+				table = name
+			}
+			return false, nil
+		}, tableExpr)
+		if !table.IsEmpty() {
+			return table
+		}
+	}
+	return target
+}
+
 // ExecNoCompare executes the query on vitess and mysql but does not compare the result with each other.
 func (mcmp *MySQLCompare) ExecNoCompare(query string) (*sqltypes.Result, *sqltypes.Result) {
 	mcmp.t.Helper()
@@ -338,6 +480,7 @@ func (mcmp *MySQLCompare) ExecWithColumnCompare(query string) *sqltypes.Result {
 
 	mysqlQr, err := mcmp.MySQLConn.ExecuteFetch(query, 1000, true)
 	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+	mcmp.compareSessionState(query)
 	CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{CompareColumnNames: true})
 	return vtQr
 }
@@ -355,6 +498,7 @@ func (mcmp *MySQLCompare) ExecAllowAndCompareError(query string, opts CompareOpt
 	vtQr, vtErr := mcmp.VtConn.ExecuteFetch(query, 1000, true)
 	mysqlQr, mysqlErr := mcmp.MySQLConn.ExecuteFetch(query, 1000, true)
 	compareVitessAndMySQLErrors(mcmp.t, vtErr, mysqlErr)
+	mcmp.compareSessionState(query)
 
 	// Since we allow errors, we don't want to compare results if one of the client failed.
 	// Vitess and MySQL should always be agreeing whether the query returns an error or not.
@@ -375,9 +519,12 @@ func (mcmp *MySQLCompare) ExecAndIgnore(query string) (*sqltypes.Result, error)
 func (mcmp *MySQLCompare) Run(name string, f func(mcmp *MySQLCompare)) {
 	mcmp.AsT().Run(name, func(t *testing.T) {
 		inner := &MySQLCompare{
-			t:         t,
-			MySQLConn: mcmp.MySQLConn,
-			VtConn:    mcmp.VtConn,
+			t:                   t,
+			MySQLConn:           mcmp.MySQLConn,
+			VtConn:              mcmp.VtConn,
+			CompareSessionState: mcmp.CompareSessionState,
+			vtParams:            mcmp.vtParams,
+			mysqlParams:         mcmp.mysqlParams,
 		}
 		f(inner)
 	})
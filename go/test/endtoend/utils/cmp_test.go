@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestDMLTable(t *testing.T) {
+	testCases := []struct {
+		dml     string
+		want    string
+		wantErr string
+	}{{
+		dml:  "insert into t1(id) values (1)",
+		want: "t1",
+	}, {
+		dml:  "insert into ks.t1(id) select id from t2",
+		want: "ks.t1",
+	}, {
+		dml:  "replace into t1(id) values (1)",
+		want: "t1",
+	}, {
+		dml:  "update t1 set a = 1 where id = 2",
+		want: "t1",
+	}, {
+		dml:  "update t1 as x set x.a = 1",
+		want: "t1",
+	}, {
+		dml:  "delete from t1 where id = 1",
+		want: "t1",
+	}, {
+		dml:  "delete t1 from t1 join t2 on t1.id = t2.id",
+		want: "t1",
+	}, {
+		dml:  "delete x from t1 as x join t2 on x.id = t2.id",
+		want: "t1",
+	}, {
+		dml:  "delete ks.t1 from ks.t1 join t2 on t1.id = t2.id",
+		want: "ks.t1",
+	}, {
+		dml:     "delete t1, t2 from t1 join t2 on t1.id = t2.id",
+		wantErr: "DML modifies more than one table: delete t1, t2 from t1 join t2 on t1.id = t2.id",
+	}, {
+		dml:     "update t1, t2 set t1.a = t2.a",
+		wantErr: "DML does not modify a single table: update t1, t2 set t1.a = t2.a",
+	}, {
+		dml:     "update t1 join t2 on t1.id = t2.id set t1.a = t2.a",
+		wantErr: "DML does not modify a single table: update t1 join t2 on t1.id = t2.id set t1.a = t2.a",
+	}, {
+		dml:     "select * from t1",
+		wantErr: "not a DML statement: select * from t1",
+	}, {
+		dml:     "insert into",
+		wantErr: "syntax error",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.dml, func(t *testing.T) {
+			table, err := dmlTable(tc.dml)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, sqlparser.String(table))
+		})
+	}
+}
+
+// newFakeSessionState returns a fake MySQL server whose session state is
+// the given values of sessionStateQuery.
+func newFakeSessionState(t *testing.T, values ...string) *fakesqldb.DB {
+	db := fakesqldb.New(t)
+	t.Cleanup(db.Close)
+	db.AddQuery(sessionStateQuery, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("@@autocommit|@@sql_mode|last_insert_id()|found_rows()|row_count()", "int64|varchar|uint64|int64|int64"),
+		values...,
+	))
+	db.AddQuery("update t1 set a = 1", &sqltypes.Result{})
+	return db
+}
+
+func TestCompareSessionState(t *testing.T) {
+	vtDB := newFakeSessionState(t, "1|STRICT_TRANS_TABLES|0|0|3")
+	mysqlDB := newFakeSessionState(t, "1|STRICT_TRANS_TABLES|0|0|2")
+
+	ctx := context.Background()
+	vtConn, err := mysql.Connect(ctx, vtDB.ConnParams())
+	require.NoError(t, err)
+	defer vtConn.Close()
+	mysqlConn, err := mysql.Connect(ctx, mysqlDB.ConnParams())
+	require.NoError(t, err)
+	defer mysqlConn.Close()
+
+	rec := &replayRecorder{}
+	mcmp := &MySQLCompare{t: rec, VtConn: vtConn, MySQLConn: mysqlConn}
+
+	// Nothing is compared unless CompareSessionState is set.
+	mcmp.compareSessionState("update t1 set a = 1")
+	assert.Empty(t, rec.diffs)
+	assert.Zero(t, vtDB.GetQueryCalledNum(sessionStateQuery))
+
+	mcmp.CompareSessionState = true
+	mcmp.compareSessionState("update t1 set a = 1")
+	assert.Equal(t, []string{"Session state diverged after query: update t1 set a = 1\nrow_count(): Vitess has \"3\", MySQL has \"2\""}, rec.diffs)
+
+	// The values are compared as strings, whatever their types.
+	rec.diffs = nil
+	mysqlDB.AddQuery(sessionStateQuery, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("@@autocommit|@@sql_mode|last_insert_id()|found_rows()|row_count()", "varchar|varchar|int64|int64|int64"),
+		"1|STRICT_TRANS_TABLES|0|0|3",
+	))
+	mcmp.compareSessionState("update t1 set a = 1")
+	assert.Empty(t, rec.diffs)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+)
+
+// ConcurrentReport is the outcome of the execution of queries on both Vitess
+// and MySQL by ExecConcurrently.
+type ConcurrentReport struct {
+	Queries int
+	// Mismatches are the queries on which Vitess and MySQL disagree, in the
+	// order of the queries. Their Statement is the 1-based position of the
+	// query.
+	Mismatches []ReplayMismatch
+}
+
+// String returns the report, listing the mismatched queries in order.
+func (r *ConcurrentReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d queries mismatched between Vitess and MySQL\n", len(r.Mismatches), r.Queries)
+	writeMismatches(&sb, r.Mismatches)
+	return sb.String()
+}
+
+// ExecConcurrently executes the queries against both Vitess and MySQL over a
+// pool of concurrency pairs of connections, and compares their results,
+// errors and session states like ExecAllowAndCompareError. Each pair of
+// connections executes one query at a time, but the order of the queries
+// between the pairs is not defined, so the queries should not depend on each
+// other. The connections of the pool do not share the session of VtConn and
+// MySQLConn.
+//
+// The mismatches do not stop the execution: they are collected in the
+// returned report, and the test is marked as failed once, with the report, if
+// there are any.
+func (mcmp *MySQLCompare) ExecConcurrently(queries []string, concurrency int) *ConcurrentReport {
+	mcmp.t.Helper()
+	concurrency = max(1, min(concurrency, len(queries)))
+
+	ctx := context.Background()
+	workers := make([]*MySQLCompare, 0, concurrency)
+	defer func() {
+		for _, worker := range workers {
+			worker.Close()
+		}
+	}()
+	for range concurrency {
+		vtConn, err := mysql.Connect(ctx, &mcmp.vtParams)
+		require.NoError(mcmp.t, err)
+		mysqlConn, err := mysql.Connect(ctx, &mcmp.mysqlParams)
+		if err != nil {
+			vtConn.Close()
+		}
+		require.NoError(mcmp.t, err)
+		workers = append(workers, &MySQLCompare{
+			t:                   mcmp.t,
+			MySQLConn:           mysqlConn,
+			VtConn:              vtConn,
+			CompareSessionState: mcmp.CompareSessionState,
+			vtParams:            mcmp.vtParams,
+			mysqlParams:         mcmp.mysqlParams,
+		})
+	}
+
+	diffs := make([][]string, len(queries))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				diffs[i] = worker.replayStatement(queries[i])
+			}
+		}()
+	}
+	for i := range queries {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	report := &ConcurrentReport{Queries: len(queries)}
+	for i, query := range queries {
+		if len(diffs[i]) > 0 {
+			report.Mismatches = append(report.Mismatches, ReplayMismatch{
+				Statement: i + 1,
+				Query:     query,
+				Diffs:     diffs[i],
+			})
+		}
+	}
+	if len(report.Mismatches) > 0 {
+		mcmp.t.Errorf("%s", report)
+	}
+	return report
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentReport(t *testing.T) {
+	report := &ConcurrentReport{
+		Queries: 4,
+		Mismatches: []ReplayMismatch{{
+			Statement: 1,
+			Query:     "select id1 from t1",
+			Diffs:     []string{"Vitess and MySQL are not erroring the same way.\nVitess error: <nil>\nMySQL error: table t1 does not exist"},
+		}, {
+			Statement: 3,
+			Query:     "select count(*) from t2",
+			Diffs:     []string{"Query (select count(*) from t2) results mismatched.\nVitess Results:\n[INT64(1)]\n"},
+		}},
+	}
+	want := "2 of 4 queries mismatched between Vitess and MySQL\n" +
+		"\n#1: select id1 from t1\n" +
+		"  Vitess and MySQL are not erroring the same way.\n  Vitess error: <nil>\n  MySQL error: table t1 does not exist\n" +
+		"\n#3: select count(*) from t2\n" +
+		"  Query (select count(*) from t2) results mismatched.\n  Vitess Results:\n  [INT64(1)]\n"
+	assert.Equal(t, want, report.String())
+}
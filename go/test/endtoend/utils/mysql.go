@@ -20,10 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand/v2"
 	"os"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"time"
 
@@ -31,6 +33,8 @@ import (
 
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/decimal"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/endtoend/cluster"
 	"vitess.io/vitess/go/vt/dbconfigs"
@@ -175,6 +179,20 @@ func prepareMySQLWithSchema(params mysql.ConnParams, sql string) error {
 type CompareOptions struct {
 	CompareColumnNames bool
 	IgnoreRowsAffected bool
+
+	// FloatTolerance makes the floating-point values of Vitess and MySQL equal
+	// when they differ by at most FloatTolerance, or by at most FloatTolerance
+	// times the larger of their absolute values.
+	FloatTolerance float64
+	// DecimalPrecision, when positive, makes the decimal values of Vitess and
+	// MySQL equal when they are once rounded to DecimalPrecision digits after
+	// the decimal point.
+	DecimalPrecision int
+	// CollationAware makes the text values of Vitess and MySQL equal when
+	// they are equal with the collation of their column in the result set of
+	// MySQL, so that the case or accent insensitive collations do not make
+	// e.g. 'a' and 'A' mismatch.
+	CollationAware bool
 }
 
 func CompareVitessAndMySQLResults(t TestingT, query string, vtConn *mysql.Conn, vtQr, mysqlQr *sqltypes.Result, opts CompareOptions) error {
@@ -233,6 +251,10 @@ func CompareVitessAndMySQLResults(t TestingT, query string, vtConn *mysql.Conn,
 	if (orderBy && sqltypes.ResultsEqual([]*sqltypes.Result{vtQr}, []*sqltypes.Result{mysqlQr})) || sqltypes.ResultsEqualUnordered([]sqltypes.Result{*vtQr}, []sqltypes.Result{*mysqlQr}) {
 		return nil
 	}
+	if (opts.FloatTolerance > 0 || opts.DecimalPrecision > 0 || opts.CollationAware) && vtQr.RowsAffected == mysqlQr.RowsAffected &&
+		((orderBy && rowsEqualWithin(vtQr.Rows, mysqlQr.Rows, mysqlQr.Fields, true, opts)) || rowsEqualWithin(vtQr.Rows, mysqlQr.Rows, mysqlQr.Fields, false, opts)) {
+		return nil
+	}
 
 	errStr := "Query (" + query + ") results mismatched.\nVitess Results:\n"
 	for _, row := range vtQr.Rows {
@@ -254,6 +276,84 @@ func CompareVitessAndMySQLResults(t TestingT, query string, vtConn *mysql.Conn,
 	return errors.New(errStr)
 }
 
+// rowsEqualWithin compares the rows of Vitess and MySQL, in order if ordered
+// is set, with the float and decimal tolerances of the options, and the
+// collations of the fields of MySQL if the options are collation aware.
+func rowsEqualWithin(vtRows, myRows []sqltypes.Row, myFields []*querypb.Field, ordered bool, opts CompareOptions) bool {
+	if len(vtRows) != len(myRows) {
+		return false
+	}
+	var colls []colldata.Collation
+	if opts.CollationAware {
+		colls = make([]colldata.Collation, len(myFields))
+		for i, field := range myFields {
+			colls[i] = colldata.Lookup(collations.ID(field.Charset))
+		}
+	}
+	rowEqual := func(vtRow, myRow sqltypes.Row) bool {
+		if len(vtRow) != len(myRow) {
+			return false
+		}
+		for i := range vtRow {
+			var coll colldata.Collation
+			if i < len(colls) {
+				coll = colls[i]
+			}
+			if !valueEqualWithin(vtRow[i], myRow[i], coll, opts) {
+				return false
+			}
+		}
+		return true
+	}
+	if ordered {
+		return slices.EqualFunc(vtRows, myRows, rowEqual)
+	}
+	matched := make([]bool, len(myRows))
+	for _, vtRow := range vtRows {
+		found := false
+		for i, myRow := range myRows {
+			if !matched[i] && rowEqual(vtRow, myRow) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqualWithin compares a value of Vitess and one of MySQL, with the float
+// and decimal tolerances of the options, and the collation of their column if
+// it is not nil.
+func valueEqualWithin(vtVal, myVal sqltypes.Value, coll colldata.Collation, opts CompareOptions) bool {
+	if vtVal.Equal(myVal) {
+		return true
+	}
+	switch {
+	case coll != nil && vtVal.IsText() && myVal.IsText():
+		return coll.Collate(vtVal.Raw(), myVal.Raw(), false) == 0
+	case opts.FloatTolerance > 0 && vtVal.IsFloat() && myVal.IsFloat():
+		vtFloat, vtErr := vtVal.ToFloat64()
+		myFloat, myErr := myVal.ToFloat64()
+		if vtErr != nil || myErr != nil {
+			return false
+		}
+		diff := math.Abs(vtFloat - myFloat)
+		return diff <= opts.FloatTolerance || diff <= opts.FloatTolerance*max(math.Abs(vtFloat), math.Abs(myFloat))
+	case opts.DecimalPrecision > 0 && vtVal.IsDecimal() && myVal.IsDecimal():
+		vtDec, vtErr := decimal.NewFromMySQL(vtVal.Raw())
+		myDec, myErr := decimal.NewFromMySQL(myVal.Raw())
+		if vtErr != nil || myErr != nil {
+			return false
+		}
+		places := int32(opts.DecimalPrecision)
+		return vtDec.Round(places).Equal(myDec.Round(places))
+	}
+	return false
+}
+
 // Parse the string representation of a type (i.e. "INT64") into a three elements slice.
 // First element of the slice will contain the full expression, second element contains the
 // type "INT" and the third element contains the size if there is any "64" or empty if we use
@@ -28,7 +28,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/endtoend/cluster"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -109,6 +111,115 @@ func TestCheckFields(t *testing.T) {
 	}
 }
 
+func TestRowsEqualWithin(t *testing.T) {
+	float := func(f string) sqltypes.Row { return sqltypes.Row{sqltypes.MakeTrusted(sqltypes.Float64, []byte(f))} }
+	dec := func(d string) sqltypes.Row { return sqltypes.Row{sqltypes.MakeTrusted(sqltypes.Decimal, []byte(d))} }
+	text := func(s string) sqltypes.Row { return sqltypes.Row{sqltypes.NewVarChar(s)} }
+	collation := func(id collations.ID) []*querypb.Field {
+		return []*querypb.Field{{Name: "col", Type: sqltypes.VarChar, Charset: uint32(id)}}
+	}
+
+	cases := []struct {
+		name           string
+		vtRows, myRows []sqltypes.Row
+		myFields       []*querypb.Field
+		ordered        bool
+		opts           CompareOptions
+		equal          bool
+	}{{
+		name:   "float without tolerance",
+		vtRows: []sqltypes.Row{float("0.30000000000000004")},
+		myRows: []sqltypes.Row{float("0.3")},
+	}, {
+		name:   "float within absolute tolerance",
+		vtRows: []sqltypes.Row{float("0.30000000000000004")},
+		myRows: []sqltypes.Row{float("0.3")},
+		opts:   CompareOptions{FloatTolerance: 1e-9},
+		equal:  true,
+	}, {
+		name:   "float within relative tolerance",
+		vtRows: []sqltypes.Row{float("12345678901.5")},
+		myRows: []sqltypes.Row{float("12345678901.25")},
+		opts:   CompareOptions{FloatTolerance: 1e-9},
+		equal:  true,
+	}, {
+		name:   "float beyond tolerance",
+		vtRows: []sqltypes.Row{float("1.5")},
+		myRows: []sqltypes.Row{float("1.25")},
+		opts:   CompareOptions{FloatTolerance: 1e-9},
+	}, {
+		name:   "decimal within precision",
+		vtRows: []sqltypes.Row{dec("2.3333333333")},
+		myRows: []sqltypes.Row{dec("2.3333")},
+		opts:   CompareOptions{DecimalPrecision: 4},
+		equal:  true,
+	}, {
+		name:   "decimal beyond precision",
+		vtRows: []sqltypes.Row{dec("2.3334")},
+		myRows: []sqltypes.Row{dec("2.3333")},
+		opts:   CompareOptions{DecimalPrecision: 4},
+	}, {
+		name:   "decimal without precision",
+		vtRows: []sqltypes.Row{dec("2.3333333333")},
+		myRows: []sqltypes.Row{dec("2.3333")},
+		opts:   CompareOptions{FloatTolerance: 1},
+	}, {
+		name:   "unordered",
+		vtRows: []sqltypes.Row{float("1.0000000001"), float("2")},
+		myRows: []sqltypes.Row{float("2"), float("1")},
+		opts:   CompareOptions{FloatTolerance: 1e-9},
+		equal:  true,
+	}, {
+		name:    "ordered",
+		vtRows:  []sqltypes.Row{float("1.0000000001"), float("2")},
+		myRows:  []sqltypes.Row{float("2"), float("1")},
+		ordered: true,
+		opts:    CompareOptions{FloatTolerance: 1e-9},
+	}, {
+		name:   "row count",
+		vtRows: []sqltypes.Row{float("1")},
+		myRows: []sqltypes.Row{float("1"), float("1")},
+		opts:   CompareOptions{FloatTolerance: 1e-9},
+	}, {
+		name:     "text without collation",
+		vtRows:   []sqltypes.Row{text("ABC")},
+		myRows:   []sqltypes.Row{text("abc")},
+		myFields: collation(collations.CollationUtf8mb4ID),
+		opts:     CompareOptions{FloatTolerance: 1e-9},
+	}, {
+		name:     "text with case insensitive collation",
+		vtRows:   []sqltypes.Row{text("ABC")},
+		myRows:   []sqltypes.Row{text("abc")},
+		myFields: collation(collations.CollationUtf8mb4ID),
+		opts:     CompareOptions{CollationAware: true},
+		equal:    true,
+	}, {
+		name:     "text with accent insensitive collation",
+		vtRows:   []sqltypes.Row{text("café"), text("b")},
+		myRows:   []sqltypes.Row{text("B"), text("cafe")},
+		myFields: collation(collations.CollationUtf8mb4ID),
+		opts:     CompareOptions{CollationAware: true},
+		equal:    true,
+	}, {
+		name:     "text with binary collation",
+		vtRows:   []sqltypes.Row{text("ABC")},
+		myRows:   []sqltypes.Row{text("abc")},
+		myFields: collation(collations.CollationUtf8mb4BinID),
+		opts:     CompareOptions{CollationAware: true},
+	}, {
+		name:     "text with different values",
+		vtRows:   []sqltypes.Row{text("abd")},
+		myRows:   []sqltypes.Row{text("abc")},
+		myFields: collation(collations.CollationUtf8mb4ID),
+		opts:     CompareOptions{CollationAware: true},
+	}}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.equal, rowsEqualWithin(c.vtRows, c.myRows, c.myFields, c.ordered, c.opts))
+		})
+	}
+}
+
 func TestCreateMySQL(t *testing.T) {
 	ctx := context.Background()
 	conn, err := mysql.Connect(ctx, &mysqlParams)
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// preparedFieldTypes maps the database type names of the columns reported by
+// the driver to the types of the fields of a result.
+var preparedFieldTypes = map[string]querypb.Type{
+	"NULL":              sqltypes.Null,
+	"TINYINT":           sqltypes.Int8,
+	"UNSIGNED TINYINT":  sqltypes.Uint8,
+	"SMALLINT":          sqltypes.Int16,
+	"UNSIGNED SMALLINT": sqltypes.Uint16,
+	"MEDIUMINT":         sqltypes.Int24,
+	"INT":               sqltypes.Int32,
+	"UNSIGNED INT":      sqltypes.Uint32,
+	"BIGINT":            sqltypes.Int64,
+	"UNSIGNED BIGINT":   sqltypes.Uint64,
+	"FLOAT":             sqltypes.Float32,
+	"DOUBLE":            sqltypes.Float64,
+	"DECIMAL":           sqltypes.Decimal,
+	"TIMESTAMP":         sqltypes.Timestamp,
+	"DATE":              sqltypes.Date,
+	"TIME":              sqltypes.Time,
+	"DATETIME":          sqltypes.Datetime,
+	"YEAR":              sqltypes.Year,
+	"TINYTEXT":          sqltypes.Text,
+	"TEXT":              sqltypes.Text,
+	"MEDIUMTEXT":        sqltypes.Text,
+	"LONGTEXT":          sqltypes.Text,
+	"TINYBLOB":          sqltypes.Blob,
+	"BLOB":              sqltypes.Blob,
+	"MEDIUMBLOB":        sqltypes.Blob,
+	"LONGBLOB":          sqltypes.Blob,
+	"VARCHAR":           sqltypes.VarChar,
+	"VARBINARY":         sqltypes.VarBinary,
+	"CHAR":              sqltypes.Char,
+	"BINARY":            sqltypes.Binary,
+	"BIT":               sqltypes.Bit,
+	"ENUM":              sqltypes.Enum,
+	"SET":               sqltypes.Set,
+	"GEOMETRY":          sqltypes.Geometry,
+	"JSON":              sqltypes.TypeJSON,
+}
+
+// ExecPrepared prepares the query on both Vitess and MySQL with the binary
+// protocol, executes it with the given parameters and compares the results,
+// including the names and types of their columns. The query uses ? for its
+// parameters. The statements are prepared on connections of their own, which
+// do not share the session of VtConn and MySQLConn. The result set of Vitess
+// is returned, with the values in their text form.
+func (mcmp *MySQLCompare) ExecPrepared(query string, bindVars ...any) *sqltypes.Result {
+	mcmp.t.Helper()
+	vtQr, err := execPrepared(mcmp.vtParams, query, bindVars)
+	require.NoError(mcmp.t, err, "[Vitess Error] for query: "+query)
+
+	mysqlQr, err := execPrepared(mcmp.mysqlParams, query, bindVars)
+	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+	CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{CompareColumnNames: true})
+	return vtQr
+}
+
+// ExecPreparedAllowError prepares and executes the query like ExecPrepared.
+// If there is no error, it compares the results. It returns the error of
+// Vitess without comparing the results.
+func (mcmp *MySQLCompare) ExecPreparedAllowError(query string, bindVars ...any) (*sqltypes.Result, error) {
+	mcmp.t.Helper()
+	vtQr, vtErr := execPrepared(mcmp.vtParams, query, bindVars)
+	if vtErr != nil {
+		return nil, vtErr
+	}
+	mysqlQr, mysqlErr := execPrepared(mcmp.mysqlParams, query, bindVars)
+	if mysqlErr == nil {
+		vtErr = CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{CompareColumnNames: true})
+	}
+	return vtQr, vtErr
+}
+
+// execPrepared prepares the query on a new connection to params, which the
+// driver does with the binary protocol since it does not interpolate the
+// parameters, and executes it with bindVars.
+func execPrepared(params mysql.ConnParams, query string, bindVars []any) (*sqltypes.Result, error) {
+	cfg := mysqldriver.NewConfig()
+	cfg.User = params.Uname
+	cfg.Passwd = params.Pass
+	cfg.DBName = params.DbName
+	if params.UnixSocket != "" {
+		cfg.Net = "unix"
+		cfg.Addr = params.UnixSocket
+	} else {
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(params.Host, strconv.Itoa(params.Port))
+	}
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.QueryContext(ctx, bindVars...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	qr := &sqltypes.Result{}
+	for _, column := range columns {
+		typ, ok := preparedFieldTypes[column.DatabaseTypeName()]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q of column %s", column.DatabaseTypeName(), column.Name())
+		}
+		field := &querypb.Field{Name: column.Name(), Type: typ}
+		if _, scale, ok := column.DecimalSize(); ok {
+			field.Decimals = uint32(scale)
+		}
+		qr.Fields = append(qr.Fields, field)
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make(sqltypes.Row, len(values))
+		for i, value := range values {
+			if value == nil {
+				row[i] = sqltypes.NULL
+				continue
+			}
+			row[i] = sqltypes.MakeTrusted(qr.Fields[i].Type, []byte(value))
+		}
+		qr.Rows = append(qr.Rows, row)
+	}
+	return qr, rows.Err()
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// ReplayMismatch is a statement of a replayed file, or a query of
+// ExecConcurrently, on which Vitess and MySQL disagree.
+type ReplayMismatch struct {
+	// Statement is the 1-based position of the statement in the file.
+	Statement int
+	Query     string
+	// Diffs are the differences reported by the comparison of the results,
+	// errors and session states of Vitess and MySQL.
+	Diffs []string
+}
+
+// ReplayReport is the outcome of the replay of a file of statements on both
+// Vitess and MySQL.
+type ReplayReport struct {
+	Path       string
+	Statements int
+	Mismatches []ReplayMismatch
+}
+
+// String returns the report, listing the mismatched statements in order.
+func (r *ReplayReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %d of %d statements mismatched between Vitess and MySQL\n", r.Path, len(r.Mismatches), r.Statements)
+	writeMismatches(&sb, r.Mismatches)
+	return sb.String()
+}
+
+// writeMismatches writes the mismatches of a report, with their differences
+// indented below them.
+func writeMismatches(sb *strings.Builder, mismatches []ReplayMismatch) {
+	for _, m := range mismatches {
+		fmt.Fprintf(sb, "\n#%d: %s\n", m.Statement, m.Query)
+		for _, diff := range m.Diffs {
+			fmt.Fprintf(sb, "  %s\n", strings.ReplaceAll(strings.TrimSpace(diff), "\n", "\n  "))
+		}
+	}
+}
+
+// ReplayFile executes each statement of the file at path against both Vitess
+// and MySQL, in order, and compares their results, errors and session states
+// like ExecAllowAndCompareError. The mismatches do not stop the replay: they
+// are collected in the returned report, and the test is marked as failed once,
+// with the report, if there are any.
+//
+// The statements of the file are delimited by semicolons, and may then span
+// several lines. A file without any semicolon, like a query log, has one
+// statement per line. Blank lines and the lines starting with -- or # are
+// skipped. The results of more than 1000 rows fail on both sides, so they are
+// not compared.
+func (mcmp *MySQLCompare) ReplayFile(path string) *ReplayReport {
+	mcmp.t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(mcmp.t, err)
+	queries, err := replayStatements(string(content))
+	require.NoError(mcmp.t, err, "reading statements of %s", path)
+
+	report := &ReplayReport{Path: path, Statements: len(queries)}
+	for i, query := range queries {
+		if diffs := mcmp.replayStatement(query); len(diffs) > 0 {
+			report.Mismatches = append(report.Mismatches, ReplayMismatch{
+				Statement: i + 1,
+				Query:     query,
+				Diffs:     diffs,
+			})
+		}
+	}
+	if len(report.Mismatches) > 0 {
+		mcmp.t.Errorf("%s", report)
+	}
+	return report
+}
+
+// replayStatement executes the query against both Vitess and MySQL, and
+// returns the differences between them instead of failing the test.
+func (mcmp *MySQLCompare) replayStatement(query string) (diffs []string) {
+	rec := &replayRecorder{}
+	inner := &MySQLCompare{
+		t:                   rec,
+		MySQLConn:           mcmp.MySQLConn,
+		VtConn:              mcmp.VtConn,
+		CompareSessionState: mcmp.CompareSessionState,
+		vtParams:            mcmp.vtParams,
+		mysqlParams:         mcmp.mysqlParams,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errReplayFailNow {
+				panic(r)
+			}
+			diffs = rec.diffs
+		}
+	}()
+	_, _ = inner.ExecAllowAndCompareError(query, CompareOptions{})
+	return rec.diffs
+}
+
+// errReplayFailNow is the value replayRecorder panics with to stop the
+// comparison of a statement, which replayStatement recovers from.
+var errReplayFailNow = errors.New("replayed statement failed")
+
+// replayRecorder is a TestingT which records the errors of the comparison of
+// a replayed statement instead of failing the test.
+type replayRecorder struct {
+	diffs []string
+}
+
+func (r *replayRecorder) Errorf(format string, args ...any) {
+	r.diffs = append(r.diffs, fmt.Sprintf(format, args...))
+}
+
+func (r *replayRecorder) FailNow() {
+	panic(errReplayFailNow)
+}
+
+func (r *replayRecorder) Helper() {}
+
+// replayStatements returns the statements of the content of a replayed file.
+func replayStatements(content string) ([]string, error) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	content = strings.Join(lines, "\n")
+	if !strings.Contains(content, ";") {
+		return lines, nil
+	}
+	pieces, err := sqlparser.NewTestParser().SplitStatementToPieces(content)
+	if err != nil {
+		return nil, err
+	}
+	for i, piece := range pieces {
+		pieces[i] = strings.TrimSpace(piece)
+	}
+	return pieces, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayStatements(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    []string
+	}{{
+		name:    "one per line",
+		content: "select 1\n\n  select * from t1 where id1 = 2  \n-- a comment\n# another comment\ninsert into t1(id1) values (3)\n",
+		want:    []string{"select 1", "select * from t1 where id1 = 2", "insert into t1(id1) values (3)"},
+	}, {
+		name:    "delimited",
+		content: "select 1;\nselect *\nfrom t1\n-- a comment\nwhere id2 = ';';\ninsert into t1(id1) values (3);\n",
+		want:    []string{"select 1", "select *\nfrom t1\nwhere id2 = ';'", "insert into t1(id1) values (3)"},
+	}, {
+		name:    "empty",
+		content: "\n-- nothing\n",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := replayStatements(tc.content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReplayReport(t *testing.T) {
+	report := &ReplayReport{
+		Path:       "queries.sql",
+		Statements: 3,
+		Mismatches: []ReplayMismatch{{
+			Statement: 2,
+			Query:     "select id1 from t1",
+			Diffs:     []string{"Query (select id1 from t1) results mismatched.\nVitess Results:\n[INT64(1)]\n"},
+		}},
+	}
+	want := "queries.sql: 1 of 3 statements mismatched between Vitess and MySQL\n" +
+		"\n#2: select id1 from t1\n" +
+		"  Query (select id1 from t1) results mismatched.\n  Vitess Results:\n  [INT64(1)]\n"
+	assert.Equal(t, want, report.String())
+}
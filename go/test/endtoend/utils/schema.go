@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/test/endtoend/cluster"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// defaultVindexType is the type of the primary vindex generated for the
+// tables of a sharded keyspace when no vindex is specified.
+const defaultVindexType = "xxhash"
+
+// ColumnVindex specifies the primary vindex of a table created by SyncSchema.
+type ColumnVindex struct {
+	Table  string
+	Column string
+	// Type is the type of the vindex. It defaults to xxhash.
+	Type string
+}
+
+// SyncSchema creates tables from the given CREATE TABLE statements on both
+// the MySQL comparison instance and the keyspace of the cluster, and adds
+// them to the vschema of the keyspace. In a sharded keyspace, every table
+// gets a primary vindex on the first column of its primary key (or its first
+// column if it has none), unless a vindex is specified for it. The tables
+// are dropped and the vschema is restored when the test finishes.
+//
+// SyncSchema waits for vtgate to know about the new tables before
+// returning, so they can be queried right away.
+func (mcmp *MySQLCompare) SyncSchema(clusterInstance *cluster.LocalProcessCluster, keyspace string, createTables string, vindexes ...ColumnVindex) {
+	t := mcmp.AsT()
+	t.Helper()
+
+	tables, err := parseCreateTables(createTables)
+	require.NoError(t, err)
+
+	original, err := clusterInstance.VtctldClientProcess.GetVSchema(keyspace)
+	require.NoError(t, err)
+	vschema, err := vschemaForTables(original, tables, vindexes)
+	require.NoError(t, err)
+	vschemaJSON, err := json2.MarshalPB(vschema)
+	require.NoError(t, err)
+
+	var names, quotedNames []string
+	for _, table := range tables {
+		names = append(names, table.Table.Name.String())
+		quotedNames = append(quotedNames, sqlparser.String(table.Table.Name))
+	}
+	t.Cleanup(func() {
+		dropTables := "drop table if exists " + strings.Join(quotedNames, ", ")
+		_, err := mcmp.MySQLConn.ExecuteFetch(dropTables, 0, false)
+		require.NoError(t, err)
+		require.NoError(t, clusterInstance.VtctldClientProcess.ApplySchema(keyspace, dropTables))
+
+		originalJSON, err := json2.MarshalPB(original)
+		require.NoError(t, err)
+		require.NoError(t, clusterInstance.VtctldClientProcess.ApplyVSchema(keyspace, string(originalJSON)))
+	})
+
+	for _, table := range tables {
+		_, err := mcmp.MySQLConn.ExecuteFetch(sqlparser.String(table), 0, false)
+		require.NoError(t, err)
+	}
+	require.NoError(t, clusterInstance.VtctldClientProcess.ApplySchema(keyspace, createTables))
+	require.NoError(t, clusterInstance.VtctldClientProcess.ApplyVSchema(keyspace, string(vschemaJSON)))
+
+	WaitForVschemaCondition(t, clusterInstance.VtgateProcess, keyspace, func(t *testing.T, keyspace map[string]any) bool {
+		tablesMap := convertToMap(keyspace["tables"])
+		for _, name := range names {
+			if _, ok := tablesMap[name]; !ok {
+				return false
+			}
+		}
+		return true
+	}, fmt.Sprintf("Waiting for tables %v to be added", names))
+}
+
+// parseCreateTables parses sql, which must only contain CREATE TABLE
+// statements.
+func parseCreateTables(sql string) ([]*sqlparser.CreateTable, error) {
+	parser := sqlparser.NewTestParser()
+	pieces, err := parser.SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*sqlparser.CreateTable
+	for _, piece := range pieces {
+		stmt, err := parser.Parse(piece)
+		if err != nil {
+			return nil, err
+		}
+		create, ok := stmt.(*sqlparser.CreateTable)
+		if !ok {
+			return nil, fmt.Errorf("not a CREATE TABLE statement: %s", piece)
+		}
+		tables = append(tables, create)
+	}
+	return tables, nil
+}
+
+// vschemaForTables returns a copy of the vschema with the tables added.
+func vschemaForTables(original *vschemapb.Keyspace, tables []*sqlparser.CreateTable, vindexes []ColumnVindex) (*vschemapb.Keyspace, error) {
+	vschema := original.CloneVT()
+	if vschema.Tables == nil {
+		vschema.Tables = make(map[string]*vschemapb.Table)
+	}
+	if vschema.Vindexes == nil {
+		vschema.Vindexes = make(map[string]*vschemapb.Vindex)
+	}
+
+	specified := make(map[string]ColumnVindex, len(vindexes))
+	for _, vindex := range vindexes {
+		specified[vindex.Table] = vindex
+	}
+
+	for _, create := range tables {
+		name := create.Table.Name.String()
+		if !vschema.Sharded {
+			vschema.Tables[name] = &vschemapb.Table{}
+			continue
+		}
+
+		vindex, ok := specified[name]
+		if !ok {
+			vindex = ColumnVindex{Table: name, Column: primaryKeyColumn(create)}
+		}
+		if vindex.Column == "" {
+			return nil, fmt.Errorf("cannot find a column for the primary vindex of table %s", name)
+		}
+		if vindex.Type == "" {
+			vindex.Type = defaultVindexType
+		}
+		if _, ok := vschema.Vindexes[vindex.Type]; !ok {
+			vschema.Vindexes[vindex.Type] = &vschemapb.Vindex{Type: vindex.Type}
+		}
+		vschema.Tables[name] = &vschemapb.Table{
+			ColumnVindexes: []*vschemapb.ColumnVindex{{
+				Name:    vindex.Type,
+				Columns: []string{vindex.Column},
+			}},
+		}
+	}
+	return vschema, nil
+}
+
+// primaryKeyColumn returns the first column of the primary key of the table,
+// or its first column if it has no primary key.
+func primaryKeyColumn(create *sqlparser.CreateTable) string {
+	if create.TableSpec == nil || len(create.TableSpec.Columns) == 0 {
+		return ""
+	}
+	for _, index := range create.TableSpec.Indexes {
+		if index.Info.Type == sqlparser.IndexTypePrimary && len(index.Columns) > 0 {
+			return index.Columns[0].Column.String()
+		}
+	}
+	for _, column := range create.TableSpec.Columns {
+		if column.Type.Options != nil && column.Type.Options.KeyOpt == sqlparser.ColKeyPrimary {
+			return column.Name.String()
+		}
+	}
+	return create.TableSpec.Columns[0].Name.String()
+}
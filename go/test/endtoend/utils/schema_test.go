@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestParseCreateTables(t *testing.T) {
+	tables, err := parseCreateTables(`
+create table t1(id bigint, primary key(id));
+create table t2(id bigint primary key, name varchar(10) default ';')
+`)
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "t1", tables[0].Table.Name.String())
+	assert.Equal(t, "t2", tables[1].Table.Name.String())
+
+	tables, err = parseCreateTables("")
+	require.NoError(t, err)
+	assert.Empty(t, tables)
+
+	_, err = parseCreateTables("create table t1(id bigint); insert into t1(id) values (1)")
+	require.ErrorContains(t, err, "not a CREATE TABLE statement:")
+	require.ErrorContains(t, err, "insert into t1(id) values (1)")
+
+	_, err = parseCreateTables("create table t1(id bigint")
+	require.ErrorContains(t, err, "syntax error")
+}
+
+func TestPrimaryKeyColumn(t *testing.T) {
+	testCases := []struct {
+		create string
+		want   string
+	}{{
+		create: "create table t(a bigint, b bigint, primary key(b, a))",
+		want:   "b",
+	}, {
+		create: "create table t(a bigint, b bigint primary key)",
+		want:   "b",
+	}, {
+		create: "create table t(a bigint, b bigint, unique key(b))",
+		want:   "a",
+	}, {
+		create: "create table t like t2",
+		want:   "",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.create, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tc.create)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, primaryKeyColumn(stmt.(*sqlparser.CreateTable)))
+		})
+	}
+}
+
+func TestVSchemaForTables(t *testing.T) {
+	tables, err := parseCreateTables(`
+create table t1(id bigint, primary key(id));
+create table t2(id bigint primary key, name varchar(10));
+create table t3(name varchar(10), id bigint)
+`)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		original *vschemapb.Keyspace
+		vindexes []ColumnVindex
+		want     *vschemapb.Keyspace
+	}{{
+		name:     "unsharded",
+		original: &vschemapb.Keyspace{},
+		want: &vschemapb.Keyspace{
+			Tables: map[string]*vschemapb.Table{
+				"t1": {},
+				"t2": {},
+				"t3": {},
+			},
+			Vindexes: map[string]*vschemapb.Vindex{},
+		},
+	}, {
+		name: "sharded",
+		original: &vschemapb.Keyspace{
+			Sharded:  true,
+			Vindexes: map[string]*vschemapb.Vindex{"hash": {Type: "hash"}},
+			Tables:   map[string]*vschemapb.Table{"t0": {}},
+		},
+		vindexes: []ColumnVindex{
+			{Table: "t1", Column: "id"},
+			{Table: "t2", Column: "name", Type: "unicode_loose_xxhash"},
+		},
+		want: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"hash":                 {Type: "hash"},
+				"xxhash":               {Type: "xxhash"},
+				"unicode_loose_xxhash": {Type: "unicode_loose_xxhash"},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"t0": {},
+				"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Columns: []string{"id"}}}},
+				"t2": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "unicode_loose_xxhash", Columns: []string{"name"}}}},
+				// Without a primary key, the first column is the vindex column.
+				"t3": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Columns: []string{"name"}}}},
+			},
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.original.CloneVT()
+			got, err := vschemaForTables(tc.original, tables, tc.vindexes)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tc.want, got), "got %v, want %v", got, tc.want)
+			// The original vschema is not modified.
+			assert.True(t, proto.Equal(original, tc.original), "original vschema modified: %v", tc.original)
+		})
+	}
+}
+
+func TestVSchemaForTablesWithoutColumn(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().Parse("create table t1 like t2")
+	require.NoError(t, err)
+
+	_, err = vschemaForTables(&vschemapb.Keyspace{Sharded: true}, []*sqlparser.CreateTable{stmt.(*sqlparser.CreateTable)}, nil)
+	require.EqualError(t, err, "cannot find a column for the primary vindex of table t1")
+}
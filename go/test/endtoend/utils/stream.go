@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// ExecStream executes the given query against both Vitess and MySQL with the
+// streaming protocol, Vitess with the OLAP workload, and compares the two
+// result sets as their rows are fetched. The rows of a query with an ORDER BY
+// are compared in the order they arrive, and the test fails at the first row
+// which does not match. The rows of other queries, and the names and types of
+// the columns, are compared once all the rows arrived.
+// The workload of VtConn is restored after the query. The result set of
+// Vitess is returned to the caller.
+func (mcmp *MySQLCompare) ExecStream(query string) *sqltypes.Result {
+	mcmp.t.Helper()
+	stmt, err := sqlparser.NewTestParser().Parse(query)
+	require.NoError(mcmp.t, err)
+	ordered := false
+	if selStmt, isSelStmt := stmt.(sqlparser.SelectStatement); isSelStmt {
+		ordered = selStmt.GetOrderBy() != nil
+	}
+
+	qr, err := mcmp.VtConn.ExecuteFetch("select @@workload", 1, false)
+	require.NoError(mcmp.t, err)
+	workload := strings.ToLower(qr.Rows[0][0].ToString())
+	_, err = mcmp.VtConn.ExecuteFetch("set workload = olap", 0, false)
+	require.NoError(mcmp.t, err)
+	defer func() {
+		_, err := mcmp.VtConn.ExecuteFetch("set workload = "+workload, 0, false)
+		require.NoError(mcmp.t, err)
+	}()
+
+	err = mcmp.VtConn.ExecuteStreamFetch(query)
+	require.NoError(mcmp.t, err, "[Vitess Error] for query: "+query)
+	defer mcmp.VtConn.CloseResult()
+	err = mcmp.MySQLConn.ExecuteStreamFetch(query)
+	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+	defer mcmp.MySQLConn.CloseResult()
+
+	vtQr, mysqlQr := &sqltypes.Result{}, &sqltypes.Result{}
+	vtQr.Fields, err = mcmp.VtConn.Fields()
+	require.NoError(mcmp.t, err, "[Vitess Error] for query: "+query)
+	mysqlQr.Fields, err = mcmp.MySQLConn.Fields()
+	require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+
+	vtDone, mysqlDone := false, false
+	for !vtDone || !mysqlDone {
+		if !vtDone {
+			row, err := mcmp.VtConn.FetchNext(nil)
+			require.NoError(mcmp.t, err, "[Vitess Error] for query: "+query)
+			vtDone = row == nil
+			if !vtDone {
+				vtQr.Rows = append(vtQr.Rows, row)
+			}
+		}
+		if !mysqlDone {
+			row, err := mcmp.MySQLConn.FetchNext(nil)
+			require.NoError(mcmp.t, err, "[MySQL Error] for query: "+query)
+			mysqlDone = row == nil
+			if !mysqlDone {
+				mysqlQr.Rows = append(mysqlQr.Rows, row)
+			}
+		}
+		if ordered && !vtDone && !mysqlDone {
+			i := len(vtQr.Rows) - 1
+			if !sqltypes.RowEqual(mysqlQr.Rows[i], vtQr.Rows[i]) {
+				mcmp.t.Errorf("Query (%s) results mismatched at row %d of the stream.\nVitess: %v\nMySQL: %v", query, i, vtQr.Rows[i], mysqlQr.Rows[i])
+				return vtQr
+			}
+		}
+	}
+	CompareVitessAndMySQLResults(mcmp.t, query, mcmp.VtConn, vtQr, mysqlQr, CompareOptions{CompareColumnNames: true})
+	return vtQr
+}
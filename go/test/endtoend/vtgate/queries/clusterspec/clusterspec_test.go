@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterspec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/test/endtoend/utils"
+)
+
+func TestClusterSpecTopology(t *testing.T) {
+	require.Len(t, clusterInstance.Keyspaces, len(clusterSpec.Keyspaces))
+	for i, ks := range clusterSpec.Keyspaces {
+		keyspace := clusterInstance.Keyspaces[i]
+		assert.Equal(t, ks.Name, keyspace.Name)
+		require.Len(t, keyspace.Shards, len(ks.Shards))
+		for j, shard := range keyspace.Shards {
+			assert.Equal(t, ks.Shards[j], shard.Name)
+			// A primary, the replicas and the rdonly tablet.
+			tablets := 1 + ks.ReplicaCount
+			if ks.Rdonly {
+				tablets++
+			}
+			assert.Len(t, shard.Vttablets, tablets)
+		}
+	}
+}
+
+func TestClusterSpecSeedData(t *testing.T) {
+	conn, err := mysql.Connect(context.Background(), &vtParams)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	utils.AssertMatches(t, conn, "select id, name from customer order by id", `[[INT64(1) VARCHAR("alice")] [INT64(2) VARCHAR("bob")] [INT64(3) VARCHAR("carol")] [INT64(4) VARCHAR("dave")]]`)
+	utils.AssertMatches(t, conn, "select name from customer where id = 3", `[[VARCHAR("carol")]]`)
+	utils.AssertMatches(t, conn, "select name from "+uks+".region order by id", `[[VARCHAR("emea")] [VARCHAR("apac")]]`)
+
+	// The seed data is on both shards.
+	for _, shard := range clusterSpec.Keyspaces[1].Shards {
+		utils.Exec(t, conn, "use `"+keyspaceName+":"+shard+"`")
+		qr := utils.Exec(t, conn, "select count(*) from customer")
+		assert.NotEqual(t, "INT64(0)", qr.Rows[0][0].String(), "shard %s", shard)
+	}
+	utils.Exec(t, conn, "use "+keyspaceName)
+}
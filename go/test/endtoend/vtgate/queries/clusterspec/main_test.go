@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterspec
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/test/endtoend/cluster"
+)
+
+var (
+	clusterInstance *cluster.LocalProcessCluster
+	clusterSpec     *cluster.ClusterSpec
+	vtParams        mysql.ConnParams
+	keyspaceName    = "ks_clusterspec"
+	uks             = "uks_clusterspec"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	exitCode := func() int {
+		// The whole topology, schemas, vschemas and seed data of the cluster
+		// are described by cluster.yaml.
+		var err error
+		clusterSpec, err = cluster.LoadClusterSpec("cluster.yaml")
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		clusterInstance, err = cluster.StartClusterFromSpec(clusterSpec)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		defer clusterInstance.Teardown()
+
+		vtParams = clusterInstance.GetVTParams(keyspaceName)
+		return m.Run()
+	}()
+	os.Exit(exitCode)
+}
@@ -0,0 +1,7 @@
+create table customer
+(
+    id        bigint,
+    name      varchar(64),
+    region_id bigint,
+    primary key (id)
+) Engine = InnoDB;
@@ -0,0 +1,2 @@
+insert into customer(id, name, region_id) values (1, 'alice', 1), (2, 'bob', 2), (3, 'carol', 1);
+insert into customer(id, name, region_id) values (4, 'dave', 2);
@@ -0,0 +1,6 @@
+create table region
+(
+    id   bigint,
+    name varchar(64),
+    primary key (id)
+) Engine = InnoDB;
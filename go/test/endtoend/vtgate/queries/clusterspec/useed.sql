@@ -0,0 +1 @@
+insert into region(id, name) values (1, 'emea'), (2, 'apac');
@@ -430,6 +430,64 @@ func TestPrepareStatements(t *testing.T) {
 	assert.ErrorContains(t, err, "VT09011: Unknown prepared statement handler (prep_art) given to DEALLOCATE PREPARE")
 }
 
+// TestPrepareStatementsBinaryProtocol compares the statements prepared with the
+// binary protocol of the driver, rather than with PREPARE and EXECUTE.
+func TestPrepareStatementsBinaryProtocol(t *testing.T) {
+	mcmp, closer := start(t)
+	defer closer()
+
+	mcmp.Exec("insert into t1(id1, id2) values (0,0), (1,0), (2,0)")
+
+	qr := mcmp.ExecPrepared("select count(*) from t1 where id1 = ?", 1)
+	assert.Equal(t, `[[INT64(1)]]`, fmt.Sprintf("%v", qr.Rows))
+	mcmp.ExecPrepared("select id1, id2 from t1 where id2 = ? order by id1", 0)
+	mcmp.ExecPrepared("select id1, id2 from t1 where id1 in (?, ?)", 0, 2)
+	mcmp.ExecPrepared("select id1 + ?, id2 from t1 where id1 = ?", 10, 2)
+
+	_, err := mcmp.ExecPreparedAllowError("select id1 from t1 where id1 in (?, ?)", 1)
+	assert.Error(t, err)
+}
+
+// TestStreamingQueries compares the queries streamed by Vitess with the OLAP
+// workload with those of MySQL.
+func TestStreamingQueries(t *testing.T) {
+	mcmp, closer := start(t)
+	defer closer()
+
+	mcmp.Exec("insert into t1(id1, id2) values (0,0), (1,0), (2,1), (3,1), (4,2)")
+
+	qr := mcmp.ExecStream("select id1, id2 from t1 order by id1 desc")
+	assert.Equal(t, `[[INT64(4) INT64(2)] [INT64(3) INT64(1)] [INT64(2) INT64(1)] [INT64(1) INT64(0)] [INT64(0) INT64(0)]]`, fmt.Sprintf("%v", qr.Rows))
+	mcmp.ExecStream("select id1, id2 from t1")
+	mcmp.ExecStream("select id2, count(*) as c from t1 group by id2 order by id2")
+	mcmp.ExecStream("select id1 from t1 where id1 > 10")
+	mcmp.ExecStream("select t1.id1, t2.id1 from t1 join t1 as t2 on t1.id2 = t2.id1 order by t1.id1")
+
+	// The workload of the connection is restored after the stream.
+	utils.AssertMatches(t, mcmp.VtConn, "select @@workload", `[[VARCHAR("OLTP")]]`)
+}
+
+// TestConcurrentQueries compares the results of Vitess and MySQL for queries
+// executed concurrently over several connections.
+func TestConcurrentQueries(t *testing.T) {
+	mcmp, closer := start(t)
+	defer closer()
+
+	mcmp.Exec("insert into t1(id1, id2) values (0,0), (1,0), (2,1), (3,1), (4,2)")
+
+	var queries []string
+	for i := range 20 {
+		queries = append(queries,
+			fmt.Sprintf("select id1, id2 from t1 where id1 = %d", i%5),
+			fmt.Sprintf("select id2, count(*) from t1 where id1 >= %d group by id2", i%5),
+			fmt.Sprintf("select t1.id1, t2.id1 from t1 join t1 as t2 on t1.id2 = t2.id1 where t1.id1 < %d", i%5),
+		)
+	}
+	report := mcmp.ExecConcurrently(queries, 4)
+	assert.Equal(t, len(queries), report.Queries)
+	assert.Empty(t, report.Mismatches)
+}
+
 // TestBuggyOuterJoin validates inconsistencies around outer joins, adding these tests to stop regressions.
 func TestBuggyOuterJoin(t *testing.T) {
 	mcmp, closer := start(t)
@@ -837,3 +895,13 @@ func TestJoinMixedCaseExpr(t *testing.T) {
 	mcmp.Exec(`prepare prep_pk from 'SELECT t1.id from all_types t1 join all_types t2 on t1.int_unsigned = (case when t2.int_unsigned in (1, 2, 3) then 1 when t2.int_unsigned = 4 then 10 else 20 end)'`)
 	mcmp.AssertMatches(`execute prep_pk`, `[[INT64(1)] [INT64(1)] [INT64(1)]]`)
 }
+
+// TestReplayFile replays a file of statements on both Vitess and MySQL.
+func TestReplayFile(t *testing.T) {
+	mcmp, closer := start(t)
+	defer closer()
+
+	report := mcmp.ReplayFile("replay.sql")
+	assert.Equal(t, 10, report.Statements)
+	assert.Empty(t, report.Mismatches)
+}
@@ -0,0 +1,14 @@
+-- Statements replayed on both Vitess and MySQL by TestReplayFile.
+insert into t1(id1, id2) values (1, 10), (2, 20), (3, 30), (4, 40);
+insert into tbl(id, unq_col, nonunq_col) values (1, 1, 10), (2, 2, 20), (3, 3, 10);
+select id1, id2 from t1 where id1 in (1, 3);
+select count(*), sum(id2) from t1;
+select t1.id1, tbl.nonunq_col
+from t1
+join tbl on t1.id1 = tbl.id
+order by t1.id1;
+update t1 set id2 = id2 + 1 where id1 > 2;
+select nonunq_col, count(*) from tbl group by nonunq_col order by nonunq_col;
+delete from t1 where id1 = 4;
+select * from t1 order by id1 desc limit 2;
+select * from no_such_table;
@@ -320,11 +320,17 @@ func TestCallProcedure(t *testing.T) {
 
 	utils.AssertMatches(t, conn, "show warnings", `[[VARCHAR("Warning") UINT16(1235) VARCHAR("'CALL' not supported in sharded mode")]]`)
 
-	err = conn.ExecuteFetchMultiDrain(`CALL sp_select()`)
-	require.ErrorContains(t, err, "Multi-Resultset not supported in stored procedure")
-
-	err = conn.ExecuteFetchMultiDrain(`CALL sp_all()`)
-	require.ErrorContains(t, err, "Multi-Resultset not supported in stored procedure")
+	// The result of the select is followed by the status of the call.
+	for _, query := range []string{`CALL sp_select()`, `CALL sp_all()`} {
+		qr, more, err := conn.ExecuteFetchMulti(query, 1000, true)
+		require.NoError(t, err)
+		require.True(t, more)
+		require.NotEmpty(t, qr.Fields)
+		qr, more, _, err = conn.ReadQueryResult(1000, true)
+		require.NoError(t, err)
+		require.False(t, more)
+		require.Empty(t, qr.Fields)
+	}
 
 	qr = utils.Exec(t, conn, `CALL sp_delete()`)
 	require.GreaterOrEqual(t, 1, int(qr.RowsAffected))
@@ -37,6 +37,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
@@ -54,6 +55,7 @@ type VSchemaWrapper struct {
 	SysVarEnabled         bool
 	ForeignKeyChecksState *bool
 	Version               plancontext.PlannerVersion
+	Flags                 plannerflags.Flags
 	EnableViews           bool
 	TestBuilder           func(query string, vschema plancontext.VSchema, keyspace string) (*engine.Plan, error)
 	Env                   *vtenv.Environment
@@ -161,6 +163,10 @@ func (vw *VSchemaWrapper) Environment() *vtenv.Environment {
 func (vw *VSchemaWrapper) PlannerWarning(_ string) {
 }
 
+func (vw *VSchemaWrapper) PlannerFlags() plannerflags.Flags {
+	return vw.Flags
+}
+
 func (vw *VSchemaWrapper) ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error) {
 	defaultFkMode := vschemapb.Keyspace_unmanaged
 	if vw.V.Keyspaces[keyspace] != nil && vw.V.Keyspaces[keyspace].ForeignKeyMode != vschemapb.Keyspace_unspecified {
@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package audit emits structured audit events for the changes made to the
+schemas, the vschemas and the routing rules, so that change management systems
+can track the history of these changes.
+
+Events are dispatched with the event package, so any plugin can listen for
+them with event.AddListener(func(ev *audit.Event) { ... }). When --audit-sinks
+is set, the events are also delivered to the listed sinks, each given as a
+scheme:target pair:
+
+	file:/var/log/vitess/audit.log
+	webhook:https://changes.example.com/vitess
+	kafka:http://kafka-rest-proxy:8082/topics/vitess-audit
+
+More sinks are plugged in with RegisterSink.
+*/
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/event"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+// EventType is the kind of change an event is about.
+type EventType string
+
+const (
+	// ApplySchema is a schema change submitted with ApplySchema.
+	ApplySchema EventType = "apply_schema"
+	// ApplyVSchema is a change of the vschema of a keyspace.
+	ApplyVSchema EventType = "apply_vschema"
+	// ApplyRoutingRules is a change of the routing rules.
+	ApplyRoutingRules EventType = "apply_routing_rules"
+	// OnlineDDL is a change of the status of an Online DDL migration.
+	OnlineDDL EventType = "online_ddl"
+)
+
+// Result is the outcome of a change.
+type Result string
+
+const (
+	Success Result = "success"
+	Failure Result = "failure"
+)
+
+// Event describes a change, who made it and its outcome.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// Actor is the principal who made the change, when known.
+	Actor    string `json:"actor,omitempty"`
+	Keyspace string `json:"keyspace,omitempty"`
+	Shard    string `json:"shard,omitempty"`
+	// MigrationUUIDs are the UUIDs of the Online DDL migrations of the change.
+	MigrationUUIDs []string `json:"migration_uuids,omitempty"`
+	// MigrationStatus is the new status of the migration of an OnlineDDL event.
+	MigrationStatus string `json:"migration_status,omitempty"`
+	// Diff is the change itself: the DDL statements of a schema change, or a
+	// unified diff of the vschema or of the routing rules.
+	Diff   string `json:"diff,omitempty"`
+	Result Result `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Emit sets the time, the actor and the result of the event, and dispatches
+// it to the listeners and the sinks. The actor is taken from the caller id
+// of the context, when the event has none.
+func Emit(ctx context.Context, ev *Event, err error) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	if ev.Actor == "" {
+		ev.Actor = actorFromContext(ctx)
+	}
+	ev.Result = Success
+	if err != nil {
+		ev.Result = Failure
+		ev.Error = err.Error()
+	}
+	event.Dispatch(ev)
+}
+
+// actorFromContext returns the principal of the effective caller id of the
+// context, or else the user authenticated by the gRPC static auth plugin, or
+// else the username of the immediate caller id.
+func actorFromContext(ctx context.Context) string {
+	if actor := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)); actor != "" {
+		return actor
+	}
+	if actor := servenv.StaticAuthUsernameFromContext(ctx); actor != "" {
+		return actor
+	}
+	return callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx))
+}
+
+// Diff returns the unified diff of the JSON representations of before and
+// after, either of which can be nil.
+func Diff(before, after proto.Message) string {
+	text := func(m proto.Message) string {
+		if m == nil || !m.ProtoReflect().IsValid() {
+			return ""
+		}
+		// protojson output is deliberately unstable, so it is indented again
+		// with encoding/json for the lines to diff well.
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return fmt.Sprintf("%v\n", m)
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			return string(data) + "\n"
+		}
+		indented.WriteByte('\n')
+		return indented.String()
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(text(before)),
+		B:        difflib.SplitLines(text(after)),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
+	return diff
+}
+
+var (
+	sinkSpecs   []string
+	queueSize   = 1000
+	sendTimeout = 10 * time.Second
+
+	eventsSent = stats.NewCountersWithMultiLabels(
+		"AuditEventsSent",
+		"Number of audit events sent to each sink, by result",
+		[]string{"Sink", "Result"})
+	eventsDropped = stats.NewCounter(
+		"AuditEventsDropped",
+		"Number of audit events dropped because the audit queue was full")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&sinkSpecs, "audit-sinks", sinkSpecs, "Comma-separated list of the sinks receiving the audit events of the schema, vschema and routing rules changes, as scheme:target pairs. Built-in sinks are file:<path>, webhook:<url> and kafka:<Kafka REST proxy topic url>.")
+	fs.IntVar(&queueSize, "audit-queue-size", queueSize, "Number of audit events waiting to be sent to the sinks, beyond which new events are dropped.")
+	fs.DurationVar(&sendTimeout, "audit-send-timeout", sendTimeout, "Timeout for sending an audit event to a sink.")
+}
+
+var (
+	mu      sync.Mutex
+	current *pipeline
+)
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtctld", "vttablet"} {
+		servenv.OnParseFor(cmd, registerFlags)
+	}
+	servenv.OnInit(func() {
+		if err := start(sinkSpecs, queueSize, sendTimeout); err != nil {
+			log.Exitf("invalid --audit-sinks: %v", err)
+		}
+	})
+	servenv.OnClose(stop)
+
+	event.AddListener(func(ev *Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if current != nil {
+			current.enqueue(ev)
+		}
+	})
+}
+
+// start delivers the events to the sinks of the specs, until stop is called.
+func start(specs []string, size int, timeout time.Duration) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	sinks := make(map[string]Sink, len(specs))
+	for _, spec := range specs {
+		sink, err := newSink(spec)
+		if err != nil {
+			for _, sink := range sinks {
+				_ = sink.Close()
+			}
+			return err
+		}
+		sinks[spec] = sink
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = newPipeline(sinks, size, timeout)
+	return nil
+}
+
+// stop delivers the queued events and closes the sinks.
+func stop() {
+	mu.Lock()
+	p := current
+	current = nil
+	mu.Unlock()
+
+	if p != nil {
+		p.close()
+	}
+}
+
+// pipeline sends the events to the sinks from a queue, so that the changes
+// are not slowed down by the sinks.
+type pipeline struct {
+	sinks   map[string]Sink
+	timeout time.Duration
+	queue   chan *Event
+	done    chan struct{}
+}
+
+func newPipeline(sinks map[string]Sink, size int, timeout time.Duration) *pipeline {
+	p := &pipeline{
+		sinks:   sinks,
+		timeout: timeout,
+		queue:   make(chan *Event, size),
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// enqueue queues the event, or drops it if the queue is full. It must not be
+// called after close.
+func (p *pipeline) enqueue(ev *Event) {
+	select {
+	case p.queue <- ev:
+	default:
+		eventsDropped.Add(1)
+		log.Warningf("Dropping audit event %s of keyspace %s: the audit queue is full", ev.Type, ev.Keyspace)
+	}
+}
+
+func (p *pipeline) run() {
+	defer close(p.done)
+	for ev := range p.queue {
+		for spec, sink := range p.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+			err := sink.Send(ctx, ev)
+			cancel()
+			if err != nil {
+				eventsSent.Add([]string{spec, "Error"}, 1)
+				log.Errorf("Failed to send audit event %s of keyspace %s to %s: %v", ev.Type, ev.Keyspace, spec, err)
+				continue
+			}
+			eventsSent.Add([]string{spec, "OK"}, 1)
+		}
+	}
+}
+
+func (p *pipeline) close() {
+	close(p.queue)
+	<-p.done
+	for spec, sink := range p.sinks {
+		if err := sink.Close(); err != nil {
+			log.Errorf("Failed to close audit sink %s: %v", spec, err)
+		}
+	}
+}
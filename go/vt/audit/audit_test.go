@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestEmit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, start([]string{"file:" + path}, 10, time.Second))
+
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("alice", "", ""), nil)
+	Emit(ctx, &Event{Type: ApplySchema, Keyspace: "ks", Diff: "alter table t add column c int"}, nil)
+	Emit(context.Background(), &Event{Type: ApplyVSchema, Keyspace: "ks", Actor: "bob"}, errors.New("boom"))
+	stop()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var ev Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ev))
+	assert.Equal(t, ApplySchema, ev.Type)
+	assert.Equal(t, "alice", ev.Actor)
+	assert.Equal(t, Success, ev.Result)
+	assert.False(t, ev.Time.IsZero())
+
+	ev = Event{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &ev))
+	assert.Equal(t, "bob", ev.Actor)
+	assert.Equal(t, Failure, ev.Result)
+	assert.Equal(t, "boom", ev.Error)
+}
+
+func TestHTTPSinks(t *testing.T) {
+	var (
+		contentType string
+		body        []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	ev := &Event{Type: OnlineDDL, Keyspace: "ks", Shard: "-80", MigrationUUIDs: []string{"uuid"}, MigrationStatus: "complete", Result: Success}
+
+	sink, err := newSink("webhook:" + server.URL + "/hook")
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(context.Background(), ev))
+	assert.Equal(t, "application/json", contentType)
+	var got Event
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, *ev, got)
+
+	sink, err = newSink("kafka:" + server.URL + "/topics/audit")
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(context.Background(), ev))
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", contentType)
+	var records kafkaRecords
+	require.NoError(t, json.Unmarshal(body, &records))
+	require.Len(t, records.Records, 1)
+	assert.Equal(t, "ks", records.Records[0].Key)
+	assert.Equal(t, ev, records.Records[0].Value)
+
+	sink, err = newSink("webhook:" + server.URL + "/fail")
+	require.NoError(t, err)
+	assert.ErrorContains(t, sink.Send(context.Background(), ev), "503 Service Unavailable: unavailable")
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := newSink("file")
+	assert.ErrorContains(t, err, "expected scheme:target")
+	_, err = newSink("syslog:local0")
+	assert.ErrorContains(t, err, "unknown audit sink scheme")
+
+	RegisterSink("test", func(target string) (Sink, error) { return nil, errors.New(target) })
+	_, err = newSink("test:target")
+	assert.EqualError(t, err, "target")
+	assert.Panics(t, func() { RegisterSink("test", nil) })
+}
+
+func TestDiff(t *testing.T) {
+	before := &vschemapb.Keyspace{Sharded: true, Tables: map[string]*vschemapb.Table{"t1": {}}}
+	after := &vschemapb.Keyspace{Sharded: true, Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}}}
+
+	diff := Diff(before, after)
+	assert.Contains(t, diff, "--- before\n+++ after\n")
+	assert.Contains(t, diff, "+    \"t2\": {}")
+	assert.Empty(t, Diff(before, before))
+
+	var none *vschemapb.Keyspace
+	assert.Contains(t, Diff(none, after), "+  \"sharded\": true,")
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Sink receives the audit events. Send is never called concurrently, nor
+// after Close.
+type Sink interface {
+	Send(ctx context.Context, ev *Event) error
+	Close() error
+}
+
+// SinkFactory creates the sink of a target.
+type SinkFactory func(target string) (Sink, error)
+
+var (
+	sinksMu       sync.Mutex
+	sinkFactories = map[string]SinkFactory{
+		"file":    newFileSink,
+		"webhook": newWebhookSink,
+		"kafka":   newKafkaSink,
+	}
+)
+
+// RegisterSink registers the factory of the sinks of a scheme, so that
+// --audit-sinks accepts scheme:target sinks. It must be called before
+// servenv.Init, usually from the init function of a plugin.
+func RegisterSink(scheme string, factory SinkFactory) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	if _, ok := sinkFactories[scheme]; ok {
+		panic(fmt.Sprintf("audit sink %s is already registered", scheme))
+	}
+	sinkFactories[scheme] = factory
+}
+
+// newSink creates the sink of a scheme:target spec.
+func newSink(spec string) (Sink, error) {
+	scheme, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid audit sink %q, expected scheme:target", spec)
+	}
+	sinksMu.Lock()
+	factory, ok := sinkFactories[scheme]
+	sinksMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown audit sink scheme %q in %q", scheme, spec)
+	}
+	return factory(target)
+}
+
+// fileSink appends the events to a file, one JSON document per line.
+type fileSink struct {
+	file *os.File
+}
+
+func newFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Send(_ context.Context, ev *Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// httpSink posts the events to a URL.
+type httpSink struct {
+	url         string
+	contentType string
+	// body returns the body of the request posting the event.
+	body func(ev *Event) any
+}
+
+// newWebhookSink returns a sink posting each event, as a JSON document, to
+// the URL.
+func newWebhookSink(url string) (Sink, error) {
+	return &httpSink{
+		url:         url,
+		contentType: "application/json",
+		body:        func(ev *Event) any { return ev },
+	}, nil
+}
+
+// kafkaRecords is the body of a request producing records to a topic through
+// a Kafka REST proxy.
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value *Event `json:"value"`
+}
+
+// newKafkaSink returns a sink producing each event as a record of a Kafka
+// topic, through the topic URL of a Kafka REST proxy, for example
+// http://kafka-rest-proxy:8082/topics/vitess-audit. The records are keyed by
+// keyspace, so that the events of a keyspace are kept in order.
+func newKafkaSink(url string) (Sink, error) {
+	return &httpSink{
+		url:         url,
+		contentType: "application/vnd.kafka.json.v2+json",
+		body: func(ev *Event) any {
+			return kafkaRecords{Records: []kafkaRecord{{Key: ev.Keyspace, Value: ev}}}
+		},
+	}, nil
+}
+
+func (s *httpSink) Send(ctx context.Context, ev *Event) error {
+	data, err := json.Marshal(s.body(ev))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", s.contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %s: %s", s.url, resp.Status, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
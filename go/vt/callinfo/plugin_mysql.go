@@ -32,14 +32,26 @@ import (
 // only for Mysql contexts.
 func MysqlCallInfo(ctx context.Context, c *mysql.Conn) context.Context {
 	return NewContext(ctx, &mysqlCallInfoImpl{
-		remoteAddr: c.RemoteAddr().String(),
-		user:       c.User,
+		remoteAddr:        c.RemoteAddr().String(),
+		user:              c.User,
+		connectAttributes: c.ConnectAttributes,
 	})
 }
 
+// MysqlConnectAttributes returns the connection attributes the client sent
+// when connecting, for Mysql contexts.
+func MysqlConnectAttributes(ctx context.Context) map[string]string {
+	mci, ok := ctx.Value(callInfoKey).(*mysqlCallInfoImpl)
+	if !ok {
+		return nil
+	}
+	return mci.connectAttributes
+}
+
 type mysqlCallInfoImpl struct {
-	remoteAddr string
-	user       string
+	remoteAddr        string
+	user              string
+	connectAttributes map[string]string
 }
 
 func (mci *mysqlCallInfoImpl) RemoteAddr() string {
@@ -17,6 +17,7 @@ limitations under the License.
 package callinfo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -33,3 +34,14 @@ func TestMysqlCallInfo(t *testing.T) {
 	require.Equal(t, "test@localhost(Mysql)", mysqlCi.Text())
 	require.Equal(t, "<b>MySQL User:</b> test <b>Remote Addr:</b> localhost", mysqlCi.HTML().String())
 }
+
+func TestMysqlConnectAttributes(t *testing.T) {
+	attrs := map[string]string{"program_name": "orders"}
+	ctx := NewContext(context.Background(), &mysqlCallInfoImpl{
+		remoteAddr:        "localhost",
+		user:              "test",
+		connectAttributes: attrs,
+	})
+	require.Equal(t, attrs, MysqlConnectAttributes(ctx))
+	require.Nil(t, MysqlConnectAttributes(context.Background()))
+}
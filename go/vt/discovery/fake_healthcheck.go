@@ -90,6 +90,12 @@ func (fhc *FakeHealthCheck) GetHealthyTabletStats(target *querypb.Target) []*Tab
 	return result
 }
 
+// GetServingTabletStats returns the same tablets as GetHealthyTabletStats, as
+// the fake health check does not filter the tablets by replication lag.
+func (fhc *FakeHealthCheck) GetServingTabletStats(target *querypb.Target) []*TabletHealth {
+	return fhc.GetHealthyTabletStats(target)
+}
+
 // GetTabletHealthByAlias results the TabletHealth of the tablet that matches the given alias
 func (fhc *FakeHealthCheck) GetTabletHealthByAlias(alias *topodatapb.TabletAlias) (*TabletHealth, error) {
 	return fhc.GetTabletHealth("", alias)
@@ -239,6 +239,11 @@ type HealthCheck interface {
 	// synchronization
 	GetHealthyTabletStats(target *query.Target) []*TabletHealth
 
+	// GetServingTabletStats returns the serving tablets the queries for the
+	// target can be routed to, regardless of their replication lag.
+	// The returned array is owned by the caller.
+	GetServingTabletStats(target *query.Target) []*TabletHealth
+
 	// GetTabletHealth results the TabletHealth of the tablet that matches the given alias
 	GetTabletHealth(kst KeyspaceShardTabletType, alias *topodata.TabletAlias) (*TabletHealth, error)
 
@@ -788,6 +793,24 @@ func (hc *HealthCheckImpl) GetHealthyTabletStats(target *query.Target) []*Tablet
 	return append(result, hc.healthy[KeyFromTarget(target)]...)
 }
 
+// GetServingTabletStats returns the serving tablets the queries for the
+// target can be routed to, regardless of their replication lag. For
+// TabletType_PRIMARY, this returns the healthy primary.
+func (hc *HealthCheckImpl) GetServingTabletStats(target *query.Target) []*TabletHealth {
+	if target.TabletType == topodata.TabletType_PRIMARY {
+		return hc.GetHealthyTabletStats(target)
+	}
+	var result []*TabletHealth
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	for _, th := range hc.healthData[KeyFromTarget(target)] {
+		if th.Serving && th.LastError == nil && th.Stats != nil && hc.isIncluded(th.Tablet.Type, th.Tablet.Alias) {
+			result = append(result, th)
+		}
+	}
+	return result
+}
+
 // GetTabletStats returns all tablets for the given target.
 // The returned array is owned by the caller.
 // For TabletType_PRIMARY, this will only return at most one entry,
@@ -1169,6 +1169,50 @@ func TestGetHealthyTablets(t *testing.T) {
 	mustMatch(t, want, a, "unexpected result")
 }
 
+func TestGetServingTablets(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	ts := memorytopo.NewServer(ctx, "cell")
+	defer ts.Close()
+	hc := createTestHc(ctx, ts)
+	defer hc.Close()
+	resultChan := hc.Subscribe("TestGetServingTablets")
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	addTablet := func(uid uint32, serving bool, lag uint32) *topodatapb.Tablet {
+		tablet := createTestTablet(uid, "cell", fmt.Sprintf("host%d", uid))
+		tablet.Type = topodatapb.TabletType_REPLICA
+		input := make(chan *querypb.StreamHealthResponse, 1)
+		createFakeConn(tablet, input)
+		hc.AddTablet(tablet)
+		<-resultChan
+		input <- &querypb.StreamHealthResponse{
+			TabletAlias:   tablet.Alias,
+			Target:        target,
+			Serving:       serving,
+			RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: lag},
+		}
+		<-resultChan
+		return tablet
+	}
+	fresh := addTablet(1, true, 1)
+	// The lag of the tablet is too high for it to be healthy.
+	lagging := addTablet(2, true, 3*60*60)
+	addTablet(3, false, 1)
+
+	healthy := hc.GetHealthyTabletStats(target)
+	require.Len(t, healthy, 1)
+	assert.Equal(t, fresh.Alias, healthy[0].Tablet.Alias)
+
+	serving := hc.GetServingTabletStats(target)
+	require.Len(t, serving, 2)
+	slices.SortFunc(serving, func(a, b *TabletHealth) int {
+		return int(a.Tablet.Alias.Uid) - int(b.Tablet.Alias.Uid)
+	})
+	assert.Equal(t, fresh.Alias, serving[0].Tablet.Alias)
+	assert.Equal(t, lagging.Alias, serving[1].Tablet.Alias)
+}
+
 func TestPrimaryInOtherCell(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
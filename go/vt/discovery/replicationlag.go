@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"vitess.io/vitess/go/viperutil"
+	"vitess.io/vitess/go/vt/dynamicflags"
 	"vitess.io/vitess/go/vt/servenv"
 )
 
@@ -64,6 +65,18 @@ var (
 
 func init() {
 	servenv.OnParseFor("vtgate", registerReplicationFlags)
+
+	dynamicflags.Register("discovery_low_replication_lag", lowReplicationLag.Get, setDynamicValue(lowReplicationLag))
+	dynamicflags.Register("discovery_high_replication_lag_minimum_serving", highReplicationLagMinServing.Get, setDynamicValue(highReplicationLagMinServing))
+	dynamicflags.Register("min_number_serving_vttablets", minNumTablets.Get, setDynamicValue(minNumTablets))
+}
+
+// setDynamicValue returns a setter of the value for the dynamic config.
+func setDynamicValue[T any](value viperutil.Value[T]) func(T) error {
+	return func(v T) error {
+		value.Set(v)
+		return nil
+	}
 }
 
 func registerReplicationFlags(fs *pflag.FlagSet) {
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dynamicflags lets the values of selected vtgate and vttablet flags
+// be overridden cluster-wide, or in a keyspace, through a DynamicConfig record
+// in the global topo.
+//
+// The overridable flags are listed in a fixed catalog. The components register
+// how to read and update the values of their flags with Register, and run a
+// Watcher that applies the overrides as they change in the topo. The original
+// value of a flag is restored when its override is removed.
+package dynamicflags
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// VTGate is the component of the flags of the vtgates.
+	VTGate = "vtgate"
+	// VTTablet is the component of the flags of the vttablets.
+	VTTablet = "vttablet"
+)
+
+// Type is the type of the value of a dynamic flag.
+type Type string
+
+const (
+	// Int flags take positive integers.
+	Int Type = "int"
+	// Duration flags take non-negative durations, like 30s.
+	Duration Type = "duration"
+	// Bool flags take true or false.
+	Bool Type = "bool"
+	// Enum flags take one of a fixed set of strings.
+	Enum Type = "enum"
+)
+
+// Flag describes a flag whose value can be overridden dynamically.
+type Flag struct {
+	Name      string
+	Component string
+	Type      Type
+	// Values are the accepted values of an Enum flag.
+	Values []string
+	Help   string
+}
+
+// PerKeyspace returns whether the flag can be overridden in a keyspace, and
+// not only cluster-wide. The vtgates serve every keyspace, so only the vttablet
+// flags can be overridden in a keyspace.
+func (f *Flag) PerKeyspace() bool {
+	return f.Component == VTTablet
+}
+
+// Parse returns the typed value of the flag represented by value: an int, a
+// time.Duration, a bool or a string.
+func (f *Flag) Parse(value string) (any, error) {
+	switch f.Type {
+	case Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: not an integer", value, f.Name)
+		}
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid value %q for %s: it must be positive", value, f.Name)
+		}
+		return i, nil
+	case Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: not a duration", value, f.Name)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s: it must not be negative", value, f.Name)
+		}
+		return d, nil
+	case Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: not a boolean", value, f.Name)
+		}
+		return b, nil
+	case Enum:
+		v := strings.ToLower(value)
+		if !slices.Contains(f.Values, v) {
+			return nil, fmt.Errorf("invalid value %q for %s: it must be one of %s", value, f.Name, strings.Join(f.Values, ", "))
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unknown type %s of %s", f.Type, f.Name)
+	}
+}
+
+// catalog lists the flags that can be overridden, sorted by name.
+var catalog = []*Flag{
+	{
+		Name:      "discovery_high_replication_lag_minimum_serving",
+		Component: VTGate,
+		Type:      Duration,
+		Help:      "Threshold above which replication lag is considered too high when applying the min_number_serving_vttablets flag.",
+	},
+	{
+		Name:      "discovery_low_replication_lag",
+		Component: VTGate,
+		Type:      Duration,
+		Help:      "Threshold below which replication lag is considered low enough to be healthy.",
+	},
+	{
+		Name:      "enable_direct_ddl",
+		Component: VTGate,
+		Type:      Bool,
+		Help:      "Allow users to submit direct DDL statements.",
+	},
+	{
+		Name:      "enable_online_ddl",
+		Component: VTGate,
+		Type:      Bool,
+		Help:      "Allow users to submit, review and control Online DDL.",
+	},
+	{
+		Name:      "min_number_serving_vttablets",
+		Component: VTGate,
+		Type:      Int,
+		Help:      "The minimum number of vttablets for each replicating tablet_type that will continue to be used even with replication lag above discovery_low_replication_lag.",
+	},
+	{
+		Name:      "queryserver-config-max-result-size",
+		Component: VTTablet,
+		Type:      Int,
+		Help:      "Maximum number of rows allowed to return from vttablet for non-streaming queries.",
+	},
+	{
+		Name:      "queryserver-config-pool-size",
+		Component: VTTablet,
+		Type:      Int,
+		Help:      "Size of the read pool, used by regular queries.",
+	},
+	{
+		Name:      "queryserver-config-query-timeout",
+		Component: VTTablet,
+		Type:      Duration,
+		Help:      "Timeout of the queries in vttablet. A query taking more than this timeout is killed.",
+	},
+	{
+		Name:      "queryserver-config-stream-pool-size",
+		Component: VTTablet,
+		Type:      Int,
+		Help:      "Size of the stream pool, used by streaming queries.",
+	},
+	{
+		Name:      "queryserver-config-transaction-cap",
+		Component: VTTablet,
+		Type:      Int,
+		Help:      "Maximum number of transactions allowed at the same time in a vttablet.",
+	},
+	{
+		Name:      "queryserver-config-warn-result-size",
+		Component: VTTablet,
+		Type:      Int,
+		Help:      "Warn if the number of rows returned from vttablet for non-streaming queries exceeds this.",
+	},
+	{
+		Name:      "transaction_mode",
+		Component: VTGate,
+		Type:      Enum,
+		Values:    []string{"single", "multi", "twopc"},
+		Help:      "Transaction mode of the vtgates: single, multi or twopc.",
+	},
+}
+
+// Flags returns the flags that can be overridden, sorted by name.
+func Flags() []*Flag {
+	return slices.Clone(catalog)
+}
+
+// Lookup returns the flag with the given name, if it can be overridden.
+func Lookup(name string) (*Flag, bool) {
+	i := slices.IndexFunc(catalog, func(f *Flag) bool { return f.Name == name })
+	if i < 0 {
+		return nil, false
+	}
+	return catalog[i], true
+}
+
+// Validate checks that the flag can be overridden with value in the keyspace,
+// or cluster-wide if keyspace is empty.
+func Validate(keyspace string, name string, value string) error {
+	f, ok := Lookup(name)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s is not a dynamic flag", name)
+	}
+	if keyspace != "" && !f.PerKeyspace() {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s is a %s flag, it can only be overridden cluster-wide", name, f.Component)
+	}
+	if _, err := f.Parse(value); err != nil {
+		return vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, err.Error())
+	}
+	return nil
+}
+
+// binding reads and updates the value of a flag in the running process.
+type binding struct {
+	get func() any
+	set func(any) error
+}
+
+var (
+	bindingsMu sync.Mutex
+	bindings   = make(map[string]binding)
+)
+
+// Register binds the flag to the functions reading and updating its value in
+// the running process. T must match the type of the flag: int, time.Duration,
+// bool, or string for an Enum flag. It panics if the flag is not in the
+// catalog or if T does not match its type. Registering a flag again replaces
+// its previous binding.
+func Register[T any](name string, get func() T, set func(T) error) {
+	f, ok := Lookup(name)
+	if !ok {
+		panic(fmt.Sprintf("dynamicflags: %s is not a dynamic flag", name))
+	}
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		ok = f.Type == Int
+	case time.Duration:
+		ok = f.Type == Duration
+	case bool:
+		ok = f.Type == Bool
+	case string:
+		ok = f.Type == Enum
+	default:
+		ok = false
+	}
+	if !ok {
+		panic(fmt.Sprintf("dynamicflags: %s is a %s flag, it cannot be registered as a %T", name, f.Type, zero))
+	}
+
+	bindingsMu.Lock()
+	defer bindingsMu.Unlock()
+	bindings[name] = binding{
+		get: func() any { return get() },
+		set: func(v any) error { return set(v.(T)) },
+	}
+}
+
+func getBinding(name string) (binding, bool) {
+	bindingsMu.Lock()
+	defer bindingsMu.Unlock()
+	b, ok := bindings[name]
+	return b, ok
+}
+
+var enabled bool
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&enabled, "enable-dynamic-config", enabled, "Apply the overrides of the dynamic flags saved in the global topo with 'vtctldclient Config set'.")
+}
+
+func init() {
+	servenv.OnParseFor(VTGate, registerFlags)
+	servenv.OnParseFor(VTTablet, registerFlags)
+}
+
+// Enabled returns whether the overrides saved in the topo must be applied.
+func Enabled() bool {
+	return enabled
+}
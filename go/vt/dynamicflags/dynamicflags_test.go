@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicflags
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogSorted(t *testing.T) {
+	assert.True(t, slices.IsSortedFunc(catalog, func(a, b *Flag) int { return strings.Compare(a.Name, b.Name) }))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		keyspace string
+		name     string
+		value    string
+		err      string
+	}{
+		{name: "queryserver-config-pool-size", value: "20"},
+		{keyspace: "ks", name: "queryserver-config-query-timeout", value: "30s"},
+		{name: "transaction_mode", value: "TWOPC"},
+		{name: "enable_online_ddl", value: "false"},
+		{name: "queryserver-config-pool-size", value: "0", err: "it must be positive"},
+		{name: "queryserver-config-query-timeout", value: "-1s", err: "it must not be negative"},
+		{name: "discovery_low_replication_lag", value: "10", err: "not a duration"},
+		{name: "enable_direct_ddl", value: "maybe", err: "not a boolean"},
+		{name: "transaction_mode", value: "xa", err: "it must be one of single, multi, twopc"},
+		{keyspace: "ks", name: "transaction_mode", value: "multi", err: "it can only be overridden cluster-wide"},
+		{name: "queryserver-config-schema-reload-time", value: "1m", err: "is not a dynamic flag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"="+tt.value, func(t *testing.T) {
+			err := Validate(tt.keyspace, tt.name, tt.value)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	assert.PanicsWithValue(t, "dynamicflags: queryserver-config-query-timeout is a duration flag, it cannot be registered as a int", func() {
+		Register("queryserver-config-query-timeout", func() int { return 0 }, func(int) error { return nil })
+	})
+	assert.Panics(t, func() {
+		Register("unknown", func() bool { return false }, func(bool) error { return nil })
+	})
+
+	timeout := time.Second
+	Register("queryserver-config-query-timeout", func() time.Duration { return timeout }, func(d time.Duration) error {
+		timeout = d
+		return nil
+	})
+	b, ok := getBinding("queryserver-config-query-timeout")
+	require.True(t, ok)
+	require.NoError(t, b.set(time.Minute))
+	assert.Equal(t, time.Minute, b.get())
+}
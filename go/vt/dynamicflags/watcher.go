@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicflags
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// retryDelay is how long a Watcher waits before watching a config again when
+// it does not exist in the topo yet, or when the watch failed.
+var retryDelay = 10 * time.Second
+
+var overriddenFlags = stats.NewGauge("DynamicConfigOverrides", "Number of flags whose values are overridden by the dynamic config in the topo")
+
+// override is the state of a flag overridden by a Watcher.
+type override struct {
+	original any
+	value    string
+}
+
+// Watcher watches the cluster-wide dynamic config, and the one of a keyspace,
+// and applies their overrides to the flags of a component. The overrides of
+// the keyspace take precedence over the cluster-wide ones.
+type Watcher struct {
+	ts        *topo.Server
+	component string
+	keyspace  string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// values holds the overrides of the cluster, and of the keyspace.
+	values    [2]map[string]string
+	overrides map[string]*override
+}
+
+// NewWatcher returns a Watcher applying the overrides of the cluster, and of
+// the keyspace if it is not empty, to the flags of the component.
+func NewWatcher(ts *topo.Server, component string, keyspace string) *Watcher {
+	return &Watcher{
+		ts:        ts,
+		component: component,
+		keyspace:  keyspace,
+		overrides: make(map[string]*override),
+	}
+}
+
+// Open starts watching the configs in the background.
+func (w *Watcher) Open() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go w.watch(ctx, 0, "")
+	if w.keyspace != "" {
+		w.wg.Add(1)
+		go w.watch(ctx, 1, w.keyspace)
+	}
+}
+
+// Close stops watching the configs. The overrides applied so far are kept.
+func (w *Watcher) Close() {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.cancel = nil
+	w.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	w.wg.Wait()
+}
+
+// watch watches the config of the keyspace, or the cluster-wide one if
+// keyspace is empty, and stores its values at index until ctx is done.
+func (w *Watcher) watch(ctx context.Context, index int, keyspace string) {
+	defer w.wg.Done()
+	for {
+		current, changes, err := w.ts.WatchDynamicConfig(ctx, keyspace)
+		if err == nil {
+			w.update(index, current.Value.Values)
+			for change := range changes {
+				if change.Err != nil {
+					err = change.Err
+					break
+				}
+				w.update(index, change.Value.Values)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case topo.IsErrType(err, topo.NoNode):
+			// The config does not exist, or was deleted.
+			w.update(index, nil)
+		case err != nil:
+			log.Warningf("Failed to watch the dynamic config of %q, retrying in %v: %v", keyspace, retryDelay, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// update stores the values of the config at index and applies the resulting
+// overrides.
+func (w *Watcher) update(index int, values map[string]string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.values[index] = values
+	w.apply()
+}
+
+// apply updates the flags of the component to the values overridden in the
+// configs, and restores the original values of the flags that are no longer
+// overridden. It must be called with mu held.
+func (w *Watcher) apply() {
+	effective := maps.Clone(w.values[0])
+	if effective == nil {
+		effective = make(map[string]string)
+	}
+	for name, value := range w.values[1] {
+		if f, ok := Lookup(name); ok && f.PerKeyspace() {
+			effective[name] = value
+		}
+	}
+
+	for _, f := range catalog {
+		if f.Component != w.component {
+			continue
+		}
+		b, ok := getBinding(f.Name)
+		if !ok {
+			continue
+		}
+
+		current := w.overrides[f.Name]
+		value, overridden := effective[f.Name]
+		switch {
+		case overridden:
+			if current != nil && current.value == value {
+				continue
+			}
+			v, err := f.Parse(value)
+			if err != nil {
+				log.Errorf("Ignoring the dynamic config of %s: %v", f.Name, err)
+				continue
+			}
+			original := b.get()
+			if current != nil {
+				original = current.original
+			}
+			if err := b.set(v); err != nil {
+				log.Errorf("Failed to set %s to %q from the dynamic config: %v", f.Name, value, err)
+				continue
+			}
+			w.overrides[f.Name] = &override{original: original, value: value}
+			log.Infof("Set %s to %q from the dynamic config", f.Name, value)
+		case current != nil:
+			if err := b.set(current.original); err != nil {
+				log.Errorf("Failed to restore %s to %v: %v", f.Name, current.original, err)
+				continue
+			}
+			delete(w.overrides, f.Name)
+			log.Infof("Restored %s to %v, its override was removed from the dynamic config", f.Name, current.original)
+		}
+	}
+	overriddenFlags.Set(int64(len(w.overrides)))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicflags
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	defer func(delay time.Duration) { retryDelay = delay }(retryDelay)
+	retryDelay = 10 * time.Millisecond
+
+	var timeout, poolSize atomic.Int64
+	timeout.Store(int64(time.Second))
+	poolSize.Store(16)
+	Register("queryserver-config-query-timeout", func() time.Duration { return time.Duration(timeout.Load()) }, func(d time.Duration) error {
+		timeout.Store(int64(d))
+		return nil
+	})
+	Register("queryserver-config-pool-size", func() int { return int(poolSize.Load()) }, func(size int) error {
+		poolSize.Store(int64(size))
+		return nil
+	})
+	var transactionMode atomic.Value
+	transactionMode.Store("multi")
+	Register("transaction_mode", func() string { return transactionMode.Load().(string) }, func(mode string) error {
+		transactionMode.Store(mode)
+		return nil
+	})
+
+	set := func(keyspace string, values map[string]string) {
+		_, err := ts.UpdateDynamicConfigFields(ctx, keyspace, func(config *vtctldatapb.DynamicConfig) error {
+			config.Values = values
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	waitFor := func(wantTimeout time.Duration, wantPoolSize int64) {
+		assert.Eventually(t, func() bool {
+			return time.Duration(timeout.Load()) == wantTimeout && poolSize.Load() == wantPoolSize
+		}, 5*time.Second, 10*time.Millisecond, "timeout=%v, pool size=%d", time.Duration(timeout.Load()), poolSize.Load())
+	}
+
+	w := NewWatcher(ts, VTTablet, "ks")
+	w.Open()
+	defer w.Close()
+
+	// The configs are picked up once they are created.
+	set("", map[string]string{
+		"queryserver-config-query-timeout": "1m",
+		"transaction_mode":                 "single",
+	})
+	waitFor(time.Minute, 16)
+	set("ks", map[string]string{
+		"queryserver-config-query-timeout": "2m",
+		"queryserver-config-pool-size":     "50",
+	})
+	waitFor(2*time.Minute, 50)
+
+	// An invalid value is ignored, and the previous one kept.
+	set("ks", map[string]string{
+		"queryserver-config-query-timeout": "3m",
+		"queryserver-config-pool-size":     "-1",
+	})
+	waitFor(3*time.Minute, 50)
+
+	// The cluster-wide override applies again once the keyspace one is removed,
+	// and the original values are restored once there is no override.
+	set("ks", nil)
+	waitFor(time.Minute, 16)
+	require.NoError(t, ts.DeleteDynamicConfig(ctx, ""))
+	waitFor(time.Second, 16)
+
+	// The flags of the vtgates are not applied by the watcher of a vttablet.
+	assert.Equal(t, "multi", transactionMode.Load())
+}
@@ -53,6 +53,9 @@ const (
 
 	// backupManifestFileName is the MANIFEST file name within a backup.
 	backupManifestFileName = "MANIFEST"
+	// backupCompressionDictionaryFileName is the file name of the dictionary
+	// of the zstd-dict compression engine within a backup.
+	backupCompressionDictionaryFileName = "COMPRESSION_DICTIONARY"
 	// RestoreState is the name of the sentinel file used to detect whether a previous restore
 	// terminated abnormally
 	RestoreState = "restore_in_progress"
@@ -175,7 +178,9 @@ func Backup(ctx context.Context, params BackupParams) error {
 	params.Logger.Infof("Using backup engine %q", be.Name())
 
 	// Take the backup, and either AbortBackup or EndBackup.
+	executeBackupAt := time.Now()
 	backupResult, err := be.ExecuteBackup(ctx, beParams, bh)
+	backupProgress.recordPhase(params.Logger, "Engine", executeBackupAt)
 	logger := params.Logger
 	var finishErr error
 	switch backupResult {
@@ -425,6 +430,7 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 	if err != nil {
 		return nil, err
 	}
+	restoreProgress.recordPhase(params.Logger, "FindBackup", startTs)
 	if restorePath.IsEmpty() {
 		// This condition should not happen; but we validate for sanity
 		return nil, vterrors.Errorf(vtrpc.Code_INTERNAL, "empty restore path")
@@ -444,10 +450,14 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 		backupstats.Component(backupstats.BackupEngine),
 		backupstats.Implementation(textutil.Title(backupEngineImplementation)),
 	)
+	executeRestoreAt := time.Now()
 	manifest, err := re.ExecuteRestore(ctx, reParams, bh)
 	if err != nil {
 		return nil, err
 	}
+	restoreProgress.recordPhase(params.Logger, "Engine", executeRestoreAt)
+
+	upgradeAt := time.Now()
 
 	if re.ShouldStartMySQLAfterRestore() { // all engines except mysqlshell since MySQL is always running there
 		// mysqld needs to be running in order for mysql_upgrade to work.
@@ -481,9 +491,11 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 	if err = ensureRestoredGTIDPurgedMatchesManifest(ctx, manifest, &params); err != nil {
 		return nil, err
 	}
+	restoreProgress.recordPhase(params.Logger, "MysqlUpgrade", upgradeAt)
 
 	if handles := restorePath.IncrementalBackupHandles(); len(handles) > 0 {
 		params.Logger.Infof("Restore: applying %v incremental backups", len(handles))
+		incrementalBackupsAt := time.Now()
 		// Incremental restores are always done via 'builtin' engine, which copies
 		// appropriate binlog files.
 		builtInRE := BackupRestoreEngineMap[builtinBackupEngineName]
@@ -495,6 +507,7 @@ func Restore(ctx context.Context, params RestoreParams) (*BackupManifest, error)
 			params.Logger.Infof("Restore: applied incremental backup: %v", manifest.Position)
 		}
 		params.Logger.Infof("Restore: done applying incremental backups")
+		restoreProgress.recordPhase(params.Logger, "IncrementalBackups", incrementalBackupsAt)
 	}
 
 	params.Logger.Infof("Restore: removing state file")
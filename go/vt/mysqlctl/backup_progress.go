@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+)
+
+// throughputCutoffs are the buckets of the throughput histograms, in bytes
+// per second.
+var throughputCutoffs = []int64{1 << 20, 4 << 20, 16 << 20, 64 << 20, 256 << 20, 1 << 30}
+
+var (
+	backupProgress  = newTransferProgress("Backup")
+	restoreProgress = newTransferProgress("Restore")
+)
+
+// transferProgress tracks the progress of the running backup, or restore, and
+// exports it as metrics: the number of files and of bytes processed so far
+// out of the total, the bytes processed for each file in flight, the
+// throughput of each file and the time spent in each phase.
+//
+// The bytes are the uncompressed bytes of the files, the total number of
+// bytes of a restore is only known for the backups whose MANIFEST records the
+// size of the files.
+type transferProgress struct {
+	operation  string
+	throughput *stats.Histogram
+	phases     *stats.Timings
+
+	mu         sync.Mutex
+	totalFiles int64
+	totalBytes int64
+	doneFiles  int64
+	doneBytes  int64
+	files      map[string]*fileProgress
+}
+
+// fileProgress is the progress of a file in flight.
+type fileProgress struct {
+	name  string
+	start time.Time
+	bytes atomic.Int64
+}
+
+// newTransferProgress creates a transferProgress, and publishes its metrics
+// prefixed by the operation unless the operation is empty.
+func newTransferProgress(operation string) *transferProgress {
+	name := func(suffix string) string {
+		if operation == "" {
+			return ""
+		}
+		return operation + suffix
+	}
+	tp := &transferProgress{
+		operation: operation,
+		throughput: stats.NewHistogram(
+			name("ThroughputBytesPerSecond"),
+			"Throughput of the files copied, in bytes per second.",
+			throughputCutoffs,
+		),
+		phases: stats.NewTimings(
+			name("PhaseTimings"),
+			"Time spent in each phase.",
+			"Phase",
+		),
+		files: make(map[string]*fileProgress),
+	}
+	stats.NewGaugeFunc(name("ProgressTotalFiles"), "Number of files to copy.", func() int64 {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		return tp.totalFiles
+	})
+	stats.NewGaugeFunc(name("ProgressFiles"), "Number of files copied.", func() int64 {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		return tp.doneFiles
+	})
+	stats.NewGaugeFunc(name("ProgressTotalBytes"), "Number of bytes to copy, or 0 if unknown.", func() int64 {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		return tp.totalBytes
+	})
+	stats.NewGaugeFunc(name("ProgressBytes"), "Number of bytes copied, including the files in flight.", func() int64 {
+		_, _, doneBytes, _ := tp.snapshot()
+		return doneBytes
+	})
+	stats.NewGaugesFuncWithMultiLabels(name("FileProgressBytes"), "Number of bytes copied for each file in flight.", []string{"File"}, func() map[string]int64 {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		files := make(map[string]int64, len(tp.files))
+		for _, fp := range tp.files {
+			files[fp.name] = fp.bytes.Load()
+		}
+		return files
+	})
+	return tp
+}
+
+// start resets the progress for a new backup or restore.
+func (tp *transferProgress) start(totalFiles int, totalBytes int64) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.totalFiles = int64(totalFiles)
+	tp.totalBytes = totalBytes
+	tp.doneFiles = 0
+	tp.doneBytes = 0
+	clear(tp.files)
+}
+
+// startFile tracks the progress of a file until endFile is called. The bytes
+// of the file are accounted with fileProgress.add.
+func (tp *transferProgress) startFile(name string) *fileProgress {
+	fp := &fileProgress{name: name, start: time.Now()}
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.files[name] = fp
+	return fp
+}
+
+// endFile stops tracking the progress of a file. The file is only accounted as
+// copied if err is nil, a failed file is expected to be retried.
+func (tp *transferProgress) endFile(fp *fileProgress, err error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if tp.files[fp.name] == fp {
+		delete(tp.files, fp.name)
+	}
+	if err != nil {
+		return
+	}
+	bytes := fp.bytes.Load()
+	tp.doneFiles++
+	tp.doneBytes += bytes
+	if elapsed := time.Since(fp.start); elapsed > 0 {
+		tp.throughput.Add(int64(float64(bytes) / elapsed.Seconds()))
+	}
+}
+
+// recordPhase records the time spent in a phase since start.
+func (tp *transferProgress) recordPhase(logger logutil.Logger, phase string, start time.Time) {
+	elapsed := time.Since(start)
+	tp.phases.Add(phase, elapsed)
+	logger.Infof("%s phase %s took %v", tp.operation, phase, elapsed.Round(time.Millisecond))
+}
+
+// snapshot returns the number of files and of bytes copied so far, and their
+// totals.
+func (tp *transferProgress) snapshot() (doneFiles, totalFiles, doneBytes, totalBytes int64) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	doneBytes = tp.doneBytes
+	for _, fp := range tp.files {
+		doneBytes += fp.bytes.Load()
+	}
+	return tp.doneFiles, tp.totalFiles, doneBytes, tp.totalBytes
+}
+
+// report logs the overall progress every period until ctx is done.
+func (tp *transferProgress) report(ctx context.Context, period time.Duration, logger logutil.Logger) {
+	tick := time.NewTicker(period)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			doneFiles, totalFiles, doneBytes, totalBytes := tp.snapshot()
+			if totalBytes == 0 {
+				logger.Infof("%s progress: %d/%d files, %.02fMiB", tp.operation, doneFiles, totalFiles, float64(doneBytes)/(1<<20))
+			} else {
+				logger.Infof("%s progress: %d/%d files, %.02f%% (%.02f/%.02fMiB)", tp.operation, doneFiles, totalFiles,
+					100.0*float64(doneBytes)/float64(totalBytes), float64(doneBytes)/(1<<20), float64(totalBytes)/(1<<20))
+			}
+		}
+	}
+}
+
+// add accounts n bytes copied for the file. Its signature matches the
+// callbacks of the metered readers and writers of the ioutil package.
+func (fp *fileProgress) add(n int, _ time.Duration) {
+	fp.bytes.Add(int64(n))
+}
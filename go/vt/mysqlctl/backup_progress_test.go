@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+)
+
+func TestTransferProgress(t *testing.T) {
+	tp := newTransferProgress("")
+	tp.start(3, 300)
+
+	fp1 := tp.startFile("f1")
+	fp1.add(100, 0)
+	fp2 := tp.startFile("f2")
+	fp2.add(50, 0)
+
+	doneFiles, totalFiles, doneBytes, totalBytes := tp.snapshot()
+	assert.EqualValues(t, 0, doneFiles)
+	assert.EqualValues(t, 3, totalFiles)
+	assert.EqualValues(t, 150, doneBytes)
+	assert.EqualValues(t, 300, totalBytes)
+
+	// A failed file is not accounted, its retry starts over.
+	tp.endFile(fp1, nil)
+	tp.endFile(fp2, errors.New("failed"))
+	doneFiles, _, doneBytes, _ = tp.snapshot()
+	assert.EqualValues(t, 1, doneFiles)
+	assert.EqualValues(t, 100, doneBytes)
+	assert.EqualValues(t, 1, tp.throughput.Count())
+
+	fp2 = tp.startFile("f2")
+	fp2.add(10, 0)
+	_, _, doneBytes, _ = tp.snapshot()
+	assert.EqualValues(t, 110, doneBytes)
+
+	logger := logutil.NewMemoryLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	go tp.report(ctx, time.Millisecond, logger)
+	require.Eventually(t, func() bool {
+		return strings.Contains(logger.String(), "progress: 1/3 files, 36.67%")
+	}, 10*time.Second, time.Millisecond)
+	cancel()
+
+	tp.recordPhase(logger, "Files", time.Now().Add(-time.Second))
+	assert.EqualValues(t, 1, tp.phases.Counts()["Files"])
+
+	tp.start(1, 0)
+	doneFiles, totalFiles, doneBytes, totalBytes = tp.snapshot()
+	assert.EqualValues(t, 0, doneFiles)
+	assert.EqualValues(t, 1, totalFiles)
+	assert.EqualValues(t, 0, doneBytes)
+	assert.EqualValues(t, 0, totalBytes)
+}
@@ -62,6 +62,10 @@ const (
 	dataDictionaryFile      = "mysql.ibd"
 
 	maxRetriesPerFile = 1
+
+	// compressionDictionarySampleChunkSize is the size of the chunks of the
+	// files sampled to train the compression dictionary, an InnoDB page.
+	compressionDictionarySampleChunkSize = 16 * 1024
 )
 
 var (
@@ -130,6 +134,15 @@ type builtinBackupManifest struct {
 	// ExternalDecompressor will be used. If neither are set, the restore will
 	// abort.
 	ExternalDecompressor string
+
+	// CompressionDictionary is the name of the file of the backup storing the
+	// dictionary of the files compressed with the zstd-dict engine. The
+	// dictionary is trained with samples of the files of each backup.
+	CompressionDictionary string `json:",omitempty"`
+
+	// compressionDictionary is the content of CompressionDictionary, read at
+	// the start of the restore.
+	compressionDictionary []byte
 }
 
 // FileEntry is one file to backup
@@ -152,6 +165,10 @@ type FileEntry struct {
 	// for writing files in a temporary directory
 	ParentPath string
 
+	// Size is the size of the file before compression. It is used to report the
+	// progress of restores, and is zero for backups taken before it was recorded.
+	Size int64 `json:",omitempty"`
+
 	// RetryCount specifies how many times we retried restoring/backing up this FileEntry.
 	// If we fail to restore/backup this FileEntry, we will retry up to maxRetriesPerFile times.
 	// Every time the builtin backup engine retries this file, we increment this field by 1.
@@ -494,12 +511,14 @@ func (be *BuiltinBackupEngine) executeFullBackup(ctx context.Context, params Bac
 	}
 
 	// shutdown mysqld
+	shutdownAt := time.Now()
 	shutdownCtx, cancel := context.WithTimeout(ctx, BuiltinBackupMysqldTimeout)
 	err = params.Mysqld.Shutdown(shutdownCtx, params.Cnf, true, params.MysqlShutdownTimeout)
 	defer cancel()
 	if err != nil {
 		return BackupUnusable, vterrors.Wrap(err, "can't shutdown mysqld")
 	}
+	backupProgress.recordPhase(params.Logger, "ShutdownMySQL", shutdownAt)
 
 	// Backup everything, capture the error.
 	backupErr := be.backupFiles(ctx, params, bh, replicationPosition, gtidPurgedPosition, replication.Position{}, "", nil, serverUUID, mysqlVersion, nil)
@@ -509,10 +528,12 @@ func (be *BuiltinBackupEngine) executeFullBackup(ctx context.Context, params Bac
 	}
 
 	// Try to restart mysqld, use background context in case we timed out the original context
+	startAt := time.Now()
 	err = params.Mysqld.Start(context.Background(), params.Cnf)
 	if err != nil {
 		return backupResult, vterrors.Wrap(err, "can't restart mysqld")
 	}
+	backupProgress.recordPhase(params.Logger, "StartMySQL", startAt)
 
 	// Resetting super_read_only back to its original value
 	params.Logger.Infof("resetting mysqld super_read_only to %v", superReadOnly)
@@ -600,21 +621,36 @@ func (be *BuiltinBackupEngine) backupFiles(
 	defer cancel()
 
 	// Get the files to backup.
-	// We don't care about totalSize because we add each file separately.
+	// The totalSize is only used to report the progress, as we add each file separately.
 	var fes []FileEntry
+	var totalSize int64
 	var err error
 	if isIncrementalBackup(params) {
-		fes, _, err = binlogFilesToBackup(params.Cnf, binlogFiles)
+		fes, totalSize, err = binlogFilesToBackup(params.Cnf, binlogFiles)
 	} else {
-		fes, _, err = findFilesToBackup(params.Cnf)
+		fes, totalSize, err = findFilesToBackup(params.Cnf)
 	}
 	if err != nil {
 		return vterrors.Wrap(err, "can't find files to backup")
 	}
 	params.Logger.Infof("found %v files to backup", len(fes))
 
+	var compressionDictionary []byte
+	var compressionDictionaryFileName string
+	if backupStorageCompress && ExternalCompressorCmd == "" && CompressionEngineName == ZstdDictCompressor {
+		compressionDictionary, err = be.backupCompressionDictionary(ctx, params, bh, fes)
+		if err != nil {
+			return vterrors.Wrap(err, "can't create compression dictionary")
+		}
+		compressionDictionaryFileName = backupCompressionDictionaryFileName
+	}
+
+	backupProgress.start(len(fes), totalSize)
+	go backupProgress.report(ctx, builtinBackupProgress, params.Logger)
+	copyFilesAt := time.Now()
+
 	// The error here can be ignored safely. Failed FileEntry's are handled in the next 'if' statement.
-	_ = be.backupFileEntries(ctx, fes, bh, params)
+	_ = be.backupFileEntries(ctx, fes, bh, params, compressionDictionary)
 
 	// BackupHandle supports the BackupErrorRecorder interface for tracking errors
 	// across any goroutines that fan out to take the backup. This means that we
@@ -645,16 +681,19 @@ func (be *BuiltinBackupEngine) backupFiles(
 			}
 			bh.ResetErrorForFile(file)
 		}
-		err = be.backupFileEntries(ctx, newFEs, bh, params)
+		err = be.backupFileEntries(ctx, newFEs, bh, params, compressionDictionary)
 		if err != nil {
 			return err
 		}
 	}
+	backupProgress.recordPhase(params.Logger, "Files", copyFilesAt)
 
 	// Backup the MANIFEST file and apply retry logic.
+	backupManifestAt := time.Now()
+	defer backupProgress.recordPhase(params.Logger, "Manifest", backupManifestAt)
 	var manifestErr error
 	for currentRetry := 0; currentRetry <= maxRetriesPerFile; currentRetry++ {
-		manifestErr = be.backupManifest(ctx, params, bh, backupPosition, purgedPosition, fromPosition, fromBackupName, serverUUID, mysqlVersion, incrDetails, fes, currentRetry)
+		manifestErr = be.backupManifest(ctx, params, bh, backupPosition, purgedPosition, fromPosition, fromBackupName, serverUUID, mysqlVersion, incrDetails, fes, compressionDictionaryFileName, currentRetry)
 		if manifestErr == nil {
 			break
 		}
@@ -670,7 +709,7 @@ func (be *BuiltinBackupEngine) backupFiles(
 // This function will ignore empty FileEntry, allowing the retry mechanism to send a partially empty slice, to not
 // mess up the index of retriable FileEntry.
 // This function does not leave any background operation behind itself, all calls to bh.AddFile will be finished or canceled.
-func (be *BuiltinBackupEngine) backupFileEntries(ctx context.Context, fes []FileEntry, bh backupstorage.BackupHandle, params BackupParams) error {
+func (be *BuiltinBackupEngine) backupFileEntries(ctx context.Context, fes []FileEntry, bh backupstorage.BackupHandle, params BackupParams, compressionDictionary []byte) error {
 	ctxCancel, cancel := context.WithCancel(ctx)
 	defer func() {
 		// If we reached this defer in all cases we can cancel the context.
@@ -704,7 +743,7 @@ func (be *BuiltinBackupEngine) backupFileEntries(ctx context.Context, fes []File
 
 			// Backup the individual file.
 			var errBackupFile error
-			if errBackupFile = be.backupFile(ctxCancel, params, bh, fe, name); errBackupFile != nil {
+			if errBackupFile = be.backupFile(ctxCancel, params, bh, fe, name, compressionDictionary); errBackupFile != nil {
 				bh.RecordError(name, vterrors.Wrapf(errBackupFile, "failed to backup file '%s'", name))
 				if fe.RetryCount >= maxRetriesPerFile {
 					// this is the last attempt, and we have an error, we can cancel everything and fail fast.
@@ -833,7 +872,7 @@ func (bp *backupPipe) ReportProgress(ctx context.Context, period time.Duration,
 }
 
 // backupFile backs up an individual file.
-func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fe *FileEntry, name string) (finalErr error) {
+func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fe *FileEntry, name string, compressionDictionary []byte) (finalErr error) {
 	// We need another context that does not live outside of this function.
 	// Reporting progress, compressing and writing are operations that will be
 	// over by the time we exit this function, they can use this cancelable context.
@@ -843,6 +882,11 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 	cancelableCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	fp := backupProgress.startFile(fe.Name)
+	defer func() {
+		backupProgress.endFile(fp, finalErr)
+	}()
+
 	// Open the source file for reading.
 	openSourceAt := time.Now()
 	source, err := fe.open(params.Cnf, true)
@@ -858,12 +902,13 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 	}()
 
 	readStats := params.Stats.Scope(stats.Operation("Source:Read"))
-	timedSource := ioutil.NewMeteredReadCloser(source, readStats.TimedIncrementBytes)
+	timedSource := ioutil.NewMeteredReadCloser(source, readStats.TimedIncrementBytes, fp.add)
 
 	fi, err := source.Stat()
 	if err != nil {
 		return err
 	}
+	fe.Size = fi.Size()
 
 	retryStr := retryToString(fe.RetryCount)
 	br := newBackupReader(fe.Name, fi.Size(), timedSource)
@@ -917,7 +962,7 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 			if ExternalCompressorCmd != "" {
 				compressor, err = newExternalCompressor(cancelableCtx, ExternalCompressorCmd, writer, params.Logger)
 			} else {
-				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, params.Logger)
+				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, compressionDictionary, params.Logger)
 			}
 			if err != nil {
 				return vterrors.Wrap(err, "can't create compressor")
@@ -962,6 +1007,85 @@ func (be *BuiltinBackupEngine) backupFile(ctx context.Context, params BackupPara
 	return nil
 }
 
+// backupCompressionDictionary trains the dictionary of the zstd-dict engine
+// with samples of the files to backup, and stores it in the backup.
+func (be *BuiltinBackupEngine) backupCompressionDictionary(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, fes []FileEntry) ([]byte, error) {
+	trainAt := time.Now()
+	samples, err := sampleFileEntries(params.Cnf, fes)
+	if err != nil {
+		return nil, err
+	}
+	dictionary, err := trainCompressionDictionary(samples)
+	if err != nil {
+		return nil, err
+	}
+	params.Logger.Infof("Trained a compression dictionary of %v bytes from %v samples in %v", len(dictionary), len(samples), time.Since(trainAt))
+
+	wc, err := bh.AddFile(ctx, backupCompressionDictionaryFileName, int64(len(dictionary)))
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot add %v to backup", backupCompressionDictionaryFileName)
+	}
+	if _, err := wc.Write(dictionary); err != nil {
+		wc.Close()
+		return nil, vterrors.Wrapf(err, "cannot write %v", backupCompressionDictionaryFileName)
+	}
+	if err := wc.Close(); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot close %v", backupCompressionDictionaryFileName)
+	}
+	return dictionary, nil
+}
+
+// sampleFileEntries reads chunks evenly spread across the files to backup, up
+// to --compression-dictionary-sample-size bytes overall.
+func sampleFileEntries(cnf *Mycnf, fes []FileEntry) ([][]byte, error) {
+	if len(fes) == 0 {
+		return nil, nil
+	}
+	chunksPerFile := max(1, compressionDictionarySampleSize/compressionDictionarySampleChunkSize/len(fes))
+	var samples [][]byte
+	for i := range fes {
+		err := func() error {
+			f, err := fes[i].open(cnf, true)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			fi, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			chunks := min(int64(chunksPerFile), max(1, fi.Size()/compressionDictionarySampleChunkSize))
+			step := fi.Size() / chunks
+			for c := range chunks {
+				buf := make([]byte, compressionDictionarySampleChunkSize)
+				n, err := f.ReadAt(buf, c*step)
+				if err != nil && err != io.EOF {
+					return err
+				}
+				if n > 0 {
+					samples = append(samples, buf[:n])
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return samples, nil
+}
+
+// readCompressionDictionary reads the dictionary of the zstd-dict engine from
+// the backup.
+func readCompressionDictionary(ctx context.Context, bh backupstorage.BackupHandle, name string) ([]byte, error) {
+	rc, err := bh.ReadFile(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
 func (be *BuiltinBackupEngine) backupManifest(
 	ctx context.Context,
 	params BackupParams,
@@ -974,6 +1098,7 @@ func (be *BuiltinBackupEngine) backupManifest(
 	mysqlVersion string,
 	incrDetails *IncrementalBackupDetails,
 	fes []FileEntry,
+	compressionDictionaryFileName string,
 	currentAttempt int,
 ) (finalErr error) {
 	retryStr := retryToString(currentAttempt)
@@ -1023,10 +1148,11 @@ func (be *BuiltinBackupEngine) backupManifest(
 			},
 
 			// Builtin-specific fields
-			FileEntries:          fes,
-			SkipCompress:         !backupStorageCompress,
-			CompressionEngine:    CompressionEngineName,
-			ExternalDecompressor: ManifestExternalDecompressorCmd,
+			FileEntries:           fes,
+			SkipCompress:          !backupStorageCompress,
+			CompressionEngine:     CompressionEngineName,
+			ExternalDecompressor:  ManifestExternalDecompressorCmd,
+			CompressionDictionary: compressionDictionaryFileName,
 		}
 		data, err := json.MarshalIndent(bm, "", "  ")
 		if err != nil {
@@ -1052,11 +1178,13 @@ func (be *BuiltinBackupEngine) backupManifest(
 
 // executeRestoreFullBackup restores the files from a full backup. The underlying mysql database service is expected to be stopped.
 func (be *BuiltinBackupEngine) executeRestoreFullBackup(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, bm builtinBackupManifest) error {
+	prepareAt := time.Now()
 	if err := prepareToRestore(ctx, params.Cnf, params.Mysqld, params.Logger, params.MysqlShutdownTimeout); err != nil {
 		return err
 	}
 
 	params.Logger.Infof("Restore: copying %v files", len(bm.FileEntries))
+	restoreProgress.recordPhase(params.Logger, "PrepareRestore", prepareAt)
 
 	if _, err := be.restoreFiles(ctx, params, bh, bm); err != nil {
 		// don't delete the file here because that is how we detect an interrupted restore
@@ -1163,6 +1291,12 @@ func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreP
 			bm.CompressionEngine = PargzipCompressor
 		}()
 	}
+	if bm.CompressionDictionary != "" {
+		bm.compressionDictionary, err = readCompressionDictionary(ctx, bh, bm.CompressionDictionary)
+		if err != nil {
+			return "", vterrors.Wrap(err, "can't read compression dictionary")
+		}
+	}
 
 	if bm.Incremental {
 		createdDir, err = os.MkdirTemp(builtinIncrementalRestorePath, "restore-incremental-*")
@@ -1171,6 +1305,17 @@ func (be *BuiltinBackupEngine) restoreFiles(ctx context.Context, params RestoreP
 		}
 	}
 	fes := bm.FileEntries
+	var totalSize int64
+	for _, fe := range fes {
+		totalSize += fe.Size
+	}
+	restoreProgress.start(len(fes), totalSize)
+	reportCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go restoreProgress.report(reportCtx, builtinBackupProgress, params.Logger)
+	copyFilesAt := time.Now()
+	defer restoreProgress.recordPhase(params.Logger, "Files", copyFilesAt)
+
 	_ = be.restoreFileEntries(ctx, fes, bh, bm, params, createdDir)
 	if files := bh.GetFailedFiles(); len(files) > 0 {
 		newFEs := make([]FileEntry, len(fes))
@@ -1244,6 +1389,11 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	fp := restoreProgress.startFile(fe.Name)
+	defer func() {
+		restoreProgress.endFile(fp, finalErr)
+	}()
+
 	// Open the source file for reading.
 	openSourceAt := time.Now()
 	source, err := bh.ReadFile(ctx, name)
@@ -1289,7 +1439,7 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 	}()
 
 	writeStats := params.Stats.Scope(stats.Operation("Destination:Write"))
-	timedDest := ioutil.NewMeteredWriter(dest, writeStats.TimedIncrementBytes)
+	timedDest := ioutil.NewMeteredWriter(dest, writeStats.TimedIncrementBytes, fp.add)
 
 	bufferedDest := bufio.NewWriterSize(timedDest, int(builtinBackupFileWriteBufferSize))
 
@@ -1311,13 +1461,13 @@ func (be *BuiltinBackupEngine) restoreFile(ctx context.Context, params RestorePa
 				deCompressionEngine = externalDecompressorCmd
 				decompressor, err = newExternalDecompressor(ctx, deCompressionEngine, reader, params.Logger)
 			} else {
-				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, params.Logger)
+				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, bm.compressionDictionary, params.Logger)
 			}
 		} else {
 			if deCompressionEngine == ExternalCompressor {
 				return fmt.Errorf("%w value: %q", errUnsupportedDeCompressionEngine, ExternalCompressor)
 			}
-			decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, params.Logger)
+			decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, bm.compressionDictionary, params.Logger)
 		}
 		if err != nil {
 			return vterrors.Wrap(err, "can't create decompressor")
@@ -18,9 +18,12 @@ limitations under the License.
 package mysqlctl
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 )
@@ -78,3 +81,31 @@ func TestShouldDrainForBackupBuiltIn(t *testing.T) {
 	assert.False(t, be.ShouldDrainForBackup(&tabletmanagerdatapb.BackupRequest{IncrementalFromPos: "99ca8ed4-399c-11ee-861b-0a43f95f28a3:1-197"}))
 	assert.False(t, be.ShouldDrainForBackup(&tabletmanagerdatapb.BackupRequest{IncrementalFromPos: "MySQL56/99ca8ed4-399c-11ee-861b-0a43f95f28a3:1-197"}))
 }
+
+func TestSampleFileEntries(t *testing.T) {
+	oldSampleSize := compressionDictionarySampleSize
+	defer func() {
+		compressionDictionarySampleSize = oldSampleSize
+	}()
+	compressionDictionarySampleSize = 8 * compressionDictionarySampleChunkSize
+
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(dataDir, "big"), make([]byte, 10*compressionDictionarySampleChunkSize), 0o644))
+	require.NoError(t, os.WriteFile(path.Join(dataDir, "small"), []byte("small"), 0o644))
+	require.NoError(t, os.WriteFile(path.Join(dataDir, "empty"), nil, 0o644))
+
+	fes := []FileEntry{
+		{Base: backupData, Name: "big"},
+		{Base: backupData, Name: "small"},
+		{Base: backupData, Name: "empty"},
+	}
+	samples, err := sampleFileEntries(&Mycnf{DataDir: dataDir}, fes)
+	require.NoError(t, err)
+
+	// 8 chunks overall are 2 chunks per file, the small file is sampled whole
+	// and the empty file is skipped.
+	require.Len(t, samples, 3)
+	assert.Len(t, samples[0], compressionDictionarySampleChunkSize)
+	assert.Len(t, samples[1], compressionDictionarySampleChunkSize)
+	assert.Equal(t, []byte("small"), samples[2])
+}
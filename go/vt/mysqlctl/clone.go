@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/capabilities"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+)
+
+const (
+	// ClonePluginStatusQuery returns a row if the clone plugin is installed.
+	ClonePluginStatusQuery = "SELECT PLUGIN_STATUS FROM information_schema.PLUGINS WHERE PLUGIN_NAME = 'clone'"
+	// InstallClonePluginQuery installs the clone plugin.
+	InstallClonePluginQuery = "INSTALL PLUGIN clone SONAME 'mysql_clone.so'"
+	// CloneStatusQuery returns the state of the last clone operation of the server.
+	CloneStatusQuery = "SELECT STATE, ERROR_NO, ERROR_MESSAGE FROM performance_schema.clone_status"
+)
+
+// ErrCloneNotSupported is returned when the server does not support cloning.
+var ErrCloneNotSupported = errors.New("the clone plugin requires MySQL 8.0.17 or newer")
+
+// CloneParams is the parameters of CloneFromDonor.
+type CloneParams struct {
+	Cnf    *Mycnf
+	Mysqld MysqlDaemon
+	Logger logutil.Logger
+	// DonorHost and DonorPort are the address of the mysqld of the donor.
+	DonorHost string
+	DonorPort int32
+	// MaxBandwidth throttles the transfer of the data, in MiB per second.
+	// Zero means no limit.
+	MaxBandwidth int64
+	// MysqlShutdownTimeout defines how long we wait during MySQL shutdown,
+	// when mysqld has to be restarted after the clone.
+	MysqlShutdownTimeout time.Duration
+}
+
+// CloneFrom replaces the data of mysqld with a copy of the data of the donor
+// at the given address, with the clone plugin of MySQL, which is installed if
+// needed. The donor must have the clone plugin installed, and the replication
+// user must have the BACKUP_ADMIN privilege on it.
+// When the clone completes, mysqld restarts on the cloned data if it is run by
+// a supervisor such as mysqld_safe, in which case CloneFrom waits for it to be
+// available again; otherwise it shuts down, and restartNeeded is true.
+func (mysqld *Mysqld) CloneFrom(ctx context.Context, cnf *Mycnf, host string, port int32, maxBandwidth int64) (restartNeeded bool, err error) {
+	qr, err := mysqld.FetchSuperQuery(ctx, ClonePluginStatusQuery)
+	if err != nil {
+		return false, err
+	}
+	var queries []string
+	if len(qr.Rows) == 0 {
+		queries = append(queries, InstallClonePluginQuery)
+	}
+	queries = append(queries,
+		fmt.Sprintf("SET GLOBAL clone_valid_donor_list = %s", sqltypes.EncodeStringSQL(fmt.Sprintf("%s:%d", host, port))),
+		fmt.Sprintf("SET GLOBAL clone_max_data_bandwidth = %d", maxBandwidth),
+	)
+	// The data is replaced anyway, and mysqld restarts with the super_read_only
+	// of its configuration.
+	if _, err := mysqld.SetSuperReadOnly(ctx, false); err != nil {
+		return false, err
+	}
+	if err := mysqld.ExecuteSuperQueryList(ctx, queries); err != nil {
+		return false, err
+	}
+
+	params, err := mysqld.dbcfgs.ReplConnector().MysqlParams()
+	if err != nil {
+		return false, err
+	}
+	query := fmt.Sprintf("CLONE INSTANCE FROM %s@%s:%d IDENTIFIED BY ",
+		sqltypes.EncodeStringSQL(params.Uname), sqltypes.EncodeStringSQL(host), port)
+	var requireSSL string
+	if params.SslEnabled() {
+		requireSSL = " REQUIRE SSL"
+	}
+	// The password must not be logged.
+	log.Infof("Executing %v", query+"'****'"+requireSSL)
+
+	conn, err := getPoolReconnect(ctx, mysqld.dbaPool)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Recycle()
+	_, err = mysqld.executeFetchContext(ctx, conn, query+sqltypes.EncodeStringSQL(params.Pass)+requireSSL, 0, false)
+	if err == nil {
+		// CLONE INSTANCE only returns without restarting mysqld when it clones
+		// into another data directory, which we never do.
+		return false, fmt.Errorf("CLONE INSTANCE returned without restarting mysqld")
+	}
+	// The connection is closed by the restart of mysqld.
+	conn.Close()
+	var sqlErr *sqlerror.SQLError
+	if !errors.As(err, &sqlErr) {
+		return false, err
+	}
+	switch sqlErr.Number() {
+	case sqlerror.ERCloneRestartServerFailed:
+		return true, nil
+	case sqlerror.CRServerLost, sqlerror.CRServerGone:
+		return false, mysqld.Wait(ctx, cnf)
+	}
+	return false, err
+}
+
+// CloneFromDonor replaces the data of mysqld with a copy of the data of the
+// donor, and returns the GTID position of the copy, from which replication can
+// start. The data of mysqld is lost, even if the clone fails.
+func CloneFromDonor(ctx context.Context, params CloneParams) (replication.Position, error) {
+	version, err := params.Mysqld.GetVersionString(ctx)
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("failed to fetch MySQL version: %v", err)
+	}
+	capable, err := mysql.ServerVersionCapableOf(version)(capabilities.ClonePluginCapability)
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("error checking if server supports the clone plugin: %v", err)
+	}
+	if !capable {
+		return replication.Position{}, ErrCloneNotSupported
+	}
+
+	params.Logger.Infof("Cloning the data of %s:%d", params.DonorHost, params.DonorPort)
+	restartNeeded, err := params.Mysqld.CloneFrom(ctx, params.Cnf, params.DonorHost, params.DonorPort, params.MaxBandwidth)
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("clone from %s:%d failed: %v", params.DonorHost, params.DonorPort, err)
+	}
+	if restartNeeded {
+		params.Logger.Infof("Restarting mysqld on the cloned data")
+		// mysqld shuts down by itself: this only waits for it to be done.
+		if err := params.Mysqld.Shutdown(ctx, params.Cnf, true, params.MysqlShutdownTimeout); err != nil {
+			return replication.Position{}, err
+		}
+		if err := params.Mysqld.Start(ctx, params.Cnf); err != nil {
+			return replication.Position{}, err
+		}
+	}
+
+	qr, err := params.Mysqld.FetchSuperQuery(ctx, CloneStatusQuery)
+	if err != nil {
+		return replication.Position{}, fmt.Errorf("failed to fetch the clone status: %v", err)
+	}
+	if len(qr.Rows) != 1 {
+		return replication.Position{}, fmt.Errorf("no clone status found")
+	}
+	if state := qr.Rows[0][0].ToString(); state != "Completed" {
+		return replication.Position{}, fmt.Errorf("clone not completed: state %v, error %v: %v", state, qr.Rows[0][1].ToString(), qr.Rows[0][2].ToString())
+	}
+
+	pos, err := params.Mysqld.PrimaryPosition(ctx)
+	if err != nil {
+		return replication.Position{}, err
+	}
+	params.Logger.Infof("Clone from %s:%d completed at position %v", params.DonorHost, params.DonorPort, pos)
+	return pos, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/logutil"
+)
+
+func TestCloneFromDonor(t *testing.T) {
+	ctx := context.Background()
+	pos, err := replication.DecodePosition("MySQL56/00000000-0000-0000-0000-000000000001:1-10")
+	require.NoError(t, err)
+	cloneStatus := func(state string) map[string]*sqltypes.Result {
+		return map[string]*sqltypes.Result{
+			CloneStatusQuery: sqltypes.MakeTestResult(sqltypes.MakeTestFields("STATE|ERROR_NO|ERROR_MESSAGE", "varchar|int64|varchar"), state+"|0|"),
+		}
+	}
+	newParams := func(fmd *FakeMysqlDaemon) CloneParams {
+		return CloneParams{
+			Cnf:       &Mycnf{},
+			Mysqld:    fmd,
+			Logger:    logutil.NewMemoryLogger(),
+			DonorHost: "donor",
+			DonorPort: 3306,
+		}
+	}
+
+	t.Run("restarted by a supervisor", func(t *testing.T) {
+		fmd := NewFakeMysqlDaemon(nil)
+		fmd.CurrentPrimaryPosition = pos
+		fmd.FetchSuperQueryMap = cloneStatus("Completed")
+		got, err := CloneFromDonor(ctx, newParams(fmd))
+		require.NoError(t, err)
+		assert.Equal(t, pos, got)
+		assert.Equal(t, "donor:3306", fmd.ClonedFrom)
+		assert.True(t, fmd.Running)
+	})
+
+	t.Run("restart needed", func(t *testing.T) {
+		fmd := NewFakeMysqlDaemon(nil)
+		fmd.CurrentPrimaryPosition = pos
+		fmd.CloneRestartNeeded = true
+		fmd.FetchSuperQueryMap = cloneStatus("Completed")
+		got, err := CloneFromDonor(ctx, newParams(fmd))
+		require.NoError(t, err)
+		assert.Equal(t, pos, got)
+		assert.True(t, fmd.Running)
+	})
+
+	t.Run("clone failed", func(t *testing.T) {
+		fmd := NewFakeMysqlDaemon(nil)
+		fmd.CloneFromError = errors.New("access denied")
+		_, err := CloneFromDonor(ctx, newParams(fmd))
+		require.ErrorContains(t, err, "clone from donor:3306 failed: access denied")
+	})
+
+	t.Run("clone not completed", func(t *testing.T) {
+		fmd := NewFakeMysqlDaemon(nil)
+		fmd.FetchSuperQueryMap = cloneStatus("Failed")
+		_, err := CloneFromDonor(ctx, newParams(fmd))
+		require.ErrorContains(t, err, "clone not completed: state Failed")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		fmd := NewFakeMysqlDaemon(nil)
+		fmd.Version = "8.0.16"
+		_, err := CloneFromDonor(ctx, newParams(fmd))
+		require.ErrorIs(t, err, ErrCloneNotSupported)
+		assert.Empty(t, fmd.ClonedFrom)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/google/shlex"
+	"github.com/klauspost/compress/dict"
 	"github.com/klauspost/compress/zstd"
 	"github.com/klauspost/pgzip"
 	"github.com/pierrec/lz4"
@@ -40,6 +41,7 @@ const (
 	PgzipCompressor    = "pgzip"
 	PargzipCompressor  = "pargzip"
 	ZstdCompressor     = "zstd"
+	ZstdDictCompressor = "zstd-dict"
 	Lz4Compressor      = "lz4"
 	ExternalCompressor = "external"
 )
@@ -54,14 +56,20 @@ var (
 	ExternalDecompressorCmd         string
 	ManifestExternalDecompressorCmd string
 
+	// compressionDictionarySize and compressionDictionarySampleSize are the
+	// maximum size of the dictionary of the zstd-dict engine, and the number of
+	// bytes sampled from the files of the backup to train it.
+	compressionDictionarySize       = 112 * 1024
+	compressionDictionarySampleSize = 16 * 1024 * 1024
+
 	errUnsupportedDeCompressionEngine = errors.New("unsupported engine in MANIFEST. You need to provide --external-decompressor if using 'external' compression engine")
-	errUnsupportedCompressionEngine   = errors.New("unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'lz4'")
+	errUnsupportedCompressionEngine   = errors.New("unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'zstd-dict', 'lz4'")
 
 	// this is used by getEngineFromExtension() to figure out which engine to use in case the user didn't specify
 	engineExtensions = map[string][]string{
 		".gz":  {PgzipCompressor, PargzipCompressor},
 		".lz4": {Lz4Compressor},
-		".zst": {ZstdCompressor},
+		".zst": {ZstdCompressor, ZstdDictCompressor},
 	}
 )
 
@@ -78,6 +86,8 @@ func registerBackupCompressionFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&ExternalCompressorExt, "external-compressor-extension", ExternalCompressorExt, "extension to use when using an external compressor.")
 	fs.StringVar(&ExternalDecompressorCmd, "external-decompressor", ExternalDecompressorCmd, "command with arguments to use when decompressing a backup.")
 	fs.StringVar(&ManifestExternalDecompressorCmd, "manifest-external-decompressor", ManifestExternalDecompressorCmd, "command with arguments to store in the backup manifest when compressing a backup with an external compression engine.")
+	fs.IntVar(&compressionDictionarySize, "compression-dictionary-size", compressionDictionarySize, "maximum size in bytes of the dictionary trained for each backup by the zstd-dict compression engine.")
+	fs.IntVar(&compressionDictionarySampleSize, "compression-dictionary-sample-size", compressionDictionarySampleSize, "number of bytes sampled from the files of a backup to train the dictionary of the zstd-dict compression engine.")
 }
 
 func getExtensionFromEngine(engine string) (string, error) {
@@ -193,7 +203,8 @@ func newExternalDecompressor(ctx context.Context, cmdStr string, reader io.Reade
 }
 
 // This returns a reader that will decompress the underlying provided reader and will use the specified supported engine.
-func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logger) (decompressor io.ReadCloser, err error) {
+// The dictionary is only used by the zstd-dict engine.
+func newBuiltinDecompressor(engine string, reader io.Reader, dictionary []byte, logger logutil.Logger) (decompressor io.ReadCloser, err error) {
 	if engine == PargzipCompressor {
 		logger.Warningf(`engine "pargzip" doesn't support decompression, using "pgzip" instead`)
 		engine = PgzipCompressor
@@ -214,6 +225,15 @@ func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logg
 			return nil, err
 		}
 		decompressor = d.IOReadCloser()
+	case ZstdDictCompressor:
+		if len(dictionary) == 0 {
+			return nil, fmt.Errorf("engine %q requires a compression dictionary", engine)
+		}
+		d, err := zstd.NewReader(reader, zstd.WithDecoderDicts(dictionary))
+		if err != nil {
+			return nil, err
+		}
+		decompressor = d.IOReadCloser()
 	default:
 		err = fmt.Errorf("Unkown decompressor engine: %q", engine)
 		return decompressor, err
@@ -224,7 +244,8 @@ func newBuiltinDecompressor(engine string, reader io.Reader, logger logutil.Logg
 }
 
 // This returns a writer that will compress the data using the specified engine before writing to the underlying writer.
-func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger) (compressor io.WriteCloser, err error) {
+// The dictionary is only used by the zstd-dict engine.
+func newBuiltinCompressor(engine string, writer io.Writer, dictionary []byte, logger logutil.Logger) (compressor io.WriteCloser, err error) {
 	switch engine {
 	case PgzipCompressor:
 		gzip, err := pgzip.NewWriterLevel(writer, compressionLevel)
@@ -251,6 +272,15 @@ func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger
 			return compressor, vterrors.Wrap(err, "cannot create zstd compressor")
 		}
 		compressor = zst
+	case ZstdDictCompressor:
+		if len(dictionary) == 0 {
+			return compressor, fmt.Errorf("engine %q requires a compression dictionary, which is only trained by the builtin backup engine", engine)
+		}
+		zst, err := zstd.NewWriter(writer, zstd.WithEncoderLevel(zstd.EncoderLevel(compressionLevel)), zstd.WithEncoderDict(dictionary))
+		if err != nil {
+			return compressor, vterrors.Wrap(err, "cannot create zstd compressor")
+		}
+		compressor = zst
 	default:
 		err = fmt.Errorf("%w value: %q", errUnsupportedCompressionEngine, engine)
 		return compressor, err
@@ -260,6 +290,19 @@ func newBuiltinCompressor(engine string, writer io.Writer, logger logutil.Logger
 	return
 }
 
+// trainCompressionDictionary trains the dictionary of the zstd-dict engine with
+// samples of the data to compress.
+func trainCompressionDictionary(samples [][]byte) ([]byte, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("no data to train the compression dictionary")
+	}
+	return dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: compressionDictionarySize,
+		HashBytes:   6,
+		ZstdLevel:   zstd.EncoderLevel(compressionLevel),
+	})
+}
+
 // This struct wraps the underlying exec.Cmd and implements the io.WriteCloser interface.
 type externalCompressor struct {
 	cmd   *exec.Cmd
@@ -284,7 +284,7 @@ func (bce *benchmarkCompressEnv) compressor(logger logutil.Logger, writer io.Wri
 	var err error
 
 	if bce.builtin != "" {
-		compressor, err = newBuiltinCompressor(bce.builtin, writer, logger)
+		compressor, err = newBuiltinCompressor(bce.builtin, writer, nil, logger)
 	} else if bce.external != "" {
 		compressor, err = newExternalCompressor(context.Background(), bce.external, writer, logger)
 	}
@@ -40,6 +40,7 @@ func TestGetExtensionFromEngine(t *testing.T) {
 		{"pargzip", ".gz", nil},
 		{"lz4", ".lz4", nil},
 		{"zstd", ".zst", nil},
+		{"zstd-dict", ".zst", nil},
 		{"foobar", "", errUnsupportedCompressionEngine},
 	}
 
@@ -60,14 +61,14 @@ func TestBuiltinCompressors(t *testing.T) {
 		t.Run(engine, func(t *testing.T) {
 			var compressed, decompressed bytes.Buffer
 			reader := bytes.NewReader(data)
-			compressor, err := newBuiltinCompressor(engine, &compressed, logger)
+			compressor, err := newBuiltinCompressor(engine, &compressed, nil, logger)
 			require.NoError(t, err)
 
 			_, err = io.Copy(compressor, reader)
 			require.NoError(t, err)
 
 			compressor.Close()
-			decompressor, err := newBuiltinDecompressor(engine, &compressed, logger)
+			decompressor, err := newBuiltinDecompressor(engine, &compressed, nil, logger)
 			require.NoError(t, err)
 
 			_, err = io.Copy(&decompressed, decompressor)
@@ -84,12 +85,47 @@ func TestUnSupportedBuiltinCompressors(t *testing.T) {
 
 	for _, engine := range []string{"external", "foobar"} {
 		t.Run(engine, func(t *testing.T) {
-			_, err := newBuiltinCompressor(engine, nil, logger)
-			require.ErrorContains(t, err, "unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'lz4' value:")
+			_, err := newBuiltinCompressor(engine, nil, nil, logger)
+			require.ErrorContains(t, err, "unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'zstd-dict', 'lz4' value:")
 		})
 	}
 }
 
+func TestZstdDictCompressor(t *testing.T) {
+	logger := logutil.NewMemoryLogger()
+
+	var samples [][]byte
+	for i := range 100 {
+		samples = append(samples, []byte(strings.Repeat(fmt.Sprintf("row %d: the quick brown fox jumps over the lazy dog. ", i), 10)))
+	}
+	dictionary, err := trainCompressionDictionary(samples)
+	require.NoError(t, err)
+	require.NotEmpty(t, dictionary)
+
+	_, err = trainCompressionDictionary(nil)
+	require.Error(t, err)
+
+	_, err = newBuiltinCompressor(ZstdDictCompressor, io.Discard, nil, logger)
+	require.ErrorContains(t, err, "requires a compression dictionary")
+	_, err = newBuiltinDecompressor(ZstdDictCompressor, nil, nil, logger)
+	require.ErrorContains(t, err, "requires a compression dictionary")
+
+	data := []byte("row 42: the quick brown fox jumps over the lazy dog.")
+	var compressed, decompressed bytes.Buffer
+	compressor, err := newBuiltinCompressor(ZstdDictCompressor, &compressed, dictionary, logger)
+	require.NoError(t, err)
+	_, err = compressor.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, compressor.Close())
+
+	decompressor, err := newBuiltinDecompressor(ZstdDictCompressor, &compressed, dictionary, logger)
+	require.NoError(t, err)
+	_, err = io.Copy(&decompressed, decompressor)
+	require.NoError(t, err)
+	require.NoError(t, decompressor.Close())
+	assert.Equal(t, data, decompressed.Bytes())
+}
+
 func TestExternalCompressors(t *testing.T) {
 	data := []byte("foo bar foobar")
 	logger := logutil.NewMemoryLogger()
@@ -175,7 +211,7 @@ func TestValidateCompressionEngineName(t *testing.T) {
 	}{
 		// we expect ls to be on PATH as it is a basic command part of busybox and most containers
 		{"external", ""},
-		{"foobar", "unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'lz4' value: \"foobar\""},
+		{"foobar", "unsupported engine value for --compression-engine-name. supported values are 'external', 'pgzip', 'pargzip', 'zstd', 'zstd-dict', 'lz4' value: \"foobar\""},
 	}
 
 	for i, tt := range tests {
@@ -140,6 +140,15 @@ type FakeMysqlDaemon struct {
 	// StopReplicationError error is used by StopReplication.
 	StopReplicationError error
 
+	// ClonedFrom is set by CloneFrom to the address of the donor.
+	ClonedFrom string
+
+	// CloneRestartNeeded is returned by CloneFrom.
+	CloneRestartNeeded bool
+
+	// CloneFromError is used by CloneFrom.
+	CloneFromError error
+
 	// WaitPrimaryPositions is checked by WaitSourcePos, if the value
 	// is found in it, then the function returns nil, else the
 	// function returns an error.
@@ -541,6 +550,15 @@ func (fmd *FakeMysqlDaemon) SetReplicationSource(ctx context.Context, host strin
 	return fmd.ExecuteSuperQueryList(ctx, cmds)
 }
 
+// CloneFrom is part of the MysqlDaemon interface.
+func (fmd *FakeMysqlDaemon) CloneFrom(ctx context.Context, cnf *Mycnf, host string, port int32, maxBandwidth int64) (bool, error) {
+	if fmd.CloneFromError != nil {
+		return false, fmd.CloneFromError
+	}
+	fmd.ClonedFrom = fmt.Sprintf("%v:%v", host, port)
+	return fmd.CloneRestartNeeded, nil
+}
+
 // WaitForReparentJournal is part of the MysqlDaemon interface.
 func (fmd *FakeMysqlDaemon) WaitForReparentJournal(ctx context.Context, timeCreatedNS int64) error {
 	return nil
@@ -92,6 +92,11 @@ type MysqlDaemon interface {
 	SetReplicationSource(ctx context.Context, host string, port int32, heartbeatInterval float64, stopReplicationBefore bool, startReplicationAfter bool) error
 	WaitForReparentJournal(ctx context.Context, timeCreatedNS int64) error
 
+	// CloneFrom replaces the data of mysqld with the data of the donor at the
+	// given address, with the clone plugin. restartNeeded is true if mysqld
+	// was shut down rather than restarted on the cloned data.
+	CloneFrom(ctx context.Context, cnf *Mycnf, host string, port int32, maxBandwidth int64) (restartNeeded bool, err error)
+
 	WaitSourcePos(context.Context, replication.Position) error
 	CatchupToGTID(context.Context, replication.Position) error
 
@@ -384,7 +384,7 @@ func (be *XtrabackupEngine) backupFiles(
 			if ExternalCompressorCmd != "" {
 				compressor, err = newExternalCompressor(ctx, ExternalCompressorCmd, writer, params.Logger)
 			} else {
-				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, params.Logger)
+				compressor, err = newBuiltinCompressor(CompressionEngineName, writer, nil, params.Logger)
 			}
 			if err != nil {
 				return replicationPosition, vterrors.Wrap(err, "can't create compressor")
@@ -647,13 +647,13 @@ func (be *XtrabackupEngine) extractFiles(ctx context.Context, logger logutil.Log
 					deCompressionEngine = externalDecompressorCmd
 					decompressor, err = newExternalDecompressor(ctx, deCompressionEngine, reader, logger)
 				} else {
-					decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, logger)
+					decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, nil, logger)
 				}
 			} else {
 				if deCompressionEngine == ExternalCompressor {
 					return fmt.Errorf("%w %q", errUnsupportedCompressionEngine, ExternalCompressor)
 				}
-				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, logger)
+				decompressor, err = newBuiltinDecompressor(deCompressionEngine, reader, nil, logger)
 			}
 			if err != nil {
 				return vterrors.Wrap(err, "can't create decompressor")
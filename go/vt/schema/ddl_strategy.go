@@ -31,6 +31,7 @@ var (
 	cutOverThresholdFlagRegexp  = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverThresholdFlag))
 	forceCutOverAfterFlagRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, forceCutOverAfterFlag))
 	retainArtifactsFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, retainArtifactsFlag))
+	shadowReadsPercentRegexp    = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, shadowReadsPercentFlag))
 )
 
 const (
@@ -52,6 +53,7 @@ const (
 	vreplicationTestSuite  = "vreplication-test-suite"
 	allowForeignKeysFlag   = "unsafe-allow-foreign-keys"
 	analyzeTableFlag       = "analyze-table"
+	shadowReadsPercentFlag = "shadow-reads-percent"
 )
 
 // DDLStrategy suggests how an ALTER TABLE should run (e.g. "direct", "online", "mysql")
@@ -119,12 +121,19 @@ func ParseDDLStrategy(strategyVariable string) (*DDLStrategySetting, error) {
 	if err != nil {
 		return nil, err
 	}
+	shadowReadsPercent, err := setting.ShadowReadsPercent()
+	if err != nil {
+		return nil, err
+	}
 	switch setting.Strategy {
 	case DDLStrategyVitess, DDLStrategyOnline:
 	default:
 		if cutoverAfter != 0 {
 			return nil, fmt.Errorf("--force-cut-over-after is only valid in 'vitess' strategy. Found %v value in '%v' strategy", cutoverAfter, setting.Strategy)
 		}
+		if shadowReadsPercent != 0 {
+			return nil, fmt.Errorf("--shadow-reads-percent is only valid in 'vitess' strategy. Found %v value in '%v' strategy", shadowReadsPercent, setting.Strategy)
+		}
 	}
 
 	switch setting.Strategy {
@@ -236,6 +245,15 @@ func isRetainArtifactsFlag(opt string) (string, bool) {
 	return submatch[1], true
 }
 
+// isShadowReadsPercentFlag returns true when given option denotes a `--shadow-reads-percent=[...]` flag
+func isShadowReadsPercentFlag(opt string) (string, bool) {
+	submatch := shadowReadsPercentRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
 // CutOverThreshold returns a the duration threshold indicated by --cut-over-threshold
 func (setting *DDLStrategySetting) CutOverThreshold() (d time.Duration, err error) {
 	// We do some ugly manual parsing of --cut-over-threshold value
@@ -290,6 +308,27 @@ func (setting *DDLStrategySetting) RetainArtifactsDuration() (d time.Duration, e
 	return d, err
 }
 
+// ShadowReadsPercent returns the percentage indicated by --shadow-reads-percent, of reads on the migrated
+// table to mirror onto the shadow table once the shadow table is in sync. Zero means no reads are mirrored.
+func (setting *DDLStrategySetting) ShadowReadsPercent() (percent float64, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isShadowReads := isShadowReadsPercentFlag(opt); isShadowReads {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				percent, err = strconv.ParseFloat(val, 64)
+				if err != nil || percent < 0 || percent > 100 {
+					return 0, fmt.Errorf("invalid --shadow-reads-percent value: %s. Expected a number between 0 and 100", val)
+				}
+			}
+		}
+	}
+	return percent, nil
+}
+
 // IsVreplicationTestSuite checks if strategy options include --vreplicatoin-test-suite
 func (setting *DDLStrategySetting) IsVreplicationTestSuite() bool {
 	return setting.hasFlag(vreplicationTestSuite)
@@ -319,6 +358,9 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		if _, ok := isRetainArtifactsFlag(opt); ok {
 			continue
 		}
+		if _, ok := isShadowReadsPercentFlag(opt); ok {
+			continue
+		}
 		switch {
 		case isFlag(opt, declarativeFlag):
 		case isFlag(opt, skipTopoFlag): // deprecated flag, parsed for backwards compatibility
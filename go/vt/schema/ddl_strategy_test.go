@@ -198,6 +198,7 @@ func TestParseDDLStrategy(t *testing.T) {
 		cutOverThreshold     time.Duration
 		forceCutOverAfter    time.Duration
 		expireArtifacts      time.Duration
+		shadowReadsPercent   float64
 		runtimeOptions       string
 		expectError          string
 	}{
@@ -352,6 +353,19 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			analyzeTable:     true,
 		},
+		{
+			strategyVariable:   "vitess --shadow-reads-percent=2.5",
+			strategy:           DDLStrategyVitess,
+			options:            "--shadow-reads-percent=2.5",
+			runtimeOptions:     "",
+			shadowReadsPercent: 2.5,
+		},
+		{
+			strategyVariable: "mysql --shadow-reads-percent=10",
+			strategy:         DDLStrategyMySQL,
+			runtimeOptions:   "",
+			expectError:      "--shadow-reads-percent is only valid in 'vitess' strategy",
+		},
 
 		{
 			strategyVariable: "vitess --alow-concrrnt", // intentional typo
@@ -392,6 +406,9 @@ func TestParseDDLStrategy(t *testing.T) {
 			forceCutOverAfter, err := setting.ForceCutOverAfter()
 			assert.NoError(t, err)
 			assert.Equal(t, ts.forceCutOverAfter, forceCutOverAfter)
+			shadowReadsPercent, err := setting.ShadowReadsPercent()
+			assert.NoError(t, err)
+			assert.Equal(t, ts.shadowReadsPercent, shadowReadsPercent)
 
 			runtimeOptions := strings.Join(setting.RuntimeOptions(), " ")
 			assert.Equal(t, ts.runtimeOptions, runtimeOptions)
@@ -413,4 +430,12 @@ func TestParseDDLStrategy(t *testing.T) {
 		_, err := ParseDDLStrategy("online --retain-artifacts=3")
 		assert.Error(t, err)
 	}
+	{
+		_, err := ParseDDLStrategy("vitess --shadow-reads-percent=X")
+		assert.Error(t, err)
+	}
+	{
+		_, err := ParseDDLStrategy("vitess --shadow-reads-percent=101")
+		assert.Error(t, err)
+	}
 }
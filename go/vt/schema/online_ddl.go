@@ -194,6 +194,35 @@ func NewOnlineDDLs(keyspace string, sql string, ddlStmt sqlparser.DDLStatement,
 	return onlineDDLs, nil
 }
 
+// NewTableMaintenanceOnlineDDLs generates the OnlineDDL instances of an ANALYZE TABLE or an OPTIMIZE TABLE statement.
+// ANALYZE TABLE runs as a single migration of its own action. OPTIMIZE TABLE generates one ALTER TABLE ... FORCE
+// migration per table, which is how MySQL optimizes InnoDB tables, and which the vitess strategy runs as a table rebuild.
+func NewTableMaintenanceOnlineDDLs(keyspace string, stmt sqlparser.Statement, ddlStrategySetting *DDLStrategySetting, migrationContext string, parser *sqlparser.Parser) (onlineDDLs []*OnlineDDL, err error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Analyze:
+		onlineDDL, err := NewOnlineDDL(keyspace, stmt.Table.Name.String(), sqlparser.String(stmt), ddlStrategySetting, migrationContext, "", parser)
+		if err != nil {
+			return nil, err
+		}
+		return []*OnlineDDL{onlineDDL}, nil
+	case *sqlparser.OptimizeTable:
+		for _, table := range stmt.Tables {
+			alterTable := &sqlparser.AlterTable{
+				Table:        table,
+				AlterOptions: []sqlparser.AlterOption{&sqlparser.Force{}},
+				FullyParsed:  true,
+			}
+			alterOnlineDDLs, err := NewOnlineDDLs(keyspace, sqlparser.String(alterTable), alterTable, ddlStrategySetting, migrationContext, "", parser)
+			if err != nil {
+				return nil, err
+			}
+			onlineDDLs = append(onlineDDLs, alterOnlineDDLs...)
+		}
+		return onlineDDLs, nil
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported statement for Online DDL: %v", sqlparser.String(stmt))
+}
+
 // NewOnlineDDL creates a schema change request with self generated UUID and RequestTime
 func NewOnlineDDL(keyspace string, table string, sql string, ddlStrategySetting *DDLStrategySetting, migrationContext string, providedUUID string, parser *sqlparser.Parser) (onlineDDL *OnlineDDL, err error) {
 	if ddlStrategySetting == nil {
@@ -235,6 +264,8 @@ func NewOnlineDDL(keyspace string, table string, sql string, ddlStrategySetting
 			stmt.SetComments(comments)
 		case *sqlparser.RevertMigration:
 			stmt.SetComments(comments)
+		case *sqlparser.Analyze:
+			stmt.SetComments(comments)
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Unsupported statement for Online DDL: %v", sqlparser.String(stmt))
 		}
@@ -270,6 +301,8 @@ func OnlineDDLFromCommentedStatement(stmt sqlparser.Statement) (onlineDDL *Onlin
 		comments = stmt.GetParsedComments()
 	case *sqlparser.RevertMigration:
 		comments = stmt.Comments
+	case *sqlparser.Analyze:
+		comments = stmt.Comments
 	default:
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported statement for Online DDL: %v", sqlparser.String(stmt))
 	}
@@ -351,6 +384,8 @@ func (onlineDDL *OnlineDDL) sqlWithoutComments(parser *sqlparser.Parser) (sql st
 		stmt.SetComments(nil)
 	case *sqlparser.RevertMigration:
 		stmt.SetComments(nil)
+	case *sqlparser.Analyze:
+		stmt.SetComments(nil)
 	}
 	sql = sqlparser.String(stmt)
 	return sql, nil
@@ -361,6 +396,9 @@ func (onlineDDL *OnlineDDL) GetAction(parser *sqlparser.Parser) (action sqlparse
 	if _, err := onlineDDL.GetRevertUUID(parser); err == nil {
 		return sqlparser.RevertDDLAction, nil
 	}
+	if onlineDDL.IsAnalyze(parser) {
+		return sqlparser.AnalyzeDDLAction, nil
+	}
 
 	_, action, err = ParseOnlineDDLStatement(onlineDDL.SQL, parser)
 	return action, err
@@ -379,6 +417,16 @@ func (onlineDDL *OnlineDDL) IsView(parser *sqlparser.Parser) bool {
 	return false
 }
 
+// IsAnalyze returns 'true' when the migration runs an ANALYZE TABLE statement
+func (onlineDDL *OnlineDDL) IsAnalyze(parser *sqlparser.Parser) bool {
+	stmt, err := parser.Parse(onlineDDL.SQL)
+	if err != nil {
+		return false
+	}
+	_, ok := stmt.(*sqlparser.Analyze)
+	return ok
+}
+
 // GetActionStr returns a string representation of the DDL action
 func (onlineDDL *OnlineDDL) GetActionStr(parser *sqlparser.Parser) (action sqlparser.DDLAction, actionStr string, err error) {
 	action, err = onlineDDL.GetAction(parser)
@@ -394,6 +442,8 @@ func (onlineDDL *OnlineDDL) GetActionStr(parser *sqlparser.Parser) (action sqlpa
 		return action, sqlparser.AlterStr, nil
 	case sqlparser.DropDDLAction:
 		return action, sqlparser.DropStr, nil
+	case sqlparser.AnalyzeDDLAction:
+		return action, sqlparser.AnalyzeStr, nil
 	}
 	return action, "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported online DDL action. SQL=%s", onlineDDL.SQL)
 }
@@ -82,6 +82,10 @@ func TestGetActionStr(t *testing.T) {
 			statement: "drop table t",
 			actionStr: sqlparser.DropStr,
 		},
+		{
+			statement: "analyze table t",
+			actionStr: sqlparser.AnalyzeStr,
+		},
 		{
 			statement: "rename table t to t2",
 			isError:   true,
@@ -353,6 +357,68 @@ func TestNewOnlineDDLs(t *testing.T) {
 	}
 }
 
+func TestNewTableMaintenanceOnlineDDLs(t *testing.T) {
+	tests := []struct {
+		query   string
+		sqls    []string
+		tables  []string
+		actions []sqlparser.DDLAction
+	}{
+		{
+			query:   "analyze table t",
+			sqls:    []string{"analyze table t"},
+			tables:  []string{"t"},
+			actions: []sqlparser.DDLAction{sqlparser.AnalyzeDDLAction},
+		},
+		{
+			query:   "analyze local table t",
+			sqls:    []string{"analyze local table t"},
+			tables:  []string{"t"},
+			actions: []sqlparser.DDLAction{sqlparser.AnalyzeDDLAction},
+		},
+		{
+			query:   "optimize table t1, t2",
+			sqls:    []string{"alter table t1 force", "alter table t2 force"},
+			tables:  []string{"t1", "t2"},
+			actions: []sqlparser.DDLAction{sqlparser.AlterDDLAction, sqlparser.AlterDDLAction},
+		},
+	}
+	migrationContext := "354b-11eb-82cd-f875a4d24e90"
+	parser := sqlparser.NewTestParser()
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+
+			onlineDDLs, err := NewTableMaintenanceOnlineDDLs("test_ks", stmt, NewDDLStrategySetting(DDLStrategyVitess, ""), migrationContext, parser)
+			require.NoError(t, err)
+
+			var sqls, tables []string
+			var actions []sqlparser.DDLAction
+			for _, onlineDDL := range onlineDDLs {
+				sql, err := onlineDDL.sqlWithoutComments(parser)
+				require.NoError(t, err)
+				sqls = append(sqls, sql)
+				tables = append(tables, onlineDDL.Table)
+				action, err := onlineDDL.GetAction(parser)
+				require.NoError(t, err)
+				actions = append(actions, action)
+				assert.Equal(t, migrationContext, onlineDDL.MigrationContext)
+			}
+			assert.Equal(t, tc.sqls, sqls)
+			assert.Equal(t, tc.tables, tables)
+			assert.Equal(t, tc.actions, actions)
+		})
+	}
+
+	t.Run("unsupported statement", func(t *testing.T) {
+		stmt, err := parser.Parse("checksum table t")
+		require.NoError(t, err)
+		_, err = NewTableMaintenanceOnlineDDLs("test_ks", stmt, NewDDLStrategySetting(DDLStrategyVitess, ""), migrationContext, parser)
+		assert.ErrorContains(t, err, "unsupported statement for Online DDL")
+	})
+}
+
 func TestNewOnlineDDLsForeignKeys(t *testing.T) {
 	queries := []string{
 		"alter table corder add FOREIGN KEY my_fk(customer_id) references customer(customer_id)",
@@ -403,6 +469,7 @@ func TestOnlineDDLFromCommentedStatement(t *testing.T) {
 		`drop view v`,
 		`alter view v as select * from t`,
 		`revert vitess_migration '4e5dcf80_354b_11eb_82cd_f875a4d24e90'`,
+		`analyze table t`,
 	}
 	strategySetting := NewDDLStrategySetting(DDLStrategyVitess, `-singleton -declarative --max-load="Threads_running=5"`)
 	migrationContext := "354b-11eb-82cd-f875a4d24e90"
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	// scheduleWindowRegexp parses a schedule window such as `22:00-06:00`
+	scheduleWindowRegexp = regexp.MustCompile(`^([0-9]{2}):([0-9]{2})-([0-9]{2}):([0-9]{2})$`)
+)
+
+// ScheduleWindow is a daily UTC time range in which a migration is allowed to start running.
+// The window may wrap around midnight, e.g. `22:00-06:00`.
+// An empty window places no restriction.
+type ScheduleWindow struct {
+	start time.Duration
+	end   time.Duration
+	empty bool
+}
+
+// ParseScheduleWindow parses a `HH:MM-HH:MM` schedule window. An empty string is a valid, empty window.
+func ParseScheduleWindow(s string) (*ScheduleWindow, error) {
+	if s == "" {
+		return &ScheduleWindow{empty: true}, nil
+	}
+	submatch := scheduleWindowRegexp.FindStringSubmatch(s)
+	if len(submatch) == 0 {
+		return nil, fmt.Errorf("invalid schedule window: %s. Expected format is 'HH:MM-HH:MM', e.g. '22:00-06:00' (UTC)", s)
+	}
+	var bounds [4]int
+	for i := range bounds {
+		bounds[i], _ = strconv.Atoi(submatch[i+1])
+	}
+	if bounds[0] > 23 || bounds[2] > 23 || bounds[1] > 59 || bounds[3] > 59 {
+		return nil, fmt.Errorf("invalid schedule window: %s. Hours must be in the range 00-23 and minutes in the range 00-59", s)
+	}
+	w := &ScheduleWindow{
+		start: time.Duration(bounds[0])*time.Hour + time.Duration(bounds[1])*time.Minute,
+		end:   time.Duration(bounds[2])*time.Hour + time.Duration(bounds[3])*time.Minute,
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("invalid schedule window: %s. Start and end times must differ", s)
+	}
+	return w, nil
+}
+
+// IsEmpty returns true when the window places no restriction.
+func (w *ScheduleWindow) IsEmpty() bool {
+	return w.empty
+}
+
+// Contains returns true when the given time falls within the window. The start time is inclusive
+// and the end time is exclusive. An empty window contains any time.
+func (w *ScheduleWindow) Contains(t time.Time) bool {
+	if w.empty {
+		return true
+	}
+	t = t.UTC()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+	// The window wraps around midnight
+	return offset >= w.start || offset < w.end
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleWindow(t *testing.T) {
+	tcases := []struct {
+		window string
+		isErr  bool
+	}{
+		{window: ""},
+		{window: "01:00-05:30"},
+		{window: "22:00-06:00"},
+		{window: "00:00-23:59"},
+		{window: "01:00", isErr: true},
+		{window: "1:00-5:00", isErr: true},
+		{window: "24:00-05:00", isErr: true},
+		{window: "01:60-05:00", isErr: true},
+		{window: "05:00-05:00", isErr: true},
+		{window: "01:00-05:00 ", isErr: true},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.window, func(t *testing.T) {
+			w, err := ParseScheduleWindow(tcase.window)
+			if tcase.isErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.window == "", w.IsEmpty())
+		})
+	}
+}
+
+func TestScheduleWindowContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tcases := []struct {
+		window   string
+		t        time.Time
+		contains bool
+	}{
+		{window: "", t: at(12, 0), contains: true},
+		{window: "01:00-05:00", t: at(0, 59), contains: false},
+		{window: "01:00-05:00", t: at(1, 0), contains: true},
+		{window: "01:00-05:00", t: at(4, 59), contains: true},
+		{window: "01:00-05:00", t: at(5, 0), contains: false},
+		{window: "22:00-06:00", t: at(21, 59), contains: false},
+		{window: "22:00-06:00", t: at(22, 0), contains: true},
+		{window: "22:00-06:00", t: at(0, 0), contains: true},
+		{window: "22:00-06:00", t: at(5, 59), contains: true},
+		{window: "22:00-06:00", t: at(6, 0), contains: false},
+		{window: "22:00-06:00", t: at(12, 0), contains: false},
+		{window: "01:00-05:00", t: at(3, 0).In(time.FixedZone("UTC+5", 5*3600)), contains: true},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.window, func(t *testing.T) {
+			w, err := ParseScheduleWindow(tcase.window)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.contains, w.Contains(tcase.t))
+		})
+	}
+}
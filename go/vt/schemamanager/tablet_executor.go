@@ -323,6 +323,11 @@ func (exec *TabletExecutor) executeSQL(ctx context.Context, sql string, provided
 			sqlparser.ThrottleAllMigrationType,
 			sqlparser.UnthrottleMigrationType,
 			sqlparser.UnthrottleAllMigrationType:
+			if stmt.MigrationContext != "" {
+				// Only the tablets know which migrations belong to the context
+				exec.executeOnAllTablets(ctx, execResult, sql, true)
+				return true, nil
+			}
 			err := exec.executeAlterMigrationThrottle(ctx, stmt)
 			if err != nil {
 				execResult.ExecutorErr = err.Error()
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"context"
+	"net"
+
+	"github.com/spf13/pflag"
+)
+
+// reusePort binds the listening sockets with SO_REUSEPORT.
+var reusePort bool
+
+func registerReusePortFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&reusePort, "reuse-port", reusePort, "If set, the HTTP, gRPC and MySQL ports are bound with SO_REUSEPORT, so that a new process can listen on them while this one drains, to restart without downtime.")
+}
+
+func init() {
+	for _, cmd := range []string{
+		"vtcombo",
+		"vtgate",
+	} {
+		OnParseFor(cmd, registerReusePortFlags)
+	}
+}
+
+// Listen announces on the local network address, like net.Listen, with
+// SO_REUSEPORT when --reuse-port is set.
+func Listen(network, address string) (net.Listener, error) {
+	var lc net.ListenConfig
+	if reusePort {
+		lc.Control = reusePortControl
+	}
+	return lc.Listen(context.Background(), network, address)
+}
+
+// ReusePort returns whether the listening sockets are bound with
+// SO_REUSEPORT.
+func ReusePort() bool {
+	return reusePort
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenReusePort(t *testing.T) {
+	defer func(old bool) { reusePort = old }(reusePort)
+
+	reusePort = false
+	l, err := Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, err = Listen("tcp", l.Addr().String())
+	assert.ErrorContains(t, err, "address already in use")
+	l.Close()
+
+	reusePort = true
+	assert.True(t, ReusePort())
+	l1, err := Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l1.Close()
+	l2, err := Listen("tcp", l1.Addr().String())
+	require.NoError(t, err)
+	defer l2.Close()
+}
@@ -19,18 +19,29 @@ package servenv
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 
 	"vitess.io/vitess/go/vt/log"
 )
 
-var pidFile string // registered in RegisterFlags as --pid_file
+// PIDFileHandoffEnv is the environment variable set for a process started
+// by another which hands its pid file over to it. The new process doesn't
+// create the pid file, which exists, but writes its pid in it with
+// TakeOverPIDFile.
+const PIDFileHandoffEnv = "VT_PID_FILE_HANDOFF"
 
-func init() {
-	pidFileCreated := false
+var (
+	pidFile string // registered in RegisterFlags as --pid_file
+
+	pidFileMu      sync.Mutex
+	pidFileCreated bool
+)
 
+func init() {
 	// Create pid file after flags are parsed.
 	OnInit(func() {
-		if pidFile == "" {
+		if pidFile == "" || os.Getenv(PIDFileHandoffEnv) != "" {
 			return
 		}
 
@@ -39,13 +50,17 @@ func init() {
 			log.Errorf("Unable to create pid file '%s': %v", pidFile, err)
 			return
 		}
+		pidFileMu.Lock()
 		pidFileCreated = true
+		pidFileMu.Unlock()
 		fmt.Fprintln(file, os.Getpid())
 		file.Close()
 	})
 
 	// Remove pid file on graceful shutdown.
 	OnClose(func() {
+		pidFileMu.Lock()
+		defer pidFileMu.Unlock()
 		if pidFile == "" {
 			return
 		}
@@ -58,3 +73,47 @@ func init() {
 		}
 	})
 }
+
+// TakeOverPIDFile writes the pid of the process in the pid file, which it
+// removes on shutdown. It is called by a process started with
+// PIDFileHandoffEnv once it has taken over the process which started it, or
+// by the latter if the handoff fails.
+func TakeOverPIDFile() error {
+	os.Unsetenv(PIDFileHandoffEnv)
+	if pidFile == "" {
+		return nil
+	}
+	pidFileMu.Lock()
+	defer pidFileMu.Unlock()
+
+	// The pid file is replaced rather than written, for it never to be seen
+	// empty or truncated.
+	tmp, err := os.CreateTemp(filepath.Dir(pidFile), filepath.Base(pidFile)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write pid file '%s': %v", pidFile, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = fmt.Fprintln(tmp, os.Getpid())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), pidFile)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write pid file '%s': %v", pidFile, err)
+	}
+	pidFileCreated = true
+	return nil
+}
+
+// HandOffPIDFile makes the process leave the pid file on shutdown, since it
+// belongs to the process to which it handed over.
+func HandOffPIDFile() {
+	pidFileMu.Lock()
+	defer pidFileMu.Unlock()
+	pidFileCreated = false
+}
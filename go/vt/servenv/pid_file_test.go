@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servenv
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIDFileHandoff(t *testing.T) {
+	oldPIDFile, oldCreated := pidFile, pidFileCreated
+	defer func() { pidFile, pidFileCreated = oldPIDFile, oldCreated }()
+
+	// The pid file of the process which hands over.
+	dir := t.TempDir()
+	pidFile = filepath.Join(dir, "vtgate.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte("1\n"), 0644))
+	pidFileCreated = false
+
+	t.Setenv(PIDFileHandoffEnv, "1")
+	require.NoError(t, TakeOverPIDFile())
+	data, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(data))
+	assert.True(t, pidFileCreated, "the pid file is removed on shutdown")
+	_, ok := os.LookupEnv(PIDFileHandoffEnv)
+	assert.False(t, ok)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file is renamed")
+
+	HandOffPIDFile()
+	assert.False(t, pidFileCreated, "the pid file is left on shutdown")
+
+	pidFile = filepath.Join(dir, "missing", "vtgate.pid")
+	assert.ErrorContains(t, TakeOverPIDFile(), "no such file or directory")
+	assert.False(t, pidFileCreated)
+}
@@ -26,6 +26,17 @@ schema for each table in _vt (in the form of a create table statement).
 
 sidecardb uses the schemadiff module in Vitess to reach the desired schema for each table.
 
+The schema files describe the latest version of the schema, as listed in migrations.go. The sidecardb_version table
+records the version of the schema of the database. A change to the schema files must add a version, with the statements
+which revert it (down), and optionally the ones which complete the upgrade once the tables are upgraded (up), e.g. to
+backfill a new column. The features which need the new tables or columns are listed in their version, and checked with
+FeatureEnabled().
+
+Before a downgrade, the schema is rolled back to the version of the older release with the down statements of the newer
+versions, using `vtctldclient SetSidecarSchemaVersion`. It is then pinned: the tablets do not upgrade the tables while
+the version is pinned, or if it is newer than the one of their binary, since converging to older schema files would
+lose the data of the newer versions.
+
 Note:
 
 The `if not exists` in the schema files should not be needed since we only create tables in the sidecar database if they don't exist.
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardb
+
+import (
+	"sync/atomic"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	readStateQuery  = "select version, pinned from %s.sidecardb_version where id = 1"
+	writeStateQuery = "insert into %s.sidecardb_version (id, version, pinned, time_updated) values (1, %d, %d, unix_timestamp()) " +
+		"on duplicate key update version = values(version), pinned = values(pinned), time_updated = values(time_updated)"
+)
+
+// migration is a version of the sidecar schema. The schema files always
+// describe the latest version: a migration records the statements which
+// complete an upgrade to it, and the ones which revert it before a
+// downgrade.
+type migration struct {
+	version int
+	name    string
+	// features are the functionalities which need this version.
+	features []string
+	// up are run in the sidecar database after the tables are upgraded to
+	// the schema files, e.g. to backfill a new column.
+	up []string
+	// down revert the changes of the version to the schema files, e.g. drop
+	// the tables or columns it added. They are run in reverse order of the
+	// versions, so they see the schema of their version.
+	down []string
+}
+
+// migrations are the versions of the sidecar schema, in increasing order.
+// Every change to the schema files must add a version, with the statements
+// which revert it.
+var migrations = []*migration{
+	{version: 1, name: "baseline"},
+}
+
+// schemaVersion is the version of the sidecar schema seen by the last call
+// to Init, or 0 if it was not called.
+var schemaVersion atomic.Int64
+
+// State is the state of the schema of a sidecar database.
+type State struct {
+	// Version is 0 if the sidecar database predates the versions.
+	Version int `json:"version"`
+	// Pinned is true if the schema was rolled back to Version: the tablets
+	// do not upgrade it then.
+	Pinned bool `json:"pinned"`
+}
+
+// LatestVersion returns the version of the sidecar schema of this binary.
+func LatestVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// FeatureEnabled returns true if the sidecar schema has the version needed
+// by a feature. Until Init is called, the schema is assumed to be the one of
+// this binary, e.g. on replicas, which get it from their primary.
+func FeatureEnabled(feature string) bool {
+	version := int(schemaVersion.Load())
+	if version == 0 {
+		version = LatestVersion()
+	}
+	for _, m := range migrations {
+		for _, f := range m.features {
+			if f == feature {
+				return m.version <= version
+			}
+		}
+	}
+	return false
+}
+
+// Features returns the features of the versions of the sidecar schema, and
+// whether the given version enables them.
+func Features(version int) map[string]bool {
+	features := make(map[string]bool)
+	for _, m := range migrations {
+		for _, f := range m.features {
+			features[f] = m.version <= version
+		}
+	}
+	return features
+}
+
+// validateMigrations checks that the versions increase by one from 1, and
+// that every feature belongs to one version.
+func validateMigrations(migrations []*migration) error {
+	features := make(map[string]bool)
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "sidecar schema version %s is %d, expected %d", m.name, m.version, i+1)
+		}
+		for _, f := range m.features {
+			if features[f] {
+				return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "feature %s of sidecar schema version %d belongs to another version", f, m.version)
+			}
+			features[f] = true
+		}
+	}
+	return nil
+}
+
+// ReadStateQuery returns the query which reads the state of the schema of
+// the sidecar database dbIdent.
+func ReadStateQuery(dbIdent string) string {
+	return sqlparser.BuildParsedQuery(readStateQuery, dbIdent).Query
+}
+
+// ParseState parses the result of the query of ReadStateQuery.
+func ParseState(qr *sqltypes.Result) (State, error) {
+	if len(qr.Rows) == 0 {
+		return State{}, nil
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 2 {
+		return State{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for the sidecar schema state: %v", qr.Rows)
+	}
+	version, err := qr.Rows[0][0].ToInt()
+	if err != nil {
+		return State{}, err
+	}
+	pinned, err := qr.Rows[0][1].ToInt()
+	if err != nil {
+		return State{}, err
+	}
+	return State{Version: version, Pinned: pinned != 0}, nil
+}
+
+func writeStateQueryFor(dbIdent string, state State) string {
+	pinned := 0
+	if state.Pinned {
+		pinned = 1
+	}
+	return sqlparser.BuildParsedQuery(writeStateQuery, dbIdent, state.Version, pinned).Query
+}
+
+// RollbackQueries returns the statements which roll the schema of the
+// sidecar database dbIdent back from the state current to version, and pin
+// it there, e.g. before a downgrade to the Vitess release of the version.
+// They must run on one connection to the primary.
+func RollbackQueries(dbIdent string, current State, version int) ([]string, error) {
+	switch {
+	case current.Version > LatestVersion():
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the sidecar schema has version %d, which is newer than version %d of this binary: it must be rolled back by a newer release", current.Version, LatestVersion())
+	case current.Version == 0:
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the sidecar schema predates versions, and cannot be rolled back")
+	case version < 1 || version > current.Version:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the sidecar schema can only be rolled back to a version between 1 and %d", current.Version)
+	}
+	queries := []string{sqlparser.BuildParsedQuery("use %s", dbIdent).Query}
+	for i := current.Version - 1; i >= version; i-- {
+		queries = append(queries, migrations[i].down...)
+	}
+	return append(queries, writeStateQueryFor(dbIdent, State{Version: version, Pinned: true})), nil
+}
+
+// UnpinQuery returns the statement which unpins the schema of the sidecar
+// database dbIdent, so that the tablets upgrade it again when they next
+// initialize it.
+func UnpinQuery(dbIdent string, current State) string {
+	return writeStateQueryFor(dbIdent, State{Version: current.Version})
+}
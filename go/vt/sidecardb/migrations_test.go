@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/vtenv"
+)
+
+// setTestMigrations replaces the versions of the sidecar schema for a test.
+func setTestMigrations(t *testing.T, testMigrations []*migration) {
+	require.NoError(t, validateMigrations(testMigrations))
+	saved := migrations
+	migrations = testMigrations
+	t.Cleanup(func() {
+		migrations = saved
+		schemaVersion.Store(0)
+	})
+}
+
+var testMigrations = []*migration{
+	{version: 1, name: "baseline"},
+	{
+		version:  2,
+		name:     "add t1.c1",
+		features: []string{"f2"},
+		up:       []string{"update t1 set c1 = 0"},
+		down:     []string{"alter table t1 drop column c1"},
+	},
+	{
+		version:  3,
+		name:     "add t2",
+		features: []string{"f3a", "f3b"},
+		down:     []string{"drop table if exists t2", "delete from t1 where c1 = 3"},
+	},
+}
+
+func TestValidateMigrations(t *testing.T) {
+	require.NoError(t, validateMigrations(migrations))
+
+	err := validateMigrations([]*migration{{version: 1, name: "baseline"}, {version: 3, name: "v3"}})
+	assert.ErrorContains(t, err, "sidecar schema version v3 is 3, expected 2")
+	err = validateMigrations([]*migration{{version: 1, name: "baseline", features: []string{"f"}}, {version: 2, name: "v2", features: []string{"f"}}})
+	assert.ErrorContains(t, err, "feature f of sidecar schema version 2 belongs to another version")
+}
+
+func TestFeatureEnabled(t *testing.T) {
+	setTestMigrations(t, testMigrations)
+
+	// Until Init runs, the schema is assumed to be the latest.
+	assert.True(t, FeatureEnabled("f3a"))
+	schemaVersion.Store(2)
+	assert.True(t, FeatureEnabled("f2"))
+	assert.False(t, FeatureEnabled("f3a"))
+	assert.False(t, FeatureEnabled("unknown"))
+	assert.Equal(t, map[string]bool{"f2": true, "f3a": false, "f3b": false}, Features(2))
+}
+
+func TestRollbackQueries(t *testing.T) {
+	setTestMigrations(t, testMigrations)
+
+	queries, err := RollbackQueries("_vt", State{Version: 3}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"use _vt",
+		"drop table if exists t2",
+		"delete from t1 where c1 = 3",
+		"alter table t1 drop column c1",
+		"insert into _vt.sidecardb_version (id, version, pinned, time_updated) values (1, 1, 1, unix_timestamp()) " +
+			"on duplicate key update version = values(version), pinned = values(pinned), time_updated = values(time_updated)",
+	}, queries)
+
+	// Rolling back to the current version only pins it.
+	queries, err = RollbackQueries("_vt", State{Version: 2, Pinned: true}, 2)
+	require.NoError(t, err)
+	assert.Len(t, queries, 2)
+
+	_, err = RollbackQueries("_vt", State{Version: 4}, 1)
+	assert.ErrorContains(t, err, "must be rolled back by a newer release")
+	_, err = RollbackQueries("_vt", State{}, 1)
+	assert.ErrorContains(t, err, "predates versions")
+	_, err = RollbackQueries("_vt", State{Version: 2}, 3)
+	assert.ErrorContains(t, err, "between 1 and 2")
+
+	assert.Equal(t, "insert into _vt.sidecardb_version (id, version, pinned, time_updated) values (1, 2, 0, unix_timestamp()) "+
+		"on duplicate key update version = values(version), pinned = values(pinned), time_updated = values(time_updated)",
+		UnpinQuery("_vt", State{Version: 2, Pinned: true}))
+}
+
+func TestParseState(t *testing.T) {
+	state, err := ParseState(&sqltypes.Result{})
+	require.NoError(t, err)
+	assert.Equal(t, State{}, state)
+
+	state, err = ParseState(sqltypes.MakeTestResult(sqltypes.MakeTestFields("version|pinned", "uint32|int8"), "2|1"))
+	require.NoError(t, err)
+	assert.Equal(t, State{Version: 2, Pinned: true}, state)
+}
+
+// TestInitVersions checks how Init upgrades the schema depending on its
+// recorded state.
+func TestInitVersions(t *testing.T) {
+	setTestMigrations(t, testMigrations)
+	ctx := context.Background()
+	env := vtenv.NewTestEnv()
+
+	testcases := []struct {
+		name  string
+		state string
+		// populated is false to check that the tables are not upgraded.
+		populated   bool
+		wantDDLs    int64
+		wantVersion int64
+		wantQueries []string
+	}{{
+		name:        "upgrade",
+		state:       "1|0",
+		populated:   true,
+		wantDDLs:    1,
+		wantVersion: 3,
+		wantQueries: []string{"update t1 set c1 = 0", writeStateQueryFor(sidecar.GetIdentifier(), State{Version: 3})},
+	}, {
+		name:        "pinned",
+		state:       "2|1",
+		wantVersion: 2,
+	}, {
+		name:        "newer",
+		state:       "4|0",
+		wantVersion: 4,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := fakesqldb.New(t)
+			defer db.Close()
+			AddSchemaInitQueries(db, tc.populated, env.Parser())
+			db.AddQuery(ReadStateQuery(sidecar.GetIdentifier()), sqltypes.MakeTestResult(sqltypes.MakeTestFields("version|pinned", "uint32|int8"), tc.state))
+			db.AddQuery(fmt.Sprintf("use %s", sidecar.GetIdentifier()), &sqltypes.Result{})
+			for _, q := range tc.wantQueries {
+				db.AddQuery(q, &sqltypes.Result{})
+			}
+
+			cp := dbconfigs.New(db.ConnParams())
+			conn, err := cp.Connect(ctx)
+			require.NoError(t, err)
+			defer conn.Close()
+			exec := func(ctx context.Context, query string, maxRows int, useDB bool) (*sqltypes.Result, error) {
+				if useDB {
+					if _, err := conn.ExecuteFetch(fmt.Sprintf("use %s", sidecar.GetIdentifier()), maxRows, true); err != nil {
+						return nil, err
+					}
+				}
+				return conn.ExecuteFetch(query, maxRows, true)
+			}
+
+			ddlCount.Set(0)
+			require.NoError(t, Init(ctx, env, exec))
+			assert.Equal(t, tc.wantDDLs, getDDLCount())
+			assert.Equal(t, tc.wantVersion, schemaVersion.Load())
+			for _, q := range tc.wantQueries {
+				assert.Equal(t, 1, db.GetQueryCalledNum(q), q)
+			}
+		})
+	}
+}
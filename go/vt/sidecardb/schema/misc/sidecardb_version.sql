@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+CREATE TABLE IF NOT EXISTS sidecardb_version
+(
+    id           TINYINT UNSIGNED NOT NULL,
+    version      INT UNSIGNED     NOT NULL,
+    pinned       TINYINT(1)       NOT NULL DEFAULT 0,
+    time_updated BIGINT UNSIGNED  NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB CHARSET = utf8mb4
@@ -78,6 +78,10 @@ CREATE TABLE IF NOT EXISTS schema_migrations
     `last_cutover_attempt_timestamp`  timestamp        NULL DEFAULT NULL,
     `force_cutover`                   tinyint unsigned NOT NULL DEFAULT '0',
     `cutover_threshold_seconds`       int unsigned     NOT NULL DEFAULT '0',
+    `schedule_window`                 varchar(32)      NOT NULL DEFAULT '',
+    `depends_on_uuid`                 varchar(64)      NOT NULL DEFAULT '',
+    `shadow_reads_compared`           bigint unsigned  NOT NULL DEFAULT '0',
+    `shadow_reads_mismatched`         bigint unsigned  NOT NULL DEFAULT '0',
     PRIMARY KEY (`id`),
     UNIQUE KEY `uuid_idx` (`migration_uuid`),
     KEY `keyspace_shard_idx` (`keyspace`(64), `shard`(64)),
@@ -69,6 +69,8 @@ const (
 	StatsKeyQueryCount = StatsKeyPrefix + "QueryCount"
 	StatsKeyErrorCount = StatsKeyPrefix + "ErrorCount"
 	StatsKeyErrors     = StatsKeyPrefix + "Errors"
+
+	StatsKeySchemaVersion = "SidecarDBSchemaVersion"
 )
 
 var (
@@ -100,6 +102,10 @@ type ddlError struct {
 }
 
 func init() {
+	if err := validateMigrations(migrations); err != nil {
+		panic(err)
+	}
+	stats.NewGaugeFunc(StatsKeySchemaVersion, "Version of the sidecar database schema", schemaVersion.Load)
 	ddlCount = stats.NewCounter(StatsKeyQueryCount, "Number of queries executed")
 	ddlErrorCount = stats.NewCounter(StatsKeyErrorCount, "Number of errors during sidecar schema upgrade")
 	ddlErrorHistory = history.New(maxDDLErrorHistoryLength)
@@ -277,11 +283,72 @@ func Init(ctx context.Context, env *vtenv.Environment, exec Exec) error {
 		return err
 	}
 
+	state, err := si.readState()
+	if err != nil {
+		return err
+	}
+	switch {
+	case state.Version > LatestVersion():
+		// Upgrading the tables to the schema files of this binary would
+		// revert the changes of the newer versions, and lose their data.
+		log.Warningf("The schema of the %s sidecar database has version %d, which is newer than version %d of this binary, and is left as is",
+			sidecar.GetName(), state.Version, LatestVersion())
+		schemaVersion.Store(int64(state.Version))
+		return nil
+	case state.Pinned:
+		log.Infof("The schema of the %s sidecar database is pinned to version %d, and is left as is", sidecar.GetName(), state.Version)
+		schemaVersion.Store(int64(state.Version))
+		return nil
+	}
+
 	for _, table := range sidecarTables {
 		if err := si.ensureSchema(table); err != nil {
 			return err
 		}
 	}
+	return si.migrate(state)
+}
+
+// readState reads the state of the sidecar schema. The state table does not
+// exist if the sidecar database predates the versions.
+func (si *schemaInit) readState() (State, error) {
+	qr, err := si.exec(si.ctx, ReadStateQuery(sidecar.GetIdentifier()), 2, false)
+	if err != nil {
+		if sqlErr, ok := err.(*sqlerror.SQLError); ok && sqlErr.Number() == sqlerror.ERNoSuchTable {
+			return State{}, nil
+		}
+		return State{}, err
+	}
+	return ParseState(qr)
+}
+
+// migrate runs the up statements of the versions after the current one,
+// once the tables are upgraded, and records the latest version.
+func (si *schemaInit) migrate(state State) error {
+	if state.Version == LatestVersion() {
+		schemaVersion.Store(int64(state.Version))
+		return nil
+	}
+	for _, m := range migrations[state.Version:] {
+		for _, query := range m.up {
+			if _, err := si.exec(si.ctx, query, 1, true); err != nil {
+				err = vterrors.Wrapf(err, "Error running statement %s of version %d of the %s sidecar database schema", query, m.version, sidecar.GetName())
+				recordDDLError(m.name, err)
+				if failOnSchemaInitError {
+					return err
+				}
+				// The versions are retried at the next init.
+				schemaVersion.Store(int64(state.Version))
+				return nil
+			}
+			ddlCount.Add(1)
+		}
+	}
+	if _, err := si.exec(si.ctx, writeStateQueryFor(sidecar.GetIdentifier(), State{Version: LatestVersion()}), 1, false); err != nil {
+		return err
+	}
+	log.Infof("Upgraded the schema of the %s sidecar database from version %d to %d", sidecar.GetName(), state.Version, LatestVersion())
+	schemaVersion.Store(int64(LatestVersion()))
 	return nil
 }
 
@@ -514,6 +581,17 @@ func AddSchemaInitQueries(db *fakesqldb.DB, populateTables bool, parser *sqlpars
 			sqlparser.String(sqlparser.NewIdentifierCS(table.name))).Query, result)
 	}
 
+	result = &sqltypes.Result{}
+	if populateTables {
+		result = sqltypes.MakeTestResult(sqltypes.MakeTestFields(
+			"version|pinned",
+			"uint32|int8"),
+			fmt.Sprintf("%d|0", LatestVersion()),
+		)
+	}
+	db.AddQuery(ReadStateQuery(sidecar.GetIdentifier()), result)
+	db.AddQuery(writeStateQueryFor(sidecar.GetIdentifier(), State{Version: LatestVersion()}), &sqltypes.Result{})
+
 	sqlModeResult := sqltypes.MakeTestResult(sqltypes.MakeTestFields(
 		"sql_mode",
 		"varchar"),
@@ -543,6 +621,10 @@ func MatchesInitQuery(query string) bool {
 	if strings.EqualFold(sdbe, query) {
 		return true
 	}
+	if strings.EqualFold(ReadStateQuery(sidecar.GetIdentifier()), query) ||
+		strings.EqualFold(writeStateQueryFor(sidecar.GetIdentifier(), State{Version: LatestVersion()}), query) {
+		return true
+	}
 	for _, q := range sidecar.DBInitQueryPatterns {
 		q = strings.ToLower(q)
 		if strings.Contains(query, q) {
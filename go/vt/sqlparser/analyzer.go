@@ -86,7 +86,7 @@ func ASTToStatementType(stmt Statement) StatementType {
 		return StmtMigration
 	case *Use:
 		return StmtUse
-	case *OtherAdmin, *Load:
+	case *OtherAdmin, *Load, *ChecksumTable, *OptimizeTable:
 		return StmtOther
 	case *Analyze:
 		return StmtAnalyze
@@ -234,7 +234,7 @@ func Preview(sql string) StatementType {
 		return StmtUse
 	case "describe", "desc", "explain":
 		return StmtExplain
-	case "repair", "optimize":
+	case "repair", "optimize", "checksum":
 		return StmtOther
 	case "analyze":
 		return StmtAnalyze
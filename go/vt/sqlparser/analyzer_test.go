@@ -68,6 +68,7 @@ func TestPreview(t *testing.T) {
 		{"explain", StmtExplain},
 		{"repair", StmtOther},
 		{"optimize", StmtOther},
+		{"checksum table a", StmtOther},
 		{"grant", StmtPriv},
 		{"revoke", StmtPriv},
 		{"truncate", StmtDDL},
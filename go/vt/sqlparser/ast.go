@@ -521,12 +521,15 @@ type (
 
 	// AlterMigration represents a ALTER VITESS_MIGRATION statement
 	AlterMigration struct {
-		Type      AlterMigrationType
-		UUID      string
-		Expire    string
-		Ratio     *Literal
-		Threshold string
-		Shards    string
+		Type             AlterMigrationType
+		UUID             string
+		Expire           string
+		Ratio            *Literal
+		Threshold        string
+		Shards           string
+		MigrationContext string
+		ScheduleWindow   string
+		DependsOn        string
 	}
 
 	// CreateProcedure represents a CREATE PROCEDURE statement.
@@ -617,8 +620,39 @@ type (
 	// DDLAction is an enum for DDL.Action
 	DDLAction int8
 
-	// Load represents a LOAD statement
+	// Load represents a LOAD DATA statement. The statements which are
+	// not parsed, like LOAD DATA FROM S3, have no FileName.
 	Load struct {
+		Local       bool
+		FileName    string
+		Replace     bool
+		Ignore      Ignore
+		Table       TableName
+		Partitions  Partitions
+		Charset     ColumnCharset
+		Fields      *LoadFields
+		Lines       *LoadLines
+		IgnoreLines int
+		// Columns are the column names and user variables the fields of
+		// each line are assigned to.
+		Columns  []Expr
+		SetExprs UpdateExprs
+	}
+
+	// LoadFields represents the FIELDS clause of a LOAD DATA statement.
+	// The options which are not given are nil.
+	LoadFields struct {
+		TerminatedBy *string
+		EnclosedBy   *string
+		Optionally   bool
+		EscapedBy    *string
+	}
+
+	// LoadLines represents the LINES clause of a LOAD DATA statement.
+	// The options which are not given are nil.
+	LoadLines struct {
+		StartingBy   *string
+		TerminatedBy *string
 	}
 
 	// PurgeBinaryLogs represents a PURGE BINARY LOGS statement
@@ -743,8 +777,26 @@ type (
 
 	// Analyze represents the Analyze statement.
 	Analyze struct {
+		Comments *ParsedComments
+		IsLocal  bool
+		Table    TableName
+	}
+
+	// OptimizeTable represents the OPTIMIZE TABLE statement.
+	// More info available on https://dev.mysql.com/doc/refman/8.0/en/optimize-table.html
+	OptimizeTable struct {
 		IsLocal bool
-		Table   TableName
+		Tables  TableNames
+	}
+
+	// ChecksumTableOption is an enum for ChecksumTable.Option
+	ChecksumTableOption int8
+
+	// ChecksumTable represents the CHECKSUM TABLE statement.
+	// More info available on https://dev.mysql.com/doc/refman/8.0/en/checksum-table.html
+	ChecksumTable struct {
+		Tables TableNames
+		Option ChecksumTableOption
 	}
 
 	// OtherAdmin represents a misc statement that relies on ADMIN privileges,
@@ -912,6 +964,8 @@ func (*SRollback) iStatement()             {}
 func (*Savepoint) iStatement()             {}
 func (*Release) iStatement()               {}
 func (*Analyze) iStatement()               {}
+func (*OptimizeTable) iStatement()         {}
+func (*ChecksumTable) iStatement()         {}
 func (*OtherAdmin) iStatement()            {}
 func (*CommentOnly) iStatement()           {}
 func (*Select) iSelectStatement()          {}
@@ -1668,6 +1722,11 @@ func (node *DropProcedure) SetComments(comments Comments) {
 	node.Comments = comments.Parsed()
 }
 
+// SetComments implements Commented interface.
+func (node *Analyze) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
 // GetParsedComments implements Commented interface.
 func (node *RenameTable) GetParsedComments() *ParsedComments {
 	// irrelevant
@@ -1761,6 +1820,11 @@ func (node *DropProcedure) GetParsedComments() *ParsedComments {
 	return node.Comments
 }
 
+// GetParsedComments implements Commented interface.
+func (node *Analyze) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
 // GetToTables implements the DDLStatement interface
 func (node *RenameTable) GetToTables() TableNames {
 	var toTables TableNames
@@ -2406,6 +2470,13 @@ type (
 		As         IdentifierCS
 		Hints      IndexHints
 		Columns    Columns
+		Sample     *TableSample
+	}
+
+	// TableSample represents a TABLESAMPLE BERNOULLI clause, which reads a
+	// random sample of the given percentage of the rows of a table.
+	TableSample struct {
+		Percent Expr
 	}
 
 	// JoinTableExpr represents a TableExpr that's a JOIN operation.
@@ -3385,6 +3456,14 @@ type (
 		Arg Expr
 	}
 
+	// ApproxCountDistinct represents a call to APPROX_COUNT_DISTINCT, a Vitess
+	// aggregation function estimating the number of distinct values of its
+	// argument. It is planned as a COUNT(DISTINCT), which the planner may
+	// approximate with HyperLogLog sketches computed by the shards.
+	ApproxCountDistinct struct {
+		Arg Expr
+	}
+
 	// RegexpInstrExpr represents REGEXP_INSTR()
 	// For more information, see https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
 	RegexpInstrExpr struct {
@@ -3628,6 +3707,7 @@ func (*CountStar) IsExpr()                          {}
 func (*Count) IsExpr()                              {}
 func (*GroupConcatExpr) IsExpr()                    {}
 func (*AnyValue) IsExpr()                           {}
+func (*ApproxCountDistinct) IsExpr()                {}
 func (*BitAnd) IsExpr()                             {}
 func (*BitOr) IsExpr()                              {}
 func (*BitXor) IsExpr()                             {}
@@ -3677,6 +3757,7 @@ func (*ConvertUsingExpr) iCallable()                   {}
 func (*MatchExpr) iCallable()                          {}
 func (*GroupConcatExpr) iCallable()                    {}
 func (*AnyValue) iCallable()                           {}
+func (*ApproxCountDistinct) iCallable()                {}
 func (*JSONSchemaValidFuncExpr) iCallable()            {}
 func (*JSONSchemaValidationReportFuncExpr) iCallable() {}
 func (*JSONPrettyExpr) iCallable()                     {}
@@ -3758,6 +3839,7 @@ func (varP *VarPop) GetArg() Expr               { return varP.Arg }
 func (varS *VarSamp) GetArg() Expr              { return varS.Arg }
 func (variance *Variance) GetArg() Expr         { return variance.Arg }
 func (av *AnyValue) GetArg() Expr               { return av.Arg }
+func (acd *ApproxCountDistinct) GetArg() Expr   { return acd.Arg }
 func (jaa *JSONArrayAgg) GetArg() Expr          { return jaa.Expr }
 func (joa *JSONObjectAgg) GetArg() Expr         { return joa.Key }
 
@@ -3779,6 +3861,7 @@ func (varP *VarPop) GetArgs() []Expr               { return []Expr{varP.Arg} }
 func (varS *VarSamp) GetArgs() []Expr              { return []Expr{varS.Arg} }
 func (variance *Variance) GetArgs() []Expr         { return []Expr{variance.Arg} }
 func (av *AnyValue) GetArgs() []Expr               { return []Expr{av.Arg} }
+func (acd *ApproxCountDistinct) GetArgs() []Expr   { return []Expr{acd.Arg} }
 func (jaa *JSONArrayAgg) GetArgs() []Expr          { return []Expr{jaa.Expr} }
 func (joa *JSONObjectAgg) GetArgs() []Expr         { return []Expr{joa.Key, joa.Value} }
 
@@ -3800,6 +3883,7 @@ func (varP *VarPop) SetArg(expr Expr)               { varP.Arg = expr }
 func (varS *VarSamp) SetArg(expr Expr)              { varS.Arg = expr }
 func (variance *Variance) SetArg(expr Expr)         { variance.Arg = expr }
 func (av *AnyValue) SetArg(expr Expr)               { av.Arg = expr }
+func (acd *ApproxCountDistinct) SetArg(expr Expr)   { acd.Arg = expr }
 func (jaa *JSONArrayAgg) SetArg(expr Expr)          { jaa.Expr = expr }
 func (joa *JSONObjectAgg) SetArg(expr Expr)         { joa.Key = expr }
 
@@ -3831,6 +3915,10 @@ func (joa *JSONObjectAgg) SetArgs(exprs []Expr) error {
 	return nil
 }
 
+func (acd *ApproxCountDistinct) SetArgs(exprs []Expr) error {
+	return setFuncArgs(acd, exprs, "APPROX_COUNT_DISTINCT")
+}
+
 func (count *Count) SetArgs(exprs []Expr) error {
 	count.Args = exprs
 	return nil
@@ -3875,6 +3963,8 @@ func (*AnyValue) AggrName() string        { return "any_value" }
 func (*JSONArrayAgg) AggrName() string    { return "json_arrayagg" }
 func (*JSONObjectAgg) AggrName() string   { return "json_objectagg" }
 
+func (*ApproxCountDistinct) AggrName() string { return "approx_count_distinct" }
+
 // Exprs represents a list of value expressions.
 // It's not a valid expression because it's not parenthesized.
 type Exprs struct {
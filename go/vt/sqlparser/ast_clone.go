@@ -59,6 +59,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfAndExpr(in)
 	case *AnyValue:
 		return CloneRefOfAnyValue(in)
+	case *ApproxCountDistinct:
+		return CloneRefOfApproxCountDistinct(in)
 	case *Argument:
 		return CloneRefOfArgument(in)
 	case *ArgumentLessWindowExpr:
@@ -97,6 +99,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfCharExpr(in)
 	case *CheckConstraintDefinition:
 		return CloneRefOfCheckConstraintDefinition(in)
+	case *ChecksumTable:
+		return CloneRefOfChecksumTable(in)
 	case *ColName:
 		return CloneRefOfColName(in)
 	case *CollateExpr:
@@ -337,6 +341,10 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfLiteral(in)
 	case *Load:
 		return CloneRefOfLoad(in)
+	case *LoadFields:
+		return CloneRefOfLoadFields(in)
+	case *LoadLines:
+		return CloneRefOfLoadLines(in)
 	case *LocateExpr:
 		return CloneRefOfLocateExpr(in)
 	case *LockOption:
@@ -385,6 +393,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneOnDup(in)
 	case *OptLike:
 		return CloneRefOfOptLike(in)
+	case *OptimizeTable:
+		return CloneRefOfOptimizeTable(in)
 	case *OrExpr:
 		return CloneRefOfOrExpr(in)
 	case *Order:
@@ -535,6 +545,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneTableNames(in)
 	case TableOptions:
 		return CloneTableOptions(in)
+	case *TableSample:
+		return CloneRefOfTableSample(in)
 	case *TableSpec:
 		return CloneRefOfTableSpec(in)
 	case *TablespaceOperation:
@@ -662,6 +674,7 @@ func CloneRefOfAliasedTableExpr(n *AliasedTableExpr) *AliasedTableExpr {
 	out.As = CloneIdentifierCS(n.As)
 	out.Hints = CloneIndexHints(n.Hints)
 	out.Columns = CloneColumns(n.Columns)
+	out.Sample = CloneRefOfTableSample(n.Sample)
 	return &out
 }
 
@@ -775,6 +788,7 @@ func CloneRefOfAnalyze(n *Analyze) *Analyze {
 		return nil
 	}
 	out := *n
+	out.Comments = CloneRefOfParsedComments(n.Comments)
 	out.Table = CloneTableName(n.Table)
 	return &out
 }
@@ -800,6 +814,16 @@ func CloneRefOfAnyValue(n *AnyValue) *AnyValue {
 	return &out
 }
 
+// CloneRefOfApproxCountDistinct creates a deep clone of the input.
+func CloneRefOfApproxCountDistinct(n *ApproxCountDistinct) *ApproxCountDistinct {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Arg = CloneExpr(n.Arg)
+	return &out
+}
+
 // CloneRefOfArgument creates a deep clone of the input.
 func CloneRefOfArgument(n *Argument) *Argument {
 	if n == nil {
@@ -994,6 +1018,16 @@ func CloneRefOfCheckConstraintDefinition(n *CheckConstraintDefinition) *CheckCon
 	return &out
 }
 
+// CloneRefOfChecksumTable creates a deep clone of the input.
+func CloneRefOfChecksumTable(n *ChecksumTable) *ChecksumTable {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Tables = CloneTableNames(n.Tables)
+	return &out
+}
+
 // CloneRefOfColName creates a deep clone of the input.
 func CloneRefOfColName(n *ColName) *ColName {
 	return n
@@ -2287,6 +2321,36 @@ func CloneRefOfLoad(n *Load) *Load {
 		return nil
 	}
 	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Partitions = ClonePartitions(n.Partitions)
+	out.Charset = CloneColumnCharset(n.Charset)
+	out.Fields = CloneRefOfLoadFields(n.Fields)
+	out.Lines = CloneRefOfLoadLines(n.Lines)
+	out.Columns = CloneSliceOfExpr(n.Columns)
+	out.SetExprs = CloneUpdateExprs(n.SetExprs)
+	return &out
+}
+
+// CloneRefOfLoadFields creates a deep clone of the input.
+func CloneRefOfLoadFields(n *LoadFields) *LoadFields {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.TerminatedBy = CloneRefOfString(n.TerminatedBy)
+	out.EnclosedBy = CloneRefOfString(n.EnclosedBy)
+	out.EscapedBy = CloneRefOfString(n.EscapedBy)
+	return &out
+}
+
+// CloneRefOfLoadLines creates a deep clone of the input.
+func CloneRefOfLoadLines(n *LoadLines) *LoadLines {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.StartingBy = CloneRefOfString(n.StartingBy)
+	out.TerminatedBy = CloneRefOfString(n.TerminatedBy)
 	return &out
 }
 
@@ -2534,6 +2598,16 @@ func CloneRefOfOptLike(n *OptLike) *OptLike {
 	return &out
 }
 
+// CloneRefOfOptimizeTable creates a deep clone of the input.
+func CloneRefOfOptimizeTable(n *OptimizeTable) *OptimizeTable {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Tables = CloneTableNames(n.Tables)
+	return &out
+}
+
 // CloneRefOfOrExpr creates a deep clone of the input.
 func CloneRefOfOrExpr(n *OrExpr) *OrExpr {
 	if n == nil {
@@ -3347,6 +3421,16 @@ func CloneTableOptions(n TableOptions) TableOptions {
 	return res
 }
 
+// CloneRefOfTableSample creates a deep clone of the input.
+func CloneRefOfTableSample(n *TableSample) *TableSample {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Percent = CloneExpr(n.Percent)
+	return &out
+}
+
 // CloneRefOfTableSpec creates a deep clone of the input.
 func CloneRefOfTableSpec(n *TableSpec) *TableSpec {
 	if n == nil {
@@ -3736,6 +3820,8 @@ func CloneAggrFunc(in AggrFunc) AggrFunc {
 	switch in := in.(type) {
 	case *AnyValue:
 		return CloneRefOfAnyValue(in)
+	case *ApproxCountDistinct:
+		return CloneRefOfApproxCountDistinct(in)
 	case *Avg:
 		return CloneRefOfAvg(in)
 	case *BitAnd:
@@ -3844,6 +3930,8 @@ func CloneCallable(in Callable) Callable {
 	switch in := in.(type) {
 	case *AnyValue:
 		return CloneRefOfAnyValue(in)
+	case *ApproxCountDistinct:
+		return CloneRefOfApproxCountDistinct(in)
 	case *ArgumentLessWindowExpr:
 		return CloneRefOfArgumentLessWindowExpr(in)
 	case *Avg:
@@ -4140,6 +4228,8 @@ func CloneExpr(in Expr) Expr {
 		return CloneRefOfAndExpr(in)
 	case *AnyValue:
 		return CloneRefOfAnyValue(in)
+	case *ApproxCountDistinct:
+		return CloneRefOfApproxCountDistinct(in)
 	case *Argument:
 		return CloneRefOfArgument(in)
 	case *ArgumentLessWindowExpr:
@@ -4512,6 +4602,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfBegin(in)
 	case *CallProc:
 		return CloneRefOfCallProc(in)
+	case *ChecksumTable:
+		return CloneRefOfChecksumTable(in)
 	case *CommentOnly:
 		return CloneRefOfCommentOnly(in)
 	case *Commit:
@@ -4552,6 +4644,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfLoad(in)
 	case *LockTables:
 		return CloneRefOfLockTables(in)
+	case *OptimizeTable:
+		return CloneRefOfOptimizeTable(in)
 	case *OtherAdmin:
 		return CloneRefOfOtherAdmin(in)
 	case *PrepareStmt:
@@ -4951,6 +5045,15 @@ func CloneRefOfJtNestedPathColDef(n *JtNestedPathColDef) *JtNestedPathColDef {
 	return &out
 }
 
+// CloneRefOfString creates a deep clone of the input.
+func CloneRefOfString(n *string) *string {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	return &out
+}
+
 // CloneTableAndLockTypes creates a deep clone of the input.
 func CloneTableAndLockTypes(n TableAndLockTypes) TableAndLockTypes {
 	if n == nil {
@@ -58,6 +58,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfAndExpr(n, parent)
 	case *AnyValue:
 		return c.copyOnRewriteRefOfAnyValue(n, parent)
+	case *ApproxCountDistinct:
+		return c.copyOnRewriteRefOfApproxCountDistinct(n, parent)
 	case *Argument:
 		return c.copyOnRewriteRefOfArgument(n, parent)
 	case *ArgumentLessWindowExpr:
@@ -96,6 +98,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfCharExpr(n, parent)
 	case *CheckConstraintDefinition:
 		return c.copyOnRewriteRefOfCheckConstraintDefinition(n, parent)
+	case *ChecksumTable:
+		return c.copyOnRewriteRefOfChecksumTable(n, parent)
 	case *ColName:
 		return c.copyOnRewriteRefOfColName(n, parent)
 	case *CollateExpr:
@@ -336,6 +340,10 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfLiteral(n, parent)
 	case *Load:
 		return c.copyOnRewriteRefOfLoad(n, parent)
+	case *LoadFields:
+		return c.copyOnRewriteRefOfLoadFields(n, parent)
+	case *LoadLines:
+		return c.copyOnRewriteRefOfLoadLines(n, parent)
 	case *LocateExpr:
 		return c.copyOnRewriteRefOfLocateExpr(n, parent)
 	case *LockOption:
@@ -384,6 +392,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteOnDup(n, parent)
 	case *OptLike:
 		return c.copyOnRewriteRefOfOptLike(n, parent)
+	case *OptimizeTable:
+		return c.copyOnRewriteRefOfOptimizeTable(n, parent)
 	case *OrExpr:
 		return c.copyOnRewriteRefOfOrExpr(n, parent)
 	case *Order:
@@ -534,6 +544,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteTableNames(n, parent)
 	case TableOptions:
 		return c.copyOnRewriteTableOptions(n, parent)
+	case *TableSample:
+		return c.copyOnRewriteRefOfTableSample(n, parent)
 	case *TableSpec:
 		return c.copyOnRewriteRefOfTableSpec(n, parent)
 	case *TablespaceOperation:
@@ -720,13 +732,15 @@ func (c *cow) copyOnRewriteRefOfAliasedTableExpr(n *AliasedTableExpr, parent SQL
 		_As, changedAs := c.copyOnRewriteIdentifierCS(n.As, n)
 		_Hints, changedHints := c.copyOnRewriteIndexHints(n.Hints, n)
 		_Columns, changedColumns := c.copyOnRewriteColumns(n.Columns, n)
-		if changedExpr || changedPartitions || changedAs || changedHints || changedColumns {
+		_Sample, changedSample := c.copyOnRewriteRefOfTableSample(n.Sample, n)
+		if changedExpr || changedPartitions || changedAs || changedHints || changedColumns || changedSample {
 			res := *n
 			res.Expr, _ = _Expr.(SimpleTableExpr)
 			res.Partitions, _ = _Partitions.(Partitions)
 			res.As, _ = _As.(IdentifierCS)
 			res.Hints, _ = _Hints.(IndexHints)
 			res.Columns, _ = _Columns.(Columns)
+			res.Sample, _ = _Sample.(*TableSample)
 			out = &res
 			if c.cloned != nil {
 				c.cloned(n, out)
@@ -975,9 +989,11 @@ func (c *cow) copyOnRewriteRefOfAnalyze(n *Analyze, parent SQLNode) (out SQLNode
 	}
 	out = n
 	if c.pre == nil || c.pre(n, parent) {
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
 		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
-		if changedTable {
+		if changedComments || changedTable {
 			res := *n
+			res.Comments, _ = _Comments.(*ParsedComments)
 			res.Table, _ = _Table.(TableName)
 			out = &res
 			if c.cloned != nil {
@@ -1037,6 +1053,28 @@ func (c *cow) copyOnRewriteRefOfAnyValue(n *AnyValue, parent SQLNode) (out SQLNo
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfApproxCountDistinct(n *ApproxCountDistinct, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Arg, changedArg := c.copyOnRewriteExpr(n.Arg, n)
+		if changedArg {
+			res := *n
+			res.Arg, _ = _Arg.(Expr)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfArgument(n *Argument, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -1467,6 +1505,28 @@ func (c *cow) copyOnRewriteRefOfCheckConstraintDefinition(n *CheckConstraintDefi
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfChecksumTable(n *ChecksumTable, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Tables, changedTables := c.copyOnRewriteTableNames(n.Tables, n)
+		if changedTables {
+			res := *n
+			res.Tables, _ = _Tables.(TableNames)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfColName(n *ColName, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -4318,6 +4378,58 @@ func (c *cow) copyOnRewriteRefOfLiteral(n *Literal, parent SQLNode) (out SQLNode
 	return
 }
 func (c *cow) copyOnRewriteRefOfLoad(n *Load, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Partitions, changedPartitions := c.copyOnRewritePartitions(n.Partitions, n)
+		_Fields, changedFields := c.copyOnRewriteRefOfLoadFields(n.Fields, n)
+		_Lines, changedLines := c.copyOnRewriteRefOfLoadLines(n.Lines, n)
+		var changedColumns bool
+		_Columns := make([]Expr, len(n.Columns))
+		for x, el := range n.Columns {
+			this, changed := c.copyOnRewriteExpr(el, n)
+			_Columns[x] = this.(Expr)
+			if changed {
+				changedColumns = true
+			}
+		}
+		_SetExprs, changedSetExprs := c.copyOnRewriteUpdateExprs(n.SetExprs, n)
+		if changedTable || changedPartitions || changedFields || changedLines || changedColumns || changedSetExprs {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.Partitions, _ = _Partitions.(Partitions)
+			res.Fields, _ = _Fields.(*LoadFields)
+			res.Lines, _ = _Lines.(*LoadLines)
+			res.Columns = _Columns
+			res.SetExprs, _ = _SetExprs.(UpdateExprs)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfLoadFields(n *LoadFields, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfLoadLines(n *LoadLines, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
 	}
@@ -4855,6 +4967,28 @@ func (c *cow) copyOnRewriteRefOfOptLike(n *OptLike, parent SQLNode) (out SQLNode
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfOptimizeTable(n *OptimizeTable, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Tables, changedTables := c.copyOnRewriteTableNames(n.Tables, n)
+		if changedTables {
+			res := *n
+			res.Tables, _ = _Tables.(TableNames)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfOrExpr(n *OrExpr, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -6576,6 +6710,28 @@ func (c *cow) copyOnRewriteTableOptions(n TableOptions, parent SQLNode) (out SQL
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfTableSample(n *TableSample, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Percent, changedPercent := c.copyOnRewriteExpr(n.Percent, n)
+		if changedPercent {
+			res := *n
+			res.Percent, _ = _Percent.(Expr)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfTableSpec(n *TableSpec, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -7442,6 +7598,8 @@ func (c *cow) copyOnRewriteAggrFunc(n AggrFunc, parent SQLNode) (out SQLNode, ch
 	switch n := n.(type) {
 	case *AnyValue:
 		return c.copyOnRewriteRefOfAnyValue(n, parent)
+	case *ApproxCountDistinct:
+		return c.copyOnRewriteRefOfApproxCountDistinct(n, parent)
 	case *Avg:
 		return c.copyOnRewriteRefOfAvg(n, parent)
 	case *BitAnd:
@@ -7550,6 +7708,8 @@ func (c *cow) copyOnRewriteCallable(n Callable, parent SQLNode) (out SQLNode, ch
 	switch n := n.(type) {
 	case *AnyValue:
 		return c.copyOnRewriteRefOfAnyValue(n, parent)
+	case *ApproxCountDistinct:
+		return c.copyOnRewriteRefOfApproxCountDistinct(n, parent)
 	case *ArgumentLessWindowExpr:
 		return c.copyOnRewriteRefOfArgumentLessWindowExpr(n, parent)
 	case *Avg:
@@ -7846,6 +8006,8 @@ func (c *cow) copyOnRewriteExpr(n Expr, parent SQLNode) (out SQLNode, changed bo
 		return c.copyOnRewriteRefOfAndExpr(n, parent)
 	case *AnyValue:
 		return c.copyOnRewriteRefOfAnyValue(n, parent)
+	case *ApproxCountDistinct:
+		return c.copyOnRewriteRefOfApproxCountDistinct(n, parent)
 	case *Argument:
 		return c.copyOnRewriteRefOfArgument(n, parent)
 	case *ArgumentLessWindowExpr:
@@ -8218,6 +8380,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfBegin(n, parent)
 	case *CallProc:
 		return c.copyOnRewriteRefOfCallProc(n, parent)
+	case *ChecksumTable:
+		return c.copyOnRewriteRefOfChecksumTable(n, parent)
 	case *CommentOnly:
 		return c.copyOnRewriteRefOfCommentOnly(n, parent)
 	case *Commit:
@@ -8258,6 +8422,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfLoad(n, parent)
 	case *LockTables:
 		return c.copyOnRewriteRefOfLockTables(n, parent)
+	case *OptimizeTable:
+		return c.copyOnRewriteRefOfOptimizeTable(n, parent)
 	case *OtherAdmin:
 		return c.copyOnRewriteRefOfOtherAdmin(n, parent)
 	case *PrepareStmt:
@@ -134,6 +134,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfAnyValue(a, b)
+	case *ApproxCountDistinct:
+		b, ok := inB.(*ApproxCountDistinct)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfApproxCountDistinct(a, b)
 	case *Argument:
 		b, ok := inB.(*Argument)
 		if !ok {
@@ -248,6 +254,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfCheckConstraintDefinition(a, b)
+	case *ChecksumTable:
+		b, ok := inB.(*ChecksumTable)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfChecksumTable(a, b)
 	case *ColName:
 		b, ok := inB.(*ColName)
 		if !ok {
@@ -968,6 +980,18 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfLoad(a, b)
+	case *LoadFields:
+		b, ok := inB.(*LoadFields)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfLoadFields(a, b)
+	case *LoadLines:
+		b, ok := inB.(*LoadLines)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfLoadLines(a, b)
 	case *LocateExpr:
 		b, ok := inB.(*LocateExpr)
 		if !ok {
@@ -1112,6 +1136,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfOptLike(a, b)
+	case *OptimizeTable:
+		b, ok := inB.(*OptimizeTable)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfOptimizeTable(a, b)
 	case *OrExpr:
 		b, ok := inB.(*OrExpr)
 		if !ok {
@@ -1562,6 +1592,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.TableOptions(a, b)
+	case *TableSample:
+		b, ok := inB.(*TableSample)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfTableSample(a, b)
 	case *TableSpec:
 		b, ok := inB.(*TableSpec)
 		if !ok {
@@ -1831,7 +1867,8 @@ func (cmp *Comparator) RefOfAliasedTableExpr(a, b *AliasedTableExpr) bool {
 		cmp.Partitions(a.Partitions, b.Partitions) &&
 		cmp.IdentifierCS(a.As, b.As) &&
 		cmp.IndexHints(a.Hints, b.Hints) &&
-		cmp.Columns(a.Columns, b.Columns)
+		cmp.Columns(a.Columns, b.Columns) &&
+		cmp.RefOfTableSample(a.Sample, b.Sample)
 }
 
 // RefOfAlterCharset does deep equals between the two objects.
@@ -1912,6 +1949,9 @@ func (cmp *Comparator) RefOfAlterMigration(a, b *AlterMigration) bool {
 		a.Expire == b.Expire &&
 		a.Threshold == b.Threshold &&
 		a.Shards == b.Shards &&
+		a.MigrationContext == b.MigrationContext &&
+		a.ScheduleWindow == b.ScheduleWindow &&
+		a.DependsOn == b.DependsOn &&
 		a.Type == b.Type &&
 		cmp.RefOfLiteral(a.Ratio, b.Ratio)
 }
@@ -1974,6 +2014,7 @@ func (cmp *Comparator) RefOfAnalyze(a, b *Analyze) bool {
 		return false
 	}
 	return a.IsLocal == b.IsLocal &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
 		cmp.TableName(a.Table, b.Table)
 }
 
@@ -2000,6 +2041,17 @@ func (cmp *Comparator) RefOfAnyValue(a, b *AnyValue) bool {
 	return cmp.Expr(a.Arg, b.Arg)
 }
 
+// RefOfApproxCountDistinct does deep equals between the two objects.
+func (cmp *Comparator) RefOfApproxCountDistinct(a, b *ApproxCountDistinct) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.Expr(a.Arg, b.Arg)
+}
+
 // RefOfArgument does deep equals between the two objects.
 func (cmp *Comparator) RefOfArgument(a, b *Argument) bool {
 	if a == b {
@@ -2224,6 +2276,18 @@ func (cmp *Comparator) RefOfCheckConstraintDefinition(a, b *CheckConstraintDefin
 		cmp.Expr(a.Expr, b.Expr)
 }
 
+// RefOfChecksumTable does deep equals between the two objects.
+func (cmp *Comparator) RefOfChecksumTable(a, b *ChecksumTable) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.TableNames(a.Tables, b.Tables) &&
+		a.Option == b.Option
+}
+
 // RefOfColName does deep equals between the two objects.
 func (cmp *Comparator) RefOfColName(a, b *ColName) bool {
 	if a == b {
@@ -3725,7 +3789,44 @@ func (cmp *Comparator) RefOfLoad(a, b *Load) bool {
 	if a == nil || b == nil {
 		return false
 	}
-	return true
+	return a.Local == b.Local &&
+		a.FileName == b.FileName &&
+		a.Replace == b.Replace &&
+		a.IgnoreLines == b.IgnoreLines &&
+		a.Ignore == b.Ignore &&
+		cmp.TableName(a.Table, b.Table) &&
+		cmp.Partitions(a.Partitions, b.Partitions) &&
+		cmp.ColumnCharset(a.Charset, b.Charset) &&
+		cmp.RefOfLoadFields(a.Fields, b.Fields) &&
+		cmp.RefOfLoadLines(a.Lines, b.Lines) &&
+		cmp.SliceOfExpr(a.Columns, b.Columns) &&
+		cmp.UpdateExprs(a.SetExprs, b.SetExprs)
+}
+
+// RefOfLoadFields does deep equals between the two objects.
+func (cmp *Comparator) RefOfLoadFields(a, b *LoadFields) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Optionally == b.Optionally &&
+		cmp.RefOfString(a.TerminatedBy, b.TerminatedBy) &&
+		cmp.RefOfString(a.EnclosedBy, b.EnclosedBy) &&
+		cmp.RefOfString(a.EscapedBy, b.EscapedBy)
+}
+
+// RefOfLoadLines does deep equals between the two objects.
+func (cmp *Comparator) RefOfLoadLines(a, b *LoadLines) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.RefOfString(a.StartingBy, b.StartingBy) &&
+		cmp.RefOfString(a.TerminatedBy, b.TerminatedBy)
 }
 
 // RefOfLocateExpr does deep equals between the two objects.
@@ -4004,6 +4105,18 @@ func (cmp *Comparator) RefOfOptLike(a, b *OptLike) bool {
 	return cmp.TableName(a.LikeTable, b.LikeTable)
 }
 
+// RefOfOptimizeTable does deep equals between the two objects.
+func (cmp *Comparator) RefOfOptimizeTable(a, b *OptimizeTable) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IsLocal == b.IsLocal &&
+		cmp.TableNames(a.Tables, b.Tables)
+}
+
 // RefOfOrExpr does deep equals between the two objects.
 func (cmp *Comparator) RefOfOrExpr(a, b *OrExpr) bool {
 	if a == b {
@@ -4944,6 +5057,17 @@ func (cmp *Comparator) TableOptions(a, b TableOptions) bool {
 	return true
 }
 
+// RefOfTableSample does deep equals between the two objects.
+func (cmp *Comparator) RefOfTableSample(a, b *TableSample) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.Expr(a.Percent, b.Percent)
+}
+
 // RefOfTableSpec does deep equals between the two objects.
 func (cmp *Comparator) RefOfTableSpec(a, b *TableSpec) bool {
 	if a == b {
@@ -5389,6 +5513,12 @@ func (cmp *Comparator) AggrFunc(inA, inB AggrFunc) bool {
 			return false
 		}
 		return cmp.RefOfAnyValue(a, b)
+	case *ApproxCountDistinct:
+		b, ok := inB.(*ApproxCountDistinct)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfApproxCountDistinct(a, b)
 	case *Avg:
 		b, ok := inB.(*Avg)
 		if !ok {
@@ -5671,6 +5801,12 @@ func (cmp *Comparator) Callable(inA, inB Callable) bool {
 			return false
 		}
 		return cmp.RefOfAnyValue(a, b)
+	case *ApproxCountDistinct:
+		b, ok := inB.(*ApproxCountDistinct)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfApproxCountDistinct(a, b)
 	case *ArgumentLessWindowExpr:
 		b, ok := inB.(*ArgumentLessWindowExpr)
 		if !ok {
@@ -6412,6 +6548,12 @@ func (cmp *Comparator) Expr(inA, inB Expr) bool {
 			return false
 		}
 		return cmp.RefOfAnyValue(a, b)
+	case *ApproxCountDistinct:
+		b, ok := inB.(*ApproxCountDistinct)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfApproxCountDistinct(a, b)
 	case *Argument:
 		b, ok := inB.(*Argument)
 		if !ok {
@@ -7381,6 +7523,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfCallProc(a, b)
+	case *ChecksumTable:
+		b, ok := inB.(*ChecksumTable)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfChecksumTable(a, b)
 	case *CommentOnly:
 		b, ok := inB.(*CommentOnly)
 		if !ok {
@@ -7501,6 +7649,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfLockTables(a, b)
+	case *OptimizeTable:
+		b, ok := inB.(*OptimizeTable)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfOptimizeTable(a, b)
 	case *OtherAdmin:
 		b, ok := inB.(*OtherAdmin)
 		if !ok {
@@ -8073,6 +8227,17 @@ func (cmp *Comparator) RefOfJtNestedPathColDef(a, b *JtNestedPathColDef) bool {
 		cmp.SliceOfRefOfJtColumnDefinition(a.Columns, b.Columns)
 }
 
+// RefOfString does deep equals between the two objects.
+func (cmp *Comparator) RefOfString(a, b *string) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
 // TableAndLockTypes does deep equals between the two objects.
 func (cmp *Comparator) TableAndLockTypes(a, b TableAndLockTypes) bool {
 	if len(a) != len(b) {
@@ -348,11 +348,25 @@ func (node *AlterMigration) Format(buf *TrackedBuffer) {
 		alterType = "force_cutover all"
 	case SetCutOverThresholdMigrationType:
 		alterType = "cutover_threshold"
+	case SetScheduleWindowMigrationType:
+		alterType = "schedule window"
+	case SetDependencyMigrationType:
+		alterType = "depends on"
 	}
 	buf.astPrintf(node, " %#s", alterType)
+	if node.MigrationContext != "" {
+		buf.astPrintf(node, " context '%#s'", node.MigrationContext)
+	}
 	if node.Threshold != "" {
 		buf.astPrintf(node, " '%#s'", node.Threshold)
 	}
+	switch node.Type {
+	case SetScheduleWindowMigrationType:
+		// An empty window is meaningful: it clears the schedule window
+		buf.astPrintf(node, " '%#s'", node.ScheduleWindow)
+	case SetDependencyMigrationType:
+		buf.astPrintf(node, " '%#s'", node.DependsOn)
+	}
 	if node.Expire != "" {
 		buf.astPrintf(node, " expire '%#s'", node.Expire)
 	}
@@ -1282,13 +1296,30 @@ func (node *CallProc) Format(buf *TrackedBuffer) {
 
 // Format formats the node.
 func (node *Analyze) Format(buf *TrackedBuffer) {
-	buf.literal("analyze ")
+	buf.astPrintf(node, "analyze %v", node.Comments)
 	if node.IsLocal {
 		buf.literal("local ")
 	}
 	buf.astPrintf(node, "table %v", node.Table)
 }
 
+// Format formats the node.
+func (node *OptimizeTable) Format(buf *TrackedBuffer) {
+	buf.literal("optimize ")
+	if node.IsLocal {
+		buf.literal("local ")
+	}
+	buf.astPrintf(node, "table %v", node.Tables)
+}
+
+// Format formats the node.
+func (node *ChecksumTable) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "checksum table %v", node.Tables)
+	if node.Option != NoChecksumTableOption {
+		buf.astPrintf(node, " %s", node.Option.ToString())
+	}
+}
+
 // Format formats the node.
 func (node *OtherAdmin) Format(buf *TrackedBuffer) {
 	buf.literal("otheradmin")
@@ -1383,6 +1414,14 @@ func (node *AliasedTableExpr) Format(buf *TrackedBuffer) {
 		// Hint node provides the space padding.
 		buf.astPrintf(node, "%v", node.Hints)
 	}
+	if node.Sample != nil {
+		buf.astPrintf(node, " %v", node.Sample)
+	}
+}
+
+// Format formats the node.
+func (node *TableSample) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "tablesample bernoulli (%v)", node.Percent)
 }
 
 // Format formats the node.
@@ -2314,7 +2353,71 @@ func (node IdentifierCS) Format(buf *TrackedBuffer) {
 
 // Format formats the node.
 func (node *Load) Format(buf *TrackedBuffer) {
-	buf.literal("AST node missing for Load type")
+	if node.FileName == "" {
+		buf.literal("AST node missing for Load type")
+		return
+	}
+	buf.literal("load data ")
+	if node.Local {
+		buf.literal("local ")
+	}
+	buf.astPrintf(node, "infile %#s ", encodeSQLString(node.FileName))
+	if node.Replace {
+		buf.literal("replace ")
+	}
+	buf.astPrintf(node, "%sinto table %v%v", node.Ignore.ToString(), node.Table, node.Partitions)
+	if node.Charset.Name != "" {
+		buf.astPrintf(node, " character set %#s", node.Charset.Name)
+	}
+	buf.astPrintf(node, "%v%v", node.Fields, node.Lines)
+	if node.IgnoreLines > 0 {
+		buf.astPrintf(node, " ignore %d lines", node.IgnoreLines)
+	}
+	if len(node.Columns) > 0 {
+		prefix := " ("
+		for _, col := range node.Columns {
+			buf.astPrintf(node, "%s%v", prefix, col)
+			prefix = ", "
+		}
+		buf.WriteByte(')')
+	}
+	if len(node.SetExprs) > 0 {
+		buf.astPrintf(node, " set %v", node.SetExprs)
+	}
+}
+
+// Format formats the node.
+func (node *LoadFields) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.literal(" fields")
+	if node.TerminatedBy != nil {
+		buf.astPrintf(node, " terminated by %#s", encodeSQLString(*node.TerminatedBy))
+	}
+	if node.EnclosedBy != nil {
+		if node.Optionally {
+			buf.literal(" optionally")
+		}
+		buf.astPrintf(node, " enclosed by %#s", encodeSQLString(*node.EnclosedBy))
+	}
+	if node.EscapedBy != nil {
+		buf.astPrintf(node, " escaped by %#s", encodeSQLString(*node.EscapedBy))
+	}
+}
+
+// Format formats the node.
+func (node *LoadLines) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.literal(" lines")
+	if node.StartingBy != nil {
+		buf.astPrintf(node, " starting by %#s", encodeSQLString(*node.StartingBy))
+	}
+	if node.TerminatedBy != nil {
+		buf.astPrintf(node, " terminated by %#s", encodeSQLString(*node.TerminatedBy))
+	}
 }
 
 // Format formats the node.
@@ -2389,7 +2492,14 @@ func (node *CreateDatabase) Format(buf *TrackedBuffer) {
 			}
 			buf.literal(createOption.Type.ToString())
 			buf.WriteByte(' ')
-			buf.literal(createOption.Value)
+			switch createOption.Type {
+			case BaseKeyspaceType:
+				buf.astPrintf(node, "%v", NewIdentifierCS(createOption.Value))
+			case RecoveryTimeType:
+				buf.literal(encodeSQLString(createOption.Value))
+			default:
+				buf.literal(createOption.Value)
+			}
 		}
 	}
 }
@@ -3044,6 +3154,10 @@ func (node *AnyValue) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "any_value(%v)", node.Arg)
 }
 
+func (node *ApproxCountDistinct) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "approx_count_distinct(%v)", node.Arg)
+}
+
 func (node *Avg) Format(buf *TrackedBuffer) {
 	buf.WriteString("avg(")
 	if node.Distinct {
@@ -478,14 +478,34 @@ func (node *AlterMigration) FormatFast(buf *TrackedBuffer) {
 		alterType = "force_cutover all"
 	case SetCutOverThresholdMigrationType:
 		alterType = "cutover_threshold"
+	case SetScheduleWindowMigrationType:
+		alterType = "schedule window"
+	case SetDependencyMigrationType:
+		alterType = "depends on"
 	}
 	buf.WriteByte(' ')
 	buf.WriteString(alterType)
+	if node.MigrationContext != "" {
+		buf.WriteString(" context '")
+		buf.WriteString(node.MigrationContext)
+		buf.WriteByte('\'')
+	}
 	if node.Threshold != "" {
 		buf.WriteString(" '")
 		buf.WriteString(node.Threshold)
 		buf.WriteByte('\'')
 	}
+	switch node.Type {
+	case SetScheduleWindowMigrationType:
+		// An empty window is meaningful: it clears the schedule window
+		buf.WriteString(" '")
+		buf.WriteString(node.ScheduleWindow)
+		buf.WriteByte('\'')
+	case SetDependencyMigrationType:
+		buf.WriteString(" '")
+		buf.WriteString(node.DependsOn)
+		buf.WriteByte('\'')
+	}
 	if node.Expire != "" {
 		buf.WriteString(" expire '")
 		buf.WriteString(node.Expire)
@@ -1686,6 +1706,7 @@ func (node *CallProc) FormatFast(buf *TrackedBuffer) {
 // FormatFast formats the node.
 func (node *Analyze) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("analyze ")
+	node.Comments.FormatFast(buf)
 	if node.IsLocal {
 		buf.WriteString("local ")
 	}
@@ -1693,6 +1714,26 @@ func (node *Analyze) FormatFast(buf *TrackedBuffer) {
 	node.Table.FormatFast(buf)
 }
 
+// FormatFast formats the node.
+func (node *OptimizeTable) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("optimize ")
+	if node.IsLocal {
+		buf.WriteString("local ")
+	}
+	buf.WriteString("table ")
+	node.Tables.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *ChecksumTable) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("checksum table ")
+	node.Tables.FormatFast(buf)
+	if node.Option != NoChecksumTableOption {
+		buf.WriteByte(' ')
+		buf.WriteString(node.Option.ToString())
+	}
+}
+
 // FormatFast formats the node.
 func (node *OtherAdmin) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("otheradmin")
@@ -1798,6 +1839,17 @@ func (node *AliasedTableExpr) FormatFast(buf *TrackedBuffer) {
 		// Hint node provides the space padding.
 		node.Hints.FormatFast(buf)
 	}
+	if node.Sample != nil {
+		buf.WriteByte(' ')
+		node.Sample.FormatFast(buf)
+	}
+}
+
+// FormatFast formats the node.
+func (node *TableSample) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("tablesample bernoulli (")
+	node.Percent.FormatFast(buf)
+	buf.WriteByte(')')
 }
 
 // FormatFast formats the node.
@@ -3039,7 +3091,87 @@ func (node IdentifierCS) FormatFast(buf *TrackedBuffer) {
 
 // FormatFast formats the node.
 func (node *Load) FormatFast(buf *TrackedBuffer) {
-	buf.WriteString("AST node missing for Load type")
+	if node.FileName == "" {
+		buf.WriteString("AST node missing for Load type")
+		return
+	}
+	buf.WriteString("load data ")
+	if node.Local {
+		buf.WriteString("local ")
+	}
+	buf.WriteString("infile ")
+	buf.WriteString(encodeSQLString(node.FileName))
+	buf.WriteByte(' ')
+	if node.Replace {
+		buf.WriteString("replace ")
+	}
+	buf.WriteString(node.Ignore.ToString())
+	buf.WriteString("into table ")
+	node.Table.FormatFast(buf)
+	node.Partitions.FormatFast(buf)
+	if node.Charset.Name != "" {
+		buf.WriteString(" character set ")
+		buf.WriteString(node.Charset.Name)
+	}
+	node.Fields.FormatFast(buf)
+	node.Lines.FormatFast(buf)
+	if node.IgnoreLines > 0 {
+		buf.WriteString(" ignore ")
+		buf.WriteString(fmt.Sprintf("%d", node.IgnoreLines))
+		buf.WriteString(" lines")
+	}
+	if len(node.Columns) > 0 {
+		prefix := " ("
+		for _, col := range node.Columns {
+			buf.WriteString(prefix)
+			col.FormatFast(buf)
+			prefix = ", "
+		}
+		buf.WriteByte(')')
+	}
+	if len(node.SetExprs) > 0 {
+		buf.WriteString(" set ")
+		node.SetExprs.FormatFast(buf)
+	}
+}
+
+// FormatFast formats the node.
+func (node *LoadFields) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" fields")
+	if node.TerminatedBy != nil {
+		buf.WriteString(" terminated by ")
+		buf.WriteString(encodeSQLString(*node.TerminatedBy))
+	}
+	if node.EnclosedBy != nil {
+		if node.Optionally {
+			buf.WriteString(" optionally")
+		}
+		buf.WriteString(" enclosed by ")
+		buf.WriteString(encodeSQLString(*node.EnclosedBy))
+	}
+	if node.EscapedBy != nil {
+		buf.WriteString(" escaped by ")
+		buf.WriteString(encodeSQLString(*node.EscapedBy))
+	}
+}
+
+// FormatFast formats the node.
+func (node *LoadLines) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" lines")
+	if node.StartingBy != nil {
+		buf.WriteString(" starting by ")
+		buf.WriteString(encodeSQLString(*node.StartingBy))
+	}
+	if node.TerminatedBy != nil {
+		buf.WriteString(" terminated by ")
+		buf.WriteString(encodeSQLString(*node.TerminatedBy))
+	}
 }
 
 // FormatFast formats the node.
@@ -3131,7 +3263,14 @@ func (node *CreateDatabase) FormatFast(buf *TrackedBuffer) {
 			}
 			buf.WriteString(createOption.Type.ToString())
 			buf.WriteByte(' ')
-			buf.WriteString(createOption.Value)
+			switch createOption.Type {
+			case BaseKeyspaceType:
+				NewIdentifierCS(createOption.Value).FormatFast(buf)
+			case RecoveryTimeType:
+				buf.WriteString(encodeSQLString(createOption.Value))
+			default:
+				buf.WriteString(createOption.Value)
+			}
 		}
 	}
 }
@@ -3990,6 +4129,12 @@ func (node *AnyValue) FormatFast(buf *TrackedBuffer) {
 	buf.WriteByte(')')
 }
 
+func (node *ApproxCountDistinct) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("approx_count_distinct(")
+	buf.printExpr(node, node.Arg, true)
+	buf.WriteByte(')')
+}
+
 func (node *Avg) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("avg(")
 	if node.Distinct {
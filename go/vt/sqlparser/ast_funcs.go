@@ -1460,6 +1460,8 @@ func (action DDLAction) ToString() string {
 		return TruncateStr
 	case CreateProcedureAction:
 		return CreateProcStr
+	case AnalyzeDDLAction:
+		return AnalyzeStr
 	case CreateVindexDDLAction:
 		return CreateVindexStr
 	case DropVindexDDLAction:
@@ -2134,6 +2136,20 @@ func (ty ExplainType) ToString() string {
 	}
 }
 
+// ToString returns the option as a string
+func (option ChecksumTableOption) ToString() string {
+	switch option {
+	case NoChecksumTableOption:
+		return EmptyStr
+	case QuickChecksumTableOption:
+		return QuickStr
+	case ExtendedChecksumTableOption:
+		return ExtendedStr
+	default:
+		return "Unknown ChecksumTableOption"
+	}
+}
+
 // ToString returns the type as a string
 func (ty VExplainType) ToString() string {
 	switch ty {
@@ -2175,6 +2191,10 @@ func (node DatabaseOptionType) ToString() string {
 		return CollateStr
 	case EncryptionType:
 		return EncryptionStr
+	case BaseKeyspaceType:
+		return BaseKeyspaceStr
+	case RecoveryTimeType:
+		return RecoveryTimeStr
 	default:
 		return "Unknown DatabaseOptionType Type"
 	}
@@ -2249,6 +2269,8 @@ func (ty ShowCommandType) ToString() string {
 		return FunctionStr
 	case GtidExecGlobal:
 		return GtidExecGlobalStr
+	case Grants:
+		return GrantsStr
 	case Index:
 		return IndexStr
 	case OpenTable:
@@ -2279,8 +2301,16 @@ func (ty ShowCommandType) ToString() string {
 		return VGtidExecGlobalStr
 	case VitessMigrations:
 		return VitessMigrationsStr
+	case VitessPlannerFlags:
+		return VitessPlannerFlagsStr
+	case VitessProcesslist:
+		return VitessProcesslistStr
+	case VitessQueryDigests:
+		return VitessQueryDigestsStr
 	case VitessReplicationStatus:
 		return VitessReplicationStatusStr
+	case VitessResultSizes:
+		return VitessResultSizesStr
 	case VitessShards:
 		return VitessShardsStr
 	case VitessTablets:
@@ -2289,6 +2319,8 @@ func (ty ShowCommandType) ToString() string {
 		return VitessTargetStr
 	case VitessVariables:
 		return VitessVariablesStr
+	case VitessVindexAdvice:
+		return VitessVindexAdviceStr
 	case VschemaTables:
 		return VschemaTablesStr
 	case VschemaKeyspaces:
@@ -31,6 +31,7 @@ const (
 	RefOfAliasedTableExprAs
 	RefOfAliasedTableExprHints
 	RefOfAliasedTableExprColumns
+	RefOfAliasedTableExprSample
 	RefOfAlterCheckName
 	RefOfAlterColumnColumn
 	RefOfAlterColumnDefaultVal
@@ -52,10 +53,12 @@ const (
 	RefOfAlterVschemaVindexSpec
 	RefOfAlterVschemaVindexColsOffset
 	RefOfAlterVschemaAutoIncSpec
+	RefOfAnalyzeComments
 	RefOfAnalyzeTable
 	RefOfAndExprLeft
 	RefOfAndExprRight
 	RefOfAnyValueArg
+	RefOfApproxCountDistinctArg
 	RefOfArgumentLessWindowExprOverClause
 	RefOfAssignmentExprLeft
 	RefOfAssignmentExprRight
@@ -87,6 +90,7 @@ const (
 	RefOfChangeColumnAfter
 	RefOfCharExprExprsOffset
 	RefOfCheckConstraintDefinitionExpr
+	RefOfChecksumTableTables
 	RefOfColNameName
 	RefOfColNameQualifier
 	RefOfCollateExprExpr
@@ -306,6 +310,12 @@ const (
 	RefOfLineStringExprPointParamsOffset
 	RefOfLinestrPropertyFuncExprLinestring
 	RefOfLinestrPropertyFuncExprPropertyDefArg
+	RefOfLoadTable
+	RefOfLoadPartitions
+	RefOfLoadFields
+	RefOfLoadLines
+	RefOfLoadColumnsOffset
+	RefOfLoadSetExprs
 	RefOfLocateExprSubStr
 	RefOfLocateExprStr
 	RefOfLocateExprPos
@@ -338,6 +348,7 @@ const (
 	RefOfOffsetOriginal
 	OnDupOffset
 	RefOfOptLikeLikeTable
+	RefOfOptimizeTableTables
 	RefOfOrExprLeft
 	RefOfOrExprRight
 	RefOfOrderExpr
@@ -474,6 +485,7 @@ const (
 	TableNameName
 	TableNameQualifier
 	TableNamesOffset
+	RefOfTableSamplePercent
 	RefOfTableSpecColumnsOffset
 	RefOfTableSpecIndexesOffset
 	RefOfTableSpecConstraintsOffset
@@ -623,6 +635,8 @@ func (s ASTStep) DebugString() string {
 		return "(*AliasedTableExpr).Hints"
 	case RefOfAliasedTableExprColumns:
 		return "(*AliasedTableExpr).Columns"
+	case RefOfAliasedTableExprSample:
+		return "(*AliasedTableExpr).Sample"
 	case RefOfAlterCheckName:
 		return "(*AlterCheck).Name"
 	case RefOfAlterColumnColumn:
@@ -665,6 +679,8 @@ func (s ASTStep) DebugString() string {
 		return "(*AlterVschema).VindexColsOffset"
 	case RefOfAlterVschemaAutoIncSpec:
 		return "(*AlterVschema).AutoIncSpec"
+	case RefOfAnalyzeComments:
+		return "(*Analyze).Comments"
 	case RefOfAnalyzeTable:
 		return "(*Analyze).Table"
 	case RefOfAndExprLeft:
@@ -673,6 +689,8 @@ func (s ASTStep) DebugString() string {
 		return "(*AndExpr).Right"
 	case RefOfAnyValueArg:
 		return "(*AnyValue).Arg"
+	case RefOfApproxCountDistinctArg:
+		return "(*ApproxCountDistinct).Arg"
 	case RefOfArgumentLessWindowExprOverClause:
 		return "(*ArgumentLessWindowExpr).OverClause"
 	case RefOfAssignmentExprLeft:
@@ -735,6 +753,8 @@ func (s ASTStep) DebugString() string {
 		return "(*CharExpr).ExprsOffset"
 	case RefOfCheckConstraintDefinitionExpr:
 		return "(*CheckConstraintDefinition).Expr"
+	case RefOfChecksumTableTables:
+		return "(*ChecksumTable).Tables"
 	case RefOfColNameName:
 		return "(*ColName).Name"
 	case RefOfColNameQualifier:
@@ -1173,6 +1193,18 @@ func (s ASTStep) DebugString() string {
 		return "(*LinestrPropertyFuncExpr).Linestring"
 	case RefOfLinestrPropertyFuncExprPropertyDefArg:
 		return "(*LinestrPropertyFuncExpr).PropertyDefArg"
+	case RefOfLoadTable:
+		return "(*Load).Table"
+	case RefOfLoadPartitions:
+		return "(*Load).Partitions"
+	case RefOfLoadFields:
+		return "(*Load).Fields"
+	case RefOfLoadLines:
+		return "(*Load).Lines"
+	case RefOfLoadColumnsOffset:
+		return "(*Load).ColumnsOffset"
+	case RefOfLoadSetExprs:
+		return "(*Load).SetExprs"
 	case RefOfLocateExprSubStr:
 		return "(*LocateExpr).SubStr"
 	case RefOfLocateExprStr:
@@ -1237,6 +1269,8 @@ func (s ASTStep) DebugString() string {
 		return "(OnDup)[]Offset"
 	case RefOfOptLikeLikeTable:
 		return "(*OptLike).LikeTable"
+	case RefOfOptimizeTableTables:
+		return "(*OptimizeTable).Tables"
 	case RefOfOrExprLeft:
 		return "(*OrExpr).Left"
 	case RefOfOrExprRight:
@@ -1509,6 +1543,8 @@ func (s ASTStep) DebugString() string {
 		return "(TableName).Qualifier"
 	case TableNamesOffset:
 		return "(TableNames)[]Offset"
+	case RefOfTableSamplePercent:
+		return "(*TableSample).Percent"
 	case RefOfTableSpecColumnsOffset:
 		return "(*TableSpec).ColumnsOffset"
 	case RefOfTableSpecIndexesOffset:
@@ -1787,6 +1823,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*AliasedTableExpr).Hints
 		case RefOfAliasedTableExprColumns:
 			node = node.(*AliasedTableExpr).Columns
+		case RefOfAliasedTableExprSample:
+			node = node.(*AliasedTableExpr).Sample
 		case RefOfAlterCheckName:
 			node = node.(*AlterCheck).Name
 		case RefOfAlterColumnColumn:
@@ -1833,6 +1871,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*AlterVschema).VindexCols[idx]
 		case RefOfAlterVschemaAutoIncSpec:
 			node = node.(*AlterVschema).AutoIncSpec
+		case RefOfAnalyzeComments:
+			node = node.(*Analyze).Comments
 		case RefOfAnalyzeTable:
 			node = node.(*Analyze).Table
 		case RefOfAndExprLeft:
@@ -1841,6 +1881,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*AndExpr).Right
 		case RefOfAnyValueArg:
 			node = node.(*AnyValue).Arg
+		case RefOfApproxCountDistinctArg:
+			node = node.(*ApproxCountDistinct).Arg
 		case RefOfArgumentLessWindowExprOverClause:
 			node = node.(*ArgumentLessWindowExpr).OverClause
 		case RefOfAssignmentExprLeft:
@@ -1909,6 +1951,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*CharExpr).Exprs[idx]
 		case RefOfCheckConstraintDefinitionExpr:
 			node = node.(*CheckConstraintDefinition).Expr
+		case RefOfChecksumTableTables:
+			node = node.(*ChecksumTable).Tables
 		case RefOfColNameName:
 			node = node.(*ColName).Name
 		case RefOfColNameQualifier:
@@ -2401,6 +2445,20 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*LinestrPropertyFuncExpr).Linestring
 		case RefOfLinestrPropertyFuncExprPropertyDefArg:
 			node = node.(*LinestrPropertyFuncExpr).PropertyDefArg
+		case RefOfLoadTable:
+			node = node.(*Load).Table
+		case RefOfLoadPartitions:
+			node = node.(*Load).Partitions
+		case RefOfLoadFields:
+			node = node.(*Load).Fields
+		case RefOfLoadLines:
+			node = node.(*Load).Lines
+		case RefOfLoadColumnsOffset:
+			idx, bytesRead := path.nextPathOffset()
+			path = path[bytesRead:]
+			node = node.(*Load).Columns[idx]
+		case RefOfLoadSetExprs:
+			node = node.(*Load).SetExprs
 		case RefOfLocateExprSubStr:
 			node = node.(*LocateExpr).SubStr
 		case RefOfLocateExprStr:
@@ -2477,6 +2535,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(OnDup)[idx]
 		case RefOfOptLikeLikeTable:
 			node = node.(*OptLike).LikeTable
+		case RefOfOptimizeTableTables:
+			node = node.(*OptimizeTable).Tables
 		case RefOfOrExprLeft:
 			node = node.(*OrExpr).Left
 		case RefOfOrExprRight:
@@ -2775,6 +2835,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			idx, bytesRead := path.nextPathOffset()
 			path = path[bytesRead:]
 			node = node.(TableNames)[idx]
+		case RefOfTableSamplePercent:
+			node = node.(*TableSample).Percent
 		case RefOfTableSpecColumnsOffset:
 			idx, bytesRead := path.nextPathOffset()
 			path = path[bytesRead:]
@@ -59,6 +59,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfAndExpr(parent, node, replacer)
 	case *AnyValue:
 		return a.rewriteRefOfAnyValue(parent, node, replacer)
+	case *ApproxCountDistinct:
+		return a.rewriteRefOfApproxCountDistinct(parent, node, replacer)
 	case *Argument:
 		return a.rewriteRefOfArgument(parent, node, replacer)
 	case *ArgumentLessWindowExpr:
@@ -97,6 +99,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfCharExpr(parent, node, replacer)
 	case *CheckConstraintDefinition:
 		return a.rewriteRefOfCheckConstraintDefinition(parent, node, replacer)
+	case *ChecksumTable:
+		return a.rewriteRefOfChecksumTable(parent, node, replacer)
 	case *ColName:
 		return a.rewriteRefOfColName(parent, node, replacer)
 	case *CollateExpr:
@@ -337,6 +341,10 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfLiteral(parent, node, replacer)
 	case *Load:
 		return a.rewriteRefOfLoad(parent, node, replacer)
+	case *LoadFields:
+		return a.rewriteRefOfLoadFields(parent, node, replacer)
+	case *LoadLines:
+		return a.rewriteRefOfLoadLines(parent, node, replacer)
 	case *LocateExpr:
 		return a.rewriteRefOfLocateExpr(parent, node, replacer)
 	case *LockOption:
@@ -385,6 +393,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteOnDup(parent, node, replacer)
 	case *OptLike:
 		return a.rewriteRefOfOptLike(parent, node, replacer)
+	case *OptimizeTable:
+		return a.rewriteRefOfOptimizeTable(parent, node, replacer)
 	case *OrExpr:
 		return a.rewriteRefOfOrExpr(parent, node, replacer)
 	case *Order:
@@ -535,6 +545,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteTableNames(parent, node, replacer)
 	case TableOptions:
 		return a.rewriteTableOptions(parent, node, replacer)
+	case *TableSample:
+		return a.rewriteRefOfTableSample(parent, node, replacer)
 	case *TableSpec:
 		return a.rewriteRefOfTableSpec(parent, node, replacer)
 	case *TablespaceOperation:
@@ -859,6 +871,15 @@ func (a *application) rewriteRefOfAliasedTableExpr(parent SQLNode, node *Aliased
 	}) {
 		return false
 	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfAliasedTableExprSample))
+	}
+	if !a.rewriteRefOfTableSample(node, node.Sample, func(newNode, parent SQLNode) {
+		parent.(*AliasedTableExpr).Sample = newNode.(*TableSample)
+	}) {
+		return false
+	}
 	if a.collectPaths {
 		a.cur.current.Pop()
 	}
@@ -1380,6 +1401,15 @@ func (a *application) rewriteRefOfAnalyze(parent SQLNode, node *Analyze, replace
 		}
 	}
 	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfAnalyzeComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*Analyze).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
 		a.cur.current.AddStep(uint16(RefOfAnalyzeTable))
 	}
 	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
@@ -1490,6 +1520,46 @@ func (a *application) rewriteRefOfAnyValue(parent SQLNode, node *AnyValue, repla
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfApproxCountDistinct(parent SQLNode, node *ApproxCountDistinct, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfApproxCountDistinctArg))
+	}
+	if !a.rewriteExpr(node, node.Arg, func(newNode, parent SQLNode) {
+		parent.(*ApproxCountDistinct).Arg = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfArgument(parent SQLNode, node *Argument, replacer replacerFunc) bool {
 	if node == nil {
@@ -2355,6 +2425,46 @@ func (a *application) rewriteRefOfCheckConstraintDefinition(parent SQLNode, node
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfChecksumTable(parent SQLNode, node *ChecksumTable, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfChecksumTableTables))
+	}
+	if !a.rewriteTableNames(node, node.Tables, func(newNode, parent SQLNode) {
+		parent.(*ChecksumTable).Tables = newNode.(TableNames)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfColName(parent SQLNode, node *ColName, replacer replacerFunc) bool {
 	if node == nil {
@@ -8224,6 +8334,132 @@ func (a *application) rewriteRefOfLiteral(parent SQLNode, node *Literal, replace
 
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfLoad(parent SQLNode, node *Load, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfLoadTable))
+	}
+	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
+		parent.(*Load).Table = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadPartitions))
+	}
+	if !a.rewritePartitions(node, node.Partitions, func(newNode, parent SQLNode) {
+		parent.(*Load).Partitions = newNode.(Partitions)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadFields))
+	}
+	if !a.rewriteRefOfLoadFields(node, node.Fields, func(newNode, parent SQLNode) {
+		parent.(*Load).Fields = newNode.(*LoadFields)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadLines))
+	}
+	if !a.rewriteRefOfLoadLines(node, node.Lines, func(newNode, parent SQLNode) {
+		parent.(*Load).Lines = newNode.(*LoadLines)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	for x, el := range node.Columns {
+		if a.collectPaths {
+			if x == 0 {
+				a.cur.current.AddStepWithOffset(uint16(RefOfLoadColumnsOffset))
+			} else {
+				a.cur.current.ChangeOffset(x)
+			}
+		}
+		if !a.rewriteExpr(node, el, func(idx int) replacerFunc {
+			return func(newNode, parent SQLNode) {
+				parent.(*Load).Columns[idx] = newNode.(Expr)
+			}
+		}(x)) {
+			return false
+		}
+	}
+	if a.collectPaths && len(node.Columns) > 0 {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadSetExprs))
+	}
+	if !a.rewriteUpdateExprs(node, node.SetExprs, func(newNode, parent SQLNode) {
+		parent.(*Load).SetExprs = newNode.(UpdateExprs)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfLoadFields(parent SQLNode, node *LoadFields, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.post != nil {
+		if a.pre == nil {
+			a.cur.replacer = replacer
+			a.cur.parent = parent
+			a.cur.node = node
+		}
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfLoadLines(parent SQLNode, node *LoadLines, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -9302,6 +9538,46 @@ func (a *application) rewriteRefOfOptLike(parent SQLNode, node *OptLike, replace
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfOptimizeTable(parent SQLNode, node *OptimizeTable, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfOptimizeTableTables))
+	}
+	if !a.rewriteTableNames(node, node.Tables, func(newNode, parent SQLNode) {
+		parent.(*OptimizeTable).Tables = newNode.(TableNames)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfOrExpr(parent SQLNode, node *OrExpr, replacer replacerFunc) bool {
 	if node == nil {
@@ -12926,6 +13202,46 @@ func (a *application) rewriteTableOptions(parent SQLNode, node TableOptions, rep
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfTableSample(parent SQLNode, node *TableSample, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfTableSamplePercent))
+	}
+	if !a.rewriteExpr(node, node.Percent, func(newNode, parent SQLNode) {
+		parent.(*TableSample).Percent = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfTableSpec(parent SQLNode, node *TableSpec, replacer replacerFunc) bool {
 	if node == nil {
@@ -14740,6 +15056,8 @@ func (a *application) rewriteAggrFunc(parent SQLNode, node AggrFunc, replacer re
 	switch node := node.(type) {
 	case *AnyValue:
 		return a.rewriteRefOfAnyValue(parent, node, replacer)
+	case *ApproxCountDistinct:
+		return a.rewriteRefOfApproxCountDistinct(parent, node, replacer)
 	case *Avg:
 		return a.rewriteRefOfAvg(parent, node, replacer)
 	case *BitAnd:
@@ -14852,6 +15170,8 @@ func (a *application) rewriteCallable(parent SQLNode, node Callable, replacer re
 	switch node := node.(type) {
 	case *AnyValue:
 		return a.rewriteRefOfAnyValue(parent, node, replacer)
+	case *ApproxCountDistinct:
+		return a.rewriteRefOfApproxCountDistinct(parent, node, replacer)
 	case *ArgumentLessWindowExpr:
 		return a.rewriteRefOfArgumentLessWindowExpr(parent, node, replacer)
 	case *Avg:
@@ -15162,6 +15482,8 @@ func (a *application) rewriteExpr(parent SQLNode, node Expr, replacer replacerFu
 		return a.rewriteRefOfAndExpr(parent, node, replacer)
 	case *AnyValue:
 		return a.rewriteRefOfAnyValue(parent, node, replacer)
+	case *ApproxCountDistinct:
+		return a.rewriteRefOfApproxCountDistinct(parent, node, replacer)
 	case *Argument:
 		return a.rewriteRefOfArgument(parent, node, replacer)
 	case *ArgumentLessWindowExpr:
@@ -15548,6 +15870,8 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfBegin(parent, node, replacer)
 	case *CallProc:
 		return a.rewriteRefOfCallProc(parent, node, replacer)
+	case *ChecksumTable:
+		return a.rewriteRefOfChecksumTable(parent, node, replacer)
 	case *CommentOnly:
 		return a.rewriteRefOfCommentOnly(parent, node, replacer)
 	case *Commit:
@@ -15588,6 +15912,8 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfLoad(parent, node, replacer)
 	case *LockTables:
 		return a.rewriteRefOfLockTables(parent, node, replacer)
+	case *OptimizeTable:
+		return a.rewriteRefOfOptimizeTable(parent, node, replacer)
 	case *OtherAdmin:
 		return a.rewriteRefOfOtherAdmin(parent, node, replacer)
 	case *PrepareStmt:
@@ -58,6 +58,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfAndExpr(in, f)
 	case *AnyValue:
 		return VisitRefOfAnyValue(in, f)
+	case *ApproxCountDistinct:
+		return VisitRefOfApproxCountDistinct(in, f)
 	case *Argument:
 		return VisitRefOfArgument(in, f)
 	case *ArgumentLessWindowExpr:
@@ -96,6 +98,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfCharExpr(in, f)
 	case *CheckConstraintDefinition:
 		return VisitRefOfCheckConstraintDefinition(in, f)
+	case *ChecksumTable:
+		return VisitRefOfChecksumTable(in, f)
 	case *ColName:
 		return VisitRefOfColName(in, f)
 	case *CollateExpr:
@@ -336,6 +340,10 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfLiteral(in, f)
 	case *Load:
 		return VisitRefOfLoad(in, f)
+	case *LoadFields:
+		return VisitRefOfLoadFields(in, f)
+	case *LoadLines:
+		return VisitRefOfLoadLines(in, f)
 	case *LocateExpr:
 		return VisitRefOfLocateExpr(in, f)
 	case *LockOption:
@@ -384,6 +392,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitOnDup(in, f)
 	case *OptLike:
 		return VisitRefOfOptLike(in, f)
+	case *OptimizeTable:
+		return VisitRefOfOptimizeTable(in, f)
 	case *OrExpr:
 		return VisitRefOfOrExpr(in, f)
 	case *Order:
@@ -534,6 +544,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitTableNames(in, f)
 	case TableOptions:
 		return VisitTableOptions(in, f)
+	case *TableSample:
+		return VisitRefOfTableSample(in, f)
 	case *TableSpec:
 		return VisitRefOfTableSpec(in, f)
 	case *TablespaceOperation:
@@ -687,6 +699,9 @@ func VisitRefOfAliasedTableExpr(in *AliasedTableExpr, f Visit) error {
 	if err := VisitColumns(in.Columns, f); err != nil {
 		return err
 	}
+	if err := VisitRefOfTableSample(in.Sample, f); err != nil {
+		return err
+	}
 	return nil
 }
 func VisitRefOfAlterCharset(in *AlterCharset, f Visit) error {
@@ -844,6 +859,9 @@ func VisitRefOfAnalyze(in *Analyze, f Visit) error {
 	if cont, err := f(in); err != nil || !cont {
 		return err
 	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
 	if err := VisitTableName(in.Table, f); err != nil {
 		return err
 	}
@@ -876,6 +894,18 @@ func VisitRefOfAnyValue(in *AnyValue, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfApproxCountDistinct(in *ApproxCountDistinct, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitExpr(in.Arg, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfArgument(in *Argument, f Visit) error {
 	if in == nil {
 		return nil
@@ -1137,6 +1167,18 @@ func VisitRefOfCheckConstraintDefinition(in *CheckConstraintDefinition, f Visit)
 	}
 	return nil
 }
+func VisitRefOfChecksumTable(in *ChecksumTable, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableNames(in.Tables, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfColName(in *ColName, f Visit) error {
 	if in == nil {
 		return nil
@@ -2905,6 +2947,44 @@ func VisitRefOfLiteral(in *Literal, f Visit) error {
 	return nil
 }
 func VisitRefOfLoad(in *Load, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitPartitions(in.Partitions, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLoadFields(in.Fields, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLoadLines(in.Lines, f); err != nil {
+		return err
+	}
+	for _, el := range in.Columns {
+		if err := VisitExpr(el, f); err != nil {
+			return err
+		}
+	}
+	if err := VisitUpdateExprs(in.SetExprs, f); err != nil {
+		return err
+	}
+	return nil
+}
+func VisitRefOfLoadFields(in *LoadFields, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	return nil
+}
+func VisitRefOfLoadLines(in *LoadLines, f Visit) error {
 	if in == nil {
 		return nil
 	}
@@ -3228,6 +3308,18 @@ func VisitRefOfOptLike(in *OptLike, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfOptimizeTable(in *OptimizeTable, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableNames(in.Tables, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfOrExpr(in *OrExpr, f Visit) error {
 	if in == nil {
 		return nil
@@ -4317,6 +4409,18 @@ func VisitTableOptions(in TableOptions, f Visit) error {
 	_, err := f(in)
 	return err
 }
+func VisitRefOfTableSample(in *TableSample, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitExpr(in.Percent, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfTableSpec(in *TableSpec, f Visit) error {
 	if in == nil {
 		return nil
@@ -4868,6 +4972,8 @@ func VisitAggrFunc(in AggrFunc, f Visit) error {
 	switch in := in.(type) {
 	case *AnyValue:
 		return VisitRefOfAnyValue(in, f)
+	case *ApproxCountDistinct:
+		return VisitRefOfApproxCountDistinct(in, f)
 	case *Avg:
 		return VisitRefOfAvg(in, f)
 	case *BitAnd:
@@ -4976,6 +5082,8 @@ func VisitCallable(in Callable, f Visit) error {
 	switch in := in.(type) {
 	case *AnyValue:
 		return VisitRefOfAnyValue(in, f)
+	case *ApproxCountDistinct:
+		return VisitRefOfApproxCountDistinct(in, f)
 	case *ArgumentLessWindowExpr:
 		return VisitRefOfArgumentLessWindowExpr(in, f)
 	case *Avg:
@@ -5272,6 +5380,8 @@ func VisitExpr(in Expr, f Visit) error {
 		return VisitRefOfAndExpr(in, f)
 	case *AnyValue:
 		return VisitRefOfAnyValue(in, f)
+	case *ApproxCountDistinct:
+		return VisitRefOfApproxCountDistinct(in, f)
 	case *Argument:
 		return VisitRefOfArgument(in, f)
 	case *ArgumentLessWindowExpr:
@@ -5644,6 +5754,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfBegin(in, f)
 	case *CallProc:
 		return VisitRefOfCallProc(in, f)
+	case *ChecksumTable:
+		return VisitRefOfChecksumTable(in, f)
 	case *CommentOnly:
 		return VisitRefOfCommentOnly(in, f)
 	case *Commit:
@@ -5684,6 +5796,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfLoad(in, f)
 	case *LockTables:
 		return VisitRefOfLockTables(in, f)
+	case *OptimizeTable:
+		return VisitRefOfOptimizeTable(in, f)
 	case *OtherAdmin:
 		return VisitRefOfOtherAdmin(in, f)
 	case *PrepareStmt:
@@ -117,6 +117,8 @@ func (cached *AliasedTableExpr) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field Sample *vitess.io/vitess/go/vt/sqlparser.TableSample
+	size += cached.Sample.CachedSize(true)
 	return size
 }
 func (cached *AlterCharset) CachedSize(alloc bool) int64 {
@@ -202,7 +204,7 @@ func (cached *AlterMigration) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(80)
+		size += int64(128)
 	}
 	// field UUID string
 	size += hack.RuntimeAllocSize(int64(len(cached.UUID)))
@@ -214,6 +216,12 @@ func (cached *AlterMigration) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Threshold)))
 	// field Shards string
 	size += hack.RuntimeAllocSize(int64(len(cached.Shards)))
+	// field MigrationContext string
+	size += hack.RuntimeAllocSize(int64(len(cached.MigrationContext)))
+	// field ScheduleWindow string
+	size += hack.RuntimeAllocSize(int64(len(cached.ScheduleWindow)))
+	// field DependsOn string
+	size += hack.RuntimeAllocSize(int64(len(cached.DependsOn)))
 	return size
 }
 func (cached *AlterTable) CachedSize(alloc bool) int64 {
@@ -307,6 +315,8 @@ func (cached *Analyze) CachedSize(alloc bool) int64 {
 	if alloc {
 		size += int64(48)
 	}
+	// field Comments *vitess.io/vitess/go/vt/sqlparser.ParsedComments
+	size += cached.Comments.CachedSize(true)
 	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
 	size += cached.Table.CachedSize(false)
 	return size
@@ -343,6 +353,20 @@ func (cached *AnyValue) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *ApproxCountDistinct) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field Arg vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Arg.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *Argument) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -673,6 +697,23 @@ func (cached *CheckConstraintDefinition) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *ChecksumTable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field Tables vitess.io/vitess/go/vt/sqlparser.TableNames
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Tables)) * int64(32))
+		for _, elem := range cached.Tables {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
 func (cached *ColName) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -2953,6 +2994,79 @@ func (cached *Literal) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Val)))
 	return size
 }
+func (cached *Load) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(192)
+	}
+	// field FileName string
+	size += hack.RuntimeAllocSize(int64(len(cached.FileName)))
+	// field Table vitess.io/vitess/go/vt/sqlparser.TableName
+	size += cached.Table.CachedSize(false)
+	// field Partitions vitess.io/vitess/go/vt/sqlparser.Partitions
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Partitions)) * int64(32))
+		for _, elem := range cached.Partitions {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field Charset vitess.io/vitess/go/vt/sqlparser.ColumnCharset
+	size += cached.Charset.CachedSize(false)
+	// field Fields *vitess.io/vitess/go/vt/sqlparser.LoadFields
+	size += cached.Fields.CachedSize(true)
+	// field Lines *vitess.io/vitess/go/vt/sqlparser.LoadLines
+	size += cached.Lines.CachedSize(true)
+	// field Columns []vitess.io/vitess/go/vt/sqlparser.Expr
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Columns)) * int64(16))
+		for _, elem := range cached.Columns {
+			if cc, ok := elem.(cachedObject); ok {
+				size += cc.CachedSize(true)
+			}
+		}
+	}
+	// field SetExprs vitess.io/vitess/go/vt/sqlparser.UpdateExprs
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.SetExprs)) * int64(8))
+		for _, elem := range cached.SetExprs {
+			size += elem.CachedSize(true)
+		}
+	}
+	return size
+}
+func (cached *LoadFields) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field TerminatedBy *string
+	size += hack.RuntimeAllocSize(int64(16))
+	// field EnclosedBy *string
+	size += hack.RuntimeAllocSize(int64(16))
+	// field EscapedBy *string
+	size += hack.RuntimeAllocSize(int64(16))
+	return size
+}
+func (cached *LoadLines) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field StartingBy *string
+	size += hack.RuntimeAllocSize(int64(16))
+	// field TerminatedBy *string
+	size += hack.RuntimeAllocSize(int64(16))
+	return size
+}
 func (cached *LocateExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -3305,6 +3419,23 @@ func (cached *OptLike) CachedSize(alloc bool) int64 {
 	size += cached.LikeTable.CachedSize(false)
 	return size
 }
+func (cached *OptimizeTable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field Tables vitess.io/vitess/go/vt/sqlparser.TableNames
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Tables)) * int64(32))
+		for _, elem := range cached.Tables {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
 func (cached *OrExpr) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -4685,6 +4816,20 @@ func (cached *TableOptions) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *TableSample) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(16)
+	}
+	// field Percent vitess.io/vitess/go/vt/sqlparser.Expr
+	if cc, ok := cached.Percent.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *TableSpec) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
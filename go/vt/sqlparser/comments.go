@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -58,6 +59,22 @@ const (
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
 	// where 0 is the highest priority, and MaxPriorityValue is the lowest one.
 	DirectivePriority = "PRIORITY"
+	// DirectiveMaxResultRows overrides the maximum number of rows of the result
+	// of the query set by vtgate for its plan type. 0 means no limit.
+	DirectiveMaxResultRows = "MAX_RESULT_ROWS"
+	// DirectiveCanaryShard executes the query on a single canary shard only. The shard may be given
+	// as a value, otherwise the first shard the query resolves to is used.
+	DirectiveCanaryShard = "CANARY_SHARD"
+	// DirectiveCanaryContinue executes the query on all the shards it resolves to, except for the
+	// given canary shard it was already executed on.
+	DirectiveCanaryContinue = "CANARY_CONTINUE"
+	// DirectivePartialAggregates asks vttablet to fold the consecutive rows of the result of a SELECT which are
+	// equal in all their other columns, aggregating the listed columns. Its value is a comma-separated list of
+	// opcode:column pairs, count_distinct_approx computing the HyperLogLog sketch of the values of its column.
+	DirectivePartialAggregates = "PARTIAL_AGGREGATES"
+	// DirectiveMaxStaleness lets the query read replicas whose data is at most as stale as its value, a duration
+	// such as 30s, overriding the staleness bound of the session.
+	DirectiveMaxStaleness = "MAX_STALENESS"
 
 	// MaxPriorityValue specifies the maximum value allowed for the priority query directive. Valid priority values are
 	// between zero and MaxPriorityValue.
@@ -69,6 +86,10 @@ const (
 
 var ErrInvalidPriority = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid priority value specified in query")
 
+var ErrInvalidMaxStaleness = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid max staleness value specified in query: it must be a duration, e.g. 30s")
+
+var ErrInvalidCanary = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid canary directives specified in query: CANARY_SHARD and CANARY_CONTINUE are mutually exclusive, CANARY_CONTINUE requires a shard, and both are only supported for INSERT, UPDATE and DELETE statements")
+
 func isNonSpace(r rune) bool {
 	return !unicode.IsSpace(r)
 }
@@ -569,6 +590,16 @@ type QueryHints struct {
 	ForeignKeyChecks    *bool
 	Priority            string
 	Timeout             *int
+	MaxResultRows       *int
+	// CanaryShard limits the execution to a single canary shard. An empty
+	// value means the first shard the query resolves to.
+	CanaryShard *string
+	// CanaryContinue is the canary shard to skip when continuing the
+	// execution on the remaining shards.
+	CanaryContinue string
+	// MaxStaleness overrides the staleness bound of the session for the
+	// tablets which may serve the query. Zero means no bound.
+	MaxStaleness *time.Duration
 }
 
 func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
@@ -590,6 +621,15 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 	qh.Workload = getWorkload(directives)
 	qh.ForeignKeyChecks = getForeignKeyChecksState(comment)
 	qh.Timeout = getQueryTimeout(directives)
+	qh.MaxResultRows = getMaxResultRows(directives)
+	qh.MaxStaleness, err = getMaxStaleness(directives)
+	if err != nil {
+		return qh, err
+	}
+	qh.CanaryShard, qh.CanaryContinue, err = getCanary(stmt, directives)
+	if err != nil {
+		return qh, err
+	}
 
 	return qh, nil
 }
@@ -662,3 +702,58 @@ func getQueryTimeout(directives *CommentDirectives) *int {
 	}
 	return &timeout
 }
+
+// getMaxResultRows gets the maximum number of rows of the result from the
+// MAX_RESULT_ROWS directive, if set.
+func getMaxResultRows(directives *CommentDirectives) *int {
+	rowsString, ok := directives.GetString(DirectiveMaxResultRows, "")
+	if !ok || rowsString == "" {
+		return nil
+	}
+
+	rows, err := strconv.Atoi(rowsString)
+	if err != nil || rows < 0 {
+		return nil
+	}
+	return &rows
+}
+
+// getMaxStaleness gets the staleness bound from the MAX_STALENESS directive,
+// if set.
+func getMaxStaleness(directives *CommentDirectives) (*time.Duration, error) {
+	stalenessString, ok := directives.GetString(DirectiveMaxStaleness, "")
+	if !ok {
+		return nil, nil
+	}
+
+	maxStaleness, err := time.ParseDuration(stalenessString)
+	if err != nil || maxStaleness < 0 {
+		return nil, ErrInvalidMaxStaleness
+	}
+	return &maxStaleness, nil
+}
+
+// getCanary gets the canary shard from the CANARY_SHARD directive, or the
+// canary shard to skip from the CANARY_CONTINUE directive.
+func getCanary(stmt Statement, directives *CommentDirectives) (*string, string, error) {
+	canaryShard, isCanary := directives.GetString(DirectiveCanaryShard, "")
+	canaryContinue, isContinue := directives.GetString(DirectiveCanaryContinue, "")
+	switch {
+	case (isCanary || isContinue) && !IsDMLStatement(stmt):
+		return nil, "", ErrInvalidCanary
+	case isCanary && isContinue:
+		return nil, "", ErrInvalidCanary
+	case isContinue:
+		if canaryContinue == "" || canaryContinue == "true" {
+			return nil, "", ErrInvalidCanary
+		}
+		return nil, canaryContinue, nil
+	case isCanary:
+		if canaryShard == "true" {
+			// The directive is given without a shard
+			canaryShard = ""
+		}
+		return &canaryShard, "", nil
+	}
+	return nil, "", nil
+}
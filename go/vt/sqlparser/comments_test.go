@@ -19,10 +19,12 @@ package sqlparser
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/vt/sysvars"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -698,3 +700,125 @@ func TestQueryTimeout(t *testing.T) {
 		})
 	}
 }
+
+// TestMaxResultRows tests the extraction of MAX_RESULT_ROWS from the comments.
+func TestMaxResultRows(t *testing.T) {
+	testCases := []struct {
+		query   string
+		expRows int
+		noRows  bool
+	}{{
+		query:  "select * from a_table",
+		noRows: true,
+	}, {
+		query:   "select /*vt+ MAX_RESULT_ROWS=5000 */ * from another_table",
+		expRows: 5000,
+	}, {
+		query:   "select /*vt+ MAX_RESULT_ROWS=0 */ * from another_table",
+		expRows: 0,
+	}, {
+		query:  "select /*vt+ MAX_RESULT_ROWS=-1 */ * from another_table",
+		noRows: true,
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			assert.NoError(t, err)
+			qh, _ := BuildQueryHints(stmt)
+			if tc.noRows {
+				assert.Nil(t, qh.MaxResultRows)
+			} else {
+				assert.Equal(t, tc.expRows, *qh.MaxResultRows)
+			}
+		})
+	}
+}
+
+// TestCanary tests the extraction of CANARY_SHARD and CANARY_CONTINUE from the comments.
+func TestCanary(t *testing.T) {
+	testCases := []struct {
+		query       string
+		expCanary   *string
+		expContinue string
+		expErr      bool
+	}{{
+		query: "delete from a_table where id > 5",
+	}, {
+		query:     "delete /*vt+ CANARY_SHARD */ from a_table where id > 5",
+		expCanary: ptr.Of(""),
+	}, {
+		query:     "delete /*vt+ CANARY_SHARD=-80 */ from a_table where id > 5",
+		expCanary: ptr.Of("-80"),
+	}, {
+		query:       "delete /*vt+ CANARY_CONTINUE=-80 */ from a_table where id > 5",
+		expContinue: "-80",
+	}, {
+		query:  "delete /*vt+ CANARY_CONTINUE */ from a_table where id > 5",
+		expErr: true,
+	}, {
+		query:  "delete /*vt+ CANARY_SHARD=-80 CANARY_CONTINUE=-80 */ from a_table where id > 5",
+		expErr: true,
+	}, {
+		query:  "select /*vt+ CANARY_SHARD */ * from a_table",
+		expErr: true,
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			qh, err := BuildQueryHints(stmt)
+			if tc.expErr {
+				assert.ErrorIs(t, err, ErrInvalidCanary)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expCanary, qh.CanaryShard)
+			assert.Equal(t, tc.expContinue, qh.CanaryContinue)
+		})
+	}
+}
+
+// TestMaxStaleness tests the extraction of MAX_STALENESS from the comments.
+func TestMaxStaleness(t *testing.T) {
+	testCases := []struct {
+		query        string
+		expStaleness *time.Duration
+		expErr       bool
+	}{{
+		query: "select * from a_table",
+	}, {
+		query:        "select /*vt+ MAX_STALENESS=30s */ * from a_table",
+		expStaleness: ptr.Of(30 * time.Second),
+	}, {
+		query:        "select /*vt+ MAX_STALENESS=1m30s */ * from a_table",
+		expStaleness: ptr.Of(90 * time.Second),
+	}, {
+		query:        "select /*vt+ MAX_STALENESS=0 */ * from a_table",
+		expStaleness: ptr.Of(time.Duration(0)),
+	}, {
+		query:  "select /*vt+ MAX_STALENESS=30 */ * from a_table",
+		expErr: true,
+	}, {
+		query:  "select /*vt+ MAX_STALENESS=-1s */ * from a_table",
+		expErr: true,
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			qh, err := BuildQueryHints(stmt)
+			if tc.expErr {
+				assert.ErrorIs(t, err, ErrInvalidMaxStaleness)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expStaleness, qh.MaxStaleness)
+		})
+	}
+}
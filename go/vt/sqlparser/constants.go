@@ -256,6 +256,8 @@ const (
 	CharacterSetStr = " character set"
 	CollateStr      = " collate"
 	EncryptionStr   = " encryption"
+	BaseKeyspaceStr = " base_keyspace"
+	RecoveryTimeStr = " recovery_time"
 
 	// MatchExpr.Option
 	NoOptionStr                              = ""
@@ -300,6 +302,10 @@ const (
 	TraceStr       = "trace"
 	KeysStr        = "keys"
 
+	// Checksum table options
+	QuickStr    = "quick"
+	ExtendedStr = "extended"
+
 	// Lock Types
 	ReadStr             = "read"
 	ReadLocalStr        = "read local"
@@ -322,6 +328,7 @@ const (
 	FunctionCStr               = " function code"
 	FunctionStr                = " function status"
 	GtidExecGlobalStr          = " global gtid_executed"
+	GrantsStr                  = " grants"
 	IndexStr                   = " indexes"
 	OpenTableStr               = " open tables"
 	PluginsStr                 = " plugins"
@@ -338,11 +345,16 @@ const (
 	VGtidExecGlobalStr         = " global vgtid_executed"
 	KeyspaceStr                = " keyspaces"
 	VitessMigrationsStr        = " vitess_migrations"
+	VitessPlannerFlagsStr      = " vitess_planner_flags"
+	VitessProcesslistStr       = " vitess_processlist"
+	VitessQueryDigestsStr      = " vitess_query_digests"
 	VitessReplicationStatusStr = " vitess_replication_status"
+	VitessResultSizesStr       = " vitess_result_sizes"
 	VitessShardsStr            = " vitess_shards"
 	VitessTabletsStr           = " vitess_tablets"
 	VitessTargetStr            = " vitess_target"
 	VitessVariablesStr         = " vitess_metadata variables"
+	VitessVindexAdviceStr      = " vitess_vindex_advice"
 	VschemaTablesStr           = " vschema tables"
 	VschemaKeyspacesStr        = " vschema keyspaces"
 	VschemaVindexesStr         = " vschema vindexes"
@@ -535,6 +547,7 @@ const (
 	DropAutoIncDDLAction
 	RevertDDLAction
 	CreateProcedureAction
+	AnalyzeDDLAction
 )
 
 // Constants for scope of variables
@@ -861,6 +874,13 @@ const (
 	KeysVExplainType
 )
 
+// Constant for Enum Type - ChecksumTableOption
+const (
+	NoChecksumTableOption ChecksumTableOption = iota
+	QuickChecksumTableOption
+	ExtendedChecksumTableOption
+)
+
 // Constant for Enum Type - SelectIntoType
 const (
 	IntoOutfile SelectIntoType = iota
@@ -881,6 +901,8 @@ const (
 	CollateType DatabaseOptionType = iota
 	CharacterSetType
 	EncryptionType
+	BaseKeyspaceType
+	RecoveryTimeType
 )
 
 // LockType constants
@@ -910,6 +932,7 @@ const (
 	FunctionC
 	Function
 	GtidExecGlobal
+	Grants
 	Index
 	OpenTable
 	Plugins
@@ -925,11 +948,16 @@ const (
 	VariableSession
 	VGtidExecGlobal
 	VitessMigrations
+	VitessPlannerFlags
+	VitessProcesslist
+	VitessQueryDigests
 	VitessReplicationStatus
+	VitessResultSizes
 	VitessShards
 	VitessTablets
 	VitessTarget
 	VitessVariables
+	VitessVindexAdvice
 	VschemaTables
 	VschemaKeyspaces
 	VschemaVindexes
@@ -973,6 +1001,8 @@ const (
 	ForceCutOverMigrationType
 	ForceCutOverAllMigrationType
 	SetCutOverThresholdMigrationType
+	SetScheduleWindowMigrationType
+	SetDependencyMigrationType
 )
 
 // ColumnStorage constants
@@ -128,6 +128,7 @@ var keywords = []keyword{
 	{"and", AND},
 	{"any", ANY},
 	{"any_value", ANY_VALUE},
+	{"approx_count_distinct", APPROX_COUNT_DISTINCT},
 	{"array", ARRAY},
 	{"as", AS},
 	{"asc", ASC},
@@ -137,8 +138,10 @@ var keywords = []keyword{
 	{"autoextend_size", AUTOEXTEND_SIZE},
 	{"avg", AVG},
 	{"avg_row_length", AVG_ROW_LENGTH},
+	{"base_keyspace", BASE_KEYSPACE},
 	{"before", BEFORE},
 	{"begin", BEGIN},
+	{"bernoulli", BERNOULLI},
 	{"between", BETWEEN},
 	{"bigint", BIGINT},
 	{"binary", BINARY},
@@ -190,6 +193,7 @@ var keywords = []keyword{
 	{"constraint_catalog", CONSTRAINT_CATALOG},
 	{"constraint_name", CONSTRAINT_NAME},
 	{"constraint_schema", CONSTRAINT_SCHEMA},
+	{"context", CONTEXT},
 	{"continue", CONTINUE},
 	{"convert", CONVERT},
 	{"copy", COPY},
@@ -235,6 +239,7 @@ var keywords = []keyword{
 	{"delayed", UNUSED},
 	{"delete", DELETE},
 	{"dense_rank", DENSE_RANK},
+	{"depends", DEPENDS},
 	{"desc", DESC},
 	{"describe", DESCRIBE},
 	{"deterministic", UNUSED},
@@ -317,6 +322,7 @@ var keywords = []keyword{
 	{"gtid_subset", GTID_SUBSET},
 	{"gtid_subtract", GTID_SUBTRACT},
 	{"grant", UNUSED},
+	{"grants", GRANTS},
 	{"group", GROUP},
 	{"grouping", UNUSED},
 	{"groups", UNUSED},
@@ -337,7 +343,7 @@ var keywords = []keyword{
 	{"in", IN},
 	{"index", INDEX},
 	{"indexes", INDEXES},
-	{"infile", UNUSED},
+	{"infile", INFILE},
 	{"inout", INOUT},
 	{"inner", INNER},
 	{"inplace", INPLACE},
@@ -532,6 +538,7 @@ var keywords = []keyword{
 	{"qualify", QUALIFY},
 	{"queries", QUERIES},
 	{"query", QUERY},
+	{"quick", QUICK},
 	{"range", RANGE},
 	{"quarter", QUARTER},
 	{"rank", RANK},
@@ -541,6 +548,7 @@ var keywords = []keyword{
 	{"read_write", UNUSED},
 	{"real", REAL},
 	{"rebuild", REBUILD},
+	{"recovery_time", RECOVERY_TIME},
 	{"recursive", RECURSIVE},
 	{"redundant", REDUNDANT},
 	{"references", REFERENCES},
@@ -580,6 +588,7 @@ var keywords = []keyword{
 	{"rtrim", RTRIM},
 	{"s3", S3},
 	{"savepoint", SAVEPOINT},
+	{"schedule", SCHEDULE},
 	{"schema", SCHEMA},
 	{"schema_name", SCHEMA_NAME},
 	{"schemas", SCHEMAS},
@@ -788,12 +797,17 @@ var keywords = []keyword{
 	{"vitess_metadata", VITESS_METADATA},
 	{"vitess_migration", VITESS_MIGRATION},
 	{"vitess_migrations", VITESS_MIGRATIONS},
+	{"vitess_planner_flags", VITESS_PLANNER_FLAGS},
+	{"vitess_processlist", VITESS_PROCESSLIST},
+	{"vitess_query_digests", VITESS_QUERY_DIGESTS},
 	{"vitess_replication_status", VITESS_REPLICATION_STATUS},
+	{"vitess_result_sizes", VITESS_RESULT_SIZES},
 	{"vitess_shards", VITESS_SHARDS},
 	{"vitess_tablets", VITESS_TABLETS},
 	{"vitess_target", VITESS_TARGET},
 	{"vitess_throttled_apps", VITESS_THROTTLED_APPS},
 	{"vitess_throttler", VITESS_THROTTLER},
+	{"vitess_vindex_advice", VITESS_VINDEX_ADVICE},
 	{"vschema", VSCHEMA},
 	{"vstream", VSTREAM},
 	{"vtexplain", VTEXPLAIN},
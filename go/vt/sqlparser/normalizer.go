@@ -173,7 +173,7 @@ func (nz *normalizer) determineQueryRewriteStrategy(in Statement) {
 // It handles normalization logic based on node types.
 func (nz *normalizer) walkDown(node, _ SQLNode) bool {
 	switch node := node.(type) {
-	case *Begin, *Commit, *Rollback, *Savepoint, *SRollback, *Release, *OtherAdmin, *Analyze,
+	case *Begin, *Commit, *Rollback, *Savepoint, *SRollback, *Release, *OtherAdmin, *Analyze, *ChecksumTable, *OptimizeTable,
 		*PrepareStmt, *ExecuteStmt, *FramePoint, *ColName, TableName, *ConvertType:
 		// These statement do not need normalizing
 		return false
@@ -727,6 +727,15 @@ func (nz *normalizer) sysVarRewrite(cursor *Cursor, node *Variable) {
 		sysvars.Version.Name,
 		sysvars.VersionComment.Name,
 		sysvars.QueryTimeout.Name,
+		sysvars.TabletTags.Name,
+		sysvars.TenantID.Name,
+		sysvars.PlannerFlags.Name,
+		sysvars.ConsistentReads.Name,
+		sysvars.ConsistentReadPositions.Name,
+		sysvars.ShardErrors.Name,
+		sysvars.PartialScatterReads.Name,
+		sysvars.MaxStaleness.Name,
+		sysvars.DDLInTransaction.Name,
 		sysvars.Workload.Name:
 		found = true
 	}
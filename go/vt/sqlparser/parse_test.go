@@ -790,6 +790,14 @@ var (
 		input: "select /* use */ 1 from t1 as t2 use index (a), t3 use index (b) where b = 1",
 	}, {
 		input: "select /* force */ 1 from t1 as t2 force index (a), t3 force index (b) where b = 1",
+	}, {
+		input:  "select /* tablesample */ 1 from t1 TABLESAMPLE BERNOULLI (10)",
+		output: "select /* tablesample */ 1 from t1 tablesample bernoulli (10)",
+	}, {
+		input:  "select /* tablesample with alias */ 1 from t1 t2 use index (a) tablesample bernoulli (2.5), t3 where b = 1",
+		output: "select /* tablesample with alias */ 1 from t1 as t2 use index (a) tablesample bernoulli (2.5), t3 where b = 1",
+	}, {
+		input: "select /* tablesample as alias */ 1 from t1 as `tablesample`",
 	}, {
 		input:  "select /* table alias */ 1 from t t1",
 		output: "select /* table alias */ 1 from t as t1",
@@ -1018,6 +1026,9 @@ var (
 		input: "select /* function with many params */ 1 from t where a = b(c, d)",
 	}, {
 		input: "select /* function with distinct */ count(distinct a) from t",
+	}, {
+		input:  "select /* approximate count of distinct values */ APPROX_COUNT_DISTINCT(a) from t",
+		output: "select /* approximate count of distinct values */ approx_count_distinct(a) from t",
 	}, {
 		input:  "select count(distinctrow(1)) from (select (1) from dual union all select 1 from dual) a",
 		output: "select count(distinct 1) from (select 1 from dual union all select 1 from dual) as a",
@@ -2277,6 +2288,19 @@ var (
 		output: "analyze local table a",
 	}, {
 		input: "analyze local table a",
+	}, {
+		input: "analyze /*vt+ ddl_strategy=\"vitess\" */ table a",
+	}, {
+		input: "checksum table a",
+	}, {
+		input: "checksum table a, c.v, b quick",
+	}, {
+		input:  "CHECKSUM TABLE a EXTENDED",
+		output: "checksum table a extended",
+	}, {
+		input: "load data local infile 'x.csv' into table t fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n' ignore 1 lines (a, b, @c) set d = @c",
+	}, {
+		input: "load data infile '/tmp/x.txt' replace into table ks.t",
 	}, {
 		input: "flush tables",
 	}, {
@@ -2373,6 +2397,17 @@ var (
 	}, {
 		input:  "show grants for 'root@localhost'",
 		output: "show grants",
+	}, {
+		input: "show grants",
+	}, {
+		input:  "show grants for current_user",
+		output: "show grants",
+	}, {
+		input:  "show grants for current_user()",
+		output: "show grants",
+	}, {
+		input:  "show grants for app@localhost",
+		output: "show grants",
 	}, {
 		input:  "show index from t",
 		output: "show indexes from t",
@@ -2505,10 +2540,26 @@ var (
 		output: "show keyspaces like '%'",
 	}, {
 		input: "show vitess_metadata variables",
+	}, {
+		input: "show vitess_planner_flags",
+	}, {
+		input: "show vitess_planner_flags like 'hash%'",
+	}, {
+		input: "show vitess_processlist",
+	}, {
+		input: "show vitess_processlist like 'orders%'",
+	}, {
+		input: "show vitess_query_digests",
+	}, {
+		input: "show vitess_query_digests like 'select%'",
 	}, {
 		input: "show vitess_replication_status",
 	}, {
 		input: "show vitess_replication_status like '%'",
+	}, {
+		input: "show vitess_result_sizes",
+	}, {
+		input: "show vitess_result_sizes like 'app%'",
 	}, {
 		input: "show vitess_shards",
 	}, {
@@ -2519,6 +2570,10 @@ var (
 		input: "show vitess_tablets like '%'",
 	}, {
 		input: "show vitess_tablets where hostname = 'some-tablet'",
+	}, {
+		input: "show vitess_vindex_advice",
+	}, {
+		input: "show vitess_vindex_advice like 'user%'",
 	}, {
 		input: "show vitess_targets",
 	}, {
@@ -2618,6 +2673,34 @@ var (
 		input: "alter vitess_migration throttle all ratio 0.7",
 	}, {
 		input: "alter vitess_migration throttle all expire '1h' ratio 0.7",
+	}, {
+		input: "alter vitess_migration throttle all context 'maintenance' expire '1h' ratio 0.7",
+	}, {
+		input: "alter vitess_migration unthrottle all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration cleanup all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration launch all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration complete all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration postpone complete all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration cancel all context 'maintenance'",
+	}, {
+		input:  "alter vitess_migration FORCE_CUTOVER ALL CONTEXT 'maintenance'",
+		output: "alter vitess_migration force_cutover all context 'maintenance'",
+	}, {
+		input: "alter vitess_migration '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90' schedule window '22:00-06:00'",
+	}, {
+		input: "alter vitess_migration '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90' schedule window ''",
+	}, {
+		input: "alter vitess_migration '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90' depends on '8748c3b7_7fdb_11eb_ac2c_f875a4d24e90'",
+	}, {
+		input: "alter vitess_migration '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90' depends on ''",
+	}, {
+		input:  "select context, schedule, depends from t",
+		output: "select `context`, `schedule`, `depends` from t",
 	}, {
 		input: "show vitess_throttled_apps",
 	}, {
@@ -2712,8 +2795,10 @@ var (
 		input:  "repair foo",
 		output: "otheradmin",
 	}, {
-		input:  "optimize foo",
-		output: "otheradmin",
+		input: "optimize table foo",
+	}, {
+		input:  "optimize no_write_to_binlog tables foo, bar.baz",
+		output: "optimize local table foo, bar.baz",
 	}, {
 		input:  "lock tables foo read",
 		output: "lock tables foo read",
@@ -2944,6 +3029,12 @@ var (
 	}, {
 		input:  "CREATE DATABASE IF NOT EXISTS `mysql` DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci ENCRYPTION='N';",
 		output: "create database if not exists mysql default character set utf8mb4 collate utf8mb4_0900_ai_ci encryption 'N'",
+	}, {
+		input:  "create database commerce_pitr base_keyspace = commerce recovery_time = '2025-01-15T10:00:00Z'",
+		output: "create database commerce_pitr base_keyspace commerce recovery_time '2025-01-15T10:00:00Z'",
+	}, {
+		input:  "create database `commerce-pitr` default charset utf8mb4 base_keyspace `commerce-1` recovery_time '2025-01-15T10:00:00Z'",
+		output: "create database `commerce-pitr` default character set utf8mb4 base_keyspace `commerce-1` recovery_time '2025-01-15T10:00:00Z'",
 	}, {
 		input: "drop /* simple */ database test_db",
 	}, {
@@ -4894,7 +4985,6 @@ func TestLoadData(t *testing.T) {
 		"load data from s3 'x.txt'",
 		"load data from s3 manifest 'x.txt'",
 		"load data from s3 file 'x.txt'",
-		"load data infile 'x.txt' into table 'c'",
 		"load data from s3 'x.txt' into table x"}
 
 	parser := NewTestParser()
@@ -4902,6 +4992,30 @@ func TestLoadData(t *testing.T) {
 		_, err := parser.Parse(tcase)
 		require.NoError(t, err)
 	}
+
+	testCases := []struct {
+		input, output string
+	}{{
+		input: "load data infile 'x.txt' into table c",
+	}, {
+		input: "load data local infile '/tmp/x.csv' into table ks.t partition (p0, p1)",
+	}, {
+		input:  "LOAD DATA LOCAL INFILE 'x.csv' REPLACE INTO TABLE t CHARACTER SET utf8mb4 COLUMNS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '' LINES STARTING BY 'x' TERMINATED BY '\\r\\n' IGNORE 1 ROWS (a, @b, c) SET d = @b + 1",
+		output: "load data local infile 'x.csv' replace into table t character set utf8mb4 fields terminated by ',' optionally enclosed by '\"' escaped by '' lines starting by 'x' terminated by '\\r\\n' ignore 1 lines (a, @b, c) set d = @b + 1",
+	}, {
+		input:  "load data local infile 'x.csv' ignore into table t fields escaped by '\\\\' enclosed by '\"' terminated by '\\t' lines terminated by '\\n' ignore 2 lines",
+		output: "load data local infile 'x.csv' ignore into table t fields terminated by '\\t' enclosed by '\"' escaped by '\\\\' lines terminated by '\\n' ignore 2 lines",
+	}}
+	for _, tcase := range testCases {
+		t.Run(tcase.input, func(t *testing.T) {
+			if tcase.output == "" {
+				tcase.output = tcase.input
+			}
+			tree, err := parser.Parse(tcase.input)
+			require.NoError(t, err)
+			require.Equal(t, tcase.output, String(tree))
+		})
+	}
 }
 
 func TestCreateTable(t *testing.T) {
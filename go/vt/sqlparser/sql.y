@@ -101,6 +101,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
   setExpr       *SetExpr
   convertType   *ConvertType
   aliasedTableName *AliasedTableExpr
+  tableSample   *TableSample
   tableSpec  *TableSpec
   columnDefinition *ColumnDefinition
   indexDefinition *IndexDefinition
@@ -112,6 +113,9 @@ func markBindVariable(yylex yyLexer, bvar string) {
   showFilter    *ShowFilter
   optLike       *OptLike
   selectInto	  *SelectInto
+  load          *Load
+  loadFields    *LoadFields
+  loadLines     *LoadLines
   createDatabase  *CreateDatabase
   alterDatabase  *AlterDatabase
   createTable      *CreateTable
@@ -201,6 +205,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
   orderDirection  OrderDirection
   explainType 	  ExplainType
   vexplainType 	  VExplainType
+  checksumTableOption ChecksumTableOption
   intervalType	  IntervalType
   lockType LockType
   referenceDefinition *ReferenceDefinition
@@ -272,6 +277,12 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // Adding no precedence also works, since shifting is the default, but it reports some conflicts
 // We need to add a lower precedence to reducing the select_options_opt rule than shifting.
 %nonassoc <str> SELECT_OPTIONS
+// EMPTY_TABLE_ALIAS is used to resolve shift-reduce conflicts occurring due to TABLESAMPLE, which is a non-reserved keyword.
+// After a table name, we can either shift TABLESAMPLE to use it as the alias of the table, or reduce an empty alias and use it
+// to start a table_sample clause. Since we want the latter option, we give reducing the empty alias a higher precedence than shifting TABLESAMPLE.
+// In order to ensure the lower precedence of shifting, the precedence declaration of TABLESAMPLE has to come before this one.
+%nonassoc <str> TABLESAMPLE
+%nonassoc <str> EMPTY_TABLE_ALIAS
 
 %token LEX_ERROR
 %left <str> UNION
@@ -279,7 +290,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> DISTINCT AS EXISTS ASC DESC INTO DUPLICATE DEFAULT SET LOCK UNLOCK KEYS DO CALL
 %left <str> ALL ANY SOME
 %token <str> DISTINCTROW PARSER GENERATED ALWAYS
-%token <str> OUTFILE S3 DATA LOAD LINES TERMINATED ESCAPED ENCLOSED
+%token <str> OUTFILE INFILE S3 DATA LOAD LINES TERMINATED ESCAPED ENCLOSED
 %token <str> DUMPFILE CSV HEADER MANIFEST OVERWRITE STARTING OPTIONALLY
 %token <str> VALUES LAST_INSERT_ID
 %token <str> NEXT VALUE SHARE MODE
@@ -352,9 +363,11 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> VINDEX VINDEXES DIRECTORY NAME UPGRADE
 %token <str> STATUS VARIABLES WARNINGS CASCADED DEFINER OPTION SQL UNDEFINED
 %token <str> SEQUENCE MERGE TEMPORARY TEMPTABLE INVOKER SECURITY FIRST AFTER LAST
+%token <str> QUICK
 
 // Migration tokens
 %token <str> VITESS_MIGRATION CANCEL RETRY LAUNCH COMPLETE CLEANUP THROTTLE UNTHROTTLE FORCE_CUTOVER CUTOVER_THRESHOLD EXPIRE RATIO POSTPONE
+%token <str> CONTEXT SCHEDULE DEPENDS
 // Throttler tokens
 %token <str> VITESS_THROTTLER
 
@@ -381,10 +394,13 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // PURGE tokens
 %token <str> PURGE BEFORE
 
+// CREATE DATABASE tokens
+%token <str> BASE_KEYSPACE RECOVERY_TIME
+
 // SHOW tokens
 %token <str> CODE COLLATION COLUMNS DATABASES ENGINES EVENT EXTENDED FIELDS FULL FUNCTION GTID_EXECUTED
-%token <str> KEYSPACES OPEN PLUGINS PRIVILEGES PROCESSLIST SCHEMAS TABLES TRIGGERS USER
-%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
+%token <str> GRANTS KEYSPACES OPEN PLUGINS PRIVILEGES PROCESSLIST SCHEMAS TABLES TRIGGERS USER
+%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_PLANNER_FLAGS VITESS_PROCESSLIST VITESS_QUERY_DIGESTS VITESS_REPLICATION_STATUS VITESS_RESULT_SIZES VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VITESS_VINDEX_ADVICE VSCHEMA VITESS_THROTTLED_APPS
 
 // SET tokens
 %token <str> NAMES GLOBAL SESSION ISOLATION LEVEL READ WRITE ONLY REPEATABLE COMMITTED UNCOMMITTED SERIALIZABLE
@@ -406,7 +422,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> JSON_ARRAY JSON_OBJECT JSON_QUOTE
 %token <str> JSON_DEPTH JSON_TYPE JSON_LENGTH JSON_VALID
 %token <str> JSON_ARRAY_APPEND JSON_ARRAY_INSERT JSON_INSERT JSON_MERGE JSON_MERGE_PATCH JSON_MERGE_PRESERVE JSON_REMOVE JSON_REPLACE JSON_SET JSON_UNQUOTE
-%token <str> COUNT AVG MAX MIN SUM GROUP_CONCAT BIT_AND BIT_OR BIT_XOR STD STDDEV STDDEV_POP STDDEV_SAMP VAR_POP VAR_SAMP VARIANCE ANY_VALUE
+%token <str> COUNT AVG MAX MIN SUM GROUP_CONCAT BIT_AND BIT_OR BIT_XOR STD STDDEV STDDEV_POP STDDEV_SAMP VAR_POP VAR_SAMP VARIANCE ANY_VALUE APPROX_COUNT_DISTINCT
 %token <str> REGEXP_INSTR REGEXP_LIKE REGEXP_REPLACE REGEXP_SUBSTR
 %token <str> ExtractValue UpdateXML
 %token <str> GET_LOCK RELEASE_LOCK RELEASE_ALL_LOCKS IS_FREE_LOCK IS_USED_LOCK
@@ -428,7 +444,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> RANDOM REFERENCE REQUIRE_ROW_FORMAT RESOURCE RESPECT RESTART RETAIN REUSE ROLE SECONDARY SECONDARY_ENGINE SECONDARY_ENGINE_ATTRIBUTE SECONDARY_LOAD SECONDARY_UNLOAD SIMPLE SKIP
 %token <str> SOURCE_COMPRESSION_ALGORITHMS SOURCE_PUBLIC_KEY_PATH SOURCE_TLS_CIPHERSUITES SOURCE_ZSTD_COMPRESSION_LEVEL SRID
 %token <str> THREAD_PRIORITY TIES UNBOUNDED VCPU VISIBLE RETURNING
-%token <str> MANUAL PARALLEL QUALIFY TABLESAMPLE
+%token <str> MANUAL PARALLEL QUALIFY BERNOULLI
 %token <str> OUT INOUT
 
 // Performance Schema Functions
@@ -484,20 +500,21 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <signalSets> signal_set_list_opt signal_set_list
 %type <signalSet> signal_set
 %type <signalConditionName> condition_information_item_name
-%type <databaseOption> collate character_set encryption
-%type <databaseOptions> create_options create_options_opt
+%type <databaseOption> collate character_set encryption snapshot_option
+%type <databaseOptions> create_options create_options_opt snapshot_options snapshot_options_opt
 %type <boolean> default_optional first_opt linear_opt jt_exists_opt jt_path_opt partition_storage_opt
 %type <compoundStatement> compound_statement compound_statement_without_semicolon compound_statement_with_semicolon
 %type <compoundStatements> compound_statement_list_opt compound_statement_list else_opt
 %type <elseIf> elseif_expression
 %type <elseIfs> elseif_list elseif_list_opt
-%type <statement> analyze_statement show_statement use_statement purge_statement other_statement
+%type <statement> analyze_statement optimize_statement checksum_table_statement show_statement use_statement purge_statement other_statement
 %type <statement> begin_statement commit_statement rollback_statement savepoint_statement release_statement load_statement
 %type <statement> lock_statement unlock_statement call_statement
 %type <statement> revert_statement
 %type <strs> comment_opt comment_list
 %type <str> wild_opt check_option_opt cascade_or_local_opt restrict_or_cascade_opt
 %type <explainType> explain_format_opt
+%type <checksumTableOption> checksum_table_option_opt
 %type <vexplainType> vexplain_type_opt
 %type <trimType> trim_type
 %type <frameUnitType> frame_units
@@ -547,6 +564,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <joinType> inner_join outer_join straight_join natural_join
 %type <tableName> table_name into_table_name delete_table_name
 %type <aliasedTableName> aliased_table_name
+%type <tableSample> table_sample
 %type <indexHint> index_hint
 %type <indexHintForType> index_hint_for_opt
 %type <indexHints> index_hint_list index_hint_list_opt
@@ -576,6 +594,14 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <orderDirection> asc_desc_opt
 %type <limit> limit_opt limit_clause
 %type <selectInto> into_clause
+%type <load> load_duplicate_opt
+%type <loadFields> load_fields_opt load_fields_opt_list
+%type <loadLines> load_lines_opt load_lines_opt_list
+%type <boolean> load_local_opt
+%type <integer> load_ignore_lines_opt
+%type <exprs> load_column_list_opt load_column_list
+%type <expr> load_column
+%type <updateExprs> load_set_opt
 %type <columnTypeOptions> column_attribute_list_opt generated_column_attribute_list_opt column_type_default_opt
 %type <str> header_opt export_options manifest_opt overwrite_opt format_opt optionally_opt regexp_symbol
 %type <str> fields_opts fields_opt_list fields_opt lines_opts lines_opt lines_opt_list
@@ -662,7 +688,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <colKeyOpt> keys
 %type <referenceDefinition> reference_definition reference_definition_opt
 %type <str> underscore_charsets
-%type <str> expire_opt null_or_unknown
+%type <str> expire_opt null_or_unknown migration_context_opt
 %type <literal> ratio_opt
 %type <txAccessModes> tx_chacteristics_opt tx_chars
 %type <txAccessMode> tx_char
@@ -730,6 +756,8 @@ command:
 | drop_statement
 | truncate_statement
 | analyze_statement
+| optimize_statement
+| checksum_table_statement
 | purge_statement
 | show_statement
 | use_statement
@@ -1038,10 +1066,165 @@ do_statement:
   }
 
 load_statement:
-  LOAD DATA skip_to_end
+  LOAD DATA load_local_opt INFILE STRING load_duplicate_opt INTO TABLE table_name opt_partition_clause charset_opt load_fields_opt load_lines_opt load_ignore_lines_opt load_column_list_opt load_set_opt
+  {
+    $6.Local = $3
+    $6.FileName = $5
+    $6.Table = $9
+    $6.Partitions = $10
+    $6.Charset = $11
+    $6.Fields = $12
+    $6.Lines = $13
+    $6.IgnoreLines = $14
+    $6.Columns = $15
+    $6.SetExprs = $16
+    $$ = $6
+  }
+| LOAD DATA FROM skip_to_end
+  {
+    $$ = &Load{}
+  }
+
+load_local_opt:
+  {
+    $$ = false
+  }
+| LOCAL
+  {
+    $$ = true
+  }
+
+load_duplicate_opt:
   {
     $$ = &Load{}
   }
+| REPLACE
+  {
+    $$ = &Load{Replace: true}
+  }
+| IGNORE
+  {
+    $$ = &Load{Ignore: true}
+  }
+
+load_fields_opt:
+  {
+    $$ = nil
+  }
+| columns_or_fields load_fields_opt_list
+  {
+    $$ = $2
+  }
+
+load_fields_opt_list:
+  TERMINATED BY STRING
+  {
+    $$ = &LoadFields{TerminatedBy: ptr.Of($3)}
+  }
+| optionally_opt ENCLOSED BY STRING
+  {
+    $$ = &LoadFields{EnclosedBy: ptr.Of($4), Optionally: $1 != ""}
+  }
+| ESCAPED BY STRING
+  {
+    $$ = &LoadFields{EscapedBy: ptr.Of($3)}
+  }
+| load_fields_opt_list TERMINATED BY STRING
+  {
+    $1.TerminatedBy = ptr.Of($4)
+    $$ = $1
+  }
+| load_fields_opt_list optionally_opt ENCLOSED BY STRING
+  {
+    $1.EnclosedBy = ptr.Of($5)
+    $1.Optionally = $2 != ""
+    $$ = $1
+  }
+| load_fields_opt_list ESCAPED BY STRING
+  {
+    $1.EscapedBy = ptr.Of($4)
+    $$ = $1
+  }
+
+load_lines_opt:
+  {
+    $$ = nil
+  }
+| LINES load_lines_opt_list
+  {
+    $$ = $2
+  }
+
+load_lines_opt_list:
+  STARTING BY STRING
+  {
+    $$ = &LoadLines{StartingBy: ptr.Of($3)}
+  }
+| TERMINATED BY STRING
+  {
+    $$ = &LoadLines{TerminatedBy: ptr.Of($3)}
+  }
+| load_lines_opt_list STARTING BY STRING
+  {
+    $1.StartingBy = ptr.Of($4)
+    $$ = $1
+  }
+| load_lines_opt_list TERMINATED BY STRING
+  {
+    $1.TerminatedBy = ptr.Of($4)
+    $$ = $1
+  }
+
+load_ignore_lines_opt:
+  {
+    $$ = 0
+  }
+| IGNORE INTEGRAL LINES
+  {
+    $$ = convertStringToInt($2)
+  }
+| IGNORE INTEGRAL ROWS
+  {
+    $$ = convertStringToInt($2)
+  }
+
+load_column_list_opt:
+  {
+    $$ = nil
+  }
+| openb load_column_list closeb
+  {
+    $$ = $2
+  }
+
+load_column_list:
+  load_column
+  {
+    $$ = []Expr{$1}
+  }
+| load_column_list ',' load_column
+  {
+    $$ = append($1, $3)
+  }
+
+load_column:
+  column_name
+  {
+    $$ = $1
+  }
+| user_defined_variable
+  {
+    $$ = $1
+  }
+
+load_set_opt:
+  {
+    $$ = nil
+  }
+| SET update_list
+  {
+    $$ = $2
+  }
 
 with_clause:
   WITH with_list
@@ -1512,10 +1695,10 @@ create_statement:
     $1.CheckOption = $5
     $$ = $1
   }
-| create_database_prefix create_options_opt
+| create_database_prefix create_options_opt snapshot_options_opt
   {
     $1.FullyParsed = true
-    $1.CreateOptions = $2
+    $1.CreateOptions = append($2, $3...)
     $$ = $1
   }
 
@@ -1759,6 +1942,35 @@ encryption:
     $$ = DatabaseOption{Type:EncryptionType, Value:encodeSQLString($4), IsDefault:$1}
   }
 
+snapshot_options_opt:
+  {
+    $$ = nil
+  }
+| snapshot_options
+  {
+    $$ = $1
+  }
+
+snapshot_options:
+  snapshot_option
+  {
+    $$ = []DatabaseOption{$1}
+  }
+| snapshot_options snapshot_option
+  {
+    $$ = append($1,$2)
+  }
+
+snapshot_option:
+  BASE_KEYSPACE equal_opt table_id
+  {
+    $$ = DatabaseOption{Type:BaseKeyspaceType, Value:$3.String()}
+  }
+| RECOVERY_TIME equal_opt STRING
+  {
+    $$ = DatabaseOption{Type:RecoveryTimeType, Value:$3}
+  }
+
 create_like:
   LIKE table_name
   {
@@ -3304,6 +3516,15 @@ after_opt:
     $$ = $2
   }
 
+migration_context_opt:
+  {
+    $$ = ""
+  }
+| CONTEXT STRING
+  {
+    $$ = string($2)
+  }
+
 expire_opt:
   {
     $$ = ""
@@ -3688,10 +3909,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION CLEANUP ALL
+| ALTER comment_opt VITESS_MIGRATION CLEANUP ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: CleanupAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING LAUNCH
@@ -3709,10 +3931,11 @@ alter_statement:
       Shards: string($7),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION LAUNCH ALL
+| ALTER comment_opt VITESS_MIGRATION LAUNCH ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: LaunchAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING COMPLETE
@@ -3722,10 +3945,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION COMPLETE ALL
+| ALTER comment_opt VITESS_MIGRATION COMPLETE ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: CompleteAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING POSTPONE COMPLETE
@@ -3735,10 +3959,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION POSTPONE COMPLETE ALL
+| ALTER comment_opt VITESS_MIGRATION POSTPONE COMPLETE ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: PostponeCompleteAllMigrationType,
+      MigrationContext: $7,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING CANCEL
@@ -3748,10 +3973,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION CANCEL ALL
+| ALTER comment_opt VITESS_MIGRATION CANCEL ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: CancelAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING THROTTLE expire_opt ratio_opt
@@ -3763,12 +3989,13 @@ alter_statement:
       Ratio: $7,
     }
   }
-| ALTER comment_opt VITESS_MIGRATION THROTTLE ALL expire_opt ratio_opt
+| ALTER comment_opt VITESS_MIGRATION THROTTLE ALL migration_context_opt expire_opt ratio_opt
   {
     $$ = &AlterMigration{
       Type: ThrottleAllMigrationType,
-      Expire: $6,
-      Ratio: $7,
+      MigrationContext: $6,
+      Expire: $7,
+      Ratio: $8,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING UNTHROTTLE
@@ -3778,10 +4005,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION UNTHROTTLE ALL
+| ALTER comment_opt VITESS_MIGRATION UNTHROTTLE ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: UnthrottleAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING FORCE_CUTOVER
@@ -3791,10 +4019,11 @@ alter_statement:
       UUID: string($4),
     }
   }
-| ALTER comment_opt VITESS_MIGRATION FORCE_CUTOVER ALL
+| ALTER comment_opt VITESS_MIGRATION FORCE_CUTOVER ALL migration_context_opt
   {
     $$ = &AlterMigration{
       Type: ForceCutOverAllMigrationType,
+      MigrationContext: $6,
     }
   }
 | ALTER comment_opt VITESS_MIGRATION STRING CUTOVER_THRESHOLD STRING
@@ -3805,6 +4034,22 @@ alter_statement:
       Threshold: $6,
     }
   }
+| ALTER comment_opt VITESS_MIGRATION STRING SCHEDULE WINDOW STRING
+  {
+    $$ = &AlterMigration{
+      Type: SetScheduleWindowMigrationType,
+      UUID: string($4),
+      ScheduleWindow: string($7),
+    }
+  }
+| ALTER comment_opt VITESS_MIGRATION STRING DEPENDS ON STRING
+  {
+    $$ = &AlterMigration{
+      Type: SetDependencyMigrationType,
+      UUID: string($4),
+      DependsOn: string($7),
+    }
+  }
 
 partitions_options_opt:
   {
@@ -4404,9 +4649,38 @@ truncate_statement:
   }
 
 analyze_statement:
-  ANALYZE local_opt TABLE table_name
+  ANALYZE comment_opt local_opt TABLE table_name
+  {
+    $$ = &Analyze{Comments: Comments($2).Parsed(), IsLocal: $3, Table: $5}
+  }
+
+optimize_statement:
+  OPTIMIZE local_opt TABLE table_name_list
+  {
+    $$ = &OptimizeTable{IsLocal: $2, Tables: $4}
+  }
+| OPTIMIZE local_opt TABLES table_name_list
+  {
+    $$ = &OptimizeTable{IsLocal: $2, Tables: $4}
+  }
+
+checksum_table_statement:
+  CHECKSUM TABLE table_name_list checksum_table_option_opt
   {
-    $$ = &Analyze{IsLocal: $2, Table: $4}
+    $$ = &ChecksumTable{Tables: $3, Option: $4}
+  }
+
+checksum_table_option_opt:
+  {
+    $$ = NoChecksumTableOption
+  }
+| QUICK
+  {
+    $$ = QuickChecksumTableOption
+  }
+| EXTENDED
+  {
+    $$ = ExtendedChecksumTableOption
   }
 
 purge_statement:
@@ -4460,6 +4734,18 @@ show_statement:
   {
     $$ = &Show{&ShowBasic{Command: OpenTable, DbName:$4, Filter: $5}}
   }
+| SHOW GRANTS
+  {
+    $$ = &Show{&ShowBasic{Command: Grants}}
+  }
+| SHOW GRANTS FOR CURRENT_USER func_paren_opt
+  {
+    $$ = &Show{&ShowBasic{Command: Grants}}
+  }
+| SHOW GRANTS FOR user_username address_opt
+  {
+    $$ = &Show{&ShowOther{Command: string($2)}}
+  }
 | SHOW PRIVILEGES
   {
     $$ = &Show{&ShowBasic{Command: Privilege}}
@@ -4584,6 +4870,22 @@ show_statement:
   {
     $$ = &Show{&ShowBasic{Command: Warnings}}
   }
+| SHOW VITESS_PLANNER_FLAGS like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessPlannerFlags, Filter: $3}}
+  }
+| SHOW VITESS_PROCESSLIST like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessProcesslist, Filter: $3}}
+  }
+| SHOW VITESS_QUERY_DIGESTS like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessQueryDigests, Filter: $3}}
+  }
+| SHOW VITESS_RESULT_SIZES like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessResultSizes, Filter: $3}}
+  }
 | SHOW VITESS_SHARDS like_or_where_opt
   {
     $$ = &Show{&ShowBasic{Command: VitessShards, Filter: $3}}
@@ -4596,6 +4898,10 @@ show_statement:
   {
     $$ = &Show{&ShowBasic{Command: VitessTarget}}
   }
+| SHOW VITESS_VINDEX_ADVICE like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessVindexAdvice, Filter: $3}}
+  }
 /*
  * Catch-all for show statements without vitess keywords:
  */
@@ -4973,10 +5279,6 @@ other_statement:
   {
     $$ = &OtherAdmin{}
   }
-| OPTIMIZE skip_to_end
-  {
-    $$ = &OtherAdmin{}
-  }
 
 lock_statement:
   LOCK TABLES lock_table_list
@@ -5385,6 +5687,11 @@ table_factor:
   {
     $$ = $1
   }
+| aliased_table_name table_sample
+  {
+    $1.Sample = $2
+    $$ = $1
+  }
 | derived_table as_opt table_id column_list_opt
   {
     $$ = &AliasedTableExpr{Expr:$1, As: $3, Columns: $4}
@@ -5418,6 +5725,12 @@ table_name as_opt_id index_hint_list_opt
     $$ = &AliasedTableExpr{Expr:$1, Partitions: $4, As: $6, Hints: $7}
   }
 
+table_sample:
+  TABLESAMPLE BERNOULLI openb expression closeb
+  {
+    $$ = &TableSample{Percent: $4}
+  }
+
 column_list_opt:
   {
     $$ = nil
@@ -5533,6 +5846,7 @@ as_opt:
   { $$ = struct{}{} }
 
 as_opt_id:
+  %prec EMPTY_TABLE_ALIAS
   {
     $$ = NewIdentifierCS("")
   }
@@ -6518,6 +6832,10 @@ UTC_DATE func_paren_opt
   {
     $$ = &AnyValue{Arg:$3}
   }
+| APPROX_COUNT_DISTINCT openb expression closeb
+  {
+    $$ = &ApproxCountDistinct{Arg:$3}
+  }
 | TIMESTAMPADD openb timestampadd_interval ',' expression ',' expression closeb
   {
     $$ = &IntervalDateExpr{Syntax: IntervalDateExprTimestampadd, Date: $7, Interval: $5, Unit: $3}
@@ -8656,6 +8974,7 @@ reserved_keyword:
 | IGNORE
 | IN
 | INDEX
+| INFILE
 | INNER
 | INOUT
 | INSERT
@@ -8774,14 +9093,17 @@ non_reserved_keyword:
 | ALWAYS
 | ANY %prec ANY_SOME
 | ANY_VALUE %prec FUNCTION_CALL_NON_KEYWORD
+| APPROX_COUNT_DISTINCT %prec FUNCTION_CALL_NON_KEYWORD
 | ARRAY
 | ASCII
 | AUTO_INCREMENT
 | AUTOEXTEND_SIZE
 | AVG %prec FUNCTION_CALL_NON_KEYWORD
 | AVG_ROW_LENGTH
+| BASE_KEYSPACE
 | BEFORE
 | BEGIN
+| BERNOULLI
 | BIGINT
 | BIT
 | BIT_AND %prec FUNCTION_CALL_NON_KEYWORD
@@ -8822,6 +9144,7 @@ non_reserved_keyword:
 | CONSTRAINT_CATALOG
 | CONSTRAINT_NAME
 | CONSTRAINT_SCHEMA
+| CONTEXT
 | COPY
 | COUNT %prec FUNCTION_CALL_NON_KEYWORD
 | CSV
@@ -8838,6 +9161,7 @@ non_reserved_keyword:
 | DELAY_KEY_WRITE
 | DEFINER
 | DEFINITION
+| DEPENDS
 | DESCRIPTION
 | DIRECTORY
 | DISABLE
@@ -8890,6 +9214,7 @@ non_reserved_keyword:
 | GET_MASTER_PUBLIC_KEY
 | GET_SOURCE_PUBLIC_KEY
 | GLOBAL
+| GRANTS
 | GROUP_CONCAT %prec FUNCTION_CALL_NON_KEYWORD
 | GTID_EXECUTED
 | GTID_SUBSET %prec FUNCTION_CALL_NON_KEYWORD
@@ -9039,10 +9364,12 @@ non_reserved_keyword:
 | QUALIFY
 | QUERIES
 | QUERY
+| QUICK
 | RANDOM
 | RATIO
 | REAL
 | REBUILD
+| RECOVERY_TIME
 | REDUNDANT
 | REFERENCE
 | REFERENCES
@@ -9072,6 +9399,7 @@ non_reserved_keyword:
 | ROW_FORMAT
 | RTRIM %prec FUNCTION_CALL_NON_KEYWORD
 | S3
+| SCHEDULE
 | SCHEMA_NAME
 | SECONDARY
 | SECONDARY_ENGINE
@@ -9231,12 +9559,17 @@ non_reserved_keyword:
 | VITESS_METADATA
 | VITESS_MIGRATION
 | VITESS_MIGRATIONS
+| VITESS_PLANNER_FLAGS
+| VITESS_PROCESSLIST
+| VITESS_QUERY_DIGESTS
 | VITESS_REPLICATION_STATUS
+| VITESS_RESULT_SIZES
 | VITESS_SHARDS
 | VITESS_TABLETS
 | VITESS_TARGET
 | VITESS_THROTTLED_APPS
 | VITESS_THROTTLER
+| VITESS_VINDEX_ADVICE
 | VSCHEMA
 | VTEXPLAIN
 | WAIT_FOR_EXECUTED_GTID_SET %prec FUNCTION_CALL_NON_KEYWORD
@@ -73,6 +73,15 @@ var (
 	TxReadOnly                  = SystemVariable{Name: "tx_read_only", IsBoolean: true, Default: off}
 	Workload                    = SystemVariable{Name: "workload", IdentifierAsString: true}
 	QueryTimeout                = SystemVariable{Name: "query_timeout"}
+	TabletTags                  = SystemVariable{Name: "vitess_tablet_tags", IdentifierAsString: true}
+	TenantID                    = SystemVariable{Name: "vitess_tenant_id", IdentifierAsString: true}
+	PlannerFlags                = SystemVariable{Name: "vitess_planner_flags", IdentifierAsString: true}
+	ConsistentReads             = SystemVariable{Name: "vitess_consistent_reads", IsBoolean: true, Default: off}
+	ConsistentReadPositions     = SystemVariable{Name: "vitess_consistent_read_positions"}
+	ShardErrors                 = SystemVariable{Name: "vitess_shard_errors"}
+	PartialScatterReads         = SystemVariable{Name: "vitess_partial_scatter_reads", IsBoolean: true, Default: off}
+	MaxStaleness                = SystemVariable{Name: "vitess_max_staleness", IdentifierAsString: true}
+	DDLInTransaction            = SystemVariable{Name: "ddl_in_transaction", IdentifierAsString: true}
 
 	// Online DDL
 	DDLStrategy      = SystemVariable{Name: "ddl_strategy", IdentifierAsString: true}
@@ -109,12 +118,21 @@ var (
 		ReadAfterWriteTimeOut,
 		SessionTrackGTIDs,
 		QueryTimeout,
+		TabletTags,
+		TenantID,
+		PlannerFlags,
+		ConsistentReads,
+		PartialScatterReads,
+		MaxStaleness,
+		DDLInTransaction,
 	}
 
 	ReadOnly = []SystemVariable{
 		Socket,
 		Version,
 		VersionComment,
+		ConsistentReadPositions,
+		ShardErrors,
 	}
 
 	IgnoreThese = []SystemVariable{
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -29,6 +30,7 @@ import (
 
 	"vitess.io/vitess/go/json2"
 	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
 	"vitess.io/vitess/go/vt/tableacl/acl"
 )
@@ -68,10 +70,12 @@ var acls = make(map[string]acl.Factory)
 var defaultACL string
 
 type tableACL struct {
-	// mutex protects entries, config, and callback
+	// mutex protects entries, roles, config, and callback
 	sync.RWMutex
 	entries aclEntries
-	config  *tableaclpb.Config
+	// roles maps the MySQL roles to the ACL groups they grant.
+	roles  map[string][]string
+	config *tableaclpb.Config
 	// callback is executed on successful reload.
 	callback func()
 	// ACL Factory override for testing
@@ -94,6 +98,12 @@ var currentTableACL tableACL
 //	      "writers": ["client1"],
 //	      "admins": ["client1"]
 //	    }
+//	  ],
+//	  "role_mappings": [
+//	    {
+//	      "role": "role1",
+//	      "groups": ["client1"]
+//	    }
 //	  ]
 //	}
 func Init(configFile string, aclCB func()) error {
@@ -172,6 +182,15 @@ func load(config *tableaclpb.Config, newACL func([]string) (acl.ACL, error)) (en
 	return entries, nil
 }
 
+// loadRoles loads the role mappings from a proto-defined Config.
+func loadRoles(config *tableaclpb.Config) map[string][]string {
+	roles := make(map[string][]string, len(config.RoleMappings))
+	for _, mapping := range config.RoleMappings {
+		roles[mapping.Role] = mapping.Groups
+	}
+	return roles
+}
+
 func (tacl *tableACL) aclFactory() (acl.Factory, error) {
 	if tacl.factory == nil {
 		return GetCurrentACLFactory()
@@ -190,6 +209,7 @@ func (tacl *tableACL) Set(config *tableaclpb.Config) error {
 	}
 	tacl.Lock()
 	tacl.entries = entries
+	tacl.roles = loadRoles(config)
 	tacl.config = config.CloneVT()
 	callback := tacl.callback
 	tacl.Unlock()
@@ -234,6 +254,16 @@ func ValidateProto(config *tableaclpb.Config) (err error) {
 			t.Insert(prefix, name)
 		}
 	}
+	roles := make(map[string]bool, len(config.RoleMappings))
+	for _, mapping := range config.RoleMappings {
+		if mapping.Role == "" {
+			return errors.New("role mappings must have a role")
+		}
+		if roles[mapping.Role] {
+			return fmt.Errorf("duplicate role mapping for role %q", mapping.Role)
+		}
+		roles[mapping.Role] = true
+	}
 	return nil
 }
 
@@ -271,6 +301,91 @@ func (tacl *tableACL) Authorized(table string, role Role) *ACLResult {
 	}
 }
 
+// ResolveRoles returns the caller with the ACL groups granted by its roles added
+// to its groups. The roles of a caller are its groups, as reported by the auth
+// server. The caller is returned as is when none of its roles are mapped.
+func ResolveRoles(caller *querypb.VTGateCallerID) *querypb.VTGateCallerID {
+	return currentTableACL.ResolveRoles(caller)
+}
+
+func (tacl *tableACL) ResolveRoles(caller *querypb.VTGateCallerID) *querypb.VTGateCallerID {
+	tacl.RLock()
+	defer tacl.RUnlock()
+	if caller == nil || len(tacl.roles) == 0 {
+		return caller
+	}
+	var granted []string
+	for _, role := range caller.Groups {
+		granted = append(granted, tacl.roles[role]...)
+	}
+	if len(granted) == 0 {
+		return caller
+	}
+	resolved := caller.CloneVT()
+	for _, group := range granted {
+		if !slices.Contains(resolved.Groups, group) {
+			resolved.Groups = append(resolved.Groups, group)
+		}
+	}
+	return resolved
+}
+
+// Roles returns the roles of the caller, i.e. the groups of the caller which
+// have a role mapping.
+func Roles(caller *querypb.VTGateCallerID) []string {
+	return currentTableACL.Roles(caller)
+}
+
+func (tacl *tableACL) Roles(caller *querypb.VTGateCallerID) []string {
+	tacl.RLock()
+	defer tacl.RUnlock()
+	var roles []string
+	for _, group := range caller.GetGroups() {
+		if _, ok := tacl.roles[group]; ok {
+			roles = append(roles, group)
+		}
+	}
+	return roles
+}
+
+// Grant is the roles a caller has on a table name or prefix.
+type Grant struct {
+	TableNameOrPrefix string
+	GroupName         string
+	Roles             []Role
+}
+
+// Grants returns the grants of the caller, sorted by table name or prefix,
+// once its roles are resolved. Table names or prefixes on which the caller
+// has no role are left out.
+func Grants(caller *querypb.VTGateCallerID) []Grant {
+	return currentTableACL.Grants(caller)
+}
+
+func (tacl *tableACL) Grants(caller *querypb.VTGateCallerID) []Grant {
+	caller = tacl.ResolveRoles(caller)
+	tacl.RLock()
+	defer tacl.RUnlock()
+	var grants []Grant
+	for _, entry := range tacl.entries {
+		var roles []Role
+		for role := READER; role < NumRoles; role++ {
+			if acl, ok := entry.acl[role]; ok && acl.IsMember(caller) {
+				roles = append(roles, role)
+			}
+		}
+		if len(roles) == 0 {
+			continue
+		}
+		grants = append(grants, Grant{
+			TableNameOrPrefix: entry.tableNameOrPrefix,
+			GroupName:         entry.groupName,
+			Roles:             roles,
+		})
+	}
+	return grants
+}
+
 // GetCurrentConfig returns a copy of current tableacl configuration.
 func GetCurrentConfig() *tableaclpb.Config {
 	return currentTableACL.Config()
@@ -199,6 +199,89 @@ func TestTableACLAuthorize(t *testing.T) {
 	}
 }
 
+func TestTableACLValidateRoleMappings(t *testing.T) {
+	tests := []struct {
+		roles []string
+		valid bool
+	}{
+		{nil, true},
+		{[]string{"analyst"}, true},
+		{[]string{"analyst", "developer"}, true},
+		{[]string{""}, false},                   // no role
+		{[]string{"analyst", "analyst"}, false}, // duplicate
+	}
+	for _, test := range tests {
+		var mappings []*tableaclpb.RoleMapping
+		for _, role := range test.roles {
+			mappings = append(mappings, &tableaclpb.RoleMapping{
+				Role:   role,
+				Groups: []string{"readers"},
+			})
+		}
+		config := &tableaclpb.Config{RoleMappings: mappings}
+		err := ValidateProto(config)
+		if test.valid {
+			require.NoError(t, err, "ValidateProto(%v)", config)
+		} else {
+			require.Error(t, err, "ValidateProto(%v)", config)
+		}
+	}
+}
+
+func TestTableACLRoles(t *testing.T) {
+	tacl := tableACL{factory: &simpleacl.Factory{}}
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{
+			{
+				Name:                 "group01",
+				TableNamesOrPrefixes: []string{"test_music"},
+				Readers:              []string{"readers", "writers"},
+				Writers:              []string{"writers"},
+			},
+			{
+				Name:                 "group02",
+				TableNamesOrPrefixes: []string{"test_video%"},
+				Readers:              []string{"readers"},
+				Admins:               []string{"admins"},
+			},
+		},
+		RoleMappings: []*tableaclpb.RoleMapping{
+			{
+				Role:   "analyst",
+				Groups: []string{"readers"},
+			},
+			{
+				Role:   "developer",
+				Groups: []string{"writers", "admins"},
+			},
+		},
+	}
+	err := tacl.Set(config)
+	require.NoError(t, err)
+
+	// A caller without mapped roles is left as is.
+	caller := &querypb.VTGateCallerID{Username: "u1", Groups: []string{"sales"}}
+	require.Same(t, caller, tacl.ResolveRoles(caller))
+	require.False(t, tacl.Authorized("test_music", READER).IsMember(tacl.ResolveRoles(caller)))
+	require.Empty(t, tacl.Grants(caller))
+
+	caller = &querypb.VTGateCallerID{Username: "u2", Groups: []string{"sales", "analyst"}}
+	resolved := tacl.ResolveRoles(caller)
+	require.Equal(t, []string{"sales", "analyst", "readers"}, resolved.Groups)
+	require.Equal(t, []string{"sales", "analyst"}, caller.Groups, "the caller should not be modified")
+	require.True(t, tacl.Authorized("test_music", READER).IsMember(resolved))
+	require.False(t, tacl.Authorized("test_music", WRITER).IsMember(resolved))
+
+	require.Equal(t, []string{"analyst"}, tacl.Roles(caller))
+
+	caller = &querypb.VTGateCallerID{Username: "u3", Groups: []string{"analyst", "developer"}}
+	require.Equal(t, []string{"analyst", "developer", "readers", "writers", "admins"}, tacl.ResolveRoles(caller).Groups)
+	require.Equal(t, []Grant{
+		{TableNameOrPrefix: "test_music", GroupName: "group01", Roles: []Role{READER, WRITER}},
+		{TableNameOrPrefix: "test_video%", GroupName: "group02", Roles: []Role{READER, ADMIN}},
+	}, tacl.Grants(caller))
+}
+
 func TestFailedToCreateACL(t *testing.T) {
 	tacl := tableACL{factory: &fakeACLFactory{}}
 	config := &tableaclpb.Config{
@@ -84,6 +84,29 @@ func (ts *Server) GetCellInfo(ctx context.Context, cell string, strongRead bool)
 	return ci, nil
 }
 
+// GetCellDrains returns the drains of the cells being drained, keyed by cell.
+func (ts *Server) GetCellDrains(ctx context.Context) (map[string]*topodatapb.CellDrain, error) {
+	cells, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drains := make(map[string]*topodatapb.CellDrain)
+	for _, cell := range cells {
+		ci, err := ts.GetCellInfo(ctx, cell, false /* strongRead */)
+		switch {
+		case err == nil:
+			if ci.Drain != nil {
+				drains[cell] = ci.Drain
+			}
+		case IsErrType(err, NoNode):
+			// The cell was deleted since it was listed.
+		default:
+			return nil, err
+		}
+	}
+	return drains, nil
+}
+
 // CreateCellInfo creates a new CellInfo with the provided content.
 func (ts *Server) CreateCellInfo(ctx context.Context, cell string, ci *topodatapb.CellInfo) error {
 	if ctx.Err() != nil {
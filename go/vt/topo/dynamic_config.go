@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file provides the utility methods to save / retrieve / watch the
+// overrides of the dynamic flags of the vtgates and vttablets in the topology
+// global cell. The cluster-wide overrides are stored at the root of the global
+// cell, and the overrides of a keyspace next to its Keyspace record.
+
+// DynamicConfigFile is the name of the file holding a DynamicConfig.
+const DynamicConfigFile = "DynamicConfig"
+
+func pathForDynamicConfig(keyspace string) string {
+	if keyspace == "" {
+		return DynamicConfigFile
+	}
+	return path.Join(KeyspacesPath, keyspace, DynamicConfigFile)
+}
+
+// GetDynamicConfig returns the dynamic config of the keyspace, or the
+// cluster-wide one if keyspace is empty. An empty config is returned if none
+// was saved.
+func (ts *Server) GetDynamicConfig(ctx context.Context, keyspace string) (*vtctldatapb.DynamicConfig, error) {
+	config := &vtctldatapb.DynamicConfig{}
+	contents, _, err := ts.globalCell.Get(ctx, pathForDynamicConfig(keyspace))
+	switch {
+	case err == nil:
+		if err := config.UnmarshalVT(contents); err != nil {
+			return nil, vterrors.Wrapf(err, "bad dynamic config data for %q", keyspace)
+		}
+	case IsErrType(err, NoNode):
+		// Nothing to do.
+	default:
+		return nil, err
+	}
+	return config, nil
+}
+
+// UpdateDynamicConfigFields is a high level helper to read the dynamic config
+// of the keyspace, or the cluster-wide one if keyspace is empty, update it
+// with the provided function, and save it back, retrying on BadVersion. If
+// the update method returns ErrNoUpdateNeeded, nothing is written.
+func (ts *Server) UpdateDynamicConfigFields(ctx context.Context, keyspace string, update func(*vtctldatapb.DynamicConfig) error) (*vtctldatapb.DynamicConfig, error) {
+	filePath := pathForDynamicConfig(keyspace)
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		config := &vtctldatapb.DynamicConfig{}
+		contents, version, err := ts.globalCell.Get(ctx, filePath)
+		switch {
+		case err == nil:
+			if err := config.UnmarshalVT(contents); err != nil {
+				return nil, err
+			}
+		case IsErrType(err, NoNode):
+			// Nothing to do.
+		default:
+			return nil, err
+		}
+
+		if err = update(config); err != nil {
+			if IsErrType(err, NoUpdateNeeded) {
+				return config, nil
+			}
+			return nil, err
+		}
+
+		contents, err = config.MarshalVT()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = ts.globalCell.Update(ctx, filePath, contents, version); !IsErrType(err, BadVersion) {
+			// This includes the 'err=nil' case.
+			if err != nil {
+				return nil, err
+			}
+			return config, nil
+		}
+	}
+}
+
+// DeleteDynamicConfig deletes the dynamic config of the keyspace, or the
+// cluster-wide one if keyspace is empty.
+func (ts *Server) DeleteDynamicConfig(ctx context.Context, keyspace string) error {
+	return ts.globalCell.Delete(ctx, pathForDynamicConfig(keyspace), nil)
+}
+
+// WatchDynamicConfigData wraps the data we receive on the watch channel.
+// The WatchDynamicConfig API guarantees exactly one of Value or Err will be
+// set.
+type WatchDynamicConfigData struct {
+	Value *vtctldatapb.DynamicConfig
+	Err   error
+}
+
+// WatchDynamicConfig will set a watch on the dynamic config of the keyspace,
+// or the cluster-wide one if keyspace is empty. It has the same contract as
+// conn.Watch, but it also unpacks the contents into a DynamicConfig object.
+// It fails with NoNode if no config was saved.
+func (ts *Server) WatchDynamicConfig(ctx context.Context, keyspace string) (*WatchDynamicConfigData, <-chan *WatchDynamicConfigData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	current, wdChannel, err := ts.globalCell.Watch(ctx, pathForDynamicConfig(keyspace))
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	value := &vtctldatapb.DynamicConfig{}
+	if err := value.UnmarshalVT(current.Contents); err != nil {
+		// Cancel the watch, drain channel.
+		cancel()
+		for range wdChannel {
+		}
+		return nil, nil, vterrors.Wrapf(err, "error unpacking initial DynamicConfig object")
+	}
+
+	changes := make(chan *WatchDynamicConfigData, 10)
+	// The background routine reads any event from the watch channel,
+	// translates it, and sends it to the caller.
+	// If cancel() is called, the underlying Watch() code will
+	// send an ErrInterrupted and then close the channel. We'll
+	// just propagate that back to our caller.
+	go func() {
+		defer cancel()
+		defer close(changes)
+
+		for wd := range wdChannel {
+			if wd.Err != nil {
+				// Last error value, we're done.
+				// wdChannel will be closed right after
+				// this, no need to do anything.
+				changes <- &WatchDynamicConfigData{Err: wd.Err}
+				return
+			}
+
+			value := &vtctldatapb.DynamicConfig{}
+			if err := value.UnmarshalVT(wd.Contents); err != nil {
+				cancel()
+				for range wdChannel {
+				}
+				changes <- &WatchDynamicConfigData{Err: vterrors.Wrapf(err, "error unpacking DynamicConfig object")}
+				return
+			}
+
+			changes <- &WatchDynamicConfigData{Value: value}
+		}
+	}()
+
+	return &WatchDynamicConfigData{Value: value}, changes, nil
+}
@@ -0,0 +1,461 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// TopologyArchiveVersion is the version of the format of the archives written
+// by ExportTopology. ImportTopology reads the archives of this version and of
+// the previous ones.
+const TopologyArchiveVersion = 1
+
+// ExportTopology exports the records of the global topology into an archive:
+// the cells, cells aliases, keyspaces, vschemas, shards, routing rules and
+// scheduled jobs. The tablets and the serving graph of the cells are not
+// exported, since the tablets register themselves and the serving graph is
+// rebuilt from the global records.
+func ExportTopology(ctx context.Context, ts *topo.Server) (*vtctldatapb.TopologyArchive, error) {
+	archive := &vtctldatapb.TopologyArchive{
+		Version:    TopologyArchiveVersion,
+		ExportedAt: protoutil.TimeToProto(time.Now()),
+		Cells:      make(map[string]*topodatapb.CellInfo),
+	}
+
+	cells, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetCellInfoNames: %w", err)
+	}
+	for _, cell := range cells {
+		ci, err := ts.GetCellInfo(ctx, cell, true)
+		if err != nil {
+			return nil, fmt.Errorf("GetCellInfo(%v): %w", cell, err)
+		}
+		archive.Cells[cell] = ci
+	}
+	if archive.CellsAliases, err = ts.GetCellsAliases(ctx, true); err != nil {
+		return nil, fmt.Errorf("GetCellsAliases: %w", err)
+	}
+
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetKeyspaces: %w", err)
+	}
+	for _, keyspace := range keyspaces {
+		ki, err := ts.GetKeyspace(ctx, keyspace)
+		if err != nil {
+			return nil, fmt.Errorf("GetKeyspace(%v): %w", keyspace, err)
+		}
+		archive.Keyspaces = append(archive.Keyspaces, &vtctldatapb.Keyspace{
+			Name:     keyspace,
+			Keyspace: ki.Keyspace,
+		})
+
+		ksvs, err := ts.GetVSchema(ctx, keyspace)
+		switch {
+		case err == nil:
+			if archive.Vschemas == nil {
+				archive.Vschemas = make(map[string]*vschemapb.Keyspace)
+			}
+			archive.Vschemas[keyspace] = ksvs.Keyspace
+		case topo.IsErrType(err, topo.NoNode):
+			// Nothing to do.
+		default:
+			return nil, fmt.Errorf("GetVSchema(%v): %w", keyspace, err)
+		}
+
+		shards, err := ts.FindAllShardsInKeyspace(ctx, keyspace, nil)
+		if err != nil && !topo.IsErrType(err, topo.NoNode) {
+			return nil, fmt.Errorf("FindAllShardsInKeyspace(%v): %w", keyspace, err)
+		}
+		for _, shard := range slices.Sorted(maps.Keys(shards)) {
+			archive.Shards = append(archive.Shards, &vtctldatapb.Shard{
+				Keyspace: keyspace,
+				Name:     shard,
+				Shard:    shards[shard].Shard,
+			})
+		}
+	}
+
+	if archive.RoutingRules, err = ts.GetRoutingRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetRoutingRules: %w", err)
+	}
+	if archive.ShardRoutingRules, err = ts.GetShardRoutingRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetShardRoutingRules: %w", err)
+	}
+	if archive.KeyspaceRoutingRules, err = ts.GetKeyspaceRoutingRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetKeyspaceRoutingRules: %w", err)
+	}
+	if archive.MirrorRules, err = ts.GetMirrorRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetMirrorRules: %w", err)
+	}
+	if archive.TenantRoutingRules, err = ts.GetTenantRoutingRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetTenantRoutingRules: %w", err)
+	}
+	if archive.PlanPins, err = ts.GetPlanPins(ctx); err != nil {
+		return nil, fmt.Errorf("GetPlanPins: %w", err)
+	}
+	if archive.RewriteRules, err = ts.GetRewriteRules(ctx); err != nil {
+		return nil, fmt.Errorf("GetRewriteRules: %w", err)
+	}
+	if archive.MaintenanceWindows, err = ts.GetMaintenanceWindows(ctx); err != nil {
+		return nil, fmt.Errorf("GetMaintenanceWindows: %w", err)
+	}
+
+	jobs, err := ts.GetScheduledJobNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetScheduledJobNames: %w", err)
+	}
+	for _, name := range jobs {
+		ji, err := ts.GetScheduledJob(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("GetScheduledJob(%v): %w", name, err)
+		}
+		archive.ScheduledJobs = append(archive.ScheduledJobs, ji.ScheduledJob)
+	}
+
+	return archive, nil
+}
+
+// archiveRecord is a record of an archive to import.
+type archiveRecord struct {
+	// name describes the record in the ImportTopologyResponse.
+	name string
+	// exists returns whether the record already exists in the topology.
+	exists func(ctx context.Context) (bool, error)
+	// write creates the record, or overwrites it if it exists.
+	write func(ctx context.Context, exists bool) error
+}
+
+// ImportTopology imports the records of an archive written by ExportTopology
+// into the global topology. The records that already exist are handled as
+// requested by the conflict policy of the request. The serving graph of the
+// cells must be rebuilt after the import, with RebuildKeyspaceGraph and
+// RebuildVSchemaGraph.
+func ImportTopology(ctx context.Context, ts *topo.Server, req *vtctldatapb.ImportTopologyRequest) (*vtctldatapb.ImportTopologyResponse, error) {
+	archive := req.Archive
+	if archive == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "archive is required")
+	}
+	if archive.Version < 1 || archive.Version > TopologyArchiveVersion {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported archive version %d, the supported versions are 1 to %d", archive.Version, TopologyArchiveVersion)
+	}
+	if _, ok := vtctldatapb.ImportTopologyRequest_ConflictPolicy_name[int32(req.OnConflict)]; !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown conflict policy %v", req.OnConflict)
+	}
+
+	records := archiveRecords(ts, archive)
+	existing := make([]bool, len(records))
+	var conflicts []string
+	for i, record := range records {
+		exists, err := record.exists(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", record.name, err)
+		}
+		existing[i] = exists
+		if exists {
+			conflicts = append(conflicts, record.name)
+		}
+	}
+	if len(conflicts) > 0 && req.OnConflict == vtctldatapb.ImportTopologyRequest_FAIL {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the topology already contains %s", strings.Join(conflicts, ", "))
+	}
+
+	resp := &vtctldatapb.ImportTopologyResponse{}
+	for i, record := range records {
+		switch {
+		case !existing[i]:
+			resp.Created = append(resp.Created, record.name)
+		case req.OnConflict == vtctldatapb.ImportTopologyRequest_SKIP:
+			resp.Skipped = append(resp.Skipped, record.name)
+			continue
+		default:
+			resp.Overwritten = append(resp.Overwritten, record.name)
+		}
+		if req.DryRun {
+			continue
+		}
+		if err := record.write(ctx, existing[i]); err != nil {
+			return nil, fmt.Errorf("failed to import %s: %w", record.name, err)
+		}
+	}
+	return resp, nil
+}
+
+// found converts the result of a topo read into whether the record exists.
+func found[T any](_ T, err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case topo.IsErrType(err, topo.NoNode):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// replace sets the value of the record read from the topology to the value of
+// the archive.
+func replace[T proto.Message](value T) func(current T) error {
+	return func(current T) error {
+		proto.Reset(current)
+		proto.Merge(current, value)
+		return nil
+	}
+}
+
+// archiveRecords returns the records of the archive in the order they must be
+// imported: the cells before the keyspaces, and the keyspaces before their
+// shards.
+func archiveRecords(ts *topo.Server, archive *vtctldatapb.TopologyArchive) []archiveRecord {
+	var records []archiveRecord
+
+	for _, cell := range slices.Sorted(maps.Keys(archive.Cells)) {
+		ci := archive.Cells[cell]
+		records = append(records, archiveRecord{
+			name: "cell " + cell,
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetCellInfo(ctx, cell, true))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.UpdateCellInfoFields(ctx, cell, replace(ci))
+			},
+		})
+	}
+
+	for _, alias := range slices.Sorted(maps.Keys(archive.CellsAliases)) {
+		cellsAlias := archive.CellsAliases[alias]
+		records = append(records, archiveRecord{
+			name: "cells alias " + alias,
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetCellsAlias(ctx, alias, true))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				if !exists {
+					return ts.CreateCellsAlias(ctx, alias, cellsAlias)
+				}
+				return ts.UpdateCellsAlias(ctx, alias, replace(cellsAlias))
+			},
+		})
+	}
+
+	for _, ks := range archive.Keyspaces {
+		records = append(records, archiveRecord{
+			name: "keyspace " + ks.Name,
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetKeyspace(ctx, ks.Name))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				if !exists {
+					return ts.CreateKeyspace(ctx, ks.Name, ks.Keyspace)
+				}
+				return overwriteKeyspace(ctx, ts, ks)
+			},
+		})
+	}
+
+	for _, keyspace := range slices.Sorted(maps.Keys(archive.Vschemas)) {
+		vs := archive.Vschemas[keyspace]
+		records = append(records, archiveRecord{
+			name: "vschema " + keyspace,
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetVSchema(ctx, keyspace))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+					Name:     keyspace,
+					Keyspace: vs,
+				})
+			},
+		})
+	}
+
+	for _, shard := range archive.Shards {
+		records = append(records, archiveRecord{
+			name: "shard " + topoproto.KeyspaceShardString(shard.Keyspace, shard.Name),
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetShard(ctx, shard.Keyspace, shard.Name))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				if !exists {
+					if err := ts.CreateShard(ctx, shard.Keyspace, shard.Name); err != nil {
+						return err
+					}
+				}
+				_, err := ts.UpdateShardFields(ctx, shard.Keyspace, shard.Name, func(si *topo.ShardInfo) error {
+					si.Shard = shard.Shard.CloneVT()
+					return nil
+				})
+				return err
+			},
+		})
+	}
+
+	if len(archive.RoutingRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "routing rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetRoutingRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveRoutingRules(ctx, archive.RoutingRules)
+			},
+		})
+	}
+	if len(archive.ShardRoutingRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "shard routing rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetShardRoutingRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveShardRoutingRules(ctx, archive.ShardRoutingRules)
+			},
+		})
+	}
+	if len(archive.KeyspaceRoutingRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "keyspace routing rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetKeyspaceRoutingRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveKeyspaceRoutingRules(ctx, archive.KeyspaceRoutingRules)
+			},
+		})
+	}
+	if len(archive.MirrorRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "mirror rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetMirrorRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveMirrorRules(ctx, archive.MirrorRules)
+			},
+		})
+	}
+	if len(archive.TenantRoutingRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "tenant routing rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetTenantRoutingRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveTenantRoutingRules(ctx, archive.TenantRoutingRules)
+			},
+		})
+	}
+	if len(archive.PlanPins.GetPins()) > 0 {
+		records = append(records, archiveRecord{
+			name: "plan pins",
+			exists: func(ctx context.Context) (bool, error) {
+				pins, err := ts.GetPlanPins(ctx)
+				return len(pins.GetPins()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SavePlanPins(ctx, archive.PlanPins)
+			},
+		})
+	}
+	if len(archive.RewriteRules.GetRules()) > 0 {
+		records = append(records, archiveRecord{
+			name: "rewrite rules",
+			exists: func(ctx context.Context) (bool, error) {
+				rules, err := ts.GetRewriteRules(ctx)
+				return len(rules.GetRules()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveRewriteRules(ctx, archive.RewriteRules)
+			},
+		})
+	}
+	if len(archive.MaintenanceWindows.GetWindows()) > 0 {
+		records = append(records, archiveRecord{
+			name: "maintenance windows",
+			exists: func(ctx context.Context) (bool, error) {
+				mw, err := ts.GetMaintenanceWindows(ctx)
+				return len(mw.GetWindows()) > 0, err
+			},
+			write: func(ctx context.Context, exists bool) error {
+				return ts.SaveMaintenanceWindows(ctx, archive.MaintenanceWindows)
+			},
+		})
+	}
+
+	for _, job := range archive.ScheduledJobs {
+		records = append(records, archiveRecord{
+			name: "scheduled job " + job.Name,
+			exists: func(ctx context.Context) (bool, error) {
+				return found(ts.GetScheduledJob(ctx, job.Name))
+			},
+			write: func(ctx context.Context, exists bool) error {
+				if !exists {
+					_, err := ts.CreateScheduledJob(ctx, job)
+					return err
+				}
+				_, err := ts.UpdateScheduledJobFields(ctx, job.Name, func(ji *topo.ScheduledJobInfo) error {
+					ji.ScheduledJob = job.CloneVT()
+					return nil
+				})
+				return err
+			},
+		})
+	}
+
+	return records
+}
+
+// overwriteKeyspace replaces the record of an existing keyspace, under the
+// keyspace lock.
+func overwriteKeyspace(ctx context.Context, ts *topo.Server, ks *vtctldatapb.Keyspace) (err error) {
+	ctx, unlock, lockErr := ts.LockKeyspace(ctx, ks.Name, "ImportTopology")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	ki, err := ts.GetKeyspace(ctx, ks.Name)
+	if err != nil {
+		return err
+	}
+	ki.Keyspace = ks.Keyspace.CloneVT()
+	return ts.UpdateKeyspace(ctx, ki)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestExportImportTopology(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fromTS := memorytopo.NewServer(ctx, "zone1", "zone2")
+	defer fromTS.Close()
+	toTS := memorytopo.NewServer(ctx, "zone1")
+	defer toTS.Close()
+
+	require.NoError(t, fromTS.CreateCellsAlias(ctx, "all", &topodatapb.CellsAlias{Cells: []string{"zone1", "zone2"}}))
+	require.NoError(t, fromTS.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{
+		DurabilityPolicy: "semi_sync",
+		ThrottlerConfig:  &topodatapb.ThrottlerConfig{Enabled: true, Threshold: 5},
+	}))
+	require.NoError(t, fromTS.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     "ks",
+		Keyspace: &vschemapb.Keyspace{Sharded: true},
+	}))
+	for _, shard := range []string{"80-", "-80"} {
+		require.NoError(t, fromTS.CreateShard(ctx, "ks", shard))
+		_, err := fromTS.UpdateShardFields(ctx, "ks", shard, func(si *topo.ShardInfo) error {
+			si.PrimaryAlias = &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, fromTS.SaveRoutingRules(ctx, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{{FromTable: "t1", ToTables: []string{"ks.t1"}}},
+	}))
+	require.NoError(t, fromTS.SaveMaintenanceWindows(ctx, &topodatapb.MaintenanceWindows{
+		Windows: []*topodatapb.MaintenanceWindow{{Name: "nightly", Schedule: "0 2 * * *", Duration: protoutil.DurationToProto(4 * time.Hour)}},
+	}))
+	_, err := fromTS.CreateScheduledJob(ctx, &vtctldatapb.ScheduledJob{
+		Name:     "nightly",
+		Schedule: "@daily",
+		Job:      &vtctldatapb.ScheduledJob_Backup{Backup: &vtctldatapb.ScheduledJob_BackupJob{}},
+	})
+	require.NoError(t, err)
+
+	archive, err := ExportTopology(ctx, fromTS)
+	require.NoError(t, err)
+	assert.EqualValues(t, TopologyArchiveVersion, archive.Version)
+	assert.Len(t, archive.Cells, 2)
+	require.Len(t, archive.Shards, 2)
+	assert.Equal(t, "-80", archive.Shards[0].Name)
+	assert.True(t, archive.Keyspaces[0].Keyspace.ThrottlerConfig.Enabled)
+
+	// zone1 already exists in the destination topology.
+	_, err = ImportTopology(ctx, toTS, &vtctldatapb.ImportTopologyRequest{Archive: archive})
+	require.ErrorContains(t, err, "the topology already contains cell zone1")
+	keyspaces, err := toTS.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keyspaces)
+
+	want := &vtctldatapb.ImportTopologyResponse{
+		Created: []string{
+			"cell zone2",
+			"cells alias all",
+			"keyspace ks",
+			"vschema ks",
+			"shard ks/-80",
+			"shard ks/80-",
+			"routing rules",
+			"maintenance windows",
+			"scheduled job nightly",
+		},
+		Skipped: []string{"cell zone1"},
+	}
+	resp, err := ImportTopology(ctx, toTS, &vtctldatapb.ImportTopologyRequest{
+		Archive:    archive,
+		OnConflict: vtctldatapb.ImportTopologyRequest_SKIP,
+		DryRun:     true,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, want, resp)
+	keyspaces, err = toTS.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keyspaces)
+
+	resp, err = ImportTopology(ctx, toTS, &vtctldatapb.ImportTopologyRequest{
+		Archive:    archive,
+		OnConflict: vtctldatapb.ImportTopologyRequest_SKIP,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, want, resp)
+
+	imported, err := ExportTopology(ctx, toTS)
+	require.NoError(t, err)
+	imported.ExportedAt = archive.ExportedAt
+	imported.Cells = archive.Cells
+	utils.MustMatch(t, archive, imported)
+
+	// The records changed since the export are restored by overwriting them.
+	_, err = toTS.UpdateShardFields(ctx, "ks", "-80", func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = &topodatapb.TabletAlias{Cell: "zone1", Uid: 200}
+		return nil
+	})
+	require.NoError(t, err)
+	resp, err = ImportTopology(ctx, toTS, &vtctldatapb.ImportTopologyRequest{
+		Archive:    archive,
+		OnConflict: vtctldatapb.ImportTopologyRequest_OVERWRITE,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Created)
+	assert.Contains(t, resp.Overwritten, "shard ks/-80")
+	si, err := toTS.GetShard(ctx, "ks", "-80")
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, si.PrimaryAlias.Uid)
+
+	archive.Version = TopologyArchiveVersion + 1
+	_, err = ImportTopology(ctx, toTS, &vtctldatapb.ImportTopologyRequest{Archive: archive})
+	require.ErrorContains(t, err, "unsupported archive version")
+}
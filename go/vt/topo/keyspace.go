@@ -385,6 +385,14 @@ func (ts *Server) DeleteKeyspace(ctx context.Context, keyspace string) error {
 		return err
 	}
 
+	if err := ts.DeleteDynamicConfig(ctx, keyspace); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+
+	if err := ts.SaveQueryRules(ctx, keyspace, nil); err != nil {
+		return err
+	}
+
 	event.Dispatch(&events.KeyspaceChange{
 		KeyspaceName: keyspace,
 		Keyspace:     nil,
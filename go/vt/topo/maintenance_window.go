@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file provides the utility methods to save / retrieve the maintenance
+// windows in the topology global cell.
+
+// MaintenanceWindowsFile is the file where the maintenance windows are stored
+// in the global cell.
+const MaintenanceWindowsFile = "MaintenanceWindows"
+
+// SaveMaintenanceWindows saves the maintenance windows into the topo. The file
+// is removed when there are no windows.
+func (ts *Server) SaveMaintenanceWindows(ctx context.Context, mw *topodatapb.MaintenanceWindows) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := mw.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, MaintenanceWindowsFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, MaintenanceWindowsFile, data, nil)
+	return err
+}
+
+// GetMaintenanceWindows fetches the maintenance windows from the topo.
+func (ts *Server) GetMaintenanceWindows(ctx context.Context) (*topodatapb.MaintenanceWindows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mw := &topodatapb.MaintenanceWindows{}
+	data, _, err := ts.globalCell.Get(ctx, MaintenanceWindowsFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return mw, nil
+		}
+		return nil, err
+	}
+	if err := mw.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrapf(err, "bad maintenance windows data: %q", data)
+	}
+	return mw, nil
+}
+
+// InMaintenanceWindow tells whether t falls within a maintenance window of the
+// keyspace. See topoproto.InMaintenanceWindow.
+func (ts *Server) InMaintenanceWindow(ctx context.Context, keyspace string, t time.Time) (bool, error) {
+	mw, err := ts.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return false, err
+	}
+	return topoproto.InMaintenanceWindow(mw, keyspace, t)
+}
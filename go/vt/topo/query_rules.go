@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+)
+
+// This file provides the utility methods to save / retrieve the vttablet
+// query rules of a keyspace, stored next to its Keyspace record as the JSON
+// array understood by the rules package of the tabletserver.
+
+// QueryRulesPath returns the path of the query rules of the keyspace in the
+// global cell.
+func QueryRulesPath(keyspace string) string {
+	return path.Join(KeyspacesPath, keyspace, QueryRulesFile)
+}
+
+// GetQueryRules returns the query rules of the keyspace, or nil if it has none.
+func (ts *Server) GetQueryRules(ctx context.Context, keyspace string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, _, err := ts.globalCell.Get(ctx, QueryRulesPath(keyspace))
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// SaveQueryRules saves the query rules of the keyspace. The rules are
+// deleted if data is empty.
+func (ts *Server) SaveQueryRules(ctx context.Context, keyspace string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, QueryRulesPath(keyspace), nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := ts.globalCell.Update(ctx, QueryRulesPath(keyspace), data, nil)
+	return err
+}
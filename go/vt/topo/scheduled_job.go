@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file provides the utility methods to save / retrieve the jobs
+// scheduled in vtctld in the topology global cell.
+
+const (
+	scheduledJobsPath    = "scheduled_jobs"
+	scheduledJobFilename = "ScheduledJob"
+)
+
+func pathForScheduledJob(name string) string {
+	return path.Join(scheduledJobsPath, name, scheduledJobFilename)
+}
+
+// ScheduledJobInfo is a meta struct that contains the version of a
+// ScheduledJob.
+type ScheduledJobInfo struct {
+	version Version
+	*vtctldatapb.ScheduledJob
+}
+
+// GetScheduledJobNames returns the names of the existing scheduled jobs. They
+// are sorted by name.
+func (ts *Server) GetScheduledJobNames(ctx context.Context) ([]string, error) {
+	entries, err := ts.globalCell.ListDir(ctx, scheduledJobsPath, false /*full*/)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err == nil:
+		return DirEntriesToStringArray(entries), nil
+	default:
+		return nil, err
+	}
+}
+
+// CreateScheduledJob creates the given scheduled job, and returns the initial
+// ScheduledJobInfo. It fails with NodeExists if the job already exists.
+func (ts *Server) CreateScheduledJob(ctx context.Context, job *vtctldatapb.ScheduledJob) (*ScheduledJobInfo, error) {
+	contents, err := job.MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := ts.globalCell.Create(ctx, pathForScheduledJob(job.Name), contents)
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduledJobInfo{
+		version:      version,
+		ScheduledJob: job,
+	}, nil
+}
+
+// GetScheduledJob reads a scheduled job from the global cell.
+func (ts *Server) GetScheduledJob(ctx context.Context, name string) (*ScheduledJobInfo, error) {
+	contents, version, err := ts.globalCell.Get(ctx, pathForScheduledJob(name))
+	if err != nil {
+		return nil, err
+	}
+
+	job := &vtctldatapb.ScheduledJob{}
+	if err := job.UnmarshalVT(contents); err != nil {
+		return nil, err
+	}
+	return &ScheduledJobInfo{
+		version:      version,
+		ScheduledJob: job,
+	}, nil
+}
+
+// SaveScheduledJob saves the ScheduledJobInfo object. If the version is not
+// good any more, ErrBadVersion is returned.
+func (ts *Server) SaveScheduledJob(ctx context.Context, ji *ScheduledJobInfo) error {
+	contents, err := ji.ScheduledJob.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	version, err := ts.globalCell.Update(ctx, pathForScheduledJob(ji.Name), contents, ji.version)
+	if err != nil {
+		return err
+	}
+	ji.version = version
+	return nil
+}
+
+// UpdateScheduledJobFields is a high level helper to read a scheduled job,
+// update it with the provided function, and save it back, retrying on
+// BadVersion. If the update method returns ErrNoUpdateNeeded, nothing is
+// written, and nil, nil is returned.
+func (ts *Server) UpdateScheduledJobFields(ctx context.Context, name string, update func(*ScheduledJobInfo) error) (*ScheduledJobInfo, error) {
+	for {
+		ji, err := ts.GetScheduledJob(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if err = update(ji); err != nil {
+			if IsErrType(err, NoUpdateNeeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if err = ts.SaveScheduledJob(ctx, ji); !IsErrType(err, BadVersion) {
+			return ji, err
+		}
+	}
+}
+
+// DeleteScheduledJob deletes the specified scheduled job. After calling this
+// function, the ScheduledJobInfo object should not be used any more.
+func (ts *Server) DeleteScheduledJob(ctx context.Context, name string) error {
+	return ts.globalCell.Delete(ctx, pathForScheduledJob(name), nil)
+}
@@ -83,6 +83,10 @@ const (
 	ShardRoutingRulesFile  = "ShardRoutingRules"
 	CommonRoutingRulesFile = "Rules"
 	MirrorRulesFile        = "MirrorRules"
+	TenantRoutingRulesFile = "TenantRoutingRules"
+	PlanPinsFile           = "PlanPins"
+	RewriteRulesFile       = "RewriteRules"
+	QueryRulesFile         = "QueryRules"
 )
 
 // Path for all object types.
@@ -439,6 +439,57 @@ func (ts *Server) UpdateSrvKeyspaceThrottlerConfig(ctx context.Context, keyspace
 	return updatedCells, nil
 }
 
+// UpdateSrvKeyspaceWriteFence sets the write fence of the shard in the
+// SrvKeyspace of the given cells, or removes it if fence is nil.
+func (ts *Server) UpdateSrvKeyspaceWriteFence(ctx context.Context, keyspace, shard string, cells []string, fence *topodatapb.WriteFence) (err error) {
+	if err = CheckKeyspaceLocked(ctx, keyspace); err != nil {
+		return err
+	}
+
+	// The caller intends to update all cells in this case
+	if len(cells) == 0 {
+		cells, err = ts.GetCellInfoNames(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	rec := concurrency.AllErrorRecorder{}
+	for _, cell := range cells {
+		wg.Add(1)
+		go func(cell string) {
+			defer wg.Done()
+			srvKeyspace, err := ts.GetSrvKeyspace(ctx, cell, keyspace)
+			switch {
+			case err == nil:
+				if fence == nil {
+					delete(srvKeyspace.WriteFences, shard)
+				} else {
+					if srvKeyspace.WriteFences == nil {
+						srvKeyspace.WriteFences = make(map[string]*topodatapb.WriteFence)
+					}
+					srvKeyspace.WriteFences[shard] = fence
+				}
+				if err := ts.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace); err != nil {
+					rec.RecordError(err)
+					return
+				}
+			case IsErrType(err, NoNode):
+				// NOOP as not every cell will contain a serving tablet in the keyspace
+			default:
+				rec.RecordError(err)
+				return
+			}
+		}(cell)
+	}
+	wg.Wait()
+	if rec.HasErrors() {
+		return NewError(PartialResult, rec.Error().Error())
+	}
+	return nil
+}
+
 // UpdateDisableQueryService will make sure the disableQueryService is
 // set appropriately in tablet controls in srvKeyspace.
 func (ts *Server) UpdateDisableQueryService(ctx context.Context, keyspace string, shards []*ShardInfo, tabletType topodatapb.TabletType, cells []string, disableQueryService bool) (err error) {
@@ -219,6 +219,24 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	srvVSchema.MirrorRules = mr
 
+	trr, err := ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetTenantRoutingRules failed: %v", err)
+	}
+	srvVSchema.TenantRoutingRules = trr
+
+	pins, err := ts.GetPlanPins(ctx)
+	if err != nil {
+		return fmt.Errorf("GetPlanPins failed: %v", err)
+	}
+	srvVSchema.PlanPins = pins
+
+	rewriteRules, err := ts.GetRewriteRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetRewriteRules failed: %v", err)
+	}
+	srvVSchema.RewriteRules = rewriteRules
+
 	// now save the SrvVSchema in all cells in parallel
 	for _, cell := range cells {
 		wg.Add(1)
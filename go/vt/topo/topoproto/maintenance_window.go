@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topoproto
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"vitess.io/vitess/go/cron"
+	"vitess.io/vitess/go/protoutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// ValidateMaintenanceWindows checks that the maintenance windows have unique
+// names, valid schedules and positive durations.
+func ValidateMaintenanceWindows(mw *topodatapb.MaintenanceWindows) error {
+	names := make(map[string]bool, len(mw.GetWindows()))
+	for _, w := range mw.GetWindows() {
+		if w.Name == "" {
+			return fmt.Errorf("maintenance windows must have a name")
+		}
+		if names[w.Name] {
+			return fmt.Errorf("duplicate maintenance window %s", w.Name)
+		}
+		names[w.Name] = true
+		if _, err := cron.ParseSchedule(w.Schedule); err != nil {
+			return fmt.Errorf("maintenance window %s: %w", w.Name, err)
+		}
+		duration, ok, err := protoutil.DurationFromProto(w.Duration)
+		if err != nil {
+			return fmt.Errorf("maintenance window %s: %w", w.Name, err)
+		}
+		if !ok || duration <= 0 {
+			return fmt.Errorf("maintenance window %s must have a positive duration", w.Name)
+		}
+	}
+	return nil
+}
+
+// InMaintenanceWindow tells whether t falls within a maintenance window of
+// the keyspace. The windows of the keyspace replace the global windows for it.
+// Disruptive actions are never held when there are no windows, so it returns
+// true if the keyspace has no windows and there are no global windows.
+func InMaintenanceWindow(mw *topodatapb.MaintenanceWindows, keyspace string, t time.Time) (bool, error) {
+	var keyspaceWindows, globalWindows []*topodatapb.MaintenanceWindow
+	for _, w := range mw.GetWindows() {
+		switch {
+		case len(w.Keyspaces) == 0:
+			globalWindows = append(globalWindows, w)
+		case slices.Contains(w.Keyspaces, keyspace):
+			keyspaceWindows = append(keyspaceWindows, w)
+		}
+	}
+	windows := keyspaceWindows
+	if len(windows) == 0 {
+		windows = globalWindows
+	}
+	if len(windows) == 0 {
+		return true, nil
+	}
+	for _, w := range windows {
+		schedule, err := cron.ParseSchedule(w.Schedule)
+		if err != nil {
+			return false, fmt.Errorf("maintenance window %s: %w", w.Name, err)
+		}
+		duration, _, err := protoutil.DurationFromProto(w.Duration)
+		if err != nil {
+			return false, fmt.Errorf("maintenance window %s: %w", w.Name, err)
+		}
+		if schedule.Covers(t, duration) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
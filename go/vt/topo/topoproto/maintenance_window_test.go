@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topoproto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	window := func(name, schedule string, duration time.Duration) *topodatapb.MaintenanceWindow {
+		return &topodatapb.MaintenanceWindow{
+			Name:     name,
+			Schedule: schedule,
+			Duration: protoutil.DurationToProto(duration),
+		}
+	}
+	tests := []struct {
+		name    string
+		windows []*topodatapb.MaintenanceWindow
+		err     string
+	}{
+		{
+			name:    "valid",
+			windows: []*topodatapb.MaintenanceWindow{window("nightly", "0 2 * * *", 4*time.Hour), window("weekend", "0 0 * * 6", 48*time.Hour)},
+		},
+		{
+			name:    "no name",
+			windows: []*topodatapb.MaintenanceWindow{window("", "0 2 * * *", time.Hour)},
+			err:     "maintenance windows must have a name",
+		},
+		{
+			name:    "duplicate",
+			windows: []*topodatapb.MaintenanceWindow{window("nightly", "0 2 * * *", time.Hour), window("nightly", "0 3 * * *", time.Hour)},
+			err:     "duplicate maintenance window nightly",
+		},
+		{
+			name:    "bad schedule",
+			windows: []*topodatapb.MaintenanceWindow{window("nightly", "0 25 * * *", time.Hour)},
+			err:     "maintenance window nightly: invalid schedule",
+		},
+		{
+			name:    "no duration",
+			windows: []*topodatapb.MaintenanceWindow{window("nightly", "0 2 * * *", 0)},
+			err:     "maintenance window nightly must have a positive duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMaintenanceWindows(&topodatapb.MaintenanceWindows{Windows: tt.windows})
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	mw := &topodatapb.MaintenanceWindows{
+		Windows: []*topodatapb.MaintenanceWindow{
+			{
+				Name:     "nightly",
+				Schedule: "0 2 * * *",
+				Duration: protoutil.DurationToProto(4 * time.Hour),
+			},
+			{
+				Name:      "customer",
+				Schedule:  "0 22 * * *",
+				Duration:  protoutil.DurationToProto(time.Hour),
+				Keyspaces: []string{"customer"},
+			},
+		},
+	}
+	night := time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC)
+	evening := time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		keyspace string
+		at       time.Time
+		want     bool
+	}{
+		{"commerce", night, true},
+		{"commerce", evening, false},
+		// The windows of the keyspace replace the global ones.
+		{"customer", night, false},
+		{"customer", evening, true},
+	}
+	for _, tt := range tests {
+		in, err := InMaintenanceWindow(mw, tt.keyspace, tt.at)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, in, "%s at %v", tt.keyspace, tt.at)
+	}
+
+	// The actions are never held without windows.
+	in, err := InMaintenanceWindow(&topodatapb.MaintenanceWindows{}, "commerce", evening)
+	require.NoError(t, err)
+	assert.True(t, in)
+}
@@ -30,9 +30,12 @@ import (
 
 func TestRebuildVSchema(t *testing.T) {
 	emptySrvVSchema := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:        &vschemapb.MirrorRules{},
+		RoutingRules:       &vschemapb.RoutingRules{},
+		ShardRoutingRules:  &vschemapb.ShardRoutingRules{},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{},
+		PlanPins:           &vschemapb.PlanPins{},
+		RewriteRules:       &vschemapb.RewriteRules{},
 	}
 
 	// Set up topology.
@@ -54,9 +57,12 @@ func TestRebuildVSchema(t *testing.T) {
 
 	// create a keyspace, rebuild, should see an empty entry
 	emptyKs1SrvVSchema := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:        &vschemapb.MirrorRules{},
+		RoutingRules:       &vschemapb.RoutingRules{},
+		ShardRoutingRules:  &vschemapb.ShardRoutingRules{},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{},
+		PlanPins:           &vschemapb.PlanPins{},
+		RewriteRules:       &vschemapb.RewriteRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": {},
 		},
@@ -87,9 +93,12 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted1 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:        &vschemapb.MirrorRules{},
+		RoutingRules:       &vschemapb.RoutingRules{},
+		ShardRoutingRules:  &vschemapb.ShardRoutingRules{},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{},
+		PlanPins:           &vschemapb.PlanPins{},
+		RewriteRules:       &vschemapb.RewriteRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 		},
@@ -132,9 +141,12 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted2 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      &vschemapb.RoutingRules{},
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:        &vschemapb.MirrorRules{},
+		RoutingRules:       &vschemapb.RoutingRules{},
+		ShardRoutingRules:  &vschemapb.ShardRoutingRules{},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{},
+		PlanPins:           &vschemapb.PlanPins{},
+		RewriteRules:       &vschemapb.RewriteRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 			"ks2": keyspace2,
@@ -171,9 +183,12 @@ func TestRebuildVSchema(t *testing.T) {
 		t.Errorf("RebuildVSchema failed: %v", err)
 	}
 	wanted3 := &vschemapb.SrvVSchema{
-		MirrorRules:       &vschemapb.MirrorRules{},
-		RoutingRules:      rr,
-		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
+		MirrorRules:        &vschemapb.MirrorRules{},
+		RoutingRules:       rr,
+		ShardRoutingRules:  &vschemapb.ShardRoutingRules{},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{},
+		PlanPins:           &vschemapb.PlanPins{},
+		RewriteRules:       &vschemapb.RewriteRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
 			"ks1": keyspace1,
 			"ks2": keyspace2,
@@ -218,6 +218,135 @@ func (ts *Server) GetShardRoutingRules(ctx context.Context) (*vschemapb.ShardRou
 	return srr, nil
 }
 
+// SaveTenantRoutingRules saves the tenant routing rules into the topo.
+func (ts *Server) SaveTenantRoutingRules(ctx context.Context, tenantRoutingRules *vschemapb.TenantRoutingRules) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := tenantRoutingRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, TenantRoutingRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, TenantRoutingRulesFile, data, nil)
+	return err
+}
+
+// GetTenantRoutingRules fetches the tenant routing rules from the topo.
+func (ts *Server) GetTenantRoutingRules(ctx context.Context) (*vschemapb.TenantRoutingRules, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	trr := &vschemapb.TenantRoutingRules{}
+	data, _, err := ts.globalCell.Get(ctx, TenantRoutingRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return trr, nil
+		}
+		return nil, err
+	}
+	err = trr.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid tenant routing rules: %q", data)
+	}
+	return trr, nil
+}
+
+// SavePlanPins saves the plan pins into the topo.
+func (ts *Server) SavePlanPins(ctx context.Context, planPins *vschemapb.PlanPins) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := planPins.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, PlanPinsFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, PlanPinsFile, data, nil)
+	return err
+}
+
+// GetPlanPins fetches the plan pins from the topo.
+func (ts *Server) GetPlanPins(ctx context.Context) (*vschemapb.PlanPins, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pins := &vschemapb.PlanPins{}
+	data, _, err := ts.globalCell.Get(ctx, PlanPinsFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return pins, nil
+		}
+		return nil, err
+	}
+	err = pins.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid plan pins: %q", data)
+	}
+	return pins, nil
+}
+
+// SaveRewriteRules saves the query rewrite rules into the topo.
+func (ts *Server) SaveRewriteRules(ctx context.Context, rewriteRules *vschemapb.RewriteRules) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := rewriteRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if err := ts.globalCell.Delete(ctx, RewriteRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, RewriteRulesFile, data, nil)
+	return err
+}
+
+// GetRewriteRules fetches the query rewrite rules from the topo.
+func (ts *Server) GetRewriteRules(ctx context.Context) (*vschemapb.RewriteRules, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rules := &vschemapb.RewriteRules{}
+	data, _, err := ts.globalCell.Get(ctx, RewriteRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return rules, nil
+		}
+		return nil, err
+	}
+	err = rules.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid rewrite rules: %q", data)
+	}
+	return rules, nil
+}
+
 // CreateKeyspaceRoutingRules wraps the underlying Conn.Create.
 func (ts *Server) CreateKeyspaceRoutingRules(ctx context.Context, value *vschemapb.KeyspaceRoutingRules) error {
 	if err := ctx.Err(); err != nil {
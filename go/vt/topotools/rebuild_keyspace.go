@@ -139,6 +139,12 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 			if !si.GetIsPrimaryServing() {
 				continue
 			}
+			if si.WriteFence != nil {
+				if srvKeyspace.WriteFences == nil {
+					srvKeyspace.WriteFences = make(map[string]*topodatapb.WriteFence)
+				}
+				srvKeyspace.WriteFences[si.ShardName()] = si.WriteFence
+			}
 			// for each type this shard is supposed to serve,
 			// add it to srvKeyspace.Partitions
 			for _, tabletType := range servedTypes {
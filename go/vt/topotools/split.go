@@ -17,8 +17,8 @@ limitations under the License.
 package topotools
 
 import (
-	"errors"
 	"fmt"
+	"slices"
 
 	"vitess.io/vitess/go/vt/key"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -49,28 +49,31 @@ func ValidateForReshard(sourceShards, targetShards []*topo.ShardInfo) error {
 	return nil
 }
 
+// MergedKeyRange returns the keyrange of the shard into which sourceShards
+// can be merged. It returns an error if there are less than two source shards,
+// or if they are not adjacent.
+func MergedKeyRange(sourceShards []*topo.ShardInfo) (*topodatapb.KeyRange, error) {
+	if len(sourceShards) < 2 {
+		return nil, fmt.Errorf("at least two source shards are needed for a merge, got %d", len(sourceShards))
+	}
+	return combineKeyRanges(sourceShards)
+}
+
 func combineKeyRanges(shards []*topo.ShardInfo) (*topodatapb.KeyRange, error) {
 	if len(shards) == 0 {
 		return nil, fmt.Errorf("there are no shards to combine")
 	}
-	result := shards[0].KeyRange
-	krmap := make(map[string]*topodatapb.KeyRange)
-	for _, si := range shards[1:] {
-		krmap[si.ShardName()] = si.KeyRange
-	}
-	for len(krmap) != 0 {
-		foundOne := false
-		for k, kr := range krmap {
-			newkr, ok := key.KeyRangeAdd(result, kr)
-			if ok {
-				foundOne = true
-				result = newkr
-				delete(krmap, k)
-			}
-		}
-		if !foundOne {
-			return nil, errors.New("shards don't form a contiguous keyrange")
+	sorted := slices.Clone(shards)
+	slices.SortFunc(sorted, func(a, b *topo.ShardInfo) int {
+		return key.KeyRangeStartCompare(a.KeyRange, b.KeyRange)
+	})
+	result := sorted[0].KeyRange
+	for i, si := range sorted[1:] {
+		newkr, ok := key.KeyRangeAdd(result, si.KeyRange)
+		if !ok {
+			return nil, fmt.Errorf("shards don't form a contiguous keyrange: %v and %v are not adjacent", sorted[i].ShardName(), si.ShardName())
 		}
+		result = newkr
 	}
 	return result, nil
 }
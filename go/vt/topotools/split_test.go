@@ -21,11 +21,24 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/topo"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
+func buildShards(shards []string) []*topo.ShardInfo {
+	sis := make([]*topo.ShardInfo, 0, len(shards))
+	for _, shard := range shards {
+		_, kr, err := topo.ValidateShardName(shard)
+		if err != nil {
+			panic(err)
+		}
+		sis = append(sis, topo.NewShardInfo("", shard, &topodatapb.Shard{KeyRange: kr}, nil))
+	}
+	return sis
+}
+
 func TestValidateForReshard(t *testing.T) {
 	testcases := []struct {
 		sources []string
@@ -74,20 +87,16 @@ func TestValidateForReshard(t *testing.T) {
 	}, {
 		sources: []string{"-30", "20-80"},
 		targets: []string{"-40", "40-"},
-		out:     "shards don't form a contiguous keyrange",
+		out:     "shards don't form a contiguous keyrange: -30 and 20-80 are not adjacent",
+	}, {
+		sources: []string{"-40", "80-"},
+		targets: []string{"-"},
+		out:     "shards don't form a contiguous keyrange: -40 and 80- are not adjacent",
+	}, {
+		sources: []string{"40-80", "-40"},
+		targets: []string{"-80"},
+		out:     "",
 	}}
-	buildShards := func(shards []string) []*topo.ShardInfo {
-		sis := make([]*topo.ShardInfo, 0, len(shards))
-		for _, shard := range shards {
-			_, kr, err := topo.ValidateShardName(shard)
-			if err != nil {
-				panic(err)
-			}
-			sis = append(sis, topo.NewShardInfo("", shard, &topodatapb.Shard{KeyRange: kr}, nil))
-		}
-		return sis
-	}
-
 	for _, tcase := range testcases {
 		sources := buildShards(tcase.sources)
 		targets := buildShards(tcase.targets)
@@ -99,3 +108,35 @@ func TestValidateForReshard(t *testing.T) {
 		}
 	}
 }
+
+func TestMergedKeyRange(t *testing.T) {
+	testcases := []struct {
+		sources []string
+		out     string
+		err     string
+	}{{
+		sources: []string{"-40", "40-80"},
+		out:     "-80",
+	}, {
+		sources: []string{"c0-", "80-c0", "40-80"},
+		out:     "40-",
+	}, {
+		sources: []string{"-80", "80-"},
+		out:     "-",
+	}, {
+		sources: []string{"-40"},
+		err:     "at least two source shards are needed for a merge, got 1",
+	}, {
+		sources: []string{"-40", "80-c0"},
+		err:     "shards don't form a contiguous keyrange: -40 and 80-c0 are not adjacent",
+	}}
+	for _, tcase := range testcases {
+		kr, err := MergedKeyRange(buildShards(tcase.sources))
+		if tcase.err != "" {
+			assert.EqualError(t, err, tcase.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tcase.out, key.KeyRangeString(kr))
+	}
+}
@@ -94,6 +94,26 @@ Consequently, no IsolationLevel must be specified while calling BeginContext. Do
 will result in an error.
 
 
+Routing
+
+The queries are routed according to the target of the connection, for example
+"@replica". A query can be routed differently with the context it's executed
+with: UseKeyspaceShard routes it to a shard of a keyspace, and WithTabletType
+to tablets of another type:
+
+  ctx = vitessdriver.UseKeyspaceShard(ctx, "customer", "-80")
+  ctx = vitessdriver.WithTabletType(ctx, topodatapb.TabletType_REPLICA)
+  rows, err := db.QueryContext(ctx, "select * from corder")
+
+To read its own writes from replicas, an application gets a token with
+NewWriteToken after writing, and reads with a context of WithReadYourWrites
+with the token. The reads are routed to the primary tablets until the
+ReadYourWritesWindow of the Configuration has passed since the writes.
+
+The queries failing with a transient error, for example during a reparent,
+are retried according to the RetryPolicy of the Configuration.
+
+
 Named arguments
 
 Vitess supports positional or named arguments. However, intermixing is not allowed
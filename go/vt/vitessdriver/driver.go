@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -235,6 +236,19 @@ type Configuration struct {
 	// SessionToken is a protobuf encoded vtgatepb.Session represented as base64, which
 	// can be used to distribute a transaction over the wire.
 	SessionToken string
+
+	// ReadYourWritesWindow is how long after the creation of a write token the
+	// queries executed with it by WithReadYourWrites are routed to the primary
+	// tablets. It should be above the replication lag of the tablets serving
+	// the reads.
+	//
+	// Default: 30s
+	ReadYourWritesWindow time.Duration `json:",omitempty"`
+
+	// RetryPolicy retries the queries failing with a transient error.
+	//
+	// Default: none
+	RetryPolicy *RetryPolicy `json:",omitempty"`
 }
 
 // toJSON converts Configuration to the JSON string which is required by the
@@ -265,6 +279,11 @@ type conn struct {
 	convert *converter
 	conn    *vtgateconn.VTGateConn
 	session *vtgateconn.VTGateSession
+
+	// baseTarget is the target of the session changed to routedTarget for
+	// the routing of a query.
+	baseTarget   string
+	routedTarget string
 }
 
 func (c *conn) dial(ctx context.Context) error {
@@ -491,7 +510,7 @@ func (c *conn) Exec(query string, args []driver.Value) (driver.Result, error) {
 		return nil, err
 	}
 
-	qr, err := c.session.Execute(ctx, query, bindVars, false)
+	qr, err := c.execute(ctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
@@ -507,7 +526,7 @@ func (c *conn) ExecContext(ctx context.Context, query string, args []driver.Name
 	if err != nil {
 		return nil, err
 	}
-	qr, err := c.session.Execute(ctx, query, bv, false)
+	qr, err := c.execute(ctx, query, bv)
 	if err != nil {
 		return nil, err
 	}
@@ -522,14 +541,14 @@ func (c *conn) Query(query string, args []driver.Value) (driver.Rows, error) {
 	}
 
 	if c.cfg.Streaming {
-		stream, err := c.session.StreamExecute(ctx, query, bindVars)
+		stream, err := c.streamExecute(ctx, query, bindVars)
 		if err != nil {
 			return nil, err
 		}
 		return newStreamingRows(stream, c.convert), nil
 	}
 
-	qr, err := c.session.Execute(ctx, query, bindVars, false)
+	qr, err := c.execute(ctx, query, bindVars)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +558,7 @@ func (c *conn) Query(query string, args []driver.Value) (driver.Rows, error) {
 func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	// special case for serializing the current sessionFromToken state
 	if query == "vt_session_token" {
+		c.restoreTarget()
 		return newSessionTokenRow(c.session.SessionPb(), c.convert)
 	}
 
@@ -548,20 +568,46 @@ func (c *conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 	}
 
 	if c.cfg.Streaming {
-		stream, err := c.session.StreamExecute(ctx, query, bv)
+		stream, err := c.streamExecute(ctx, query, bv)
 		if err != nil {
 			return nil, err
 		}
 		return newStreamingRows(stream, c.convert), nil
 	}
 
-	qr, err := c.session.Execute(ctx, query, bv, false)
+	qr, err := c.execute(ctx, query, bv)
 	if err != nil {
 		return nil, err
 	}
 	return newRows(qr, c.convert), nil
 }
 
+// execute executes a query on the session, with the routing of the context,
+// and retries it according to the retry policy.
+func (c *conn) execute(ctx context.Context, query string, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	if err := c.routeQuery(ctx); err != nil {
+		return nil, err
+	}
+	defer c.restoreTarget()
+
+	inTransaction := c.session.InTransaction()
+	for retry := 0; ; retry++ {
+		qr, err := c.session.Execute(ctx, query, bindVars, false)
+		if err == nil || inTransaction || !c.cfg.RetryPolicy.retry(ctx, retry, err) {
+			return qr, err
+		}
+	}
+}
+
+// streamExecute executes a query on the session with the routing of the
+// context. The target of the session is restored by the next query.
+func (c *conn) streamExecute(ctx context.Context, query string, bindVars map[string]*querypb.BindVariable) (sqltypes.ResultStream, error) {
+	if err := c.routeQuery(ctx); err != nil {
+		return nil, err
+	}
+	return c.session.StreamExecute(ctx, query, bindVars)
+}
+
 type stmt struct {
 	c     *conn
 	query string
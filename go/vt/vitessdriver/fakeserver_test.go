@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 
@@ -28,7 +29,9 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
 )
 
@@ -36,6 +39,10 @@ import (
 type (
 	fakeVTGateService struct {
 		execMap map[string]execMapResponse
+
+		mu sync.Mutex
+		// executions counts the executions of the queries.
+		executions map[string]int
 	}
 
 	execMapResponse struct {
@@ -44,6 +51,9 @@ type (
 		result      *sqltypes.Result
 		session     *vtgatepb.Session
 		err         error
+		// failures is the number of the first executions failing with err,
+		// or all of them if 0.
+		failures int
 	}
 
 	// queryExecute contains all the fields we use to test Execute
@@ -81,6 +91,9 @@ func (f *fakeVTGateService) Execute(
 	if !query.Equal(execCase.execQuery) {
 		return session, nil, fmt.Errorf("Execute request mismatch: got %+v, want %+v", query, execCase.execQuery)
 	}
+	if execCase.err != nil && (execCase.failures == 0 || f.execute(sql) <= execCase.failures) {
+		return session, nil, execCase.err
+	}
 	if execCase.session != nil {
 		proto.Reset(session)
 		proto.Merge(session, execCase.session)
@@ -88,6 +101,15 @@ func (f *fakeVTGateService) Execute(
 	return session, execCase.result, nil
 }
 
+// execute counts an execution of the query, and returns the number of its
+// executions.
+func (f *fakeVTGateService) execute(sql string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.executions[sql]++
+	return f.executions[sql]
+}
+
 // ExecuteBatch is part of the VTGateService interface
 func (f *fakeVTGateService) ExecuteBatch(ctx context.Context, session *vtgatepb.Session, sql []string, bindVariables []map[string]*querypb.BindVariable) (*vtgatepb.Session, []sqltypes.QueryResponse, error) {
 	if len(sql) == 1 {
@@ -225,7 +247,8 @@ func (f *fakeVTGateService) HandlePanic(err *error) {
 // CreateFakeServer returns the fake server for the tests
 func CreateFakeServer() vtgateservice.VTGateService {
 	return &fakeVTGateService{
-		execMap: createExecMap(),
+		execMap:    createExecMap(),
+		executions: make(map[string]int),
 	}
 }
 
@@ -330,6 +353,49 @@ func createExecMap() map[string]execMapResponse {
 				TargetString: "@primary",
 			},
 		},
+		"routedRequest": {
+			execQuery: &queryExecute{
+				SQL: "routedRequest",
+				Session: &vtgatepb.Session{
+					TargetString: "ks:-80@replica",
+					Autocommit:   true,
+				},
+			},
+			result: &sqltypes.Result{},
+		},
+		"primaryRequest": {
+			execQuery: &queryExecute{
+				SQL: "primaryRequest",
+				Session: &vtgatepb.Session{
+					TargetString: "@primary",
+					Autocommit:   true,
+				},
+			},
+			result: &sqltypes.Result{},
+		},
+		"retriedRequest": {
+			execQuery: &queryExecute{
+				SQL: "retriedRequest",
+				Session: &vtgatepb.Session{
+					TargetString: "@rdonly",
+					Autocommit:   true,
+				},
+			},
+			result:   &sqltypes.Result{},
+			err:      vterrors.Errorf(vtrpcpb.Code_CLUSTER_EVENT, "primary is not serving, there may be a reparent operation in progress"),
+			failures: 2,
+		},
+		"unavailableRequest": {
+			execQuery: &queryExecute{
+				SQL: "unavailableRequest",
+				Session: &vtgatepb.Session{
+					TargetString: "@rdonly",
+					Autocommit:   true,
+				},
+			},
+			result: &sqltypes.Result{},
+			err:    vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available"),
+		},
 		"use @rdonly": {
 			execQuery: &queryExecute{
 				SQL: "use @rdonly",
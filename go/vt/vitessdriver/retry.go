@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	defaultRetryBackoff    = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy retries the queries failing with a transient error, that is
+// when vtgate or the tablets are unavailable, or while a shard is reparented
+// or resharded. The queries of transactions and the streaming queries are
+// not retried.
+//
+// A write failing while its tablet becomes unavailable may have been applied,
+// so the writes retried outside of transactions should be idempotent.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a query is retried.
+	MaxRetries int
+
+	// Backoff is the time to wait before the first retry, doubled for each
+	// next retry.
+	//
+	// Default: 100ms
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum time to wait before a retry.
+	//
+	// Default: 5s
+	MaxBackoff time.Duration
+}
+
+// retry returns whether a query which failed with err should be retried, once
+// the backoff of the retry has passed. retry is the number of the retries
+// already done.
+func (p *RetryPolicy) retry(ctx context.Context, retry int, err error) bool {
+	if p == nil || retry >= p.MaxRetries {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_CLUSTER_EVENT:
+	default:
+		return false
+	}
+
+	backoff, maxBackoff := p.Backoff, p.MaxBackoff
+	if backoff == 0 {
+		backoff = defaultRetryBackoff
+	}
+	if maxBackoff == 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	for i := 0; i < retry && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maxBackoff)
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// defaultReadYourWritesWindow is the default ReadYourWritesWindow.
+const defaultReadYourWritesWindow = 30 * time.Second
+
+// routingKey is the context key of the routing of the queries.
+type routingKey struct{}
+
+// routing overrides parts of the target of the session for the queries
+// executed with a context.
+type routing struct {
+	keyspace   string
+	shard      string
+	tabletType topodatapb.TabletType
+	// writeToken is the token of the writes the queries must read.
+	writeToken string
+}
+
+func routingFromContext(ctx context.Context) routing {
+	r, _ := ctx.Value(routingKey{}).(routing)
+	return r
+}
+
+// UseKeyspaceShard returns a context routing the queries executed with it to
+// a shard of a keyspace, like "USE keyspace:shard" would, but without
+// changing the target of the connection.
+func UseKeyspaceShard(ctx context.Context, keyspace, shard string) context.Context {
+	r := routingFromContext(ctx)
+	r.keyspace, r.shard = keyspace, shard
+	return context.WithValue(ctx, routingKey{}, r)
+}
+
+// WithTabletType returns a context routing the queries executed with it to
+// tablets of a type, for example topodatapb.TabletType_REPLICA, instead of
+// the tablet type of the target of the connection.
+func WithTabletType(ctx context.Context, tabletType topodatapb.TabletType) context.Context {
+	r := routingFromContext(ctx)
+	r.tabletType = tabletType
+	return context.WithValue(ctx, routingKey{}, r)
+}
+
+// NewWriteToken returns a token of the writes done so far, which can be
+// passed around, for example to another request of the same user, and
+// then to WithReadYourWrites.
+func NewWriteToken() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// WithReadYourWrites returns a context with which the queries read the writes
+// of a token returned by NewWriteToken. Until the ReadYourWritesWindow of
+// the configuration has passed since the token was created, the queries are
+// routed to the primary tablets, and afterwards to the tablet type of the
+// context or of the connection, whose replication lag is expected to be
+// lower than the window.
+func WithReadYourWrites(ctx context.Context, token string) context.Context {
+	r := routingFromContext(ctx)
+	r.writeToken = token
+	return context.WithValue(ctx, routingKey{}, r)
+}
+
+// target returns the target of a query of the connection whose target is
+// base, with the routing of the context.
+func (r routing) target(base string, readYourWritesWindow time.Duration) (string, error) {
+	keyspaceShard, tabletType, hasTabletType := strings.Cut(base, "@")
+	if r.keyspace != "" {
+		keyspaceShard = r.keyspace
+		if r.shard != "" {
+			keyspaceShard += ":" + r.shard
+		}
+	}
+	if r.tabletType != topodatapb.TabletType_UNKNOWN {
+		tabletType, hasTabletType = topoproto.TabletTypeLString(r.tabletType), true
+	}
+	if r.writeToken != "" {
+		written, err := strconv.ParseInt(r.writeToken, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid write token %q", r.writeToken)
+		}
+		if time.Since(time.Unix(0, written)) < readYourWritesWindow {
+			tabletType, hasTabletType = topoproto.TabletTypeLString(topodatapb.TabletType_PRIMARY), true
+		}
+	}
+	if !hasTabletType {
+		return keyspaceShard, nil
+	}
+	return keyspaceShard + "@" + tabletType, nil
+}
+
+// routeQuery sets the target of the session for a query executed with the
+// context. The target is restored by restoreTarget.
+func (c *conn) routeQuery(ctx context.Context) error {
+	c.restoreTarget()
+	r := routingFromContext(ctx)
+	if r == (routing{}) {
+		return nil
+	}
+
+	window := c.cfg.ReadYourWritesWindow
+	if window == 0 {
+		window = defaultReadYourWritesWindow
+	}
+	session := c.session.SessionPb()
+	target, err := r.target(session.TargetString, window)
+	if err != nil {
+		return err
+	}
+	if target == session.TargetString {
+		return nil
+	}
+	c.baseTarget, c.routedTarget = session.TargetString, target
+	session.TargetString = target
+	return nil
+}
+
+// restoreTarget restores the target of the session changed by routeQuery.
+// The target is kept if it was changed by the query, for example by a USE
+// statement.
+func (c *conn) restoreTarget() {
+	if c.routedTarget == "" {
+		return
+	}
+	if session := c.session.SessionPb(); session.TargetString == c.routedTarget {
+		session.TargetString = c.baseTarget
+	}
+	c.baseTarget, c.routedTarget = "", ""
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vitessdriver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestRoutingTarget(t *testing.T) {
+	ctx := context.Background()
+	oldToken := strconv.FormatInt(time.Now().Add(-time.Minute).UnixNano(), 10)
+	testcases := []struct {
+		ctx  context.Context
+		base string
+		want string
+	}{{
+		ctx:  UseKeyspaceShard(ctx, "ks", "-80"),
+		base: "@replica",
+		want: "ks:-80@replica",
+	}, {
+		ctx:  UseKeyspaceShard(ctx, "ks", ""),
+		base: "other:80-",
+		want: "ks",
+	}, {
+		ctx:  WithTabletType(ctx, topodatapb.TabletType_RDONLY),
+		base: "ks",
+		want: "ks@rdonly",
+	}, {
+		ctx:  WithTabletType(UseKeyspaceShard(ctx, "ks", "0"), topodatapb.TabletType_REPLICA),
+		base: "@primary",
+		want: "ks:0@replica",
+	}, {
+		ctx:  WithReadYourWrites(WithTabletType(ctx, topodatapb.TabletType_REPLICA), NewWriteToken()),
+		base: "ks",
+		want: "ks@primary",
+	}, {
+		ctx:  WithReadYourWrites(ctx, oldToken),
+		base: "ks@replica",
+		want: "ks@replica",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			target, err := routingFromContext(tc.ctx).target(tc.base, 30*time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, target)
+		})
+	}
+
+	_, err := routingFromContext(WithReadYourWrites(ctx, "token")).target("ks", time.Second)
+	assert.EqualError(t, err, `invalid write token "token"`)
+}
+
+func TestRouting(t *testing.T) {
+	db, err := Open(testAddress, "@rdonly")
+	require.NoError(t, err)
+	defer db.Close()
+	// The queries share the session of a single connection.
+	db.SetMaxOpenConns(1)
+
+	ctx := WithTabletType(UseKeyspaceShard(context.Background(), "ks", "-80"), topodatapb.TabletType_REPLICA)
+	_, err = db.ExecContext(ctx, "routedRequest")
+	require.NoError(t, err)
+	rows, err := db.QueryContext(WithReadYourWrites(context.Background(), NewWriteToken()), "primaryRequest")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	// The target of the connection is restored.
+	_, err = db.ExecContext(context.Background(), "request", int64(0))
+	require.NoError(t, err)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	db, err := OpenWithConfiguration(Configuration{
+		Address:     testAddress,
+		Target:      "@rdonly",
+		RetryPolicy: &RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(context.Background(), "retriedRequest")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), "unavailableRequest")
+	assert.ErrorContains(t, err, "no healthy tablet available")
+
+	// The errors which aren't transient aren't retried.
+	var policy *RetryPolicy
+	assert.False(t, policy.retry(context.Background(), 0, err))
+	policy = &RetryPolicy{MaxRetries: 1}
+	assert.False(t, policy.retry(context.Background(), 0, assert.AnError))
+	assert.False(t, policy.retry(context.Background(), 1, err))
+}
@@ -429,6 +429,7 @@ func (api *API) Handler() http.Handler {
 	router.HandleFunc("/transaction/{cluster_id}/{dtid}/info", httpAPI.Adapt(vtadminhttp.GetTransactionInfo)).Name("API.GetTransactionInfo")
 	router.HandleFunc("/vschema/{cluster_id}/{keyspace}", httpAPI.Adapt(vtadminhttp.GetVSchema)).Name("API.GetVSchema")
 	router.HandleFunc("/vschemas", httpAPI.Adapt(vtadminhttp.GetVSchemas)).Name("API.GetVSchemas")
+	router.HandleFunc("/vindex_advice", httpAPI.Adapt(vtadminhttp.GetVindexAdvice)).Name("API.GetVindexAdvice")
 	router.HandleFunc("/vdiff/{cluster_id}/", httpAPI.Adapt(vtadminhttp.VDiffCreate)).Name("API.VDiffCreate").Methods("POST")
 	router.HandleFunc("/vdiff/{cluster_id}/show", httpAPI.Adapt(vtadminhttp.VDiffShow)).Name("API.VDiffShow")
 	router.HandleFunc("/vtctlds", httpAPI.Adapt(vtadminhttp.GetVtctlds)).Name("API.GetVtctlds")
@@ -1706,6 +1707,53 @@ func (api *API) GetVSchemas(ctx context.Context, req *vtadminpb.GetVSchemasReque
 	}, nil
 }
 
+// GetVindexAdvice is part of the vtadminpb.VTAdminServer interface.
+func (api *API) GetVindexAdvice(ctx context.Context, req *vtadminpb.GetVindexAdviceRequest) (*vtadminpb.GetVindexAdviceResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "API.GetVindexAdvice")
+	defer span.Finish()
+
+	clusters, _ := api.getClustersForRequest(req.ClusterIds)
+
+	var (
+		advice []*vtadminpb.VindexAdvice
+		wg     sync.WaitGroup
+		er     concurrency.AllErrorRecorder
+		m      sync.Mutex
+	)
+
+	for _, c := range clusters {
+		if !api.authz.IsAuthorized(ctx, c.ID, rbac.VindexAdviceResource, rbac.GetAction) {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(c *cluster.Cluster) {
+			defer wg.Done()
+
+			ca, err := c.GetVindexAdvice(ctx)
+			if err != nil {
+				er.RecordError(fmt.Errorf("GetVindexAdvice(cluster = %s): %w", c.ID, err))
+				return
+			}
+
+			m.Lock()
+			advice = append(advice, ca...)
+			m.Unlock()
+		}(c)
+	}
+
+	wg.Wait()
+
+	if er.HasErrors() {
+		return nil, er.Error()
+	}
+
+	return &vtadminpb.GetVindexAdviceResponse{
+		Advice: advice,
+	}, nil
+}
+
 // VDiffCreate is part of the vtadminpb.VTAdminServer interface.
 func (api *API) VDiffCreate(ctx context.Context, req *vtadminpb.VDiffCreateRequest) (*vtctldatapb.VDiffCreateResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "API.VDiffCreate")
@@ -4195,6 +4195,153 @@ func TestGetVSchemas(t *testing.T) {
 	}
 }
 
+func TestGetVindexAdvice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		clusterAdvice [][]*vtadminpb.VindexAdvice
+		dbconfigs     map[string]vtadmintestutil.Dbcfg
+		req           *vtadminpb.GetVindexAdviceRequest
+		expected      []*vtadminpb.VindexAdvice
+		shouldErr     bool
+	}{
+		{
+			name: "multiple clusters",
+			clusterAdvice: [][]*vtadminpb.VindexAdvice{
+				/* cluster 0 */
+				{
+					{
+						Keyspace:   "ks1",
+						Table:      "user",
+						Column:     "email",
+						QueryCount: 42,
+						Digests:    []string{"d1", "d2"},
+					},
+				},
+				/* cluster 1 */
+				{
+					{
+						Keyspace:   "ks2",
+						Table:      "orders",
+						Column:     "status",
+						QueryCount: 3,
+					},
+				},
+			},
+			req: &vtadminpb.GetVindexAdviceRequest{},
+			expected: []*vtadminpb.VindexAdvice{
+				{
+					Cluster: &vtadminpb.Cluster{
+						Id:   "c0",
+						Name: "cluster0",
+					},
+					Keyspace:   "ks1",
+					Table:      "user",
+					Column:     "email",
+					QueryCount: 42,
+					Digests:    []string{"d1", "d2"},
+				},
+				{
+					Cluster: &vtadminpb.Cluster{
+						Id:   "c1",
+						Name: "cluster1",
+					},
+					Keyspace:   "ks2",
+					Table:      "orders",
+					Column:     "status",
+					QueryCount: 3,
+				},
+			},
+		},
+		{
+			name: "filtered by cluster",
+			clusterAdvice: [][]*vtadminpb.VindexAdvice{
+				/* cluster 0 */
+				{
+					{
+						Keyspace:   "ks1",
+						Table:      "user",
+						Column:     "email",
+						QueryCount: 42,
+					},
+				},
+				/* cluster 1 */
+				{
+					{
+						Keyspace:   "ks2",
+						Table:      "orders",
+						Column:     "status",
+						QueryCount: 3,
+					},
+				},
+			},
+			req: &vtadminpb.GetVindexAdviceRequest{
+				ClusterIds: []string{"c1"},
+			},
+			expected: []*vtadminpb.VindexAdvice{
+				{
+					Cluster: &vtadminpb.Cluster{
+						Id:   "c1",
+						Name: "cluster1",
+					},
+					Keyspace:   "ks2",
+					Table:      "orders",
+					Column:     "status",
+					QueryCount: 3,
+				},
+			},
+		},
+		{
+			name: "one cluster errors",
+			clusterAdvice: [][]*vtadminpb.VindexAdvice{
+				/* cluster 0 */
+				{},
+				/* cluster 1 */
+				{},
+			},
+			dbconfigs: map[string]vtadmintestutil.Dbcfg{
+				"c1": {ShouldErr: true},
+			},
+			req:       &vtadminpb.GetVindexAdviceRequest{},
+			shouldErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clusters := make([]*cluster.Cluster, len(tt.clusterAdvice))
+
+			for i, advice := range tt.clusterAdvice {
+				cid := fmt.Sprintf("c%d", i)
+
+				clusters[i] = vtadmintestutil.BuildCluster(t, vtadmintestutil.TestClusterConfig{
+					Cluster: &vtadminpb.Cluster{
+						Id:   cid,
+						Name: fmt.Sprintf("cluster%d", i),
+					},
+					VindexAdvice: advice,
+					DBConfig:     tt.dbconfigs[cid],
+				})
+			}
+
+			api := NewAPI(vtenv.NewTestEnv(), clusters, Options{})
+			resp, err := api.GetVindexAdvice(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, resp.Advice)
+		})
+	}
+}
+
 func TestGetVtctlds(t *testing.T) {
 	t.Parallel()
 
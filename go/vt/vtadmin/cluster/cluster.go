@@ -1322,6 +1322,50 @@ func (c *Cluster) getTablets(ctx context.Context) ([]*vtadminpb.Tablet, error) {
 	return c.parseTablets(rows)
 }
 
+// GetVindexAdvice returns the columns without vindex which the scatter queries
+// served by the cluster's vtgate filter on.
+func (c *Cluster) GetVindexAdvice(ctx context.Context) ([]*vtadminpb.VindexAdvice, error) {
+	span, ctx := trace.NewSpan(ctx, "Cluster.GetVindexAdvice")
+	defer span.Finish()
+
+	AnnotateSpan(c, span)
+
+	rows, err := c.DB.ShowVindexAdvice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var advice []*vtadminpb.VindexAdvice
+
+	// Fields are:
+	// Keyspace | Table | Column | Queries | Digests | FirstSeen | LastSeen.
+	for rows.Next() {
+		var (
+			a         = &vtadminpb.VindexAdvice{Cluster: c.ToProto()}
+			digests   string
+			firstSeen string
+			lastSeen  string
+		)
+
+		if err := rows.Scan(&a.Keyspace, &a.Table, &a.Column, &a.QueryCount, &digests, &firstSeen, &lastSeen); err != nil {
+			return nil, err
+		}
+
+		if digests != "" {
+			a.Digests = strings.Split(digests, ",")
+		}
+
+		advice = append(advice, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return advice, nil
+}
+
 // GetSchemaOptions contains the options that modify the behavior of the
 // (*Cluster).GetSchema method.
 type GetSchemaOptions struct {
@@ -44,3 +44,13 @@ func GetVSchemas(ctx context.Context, r Request, api *API) *JSONResponse {
 
 	return NewJSONResponse(vschemas, err)
 }
+
+// GetVindexAdvice implements the http wrapper for the
+// /vindex_advice[?cluster_id=[&cluster_id=]] route.
+func GetVindexAdvice(ctx context.Context, r Request, api *API) *JSONResponse {
+	advice, err := api.server.GetVindexAdvice(ctx, &vtadminpb.GetVindexAdviceRequest{
+		ClusterIds: r.URL.Query()["cluster_id"],
+	})
+
+	return NewJSONResponse(advice, err)
+}
@@ -138,5 +138,7 @@ const (
 
 	VExplainResource Resource = "VExplain"
 
+	VindexAdviceResource Resource = "VindexAdvice"
+
 	TabletFullStatusResource Resource = "TabletFullStatus"
 )
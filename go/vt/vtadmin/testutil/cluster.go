@@ -70,6 +70,9 @@ type TestClusterConfig struct {
 	// match the Cluster provided by this TestClusterConfig, so mutations are
 	// transparent to the caller.
 	Tablets []*vtadminpb.Tablet
+	// VindexAdvice provides the result of SHOW vitess_vindex_advice on this
+	// cluster's vtsql.DB.
+	VindexAdvice []*vtadminpb.VindexAdvice
 	// DBConfig controls the behavior of the cluster's vtsql.DB.
 	DBConfig Dbcfg
 	// Config controls certain cluster config options, primarily used to
@@ -123,7 +126,7 @@ func BuildCluster(t testing.TB, cfg TestClusterConfig) *cluster.Cluster {
 	clusterConf = clusterConf.WithVtctldTestConfigOptions(vtadminvtctldclient.WithDialFunc(func(ctx context.Context, addr string, ff grpcclient.FailFast, opts ...grpc.DialOption) (vtctldclient.VtctldClient, error) {
 		return cfg.VtctldClient, nil
 	})).WithVtSQLTestConfigOptions(vtsql.WithDialFunc(func(c vitessdriver.Configuration) (*sql.DB, error) {
-		return sql.OpenDB(&fakevtsql.Connector{Tablets: tablets, ShouldErr: cfg.DBConfig.ShouldErr}), nil
+		return sql.OpenDB(&fakevtsql.Connector{Tablets: tablets, VindexAdvice: cfg.VindexAdvice, ShouldErr: cfg.DBConfig.ShouldErr}), nil
 	}))
 
 	m.Lock()
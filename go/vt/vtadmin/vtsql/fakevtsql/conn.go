@@ -42,8 +42,9 @@ var (
 )
 
 type conn struct {
-	tablets   []*vtadminpb.Tablet
-	shouldErr bool
+	tablets      []*vtadminpb.Tablet
+	vindexAdvice []*vtadminpb.VindexAdvice
+	shouldErr    bool
 }
 
 var (
@@ -90,6 +91,28 @@ func (c *conn) QueryContext(ctx context.Context, query string, args []driver.Nam
 			})
 		}
 
+		return &rows{
+			cols:   columns,
+			vals:   vals,
+			pos:    0,
+			closed: false,
+		}, nil
+	case "show vitess_vindex_advice":
+		columns := []string{"Keyspace", "Table", "Column", "Queries", "Digests", "FirstSeen", "LastSeen"}
+		vals := [][]any{}
+
+		for _, advice := range c.vindexAdvice {
+			vals = append(vals, []any{
+				advice.Keyspace,
+				advice.Table,
+				advice.Column,
+				advice.QueryCount,
+				strings.Join(advice.Digests, ","),
+				"",
+				"",
+			})
+		}
+
 		return &rows{
 			cols:   columns,
 			vals:   vals,
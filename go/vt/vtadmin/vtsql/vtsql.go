@@ -49,6 +49,9 @@ type DB interface {
 	// ShowTablets executes `SHOW vitess_tablets` and returns the result.
 	ShowTablets(ctx context.Context) (*sql.Rows, error)
 
+	// ShowVindexAdvice executes `SHOW vitess_vindex_advice` and returns the result.
+	ShowVindexAdvice(ctx context.Context) (*sql.Rows, error)
+
 	// VExplain executes query - `vexplain [ALL|PLAN|QUERIES|TRACE|KEYS] query` and returns the results
 	VExplain(ctx context.Context, query string, vexplainStmt *sqlparser.VExplainStmt) (*vtadminpb.VExplainResponse, error)
 
@@ -181,6 +184,16 @@ func (vtgate *VTGateProxy) ShowTablets(ctx context.Context) (*sql.Rows, error) {
 	return vtgate.conn.QueryContext(vtgate.getQueryContext(ctx), "SHOW vitess_tablets")
 }
 
+// ShowVindexAdvice is part of the DB interface.
+func (vtgate *VTGateProxy) ShowVindexAdvice(ctx context.Context) (*sql.Rows, error) {
+	span, ctx := trace.NewSpan(ctx, "VTGateProxy.ShowVindexAdvice")
+	defer span.Finish()
+
+	vtadminproto.AnnotateClusterSpan(vtgate.cluster, span)
+
+	return vtgate.conn.QueryContext(vtgate.getQueryContext(ctx), "SHOW vitess_vindex_advice")
+}
+
 // VExplain is part of the DB interface.
 func (vtgate *VTGateProxy) VExplain(ctx context.Context, query string, vexplainStmt *sqlparser.VExplainStmt) (*vtadminpb.VExplainResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "VTGateProxy.VExplain")
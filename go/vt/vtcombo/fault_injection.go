@@ -0,0 +1,498 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcombo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// FaultInjectionAPIPath is the prefix of the HTTP endpoints of the fault
+	// injection API.
+	FaultInjectionAPIPath = "/debug/vttest/faults"
+
+	// defaultStalledReplicationLag is the replication lag reported by the
+	// replicas of a shard whose replication was stalled without an explicit lag.
+	defaultStalledReplicationLag = time.Hour
+)
+
+// faultInjector holds the faults which are injected between vtgate and the
+// tablets of vtcombo. Since all tablets share a single MySQL instance, the
+// faults are simulated on the connections to the tablets: vtgate observes
+// them through failing queries and health streams, exactly like it would
+// observe the failure of a real tablet or cell.
+type faultInjector struct {
+	mu sync.Mutex
+	// cellLatencies is the latency added to every request to a tablet of the cell.
+	cellLatencies map[string]time.Duration
+	// partitionedCells are the cells whose tablets are unreachable.
+	partitionedCells map[string]bool
+	// downTablets are the uids of the tablets which were killed.
+	downTablets map[uint32]bool
+	// stalledShards maps "keyspace/shard" to the time at which the
+	// replication of the shard was stalled, offset by the initial lag.
+	stalledShards map[string]time.Time
+	// changed is closed whenever the faults change, so that running health
+	// streams are restarted and observe the new faults.
+	changed chan struct{}
+}
+
+// faults are the faults injected into the tablets of the tablet map.
+var faults = newFaultInjector()
+
+func newFaultInjector() *faultInjector {
+	return &faultInjector{
+		cellLatencies:    make(map[string]time.Duration),
+		partitionedCells: make(map[string]bool),
+		downTablets:      make(map[uint32]bool),
+		stalledShards:    make(map[string]time.Time),
+		changed:          make(chan struct{}),
+	}
+}
+
+// SetCellLatencies sets the latency which is added to every request to the
+// tablets of the given cells.
+func SetCellLatencies(latencies map[string]time.Duration) {
+	faults.mu.Lock()
+	defer faults.mu.Unlock()
+	for cell, latency := range latencies {
+		faults.cellLatencies[cell] = latency
+	}
+	faults.notifyLocked()
+}
+
+// notifyLocked restarts the health streams of all tablets.
+func (fi *faultInjector) notifyLocked() {
+	close(fi.changed)
+	fi.changed = make(chan struct{})
+}
+
+// unreachableLocked returns an error if the tablet cannot be reached.
+func (fi *faultInjector) unreachableLocked(t *comboTablet) error {
+	if fi.downTablets[t.uid] {
+		return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "vttest: tablet %v was killed", topoproto.TabletAliasString(t.alias))
+	}
+	if fi.partitionedCells[t.alias.Cell] {
+		return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "vttest: cell %v is partitioned", t.alias.Cell)
+	}
+	return nil
+}
+
+// before is called before every request to the tablet. It returns an error
+// if the tablet cannot be reached, and otherwise waits for the latency of
+// the cell of the tablet.
+func (fi *faultInjector) before(ctx context.Context, t *comboTablet) error {
+	fi.mu.Lock()
+	err := fi.unreachableLocked(t)
+	latency := fi.cellLatencies[t.alias.Cell]
+	fi.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if latency <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// patchHealth reports the replication lag of stalled replicas.
+func (fi *faultInjector) patchHealth(t *comboTablet, shr *querypb.StreamHealthResponse) *querypb.StreamHealthResponse {
+	if t.currentType() == topodatapb.TabletType_PRIMARY {
+		return shr
+	}
+	fi.mu.Lock()
+	stalledSince, ok := fi.stalledShards[topoproto.KeyspaceShardString(t.keyspace, t.shard)]
+	fi.mu.Unlock()
+	if !ok {
+		return shr
+	}
+	shr = shr.CloneVT()
+	if shr.RealtimeStats == nil {
+		shr.RealtimeStats = &querypb.RealtimeStats{}
+	}
+	shr.RealtimeStats.ReplicationLagSeconds = uint32(time.Since(stalledSince).Seconds())
+	return shr
+}
+
+// faultInjectingConn injects the faults of the tablet map into the
+// connection to a tablet.
+type faultInjectingConn struct {
+	queryservice.QueryService
+	tablet *comboTablet
+}
+
+func newFaultInjectingConn(conn queryservice.QueryService, t *comboTablet) queryservice.QueryService {
+	return &faultInjectingConn{
+		QueryService: queryservice.Wrap(conn, func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService, name string, inTransaction bool, inner func(context.Context, *querypb.Target, queryservice.QueryService) (bool, error)) error {
+			if name != "Close" {
+				if err := faults.before(ctx, t); err != nil {
+					return err
+				}
+			}
+			_, err := inner(ctx, target, conn)
+			return err
+		}),
+		tablet: t,
+	}
+}
+
+// StreamHealth is part of queryservice.QueryService. The stream is ended
+// whenever the faults change, so that the healthcheck reconnects and
+// observes the new state of the tablet.
+func (c *faultInjectingConn) StreamHealth(ctx context.Context, callback func(*querypb.StreamHealthResponse) error) error {
+	faults.mu.Lock()
+	err := faults.unreachableLocked(c.tablet)
+	changed := faults.changed
+	faults.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-changed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err = c.QueryService.StreamHealth(ctx, func(shr *querypb.StreamHealthResponse) error {
+		return callback(faults.patchHealth(c.tablet, shr))
+	})
+	select {
+	case <-changed:
+		return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "vttest: faults of tablet %v changed", topoproto.TabletAliasString(c.tablet.alias))
+	default:
+		return err
+	}
+}
+
+// killPrimary makes the primary of the shard unreachable and promotes one of
+// its reachable replicas, preferring replicas in the cell of the old primary.
+// It returns the alias of the new primary. The primary stays reachable if no
+// replica can be promoted.
+func (fi *faultInjector) killPrimary(ctx context.Context, keyspace, shard string) (*topodatapb.TabletAlias, error) {
+	fi.mu.Lock()
+	var primary *comboTablet
+	var candidates []*comboTablet
+	for _, t := range tabletMap {
+		if t.keyspace != keyspace || t.shard != shard || fi.unreachableLocked(t) != nil {
+			continue
+		}
+		switch t.currentType() {
+		case topodatapb.TabletType_PRIMARY:
+			primary = t
+		case topodatapb.TabletType_REPLICA:
+			candidates = append(candidates, t)
+		}
+	}
+	if primary == nil {
+		fi.mu.Unlock()
+		return nil, fmt.Errorf("no reachable primary found for %v", topoproto.KeyspaceShardString(keyspace, shard))
+	}
+	if len(candidates) == 0 {
+		fi.mu.Unlock()
+		return nil, fmt.Errorf("no reachable replica to promote in %v", topoproto.KeyspaceShardString(keyspace, shard))
+	}
+	fi.downTablets[primary.uid] = true
+	fi.notifyLocked()
+	fi.mu.Unlock()
+	log.Infof("vttest: killed primary %v of %v", topoproto.TabletAliasString(primary.alias), topoproto.KeyspaceShardString(keyspace, shard))
+
+	slices.SortFunc(candidates, func(a, b *comboTablet) int {
+		if (a.alias.Cell == primary.alias.Cell) != (b.alias.Cell == primary.alias.Cell) {
+			if a.alias.Cell == primary.alias.Cell {
+				return -1
+			}
+			return 1
+		}
+		return int(a.uid) - int(b.uid)
+	})
+	newPrimary := candidates[0]
+	// Semi-sync has to be set to false, since we have 1 single backing MySQL.
+	if err := newPrimary.tm.ChangeType(ctx, topodatapb.TabletType_PRIMARY, false); err != nil {
+		// The shard is left with its old primary rather than without one.
+		fi.mu.Lock()
+		delete(fi.downTablets, primary.uid)
+		fi.notifyLocked()
+		fi.mu.Unlock()
+		return nil, fmt.Errorf("failed to promote %v: %v", topoproto.TabletAliasString(newPrimary.alias), err)
+	}
+	log.Infof("vttest: promoted %v to primary of %v", topoproto.TabletAliasString(newPrimary.alias), topoproto.KeyspaceShardString(keyspace, shard))
+	return newPrimary.alias, nil
+}
+
+// restoreTablet makes a killed tablet reachable again. A killed primary
+// which has been replaced in the meantime rejoins the shard as a replica.
+func (fi *faultInjector) restoreTablet(ctx context.Context, alias *topodatapb.TabletAlias) error {
+	t, ok := tabletMap[alias.Uid]
+	if !ok || t.alias.Cell != alias.Cell {
+		return fmt.Errorf("tablet %v not found", topoproto.TabletAliasString(alias))
+	}
+	if t.currentType() == topodatapb.TabletType_PRIMARY {
+		for _, other := range tabletMap {
+			if other != t && other.keyspace == t.keyspace && other.shard == t.shard && other.currentType() == topodatapb.TabletType_PRIMARY {
+				if err := t.tm.ChangeType(ctx, topodatapb.TabletType_REPLICA, false); err != nil {
+					return fmt.Errorf("failed to demote %v: %v", topoproto.TabletAliasString(alias), err)
+				}
+				break
+			}
+		}
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	delete(fi.downTablets, alias.Uid)
+	fi.notifyLocked()
+	return nil
+}
+
+func (fi *faultInjector) setCellPartitioned(cell string, partitioned bool) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if partitioned {
+		fi.partitionedCells[cell] = true
+	} else {
+		delete(fi.partitionedCells, cell)
+	}
+	fi.notifyLocked()
+}
+
+func (fi *faultInjector) setCellLatency(cell string, latency time.Duration) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if latency > 0 {
+		fi.cellLatencies[cell] = latency
+	} else {
+		delete(fi.cellLatencies, cell)
+	}
+	fi.notifyLocked()
+}
+
+// stallReplication makes the replicas of the shard report a replication lag
+// which starts at lag and keeps growing until replication is resumed.
+func (fi *faultInjector) stallReplication(keyspace, shard string, lag time.Duration) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.stalledShards[topoproto.KeyspaceShardString(keyspace, shard)] = time.Now().Add(-lag)
+	fi.notifyLocked()
+}
+
+func (fi *faultInjector) resumeReplication(keyspace, shard string) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	delete(fi.stalledShards, topoproto.KeyspaceShardString(keyspace, shard))
+	fi.notifyLocked()
+}
+
+// faultStatus is the JSON representation of the injected faults.
+type faultStatus struct {
+	CellLatencies    map[string]string `json:"cell_latencies"`
+	PartitionedCells []string          `json:"partitioned_cells"`
+	DownTablets      []string          `json:"down_tablets"`
+	StalledShards    []string          `json:"stalled_shards"`
+	Primaries        map[string]string `json:"primaries"`
+}
+
+func (fi *faultInjector) status() *faultStatus {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	status := &faultStatus{
+		CellLatencies:    make(map[string]string, len(fi.cellLatencies)),
+		PartitionedCells: []string{},
+		DownTablets:      []string{},
+		StalledShards:    []string{},
+		Primaries:        make(map[string]string),
+	}
+	for cell, latency := range fi.cellLatencies {
+		status.CellLatencies[cell] = latency.String()
+	}
+	for cell := range fi.partitionedCells {
+		status.PartitionedCells = append(status.PartitionedCells, cell)
+	}
+	for _, t := range tabletMap {
+		if fi.downTablets[t.uid] {
+			status.DownTablets = append(status.DownTablets, topoproto.TabletAliasString(t.alias))
+		} else if t.currentType() == topodatapb.TabletType_PRIMARY {
+			status.Primaries[topoproto.KeyspaceShardString(t.keyspace, t.shard)] = topoproto.TabletAliasString(t.alias)
+		}
+	}
+	for keyspaceShard := range fi.stalledShards {
+		status.StalledShards = append(status.StalledShards, keyspaceShard)
+	}
+	slices.Sort(status.PartitionedCells)
+	slices.Sort(status.DownTablets)
+	slices.Sort(status.StalledShards)
+	return status
+}
+
+// RegisterFaultInjectionAPI registers the HTTP endpoints which allow tests
+// to inject failures into the cluster:
+//
+//	GET  /debug/vttest/faults                                              returns the injected faults
+//	POST /debug/vttest/faults/kill_primary?keyspace=ks&shard=-80          kills the primary and promotes a replica
+//	POST /debug/vttest/faults/restore_tablet?tablet=cell-uid              restarts a killed tablet
+//	POST /debug/vttest/faults/partition_cell?cell=cell                    makes all tablets of the cell unreachable
+//	POST /debug/vttest/faults/heal_cell?cell=cell                         heals a partitioned cell
+//	POST /debug/vttest/faults/cell_latency?cell=cell&latency=50ms         sets the latency of the cell
+//	POST /debug/vttest/faults/stall_replication?keyspace=ks&shard=-80&lag=1h  stalls the replication of the shard
+//	POST /debug/vttest/faults/resume_replication?keyspace=ks&shard=-80    resumes the replication of the shard
+func RegisterFaultInjectionAPI() {
+	servenv.HTTPHandleFunc(FaultInjectionAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		writeFaultStatus(w)
+	})
+
+	handle := func(action string, f func(r *http.Request) error) {
+		servenv.HTTPHandleFunc(FaultInjectionAPIPath+"/"+action, func(w http.ResponseWriter, r *http.Request) {
+			if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+				acl.SendError(w, err)
+				return
+			}
+			if r.Method != http.MethodPost {
+				http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			if err := f(r); err != nil {
+				http.Error(w, fmt.Sprintf("%s failed: %v", action, err), http.StatusBadRequest)
+				return
+			}
+			writeFaultStatus(w)
+		})
+	}
+
+	handle("kill_primary", func(r *http.Request) error {
+		keyspace, shard, err := keyspaceShardParams(r)
+		if err != nil {
+			return err
+		}
+		_, err = faults.killPrimary(r.Context(), keyspace, shard)
+		return err
+	})
+	handle("restore_tablet", func(r *http.Request) error {
+		alias, err := topoproto.ParseTabletAlias(r.FormValue("tablet"))
+		if err != nil {
+			return err
+		}
+		return faults.restoreTablet(r.Context(), alias)
+	})
+	handle("partition_cell", func(r *http.Request) error {
+		cell, err := requiredParam(r, "cell")
+		if err != nil {
+			return err
+		}
+		faults.setCellPartitioned(cell, true)
+		return nil
+	})
+	handle("heal_cell", func(r *http.Request) error {
+		cell, err := requiredParam(r, "cell")
+		if err != nil {
+			return err
+		}
+		faults.setCellPartitioned(cell, false)
+		return nil
+	})
+	handle("cell_latency", func(r *http.Request) error {
+		cell, err := requiredParam(r, "cell")
+		if err != nil {
+			return err
+		}
+		latency, err := time.ParseDuration(r.FormValue("latency"))
+		if err != nil {
+			return err
+		}
+		faults.setCellLatency(cell, latency)
+		return nil
+	})
+	handle("stall_replication", func(r *http.Request) error {
+		keyspace, shard, err := keyspaceShardParams(r)
+		if err != nil {
+			return err
+		}
+		lag := defaultStalledReplicationLag
+		if value := r.FormValue("lag"); value != "" {
+			if lag, err = time.ParseDuration(value); err != nil {
+				return err
+			}
+		}
+		faults.stallReplication(keyspace, shard, lag)
+		return nil
+	})
+	handle("resume_replication", func(r *http.Request) error {
+		keyspace, shard, err := keyspaceShardParams(r)
+		if err != nil {
+			return err
+		}
+		faults.resumeReplication(keyspace, shard)
+		return nil
+	})
+}
+
+func requiredParam(r *http.Request, name string) (string, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return "", fmt.Errorf("missing %s parameter", name)
+	}
+	return value, nil
+}
+
+func keyspaceShardParams(r *http.Request) (keyspace, shard string, err error) {
+	if keyspace, err = requiredParam(r, "keyspace"); err != nil {
+		return "", "", err
+	}
+	if shard, err = requiredParam(r, "shard"); err != nil {
+		return "", "", err
+	}
+	return keyspace, shard, nil
+}
+
+func writeFaultStatus(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(faults.status()); err != nil {
+		log.Errorf("failed to write fault injection status: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtcombo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/queryservice/fakes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// healthStreamer sends a single health response and then blocks until the
+// context of the stream is canceled.
+type healthStreamer struct {
+	queryservice.QueryService
+}
+
+func (h *healthStreamer) StreamHealth(ctx context.Context, callback func(*querypb.StreamHealthResponse) error) error {
+	if err := callback(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{}}); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func setupFaultInjection(t *testing.T, tablets ...*comboTablet) {
+	savedFaults, savedTabletMap := faults, tabletMap
+	t.Cleanup(func() {
+		faults, tabletMap = savedFaults, savedTabletMap
+	})
+	faults = newFaultInjector()
+	tabletMap = make(map[uint32]*comboTablet)
+	for _, tablet := range tablets {
+		tabletMap[tablet.uid] = tablet
+	}
+}
+
+func newTestComboTablet(cell string, uid uint32, tabletType topodatapb.TabletType) *comboTablet {
+	return &comboTablet{
+		alias:      &topodatapb.TabletAlias{Cell: cell, Uid: uid},
+		keyspace:   "ks",
+		shard:      "-80",
+		tabletType: tabletType,
+		uid:        uid,
+	}
+}
+
+func TestFaultInjectionUnreachable(t *testing.T) {
+	primary := newTestComboTablet("zone1", 1, topodatapb.TabletType_PRIMARY)
+	replica := newTestComboTablet("zone2", 2, topodatapb.TabletType_REPLICA)
+	setupFaultInjection(t, primary, replica)
+
+	ctx := context.Background()
+	target := &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	primaryConn := newFaultInjectingConn(fakes.NewStreamHealthQueryService(target), primary)
+	replicaConn := newFaultInjectingConn(fakes.NewStreamHealthQueryService(target), replica)
+
+	_, err := primaryConn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+
+	faults.setCellPartitioned("zone2", true)
+	_, err = replicaConn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	assert.EqualError(t, err, "vttest: cell zone2 is partitioned")
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+	_, err = primaryConn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+
+	faults.setCellPartitioned("zone2", false)
+	_, err = replicaConn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+
+	faults.mu.Lock()
+	faults.downTablets[primary.uid] = true
+	faults.mu.Unlock()
+	_, err = primaryConn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	assert.EqualError(t, err, "vttest: tablet zone1-0000000001 was killed")
+	err = primaryConn.StreamHealth(ctx, func(*querypb.StreamHealthResponse) error { return nil })
+	assert.EqualError(t, err, "vttest: tablet zone1-0000000001 was killed")
+
+	assert.Equal(t, &faultStatus{
+		CellLatencies:    map[string]string{},
+		PartitionedCells: []string{},
+		DownTablets:      []string{"zone1-0000000001"},
+		StalledShards:    []string{},
+		Primaries:        map[string]string{},
+	}, faults.status())
+}
+
+func TestFaultInjectionKillPrimaryWithoutReplica(t *testing.T) {
+	primary := newTestComboTablet("zone1", 1, topodatapb.TabletType_PRIMARY)
+	replica := newTestComboTablet("zone2", 2, topodatapb.TabletType_REPLICA)
+	setupFaultInjection(t, primary, replica)
+
+	// The only replica is unreachable, so the primary is not killed.
+	faults.setCellPartitioned("zone2", true)
+	_, err := faults.killPrimary(context.Background(), "ks", "-80")
+	assert.EqualError(t, err, "no reachable replica to promote in ks/-80")
+	assert.Empty(t, faults.status().DownTablets)
+}
+
+func TestFaultInjectionCellLatency(t *testing.T) {
+	tablet := newTestComboTablet("zone1", 1, topodatapb.TabletType_PRIMARY)
+	setupFaultInjection(t, tablet)
+
+	target := &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	conn := newFaultInjectingConn(fakes.NewStreamHealthQueryService(target), tablet)
+
+	SetCellLatencies(map[string]time.Duration{"zone1": 50 * time.Millisecond})
+	start := time.Now()
+	_, err := conn.Execute(context.Background(), target, "select 1", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// The latency is bounded by the deadline of the request.
+	faults.setCellLatency("zone1", time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = conn.Execute(ctx, target, "select 1", nil, 0, 0, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	faults.setCellLatency("zone1", 0)
+	assert.Empty(t, faults.status().CellLatencies)
+}
+
+func TestFaultInjectionStreamHealth(t *testing.T) {
+	primary := newTestComboTablet("zone1", 1, topodatapb.TabletType_PRIMARY)
+	replica := newTestComboTablet("zone1", 2, topodatapb.TabletType_REPLICA)
+	setupFaultInjection(t, primary, replica)
+
+	faults.stallReplication("ks", "-80", 10*time.Minute)
+	assert.Equal(t, []string{"ks/-80"}, faults.status().StalledShards)
+
+	lags := make(chan uint32, 1)
+	done := make(chan error, 1)
+	conn := newFaultInjectingConn(&healthStreamer{}, replica)
+	go func() {
+		done <- conn.StreamHealth(context.Background(), func(shr *querypb.StreamHealthResponse) error {
+			lags <- shr.RealtimeStats.ReplicationLagSeconds
+			return nil
+		})
+	}()
+	assert.GreaterOrEqual(t, <-lags, uint32(600))
+
+	// Any change of the faults restarts the stream.
+	faults.resumeReplication("ks", "-80")
+	err := <-done
+	assert.EqualError(t, err, "vttest: faults of tablet zone1-0000000002 changed")
+	assert.Equal(t, vtrpcpb.Code_UNAVAILABLE, vterrors.Code(err))
+
+	// Primaries never report a replication lag.
+	faults.stallReplication("ks", "-80", 10*time.Minute)
+	shr := faults.patchHealth(primary, &querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{}})
+	assert.Zero(t, shr.RealtimeStats.ReplicationLagSeconds)
+}
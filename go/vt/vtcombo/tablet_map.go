@@ -70,6 +70,15 @@ type comboTablet struct {
 	tm  *tabletmanager.TabletManager
 }
 
+// currentType returns the current type of the tablet, which changes when the
+// tablet is reparented.
+func (t *comboTablet) currentType() topodatapb.TabletType {
+	if t.tm == nil {
+		return t.tabletType
+	}
+	return t.tm.Tablet().Type
+}
+
 // tabletMap maps the tablet uid to the tablet record
 var tabletMap map[uint32]*comboTablet
 
@@ -441,10 +450,10 @@ func dialer(ctx context.Context, tablet *topodatapb.Tablet, failFast grpcclient.
 		return nil, vterrors.New(vtrpcpb.Code_UNAVAILABLE, "connection refused")
 	}
 
-	return &internalTabletConn{
+	return newFaultInjectingConn(&internalTabletConn{
 		tablet:     t,
 		topoTablet: tablet,
-	}, nil
+	}, t), nil
 }
 
 // internalTabletConn implements queryservice.QueryService by forwarding everything
@@ -502,9 +511,9 @@ func (itc *internalTabletConn) Begin(
 }
 
 // Commit is part of queryservice.QueryService
-func (itc *internalTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
-	rID, err := itc.tablet.qsc.QueryService().Commit(ctx, target, transactionID)
-	return rID, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
+func (itc *internalTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
+	rID, sessionStateChanges, err := itc.tablet.qsc.QueryService().Commit(ctx, target, transactionID)
+	return rID, sessionStateChanges, tabletconn.ErrorFromGRPC(vterrors.ToGRPC(err))
 }
 
 // Rollback is part of queryservice.QueryService
@@ -807,6 +816,15 @@ func (itmc *internalTabletManagerClient) GetGlobalStatusVars(ctx context.Context
 	return t.tm.GetGlobalStatusVars(ctx, variables)
 }
 
+// GetAuditLog is part of the tmclient.TabletManagerClient interface.
+func (itmc *internalTabletManagerClient) GetAuditLog(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error) {
+	t, ok := tabletMap[tablet.Alias.Uid]
+	if !ok {
+		return nil, fmt.Errorf("tmclient: cannot find tablet %v", topoproto.TabletAliasString(tablet.Alias))
+	}
+	return t.tm.GetAuditLog(ctx, req)
+}
+
 func (itmc *internalTabletManagerClient) SetReadOnly(ctx context.Context, tablet *topodatapb.Tablet) error {
 	return fmt.Errorf("not implemented in vtcombo")
 }
@@ -1045,6 +1063,10 @@ func (itmc *internalTabletManagerClient) RestoreFromBackup(context.Context, *top
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) RestoreFromClone(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	return nil, fmt.Errorf("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) CheckThrottler(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
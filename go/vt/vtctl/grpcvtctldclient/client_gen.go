@@ -47,6 +47,15 @@ func (client *gRPCVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldata
 	return client.c.AddCellsAlias(ctx, in, opts...)
 }
 
+// AddQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) AddQueryRule(ctx context.Context, in *vtctldatapb.AddQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.AddQueryRuleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.AddQueryRule(ctx, in, opts...)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -56,6 +65,33 @@ func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, i
 	return client.c.ApplyKeyspaceRoutingRules(ctx, in, opts...)
 }
 
+// ApplyMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyMaintenanceWindows(ctx context.Context, in *vtctldatapb.ApplyMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyMaintenanceWindowsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyMaintenanceWindows(ctx, in, opts...)
+}
+
+// ApplyPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyPlanPins(ctx context.Context, in *vtctldatapb.ApplyPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyPlanPinsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyPlanPins(ctx, in, opts...)
+}
+
+// ApplyRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyRewriteRules(ctx context.Context, in *vtctldatapb.ApplyRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRewriteRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyRewriteRules(ctx, in, opts...)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -83,6 +119,15 @@ func (client *gRPCVtctldClient) ApplyShardRoutingRules(ctx context.Context, in *
 	return client.c.ApplyShardRoutingRules(ctx, in, opts...)
 }
 
+// ApplyTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyTenantRoutingRules(ctx context.Context, in *vtctldatapb.ApplyTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTenantRoutingRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyTenantRoutingRules(ctx, in, opts...)
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	if client.c == nil {
@@ -155,6 +200,15 @@ func (client *gRPCVtctldClient) CleanupSchemaMigration(ctx context.Context, in *
 	return client.c.CleanupSchemaMigration(ctx, in, opts...)
 }
 
+// CloneTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) CloneTablet(ctx context.Context, in *vtctldatapb.CloneTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.CloneTabletResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.CloneTablet(ctx, in, opts...)
+}
+
 // CompleteSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) CompleteSchemaMigration(ctx context.Context, in *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -227,6 +281,24 @@ func (client *gRPCVtctldClient) DeleteKeyspace(ctx context.Context, in *vtctldat
 	return client.c.DeleteKeyspace(ctx, in, opts...)
 }
 
+// DeleteQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DeleteQueryRule(ctx context.Context, in *vtctldatapb.DeleteQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteQueryRuleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DeleteQueryRule(ctx, in, opts...)
+}
+
+// DeleteScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DeleteScheduledJob(ctx context.Context, in *vtctldatapb.DeleteScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteScheduledJobResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DeleteScheduledJob(ctx, in, opts...)
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) DeleteShards(ctx context.Context, in *vtctldatapb.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteShardsResponse, error) {
 	if client.c == nil {
@@ -254,6 +326,15 @@ func (client *gRPCVtctldClient) DeleteTablets(ctx context.Context, in *vtctldata
 	return client.c.DeleteTablets(ctx, in, opts...)
 }
 
+// DrainCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) DrainCell(ctx context.Context, in *vtctldatapb.DrainCellRequest, opts ...grpc.CallOption) (*vtctldatapb.DrainCellResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.DrainCell(ctx, in, opts...)
+}
+
 // EmergencyReparentShard is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldatapb.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.EmergencyReparentShardResponse, error) {
 	if client.c == nil {
@@ -299,6 +380,15 @@ func (client *gRPCVtctldClient) ExecuteMultiFetchAsDBA(ctx context.Context, in *
 	return client.c.ExecuteMultiFetchAsDBA(ctx, in, opts...)
 }
 
+// ExportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ExportTopology(ctx context.Context, in *vtctldatapb.ExportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ExportTopologyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ExportTopology(ctx, in, opts...)
+}
+
 // FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldatapb.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.FindAllShardsInKeyspaceResponse, error) {
 	if client.c == nil {
@@ -308,6 +398,15 @@ func (client *gRPCVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in
 	return client.c.FindAllShardsInKeyspace(ctx, in, opts...)
 }
 
+// FindErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) FindErrantGTIDs(ctx context.Context, in *vtctldatapb.FindErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.FindErrantGTIDsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.FindErrantGTIDs(ctx, in, opts...)
+}
+
 // ForceCutOverSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ForceCutOverSchemaMigration(ctx context.Context, in *vtctldatapb.ForceCutOverSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.ForceCutOverSchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -353,6 +452,15 @@ func (client *gRPCVtctldClient) GetCellsAliases(ctx context.Context, in *vtctlda
 	return client.c.GetCellsAliases(ctx, in, opts...)
 }
 
+// GetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetDynamicConfig(ctx context.Context, in *vtctldatapb.GetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetDynamicConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetDynamicConfig(ctx, in, opts...)
+}
+
 // GetFullStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetFullStatus(ctx context.Context, in *vtctldatapb.GetFullStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetFullStatusResponse, error) {
 	if client.c == nil {
@@ -389,6 +497,15 @@ func (client *gRPCVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatap
 	return client.c.GetKeyspaces(ctx, in, opts...)
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetMaintenanceWindows(ctx context.Context, in *vtctldatapb.GetMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetMaintenanceWindows(ctx, in, opts...)
+}
+
 // GetMirrorRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldatapb.GetMirrorRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMirrorRulesResponse, error) {
 	if client.c == nil {
@@ -407,6 +524,33 @@ func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldat
 	return client.c.GetPermissions(ctx, in, opts...)
 }
 
+// GetPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetPlanPins(ctx context.Context, in *vtctldatapb.GetPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPlanPinsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetPlanPins(ctx, in, opts...)
+}
+
+// GetQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetQueryRules(ctx context.Context, in *vtctldatapb.GetQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetQueryRules(ctx, in, opts...)
+}
+
+// GetRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetRewriteRules(ctx context.Context, in *vtctldatapb.GetRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRewriteRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetRewriteRules(ctx, in, opts...)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -416,6 +560,15 @@ func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctlda
 	return client.c.GetRoutingRules(ctx, in, opts...)
 }
 
+// GetScheduledJobs is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetScheduledJobs(ctx context.Context, in *vtctldatapb.GetScheduledJobsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetScheduledJobsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetScheduledJobs(ctx, in, opts...)
+}
+
 // GetSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetSchema(ctx context.Context, in *vtctldatapb.GetSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaResponse, error) {
 	if client.c == nil {
@@ -515,6 +668,15 @@ func (client *gRPCVtctldClient) GetTablets(ctx context.Context, in *vtctldatapb.
 	return client.c.GetTablets(ctx, in, opts...)
 }
 
+// GetTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTenantRoutingRules(ctx context.Context, in *vtctldatapb.GetTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTenantRoutingRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTenantRoutingRules(ctx, in, opts...)
+}
+
 // GetThrottlerStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetThrottlerStatus(ctx context.Context, in *vtctldatapb.GetThrottlerStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetThrottlerStatusResponse, error) {
 	if client.c == nil {
@@ -578,6 +740,15 @@ func (client *gRPCVtctldClient) GetWorkflows(ctx context.Context, in *vtctldatap
 	return client.c.GetWorkflows(ctx, in, opts...)
 }
 
+// ImportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ImportTopology(ctx context.Context, in *vtctldatapb.ImportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ImportTopologyResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ImportTopology(ctx, in, opts...)
+}
+
 // InitShardPrimary is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) InitShardPrimary(ctx context.Context, in *vtctldatapb.InitShardPrimaryRequest, opts ...grpc.CallOption) (*vtctldatapb.InitShardPrimaryResponse, error) {
 	if client.c == nil {
@@ -812,6 +983,15 @@ func (client *gRPCVtctldClient) RemoveShardCell(ctx context.Context, in *vtctlda
 	return client.c.RemoveShardCell(ctx, in, opts...)
 }
 
+// RepairErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RepairErrantGTIDs(ctx context.Context, in *vtctldatapb.RepairErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.RepairErrantGTIDsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RepairErrantGTIDs(ctx, in, opts...)
+}
+
 // ReparentTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ReparentTablet(ctx context.Context, in *vtctldatapb.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.ReparentTabletResponse, error) {
 	if client.c == nil {
@@ -857,6 +1037,15 @@ func (client *gRPCVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldat
 	return client.c.RunHealthCheck(ctx, in, opts...)
 }
 
+// SetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetDynamicConfig(ctx context.Context, in *vtctldatapb.SetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.SetDynamicConfigResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetDynamicConfig(ctx, in, opts...)
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
 	if client.c == nil {
@@ -866,6 +1055,15 @@ func (client *gRPCVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context,
 	return client.c.SetKeyspaceDurabilityPolicy(ctx, in, opts...)
 }
 
+// SetScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetScheduledJob(ctx context.Context, in *vtctldatapb.SetScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.SetScheduledJobResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetScheduledJob(ctx, in, opts...)
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
 	if client.c == nil {
@@ -884,6 +1082,15 @@ func (client *gRPCVtctldClient) SetShardTabletControl(ctx context.Context, in *v
 	return client.c.SetShardTabletControl(ctx, in, opts...)
 }
 
+// SetShardWriteFence is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) SetShardWriteFence(ctx context.Context, in *vtctldatapb.SetShardWriteFenceRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardWriteFenceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.SetShardWriteFence(ctx, in, opts...)
+}
+
 // SetWritable is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) SetWritable(ctx context.Context, in *vtctldatapb.SetWritableRequest, opts ...grpc.CallOption) (*vtctldatapb.SetWritableResponse, error) {
 	if client.c == nil {
@@ -1136,6 +1343,15 @@ func (client *gRPCVtctldClient) WorkflowAddTables(ctx context.Context, in *vtctl
 	return client.c.WorkflowAddTables(ctx, in, opts...)
 }
 
+// WorkflowCutBack is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowCutBack(ctx context.Context, in *vtctldatapb.WorkflowCutBackRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowCutBackResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowCutBack(ctx, in, opts...)
+}
+
 // WorkflowDelete is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) WorkflowDelete(ctx context.Context, in *vtctldatapb.WorkflowDeleteRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowDeleteResponse, error) {
 	if client.c == nil {
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// replicaErrantGTIDs are the errant GTIDs of a replica of a shard.
+type replicaErrantGTIDs struct {
+	tablet *topodatapb.Tablet
+	gtids  replication.Mysql56GTIDSet
+}
+
+// shardErrantGTIDs returns the primary of the shard and the replicas of the
+// shard which have errant GTIDs, sorted by tablet alias. Replicas whose
+// position could not be read are skipped.
+func (s *VtctldServer) shardErrantGTIDs(ctx context.Context, keyspace, shard string) (*topodatapb.Tablet, []*replicaErrantGTIDs, error) {
+	positions, err := s.ShardReplicationPositions(ctx, &vtctldatapb.ShardReplicationPositionsRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var primary *topodatapb.Tablet
+	for _, tablet := range positions.TabletMap {
+		if tablet.Type == topodatapb.TabletType_PRIMARY {
+			primary = tablet
+		}
+	}
+	if primary == nil {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", keyspace, shard)
+	}
+	primaryAlias := topoproto.TabletAliasString(primary.Alias)
+
+	// The status of the primary is read after the positions of the replicas,
+	// so that the transactions the replicas received from it in the meantime
+	// are not taken for errant ones.
+	primaryStatus, err := s.tmc.PrimaryStatus(ctx, primary)
+	if err != nil {
+		return nil, nil, fmt.Errorf("PrimaryStatus(%s) failed: %w", primaryAlias, err)
+	}
+	primaryPosition, err := replication.DecodePosition(primaryStatus.Position)
+	if err != nil {
+		return nil, nil, err
+	}
+	primarySID, err := replication.ParseSID(primaryStatus.ServerUuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var replicas []*replicaErrantGTIDs
+	for alias, status := range positions.ReplicationStatuses {
+		if alias == primaryAlias || status == nil {
+			continue
+		}
+		position, err := replication.DecodePosition(status.Position)
+		if err != nil {
+			return nil, nil, err
+		}
+		gtids, err := reparentutil.ErrantGTIDs(position, primaryPosition, primarySID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(gtids) == 0 {
+			continue
+		}
+		replicas = append(replicas, &replicaErrantGTIDs{
+			tablet: positions.TabletMap[alias],
+			gtids:  gtids,
+		})
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return topoproto.TabletAliasString(replicas[i].tablet.Alias) < topoproto.TabletAliasString(replicas[j].tablet.Alias)
+	})
+	return primary, replicas, nil
+}
+
+// explainErrantGTIDs reads the binary log events of the errant transactions
+// of the replica into its errant GTIDs.
+func (s *VtctldServer) explainErrantGTIDs(ctx context.Context, replica *replicaErrantGTIDs, req *vtctldatapb.FindErrantGTIDsRequest, errant *vtctldatapb.ReplicaErrantGTIDs) error {
+	fetch := func(query string, maxRows int64) (*sqltypes.Result, error) {
+		qr, err := s.tmc.ExecuteFetchAsDba(ctx, replica.tablet, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+			Query:   []byte(query),
+			MaxRows: uint64(maxRows),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sqltypes.Proto3ToResult(qr), nil
+	}
+
+	explanation, err := reparentutil.ExplainErrantGTIDs(replica.gtids, req.MaxGtids, req.MaxBinlogEvents, fetch)
+	if err != nil {
+		return err
+	}
+	errant.Transactions = explanation.Transactions
+	errant.PurgedGtids = explanation.Purged.String()
+	errant.UnexplainedGtids = explanation.Unexplained.String()
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The guardrails of ExecuteFetchAsApp, ExecuteFetchAsDBA and
+// ExecuteMultiFetchAsDBA protect against fat-fingered manual operations.
+var (
+	executeFetchDeniedStatements []string
+	executeFetchSelectLimit      int
+
+	executeFetchGuardrails = stats.NewCountersWithMultiLabels(
+		"VtctldExecuteFetchGuardrails",
+		"Number of statements of ExecuteFetchAsApp, ExecuteFetchAsDBA and ExecuteMultiFetchAsDBA denied, forced or limited by the guardrails",
+		[]string{"Method", "Action"})
+)
+
+func registerExecuteFetchGuardrailsFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&executeFetchDeniedStatements, "execute-fetch-denied-statements", executeFetchDeniedStatements, "Comma-separated list of statement classes, given by the first keyword of the statements (e.g. drop,truncate,shutdown), that ExecuteFetchAsApp, ExecuteFetchAsDBA and ExecuteMultiFetchAsDBA refuse to execute unless --force is passed.")
+	fs.IntVar(&executeFetchSelectLimit, "execute-fetch-select-limit", executeFetchSelectLimit, "If positive, the SELECT statements of ExecuteFetchAsApp, ExecuteFetchAsDBA and ExecuteMultiFetchAsDBA without a LIMIT, or with a greater one, are executed with a LIMIT of this number of rows.")
+}
+
+func init() {
+	servenv.OnParseFor("vtctld", registerExecuteFetchGuardrailsFlags)
+}
+
+// statementClass returns the class of the statement, which is its first
+// keyword in lower case, e.g. "select" or "drop". Unlike the statement types
+// of the parser, it is also defined for the statements that Vitess can't
+// parse, like SHUTDOWN.
+func statementClass(query string) string {
+	query = strings.TrimSpace(sqlparser.StripLeadingComments(query))
+	if end := strings.IndexFunc(query, func(r rune) bool { return !unicode.IsLetter(r) && r != '_' }); end >= 0 {
+		query = query[:end]
+	}
+	return strings.ToLower(query)
+}
+
+// applyExecuteFetchGuardrails applies the guardrails to the sql of an
+// ExecuteFetch RPC, which can hold several statements. It fails if a statement
+// is of a denied class and force is not set, and returns the sql to execute,
+// with the LIMIT of its SELECT statements capped to
+// --execute-fetch-select-limit.
+func applyExecuteFetchGuardrails(parser *sqlparser.Parser, method string, sql string, force bool) (string, error) {
+	if len(executeFetchDeniedStatements) == 0 && executeFetchSelectLimit <= 0 {
+		return sql, nil
+	}
+	queries, err := parser.SplitStatementToPieces(sql)
+	if err != nil {
+		return "", err
+	}
+
+	limited := false
+	for i, query := range queries {
+		class := statementClass(query)
+		if slices.ContainsFunc(executeFetchDeniedStatements, func(denied string) bool { return strings.EqualFold(denied, class) }) {
+			if !force {
+				executeFetchGuardrails.Add([]string{method, "Denied"}, 1)
+				return "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%s statements are denied by --execute-fetch-denied-statements, use --force to execute them: %s", strings.ToUpper(class), parser.TruncateForLog(query))
+			}
+			executeFetchGuardrails.Add([]string{method, "Forced"}, 1)
+			log.Warningf("%s: forcing the execution of a %s statement denied by --execute-fetch-denied-statements: %s", method, strings.ToUpper(class), parser.TruncateForLog(query))
+		}
+
+		if executeFetchSelectLimit <= 0 || class != "select" {
+			continue
+		}
+		stmt, err := parser.Parse(query)
+		if err != nil {
+			// The statements that can't be parsed are executed as they are.
+			continue
+		}
+		if limitSelect(stmt, executeFetchSelectLimit) {
+			queries[i] = sqlparser.String(stmt)
+			limited = true
+			executeFetchGuardrails.Add([]string{method, "Limited"}, 1)
+			log.Infof("%s: limiting a SELECT statement to %d rows with --execute-fetch-select-limit: %s", method, executeFetchSelectLimit, parser.TruncateForLog(queries[i]))
+		}
+	}
+	if !limited {
+		return sql, nil
+	}
+	return strings.Join(queries, ";"), nil
+}
+
+// limitSelect sets the LIMIT of the SELECT statement to limit if it has none,
+// or a greater one. It returns whether the statement was changed.
+func limitSelect(stmt sqlparser.Statement, limit int) bool {
+	var sel sqlparser.OrderAndLimit
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		sel = stmt
+	case *sqlparser.Union:
+		sel = stmt
+	default:
+		return false
+	}
+
+	rowcount := sqlparser.NewIntLiteral(strconv.Itoa(limit))
+	current := sel.GetLimit()
+	if current == nil {
+		sel.SetLimit(&sqlparser.Limit{Rowcount: rowcount})
+		return true
+	}
+	if lit, ok := current.Rowcount.(*sqlparser.Literal); ok && lit.Type == sqlparser.IntVal {
+		if n, err := strconv.ParseUint(lit.Val, 10, 64); err == nil && n <= uint64(limit) {
+			return false
+		}
+	}
+	current.Rowcount = rowcount
+	return true
+}
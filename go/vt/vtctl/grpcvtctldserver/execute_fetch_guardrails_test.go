@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestStatementClass(t *testing.T) {
+	tests := map[string]string{
+		"select 1":                         "select",
+		"  DROP TABLE t":                   "drop",
+		"/* comment */ truncate t":         "truncate",
+		"shutdown":                         "shutdown",
+		"alter table t add column c int":   "alter",
+		"(select 1) union (select 2)":      "",
+		"set @@session.sql_log_bin = 0":    "set",
+		"create table t(id int)":           "create",
+		"\n-- comment\nDELETE FROM t":      "delete",
+		"lock_tables_are_not_a_statement;": "lock_tables_are_not_a_statement",
+	}
+	for query, class := range tests {
+		assert.Equal(t, class, statementClass(query), query)
+	}
+}
+
+func TestApplyExecuteFetchGuardrails(t *testing.T) {
+	defer func(denied []string, limit int) {
+		executeFetchDeniedStatements, executeFetchSelectLimit = denied, limit
+	}(executeFetchDeniedStatements, executeFetchSelectLimit)
+	parser := sqlparser.NewTestParser()
+
+	tests := []struct {
+		name   string
+		denied []string
+		limit  int
+		sql    string
+		force  bool
+		want   string
+		errMsg string
+	}{
+		{
+			name: "no guardrails",
+			sql:  "drop table t; select * from t",
+			want: "drop table t; select * from t",
+		},
+		{
+			name:   "denied statement",
+			denied: []string{"drop", "SHUTDOWN"},
+			sql:    "DROP TABLE t",
+			errMsg: "DROP statements are denied by --execute-fetch-denied-statements, use --force to execute them",
+		},
+		{
+			name:   "unparsable denied statement",
+			denied: []string{"drop", "shutdown"},
+			sql:    "shutdown",
+			errMsg: "SHUTDOWN statements are denied",
+		},
+		{
+			name:   "denied statement in multiple statements",
+			denied: []string{"truncate"},
+			sql:    "select 1; truncate t",
+			errMsg: "TRUNCATE statements are denied",
+		},
+		{
+			name:   "forced denied statement",
+			denied: []string{"drop"},
+			sql:    "drop table t",
+			force:  true,
+			want:   "drop table t",
+		},
+		{
+			name:   "allowed statement",
+			denied: []string{"drop"},
+			sql:    "alter table t drop column c",
+			want:   "alter table t drop column c",
+		},
+		{
+			name:  "select without limit",
+			limit: 100,
+			sql:   "select * from t where id > 5",
+			want:  "select * from t where id > 5 limit 100",
+		},
+		{
+			name:  "select with a greater limit",
+			limit: 100,
+			sql:   "select * from t limit 10, 1000",
+			want:  "select * from t limit 10, 100",
+		},
+		{
+			name:  "select with a lower limit",
+			limit: 100,
+			sql:   "select * from t limit 10",
+			want:  "select * from t limit 10",
+		},
+		{
+			name:  "union without limit",
+			limit: 100,
+			sql:   "select id from t1 union select id from t2",
+			want:  "select id from t1 union select id from t2 limit 100",
+		},
+		{
+			name:  "limit applies to the selects of multiple statements",
+			limit: 100,
+			sql:   "update t set c = 1; select * from t",
+			want:  "update t set c = 1;select * from t limit 100",
+		},
+		{
+			name:  "limit is not lifted by force",
+			limit: 100,
+			sql:   "select * from t",
+			force: true,
+			want:  "select * from t limit 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executeFetchDeniedStatements, executeFetchSelectLimit = tt.denied, tt.limit
+			sql, err := applyExecuteFetchGuardrails(parser, "ExecuteFetchAsDBA", tt.sql, tt.force)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sql)
+		})
+	}
+}
@@ -22,10 +22,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"net/http"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,15 +39,18 @@ import (
 	"google.golang.org/grpc"
 
 	"vitess.io/vitess/go/event"
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/netutil"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sets"
 	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/dtids"
+	"vitess.io/vitess/go/vt/dynamicflags"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
@@ -68,16 +73,19 @@ import (
 	"vitess.io/vitess/go/vt/schemamanager"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/helpers"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/topotools/events"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
+	"vitess.io/vitess/go/vt/vtctl/scheduler"
 	"vitess.io/vitess/go/vt/vtctl/schematools"
 	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 )
@@ -87,11 +95,16 @@ const (
 
 	// DefaultWaitReplicasTimeout is the default value for waitReplicasTimeout, which is used when calling method ApplySchema.
 	DefaultWaitReplicasTimeout = 10 * time.Second
+
+	// dynamicConfigHistorySize is the number of changes kept in the history of
+	// a dynamic config.
+	dynamicConfigHistorySize = 100
 )
 
 // VtctldServer implements the Vtctld RPC service protocol.
 type VtctldServer struct {
 	vtctlservicepb.UnimplementedVtctldServer
+	env *vtenv.Environment
 	ts  *topo.Server
 	tmc tmclient.TabletManagerClient
 	ws  *workflow.Server
@@ -102,6 +115,7 @@ func NewVtctldServer(env *vtenv.Environment, ts *topo.Server) *VtctldServer {
 	tmc := tmclient.NewTabletManagerClient()
 
 	return &VtctldServer{
+		env: env,
 		ts:  ts,
 		tmc: tmc,
 		ws:  workflow.NewServer(env, ts, tmc),
@@ -111,10 +125,12 @@ func NewVtctldServer(env *vtenv.Environment, ts *topo.Server) *VtctldServer {
 // NewTestVtctldServer returns a new VtctldServer for the given topo server
 // AND tmclient for use in tests. This should NOT be used in production.
 func NewTestVtctldServer(ts *topo.Server, tmc tmclient.TabletManagerClient) *VtctldServer {
+	env := vtenv.NewTestEnv()
 	return &VtctldServer{
+		env: env,
 		ts:  ts,
 		tmc: tmc,
-		ws:  workflow.NewServer(vtenv.NewTestEnv(), ts, tmc),
+		ws:  workflow.NewServer(env, ts, tmc),
 	}
 }
 
@@ -170,6 +186,234 @@ func (s *VtctldServer) AddCellsAlias(ctx context.Context, req *vtctldatapb.AddCe
 	return &vtctldatapb.AddCellsAliasResponse{}, nil
 }
 
+// AddQueryRule is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) AddQueryRule(ctx context.Context, req *vtctldatapb.AddQueryRuleRequest) (resp *vtctldatapb.AddQueryRuleResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.AddQueryRule")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("replace", req.Replace)
+	span.Annotate("dry_run", req.DryRun)
+
+	rule, err := rules.UnmarshalRule([]byte(req.Rule))
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "invalid query rule")
+	}
+	if rule.Name == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "query rule must have a Name")
+	}
+	if _, err = s.ts.GetKeyspace(ctx, req.Keyspace); err != nil {
+		return nil, err
+	}
+
+	if !req.DryRun {
+		var unlock func(*error)
+		ctx, unlock, err = s.ts.LockKeyspace(ctx, req.Keyspace, "AddQueryRule")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock(&err)
+	}
+
+	qrs, err := s.getQueryRules(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	// The rule replaces the rule of the same name in place, as the first
+	// matching rule of a query determines its action.
+	newQrs := rules.New()
+	replaced := false
+	for _, qr := range qrs.CopyUnderlying() {
+		if qr.Name == rule.Name {
+			if !req.Replace {
+				return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "query rule %s already exists in keyspace %s", rule.Name, req.Keyspace)
+			}
+			qr, replaced = rule, true
+		}
+		newQrs.Add(qr)
+	}
+	if !replaced {
+		newQrs.Add(rule)
+	}
+
+	data, err := newQrs.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if !req.DryRun {
+		if err = s.ts.SaveQueryRules(ctx, req.Keyspace, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &vtctldatapb.AddQueryRuleResponse{
+		Rules:   string(data),
+		Matches: matchQueryRules(s.env, newQrs, req.SampleQueries),
+	}, nil
+}
+
+// getQueryRules returns the query rules of the keyspace.
+func (s *VtctldServer) getQueryRules(ctx context.Context, keyspace string) (*rules.Rules, error) {
+	data, err := s.ts.GetQueryRules(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	qrs := rules.New()
+	if len(data) == 0 {
+		return qrs, nil
+	}
+	if err := qrs.UnmarshalJSON(data); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid query rules in keyspace %s", keyspace)
+	}
+	return qrs, nil
+}
+
+// matchQueryRules matches each query against the rules, as the tablets do.
+func matchQueryRules(env *vtenv.Environment, qrs *rules.Rules, queries []string) []*vtctldatapb.QueryRuleMatch {
+	matches := make([]*vtctldatapb.QueryRuleMatch, 0, len(queries))
+	for _, query := range queries {
+		match := &vtctldatapb.QueryRuleMatch{Query: query}
+		matches = append(matches, match)
+
+		planID, tables, matching, err := qrs.Match(env, query)
+		if err != nil {
+			match.Error = err.Error()
+			continue
+		}
+		match.Plan = planID.String()
+		match.Tables = tables
+		for _, qr := range matching.CopyUnderlying() {
+			match.Rules = append(match.Rules, qr.Name)
+		}
+	}
+	return matches
+}
+
+// ApplyMaintenanceWindows is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyMaintenanceWindows(ctx context.Context, req *vtctldatapb.ApplyMaintenanceWindowsRequest) (resp *vtctldatapb.ApplyMaintenanceWindowsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyMaintenanceWindows")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("windows", len(req.MaintenanceWindows.GetWindows()))
+
+	if err := topoproto.ValidateMaintenanceWindows(req.MaintenanceWindows); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", err)
+	}
+
+	if err := s.ts.SaveMaintenanceWindows(ctx, req.MaintenanceWindows); err != nil {
+		return nil, err
+	}
+
+	mw, err := s.ts.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.ApplyMaintenanceWindowsResponse{
+		MaintenanceWindows: mw,
+	}, nil
+}
+
+// ApplyPlanPins is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyPlanPins(ctx context.Context, req *vtctldatapb.ApplyPlanPinsRequest) (*vtctldatapb.ApplyPlanPinsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyPlanPins")
+	defer span.Finish()
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if err := validatePlanPins(req.PlanPins); err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SavePlanPins(ctx, req.PlanPins); err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyPlanPinsResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
+// validatePlanPins checks that each pin has a digest and a JSON plan, and that
+// there is at most one pin for each digest.
+func validatePlanPins(pins *vschemapb.PlanPins) error {
+	seen := make(map[string]bool, len(pins.GetPins()))
+	for _, pin := range pins.GetPins() {
+		if pin.Digest == "" {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "plan pin must have a digest")
+		}
+		var plan map[string]any
+		if err := json.Unmarshal([]byte(pin.Plan), &plan); err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the plan of digest %s is not a JSON plan description: %v", pin.Digest, err)
+		}
+		if seen[pin.Digest] {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "duplicate plan pin for digest %s", pin.Digest)
+		}
+		seen[pin.Digest] = true
+	}
+	return nil
+}
+
+// ApplyRewriteRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyRewriteRules(ctx context.Context, req *vtctldatapb.ApplyRewriteRulesRequest) (*vtctldatapb.ApplyRewriteRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRewriteRules")
+	defer span.Finish()
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if err := s.validateRewriteRules(req.RewriteRules); err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SaveRewriteRules(ctx, req.RewriteRules); err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyRewriteRulesResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
+// validateRewriteRules checks that each rule compiles, and that there is at
+// most one rule with each name.
+func (s *VtctldServer) validateRewriteRules(rules *vschemapb.RewriteRules) error {
+	seen := make(map[string]bool, len(rules.GetRules()))
+	for _, rule := range rules.GetRules() {
+		if _, err := vindexes.BuildRewriteRule(rule, s.ws.SQLParser()); err != nil {
+			return err
+		}
+		if seen[rule.Name] {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "duplicate rewrite rule %s", rule.Name)
+		}
+		seen[rule.Name] = true
+	}
+	return nil
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesRequest) (resp *vtctldatapb.ApplyRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRules")
@@ -180,6 +424,17 @@ func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.A
 	span.Annotate("skip_rebuild", req.SkipRebuild)
 	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
 
+	current, err := s.ts.GetRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		audit.Emit(ctx, &audit.Event{
+			Type: audit.ApplyRoutingRules,
+			Diff: audit.Diff(current, req.RoutingRules),
+		}, err)
+	}()
+
 	if err = s.ts.SaveRoutingRules(ctx, req.RoutingRules); err != nil {
 		return nil, err
 	}
@@ -242,6 +497,15 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 		return nil, err
 	}
 
+	defer func() {
+		audit.Emit(ctx, &audit.Event{
+			Type:           audit.ApplySchema,
+			Keyspace:       req.Keyspace,
+			MigrationUUIDs: resp.GetUuidList(),
+			Diff:           strings.Join(req.Sql, ";\n"),
+		}, err)
+	}()
+
 	// Attach the callerID as the EffectiveCallerID.
 	if req.CallerId != nil {
 		span.Annotate("caller_id", req.CallerId.Principal)
@@ -313,6 +577,53 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 	return resp, err
 }
 
+// ApplyTenantRoutingRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyTenantRoutingRules(ctx context.Context, req *vtctldatapb.ApplyTenantRoutingRulesRequest) (*vtctldatapb.ApplyTenantRoutingRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyTenantRoutingRules")
+	defer span.Finish()
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if err := validateTenantRoutingRules(req.TenantRoutingRules); err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SaveTenantRoutingRules(ctx, req.TenantRoutingRules); err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.ApplyTenantRoutingRulesResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		return nil, vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+	}
+
+	return resp, nil
+}
+
+// validateTenantRoutingRules checks that the rules are complete, and that there
+// is at most one rule for each tenant of a keyspace.
+func validateTenantRoutingRules(rules *vschemapb.TenantRoutingRules) error {
+	seen := make(map[string]bool, len(rules.GetRules()))
+	for _, rule := range rules.GetRules() {
+		if rule.Keyspace == "" || rule.TenantId == "" || rule.ToKeyspace == "" {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tenant routing rule %v must have a keyspace, a tenant_id and a to_keyspace", rule)
+		}
+		key := rule.Keyspace + "." + rule.TenantId
+		if seen[key] {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "duplicate tenant routing rule for tenant %s of keyspace %s", rule.TenantId, rule.Keyspace)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyVSchemaRequest) (resp *vtctldatapb.ApplyVSchemaResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyVSchema")
@@ -407,6 +718,18 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 		return response, err
 	}
 
+	var current *vschemapb.Keyspace
+	if currentVS, err := s.ts.GetVSchema(ctx, req.Keyspace); err == nil {
+		current = currentVS.Keyspace
+	}
+	defer func() {
+		audit.Emit(ctx, &audit.Event{
+			Type:     audit.ApplyVSchema,
+			Keyspace: req.Keyspace,
+			Diff:     audit.Diff(current, ksvs.Keyspace),
+		}, err)
+	}()
+
 	if err = s.ts.SaveVSchema(ctx, ksvs); err != nil {
 		err = vterrors.Wrapf(err, "SaveVSchema(%s, %v)", req.Keyspace, req.VSchema)
 		return nil, err
@@ -821,6 +1144,133 @@ func (s *VtctldServer) CleanupSchemaMigration(ctx context.Context, req *vtctldat
 	return resp, nil
 }
 
+// CloneTablet is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) CloneTablet(ctx context.Context, req *vtctldatapb.CloneTabletRequest) (resp *vtctldatapb.CloneTabletResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.CloneTablet")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("max_bandwidth", req.MaxBandwidth)
+	span.Annotate("allow_primary_donor", req.AllowPrimaryDonor)
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+	if ti.Type == topodatapb.TabletType_PRIMARY {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot clone into primary tablet %v", topoproto.TabletAliasString(req.TabletAlias))
+	}
+
+	var donor *topodatapb.Tablet
+	if req.DonorAlias != nil {
+		if topoproto.TabletAliasEqual(req.DonorAlias, req.TabletAlias) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet %v cannot be its own donor", topoproto.TabletAliasString(req.TabletAlias))
+		}
+		donorInfo, err := s.ts.GetTablet(ctx, req.DonorAlias)
+		if err != nil {
+			return nil, err
+		}
+		if donorInfo.Keyspace != ti.Keyspace || donorInfo.Shard != ti.Shard {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "donor %v is not in the shard %v/%v of tablet %v", topoproto.TabletAliasString(req.DonorAlias), ti.Keyspace, ti.Shard, topoproto.TabletAliasString(req.TabletAlias))
+		}
+		if donorInfo.Type == topodatapb.TabletType_PRIMARY && !req.AllowPrimaryDonor {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "donor %v is the primary, which requires --allow-primary-donor", topoproto.TabletAliasString(req.DonorAlias))
+		}
+		donor = donorInfo.Tablet
+	} else {
+		donor, err = s.selectCloneDonor(ctx, ti.Tablet, req.AllowPrimaryDonor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	span.Annotate("donor_alias", topoproto.TabletAliasString(donor.Alias))
+
+	// The clone plugin of the donor cannot be installed on the fly when it is
+	// in super_read_only mode, as the replicas are: it is checked beforehand
+	// to fail fast.
+	qr, err := s.tmc.ExecuteFetchAsDba(ctx, donor, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(mysqlctl.ClonePluginStatusQuery),
+		MaxRows: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Rows) == 0 || sqltypes.Proto3ToResult(qr).Rows[0][0].ToString() != "ACTIVE" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the clone plugin is not active on donor %v: load it with plugin-load-add=mysql_clone.so", topoproto.TabletAliasString(donor.Alias))
+	}
+
+	log.Infof("Cloning tablet %v from donor %v", topoproto.TabletAliasString(req.TabletAlias), topoproto.TabletAliasString(donor.Alias))
+	cloneResp, err := s.tmc.RestoreFromClone(ctx, ti.Tablet, &tabletmanagerdatapb.RestoreFromCloneRequest{
+		DonorAlias:   donor.Alias,
+		MaxBandwidth: req.MaxBandwidth,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.CloneTabletResponse{
+		DonorAlias: donor.Alias,
+		Position:   cloneResp.Position,
+	}, nil
+}
+
+// selectCloneDonor selects the donor of a clone of the given tablet among the
+// replicas of its shard with a known replication lag: the ones in the cell of
+// the tablet are preferred, then the ones with the lowest lag. If there is no
+// such replica, the primary is selected if allowed.
+func (s *VtctldServer) selectCloneDonor(ctx context.Context, tablet *topodatapb.Tablet, allowPrimary bool) (*topodatapb.Tablet, error) {
+	tablets, stats, err := reparentutil.ShardReplicationStatuses(ctx, s.ts, s.tmc, tablet.Keyspace, tablet.Shard)
+	// The tablets which failed to return their replication status cannot be
+	// donors, but the other ones can.
+	if err != nil && len(tablets) == 0 {
+		return nil, err
+	}
+	if err != nil {
+		log.Warningf("Selecting the donor to clone tablet %v among the available tablets: %v", topoproto.TabletAliasString(tablet.Alias), err)
+	}
+
+	var (
+		donor       *topodatapb.Tablet
+		donorLag    uint32
+		donorInCell bool
+		primary     *topodatapb.Tablet
+	)
+	for i, candidate := range tablets {
+		if stats[i] == nil || topoproto.TabletAliasEqual(candidate.Alias, tablet.Alias) {
+			continue
+		}
+		switch candidate.Type {
+		case topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_SPARE:
+		case topodatapb.TabletType_PRIMARY:
+			primary = candidate.Tablet
+			continue
+		default:
+			continue
+		}
+		if stats[i].ReplicationLagUnknown {
+			continue
+		}
+
+		lag := stats[i].ReplicationLagSeconds
+		inCell := candidate.Alias.Cell == tablet.Alias.Cell
+		if donor == nil || (inCell && !donorInCell) || (inCell == donorInCell && lag < donorLag) {
+			donor = candidate.Tablet
+			donorLag = lag
+			donorInCell = inCell
+		}
+	}
+
+	if donor == nil && allowPrimary {
+		donor = primary
+	}
+	if donor == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no donor available to clone tablet %v", topoproto.TabletAliasString(tablet.Alias))
+	}
+	return donor, nil
+}
+
 // ForceCutOverSchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ForceCutOverSchemaMigration(ctx context.Context, req *vtctldatapb.ForceCutOverSchemaMigrationRequest) (resp *vtctldatapb.ForceCutOverSchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ForceCutOverSchemaMigration")
@@ -1208,6 +1658,58 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 	return &vtctldatapb.DeleteKeyspaceResponse{}, nil
 }
 
+// DeleteQueryRule is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DeleteQueryRule(ctx context.Context, req *vtctldatapb.DeleteQueryRuleRequest) (resp *vtctldatapb.DeleteQueryRuleResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteQueryRule")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("name", req.Name)
+
+	ctx, unlock, err := s.ts.LockKeyspace(ctx, req.Keyspace, "DeleteQueryRule")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock(&err)
+
+	qrs, err := s.getQueryRules(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if qrs.Delete(req.Name) == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "query rule %s not found in keyspace %s", req.Name, req.Keyspace)
+	}
+
+	var data []byte
+	if len(qrs.CopyUnderlying()) > 0 {
+		if data, err = qrs.MarshalJSON(); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.ts.SaveQueryRules(ctx, req.Keyspace, data); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.DeleteQueryRuleResponse{}, nil
+}
+
+// DeleteScheduledJob is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DeleteScheduledJob(ctx context.Context, req *vtctldatapb.DeleteScheduledJobRequest) (resp *vtctldatapb.DeleteScheduledJobResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteScheduledJob")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+
+	if err := s.ts.DeleteScheduledJob(ctx, req.Name); err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.DeleteScheduledJobResponse{}, nil
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) DeleteShards(ctx context.Context, req *vtctldatapb.DeleteShardsRequest) (resp *vtctldatapb.DeleteShardsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.DeleteShards")
@@ -1274,6 +1776,107 @@ func (s *VtctldServer) DeleteTablets(ctx context.Context, req *vtctldatapb.Delet
 	return &vtctldatapb.DeleteTabletsResponse{}, nil
 }
 
+// DrainCell is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) DrainCell(ctx context.Context, req *vtctldatapb.DrainCellRequest) (resp *vtctldatapb.DrainCellResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.DrainCell")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("cell", req.Cell)
+	span.Annotate("step", req.Step)
+	span.Annotate("undrain", req.Undrain)
+
+	if req.Cell == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cell must be specified")
+	}
+	if req.Step < 0 || req.Step > 100 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "step must be between 0 and 100, got %d", req.Step)
+	}
+	stepInterval, _, err := protoutil.DurationFromProto(req.StepInterval)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse StepInterval into a valid duration")
+	}
+
+	getCtx, getCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer getCancel()
+	ci, err := s.ts.GetCellInfo(getCtx, req.Cell, true /* strongRead */)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Undrain {
+		ci, err = s.updateCellDrain(ctx, req.Cell, func(ci *topodatapb.CellInfo) {
+			ci.Drain = nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &vtctldatapb.DrainCellResponse{CellInfo: ci}, nil
+	}
+
+	// A drain that was interrupted resumes from the read weight it reached.
+	readWeight := int32(100)
+	if ci.Drain != nil {
+		readWeight = ci.Drain.ReadWeight
+	}
+	step := req.Step
+	if step == 0 {
+		step = 100
+	}
+	for {
+		readWeight = max(readWeight-step, 0)
+		ci, err = s.updateCellDrain(ctx, req.Cell, func(ci *topodatapb.CellInfo) {
+			if ci.Drain == nil {
+				ci.Drain = &topodatapb.CellDrain{StartTime: protoutil.TimeToProto(time.Now())}
+			}
+			ci.Drain.ReadWeight = readWeight
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Cell %s is draining: %d%% of its reads are left", req.Cell, readWeight)
+		if readWeight == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(stepInterval):
+		}
+	}
+
+	listCtx, listCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer listCancel()
+	tablets, err := s.ts.GetTabletsByCell(listCtx, req.Cell, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp = &vtctldatapb.DrainCellResponse{CellInfo: ci}
+	for _, tablet := range tablets {
+		if tablet.Type == topodatapb.TabletType_PRIMARY {
+			resp.Primaries = append(resp.Primaries, tablet.Alias)
+		}
+	}
+	resp.SafeToShutDown = len(resp.Primaries) == 0
+	return resp, nil
+}
+
+// updateCellDrain updates the drain of the cell and returns the updated
+// CellInfo.
+func (s *VtctldServer) updateCellDrain(ctx context.Context, cell string, update func(ci *topodatapb.CellInfo)) (*topodatapb.CellInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	var updatedCi *topodatapb.CellInfo
+	err := s.ts.UpdateCellInfoFields(ctx, cell, func(ci *topodatapb.CellInfo) error {
+		update(ci)
+		updatedCi = ci.CloneVT()
+		return nil
+	})
+	return updatedCi, err
+}
+
 // EmergencyReparentShard is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) EmergencyReparentShard(ctx context.Context, req *vtctldatapb.EmergencyReparentShardRequest) (resp *vtctldatapb.EmergencyReparentShardResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.EmergencyReparentShard")
@@ -1354,6 +1957,12 @@ func (s *VtctldServer) ExecuteFetchAsApp(ctx context.Context, req *vtctldatapb.E
 	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
 	span.Annotate("max_rows", req.MaxRows)
 	span.Annotate("use_pool", req.UsePool)
+	span.Annotate("force", req.Force)
+
+	query, err := applyExecuteFetchGuardrails(s.ws.SQLParser(), "ExecuteFetchAsApp", req.Query, req.Force)
+	if err != nil {
+		return nil, err
+	}
 
 	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
 	if err != nil {
@@ -1361,7 +1970,7 @@ func (s *VtctldServer) ExecuteFetchAsApp(ctx context.Context, req *vtctldatapb.E
 	}
 
 	qr, err := s.tmc.ExecuteFetchAsApp(ctx, ti.Tablet, req.UsePool, &tabletmanagerdatapb.ExecuteFetchAsAppRequest{
-		Query:   []byte(req.Query),
+		Query:   []byte(query),
 		MaxRows: uint64(req.MaxRows),
 	})
 	if err != nil {
@@ -1382,6 +1991,12 @@ func (s *VtctldServer) ExecuteFetchAsDBA(ctx context.Context, req *vtctldatapb.E
 	span.Annotate("max_rows", req.MaxRows)
 	span.Annotate("disable_binlogs", req.DisableBinlogs)
 	span.Annotate("reload_schema", req.ReloadSchema)
+	span.Annotate("force", req.Force)
+
+	query, err := applyExecuteFetchGuardrails(s.ws.SQLParser(), "ExecuteFetchAsDBA", req.Query, req.Force)
+	if err != nil {
+		return nil, err
+	}
 
 	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
 	if err != nil {
@@ -1389,7 +2004,7 @@ func (s *VtctldServer) ExecuteFetchAsDBA(ctx context.Context, req *vtctldatapb.E
 	}
 
 	qr, err := s.tmc.ExecuteFetchAsDba(ctx, ti.Tablet, false, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
-		Query:          []byte(req.Query),
+		Query:          []byte(query),
 		MaxRows:        uint64(req.MaxRows),
 		DisableBinlogs: req.DisableBinlogs,
 		ReloadSchema:   req.ReloadSchema,
@@ -1412,6 +2027,12 @@ func (s *VtctldServer) ExecuteMultiFetchAsDBA(ctx context.Context, req *vtctldat
 	span.Annotate("max_rows", req.MaxRows)
 	span.Annotate("disable_binlogs", req.DisableBinlogs)
 	span.Annotate("reload_schema", req.ReloadSchema)
+	span.Annotate("force", req.Force)
+
+	sql, err := applyExecuteFetchGuardrails(s.ws.SQLParser(), "ExecuteMultiFetchAsDBA", req.Sql, req.Force)
+	if err != nil {
+		return nil, err
+	}
 
 	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
 	if err != nil {
@@ -1419,7 +2040,7 @@ func (s *VtctldServer) ExecuteMultiFetchAsDBA(ctx context.Context, req *vtctldat
 	}
 
 	qrs, err := s.tmc.ExecuteMultiFetchAsDba(ctx, ti.Tablet, false, &tabletmanagerdatapb.ExecuteMultiFetchAsDbaRequest{
-		Sql:            []byte(req.Sql),
+		Sql:            []byte(sql),
 		MaxRows:        uint64(req.MaxRows),
 		DisableBinlogs: req.DisableBinlogs,
 		ReloadSchema:   req.ReloadSchema,
@@ -1470,6 +2091,20 @@ func (s *VtctldServer) ExecuteHook(ctx context.Context, req *vtctldatapb.Execute
 	}}, nil
 }
 
+// ExportTopology is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ExportTopology(ctx context.Context, req *vtctldatapb.ExportTopologyRequest) (resp *vtctldatapb.ExportTopologyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ExportTopology")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	archive, err := helpers.ExportTopology(ctx, s.ts)
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.ExportTopologyResponse{Archive: archive}, nil
+}
+
 // FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctldatapb.FindAllShardsInKeyspaceRequest) (resp *vtctldatapb.FindAllShardsInKeyspaceResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.FindAllShardsInKeyspace")
@@ -1498,6 +2133,46 @@ func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctlda
 	}, nil
 }
 
+// FindErrantGTIDs is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) FindErrantGTIDs(ctx context.Context, req *vtctldatapb.FindErrantGTIDsRequest) (resp *vtctldatapb.FindErrantGTIDsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.FindErrantGTIDs")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("explain", req.Explain)
+	span.Annotate("max_binlog_events", req.MaxBinlogEvents)
+	span.Annotate("max_gtids", req.MaxGtids)
+
+	_, replicas, err := s.shardErrantGTIDs(ctx, req.Keyspace, req.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.FindErrantGTIDsResponse{}
+	for _, replica := range replicas {
+		count, err := replication.GTIDCount(replica.gtids.String())
+		if err != nil {
+			return nil, err
+		}
+		errant := &vtctldatapb.ReplicaErrantGTIDs{
+			TabletAlias:     replica.tablet.Alias,
+			ErrantGtids:     replica.gtids.String(),
+			ErrantGtidCount: count,
+		}
+		if req.Explain {
+			if err = s.explainErrantGTIDs(ctx, replica, req, errant); err != nil {
+				err = fmt.Errorf("cannot explain the errant GTIDs of %s: %w", topoproto.TabletAliasString(replica.tablet.Alias), err)
+				return nil, err
+			}
+		}
+		resp.Replicas = append(resp.Replicas, errant)
+	}
+	return resp, nil
+}
+
 // GetBackups is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest) (resp *vtctldatapb.GetBackupsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackups")
@@ -1609,20 +2284,38 @@ func (s *VtctldServer) GetCellInfo(ctx context.Context, req *vtctldatapb.GetCell
 	return &vtctldatapb.GetCellInfoResponse{CellInfo: ci}, nil
 }
 
-// GetCellsAliases is part of the vtctlservicepb.VtctldServer interface.
-func (s *VtctldServer) GetCellsAliases(ctx context.Context, req *vtctldatapb.GetCellsAliasesRequest) (resp *vtctldatapb.GetCellsAliasesResponse, err error) {
-	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellsAliases")
+// GetCellsAliases is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetCellsAliases(ctx context.Context, req *vtctldatapb.GetCellsAliasesRequest) (resp *vtctldatapb.GetCellsAliasesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetCellsAliases")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	strongRead := true
+	aliases, err := s.ts.GetCellsAliases(ctx, strongRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetCellsAliasesResponse{Aliases: aliases}, nil
+}
+
+// GetDynamicConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetDynamicConfig(ctx context.Context, req *vtctldatapb.GetDynamicConfigRequest) (resp *vtctldatapb.GetDynamicConfigResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetDynamicConfig")
 	defer span.Finish()
 
 	defer panicHandler(&err)
 
-	strongRead := true
-	aliases, err := s.ts.GetCellsAliases(ctx, strongRead)
+	span.Annotate("keyspace", req.Keyspace)
+
+	config, err := s.ts.GetDynamicConfig(ctx, req.Keyspace)
 	if err != nil {
 		return nil, err
 	}
-
-	return &vtctldatapb.GetCellsAliasesResponse{Aliases: aliases}, nil
+	return &vtctldatapb.GetDynamicConfigResponse{
+		Config: config,
+	}, nil
 }
 
 // GetFullStatus is part of the vtctlservicepb.VtctldServer interface.
@@ -1698,6 +2391,23 @@ func (s *VtctldServer) GetKeyspaces(ctx context.Context, req *vtctldatapb.GetKey
 	return &vtctldatapb.GetKeyspacesResponse{Keyspaces: keyspaces}, nil
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetMaintenanceWindows(ctx context.Context, req *vtctldatapb.GetMaintenanceWindowsRequest) (resp *vtctldatapb.GetMaintenanceWindowsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetMaintenanceWindows")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	mw, err := s.ts.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetMaintenanceWindowsResponse{
+		MaintenanceWindows: mw,
+	}, nil
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetPermissionsRequest) (resp *vtctldatapb.GetPermissionsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetPermissions")
@@ -1722,6 +2432,60 @@ func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetP
 	}, nil
 }
 
+// GetPlanPins is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetPlanPins(ctx context.Context, req *vtctldatapb.GetPlanPinsRequest) (*vtctldatapb.GetPlanPinsResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetPlanPins")
+	defer span.Finish()
+
+	pins, err := s.ts.GetPlanPins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetPlanPinsResponse{
+		PlanPins: pins,
+	}, nil
+}
+
+// GetQueryRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetQueryRules(ctx context.Context, req *vtctldatapb.GetQueryRulesRequest) (resp *vtctldatapb.GetQueryRulesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetQueryRules")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	qrs, err := s.getQueryRules(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	data, err := qrs.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetQueryRulesResponse{
+		Rules:   string(data),
+		Matches: matchQueryRules(s.env, qrs, req.SampleQueries),
+	}, nil
+}
+
+// GetRewriteRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetRewriteRules(ctx context.Context, req *vtctldatapb.GetRewriteRulesRequest) (*vtctldatapb.GetRewriteRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRewriteRules")
+	defer span.Finish()
+
+	rules, err := s.ts.GetRewriteRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetRewriteRulesResponse{
+		RewriteRules: rules,
+	}, nil
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetRoutingRules(ctx context.Context, req *vtctldatapb.GetRoutingRulesRequest) (resp *vtctldatapb.GetRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRoutingRules")
@@ -1754,6 +2518,33 @@ func (s *VtctldServer) GetShardRoutingRules(ctx context.Context, req *vtctldatap
 	}, nil
 }
 
+// GetScheduledJobs is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetScheduledJobs(ctx context.Context, req *vtctldatapb.GetScheduledJobsRequest) (resp *vtctldatapb.GetScheduledJobsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetScheduledJobs")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+
+	names := []string{req.Name}
+	if req.Name == "" {
+		if names, err = s.ts.GetScheduledJobNames(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp = &vtctldatapb.GetScheduledJobsResponse{}
+	for _, name := range names {
+		ji, err := s.ts.GetScheduledJob(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		resp.Jobs = append(resp.Jobs, ji.ScheduledJob)
+	}
+	return resp, nil
+}
+
 // GetSchema is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetSchema(ctx context.Context, req *vtctldatapb.GetSchemaRequest) (resp *vtctldatapb.GetSchemaResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetSchema")
@@ -2446,6 +3237,21 @@ func (s *VtctldServer) GetTablets(ctx context.Context, req *vtctldatapb.GetTable
 	}, nil
 }
 
+// GetTenantRoutingRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTenantRoutingRules(ctx context.Context, req *vtctldatapb.GetTenantRoutingRulesRequest) (*vtctldatapb.GetTenantRoutingRulesResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTenantRoutingRules")
+	defer span.Finish()
+
+	trr, err := s.ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetTenantRoutingRulesResponse{
+		TenantRoutingRules: trr,
+	}, nil
+}
+
 // GetTopologyPath is part of the vtctlservicepb.VtctldServer interface.
 // It returns the cell located at the provided path in the topology server.
 func (s *VtctldServer) GetTopologyPath(ctx context.Context, req *vtctldatapb.GetTopologyPathRequest) (*vtctldatapb.GetTopologyPathResponse, error) {
@@ -2732,6 +3538,19 @@ func (s *VtctldServer) GetWorkflows(ctx context.Context, req *vtctldatapb.GetWor
 	return resp, err
 }
 
+// ImportTopology is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ImportTopology(ctx context.Context, req *vtctldatapb.ImportTopologyRequest) (resp *vtctldatapb.ImportTopologyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ImportTopology")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("on_conflict", req.OnConflict.String())
+	span.Annotate("dry_run", req.DryRun)
+
+	return helpers.ImportTopology(ctx, s.ts, req)
+}
+
 // InitShardPrimary is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) InitShardPrimary(ctx context.Context, req *vtctldatapb.InitShardPrimaryRequest) (resp *vtctldatapb.InitShardPrimaryResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.InitShardPrimary")
@@ -3640,6 +4459,90 @@ func (s *VtctldServer) RemoveShardCell(ctx context.Context, req *vtctldatapb.Rem
 	return &vtctldatapb.RemoveShardCellResponse{}, nil
 }
 
+// RepairErrantGTIDs is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RepairErrantGTIDs(ctx context.Context, req *vtctldatapb.RepairErrantGTIDsRequest) (resp *vtctldatapb.RepairErrantGTIDsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RepairErrantGTIDs")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	if req.TabletAlias == nil {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet alias must not be nil")
+		return nil, err
+	}
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("method", req.Method.String())
+	span.Annotate("max_gtids", req.MaxGtids)
+	span.Annotate("dry_run", req.DryRun)
+
+	switch req.Method {
+	case vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS, vtctldatapb.RepairErrantGTIDsRequest_RESTORE:
+	default:
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported repair method %s", req.Method)
+		return nil, err
+	}
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	span.Annotate("keyspace", ti.Keyspace)
+	span.Annotate("shard", ti.Shard)
+
+	primary, replicas, err := s.shardErrantGTIDs(ctx, ti.Keyspace, ti.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.RepairErrantGTIDsResponse{Primary: primary.Alias}
+	var replica *replicaErrantGTIDs
+	for _, r := range replicas {
+		if topoproto.TabletAliasEqual(r.tablet.Alias, req.TabletAlias) {
+			replica = r
+		}
+	}
+	if replica == nil {
+		return resp, nil
+	}
+	resp.ErrantGtids = replica.gtids.String()
+
+	switch req.Method {
+	case vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS:
+		resp.Queries, err = reparentutil.EmptyTransactionQueries(replica.gtids, req.MaxGtids)
+		if err != nil {
+			err = vterrors.Wrap(err, "cannot inject empty transactions")
+			return nil, err
+		}
+		if req.DryRun {
+			return resp, nil
+		}
+		// The statements run on one connection, so that GTID_NEXT applies
+		// to the transactions which follow it.
+		_, err = s.tmc.ExecuteMultiFetchAsDba(ctx, primary, false, &tabletmanagerdatapb.ExecuteMultiFetchAsDbaRequest{
+			Sql:     []byte(strings.Join(resp.Queries, ";")),
+			MaxRows: 1,
+		})
+		if err != nil {
+			err = fmt.Errorf("ExecuteMultiFetchAsDba(%s) failed: %w", topoproto.TabletAliasString(primary.Alias), err)
+			return nil, err
+		}
+	case vtctldatapb.RepairErrantGTIDsRequest_RESTORE:
+		if req.DryRun {
+			return resp, nil
+		}
+		err = s.restoreFromBackup(ctx, &vtctldatapb.RestoreFromBackupRequest{TabletAlias: req.TabletAlias}, ti, func(event *logutilpb.Event) error {
+			resp.Events = append(resp.Events, event)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
 // ReparentTablet is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) ReparentTablet(ctx context.Context, req *vtctldatapb.ReparentTabletRequest) (resp *vtctldatapb.ReparentTabletResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ReparentTablet")
@@ -3750,6 +4653,25 @@ func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupReque
 	span.Annotate("keyspace", ti.Keyspace)
 	span.Annotate("shard", ti.Shard)
 
+	logger := logutil.NewConsoleLogger()
+	return s.restoreFromBackup(ctx, req, ti, func(event *logutilpb.Event) error {
+		resp := &vtctldatapb.RestoreFromBackupResponse{
+			TabletAlias: req.TabletAlias,
+			Keyspace:    ti.Keyspace,
+			Shard:       ti.Shard,
+			Event:       event,
+		}
+		if err := stream.Send(resp); err != nil {
+			logger.Errorf("failed to send stream response %+v: %v", resp, err)
+		}
+		return nil
+	})
+}
+
+// restoreFromBackup restores the tablet from a backup, passing the events of
+// the restore to send, and then points it at the shard primary, unless it was
+// restored to a point in time.
+func (s *VtctldServer) restoreFromBackup(ctx context.Context, req *vtctldatapb.RestoreFromBackupRequest, ti *topo.TabletInfo, send func(event *logutilpb.Event) error) (err error) {
 	r := &tabletmanagerdatapb.RestoreFromBackupRequest{
 		BackupTime:           req.BackupTime,
 		RestoreToPos:         req.RestoreToPos,
@@ -3770,14 +4692,8 @@ func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupReque
 		switch err {
 		case nil:
 			logutil.LogEvent(logger, event)
-			resp := &vtctldatapb.RestoreFromBackupResponse{
-				TabletAlias: req.TabletAlias,
-				Keyspace:    ti.Keyspace,
-				Shard:       ti.Shard,
-				Event:       event,
-			}
-			if err = stream.Send(resp); err != nil {
-				logger.Errorf("failed to send stream response %+v: %v", resp, err)
+			if err = send(event); err != nil {
+				return err
 			}
 		case io.EOF:
 			// Do not do anything when active reparenting is disabled.
@@ -3862,6 +4778,76 @@ func (s *VtctldServer) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunH
 	return &vtctldatapb.RunHealthCheckResponse{}, nil
 }
 
+// SetDynamicConfig is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetDynamicConfig(ctx context.Context, req *vtctldatapb.SetDynamicConfigRequest) (resp *vtctldatapb.SetDynamicConfigResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetDynamicConfig")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	if len(req.Values) == 0 && len(req.Unset) == 0 {
+		return nil, vterrors.New(vtrpcpb.Code_INVALID_ARGUMENT, "no flag to set or unset")
+	}
+	for name, value := range req.Values {
+		if slices.Contains(req.Unset, name) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s cannot be both set and unset", name)
+		}
+		if err := dynamicflags.Validate(req.Keyspace, name, value); err != nil {
+			return nil, err
+		}
+	}
+	if req.Keyspace != "" {
+		// The config of a keyspace is deleted with the keyspace.
+		if _, err := s.ts.GetKeyspace(ctx, req.Keyspace); err != nil {
+			return nil, err
+		}
+	}
+
+	now := protoutil.TimeToProto(time.Now())
+	config, err := s.ts.UpdateDynamicConfigFields(ctx, req.Keyspace, func(config *vtctldatapb.DynamicConfig) error {
+		if config.Values == nil {
+			config.Values = make(map[string]string, len(req.Values))
+		}
+		changes := len(config.History)
+		for _, name := range slices.Sorted(maps.Keys(req.Values)) {
+			if old := config.Values[name]; old != req.Values[name] {
+				config.History = append(config.History, &vtctldatapb.DynamicConfigChange{
+					Time:     now,
+					Flag:     name,
+					OldValue: old,
+					NewValue: req.Values[name],
+				})
+				config.Values[name] = req.Values[name]
+			}
+		}
+		for _, name := range req.Unset {
+			if old, ok := config.Values[name]; ok {
+				config.History = append(config.History, &vtctldatapb.DynamicConfigChange{
+					Time:     now,
+					Flag:     name,
+					OldValue: old,
+				})
+				delete(config.Values, name)
+			}
+		}
+		if len(config.History) == changes {
+			return topo.NewError(topo.NoUpdateNeeded, req.Keyspace)
+		}
+		if len(config.History) > dynamicConfigHistorySize {
+			config.History = config.History[len(config.History)-dynamicConfigHistorySize:]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.SetDynamicConfigResponse{
+		Config: config,
+	}, nil
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtctldatapb.SetKeyspaceDurabilityPolicyRequest) (resp *vtctldatapb.SetKeyspaceDurabilityPolicyResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceDurabilityPolicy")
@@ -3903,6 +4889,42 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 	}, nil
 }
 
+// SetScheduledJob is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetScheduledJob(ctx context.Context, req *vtctldatapb.SetScheduledJobRequest) (resp *vtctldatapb.SetScheduledJobResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetScheduledJob")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	if err := scheduler.ValidateJob(req.Job); err != nil {
+		return nil, err
+	}
+	span.Annotate("name", req.Job.Name)
+	span.Annotate("schedule", req.Job.Schedule)
+
+	job := req.Job.CloneVT()
+	job.Runs = nil
+	job.UpdatedAt = protoutil.TimeToProto(time.Now())
+
+	// The runs of an existing job are kept, so that the job is not run again
+	// for an occurrence it already ran.
+	ji, err := s.ts.UpdateScheduledJobFields(ctx, job.Name, func(ji *topo.ScheduledJobInfo) error {
+		runs := ji.Runs
+		ji.ScheduledJob = job.CloneVT()
+		ji.Runs = runs
+		return nil
+	})
+	if topo.IsErrType(err, topo.NoNode) {
+		ji, err = s.ts.CreateScheduledJob(ctx, job)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.SetScheduledJobResponse{
+		Job: ji.ScheduledJob,
+	}, nil
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetShardIsPrimaryServing(ctx context.Context, req *vtctldatapb.SetShardIsPrimaryServingRequest) (resp *vtctldatapb.SetShardIsPrimaryServingResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetShardIsPrimaryServing")
@@ -3985,6 +5007,70 @@ func (s *VtctldServer) SetShardTabletControl(ctx context.Context, req *vtctldata
 	}, nil
 }
 
+// SetShardWriteFence is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetShardWriteFence(ctx context.Context, req *vtctldatapb.SetShardWriteFenceRequest) (resp *vtctldatapb.SetShardWriteFenceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetShardWriteFence")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("reason", req.Reason)
+	span.Annotate("remove", req.Remove)
+
+	var fence *topodatapb.WriteFence
+	if !req.Remove {
+		if req.Reason == "" {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a reason is required to fence the writes of a shard")
+			return nil, err
+		}
+		bufferDuration, _, durErr := protoutil.DurationFromProto(req.BufferDuration)
+		if durErr != nil || bufferDuration < 0 {
+			err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid buffer duration %v", req.BufferDuration)
+			return nil, err
+		}
+		fence = &topodatapb.WriteFence{
+			Reason:         req.Reason,
+			BufferDuration: req.BufferDuration,
+		}
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetShardWriteFence")
+	if lockErr != nil {
+		err = lockErr
+		return nil, err
+	}
+
+	defer unlock(&err)
+
+	si, err := s.ts.UpdateShardFields(ctx, req.Keyspace, req.Shard, func(si *topo.ShardInfo) error {
+		if fence == nil && si.WriteFence == nil {
+			return topo.NewError(topo.NoUpdateNeeded, si.ShardName())
+		}
+		si.WriteFence = fence
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	if si == nil { // occurs only when there is no fence to remove
+		si, err = s.ts.GetShard(ctx, req.Keyspace, req.Shard)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.ts.UpdateSrvKeyspaceWriteFence(ctx, req.Keyspace, si.ShardName(), nil, fence); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetShardWriteFenceResponse{
+		Shard: si.Shard,
+	}, nil
+}
+
 // SetWritable is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) SetWritable(ctx context.Context, req *vtctldatapb.SetWritableRequest) (resp *vtctldatapb.SetWritableResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetWritable")
@@ -5543,6 +6629,21 @@ func (s *VtctldServer) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStop
 	return resp, err
 }
 
+// WorkflowCutBack is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowCutBack(ctx context.Context, req *vtctldatapb.WorkflowCutBackRequest) (resp *vtctldatapb.WorkflowCutBackResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowCutBack")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("dry_run", req.DryRun)
+
+	resp, err = s.ws.WorkflowCutBack(ctx, req)
+	return resp, err
+}
+
 // WorkflowDelete is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) WorkflowDelete(ctx context.Context, req *vtctldatapb.WorkflowDeleteRequest) (resp *vtctldatapb.WorkflowDeleteResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowDelete")
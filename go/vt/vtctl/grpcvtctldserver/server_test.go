@@ -18,6 +18,7 @@ package grpcvtctldserver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -26,6 +27,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -38,11 +40,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/event"
 	"vitess.io/vitess/go/mysql"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/callerid"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
@@ -353,6 +357,427 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplyTenantRoutingRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rules := &vschemapb.TenantRoutingRules{
+		Rules: []*vschemapb.TenantRoutingRule{
+			{
+				Keyspace:   "customers",
+				TenantId:   "42",
+				ToKeyspace: "customers_42",
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ApplyTenantRoutingRulesRequest
+		shouldErr string
+	}{
+		{
+			name: "success",
+			req: &vtctldatapb.ApplyTenantRoutingRulesRequest{
+				TenantRoutingRules: rules,
+			},
+		},
+		{
+			name: "missing tenant id",
+			req: &vtctldatapb.ApplyTenantRoutingRulesRequest{
+				TenantRoutingRules: &vschemapb.TenantRoutingRules{
+					Rules: []*vschemapb.TenantRoutingRule{
+						{
+							Keyspace:   "customers",
+							ToKeyspace: "customers_42",
+						},
+					},
+				},
+			},
+			shouldErr: "must have a keyspace, a tenant_id and a to_keyspace",
+		},
+		{
+			name: "duplicate rule",
+			req: &vtctldatapb.ApplyTenantRoutingRulesRequest{
+				TenantRoutingRules: &vschemapb.TenantRoutingRules{
+					Rules: []*vschemapb.TenantRoutingRule{
+						{
+							Keyspace:   "customers",
+							TenantId:   "42",
+							ToKeyspace: "customers_42",
+						},
+						{
+							Keyspace:   "customers",
+							TenantId:   "42",
+							ToKeyspace: "customers",
+						},
+					},
+				},
+			},
+			shouldErr: "duplicate tenant routing rule for tenant 42 of keyspace customers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyTenantRoutingRules(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err, "ApplyTenantRoutingRules(%+v) failed", tt.req)
+
+			resp, err := vtctld.GetTenantRoutingRules(ctx, &vtctldatapb.GetTenantRoutingRulesRequest{})
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.TenantRoutingRules, resp.TenantRoutingRules)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.TenantRoutingRules, srvVSchema.TenantRoutingRules)
+		})
+	}
+}
+
+func TestQueryRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	ruleNames := func(t *testing.T) []string {
+		resp, err := vtctld.GetQueryRules(ctx, &vtctldatapb.GetQueryRulesRequest{Keyspace: "ks"})
+		require.NoError(t, err)
+		var rules []struct{ Name string }
+		require.NoError(t, json.Unmarshal([]byte(resp.Rules), &rules))
+		var names []string
+		for _, rule := range rules {
+			names = append(names, rule.Name)
+		}
+		return names
+	}
+
+	for _, tc := range []struct {
+		keyspace string
+		rule     string
+		err      string
+	}{
+		{keyspace: "ks", rule: `{"Name": "r1", `, err: "invalid query rule"},
+		{keyspace: "ks", rule: `{"Name": "r1", "Tables": ["t1"]}`, err: "unrecognized tag Tables"},
+		{keyspace: "ks", rule: `{"Name": "r1", "Plans": ["Drop"]}`, err: "invalid plan name: Drop"},
+		{keyspace: "ks", rule: `{"Action": "FAIL"}`, err: "query rule must have a Name"},
+		{keyspace: "unknown", rule: `{"Name": "r1"}`, err: "node doesn't exist"},
+	} {
+		_, err := vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{Keyspace: tc.keyspace, Rule: tc.rule})
+		assert.ErrorContains(t, err, tc.err, tc.rule)
+	}
+
+	resp, err := vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{
+		Keyspace:      "ks",
+		Rule:          `{"Name": "deny_t1_deletes", "Plans": ["Delete", "DeleteLimit"], "TableNames": ["t1"], "Action": "FAIL"}`,
+		SampleQueries: []string{"delete from t1 where id = 1", "select * from t1", "delete from t2", "selec 1"},
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vtctldatapb.QueryRuleMatch{{
+		Query:  "delete from t1 where id = 1",
+		Plan:   "DeleteLimit",
+		Tables: []string{"t1"},
+		Rules:  []string{"deny_t1_deletes"},
+	}, {
+		Query:  "select * from t1",
+		Plan:   "Select",
+		Tables: []string{"t1"},
+	}, {
+		Query:  "delete from t2",
+		Plan:   "DeleteLimit",
+		Tables: []string{"t2"},
+	}, {
+		Query: "selec 1",
+		Error: "syntax error at position 6 near 'selec'",
+	}}, resp.Matches)
+
+	_, err = vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{Keyspace: "ks", Rule: `{"Name": "deny_t1_deletes"}`})
+	assert.ErrorContains(t, err, "query rule deny_t1_deletes already exists in keyspace ks")
+
+	// A dry run doesn't save the rule.
+	resp, err = vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{
+		Keyspace:      "ks",
+		Rule:          `{"Name": "deny_t2", "TableNames": ["t2"], "Action": "FAIL_RETRY"}`,
+		DryRun:        true,
+		SampleQueries: []string{"select * from t2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deny_t2"}, resp.Matches[0].Rules)
+	assert.Equal(t, []string{"deny_t1_deletes"}, ruleNames(t))
+
+	// A replaced rule keeps its position.
+	_, err = vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{Keyspace: "ks", Rule: `{"Name": "deny_t2", "TableNames": ["t2"]}`})
+	require.NoError(t, err)
+	_, err = vtctld.AddQueryRule(ctx, &vtctldatapb.AddQueryRuleRequest{Keyspace: "ks", Rule: `{"Name": "deny_t1_deletes", "Plans": ["Delete"], "TableNames": ["t1"]}`, Replace: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deny_t1_deletes", "deny_t2"}, ruleNames(t))
+
+	getResp, err := vtctld.GetQueryRules(ctx, &vtctldatapb.GetQueryRulesRequest{Keyspace: "ks", SampleQueries: []string{"delete from t1 limit 1", "delete from t1"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deny_t1_deletes"}, getResp.Matches[0].Rules)
+	assert.Empty(t, getResp.Matches[1].Rules)
+
+	_, err = vtctld.DeleteQueryRule(ctx, &vtctldatapb.DeleteQueryRuleRequest{Keyspace: "ks", Name: "deny_t1_deletes"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deny_t2"}, ruleNames(t))
+	_, err = vtctld.DeleteQueryRule(ctx, &vtctldatapb.DeleteQueryRuleRequest{Keyspace: "ks", Name: "deny_t1_deletes"})
+	assert.ErrorContains(t, err, "query rule deny_t1_deletes not found in keyspace ks")
+
+	// Deleting the last rule deletes the rules of the keyspace.
+	_, err = vtctld.DeleteQueryRule(ctx, &vtctldatapb.DeleteQueryRuleRequest{Keyspace: "ks", Name: "deny_t2"})
+	require.NoError(t, err)
+	data, err := ts.GetQueryRules(ctx, "ks")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestApplyMaintenanceWindows(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	mw := &topodatapb.MaintenanceWindows{
+		Windows: []*topodatapb.MaintenanceWindow{
+			{
+				Name:     "nightly",
+				Schedule: "0 2 * * *",
+				Duration: protoutil.DurationToProto(4 * time.Hour),
+			},
+			{
+				Name:      "customer-weekend",
+				Schedule:  "0 0 * * 6",
+				Duration:  protoutil.DurationToProto(48 * time.Hour),
+				Keyspaces: []string{"customer"},
+			},
+		},
+	}
+	resp, err := vtctld.ApplyMaintenanceWindows(ctx, &vtctldatapb.ApplyMaintenanceWindowsRequest{
+		MaintenanceWindows: mw,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, mw, resp.MaintenanceWindows)
+
+	getResp, err := vtctld.GetMaintenanceWindows(ctx, &vtctldatapb.GetMaintenanceWindowsRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, mw, getResp.MaintenanceWindows)
+
+	_, err = vtctld.ApplyMaintenanceWindows(ctx, &vtctldatapb.ApplyMaintenanceWindowsRequest{
+		MaintenanceWindows: &topodatapb.MaintenanceWindows{
+			Windows: []*topodatapb.MaintenanceWindow{{Name: "nightly", Schedule: "0 2 * *"}},
+		},
+	})
+	assert.ErrorContains(t, err, "maintenance window nightly: schedule \"0 2 * *\" must have 5 fields")
+
+	// Applying no windows removes them.
+	resp, err = vtctld.ApplyMaintenanceWindows(ctx, &vtctldatapb.ApplyMaintenanceWindowsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.MaintenanceWindows.Windows)
+}
+
+func TestApplyPlanPins(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ApplyPlanPinsRequest
+		shouldErr string
+	}{
+		{
+			name: "success",
+			req: &vtctldatapb.ApplyPlanPinsRequest{
+				PlanPins: &vschemapb.PlanPins{
+					Pins: []*vschemapb.PlanPin{
+						{
+							Digest: "8c3e4c7a5b6d2f10",
+							Plan:   `{"OperatorType": "Route", "Variant": "EqualUnique", "Keyspace": {"Name": "customers", "Sharded": true}}`,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing digest",
+			req: &vtctldatapb.ApplyPlanPinsRequest{
+				PlanPins: &vschemapb.PlanPins{
+					Pins: []*vschemapb.PlanPin{
+						{
+							Plan: `{"OperatorType": "Route"}`,
+						},
+					},
+				},
+			},
+			shouldErr: "plan pin must have a digest",
+		},
+		{
+			name: "invalid plan",
+			req: &vtctldatapb.ApplyPlanPinsRequest{
+				PlanPins: &vschemapb.PlanPins{
+					Pins: []*vschemapb.PlanPin{
+						{
+							Digest: "8c3e4c7a5b6d2f10",
+							Plan:   "select 1",
+						},
+					},
+				},
+			},
+			shouldErr: "the plan of digest 8c3e4c7a5b6d2f10 is not a JSON plan description",
+		},
+		{
+			name: "duplicate digest",
+			req: &vtctldatapb.ApplyPlanPinsRequest{
+				PlanPins: &vschemapb.PlanPins{
+					Pins: []*vschemapb.PlanPin{
+						{
+							Digest: "8c3e4c7a5b6d2f10",
+							Plan:   `{"OperatorType": "Route"}`,
+						},
+						{
+							Digest: "8c3e4c7a5b6d2f10",
+							Plan:   `{"OperatorType": "Join"}`,
+						},
+					},
+				},
+			},
+			shouldErr: "duplicate plan pin for digest 8c3e4c7a5b6d2f10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyPlanPins(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err, "ApplyPlanPins(%+v) failed", tt.req)
+
+			resp, err := vtctld.GetPlanPins(ctx, &vtctldatapb.GetPlanPinsRequest{})
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.PlanPins, resp.PlanPins)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.PlanPins, srvVSchema.PlanPins)
+		})
+	}
+}
+
+func TestApplyRewriteRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tests := []struct {
+		name      string
+		req       *vtctldatapb.ApplyRewriteRulesRequest
+		shouldErr string
+	}{
+		{
+			name: "success",
+			req: &vtctldatapb.ApplyRewriteRulesRequest{
+				RewriteRules: &vschemapb.RewriteRules{
+					Rules: []*vschemapb.RewriteRule{
+						{
+							Name:        "hint",
+							Pattern:     "select * from orders where customer_id = :id",
+							Replacement: "select * from orders force index (customer) where customer_id = :id",
+						},
+						{
+							Name:        "rename",
+							Regex:       `\blegacy_orders\b`,
+							Replacement: "orders",
+							DryRun:      true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid pattern",
+			req: &vtctldatapb.ApplyRewriteRulesRequest{
+				RewriteRules: &vschemapb.RewriteRules{
+					Rules: []*vschemapb.RewriteRule{
+						{
+							Name:        "hint",
+							Pattern:     "select * from",
+							Replacement: "select 1",
+						},
+					},
+				},
+			},
+			shouldErr: "rewrite rule hint: invalid pattern",
+		},
+		{
+			name: "duplicate name",
+			req: &vtctldatapb.ApplyRewriteRulesRequest{
+				RewriteRules: &vschemapb.RewriteRules{
+					Rules: []*vschemapb.RewriteRule{
+						{
+							Name:        "rename",
+							Regex:       `\blegacy_orders\b`,
+							Replacement: "orders",
+						},
+						{
+							Name:        "rename",
+							Regex:       `\blegacy_users\b`,
+							Replacement: "users",
+						},
+					},
+				},
+			},
+			shouldErr: "duplicate rewrite rule rename",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := memorytopo.NewServer(ctx, "zone1")
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			_, err := vtctld.ApplyRewriteRules(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err, "ApplyRewriteRules(%+v) failed", tt.req)
+
+			resp, err := vtctld.GetRewriteRules(ctx, &vtctldatapb.GetRewriteRulesRequest{})
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.RewriteRules, resp.RewriteRules)
+
+			srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.req.RewriteRules, srvVSchema.RewriteRules)
+		})
+	}
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -685,6 +1110,15 @@ func TestApplyVSchema(t *testing.T) {
 					ShardRoutingRules: &vschemapb.ShardRoutingRules{
 						Rules: []*vschemapb.ShardRoutingRule{},
 					},
+					TenantRoutingRules: &vschemapb.TenantRoutingRules{
+						Rules: []*vschemapb.TenantRoutingRule{},
+					},
+					PlanPins: &vschemapb.PlanPins{
+						Pins: []*vschemapb.PlanPin{},
+					},
+					RewriteRules: &vschemapb.RewriteRules{
+						Rules: []*vschemapb.RewriteRule{},
+					},
 				}
 				utils.MustMatch(t, changedSrvVSchema, finalSrvVSchema)
 			}
@@ -692,6 +1126,65 @@ func TestApplyVSchema(t *testing.T) {
 	}
 }
 
+func TestApplyVSchemaAudit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The listeners can't be removed, so the events are told apart by keyspace.
+	const keyspace = "auditkeyspace"
+	var (
+		mu     sync.Mutex
+		events []*audit.Event
+	)
+	event.AddListener(func(ev *audit.Event) {
+		if ev.Keyspace != keyspace {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	})
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     keyspace,
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	err := ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     keyspace,
+		Keyspace: &vschemapb.Keyspace{Tables: map[string]*vschemapb.Table{"t1": {}}},
+	})
+	require.NoError(t, err)
+
+	ctx = callerid.NewContext(ctx, callerid.NewEffectiveCallerID("alice", "", ""), nil)
+	req := &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace: keyspace,
+		VSchema:  &vschemapb.Keyspace{Tables: map[string]*vschemapb.Table{"t1": {}, "t2": {}}},
+	}
+
+	// A dry run changes nothing, and is not audited.
+	req.DryRun = true
+	_, err = vtctld.ApplyVSchema(ctx, req)
+	require.NoError(t, err)
+
+	req.DryRun = false
+	_, err = vtctld.ApplyVSchema(ctx, req)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 1)
+	assert.Equal(t, audit.ApplyVSchema, events[0].Type)
+	assert.Equal(t, "alice", events[0].Actor)
+	assert.Equal(t, audit.Success, events[0].Result)
+	assert.Contains(t, events[0].Diff, "+    \"t2\": {}")
+}
+
 func TestBackup(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -2435,6 +2928,150 @@ func TestForceCutOverSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestCloneTablet(t *testing.T) {
+	t.Parallel()
+
+	tablet := func(cell string, uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: cell, Uid: uid},
+			Keyspace: "ks",
+			Shard:    "-",
+			Type:     tabletType,
+		}
+	}
+	tablets := []*topodatapb.Tablet{
+		tablet("zone1", 100, topodatapb.TabletType_REPLICA),
+		tablet("zone1", 101, topodatapb.TabletType_REPLICA),
+		tablet("zone2", 102, topodatapb.TabletType_REPLICA),
+		tablet("zone1", 103, topodatapb.TabletType_RDONLY),
+		tablet("zone1", 200, topodatapb.TabletType_PRIMARY),
+	}
+	newTMC := func(pluginStatus string) *testutil.TabletManagerClient {
+		pluginResult := &querypb.QueryResult{}
+		if pluginStatus != "" {
+			pluginResult = sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("PLUGIN_STATUS", "varchar"), pluginStatus))
+		}
+		tmc := &testutil.TabletManagerClient{
+			ExecuteFetchAsDbaResults: map[string]struct {
+				Response *querypb.QueryResult
+				Error    error
+			}{},
+			PrimaryPositionResults: map[string]struct {
+				Position string
+				Error    error
+			}{
+				"zone1-0000000200": {Position: "some-position"},
+			},
+			ReplicationStatusResults: map[string]struct {
+				Position *replicationdatapb.Status
+				Error    error
+			}{
+				"zone1-0000000100": {Error: assert.AnError},
+				"zone1-0000000101": {Position: &replicationdatapb.Status{ReplicationLagSeconds: 5}},
+				"zone2-0000000102": {Position: &replicationdatapb.Status{ReplicationLagSeconds: 0}},
+				"zone1-0000000103": {Position: &replicationdatapb.Status{ReplicationLagSeconds: 2}},
+			},
+			RestoreFromCloneResults: map[string]struct {
+				Response *tabletmanagerdatapb.RestoreFromCloneResponse
+				Error    error
+			}{
+				"zone1-0000000100": {Response: &tabletmanagerdatapb.RestoreFromCloneResponse{Position: "cloned-position"}},
+			},
+		}
+		for _, tablet := range tablets {
+			tmc.ExecuteFetchAsDbaResults[topoproto.TabletAliasString(tablet.Alias)] = struct {
+				Response *querypb.QueryResult
+				Error    error
+			}{Response: pluginResult}
+		}
+		return tmc
+	}
+
+	tests := []struct {
+		name      string
+		tmc       *testutil.TabletManagerClient
+		req       *vtctldatapb.CloneTabletRequest
+		expected  *vtctldatapb.CloneTabletResponse
+		shouldErr string
+	}{
+		{
+			name: "replica of the cell with the lowest lag",
+			tmc:  newTMC("ACTIVE"),
+			req: &vtctldatapb.CloneTabletRequest{
+				TabletAlias: tablets[0].Alias,
+			},
+			expected: &vtctldatapb.CloneTabletResponse{
+				DonorAlias: tablets[3].Alias,
+				Position:   "cloned-position",
+			},
+		},
+		{
+			name: "given donor",
+			tmc:  newTMC("ACTIVE"),
+			req: &vtctldatapb.CloneTabletRequest{
+				TabletAlias: tablets[0].Alias,
+				DonorAlias:  tablets[2].Alias,
+			},
+			expected: &vtctldatapb.CloneTabletResponse{
+				DonorAlias: tablets[2].Alias,
+				Position:   "cloned-position",
+			},
+		},
+		{
+			name: "primary donor not allowed",
+			tmc:  newTMC("ACTIVE"),
+			req: &vtctldatapb.CloneTabletRequest{
+				TabletAlias: tablets[0].Alias,
+				DonorAlias:  tablets[4].Alias,
+			},
+			shouldErr: "requires --allow-primary-donor",
+		},
+		{
+			name: "clone into the primary",
+			tmc:  newTMC("ACTIVE"),
+			req: &vtctldatapb.CloneTabletRequest{
+				TabletAlias: tablets[4].Alias,
+			},
+			shouldErr: "cannot clone into primary tablet",
+		},
+		{
+			name: "clone plugin not installed on the donor",
+			tmc:  newTMC(""),
+			req: &vtctldatapb.CloneTabletRequest{
+				TabletAlias: tablets[0].Alias,
+			},
+			shouldErr: "the clone plugin is not active on donor zone1-0000000103",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+				AlsoSetShardPrimary: true,
+			}, tablets...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, test.tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+
+			resp, err := vtctld.CloneTablet(ctx, test.req)
+			if test.shouldErr != "" {
+				assert.ErrorContains(t, err, test.shouldErr)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, test.expected, resp)
+		})
+	}
+}
+
 func TestCompleteSchemaMigration(t *testing.T) {
 	t.Parallel()
 
@@ -4672,23 +5309,94 @@ func TestDeleteTablets(t *testing.T) {
 				utils.MustMatch(t, tt.expectedRemainingTablets, resp.Tablets)
 			}
 
-			// Run the test
-			resp, err := vtctld.DeleteTablets(ctx, tt.req)
-			if tt.shouldErr {
-				assert.Error(t, err)
+			// Run the test
+			resp, err := vtctld.DeleteTablets(ctx, tt.req)
+			if tt.shouldErr {
+				assert.Error(t, err)
+
+				if tt.expectedRemainingTablets != nil {
+					checkRemainingTablets()
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+			checkRemainingTablets()
+		})
+	}
+}
+
+func TestDrainCell(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+	primary := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}
+	testutil.AddTablets(ctx, t, ts, nil, primary, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "testkeyspace",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	})
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	_, err := vtctld.DrainCell(ctx, &vtctldatapb.DrainCellRequest{
+		Cell: "zone1",
+		Step: 101,
+	})
+	assert.ErrorContains(t, err, "step must be between 0 and 100")
+
+	// The primary left in the cell makes it unsafe to shut down.
+	resp, err := vtctld.DrainCell(ctx, &vtctldatapb.DrainCellRequest{
+		Cell:         "zone1",
+		Step:         50,
+		StepInterval: protoutil.DurationToProto(time.Millisecond),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.CellInfo.Drain)
+	assert.EqualValues(t, 0, resp.CellInfo.Drain.ReadWeight)
+	assert.NotNil(t, resp.CellInfo.Drain.StartTime)
+	utils.MustMatch(t, []*topodatapb.TabletAlias{primary.Alias}, resp.Primaries)
+	assert.False(t, resp.SafeToShutDown)
 
-				if tt.expectedRemainingTablets != nil {
-					checkRemainingTablets()
-				}
+	drains, err := ts.GetCellDrains(ctx)
+	require.NoError(t, err)
+	assert.Len(t, drains, 1)
+	assert.Contains(t, drains, "zone1")
 
-				return
-			}
+	// Once the primary is gone, the cell is safe to shut down.
+	_, err = ts.UpdateTabletFields(ctx, primary.Alias, func(tablet *topodatapb.Tablet) error {
+		tablet.Type = topodatapb.TabletType_REPLICA
+		return nil
+	})
+	require.NoError(t, err)
+	resp, err = vtctld.DrainCell(ctx, &vtctldatapb.DrainCellRequest{Cell: "zone1"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Primaries)
+	assert.True(t, resp.SafeToShutDown)
 
-			assert.NoError(t, err)
-			utils.MustMatch(t, tt.expected, resp)
-			checkRemainingTablets()
-		})
-	}
+	resp, err = vtctld.DrainCell(ctx, &vtctldatapb.DrainCellRequest{
+		Cell:    "zone1",
+		Undrain: true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.CellInfo.Drain)
+	assert.False(t, resp.SafeToShutDown)
+
+	_, err = vtctld.DrainCell(ctx, &vtctldatapb.DrainCellRequest{Cell: "zone3"})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected a NoNode error, got %v", err)
 }
 
 func TestEmergencyReparentShard(t *testing.T) {
@@ -5778,6 +6486,117 @@ func TestFindAllShardsInKeyspace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+const (
+	errantGTIDsTestPrimarySID = "00010203-0405-0607-0809-0a0b0c0d0e0f"
+	errantGTIDsTestErrantSID  = "00010203-0405-0607-0809-0a0b0c0d0eff"
+)
+
+// errantGTIDsTestShard adds a shard with primary zone1-0000000200, replica
+// zone1-0000000100 which has errant GTIDs and replica zone1-0000000101 which
+// has none, and returns a fake tablet manager client which reports their
+// positions.
+func errantGTIDsTestShard(ctx context.Context, t *testing.T, ts *topo.Server) *testutil.TabletManagerClient {
+	t.Helper()
+
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{
+		AlsoSetShardPrimary: true,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+		Keyspace: "ks",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_REPLICA,
+	}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Keyspace: "ks",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_RDONLY,
+	})
+
+	return &testutil.TabletManagerClient{
+		PrimaryPositionResults: map[string]struct {
+			Position string
+			Error    error
+		}{
+			"zone1-0000000200": {
+				Position: "MySQL56/" + errantGTIDsTestPrimarySID + ":1-10",
+			},
+		},
+		PrimaryStatusResults: map[string]struct {
+			Status *replicationdatapb.PrimaryStatus
+			Error  error
+		}{
+			"zone1-0000000200": {
+				Status: &replicationdatapb.PrimaryStatus{
+					Position:   "MySQL56/" + errantGTIDsTestPrimarySID + ":1-12",
+					ServerUuid: errantGTIDsTestPrimarySID,
+				},
+			},
+		},
+		ReplicationStatusResults: map[string]struct {
+			Position *replicationdatapb.Status
+			Error    error
+		}{
+			"zone1-0000000100": {
+				Position: &replicationdatapb.Status{
+					Position: "MySQL56/" + errantGTIDsTestPrimarySID + ":1-10," + errantGTIDsTestErrantSID + ":1-3",
+				},
+			},
+			"zone1-0000000101": {
+				// The replica received transactions the primary executed
+				// after its position was read.
+				Position: &replicationdatapb.Status{
+					Position: "MySQL56/" + errantGTIDsTestPrimarySID + ":1-11",
+				},
+			},
+		},
+	}
+}
+
+func TestFindErrantGTIDs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	tmc := errantGTIDsTestShard(ctx, t, ts)
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	resp, err := vtctld.FindErrantGTIDs(ctx, &vtctldatapb.FindErrantGTIDsRequest{
+		Keyspace: "ks",
+		Shard:    "-",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.FindErrantGTIDsResponse{
+		Replicas: []*vtctldatapb.ReplicaErrantGTIDs{{
+			TabletAlias:     &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			ErrantGtids:     errantGTIDsTestErrantSID + ":1-3",
+			ErrantGtidCount: 3,
+		}},
+	}, resp)
+
+	// The binary logs of the replica cannot be read.
+	_, err = vtctld.FindErrantGTIDs(ctx, &vtctldatapb.FindErrantGTIDsRequest{
+		Keyspace: "ks",
+		Shard:    "-",
+		Explain:  true,
+		MaxGtids: 1_000,
+	})
+	assert.ErrorContains(t, err, "cannot explain the errant GTIDs of zone1-0000000100")
+
+	_, err = vtctld.FindErrantGTIDs(ctx, &vtctldatapb.FindErrantGTIDsRequest{
+		Keyspace: "ks",
+		Shard:    "-80",
+	})
+	assert.Error(t, err)
+}
+
 func TestGetBackups(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -6013,6 +6832,79 @@ func TestGetCellsAliases(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDynamicConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+
+	resp, err := vtctld.GetDynamicConfig(ctx, &vtctldatapb.GetDynamicConfigRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Config.Values)
+
+	setResp, err := vtctld.SetDynamicConfig(ctx, &vtctldatapb.SetDynamicConfigRequest{
+		Values: map[string]string{
+			"transaction_mode":             "twopc",
+			"min_number_serving_vttablets": "3",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"transaction_mode": "twopc", "min_number_serving_vttablets": "3"}, setResp.Config.Values)
+	require.Len(t, setResp.Config.History, 2)
+	assert.Equal(t, "min_number_serving_vttablets", setResp.Config.History[0].Flag)
+
+	setResp, err = vtctld.SetDynamicConfig(ctx, &vtctldatapb.SetDynamicConfigRequest{
+		Values: map[string]string{"min_number_serving_vttablets": "4"},
+		Unset:  []string{"transaction_mode"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"min_number_serving_vttablets": "4"}, setResp.Config.Values)
+	require.Len(t, setResp.Config.History, 4)
+	assert.Equal(t, "3", setResp.Config.History[2].OldValue)
+	assert.Equal(t, "4", setResp.Config.History[2].NewValue)
+	assert.Equal(t, "transaction_mode", setResp.Config.History[3].Flag)
+	assert.Empty(t, setResp.Config.History[3].NewValue)
+
+	// Setting the same values again does not change the history.
+	setResp, err = vtctld.SetDynamicConfig(ctx, &vtctldatapb.SetDynamicConfigRequest{
+		Values: map[string]string{"min_number_serving_vttablets": "4"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, setResp.Config.History, 4)
+
+	_, err = vtctld.SetDynamicConfig(ctx, &vtctldatapb.SetDynamicConfigRequest{
+		Keyspace: "ks",
+		Values:   map[string]string{"queryserver-config-pool-size": "50"},
+	})
+	require.NoError(t, err)
+	resp, err = vtctld.GetDynamicConfig(ctx, &vtctldatapb.GetDynamicConfigRequest{Keyspace: "ks"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"queryserver-config-pool-size": "50"}, resp.Config.Values)
+
+	for _, req := range []*vtctldatapb.SetDynamicConfigRequest{
+		{},
+		{Values: map[string]string{"queryserver-config-pool-size": "none"}},
+		{Keyspace: "ks", Values: map[string]string{"transaction_mode": "single"}},
+		{Values: map[string]string{"transaction_mode": "single"}, Unset: []string{"transaction_mode"}},
+		{Keyspace: "unknown", Values: map[string]string{"queryserver-config-pool-size": "50"}},
+	} {
+		_, err = vtctld.SetDynamicConfig(ctx, req)
+		assert.Error(t, err, "%v", req)
+	}
+
+	// The config of a keyspace is deleted with the keyspace.
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{Keyspace: "ks"})
+	require.NoError(t, err)
+	resp, err = vtctld.GetDynamicConfig(ctx, &vtctldatapb.GetDynamicConfigRequest{Keyspace: "ks"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Config.Values)
+}
+
 func TestGetFullStatus(t *testing.T) {
 	t.Parallel()
 
@@ -6366,6 +7258,83 @@ func TestGetRoutingRules(t *testing.T) {
 	}
 }
 
+func TestScheduledJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	backups := &vtctldatapb.ScheduledJob{
+		Name:     "backups",
+		Schedule: "0 2 * * *",
+		Job: &vtctldatapb.ScheduledJob_Backup{
+			Backup: &vtctldatapb.ScheduledJob_BackupJob{Keyspaces: []string{"commerce"}},
+		},
+		// The runs are ignored.
+		Runs: []*vtctldatapb.ScheduledJobRun{{Vtctld: "vtctld1"}},
+	}
+	resp, err := vtctld.SetScheduledJob(ctx, &vtctldatapb.SetScheduledJobRequest{Job: backups})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Job.Runs)
+	assert.NotNil(t, resp.Job.UpdatedAt)
+
+	_, err = vtctld.SetScheduledJob(ctx, &vtctldatapb.SetScheduledJobRequest{Job: &vtctldatapb.ScheduledJob{
+		Name:     "purge",
+		Schedule: "every day",
+		Job: &vtctldatapb.ScheduledJob_PurgeSchemaMigrations{
+			PurgeSchemaMigrations: &vtctldatapb.ScheduledJob_PurgeSchemaMigrationsJob{},
+		},
+	}})
+	assert.ErrorContains(t, err, "invalid job purge")
+
+	// Setting an existing job keeps its runs.
+	_, err = ts.UpdateScheduledJobFields(ctx, "backups", func(ji *topo.ScheduledJobInfo) error {
+		ji.Runs = []*vtctldatapb.ScheduledJobRun{{Vtctld: "vtctld1"}}
+		return nil
+	})
+	require.NoError(t, err)
+	backups.Schedule = "@daily"
+	backups.Concurrency = 4
+	resp, err = vtctld.SetScheduledJob(ctx, &vtctldatapb.SetScheduledJobRequest{Job: backups})
+	require.NoError(t, err)
+	assert.Equal(t, "@daily", resp.Job.Schedule)
+	require.Len(t, resp.Job.Runs, 1)
+	assert.Equal(t, "vtctld1", resp.Job.Runs[0].Vtctld)
+
+	_, err = vtctld.SetScheduledJob(ctx, &vtctldatapb.SetScheduledJobRequest{Job: &vtctldatapb.ScheduledJob{
+		Name:     "vdiffs",
+		Schedule: "@weekly",
+		Job: &vtctldatapb.ScheduledJob_Vdiff{
+			Vdiff: &vtctldatapb.ScheduledJob_VDiffJob{Workflows: []string{"customer.commerce2customer"}},
+		},
+	}})
+	require.NoError(t, err)
+
+	getResp, err := vtctld.GetScheduledJobs(ctx, &vtctldatapb.GetScheduledJobsRequest{})
+	require.NoError(t, err)
+	require.Len(t, getResp.Jobs, 2)
+	assert.Equal(t, "backups", getResp.Jobs[0].Name)
+	assert.EqualValues(t, 4, getResp.Jobs[0].Concurrency)
+	assert.Equal(t, "vdiffs", getResp.Jobs[1].Name)
+
+	getResp, err = vtctld.GetScheduledJobs(ctx, &vtctldatapb.GetScheduledJobsRequest{Name: "vdiffs"})
+	require.NoError(t, err)
+	require.Len(t, getResp.Jobs, 1)
+	assert.Equal(t, []string{"customer.commerce2customer"}, getResp.Jobs[0].GetVdiff().Workflows)
+
+	_, err = vtctld.DeleteScheduledJob(ctx, &vtctldatapb.DeleteScheduledJobRequest{Name: "vdiffs"})
+	require.NoError(t, err)
+	_, err = vtctld.GetScheduledJobs(ctx, &vtctldatapb.GetScheduledJobsRequest{Name: "vdiffs"})
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+	getResp, err = vtctld.GetScheduledJobs(ctx, &vtctldatapb.GetScheduledJobsRequest{})
+	require.NoError(t, err)
+	require.Len(t, getResp.Jobs, 1)
+}
+
 func TestGetSchema(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -10394,6 +11363,147 @@ func TestRemoveShardCell(t *testing.T) {
 	}
 }
 
+func TestRepairErrantGTIDs(t *testing.T) {
+	t.Parallel()
+
+	replica := &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}
+	primary := &topodatapb.TabletAlias{Cell: "zone1", Uid: 200}
+	queries := []string{
+		"SET GTID_NEXT = '" + errantGTIDsTestErrantSID + ":1'", "BEGIN", "COMMIT",
+		"SET GTID_NEXT = '" + errantGTIDsTestErrantSID + ":2'", "BEGIN", "COMMIT",
+		"SET GTID_NEXT = '" + errantGTIDsTestErrantSID + ":3'", "BEGIN", "COMMIT",
+		"SET GTID_NEXT = 'AUTOMATIC'",
+	}
+
+	tests := []struct {
+		name     string
+		tmc      func(tmc *testutil.TabletManagerClient)
+		req      *vtctldatapb.RepairErrantGTIDsRequest
+		expected *vtctldatapb.RepairErrantGTIDsResponse
+		// events is the number of restore events expected in the response.
+		events    int
+		shouldErr string
+	}{
+		{
+			name: "inject empty transactions",
+			tmc: func(tmc *testutil.TabletManagerClient) {
+				tmc.ExecuteMultiFetchAsDbaResults = map[string]struct {
+					Response []*querypb.QueryResult
+					Error    error
+				}{
+					"zone1-0000000200": {},
+				}
+			},
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: replica,
+				Method:      vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS,
+				MaxGtids:    3,
+			},
+			expected: &vtctldatapb.RepairErrantGTIDsResponse{
+				ErrantGtids: errantGTIDsTestErrantSID + ":1-3",
+				Primary:     primary,
+				Queries:     queries,
+			},
+		},
+		{
+			name: "inject empty transactions dry run",
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: replica,
+				Method:      vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS,
+				MaxGtids:    3,
+				DryRun:      true,
+			},
+			expected: &vtctldatapb.RepairErrantGTIDsResponse{
+				ErrantGtids: errantGTIDsTestErrantSID + ":1-3",
+				Primary:     primary,
+				Queries:     queries,
+			},
+		},
+		{
+			name: "too many GTIDs to inject",
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: replica,
+				Method:      vtctldatapb.RepairErrantGTIDsRequest_INJECT_EMPTY_TRANSACTIONS,
+				MaxGtids:    2,
+			},
+			shouldErr: "3 GTIDs would have to be injected as empty transactions, more than the maximum of 2",
+		},
+		{
+			name: "restore",
+			tmc: func(tmc *testutil.TabletManagerClient) {
+				tmc.RestoreFromBackupResults = map[string]struct {
+					Events        []*logutilpb.Event
+					EventInterval time.Duration
+					EventJitter   time.Duration
+					ErrorAfter    time.Duration
+				}{
+					"zone1-0000000100": {
+						Events:        []*logutilpb.Event{{}, {}, {}},
+						EventInterval: time.Millisecond,
+						EventJitter:   time.Millisecond,
+					},
+				}
+				tmc.SetReplicationSourceResults = map[string]error{
+					"zone1-0000000100": nil,
+				}
+			},
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: replica,
+				Method:      vtctldatapb.RepairErrantGTIDsRequest_RESTORE,
+			},
+			expected: &vtctldatapb.RepairErrantGTIDsResponse{
+				ErrantGtids: errantGTIDsTestErrantSID + ":1-3",
+				Primary:     primary,
+			},
+			events: 3,
+		},
+		{
+			name: "no errant GTIDs",
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+				Method:      vtctldatapb.RepairErrantGTIDsRequest_RESTORE,
+			},
+			expected: &vtctldatapb.RepairErrantGTIDsResponse{
+				Primary: primary,
+			},
+		},
+		{
+			name: "unknown method",
+			req: &vtctldatapb.RepairErrantGTIDsRequest{
+				TabletAlias: replica,
+			},
+			shouldErr: "unsupported repair method UNKNOWN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := errantGTIDsTestShard(ctx, t, ts)
+			if tt.tmc != nil {
+				tt.tmc(tmc)
+			}
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+
+			resp, err := vtctld.RepairErrantGTIDs(ctx, tt.req)
+			if tt.shouldErr != "" {
+				assert.ErrorContains(t, err, tt.shouldErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, resp.Events, tt.events)
+			resp.Events = nil
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestReparentTablet(t *testing.T) {
 	t.Parallel()
 
@@ -11832,6 +12942,68 @@ func TestSetShardTabletControl(t *testing.T) {
 	}
 }
 
+func TestSetShardWriteFence(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "-80",
+	})
+	// zone2 has no SrvKeyspace, and is skipped.
+	err := ts.UpdateSrvKeyspace(ctx, "zone1", "testkeyspace", &topodatapb.SrvKeyspace{})
+	require.NoError(t, err)
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	fence := &topodatapb.WriteFence{
+		Reason:         "maintenance",
+		BufferDuration: protoutil.DurationToProto(2 * time.Second),
+	}
+	resp, err := vtctld.SetShardWriteFence(ctx, &vtctldatapb.SetShardWriteFenceRequest{
+		Keyspace:       "testkeyspace",
+		Shard:          "-80",
+		Reason:         fence.Reason,
+		BufferDuration: fence.BufferDuration,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, fence, resp.Shard.WriteFence)
+	srvKeyspace, err := ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]*topodatapb.WriteFence{"-80": fence}, srvKeyspace.WriteFences)
+
+	// A reason is required.
+	_, err = vtctld.SetShardWriteFence(ctx, &vtctldatapb.SetShardWriteFenceRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-80",
+	})
+	assert.ErrorContains(t, err, "a reason is required")
+
+	resp, err = vtctld.SetShardWriteFence(ctx, &vtctldatapb.SetShardWriteFenceRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-80",
+		Remove:   true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Shard.WriteFence)
+	srvKeyspace, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+	assert.Empty(t, srvKeyspace.WriteFences)
+
+	// Removing a missing fence is a no-op.
+	_, err = vtctld.SetShardWriteFence(ctx, &vtctldatapb.SetShardWriteFenceRequest{
+		Keyspace: "testkeyspace",
+		Shard:    "-80",
+		Remove:   true,
+	})
+	require.NoError(t, err)
+}
+
 func TestSetWritable(t *testing.T) {
 	t.Parallel()
 
@@ -328,6 +328,11 @@ type TabletManagerClient struct {
 		EventJitter   time.Duration
 		ErrorAfter    time.Duration
 	}
+	// keyed by tablet alias.
+	RestoreFromCloneResults map[string]struct {
+		Response *tabletmanagerdatapb.RestoreFromCloneResponse
+		Error    error
+	}
 	// keyed by tablet alias
 	RunHealthCheckDelays map[string]time.Duration
 	// keyed by tablet alias
@@ -1211,6 +1216,16 @@ func (fake *TabletManagerClient) RestoreFromBackup(ctx context.Context, tablet *
 	return stream, nil
 }
 
+// RestoreFromClone is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) RestoreFromClone(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.RestoreFromCloneResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return nil, fmt.Errorf("no RestoreFromClone fake result set for %s", key)
+}
+
 // RunHealthCheck is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) RunHealthCheck(ctx context.Context, tablet *topodatapb.Tablet) error {
 	if fake.RunHealthCheckResults == nil {
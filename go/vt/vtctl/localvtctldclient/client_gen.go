@@ -39,11 +39,31 @@ func (client *localVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldat
 	return client.s.AddCellsAlias(ctx, in)
 }
 
+// AddQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) AddQueryRule(ctx context.Context, in *vtctldatapb.AddQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.AddQueryRuleResponse, error) {
+	return client.s.AddQueryRule(ctx, in)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	return client.s.ApplyKeyspaceRoutingRules(ctx, in)
 }
 
+// ApplyMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyMaintenanceWindows(ctx context.Context, in *vtctldatapb.ApplyMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyMaintenanceWindowsResponse, error) {
+	return client.s.ApplyMaintenanceWindows(ctx, in)
+}
+
+// ApplyPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyPlanPins(ctx context.Context, in *vtctldatapb.ApplyPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyPlanPinsResponse, error) {
+	return client.s.ApplyPlanPins(ctx, in)
+}
+
+// ApplyRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyRewriteRules(ctx context.Context, in *vtctldatapb.ApplyRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRewriteRulesResponse, error) {
+	return client.s.ApplyRewriteRules(ctx, in)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	return client.s.ApplyRoutingRules(ctx, in)
@@ -59,6 +79,11 @@ func (client *localVtctldClient) ApplyShardRoutingRules(ctx context.Context, in
 	return client.s.ApplyShardRoutingRules(ctx, in)
 }
 
+// ApplyTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyTenantRoutingRules(ctx context.Context, in *vtctldatapb.ApplyTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTenantRoutingRulesResponse, error) {
+	return client.s.ApplyTenantRoutingRules(ctx, in)
+}
+
 // ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
 	return client.s.ApplyVSchema(ctx, in)
@@ -191,6 +216,11 @@ func (client *localVtctldClient) CleanupSchemaMigration(ctx context.Context, in
 	return client.s.CleanupSchemaMigration(ctx, in)
 }
 
+// CloneTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) CloneTablet(ctx context.Context, in *vtctldatapb.CloneTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.CloneTabletResponse, error) {
+	return client.s.CloneTablet(ctx, in)
+}
+
 // CompleteSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) CompleteSchemaMigration(ctx context.Context, in *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
 	return client.s.CompleteSchemaMigration(ctx, in)
@@ -231,6 +261,16 @@ func (client *localVtctldClient) DeleteKeyspace(ctx context.Context, in *vtctlda
 	return client.s.DeleteKeyspace(ctx, in)
 }
 
+// DeleteQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DeleteQueryRule(ctx context.Context, in *vtctldatapb.DeleteQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteQueryRuleResponse, error) {
+	return client.s.DeleteQueryRule(ctx, in)
+}
+
+// DeleteScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DeleteScheduledJob(ctx context.Context, in *vtctldatapb.DeleteScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteScheduledJobResponse, error) {
+	return client.s.DeleteScheduledJob(ctx, in)
+}
+
 // DeleteShards is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) DeleteShards(ctx context.Context, in *vtctldatapb.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteShardsResponse, error) {
 	return client.s.DeleteShards(ctx, in)
@@ -246,6 +286,11 @@ func (client *localVtctldClient) DeleteTablets(ctx context.Context, in *vtctldat
 	return client.s.DeleteTablets(ctx, in)
 }
 
+// DrainCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) DrainCell(ctx context.Context, in *vtctldatapb.DrainCellRequest, opts ...grpc.CallOption) (*vtctldatapb.DrainCellResponse, error) {
+	return client.s.DrainCell(ctx, in)
+}
+
 // EmergencyReparentShard is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldatapb.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.EmergencyReparentShardResponse, error) {
 	return client.s.EmergencyReparentShard(ctx, in)
@@ -271,11 +316,21 @@ func (client *localVtctldClient) ExecuteMultiFetchAsDBA(ctx context.Context, in
 	return client.s.ExecuteMultiFetchAsDBA(ctx, in)
 }
 
+// ExportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ExportTopology(ctx context.Context, in *vtctldatapb.ExportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ExportTopologyResponse, error) {
+	return client.s.ExportTopology(ctx, in)
+}
+
 // FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldatapb.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.FindAllShardsInKeyspaceResponse, error) {
 	return client.s.FindAllShardsInKeyspace(ctx, in)
 }
 
+// FindErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) FindErrantGTIDs(ctx context.Context, in *vtctldatapb.FindErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.FindErrantGTIDsResponse, error) {
+	return client.s.FindErrantGTIDs(ctx, in)
+}
+
 // ForceCutOverSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ForceCutOverSchemaMigration(ctx context.Context, in *vtctldatapb.ForceCutOverSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.ForceCutOverSchemaMigrationResponse, error) {
 	return client.s.ForceCutOverSchemaMigration(ctx, in)
@@ -301,6 +356,11 @@ func (client *localVtctldClient) GetCellsAliases(ctx context.Context, in *vtctld
 	return client.s.GetCellsAliases(ctx, in)
 }
 
+// GetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetDynamicConfig(ctx context.Context, in *vtctldatapb.GetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetDynamicConfigResponse, error) {
+	return client.s.GetDynamicConfig(ctx, in)
+}
+
 // GetFullStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetFullStatus(ctx context.Context, in *vtctldatapb.GetFullStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetFullStatusResponse, error) {
 	return client.s.GetFullStatus(ctx, in)
@@ -321,6 +381,11 @@ func (client *localVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldata
 	return client.s.GetKeyspaces(ctx, in)
 }
 
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetMaintenanceWindows(ctx context.Context, in *vtctldatapb.GetMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	return client.s.GetMaintenanceWindows(ctx, in)
+}
+
 // GetMirrorRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldatapb.GetMirrorRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMirrorRulesResponse, error) {
 	return client.s.GetMirrorRules(ctx, in)
@@ -331,11 +396,31 @@ func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctlda
 	return client.s.GetPermissions(ctx, in)
 }
 
+// GetPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetPlanPins(ctx context.Context, in *vtctldatapb.GetPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPlanPinsResponse, error) {
+	return client.s.GetPlanPins(ctx, in)
+}
+
+// GetQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetQueryRules(ctx context.Context, in *vtctldatapb.GetQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryRulesResponse, error) {
+	return client.s.GetQueryRules(ctx, in)
+}
+
+// GetRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetRewriteRules(ctx context.Context, in *vtctldatapb.GetRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRewriteRulesResponse, error) {
+	return client.s.GetRewriteRules(ctx, in)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	return client.s.GetRoutingRules(ctx, in)
 }
 
+// GetScheduledJobs is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetScheduledJobs(ctx context.Context, in *vtctldatapb.GetScheduledJobsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetScheduledJobsResponse, error) {
+	return client.s.GetScheduledJobs(ctx, in)
+}
+
 // GetSchema is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetSchema(ctx context.Context, in *vtctldatapb.GetSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaResponse, error) {
 	return client.s.GetSchema(ctx, in)
@@ -391,6 +476,11 @@ func (client *localVtctldClient) GetTablets(ctx context.Context, in *vtctldatapb
 	return client.s.GetTablets(ctx, in)
 }
 
+// GetTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTenantRoutingRules(ctx context.Context, in *vtctldatapb.GetTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTenantRoutingRulesResponse, error) {
+	return client.s.GetTenantRoutingRules(ctx, in)
+}
+
 // GetThrottlerStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetThrottlerStatus(ctx context.Context, in *vtctldatapb.GetThrottlerStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetThrottlerStatusResponse, error) {
 	return client.s.GetThrottlerStatus(ctx, in)
@@ -426,6 +516,11 @@ func (client *localVtctldClient) GetWorkflows(ctx context.Context, in *vtctldata
 	return client.s.GetWorkflows(ctx, in)
 }
 
+// ImportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ImportTopology(ctx context.Context, in *vtctldatapb.ImportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ImportTopologyResponse, error) {
+	return client.s.ImportTopology(ctx, in)
+}
+
 // InitShardPrimary is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) InitShardPrimary(ctx context.Context, in *vtctldatapb.InitShardPrimaryRequest, opts ...grpc.CallOption) (*vtctldatapb.InitShardPrimaryResponse, error) {
 	return client.s.InitShardPrimary(ctx, in)
@@ -556,6 +651,11 @@ func (client *localVtctldClient) RemoveShardCell(ctx context.Context, in *vtctld
 	return client.s.RemoveShardCell(ctx, in)
 }
 
+// RepairErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RepairErrantGTIDs(ctx context.Context, in *vtctldatapb.RepairErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.RepairErrantGTIDsResponse, error) {
+	return client.s.RepairErrantGTIDs(ctx, in)
+}
+
 // ReparentTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ReparentTablet(ctx context.Context, in *vtctldatapb.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.ReparentTabletResponse, error) {
 	return client.s.ReparentTablet(ctx, in)
@@ -627,11 +727,21 @@ func (client *localVtctldClient) RunHealthCheck(ctx context.Context, in *vtctlda
 	return client.s.RunHealthCheck(ctx, in)
 }
 
+// SetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetDynamicConfig(ctx context.Context, in *vtctldatapb.SetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.SetDynamicConfigResponse, error) {
+	return client.s.SetDynamicConfig(ctx, in)
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
 	return client.s.SetKeyspaceDurabilityPolicy(ctx, in)
 }
 
+// SetScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetScheduledJob(ctx context.Context, in *vtctldatapb.SetScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.SetScheduledJobResponse, error) {
+	return client.s.SetScheduledJob(ctx, in)
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
 	return client.s.SetShardIsPrimaryServing(ctx, in)
@@ -642,6 +752,11 @@ func (client *localVtctldClient) SetShardTabletControl(ctx context.Context, in *
 	return client.s.SetShardTabletControl(ctx, in)
 }
 
+// SetShardWriteFence is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetShardWriteFence(ctx context.Context, in *vtctldatapb.SetShardWriteFenceRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardWriteFenceResponse, error) {
+	return client.s.SetShardWriteFence(ctx, in)
+}
+
 // SetWritable is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetWritable(ctx context.Context, in *vtctldatapb.SetWritableRequest, opts ...grpc.CallOption) (*vtctldatapb.SetWritableResponse, error) {
 	return client.s.SetWritable(ctx, in)
@@ -782,6 +897,11 @@ func (client *localVtctldClient) WorkflowAddTables(ctx context.Context, in *vtct
 	return client.s.WorkflowAddTables(ctx, in)
 }
 
+// WorkflowCutBack is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowCutBack(ctx context.Context, in *vtctldatapb.WorkflowCutBackRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowCutBackResponse, error) {
+	return client.s.WorkflowCutBack(ctx, in)
+}
+
 // WorkflowDelete is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) WorkflowDelete(ctx context.Context, in *vtctldatapb.WorkflowDeleteRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowDeleteResponse, error) {
 	return client.s.WorkflowDelete(ctx, in)
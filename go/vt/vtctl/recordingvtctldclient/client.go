@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recordingvtctldclient provides a vtctldclient.VtctldClient that
+// records the responses of another client, so that they can be output in a
+// structured format or returned to Go automation, instead of scraping the
+// text output of the commands.
+package recordingvtctldclient
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+)
+
+type recordingVtctldClient struct {
+	c      vtctldclient.VtctldClient
+	record func(msg proto.Message)
+}
+
+//go:generate -command recordingvtctldclient go run ../vtctldclient/codegen
+//go:generate recordingvtctldclient --targetpkg recordingvtctldclient --impl recordingVtctldClient --out client_gen.go --recording
+
+// New returns a vtctldclient.VtctldClient that calls record with the response
+// of each successful unary RPC of c, and with each message received from its
+// streaming RPCs, in the order they are received.
+func New(c vtctldclient.VtctldClient, record func(msg proto.Message)) vtctldclient.VtctldClient {
+	return &recordingVtctldClient{
+		c:      c,
+		record: record,
+	}
+}
+
+// Close is part of the vtctldclient.VtctldClient interface.
+func (client *recordingVtctldClient) Close() error {
+	return client.c.Close()
+}
+
+// recordingStream records the messages received from a stream.
+type recordingStream[T proto.Message] struct {
+	grpc.ClientStream
+	recv   func() (T, error)
+	record func(msg proto.Message)
+}
+
+// Recv is part of the streaming client interfaces of the vtctlservicepb
+// package.
+func (stream *recordingStream[T]) Recv() (T, error) {
+	msg, err := stream.recv()
+	if err == nil {
+		stream.record(msg)
+	}
+	return msg, err
+}
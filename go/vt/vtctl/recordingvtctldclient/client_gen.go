@@ -0,0 +1,1709 @@
+// Code generated by recordingvtctldclient-generator. DO NOT EDIT.
+
+/*
+Copyright 2021 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordingvtctldclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+// AddCellInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) AddCellInfo(ctx context.Context, in *vtctldatapb.AddCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.AddCellInfoResponse, error) {
+	resp, err := client.c.AddCellInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// AddCellsAlias is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldatapb.AddCellsAliasRequest, opts ...grpc.CallOption) (*vtctldatapb.AddCellsAliasResponse, error) {
+	resp, err := client.c.AddCellsAlias(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// AddQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) AddQueryRule(ctx context.Context, in *vtctldatapb.AddQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.AddQueryRuleResponse, error) {
+	resp, err := client.c.AddQueryRule(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
+	resp, err := client.c.ApplyKeyspaceRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyMaintenanceWindows(ctx context.Context, in *vtctldatapb.ApplyMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyMaintenanceWindowsResponse, error) {
+	resp, err := client.c.ApplyMaintenanceWindows(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyPlanPins(ctx context.Context, in *vtctldatapb.ApplyPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyPlanPinsResponse, error) {
+	resp, err := client.c.ApplyPlanPins(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyRewriteRules(ctx context.Context, in *vtctldatapb.ApplyRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRewriteRulesResponse, error) {
+	resp, err := client.c.ApplyRewriteRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
+	resp, err := client.c.ApplyRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplySchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplySchema(ctx context.Context, in *vtctldatapb.ApplySchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplySchemaResponse, error) {
+	resp, err := client.c.ApplySchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyShardRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyShardRoutingRules(ctx context.Context, in *vtctldatapb.ApplyShardRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyShardRoutingRulesResponse, error) {
+	resp, err := client.c.ApplyShardRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyTenantRoutingRules(ctx context.Context, in *vtctldatapb.ApplyTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyTenantRoutingRulesResponse, error) {
+	resp, err := client.c.ApplyTenantRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ApplyVSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ApplyVSchema(ctx context.Context, in *vtctldatapb.ApplyVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyVSchemaResponse, error) {
+	resp, err := client.c.ApplyVSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// Backup is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) Backup(ctx context.Context, in *vtctldatapb.BackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_BackupClient, error) {
+	stream, err := client.c.Backup(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingStream[*vtctldatapb.BackupResponse]{
+		ClientStream: stream,
+		recv:         stream.Recv,
+		record:       client.record,
+	}, nil
+}
+
+// BackupShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) BackupShard(ctx context.Context, in *vtctldatapb.BackupShardRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_BackupShardClient, error) {
+	stream, err := client.c.BackupShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingStream[*vtctldatapb.BackupResponse]{
+		ClientStream: stream,
+		recv:         stream.Recv,
+		record:       client.record,
+	}, nil
+}
+
+// CancelSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CancelSchemaMigration(ctx context.Context, in *vtctldatapb.CancelSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CancelSchemaMigrationResponse, error) {
+	resp, err := client.c.CancelSchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ChangeTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ChangeTabletTags(ctx context.Context, in *vtctldatapb.ChangeTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTagsResponse, error) {
+	resp, err := client.c.ChangeTabletTags(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ChangeTabletType is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ChangeTabletType(ctx context.Context, in *vtctldatapb.ChangeTabletTypeRequest, opts ...grpc.CallOption) (*vtctldatapb.ChangeTabletTypeResponse, error) {
+	resp, err := client.c.ChangeTabletType(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CheckThrottler is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CheckThrottler(ctx context.Context, in *vtctldatapb.CheckThrottlerRequest, opts ...grpc.CallOption) (*vtctldatapb.CheckThrottlerResponse, error) {
+	resp, err := client.c.CheckThrottler(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CleanupSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CleanupSchemaMigration(ctx context.Context, in *vtctldatapb.CleanupSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CleanupSchemaMigrationResponse, error) {
+	resp, err := client.c.CleanupSchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CloneTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CloneTablet(ctx context.Context, in *vtctldatapb.CloneTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.CloneTabletResponse, error) {
+	resp, err := client.c.CloneTablet(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CompleteSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CompleteSchemaMigration(ctx context.Context, in *vtctldatapb.CompleteSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CompleteSchemaMigrationResponse, error) {
+	resp, err := client.c.CompleteSchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ConcludeTransaction is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ConcludeTransaction(ctx context.Context, in *vtctldatapb.ConcludeTransactionRequest, opts ...grpc.CallOption) (*vtctldatapb.ConcludeTransactionResponse, error) {
+	resp, err := client.c.ConcludeTransaction(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CopySchemaShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CopySchemaShard(ctx context.Context, in *vtctldatapb.CopySchemaShardRequest, opts ...grpc.CallOption) (*vtctldatapb.CopySchemaShardResponse, error) {
+	resp, err := client.c.CopySchemaShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CreateKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CreateKeyspace(ctx context.Context, in *vtctldatapb.CreateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateKeyspaceResponse, error) {
+	resp, err := client.c.CreateKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// CreateShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) CreateShard(ctx context.Context, in *vtctldatapb.CreateShardRequest, opts ...grpc.CallOption) (*vtctldatapb.CreateShardResponse, error) {
+	resp, err := client.c.CreateShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteCellInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteCellInfo(ctx context.Context, in *vtctldatapb.DeleteCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteCellInfoResponse, error) {
+	resp, err := client.c.DeleteCellInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteCellsAlias is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteCellsAlias(ctx context.Context, in *vtctldatapb.DeleteCellsAliasRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteCellsAliasResponse, error) {
+	resp, err := client.c.DeleteCellsAlias(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteKeyspace(ctx context.Context, in *vtctldatapb.DeleteKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteKeyspaceResponse, error) {
+	resp, err := client.c.DeleteKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteQueryRule is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteQueryRule(ctx context.Context, in *vtctldatapb.DeleteQueryRuleRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteQueryRuleResponse, error) {
+	resp, err := client.c.DeleteQueryRule(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteScheduledJob(ctx context.Context, in *vtctldatapb.DeleteScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteScheduledJobResponse, error) {
+	resp, err := client.c.DeleteScheduledJob(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteShards is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteShards(ctx context.Context, in *vtctldatapb.DeleteShardsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteShardsResponse, error) {
+	resp, err := client.c.DeleteShards(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteSrvVSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteSrvVSchema(ctx context.Context, in *vtctldatapb.DeleteSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteSrvVSchemaResponse, error) {
+	resp, err := client.c.DeleteSrvVSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DeleteTablets is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DeleteTablets(ctx context.Context, in *vtctldatapb.DeleteTabletsRequest, opts ...grpc.CallOption) (*vtctldatapb.DeleteTabletsResponse, error) {
+	resp, err := client.c.DeleteTablets(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// DrainCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) DrainCell(ctx context.Context, in *vtctldatapb.DrainCellRequest, opts ...grpc.CallOption) (*vtctldatapb.DrainCellResponse, error) {
+	resp, err := client.c.DrainCell(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// EmergencyReparentShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) EmergencyReparentShard(ctx context.Context, in *vtctldatapb.EmergencyReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.EmergencyReparentShardResponse, error) {
+	resp, err := client.c.EmergencyReparentShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ExecuteFetchAsApp is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ExecuteFetchAsApp(ctx context.Context, in *vtctldatapb.ExecuteFetchAsAppRequest, opts ...grpc.CallOption) (*vtctldatapb.ExecuteFetchAsAppResponse, error) {
+	resp, err := client.c.ExecuteFetchAsApp(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ExecuteFetchAsDBA is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ExecuteFetchAsDBA(ctx context.Context, in *vtctldatapb.ExecuteFetchAsDBARequest, opts ...grpc.CallOption) (*vtctldatapb.ExecuteFetchAsDBAResponse, error) {
+	resp, err := client.c.ExecuteFetchAsDBA(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ExecuteHook is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ExecuteHook(ctx context.Context, in *vtctldatapb.ExecuteHookRequest, opts ...grpc.CallOption) (*vtctldatapb.ExecuteHookResponse, error) {
+	resp, err := client.c.ExecuteHook(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ExecuteMultiFetchAsDBA is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ExecuteMultiFetchAsDBA(ctx context.Context, in *vtctldatapb.ExecuteMultiFetchAsDBARequest, opts ...grpc.CallOption) (*vtctldatapb.ExecuteMultiFetchAsDBAResponse, error) {
+	resp, err := client.c.ExecuteMultiFetchAsDBA(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ExportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ExportTopology(ctx context.Context, in *vtctldatapb.ExportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ExportTopologyResponse, error) {
+	resp, err := client.c.ExportTopology(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// FindAllShardsInKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) FindAllShardsInKeyspace(ctx context.Context, in *vtctldatapb.FindAllShardsInKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.FindAllShardsInKeyspaceResponse, error) {
+	resp, err := client.c.FindAllShardsInKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// FindErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) FindErrantGTIDs(ctx context.Context, in *vtctldatapb.FindErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.FindErrantGTIDsResponse, error) {
+	resp, err := client.c.FindErrantGTIDs(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ForceCutOverSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ForceCutOverSchemaMigration(ctx context.Context, in *vtctldatapb.ForceCutOverSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.ForceCutOverSchemaMigrationResponse, error) {
+	resp, err := client.c.ForceCutOverSchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
+	resp, err := client.c.GetBackups(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetCellInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetCellInfo(ctx context.Context, in *vtctldatapb.GetCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellInfoResponse, error) {
+	resp, err := client.c.GetCellInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetCellInfoNames is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetCellInfoNames(ctx context.Context, in *vtctldatapb.GetCellInfoNamesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellInfoNamesResponse, error) {
+	resp, err := client.c.GetCellInfoNames(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetCellsAliases is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetCellsAliases(ctx context.Context, in *vtctldatapb.GetCellsAliasesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetCellsAliasesResponse, error) {
+	resp, err := client.c.GetCellsAliases(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetDynamicConfig(ctx context.Context, in *vtctldatapb.GetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.GetDynamicConfigResponse, error) {
+	resp, err := client.c.GetDynamicConfig(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetFullStatus is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetFullStatus(ctx context.Context, in *vtctldatapb.GetFullStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetFullStatusResponse, error) {
+	resp, err := client.c.GetFullStatus(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetKeyspace(ctx context.Context, in *vtctldatapb.GetKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspaceResponse, error) {
+	resp, err := client.c.GetKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.GetKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspaceRoutingRulesResponse, error) {
+	resp, err := client.c.GetKeyspaceRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetKeyspaces is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetKeyspaces(ctx context.Context, in *vtctldatapb.GetKeyspacesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetKeyspacesResponse, error) {
+	resp, err := client.c.GetKeyspaces(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetMaintenanceWindows is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetMaintenanceWindows(ctx context.Context, in *vtctldatapb.GetMaintenanceWindowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMaintenanceWindowsResponse, error) {
+	resp, err := client.c.GetMaintenanceWindows(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetMirrorRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldatapb.GetMirrorRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMirrorRulesResponse, error) {
+	resp, err := client.c.GetMirrorRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetPermissions is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
+	resp, err := client.c.GetPermissions(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetPlanPins is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetPlanPins(ctx context.Context, in *vtctldatapb.GetPlanPinsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPlanPinsResponse, error) {
+	resp, err := client.c.GetPlanPins(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetQueryRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetQueryRules(ctx context.Context, in *vtctldatapb.GetQueryRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetQueryRulesResponse, error) {
+	resp, err := client.c.GetQueryRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetRewriteRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetRewriteRules(ctx context.Context, in *vtctldatapb.GetRewriteRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRewriteRulesResponse, error) {
+	resp, err := client.c.GetRewriteRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
+	resp, err := client.c.GetRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetScheduledJobs is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetScheduledJobs(ctx context.Context, in *vtctldatapb.GetScheduledJobsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetScheduledJobsResponse, error) {
+	resp, err := client.c.GetScheduledJobs(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSchema(ctx context.Context, in *vtctldatapb.GetSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaResponse, error) {
+	resp, err := client.c.GetSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSchemaMigrations is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSchemaMigrations(ctx context.Context, in *vtctldatapb.GetSchemaMigrationsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSchemaMigrationsResponse, error) {
+	resp, err := client.c.GetSchemaMigrations(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetShard(ctx context.Context, in *vtctldatapb.GetShardRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardResponse, error) {
+	resp, err := client.c.GetShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetShardReplication is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetShardReplication(ctx context.Context, in *vtctldatapb.GetShardReplicationRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardReplicationResponse, error) {
+	resp, err := client.c.GetShardReplication(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetShardRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetShardRoutingRules(ctx context.Context, in *vtctldatapb.GetShardRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetShardRoutingRulesResponse, error) {
+	resp, err := client.c.GetShardRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSrvKeyspaceNames is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSrvKeyspaceNames(ctx context.Context, in *vtctldatapb.GetSrvKeyspaceNamesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvKeyspaceNamesResponse, error) {
+	resp, err := client.c.GetSrvKeyspaceNames(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSrvKeyspaces is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSrvKeyspaces(ctx context.Context, in *vtctldatapb.GetSrvKeyspacesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvKeyspacesResponse, error) {
+	resp, err := client.c.GetSrvKeyspaces(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSrvVSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSrvVSchema(ctx context.Context, in *vtctldatapb.GetSrvVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvVSchemaResponse, error) {
+	resp, err := client.c.GetSrvVSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetSrvVSchemas is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetSrvVSchemas(ctx context.Context, in *vtctldatapb.GetSrvVSchemasRequest, opts ...grpc.CallOption) (*vtctldatapb.GetSrvVSchemasResponse, error) {
+	resp, err := client.c.GetSrvVSchemas(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetTablet(ctx context.Context, in *vtctldatapb.GetTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletResponse, error) {
+	resp, err := client.c.GetTablet(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetTablets is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetTablets(ctx context.Context, in *vtctldatapb.GetTabletsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletsResponse, error) {
+	resp, err := client.c.GetTablets(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetTenantRoutingRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetTenantRoutingRules(ctx context.Context, in *vtctldatapb.GetTenantRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTenantRoutingRulesResponse, error) {
+	resp, err := client.c.GetTenantRoutingRules(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetThrottlerStatus is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetThrottlerStatus(ctx context.Context, in *vtctldatapb.GetThrottlerStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.GetThrottlerStatusResponse, error) {
+	resp, err := client.c.GetThrottlerStatus(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetTopologyPath is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetTopologyPath(ctx context.Context, in *vtctldatapb.GetTopologyPathRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTopologyPathResponse, error) {
+	resp, err := client.c.GetTopologyPath(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetTransactionInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetTransactionInfo(ctx context.Context, in *vtctldatapb.GetTransactionInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTransactionInfoResponse, error) {
+	resp, err := client.c.GetTransactionInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetUnresolvedTransactions is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetUnresolvedTransactions(ctx context.Context, in *vtctldatapb.GetUnresolvedTransactionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetUnresolvedTransactionsResponse, error) {
+	resp, err := client.c.GetUnresolvedTransactions(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetVSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetVSchema(ctx context.Context, in *vtctldatapb.GetVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVSchemaResponse, error) {
+	resp, err := client.c.GetVSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetVersion is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetVersion(ctx context.Context, in *vtctldatapb.GetVersionRequest, opts ...grpc.CallOption) (*vtctldatapb.GetVersionResponse, error) {
+	resp, err := client.c.GetVersion(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// GetWorkflows is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) GetWorkflows(ctx context.Context, in *vtctldatapb.GetWorkflowsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetWorkflowsResponse, error) {
+	resp, err := client.c.GetWorkflows(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ImportTopology is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ImportTopology(ctx context.Context, in *vtctldatapb.ImportTopologyRequest, opts ...grpc.CallOption) (*vtctldatapb.ImportTopologyResponse, error) {
+	resp, err := client.c.ImportTopology(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// InitShardPrimary is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) InitShardPrimary(ctx context.Context, in *vtctldatapb.InitShardPrimaryRequest, opts ...grpc.CallOption) (*vtctldatapb.InitShardPrimaryResponse, error) {
+	resp, err := client.c.InitShardPrimary(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// LaunchSchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) LaunchSchemaMigration(ctx context.Context, in *vtctldatapb.LaunchSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.LaunchSchemaMigrationResponse, error) {
+	resp, err := client.c.LaunchSchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// LookupVindexComplete is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) LookupVindexComplete(ctx context.Context, in *vtctldatapb.LookupVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCompleteResponse, error) {
+	resp, err := client.c.LookupVindexComplete(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// LookupVindexCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) LookupVindexCreate(ctx context.Context, in *vtctldatapb.LookupVindexCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCreateResponse, error) {
+	resp, err := client.c.LookupVindexCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// LookupVindexExternalize is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) LookupVindexExternalize(ctx context.Context, in *vtctldatapb.LookupVindexExternalizeRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexExternalizeResponse, error) {
+	resp, err := client.c.LookupVindexExternalize(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// LookupVindexInternalize is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) LookupVindexInternalize(ctx context.Context, in *vtctldatapb.LookupVindexInternalizeRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexInternalizeResponse, error) {
+	resp, err := client.c.LookupVindexInternalize(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
+	resp, err := client.c.MaterializeCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MigrateCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MigrateCreate(ctx context.Context, in *vtctldatapb.MigrateCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	resp, err := client.c.MigrateCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MountList is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MountList(ctx context.Context, in *vtctldatapb.MountListRequest, opts ...grpc.CallOption) (*vtctldatapb.MountListResponse, error) {
+	resp, err := client.c.MountList(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MountRegister is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MountRegister(ctx context.Context, in *vtctldatapb.MountRegisterRequest, opts ...grpc.CallOption) (*vtctldatapb.MountRegisterResponse, error) {
+	resp, err := client.c.MountRegister(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MountShow is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MountShow(ctx context.Context, in *vtctldatapb.MountShowRequest, opts ...grpc.CallOption) (*vtctldatapb.MountShowResponse, error) {
+	resp, err := client.c.MountShow(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MountUnregister is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MountUnregister(ctx context.Context, in *vtctldatapb.MountUnregisterRequest, opts ...grpc.CallOption) (*vtctldatapb.MountUnregisterResponse, error) {
+	resp, err := client.c.MountUnregister(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MoveTablesComplete is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MoveTablesComplete(ctx context.Context, in *vtctldatapb.MoveTablesCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.MoveTablesCompleteResponse, error) {
+	resp, err := client.c.MoveTablesComplete(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// MoveTablesCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) MoveTablesCreate(ctx context.Context, in *vtctldatapb.MoveTablesCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	resp, err := client.c.MoveTablesCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// PingTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) PingTablet(ctx context.Context, in *vtctldatapb.PingTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.PingTabletResponse, error) {
+	resp, err := client.c.PingTablet(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// PlannedReparentShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) PlannedReparentShard(ctx context.Context, in *vtctldatapb.PlannedReparentShardRequest, opts ...grpc.CallOption) (*vtctldatapb.PlannedReparentShardResponse, error) {
+	resp, err := client.c.PlannedReparentShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
+	resp, err := client.c.RebuildKeyspaceGraph(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RebuildVSchemaGraph is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RebuildVSchemaGraph(ctx context.Context, in *vtctldatapb.RebuildVSchemaGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildVSchemaGraphResponse, error) {
+	resp, err := client.c.RebuildVSchemaGraph(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RefreshState is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RefreshState(ctx context.Context, in *vtctldatapb.RefreshStateRequest, opts ...grpc.CallOption) (*vtctldatapb.RefreshStateResponse, error) {
+	resp, err := client.c.RefreshState(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RefreshStateByShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RefreshStateByShard(ctx context.Context, in *vtctldatapb.RefreshStateByShardRequest, opts ...grpc.CallOption) (*vtctldatapb.RefreshStateByShardResponse, error) {
+	resp, err := client.c.RefreshStateByShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ReloadSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ReloadSchema(ctx context.Context, in *vtctldatapb.ReloadSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ReloadSchemaResponse, error) {
+	resp, err := client.c.ReloadSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ReloadSchemaKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ReloadSchemaKeyspace(ctx context.Context, in *vtctldatapb.ReloadSchemaKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ReloadSchemaKeyspaceResponse, error) {
+	resp, err := client.c.ReloadSchemaKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ReloadSchemaShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ReloadSchemaShard(ctx context.Context, in *vtctldatapb.ReloadSchemaShardRequest, opts ...grpc.CallOption) (*vtctldatapb.ReloadSchemaShardResponse, error) {
+	resp, err := client.c.ReloadSchemaShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RemoveBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RemoveBackup(ctx context.Context, in *vtctldatapb.RemoveBackupRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveBackupResponse, error) {
+	resp, err := client.c.RemoveBackup(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RemoveKeyspaceCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RemoveKeyspaceCell(ctx context.Context, in *vtctldatapb.RemoveKeyspaceCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveKeyspaceCellResponse, error) {
+	resp, err := client.c.RemoveKeyspaceCell(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RemoveShardCell is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RemoveShardCell(ctx context.Context, in *vtctldatapb.RemoveShardCellRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveShardCellResponse, error) {
+	resp, err := client.c.RemoveShardCell(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RepairErrantGTIDs is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RepairErrantGTIDs(ctx context.Context, in *vtctldatapb.RepairErrantGTIDsRequest, opts ...grpc.CallOption) (*vtctldatapb.RepairErrantGTIDsResponse, error) {
+	resp, err := client.c.RepairErrantGTIDs(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ReparentTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ReparentTablet(ctx context.Context, in *vtctldatapb.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.ReparentTabletResponse, error) {
+	resp, err := client.c.ReparentTablet(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ReshardCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ReshardCreate(ctx context.Context, in *vtctldatapb.ReshardCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	resp, err := client.c.ReshardCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RestoreFromBackup is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RestoreFromBackup(ctx context.Context, in *vtctldatapb.RestoreFromBackupRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_RestoreFromBackupClient, error) {
+	stream, err := client.c.RestoreFromBackup(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingStream[*vtctldatapb.RestoreFromBackupResponse]{
+		ClientStream: stream,
+		recv:         stream.Recv,
+		record:       client.record,
+	}, nil
+}
+
+// RetrySchemaMigration is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RetrySchemaMigration(ctx context.Context, in *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
+	resp, err := client.c.RetrySchemaMigration(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// RunHealthCheck is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) RunHealthCheck(ctx context.Context, in *vtctldatapb.RunHealthCheckRequest, opts ...grpc.CallOption) (*vtctldatapb.RunHealthCheckResponse, error) {
+	resp, err := client.c.RunHealthCheck(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetDynamicConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetDynamicConfig(ctx context.Context, in *vtctldatapb.SetDynamicConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.SetDynamicConfigResponse, error) {
+	resp, err := client.c.SetDynamicConfig(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
+	resp, err := client.c.SetKeyspaceDurabilityPolicy(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetScheduledJob is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetScheduledJob(ctx context.Context, in *vtctldatapb.SetScheduledJobRequest, opts ...grpc.CallOption) (*vtctldatapb.SetScheduledJobResponse, error) {
+	resp, err := client.c.SetScheduledJob(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
+	resp, err := client.c.SetShardIsPrimaryServing(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetShardTabletControl is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetShardTabletControl(ctx context.Context, in *vtctldatapb.SetShardTabletControlRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardTabletControlResponse, error) {
+	resp, err := client.c.SetShardTabletControl(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetShardWriteFence is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetShardWriteFence(ctx context.Context, in *vtctldatapb.SetShardWriteFenceRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardWriteFenceResponse, error) {
+	resp, err := client.c.SetShardWriteFence(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SetWritable is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SetWritable(ctx context.Context, in *vtctldatapb.SetWritableRequest, opts ...grpc.CallOption) (*vtctldatapb.SetWritableResponse, error) {
+	resp, err := client.c.SetWritable(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ShardReplicationAdd is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ShardReplicationAdd(ctx context.Context, in *vtctldatapb.ShardReplicationAddRequest, opts ...grpc.CallOption) (*vtctldatapb.ShardReplicationAddResponse, error) {
+	resp, err := client.c.ShardReplicationAdd(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ShardReplicationFix is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ShardReplicationFix(ctx context.Context, in *vtctldatapb.ShardReplicationFixRequest, opts ...grpc.CallOption) (*vtctldatapb.ShardReplicationFixResponse, error) {
+	resp, err := client.c.ShardReplicationFix(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ShardReplicationPositions is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ShardReplicationPositions(ctx context.Context, in *vtctldatapb.ShardReplicationPositionsRequest, opts ...grpc.CallOption) (*vtctldatapb.ShardReplicationPositionsResponse, error) {
+	resp, err := client.c.ShardReplicationPositions(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ShardReplicationRemove is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ShardReplicationRemove(ctx context.Context, in *vtctldatapb.ShardReplicationRemoveRequest, opts ...grpc.CallOption) (*vtctldatapb.ShardReplicationRemoveResponse, error) {
+	resp, err := client.c.ShardReplicationRemove(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SleepTablet is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SleepTablet(ctx context.Context, in *vtctldatapb.SleepTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.SleepTabletResponse, error) {
+	resp, err := client.c.SleepTablet(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SourceShardAdd is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SourceShardAdd(ctx context.Context, in *vtctldatapb.SourceShardAddRequest, opts ...grpc.CallOption) (*vtctldatapb.SourceShardAddResponse, error) {
+	resp, err := client.c.SourceShardAdd(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// SourceShardDelete is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) SourceShardDelete(ctx context.Context, in *vtctldatapb.SourceShardDeleteRequest, opts ...grpc.CallOption) (*vtctldatapb.SourceShardDeleteResponse, error) {
+	resp, err := client.c.SourceShardDelete(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// StartReplication is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) StartReplication(ctx context.Context, in *vtctldatapb.StartReplicationRequest, opts ...grpc.CallOption) (*vtctldatapb.StartReplicationResponse, error) {
+	resp, err := client.c.StartReplication(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// StopReplication is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) StopReplication(ctx context.Context, in *vtctldatapb.StopReplicationRequest, opts ...grpc.CallOption) (*vtctldatapb.StopReplicationResponse, error) {
+	resp, err := client.c.StopReplication(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// TabletExternallyReparented is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) TabletExternallyReparented(ctx context.Context, in *vtctldatapb.TabletExternallyReparentedRequest, opts ...grpc.CallOption) (*vtctldatapb.TabletExternallyReparentedResponse, error) {
+	resp, err := client.c.TabletExternallyReparented(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// UpdateCellInfo is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) UpdateCellInfo(ctx context.Context, in *vtctldatapb.UpdateCellInfoRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateCellInfoResponse, error) {
+	resp, err := client.c.UpdateCellInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// UpdateCellsAlias is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) UpdateCellsAlias(ctx context.Context, in *vtctldatapb.UpdateCellsAliasRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateCellsAliasResponse, error) {
+	resp, err := client.c.UpdateCellsAlias(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// UpdateThrottlerConfig is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) UpdateThrottlerConfig(ctx context.Context, in *vtctldatapb.UpdateThrottlerConfigRequest, opts ...grpc.CallOption) (*vtctldatapb.UpdateThrottlerConfigResponse, error) {
+	resp, err := client.c.UpdateThrottlerConfig(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// VDiffCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) VDiffCreate(ctx context.Context, in *vtctldatapb.VDiffCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffCreateResponse, error) {
+	resp, err := client.c.VDiffCreate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// VDiffDelete is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) VDiffDelete(ctx context.Context, in *vtctldatapb.VDiffDeleteRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffDeleteResponse, error) {
+	resp, err := client.c.VDiffDelete(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// VDiffResume is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) VDiffResume(ctx context.Context, in *vtctldatapb.VDiffResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffResumeResponse, error) {
+	resp, err := client.c.VDiffResume(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// VDiffShow is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) VDiffShow(ctx context.Context, in *vtctldatapb.VDiffShowRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffShowResponse, error) {
+	resp, err := client.c.VDiffShow(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// VDiffStop is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) VDiffStop(ctx context.Context, in *vtctldatapb.VDiffStopRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffStopResponse, error) {
+	resp, err := client.c.VDiffStop(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// Validate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) Validate(ctx context.Context, in *vtctldatapb.ValidateRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateResponse, error) {
+	resp, err := client.c.Validate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateKeyspace(ctx context.Context, in *vtctldatapb.ValidateKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateKeyspaceResponse, error) {
+	resp, err := client.c.ValidateKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidatePermissionsKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidatePermissionsKeyspace(ctx context.Context, in *vtctldatapb.ValidatePermissionsKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidatePermissionsKeyspaceResponse, error) {
+	resp, err := client.c.ValidatePermissionsKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateSchemaKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateSchemaKeyspace(ctx context.Context, in *vtctldatapb.ValidateSchemaKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateSchemaKeyspaceResponse, error) {
+	resp, err := client.c.ValidateSchemaKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateShard(ctx context.Context, in *vtctldatapb.ValidateShardRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateShardResponse, error) {
+	resp, err := client.c.ValidateShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateVSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateVSchema(ctx context.Context, in *vtctldatapb.ValidateVSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateVSchemaResponse, error) {
+	resp, err := client.c.ValidateVSchema(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateVersionKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateVersionKeyspace(ctx context.Context, in *vtctldatapb.ValidateVersionKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateVersionKeyspaceResponse, error) {
+	resp, err := client.c.ValidateVersionKeyspace(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// ValidateVersionShard is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) ValidateVersionShard(ctx context.Context, in *vtctldatapb.ValidateVersionShardRequest, opts ...grpc.CallOption) (*vtctldatapb.ValidateVersionShardResponse, error) {
+	resp, err := client.c.ValidateVersionShard(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowAddTables is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowAddTables(ctx context.Context, in *vtctldatapb.WorkflowAddTablesRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowAddTablesResponse, error) {
+	resp, err := client.c.WorkflowAddTables(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowCutBack is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowCutBack(ctx context.Context, in *vtctldatapb.WorkflowCutBackRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowCutBackResponse, error) {
+	resp, err := client.c.WorkflowCutBack(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowDelete is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowDelete(ctx context.Context, in *vtctldatapb.WorkflowDeleteRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowDeleteResponse, error) {
+	resp, err := client.c.WorkflowDelete(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowMirrorTraffic is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowMirrorTraffic(ctx context.Context, in *vtctldatapb.WorkflowMirrorTrafficRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowMirrorTrafficResponse, error) {
+	resp, err := client.c.WorkflowMirrorTraffic(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowStatus is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowStatus(ctx context.Context, in *vtctldatapb.WorkflowStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
+	resp, err := client.c.WorkflowStatus(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowSwitchTraffic is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowSwitchTraffic(ctx context.Context, in *vtctldatapb.WorkflowSwitchTrafficRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowSwitchTrafficResponse, error) {
+	resp, err := client.c.WorkflowSwitchTraffic(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
+
+// WorkflowUpdate is part of the vtctlservicepb.VtctldClient interface.
+func (client *recordingVtctldClient) WorkflowUpdate(ctx context.Context, in *vtctldatapb.WorkflowUpdateRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowUpdateResponse, error) {
+	resp, err := client.c.WorkflowUpdate(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordingvtctldclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtctl/localvtctldclient"
+
+	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+type fakeServer struct {
+	vtctlservicepb.UnimplementedVtctldServer
+}
+
+func (s *fakeServer) GetCellInfoNames(ctx context.Context, req *vtctldatapb.GetCellInfoNamesRequest) (*vtctldatapb.GetCellInfoNamesResponse, error) {
+	return &vtctldatapb.GetCellInfoNamesResponse{Names: []string{"zone1"}}, nil
+}
+
+func (s *fakeServer) Backup(req *vtctldatapb.BackupRequest, stream vtctlservicepb.Vtctld_BackupServer) error {
+	for _, value := range []string{"starting", "done"} {
+		if err := stream.Send(&vtctldatapb.BackupResponse{Event: &logutilpb.Event{Value: value}}); err != nil {
+			return err
+		}
+	}
+	return errors.New("backup failed")
+}
+
+func TestRecordingClient(t *testing.T) {
+	ctx := context.Background()
+	var recorded []proto.Message
+	client := New(localvtctldclient.New(&fakeServer{}), func(msg proto.Message) {
+		recorded = append(recorded, msg)
+	})
+	defer client.Close()
+
+	resp, err := client.GetCellInfoNames(ctx, &vtctldatapb.GetCellInfoNamesRequest{})
+	require.NoError(t, err)
+	_, err = client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+	require.Error(t, err, "the errors are returned and not recorded")
+
+	stream, err := client.Backup(ctx, &vtctldatapb.BackupRequest{})
+	require.NoError(t, err)
+	var events []string
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			assert.False(t, errors.Is(err, io.EOF))
+			assert.ErrorContains(t, err, "backup failed")
+			break
+		}
+		events = append(events, msg.Event.Value)
+	}
+	assert.Equal(t, []string{"starting", "done"}, events)
+
+	utils.MustMatch(t, []proto.Message{
+		resp,
+		&vtctldatapb.BackupResponse{Event: &logutilpb.Event{Value: "starting"}},
+		&vtctldatapb.BackupResponse{Event: &logutilpb.Event{Value: "done"}},
+	}, recorded)
+}
@@ -68,6 +68,7 @@ type EmergencyReparentOptions struct {
 	// these details back out.
 	lockAction string
 	durability policy.Durabler
+	cellDrains map[string]*topodatapb.CellDrain
 }
 
 // counters for Emergency Reparent Shard
@@ -187,6 +188,12 @@ func (erp *EmergencyReparenter) reparentShardLocked(ctx context.Context, ev *eve
 		return err
 	}
 
+	// Primaries must not be promoted in the cells being drained.
+	opts.cellDrains, err = erp.ts.GetCellDrains(ctx)
+	if err != nil {
+		return err
+	}
+
 	// get the previous primary according to the topology server,
 	// we use this information to choose the best candidate in the same cell
 	// and to undo promotion in case of failure
@@ -754,6 +761,14 @@ func (erp *EmergencyReparenter) filterValidCandidates(validTablets []*topodatapb
 			}
 			continue
 		}
+		// Remove tablets in the cells being drained, since they are about to be shut down
+		if _, draining := opts.cellDrains[tablet.Alias.Cell]; draining {
+			erp.logger.Infof("Removing %s from list of valid candidates for promotion because its cell is being drained", tabletAliasStr)
+			if opts.NewPrimaryAlias != nil && topoproto.TabletAliasEqual(opts.NewPrimaryAlias, tablet.Alias) {
+				return nil, vterrors.Errorf(vtrpc.Code_ABORTED, "proposed primary %s is in cell %s, which is being drained", topoproto.TabletAliasString(opts.NewPrimaryAlias), tablet.Alias.Cell)
+			}
+			continue
+		}
 		// If ERS is configured to prevent cross cell promotions, remove any tablet not from the same cell as the previous primary
 		if opts.PreventCrossCellPromotion && prevPrimary != nil && tablet.Alias.Cell != prevPrimary.Alias.Cell {
 			erp.logger.Infof("Removing %s from list of valid candidates for promotion because it isn't in the same cell as the previous primary", tabletAliasStr)
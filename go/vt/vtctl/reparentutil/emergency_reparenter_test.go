@@ -4522,6 +4522,18 @@ func TestEmergencyReparenter_filterValidCandidates(t *testing.T) {
 				PreventCrossCellPromotion: true,
 			},
 			filteredTablets: []*topodatapb.Tablet{primaryTablet, replicaTablet},
+		}, {
+			name:                "filter drained cell",
+			durability:          policy.DurabilityNone,
+			validTablets:        allTablets,
+			tabletsReachable:    allTablets,
+			tabletsTakingBackup: noTabletsTakingBackup,
+			opts: EmergencyReparentOptions{
+				cellDrains: map[string]*topodatapb.CellDrain{
+					"zone-1": {},
+				},
+			},
+			filteredTablets: []*topodatapb.Tablet{replicaCrossCellTablet},
 		}, {
 			name:                "filter establish",
 			durability:          policy.DurabilityCrossCell,
@@ -4554,6 +4566,19 @@ func TestEmergencyReparenter_filterValidCandidates(t *testing.T) {
 				NewPrimaryAlias: rdonlyTablet.Alias,
 			},
 			errShouldContain: "proposed primary zone-1-0000000003 has a must not promotion rule",
+		}, {
+			name:                "error - requested primary in drained cell",
+			durability:          policy.DurabilityNone,
+			validTablets:        allTablets,
+			tabletsReachable:    allTablets,
+			tabletsTakingBackup: noTabletsTakingBackup,
+			opts: EmergencyReparentOptions{
+				NewPrimaryAlias: replicaTablet.Alias,
+				cellDrains: map[string]*topodatapb.CellDrain{
+					"zone-1": {},
+				},
+			},
+			errShouldContain: "proposed primary zone-1-0000000002 is in cell zone-1, which is being drained",
 		}, {
 			name:                "error - requested primary not in same cell",
 			durability:          policy.DurabilityNone,
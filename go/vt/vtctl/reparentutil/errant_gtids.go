@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const (
+	// ShowBinaryLogsQuery lists the binary logs of a server, oldest first.
+	ShowBinaryLogsQuery = "SHOW BINARY LOGS"
+
+	// binlogEventTypeGTID and binlogEventTypePreviousGTIDs are the values of
+	// the Event_type column of SHOW BINLOG EVENTS for the events we look at.
+	binlogEventTypeGTID          = "Gtid"
+	binlogEventTypePreviousGTIDs = "Previous_gtids"
+	binlogEventTypeXID           = "Xid"
+	binlogEventTypeRotate        = "Rotate"
+	binlogEventTypeStop          = "Stop"
+)
+
+// ErrantGTIDs returns the GTIDs of the replica which are neither in the
+// executed GTID set of the primary, nor were originally committed by the
+// primary. The positions must be of the MySQL 5.6 flavor; for other flavors
+// an empty set is returned.
+func ErrantGTIDs(replicaPosition, primaryPosition replication.Position, primarySID replication.SID) (replication.Mysql56GTIDSet, error) {
+	errant, err := replication.ErrantGTIDsOnReplica(replicaPosition, primaryPosition, primarySID)
+	if err != nil || errant == "" {
+		return replication.Mysql56GTIDSet{}, err
+	}
+	return replication.ParseMysql56GTIDSet(errant)
+}
+
+// EmptyTransactionQueries returns the statements which commit an empty
+// transaction for each of the GTIDs of the set. Running them on the primary
+// makes the GTIDs part of its executed GTID set, so that they are no longer
+// errant on the replicas that have them. It fails if the set has more than
+// maxGTIDs GTIDs. The statements must run on a single connection.
+func EmptyTransactionQueries(gtids replication.Mysql56GTIDSet, maxGTIDs int64) ([]string, error) {
+	count, err := replication.GTIDCount(gtids.String())
+	if err != nil {
+		return nil, err
+	}
+	if count > maxGTIDs {
+		return nil, fmt.Errorf("%d GTIDs would have to be injected as empty transactions, more than the maximum of %d", count, maxGTIDs)
+	}
+
+	queries := make([]string, 0, 3*count+1)
+	for _, gtid := range gtids.GTIDs() {
+		queries = append(queries,
+			"SET GTID_NEXT = "+sqltypes.EncodeStringSQL(gtid.String()),
+			"BEGIN",
+			"COMMIT",
+		)
+	}
+	return append(queries, "SET GTID_NEXT = 'AUTOMATIC'"), nil
+}
+
+// PreviousGTIDsQuery returns the query which reads the Previous_gtids event
+// of a binary log, i.e. the GTID set executed before the binary log started.
+func PreviousGTIDsQuery(binlogFile string) string {
+	return fmt.Sprintf("SHOW BINLOG EVENTS IN %s LIMIT 2", sqltypes.EncodeStringSQL(binlogFile))
+}
+
+// BinlogEventsQuery returns the query which reads the events of a binary log.
+func BinlogEventsQuery(binlogFile string) string {
+	return "SHOW BINLOG EVENTS IN " + sqltypes.EncodeStringSQL(binlogFile)
+}
+
+// ParseBinaryLogs returns the names of the binary logs in the result of
+// ShowBinaryLogsQuery.
+func ParseBinaryLogs(qr *sqltypes.Result) []string {
+	var files []string
+	for _, row := range qr.Named().Rows {
+		files = append(files, row.AsString("Log_name", ""))
+	}
+	return files
+}
+
+// ParsePreviousGTIDs returns the GTID set of the Previous_gtids event in the
+// result of PreviousGTIDsQuery.
+func ParsePreviousGTIDs(qr *sqltypes.Result) (replication.Mysql56GTIDSet, error) {
+	for _, row := range qr.Named().Rows {
+		if row.AsString("Event_type", "") == binlogEventTypePreviousGTIDs {
+			return replication.ParseMysql56GTIDSet(row.AsString("Info", ""))
+		}
+	}
+	return nil, fmt.Errorf("no %s event found", binlogEventTypePreviousGTIDs)
+}
+
+// BinlogFileOfGTID returns the binary log which contains the GTID, given the
+// binary logs of the server, oldest first, and the GTID set each of them was
+// started with. It returns an empty string if the binary log was purged.
+func BinlogFileOfGTID(gtid replication.Mysql56GTID, files []string, previousGTIDs []replication.Mysql56GTIDSet) string {
+	for i := len(files) - 1; i >= 0; i-- {
+		if !previousGTIDs[i].ContainsGTID(gtid) {
+			return files[i]
+		}
+	}
+	return ""
+}
+
+// ParseErrantTransactions groups the events in the result of BinlogEventsQuery
+// by the GTID of the transaction they belong to, and returns the groups of the
+// GTIDs of the set. This explains where errant GTIDs come from: the server ID
+// of the events is the one of the server which originally committed them.
+func ParseErrantTransactions(qr *sqltypes.Result, gtids replication.Mysql56GTIDSet) (map[string][]*vtctldatapb.BinlogEvent, error) {
+	transactions := make(map[string][]*vtctldatapb.BinlogEvent)
+	var current string
+	for _, row := range qr.Named().Rows {
+		event := &vtctldatapb.BinlogEvent{
+			File:      row.AsString("Log_name", ""),
+			Position:  row.AsUint64("Pos", 0),
+			EventType: row.AsString("Event_type", ""),
+			ServerId:  row.AsUint64("Server_id", 0),
+			Info:      row.AsString("Info", ""),
+		}
+		if event.EventType == binlogEventTypeGTID {
+			// The info of a Gtid event is of the form
+			// SET @@SESSION.GTID_NEXT= '<sid>:<sequence>'.
+			_, value, ok := strings.Cut(event.Info, "'")
+			if !ok {
+				return nil, fmt.Errorf("cannot parse the GTID of event %q", event.Info)
+			}
+			gtid, err := replication.ParseGTID(replication.Mysql56FlavorID, strings.TrimSuffix(value, "'"))
+			if err != nil {
+				return nil, err
+			}
+			current = ""
+			if gtids.ContainsGTID(gtid) {
+				current = gtid.String()
+			}
+		}
+		if event.EventType == binlogEventTypeRotate || event.EventType == binlogEventTypeStop {
+			current = ""
+		}
+		if current != "" {
+			transactions[current] = append(transactions[current], event)
+		}
+		if event.EventType == binlogEventTypeXID {
+			current = ""
+		}
+	}
+	return transactions, nil
+}
+
+// ErrantGTIDsExplanation are the errant transactions of a replica read by
+// ExplainErrantGTIDs.
+type ErrantGTIDsExplanation struct {
+	// Transactions are the binary log events of the errant transactions, in
+	// the order of their GTIDs.
+	Transactions []*vtctldatapb.ErrantTransaction
+	// Purged are the errant GTIDs whose binary log was purged.
+	Purged replication.Mysql56GTIDSet
+	// Unexplained are the errant GTIDs beyond maxGTIDs, which were not read.
+	Unexplained replication.Mysql56GTIDSet
+}
+
+// ExplainErrantGTIDs reads the binary log events of the errant transactions of
+// a replica, with fetch running the queries on the replica. Only the first
+// maxGTIDs GTIDs of the set are looked up, so that a replica with a huge
+// number of errant GTIDs is not read for each of them, and at most
+// maxBinlogEvents events are read from a binary log.
+func ExplainErrantGTIDs(gtids replication.Mysql56GTIDSet, maxGTIDs, maxBinlogEvents int64, fetch func(query string, maxRows int64) (*sqltypes.Result, error)) (*ErrantGTIDsExplanation, error) {
+	head, tail := gtids.Split(maxGTIDs)
+	explained := head.GTIDs()
+	explanation := &ErrantGTIDsExplanation{
+		Purged:      replication.Mysql56GTIDSet{},
+		Unexplained: tail,
+	}
+
+	qr, err := fetch(ShowBinaryLogsQuery, 100_000)
+	if err != nil {
+		return nil, err
+	}
+	files := ParseBinaryLogs(qr)
+	previousGTIDs := make([]replication.Mysql56GTIDSet, 0, len(files))
+	for _, file := range files {
+		qr, err := fetch(PreviousGTIDsQuery(file), 2)
+		if err != nil {
+			return nil, err
+		}
+		previous, err := ParsePreviousGTIDs(qr)
+		if err != nil {
+			return nil, fmt.Errorf("binary log %s: %w", file, err)
+		}
+		previousGTIDs = append(previousGTIDs, previous)
+	}
+
+	// Read each binary log which has errant transactions once, oldest first.
+	gtidsByFile := make(map[string]replication.Mysql56GTIDSet)
+	for _, gtid := range explained {
+		file := BinlogFileOfGTID(gtid, files, previousGTIDs)
+		if file == "" {
+			explanation.Purged = explanation.Purged.AddGTID(gtid).(replication.Mysql56GTIDSet)
+			continue
+		}
+		if gtidsByFile[file] == nil {
+			gtidsByFile[file] = replication.Mysql56GTIDSet{}
+		}
+		gtidsByFile[file] = gtidsByFile[file].AddGTID(gtid).(replication.Mysql56GTIDSet)
+	}
+
+	transactions := make(map[string][]*vtctldatapb.BinlogEvent)
+	for _, file := range files {
+		gtids, ok := gtidsByFile[file]
+		if !ok {
+			continue
+		}
+		qr, err := fetch(BinlogEventsQuery(file), maxBinlogEvents)
+		if err != nil {
+			return nil, fmt.Errorf("binary log %s: %w", file, err)
+		}
+		fileTransactions, err := ParseErrantTransactions(qr, gtids)
+		if err != nil {
+			return nil, fmt.Errorf("binary log %s: %w", file, err)
+		}
+		for gtid, events := range fileTransactions {
+			transactions[gtid] = events
+		}
+	}
+	for _, gtid := range explained {
+		if events, ok := transactions[gtid.String()]; ok {
+			explanation.Transactions = append(explanation.Transactions, &vtctldatapb.ErrantTransaction{
+				Gtid:   gtid.String(),
+				Events: events,
+			})
+		}
+	}
+	return explanation, nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reparentutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const (
+	testPrimarySID = "00010203-0405-0607-0809-0a0b0c0d0e0f"
+	testErrantSID  = "00010203-0405-0607-0809-0a0b0c0d0eff"
+)
+
+func TestErrantGTIDs(t *testing.T) {
+	primarySID, err := replication.ParseSID(testPrimarySID)
+	require.NoError(t, err)
+	primaryPosition, err := replication.DecodePosition("MySQL56/" + testPrimarySID + ":1-10")
+	require.NoError(t, err)
+
+	// GTIDs of the primary which the primary has not reported yet are not errant.
+	replicaPosition, err := replication.DecodePosition("MySQL56/" + testPrimarySID + ":1-12," + testErrantSID + ":3-4")
+	require.NoError(t, err)
+	errant, err := ErrantGTIDs(replicaPosition, primaryPosition, primarySID)
+	require.NoError(t, err)
+	assert.Equal(t, testErrantSID+":3-4", errant.String())
+
+	replicaPosition, err = replication.DecodePosition("MySQL56/" + testPrimarySID + ":1-8")
+	require.NoError(t, err)
+	errant, err = ErrantGTIDs(replicaPosition, primaryPosition, primarySID)
+	require.NoError(t, err)
+	assert.Empty(t, errant)
+}
+
+func TestEmptyTransactionQueries(t *testing.T) {
+	gtids, err := replication.ParseMysql56GTIDSet(testErrantSID + ":3-4")
+	require.NoError(t, err)
+
+	queries, err := EmptyTransactionQueries(gtids, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"SET GTID_NEXT = '" + testErrantSID + ":3'",
+		"BEGIN",
+		"COMMIT",
+		"SET GTID_NEXT = '" + testErrantSID + ":4'",
+		"BEGIN",
+		"COMMIT",
+		"SET GTID_NEXT = 'AUTOMATIC'",
+	}, queries)
+
+	_, err = EmptyTransactionQueries(gtids, 1)
+	assert.ErrorContains(t, err, "2 GTIDs would have to be injected as empty transactions, more than the maximum of 1")
+}
+
+func TestBinlogFileOfGTID(t *testing.T) {
+	binaryLogs := sqltypes.MakeTestResult(sqltypes.MakeTestFields("Log_name|File_size|Encrypted", "varchar|uint64|varchar"),
+		"binlog.000001|1024|No",
+		"binlog.000002|1024|No",
+		"binlog.000003|1024|No",
+	)
+	files := ParseBinaryLogs(binaryLogs)
+	require.Equal(t, []string{"binlog.000001", "binlog.000002", "binlog.000003"}, files)
+
+	fields := sqltypes.MakeTestFields("Log_name|Pos|Event_type|Server_id|End_log_pos|Info", "varchar|uint64|varchar|uint64|uint64|varchar")
+	var previousGTIDs []replication.Mysql56GTIDSet
+	for _, set := range []string{"", testErrantSID + ":1-5", testErrantSID + ":1-9"} {
+		previous, err := ParsePreviousGTIDs(sqltypes.MakeTestResult(fields,
+			"binlog|4|Format_desc|100|126|Server ver: 8.0.40, Binlog ver: 4",
+			"binlog|126|Previous_gtids|100|157|"+set,
+		))
+		require.NoError(t, err)
+		previousGTIDs = append(previousGTIDs, previous)
+	}
+
+	for sequence, want := range map[int64]string{1: "binlog.000001", 5: "binlog.000001", 6: "binlog.000002", 10: "binlog.000003"} {
+		gtid := replication.Mysql56GTID{Server: previousGTIDs[1].SIDs()[0], Sequence: sequence}
+		assert.Equal(t, want, BinlogFileOfGTID(gtid, files, previousGTIDs), gtid.String())
+	}
+	// Once the first binary log is purged, its GTIDs cannot be found anymore.
+	gtid := replication.Mysql56GTID{Server: previousGTIDs[1].SIDs()[0], Sequence: 1}
+	assert.Empty(t, BinlogFileOfGTID(gtid, files[1:], previousGTIDs[1:]))
+
+	_, err := ParsePreviousGTIDs(sqltypes.MakeTestResult(fields))
+	assert.ErrorContains(t, err, "no Previous_gtids event found")
+}
+
+func TestParseErrantTransactions(t *testing.T) {
+	fields := sqltypes.MakeTestFields("Log_name|Pos|Event_type|Server_id|End_log_pos|Info", "varchar|uint64|varchar|uint64|uint64|varchar")
+	events := sqltypes.MakeTestResult(fields,
+		"binlog.000002|157|Gtid|100|236|SET @@SESSION.GTID_NEXT= '"+testPrimarySID+":11'",
+		"binlog.000002|236|Query|100|315|BEGIN",
+		"binlog.000002|315|Xid|100|346|COMMIT /* xid=10 */",
+		"binlog.000002|346|Gtid|200|425|SET @@SESSION.GTID_NEXT= '"+testErrantSID+":3'",
+		"binlog.000002|425|Query|200|520|insert into t1 values (1)",
+		"binlog.000002|520|Xid|200|551|COMMIT /* xid=11 */",
+		"binlog.000002|551|Rotate|100|598|binlog.000003;pos=4",
+	)
+	gtids, err := replication.ParseMysql56GTIDSet(testErrantSID + ":3-4")
+	require.NoError(t, err)
+
+	transactions, err := ParseErrantTransactions(events, gtids)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	transaction := transactions[testErrantSID+":3"]
+	require.Len(t, transaction, 3)
+	utils.MustMatch(t, &vtctldatapb.BinlogEvent{
+		File:      "binlog.000002",
+		Position:  425,
+		EventType: "Query",
+		ServerId:  200,
+		Info:      "insert into t1 values (1)",
+	}, transaction[1])
+
+	_, err = ParseErrantTransactions(sqltypes.MakeTestResult(fields, "binlog.000002|157|Gtid|100|236|garbage"), gtids)
+	assert.ErrorContains(t, err, "cannot parse the GTID of event")
+}
+
+func TestExplainErrantGTIDs(t *testing.T) {
+	fields := sqltypes.MakeTestFields("Log_name|Pos|Event_type|Server_id|End_log_pos|Info", "varchar|uint64|varchar|uint64|uint64|varchar")
+	results := map[string]*sqltypes.Result{
+		ShowBinaryLogsQuery: sqltypes.MakeTestResult(sqltypes.MakeTestFields("Log_name|File_size|Encrypted", "varchar|uint64|varchar"),
+			"binlog.000002|1024|No",
+			"binlog.000003|1024|No",
+		),
+		// The errant transaction 1 was in binlog.000001, which was purged.
+		PreviousGTIDsQuery("binlog.000002"): sqltypes.MakeTestResult(fields,
+			"binlog.000002|126|Previous_gtids|100|157|"+testErrantSID+":1",
+		),
+		PreviousGTIDsQuery("binlog.000003"): sqltypes.MakeTestResult(fields,
+			"binlog.000003|126|Previous_gtids|100|157|"+testErrantSID+":1-3",
+		),
+		BinlogEventsQuery("binlog.000002"): sqltypes.MakeTestResult(fields,
+			"binlog.000002|157|Gtid|200|236|SET @@SESSION.GTID_NEXT= '"+testErrantSID+":2'",
+			"binlog.000002|236|Query|200|315|BEGIN",
+			"binlog.000002|315|Xid|200|346|COMMIT /* xid=10 */",
+			"binlog.000002|346|Gtid|200|425|SET @@SESSION.GTID_NEXT= '"+testErrantSID+":3'",
+			"binlog.000002|425|Query|200|520|BEGIN",
+			"binlog.000002|520|Xid|200|551|COMMIT /* xid=11 */",
+		),
+	}
+	var queries []string
+	fetch := func(query string, maxRows int64) (*sqltypes.Result, error) {
+		queries = append(queries, query)
+		qr, ok := results[query]
+		require.True(t, ok, query)
+		return qr, nil
+	}
+
+	gtids, err := replication.ParseMysql56GTIDSet(testErrantSID + ":1-1000000")
+	require.NoError(t, err)
+	explanation, err := ExplainErrantGTIDs(gtids, 2, 1_000, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, testErrantSID+":1", explanation.Purged.String())
+	// The GTIDs beyond the maximum are neither enumerated nor looked up.
+	assert.Equal(t, testErrantSID+":3-1000000", explanation.Unexplained.String())
+	require.Len(t, explanation.Transactions, 1)
+	assert.Equal(t, testErrantSID+":2", explanation.Transactions[0].Gtid)
+	assert.Len(t, explanation.Transactions[0].Events, 3)
+	assert.NotContains(t, queries, BinlogEventsQuery("binlog.000003"))
+}
@@ -73,6 +73,7 @@ type PlannedReparentOptions struct {
 
 	lockAction string
 	durability policy.Durabler
+	cellDrains map[string]*topodatapb.CellDrain
 }
 
 // NewPlannedReparenter returns a new PlannedReparenter object, ready to perform
@@ -174,6 +175,12 @@ func (pr *PlannedReparenter) preflightChecks(
 		return true, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "primary-elect tablet %v is the same as the tablet to avoid", topoproto.TabletAliasString(opts.NewPrimaryAlias))
 	}
 
+	if opts.NewPrimaryAlias != nil {
+		if _, draining := opts.cellDrains[opts.NewPrimaryAlias.Cell]; draining {
+			return true, vterrors.Errorf(vtrpc.Code_FAILED_PRECONDITION, "primary-elect tablet %v is in cell %v, which is being drained", topoproto.TabletAliasString(opts.NewPrimaryAlias), opts.NewPrimaryAlias.Cell)
+		}
+	}
+
 	if opts.NewPrimaryAlias == nil {
 		// We don't want to fail when both ShardInfo.PrimaryAlias and AvoidPrimaryAlias are nil.
 		// This happens when we are using PRS to initialize the cluster without specifying the NewPrimaryAlias
@@ -527,6 +534,12 @@ func (pr *PlannedReparenter) reparentShardLocked(
 		return err
 	}
 
+	// Primaries must not be promoted in the cells being drained.
+	opts.cellDrains, err = pr.ts.GetCellDrains(ctx)
+	if err != nil {
+		return err
+	}
+
 	ev.ShardInfo = *shardInfo
 
 	event.DispatchUpdate(ev, "reading tablet map")
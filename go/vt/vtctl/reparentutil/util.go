@@ -59,8 +59,8 @@ const (
 // cell as the current primary, and to be different from avoidPrimaryAlias. The
 // tablet with the most advanced replication position is chosen to minimize the
 // amount of time spent catching up with the current primary. Further ties are
-// broken by the durability rules. Tablets taking backups are excluded from
-// consideration.
+// broken by the durability rules. Tablets taking backups and tablets in the
+// cells being drained are excluded from consideration.
 // Note that the search for the most advanced replication position will race
 // with transactions being executed on the current primary, so when all tablets
 // are at roughly the same position, then the choice of new primary-elect will
@@ -105,6 +105,9 @@ func ElectNewPrimary(
 		case !opts.AllowCrossCellPromotion && primaryCell != "" && tablet.Alias.Cell != primaryCell:
 			reasonsToInvalidate.WriteString(fmt.Sprintf("\n%v is not in the same cell as the previous primary", topoproto.TabletAliasString(tablet.Alias)))
 			continue
+		case opts.cellDrains[tablet.Alias.Cell] != nil:
+			reasonsToInvalidate.WriteString(fmt.Sprintf("\n%v is in a cell being drained", topoproto.TabletAliasString(tablet.Alias)))
+			continue
 		case opts.AvoidPrimaryAlias != nil && topoproto.TabletAliasEqual(tablet.Alias, opts.AvoidPrimaryAlias):
 			reasonsToInvalidate.WriteString(fmt.Sprintf("\n%v matches the primary alias to avoid", topoproto.TabletAliasString(tablet.Alias)))
 			continue
@@ -77,6 +77,7 @@ func TestElectNewPrimary(t *testing.T) {
 		avoidPrimaryAlias       *topodatapb.TabletAlias
 		tolerableReplLag        time.Duration
 		allowCrossCellPromotion bool
+		cellDrains              map[string]*topodatapb.CellDrain
 		expected                *topodatapb.TabletAlias
 		errContains             []string
 	}{
@@ -1051,6 +1052,63 @@ func TestElectNewPrimary(t *testing.T) {
 				Uid:  102,
 			},
 		},
+		{
+			name: "tablets in drained cells are not promoted",
+			tmc: &chooseNewPrimaryTestTMClient{
+				// zone2-200 is behind zone1-101
+				replicationStatuses: map[string]*replicationdatapb.Status{
+					"zone1-0000000101": {
+						Position: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5",
+					},
+					"zone2-0000000200": {
+						Position: "MySQL56/3E11FA47-71CA-11E1-9E33-C80AA9429562:1",
+					},
+				},
+			},
+			allowCrossCellPromotion: true,
+			cellDrains: map[string]*topodatapb.CellDrain{
+				"zone1": {},
+			},
+			shardInfo: topo.NewShardInfo("testkeyspace", "-", &topodatapb.Shard{
+				PrimaryAlias: &topodatapb.TabletAlias{
+					Cell: "zone1",
+					Uid:  100,
+				},
+			}, nil),
+			tabletMap: map[string]*topo.TabletInfo{
+				"primary": {
+					Tablet: &topodatapb.Tablet{
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  100,
+						},
+						Type: topodatapb.TabletType_PRIMARY,
+					},
+				},
+				"replica1": {
+					Tablet: &topodatapb.Tablet{
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone1",
+							Uid:  101,
+						},
+						Type: topodatapb.TabletType_REPLICA,
+					},
+				},
+				"replica2": {
+					Tablet: &topodatapb.Tablet{
+						Alias: &topodatapb.TabletAlias{
+							Cell: "zone2",
+							Uid:  200,
+						},
+						Type: topodatapb.TabletType_REPLICA,
+					},
+				},
+			},
+			expected: &topodatapb.TabletAlias{
+				Cell: "zone2",
+				Uid:  200,
+			},
+		},
 		{
 			name: "only available tablet is AvoidPrimary",
 			tmc: &chooseNewPrimaryTestTMClient{
@@ -1136,6 +1194,7 @@ zone1-0000000100 is not a replica`,
 				durability:              durability,
 				AllowCrossCellPromotion: tt.allowCrossCellPromotion,
 				WaitReplicasTimeout:     time.Millisecond * 50,
+				cellDrains:              tt.cellDrains,
 			}
 			actual, err := ElectNewPrimary(ctx, tt.tmc, tt.shardInfo, tt.tabletMap, tt.innodbBufferPoolData, options, logger)
 			if len(tt.errContains) > 0 {
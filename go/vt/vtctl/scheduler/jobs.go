@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cron"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ValidateJob validates the job before it is saved in the topo.
+func ValidateJob(job *vtctldatapb.ScheduledJob) error {
+	if job == nil {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "job is required")
+	}
+	if job.Name == "" || strings.Contains(job.Name, "/") {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid job name %q", job.Name)
+	}
+	if _, err := cron.ParseSchedule(job.Schedule); err != nil {
+		return vterrors.Wrapf(err, "invalid job %s", job.Name)
+	}
+	if job.Concurrency < 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid concurrency %d of job %s", job.Concurrency, job.Name)
+	}
+	if _, _, err := protoutil.DurationFromProto(job.Jitter); err != nil {
+		return vterrors.Wrapf(err, "invalid jitter of job %s", job.Name)
+	}
+	if _, _, err := protoutil.DurationFromProto(job.Timeout); err != nil {
+		return vterrors.Wrapf(err, "invalid timeout of job %s", job.Name)
+	}
+
+	switch j := job.Job.(type) {
+	case *vtctldatapb.ScheduledJob_Backup:
+	case *vtctldatapb.ScheduledJob_Vdiff:
+		if len(j.Vdiff.Workflows) == 0 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "VDiff job %s has no workflows", job.Name)
+		}
+		for _, workflow := range j.Vdiff.Workflows {
+			if _, _, err := splitWorkflow(workflow); err != nil {
+				return err
+			}
+		}
+	case *vtctldatapb.ScheduledJob_PurgeSchemaMigrations:
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "job %s has no backup, vdiff or purge_schema_migrations job", job.Name)
+	}
+	return nil
+}
+
+func splitWorkflow(workflow string) (keyspace string, name string, err error) {
+	keyspace, name, ok := strings.Cut(workflow, ".")
+	if !ok || keyspace == "" || name == "" {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid workflow %q, it must be <keyspace>.<workflow>", workflow)
+	}
+	return keyspace, name, nil
+}
+
+// runScheduledJob runs the job once.
+func runScheduledJob(ctx context.Context, ts *topo.Server, client vtctldclient.VtctldClient, job *vtctldatapb.ScheduledJob) error {
+	concurrency := max(int(job.Concurrency), 1)
+	switch j := job.Job.(type) {
+	case *vtctldatapb.ScheduledJob_Backup:
+		return runBackupJob(ctx, ts, client, j.Backup, concurrency)
+	case *vtctldatapb.ScheduledJob_Vdiff:
+		return runVDiffJob(ctx, client, j.Vdiff, concurrency)
+	case *vtctldatapb.ScheduledJob_PurgeSchemaMigrations:
+		return runPurgeSchemaMigrationsJob(ctx, ts, client, j.PurgeSchemaMigrations, concurrency)
+	default:
+		return fmt.Errorf("unknown job type %T", job.Job)
+	}
+}
+
+// forEach calls fn for each item, running up to limit calls at the same time.
+// It does not stop at the first error, and returns all of them.
+func forEach(ctx context.Context, items []string, limit int, fn func(ctx context.Context, item string) error) error {
+	rec := concurrency.AllErrorRecorder{}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			rec.RecordError(ctx.Err())
+			wg.Wait()
+			return rec.Error()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, item); err != nil {
+				rec.RecordError(fmt.Errorf("%s: %w", item, err))
+			}
+		}()
+	}
+	wg.Wait()
+	return rec.Error()
+}
+
+func keyspacesOrAll(ctx context.Context, ts *topo.Server, keyspaces []string) ([]string, error) {
+	if len(keyspaces) > 0 {
+		return keyspaces, nil
+	}
+	return ts.GetKeyspaces(ctx)
+}
+
+func runBackupJob(ctx context.Context, ts *topo.Server, client vtctldclient.VtctldClient, job *vtctldatapb.ScheduledJob_BackupJob, concurrency int) error {
+	keyspaces, err := keyspacesOrAll(ctx, ts, job.Keyspaces)
+	if err != nil {
+		return err
+	}
+	var shards []string
+	for _, keyspace := range keyspaces {
+		names, err := ts.GetShardNames(ctx, keyspace)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			shards = append(shards, topoproto.KeyspaceShardString(keyspace, name))
+		}
+	}
+
+	return forEach(ctx, shards, concurrency, func(ctx context.Context, keyspaceShard string) error {
+		keyspace, shard, err := topoproto.ParseKeyspaceShard(keyspaceShard)
+		if err != nil {
+			return err
+		}
+		stream, err := client.BackupShard(ctx, &vtctldatapb.BackupShardRequest{
+			Keyspace:     keyspace,
+			Shard:        shard,
+			AllowPrimary: job.AllowPrimary,
+			UpgradeSafe:  job.UpgradeSafe,
+		})
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	})
+}
+
+func runVDiffJob(ctx context.Context, client vtctldclient.VtctldClient, job *vtctldatapb.ScheduledJob_VDiffJob, concurrency int) error {
+	return forEach(ctx, job.Workflows, concurrency, func(ctx context.Context, workflow string) error {
+		keyspace, name, err := splitWorkflow(workflow)
+		if err != nil {
+			return err
+		}
+		// The options are the defaults of vtctldclient VDiff create. The VDiff
+		// runs asynchronously on the target tablets, and its report is read
+		// with vtctldclient VDiff show.
+		_, err = client.VDiffCreate(ctx, &vtctldatapb.VDiffCreateRequest{
+			Workflow:                    name,
+			TargetKeyspace:              keyspace,
+			Limit:                       math.MaxInt64,
+			FilteredReplicationWaitTime: protoutil.DurationToProto(30 * time.Second),
+			MaxExtraRowsToCompare:       1000,
+			AutoRetry:                   true,
+			MaxReportSampleRows:         10,
+			RowDiffColumnTruncateAt:     128,
+		})
+		return err
+	})
+}
+
+func runPurgeSchemaMigrationsJob(ctx context.Context, ts *topo.Server, client vtctldclient.VtctldClient, job *vtctldatapb.ScheduledJob_PurgeSchemaMigrationsJob, concurrency int) error {
+	keyspaces, err := keyspacesOrAll(ctx, ts, job.Keyspaces)
+	if err != nil {
+		return err
+	}
+	return forEach(ctx, keyspaces, concurrency, func(ctx context.Context, keyspace string) error {
+		_, err := client.CleanupSchemaMigration(ctx, &vtctldatapb.CleanupSchemaMigrationRequest{
+			Keyspace: keyspace,
+			Uuid:     "all",
+		})
+		return err
+	})
+}
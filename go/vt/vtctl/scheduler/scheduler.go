@@ -0,0 +1,346 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheduler runs the recurring jobs scheduled in vtctld, like nightly
+// backups of every shard or weekly VDiffs of the critical workflows.
+//
+// The jobs are stored in the global topo, so every vtctld of the cluster runs
+// a scheduler. A vtctld claims an occurrence of a job by adding a run to the
+// job record with a compare-and-swap on its topo version, so each occurrence
+// is run by a single vtctld.
+package scheduler
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/cron"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const (
+	// DefaultTimeout is the timeout of the runs of the jobs that don't set
+	// one.
+	DefaultTimeout = 24 * time.Hour
+
+	errPreviousRunInProgress = "skipped: the previous run is still in progress"
+)
+
+var (
+	scheduledJobRuns = stats.NewCountersWithMultiLabels(
+		"ScheduledJobRuns",
+		"Number of runs of the jobs scheduled in vtctld",
+		[]string{"Job", "Result"})
+	scheduledJobRunDurations = stats.NewTimings(
+		"ScheduledJobRunDurations",
+		"Duration of the runs of the jobs scheduled in vtctld",
+		"Job")
+)
+
+// Scheduler periodically checks the jobs scheduled in the topo and runs the
+// ones that are due.
+type Scheduler struct {
+	ts            *topo.Server
+	client        vtctldclient.VtctldClient
+	checkInterval time.Duration
+	historySize   int
+	vtctld        string
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running map[string]bool
+}
+
+// New returns a Scheduler that checks the jobs every checkInterval, runs them
+// with client, and keeps the historySize most recent runs of each job.
+func New(ts *topo.Server, client vtctldclient.VtctldClient, checkInterval time.Duration, historySize int) *Scheduler {
+	vtctld, err := os.Hostname()
+	if err != nil {
+		vtctld = "unknown"
+	}
+	return &Scheduler{
+		ts:            ts,
+		client:        client,
+		checkInterval: checkInterval,
+		historySize:   max(historySize, 1),
+		vtctld:        vtctld,
+		running:       make(map[string]bool),
+	}
+}
+
+// Open starts checking the jobs in the background.
+func (s *Scheduler) Open() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.checkInterval)
+		defer ticker.Stop()
+		for {
+			s.checkJobs(ctx, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Close stops the scheduler and cancels the runs in progress, then waits for
+// them to record their results.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	s.wg.Wait()
+}
+
+// checkJobs starts a run of each job due at now that is not already running in
+// this vtctld.
+func (s *Scheduler) checkJobs(ctx context.Context, now time.Time) {
+	names, err := s.ts.GetScheduledJobNames(ctx)
+	if err != nil {
+		log.Errorf("Failed to list the scheduled jobs: %v", err)
+		return
+	}
+	for _, name := range names {
+		ji, err := s.ts.GetScheduledJob(ctx, name)
+		if err != nil {
+			if !topo.IsErrType(err, topo.NoNode) {
+				log.Errorf("Failed to read scheduled job %s: %v", name, err)
+			}
+			continue
+		}
+		due, err := dueOccurrence(ji.ScheduledJob, now)
+		if err != nil {
+			log.Errorf("Invalid scheduled job %s: %v", name, err)
+			continue
+		}
+		if due.IsZero() {
+			continue
+		}
+
+		s.mu.Lock()
+		if s.running[name] {
+			s.mu.Unlock()
+			continue
+		}
+		s.running[name] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				delete(s.running, name)
+			}()
+			s.runJob(ctx, name, due, now, jitterDelay(ji.ScheduledJob))
+		}()
+	}
+}
+
+// dueOccurrence returns the most recent occurrence of the schedule of the job
+// at or before now that was not run yet, or the zero time if there is none.
+// The occurrences missed while no vtctld was running are not caught up.
+func dueOccurrence(job *vtctldatapb.ScheduledJob, now time.Time) (time.Time, error) {
+	if job.Disabled {
+		return time.Time{}, nil
+	}
+	schedule, err := cron.ParseSchedule(job.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	base := protoutil.TimeFromProto(job.UpdatedAt).UTC()
+	if n := len(job.Runs); n > 0 {
+		if last := protoutil.TimeFromProto(job.Runs[n-1].ScheduledAt).UTC(); last.After(base) {
+			base = last
+		}
+	}
+	due := schedule.Next(base)
+	if due.IsZero() || due.After(now) {
+		return time.Time{}, nil
+	}
+	for {
+		next := schedule.Next(due)
+		if next.IsZero() || next.After(now) {
+			return due, nil
+		}
+		due = next
+	}
+}
+
+func jitterDelay(job *vtctldatapb.ScheduledJob) time.Duration {
+	jitter, _, err := protoutil.DurationFromProto(job.Jitter)
+	if err != nil || jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter)
+}
+
+func jobTimeout(job *vtctldatapb.ScheduledJob) time.Duration {
+	timeout, ok, err := protoutil.DurationFromProto(job.Timeout)
+	if err != nil || !ok || timeout <= 0 {
+		return DefaultTimeout
+	}
+	return timeout
+}
+
+// runJob waits for the jitter, claims the occurrence of the job scheduled at
+// due, runs it and records its result. now is the time the job was checked at.
+func (s *Scheduler) runJob(ctx context.Context, name string, due time.Time, now time.Time, jitter time.Duration) {
+	if jitter > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter):
+		}
+	}
+
+	job, err := s.claim(ctx, name, due, now.Add(jitter))
+	if err != nil {
+		log.Errorf("Failed to claim the run of scheduled job %s at %v: %v", name, due, err)
+		return
+	}
+	if job == nil {
+		// Another vtctld claimed this occurrence, the job changed, or the
+		// previous run is still in progress.
+		return
+	}
+
+	log.Infof("Running scheduled job %s scheduled at %v", name, due)
+	start := time.Now()
+	runCtx, cancel := context.WithTimeout(ctx, jobTimeout(job))
+	runErr := runScheduledJob(runCtx, s.ts, s.client, job)
+	cancel()
+	scheduledJobRunDurations.Record(name, start)
+	if runErr != nil {
+		scheduledJobRuns.Add([]string{name, "Failed"}, 1)
+		log.Errorf("Scheduled job %s scheduled at %v failed: %v", name, due, runErr)
+	} else {
+		scheduledJobRuns.Add([]string{name, "Succeeded"}, 1)
+		log.Infof("Scheduled job %s scheduled at %v succeeded in %v", name, due, time.Since(start))
+	}
+
+	// The run is recorded even if the scheduler was closed, which canceled it.
+	recordCtx, recordCancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer recordCancel()
+	if err := s.finish(recordCtx, name, due, runErr); err != nil {
+		log.Errorf("Failed to record the run of scheduled job %s at %v: %v", name, due, err)
+	}
+}
+
+// claim adds a run of the job for the occurrence scheduled at due, unless it
+// was already claimed, and returns the job to run. It returns nil if the
+// occurrence must not be run by this vtctld.
+func (s *Scheduler) claim(ctx context.Context, name string, due time.Time, now time.Time) (*vtctldatapb.ScheduledJob, error) {
+	var job *vtctldatapb.ScheduledJob
+	_, err := s.ts.UpdateScheduledJobFields(ctx, name, func(ji *topo.ScheduledJobInfo) error {
+		job = nil
+		current, err := dueOccurrence(ji.ScheduledJob, now)
+		if err != nil {
+			return err
+		}
+		if !current.Equal(due) {
+			return topo.NewError(topo.NoUpdateNeeded, name)
+		}
+
+		run := &vtctldatapb.ScheduledJobRun{
+			ScheduledAt: protoutil.TimeToProto(due),
+			StartedAt:   protoutil.TimeToProto(now),
+			Vtctld:      s.vtctld,
+		}
+		if n := len(ji.Runs); n > 0 && previousRunInProgress(ji.ScheduledJob, ji.Runs[n-1], now) {
+			run.FinishedAt = run.StartedAt
+			run.Error = errPreviousRunInProgress
+		} else {
+			job = ji.ScheduledJob
+		}
+		ji.Runs = append(ji.Runs, run)
+		if len(ji.Runs) > s.historySize {
+			ji.Runs = ji.Runs[len(ji.Runs)-s.historySize:]
+		}
+		return nil
+	})
+	if topo.IsErrType(err, topo.NoNode) || topo.IsErrType(err, topo.NoUpdateNeeded) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		scheduledJobRuns.Add([]string{name, "Skipped"}, 1)
+		log.Warningf("Skipping scheduled job %s scheduled at %v: the previous run is still in progress", name, due)
+	}
+	return job, nil
+}
+
+// previousRunInProgress returns whether the run has not finished yet, and was
+// not abandoned by a vtctld that stopped before its timeout.
+func previousRunInProgress(job *vtctldatapb.ScheduledJob, run *vtctldatapb.ScheduledJobRun, now time.Time) bool {
+	if run.FinishedAt != nil {
+		return false
+	}
+	return now.Before(protoutil.TimeFromProto(run.StartedAt).Add(jobTimeout(job)))
+}
+
+// finish records the result of the run of the job scheduled at due.
+func (s *Scheduler) finish(ctx context.Context, name string, due time.Time, runErr error) error {
+	_, err := s.ts.UpdateScheduledJobFields(ctx, name, func(ji *topo.ScheduledJobInfo) error {
+		for _, run := range ji.Runs {
+			if run.Vtctld != s.vtctld || run.FinishedAt != nil || !protoutil.TimeFromProto(run.ScheduledAt).Equal(due) {
+				continue
+			}
+			run.FinishedAt = protoutil.TimeToProto(time.Now())
+			if runErr != nil {
+				run.Error = runErr.Error()
+			}
+			return nil
+		}
+		// The run was dropped from the history, or the job was set again.
+		return topo.NewError(topo.NoUpdateNeeded, name)
+	})
+	if topo.IsErrType(err, topo.NoNode) {
+		// The job was deleted while it was running.
+		return nil
+	}
+	return err
+}
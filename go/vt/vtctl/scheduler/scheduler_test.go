@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/vtctldclient"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// fakeClient records the CleanupSchemaMigration and VDiffCreate calls.
+type fakeClient struct {
+	vtctldclient.VtctldClient
+
+	mu      sync.Mutex
+	calls   []string
+	errs    map[string]error
+	block   chan struct{}
+	started chan struct{}
+}
+
+func (c *fakeClient) call(ctx context.Context, name string) error {
+	if c.started != nil {
+		c.started <- struct{}{}
+	}
+	if c.block != nil {
+		select {
+		case <-c.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, name)
+	return c.errs[name]
+}
+
+func (c *fakeClient) CleanupSchemaMigration(ctx context.Context, req *vtctldatapb.CleanupSchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.CleanupSchemaMigrationResponse, error) {
+	return &vtctldatapb.CleanupSchemaMigrationResponse{}, c.call(ctx, "cleanup "+req.Keyspace+" "+req.Uuid)
+}
+
+func (c *fakeClient) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffCreateResponse, error) {
+	return &vtctldatapb.VDiffCreateResponse{}, c.call(ctx, "vdiff "+req.TargetKeyspace+"."+req.Workflow)
+}
+
+func (c *fakeClient) getCalls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	calls := slices.Clone(c.calls)
+	slices.Sort(calls)
+	return calls
+}
+
+func TestDueOccurrence(t *testing.T) {
+	updated := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	job := &vtctldatapb.ScheduledJob{
+		Schedule:  "0 * * * *",
+		UpdatedAt: protoutil.TimeToProto(updated),
+	}
+
+	due, err := dueOccurrence(job, updated.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, due.IsZero())
+
+	due, err = dueOccurrence(job, updated.Add(40*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), due)
+
+	// The missed occurrences are not caught up.
+	due, err = dueOccurrence(job, updated.Add(5*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC), due)
+
+	job.Runs = []*vtctldatapb.ScheduledJobRun{{ScheduledAt: protoutil.TimeToProto(due)}}
+	due, err = dueOccurrence(job, updated.Add(5*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, due.IsZero())
+
+	job.Disabled = true
+	due, err = dueOccurrence(job, updated.Add(10*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, due.IsZero())
+
+	job.Disabled = false
+	job.Schedule = "0 *"
+	_, err = dueOccurrence(job, updated.Add(10*time.Hour))
+	assert.Error(t, err)
+}
+
+func newTestScheduler(ts *topo.Server, client vtctldclient.VtctldClient, vtctld string) *Scheduler {
+	s := New(ts, client, time.Minute, 3)
+	s.vtctld = vtctld
+	return s
+}
+
+func TestSchedulerRunsJobOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	for _, keyspace := range []string{"commerce", "customer"} {
+		require.NoError(t, ts.CreateKeyspace(ctx, keyspace, &topodatapb.Keyspace{}))
+	}
+
+	updated := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	_, err := ts.CreateScheduledJob(ctx, &vtctldatapb.ScheduledJob{
+		Name:        "purge",
+		Schedule:    "@hourly",
+		Concurrency: 2,
+		UpdatedAt:   protoutil.TimeToProto(updated),
+		Job: &vtctldatapb.ScheduledJob_PurgeSchemaMigrations{
+			PurgeSchemaMigrations: &vtctldatapb.ScheduledJob_PurgeSchemaMigrationsJob{},
+		},
+	})
+	require.NoError(t, err)
+
+	client := &fakeClient{errs: map[string]error{"cleanup customer all": errors.New("no primary")}}
+	s1 := newTestScheduler(ts, client, "vtctld1")
+	s2 := newTestScheduler(ts, client, "vtctld2")
+
+	// Nothing is due yet.
+	s1.checkJobs(ctx, updated.Add(time.Minute))
+	s1.wg.Wait()
+	assert.Empty(t, client.getCalls())
+
+	// The occurrence of 11:00 is run by a single scheduler.
+	now := updated.Add(45 * time.Minute)
+	s1.checkJobs(ctx, now)
+	s1.wg.Wait()
+	s2.checkJobs(ctx, now)
+	s2.wg.Wait()
+	assert.Equal(t, []string{"cleanup commerce all", "cleanup customer all"}, client.getCalls())
+
+	ji, err := ts.GetScheduledJob(ctx, "purge")
+	require.NoError(t, err)
+	require.Len(t, ji.Runs, 1)
+	run := ji.Runs[0]
+	assert.Equal(t, time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), protoutil.TimeFromProto(run.ScheduledAt).UTC())
+	assert.Equal(t, "vtctld1", run.Vtctld)
+	assert.NotNil(t, run.FinishedAt)
+	assert.Contains(t, run.Error, "customer: no primary")
+
+	// The history keeps the 3 most recent runs.
+	for i := 2; i <= 5; i++ {
+		s2.checkJobs(ctx, updated.Add(time.Duration(i)*time.Hour))
+		s2.wg.Wait()
+	}
+	ji, err = ts.GetScheduledJob(ctx, "purge")
+	require.NoError(t, err)
+	require.Len(t, ji.Runs, 3)
+	assert.Equal(t, time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC), protoutil.TimeFromProto(ji.Runs[2].ScheduledAt).UTC())
+	assert.Equal(t, "vtctld2", ji.Runs[2].Vtctld)
+}
+
+func TestSchedulerSkipsRunInProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	updated := time.Now().Add(-2 * time.Minute)
+	_, err := ts.CreateScheduledJob(ctx, &vtctldatapb.ScheduledJob{
+		Name:      "vdiff",
+		Schedule:  "* * * * *",
+		UpdatedAt: protoutil.TimeToProto(updated),
+		Job: &vtctldatapb.ScheduledJob_Vdiff{
+			Vdiff: &vtctldatapb.ScheduledJob_VDiffJob{Workflows: []string{"customer.commerce2customer"}},
+		},
+	})
+	require.NoError(t, err)
+
+	client := &fakeClient{block: make(chan struct{}), started: make(chan struct{}, 1)}
+	s1 := newTestScheduler(ts, client, "vtctld1")
+	s2 := newTestScheduler(ts, client, "vtctld2")
+
+	runCtx, runCancel := context.WithCancel(ctx)
+	s1.cancel = runCancel
+	s1.checkJobs(runCtx, time.Now())
+	<-client.started
+
+	// The next occurrence is skipped by the other vtctld, as the previous run
+	// is still in progress.
+	s2.checkJobs(ctx, time.Now().Add(time.Minute))
+	s2.wg.Wait()
+	ji, err := ts.GetScheduledJob(ctx, "vdiff")
+	require.NoError(t, err)
+	require.Len(t, ji.Runs, 2)
+	assert.Nil(t, ji.Runs[0].FinishedAt)
+	assert.Equal(t, errPreviousRunInProgress, ji.Runs[1].Error)
+
+	// Closing the scheduler cancels the run and records it.
+	s1.Close()
+	ji, err = ts.GetScheduledJob(ctx, "vdiff")
+	require.NoError(t, err)
+	assert.NotNil(t, ji.Runs[0].FinishedAt)
+	assert.Contains(t, ji.Runs[0].Error, "context canceled")
+	assert.Empty(t, client.getCalls())
+}
+
+func TestValidateJob(t *testing.T) {
+	backup := &vtctldatapb.ScheduledJob_Backup{Backup: &vtctldatapb.ScheduledJob_BackupJob{}}
+	tests := []struct {
+		name   string
+		job    *vtctldatapb.ScheduledJob
+		errMsg string
+	}{
+		{
+			name: "valid",
+			job:  &vtctldatapb.ScheduledJob{Name: "backups", Schedule: "@daily", Job: backup},
+		},
+		{
+			name:   "missing job",
+			errMsg: "job is required",
+		},
+		{
+			name:   "invalid name",
+			job:    &vtctldatapb.ScheduledJob{Name: "a/b", Schedule: "@daily", Job: backup},
+			errMsg: "invalid job name",
+		},
+		{
+			name:   "invalid schedule",
+			job:    &vtctldatapb.ScheduledJob{Name: "backups", Schedule: "0 25 * * *", Job: backup},
+			errMsg: "invalid hour",
+		},
+		{
+			name:   "no job type",
+			job:    &vtctldatapb.ScheduledJob{Name: "backups", Schedule: "@daily"},
+			errMsg: "has no backup, vdiff or purge_schema_migrations job",
+		},
+		{
+			name: "invalid workflow",
+			job: &vtctldatapb.ScheduledJob{Name: "vdiffs", Schedule: "@weekly", Job: &vtctldatapb.ScheduledJob_Vdiff{
+				Vdiff: &vtctldatapb.ScheduledJob_VDiffJob{Workflows: []string{"commerce2customer"}},
+			}},
+			errMsg: "it must be <keyspace>.<workflow>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJob(tt.job)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
@@ -36,6 +36,7 @@ func main() { // nolint:funlen
 	implType := pflag.String("impl", "gRPCVtctldClient", "type implementing the interface")
 	pkgName := pflag.String("targetpkg", "grpcvtctldclient", "package name to generate code for")
 	local := pflag.Bool("local", false, "generate a local, in-process client rather than a grpcclient")
+	recording := pflag.Bool("recording", false, "generate a client recording the responses of another client rather than a grpcclient")
 	out := pflag.String("out", "", "output destination. leave empty to use stdout")
 
 	pflag.Parse()
@@ -144,9 +145,9 @@ func main() { // nolint:funlen
 			case *types.Interface:
 				f.IsStreaming = true
 				localType, localImport, pkgPath, err = extractLocalNamedType(result)
-				if err == nil && *local {
+				if err == nil && (*local || *recording) {
 					// We need to get the pointer type returned by `stream.Recv()`
-					// in the local case for the stream adapter.
+					// in the local and recording cases for the stream adapter.
 					var recvType, recvImport, recvPkgPath string
 					recvType, recvImport, recvPkgPath, err = extractRecvType(result)
 					if err == nil {
@@ -178,9 +179,13 @@ func main() { // nolint:funlen
 		ClientName:  "grpcvtctldclient",
 	}
 
-	if *local {
+	switch {
+	case *local:
 		def.ClientName = "localvtctldclient"
 		def.Local = true
+	case *recording:
+		def.ClientName = "recordingvtctldclient"
+		def.Recording = true
 	}
 
 	for _, name := range importNames {
@@ -212,6 +217,7 @@ type ClientInterfaceDef struct {
 	Imports     []*Import
 	Methods     []*Func
 	Local       bool
+	Recording   bool
 	ClientName  string
 }
 
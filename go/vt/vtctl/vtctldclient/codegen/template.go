@@ -45,7 +45,7 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
-	{{ if not .Local -}}
+	{{ if not (or .Local .Recording) -}}
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	{{- end }}
@@ -98,7 +98,28 @@ func (stream *{{ streamAdapterName .Name }}) Send(msg {{ .StreamMessage.Type }})
 {{ end -}}
 // {{ .Name }} is part of the vtctlservicepb.VtctldClient interface.
 func (client *{{ $.Type }}) {{ .Name }}(ctx context.Context, {{ .Param.Name }} {{ .Param.Type }}, opts ...grpc.CallOption) ({{ .Result.Type }}, error) {
-	{{ if not $.Local -}}
+	{{ if $.Recording -}}
+	{{- if .IsStreaming -}}
+	stream, err := client.c.{{ .Name }}(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingStream[{{ .StreamMessage.Type }}]{
+		ClientStream: stream,
+		recv:         stream.Recv,
+		record:       client.record,
+	}, nil
+	{{- else -}}
+	resp, err := client.c.{{ .Name }}(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.record(resp)
+	return resp, nil
+	{{- end -}}
+	{{- else if not $.Local -}}
 	if client.c == nil {
 		return nil, status.Error(codes.Unavailable, connClosedMsg)
 	}
@@ -0,0 +1,409 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// DefaultConsistencySampleSize is the default number of rows of each
+	// table compared when verifying the consistency of a writes switch.
+	DefaultConsistencySampleSize = 1000
+
+	// The number of primary keys looked up on the targets per query.
+	consistencySampleBatchSize = 100
+	// The maximum number of differing rows reported per table.
+	maxReportedSampleDiffs = 10
+)
+
+// The steps of the consistency fence of a writes switch, in order.
+const (
+	FenceStepStopSourceWrites = "StopSourceWrites"
+	FenceStepWaitForCatchup   = "WaitForCatchup"
+	FenceStepVerifyPositions  = "VerifyPositions"
+	FenceStepSampledDiff      = "SampledDiff"
+	FenceStepSwitchWrites     = "SwitchWrites"
+	// FenceStepRollback is only added when a step fails.
+	FenceStepRollback = "Rollback"
+)
+
+// The states of the steps of a consistency fence.
+const (
+	FenceStepPending   = "Pending"
+	FenceStepRunning   = "Running"
+	FenceStepSucceeded = "Succeeded"
+	FenceStepFailed    = "Failed"
+)
+
+// consistencyFence tracks the steps of a writes switch whose consistency is
+// verified: the writes on the source are stopped, the target streams catch
+// up, their positions are compared to the source positions, a sample of the
+// rows of each table is compared on the source and the target, and only then
+// are the writes switched. The switch is rolled back if any step fails.
+//
+// The methods of a nil consistencyFence do nothing, so that the steps of a
+// switch whose consistency is not verified need not be guarded.
+type consistencyFence struct {
+	steps []*vtctldatapb.WorkflowSwitchTrafficResponse_ConsistencyFenceStep
+}
+
+func newConsistencyFence() *consistencyFence {
+	fence := &consistencyFence{}
+	for _, name := range []string{FenceStepStopSourceWrites, FenceStepWaitForCatchup, FenceStepVerifyPositions, FenceStepSampledDiff, FenceStepSwitchWrites} {
+		fence.steps = append(fence.steps, &vtctldatapb.WorkflowSwitchTrafficResponse_ConsistencyFenceStep{
+			Name:  name,
+			State: FenceStepPending,
+		})
+	}
+	return fence
+}
+
+func (f *consistencyFence) step(name string) *vtctldatapb.WorkflowSwitchTrafficResponse_ConsistencyFenceStep {
+	for _, step := range f.steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	step := &vtctldatapb.WorkflowSwitchTrafficResponse_ConsistencyFenceStep{Name: name}
+	f.steps = append(f.steps, step)
+	return step
+}
+
+// start marks the step as running.
+func (f *consistencyFence) start(name string) {
+	if f == nil {
+		return
+	}
+	f.step(name).State = FenceStepRunning
+}
+
+// succeed marks the step as succeeded.
+func (f *consistencyFence) succeed(name string) {
+	if f == nil {
+		return
+	}
+	f.step(name).State = FenceStepSucceeded
+}
+
+// fail marks the step as failed with the error.
+func (f *consistencyFence) fail(name string, err error) {
+	if f == nil {
+		return
+	}
+	step := f.step(name)
+	step.State = FenceStepFailed
+	step.Message = err.Error()
+}
+
+// rolledBack records the rollback of the switch once the step failed, with
+// the error of the rollback if any, and returns the error of the step
+// annotated with the failed step.
+func (f *consistencyFence) rolledBack(name string, err, rollbackErr error) error {
+	if f == nil {
+		return err
+	}
+	step := f.step(FenceStepRollback)
+	if rollbackErr != nil {
+		step.State = FenceStepFailed
+		step.Message = rollbackErr.Error()
+		return vterrors.Wrapf(err, "consistency fence failed at step %s and its rollback failed", name)
+	}
+	step.State = FenceStepSucceeded
+	return vterrors.Wrapf(err, "consistency fence failed at step %s and was rolled back", name)
+}
+
+// Steps returns the steps of the fence, in order.
+func (f *consistencyFence) Steps() []*vtctldatapb.WorkflowSwitchTrafficResponse_ConsistencyFenceStep {
+	if f == nil {
+		return nil
+	}
+	return f.steps
+}
+
+// consistencySampleSize returns the number of rows of each table compared
+// when verifying the consistency of the writes switch.
+func consistencySampleSize(req *vtctldatapb.WorkflowSwitchTrafficRequest) int64 {
+	if req.GetConsistencySampleSize() > 0 {
+		return req.GetConsistencySampleSize()
+	}
+	return DefaultConsistencySampleSize
+}
+
+// verifyTargetPositions verifies that each stream of the workflow on the
+// target primaries reached the position of its source primary, as recorded
+// once the writes on the sources were stopped.
+func (ts *trafficSwitcher) verifyTargetPositions(ctx context.Context) error {
+	return ts.ForAllTargets(func(target *MigrationTarget) error {
+		resp, err := ts.TabletManagerClient().ReadVReplicationWorkflow(ctx, target.GetPrimary().Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: ts.WorkflowName(),
+		})
+		if err != nil {
+			return err
+		}
+		verified := 0
+		for _, stream := range resp.GetStreams() {
+			bls, ok := target.Sources[stream.Id]
+			if !ok {
+				continue
+			}
+			source, ok := ts.Sources()[bls.Shard]
+			if !ok {
+				return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no source found for shard %s of stream %d on %s",
+					bls.Shard, stream.Id, topoproto.TabletAliasString(target.GetPrimary().Alias))
+			}
+			want, err := replication.DecodePosition(source.Position)
+			if err != nil {
+				return vterrors.Wrapf(err, "failed to decode the position of source shard %s", bls.Shard)
+			}
+			got, err := replication.DecodePosition(stream.Pos)
+			if err != nil {
+				return vterrors.Wrapf(err, "failed to decode the position of stream %d on %s", stream.Id,
+					topoproto.TabletAliasString(target.GetPrimary().Alias))
+			}
+			if !got.AtLeast(want) {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "stream %d on %s is at position %s, which does not include the position %s of source shard %s",
+					stream.Id, topoproto.TabletAliasString(target.GetPrimary().Alias), stream.Pos, source.Position, bls.Shard)
+			}
+			verified++
+		}
+		if verified != len(target.Sources) {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "only %d of the %d streams of the workflow were found on %s",
+				verified, len(target.Sources), topoproto.TabletAliasString(target.GetPrimary().Alias))
+		}
+		ts.Logger().Infof("Verified the positions of the %d streams on target shard %s", verified, target.GetShard().ShardName())
+		return nil
+	})
+}
+
+// diffSample compares the row counts of the tables of the workflow on the
+// sources and the targets, and a sample of their rows: the rows with the
+// lowest and the highest primary keys of each source shard are looked up
+// on all the target shards. The writes must be stopped on both sides.
+func (ts *trafficSwitcher) diffSample(ctx context.Context, sampleSize int64) error {
+	var oneSource *MigrationSource
+	for _, source := range ts.Sources() {
+		oneSource = source
+		break
+	}
+	if oneSource == nil {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no source found for the %s workflow", ts.WorkflowName())
+	}
+	sd, err := ts.TabletManagerClient().GetSchema(ctx, oneSource.GetPrimary().Tablet, &tabletmanagerdatapb.GetSchemaRequest{
+		Tables: ts.Tables(),
+	})
+	if err != nil {
+		return vterrors.Wrapf(err, "failed to get the schema of source shard %s", oneSource.GetShard().ShardName())
+	}
+
+	// The rows with the lowest and the highest primary keys of each shard.
+	perShard := max(sampleSize/int64(2*len(ts.Sources())), 1)
+	var diffs []string
+	for _, td := range sd.GetTableDefinitions() {
+		if td.Type == tmutils.TableView || schema.IsInternalOperationTableName(td.Name) {
+			continue
+		}
+		tableDiffs, err := ts.diffTableSample(ctx, td, perShard)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to compare table %s", td.Name)
+		}
+		diffs = append(diffs, tableDiffs...)
+	}
+	if len(diffs) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the source and the target differ:\n  %s", strings.Join(diffs, "\n  "))
+	}
+	return nil
+}
+
+// diffTableSample compares the row count and a sample of the rows of the
+// table on the sources and the targets, and returns their differences.
+func (ts *trafficSwitcher) diffTableSample(ctx context.Context, td *tabletmanagerdatapb.TableDefinition, perShard int64) ([]string, error) {
+	table := sqlescape.EscapeID(td.Name)
+	columns := make([]string, 0, len(td.Columns))
+	for _, column := range td.Columns {
+		columns = append(columns, sqlescape.EscapeID(column))
+	}
+	var (
+		pkColumns []string
+		pkIndexes []int
+	)
+	for _, pk := range td.PrimaryKeyColumns {
+		pkColumns = append(pkColumns, sqlescape.EscapeID(pk))
+		pkIndexes = append(pkIndexes, slices.Index(td.Columns, pk))
+	}
+
+	var (
+		mu            sync.Mutex
+		sourceCount   int64
+		targetCount   int64
+		sourceSample  = make(map[string]sqltypes.Row)
+		targetSample  = make(map[string]sqltypes.Row)
+		countQuery    = "select count(*) from " + table
+		selectColumns = "select " + strings.Join(columns, ", ") + " from " + table
+	)
+	if err := ts.ForAllSources(func(source *MigrationSource) error {
+		count, err := ts.countRows(ctx, source.GetPrimary(), countQuery)
+		if err != nil {
+			return err
+		}
+		var rows []sqltypes.Row
+		if len(pkColumns) > 0 {
+			for _, order := range []string{"asc", "desc"} {
+				query := fmt.Sprintf("%s order by %s limit %d", selectColumns, strings.Join(pkColumns, " "+order+", ")+" "+order, perShard)
+				qr, err := ts.executeFetch(ctx, source.GetPrimary(), query, uint64(perShard))
+				if err != nil {
+					return err
+				}
+				rows = append(rows, qr.Rows...)
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		sourceCount += count
+		for _, row := range rows {
+			sourceSample[sampleRowKey(row, pkIndexes)] = row
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(sourceSample))
+	for key := range sourceSample {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if err := ts.ForAllTargets(func(target *MigrationTarget) error {
+		count, err := ts.countRows(ctx, target.GetPrimary(), countQuery)
+		if err != nil {
+			return err
+		}
+		var rows []sqltypes.Row
+		for batch := range slices.Chunk(keys, consistencySampleBatchSize) {
+			query := fmt.Sprintf("%s where (%s) in (%s)", selectColumns, strings.Join(pkColumns, ", "), strings.Join(batch, ", "))
+			qr, err := ts.executeFetch(ctx, target.GetPrimary(), query, uint64(len(batch)))
+			if err != nil {
+				return err
+			}
+			rows = append(rows, qr.Rows...)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		targetCount += count
+		for _, row := range rows {
+			targetSample[sampleRowKey(row, pkIndexes)] = row
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	if sourceCount != targetCount {
+		diffs = append(diffs, fmt.Sprintf("table %s has %d rows on the source and %d rows on the target", td.Name, sourceCount, targetCount))
+	}
+	diffs = append(diffs, diffSampleRows(td.Name, keys, sourceSample, targetSample)...)
+	ts.Logger().Infof("Compared %d rows, and the row counts, of table %s: %d differences", len(keys), td.Name, len(diffs))
+	return diffs, nil
+}
+
+// diffSampleRows returns the differences of the rows of the sample of the
+// table, looked up on the target by the given keys.
+func diffSampleRows(table string, keys []string, sourceSample, targetSample map[string]sqltypes.Row) []string {
+	var diffs []string
+	for _, key := range keys {
+		if len(diffs) == maxReportedSampleDiffs {
+			diffs = append(diffs, fmt.Sprintf("table %s has more differing rows", table))
+			break
+		}
+		sourceRow, targetRow := sourceSample[key], targetSample[key]
+		switch {
+		case targetRow == nil:
+			diffs = append(diffs, fmt.Sprintf("table %s is missing the row with primary key %s on the target", table, key))
+		case !sampleRowsEqual(sourceRow, targetRow):
+			diffs = append(diffs, fmt.Sprintf("table %s has a different row with primary key %s on the target: %v instead of %v", table, key, targetRow, sourceRow))
+		}
+	}
+	return diffs
+}
+
+// sampleRowKey returns the primary key of the row as a SQL row constructor
+// of literals, which is both unique and usable in an IN clause.
+func sampleRowKey(row sqltypes.Row, pkIndexes []int) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for i, idx := range pkIndexes {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		row[idx].EncodeSQLStringBuilder(&sb)
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+func sampleRowsEqual(a, b sqltypes.Row) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].IsNull() != b[i].IsNull() || string(a[i].Raw()) != string(b[i].Raw()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ts *trafficSwitcher) countRows(ctx context.Context, primary *topo.TabletInfo, query string) (int64, error) {
+	qr, err := ts.executeFetch(ctx, primary, query, 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) != 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for %q on %s: %v", query, topoproto.TabletAliasString(primary.Alias), qr.Rows)
+	}
+	return qr.Rows[0][0].ToInt64()
+}
+
+func (ts *trafficSwitcher) executeFetch(ctx context.Context, primary *topo.TabletInfo, query string, maxRows uint64) (*sqltypes.Result, error) {
+	qr, err := ts.TabletManagerClient().ExecuteFetchAsDba(ctx, primary.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		DbName:  primary.DbName(),
+		MaxRows: maxRows,
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to execute %q on %s", query, topoproto.TabletAliasString(primary.Alias))
+	}
+	return sqltypes.Proto3ToResult(qr), nil
+}
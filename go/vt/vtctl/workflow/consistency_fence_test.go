@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestConsistencyFence(t *testing.T) {
+	states := func(fence *consistencyFence) map[string]string {
+		states := make(map[string]string)
+		for _, step := range fence.Steps() {
+			states[step.Name] = step.State
+		}
+		return states
+	}
+
+	fence := newConsistencyFence()
+	fence.start(FenceStepStopSourceWrites)
+	fence.succeed(FenceStepStopSourceWrites)
+	fence.start(FenceStepWaitForCatchup)
+	fence.succeed(FenceStepWaitForCatchup)
+	fence.start(FenceStepVerifyPositions)
+	fence.succeed(FenceStepVerifyPositions)
+	fence.start(FenceStepSampledDiff)
+	diffErr := errors.New("table t1 has 10 rows on the source and 9 rows on the target")
+	fence.fail(FenceStepSampledDiff, diffErr)
+	err := fence.rolledBack(FenceStepSampledDiff, diffErr, nil)
+	require.ErrorContains(t, err, "consistency fence failed at step SampledDiff and was rolled back")
+	require.ErrorContains(t, err, diffErr.Error())
+	require.Equal(t, map[string]string{
+		FenceStepStopSourceWrites: FenceStepSucceeded,
+		FenceStepWaitForCatchup:   FenceStepSucceeded,
+		FenceStepVerifyPositions:  FenceStepSucceeded,
+		FenceStepSampledDiff:      FenceStepFailed,
+		FenceStepSwitchWrites:     FenceStepPending,
+		FenceStepRollback:         FenceStepSucceeded,
+	}, states(fence))
+	require.Equal(t, diffErr.Error(), fence.Steps()[3].Message)
+
+	fence = newConsistencyFence()
+	err = fence.rolledBack(FenceStepWaitForCatchup, diffErr, errors.New("could not restart vreplication"))
+	require.ErrorContains(t, err, "consistency fence failed at step WaitForCatchup and its rollback failed")
+	require.Equal(t, FenceStepFailed, states(fence)[FenceStepRollback])
+
+	// The steps of a switch whose consistency is not verified are not tracked.
+	var none *consistencyFence
+	none.start(FenceStepSwitchWrites)
+	none.fail(FenceStepSwitchWrites, diffErr)
+	require.Equal(t, diffErr, none.rolledBack(FenceStepSwitchWrites, diffErr, nil))
+	require.Nil(t, none.Steps())
+}
+
+func TestDiffSampleRows(t *testing.T) {
+	row := func(id int64, name string) sqltypes.Row {
+		return sqltypes.Row{sqltypes.NewInt64(id), sqltypes.NewVarChar(name)}
+	}
+	pkIndexes := []int{0}
+	source := map[string]sqltypes.Row{}
+	for _, r := range []sqltypes.Row{row(1, "a"), row(2, "b"), row(3, "c")} {
+		source[sampleRowKey(r, pkIndexes)] = r
+	}
+	keys := []string{"(1)", "(2)", "(3)"}
+	for _, key := range keys {
+		require.Contains(t, source, key)
+	}
+
+	target := map[string]sqltypes.Row{
+		"(1)": row(1, "a"),
+		"(2)": {sqltypes.NewInt64(2), sqltypes.NULL},
+	}
+	require.Equal(t, []string{
+		"table t1 has a different row with primary key (2) on the target: [INT64(2) NULL] instead of [INT64(2) VARCHAR(\"b\")]",
+		"table t1 is missing the row with primary key (3) on the target",
+	}, diffSampleRows("t1", keys, source, target))
+
+	target["(2)"] = row(2, "b")
+	target["(3)"] = row(3, "c")
+	require.Empty(t, diffSampleRows("t1", keys, source, target))
+
+	require.Equal(t, "(1, 'a')", sampleRowKey(row(1, "a"), []int{0, 1}))
+}
@@ -103,6 +103,13 @@ func (s *Server) buildResharder(ctx context.Context, req *vtctldata.ReshardCreat
 		}
 		rs.sourcePrimaries[si.ShardName()] = primary
 	}
+	if len(targets) == 0 {
+		target, err := s.findMergeTarget(ctx, keyspace, rs.sourceShards)
+		if err != nil {
+			return nil, err
+		}
+		targets = []string{target}
+	}
 	for _, shard := range targets {
 		si, err := s.ts.GetShard(ctx, keyspace, shard)
 		if err != nil {
@@ -140,6 +147,26 @@ func (s *Server) buildResharder(ctx context.Context, req *vtctldata.ReshardCreat
 	return rs, nil
 }
 
+// findMergeTarget returns the name of the shard of the keyspace covering the
+// keyranges of the source shards, into which they are merged when no target
+// shards are given.
+func (s *Server) findMergeTarget(ctx context.Context, keyspace string, sourceShards []*topo.ShardInfo) (string, error) {
+	kr, err := topotools.MergedKeyRange(sourceShards)
+	if err != nil {
+		return "", vterrors.Wrap(err, "no target shards were given")
+	}
+	shards, err := s.ts.FindAllShardsInKeyspace(ctx, keyspace, nil)
+	if err != nil {
+		return "", vterrors.Wrapf(err, "FindAllShardsInKeyspace(%s) failed", keyspace)
+	}
+	for name, si := range shards {
+		if key.KeyRangeEqual(si.KeyRange, kr) {
+			return name, nil
+		}
+	}
+	return "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no shard with keyrange %s to merge the source shards into: create it before merging", key.KeyRangeString(kr))
+}
+
 // validateTargets ensures that the target shards have no existing
 // VReplication workflow streams as that is an invalid starting
 // state for the non-serving shards involved in a Reshard.
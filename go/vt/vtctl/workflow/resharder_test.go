@@ -19,6 +19,7 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 
 	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
@@ -73,7 +75,9 @@ func TestReshardCreate(t *testing.T) {
 		want                           *vtctldatapb.WorkflowStatusResponse
 		updateVReplicationRequest      *tabletmanagerdatapb.UpdateVReplicationWorkflowsRequest
 		autoStart                      bool
-		wantErr                        string
+		// merge sends no target shards, to merge the source shards.
+		merge   bool
+		wantErr string
 	}{
 		{
 			name: "basic",
@@ -133,6 +137,45 @@ func TestReshardCreate(t *testing.T) {
 			},
 			wantErr: "buildResharder: target shard -80 has no primary tablet",
 		},
+		{
+			name: "merge",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"0"},
+			},
+			merge: true,
+			want: &vtctldatapb.WorkflowStatusResponse{
+				ShardStreams: map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams{
+					"targetks/0": {
+						Streams: []*vtctldatapb.WorkflowStatusResponse_ShardStreamState{
+							{
+								Id:          1,
+								Tablet:      &topodatapb.TabletAlias{Cell: defaultCellName, Uid: startingTargetTabletUID},
+								SourceShard: "targetks/-80", Position: gtid(position), Status: "Running", Info: "VStream Lag: 0s",
+							},
+						},
+					},
+				},
+				TrafficState: "Reads Not Switched. Writes Not Switched",
+			},
+		},
+		{
+			name: "merge without target shard",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-40", "40-"},
+			},
+			merge:   true,
+			wantErr: "buildResharder: no shard with keyrange - to merge the source shards into: create it before merging",
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -152,40 +195,50 @@ func TestReshardCreate(t *testing.T) {
 				Cells:        []string{env.cell},
 				AutoStart:    tc.autoStart,
 			}
+			if tc.merge {
+				req.TargetShards = nil
+			}
 
+			// The workflow has a stream per source shard, whose copy states
+			// are read in any order.
+			expectCopyStateQueries := func(tabletUID int) {
+				for range tc.sourceKeyspace.ShardNames {
+					env.tmc.expectVRQuery(
+						tabletUID,
+						"/select distinct table_name from _vt.copy_state cs, _vt.vreplication vr where vr.id = cs.vrepl_id and vr.id = [0-9]+$",
+						&sqltypes.Result{},
+					)
+				}
+				for range tc.sourceKeyspace.ShardNames {
+					env.tmc.expectVRQuery(
+						tabletUID,
+						`/select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in \(([0-9]+)\) and id in \(select max\(id\) from _vt.copy_state where vrepl_id in \(([0-9]+)\) group by vrepl_id, table_name\)$`,
+						&sqltypes.Result{},
+					)
+				}
+			}
 			for i := range tc.sourceKeyspace.ShardNames {
-				tabletUID := startingSourceTabletUID + (tabletUIDStep * i)
-				env.tmc.expectVRQuery(
-					tabletUID,
-					"select distinct table_name from _vt.copy_state cs, _vt.vreplication vr where vr.id = cs.vrepl_id and vr.id = 1",
-					&sqltypes.Result{},
-				)
-				env.tmc.expectVRQuery(
-					tabletUID,
-					"select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name)",
-					&sqltypes.Result{},
-				)
+				expectCopyStateQueries(startingSourceTabletUID + (tabletUIDStep * i))
 			}
 
 			for i, target := range tc.targetKeyspace.ShardNames {
 				tabletUID := startingTargetTabletUID + (tabletUIDStep * i)
+				_, targetKeyRange, err := topo.ValidateShardName(target)
+				require.NoError(t, err)
+				var rows []string
+				for _, source := range tc.sourceKeyspace.ShardNames {
+					if !shardsIntersect(t, source, target) {
+						continue
+					}
+					rows = append(rows, `\('`+workflowName+`', 'keyspace:"`+targetKeyspaceName+`" shard:"`+source+`" filter:{rules:{match:"/.*" filter:"`+key.KeyRangeString(targetKeyRange)+`"}}', '', [0-9]*, [0-9]*, '`+
+						env.cell+`', '`+tabletTypesStr+`', [0-9]*, 0, 'Stopped', 'vt_`+targetKeyspaceName+`', 4, 0, false, '{}'\)`)
+				}
 				env.tmc.expectVRQuery(
 					tabletUID,
-					insertPrefix+
-						`\('`+workflowName+`', 'keyspace:"`+targetKeyspaceName+`" shard:"0" filter:{rules:{match:"/.*" filter:"`+target+`"}}', '', [0-9]*, [0-9]*, '`+
-						env.cell+`', '`+tabletTypesStr+`', [0-9]*, 0, 'Stopped', 'vt_`+targetKeyspaceName+`', 4, 0, false, '{}'\)`+eol,
-					&sqltypes.Result{},
-				)
-				env.tmc.expectVRQuery(
-					tabletUID,
-					"select distinct table_name from _vt.copy_state cs, _vt.vreplication vr where vr.id = cs.vrepl_id and vr.id = 1",
-					&sqltypes.Result{},
-				)
-				env.tmc.expectVRQuery(
-					tabletUID,
-					"select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name)",
+					insertPrefix+strings.Join(rows, ", ")+eol,
 					&sqltypes.Result{},
 				)
+				expectCopyStateQueries(tabletUID)
 				if tc.updateVReplicationRequest != nil {
 					env.tmc.AddUpdateVReplicationRequests(uint32(tabletUID), tc.updateVReplicationRequest)
 				}
@@ -216,6 +269,15 @@ func TestReshardCreate(t *testing.T) {
 	}
 }
 
+// shardsIntersect returns true if the keyranges of the shards intersect.
+func shardsIntersect(t *testing.T, a, b string) bool {
+	_, akr, err := topo.ValidateShardName(a)
+	require.NoError(t, err)
+	_, bkr, err := topo.ValidateShardName(b)
+	require.NoError(t, err)
+	return key.KeyRangeIntersect(akr, bkr)
+}
+
 func TestReadRefStreams(t *testing.T) {
 	ctx := context.Background()
 
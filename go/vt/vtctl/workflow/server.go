@@ -459,8 +459,9 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 		table := ts.Tables()[0]
 
 		if ts.IsMultiTenantMigration() {
-			// Deduce which traffic has been switched by looking at the current keyspace routing rules.
-			err := updateKeyspaceRoutingState(ctx, ts.TopoServer(), sourceKeyspace, targetKeyspace, state)
+			// Deduce which traffic has been switched by looking at the current keyspace, or
+			// tenant, routing rules.
+			err := ts.updateMultiTenantState(ctx, sourceKeyspace, targetKeyspace, state)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -1464,7 +1465,7 @@ func (s *Server) MoveTablesComplete(ctx context.Context, req *vtctldatapb.MoveTa
 	} else {
 		renameTable = DropTable
 	}
-	if dryRunResults, err = s.dropSources(ctx, ts, renameTable, req.KeepData, req.KeepRoutingRules, false, req.DryRun, opts...); err != nil {
+	if dryRunResults, err = s.dropSources(ctx, ts, renameTable, req.KeepData, req.KeepRoutingRules, false, req.DryRun, req.DeleteBatchSize, opts...); err != nil {
 		return nil, err
 	}
 
@@ -1523,10 +1524,14 @@ func (s *Server) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCrea
 	} else {
 		s.Logger().Warningf("Streams will not be started since --auto-start is set to false")
 	}
+	targetShards := make([]string, 0, len(rs.targetShards))
+	for _, si := range rs.targetShards {
+		targetShards = append(targetShards, si.ShardName())
+	}
 	return s.WorkflowStatus(ctx, &vtctldatapb.WorkflowStatusRequest{
 		Keyspace: req.Keyspace,
 		Workflow: req.Workflow,
-		Shards:   req.TargetShards,
+		Shards:   targetShards,
 	})
 }
 
@@ -2391,7 +2396,8 @@ func (s *Server) dropRelatedArtifacts(ctx context.Context, keepRoutingRules bool
 
 // dropSources cleans up source tables, shards and denied tables after a
 // MoveTables/Reshard is completed.
-func (s *Server) dropSources(ctx context.Context, ts *trafficSwitcher, removalType TableRemovalType, keepData, keepRoutingRules, force, dryRun bool, opts ...WorkflowActionOption) (*[]string, error) {
+func (s *Server) dropSources(ctx context.Context, ts *trafficSwitcher, removalType TableRemovalType, keepData, keepRoutingRules, force, dryRun bool,
+	deleteBatchSize int64, opts ...WorkflowActionOption) (*[]string, error) {
 	wopts := processWorkflowActionOptions(opts)
 	var (
 		sw                         iswitcher
@@ -2436,6 +2442,14 @@ func (s *Server) dropSources(ctx context.Context, ts *trafficSwitcher, removalTy
 	if !keepData {
 		switch ts.MigrationType() {
 		case binlogdatapb.MigrationType_TABLES:
+			if ts.IsTenantMove() {
+				// The source tables also hold the rows of the other tenants.
+				s.Logger().Infof("Deleting the rows of tenant %s", ts.options.TenantId)
+				if err := sw.removeSourceTenantRows(ctx, deleteBatchSize); err != nil {
+					return nil, err
+				}
+				break
+			}
 			if !wopts.ignoreSourceKeyspace {
 				s.Logger().Infof("Deleting tables")
 				if err := sw.removeSourceTables(ctx, removalType); err != nil {
@@ -2731,15 +2745,23 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	if startState.WorkflowType == TypeMigrate {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for Migrate workflow: SwitchTraffic")
 	}
+	if req.VerifyConsistency && ts.IsMultiTenantMigration() {
+		// The tables on the target also contain the rows of the other tenants.
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot verify the consistency of multi-tenant migrations")
+	}
 
 	if ts.IsMultiTenantMigration() {
-		// Multi-tenant migrations use keyspace routing rules, so we need to update the state
-		// using them.
-		err = updateKeyspaceRoutingState(ctx, ts.TopoServer(), ts.sourceKeyspace, ts.targetKeyspace, startState)
+		// Multi-tenant migrations use keyspace, or tenant, routing rules, so we need to update
+		// the state using them.
+		err = ts.updateMultiTenantState(ctx, ts.sourceKeyspace, ts.targetKeyspace, startState)
 		if err != nil {
-			return nil, vterrors.Wrap(err, "failed to update multi-tenant workflow state using keyspace routing rules")
+			return nil, vterrors.Wrap(err, "failed to update multi-tenant workflow state using routing rules")
 		}
 	}
+	if ts.IsTenantMove() && !switchPrimary {
+		// The tenant routing rules route all the tablet types of the tenant.
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the read and write traffic of tenant moves must be switched together")
+	}
 
 	// We need this to know when there isn't a (non-FROZEN) reverse workflow to use.
 	onlySwitchingReads := !startState.WritesSwitched && !switchPrimary
@@ -2767,6 +2789,19 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 		direction = DirectionForward
 	}
 
+	// Switching writes is the disruptive part of the cut-over, so we only do it while
+	// a maintenance window is open for the target keyspace.
+	if switchPrimary && !writesAlreadySwitched && !req.DryRun && !req.IgnoreMaintenanceWindows {
+		inWindow, err := s.ts.InMaintenanceWindow(ctx, ts.TargetKeyspaceName(), time.Now())
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to check the maintenance windows of keyspace %s", ts.TargetKeyspaceName())
+		}
+		if !inWindow {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot switch writes for workflow %s outside of a maintenance window for keyspace %s; use --ignore-maintenance-windows to switch them anyway",
+				startState.Workflow, ts.TargetKeyspaceName())
+		}
+	}
+
 	// Lock the workflow for the traffic switching work.
 	lockName := fmt.Sprintf("%s/%s", ts.TargetKeyspaceName(), ts.WorkflowName())
 	ctx, workflowUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowSwitchTraffic")
@@ -2777,6 +2812,9 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	defer workflowUnlock(&err)
 
 	ts.force = req.GetForce()
+	if req.VerifyConsistency && switchPrimary && !req.DryRun {
+		ts.fence = newConsistencyFence()
+	}
 
 	if writesAlreadySwitched {
 		s.Logger().Infof("Writes already switched no need to check lag for the %s.%s workflow",
@@ -2832,19 +2870,20 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	} else {
 		s.Logger().Infof("%s done for workflow %s.%s", cmd, req.Keyspace, req.Workflow)
 		resp.Summary = fmt.Sprintf("%s was successful for workflow %s.%s", cmd, req.Keyspace, req.Workflow)
+		resp.ConsistencyFenceSteps = ts.fence.Steps()
 		// Reload the state after the SwitchTraffic operation and return that
 		// as a string.
 		resp.StartState = startState.String()
 		s.Logger().Infof("Before reloading workflow state after switching traffic: %+v\n", resp.StartState)
 		_, currentState, err := s.getWorkflowState(ctx, ts.targetKeyspace, ts.workflow)
 		if ts.IsMultiTenantMigration() {
-			// Multi-tenant migrations use keyspace routing rules, so we need to update the state
-			// using them.
+			// Multi-tenant migrations use keyspace, or tenant, routing rules, so we need to
+			// update the state using them.
 			sourceKs, targetKs := ts.sourceKeyspace, ts.targetKeyspace
 			if TrafficSwitchDirection(req.Direction) == DirectionBackward {
 				sourceKs, targetKs = targetKs, sourceKs
 			}
-			err = updateKeyspaceRoutingState(ctx, ts.TopoServer(), sourceKs, targetKs, currentState)
+			err = ts.updateMultiTenantState(ctx, sourceKs, targetKs, currentState)
 		}
 		if err != nil {
 			resp.CurrentState = fmt.Sprintf("Error reloading workflow state after switching traffic: %v", err)
@@ -2857,6 +2896,112 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	return resp, nil
 }
 
+// WorkflowCutBack switches all the traffic of a workflow whose writes were
+// switched back to its source keyspace, like ReverseTraffic does, but only
+// once the preflight checks of its reverse workflow passed.
+func (s *Server) WorkflowCutBack(ctx context.Context, req *vtctldatapb.WorkflowCutBackRequest) (*vtctldatapb.WorkflowCutBackResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowCutBack")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("dry-run", req.DryRun)
+
+	maxReplicationLagAllowed, set, err := protoutil.DurationFromProto(req.MaxReplicationLagAllowed)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse MaxReplicationLagAllowed into a valid duration")
+	}
+	if !set {
+		maxReplicationLagAllowed = DefaultTimeout
+	}
+	ts, state, err := s.getWorkflowState(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if state.WorkflowType == TypeMigrate {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid action for Migrate workflow: CutBack")
+	}
+	if ts.IsMultiTenantMigration() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot reverse write traffic for multi-tenant migrations")
+	}
+	if !state.WritesSwitched {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"cannot cut back workflow %s.%s as its writes have not been switched: use ReverseTraffic to switch its reads back", req.Keyspace, req.Workflow)
+	}
+	checks, err := s.checkReverseWorkflow(ctx, ts, int64(maxReplicationLagAllowed.Seconds()))
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot cut back workflow %s.%s", req.Keyspace, req.Workflow)
+	}
+
+	switchResp, err := s.WorkflowSwitchTraffic(ctx, &vtctldatapb.WorkflowSwitchTrafficRequest{
+		Keyspace:                 req.Keyspace,
+		Workflow:                 req.Workflow,
+		TabletTypes:              []topodatapb.TabletType{topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY},
+		MaxReplicationLagAllowed: req.MaxReplicationLagAllowed,
+		EnableReverseReplication: true,
+		Direction:                int32(DirectionBackward),
+		Timeout:                  req.Timeout,
+		DryRun:                   req.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &vtctldatapb.WorkflowCutBackResponse{
+		PreflightChecks: checks,
+		StartState:      switchResp.StartState,
+		CurrentState:    switchResp.CurrentState,
+		DryRunResults:   switchResp.DryRunResults,
+	}
+	if req.DryRun {
+		resp.Summary = fmt.Sprintf("CutBack dry run results for workflow %s.%s at %v",
+			req.Keyspace, req.Workflow, time.Now().UTC().Format(time.RFC822))
+	} else {
+		resp.Summary = fmt.Sprintf("CutBack was successful for workflow %s.%s", req.Keyspace, req.Workflow)
+	}
+	return resp, nil
+}
+
+// checkReverseWorkflow runs the preflight checks of a cut back on the reverse
+// workflow of ts and returns a description of each one that passed.
+func (s *Server) checkReverseWorkflow(ctx context.Context, ts *trafficSwitcher, maxAllowedReplLagSecs int64) ([]string, error) {
+	wf, err := s.GetWorkflow(ctx, ts.sourceKeyspace, ts.reverseWorkflow, false, nil)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to read the reverse workflow %s.%s", ts.sourceKeyspace, ts.reverseWorkflow)
+	}
+	checks := []string{fmt.Sprintf("Reverse workflow %s.%s exists", ts.sourceKeyspace, ts.reverseWorkflow)}
+
+	streams := 0
+	shards := make(map[string]bool)
+	for _, stream := range wf.ShardStreams {
+		for _, st := range stream.GetStreams() {
+			if st.Message == Frozen {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the reverse workflow is frozen")
+			}
+			if st.State != binlogdatapb.VReplicationWorkflowState_Running.String() {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "stream %d of the reverse workflow on shard %s is %s: %s",
+					st.Id, st.Shard, st.State, st.Message)
+			}
+			streams++
+			shards[st.Shard] = true
+		}
+	}
+	for shard := range ts.sources {
+		if !shards[shard] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the reverse workflow has no stream on shard %s/%s",
+				ts.sourceKeyspace, shard)
+		}
+	}
+	checks = append(checks, fmt.Sprintf("All %d streams of the reverse workflow are running on the %d shards of keyspace %s",
+		streams, len(shards), ts.sourceKeyspace))
+
+	if wf.MaxVReplicationTransactionLag > maxAllowedReplLagSecs {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, cannotSwitchHighLag, wf.MaxVReplicationTransactionLag, maxAllowedReplLagSecs)
+	}
+	checks = append(checks, fmt.Sprintf("Replication lag of the reverse workflow is %ds, within the allowed %ds",
+		wf.MaxVReplicationTransactionLag, maxAllowedReplLagSecs))
+	return checks, nil
+}
+
 // switchReads is a generic way of switching read traffic for a workflow.
 func (s *Server) switchReads(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, ts *trafficSwitcher, state *State, rebuildSrvVSchema bool, direction TrafficSwitchDirection) (*[]string, error) {
 	var roTabletTypes []topodatapb.TabletType
@@ -3076,6 +3221,10 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 	// existing streams, waiting for replication to catch up, and initializing
 	// the target sequences -- to be sure the lock is not lost.
 	ksLockTTL := waitTimeout * 3
+	if req.VerifyConsistency {
+		// The sampled diff is also bounded by waitTimeout.
+		ksLockTTL = waitTimeout * 4
+	}
 
 	// Need to lock both source and target keyspaces.
 	ctx, sourceUnlock, lockErr := sw.lockKeyspace(ctx, ts.SourceKeyspaceName(), "SwitchWrites", topo.WithTTL(ksLockTTL))
@@ -3143,6 +3292,17 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 			}
 			return 0, sw.logs(), err
 		}
+		// cancelFencedStep cancels the migration once a step of the
+		// consistency fence failed.
+		cancelFencedStep := func(step string, err error) error {
+			ts.fence.fail(step, err)
+			cerr := sw.cancelMigration(ctx, sm)
+			err = ts.fence.rolledBack(step, err, cerr)
+			if cerr != nil {
+				err = vterrors.Errorf(vtrpcpb.Code_CANCELED, "%v\n\n%v", err, cerr)
+			}
+			return err
+		}
 
 		// We stop writes on the source before stopping the source streams so that the catchup time
 		// is lessened and other workflows that we have to migrate such as intra-keyspace materialize
@@ -3152,12 +3312,12 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 		// the keyspace being resharded, we wait for those to catchup in the stopStreams path before
 		// we actually stop them.
 		ts.Logger().Infof("Stopping source writes")
+		ts.fence.start(FenceStepStopSourceWrites)
 		if err := sw.stopSourceWrites(ctx); err != nil {
-			if cerr := sw.cancelMigration(ctx, sm); cerr != nil {
-				err = vterrors.Errorf(vtrpcpb.Code_CANCELED, "%v\n\n%v", err, cerr)
-			}
+			err = cancelFencedStep(FenceStepStopSourceWrites, err)
 			return handleError(fmt.Sprintf("failed to stop writes in the %s keyspace", ts.SourceKeyspaceName()), err)
 		}
+		ts.fence.succeed(FenceStepStopSourceWrites)
 
 		ts.Logger().Infof("Stopping streams")
 		// Use a shorter context for this since since when doing a Reshard, if there are intra-keyspace
@@ -3206,12 +3366,35 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 			return handleError("locks were lost", err)
 		}
 		ts.Logger().Infof("Waiting for streams to catchup")
+		ts.fence.start(FenceStepWaitForCatchup)
 		if err := sw.waitForCatchup(ctx, waitTimeout); err != nil {
-			if cerr := sw.cancelMigration(ctx, sm); cerr != nil {
-				err = vterrors.Errorf(vtrpcpb.Code_CANCELED, "%v\n\n%v", err, cerr)
-			}
+			err = cancelFencedStep(FenceStepWaitForCatchup, err)
 			return handleError("failed to sync up replication between the source and target", err)
 		}
+		ts.fence.succeed(FenceStepWaitForCatchup)
+
+		if req.VerifyConsistency {
+			ts.Logger().Infof("Verifying the positions of the streams")
+			ts.fence.start(FenceStepVerifyPositions)
+			if err := sw.verifyTargetPositions(ctx); err != nil {
+				err = cancelFencedStep(FenceStepVerifyPositions, err)
+				return handleError("failed to verify the positions of the target streams", err)
+			}
+			ts.fence.succeed(FenceStepVerifyPositions)
+
+			if err := confirmKeyspaceLocksHeld(); err != nil {
+				return handleError("locks were lost", err)
+			}
+			ts.Logger().Infof("Comparing a sample of the rows of the source and the target")
+			ts.fence.start(FenceStepSampledDiff)
+			diffCtx, diffCancel := context.WithTimeout(ctx, waitTimeout)
+			defer diffCancel()
+			if err := sw.diffSample(diffCtx, consistencySampleSize(req)); err != nil {
+				err = cancelFencedStep(FenceStepSampledDiff, err)
+				return handleError("failed to verify the consistency of the source and the target", err)
+			}
+			ts.fence.succeed(FenceStepSampledDiff)
+		}
 
 		if err := confirmKeyspaceLocksHeld(); err != nil {
 			return handleError("locks were lost", err)
@@ -3279,15 +3462,20 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 	if err := confirmKeyspaceLocksHeld(); err != nil {
 		return handleError("locks were lost", err)
 	}
+	ts.fence.start(FenceStepSwitchWrites)
 	if err := sw.createJournals(ctx, sourceWorkflows); err != nil {
+		ts.fence.fail(FenceStepSwitchWrites, err)
 		return handleError("failed to create the journal", err)
 	}
 	if err := sw.allowTargetWrites(ctx); err != nil {
+		ts.fence.fail(FenceStepSwitchWrites, err)
 		return handleError(fmt.Sprintf("failed to allow writes in the %s keyspace", ts.TargetKeyspaceName()), err)
 	}
 	if err := sw.changeRouting(ctx); err != nil {
+		ts.fence.fail(FenceStepSwitchWrites, err)
 		return handleError("failed to update the routing rules", err)
 	}
+	ts.fence.succeed(FenceStepSwitchWrites)
 	if err := sw.streamMigraterfinalize(ctx, ts, sourceWorkflows); err != nil {
 		return handleError("failed to finalize the traffic switch", err)
 	}
@@ -33,6 +33,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
@@ -1188,6 +1189,19 @@ func TestWorkflowDelete(t *testing.T) {
 	}
 }
 
+// yearlyMaintenanceWindows returns maintenance windows for the given keyspaces which
+// are only open for the first second of every year, so they are closed during tests.
+func yearlyMaintenanceWindows(keyspaces ...string) *topodatapb.MaintenanceWindows {
+	return &topodatapb.MaintenanceWindows{
+		Windows: []*topodatapb.MaintenanceWindow{{
+			Name:      "new-year",
+			Schedule:  "0 0 1 1 *",
+			Duration:  protoutil.DurationToProto(time.Second),
+			Keyspaces: keyspaces,
+		}},
+	}
+}
+
 func TestMoveTablesTrafficSwitching(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -1431,6 +1445,81 @@ func TestMoveTablesTrafficSwitching(t *testing.T) {
 				CurrentState: "All Reads Switched. Writes Switched",
 			},
 		},
+		{
+			name: "forward outside of a maintenance window",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"0"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:    targetKeyspaceName,
+				Workflow:    workflowName,
+				Direction:   int32(DirectionForward),
+				TabletTypes: allTabletTypes,
+			},
+			preFunc: func(env *testEnv) {
+				err := env.ts.SaveMaintenanceWindows(ctx, yearlyMaintenanceWindows(targetKeyspaceName))
+				require.NoError(t, err)
+			},
+			wantErr: true,
+		},
+		{
+			name: "forward outside of a maintenance window with ignore-maintenance-windows",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"0"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:                 targetKeyspaceName,
+				Workflow:                 workflowName,
+				Direction:                int32(DirectionForward),
+				TabletTypes:              allTabletTypes,
+				IgnoreMaintenanceWindows: true,
+			},
+			preFunc: func(env *testEnv) {
+				err := env.ts.SaveMaintenanceWindows(ctx, yearlyMaintenanceWindows(targetKeyspaceName))
+				require.NoError(t, err)
+			},
+			want: &vtctldatapb.WorkflowSwitchTrafficResponse{
+				Summary:      fmt.Sprintf("SwitchTraffic was successful for workflow %s.%s", targetKeyspaceName, workflowName),
+				StartState:   "Reads Not Switched. Writes Not Switched",
+				CurrentState: "All Reads Switched. Writes Switched",
+			},
+		},
+		{
+			name: "backward for read-only tablets outside of a maintenance window",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"0"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:    targetKeyspaceName,
+				Workflow:    workflowName,
+				Direction:   int32(DirectionBackward),
+				TabletTypes: roTabletTypes,
+			},
+			preFunc: func(env *testEnv) {
+				err := env.ts.SaveMaintenanceWindows(ctx, yearlyMaintenanceWindows(sourceKeyspaceName, targetKeyspaceName))
+				require.NoError(t, err)
+			},
+			want: &vtctldatapb.WorkflowSwitchTrafficResponse{
+				Summary:      fmt.Sprintf("ReverseTraffic was successful for workflow %s.%s", targetKeyspaceName, workflowName),
+				StartState:   "All Reads Switched. Writes Not Switched",
+				CurrentState: "Reads Not Switched. Writes Not Switched",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1702,6 +1791,51 @@ func TestMoveTablesTrafficSwitchingDryRun(t *testing.T) {
 				fmt.Sprintf("Unlock keyspace %s", sourceKeyspaceName),
 			},
 		},
+		{
+			name: "forward with consistency verification",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:              targetKeyspaceName,
+				Workflow:              workflowName,
+				Direction:             int32(DirectionForward),
+				TabletTypes:           allTabletTypes,
+				DryRun:                true,
+				VerifyConsistency:     true,
+				ConsistencySampleSize: 100,
+			},
+			want: []string{
+				fmt.Sprintf("Lock keyspace %s", sourceKeyspaceName),
+				fmt.Sprintf("Mirroring 0.00 percent of traffic from keyspace %s to keyspace %s for tablet types [REPLICA,RDONLY]", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Switch reads for tables [%s] to keyspace %s for tablet types [REPLICA,RDONLY]", tablesStr, targetKeyspaceName),
+				fmt.Sprintf("Routing rules for tables [%s] will be updated", tablesStr),
+				fmt.Sprintf("Unlock keyspace %s", sourceKeyspaceName),
+				fmt.Sprintf("Lock keyspace %s", sourceKeyspaceName),
+				fmt.Sprintf("Lock keyspace %s", targetKeyspaceName),
+				fmt.Sprintf("Mirroring 0.00 percent of traffic from keyspace %s to keyspace %s for tablet types [PRIMARY]", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Stop writes on keyspace %s for tables [%s]: [keyspace:%s;shard:-80;position:%s,keyspace:%s;shard:80-;position:%s]",
+					sourceKeyspaceName, tablesStr, sourceKeyspaceName, position, sourceKeyspaceName, position),
+				"Wait for vreplication on stopped streams to catchup for up to 30s",
+				"Verify that the stopped streams reached the positions of the source primaries",
+				"Compare the row counts and a sample of up to 100 rows of each table on the source and the target",
+				fmt.Sprintf("Create reverse vreplication workflow %s", ReverseWorkflowName(workflowName)),
+				"Create journal entries on source databases",
+				fmt.Sprintf("Enable writes on keyspace %s for tables [%s]", targetKeyspaceName, tablesStr),
+				fmt.Sprintf("Switch routing from keyspace %s to keyspace %s", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Routing rules for tables [%s] will be updated", tablesStr),
+				fmt.Sprintf("Switch writes completed, freeze and delete vreplication streams on: [tablet:%d,tablet:%d]", startingTargetTabletUID, startingTargetTabletUID+tabletUIDStep),
+				fmt.Sprintf("Mark vreplication streams frozen on: [keyspace:%s;shard:-80;tablet:%d;workflow:%s;dbname:vt_%s,keyspace:%s;shard:80-;tablet:%d;workflow:%s;dbname:vt_%s]",
+					targetKeyspaceName, startingTargetTabletUID, workflowName, targetKeyspaceName, targetKeyspaceName, startingTargetTabletUID+tabletUIDStep, workflowName, targetKeyspaceName),
+				fmt.Sprintf("Unlock keyspace %s", targetKeyspaceName),
+				fmt.Sprintf("Unlock keyspace %s", sourceKeyspaceName),
+			},
+		},
 		{
 			name: "basic backward",
 			sourceKeyspace: &testKeyspace{
@@ -2872,3 +3006,69 @@ func TestMaterializeAddTables(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowCutBack(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	tableName := "t1"
+	sourceKeyspace := &testKeyspace{
+		KeyspaceName: "sourceks",
+		ShardNames:   []string{"-80", "80-"},
+	}
+	targetKeyspace := &testKeyspace{
+		KeyspaceName: "targetks",
+		ShardNames:   []string{"-80", "80-"},
+	}
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		tableName: {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   tableName,
+					Schema: fmt.Sprintf("CREATE TABLE %s (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))", tableName),
+				},
+			},
+		},
+	}
+	copyTableQR := &queryResult{
+		query:  "select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name)",
+		result: &querypb.QueryResult{},
+	}
+
+	t.Run("writes not switched", func(t *testing.T) {
+		env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+		defer env.close()
+		env.tmc.schema = schema
+		_, err := env.ws.WorkflowCutBack(ctx, &vtctldatapb.WorkflowCutBackRequest{
+			Keyspace: targetKeyspace.KeyspaceName,
+			Workflow: workflowName,
+		})
+		require.ErrorContains(t, err, "its writes have not been switched")
+	})
+
+	t.Run("preflight checks", func(t *testing.T) {
+		env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+		defer env.close()
+		env.tmc.schema = schema
+		env.tmc.reverse.Store(true)
+		env.updateTableRoutingRules(t, ctx, allTabletTypes, []string{tableName},
+			sourceKeyspace.KeyspaceName, targetKeyspace.KeyspaceName, targetKeyspace.KeyspaceName)
+		ts, state, err := env.ws.getWorkflowState(ctx, targetKeyspace.KeyspaceName, workflowName)
+		require.NoError(t, err)
+		require.True(t, state.WritesSwitched)
+
+		env.tmc.expectVRQueryResultOnKeyspaceTablets(sourceKeyspace.KeyspaceName, copyTableQR)
+		checks, err := env.ws.checkReverseWorkflow(ctx, ts, 30)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			fmt.Sprintf("Reverse workflow %s.%s exists", sourceKeyspace.KeyspaceName, ReverseWorkflowName(workflowName)),
+			fmt.Sprintf("All 2 streams of the reverse workflow are running on the 2 shards of keyspace %s", sourceKeyspace.KeyspaceName),
+			"Replication lag of the reverse workflow is 0s, within the allowed 30s",
+		}, checks)
+
+		env.tmc.expectVRQueryResultOnKeyspaceTablets(sourceKeyspace.KeyspaceName, copyTableQR)
+		_, err = env.ws.checkReverseWorkflow(ctx, ts, -1)
+		require.ErrorContains(t, err, "replication lag 0s is higher than allowed lag -1s")
+	})
+}
@@ -64,11 +64,19 @@ func (r *switcher) removeSourceTables(ctx context.Context, removalType TableRemo
 	return r.ts.removeSourceTables(ctx, removalType)
 }
 
+func (r *switcher) removeSourceTenantRows(ctx context.Context, batchSize int64) error {
+	return r.ts.removeSourceTenantRows(ctx, batchSize)
+}
+
 func (r *switcher) dropSourceShards(ctx context.Context) error {
 	return r.ts.dropSourceShards(ctx)
 }
 
 func (r *switcher) switchKeyspaceReads(ctx context.Context, servedTypes []topodatapb.TabletType) error {
+	if r.ts.IsTenantMove() {
+		// The reads are switched with the writes, by the tenant routing rules.
+		return nil
+	}
 	if err := changeKeyspaceRouting(ctx, r.ts.TopoServer(), servedTypes,
 		r.ts.SourceKeyspaceName() /* from */, r.ts.TargetKeyspaceName() /* to */, "SwitchReads"); err != nil {
 		return err
@@ -116,6 +124,14 @@ func (r *switcher) waitForCatchup(ctx context.Context, filteredReplicationWaitTi
 	return r.ts.waitForCatchup(ctx, filteredReplicationWaitTime)
 }
 
+func (r *switcher) verifyTargetPositions(ctx context.Context) error {
+	return r.ts.verifyTargetPositions(ctx)
+}
+
+func (r *switcher) diffSample(ctx context.Context, sampleSize int64) error {
+	return r.ts.diffSample(ctx, sampleSize)
+}
+
 func (r *switcher) stopSourceWrites(ctx context.Context) error {
 	return r.ts.stopSourceWrites(ctx)
 }
@@ -58,7 +58,7 @@ func (dr *switcherDryRun) deleteShardRoutingRules(ctx context.Context) error {
 }
 
 func (dr *switcherDryRun) deleteKeyspaceRoutingRules(ctx context.Context) error {
-	if dr.ts.IsMultiTenantMigration() {
+	if dr.ts.IsMultiTenantMigration() && !dr.ts.IsTenantMove() {
 		dr.drLog.Log("Keyspace routing rules will be deleted")
 	}
 	return nil
@@ -76,6 +76,10 @@ func (dr *switcherDryRun) mirrorTableTraffic(ctx context.Context, types []topoda
 }
 
 func (dr *switcherDryRun) switchKeyspaceReads(ctx context.Context, types []topodatapb.TabletType) error {
+	if dr.ts.IsTenantMove() {
+		dr.drLog.Logf("Reads of tenant %s will be switched with its writes", dr.ts.options.TenantId)
+		return nil
+	}
 	var tabletTypes []string
 	for _, servedType := range types {
 		tabletTypes = append(tabletTypes, servedType.String())
@@ -144,6 +148,10 @@ func (dr *switcherDryRun) allowTargetWrites(ctx context.Context) error {
 func (dr *switcherDryRun) changeRouting(ctx context.Context) error {
 	dr.drLog.Logf("Switch routing from keyspace %s to keyspace %s", dr.ts.SourceKeyspaceName(), dr.ts.TargetKeyspaceName())
 	var deleteLogs, addLogs []string
+	if dr.ts.IsTenantMove() {
+		dr.drLog.Logf("Tenant routing rules for tenant %s will be updated", dr.ts.options.TenantId)
+		return nil
+	}
 	if dr.ts.MigrationType() == binlogdatapb.MigrationType_TABLES {
 		sort.Strings(dr.ts.Tables()) // For deterministic output
 		tables := strings.Join(dr.ts.Tables(), ",")
@@ -260,6 +268,16 @@ func (dr *switcherDryRun) waitForCatchup(ctx context.Context, filteredReplicatio
 	return nil
 }
 
+func (dr *switcherDryRun) verifyTargetPositions(ctx context.Context) error {
+	dr.drLog.Log("Verify that the stopped streams reached the positions of the source primaries")
+	return nil
+}
+
+func (dr *switcherDryRun) diffSample(ctx context.Context, sampleSize int64) error {
+	dr.drLog.Logf("Compare the row counts and a sample of up to %d rows of each table on the source and the target", sampleSize)
+	return nil
+}
+
 func (dr *switcherDryRun) stopSourceWrites(ctx context.Context) error {
 	logs := make([]string, 0)
 	sources := maps.Values(dr.ts.Sources())
@@ -313,6 +331,12 @@ func (dr *switcherDryRun) lockKeyspace(ctx context.Context, keyspace, _ string,
 	}, nil
 }
 
+func (dr *switcherDryRun) removeSourceTenantRows(ctx context.Context, batchSize int64) error {
+	dr.drLog.Logf("Deleting the rows of tenant %s from these tables of keyspace %s in batches of %d, once the tables in keyspace %s are verified to have as many rows of the tenant: [%s]",
+		dr.ts.options.TenantId, dr.ts.SourceKeyspaceName(), batchSize, dr.ts.TargetKeyspaceName(), strings.Join(dr.ts.Tables(), ","))
+	return nil
+}
+
 func (dr *switcherDryRun) removeSourceTables(ctx context.Context, removalType TableRemovalType) error {
 	logs := make([]string, 0)
 	sort.Strings(dr.ts.Tables()) // For deterministic output
@@ -31,6 +31,8 @@ type iswitcher interface {
 	stopStreams(ctx context.Context, sm *StreamMigrator) ([]string, error)
 	stopSourceWrites(ctx context.Context) error
 	waitForCatchup(ctx context.Context, filteredReplicationWaitTime time.Duration) error
+	verifyTargetPositions(ctx context.Context) error
+	diffSample(ctx context.Context, sampleSize int64) error
 	migrateStreams(ctx context.Context, sm *StreamMigrator) error
 	createReverseVReplication(ctx context.Context) error
 	createJournals(ctx context.Context, sourceWorkflows []string) error
@@ -44,6 +46,7 @@ type iswitcher interface {
 	switchShardReads(ctx context.Context, cells []string, servedType []topodatapb.TabletType, direction TrafficSwitchDirection) error
 	validateWorkflowHasCompleted(ctx context.Context) error
 	removeSourceTables(ctx context.Context, removalType TableRemovalType) error
+	removeSourceTenantRows(ctx context.Context, batchSize int64) error
 	dropSourceShards(ctx context.Context) error
 	dropSourceDeniedTables(ctx context.Context) error
 	dropTargetDeniedTables(ctx context.Context) error
@@ -228,6 +228,9 @@ type trafficSwitcher struct {
 	// Should we continue if we encounter some potentially non-fatal errors such
 	// as partial tablet refreshes?
 	force bool
+	// The consistency fence of the writes switch, if its consistency is
+	// verified.
+	fence *consistencyFence
 	// If frozen is true, the rest of the fields are not set.
 	frozen           bool
 	reverseWorkflow  string
@@ -452,7 +455,7 @@ func (ts *trafficSwitcher) deleteShardRoutingRules(ctx context.Context) error {
 }
 
 func (ts *trafficSwitcher) deleteKeyspaceRoutingRules(ctx context.Context) error {
-	if !ts.IsMultiTenantMigration() {
+	if !ts.IsMultiTenantMigration() || ts.IsTenantMove() {
 		return nil
 	}
 	ts.Logger().Infof("deleteKeyspaceRoutingRules: workflow %s.%s", ts.targetKeyspace, ts.workflow)
@@ -588,6 +591,78 @@ func (ts *trafficSwitcher) removeSourceTables(ctx context.Context, removalType T
 	return ts.dropParticipatingTablesFromKeyspace(ctx, ts.SourceKeyspaceName())
 }
 
+// removeSourceTenantRows deletes the rows of the tenant of a tenant move from the source
+// tables, in batches of the given size. It first verifies that the target tables have at
+// least as many rows of the tenant as the source tables.
+func (ts *trafficSwitcher) removeSourceTenantRows(ctx context.Context, batchSize int64) error {
+	if len(ts.tables) == 0 { // Nothing to delete
+		return nil
+	}
+	parser := ts.ws.env.Parser()
+	sourcePredicate, err := getTenantClause(ts.options, ts.sourceKSSchema, parser)
+	if err != nil {
+		return vterrors.Wrap(err, "failed to build source filter")
+	}
+	targetPredicate, err := ts.buildTenantPredicate(ctx)
+	if err != nil {
+		return vterrors.Wrap(err, "failed to build target filter")
+	}
+
+	var (
+		mu           sync.Mutex
+		sourceCounts = make(map[string]int64, len(ts.tables))
+		targetCounts = make(map[string]int64, len(ts.tables))
+	)
+	countRows := func(tablet *topo.TabletInfo, predicate sqlparser.Expr, counts map[string]int64) error {
+		for _, table := range ts.tables {
+			tableName, err := sqlescape.EnsureEscaped(table)
+			if err != nil {
+				return err
+			}
+			count, err := ts.countRows(ctx, tablet, fmt.Sprintf("select count(*) from %s where %s", tableName, sqlparser.String(predicate)))
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			counts[table] += count
+			mu.Unlock()
+		}
+		return nil
+	}
+	if err := ts.ForAllSources(func(source *MigrationSource) error {
+		return countRows(source.GetPrimary(), *sourcePredicate, sourceCounts)
+	}); err != nil {
+		return err
+	}
+	if err := ts.ForAllTargets(func(target *MigrationTarget) error {
+		return countRows(target.GetPrimary(), *targetPredicate, targetCounts)
+	}); err != nil {
+		return err
+	}
+	for _, table := range ts.tables {
+		if targetCounts[table] < sourceCounts[table] {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+				"cannot delete the rows of tenant %s from the source: the %s table has %d rows of the tenant in the %s keyspace but only %d in the %s keyspace",
+				ts.options.TenantId, table, sourceCounts[table], ts.SourceKeyspaceName(), targetCounts[table], ts.TargetKeyspaceName())
+		}
+	}
+
+	deleteFilter := sqlparser.String(&sqlparser.Where{Expr: *sourcePredicate})
+	tableFilters := make(map[string]string, len(ts.tables))
+	for _, table := range ts.tables {
+		tableFilters[table] = deleteFilter
+	}
+	return ts.ForAllSources(func(source *MigrationSource) error {
+		ts.Logger().Infof("%s: Deleting the rows of tenant %s from tables %s",
+			topoproto.TabletAliasString(source.GetPrimary().GetAlias()), ts.options.TenantId, strings.Join(ts.tables, ","))
+		_, err := ts.ws.tmc.DeleteTableData(ctx, source.GetPrimary().Tablet, &tabletmanagerdatapb.DeleteTableDataRequest{
+			TableFilters: tableFilters,
+			BatchSize:    batchSize,
+		})
+		return err
+	})
+}
+
 // FIXME: even after dropSourceShards there are still entries in the topo, need to research and fix
 func (ts *trafficSwitcher) dropSourceShards(ctx context.Context) error {
 	return ts.ForAllSources(func(source *MigrationSource) error {
@@ -763,9 +838,19 @@ func (ts *trafficSwitcher) changeRouting(ctx context.Context) error {
 }
 
 func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
+	if ts.IsTenantMove() {
+		// The other tenants of the source keyspace keep using it, so only the traffic of the
+		// tenant is switched, for all the tablet types at once.
+		ts.Logger().Infof("Pointing tenant routing rules for tenant %s to %s for workflow %s", ts.options.TenantId, ts.TargetKeyspaceName(), ts.workflow)
+		return switchTenantRouting(ctx, ts.TopoServer(), ts.options.TenantId, ts.SourceKeyspaceName(), ts.TargetKeyspaceName())
+	}
 	if ts.IsMultiTenantMigration() {
 		// For multi-tenant migrations, we can only move forward and not backwards.
 		ts.Logger().Infof("Pointing keyspace routing rules for primary to %s for workflow %s", ts.TargetKeyspaceName(), ts.workflow)
+		// The tenant routing rules of the tenant are rebuilt into the SrvVSchema with the keyspace routing rules.
+		if err := moveTenantRouting(ctx, ts.TopoServer(), ts.options.TenantId, ts.SourceKeyspaceName(), ts.TargetKeyspaceName()); err != nil {
+			return err
+		}
 		if err := changeKeyspaceRouting(ctx, ts.TopoServer(), []topodatapb.TabletType{topodatapb.TabletType_PRIMARY},
 			ts.SourceKeyspaceName() /* from */, ts.TargetKeyspaceName() /* to */, "SwitchWrites"); err != nil {
 			return err
@@ -1545,6 +1630,22 @@ func (ts *trafficSwitcher) IsMultiTenantMigration() bool {
 	return false
 }
 
+// IsTenantMove returns true for the multi-tenant migrations of a tenant out of a source
+// keyspace which is itself multi-tenant. The traffic of such a migration is switched with
+// the tenant routing rules of the tenant, and only its rows are removed from the source.
+func (ts *trafficSwitcher) IsTenantMove() bool {
+	return ts.IsMultiTenantMigration() && ts.sourceKSSchema != nil && ts.sourceKSSchema.MultiTenantSpec != nil
+}
+
+// updateMultiTenantState deduces which traffic of a multi-tenant migration has been
+// switched from the routing rules it uses.
+func (ts *trafficSwitcher) updateMultiTenantState(ctx context.Context, sourceKeyspace, targetKeyspace string, state *State) error {
+	if ts.IsTenantMove() {
+		return updateTenantRoutingState(ctx, ts.TopoServer(), ts.options.TenantId, sourceKeyspace, targetKeyspace, state)
+	}
+	return updateKeyspaceRoutingState(ctx, ts.TopoServer(), sourceKeyspace, targetKeyspace, state)
+}
+
 func (ts *trafficSwitcher) mirrorTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
 	mrs, err := topotools.GetMirrorRules(ctx, ts.TopoServer())
 	if err != nil {
@@ -50,6 +50,7 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
@@ -873,6 +874,60 @@ func changeKeyspaceRouting(ctx context.Context, ts *topo.Server, tabletTypes []t
 	return ts.RebuildSrvVSchema(ctx, nil)
 }
 
+// moveTenantRouting points the tenant routing rules which route the given tenant to
+// the source keyspace of its multi-tenant migration to the target keyspace instead.
+// It does not rebuild the SrvVSchema.
+func moveTenantRouting(ctx context.Context, ts *topo.Server, tenantID, sourceKeyspace, targetKeyspace string) error {
+	rules, err := ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	moved := false
+	for _, rule := range rules.GetRules() {
+		if rule.TenantId == tenantID && rule.ToKeyspace == sourceKeyspace {
+			rule.ToKeyspace = targetKeyspace
+			moved = true
+		}
+	}
+	if !moved {
+		return nil
+	}
+	return ts.SaveTenantRoutingRules(ctx, rules)
+}
+
+// switchTenantRouting routes the queries of the given tenant targeting the source keyspace
+// of its tenant move to the target keyspace, and points its tenant routing rules routing it
+// to the source keyspace to the target keyspace as well. It then rebuilds the SrvVSchema.
+func switchTenantRouting(ctx context.Context, ts *topo.Server, tenantID, sourceKeyspace, targetKeyspace string) error {
+	rules, err := ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, rule := range rules.GetRules() {
+		if rule.TenantId != tenantID {
+			continue
+		}
+		if rule.Keyspace == sourceKeyspace {
+			found = true
+		}
+		if rule.Keyspace == sourceKeyspace || rule.ToKeyspace == sourceKeyspace {
+			rule.ToKeyspace = targetKeyspace
+		}
+	}
+	if !found {
+		rules.Rules = append(rules.Rules, &vschemapb.TenantRoutingRule{
+			Keyspace:   sourceKeyspace,
+			TenantId:   tenantID,
+			ToKeyspace: targetKeyspace,
+		})
+	}
+	if err := ts.SaveTenantRoutingRules(ctx, rules); err != nil {
+		return err
+	}
+	return ts.RebuildSrvVSchema(ctx, nil)
+}
+
 // updateKeyspaceRoutingRules updates the keyspace routing rules for the (effective) source
 // keyspace to the target keyspace.
 func updateKeyspaceRoutingRules(ctx context.Context, ts *topo.Server, reason string, routes map[string]string) error {
@@ -948,6 +1003,35 @@ func updateKeyspaceRoutingState(ctx context.Context, ts *topo.Server, sourceKeys
 	return nil
 }
 
+// updateTenantRoutingState updates the state of a tenant move from the tenant routing rules.
+// All the traffic of the tenant is switched at once.
+func updateTenantRoutingState(ctx context.Context, ts *topo.Server, tenantID, sourceKeyspace, targetKeyspace string, state *State) error {
+	cells, err := ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return err
+	}
+	rules, err := ts.GetTenantRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	switched := false
+	for _, rule := range rules.GetRules() {
+		if rule.Keyspace == sourceKeyspace && rule.TenantId == tenantID {
+			switched = rule.ToKeyspace == targetKeyspace
+			break
+		}
+	}
+	if switched {
+		state.RdonlyCellsSwitched, state.RdonlyCellsNotSwitched = cells, nil
+		state.ReplicaCellsSwitched, state.ReplicaCellsNotSwitched = cells, nil
+	} else {
+		state.RdonlyCellsSwitched, state.RdonlyCellsNotSwitched = nil, cells
+		state.ReplicaCellsSwitched, state.ReplicaCellsNotSwitched = nil, cells
+	}
+	state.WritesSwitched = switched
+	return nil
+}
+
 func getTabletTypeSuffix(tabletType topodatapb.TabletType) string {
 	switch tabletType {
 	case topodatapb.TabletType_REPLICA:
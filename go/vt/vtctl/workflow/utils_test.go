@@ -16,6 +16,7 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/testfiles"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/topo"
@@ -24,6 +25,7 @@ import (
 	"vitess.io/vitess/go/vt/topotools"
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	"vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -116,6 +118,81 @@ func TestUpdateKeyspaceRoutingRule(t *testing.T) {
 	require.EqualValues(t, routes, rules)
 }
 
+// TestMoveTenantRouting confirms that only the tenant routing rules of the moved tenant
+// pointing to the source keyspace are repointed to the target keyspace.
+func TestMoveTenantRouting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+	err := ts.SaveTenantRoutingRules(ctx, &vschemapb.TenantRoutingRules{
+		Rules: []*vschemapb.TenantRoutingRule{
+			{Keyspace: "customers", TenantId: "42", ToKeyspace: "customers"},
+			{Keyspace: "customers", TenantId: "43", ToKeyspace: "customers"},
+		},
+	})
+	require.NoError(t, err)
+
+	err = moveTenantRouting(ctx, ts, "42", "customers", "customers_42")
+	require.NoError(t, err)
+	rules, err := ts.GetTenantRoutingRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 2)
+	require.Equal(t, "customers_42", rules.Rules[0].ToKeyspace)
+	require.Equal(t, "customers", rules.Rules[1].ToKeyspace)
+
+	// A tenant without a rule is left alone.
+	err = moveTenantRouting(ctx, ts, "44", "customers", "customers_44")
+	require.NoError(t, err)
+	rules, err = ts.GetTenantRoutingRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 2)
+}
+
+// TestSwitchTenantRouting confirms that switching the traffic of a tenant move adds, or
+// repoints, the tenant routing rules of the tenant only, and that the workflow state is
+// deduced from them.
+func TestSwitchTenantRouting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+	defer ts.Close()
+	err := ts.SaveTenantRoutingRules(ctx, &vschemapb.TenantRoutingRules{
+		Rules: []*vschemapb.TenantRoutingRule{
+			{Keyspace: "legacy", TenantId: "42", ToKeyspace: "shared"},
+			{Keyspace: "shared", TenantId: "43", ToKeyspace: "shared"},
+		},
+	})
+	require.NoError(t, err)
+
+	state := &State{}
+	err = updateTenantRoutingState(ctx, ts, "42", "shared", "dedicated", state)
+	require.NoError(t, err)
+	require.False(t, state.WritesSwitched)
+	require.ElementsMatch(t, []string{"zone1", "zone2"}, state.ReplicaCellsNotSwitched)
+
+	err = switchTenantRouting(ctx, ts, "42", "shared", "dedicated")
+	require.NoError(t, err)
+	rules, err := ts.GetTenantRoutingRules(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, []*vschemapb.TenantRoutingRule{
+		{Keyspace: "legacy", TenantId: "42", ToKeyspace: "dedicated"},
+		{Keyspace: "shared", TenantId: "43", ToKeyspace: "shared"},
+		{Keyspace: "shared", TenantId: "42", ToKeyspace: "dedicated"},
+	}, rules.Rules)
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, srvVSchema.TenantRoutingRules)
+
+	state = &State{}
+	err = updateTenantRoutingState(ctx, ts, "42", "shared", "dedicated", state)
+	require.NoError(t, err)
+	require.True(t, state.WritesSwitched)
+	require.ElementsMatch(t, []string{"zone1", "zone2"}, state.ReplicaCellsSwitched)
+	require.ElementsMatch(t, []string{"zone1", "zone2"}, state.RdonlyCellsSwitched)
+	require.Empty(t, state.RdonlyCellsNotSwitched)
+}
+
 // TestConcurrentKeyspaceRoutingRulesUpdates runs multiple keyspace routing rules updates concurrently to test
 // the locking mechanism.
 func TestConcurrentKeyspaceRoutingRulesUpdates(t *testing.T) {
@@ -60,6 +60,7 @@ var (
 	VT03031 = errorWithoutState("VT03031", vtrpcpb.Code_INVALID_ARGUMENT, "EXPLAIN is only supported for single keyspace", "EXPLAIN has to be sent down as a single query to the underlying MySQL, and this is not possible if it uses tables from multiple keyspaces")
 	VT03032 = errorWithState("VT03032", vtrpcpb.Code_INVALID_ARGUMENT, NonUpdateableTable, "the target table %s of the UPDATE is not updatable", "You cannot update a table that is not a real MySQL table.")
 	VT03033 = errorWithState("VT03033", vtrpcpb.Code_INVALID_ARGUMENT, ViewWrongList, "In definition of view, derived table or common table expression, SELECT list and column names list have different column counts", "The table column list and derived column list have different column counts.")
+	VT03034 = errorWithState("VT03034", vtrpcpb.Code_INVALID_ARGUMENT, FieldSpecifiedTwice, "Column '%s' specified twice", "The column is specified more than once in the column list of the INSERT.")
 
 	VT05001 = errorWithState("VT05001", vtrpcpb.Code_NOT_FOUND, DbDropExists, "cannot drop database '%s'; database does not exists", "The given database does not exist; Vitess cannot drop it.")
 	VT05002 = errorWithState("VT05002", vtrpcpb.Code_NOT_FOUND, BadDb, "cannot alter database '%s'; unknown database", "The given database does not exist; Vitess cannot alter it.")
@@ -105,6 +106,8 @@ var (
 	VT09030 = errorWithState("VT09030", vtrpcpb.Code_FAILED_PRECONDITION, CTEMaxRecursionDepth, "Recursive query aborted after 1000 iterations.", "")
 	VT09031 = errorWithoutState("VT09031", vtrpcpb.Code_FAILED_PRECONDITION, "Primary demotion is stalled", "")
 	VT09032 = errorWithoutState("VT09032", vtrpcpb.Code_FAILED_PRECONDITION, "previous transaction failed. Issue a ROLLBACK to resolve the failure.", "This error occurs after a VT15001 error was sent to the client. Later queries in the same session will continue to fail until the client sends a ROLLBACK.")
+	VT09033 = errorWithState("VT09033", vtrpcpb.Code_FAILED_PRECONDITION, CantDoThisInTransaction, "DDL statements are not allowed in a transaction when ddl_in_transaction is 'error'. Issue a COMMIT or a ROLLBACK first.", "This error occurs when a DDL statement is executed while the session has an open transaction, and the ddl_in_transaction of the session is set to 'error'. The transaction is left open.")
+	VT09034 = errorWithoutState("VT09034", vtrpcpb.Code_FAILED_PRECONDITION, "writes to shard %s are fenced: %s", "This error occurs when a write targets a shard whose writes were fenced with SetShardWriteFence, for example during an emergency maintenance. The writes are accepted again once the fence is removed.")
 
 	VT10001 = errorWithoutState("VT10001", vtrpcpb.Code_ABORTED, "foreign key constraints are not allowed", "Foreign key constraints are not allowed, see https://vitess.io/blog/2021-06-15-online-ddl-why-no-fk/.")
 	VT10002 = errorWithoutState("VT10002", vtrpcpb.Code_ABORTED, "atomic distributed transaction not allowed: %s", "The distributed transaction cannot be committed. A rollback decision is taken.")
@@ -160,6 +163,7 @@ var (
 		VT03031,
 		VT03032,
 		VT03033,
+		VT03034,
 		VT05001,
 		VT05002,
 		VT05003,
@@ -200,6 +204,8 @@ var (
 		VT09030,
 		VT09031,
 		VT09032,
+		VT09033,
+		VT09034,
 		VT10001,
 		VT10002,
 		VT12001,
@@ -50,6 +50,7 @@ const (
 	BadNullError
 	InvalidGroupFuncUse
 	ViewWrongList
+	FieldSpecifiedTwice
 
 	// failed precondition
 	NoDB
@@ -127,6 +128,9 @@ const (
 
 	VectorConversion
 
+	// load data local infile
+	ClientLocalFilesDisabled
+
 	// No state should be added below NumOfStates
 	NumOfStates
 )
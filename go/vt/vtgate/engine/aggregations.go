@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"vitess.io/vitess/go/hll"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
@@ -177,6 +178,31 @@ func (a *aggregatorCountStar) reset() {
 	a.n = 0
 }
 
+// aggregatorHLL estimates the number of distinct values by merging the
+// HyperLogLog sketches of the values of each shard.
+type aggregatorHLL struct {
+	from   int
+	sketch hll.Sketch
+}
+
+func (a *aggregatorHLL) add(row []sqltypes.Value) error {
+	if row[a.from].IsNull() {
+		return nil
+	}
+	if err := a.sketch.MergeBinary(row[a.from].Raw()); err != nil {
+		return vterrors.Wrapf(err, "failed to merge the approximate count of distinct values")
+	}
+	return nil
+}
+
+func (a *aggregatorHLL) finish() sqltypes.Value {
+	return sqltypes.NewInt64(int64(a.sketch.Estimate()))
+}
+
+func (a *aggregatorHLL) reset() {
+	a.sketch.Reset()
+}
+
 type aggregatorMinMax struct {
 	from   int
 	minmax evalengine.MinMax
@@ -393,6 +419,9 @@ func newAggregation(fields []*querypb.Field, aggregates []*AggregateParams) (agg
 				},
 			}
 
+		case opcode.AggregateCountDistinctApprox:
+			ag = &aggregatorHLL{from: aggr.Col}
+
 		case opcode.AggregateSum, opcode.AggregateSumDistinct:
 			var sum evalengine.Sum
 			switch aggr.OrigOpcode {
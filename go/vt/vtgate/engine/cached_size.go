@@ -75,6 +75,32 @@ func (cached *CheckCol) CachedSize(alloc bool) int64 {
 	size += cached.CollationEnv.CachedSize(true)
 	return size
 }
+func (cached *ChecksumTable) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Tables []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Tables)) * int64(16))
+		for _, elem := range cached.Tables {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	// field Sources []vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Sources)) * int64(16))
+		for _, elem := range cached.Sources {
+			if cc, ok := elem.(cachedObject); ok {
+				size += cc.CachedSize(true)
+			}
+		}
+	}
+	return size
+}
 
 //go:nocheckptr
 func (cached *Concatenate) CachedSize(alloc bool) int64 {
@@ -612,6 +638,42 @@ func (cached *Limit) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Load) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(112)
+	}
+	// field FileName string
+	size += hack.RuntimeAllocSize(int64(len(cached.FileName)))
+	// field Insert *vitess.io/vitess/go/vt/sqlparser.Insert
+	size += cached.Insert.CachedSize(true)
+	// field Format vitess.io/vitess/go/vt/vtgate/engine.LoadFormat
+	size += cached.Format.CachedSize(false)
+	return size
+}
+func (cached *LoadFormat) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field FieldsTerminatedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.FieldsTerminatedBy)))
+	// field FieldsEnclosedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.FieldsEnclosedBy)))
+	// field FieldsEscapedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.FieldsEscapedBy)))
+	// field LinesStartingBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.LinesStartingBy)))
+	// field LinesTerminatedBy string
+	size += hack.RuntimeAllocSize(int64(len(cached.LinesTerminatedBy)))
+	return size
+}
 func (cached *Lock) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -722,6 +784,30 @@ func (cached *MergeSort) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *NextID) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Sequence vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Sequence.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Count vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Count.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Variant vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	if cc, ok := cached.Variant.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Column string
+	size += hack.RuntimeAllocSize(int64(len(cached.Column)))
+	return size
+}
 func (cached *NonLiteralUpdateInfo) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -961,6 +1047,28 @@ func (cached *RevertMigration) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Rollup) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field Table string
+	size += hack.RuntimeAllocSize(int64(len(cached.Table)))
+	// field Keyspace string
+	size += hack.RuntimeAllocSize(int64(len(cached.Keyspace)))
+	// field Rollup vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Rollup.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Base vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Base.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *Route) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1308,6 +1416,32 @@ func (cached *SysVarSetAware) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *TableMaintenance) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Keyspace *vitess.io/vitess/go/vt/vtgate/vindexes.Keyspace
+	size += cached.Keyspace.CachedSize(true)
+	// field TargetDestination vitess.io/vitess/go/vt/key.ShardDestination
+	if cc, ok := cached.TargetDestination.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Statement vitess.io/vitess/go/vt/sqlparser.Statement
+	if cc, ok := cached.Statement.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Direct *vitess.io/vitess/go/vt/vtgate/engine.Send
+	size += cached.Direct.CachedSize(true)
+	// field Config vitess.io/vitess/go/vt/vtgate/dynamicconfig.DDL
+	if cc, ok := cached.Config.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
 func (cached *ThrottleApp) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
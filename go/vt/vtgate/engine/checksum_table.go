@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*ChecksumTable)(nil)
+
+// ChecksumTable is the primitive for the CHECKSUM TABLE statement.
+// Every source sends the CHECKSUM TABLE of one table to all the shards
+// holding it, and the checksums of the shards are combined into the
+// checksum of the whole table.
+//
+// MySQL computes the checksum of a table as the sum, modulo 2^32, of the
+// checksums of its rows. That sum depends neither on the order nor on the
+// location of the rows, so adding up the checksums of the shards modulo 2^32
+// gives the checksum MySQL would report for all the rows of the table in a
+// single database, provided the shards share the table definition.
+// The checksum is NULL when any shard reports NULL for it.
+type ChecksumTable struct {
+	noTxNeeded
+
+	// Tables are the names reported in the Table column, one for each source.
+	Tables []string
+	// Sources send the CHECKSUM TABLE of each table to its shards.
+	Sources []Primitive
+}
+
+// TryExecute implements the Primitive interface
+func (c *ChecksumTable) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	result := &sqltypes.Result{Fields: c.getFields()}
+	for i, source := range c.Sources {
+		qr, err := vcursor.ExecutePrimitive(ctx, source, bindVars, false)
+		if err != nil {
+			return nil, err
+		}
+		checksum, err := combineChecksums(qr)
+		if err != nil {
+			return nil, err
+		}
+		result.Rows = append(result.Rows, sqltypes.Row{sqltypes.NewVarChar(c.Tables[i]), checksum})
+	}
+	return result, nil
+}
+
+// combineChecksums adds up the checksums reported by the shards modulo 2^32.
+func combineChecksums(qr *sqltypes.Result) (sqltypes.Value, error) {
+	var checksum uint32
+	for _, row := range qr.Rows {
+		if len(row) < 2 || row[1].IsNull() {
+			return sqltypes.NULL, nil
+		}
+		shardChecksum, err := row[1].ToUint64()
+		if err != nil {
+			return sqltypes.NULL, err
+		}
+		checksum += uint32(shardChecksum)
+	}
+	return sqltypes.NewInt64(int64(checksum)), nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (c *ChecksumTable) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	qr, err := c.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(qr)
+}
+
+// GetFields implements the Primitive interface
+func (c *ChecksumTable) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: c.getFields()}, nil
+}
+
+func (c *ChecksumTable) getFields() []*querypb.Field {
+	return []*querypb.Field{
+		{
+			Name: "Table",
+			Type: sqltypes.VarChar,
+		},
+		{
+			Name: "Checksum",
+			Type: sqltypes.Int64,
+		},
+	}
+}
+
+// Inputs implements the Primitive interface
+func (c *ChecksumTable) Inputs() ([]Primitive, []map[string]any) {
+	return c.Sources, nil
+}
+
+func (c *ChecksumTable) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "ChecksumTable",
+		Other: map[string]any{
+			"Tables": c.Tables,
+		},
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestChecksumTable(t *testing.T) {
+	fields := sqltypes.MakeTestFields("Table|Checksum", "varchar|int64")
+	checksumTable := &ChecksumTable{
+		Tables: []string{"ks.t1", "ks.t2", "ks.t3"},
+		Sources: []Primitive{
+			// the checksums of the shards add up modulo 2^32.
+			&fakePrimitive{results: []*sqltypes.Result{
+				sqltypes.MakeTestResult(fields, "vt_ks.t1|4294967295", "vt_ks.t1|3", "vt_ks.t1|1000"),
+			}},
+			// a NULL checksum on any shard makes the checksum NULL.
+			&fakePrimitive{results: []*sqltypes.Result{
+				sqltypes.MakeTestResult(fields, "vt_ks.t2|5", "vt_ks.t2|null"),
+			}},
+			// an empty table has a checksum of 0.
+			&fakePrimitive{results: []*sqltypes.Result{
+				sqltypes.MakeTestResult(fields, "vt_ks.t3|0", "vt_ks.t3|0"),
+			}},
+		},
+	}
+
+	want := sqltypes.MakeTestResult(fields, "ks.t1|1002", "ks.t2|null", "ks.t3|0")
+	qr, err := checksumTable.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResult(t, qr, want)
+
+	for _, source := range checksumTable.Sources {
+		source.(*fakePrimitive).rewind()
+	}
+	qr, err = wrapStreamExecute(checksumTable, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	expectResult(t, qr, want)
+}
+
+func TestChecksumTableError(t *testing.T) {
+	checksumTable := &ChecksumTable{
+		Tables: []string{"ks.t1"},
+		Sources: []Primitive{
+			&fakePrimitive{sendErr: errors.New("shard unavailable")},
+		},
+	}
+
+	_, err := checksumTable.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.EqualError(t, err, "shard unavailable")
+}
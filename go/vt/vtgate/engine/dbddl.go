@@ -55,6 +55,14 @@ type DBDDLPlugin interface {
 	DropDatabase(ctx context.Context, name string) error
 }
 
+// DBDDLSnapshotPlugin is the interface that a DBDDLPlugin implements to handle
+// CREATE DATABASE ... BASE_KEYSPACE = ... RECOVERY_TIME = '...', that creates
+// a database as a read-only snapshot of the base database, restored to the
+// recovery time.
+type DBDDLSnapshotPlugin interface {
+	CreateSnapshotDatabase(ctx context.Context, name string, baseName string, recoveryTime time.Time) error
+}
+
 const dbDDLDefaultTimeout = 500 * time.Millisecond
 
 // DBDDL is just a container around custom database provisioning plugins
@@ -66,6 +74,10 @@ type DBDDL struct {
 	name         string
 	create       bool
 	queryTimeout int
+
+	// baseKeyspace and recoveryTime are set when creating a snapshot database.
+	baseKeyspace string
+	recoveryTime time.Time
 }
 
 // NewDBDDL creates the engine primitive
@@ -78,6 +90,18 @@ func NewDBDDL(dbName string, create bool, timeout int) *DBDDL {
 	}
 }
 
+// NewSnapshotDBDDL creates the engine primitive creating the database dbName as
+// a snapshot of baseKeyspace, restored to recoveryTime.
+func NewSnapshotDBDDL(dbName string, baseKeyspace string, recoveryTime time.Time, timeout int) *DBDDL {
+	return &DBDDL{
+		name:         dbName,
+		create:       true,
+		queryTimeout: timeout,
+		baseKeyspace: baseKeyspace,
+		recoveryTime: recoveryTime,
+	}
+}
+
 func (c *DBDDL) routeType() string {
 	if c.create {
 		return "CreateDB"
@@ -87,6 +111,10 @@ func (c *DBDDL) routeType() string {
 
 // TryExecute implements the Primitive interface
 func (c *DBDDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if err := commitBeforeDDL(ctx, vcursor); err != nil {
+		return nil, err
+	}
+
 	name := vcursor.GetDBDDLPluginName()
 	plugin, ok := databaseCreatorPlugins[name]
 	if !ok {
@@ -100,6 +128,9 @@ func (c *DBDDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 		defer cancel()
 	}
 
+	if c.create && c.baseKeyspace != "" {
+		return c.createSnapshotDatabase(ctx, vcursor, name, plugin)
+	}
 	if c.create {
 		return c.createDatabase(ctx, vcursor, plugin)
 	}
@@ -155,6 +186,28 @@ func (c *DBDDL) createDatabase(ctx context.Context, vcursor VCursor, plugin DBDD
 	return &sqltypes.Result{RowsAffected: 1}, nil
 }
 
+func (c *DBDDL) createSnapshotDatabase(ctx context.Context, vcursor VCursor, name string, plugin DBDDLPlugin) (*sqltypes.Result, error) {
+	snapshotPlugin, ok := plugin.(DBDDLSnapshotPlugin)
+	if !ok {
+		return nil, vterrors.VT12001(fmt.Sprintf("create database with recovery_time by %s dbddl plugin", name))
+	}
+	err := snapshotPlugin.CreateSnapshotDatabase(ctx, c.name, c.baseKeyspace, c.recoveryTime)
+	if err != nil {
+		return nil, err
+	}
+	// The tablets of a snapshot database restore their backups in the
+	// background, and only serve replica and rdonly traffic: it is ready to be
+	// queried once it is in the vschema.
+	for !vcursor.KeyspaceAvailable(c.name) {
+		select {
+		case <-ctx.Done(): // context cancelled
+			return nil, vterrors.Errorf(vtrpc.Code_DEADLINE_EXCEEDED, "could not validate create database: keyspace not available in vschema")
+		case <-time.After(dbDDLDefaultTimeout): // timeout
+		}
+	}
+	return &sqltypes.Result{RowsAffected: 1}, nil
+}
+
 func (c *DBDDL) dropDatabase(ctx context.Context, vcursor VCursor, plugin DBDDLPlugin) (*sqltypes.Result, error) {
 	err := plugin.DropDatabase(ctx, c.name)
 	if err != nil {
@@ -187,8 +240,16 @@ func (c *DBDDL) GetFields(context.Context, VCursor, map[string]*querypb.BindVari
 
 // description implements the Primitive interface
 func (c *DBDDL) description() PrimitiveDescription {
+	var other map[string]any
+	if c.baseKeyspace != "" {
+		other = map[string]any{
+			"BaseKeyspace": c.baseKeyspace,
+			"RecoveryTime": c.recoveryTime.UTC().Format(time.RFC3339),
+		}
+	}
 	return PrimitiveDescription{
 		OperatorType: strings.ToUpper(c.routeType()),
 		Keyspace:     &vindexes.Keyspace{Name: c.name},
+		Other:        other,
 	}
 }
@@ -18,6 +18,7 @@ package engine
 
 import (
 	"context"
+	"time"
 
 	"vitess.io/vitess/go/vt/vterrors"
 )
@@ -46,6 +47,11 @@ func (noOp) DropDatabase(context.Context, string) error {
 	return nil
 }
 
+// CreateSnapshotDatabase implements the DBDDLSnapshotPlugin interface
+func (noOp) CreateSnapshotDatabase(context.Context, string, string, time.Time) error {
+	return nil
+}
+
 const (
 	faildbDDL          = "fail"
 	noOpdbDDL          = "noop"
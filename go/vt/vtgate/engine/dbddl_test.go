@@ -14,6 +14,9 @@ import (
 type dbddlTestFake struct {
 	createCalled, dropCalled bool
 	sleep                    int
+
+	snapshotBase         string
+	snapshotRecoveryTime time.Time
 }
 
 func (d *dbddlTestFake) CreateDatabase(ctx context.Context, name string) error {
@@ -32,7 +35,15 @@ func (d *dbddlTestFake) DropDatabase(ctx context.Context, name string) error {
 	return nil
 }
 
+func (d *dbddlTestFake) CreateSnapshotDatabase(ctx context.Context, name string, baseName string, recoveryTime time.Time) error {
+	d.createCalled = true
+	d.snapshotBase = baseName
+	d.snapshotRecoveryTime = recoveryTime
+	return nil
+}
+
 var _ DBDDLPlugin = (*dbddlTestFake)(nil)
+var _ DBDDLSnapshotPlugin = (*dbddlTestFake)(nil)
 
 func TestDBDDLCreateExecute(t *testing.T) {
 	pluginName := "createFake"
@@ -52,6 +63,30 @@ func TestDBDDLCreateExecute(t *testing.T) {
 	require.False(t, plugin.dropCalled)
 }
 
+func TestDBDDLCreateSnapshotExecute(t *testing.T) {
+	pluginName := "createSnapshotFake"
+	plugin := &dbddlTestFake{}
+	DBDDLRegister(pluginName, plugin)
+
+	recoveryTime := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	primitive := NewSnapshotDBDDL("ks_pitr", "ks", recoveryTime, 100)
+
+	vc := &loggingVCursor{dbDDLPlugin: pluginName, ksAvailable: true}
+	_, err := primitive.TryExecute(context.Background(), vc, nil, false)
+	require.NoError(t, err)
+	require.True(t, plugin.createCalled)
+	require.Equal(t, "ks", plugin.snapshotBase)
+	require.Equal(t, recoveryTime, plugin.snapshotRecoveryTime)
+
+	vc = &loggingVCursor{dbDDLPlugin: pluginName}
+	_, err = primitive.TryExecute(context.Background(), vc, nil, false)
+	assert.EqualError(t, err, "could not validate create database: keyspace not available in vschema")
+
+	vc = &loggingVCursor{dbDDLPlugin: faildbDDL}
+	_, err = primitive.TryExecute(context.Background(), vc, nil, false)
+	assert.EqualError(t, err, "VT12001: unsupported: create database with recovery_time by fail dbddl plugin")
+}
+
 func TestDBDDLDropExecute(t *testing.T) {
 	pluginName := "dropFake"
 	plugin := &dbddlTestFake{}
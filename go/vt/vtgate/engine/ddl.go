@@ -19,8 +19,10 @@ package engine
 import (
 	"context"
 
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -82,8 +84,7 @@ func (ddl *DDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[st
 		return vcursor.ExecutePrimitive(ctx, ddl.NormalDDL, bindVars, wantfields)
 	}
 
-	// Commit any open transaction before executing the ddl query.
-	if err = vcursor.Session().Commit(ctx); err != nil {
+	if err = commitBeforeDDL(ctx, vcursor); err != nil {
 		return nil, err
 	}
 
@@ -116,6 +117,27 @@ func (ddl *DDL) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars
 	return callback(results)
 }
 
+// commitBeforeDDL commits any open transaction before executing a ddl query,
+// as MySQL does implicitly, and warns about it. If the session is set to
+// fail the ddl queries in a transaction instead, the transaction is left open.
+func commitBeforeDDL(ctx context.Context, vcursor VCursor) error {
+	session := vcursor.Session()
+	txOpen := session.InTransaction() && session.IsTxOpen()
+	if txOpen && session.GetDDLInTransaction() == vtgatepb.DDLInTransaction_ERROR {
+		return vterrors.VT09033()
+	}
+	if err := session.Commit(ctx); err != nil {
+		return err
+	}
+	if txOpen {
+		session.RecordWarning(&query.QueryWarning{
+			Code:    uint32(sqlerror.ERCantDoThisDuringAnTransaction),
+			Message: "the open transaction was implicitly committed before the DDL statement",
+		})
+	}
+	return nil
+}
+
 // GetFields implements the Primitive interface
 func (ddl *DDL) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*query.BindVariable) (*sqltypes.Result, error) {
 	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] GetFields in not reachable")
@@ -20,9 +20,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/vt/key"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
@@ -93,3 +95,38 @@ func TestDDLTempTable(t *testing.T) {
 		"ExecuteMultiShard false false",
 	})
 }
+
+func TestDDLInTransaction(t *testing.T) {
+	ddl := &DDL{
+		DDL: &sqlparser.CreateTable{
+			Table: sqlparser.NewTableName("a"),
+		},
+		Config:    ddlConfig{},
+		OnlineDDL: &OnlineDDL{},
+		NormalDDL: &Send{
+			Keyspace: &vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			TargetDestination: key.DestinationAllShards{},
+			Query:             "ddl query",
+		},
+	}
+
+	vc := &loggingVCursor{noopVCursor: noopVCursor{inTx: true}}
+	_, err := ddl.TryExecute(context.Background(), vc, nil, true)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		"commit",
+		"ResolveDestinations ks [] Destinations:DestinationAllShards()",
+		"ExecuteMultiShard false false",
+	})
+	require.Len(t, vc.warnings, 1)
+	assert.Equal(t, "the open transaction was implicitly committed before the DDL statement", vc.warnings[0].Message)
+
+	vc = &loggingVCursor{noopVCursor: noopVCursor{inTx: true}, ddlInTransaction: vtgatepb.DDLInTransaction_ERROR}
+	_, err = ddl.TryExecute(context.Background(), vc, nil, true)
+	require.ErrorContains(t, err, "VT09033")
+	vc.ExpectLog(t, nil)
+	assert.Empty(t, vc.warnings)
+}
@@ -123,6 +123,9 @@ func (d *Distinct) TryExecute(ctx context.Context, vcursor VCursor, bindVars map
 			result.Rows = append(result.Rows, appendRow)
 		}
 	}
+	if err := trackRows(vcursor, result.Rows); err != nil {
+		return nil, err
+	}
 	if d.Truncate > 0 {
 		return result.Truncate(d.Truncate), nil
 	}
@@ -150,6 +153,10 @@ func (d *Distinct) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVa
 				result.Rows = append(result.Rows, appendRow)
 			}
 		}
+		// the distinct rows are kept in the probe table until the query finishes
+		if err := trackRows(vcursor, result.Rows); err != nil {
+			return err
+		}
 		return callback(result.Truncate(len(d.CheckCols)))
 	})
 
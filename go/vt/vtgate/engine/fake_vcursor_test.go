@@ -47,8 +47,10 @@ import (
 )
 
 var (
-	testMaxMemoryRows       = 100
-	testIgnoreMaxMemoryRows = false
+	testMaxMemoryRows            = 100
+	testIgnoreMaxMemoryRows      = false
+	testMaxCrossShardCascadeRows = 1
+	testLoadDataChunkRows        = 2
 )
 
 var (
@@ -83,6 +85,10 @@ func (t *noopVCursor) InTransaction() bool {
 	return t.inTx
 }
 
+func (t *noopVCursor) IsTxOpen() bool {
+	return t.inTx
+}
+
 func (t *noopVCursor) SetCommitOrder(co vtgatepb.CommitOrder) {
 	// TODO implement me
 	panic("implement me")
@@ -128,6 +134,14 @@ func (t *noopVCursor) CloneForMirroring(ctx context.Context) VCursor {
 	panic("implement me")
 }
 
+func (t *noopVCursor) GetMirrorCompareResults() bool {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetMirrorMismatchLogRate() float64 {
+	panic("implement me")
+}
+
 func (t *noopVCursor) ReadTransaction(ctx context.Context, transactionID string) (*querypb.TransactionMetadata, error) {
 	panic("implement me")
 }
@@ -341,6 +355,42 @@ func (t *noopVCursor) SetWorkloadName(string) {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetTabletTags(map[string]string) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetTenantID(string) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetPlannerFlags(map[string]string) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetConsistentReads(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetPartialScatterReads(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetPartialScatterReads() bool {
+	return false
+}
+
+func (t *noopVCursor) SetMaxStaleness(time.Duration) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetDDLInTransaction(vtgatepb.DDLInTransaction) {
+	panic("implement me")
+}
+
+func (t *noopVCursor) GetDDLInTransaction() vtgatepb.DDLInTransaction {
+	return vtgatepb.DDLInTransaction_IMPLICIT_COMMIT
+}
+
 func (t *noopVCursor) SetPlannerVersion(querypb.ExecuteOptions_PlannerVersion) {
 	panic("implement me")
 }
@@ -365,6 +415,24 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) TrackMemory(int64) error {
+	return nil
+}
+
+func (t *noopVCursor) UntrackMemory(int64) {}
+
+func (t *noopVCursor) SpillConfig() *SpillConfig {
+	return nil
+}
+
+func (t *noopVCursor) MaxCrossShardCascadeRows() int {
+	return testMaxCrossShardCascadeRows
+}
+
+func (t *noopVCursor) LoadDataChunkRows() int {
+	return testLoadDataChunkRows
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return "test_ks"
 }
@@ -404,6 +472,18 @@ func (t *noopVCursor) ResolveDestinationsMultiCol(ctx context.Context, keyspace
 	panic("unimplemented")
 }
 
+func (t *noopVCursor) ReplicationStaleness(string, string) (time.Duration, error) {
+	panic("unimplemented")
+}
+
+func (t *noopVCursor) FilteredReplicationLag(context.Context, string) (time.Duration, error) {
+	panic("unimplemented")
+}
+
+func (t *noopVCursor) NextIDs(context.Context, string, int64, bool, func(context.Context, int64) (int64, error)) (int64, error) {
+	panic("unimplemented")
+}
+
 func (t *noopVCursor) GetDBDDLPluginName() string {
 	panic("unimplemented")
 }
@@ -472,9 +552,52 @@ type loggingVCursor struct {
 	onStreamExecuteMultiFn func(context.Context, Primitive, string, []*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, bool, bool, func(*sqltypes.Result) error)
 	onRecordMirrorStatsFn  func(time.Duration, time.Duration, error)
 
+	mirrorCompareResults bool
+
+	ddlInTransaction    vtgatepb.DDLInTransaction
+	ddlStrategy         string
+	migrationContext    string
+	partialScatterReads bool
+
+	// memoryLimit is the memory limit of the query, 0 meaning no limit, and
+	// memoryUsed the memory accounted for by TrackMemory.
+	memoryLimit int64
+	memoryUsed  int64
+	spill       *SpillConfig
+
+	// filteredReplicationLag is returned by FilteredReplicationLag, which
+	// fails with filteredReplicationErr if set.
+	filteredReplicationLag time.Duration
+	filteredReplicationErr error
+
 	metrics *Metrics
 }
 
+func (f *loggingVCursor) FilteredReplicationLag(_ context.Context, keyspace string) (time.Duration, error) {
+	f.log = append(f.log, "FilteredReplicationLag "+keyspace)
+	return f.filteredReplicationLag, f.filteredReplicationErr
+}
+
+func (f *loggingVCursor) TrackMemory(size int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.memoryLimit > 0 && f.memoryUsed+size > f.memoryLimit {
+		return fmt.Errorf("query memory limit exceeded: the query buffers more than %d bytes at vtgate", f.memoryLimit)
+	}
+	f.memoryUsed += size
+	return nil
+}
+
+func (f *loggingVCursor) UntrackMemory(size int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.memoryUsed -= size
+}
+
+func (f *loggingVCursor) SpillConfig() *SpillConfig {
+	return f.spill
+}
+
 func (f *loggingVCursor) GetExecutionMetrics() *Metrics {
 	return f.metrics
 }
@@ -603,6 +726,14 @@ func (f *loggingVCursor) CloneForMirroring(ctx context.Context) VCursor {
 	panic("no mirror clones available")
 }
 
+func (f *loggingVCursor) GetMirrorCompareResults() bool {
+	return f.mirrorCompareResults
+}
+
+func (f *loggingVCursor) GetMirrorMismatchLogRate() float64 {
+	return 0
+}
+
 func (f *loggingVCursor) Execute(ctx context.Context, method string, query string, bindvars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error) {
 	name := "Unknown"
 	switch co {
@@ -855,6 +986,38 @@ func (f *loggingVCursor) SetWorkloadName(string) {
 	panic("implement me")
 }
 
+func (f *loggingVCursor) SetTabletTags(map[string]string) {
+	panic("implement me")
+}
+
+func (f *loggingVCursor) SetTenantID(string) {
+	panic("implement me")
+}
+
+func (f *loggingVCursor) SetPlannerFlags(map[string]string) {
+	panic("implement me")
+}
+
+func (f *loggingVCursor) SetConsistentReads(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (f *loggingVCursor) GetPartialScatterReads() bool {
+	return f.partialScatterReads
+}
+
+func (f *loggingVCursor) GetDDLInTransaction() vtgatepb.DDLInTransaction {
+	return f.ddlInTransaction
+}
+
+func (f *loggingVCursor) GetDDLStrategy() string {
+	return f.ddlStrategy
+}
+
+func (f *loggingVCursor) GetMigrationContext() string {
+	return f.migrationContext
+}
+
 func (f *loggingVCursor) SetPlannerVersion(querypb.ExecuteOptions_PlannerVersion) {
 	panic("implement me")
 }
@@ -22,7 +22,29 @@ import (
 	"maps"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	fkCascadeShardScoped = "ShardScoped"
+	fkCascadeCrossShard  = "CrossShard"
+)
+
+var (
+	fkCascades = stats.NewCountersWithSingleLabel(
+		"FkCascades",
+		"Number of foreign key cascades executed by vtgate on child tables, by scope",
+		"Scope")
+	fkCascadeRows = stats.NewCountersWithSingleLabel(
+		"FkCascadeRows",
+		"Number of parent rows whose changes were cascaded by vtgate to child tables, by scope",
+		"Scope")
+	fkCascadesRejected = stats.NewCounter(
+		"FkCascadesRejected",
+		"Number of cross-shard foreign key cascades rejected for exceeding the row limit")
 )
 
 // FkChild contains the Child Primitive to be executed collecting the values from the Selection Primitive using the column indexes.
@@ -35,6 +57,9 @@ type FkChild struct {
 	// NonLiteralInfo stores the information that is needed to run an update query with non-literal values.
 	NonLiteralInfo []NonLiteralUpdateInfo
 	Exec           Primitive
+	// CrossShard is true when the child rows can live on other shards than the parent rows.
+	// The number of parent rows cascaded to such a child is limited.
+	CrossShard bool
 }
 
 // NonLiteralUpdateInfo stores the information required to process non-literal update queries.
@@ -76,6 +101,11 @@ func (fkc *FkCascade) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 		return &sqltypes.Result{}, nil
 	}
 
+	// Fail before any child is modified if a cross-shard cascade is over the limit.
+	if err := fkc.checkCrossShardLimit(vcursor, len(selectionRes.Rows)); err != nil {
+		return nil, err
+	}
+
 	for _, child := range fkc.Children {
 		// Having non-empty UpdateExprBvNames is an indication that we have an update query with non-literal expressions in it.
 		// We need to run this query differently because we need to run an update for each row we get back from the SELECT.
@@ -87,12 +117,33 @@ func (fkc *FkCascade) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 		if err != nil {
 			return nil, err
 		}
+		scope := fkCascadeShardScoped
+		if child.CrossShard {
+			scope = fkCascadeCrossShard
+		}
+		fkCascades.Add(scope, 1)
+		fkCascadeRows.Add(scope, int64(len(selectionRes.Rows)))
 	}
 
 	// All the children are modified successfully, we can now execute the Parent Primitive.
 	return vcursor.ExecutePrimitive(ctx, fkc.Parent, bindVars, wantfields)
 }
 
+// checkCrossShardLimit fails if rowCount parent rows are more than can be cascaded to the cross-shard children.
+func (fkc *FkCascade) checkCrossShardLimit(vcursor VCursor, rowCount int) error {
+	limit := vcursor.MaxCrossShardCascadeRows()
+	if limit <= 0 || rowCount <= limit {
+		return nil
+	}
+	for _, child := range fkc.Children {
+		if child.CrossShard {
+			fkCascadesRejected.Add(1)
+			return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "cross-shard foreign key cascade of %d rows exceeded the allowed limit of %d", rowCount, limit)
+		}
+	}
+	return nil
+}
+
 func (fkc *FkCascade) executeLiteralExprFkChild(ctx context.Context, vcursor VCursor, in map[string]*querypb.BindVariable, wantfields bool, selectionRes *sqltypes.Result, child *FkChild, isStreaming bool) error {
 	bindVars := maps.Clone(in)
 	// We create a bindVariable that stores the tuple of columns involved in the fk constraint.
@@ -200,6 +251,9 @@ func (fkc *FkCascade) Inputs() ([]Primitive, []map[string]any) {
 		if len(child.NonLiteralInfo) > 0 {
 			childInfoMap["NonLiteralUpdateInfo"] = child.NonLiteralInfo
 		}
+		if child.CrossShard {
+			childInfoMap["CrossShard"] = true
+		}
 		inputsMap = append(inputsMap, childInfoMap)
 		inputs = append(inputs, child.Exec)
 	}
@@ -149,6 +149,68 @@ func TestUpdateCascade(t *testing.T) {
 	})
 }
 
+// TestCrossShardCascadeLimit tests that FkCascade rejects a cross-shard cascade of more rows than the limit.
+func TestCrossShardCascadeLimit(t *testing.T) {
+	inputP := &Route{
+		Query: "select cola from parent where foo = 48",
+		RoutingParameters: &RoutingParameters{
+			Opcode:   Unsharded,
+			Keyspace: &vindexes.Keyspace{Name: "ks"},
+		},
+	}
+	childP := &Delete{
+		DML: &DML{
+			Query: "delete from child where (ca) in ::__vals",
+			RoutingParameters: &RoutingParameters{
+				Opcode:   Unsharded,
+				Keyspace: &vindexes.Keyspace{Name: "ks"},
+			},
+		},
+	}
+	parentP := &Delete{
+		DML: &DML{
+			Query: "delete from parent where foo = 48",
+			RoutingParameters: &RoutingParameters{
+				Opcode:   Unsharded,
+				Keyspace: &vindexes.Keyspace{Name: "ks"},
+			},
+		},
+	}
+	fkc := &FkCascade{
+		Selection: inputP,
+		Children:  []*FkChild{{BVName: "__vals", Cols: []int{0}, Exec: childP, CrossShard: true}},
+		Parent:    parentP,
+	}
+
+	// The selected rows are over the limit: neither the child nor the parent are modified.
+	vc := newTestVCursor("0")
+	vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("cola", "int64"), "1", "2")}
+	rejected := fkCascadesRejected.Get()
+	_, err := fkc.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+	require.EqualError(t, err, "cross-shard foreign key cascade of 2 rows exceeded the allowed limit of 1")
+	require.EqualValues(t, rejected+1, fkCascadesRejected.Get())
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: select cola from parent where foo = 48 {} false false`,
+	})
+
+	// The selected rows are within the limit.
+	vc = newTestVCursor("0")
+	vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("cola", "int64"), "1")}
+	cascades := fkCascades.Counts()[fkCascadeCrossShard]
+	_, err = fkc.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+	require.NoError(t, err)
+	require.EqualValues(t, cascades+1, fkCascades.Counts()[fkCascadeCrossShard])
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: select cola from parent where foo = 48 {} false false`,
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: delete from child where (ca) in ::__vals {__vals: type:TUPLE values:{type:TUPLE value:"\x89\x02\x011"}} true true`,
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: delete from parent where foo = 48 {} true true`,
+	})
+}
+
 // TestNonLiteralUpdateCascade tests that FkCascade executes the child and parent primitives for a non-literal update cascade.
 func TestNonLiteralUpdateCascade(t *testing.T) {
 	fakeRes := sqltypes.MakeTestResult(sqltypes.MakeTestFields("cola|cola <=> colb + 2|colb + 2", "int64|int64|int64"), "1|1|3", "2|0|5", "3|0|7")
@@ -18,7 +18,9 @@ package engine
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -99,6 +101,9 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 	}
 
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	if err := trackRows(vcursor, lresult.Rows); err != nil {
+		return nil, err
+	}
 	// build the probe table from the LHS result
 	for _, row := range lresult.Rows {
 		err := pt.addLeftRow(row)
@@ -127,6 +132,9 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 	if hj.Opcode == LeftJoin {
 		result.Rows = append(result.Rows, pt.notFetched()...)
 	}
+	if err := trackRows(vcursor, result.Rows); err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
@@ -137,23 +145,53 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
 	var lfields []*querypb.Field
 	var mu sync.Mutex
+
+	// Once the LHS rows buffered by the probe table go above the spill buffer
+	// size, the rows of both sides are spilled to disk instead, partitioned
+	// by the hash of their join column.
+	spill := vcursor.SpillConfig()
+	var partitions *hashJoinPartitions
+	defer func() {
+		partitions.close()
+	}()
+	var buffered int64
+
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
 		mu.Lock()
 		defer mu.Unlock()
 		if len(lfields) == 0 && len(result.Fields) != 0 {
 			lfields = result.Fields
 		}
+		if partitions != nil {
+			return partitions.addLeftRows(result.Rows)
+		}
+		size := rowsSize(result.Rows)
+		if err := vcursor.TrackMemory(size); err != nil {
+			return err
+		}
+		buffered += size
 		for _, current := range result.Rows {
 			err := pt.addLeftRow(current)
 			if err != nil {
 				return err
 			}
 		}
+		if spill != nil && buffered >= spill.BufferSize {
+			partitions = &hashJoinPartitions{spill: spill, pt: pt}
+			if err := partitions.addProbeTable(pt); err != nil {
+				return err
+			}
+			vcursor.UntrackMemory(buffered)
+			buffered = 0
+		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	if partitions != nil {
+		return hj.streamPartitions(ctx, vcursor, bindVars, wantfields, lfields, partitions, callback)
+	}
 
 	var sendFields atomic.Bool
 	sendFields.Store(wantfields)
@@ -201,6 +239,115 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 	return nil
 }
 
+// streamPartitions joins the rows spilled to disk, one partition at a time.
+func (hj *HashJoin) streamPartitions(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, lfields []*querypb.Field, partitions *hashJoinPartitions, callback func(*sqltypes.Result) error) error {
+	var rfields []*querypb.Field
+	var mu sync.Mutex
+	err := vcursor.StreamExecutePrimitive(ctx, hj.Right, bindVars, wantfields, func(result *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(rfields) == 0 && len(result.Fields) != 0 {
+			rfields = result.Fields
+		}
+		return partitions.addRightRows(result.Rows)
+	})
+	if err != nil {
+		return err
+	}
+
+	if wantfields {
+		if len(rfields) == 0 {
+			rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
+			if err != nil {
+				return err
+			}
+			rfields = rres.Fields
+		}
+		if err := callback(&sqltypes.Result{Fields: joinFields(lfields, rfields, hj.Cols)}); err != nil {
+			return err
+		}
+	}
+
+	for i := range partitions.left {
+		if err := hj.joinPartition(vcursor, partitions.left[i], partitions.right[i], callback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinPartition joins a partition of the rows of the LHS, loaded in a probe
+// table, with the same partition of the rows of the RHS.
+func (hj *HashJoin) joinPartition(vcursor VCursor, left, right *spillFile, callback func(*sqltypes.Result) error) error {
+	if left == nil {
+		return nil
+	}
+	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	if err := left.rewind(); err != nil {
+		return err
+	}
+	var buffered int64
+	defer func() {
+		vcursor.UntrackMemory(buffered)
+	}()
+	for {
+		row, err := left.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		size := rowsSize([]sqltypes.Row{row})
+		if err := vcursor.TrackMemory(size); err != nil {
+			return err
+		}
+		buffered += size
+		if err := pt.addLeftRow(row); err != nil {
+			return err
+		}
+	}
+
+	if right != nil {
+		if err := right.rewind(); err != nil {
+			return err
+		}
+		res := &sqltypes.Result{}
+		for {
+			row, err := right.next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			matches, err := pt.get(row)
+			if err != nil {
+				return err
+			}
+			res.Rows = append(res.Rows, matches...)
+			if len(res.Rows) >= spillBatchRows {
+				if err := callback(res); err != nil {
+					return err
+				}
+				res = &sqltypes.Result{}
+			}
+		}
+		if len(res.Rows) != 0 {
+			if err := callback(res); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hj.Opcode == LeftJoin {
+		if rows := pt.notFetched(); len(rows) != 0 {
+			return callback(&sqltypes.Result{Rows: rows})
+		}
+	}
+	return nil
+}
+
 // GetFields implements the Primitive interface
 func (hj *HashJoin) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	joinVars := make(map[string]*querypb.BindVariable)
@@ -311,3 +458,87 @@ func (pt *hashJoinProbeTable) notFetched() (rows []sqltypes.Row) {
 	}
 	return
 }
+
+// hashJoinSpillPartitions is the number of partitions of the rows of a hash
+// join spilled to disk.
+const hashJoinSpillPartitions = 16
+
+// hashJoinPartitions holds the rows of the two sides of a hash join spilled
+// to disk, partitioned by the hash of their join column, so that the rows of
+// each partition of the LHS can be joined in memory with the rows of the same
+// partition of the RHS. The file of a partition is only created with its
+// first row.
+type hashJoinPartitions struct {
+	spill *SpillConfig
+	// pt hashes the join columns of the rows.
+	pt *hashJoinProbeTable
+
+	left, right [hashJoinSpillPartitions]*spillFile
+}
+
+func (p *hashJoinPartitions) add(files *[hashJoinSpillPartitions]*spillFile, hash vthash.Hash, row sqltypes.Row) error {
+	i := binary.LittleEndian.Uint64(hash[:8]) % hashJoinSpillPartitions
+	if files[i] == nil {
+		file, err := newSpillFile(p.spill, "HashJoin")
+		if err != nil {
+			return err
+		}
+		files[i] = file
+	}
+	return files[i].write(row)
+}
+
+// addProbeTable spills the rows of the probe table, and empties it.
+func (p *hashJoinPartitions) addProbeTable(pt *hashJoinProbeTable) error {
+	for hash, e := range pt.innerMap {
+		for ; e != nil; e = e.next {
+			if err := p.add(&p.left, hash, e.row); err != nil {
+				return err
+			}
+		}
+	}
+	pt.innerMap = map[vthash.Hash]*probeTableEntry{}
+	return nil
+}
+
+func (p *hashJoinPartitions) addLeftRows(rows []sqltypes.Row) error {
+	for _, row := range rows {
+		hash, err := p.pt.hash(row[p.pt.lhsKey])
+		if err != nil {
+			return err
+		}
+		if err := p.add(&p.left, hash, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRightRows spills the rows of the RHS, except the ones with a NULL join
+// column, which can't match any row of the LHS.
+func (p *hashJoinPartitions) addRightRows(rows []sqltypes.Row) error {
+	for _, row := range rows {
+		val := row[p.pt.rhsKey]
+		if val.IsNull() {
+			continue
+		}
+		hash, err := p.pt.hash(val)
+		if err != nil {
+			return err
+		}
+		if err := p.add(&p.right, hash, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *hashJoinPartitions) close() {
+	if p == nil {
+		return
+	}
+	for i := range p.left {
+		p.left[i].close()
+		p.right[i].close()
+	}
+}
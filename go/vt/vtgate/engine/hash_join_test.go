@@ -159,6 +159,15 @@ func TestHashJoinVariations(t *testing.T) {
 			require.NoError(t, err)
 			expectResultAnyOrder(t, r, expected)
 		})
+		t.Run("Spilling "+tc.name, func(t *testing.T) {
+			jn.Left = first()
+			jn.Right = last()
+			vc := &loggingVCursor{spill: &SpillConfig{Dir: t.TempDir(), BufferSize: 1}}
+			r, err := wrapStreamExecute(jn, vc, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResultAnyOrder(t, r, expected)
+			require.Zero(t, vc.memoryUsed)
+		})
 	}
 }
 
@@ -215,6 +215,11 @@ func (ins *Insert) getInsertShardedQueries(
 	// Build 3-d bindvars. Skip rows with nil keyspace ids in case
 	// we're executing an insert ignore.
 	for vIdx, colVindex := range ins.ColVindexes {
+		if colVindex.Expression != nil {
+			// The values of a functional vindex are computed from the
+			// column, which keeps the value given by the query.
+			continue
+		}
 		for rowNum, rowColumnKeys := range vindexRowsValues[vIdx] {
 			if keyspaceIDs[rowNum] == nil {
 				// InsertIgnore: skip the row.
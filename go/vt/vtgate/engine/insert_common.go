@@ -270,8 +270,10 @@ func (ic *InsertCommon) processUnowned(ctx context.Context, vcursor VCursor, vin
 	var verifyKeys []sqltypes.Row
 	var verifyKsids []ksID
 
-	// Check if this VIndex is reversible or not.
+	// Check if this VIndex is reversible or not. The column of a functional
+	// vindex cannot be recovered from the value of its expression.
 	reversibleVindex, isReversible := colVindex.Vindex.(vindexes.Reversible)
+	isReversible = isReversible && colVindex.Expression == nil
 
 	for rowNum, rowColumnKeys := range vindexColumnsKeys {
 		// If we weren't able to determine a keyspace id from the primary VIndex, skip this row
@@ -204,6 +204,71 @@ func TestJoinExecuteMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestJoinExecuteMemoryLimit(t *testing.T) {
+	testCases := []struct {
+		memoryLimit int64
+		err         string
+	}{
+		{16, ""},
+		{15, "query memory limit exceeded: the query buffers more than 15 bytes at vtgate"},
+	}
+	for _, test := range testCases {
+		leftPrim := &fakePrimitive{
+			results: []*sqltypes.Result{
+				sqltypes.MakeTestResult(
+					sqltypes.MakeTestFields(
+						"col1|col2|col3",
+						"int64|varchar|varchar",
+					),
+					"1|a|aa",
+					"2|b|bb",
+					"3|c|cc",
+				),
+			},
+		}
+		rightFields := sqltypes.MakeTestFields(
+			"col4|col5|col6",
+			"int64|varchar|varchar",
+		)
+		rightPrim := &fakePrimitive{
+			results: []*sqltypes.Result{
+				sqltypes.MakeTestResult(
+					rightFields,
+					"4|d|dd",
+				),
+				sqltypes.MakeTestResult(
+					rightFields,
+				),
+				sqltypes.MakeTestResult(
+					rightFields,
+					"5|e|ee",
+					"6|f|ff",
+					"7|g|gg",
+				),
+			},
+		}
+
+		// The four joined rows take four bytes each.
+		jn := &Join{
+			Opcode: InnerJoin,
+			Left:   leftPrim,
+			Right:  rightPrim,
+			Cols:   []int{-1, -2, 1, 2},
+			Vars: map[string]int{
+				"bv": 1,
+			},
+		}
+		vc := &loggingVCursor{memoryLimit: test.memoryLimit}
+		_, err := jn.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+		if test.err == "" {
+			require.NoError(t, err)
+			require.EqualValues(t, 16, vc.memoryUsed)
+		} else {
+			require.EqualError(t, err, test.err)
+		}
+	}
+}
+
 func TestJoinExecuteNoResult(t *testing.T) {
 	leftPrim := &fakePrimitive{
 		results: []*sqltypes.Result{
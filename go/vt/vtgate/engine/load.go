@@ -0,0 +1,425 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var _ Primitive = (*Load)(nil)
+
+// LocalInfile opens the file of a LOAD DATA LOCAL INFILE statement, which is
+// sent by the client.
+type LocalInfile func(filename string) (io.ReadCloser, error)
+
+// WithLocalInfile returns a context in which the LOAD DATA LOCAL INFILE
+// statements read their files with open.
+func WithLocalInfile(ctx context.Context, open LocalInfile) context.Context {
+	return context.WithValue(ctx, localInfile, open)
+}
+
+// Load is the primitive of LOAD DATA LOCAL INFILE. It reads the rows of the
+// file sent by the client, and inserts them with INSERT statements of up to
+// VCursor.LoadDataChunkRows rows, which are planned and routed like any
+// other INSERT. In autocommit, each INSERT is executed in its own
+// transaction, so the file is loaded chunk by chunk.
+//
+// The loading stops at the first chunk which fails, with an error naming its
+// lines, and the rows of the chunks before it stay loaded.
+type Load struct {
+	noTxNeeded
+	noInputs
+	noFields
+
+	// FileName is the name of the file the client is asked for.
+	FileName string
+
+	// Insert is the statement the rows are inserted with, whose rows are
+	// replaced by the rows of each chunk.
+	Insert *sqlparser.Insert
+
+	// Format is how the fields and lines of the file are delimited.
+	Format LoadFormat
+
+	// IgnoreLines is the number of lines skipped at the start of the file.
+	IgnoreLines int
+}
+
+// LoadFormat is how the fields and lines of the file of a LOAD DATA are
+// delimited, see the FIELDS and LINES clauses of the statement.
+type LoadFormat struct {
+	FieldsTerminatedBy string
+	// FieldsEnclosedBy and FieldsEscapedBy are a single character, or empty.
+	FieldsEnclosedBy  string
+	FieldsEscapedBy   string
+	LinesStartingBy   string
+	LinesTerminatedBy string
+}
+
+// DefaultLoadFormat is the format of the LOAD DATA statements without FIELDS
+// or LINES clauses.
+var DefaultLoadFormat = LoadFormat{
+	FieldsTerminatedBy: "\t",
+	FieldsEscapedBy:    `\`,
+	LinesTerminatedBy:  "\n",
+}
+
+// TryExecute implements the Primitive interface
+func (l *Load) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	open, ok := ctx.Value(localInfile).(LocalInfile)
+	if !ok {
+		return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.ClientLocalFilesDisabled, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	}
+	file, err := open(l.FileName)
+	if err != nil {
+		return nil, err
+	}
+	res, err := l.load(ctx, vcursor, newLoadReader(file, &l.Format))
+	// The rest of the file is read even if the loading failed.
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// load inserts the rows of the file, chunk by chunk.
+func (l *Load) load(ctx context.Context, vcursor VCursor, r *loadReader) (*sqltypes.Result, error) {
+	for r.line < l.IgnoreLines {
+		if err := r.skipLine(); err == io.EOF {
+			return &sqltypes.Result{}, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	chunkRows := max(1, vcursor.LoadDataChunkRows())
+	res := &sqltypes.Result{}
+	for {
+		firstLine := r.line + 1
+		rows, bvs, err := l.readChunk(r, chunkRows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return res, nil
+		}
+
+		ins := *l.Insert
+		ins.Rows = rows
+		qr, err := vcursor.Execute(ctx, "Load", sqlparser.String(&ins), bvs, true, vtgatepb.CommitOrder_NORMAL)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "LOAD DATA failed on the rows of lines %d to %d, after %d rows were loaded", firstLine, r.line, res.RowsAffected)
+		}
+		res.RowsAffected += qr.RowsAffected
+		if len(rows) < chunkRows {
+			return res, nil
+		}
+	}
+}
+
+// readChunk reads up to chunkRows rows of the file, as the rows of an INSERT
+// and their bind variables. The missing fields of a row are inserted with
+// their default value, and its extra fields are ignored.
+func (l *Load) readChunk(r *loadReader, chunkRows int) (sqlparser.Values, map[string]*querypb.BindVariable, error) {
+	var rows sqlparser.Values
+	bvs := make(map[string]*querypb.BindVariable)
+	for len(rows) < chunkRows {
+		fields, err := r.readLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		numCols := len(l.Insert.Columns)
+		if numCols == 0 {
+			numCols = len(fields)
+		}
+		row := make(sqlparser.ValTuple, 0, numCols)
+		for i := range numCols {
+			if i >= len(fields) {
+				row = append(row, &sqlparser.Default{})
+				continue
+			}
+			name := fmt.Sprintf("ld%d", len(bvs))
+			bvs[name] = fields[i]
+			row = append(row, sqlparser.NewArgument(name))
+		}
+		rows = append(rows, row)
+	}
+	return rows, bvs, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (l *Load) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	res, err := l.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(res)
+}
+
+func (l *Load) description() PrimitiveDescription {
+	// The query is described without rows.
+	ins := *l.Insert
+	ins.Rows = sqlparser.Values{}
+	other := map[string]any{
+		"FileName": l.FileName,
+		"Query":    strings.TrimSpace(sqlparser.String(&ins)),
+	}
+	if l.IgnoreLines > 0 {
+		other["IgnoreLines"] = l.IgnoreLines
+	}
+	return PrimitiveDescription{
+		OperatorType: "Load",
+		Other:        other,
+	}
+}
+
+// loadReader reads the lines of the file of a LOAD DATA, following the
+// semantics of MySQL.
+type loadReader struct {
+	r      *bufio.Reader
+	format *LoadFormat
+	// line is the number of lines read.
+	line int
+}
+
+func newLoadReader(r io.Reader, format *LoadFormat) *loadReader {
+	return &loadReader{
+		r:      bufio.NewReader(r),
+		format: format,
+	}
+}
+
+// consume reads s if the file continues with it.
+func (lr *loadReader) consume(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	next, err := lr.r.Peek(len(s))
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	if string(next) != s {
+		return false, nil
+	}
+	_, err = lr.r.Discard(len(s))
+	return true, err
+}
+
+// startLine skips what precedes the LINES STARTING BY prefix of the next
+// line, including the lines without it. It returns io.EOF at the end of the
+// file.
+func (lr *loadReader) startLine() error {
+	if _, err := lr.r.Peek(1); err != nil {
+		return err
+	}
+	prefix := lr.format.LinesStartingBy
+	if prefix == "" {
+		return nil
+	}
+	for {
+		ok, err := lr.consume(prefix)
+		if ok || err != nil {
+			return err
+		}
+		if _, err := lr.r.ReadByte(); err != nil {
+			return err
+		}
+	}
+}
+
+// skipLine skips the next line, for IGNORE LINES.
+func (lr *loadReader) skipLine() error {
+	if err := lr.startLine(); err != nil {
+		return err
+	}
+	lr.line++
+	for {
+		ok, err := lr.consume(lr.format.LinesTerminatedBy)
+		if ok || err != nil {
+			return err
+		}
+		if _, err := lr.r.ReadByte(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// readLine reads the fields of the next line. It returns io.EOF at the end of
+// the file.
+func (lr *loadReader) readLine() ([]*querypb.BindVariable, error) {
+	if err := lr.startLine(); err != nil {
+		return nil, err
+	}
+	lr.line++
+	var fields []*querypb.BindVariable
+	for {
+		field, endOfLine, err := lr.readField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		if endOfLine {
+			return fields, nil
+		}
+	}
+}
+
+// readField reads the next field of the line, and whether it is the last one
+// of the line.
+func (lr *loadReader) readField() (*querypb.BindVariable, bool, error) {
+	f := lr.format
+	enclosed := false
+	if f.FieldsEnclosedBy != "" {
+		var err error
+		if enclosed, err = lr.consume(f.FieldsEnclosedBy); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var value bytes.Buffer
+	escaped := false
+	for {
+		// The field ends with the enclosing character when it is followed
+		// by the end of the field or of the line, and it is doubled when
+		// it is part of the field.
+		if enclosed {
+			ok, err := lr.consume(f.FieldsEnclosedBy)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				if again, err := lr.consume(f.FieldsEnclosedBy); err != nil {
+					return nil, false, err
+				} else if again {
+					value.WriteString(f.FieldsEnclosedBy)
+					continue
+				}
+				endOfLine, endOfField, err := lr.endOfField()
+				if err != nil {
+					return nil, false, err
+				}
+				if endOfLine || endOfField {
+					return sqltypes.StringBindVariable(value.String()), endOfLine, nil
+				}
+				value.WriteString(f.FieldsEnclosedBy)
+				continue
+			}
+		} else {
+			endOfLine, endOfField, err := lr.endOfField()
+			if err != nil {
+				return nil, false, err
+			}
+			if endOfLine || endOfField {
+				return lr.unenclosedValue(value.Bytes(), escaped), endOfLine, nil
+			}
+		}
+
+		c, err := lr.r.ReadByte()
+		if err == io.EOF {
+			if enclosed {
+				// The enclosing character was not closed: it is part
+				// of the field, like in MySQL.
+				return sqltypes.StringBindVariable(f.FieldsEnclosedBy + value.String()), true, nil
+			}
+			return lr.unenclosedValue(value.Bytes(), escaped), true, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if f.FieldsEscapedBy != "" && c == f.FieldsEscapedBy[0] {
+			next, err := lr.r.ReadByte()
+			if err == io.EOF {
+				value.WriteByte(c)
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			escaped = escaped || next == 'N'
+			value.WriteByte(unescapeLoadByte(next))
+			continue
+		}
+		value.WriteByte(c)
+	}
+}
+
+// endOfField reads the end of the line or of the field, if the file continues
+// with it. The end of the file is the end of the line.
+func (lr *loadReader) endOfField() (endOfLine, endOfField bool, err error) {
+	if _, err := lr.r.Peek(1); err == io.EOF {
+		return true, false, nil
+	}
+	if endOfLine, err = lr.consume(lr.format.LinesTerminatedBy); endOfLine || err != nil {
+		return endOfLine, false, err
+	}
+	endOfField, err = lr.consume(lr.format.FieldsTerminatedBy)
+	return false, endOfField, err
+}
+
+// unenclosedValue returns the value of a field which is not enclosed: \N is
+// NULL, and so is NULL when the fields can be enclosed.
+func (lr *loadReader) unenclosedValue(value []byte, escaped bool) *querypb.BindVariable {
+	if escaped && len(value) == 1 && value[0] == 'N' {
+		return sqltypes.NullBindVariable
+	}
+	if lr.format.FieldsEnclosedBy != "" && string(value) == "NULL" {
+		return sqltypes.NullBindVariable
+	}
+	return sqltypes.StringBindVariable(string(value))
+}
+
+// unescapeLoadByte returns the character escaped by the escape character of
+// the fields.
+func unescapeLoadByte(c byte) byte {
+	switch c {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	}
+	return c
+}
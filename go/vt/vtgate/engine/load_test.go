@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestLoadReader(t *testing.T) {
+	csv := LoadFormat{
+		FieldsTerminatedBy: ",",
+		FieldsEnclosedBy:   `"`,
+		FieldsEscapedBy:    `\`,
+		LinesTerminatedBy:  "\r\n",
+	}
+	tcases := []struct {
+		name   string
+		format LoadFormat
+		file   string
+		want   [][]string
+	}{{
+		name:   "default format",
+		format: DefaultLoadFormat,
+		file:   "1\ta\n2\t\\N\n3\tx\\ty\n4\t\n",
+		want:   [][]string{{"1", "a"}, {"2", "NULL"}, {"3", "x\ty"}, {"4", ""}},
+	}, {
+		name:   "no terminator at the end of the file",
+		format: DefaultLoadFormat,
+		file:   "1\ta\n2\tb",
+		want:   [][]string{{"1", "a"}, {"2", "b"}},
+	}, {
+		name:   "enclosed fields",
+		format: csv,
+		file:   "1,\"a,b\"\r\n2,\"say \"\"hi\"\"\"\r\n3,NULL\r\n4,\"NULL\"\r\n5,\"x\r\ny\"\r\n6,\"\\\"\"\r\n",
+		want:   [][]string{{"1", "a,b"}, {"2", `say "hi"`}, {"3", "NULL"}, {"4", "'NULL'"}, {"5", "x\r\ny"}, {"6", `"`}},
+	}, {
+		name:   "enclosing character inside a field",
+		format: csv,
+		file:   "1,\"a\"b\",c\r\n",
+		want:   [][]string{{"1", `a"b`, "c"}},
+	}, {
+		name: "lines starting by a prefix",
+		format: LoadFormat{
+			FieldsTerminatedBy: ",",
+			LinesStartingBy:    ">>",
+			LinesTerminatedBy:  "\n",
+		},
+		file: "skipped>>1,a\nskipped\n>>2,b\n",
+		want: [][]string{{"1", "a"}, {"2", "b"}},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			r := newLoadReader(strings.NewReader(tcase.file), &tcase.format)
+			var got [][]string
+			for {
+				fields, err := r.readLine()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				var line []string
+				for _, field := range fields {
+					switch {
+					case field.Type == sqltypes.Null:
+						line = append(line, "NULL")
+					case string(field.Value) == "NULL":
+						line = append(line, "'NULL'")
+					default:
+						line = append(line, string(field.Value))
+					}
+				}
+				got = append(got, line)
+			}
+			assert.Equal(t, tcase.want, got)
+			assert.Equal(t, len(tcase.want), r.line)
+		})
+	}
+}
+
+// localInfileContext returns a context in which the LOAD DATA LOCAL INFILE
+// statements read file, and records the files it was asked for.
+func localInfileContext(file string, opened *[]string) context.Context {
+	return WithLocalInfile(context.Background(), func(filename string) (io.ReadCloser, error) {
+		*opened = append(*opened, filename)
+		return io.NopCloser(strings.NewReader(file)), nil
+	})
+}
+
+func newTestLoad(t *testing.T, insert string) *Load {
+	stmt, err := sqlparser.NewTestParser().Parse(insert)
+	require.NoError(t, err)
+	return &Load{
+		FileName: "data.tsv",
+		Insert:   stmt.(*sqlparser.Insert),
+		Format:   DefaultLoadFormat,
+	}
+}
+
+func TestLoad(t *testing.T) {
+	load := newTestLoad(t, "insert ignore into t(a, b) values (1, 2)")
+	load.IgnoreLines = 1
+
+	var opened []string
+	ctx := localInfileContext("a\tb\n1\tx\n2\n3\ty\textra\n", &opened)
+	vc := &loggingVCursor{results: []*sqltypes.Result{{RowsAffected: 2}, {RowsAffected: 1}}}
+	qr, err := load.TryExecute(ctx, vc, nil, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, qr.RowsAffected)
+	assert.Equal(t, []string{"data.tsv"}, opened)
+
+	// The rows are inserted 2 at a time, the missing fields with their
+	// default value.
+	vc.ExpectLog(t, []string{
+		`Execute insert ignore into t(a, b) values (:ld0, :ld1), (:ld2, default) ` +
+			`ld0: type:VARCHAR value:"1" ld1: type:VARCHAR value:"x" ld2: type:VARCHAR value:"2" true`,
+		`Execute insert ignore into t(a, b) values (:ld0, :ld1) ` +
+			`ld0: type:VARCHAR value:"3" ld1: type:VARCHAR value:"y" true`,
+	})
+}
+
+func TestLoadChunkError(t *testing.T) {
+	load := newTestLoad(t, "replace into t values (1)")
+
+	var opened []string
+	ctx := localInfileContext("1\n2\n3\n4\n5\n", &opened)
+	vc := &loggingVCursor{
+		results:   []*sqltypes.Result{{RowsAffected: 2}},
+		resultErr: sqlerror.NewSQLError(sqlerror.ERDupEntry, sqlerror.SSConstraintViolation, "Duplicate entry '3' for key 'PRIMARY'"),
+	}
+	_, err := load.TryExecute(ctx, vc, nil, false)
+	require.ErrorContains(t, err, "LOAD DATA failed on the rows of lines 3 to 4, after 2 rows were loaded: Duplicate entry '3' for key 'PRIMARY'")
+	assert.Len(t, vc.log, 2)
+}
+
+func TestLoadLocalFilesDisabled(t *testing.T) {
+	load := newTestLoad(t, "insert ignore into t values (1)")
+	_, err := load.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.EqualError(t, err, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	assert.EqualValues(t, sqlerror.ERClientLocalFilesDisabled, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Number())
+
+	// The error of the client is returned as is.
+	ctx := WithLocalInfile(context.Background(), func(string) (io.ReadCloser, error) {
+		return nil, errors.New("connection lost")
+	})
+	_, err = load.TryExecute(ctx, &noopVCursor{}, nil, false)
+	require.EqualError(t, err, "connection lost")
+}
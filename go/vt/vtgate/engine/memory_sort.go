@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"strconv"
@@ -55,6 +56,9 @@ func (ms *MemorySort) TryExecute(ctx context.Context, vcursor VCursor, bindVars
 	if err != nil {
 		return nil, err
 	}
+	if err := trackRows(vcursor, result.Rows); err != nil {
+		return nil, err
+	}
 
 	if err = ms.OrderBy.SortResult(result); err != nil {
 		return nil, err
@@ -83,6 +87,18 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		Limit:   count,
 	}
 
+	// Once the rows buffered by the sorter go above the spill buffer size,
+	// they are spilled to disk as a sorted run, and the runs are merged at
+	// the end.
+	spill := vcursor.SpillConfig()
+	var runs []*spillFile
+	defer func() {
+		for _, run := range runs {
+			run.close()
+		}
+	}()
+	var buffered int64
+
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		mu.Lock()
@@ -92,9 +108,31 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 				return err
 			}
 		}
+		sorted := sorter.Len()
 		for _, row := range qr.Rows {
 			sorter.Push(row)
 		}
+		// The sorter keeps at most count rows, so only the rows it grew by
+		// are accounted for.
+		size := rowsSize(qr.Rows[:sorter.Len()-sorted])
+		if err := vcursor.TrackMemory(size); err != nil {
+			return err
+		}
+		buffered += size
+		if spill != nil && buffered >= spill.BufferSize {
+			run, err := spillRows(spill, "MemorySort", sorter.Sorted())
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+			vcursor.UntrackMemory(buffered)
+			buffered = 0
+			sorter = &evalengine.Sorter{
+				Compare: ms.OrderBy,
+				Limit:   count,
+			}
+			return nil
+		}
 		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
 			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
 		}
@@ -103,7 +141,67 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 	if err != nil {
 		return err
 	}
-	return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	if len(runs) == 0 {
+		return cb(&sqltypes.Result{Rows: sorter.Sorted()})
+	}
+	return ms.mergeRuns(runs, sorter.Sorted(), count, cb)
+}
+
+// mergeRuns sends the first count rows of the sorted runs spilled to disk
+// and of the sorted rows left in memory, merged together.
+func (ms *MemorySort) mergeRuns(runs []*spillFile, rows []sqltypes.Row, count int, callback func(*sqltypes.Result) error) error {
+	// The rows left in memory are the last source of the merge.
+	next := func(source int) (sqltypes.Row, error) {
+		if source < len(runs) {
+			return runs[source].next()
+		}
+		if len(rows) == 0 {
+			return nil, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+	merger := &evalengine.Merger{Compare: ms.OrderBy}
+	push := func(source int) error {
+		row, err := next(source)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		merger.Push(row, source)
+		return nil
+	}
+
+	for _, run := range runs {
+		if err := run.rewind(); err != nil {
+			return err
+		}
+	}
+	for source := 0; source <= len(runs); source++ {
+		if err := push(source); err != nil {
+			return err
+		}
+	}
+	merger.Init()
+
+	result := &sqltypes.Result{}
+	for sent := 0; sent < count && merger.Len() > 0; sent++ {
+		row, source := merger.Pop()
+		result.Rows = append(result.Rows, row)
+		if len(result.Rows) == spillBatchRows {
+			if err := callback(result); err != nil {
+				return err
+			}
+			result = &sqltypes.Result{}
+		}
+		if err := push(source); err != nil {
+			return err
+		}
+	}
+	return callback(result)
 }
 
 // GetFields satisfies the Primitive interface.
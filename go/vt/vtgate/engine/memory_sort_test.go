@@ -82,6 +82,103 @@ func TestMemorySortExecute(t *testing.T) {
 	utils.MustMatch(t, wantResult, result)
 }
 
+func TestMemorySortStreamExecuteMemoryLimit(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"g|2",
+			"a|1",
+			"c|4",
+			"c|3",
+		)},
+	}
+
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+		}},
+		Input:      fp,
+		UpperLimit: evalengine.NewBindVar("__upper_limit", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)),
+	}
+	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(3)}
+
+	// Only the three rows kept by the sorter are accounted for.
+	vc := &loggingVCursor{memoryLimit: 6}
+	err := ms.TryStreamExecute(context.Background(), vc, bv, false, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 6, vc.memoryUsed)
+
+	fp.rewind()
+	bv["__upper_limit"] = sqltypes.Int64BindVariable(4)
+	vc = &loggingVCursor{memoryLimit: 6}
+	err = ms.TryStreamExecute(context.Background(), vc, bv, false, func(qr *sqltypes.Result) error {
+		return nil
+	})
+	require.EqualError(t, err, "query memory limit exceeded: the query buffers more than 6 bytes at vtgate")
+}
+
+func TestMemorySortStreamExecuteSpill(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varbinary|decimal",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|1",
+			"g|2",
+			"a|1",
+			"c|4",
+			"c|3",
+		)},
+	}
+
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+		}},
+		Input: fp,
+	}
+
+	// The rows come two at a time and take two bytes each, so that two runs
+	// are spilled before the last row.
+	vc := &loggingVCursor{spill: &SpillConfig{Dir: t.TempDir(), BufferSize: 4}}
+	result, err := wrapStreamExecute(ms, vc, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"a|1",
+		"a|1",
+		"g|2",
+		"c|3",
+		"c|4",
+	), result)
+	// Only the last row, sorted in memory, is still accounted for.
+	require.EqualValues(t, 2, vc.memoryUsed)
+
+	fp.rewind()
+	ms.UpperLimit = evalengine.NewBindVar("__upper_limit", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID))
+	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(3)}
+	vc = &loggingVCursor{spill: &SpillConfig{Dir: t.TempDir(), BufferSize: 4}}
+	result, err = wrapStreamExecute(ms, vc, bv, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"a|1",
+		"a|1",
+		"g|2",
+	), result)
+}
+
 func TestMemorySortStreamExecuteWeightString(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"weightString|normal",
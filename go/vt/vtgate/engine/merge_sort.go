@@ -53,6 +53,9 @@ type MergeSort struct {
 	OrderBy                 evalengine.Comparison
 	ScatterErrorsAsWarnings bool
 	FetchLastInsertID       bool
+	// PartialResults is true if the rows of the inputs are returned even if
+	// some of them are unreachable, as set with @@vitess_partial_scatter_reads.
+	PartialResults bool
 }
 
 // TryExecute is not supported.
@@ -76,7 +79,7 @@ func (ms *MergeSort) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 	handles := make([]*streamHandle, len(ms.Primitives))
 	for i, input := range ms.Primitives {
 		handles[i] = runOneStream(ctx, vcursor, input, bindVars, gotFields, ms.FetchLastInsertID)
-		if !ms.ScatterErrorsAsWarnings {
+		if !ms.ScatterErrorsAsWarnings && !ms.PartialResults {
 			// we only need the fields from the first input, unless we allow ScatterErrorsAsWarnings.
 			// in that case, we need to ask all the inputs for fields - we don't know which will return anything
 			gotFields = false
@@ -104,7 +107,7 @@ func (ms *MergeSort) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 		case row, ok := <-handle.row:
 			if !ok {
 				if handle.err != nil {
-					if ms.ScatterErrorsAsWarnings {
+					if ms.ScatterErrorsAsWarnings || (ms.PartialResults && isShardUnreachable(handle.err)) {
 						errs = append(errs, handle.err)
 						break
 					}
@@ -146,11 +149,13 @@ func (ms *MergeSort) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 	}
 
 	err = vterrors.Aggregate(errs)
-	if err != nil && ms.ScatterErrorsAsWarnings && len(errs) < len(handles) {
+	if err != nil && len(errs) < len(handles) {
 		// we got errors, but not all shards failed, so we can hide the error and just warn instead
 		partialSuccessScatterQueries.Add(1)
-		sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
-		vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+		if ms.ScatterErrorsAsWarnings {
+			sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+			vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+		}
 		return nil
 	}
 	return err
@@ -159,7 +164,7 @@ func (ms *MergeSort) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 func (ms *MergeSort) getStreamingFields(handles []*streamHandle) ([]*querypb.Field, error) {
 	var fields []*querypb.Field
 
-	if ms.ScatterErrorsAsWarnings {
+	if ms.ScatterErrorsAsWarnings || ms.PartialResults {
 		for _, handle := range handles {
 			// Fetch field info from just one stream.
 			fields = <-handle.fields
@@ -174,7 +179,7 @@ func (ms *MergeSort) getStreamingFields(handles []*streamHandle) ([]*querypb.Fie
 	}
 	if fields == nil {
 		// something went wrong. need to figure out where the error can be
-		if !ms.ScatterErrorsAsWarnings {
+		if !ms.ScatterErrorsAsWarnings && !ms.PartialResults {
 			return nil, handles[0].err
 		}
 
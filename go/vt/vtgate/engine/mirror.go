@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -29,6 +31,13 @@ import (
 
 var errMirrorTargetQueryTookTooLong = vterrors.Errorf(vtrpc.Code_ABORTED, "Mirror target query took too long")
 
+var (
+	mirrorResultComparisons = stats.NewCountersWithSingleLabel(
+		"MirrorResultComparisons",
+		"Number of mirrored queries whose results were compared with the results of the source",
+		"Result")
+)
+
 type (
 	// percentBasedMirror represents the instructions to execute an
 	// authoritative primitive and, based on whether a die-roll exceeds a
@@ -42,6 +51,7 @@ type (
 	mirrorResult struct {
 		execTime time.Duration
 		err      error
+		digest   sqltypes.ResultDigest
 	}
 )
 
@@ -49,6 +59,10 @@ const (
 	// maxMirrorTargetLag limits how long a mirror target may continue
 	// executing after the main primitive has finished.
 	maxMirrorTargetLag = 100 * time.Millisecond
+
+	mirrorResultMatch            = "Match"
+	mirrorResultRowCountMismatch = "RowCountMismatch"
+	mirrorResultRowsMismatch     = "RowsMismatch"
 )
 
 var _ Primitive = (*percentBasedMirror)(nil)
@@ -75,14 +89,19 @@ func (m *percentBasedMirror) TryExecute(ctx context.Context, vcursor VCursor, bi
 	mirrorCtx, mirrorCtxCancel := context.WithCancel(ctx)
 	defer mirrorCtxCancel()
 
+	compare := vcursor.GetMirrorCompareResults()
 	go func() {
 		mirrorVCursor := vcursor.CloneForMirroring(mirrorCtx)
 		targetStartTime := time.Now()
-		_, targetErr := mirrorVCursor.ExecutePrimitive(mirrorCtx, m.target, bindVars, wantfields)
-		mirrorCh <- mirrorResult{
+		targetRes, targetErr := mirrorVCursor.ExecutePrimitive(mirrorCtx, m.target, bindVars, wantfields)
+		res := mirrorResult{
 			execTime: time.Since(targetStartTime),
 			err:      targetErr,
 		}
+		if compare && targetErr == nil {
+			res.digest.Add(targetRes)
+		}
+		mirrorCh <- res
 	}()
 
 	var (
@@ -96,10 +115,17 @@ func (m *percentBasedMirror) TryExecute(ctx context.Context, vcursor VCursor, bi
 
 	// Cancel the mirror context if it continues executing too long.
 	select {
-	case r := <-mirrorCh:
+	case mr := <-mirrorCh:
 		// Mirror target finished on time.
-		targetExecTime = r.execTime
-		targetErr = r.err
+		targetExecTime = mr.execTime
+		targetErr = mr.err
+		if compare && err == nil && targetErr == nil {
+			// The source result is digested before it is returned, since the
+			// caller may modify it.
+			var sourceDigest sqltypes.ResultDigest
+			sourceDigest.Add(r)
+			m.compareResults(sourceDigest, mr.digest, vcursor.GetMirrorMismatchLogRate())
+		}
 	case <-time.After(maxMirrorTargetLag):
 		// Mirror target took too long.
 		mirrorCtxCancel()
@@ -121,15 +147,21 @@ func (m *percentBasedMirror) TryStreamExecute(ctx context.Context, vcursor VCurs
 	mirrorCtx, mirrorCtxCancel := context.WithCancel(ctx)
 	defer mirrorCtxCancel()
 
+	compare := vcursor.GetMirrorCompareResults()
 	go func() {
 		mirrorVCursor := vcursor.CloneForMirroring(mirrorCtx)
 		mirrorStartTime := time.Now()
-		targetErr := mirrorVCursor.StreamExecutePrimitive(mirrorCtx, m.target, bindVars, wantfields, func(_ *sqltypes.Result) error {
+		var digest sqltypes.ResultDigest
+		targetErr := mirrorVCursor.StreamExecutePrimitive(mirrorCtx, m.target, bindVars, wantfields, func(qr *sqltypes.Result) error {
+			if compare {
+				digest.Add(qr)
+			}
 			return nil
 		})
 		mirrorCh <- mirrorResult{
 			execTime: time.Since(mirrorStartTime),
 			err:      targetErr,
+			digest:   digest,
 		}
 	}()
 
@@ -138,8 +170,17 @@ func (m *percentBasedMirror) TryStreamExecute(ctx context.Context, vcursor VCurs
 		targetErr                      error
 	)
 
+	var sourceDigest sqltypes.ResultDigest
+	sourceCallback := callback
+	if compare {
+		sourceCallback = func(qr *sqltypes.Result) error {
+			sourceDigest.Add(qr)
+			return callback(qr)
+		}
+	}
+
 	sourceStartTime := time.Now()
-	err := vcursor.StreamExecutePrimitive(ctx, m.primitive, bindVars, wantfields, callback)
+	err := vcursor.StreamExecutePrimitive(ctx, m.primitive, bindVars, wantfields, sourceCallback)
 	sourceExecTime = time.Since(sourceStartTime)
 
 	// Cancel the mirror context if it continues executing too long.
@@ -148,6 +189,9 @@ func (m *percentBasedMirror) TryStreamExecute(ctx context.Context, vcursor VCurs
 		// Mirror target finished on time.
 		targetExecTime = r.execTime
 		targetErr = r.err
+		if compare && err == nil && targetErr == nil {
+			m.compareResults(sourceDigest, r.digest, vcursor.GetMirrorMismatchLogRate())
+		}
 	case <-time.After(maxMirrorTargetLag):
 		// Mirror target took too long.
 		mirrorCtxCancel()
@@ -181,3 +225,36 @@ func (m *percentBasedMirror) description() PrimitiveDescription {
 func (m *percentBasedMirror) percentAtLeastDieRoll() bool {
 	return m.percent >= (rand.Float32() * 100.0)
 }
+
+// compareResults records whether the results of the source and the target
+// match, and logs a sample of the mismatches.
+func (m *percentBasedMirror) compareResults(source, target sqltypes.ResultDigest, logRate float64) {
+	var outcome string
+	switch {
+	case source.Rows != target.Rows:
+		outcome = mirrorResultRowCountMismatch
+	case source.Sum != target.Sum:
+		outcome = mirrorResultRowsMismatch
+	default:
+		mirrorResultComparisons.Add(mirrorResultMatch, 1)
+		return
+	}
+	mirrorResultComparisons.Add(outcome, 1)
+	if logRate > 0 && rand.Float64() < logRate {
+		log.Warningf("Mirror result mismatch (%s): source returned %d rows, target returned %d rows, source: %s, target: %s",
+			outcome, source.Rows, target.Rows, mirrorQuery(m.primitive), mirrorQuery(m.target))
+	}
+}
+
+// mirrorQuery returns the keyspace and query of the first route of the given
+// primitive, to identify the query in mismatch logs.
+func mirrorQuery(p Primitive) string {
+	route, ok := Find(func(p Primitive) bool {
+		_, isRoute := p.(*Route)
+		return isRoute
+	}, p).(*Route)
+	if !ok {
+		return "<unknown>"
+	}
+	return route.Keyspace.Name + ": " + route.Query
+}
@@ -547,3 +547,98 @@ func TestMirror(t *testing.T) {
 		require.ErrorContains(t, *targetErr.Load(), "Mirror target query took too long")
 	})
 }
+
+func TestMirrorCompareResults(t *testing.T) {
+	primitive := NewRoute(
+		Unsharded,
+		&vindexes.Keyspace{Name: "ks1"},
+		"select f.bar from foo f",
+		"select f.bar from foo f where 1 != 1",
+	)
+	target := NewRoute(
+		Unsharded,
+		&vindexes.Keyspace{Name: "ks2"},
+		"select f.bar from foo f",
+		"select f.bar from foo f where 1 != 1",
+	)
+	mirror := NewPercentBasedMirror(100, primitive, target)
+
+	fields := sqltypes.MakeTestFields("bar", "varchar")
+	sourceResult := sqltypes.MakeTestResult(fields, "a", "b")
+
+	tcases := []struct {
+		name         string
+		targetResult *sqltypes.Result
+		want         string
+	}{
+		{
+			name:         "match",
+			targetResult: sqltypes.MakeTestResult(fields, "b", "a"),
+			want:         mirrorResultMatch,
+		},
+		{
+			name:         "row count mismatch",
+			targetResult: sqltypes.MakeTestResult(fields, "a"),
+			want:         mirrorResultRowCountMismatch,
+		},
+		{
+			name:         "rows mismatch",
+			targetResult: sqltypes.MakeTestResult(fields, "a", "c"),
+			want:         mirrorResultRowsMismatch,
+		},
+	}
+	newVCursor := func(targetResult *sqltypes.Result, compare bool) *loggingVCursor {
+		mirrorVC := &loggingVCursor{
+			shards:  []string{"0"},
+			results: []*sqltypes.Result{targetResult},
+		}
+		return &loggingVCursor{
+			shards:               []string{"0"},
+			results:              []*sqltypes.Result{sourceResult},
+			mirrorCompareResults: compare,
+			onMirrorClonesFn: func(ctx context.Context) VCursor {
+				return mirrorVC
+			},
+		}
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name+" TryExecute", func(t *testing.T) {
+			before := mirrorResultComparisons.Counts()[tcase.want]
+			res, err := mirror.TryExecute(context.Background(), newVCursor(tcase.targetResult, true), map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			require.Equal(t, sourceResult, res)
+			require.Equal(t, before+1, mirrorResultComparisons.Counts()[tcase.want])
+		})
+
+		t.Run(tcase.name+" TryStreamExecute", func(t *testing.T) {
+			before := mirrorResultComparisons.Counts()[tcase.want]
+			err := mirror.TryStreamExecute(context.Background(), newVCursor(tcase.targetResult, true), map[string]*querypb.BindVariable{}, true, func(result *sqltypes.Result) error {
+				require.Equal(t, sourceResult, result)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, before+1, mirrorResultComparisons.Counts()[tcase.want])
+		})
+	}
+
+	t.Run("source result modified by the caller", func(t *testing.T) {
+		// The caller owns the returned result, e.g. the MySQL server writes
+		// it out, so the comparison must not read it once it is returned.
+		before := mirrorResultComparisons.Counts()[mirrorResultMatch]
+		vc := newVCursor(sqltypes.MakeTestResult(fields, "a", "b"), true)
+		vc.results = []*sqltypes.Result{sqltypes.MakeTestResult(fields, "a", "b")}
+		res, err := mirror.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+		require.NoError(t, err)
+		res.Rows[0][0] = sqltypes.NewVarChar("z")
+		res.Rows = nil
+		require.Equal(t, before+1, mirrorResultComparisons.Counts()[mirrorResultMatch])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		before := mirrorResultComparisons.Counts()
+		_, err := mirror.TryExecute(context.Background(), newVCursor(sourceResult, false), map[string]*querypb.BindVariable{}, true)
+		require.NoError(t, err)
+		require.Equal(t, before, mirrorResultComparisons.Counts())
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+const (
+	// NextIDVariantSequence generates the ids from the sequence table.
+	NextIDVariantSequence = "sequence"
+	// NextIDVariantKOrdered generates Snowflake-like ids, roughly ordered by time.
+	NextIDVariantKOrdered = "k_ordered"
+)
+
+var _ Primitive = (*NextID)(nil)
+
+// NextID is a primitive that generates ids for `select vitess_next_id('seq', count)`.
+// It returns the first of count consecutive ids of the sequence.
+type NextID struct {
+	noInputs
+	noTxNeeded
+
+	// Sequence evaluates to the name of the sequence table.
+	Sequence evalengine.Expr
+	// Count evaluates to the number of ids to generate.
+	Count evalengine.Expr
+	// Variant evaluates to the variant of the ids, see NextIDVariantSequence
+	// and NextIDVariantKOrdered. A nil Variant stands for NextIDVariantSequence.
+	Variant evalengine.Expr
+
+	// Column is the name of the result column.
+	Column string
+}
+
+// TryExecute is part of the Primitive interface
+func (n *NextID) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	seq, err := n.sequenceTable(env, vcursor)
+	if err != nil {
+		return nil, err
+	}
+	count, err := n.count(env, vcursor)
+	if err != nil {
+		return nil, err
+	}
+	kOrdered, err := n.kOrdered(env, vcursor)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := seq.Keyspace.Name + "." + seq.Name.String()
+	id, err := vcursor.NextIDs(ctx, sequence, count, kOrdered, func(ctx context.Context, blockSize int64) (int64, error) {
+		return n.fetchBlock(ctx, vcursor, seq, blockSize)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{
+		Fields: n.fields(),
+		Rows:   []sqltypes.Row{{sqltypes.NewInt64(id)}},
+	}, nil
+}
+
+// TryStreamExecute is part of the Primitive interface
+func (n *NextID) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	qr, err := n.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(qr)
+}
+
+// GetFields is part of the Primitive interface
+func (n *NextID) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: n.fields()}, nil
+}
+
+func (n *NextID) fields() []*querypb.Field {
+	return []*querypb.Field{{
+		Name: n.Column,
+		Type: sqltypes.Int64,
+	}}
+}
+
+// sequenceTable finds the sequence table the ids are generated from.
+func (n *NextID) sequenceTable(env *evalengine.ExpressionEnv, vcursor VCursor) (*vindexes.BaseTable, error) {
+	er, err := env.Evaluate(n.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	name := er.Value(vcursor.ConnCollation()).ToString()
+	keyspace, table, err := vcursor.Environment().Parser().ParseTable(name)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid sequence name '%s': %v", name, err)
+	}
+	seq, err := vcursor.FindRoutedTable(sqlparser.NewTableNameWithQualifier(table, keyspace))
+	if err != nil {
+		return nil, err
+	}
+	if seq == nil {
+		return nil, vterrors.VT05004(name)
+	}
+	if seq.Type != vindexes.TypeSequence {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "vitess_next_id used on a non-sequence table '%s'", name)
+	}
+	return seq, nil
+}
+
+func (n *NextID) count(env *evalengine.ExpressionEnv, vcursor VCursor) (int64, error) {
+	er, err := env.Evaluate(n.Count)
+	if err != nil {
+		return 0, err
+	}
+	value := er.Value(vcursor.ConnCollation())
+	count, err := value.ToCastInt64()
+	if err != nil || count < 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid number of ids for vitess_next_id: %s", value.ToString())
+	}
+	return count, nil
+}
+
+func (n *NextID) kOrdered(env *evalengine.ExpressionEnv, vcursor VCursor) (bool, error) {
+	if n.Variant == nil {
+		return false, nil
+	}
+	er, err := env.Evaluate(n.Variant)
+	if err != nil {
+		return false, err
+	}
+	switch variant := strings.ToLower(er.Value(vcursor.ConnCollation()).ToString()); variant {
+	case NextIDVariantSequence:
+		return false, nil
+	case NextIDVariantKOrdered:
+		return true, nil
+	default:
+		return false, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown vitess_next_id variant '%s', expected '%s' or '%s'", variant, NextIDVariantSequence, NextIDVariantKOrdered)
+	}
+}
+
+// fetchBlock fetches a block of blockSize ids from the sequence table, and returns the first one.
+func (n *NextID) fetchBlock(ctx context.Context, vcursor VCursor, seq *vindexes.BaseTable, blockSize int64) (int64, error) {
+	rss, _, err := vcursor.ResolveDestinations(ctx, seq.Keyspace.Name, nil, []key.ShardDestination{key.DestinationAnyShard{}})
+	if err != nil {
+		return 0, err
+	}
+	if len(rss) != 1 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "sequence generation can happen through single shard only, it is getting routed to %d shards", len(rss))
+	}
+	selNext := &sqlparser.Select{
+		From: []sqlparser.TableExpr{&sqlparser.AliasedTableExpr{Expr: sqlparser.TableName{Name: seq.Name}}},
+	}
+	selNext.AddSelectExpr(&sqlparser.Nextval{Expr: &sqlparser.Argument{Name: nextValBV, Type: sqltypes.Int64}})
+	bindVars := map[string]*querypb.BindVariable{nextValBV: sqltypes.Int64BindVariable(blockSize)}
+	qr, err := vcursor.ExecuteStandalone(ctx, n, sqlparser.String(selNext), bindVars, rss[0], false)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) == 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result from sequence %s: %v", seq.Name.String(), qr.Rows)
+	}
+	return qr.Rows[0][0].ToCastInt64()
+}
+
+func (n *NextID) description() PrimitiveDescription {
+	other := map[string]any{
+		"Sequence": sqlparser.String(n.Sequence),
+		"Count":    sqlparser.String(n.Count),
+	}
+	if n.Variant != nil {
+		other["Variant"] = sqlparser.String(n.Variant)
+	}
+	return PrimitiveDescription{
+		OperatorType: "NextID",
+		Other:        other,
+	}
+}
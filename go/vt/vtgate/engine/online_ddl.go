@@ -57,7 +57,27 @@ func (v *OnlineDDL) description() PrimitiveDescription {
 
 // TryExecute implements the Primitive interface
 func (v *OnlineDDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (result *sqltypes.Result, err error) {
-	result = &sqltypes.Result{
+	onlineDDLs, err := schema.NewOnlineDDLs(v.Keyspace.Name, v.SQL, v.DDL,
+		v.DDLStrategySetting, onlineDDLMigrationContext(vcursor), "", vcursor.Environment().Parser(),
+	)
+	if err != nil {
+		return newOnlineDDLResult(vcursor), err
+	}
+	return submitOnlineDDLs(ctx, vcursor, v.Keyspace, v.TargetDestination, onlineDDLs, bindVars, wantfields)
+}
+
+// onlineDDLMigrationContext returns the migration context of the session, which defaults to the session UUID.
+func onlineDDLMigrationContext(vcursor VCursor) string {
+	migrationContext := vcursor.Session().GetMigrationContext()
+	if migrationContext == "" {
+		// default to @@session_uuid
+		migrationContext = fmt.Sprintf("vtgate:%s", vcursor.Session().GetSessionUUID())
+	}
+	return migrationContext
+}
+
+func newOnlineDDLResult(vcursor VCursor) *sqltypes.Result {
+	return &sqltypes.Result{
 		Fields: []*querypb.Field{
 			{
 				Name:    "uuid",
@@ -67,22 +87,16 @@ func (v *OnlineDDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 		},
 		Rows: [][]sqltypes.Value{},
 	}
-	migrationContext := vcursor.Session().GetMigrationContext()
-	if migrationContext == "" {
-		// default to @@session_uuid
-		migrationContext = fmt.Sprintf("vtgate:%s", vcursor.Session().GetSessionUUID())
-	}
-	onlineDDLs, err := schema.NewOnlineDDLs(v.Keyspace.Name, v.SQL, v.DDL,
-		v.DDLStrategySetting, migrationContext, "", vcursor.Environment().Parser(),
-	)
-	if err != nil {
-		return result, err
-	}
+}
+
+// submitOnlineDDLs sends the migrations to the tablets of the target destination, and returns their UUIDs.
+func submitOnlineDDLs(ctx context.Context, vcursor VCursor, keyspace *vindexes.Keyspace, targetDestination key.ShardDestination, onlineDDLs []*schema.OnlineDDL, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	result := newOnlineDDLResult(vcursor)
 	for _, onlineDDL := range onlineDDLs {
 		// Go directly to tablets, much like Send primitive does
 		s := Send{
-			Keyspace:          v.Keyspace,
-			TargetDestination: v.TargetDestination,
+			Keyspace:          keyspace,
+			TargetDestination: targetDestination,
 			Query:             onlineDDL.SQL,
 			IsDML:             false,
 			SingleShardOnly:   false,
@@ -94,7 +108,7 @@ func (v *OnlineDDL) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 			sqltypes.NewVarChar(onlineDDL.UUID),
 		})
 	}
-	return result, err
+	return result, nil
 }
 
 // TryStreamExecute implements the Primitive interface
@@ -77,7 +77,10 @@ const (
 	AggregateCountStar
 	AggregateGroupConcat
 	AggregateAvg
-	AggregateUDF  // This is an opcode used to represent UDFs
+	AggregateUDF // This is an opcode used to represent UDFs
+	// AggregateCountDistinctApprox estimates a COUNT(DISTINCT) by merging
+	// the HyperLogLog sketches of the distinct values computed by the shards.
+	AggregateCountDistinctApprox
 	_NumOfOpCodes // This line must be last of the opcodes!
 )
 
@@ -97,6 +100,11 @@ var SupportedAggregates = map[string]AggregateOpcode{
 	"count_star":     AggregateCountStar,
 	"any_value":      AggregateAnyValue,
 	"group_concat":   AggregateGroupConcat,
+
+	"count_distinct_approx": AggregateCountDistinctApprox,
+	// APPROX_COUNT_DISTINCT is planned as a COUNT(DISTINCT), which
+	// the planner may approximate.
+	"approx_count_distinct": AggregateCountDistinct,
 }
 
 var AggregateName = map[AggregateOpcode]string{
@@ -111,6 +119,8 @@ var AggregateName = map[AggregateOpcode]string{
 	AggregateGroupConcat:   "group_concat",
 	AggregateAnyValue:      "any_value",
 	AggregateAvg:           "avg",
+
+	AggregateCountDistinctApprox: "count_distinct_approx",
 }
 
 func (code AggregateOpcode) String() string {
@@ -150,7 +160,7 @@ func (code AggregateOpcode) SQLType(typ querypb.Type) querypb.Type {
 			return sqltypes.Decimal
 		}
 		return sqltypes.Float64
-	case AggregateCount, AggregateCountStar, AggregateCountDistinct:
+	case AggregateCount, AggregateCountStar, AggregateCountDistinct, AggregateCountDistinctApprox:
 		return sqltypes.Int64
 	case AggregateGtid:
 		return sqltypes.VarChar
@@ -163,7 +173,7 @@ func (code AggregateOpcode) SQLType(typ querypb.Type) querypb.Type {
 
 func (code AggregateOpcode) Nullable() bool {
 	switch code {
-	case AggregateCount, AggregateCountStar:
+	case AggregateCount, AggregateCountStar, AggregateCountDistinctApprox:
 		return false
 	default:
 		return true
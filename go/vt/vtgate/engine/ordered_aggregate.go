@@ -127,6 +127,9 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 	if err != nil {
 		return nil, err
 	}
+	if err := trackRows(vcursor, result.Rows); err != nil {
+		return nil, err
+	}
 	if len(oa.Aggregates) == 0 {
 		return oa.executeGroupBy(result)
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/hll"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
@@ -636,6 +637,53 @@ func TestOrderedAggregateExecuteGtid(t *testing.T) {
 	utils.MustMatch(t, wantResult, result)
 }
 
+func TestOrderedAggregateCountDistinctApprox(t *testing.T) {
+	sketch := func(hashes ...uint64) sqltypes.Value {
+		var s hll.Sketch
+		for _, hash := range hashes {
+			s.Add(hash)
+		}
+		return sqltypes.MakeTrusted(sqltypes.VarBinary, s.Marshal())
+	}
+	fields := sqltypes.MakeTestFields("col|sketch", "int64|varbinary")
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{{
+			Fields: fields,
+			Rows: []sqltypes.Row{
+				// The sketches of the shards have common values.
+				{sqltypes.NewInt64(1), sketch(1<<60, 2<<60, 3<<60)},
+				{sqltypes.NewInt64(1), sketch(2<<60, 4<<60)},
+				{sqltypes.NewInt64(2), sketch(5 << 60)},
+				{sqltypes.NewInt64(2), sqltypes.NULL},
+			},
+		}},
+	}
+
+	oa := &OrderedAggregate{
+		Aggregates:  []*AggregateParams{NewAggregateParam(AggregateCountDistinctApprox, 1, "count(distinct sketch)", collations.MySQL8())},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0}},
+		Input:       fp,
+	}
+
+	result, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"col|count(distinct sketch)",
+			"int64|int64",
+		),
+		"1|4",
+		"2|1",
+	)
+	utils.MustMatch(t, wantResult, result)
+
+	fp.rewind()
+	fp.results[0].Rows[3][1] = sqltypes.NewVarBinary("not a sketch")
+	_, err = oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.ErrorContains(t, err, "failed to merge the approximate count of distinct values")
+}
+
 func TestCountDistinctOnVarchar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2|weight_string(c2)",
@@ -50,6 +50,9 @@ type (
 		QueryHints   sqlparser.QueryHints    // QueryHints stores any SET_VAR hints that influenced plan generation.
 		ParamsCount  uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
 		Optimized    atomic.Bool             // Prepared queries need to be optimized before the first execution
+		Source       *PlanSource             // Source records what a cached plan was built from, nil for prepared statements.
+		// VindexCandidates lists the columns without vindex the scatter routes of the plan filter on.
+		VindexCandidates []VindexCandidate
 
 		ExecCount    uint64 // ExecCount is how many times this plan has been executed.
 		ExecTime     uint64 // ExecTime is the total accumulated execution time in nanoseconds.
@@ -68,6 +71,25 @@ type (
 		Query           string                // Query is the original or normalized SQL statement used to build the plan.
 		SetVarComment   string                // SetVarComment holds any embedded SET_VAR hints within the query.
 		Collation       collations.ID         // Collation is the character collation ID that governs string comparison.
+		PlannerFlags    string                // PlannerFlags are the planner flags in effect when the plan was built.
+	}
+
+	// PlanSource records the inputs a cached plan was built from, so that the
+	// plan can be built again against a new vschema.
+	PlanSource struct {
+		Key          PlanKey // Key is the key of the plan in the plan cache.
+		Query        string  // Query is the query text before normalization.
+		Parameterize bool    // Parameterize tells whether the literals of the query were turned into bind variables.
+		Digest       string  // Digest is the digest of the query, only set when the plan has vindex candidates.
+	}
+
+	// VindexCandidate is a column of a sharded table which a scatter route
+	// filters on while the table has no vindex on it. A vindex on the column
+	// would let the route target the shards holding the matching rows.
+	VindexCandidate struct {
+		Keyspace string
+		Table    string
+		Column   string
 	}
 )
 
@@ -129,7 +151,7 @@ func getPlanType(p Primitive) PlanType {
 	switch prim := p.(type) {
 	case *SessionPrimitive, *SingleRow, *UpdateTarget, *VindexFunc:
 		return PlanLocal
-	case *Lock, *ReplaceVariables, *RevertMigration, *Rows:
+	case *Lock, *NextID, *ReplaceVariables, *RevertMigration, *Rows:
 		return PlanPassthrough
 	case *Send:
 		return getPlanTypeFromTarget(prim)
@@ -180,6 +202,9 @@ func getPlanType(p Primitive) PlanType {
 		return getPlanType(prim.Input)
 	case *RenameFields:
 		return getPlanType(prim.Input)
+	case *TableMaintenance:
+		// The ddl_strategy is only known at execution, the plan type is that of the direct execution.
+		return getPlanType(prim.Direct)
 	default:
 		return PlanComplex
 	}
@@ -255,7 +280,7 @@ func getPlanTypeForUpsert(prim *Upsert) PlanType {
 }
 
 func (pk PlanKey) DebugString() string {
-	return fmt.Sprintf("CurrentKeyspace: %s, TabletType: %s, Destination: %s, Query: %s, SetVarComment: %s, Collation: %d", pk.CurrentKeyspace, pk.TabletType.String(), pk.Destination, pk.Query, pk.SetVarComment, pk.Collation)
+	return fmt.Sprintf("CurrentKeyspace: %s, TabletType: %s, Destination: %s, Query: %s, SetVarComment: %s, Collation: %d, PlannerFlags: %s", pk.CurrentKeyspace, pk.TabletType.String(), pk.Destination, pk.Query, pk.SetVarComment, pk.Collation, pk.PlannerFlags)
 }
 
 func (pk PlanKey) Hash() theine.HashKey256 {
@@ -265,6 +290,7 @@ func (pk PlanKey) Hash() theine.HashKey256 {
 	_, _ = hasher.WriteString(pk.CurrentKeyspace)
 	_, _ = hasher.WriteString(pk.Destination)
 	_, _ = hasher.WriteString(pk.SetVarComment)
+	_, _ = hasher.WriteString(pk.PlannerFlags)
 	_, _ = hasher.WriteString(pk.Query)
 
 	var planKey theine.HashKey256
@@ -56,6 +56,26 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// TrackMemory accounts for size bytes of rows buffered by the query,
+		// and fails when the query goes above its memory limit.
+		TrackMemory(size int64) error
+
+		// UntrackMemory returns size bytes of rows the query no longer
+		// buffers, as they were spilled to disk.
+		UntrackMemory(size int64)
+
+		// SpillConfig returns how the sorts and hash joins of the streaming
+		// queries spill their rows to disk, nil meaning they never do.
+		SpillConfig() *SpillConfig
+
+		// MaxCrossShardCascadeRows returns the maximum number of parent rows
+		// a cross-shard foreign key cascade can modify. Zero means no limit.
+		MaxCrossShardCascadeRows() int
+
+		// LoadDataChunkRows returns the number of rows of the file of a
+		// LOAD DATA LOCAL INFILE inserted by each INSERT.
+		LoadDataChunkRows() int
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -134,6 +154,12 @@ type (
 
 		// CloneForMirroring clones the VCursor for re-use in mirroring queries to other keyspaces
 		CloneForMirroring(ctx context.Context) VCursor
+
+		// GetMirrorCompareResults returns true if the results of mirrored queries should be compared with the source results
+		GetMirrorCompareResults() bool
+
+		// GetMirrorMismatchLogRate returns the fraction of mirror result mismatches to log
+		GetMirrorMismatchLogRate() float64
 		//
 		// ReadTransaction reads the state of the given transaction from the metadata manager
 		ReadTransaction(ctx context.Context, transactionID string) (*querypb.TransactionMetadata, error)
@@ -150,6 +176,21 @@ type (
 
 		SetLastInsertID(uint64)
 
+		// ReplicationStaleness returns the staleness of the data of the
+		// tablets the queries for the shard are routed to.
+		ReplicationStaleness(keyspace, shard string) (time.Duration, error)
+
+		// FilteredReplicationLag returns the lag of the VReplication workflows
+		// writing to the keyspace, which is that of the most lagging primary
+		// of its shards. It fails if a primary runs no workflow.
+		FilteredReplicationLag(ctx context.Context, keyspace string) (time.Duration, error)
+
+		// NextIDs generates count consecutive ids of the given sequence, and returns the first one.
+		// The ids are served from the block of ids vtgate holds for the sequence, and fetch is called
+		// to fetch a new block of ids from the sequence table. With kOrdered, vtgate generates
+		// Snowflake-like ids instead.
+		NextIDs(ctx context.Context, sequence string, count int64, kOrdered bool, fetch func(ctx context.Context, blockSize int64) (int64, error)) (int64, error)
+
 		GetExecutionMetrics() *Metrics
 	}
 
@@ -193,6 +234,37 @@ type (
 		SetMigrationContext(string)
 		GetMigrationContext() string
 
+		// SetTabletTags sets the tags which the tablets must have to serve the
+		// queries of the session which do not run on the primary.
+		SetTabletTags(map[string]string)
+
+		// SetTenantID sets the tenant of the session, which selects the keyspace
+		// its queries are routed to through the tenant routing rules.
+		SetTenantID(string)
+
+		// SetPlannerFlags sets the planner flags of the session, which take
+		// precedence over the planner flags of its keyspace.
+		SetPlannerFlags(map[string]string)
+
+		// SetConsistentReads sets whether the SELECT statements of the session
+		// outside of a transaction read the shards from consistent snapshots.
+		SetConsistentReads(context.Context, bool) error
+
+		// SetPartialScatterReads sets whether the SELECT statements of the
+		// session outside of a transaction return the results of the shards
+		// they could reach when some of the shards they target are unreachable.
+		SetPartialScatterReads(context.Context, bool) error
+		GetPartialScatterReads() bool
+
+		// SetMaxStaleness sets the staleness of the data of the tablets which
+		// may serve the queries of the session which do not run on the primary.
+		SetMaxStaleness(time.Duration)
+
+		// SetDDLInTransaction sets how the DDL statements of the session
+		// are executed when it has an open transaction.
+		SetDDLInTransaction(vtgatepb.DDLInTransaction)
+		GetDDLInTransaction() vtgatepb.DDLInTransaction
+
 		GetSessionUUID() string
 
 		SetSessionEnableSystemSettings(context.Context, bool) error
@@ -235,6 +307,10 @@ type (
 		// will start a transaction on the query execution.
 		InTransaction() bool
 
+		// IsTxOpen returns true if the transaction of the session has
+		// open connections to shards.
+		IsTxOpen() bool
+
 		Commit(ctx context.Context) error
 	}
 
@@ -319,3 +395,20 @@ func (txNeeded) NeedsTransaction() bool {
 func (noFields) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	return &sqltypes.Result{}, nil
 }
+
+// trackRows accounts for the memory of the rows buffered by a primitive, see
+// VCursor.TrackMemory.
+func trackRows(vcursor VCursor, rows []sqltypes.Row) error {
+	return vcursor.TrackMemory(rowsSize(rows))
+}
+
+// rowsSize returns the number of bytes of the values of the rows.
+func rowsSize(rows []sqltypes.Row) int64 {
+	var size int64
+	for _, row := range rows {
+		for _, col := range row {
+			size += int64(col.Len())
+		}
+	}
+	return size
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var rollupReads = stats.NewCountersWithMultiLabels(
+	"RollupReads",
+	"Number of queries matching a rollup table, by rollup table and by whether they read the rollup or their base tables",
+	[]string{"Table", "Source"})
+
+const (
+	rollupSourceRollup = "Rollup"
+	rollupSourceBase   = "Base"
+)
+
+// Rollup executes a query matching a rollup table, which materializes an
+// aggregate query, either on the rollup or on the base tables of the query.
+// The rollup is read when the VReplication workflows writing to its keyspace
+// are running and lag less than MaxLag, and the session is not in a
+// transaction, whose writes the rollup would not reflect.
+type Rollup struct {
+	noTxNeeded
+
+	// Table is the keyspace-qualified name of the rollup table.
+	Table string
+	// Keyspace is the keyspace of the rollup table.
+	Keyspace string
+	// MaxLag is the maximum lag of the rollup, zero if it is not bounded.
+	MaxLag time.Duration
+
+	// Rollup reads the rollup table, Base the base tables.
+	Rollup Primitive
+	Base   Primitive
+}
+
+// GetFields implements the Primitive interface. The fields are those of the
+// base tables.
+func (r *Rollup) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return r.Base.GetFields(ctx, vcursor, bindVars)
+}
+
+// TryExecute implements the Primitive interface.
+func (r *Rollup) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	return vcursor.ExecutePrimitive(ctx, r.choose(ctx, vcursor), bindVars, wantfields)
+}
+
+// TryStreamExecute implements the Primitive interface.
+func (r *Rollup) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	return vcursor.StreamExecutePrimitive(ctx, r.choose(ctx, vcursor), bindVars, wantfields, callback)
+}
+
+// choose returns the primitive reading the rollup if it is fresh enough, and
+// the one reading the base tables otherwise.
+func (r *Rollup) choose(ctx context.Context, vcursor VCursor) Primitive {
+	if r.isFresh(ctx, vcursor) {
+		rollupReads.Add([]string{r.Table, rollupSourceRollup}, 1)
+		return r.Rollup
+	}
+	rollupReads.Add([]string{r.Table, rollupSourceBase}, 1)
+	return r.Base
+}
+
+func (r *Rollup) isFresh(ctx context.Context, vcursor VCursor) bool {
+	if vcursor.Session().InTransaction() {
+		return false
+	}
+	lag, err := vcursor.FilteredReplicationLag(ctx, r.Keyspace)
+	if err != nil {
+		return false
+	}
+	return r.MaxLag == 0 || lag <= r.MaxLag
+}
+
+// Inputs implements the Primitive interface.
+func (r *Rollup) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{r.Rollup, r.Base}, []map[string]any{{
+		inputName: "Rollup",
+	}, {
+		inputName: "Base",
+	}}
+}
+
+func (r *Rollup) description() PrimitiveDescription {
+	other := map[string]any{
+		"Table": r.Table,
+	}
+	if r.MaxLag != 0 {
+		other["MaxLag"] = r.MaxLag.String()
+	}
+	return PrimitiveDescription{
+		OperatorType: "Rollup",
+		Other:        other,
+	}
+}
+
+var _ Primitive = (*Rollup)(nil)
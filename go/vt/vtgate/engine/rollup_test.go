@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestRollup(t *testing.T) {
+	fields := sqltypes.MakeTestFields("region|orders", "varchar|int64")
+	tcases := []struct {
+		name   string
+		maxLag time.Duration
+		lag    time.Duration
+		lagErr error
+		inTx   bool
+		want   string
+	}{{
+		name:   "fresh",
+		maxLag: time.Minute,
+		lag:    time.Second,
+		want:   rollupSourceRollup,
+	}, {
+		name:   "lagging",
+		maxLag: time.Minute,
+		lag:    2 * time.Minute,
+		want:   rollupSourceBase,
+	}, {
+		name: "unbounded",
+		lag:  time.Hour,
+		want: rollupSourceRollup,
+	}, {
+		name:   "no workflow",
+		lagErr: errors.New("no VReplication stream running"),
+		want:   rollupSourceBase,
+	}, {
+		name: "in transaction",
+		inTx: true,
+		want: rollupSourceBase,
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			r := &Rollup{
+				Table:    "rollups.orders_by_region",
+				Keyspace: "rollups",
+				MaxLag:   tcase.maxLag,
+				Rollup:   &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "east|1")}},
+				Base:     &fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields, "east|2")}},
+			}
+			want := r.Base
+			if tcase.want == rollupSourceRollup {
+				want = r.Rollup
+			}
+			expected, err := want.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+			require.NoError(t, err)
+			want.(*fakePrimitive).rewind()
+
+			vc := &loggingVCursor{
+				filteredReplicationLag: tcase.lag,
+				filteredReplicationErr: tcase.lagErr,
+			}
+			vc.inTx = tcase.inTx
+			initial := rollupReads.Counts()["rollups_orders_by_region."+tcase.want]
+			qr, err := r.TryExecute(context.Background(), vc, nil, true)
+			require.NoError(t, err)
+			assert.Equal(t, expected, qr)
+			assert.EqualValues(t, 1, rollupReads.Counts()["rollups_orders_by_region."+tcase.want]-initial)
+			want.(*fakePrimitive).rewind()
+
+			var rows []sqltypes.Row
+			err = r.TryStreamExecute(context.Background(), vc, nil, true, func(qr *sqltypes.Result) error {
+				rows = append(rows, qr.Rows...)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, expected.Rows, rows)
+		})
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 var _ Primitive = (*Route)(nil)
@@ -121,6 +122,8 @@ type cxtKey int
 
 const (
 	IgnoreReserveTxn cxtKey = iota
+	// localInfile is the key of the LocalInfile of the context, see WithLocalInfile.
+	localInfile
 )
 
 func (route *Route) executeShards(
@@ -166,15 +169,17 @@ func (route *Route) executeShards(
 
 	if errs != nil {
 		errs = filterOutNilErrors(errs)
-		if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
+		if !route.allowPartialResults(vcursor, errs, len(rss)) {
 			return nil, vterrors.Aggregate(errs)
 		}
 
 		partialSuccessScatterQueries.Add(1)
 
-		for _, err := range errs {
-			serr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
-			vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(serr.Num), Message: err.Error()})
+		if route.ScatterErrorsAsWarnings {
+			for _, err := range errs {
+				serr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+				vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(serr.Num), Message: err.Error()})
+			}
 		}
 	}
 
@@ -189,6 +194,48 @@ func (route *Route) executeShards(
 	return result.Truncate(route.TruncateColumnCount), nil
 }
 
+// allowPartialResults returns whether the results of the shards which succeeded
+// are returned despite the errors of the others. They are with the
+// SCATTER_ERRORS_AS_WARNINGS directive, and with @@vitess_partial_scatter_reads
+// when the other shards are unreachable. The results are never returned when
+// all the shards failed.
+func (route *Route) allowPartialResults(vcursor VCursor, errs []error, shards int) bool {
+	if len(errs) == shards {
+		return false
+	}
+	if route.ScatterErrorsAsWarnings {
+		return true
+	}
+	if !partialScatterReads(vcursor) {
+		return false
+	}
+	for _, err := range errs {
+		if !isShardUnreachable(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// partialScatterReads returns whether the reads of the session return the
+// results of the shards they could reach when some of the shards are
+// unreachable. The reads of a transaction are never partial.
+func partialScatterReads(vcursor VCursor) bool {
+	return vcursor.Session().GetPartialScatterReads() && !vcursor.Session().InTransaction()
+}
+
+// isShardUnreachable returns whether the error of a shard is that it could not
+// be reached, for example because it has no healthy tablet or is being
+// reparented, as opposed to an error of the query.
+func isShardUnreachable(err error) bool {
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_CLUSTER_EVENT:
+		return true
+	default:
+		return false
+	}
+}
+
 func filterOutNilErrors(errs []error) []error {
 	var errors []error
 	for _, err := range errs {
@@ -261,13 +308,15 @@ func (route *Route) streamExecuteShards(
 			return callback(qr.Truncate(route.TruncateColumnCount))
 		})
 		if len(errs) > 0 {
-			if !route.ScatterErrorsAsWarnings || len(errs) == len(rss) {
+			if !route.allowPartialResults(vcursor, errs, len(rss)) {
 				return vterrors.Aggregate(errs)
 			}
 			partialSuccessScatterQueries.Add(1)
-			for _, err := range errs {
-				sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
-				vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+			if route.ScatterErrorsAsWarnings {
+				for _, err := range errs {
+					sErr := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+					vcursor.Session().RecordWarning(&querypb.QueryWarning{Code: uint32(sErr.Num), Message: err.Error()})
+				}
 			}
 		}
 		return nil
@@ -312,6 +361,7 @@ func (route *Route) mergeSort(
 	}
 
 	ms := createMergeSort(prims, route.OrderBy, route.ScatterErrorsAsWarnings, route.FetchLastInsertID)
+	ms.PartialResults = partialScatterReads(vcursor)
 	return vcursor.StreamExecutePrimitive(ctx, ms, bindVars, wantfields, func(qr *sqltypes.Result) error {
 		return callback(qr.Truncate(route.TruncateColumnCount))
 	})
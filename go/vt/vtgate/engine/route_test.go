@@ -1514,6 +1514,47 @@ func TestExecFail(t *testing.T) {
 		require.NoError(t, err, "unexpected ScatterErrorsAsWarnings error %v", err)
 		vc.ExpectWarnings(t, []*querypb.QueryWarning{{Code: uint32(sqlerror.ERQueryInterrupted), Message: "query timeout -20 (errno 1317) (sqlstate HY000)"}})
 	})
+
+	t.Run("partial scatter reads", func(t *testing.T) {
+		// Scatter succeeds if one of N is unreachable with partial scatter reads
+		sel := NewRoute(
+			Scatter,
+			&vindexes.Keyspace{
+				Name:    "ks",
+				Sharded: true,
+			},
+			"dummy_select",
+			"dummy_select_field",
+		)
+
+		vc := &loggingVCursor{
+			shards:              []string{"-20", "20-"},
+			results:             []*sqltypes.Result{defaultSelectResult},
+			partialScatterReads: true,
+			multiShardErrs: []error{
+				vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available for 'keyspace:\"ks\" shard:\"-20\"'"),
+				nil,
+			},
+		}
+		result, err := sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		expectResult(t, result, defaultSelectResult)
+		// The unreachable shards are listed in a warning by the executor.
+		vc.ExpectWarnings(t, nil)
+
+		// The errors of the queries are not ignored.
+		vc.Rewind()
+		vc.multiShardErrs = []error{vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "result error -20"), nil}
+		_, err = sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.EqualError(t, err, "result error -20")
+
+		// Neither are the errors of the reads of a transaction.
+		vc.Rewind()
+		vc.inTx = true
+		vc.multiShardErrs = []error{vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "shard -20 is unavailable"), nil}
+		_, err = sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.EqualError(t, err, "shard -20 is unavailable")
+	})
 }
 
 func TestSelectEqualUniqueMultiColumnVindex(t *testing.T) {
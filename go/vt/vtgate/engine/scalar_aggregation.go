@@ -68,6 +68,9 @@ func (sa *ScalarAggregate) TryExecute(ctx context.Context, vcursor VCursor, bind
 	if err != nil {
 		return nil, err
 	}
+	if err := trackRows(vcursor, result.Rows); err != nil {
+		return nil, err
+	}
 
 	agg, fields, err := newAggregation(result.Fields, sa.Aggregates)
 	if err != nil {
@@ -198,7 +198,7 @@ func (s *Send) description() PrimitiveDescription {
 // commitIfDDL commits any open transaction before executing the ddl query.
 func (s *Send) commitIfDDL(ctx context.Context, vcursor VCursor) error {
 	if s.IsDDL {
-		return vcursor.Session().Commit(ctx)
+		return commitBeforeDDL(ctx, vcursor)
 	}
 	return nil
 }
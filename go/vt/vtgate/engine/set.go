@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
@@ -34,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/sysvars"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
@@ -421,6 +423,10 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetClientFoundRows)
 	case sysvars.SkipQueryPlanCache.Name:
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetSkipQueryPlanCache)
+	case sysvars.ConsistentReads.Name:
+		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetConsistentReads)
+	case sysvars.PartialScatterReads.Name:
+		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetPartialScatterReads)
 	case sysvars.TxReadOnly.Name,
 		sysvars.TransactionReadOnly.Name:
 		// TODO (4127): This is a dangerous NOP.
@@ -442,6 +448,16 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid transaction_mode: %s", str)
 		}
 		vcursor.Session().SetTransactionMode(vtgatepb.TransactionMode(out))
+	case sysvars.DDLInTransaction.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		out, ok := vtgatepb.DDLInTransaction_value[strings.ToUpper(str)]
+		if !ok {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid ddl_in_transaction: %s", str)
+		}
+		vcursor.Session().SetDDLInTransaction(vtgatepb.DDLInTransaction(out))
 	case sysvars.Workload.Name:
 		str, err := svss.evalAsString(env, vcursor)
 		if err != nil {
@@ -470,6 +486,42 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid migration_context: %s", str)
 		}
 		vcursor.Session().SetMigrationContext(str)
+	case sysvars.TabletTags.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		tags, err := parseTabletTags(str)
+		if err != nil {
+			return err
+		}
+		vcursor.Session().SetTabletTags(tags)
+	case sysvars.MaxStaleness.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		maxStaleness, err := parseMaxStaleness(str)
+		if err != nil {
+			return err
+		}
+		vcursor.Session().SetMaxStaleness(maxStaleness)
+	case sysvars.TenantID.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		vcursor.Session().SetTenantID(str)
+	case sysvars.PlannerFlags.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		flags, err := plannerflags.Parse(str)
+		if err != nil {
+			return err
+		}
+		vcursor.Session().SetPlannerFlags(flags)
 	case sysvars.QueryTimeout.Name:
 		queryTimeout, err := svss.evalAsInt64(env, vcursor)
 		if err != nil {
@@ -592,3 +644,35 @@ func (v *VitessMetadata) Execute(ctx context.Context, vcursor VCursor, env *eval
 func (v *VitessMetadata) VariableName() string {
 	return v.Name
 }
+
+// parseTabletTags parses a comma-separated list of tablet tags, as key=value
+// pairs. An empty list clears the tags.
+func parseTabletTags(str string) (map[string]string, error) {
+	if strings.TrimSpace(str) == "" {
+		return nil, nil
+	}
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(str, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid tablet tag '%s': tablet tags are key=value pairs", pair)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// parseMaxStaleness parses a staleness bound, as a duration such as 30s. An
+// empty value clears the bound.
+func parseMaxStaleness(str string) (time.Duration, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return 0, nil
+	}
+	maxStaleness, err := time.ParseDuration(str)
+	if err != nil || maxStaleness < 0 {
+		return 0, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid max staleness '%s': the staleness is a duration, e.g. 30s", str)
+	}
+	return maxStaleness, nil
+}
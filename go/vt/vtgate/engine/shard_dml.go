@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/srvtopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// ShardDML is a DML which is executed by sending a single query to a single
+// shard, without any other work.
+type ShardDML struct {
+	Shard             *srvtopo.ResolvedShard
+	Query             *querypb.BoundQuery
+	FetchLastInsertID bool
+}
+
+// SingleShardDML returns the shard and the query of a DML primitive, if its
+// execution only consists of sending the query to a single shard: the updates
+// and deletes which change no lookup vindex, and the inserts which neither
+// generate sequence values nor create lookup vindex entries.
+// It returns nil for any other primitive, which must be executed as usual.
+// Since the query is sent by the caller, the DMLs of different shards can be
+// executed concurrently, as long as the DMLs of each shard are executed in
+// order.
+func SingleShardDML(ctx context.Context, vcursor VCursor, primitive Primitive, bindVars map[string]*querypb.BindVariable) (*ShardDML, error) {
+	switch primitive := primitive.(type) {
+	case *Update:
+		if primitive.isVindexModified() {
+			return nil, nil
+		}
+		return primitive.singleShardDML(ctx, vcursor, bindVars)
+	case *Delete:
+		if primitive.isVindexModified() {
+			return nil, nil
+		}
+		return primitive.singleShardDML(ctx, vcursor, bindVars)
+	case *Insert:
+		return primitive.singleShardDML(ctx, vcursor, bindVars)
+	}
+	return nil, nil
+}
+
+func (dml *DML) singleShardDML(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*ShardDML, error) {
+	if dml.QueryTimeout != 0 {
+		return nil, nil
+	}
+	rss, bvs, err := dml.findRoute(ctx, vcursor, bindVars)
+	if err != nil || len(rss) != 1 {
+		return nil, err
+	}
+	if err := allowOnlyPrimary(rss...); err != nil {
+		return nil, err
+	}
+	return &ShardDML{
+		Shard:             rss[0],
+		Query:             &querypb.BoundQuery{Sql: dml.Query, BindVariables: bvs[0]},
+		FetchLastInsertID: dml.FetchLastInsertID,
+	}, nil
+}
+
+func (ins *Insert) singleShardDML(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*ShardDML, error) {
+	if ins.QueryTimeout != 0 || ins.Generate != nil {
+		return nil, nil
+	}
+	var (
+		rss     []*srvtopo.ResolvedShard
+		queries []*querypb.BoundQuery
+		err     error
+	)
+	switch ins.Opcode {
+	case InsertUnsharded:
+		rss, _, err = vcursor.ResolveDestinations(ctx, ins.Keyspace.Name, nil, []key.ShardDestination{key.DestinationAllShards{}})
+		if err != nil || len(rss) != 1 {
+			return nil, err
+		}
+		queries = []*querypb.BoundQuery{{Sql: ins.Query, BindVariables: bindVars}}
+	case InsertSharded:
+		// The owned vindexes have their entries created while routing the
+		// rows, which must not be done before the insert is executed.
+		for _, colVindex := range ins.ColVindexes {
+			if colVindex.Owned {
+				return nil, nil
+			}
+		}
+		rss, queries, err = ins.getInsertShardedQueries(ctx, vcursor, bindVars)
+		if err != nil || len(rss) != 1 {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	if err := allowOnlyPrimary(rss...); err != nil {
+		return nil, err
+	}
+	return &ShardDML{
+		Shard:             rss[0],
+		Query:             queries[0],
+		FetchLastInsertID: ins.FetchLastInsertID,
+	}, nil
+}
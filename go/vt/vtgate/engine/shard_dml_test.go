@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestSingleShardDML(t *testing.T) {
+	vindex, _ := vindexes.CreateVindex("hash", "", nil)
+	ks := &vindexes.Keyspace{Name: "ks", Sharded: true}
+	newUpdate := func(opcode Opcode) *Update {
+		return &Update{
+			DML: &DML{
+				RoutingParameters: &RoutingParameters{
+					Opcode:   opcode,
+					Keyspace: ks,
+					Vindex:   vindex,
+					Values:   []evalengine.Expr{evalengine.NewLiteralInt(1)},
+				},
+				Query: "dummy_update",
+			},
+		}
+	}
+
+	vc := newTestVCursor("-20", "20-")
+	dml, err := SingleShardDML(context.Background(), vc, newUpdate(EqualUnique), map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	require.NotNil(t, dml)
+	assert.Equal(t, "-20", dml.Shard.Target.Shard)
+	assert.Equal(t, "dummy_update", dml.Query.Sql)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [type:INT64 value:"1"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+	})
+
+	// A scatter DML is executed as usual.
+	vc = newTestVCursor("-20", "20-")
+	dml, err = SingleShardDML(context.Background(), vc, newUpdate(Scatter), map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	assert.Nil(t, dml)
+
+	// So is a DML changing a lookup vindex.
+	upd := newUpdate(EqualUnique)
+	upd.ChangedVindexValues = map[string]*VindexValues{"lkp": {}}
+	dml, err = SingleShardDML(context.Background(), vc, upd, map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	assert.Nil(t, dml)
+
+	// An unsharded insert.
+	vc = newTestVCursor("0")
+	ins := newQueryInsert(InsertUnsharded, &vindexes.Keyspace{Name: "ks"}, "dummy_insert")
+	dml, err = SingleShardDML(context.Background(), vc, ins, map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	require.NotNil(t, dml)
+	assert.Equal(t, "0", dml.Shard.Target.Shard)
+	assert.Equal(t, "dummy_insert", dml.Query.Sql)
+
+	// An insert generating sequence values is executed as usual.
+	ins.Generate = &Generate{}
+	dml, err = SingleShardDML(context.Background(), vc, ins, map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	assert.Nil(t, dml)
+
+	// As is any other primitive.
+	dml, err = SingleShardDML(context.Background(), vc, &SingleRow{}, map[string]*querypb.BindVariable{})
+	require.NoError(t, err)
+	assert.Nil(t, dml)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// spillBatchRows is the number of rows of the results sent by a primitive
+// reading back the rows it spilled to disk.
+const spillBatchRows = 1000
+
+var (
+	spillFiles = stats.NewCountersWithSingleLabel(
+		"QuerySpillFiles",
+		"Number of temporary files the sorts and hash joins of the streaming queries spilled their rows to, by primitive",
+		"Primitive")
+	spillBytes = stats.NewCounter(
+		"QuerySpillBytes",
+		"Number of bytes of rows spilled to temporary files by the sorts and hash joins of the streaming queries")
+)
+
+// SpillConfig configures how the sorts and hash joins of the streaming
+// queries spill the rows they buffer to disk, so that they can process
+// more rows than fit in the memory of vtgate.
+type SpillConfig struct {
+	// Dir is the directory of the temporary files the rows are spilled to.
+	Dir string
+	// BufferSize is the number of bytes of rows a primitive buffers in
+	// memory before spilling them.
+	BufferSize int64
+	// Encrypt encrypts the spilled rows with a key generated for each
+	// file, which is only kept in memory.
+	Encrypt bool
+}
+
+// spillFile is a temporary file rows are written to, and then read back in
+// the same order. The file is removed as soon as it is created, so that its
+// space is reclaimed once it's closed, whatever happens to vtgate.
+type spillFile struct {
+	file *os.File
+	w    *bufio.Writer
+	r    *bufio.Reader
+
+	// block and iv encrypt the file with AES-CTR, when it is encrypted.
+	block cipher.Block
+	iv    []byte
+
+	buf []byte
+}
+
+func newSpillFile(cfg *SpillConfig, primitive string) (*spillFile, error) {
+	file, err := os.CreateTemp(cfg.Dir, "vtgate-spill-")
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to create a file to spill rows to")
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return nil, vterrors.Wrapf(err, "failed to create a file to spill rows to")
+	}
+
+	f := &spillFile{file: file}
+	var w io.Writer = file
+	if cfg.Encrypt {
+		key := make([]byte, 32)
+		f.iv = make([]byte, aes.BlockSize)
+		if _, err := rand.Read(key); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if _, err := rand.Read(f.iv); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if f.block, err = aes.NewCipher(key); err != nil {
+			file.Close()
+			return nil, err
+		}
+		w = cipher.StreamWriter{S: cipher.NewCTR(f.block, f.iv), W: file}
+	}
+	f.w = bufio.NewWriter(w)
+	spillFiles.Add(primitive, 1)
+	return f, nil
+}
+
+// spillRows writes the rows to a new spill file.
+func spillRows(cfg *SpillConfig, primitive string, rows []sqltypes.Row) (*spillFile, error) {
+	f, err := newSpillFile(cfg, primitive)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := f.write(row); err != nil {
+			f.close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// write appends the row to the file. Each value is written with its type,
+// so that the row can be read back without its fields.
+func (f *spillFile) write(row sqltypes.Row) error {
+	f.buf = binary.AppendUvarint(f.buf[:0], uint64(len(row)))
+	for _, col := range row {
+		f.buf = binary.AppendUvarint(f.buf, uint64(col.Type()))
+		f.buf = binary.AppendUvarint(f.buf, uint64(col.Len()))
+		f.buf = append(f.buf, col.Raw()...)
+	}
+	if _, err := f.w.Write(f.buf); err != nil {
+		return vterrors.Wrapf(err, "failed to spill rows")
+	}
+	spillBytes.Add(int64(len(f.buf)))
+	return nil
+}
+
+// rewind ends the writing of the file, and starts reading it back from its
+// first row.
+func (f *spillFile) rewind() error {
+	if err := f.w.Flush(); err != nil {
+		return vterrors.Wrapf(err, "failed to spill rows")
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return vterrors.Wrapf(err, "failed to read spilled rows")
+	}
+	var r io.Reader = f.file
+	if f.block != nil {
+		r = cipher.StreamReader{S: cipher.NewCTR(f.block, f.iv), R: f.file}
+	}
+	f.r = bufio.NewReader(r)
+	return nil
+}
+
+// next returns the next row of the file, or io.EOF after its last row.
+func (f *spillFile) next() (sqltypes.Row, error) {
+	n, err := binary.ReadUvarint(f.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to read spilled rows")
+	}
+	row := make(sqltypes.Row, n)
+	for i := range row {
+		typ, err := binary.ReadUvarint(f.r)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read spilled rows")
+		}
+		size, err := binary.ReadUvarint(f.r)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read spilled rows")
+		}
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(f.r, raw); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read spilled rows")
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), raw)
+	}
+	return row, nil
+}
+
+func (f *spillFile) close() {
+	if f != nil {
+		f.file.Close()
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestSpillFile(t *testing.T) {
+	rows := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"id|name|price",
+			"int64|varchar|decimal",
+		),
+		"1|secret|1.50",
+		"2||null",
+		"null|other secret|3",
+	).Rows
+
+	for _, encrypt := range []bool{false, true} {
+		dir := t.TempDir()
+		f, err := spillRows(&SpillConfig{Dir: dir, Encrypt: encrypt}, "Test", rows)
+		require.NoError(t, err)
+		defer f.close()
+
+		// The file is removed as soon as it's created.
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		require.NoError(t, f.rewind())
+		content, err := io.ReadAll(f.file)
+		require.NoError(t, err)
+		assert.Equal(t, !encrypt, bytes.Contains(content, []byte("secret")), "encrypt: %v", encrypt)
+
+		require.NoError(t, f.rewind())
+		var read []sqltypes.Row
+		for {
+			row, err := f.next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			read = append(read, row)
+		}
+		assert.Equal(t, sqltypes.RowsToProto3(rows), sqltypes.RowsToProto3(read), "encrypt: %v", encrypt)
+	}
+}
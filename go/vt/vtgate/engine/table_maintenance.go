@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/dynamicconfig"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var _ Primitive = (*TableMaintenance)(nil)
+
+// TableMaintenance represents an ANALYZE TABLE or an OPTIMIZE TABLE statement.
+// With a direct ddl_strategy the statement is sent as is to the tablets.
+// Otherwise, it is submitted to the Online DDL scheduler of every shard,
+// which runs it subject to throttling and reports its progress.
+type TableMaintenance struct {
+	noTxNeeded
+	noInputs
+
+	Keyspace *vindexes.Keyspace
+	// TargetDestination specifies the shards to submit the migrations to.
+	TargetDestination key.ShardDestination
+	// Statement is either an *sqlparser.Analyze or an *sqlparser.OptimizeTable.
+	Statement sqlparser.Statement
+
+	// Direct sends the statement to the tablets with a direct ddl_strategy.
+	Direct *Send
+
+	Config dynamicconfig.DDL
+}
+
+func (t *TableMaintenance) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType:      "TableMaintenance",
+		Keyspace:          t.Keyspace,
+		TargetDestination: t.TargetDestination,
+		Other: map[string]any{
+			"Query": sqlparser.String(t.Statement),
+		},
+	}
+}
+
+// TryExecute implements the Primitive interface
+func (t *TableMaintenance) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	ddlStrategySetting, err := schema.ParseDDLStrategy(vcursor.Session().GetDDLStrategy())
+	if err != nil {
+		return nil, err
+	}
+	if ddlStrategySetting.Strategy.IsDirect() {
+		return vcursor.ExecutePrimitive(ctx, t.Direct, bindVars, wantfields)
+	}
+	if !t.Config.OnlineEnabled() {
+		return nil, schema.ErrOnlineDDLDisabled
+	}
+	if err := commitBeforeDDL(ctx, vcursor); err != nil {
+		return nil, err
+	}
+	onlineDDLs, err := schema.NewTableMaintenanceOnlineDDLs(t.Keyspace.Name, t.Statement,
+		ddlStrategySetting, onlineDDLMigrationContext(vcursor), vcursor.Environment().Parser(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return submitOnlineDDLs(ctx, vcursor, t.Keyspace, t.TargetDestination, onlineDDLs, bindVars, wantfields)
+}
+
+// TryStreamExecute implements the Primitive interface
+func (t *TableMaintenance) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	result, err := t.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(result)
+}
+
+// GetFields implements the Primitive interface
+func (t *TableMaintenance) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return t.Direct.GetFields(ctx, vcursor, bindVars)
+}
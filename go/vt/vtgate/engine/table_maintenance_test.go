@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/key"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+type onlineDDLDisabledConfig struct{}
+
+func (onlineDDLDisabledConfig) DirectEnabled() bool {
+	return true
+}
+
+func (onlineDDLDisabledConfig) OnlineEnabled() bool {
+	return false
+}
+
+func newTestTableMaintenance(t *testing.T, query string) *TableMaintenance {
+	stmt, err := sqlparser.NewTestParser().Parse(query)
+	require.NoError(t, err)
+	ks := &vindexes.Keyspace{
+		Name:    "ks",
+		Sharded: true,
+	}
+	return &TableMaintenance{
+		Keyspace:          ks,
+		TargetDestination: key.DestinationAllShards{},
+		Statement:         stmt,
+		Direct: &Send{
+			Keyspace:          ks,
+			TargetDestination: key.DestinationAllShards{},
+			Query:             query,
+		},
+		Config: ddlConfig{},
+	}
+}
+
+func TestTableMaintenanceDirect(t *testing.T) {
+	tm := newTestTableMaintenance(t, "analyze table t")
+
+	vc := &loggingVCursor{shards: []string{"-80", "80-"}, ddlStrategy: "direct"}
+	_, err := tm.TryExecute(context.Background(), vc, nil, true)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		"ResolveDestinations ks [] Destinations:DestinationAllShards()",
+		"ExecuteMultiShard ks.-80: analyze table t {} ks.80-: analyze table t {} false false",
+	})
+}
+
+func TestTableMaintenanceOnline(t *testing.T) {
+	tcases := []struct {
+		query  string
+		tables []string
+		sqls   []string
+	}{
+		{
+			query:  "analyze table t",
+			tables: []string{"t"},
+			sqls:   []string{"analyze table t"},
+		},
+		{
+			query:  "optimize table t1, t2",
+			tables: []string{"t1", "t2"},
+			sqls:   []string{"alter table t1 force", "alter table t2 force"},
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			tm := newTestTableMaintenance(t, tcase.query)
+
+			var submitted []*schema.OnlineDDL
+			vc := &loggingVCursor{
+				shards:           []string{"-80", "80-"},
+				ddlStrategy:      "vitess --postpone-completion",
+				migrationContext: "maintenance",
+				onExecuteMultiShardFn: func(ctx context.Context, primitive Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit bool) {
+					require.Len(t, queries, 2)
+					assert.Equal(t, queries[0].Sql, queries[1].Sql)
+					stmt, err := sqlparser.NewTestParser().Parse(queries[0].Sql)
+					require.NoError(t, err)
+					onlineDDL, err := schema.OnlineDDLFromCommentedStatement(stmt)
+					require.NoError(t, err)
+					submitted = append(submitted, onlineDDL)
+				},
+			}
+			qr, err := tm.TryExecute(context.Background(), vc, nil, true)
+			require.NoError(t, err)
+			require.Len(t, qr.Rows, len(tcase.tables))
+			require.Len(t, submitted, len(tcase.tables))
+			for i, onlineDDL := range submitted {
+				assert.Equal(t, qr.Rows[i][0].ToString(), onlineDDL.UUID)
+				assert.Equal(t, tcase.tables[i], onlineDDL.Table)
+				assert.Equal(t, tcase.sqls[i], onlineDDL.SQL)
+				assert.Equal(t, schema.DDLStrategyVitess, onlineDDL.Strategy)
+				assert.Equal(t, "--postpone-completion", onlineDDL.Options)
+				assert.Equal(t, "maintenance", onlineDDL.MigrationContext)
+			}
+		})
+	}
+}
+
+func TestTableMaintenanceOnlineDisabled(t *testing.T) {
+	tm := newTestTableMaintenance(t, "optimize table t")
+	tm.Config = onlineDDLDisabledConfig{}
+
+	vc := &loggingVCursor{ddlStrategy: "vitess"}
+	_, err := tm.TryExecute(context.Background(), vc, nil, true)
+	require.ErrorIs(t, err, schema.ErrOnlineDDLDisabled)
+	vc.ExpectLog(t, nil)
+}
@@ -3684,6 +3684,22 @@ func (asm *assembler) Fn_Database() {
 	}, "FN DATABASE")
 }
 
+func (asm *assembler) Fn_REPLICATION_FRESHNESS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		keyspace := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		shard := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		staleness, err := env.VCursor().ReplicationStaleness(keyspace.string(), shard.string())
+		if err != nil {
+			env.vm.err = err
+			return 0
+		}
+		env.vm.stack[env.vm.sp-2] = env.vm.arena.newEvalFloat(staleness.Seconds())
+		env.vm.sp--
+		return 1
+	}, "FN VITESS_REPLICATION_FRESHNESS VARBINARY(SP-2), VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_Version() {
 	asm.adjustStack(1)
 	asm.emit(func(env *ExpressionEnv) int {
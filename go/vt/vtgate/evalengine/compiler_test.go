@@ -920,6 +920,7 @@ func TestBindVarLiteral(t *testing.T) {
 
 type testVcursor struct {
 	lastInsertID *uint64
+	staleness    map[string]time.Duration
 	env          *vtenv.Environment
 }
 
@@ -943,6 +944,14 @@ func (t *testVcursor) SetLastInsertID(id uint64) {
 	t.lastInsertID = &id
 }
 
+func (t *testVcursor) ReplicationStaleness(keyspace, shard string) (time.Duration, error) {
+	staleness, ok := t.staleness[keyspace+"/"+shard]
+	if !ok {
+		return 0, fmt.Errorf("no healthy tablet available for %s/%s", keyspace, shard)
+	}
+	return staleness, nil
+}
+
 var _ evalengine.VCursor = (*testVcursor)(nil)
 
 func TestLastInsertID(t *testing.T) {
@@ -1011,6 +1020,54 @@ func runTest(t *testing.T, expr sqlparser.Expr, cfg *evalengine.Config, tc struc
 	}
 }
 
+func TestReplicationFreshness(t *testing.T) {
+	var testCases = []struct {
+		expression string
+		result     string
+		err        string
+	}{
+		{
+			expression: `vitess_replication_freshness('ks', '-80')`,
+			result:     `FLOAT64(1.5)`,
+		}, {
+			expression: `vitess_replication_freshness('ks', concat('80', '-'))`,
+			result:     `FLOAT64(0)`,
+		}, {
+			expression: `vitess_replication_freshness('ks', null)`,
+			result:     `NULL`,
+		}, {
+			expression: `vitess_replication_freshness('other', '-80')`,
+			err:        `no healthy tablet available for other/-80`,
+		},
+	}
+
+	venv := vtenv.NewTestEnv()
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr, err := venv.Parser().ParseExpr(tc.expression)
+			require.NoError(t, err)
+
+			for _, noCompilation := range []bool{true, false} {
+				converted, err := evalengine.Translate(expr, &evalengine.Config{
+					Collation:     collations.CollationUtf8mb4ID,
+					NoCompilation: noCompilation,
+					Environment:   venv,
+				})
+				require.NoError(t, err)
+
+				vc := &testVcursor{env: venv, staleness: map[string]time.Duration{"ks/-80": 1500 * time.Millisecond, "ks/80-": 0}}
+				res, err := evalengine.NewExpressionEnv(context.Background(), nil, vc).Evaluate(converted)
+				if tc.err != "" {
+					require.EqualError(t, err, tc.err)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, res.Value(collations.CollationUtf8mb4ID).String())
+			}
+		})
+	}
+}
+
 func TestCompilerNonConstant(t *testing.T) {
 	var testCases = []struct {
 		expression string
@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/vt/callerid"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
 )
 
 type VCursor interface {
@@ -36,6 +37,9 @@ type VCursor interface {
 	SQLMode() string
 	Environment() *vtenv.Environment
 	SetLastInsertID(id uint64)
+	// ReplicationStaleness returns the staleness of the data of the tablets
+	// the queries for the shard are routed to.
+	ReplicationStaleness(keyspace, shard string) (time.Duration, error)
 }
 
 type (
@@ -143,6 +147,10 @@ func (e *emptyVCursor) SQLMode() string {
 }
 func (e *emptyVCursor) SetLastInsertID(_ uint64) {}
 
+func (e *emptyVCursor) ReplicationStaleness(_, _ string) (time.Duration, error) {
+	return 0, vterrors.VT12001("vitess_replication_freshness outside of vtgate")
+}
+
 func NewEmptyVCursor(env *vtenv.Environment, tz *time.Location) VCursor {
 	return &emptyVCursor{env: env, tz: tz}
 }
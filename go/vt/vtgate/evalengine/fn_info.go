@@ -76,3 +76,49 @@ func (*builtinDatabase) compile(c *compiler) (ctype, error) {
 func (call *builtinDatabase) constant() bool {
 	return false
 }
+
+type builtinReplicationFreshness struct {
+	CallExpr
+}
+
+var _ IR = (*builtinReplicationFreshness)(nil)
+
+func (call *builtinReplicationFreshness) eval(env *ExpressionEnv) (eval, error) {
+	keyspace, shard, err := call.arg2(env)
+	if keyspace == nil || shard == nil || err != nil {
+		return nil, err
+	}
+	staleness, err := env.VCursor().ReplicationStaleness(evalToBinary(keyspace).string(), evalToBinary(shard).string())
+	if err != nil {
+		return nil, err
+	}
+	return newEvalFloat(staleness.Seconds()), nil
+}
+
+func (call *builtinReplicationFreshness) compile(c *compiler) (ctype, error) {
+	keyspace, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+	shard, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck2(keyspace, shard)
+
+	if !keyspace.isTextual() {
+		c.asm.Convert_xb(2, sqltypes.VarBinary, nil)
+	}
+	if !shard.isTextual() {
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+	c.asm.Fn_REPLICATION_FRESHNESS()
+	c.asm.jumpDestination(skip)
+
+	return ctype{Type: sqltypes.Float64, Flag: (keyspace.Flag | shard.Flag) & flagNullable, Col: collationNumeric}, nil
+}
+
+func (call *builtinReplicationFreshness) constant() bool {
+	return false // the freshness changes with every evaluation
+}
@@ -211,6 +211,10 @@ type vcursor struct {
 
 func (vc *vcursor) SetLastInsertID(id uint64) {}
 
+func (vc *vcursor) ReplicationStaleness(_, _ string) (time.Duration, error) {
+	return 0, nil
+}
+
 var _ evalengine.VCursor = (*vcursor)(nil)
 
 func (vc *vcursor) GetKeyspace() string {
@@ -621,6 +621,11 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinVersion{CallExpr: call}, nil
+	case "vitess_replication_freshness":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinReplicationFreshness{CallExpr: call}, nil
 	case "md5":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -41,6 +41,7 @@ import (
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
@@ -75,6 +76,8 @@ var (
 	queryExecutions        = stats.NewCountersWithMultiLabels("QueryExecutions", "Counts queries executed at VTGate by query type, plan type, and tablet type.", []string{"Query", "Plan", "Tablet"})
 	queryRoutes            = stats.NewCountersWithMultiLabels("QueryRoutes", "Counts queries routed from VTGate to VTTablet by query type, plan type, and tablet type.", []string{"Query", "Plan", "Tablet"})
 	queryExecutionsByTable = stats.NewCountersWithMultiLabels("QueryExecutionsByTable", "Counts queries executed at VTGate per table by query type and table.", []string{"Query", "Table"})
+	queryTimingsByTable    = stats.NewMultiTimings("QueryTimingsByTable", "Timings of the queries executed at VTGate per keyspace and table, by query type. Only recorded with --enable-table-timings.", []string{"Keyspace", "Table", "Query"})
+	queryErrorsByTable     = stats.NewCountersWithMultiLabels("QueryErrorsByTable", "Counts the queries executed at VTGate which failed per keyspace and table, by query type. Only recorded with --enable-table-timings.", []string{"Keyspace", "Table", "Query"})
 
 	// commitMode records the timing of the commit phase of a transaction.
 	// It also tracks between different transaction mode i.e. Single, Multi and TwoPC
@@ -112,6 +115,15 @@ type (
 		AllowScatter        bool
 		WarmingReadsPercent int
 		QueryLogToFile      string
+		// PlanWarmupCount is the number of the most executed cached plans
+		// built again against a new vschema before it replaces the current one.
+		PlanWarmupCount int
+		// PlanWarmupTimeout bounds the time spent building these plans.
+		PlanWarmupTimeout time.Duration
+		// MaxResultRows are the maximum numbers of rows of the non-streaming
+		// results, by plan type or by keyspace and plan type, see
+		// parseMaxResultRows.
+		MaxResultRows map[string]int
 	}
 
 	Executor struct {
@@ -138,9 +150,28 @@ type (
 
 		// queryLogger is passed in for logging from this vtgate executor.
 		queryLogger *streamlog.StreamLogger[*logstats.LogStats]
+		// slowQueryLogger receives the queries slower than --slow-query-log-threshold.
+		slowQueryLogger *streamlog.StreamLogger[*slowQuery]
 
 		warmingReadsChannel chan bool
 
+		// resultSizes accounts the size of the results returned to each caller.
+		resultSizes *resultSizeTracker
+		// queryDigests aggregates the statistics of the sampled queries by digest.
+		queryDigests *queryDigestTracker
+		// planPins compares the plans of the pinned query digests to their pinned plans.
+		planPins *planPinTracker
+		// vindexAdvice counts the scatter queries filtering on columns without vindex.
+		vindexAdvice *vindexAdviceTracker
+		// processList tracks the client connections of the MySQL protocol server.
+		processList *processList
+		// tableLabels bounds the number of tables labeling the per-table metrics.
+		tableLabels *stats.LabelLimiter
+		// olapLimiter limits the number of concurrent queries of OLAP sessions.
+		olapLimiter *olapLimiter
+		// idGenerator generates the ids of vitess_next_id.
+		idGenerator *idGenerator
+
 		vConfig   econtext.VCursorConfig
 		ddlConfig dynamicconfig.DDL
 	}
@@ -155,6 +186,8 @@ var executorOnce sync.Once
 const pathQueryPlans = "/debug/query_plans"
 const pathScatterStats = "/debug/scatter_stats"
 const pathVSchema = "/debug/vschema"
+const pathQueryDigests = "/debug/query_digests"
+const pathPlanPins = "/debug/plan_pins"
 
 type PlanCacheKey = theine.HashKey256
 type PlanCache = theine.Store[PlanCacheKey, *engine.Plan]
@@ -192,6 +225,14 @@ func NewExecutor(
 		schemaTracker:       schemaTracker,
 		plans:               plans,
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
+		resultSizes:         newResultSizeTracker(resultSizeMaxCallers),
+		queryDigests:        newQueryDigestTracker(queryDigestMaxEntries, queryDigestResetInterval),
+		planPins:            newPlanPinTracker(),
+		vindexAdvice:        newVindexAdviceTracker(),
+		processList:         newProcessList(),
+		tableLabels:         stats.NewLabelLimiter(tableMetricsAllowlist, tableMetricsMaxTables),
+		olapLimiter:         newOlapLimiter(olapMaxConcurrency),
+		idGenerator:         newIDGenerator(idGenerationBlockSize, idGenerationNodeID),
 		ddlConfig:           ddlConfig,
 	}
 	// setting the vcursor config.
@@ -232,6 +273,8 @@ func NewExecutor(
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
+		servenv.HTTPHandle(pathQueryDigests, e)
+		servenv.HTTPHandle(pathPlanPins, e)
 	})
 	return e
 }
@@ -251,8 +294,21 @@ func (e *Executor) Execute(
 	trace.AnnotateSQL(span, sqlparser.Preview(sql))
 	defer span.Finish()
 
+	queriesByWorkload.Add(safeSession.GetOptions().GetWorkload().String(), 1)
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
-	stmtType, result, err := e.execute(ctx, mysqlCtx, safeSession, sql, bindVars, prepared, logStats)
+	logStats.QueryAttributes = safeSession.GetOptions().GetQueryAttributes()
+	logStats.ConnectAttributes = callinfo.MysqlConnectAttributes(ctx)
+	stmtType, result, err := e.execute(slowQueryLogContext(ctx, logStats), mysqlCtx, safeSession, sql, bindVars, prepared, logStats)
+	if err == nil && result != nil {
+		result, err = e.checkResultSize(ctx, safeSession, result)
+	}
+	// The GTIDs of the transactions committed by the query are returned in
+	// the session state changes, which the MySQL protocol sends in the OK
+	// packet to the clients tracking them.
+	if gtids := safeSession.TakeCommittedGtids(); gtids != "" && err == nil && result != nil {
+		result.SessionStateChanges = gtids
+	}
+	err = saveShardErrors(safeSession, logStats, err)
 	logStats.Error = err
 	if result == nil {
 		saveSessionStats(safeSession, stmtType, 0, 0, err)
@@ -275,6 +331,8 @@ func (e *Executor) Execute(
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	e.recordQueryDigest(sql, logStats, logStats.RowsReturned)
+	e.logSlowQuery(sql, logStats, logStats.RowsReturned)
 
 	err = errorTransform.TransformError(err)
 	err = vterrors.TruncateError(err, truncateErrorLen)
@@ -283,11 +341,14 @@ func (e *Executor) Execute(
 }
 
 type streaminResultReceiver struct {
-	mu           sync.Mutex
-	stmtType     sqlparser.StatementType
-	rowsAffected uint64
-	rowsReturned int
-	callback     func(*sqltypes.Result) error
+	mu            sync.Mutex
+	stmtType      sqlparser.StatementType
+	rowsAffected  uint64
+	rowsReturned  int
+	bytesReturned int64
+	// maxRows is the maximum number of rows returned, or 0.
+	maxRows  int
+	callback func(*sqltypes.Result) error
 }
 
 func (s *streaminResultReceiver) storeResultStats(typ sqlparser.StatementType, qr *sqltypes.Result) error {
@@ -295,7 +356,11 @@ func (s *streaminResultReceiver) storeResultStats(typ sqlparser.StatementType, q
 	defer s.mu.Unlock()
 	s.rowsAffected += qr.RowsAffected
 	s.rowsReturned += len(qr.Rows)
+	s.bytesReturned += resultSize(qr)
 	s.stmtType = typ
+	if s.maxRows > 0 && s.rowsReturned > s.maxRows {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "OLAP query returned more than %d rows", s.maxRows)
+	}
 	return s.callback(qr)
 }
 
@@ -314,18 +379,43 @@ func (e *Executor) StreamExecute(
 	trace.AnnotateSQL(span, sqlparser.Preview(sql))
 	defer span.Finish()
 
-	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
+	workload := safeSession.GetOptions().GetWorkload()
+	queriesByWorkload.Add(workload.String(), 1)
+	streamSize := e.streamSize(workload)
 	srr := &streaminResultReceiver{callback: callback}
+	if workload == querypb.ExecuteOptions_OLAP {
+		release, err := e.olapLimiter.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		srr.maxRows = olapMaxRows
+	}
+
+	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
+	logStats.QueryAttributes = safeSession.GetOptions().GetQueryAttributes()
+	logStats.ConnectAttributes = callinfo.MysqlConnectAttributes(ctx)
 	var err error
 
 	resultHandler := func(ctx context.Context, plan *engine.Plan, vc *econtext.VCursorImpl, bindVars map[string]*querypb.BindVariable, execStart time.Time) error {
 		var seenResults atomic.Bool
 		var resultMu sync.Mutex
 		result := &sqltypes.Result{}
+		// moreResults is set when the last result set ended with
+		// SERVER_MORE_RESULTS_EXISTS, as the result sets of a CALL do.
+		var moreResults bool
 		if canReturnRows(plan.QueryType) {
 			srr.callback = func(qr *sqltypes.Result) error {
 				resultMu.Lock()
 				defer resultMu.Unlock()
+				// A result set without fields following another one only
+				// holds a status, such as the one ending a CALL: send it as is.
+				if moreResults && len(qr.Fields) == 0 {
+					moreResults = qr.IsMoreResultsExists()
+					seenResults.Store(true)
+					return callback(qr)
+				}
+				moreResults = false
 				// If the row has field info, send it separately.
 				// TODO(sougou): this behavior is for handling tests because
 				// the framework currently sends all results as one packet.
@@ -344,7 +434,7 @@ func (e *Executor) StreamExecute(
 						byteCount += col.Len()
 					}
 
-					if byteCount >= e.config.StreamSize {
+					if byteCount >= streamSize {
 						err := callback(result)
 						seenResults.Store(true)
 						result = &sqltypes.Result{}
@@ -354,6 +444,19 @@ func (e *Executor) StreamExecute(
 						}
 					}
 				}
+
+				// The end of a result set followed by another one is sent
+				// along with its last rows, so that the boundary is kept.
+				if qr.IsMoreResultsExists() {
+					result.StatusFlags = sqltypes.ServerMoreResultsExists
+					err := callback(result)
+					seenResults.Store(true)
+					result = &sqltypes.Result{}
+					moreResults = true
+					if err != nil {
+						return err
+					}
+				}
 				return nil
 			}
 		}
@@ -392,14 +495,20 @@ func (e *Executor) StreamExecute(
 		logStats.ActiveKeyspace = vc.GetKeyspace()
 
 		e.updateQueryStats(plan.QueryType.String(), plan.Type.String(), vc.TabletType().String(), int64(logStats.ShardQueries), plan.TablesUsed)
+		e.updateTableTimings(plan.QueryType.String(), plan.TablesUsed, logStats.ExecuteTime, false)
+		e.recordVindexCandidates(plan)
 
 		return err
 	}
 
-	err = e.newExecute(ctx, mysqlCtx, safeSession, sql, bindVars, false, logStats, resultHandler, srr.storeResultStats)
+	err = e.newExecute(slowQueryLogContext(ctx, logStats), mysqlCtx, safeSession, sql, bindVars, false, logStats, resultHandler, srr.storeResultStats)
 
+	err = saveShardErrors(safeSession, logStats, err)
 	logStats.Error = err
 	saveSessionStats(safeSession, srr.stmtType, srr.rowsAffected, srr.rowsReturned, err)
+	// Streaming results are not buffered by vtgate, so they are only accounted
+	// for and never subject to --max-result-bytes.
+	e.resultSizes.record(resultSizeCaller(ctx), srr.bytesReturned, false)
 	if srr.rowsReturned > warnMemoryRows {
 		warnings.Add("ResultsExceeded", 1)
 		piiSafeSQL, err := e.env.Parser().RedactSQLQuery(sql)
@@ -416,6 +525,8 @@ func (e *Executor) StreamExecute(
 
 	logStats.SaveEndTime()
 	e.queryLogger.Send(logStats)
+	e.recordQueryDigest(sql, logStats, uint64(srr.rowsReturned))
+	e.logSlowQuery(sql, logStats, uint64(srr.rowsReturned))
 
 	err = errorTransform.TransformError(err)
 	err = vterrors.TruncateError(err, truncateErrorLen)
@@ -448,6 +559,33 @@ func saveSessionStats(safeSession *econtext.SafeSession, stmtType sqlparser.Stat
 	}
 }
 
+// saveShardErrors records in the session the errors of the shards which failed
+// a query executed on shards, for @@vitess_shard_errors. The errors of a query
+// which failed on several shards are described at the end of its error, so
+// that the clients can tell which shards failed, and whether it can be
+// retried. The shards which could not be reached by a read returning partial
+// results, with @@vitess_partial_scatter_reads, are listed in a warning.
+func saveShardErrors(safeSession *econtext.SafeSession, logStats *logstats.LogStats, err error) error {
+	shardErrors := safeSession.TakeShardErrors()
+	if logStats.ShardQueries == 0 {
+		return err
+	}
+	safeSession.SetShardErrors(econtext.ShardErrorsJSON(shardErrors))
+	if err == nil {
+		if len(shardErrors) > 0 && safeSession.GetPartialScatterReads() {
+			safeSession.RecordWarning(&querypb.QueryWarning{
+				Code:    uint32(sqlerror.ERUnknownError),
+				Message: "partial results, " + econtext.ShardErrorsSummary(shardErrors),
+			})
+		}
+		return nil
+	}
+	if len(shardErrors) < 2 {
+		return err
+	}
+	return vterrors.NewErrorf(vterrors.Code(err), vterrors.ErrState(err), "%s (%s)", strings.TrimSuffix(err.Error(), "\n"), econtext.ShardErrorsSummary(shardErrors))
+}
+
 func (e *Executor) execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, safeSession *econtext.SafeSession, sql string, bindVars map[string]*querypb.BindVariable, prepared bool, logStats *logstats.LogStats) (sqlparser.StatementType, *sqltypes.Result, error) {
 	var err error
 	var qr *sqltypes.Result
@@ -455,6 +593,9 @@ func (e *Executor) execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 	err = e.newExecute(ctx, mysqlCtx, safeSession, sql, bindVars, prepared, logStats, func(ctx context.Context, plan *engine.Plan, vc *econtext.VCursorImpl, bindVars map[string]*querypb.BindVariable, time time.Time) error {
 		stmtType = plan.QueryType
 		qr, err = e.executePlan(ctx, safeSession, plan, vc, bindVars, logStats, time)
+		if err == nil {
+			qr = e.limitResultRows(safeSession, plan, qr)
+		}
 		return err
 	}, func(typ sqlparser.StatementType, result *sqltypes.Result) error {
 		stmtType = typ
@@ -523,6 +664,24 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[key] = sqltypes.StringBindVariable(session.MigrationContext)
 		case sysvars.SessionUUID.Name:
 			bindVars[key] = sqltypes.StringBindVariable(session.SessionUUID)
+		case sysvars.TabletTags.Name:
+			bindVars[key] = sqltypes.StringBindVariable(sortedPairsString(session.GetTabletTags()))
+		case sysvars.TenantID.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.GetTenantID())
+		case sysvars.PlannerFlags.Name:
+			bindVars[key] = sqltypes.StringBindVariable(sortedPairsString(session.GetPlannerFlags()))
+		case sysvars.ConsistentReads.Name:
+			bindVars[key] = sqltypes.BoolBindVariable(session.GetConsistentReads())
+		case sysvars.ConsistentReadPositions.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.GetConsistentReadPositions())
+		case sysvars.PartialScatterReads.Name:
+			bindVars[key] = sqltypes.BoolBindVariable(session.GetPartialScatterReads())
+		case sysvars.MaxStaleness.Name:
+			bindVars[key] = sqltypes.StringBindVariable(maxStalenessString(session.GetMaxStaleness()))
+		case sysvars.ShardErrors.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.GetShardErrors())
+		case sysvars.DDLInTransaction.Name:
+			bindVars[key] = sqltypes.StringBindVariable(session.GetDDLInTransaction().String())
 		case sysvars.SessionEnableSystemSettings.Name:
 			bindVars[key] = sqltypes.BoolBindVariable(session.EnableSystemSettings)
 		case sysvars.ReadAfterWriteGTID.Name:
@@ -597,6 +756,26 @@ func ifOptionsExist(session *econtext.SafeSession, f func(*querypb.ExecuteOption
 	}
 }
 
+// maxStalenessString returns the staleness bound of the session, or an empty
+// string if it has none.
+func maxStalenessString(maxStaleness time.Duration) string {
+	if maxStaleness == 0 {
+		return ""
+	}
+	return maxStaleness.String()
+}
+
+// sortedPairsString returns the map, e.g. the tablet tags or the planner
+// flags of the session, as sorted key=value pairs.
+func sortedPairsString(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 func ifReadAfterWriteExist(session *econtext.SafeSession, f func(*vtgatepb.ReadAfterWrite)) {
 	raw := session.ReadAfterWrite
 	if raw != nil {
@@ -793,6 +972,18 @@ func (e *Executor) ShowVitessMetadata(ctx context.Context, filter *sqlparser.Sho
 
 type tabletFilter func(tablet *topodatapb.Tablet, servingState string, primaryTermStartTime int64) bool
 
+// ReplicationStaleness returns the staleness of the data of the tablets the
+// queries for the target are routed to.
+func (e *Executor) ReplicationStaleness(target *querypb.Target) (time.Duration, error) {
+	return e.scatterConn.gateway.ReplicationStaleness(target)
+}
+
+// FilteredReplicationLag returns the lag of the VReplication workflows of the
+// primary of the target's shard.
+func (e *Executor) FilteredReplicationLag(target *querypb.Target) (time.Duration, error) {
+	return e.scatterConn.gateway.FilteredReplicationLag(target)
+}
+
 func (e *Executor) ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error) {
 	showVitessShardsFilters := func(filter *sqlparser.ShowFilter) ([]func(string) bool, []func(string, *topodatapb.ShardReference) bool) {
 		keyspaceFilters := []func(string) bool{}
@@ -1072,15 +1263,26 @@ func (e *Executor) VSchema() *vindexes.VSchema {
 	return e.vschema
 }
 
-// SaveVSchema updates the vschema and stats
+// SaveVSchema updates the vschema and stats. The most executed cached plans
+// are built again against the new vschema before the swap, see warmupPlans.
 func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
+	warmed := e.warmupPlans(vschema)
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	if vschema != nil {
 		e.vschema = vschema
 	}
 	e.vschemaStats = stats
+	if e.vschema != nil {
+		e.planPins.prune(e.vschema)
+		e.vindexAdvice.prune(e.vschema)
+		e.saveTabletTypeFallbacks(e.vschema)
+	}
 	e.ClearPlans()
+	for key, plan := range warmed {
+		e.plans.Set(key, plan, 0, e.epoch.Load())
+	}
 
 	if vschemaCounters != nil {
 		vschemaCounters.Add("Reload", 1)
@@ -1095,6 +1297,21 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	}
 }
 
+// saveTabletTypeFallbacks passes the tablet type fallbacks of the keyspaces of
+// the vschema to the gateway.
+func (e *Executor) saveTabletTypeFallbacks(vschema *vindexes.VSchema) {
+	if e.resolver == nil || e.resolver.scatterConn == nil || e.resolver.scatterConn.gateway == nil {
+		return
+	}
+	fallbacks := make(map[string][]topodatapb.TabletType)
+	for name, ks := range vschema.Keyspaces {
+		if len(ks.TabletTypeFallbacks) > 0 {
+			fallbacks[name] = ks.TabletTypeFallbacks
+		}
+	}
+	e.resolver.scatterConn.gateway.SetTabletTypeFallbacks(fallbacks)
+}
+
 // ParseDestinationTarget parses destination target string and sets default keyspace if possible.
 func (e *Executor) ParseDestinationTarget(targetString string) (string, topodatapb.TabletType, key.ShardDestination, error) {
 	return econtext.ParseDestinationTarget(targetString, defaultTabletType, e.VSchema())
@@ -1211,6 +1428,7 @@ func (e *Executor) applyQueryHints(vcursor *econtext.VCursorImpl, plan *engine.P
 	vcursor.SetWorkloadName(qh.Workload)
 	vcursor.SetPriority(qh.Priority)
 	vcursor.SetExecQueryTimeout(qh.Timeout)
+	vcursor.SetCanary(qh.CanaryShard, qh.CanaryContinue)
 }
 
 func (e *Executor) getCachedOrBuildPlan(
@@ -1223,10 +1441,12 @@ func (e *Executor) getCachedOrBuildPlan(
 	planKey engine.PlanKey,
 	ignoreCache bool,
 ) (plan *engine.Plan, cached bool, stmt sqlparser.Statement, err error) {
+	rawQuery := query
 	stmt, reservedVars, err := parseAndValidateQuery(query, e.env.Parser())
 	if err != nil {
 		return nil, false, nil, err
 	}
+	query, stmt, reservedVars = e.applyRewriteRules(vcursor.GetVSchema(), query, stmt, reservedVars)
 
 	defer func() {
 		if err == nil {
@@ -1277,17 +1497,27 @@ func (e *Executor) getCachedOrBuildPlan(
 	}
 
 	planCachable := sqlparser.CachePlan(stmt) && vcursor.CachePlan()
-	if planCachable && !ignoreCache {
-		if !preparedPlan {
-			// build Plan key
-			planKey = buildPlanKey(ctx, vcursor, query, setVarComment)
+	if planCachable && !preparedPlan {
+		// build Plan key
+		planKey = buildPlanKey(ctx, vcursor, query, setVarComment)
+	}
+	build := func() (*engine.Plan, error) {
+		plan, err := e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds, qh, paramsCount)
+		if err == nil && planCachable && !preparedPlan {
+			plan.Source = &engine.PlanSource{Key: planKey, Query: rawQuery, Parameterize: parameterize}
+			plan.VindexCandidates = vindexCandidates(e.env.Parser(), vcursor.GetVSchema(), plan)
+			if len(plan.VindexCandidates) > 0 {
+				plan.Source.Digest = queryDigest(queryDigestText(e.env.Parser(), rawQuery, plan.QueryType.String()))
+			}
+			plan = e.applyPlanPin(vcursor.GetVSchema(), rawQuery, plan)
 		}
-		plan, cached, err = e.plans.GetOrLoad(planKey.Hash(), e.epoch.Load(), func() (*engine.Plan, error) {
-			return e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds, qh, paramsCount)
-		})
+		return plan, err
+	}
+	if planCachable && !ignoreCache {
+		plan, cached, err = e.plans.GetOrLoad(planKey.Hash(), e.epoch.Load(), build)
 		return plan, cached, stmt, err
 	}
-	plan, err = e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds, qh, paramsCount)
+	plan, err = build()
 	return plan, false, stmt, err
 }
 
@@ -1301,6 +1531,7 @@ func buildPlanKey(ctx context.Context, vcursor *econtext.VCursorImpl, query stri
 		Query:           query,
 		SetVarComment:   setVarComment,
 		Collation:       vcursor.ConnCollation(),
+		PlannerFlags:    vcursor.PlannerFlags().String(),
 	}
 }
 
@@ -1377,6 +1608,10 @@ func (e *Executor) ServeHTTP(response http.ResponseWriter, request *http.Request
 		returnAsJSON(response, e.VSchema())
 	case pathScatterStats:
 		e.WriteScatterStats(response)
+	case pathQueryDigests:
+		e.serveQueryDigests(response, request)
+	case pathPlanPins:
+		returnAsJSON(response, e.planPins.statusList())
 	default:
 		response.WriteHeader(http.StatusNotFound)
 	}
@@ -1413,7 +1648,29 @@ func (e *Executor) updateQueryStats(queryType, planType, tabletType string, shar
 	queryExecutions.Add([]string{queryType, planType, tabletType}, 1)
 	queryRoutes.Add([]string{queryType, planType, tabletType}, shards)
 	for _, table := range tables {
-		queryExecutionsByTable.Add([]string{queryType, table}, 1)
+		// The tables outside of --table-metrics-allowlist, or above
+		// --table-metrics-max-tables, are accounted as "other".
+		queryExecutionsByTable.Add([]string{queryType, e.tableLabels.Value(table)}, 1)
+	}
+}
+
+// updateTableTimings records the execution time of the query, and whether it
+// failed, for each keyspace and table it used, if --enable-table-timings is set.
+func (e *Executor) updateTableTimings(queryType string, tables []string, execTime time.Duration, failed bool) {
+	if !enableTableTimings {
+		return
+	}
+	for _, table := range tables {
+		table = e.tableLabels.Value(table)
+		keyspace, name, ok := strings.Cut(table, ".")
+		if !ok {
+			// The tables accounted as "other" have no keyspace.
+			keyspace = table
+		}
+		queryTimingsByTable.Add([]string{keyspace, name, queryType}, execTime)
+		if failed {
+			queryErrorsByTable.Add([]string{keyspace, name, queryType}, 1)
+		}
 	}
 }
 
@@ -1545,6 +1802,29 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 		WarmingReadsPercent: e.config.WarmingReadsPercent,
 		WarmingReadsTimeout: warmingReadsQueryTimeout,
 		WarmingReadsChannel: e.warmingReadsChannel,
+
+		MirrorCompareResults:  mirrorCompareResults,
+		MirrorMismatchLogRate: mirrorMismatchLogRate,
+
+		MaxCrossShardCascadeRows: maxCrossShardCascadeRows,
+		LoadDataChunkRows:        loadDataChunkRows,
+
+		QueryMemoryLimit: queryMemoryLimit,
+		MemoryBudget:     econtext.NewMemoryBudget(globalQueryMemoryLimit),
+		Spill:            querySpillConfig(),
+	}
+}
+
+// querySpillConfig returns how the streaming queries spill the rows of their
+// sorts and hash joins to disk, nil when they never do.
+func querySpillConfig() *engine.SpillConfig {
+	if querySpillDir == "" {
+		return nil
+	}
+	return &engine.SpillConfig{
+		Dir:        querySpillDir,
+		BufferSize: querySpillBufferSize,
+		Encrypt:    querySpillEncryption,
 	}
 }
 
@@ -1645,7 +1925,9 @@ func parseAndValidateQuery(query string, parser *sqlparser.Parser) (sqlparser.St
 
 // ExecuteMultiShard implements the IExecutor interface
 func (e *Executor) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *econtext.SafeSession, autocommit bool, ignoreMaxMemoryRows bool, resultsObserver econtext.ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error) {
-	return e.scatterConn.ExecuteMultiShard(ctx, primitive, rss, queries, session, autocommit, ignoreMaxMemoryRows, resultsObserver, fetchLastInsertID)
+	qr, errs = e.scatterConn.ExecuteMultiShard(ctx, primitive, rss, queries, session, autocommit, ignoreMaxMemoryRows, resultsObserver, fetchLastInsertID)
+	e.recordShardResultsSize(ctx, errs)
+	return qr, errs
 }
 
 // StreamExecuteMulti implements the IExecutor interface
@@ -1653,6 +1935,18 @@ func (e *Executor) StreamExecuteMulti(ctx context.Context, primitive engine.Prim
 	return e.scatterConn.StreamExecuteMulti(ctx, primitive, query, rss, vars, session, autocommit, callback, resultsObserver, fetchLastInsertID)
 }
 
+// ExecuteConsistentRead implements the IExecutor interface
+func (e *Executor) ExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *econtext.SafeSession, ignoreMaxMemoryRows bool, resultsObserver econtext.ResultsObserver) (*sqltypes.Result, []error) {
+	qr, errs := e.scatterConn.ExecuteConsistentRead(ctx, primitive, rss, queries, session, ignoreMaxMemoryRows, resultsObserver)
+	e.recordShardResultsSize(ctx, errs)
+	return qr, errs
+}
+
+// StreamExecuteConsistentRead implements the IExecutor interface
+func (e *Executor) StreamExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, vars []map[string]*querypb.BindVariable, session *econtext.SafeSession, callback func(reply *sqltypes.Result) error, resultsObserver econtext.ResultsObserver) []error {
+	return e.scatterConn.StreamExecuteConsistentRead(ctx, primitive, query, rss, vars, session, callback, resultsObserver)
+}
+
 // ExecuteLock implements the IExecutor interface
 func (e *Executor) ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *econtext.SafeSession, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error) {
 	return e.scatterConn.ExecuteLock(ctx, rs, query, session, lockFuncType)
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"time"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// recordCanaryWarning reports the canary shard a canary execution of a query
+// executed on, how long it took, and how to continue the execution on the
+// shards it skipped.
+func recordCanaryWarning(safeSession *econtext.SafeSession, vcursor *econtext.VCursorImpl, execStart time.Time) {
+	shard, skipped, ok := vcursor.CanaryShard()
+	if !ok {
+		return
+	}
+	elapsed := time.Since(execStart)
+	var message string
+	if shard == "" {
+		message = fmt.Sprintf("canary execution did not reach any shard, executed in %v", elapsed)
+	} else {
+		message = fmt.Sprintf("canary execution on shard %s executed in %v, skipping %d shards: use the /*vt+ %s=%s */ query directive to execute the query on the remaining shards",
+			shard, elapsed, skipped, sqlparser.DirectiveCanaryContinue, shard)
+	}
+	warnings.Add("CanaryExecution", 1)
+	safeSession.RecordWarning(&querypb.QueryWarning{Message: message})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestExecutorCanaryShard(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	// The canary execution runs on the first shard only.
+	initialCanaries := warnings.Counts()["CanaryExecution"]
+	_, err := executorExecSession(ctx, executor, session, "delete /*vt+ CANARY_SHARD */ from user_extra", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+	assert.EqualValues(t, 0, sbc2.ExecCount.Load())
+	require.Len(t, session.Warnings, 1)
+	assert.Contains(t, session.Warnings[0].Message, "canary execution on shard -20 executed in")
+	assert.Contains(t, session.Warnings[0].Message, "skipping 7 shards: use the /*vt+ CANARY_CONTINUE=-20 */ query directive")
+	assert.Equal(t, initialCanaries+1, warnings.Counts()["CanaryExecution"])
+
+	// The continuation runs on all the shards but the canary shard.
+	_, err = executorExecSession(ctx, executor, session, "delete /*vt+ CANARY_CONTINUE=-20 */ from user_extra", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+	assert.EqualValues(t, 1, sbc2.ExecCount.Load())
+	assert.Empty(t, session.Warnings)
+
+	// The canary shard may be named.
+	_, err = executorExecSession(ctx, executor, session, "update /*vt+ CANARY_SHARD=40-60 */ user_extra set extra_id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+	assert.EqualValues(t, 2, sbc2.ExecCount.Load())
+	require.Len(t, session.Warnings, 1)
+	assert.Contains(t, session.Warnings[0].Message, "canary execution on shard 40-60 executed in")
+
+	// Canary executions are only supported for DMLs.
+	_, err = executorExecSession(ctx, executor, session, "select /*vt+ CANARY_SHARD */ id from user", nil)
+	assert.ErrorIs(t, err, sqlparser.ErrInvalidCanary)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// batchedDML is a DML of a batch grouped by shard.
+type batchedDML struct {
+	sql       string
+	plan      *engine.Plan
+	vcursor   *econtext.VCursorImpl
+	bindVars  map[string]*querypb.BindVariable
+	logStats  *logstats.LogStats
+	dml       *engine.ShardDML
+	execStart time.Time
+
+	executed bool
+	result   *sqltypes.Result
+	err      error
+}
+
+// ExecuteDMLBatch executes the single-shard DMLs starting the queries of a
+// multi-statement query in a transaction, grouped by shard: the DMLs of each
+// shard are sent in order on its connection, and the shards are executed
+// concurrently, instead of waiting for each DML to complete before sending the
+// next one. It returns the results of the DMLs, or no result if the queries do
+// not start with at least two such DMLs, in which case nothing was executed.
+// If a DML fails, the results of the DMLs before it are returned with its
+// error. The DMLs following it are not executed, except the ones already sent
+// to other shards, in which case the transaction is rolled back.
+func (e *Executor) ExecuteDMLBatch(
+	ctx context.Context,
+	method string,
+	safeSession *econtext.SafeSession,
+	queries []string,
+) ([]*sqltypes.Result, error) {
+	ctx = withTabletTags(ctx, safeSession.GetTabletTags())
+	if err := e.startTxIfNecessary(ctx, safeSession); err != nil {
+		return nil, err
+	}
+	// The transactions limited to a single shard fail on the first DML of
+	// another shard, which must be the first in the order of the queries.
+	txMode := safeSession.TransactionMode
+	if txMode == vtgatepb.TransactionMode_UNSPECIFIED {
+		txMode = transactionMode.Get()
+	}
+	if !safeSession.InTransaction() || txMode == vtgatepb.TransactionMode_SINGLE {
+		return nil, nil
+	}
+
+	batch := e.planDMLBatch(ctx, method, safeSession, queries)
+	if len(batch) < 2 {
+		return nil, nil
+	}
+	queriesByWorkload.Add(safeSession.GetOptions().GetWorkload().String(), int64(len(batch)))
+	safeSession.ClearWarnings()
+	safeSession.SetAutocommittable(false)
+
+	var shards []string
+	dmlsByShard := make(map[string][]int)
+	for i, b := range batch {
+		shard := topoproto.KeyspaceShardString(b.dml.Shard.Target.Keyspace, b.dml.Shard.Target.Shard)
+		if _, ok := dmlsByShard[shard]; !ok {
+			shards = append(shards, shard)
+		}
+		dmlsByShard[shard] = append(dmlsByShard[shard], i)
+	}
+
+	// failed is the position of the first DML which failed: the DMLs after it
+	// are no longer sent to the shards.
+	var failed atomic.Int64
+	failed.Store(int64(len(batch)))
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		wg.Add(1)
+		go func(dmls []int) {
+			defer wg.Done()
+			for _, i := range dmls {
+				if int64(i) > failed.Load() {
+					return
+				}
+				b := batch[i]
+				execCtx, cancel := b.vcursor.GetContextWithTimeOut(ctx)
+				b.execStart = time.Now()
+				b.result, b.err = b.vcursor.ExecuteShardDML(execCtx, b.plan.Instructions, b.dml)
+				cancel()
+				b.executed = true
+				if b.err != nil {
+					for {
+						pos := failed.Load()
+						if int64(i) >= pos || failed.CompareAndSwap(pos, int64(i)) {
+							break
+						}
+					}
+					return
+				}
+			}
+		}(dmlsByShard[shard])
+	}
+	wg.Wait()
+
+	var (
+		results []*sqltypes.Result
+		err     error
+		last    *batchedDML
+	)
+	pos := int(failed.Load())
+	for i, b := range batch {
+		if !b.executed {
+			continue
+		}
+		e.setLogStats(b.logStats, b.plan, b.vcursor, b.execStart, b.err, b.result)
+		if b.err == nil {
+			recordCanaryWarning(safeSession, b.vcursor, b.execStart)
+		}
+		if i > pos {
+			// The DML was executed after the failed one: its changes are
+			// reverted by rolling back the transaction.
+			safeSession.SetRollbackCommand()
+			continue
+		}
+		last = b
+		if i == pos {
+			err = b.err
+			continue
+		}
+		if b.result.InsertIDUpdated() {
+			safeSession.LastInsertId = b.result.InsertID
+		}
+		results = append(results, b.result)
+	}
+	if err != nil {
+		err = e.rollbackExecIfNeeded(ctx, safeSession, last.bindVars, last.logStats, err)
+	}
+	err = saveShardErrors(safeSession, last.logStats, err)
+	if err == nil {
+		saveSessionStats(safeSession, last.plan.QueryType, last.result.RowsAffected, 0, nil)
+	} else {
+		saveSessionStats(safeSession, last.plan.QueryType, 0, 0, err)
+	}
+
+	for i, b := range batch {
+		if !b.executed {
+			continue
+		}
+		if i == pos {
+			b.logStats.Error = err
+		}
+		b.logStats.SaveEndTime()
+		e.queryLogger.Send(b.logStats)
+		e.recordQueryDigest(b.sql, b.logStats, b.logStats.RowsReturned)
+		e.logSlowQuery(b.sql, b.logStats, b.logStats.RowsReturned)
+	}
+
+	if err != nil {
+		err = errorTransform.TransformError(err)
+		err = vterrors.TruncateError(err, truncateErrorLen)
+	}
+	return results, err
+}
+
+// planDMLBatch plans the single-shard DMLs starting the queries, which can be
+// executed concurrently with the DMLs of the other shards. It stops at the
+// first query which cannot, for example because it depends on the results of
+// the previous queries through last_insert_id() or row_count(): that query and
+// the following ones are executed as usual.
+func (e *Executor) planDMLBatch(ctx context.Context, method string, safeSession *econtext.SafeSession, queries []string) []*batchedDML {
+	var batch []*batchedDML
+	for _, sql := range queries {
+		logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), nil, streamlog.GetQueryLogConfig())
+		logStats.QueryAttributes = safeSession.GetOptions().GetQueryAttributes()
+		logStats.ConnectAttributes = callinfo.MysqlConnectAttributes(ctx)
+		bindVars := make(map[string]*querypb.BindVariable)
+		plan, vcursor, _, err := e.fetchOrCreatePlan(ctx, safeSession, sql, bindVars, e.config.Normalize, false, logStats, true)
+		e.logPlanningFinished(logStats, plan)
+		if err != nil || len(plan.Warnings) > 0 || len(plan.BindVarNeeds.NeedFunctionResult) > 0 || shouldBlockQueries(plan, safeSession) {
+			break
+		}
+		switch plan.QueryType {
+		case sqlparser.StmtInsert, sqlparser.StmtReplace, sqlparser.StmtUpdate, sqlparser.StmtDelete:
+		default:
+			return batch
+		}
+		if err := e.addNeededBindVars(vcursor, plan.BindVarNeeds, bindVars, safeSession); err != nil {
+			break
+		}
+		// The queries which cannot be grouped, or whose routing fails, are
+		// executed as usual, which reports their errors.
+		dml, err := engine.SingleShardDML(ctx, vcursor, plan.Instructions, bindVars)
+		if err != nil || dml == nil {
+			break
+		}
+		batch = append(batch, &batchedDML{
+			sql:      sql,
+			plan:     plan,
+			vcursor:  vcursor,
+			bindVars: bindVars,
+			logStats: logStats,
+			dml:      dml,
+		})
+	}
+	return batch
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestExecuteDMLBatch(t *testing.T) {
+	executor, sbc1, sbc2, sbclookup, ctx := createExecutorEnv(t)
+
+	shardQueries := func(sbc *sandboxconn.SandboxConn) []string {
+		var queries []string
+		for _, query := range sbc.Queries {
+			queries = append(queries, query.Sql)
+		}
+		sbc.Queries = nil
+		return queries
+	}
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	qrs, err := executor.ExecuteDMLBatch(ctx, "TestExecute", session, []string{
+		"update user set a = 1 where id = 1",
+		"update user set a = 2 where id = 3",
+		"delete from user_extra where user_id = 1",
+		"update user set a = 3 where id = 3",
+		// The insert creates lookup vindex entries, and is executed as usual.
+		"insert into user(id, name) values (4, 'foo')",
+		"update user set a = 4 where id = 1",
+	})
+	require.NoError(t, err)
+	require.Len(t, qrs, 4)
+	assert.True(t, session.InTransaction())
+	assert.Equal(t, []string{
+		"update `user` set a = 1 where id = 1",
+		"delete from user_extra where user_id = 1",
+	}, shardQueries(sbc1))
+	assert.Equal(t, []string{
+		"update `user` set a = 2 where id = 3",
+		"update `user` set a = 3 where id = 3",
+	}, shardQueries(sbc2))
+	assert.Empty(t, shardQueries(sbclookup))
+
+	// A single DML is executed as usual.
+	qrs, err = executor.ExecuteDMLBatch(ctx, "TestExecute", session, []string{
+		"update user set a = 1 where id = 1",
+		"select id from user",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, qrs)
+	assert.Empty(t, shardQueries(sbc1))
+
+	// The DMLs of a failed batch are returned up to the failed one.
+	sbc2.MustFailCodes[vtrpcpb.Code_ALREADY_EXISTS] = 1
+	qrs, err = executor.ExecuteDMLBatch(ctx, "TestExecute", session, []string{
+		"update user set a = 1 where id = 1",
+		"update user set a = 2 where id = 3",
+	})
+	require.ErrorContains(t, err, "ALREADY_EXISTS")
+	assert.Len(t, qrs, 1)
+	assert.True(t, session.InTransaction())
+
+	// The DMLs of the sessions in autocommit are executed one by one.
+	session = econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	qrs, err = executor.ExecuteDMLBatch(ctx, "TestExecute", session, []string{
+		"update user set a = 1 where id = 1",
+		"update user set a = 2 where id = 3",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, qrs)
+}
@@ -19,22 +19,28 @@ package vtgate
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/discovery"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 	_ "vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
@@ -3265,3 +3271,124 @@ func TestConsistentLookupInsert(t *testing.T) {
 		testQueryLog(t, executor, logChan, "TestExecute", "INSERT", "insert into t1(id, unq_col) values (1, 10), (4, 10)", 0)
 	})
 }
+
+func TestDMLSessionTrackGtids(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+
+	session := &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	_, err := executorExec(ctx, executor, session, "set session_track_gtids = 'own_gtid'", nil)
+	require.NoError(t, err)
+
+	sbc1.SetResults([]*sqltypes.Result{{
+		RowsAffected:        1,
+		SessionStateChanges: "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:12",
+	}})
+	qr, err := executorExec(ctx, executor, session, "update user set a=2 where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Options, 1)
+	assert.True(t, sbc1.Options[0].SessionTrackGtids)
+
+	var vgtid binlogdatapb.VGtid
+	require.NoError(t, protojson.Unmarshal([]byte(qr.SessionStateChanges), &vgtid))
+	want := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: KsTestSharded,
+		Shard:    "-20",
+		Gtid:     "MySQL56/e2f7c9a0-6b1d-11ee-8c99-0242ac120002:12",
+	}}}
+	utils.MustMatch(t, want, &vgtid)
+
+	// The GTIDs are not returned once the session stops tracking them.
+	_, err = executorExec(ctx, executor, session, "set session_track_gtids = 'off'", nil)
+	require.NoError(t, err)
+	sbc1.SetResults([]*sqltypes.Result{{
+		RowsAffected:        1,
+		SessionStateChanges: "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:13",
+	}})
+	qr, err = executorExec(ctx, executor, session, "update user set a=2 where id = 1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, qr.SessionStateChanges)
+}
+
+func TestWriteFence(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	sand := getSandbox(KsTestSharded)
+	setWriteFences := func(fences map[string]*topodatapb.WriteFence) {
+		sand.sandmu.Lock()
+		defer sand.sandmu.Unlock()
+		sand.WriteFences = fences
+	}
+	defer setWriteFences(nil)
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	setWriteFences(map[string]*topodatapb.WriteFence{"-20": {Reason: "maintenance"}})
+	_, err := executorExec(ctx, executor, session, "update user set a = 2 where id = 1", nil)
+	require.ErrorContains(t, err, "VT09034: writes to shard TestExecutor/-20 are fenced: maintenance")
+	assert.Empty(t, sbc1.Queries)
+	_, err = executorExec(ctx, executor, session, "update user set a = 2", nil)
+	require.ErrorContains(t, err, "VT09034: writes to shard TestExecutor/-20 are fenced: maintenance")
+	assert.Empty(t, sbc1.Queries)
+	assert.Empty(t, sbc2.Queries)
+
+	// The reads and the writes to the other shards are not fenced.
+	_, err = executorExec(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorExec(ctx, executor, session, "update user set a = 2 where id = 3", nil)
+	require.NoError(t, err)
+	assert.Len(t, sbc2.Queries, 1)
+	sbc1.Queries = nil
+
+	// The buffered writes are rejected when the buffer duration elapses.
+	setWriteFences(map[string]*topodatapb.WriteFence{"-20": {Reason: "maintenance", BufferDuration: protoutil.DurationToProto(200 * time.Millisecond)}})
+	start := time.Now()
+	_, err = executorExec(ctx, executor, session, "update user set a = 2 where id = 1", nil)
+	require.ErrorContains(t, err, "VT09034")
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	assert.Empty(t, sbc1.Queries)
+
+	// They are executed if the fence is removed meanwhile.
+	setWriteFences(map[string]*topodatapb.WriteFence{"-20": {Reason: "maintenance", BufferDuration: protoutil.DurationToProto(10 * time.Second)}})
+	time.AfterFunc(200*time.Millisecond, func() { setWriteFences(nil) })
+	_, err = executorExec(ctx, executor, session, "update user set a = 2 where id = 1", nil)
+	require.NoError(t, err)
+	assert.Len(t, sbc1.Queries, 1)
+}
+
+func TestLoadDataLocalInfile(t *testing.T) {
+	executor, sbc1, sbc2, _, ctx := createExecutorEnv(t)
+	executor.vConfig.LoadDataChunkRows = 2
+
+	ctx = engine.WithLocalInfile(ctx, func(filename string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("1\ta\n3\tb\n1\tc\n")), nil
+	})
+	session := econtext.NewAutocommitSession(&vtgatepb.Session{TargetString: "@primary"})
+	qr, err := executorExec(ctx, executor, session.Session, "load data local infile 'data.tsv' into table user_extra (user_id, extra)", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, qr.RowsAffected)
+
+	// The rows are routed by the vindex of the table, 2 at a time: the
+	// first chunk is committed across both shards, the second one in
+	// autocommit.
+	assertQueries(t, sbc1, []*querypb.BoundQuery{{
+		Sql: "insert ignore into user_extra(user_id, extra) values (:_user_id_0, :ld1)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.StringBindVariable("1"),
+			"ld1":        sqltypes.StringBindVariable("a"),
+		},
+	}, {
+		Sql: "insert ignore into user_extra(user_id, extra) values (:_user_id_0, :ld1)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_0": sqltypes.StringBindVariable("1"),
+			"ld1":        sqltypes.StringBindVariable("c"),
+		},
+	}})
+	assertQueries(t, sbc2, []*querypb.BoundQuery{{
+		Sql: "insert ignore into user_extra(user_id, extra) values (:_user_id_1, :ld3)",
+		BindVariables: map[string]*querypb.BindVariable{
+			"_user_id_1": sqltypes.StringBindVariable("3"),
+			"ld3":        sqltypes.StringBindVariable("b"),
+		},
+	}})
+	assert.EqualValues(t, 1, sbc1.CommitCount.Load())
+	assert.EqualValues(t, 1, sbc2.CommitCount.Load())
+	assert.False(t, session.InTransaction())
+}
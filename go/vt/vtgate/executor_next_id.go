@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+)
+
+const (
+	// A k-ordered id is made of, from the highest to the lowest bits, the
+	// milliseconds since kOrderedIDEpoch, the node id of the vtgate and a
+	// counter of the ids generated in that millisecond.
+	kOrderedIDNodeBits    = 10
+	kOrderedIDCounterBits = 12
+	maxKOrderedIDNodeID   = 1<<kOrderedIDNodeBits - 1
+	maxKOrderedIDCount    = 1 << kOrderedIDCounterBits
+)
+
+var (
+	kOrderedIDEpoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	idsGenerated = stats.NewCountersWithMultiLabels(
+		"VtGateIDsGenerated",
+		"Number of ids generated by vitess_next_id, by sequence and variant",
+		[]string{"Sequence", "Variant"})
+	idBlockFetches = stats.NewCountersWithSingleLabel(
+		"VtGateIDBlockFetches",
+		"Number of blocks of ids fetched from the sequence tables by vitess_next_id",
+		"Sequence")
+)
+
+// idGenerator generates the ids of vitess_next_id. The ids of a sequence are
+// served from a block of ids fetched from its sequence table, so that the
+// sequence table is only accessed once per block. K-ordered ids are generated
+// by the vtgate alone.
+type idGenerator struct {
+	blockSize int64
+	nodeID    int64
+	now       func() time.Time
+
+	mu       sync.Mutex
+	blocks   map[string]*idBlock
+	kOrdered map[string]*kOrderedIDs
+}
+
+// idBlock holds the ids of a sequence which are yet to be served, [next, end).
+type idBlock struct {
+	mu   sync.Mutex
+	next int64
+	end  int64
+}
+
+// kOrderedIDs holds the state of the k-ordered ids of a sequence.
+type kOrderedIDs struct {
+	mu sync.Mutex
+	// millis is the timestamp of the last generated ids, and counter the
+	// number of ids generated for that timestamp.
+	millis  int64
+	counter int64
+}
+
+func newIDGenerator(blockSize int64, nodeID int64) *idGenerator {
+	return &idGenerator{
+		blockSize: blockSize,
+		nodeID:    nodeID,
+		now:       time.Now,
+		blocks:    make(map[string]*idBlock),
+		kOrdered:  make(map[string]*kOrderedIDs),
+	}
+}
+
+// next generates count consecutive ids of the given sequence, and returns the first one.
+func (g *idGenerator) next(ctx context.Context, sequence string, count int64, kOrdered bool, fetch func(ctx context.Context, blockSize int64) (int64, error)) (int64, error) {
+	if kOrdered {
+		id, err := g.nextKOrdered(sequence, count)
+		if err != nil {
+			return 0, err
+		}
+		idsGenerated.Add([]string{sequence, engine.NextIDVariantKOrdered}, count)
+		return id, nil
+	}
+
+	g.mu.Lock()
+	block, ok := g.blocks[sequence]
+	if !ok {
+		block = &idBlock{}
+		g.blocks[sequence] = block
+	}
+	g.mu.Unlock()
+
+	block.mu.Lock()
+	defer block.mu.Unlock()
+	if block.end-block.next < count {
+		// The remaining ids of the block are not consecutive with the next
+		// block, and are discarded.
+		blockSize := max(g.blockSize, count)
+		first, err := fetch(ctx, blockSize)
+		if err != nil {
+			return 0, err
+		}
+		idBlockFetches.Add(sequence, 1)
+		block.next, block.end = first, first+blockSize
+	}
+	id := block.next
+	block.next += count
+	idsGenerated.Add([]string{sequence, engine.NextIDVariantSequence}, count)
+	return id, nil
+}
+
+// nextKOrdered generates count consecutive k-ordered ids of the given sequence, and returns the first one.
+func (g *idGenerator) nextKOrdered(sequence string, count int64) (int64, error) {
+	if count > maxKOrderedIDCount {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "at most %d k-ordered ids can be generated at once, got %d", maxKOrderedIDCount, count)
+	}
+
+	g.mu.Lock()
+	ids, ok := g.kOrdered[sequence]
+	if !ok {
+		ids = &kOrderedIDs{}
+		g.kOrdered[sequence] = ids
+	}
+	g.mu.Unlock()
+
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+	millis := g.now().Sub(kOrderedIDEpoch).Milliseconds()
+	if millis <= ids.millis {
+		// Keep generating ids for the last timestamp when the clock did not
+		// move forward, or went backwards, so that the ids are unique.
+		millis = ids.millis
+	} else {
+		ids.counter = 0
+	}
+	if ids.counter+count > maxKOrderedIDCount {
+		// The counter of the timestamp is exhausted: borrow the next one.
+		millis++
+		ids.counter = 0
+	}
+	id := millis<<(kOrderedIDNodeBits+kOrderedIDCounterBits) | g.nodeID<<kOrderedIDCounterBits | ids.counter
+	ids.millis = millis
+	ids.counter += count
+	return id, nil
+}
+
+// NextIDs generates count consecutive ids of the given sequence, and returns the first one.
+func (e *Executor) NextIDs(ctx context.Context, sequence string, count int64, kOrdered bool, fetch func(ctx context.Context, blockSize int64) (int64, error)) (int64, error) {
+	return e.idGenerator.next(ctx, sequence, count, kOrdered, fetch)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestIDGeneratorSequence(t *testing.T) {
+	g := newIDGenerator(10, 0)
+	var fetches []int64
+	nextBlock := int64(1)
+	fetch := func(_ context.Context, blockSize int64) (int64, error) {
+		fetches = append(fetches, blockSize)
+		first := nextBlock
+		nextBlock += blockSize
+		return first, nil
+	}
+	ctx := context.Background()
+
+	for _, tcase := range []struct {
+		count  int64
+		expect int64
+	}{
+		{count: 1, expect: 1},
+		{count: 5, expect: 2},
+		{count: 4, expect: 7},
+		// The block is exhausted.
+		{count: 1, expect: 11},
+		// The remaining 9 ids of the block are not enough, and discarded.
+		{count: 12, expect: 21},
+		{count: 1, expect: 33},
+	} {
+		id, err := g.next(ctx, "ks.seq", tcase.count, false, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, tcase.expect, id, "count %d", tcase.count)
+	}
+	assert.Equal(t, []int64{10, 10, 12, 10}, fetches)
+
+	// Sequences have their own blocks.
+	id, err := g.next(ctx, "ks.other_seq", 1, false, fetch)
+	require.NoError(t, err)
+	assert.EqualValues(t, 43, id)
+
+	// A failed fetch leaves the block untouched.
+	_, err = g.next(ctx, "ks.other_seq", 20, false, func(context.Context, int64) (int64, error) {
+		return 0, errors.New("fetch failed")
+	})
+	assert.EqualError(t, err, "fetch failed")
+	id, err = g.next(ctx, "ks.other_seq", 1, false, fetch)
+	require.NoError(t, err)
+	assert.EqualValues(t, 44, id)
+}
+
+func TestIDGeneratorKOrdered(t *testing.T) {
+	g := newIDGenerator(10, 3)
+	now := kOrderedIDEpoch.Add(time.Second)
+	g.now = func() time.Time { return now }
+	kOrderedID := func(millis, counter int64) int64 {
+		return millis<<22 | 3<<12 | counter
+	}
+	next := func(count int64) int64 {
+		id, err := g.next(context.Background(), "ks.seq", count, true, nil)
+		require.NoError(t, err)
+		return id
+	}
+
+	assert.Equal(t, kOrderedID(1000, 0), next(1))
+	assert.Equal(t, kOrderedID(1000, 1), next(10))
+	assert.Equal(t, kOrderedID(1000, 11), next(1))
+
+	now = now.Add(time.Millisecond)
+	assert.Equal(t, kOrderedID(1001, 0), next(4000))
+	// The counter of the timestamp is exhausted.
+	assert.Equal(t, kOrderedID(1002, 0), next(100))
+
+	// The clock went backwards.
+	now = now.Add(-time.Second)
+	assert.Equal(t, kOrderedID(1002, 100), next(1))
+
+	_, err := g.next(context.Background(), "ks.seq", maxKOrderedIDCount+1, true, nil)
+	assert.ErrorContains(t, err, "at most 4096 k-ordered ids can be generated at once")
+}
+
+func TestExecutorNextID(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	executor.idGenerator = newIDGenerator(100, 0)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("nextval", "int64"), "1001")})
+	qr, err := executorExecSession(ctx, executor, session, "select vitess_next_id('user_seq', 5)", nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{{sqltypes.NewInt64(1001)}}, qr.Rows)
+	assertQueries(t, sbclookup, []*querypb.BoundQuery{{
+		Sql:           "select next :n /* INT64 */ values from user_seq",
+		BindVariables: map[string]*querypb.BindVariable{"n": sqltypes.Int64BindVariable(100)},
+	}})
+
+	// The ids are served from the block held by vtgate.
+	qr, err = executorExecSession(ctx, executor, session, "select vitess_next_id('TestUnsharded.user_seq', 2) as id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "id", qr.Fields[0].Name)
+	assert.Equal(t, [][]sqltypes.Value{{sqltypes.NewInt64(1006)}}, qr.Rows)
+	assert.Len(t, sbclookup.Queries, 1)
+
+	_, err = executorExecSession(ctx, executor, session, "select vitess_next_id('TestExecutor.user', 1)", nil)
+	assert.ErrorContains(t, err, "vitess_next_id used on a non-sequence table 'TestExecutor.user'")
+	_, err = executorExecSession(ctx, executor, session, "select vitess_next_id('no_such_seq', 1)", nil)
+	assert.ErrorContains(t, err, "table 'no_such_seq' does not exist")
+	_, err = executorExecSession(ctx, executor, session, "select vitess_next_id('user_seq', 0)", nil)
+	assert.ErrorContains(t, err, "invalid number of ids for vitess_next_id: 0")
+	_, err = executorExecSession(ctx, executor, session, "select vitess_next_id('user_seq', 1, 'uuid')", nil)
+	assert.ErrorContains(t, err, "unknown vitess_next_id variant 'uuid'")
+}
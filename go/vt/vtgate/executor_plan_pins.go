@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var (
+	planPinChecks = stats.NewCountersWithSingleLabel("QueryPlanPinChecks", "Number of plans built for the queries of a digest with a pinned plan, by result", "Result", "Matched", "Differed", "RolledBack")
+
+	logPlanPins = logutil.NewThrottledLogger("PlanPin", 5*time.Second)
+)
+
+// planPinStatus reports how the plans built for the queries of a pinned digest
+// compare to its pinned plan.
+type planPinStatus struct {
+	Digest     string
+	PinnedPlan json.RawMessage
+	// Matched is the number of plans matching the pinned plan.
+	Matched int64
+	// Differed is the number of plans differing from the pinned plan which
+	// were used as no plan matching it had been built for their query.
+	Differed int64
+	// RolledBack is the number of plans differing from the pinned plan which
+	// were replaced by the last plan built for their query matching it.
+	RolledBack int64
+	// LastDifferentPlan is the last plan differing from the pinned plan.
+	LastDifferentPlan json.RawMessage `json:",omitempty"`
+	LastDifferentTime time.Time       `json:",omitzero"`
+}
+
+// pinnedPlan is the last plan built for a query which matched the pinned plan
+// of its digest.
+type pinnedPlan struct {
+	digest string
+	pin    string
+	plan   *engine.Plan
+}
+
+// planPinTracker compares the plans built for the queries of the digests with
+// a pinned plan to the pinned plans. It keeps, by plan cache key, the last plan
+// built matching the pinned plan, so that it keeps being used when the query
+// is planned again, e.g. against a new vschema, and its new plan differs.
+type planPinTracker struct {
+	mu       sync.Mutex
+	plans    map[PlanCacheKey]*pinnedPlan
+	statuses map[string]*planPinStatus
+}
+
+func newPlanPinTracker() *planPinTracker {
+	return &planPinTracker{
+		plans:    make(map[PlanCacheKey]*pinnedPlan),
+		statuses: make(map[string]*planPinStatus),
+	}
+}
+
+// prune forgets the plans and the statuses of the digests which are no longer
+// pinned, or pinned to another plan, in the vschema.
+func (t *planPinTracker) prune(vschema *vindexes.VSchema) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, pp := range t.plans {
+		if pin, ok := vschema.FindPlanPin(pp.digest); !ok || pin != pp.pin {
+			delete(t.plans, key)
+		}
+	}
+	for digest, status := range t.statuses {
+		if pin, ok := vschema.FindPlanPin(digest); !ok || pin != string(status.PinnedPlan) {
+			delete(t.statuses, digest)
+		}
+	}
+}
+
+// check compares the plan built for the query of the digest to the pinned plan
+// of the digest, and returns the plan to use.
+func (t *planPinTracker) check(digest, pin string, plan *engine.Plan) *engine.Plan {
+	want, err := normalizePlanDescription([]byte(pin))
+	if err != nil {
+		logPlanPins.Warningf("Invalid pinned plan for the query digest %s: %v", digest, err)
+		return plan
+	}
+	data, err := json.Marshal(engine.PrimitiveToPlanDescription(plan.Instructions, nil))
+	if err != nil {
+		return plan
+	}
+	got, err := normalizePlanDescription(data)
+	if err != nil {
+		return plan
+	}
+
+	key := plan.Source.Key.Hash()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	status, ok := t.statuses[digest]
+	if !ok || string(status.PinnedPlan) != pin {
+		status = &planPinStatus{Digest: digest, PinnedPlan: json.RawMessage(pin)}
+		t.statuses[digest] = status
+	}
+	if got == want {
+		t.plans[key] = &pinnedPlan{digest: digest, pin: pin, plan: plan}
+		status.Matched++
+		planPinChecks.Add("Matched", 1)
+		return plan
+	}
+
+	status.LastDifferentPlan = json.RawMessage(got)
+	status.LastDifferentTime = time.Now()
+	if pp, ok := t.plans[key]; ok && pp.pin == pin {
+		status.RolledBack++
+		planPinChecks.Add("RolledBack", 1)
+		logPlanPins.Warningf("The new plan of the query digest %s differs from its pinned plan, keeping the previous plan of the query: %s", digest, got)
+		return pp.plan
+	}
+	status.Differed++
+	planPinChecks.Add("Differed", 1)
+	logPlanPins.Warningf("The plan of the query digest %s differs from its pinned plan: %s", digest, got)
+	return plan
+}
+
+// statusList returns a copy of the statuses of the pinned digests, by digest.
+func (t *planPinTracker) statusList() []planPinStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	statuses := make([]planPinStatus, 0, len(t.statuses))
+	for _, status := range t.statuses {
+		statuses = append(statuses, *status)
+	}
+	slices.SortFunc(statuses, func(a, b planPinStatus) int {
+		return cmp.Compare(a.Digest, b.Digest)
+	})
+	return statuses
+}
+
+// normalizePlanDescription returns the JSON plan description with its keys
+// sorted and without white space, so that the plans output by VEXPLAIN PLAN
+// can be compared regardless of their formatting.
+func normalizePlanDescription(data []byte) (string, error) {
+	var description any
+	if err := json.Unmarshal(data, &description); err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(description)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// applyPlanPin checks the plan built for the query against the plan pinned for
+// the digest of the query in the vschema, if any, and returns the plan to use.
+func (e *Executor) applyPlanPin(vschema *vindexes.VSchema, query string, plan *engine.Plan) *engine.Plan {
+	if vschema == nil || len(vschema.PlanPins) == 0 || plan.Source == nil || plan.Instructions == nil {
+		return plan
+	}
+	digest := queryDigest(queryDigestText(e.env.Parser(), query, plan.QueryType.String()))
+	pin, ok := vschema.FindPlanPin(digest)
+	if !ok {
+		return plan
+	}
+	return e.planPins.check(digest, pin, plan)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// vexplainPlan returns the plan description of the plan as output by VEXPLAIN PLAN.
+func vexplainPlan(t *testing.T, plan *engine.Plan) string {
+	description, err := json.MarshalIndent(engine.PrimitiveToPlanDescription(plan.Instructions, nil), "", "\t")
+	require.NoError(t, err)
+	return string(description)
+}
+
+func TestPlanPinTracker(t *testing.T) {
+	r, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	pinned, _ := getPlanCached(t, ctx, r, session, "select * from user where id = 1", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	scatter, _ := getPlanCached(t, ctx, r, session, "select * from user", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	pin := vexplainPlan(t, pinned)
+	// A new plan of the same query, which differs from the pinned plan.
+	differing := &engine.Plan{Instructions: scatter.Instructions, Source: pinned.Source}
+
+	tracker := newPlanPinTracker()
+	assert.Same(t, differing, tracker.check("d1", pin, differing))
+	assert.Same(t, pinned, tracker.check("d1", pin, pinned))
+	assert.Same(t, pinned, tracker.check("d1", pin, differing))
+
+	statuses := tracker.statusList()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "d1", statuses[0].Digest)
+	assert.EqualValues(t, 1, statuses[0].Matched)
+	assert.EqualValues(t, 1, statuses[0].Differed)
+	assert.EqualValues(t, 1, statuses[0].RolledBack)
+	assert.Contains(t, string(statuses[0].LastDifferentPlan), `"Variant":"Scatter"`)
+
+	// A plan matching another pinned plan is not used.
+	assert.Same(t, differing, tracker.check("d1", vexplainPlan(t, scatter)+"\n", differing))
+	assert.Same(t, differing, tracker.check("d1", pin, differing))
+}
+
+func TestExecutorPlanPins(t *testing.T) {
+	r, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	query := "select * from user where id = 1"
+
+	plan, _ := getPlanCached(t, ctx, r, session, query, makeComments(""), map[string]*querypb.BindVariable{}, true)
+	digest := queryDigest(queryDigestText(r.env.Parser(), query, ""))
+	vschema := r.VSchema()
+	vschema.PlanPins = map[string]string{digest: vexplainPlan(t, plan)}
+
+	// The plans of the other queries of the digest are checked against the pinned plan.
+	_, _ = getPlanCached(t, ctx, r, session, "select * from user where id = 2", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	statuses := r.planPins.statusList()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, digest, statuses[0].Digest)
+	assert.EqualValues(t, 1, statuses[0].Matched)
+
+	// The plans of the digest are forgotten once it is no longer pinned.
+	vschema.PlanPins = nil
+	r.SaveVSchema(vschema, r.vschemaStats)
+	assert.Empty(t, r.planPins.statusList())
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"slices"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var planWarmups = stats.NewCountersWithSingleLabel("QueryPlanCacheWarmups", "Number of cached plans built again against a new vschema, by result", "Result", "Warmed", "Skipped", "Failed")
+
+// warmupPlans builds the most executed cached plans again against the new
+// vschema, so that they can be added to the plan cache once it is cleared, and
+// the queries they serve do not need to be planned again after the swap.
+// Only the plans built for the session target, without SET_VAR hints, can be
+// built again; a plan is skipped if its query would now get a different key.
+func (e *Executor) warmupPlans(vschema *vindexes.VSchema) map[PlanCacheKey]*engine.Plan {
+	if e.config.PlanWarmupCount <= 0 {
+		return nil
+	}
+	if vschema == nil {
+		vschema = e.VSchema()
+		if vschema == nil {
+			return nil
+		}
+	}
+
+	var sources []*engine.Plan
+	e.ForEachPlan(func(plan *engine.Plan) bool {
+		if src := plan.Source; src != nil && src.Key.Destination == "" && src.Key.SetVarComment == "" {
+			sources = append(sources, plan)
+		}
+		return true
+	})
+	slices.SortFunc(sources, func(a, b *engine.Plan) int {
+		aCount, _, _, _, _, _ := a.Stats()
+		bCount, _, _, _, _, _ := b.Stats()
+		switch {
+		case aCount > bCount:
+			return -1
+		case aCount < bCount:
+			return 1
+		}
+		return 0
+	})
+	if len(sources) > e.config.PlanWarmupCount {
+		sources = sources[:e.config.PlanWarmupCount]
+	}
+
+	ctx := context.Background()
+	if e.config.PlanWarmupTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.config.PlanWarmupTimeout)
+		defer cancel()
+	}
+
+	warmed := make(map[PlanCacheKey]*engine.Plan, len(sources))
+	for i, source := range sources {
+		if ctx.Err() != nil {
+			log.Warningf("Timed out building the cached plans against the new vschema, %d of %d plans skipped", len(sources)-i, len(sources))
+			planWarmups.Add("Skipped", int64(len(sources)-i))
+			break
+		}
+		plan, err := e.warmupPlan(ctx, vschema, source.Source)
+		switch {
+		case err != nil:
+			planWarmups.Add("Failed", 1)
+		case plan == nil:
+			planWarmups.Add("Skipped", 1)
+		default:
+			// The plan serves the same query, keep its statistics so that it
+			// is still ranked by them on the next vschema change. The plan is
+			// the source itself when it was kept for its pinned plan.
+			if plan != source {
+				plan.AddStats(source.Stats())
+			}
+			warmed[plan.Source.Key.Hash()] = plan
+			planWarmups.Add("Warmed", 1)
+		}
+	}
+	return warmed
+}
+
+// warmupPlan builds the plan of the source against the vschema. It returns
+// nil if the plan would not be cached under the same key.
+func (e *Executor) warmupPlan(ctx context.Context, vschema *vindexes.VSchema, source *engine.PlanSource) (*engine.Plan, error) {
+	// The planner flags of the key hold the value of every flag, setting them
+	// on the session builds the plan with the same flags.
+	plannerFlags, err := plannerflags.Parse(source.Key.PlannerFlags)
+	if err != nil {
+		return nil, err
+	}
+	safeSession := econtext.NewSafeSession(&vtgatepb.Session{
+		TargetString: source.Key.CurrentKeyspace + "@" + topoproto.TabletTypeLString(source.Key.TabletType),
+		Autocommit:   true,
+		PlannerFlags: plannerFlags,
+	})
+	logStats := logstats.NewLogStats(ctx, "PlanWarmup", source.Query, "", nil, streamlog.GetQueryLogConfig())
+	vcursor, err := econtext.NewVCursorImpl(safeSession, sqlparser.MarginComments{}, e, logStats, e.vm, vschema, e.resolver.resolver, e.serv, nullResultsObserver{}, e.vConfig, e.metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	bindVars := make(map[string]*querypb.BindVariable)
+	plan, _, _, err := e.getCachedOrBuildPlan(ctx, vcursor, source.Query, bindVars, "", source.Parameterize, engine.PlanKey{}, true)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Source == nil || plan.Source.Key != source.Key {
+		return nil, nil
+	}
+	return plan, nil
+}
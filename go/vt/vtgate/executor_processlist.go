@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// process is a client connection of the MySQL protocol server.
+type process struct {
+	ID        uint32
+	User      string
+	Host      string
+	Connected time.Time
+	// ConnectAttributes are the connection attributes sent by the client,
+	// like program_name, _client_name and _client_version.
+	ConnectAttributes map[string]string
+}
+
+// processList tracks the client connections of the MySQL protocol server
+// which completed the handshake, to tell which applications are connected.
+type processList struct {
+	mu        sync.Mutex
+	processes map[uint32]*process
+}
+
+func newProcessList() *processList {
+	return &processList{processes: make(map[uint32]*process)}
+}
+
+// add tracks the connection. It must be called once the handshake is done.
+func (pl *processList) add(c *mysql.Conn) {
+	p := &process{
+		ID:                c.ConnectionID,
+		User:              c.User,
+		Host:              c.RemoteAddr().String(),
+		Connected:         time.Now(),
+		ConnectAttributes: c.ConnectAttributes,
+	}
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	pl.processes[p.ID] = p
+}
+
+// remove stops tracking the connection.
+func (pl *processList) remove(id uint32) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	delete(pl.processes, id)
+}
+
+// list returns the tracked connections, ordered by id.
+func (pl *processList) list() []*process {
+	pl.mu.Lock()
+	processes := make([]*process, 0, len(pl.processes))
+	for _, p := range pl.processes {
+		processes = append(processes, p)
+	}
+	pl.mu.Unlock()
+
+	slices.SortFunc(processes, func(a, b *process) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return processes
+}
+
+// ShowProcesslist returns the client connections of the MySQL protocol
+// server, with the program name and the client sent when connecting. The
+// LIKE filter applies to the program names.
+func (e *Executor) ShowProcesslist(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	var likeFilter func(string) bool
+	if filter != nil && filter.Like != "" {
+		programRegexp := sqlparser.LikeToRegexp(filter.Like)
+		likeFilter = programRegexp.MatchString
+	}
+
+	now := time.Now()
+	rows := [][]sqltypes.Value{}
+	for _, p := range e.processList.list() {
+		programName := p.ConnectAttributes["program_name"]
+		if likeFilter != nil && !likeFilter(programName) {
+			continue
+		}
+		attributes := []byte("{}")
+		if len(p.ConnectAttributes) > 0 {
+			var err error
+			if attributes, err = json.Marshal(p.ConnectAttributes); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.NewUint32(p.ID),
+			sqltypes.NewVarChar(p.User),
+			sqltypes.NewVarChar(p.Host),
+			sqltypes.NewInt64(int64(now.Sub(p.Connected).Seconds())),
+			sqltypes.NewVarChar(programName),
+			sqltypes.NewVarChar(p.ConnectAttributes["_client_name"]),
+			sqltypes.NewVarChar(p.ConnectAttributes["_client_version"]),
+			sqltypes.NewVarChar(string(attributes)),
+		})
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "Id", Type: sqltypes.Uint32},
+			{Name: "User", Type: sqltypes.VarChar},
+			{Name: "Host", Type: sqltypes.VarChar},
+			{Name: "Connected", Type: sqltypes.Int64},
+			{Name: "ProgramName", Type: sqltypes.VarChar},
+			{Name: "ClientName", Type: sqltypes.VarChar},
+			{Name: "ClientVersion", Type: sqltypes.VarChar},
+			{Name: "ConnectAttributes", Type: sqltypes.VarChar},
+		},
+		Rows: rows,
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestShowProcesslist(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	vh := newVtgateHandler(&VTGate{executor: executor})
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	orders := mysql.GetTestConn()
+	orders.ConnectionID = 2
+	orders.User = "app"
+	orders.ConnectAttributes = map[string]string{"program_name": "orders", "_client_name": "libmysql", "_client_version": "8.0.36"}
+	vh.ConnectionReady(orders)
+	cli := mysql.GetTestConn()
+	cli.ConnectionID = 1
+	cli.User = "admin"
+	vh.ConnectionReady(cli)
+
+	qr, err := executorExecSession(ctx, executor, session, "show vitess_processlist", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 2)
+	assert.Equal(t, `[UINT32(1) VARCHAR("admin") VARCHAR("a") INT64(0) VARCHAR("") VARCHAR("") VARCHAR("") VARCHAR("{}")]`, fmt.Sprintf("%v", qr.Rows[0]))
+	assert.Equal(t, `[UINT32(2) VARCHAR("app") VARCHAR("a") INT64(0) VARCHAR("orders") VARCHAR("libmysql") VARCHAR("8.0.36") VARCHAR("{\"_client_name\":\"libmysql\",\"_client_version\":\"8.0.36\",\"program_name\":\"orders\"}")]`, fmt.Sprintf("%v", qr.Rows[1]))
+
+	qr, err = executorExecSession(ctx, executor, session, "show vitess_processlist like 'ord%'", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "2", qr.Rows[0][0].ToString())
+
+	// The closed connections are no longer listed.
+	vh.ConnectionClosed(orders)
+	qr, err = executorExecSession(ctx, executor, session, "show vitess_processlist", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "1", qr.Rows[0][0].ToString())
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// queryDigestOther is the digest text under which all queries are
+	// accounted once --query-digest-max-entries distinct digests have been seen.
+	queryDigestOther = "other"
+	// maxQueryDigestTextLength bounds the memory used by the text of a digest.
+	maxQueryDigestTextLength = 1024
+)
+
+// queryDigestLatencyBounds are the upper bounds of the buckets of the latency
+// histogram of a digest. Latencies above the last bound go to an overflow bucket.
+var queryDigestLatencyBounds = []time.Duration{
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second,
+	10 * time.Second, 30 * time.Second, time.Minute,
+}
+
+// queryDigestStats holds the statistics of the queries of a single digest.
+type queryDigestStats struct {
+	Digest     string
+	DigestText string
+	Queries    int64
+	Errors     int64
+	ErrorRate  float64
+	TotalTime  time.Duration
+	MaxTime    time.Duration
+	// P99Time is the upper bound of the latency histogram bucket of the 99th
+	// percentile, capped by MaxTime.
+	P99Time      time.Duration
+	RowsReturned uint64
+	RowsAffected uint64
+	ShardQueries uint64
+	// PeakMemory is the largest number of bytes of rows buffered at vtgate
+	// by a query of the digest.
+	PeakMemory int64
+	FirstSeen  time.Time
+	LastSeen   time.Time
+
+	latencies []int64
+}
+
+// queryDigestSort is the order in which the digests are reported.
+type queryDigestSort string
+
+const (
+	queryDigestSortTime      queryDigestSort = "time"
+	queryDigestSortQueries   queryDigestSort = "queries"
+	queryDigestSortRows      queryDigestSort = "rows"
+	queryDigestSortShards    queryDigestSort = "shards"
+	queryDigestSortErrorRate queryDigestSort = "errors"
+	queryDigestSortP99       queryDigestSort = "p99"
+	queryDigestSortMemory    queryDigestSort = "memory"
+)
+
+// queryDigestTracker aggregates the statistics of the queries by digest, i.e.
+// by their normalized text. The number of tracked digests is bounded: once
+// maxDigests distinct digests have been seen, any new digest is accounted
+// under queryDigestOther. All the statistics are reset every resetInterval.
+type queryDigestTracker struct {
+	maxDigests    int
+	resetInterval time.Duration
+
+	mu      sync.Mutex
+	since   time.Time
+	digests map[string]*queryDigestStats
+}
+
+func newQueryDigestTracker(maxDigests int, resetInterval time.Duration) *queryDigestTracker {
+	return &queryDigestTracker{
+		maxDigests:    maxDigests,
+		resetInterval: resetInterval,
+		since:         time.Now(),
+		digests:       make(map[string]*queryDigestStats),
+	}
+}
+
+// maybeReset drops all the statistics if the reset interval has elapsed
+// since the last reset. The caller must hold the lock.
+func (t *queryDigestTracker) maybeReset(now time.Time) {
+	if t.resetInterval <= 0 || now.Sub(t.since) < t.resetInterval {
+		return
+	}
+	t.since = now
+	t.digests = make(map[string]*queryDigestStats)
+}
+
+// record accounts a query of the digest text.
+func (t *queryDigestTracker) record(now time.Time, text string, elapsed time.Duration, shardQueries, rowsReturned, rowsAffected uint64, peakMemory int64, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maybeReset(now)
+	s, ok := t.digests[text]
+	if !ok {
+		if len(t.digests) >= t.maxDigests {
+			text = queryDigestOther
+			s = t.digests[text]
+		}
+		if s == nil {
+			s = &queryDigestStats{
+				Digest:     queryDigest(text),
+				DigestText: text,
+				FirstSeen:  now,
+				latencies:  make([]int64, len(queryDigestLatencyBounds)+1),
+			}
+			t.digests[text] = s
+		}
+	}
+	s.Queries++
+	if failed {
+		s.Errors++
+	}
+	s.TotalTime += elapsed
+	s.MaxTime = max(s.MaxTime, elapsed)
+	s.RowsReturned += rowsReturned
+	s.RowsAffected += rowsAffected
+	s.ShardQueries += shardQueries
+	s.PeakMemory = max(s.PeakMemory, peakMemory)
+	s.LastSeen = now
+	s.latencies[sort.Search(len(queryDigestLatencyBounds), func(i int) bool {
+		return queryDigestLatencyBounds[i] >= elapsed
+	})]++
+}
+
+// top returns a copy of the statistics of all digests in the given order
+// (descending), and the time of the last reset.
+func (t *queryDigestTracker) top(now time.Time, sortBy queryDigestSort) ([]queryDigestStats, time.Time) {
+	t.mu.Lock()
+	t.maybeReset(now)
+	since := t.since
+	all := make([]queryDigestStats, 0, len(t.digests))
+	for _, s := range t.digests {
+		c := *s
+		c.ErrorRate = float64(s.Errors) / float64(s.Queries)
+		c.P99Time = queryDigestPercentile(s.latencies, 0.99, s.MaxTime)
+		c.latencies = nil
+		all = append(all, c)
+	}
+	t.mu.Unlock()
+
+	key := func(s queryDigestStats) float64 {
+		switch sortBy {
+		case queryDigestSortQueries:
+			return float64(s.Queries)
+		case queryDigestSortRows:
+			return float64(s.RowsReturned + s.RowsAffected)
+		case queryDigestSortShards:
+			return float64(s.ShardQueries)
+		case queryDigestSortErrorRate:
+			return s.ErrorRate
+		case queryDigestSortP99:
+			return float64(s.P99Time)
+		case queryDigestSortMemory:
+			return float64(s.PeakMemory)
+		default:
+			return float64(s.TotalTime)
+		}
+	}
+	slices.SortFunc(all, func(a, b queryDigestStats) int {
+		if c := cmp.Compare(key(b), key(a)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.DigestText, b.DigestText)
+	})
+	return all, since
+}
+
+// queryDigestPercentile returns the upper bound of the latency histogram
+// bucket of the percentile, capped by the maximum latency seen.
+func queryDigestPercentile(histogram []int64, percentile float64, maxTime time.Duration) time.Duration {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	rank := int64(percentile * float64(total))
+	var seen int64
+	for i, count := range histogram {
+		seen += count
+		if seen > rank && i < len(queryDigestLatencyBounds) {
+			return min(queryDigestLatencyBounds[i], maxTime)
+		}
+	}
+	return maxTime
+}
+
+// queryDigest returns a short hash identifying the digest text.
+func queryDigest(text string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(text))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// queryDigestText returns the normalized text of the query, without its
+// comments and with its literals replaced by bind variables. The statement
+// type is used for the queries which cannot be parsed.
+func queryDigestText(parser *sqlparser.Parser, sql, stmtType string) string {
+	stripped, _ := sqlparser.SplitMarginComments(sql)
+	text, err := parser.RedactSQLQuery(stripped)
+	if err != nil {
+		text = stmtType
+	}
+	if len(text) > maxQueryDigestTextLength {
+		text = text[:maxQueryDigestTextLength]
+	}
+	return text
+}
+
+// recordQueryDigest samples the query into the digest statistics, according
+// to --query-digest-sample-rate.
+func (e *Executor) recordQueryDigest(sql string, logStats *logstats.LogStats, rowsReturned uint64) {
+	if queryDigestSampleRate <= 0 || rand.Float64() >= queryDigestSampleRate {
+		return
+	}
+	text := queryDigestText(e.env.Parser(), sql, logStats.StmtType)
+	e.queryDigests.record(logStats.EndTime, text, logStats.TotalTime(), logStats.ShardQueries, rowsReturned, logStats.RowsAffected, logStats.PeakMemory, logStats.Error != nil)
+}
+
+// ShowQueryDigests returns the statistics of the sampled queries per digest,
+// ordered by their total execution time.
+func (e *Executor) ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	var likeFilter func(string) bool
+	if filter != nil && filter.Like != "" {
+		textRegexp := sqlparser.LikeToRegexp(filter.Like)
+		likeFilter = textRegexp.MatchString
+	}
+
+	milliseconds := func(d time.Duration) sqltypes.Value {
+		return sqltypes.NewFloat64(float64(d.Microseconds()) / 1000)
+	}
+	rows := [][]sqltypes.Value{}
+	digests, _ := e.queryDigests.top(time.Now(), queryDigestSortTime)
+	for _, s := range digests {
+		if likeFilter != nil && !likeFilter(s.DigestText) {
+			continue
+		}
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.NewVarChar(s.Digest),
+			sqltypes.NewVarChar(s.DigestText),
+			sqltypes.NewInt64(s.Queries),
+			sqltypes.NewInt64(s.Errors),
+			sqltypes.NewFloat64(s.ErrorRate),
+			milliseconds(s.TotalTime),
+			milliseconds(s.TotalTime / time.Duration(s.Queries)),
+			milliseconds(s.P99Time),
+			milliseconds(s.MaxTime),
+			sqltypes.NewUint64(s.RowsReturned),
+			sqltypes.NewUint64(s.RowsAffected),
+			sqltypes.NewUint64(s.ShardQueries),
+			sqltypes.NewInt64(s.PeakMemory),
+			sqltypes.NewVarChar(s.FirstSeen.UTC().Format(time.DateTime)),
+			sqltypes.NewVarChar(s.LastSeen.UTC().Format(time.DateTime)),
+		})
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "Digest", Type: sqltypes.VarChar},
+			{Name: "DigestText", Type: sqltypes.VarChar},
+			{Name: "Queries", Type: sqltypes.Int64},
+			{Name: "Errors", Type: sqltypes.Int64},
+			{Name: "ErrorRate", Type: sqltypes.Float64},
+			{Name: "TotalTimeMs", Type: sqltypes.Float64},
+			{Name: "AvgTimeMs", Type: sqltypes.Float64},
+			{Name: "P99TimeMs", Type: sqltypes.Float64},
+			{Name: "MaxTimeMs", Type: sqltypes.Float64},
+			{Name: "RowsReturned", Type: sqltypes.Uint64},
+			{Name: "RowsAffected", Type: sqltypes.Uint64},
+			{Name: "ShardQueries", Type: sqltypes.Uint64},
+			{Name: "PeakMemoryBytes", Type: sqltypes.Int64},
+			{Name: "FirstSeen", Type: sqltypes.VarChar},
+			{Name: "LastSeen", Type: sqltypes.VarChar},
+		},
+		Rows: rows,
+	}, nil
+}
+
+// serveQueryDigests returns the statistics of the sampled queries per digest
+// as JSON. The order and the number of digests are set with the sort and
+// limit query parameters.
+func (e *Executor) serveQueryDigests(response http.ResponseWriter, request *http.Request) {
+	sortBy := queryDigestSortTime
+	if s := request.URL.Query().Get("sort"); s != "" {
+		sortBy = queryDigestSort(s)
+	}
+	switch sortBy {
+	case queryDigestSortTime, queryDigestSortQueries, queryDigestSortRows, queryDigestSortShards, queryDigestSortErrorRate, queryDigestSortP99, queryDigestSortMemory:
+	default:
+		http.Error(response, fmt.Sprintf("invalid sort %q: expected one of time, queries, rows, shards, errors, p99 or memory", sortBy), http.StatusBadRequest)
+		return
+	}
+	digests, since := e.queryDigests.top(time.Now(), sortBy)
+	if l := request.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 0 {
+			http.Error(response, fmt.Sprintf("invalid limit %q", l), http.StatusBadRequest)
+			return
+		}
+		digests = digests[:min(limit, len(digests))]
+	}
+	returnAsJSON(response, struct {
+		Since   time.Time
+		Digests []queryDigestStats
+	}{
+		Since:   since,
+		Digests: digests,
+	})
+}
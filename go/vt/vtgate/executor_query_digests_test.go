@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestQueryDigestTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newQueryDigestTracker(2, time.Hour)
+	tracker.since = now
+
+	for i := range 100 {
+		tracker.record(now, "select a", time.Millisecond, 1, 1, 0, 0, false)
+		if i == 0 {
+			tracker.record(now, "select a", 3*time.Second, 1, 1, 0, 2048, false)
+		}
+	}
+	tracker.record(now, "update b", 10*time.Millisecond, 4, 0, 10, 0, false)
+	tracker.record(now.Add(time.Minute), "update b", 20*time.Millisecond, 4, 0, 5, 0, true)
+	// The tracker is full, new digests are accounted as "other".
+	tracker.record(now, "delete c", time.Millisecond, 1, 0, 1, 0, true)
+	tracker.record(now, "delete d", time.Millisecond, 1, 0, 1, 0, true)
+
+	digests, since := tracker.top(now.Add(time.Minute), queryDigestSortTime)
+	assert.Equal(t, now, since)
+	require.Len(t, digests, 3)
+	selectA := digests[0]
+	assert.Equal(t, "select a", selectA.DigestText)
+	assert.Equal(t, queryDigest("select a"), selectA.Digest)
+	assert.EqualValues(t, 101, selectA.Queries)
+	assert.Equal(t, 3*time.Second+100*time.Millisecond, selectA.TotalTime)
+	assert.Equal(t, 3*time.Second, selectA.MaxTime)
+	assert.Equal(t, time.Millisecond, selectA.P99Time)
+	assert.EqualValues(t, 101, selectA.RowsReturned)
+	assert.EqualValues(t, 2048, selectA.PeakMemory)
+	assert.Zero(t, selectA.ErrorRate)
+
+	updateB := digests[1]
+	assert.Equal(t, "update b", updateB.DigestText)
+	assert.EqualValues(t, 1, updateB.Errors)
+	assert.Equal(t, 0.5, updateB.ErrorRate)
+	assert.Equal(t, 20*time.Millisecond, updateB.P99Time)
+	assert.EqualValues(t, 15, updateB.RowsAffected)
+	assert.EqualValues(t, 8, updateB.ShardQueries)
+	assert.Equal(t, now, updateB.FirstSeen)
+	assert.Equal(t, now.Add(time.Minute), updateB.LastSeen)
+
+	assert.Equal(t, "other", digests[2].DigestText)
+	assert.EqualValues(t, 2, digests[2].Queries)
+
+	digests, _ = tracker.top(now, queryDigestSortErrorRate)
+	assert.Equal(t, []string{"other", "update b", "select a"}, []string{digests[0].DigestText, digests[1].DigestText, digests[2].DigestText})
+	digests, _ = tracker.top(now, queryDigestSortShards)
+	assert.Equal(t, []string{"select a", "update b", "other"}, []string{digests[0].DigestText, digests[1].DigestText, digests[2].DigestText})
+	digests, _ = tracker.top(now, queryDigestSortMemory)
+	assert.Equal(t, "select a", digests[0].DigestText)
+
+	// The statistics are reset once the reset interval has elapsed.
+	digests, since = tracker.top(now.Add(time.Hour), queryDigestSortTime)
+	assert.Empty(t, digests)
+	assert.Equal(t, now.Add(time.Hour), since)
+}
+
+func TestQueryDigestText(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	assert.Equal(t, "select a from t where x = :x /* INT64 */", queryDigestText(parser, "/* leading */ select a from t where x = 1 /* trailing */", "SELECT"))
+	assert.Equal(t, "SELECT", queryDigestText(parser, "select from from", "SELECT"))
+}
+
+func TestExecutorQueryDigests(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+
+	saveRate := queryDigestSampleRate
+	defer func() {
+		queryDigestSampleRate = saveRate
+	}()
+	queryDigestSampleRate = 1
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "varchar"), "a")
+	sbclookup.SetResults([]*sqltypes.Result{result, result})
+	_, err := executorExecSession(ctx, executor, session, "select * from main1 where id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select * from main1 where id = 2", nil)
+	require.NoError(t, err)
+
+	qr, err := executor.ShowQueryDigests(&sqlparser.ShowFilter{Like: "select * from main1%"})
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, "select * from main1 where id = :id /* INT64 */", qr.Rows[0][1].ToString())
+	assert.Equal(t, "2", qr.Rows[0][2].ToString())
+	assert.Equal(t, "0", qr.Rows[0][3].ToString())
+	assert.Equal(t, "2", qr.Rows[0][9].ToString())
+
+	qr, err = executor.ShowQueryDigests(&sqlparser.ShowFilter{Like: "update%"})
+	require.NoError(t, err)
+	assert.Empty(t, qr.Rows)
+}
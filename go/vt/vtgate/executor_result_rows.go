@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// parseMaxResultRows parses the value of --max-result-rows-by-plan-type. Its
+// keys are a plan type, or a keyspace and a plan type separated by a dot, and
+// its values the maximum numbers of rows. The returned limits are keyed the
+// same way, with the plan types spelled as by engine.PlanType.String.
+func parseMaxResultRows(limits map[string]string) (map[string]int, error) {
+	parsed := make(map[string]int, len(limits))
+	for key, value := range limits {
+		keyspace, name, ok := strings.Cut(key, ".")
+		if !ok {
+			keyspace, name = "", key
+		}
+		planType, ok := parsePlanType(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown plan type %q in %q", name, key)
+		}
+		rows, err := strconv.Atoi(value)
+		if err != nil || rows < 0 {
+			return nil, fmt.Errorf("invalid number of rows %q for %q", value, key)
+		}
+		parsed[maxResultRowsKey(keyspace, planType)] = rows
+	}
+	return parsed, nil
+}
+
+// parsePlanType returns the plan type of the given name, case-insensitively.
+func parsePlanType(name string) (engine.PlanType, bool) {
+	for planType := engine.PlanLocal; planType <= engine.PlanTopoOp; planType++ {
+		if strings.EqualFold(planType.String(), name) {
+			return planType, true
+		}
+	}
+	return engine.PlanUnknown, false
+}
+
+func maxResultRowsKey(keyspace string, planType engine.PlanType) string {
+	if keyspace == "" {
+		return planType.String()
+	}
+	return keyspace + "." + planType.String()
+}
+
+// maxResultRows returns the maximum number of rows of the results of the
+// plan, or 0 if they are not limited. The MAX_RESULT_ROWS directive of the
+// query takes precedence over the limits of the keyspaces of the plan, the
+// smallest of which is used, which take precedence over the cluster-wide
+// limit of the plan type.
+func (e *Executor) maxResultRows(plan *engine.Plan) int {
+	if plan.QueryHints.MaxResultRows != nil {
+		return *plan.QueryHints.MaxResultRows
+	}
+	if len(e.config.MaxResultRows) == 0 {
+		return 0
+	}
+
+	limit := 0
+	for _, table := range plan.TablesUsed {
+		keyspace, _, ok := strings.Cut(table, ".")
+		if !ok {
+			continue
+		}
+		if rows, ok := e.config.MaxResultRows[maxResultRowsKey(keyspace, plan.Type)]; ok && (limit == 0 || rows < limit) {
+			limit = rows
+		}
+	}
+	if limit > 0 {
+		return limit
+	}
+	return e.config.MaxResultRows[maxResultRowsKey("", plan.Type)]
+}
+
+// limitResultRows truncates a non-streaming result to the maximum number of
+// rows of its plan, with a warning.
+func (e *Executor) limitResultRows(safeSession *econtext.SafeSession, plan *engine.Plan, result *sqltypes.Result) *sqltypes.Result {
+	limit := e.maxResultRows(plan)
+	if limit == 0 || result == nil || len(result.Rows) <= limit {
+		return result
+	}
+
+	truncated := result.ShallowCopy()
+	truncated.Rows = result.Rows[:limit]
+	warnings.Add("ResultRowsTruncated", 1)
+	safeSession.RecordWarning(&querypb.QueryWarning{
+		Code:    uint32(sqlerror.ERTooManyRows),
+		Message: fmt.Sprintf("result truncated to %d of %d rows: %s plans are limited to %d rows, use the MAX_RESULT_ROWS query directive to override the limit", limit, len(result.Rows), plan.Type, limit),
+	})
+	return truncated
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestParseMaxResultRows(t *testing.T) {
+	limits, err := parseMaxResultRows(map[string]string{
+		"scatter":          "100",
+		"commerce.Scatter": "10",
+		"join":             "50",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"Scatter":          100,
+		"commerce.Scatter": 10,
+		"Join":             50,
+	}, limits)
+
+	_, err = parseMaxResultRows(map[string]string{"Sctter": "100"})
+	assert.EqualError(t, err, `unknown plan type "Sctter" in "Sctter"`)
+	_, err = parseMaxResultRows(map[string]string{"Scatter": "-1"})
+	assert.EqualError(t, err, `invalid number of rows "-1" for "Scatter"`)
+}
+
+func TestExecutorMaxResultRows(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	// Each of the 8 shards returns a row.
+	qr, err := executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 8)
+
+	executor.config.MaxResultRows = map[string]int{"Scatter": 5, "Passthrough": 1}
+	initialTruncated := warnings.Counts()["ResultRowsTruncated"]
+	qr, err = executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 5)
+	require.NotEmpty(t, session.Warnings)
+	assert.Equal(t, "result truncated to 5 of 8 rows: Scatter plans are limited to 5 rows, use the MAX_RESULT_ROWS query directive to override the limit", session.Warnings[len(session.Warnings)-1].Message)
+	assert.Equal(t, initialTruncated+1, warnings.Counts()["ResultRowsTruncated"])
+
+	// The limit of the keyspace takes precedence.
+	executor.config.MaxResultRows[KsTestSharded+".Scatter"] = 3
+	qr, err = executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 3)
+
+	// The directive overrides the limits.
+	qr, err = executorExecSession(ctx, executor, session, "select /*vt+ MAX_RESULT_ROWS=0 */ id from user", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 8)
+	assert.Empty(t, session.Warnings)
+	qr, err = executorExecSession(ctx, executor, session, "select /*vt+ MAX_RESULT_ROWS=7 */ id from user", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 7)
+
+	// The other plan types have their own limits.
+	qr, err = executorExecSession(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 1)
+	assert.Empty(t, session.Warnings)
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// resultSizeOtherCaller is the key under which all callers are accounted
+	// once --result-size-max-callers distinct callers have been seen.
+	resultSizeOtherCaller = "other"
+	// resultSizeUnknownCaller is used when the context carries no caller id.
+	resultSizeUnknownCaller = "unknown"
+)
+
+// resultSizeStats holds the result size accounting of a single caller.
+type resultSizeStats struct {
+	Caller   string
+	Queries  int64
+	Bytes    int64
+	MaxBytes int64
+	Exceeded int64
+}
+
+// resultSizeTracker accounts the number of bytes returned to each caller.
+// The number of tracked callers is bounded: once maxCallers distinct callers
+// have been seen, any new caller is accounted under resultSizeOtherCaller.
+type resultSizeTracker struct {
+	maxCallers int
+
+	mu      sync.Mutex
+	callers map[string]*resultSizeStats
+}
+
+func newResultSizeTracker(maxCallers int) *resultSizeTracker {
+	return &resultSizeTracker{
+		maxCallers: maxCallers,
+		callers:    make(map[string]*resultSizeStats),
+	}
+}
+
+// record accounts a result of the given size for the caller.
+func (t *resultSizeTracker) record(caller string, size int64, exceeded bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.callers[caller]
+	if !ok {
+		if len(t.callers) >= t.maxCallers {
+			caller = resultSizeOtherCaller
+			s = t.callers[caller]
+		}
+		if s == nil {
+			s = &resultSizeStats{Caller: caller}
+			t.callers[caller] = s
+		}
+	}
+	s.Queries++
+	s.Bytes += size
+	s.MaxBytes = max(s.MaxBytes, size)
+	if exceeded {
+		s.Exceeded++
+	}
+}
+
+// top returns a copy of the stats of all callers, ordered by the total number
+// of returned bytes (descending).
+func (t *resultSizeTracker) top() []resultSizeStats {
+	t.mu.Lock()
+	all := make([]resultSizeStats, 0, len(t.callers))
+	for _, s := range t.callers {
+		all = append(all, *s)
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b resultSizeStats) int {
+		if a.Bytes != b.Bytes {
+			if a.Bytes > b.Bytes {
+				return -1
+			}
+			return 1
+		}
+		if a.Caller < b.Caller {
+			return -1
+		}
+		if a.Caller > b.Caller {
+			return 1
+		}
+		return 0
+	})
+	return all
+}
+
+// resultSizeCaller returns the name under which the result size of a query
+// is accounted. The immediate caller is preferred over the effective caller.
+func resultSizeCaller(ctx context.Context) string {
+	if username := callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)); username != "" {
+		return username
+	}
+	if principal := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)); principal != "" {
+		return principal
+	}
+	return resultSizeUnknownCaller
+}
+
+// resultSize returns the number of bytes held by the rows of the result.
+func resultSize(qr *sqltypes.Result) int64 {
+	var size int64
+	for _, row := range qr.Rows {
+		for _, col := range row {
+			size += int64(col.Len())
+		}
+	}
+	return size
+}
+
+// shardResultsLimitKey marks the context of a plan whose result is made of
+// the results of its shards as they are.
+type shardResultsLimitKey struct{}
+
+// withShardResultsLimit returns the context in which the plan is executed,
+// marked if --max-result-bytes can be enforced on the results of its shards
+// while they are accumulated. This is only the case if the plan is a route
+// returning them as they are: the results of the shards of a query which
+// vtgate aggregates, joins or limits can be far larger than its result.
+func withShardResultsLimit(ctx context.Context, plan *engine.Plan) context.Context {
+	if maxResultBytes <= 0 || truncateResultBytes {
+		return ctx
+	}
+	if route, ok := plan.Instructions.(*engine.Route); !ok || route.TruncateColumnCount > 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, shardResultsLimitKey{}, true)
+}
+
+// shardResultsSize enforces --max-result-bytes on the results of the shards
+// of a query while vtgate accumulates them, so that a query whose result is
+// above the limit fails before the whole result is buffered. The results are
+// only accounted in a context marked by withShardResultsLimit, so that they
+// are never dropped from a result which would then be computed over partial
+// data: the results above the limit are otherwise truncated or rejected by
+// checkResultSize. It is not safe for concurrent use, the results being
+// accumulated under a lock.
+type shardResultsSize struct {
+	limited bool
+	size    int64
+	// cancel cancels the queries of the shards still running once the limit
+	// is exceeded. It is nil when they must complete, in a transaction.
+	cancel context.CancelFunc
+}
+
+func newShardResultsSize(ctx context.Context) shardResultsSize {
+	return shardResultsSize{limited: ctx.Value(shardResultsLimitKey{}) != nil}
+}
+
+// add accounts the result of a shard, and returns whether it is appended to
+// the accumulated result. Once the limit is exceeded, the query fails with err
+// and the results of the other shards are dropped.
+func (s *shardResultsSize) add(qr *sqltypes.Result) bool {
+	if !s.limited || qr == nil {
+		return true
+	}
+	if s.size > maxResultBytes {
+		return false
+	}
+	s.size += resultSize(qr)
+	if s.size <= maxResultBytes {
+		return true
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return false
+}
+
+// err returns the error of a query whose shard results exceed the limit.
+func (s *shardResultsSize) err() error {
+	if !s.limited || s.size <= maxResultBytes {
+		return nil
+	}
+	return &resultBytesExceededError{size: s.size, limit: maxResultBytes}
+}
+
+// resultBytesExceededError is the error of a query aborted as the results of
+// its shards exceed --max-result-bytes, for the executor to account it.
+type resultBytesExceededError struct {
+	size  int64
+	limit int64
+}
+
+func (e *resultBytesExceededError) Error() string {
+	return fmt.Sprintf("result size of at least %d bytes exceeded allowed limit of %d bytes", e.size, e.limit)
+}
+
+func (e *resultBytesExceededError) ErrorState() vterrors.State { return vterrors.NetPacketTooLarge }
+
+func (e *resultBytesExceededError) ErrorCode() vtrpcpb.Code { return vtrpcpb.Code_RESOURCE_EXHAUSTED }
+
+// recordShardResultsSize accounts the query of the caller if the results of
+// its shards exceeded the limit, since the query fails without a result.
+func (e *Executor) recordShardResultsSize(ctx context.Context, errs []error) {
+	for _, err := range errs {
+		if sizeErr, ok := err.(*resultBytesExceededError); ok {
+			e.resultSizes.record(resultSizeCaller(ctx), sizeErr.size, true)
+			return
+		}
+	}
+}
+
+// checkResultSize accounts the size of a non-streaming result and enforces
+// --max-result-bytes. Depending on --truncate-result-bytes, results above the
+// limit are either rejected or truncated with a warning.
+func (e *Executor) checkResultSize(ctx context.Context, safeSession *econtext.SafeSession, result *sqltypes.Result) (*sqltypes.Result, error) {
+	size := resultSize(result)
+	exceeded := maxResultBytes > 0 && size > maxResultBytes
+	e.resultSizes.record(resultSizeCaller(ctx), size, exceeded)
+
+	if !exceeded {
+		if warnResultBytes > 0 && size > warnResultBytes {
+			warnings.Add("ResultBytesExceeded", 1)
+			safeSession.RecordWarning(&querypb.QueryWarning{
+				Code:    uint32(sqlerror.EROutOfMemory),
+				Message: fmt.Sprintf("result size of %d bytes exceeds warning threshold of %d bytes", size, warnResultBytes),
+			})
+		}
+		return result, nil
+	}
+
+	if !truncateResultBytes {
+		return nil, vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "result size of %d bytes exceeded allowed limit of %d bytes", size, maxResultBytes)
+	}
+
+	var kept int64
+	rows := 0
+	for _, row := range result.Rows {
+		var rowSize int64
+		for _, col := range row {
+			rowSize += int64(col.Len())
+		}
+		if kept+rowSize > maxResultBytes {
+			break
+		}
+		kept += rowSize
+		rows++
+	}
+	truncated := result.ShallowCopy()
+	truncated.Rows = result.Rows[:rows]
+	warnings.Add("ResultBytesTruncated", 1)
+	safeSession.RecordWarning(&querypb.QueryWarning{
+		Code:    uint32(sqlerror.EROutOfMemory),
+		Message: fmt.Sprintf("result truncated to %d of %d rows: result size of %d bytes exceeded allowed limit of %d bytes", rows, len(result.Rows), size, maxResultBytes),
+	})
+	return truncated, nil
+}
+
+// ShowResultSizes returns the result size accounting per caller, ordered by
+// the total number of returned bytes.
+func (e *Executor) ShowResultSizes(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	var likeFilter func(string) bool
+	if filter != nil && filter.Like != "" {
+		callerRegexp := sqlparser.LikeToRegexp(filter.Like)
+		likeFilter = callerRegexp.MatchString
+	}
+
+	rows := [][]sqltypes.Value{}
+	for _, s := range e.resultSizes.top() {
+		if likeFilter != nil && !likeFilter(s.Caller) {
+			continue
+		}
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.NewVarChar(s.Caller),
+			sqltypes.NewInt64(s.Queries),
+			sqltypes.NewInt64(s.Bytes),
+			sqltypes.NewInt64(s.MaxBytes),
+			sqltypes.NewInt64(s.Exceeded),
+		})
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "Caller", Type: sqltypes.VarChar},
+			{Name: "Queries", Type: sqltypes.Int64},
+			{Name: "Bytes", Type: sqltypes.Int64},
+			{Name: "MaxBytes", Type: sqltypes.Int64},
+			{Name: "Exceeded", Type: sqltypes.Int64},
+		},
+		Rows: rows,
+	}, nil
+}
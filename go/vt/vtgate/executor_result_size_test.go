@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestResultSizeTracker(t *testing.T) {
+	tracker := newResultSizeTracker(2)
+	tracker.record("a", 10, false)
+	tracker.record("b", 30, true)
+	tracker.record("a", 5, false)
+	// The tracker is full, new callers are accounted as "other".
+	tracker.record("c", 1, false)
+	tracker.record("d", 2, false)
+
+	assert.Equal(t, []resultSizeStats{
+		{Caller: "b", Queries: 1, Bytes: 30, MaxBytes: 30, Exceeded: 1},
+		{Caller: "a", Queries: 2, Bytes: 15, MaxBytes: 10},
+		{Caller: "other", Queries: 2, Bytes: 3, MaxBytes: 2},
+	}, tracker.top())
+}
+
+func TestExecutorMaxResultBytes(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	ctx = callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("app"))
+
+	saveMax, saveWarn, saveTruncate := maxResultBytes, warnResultBytes, truncateResultBytes
+	defer func() {
+		maxResultBytes, warnResultBytes, truncateResultBytes = saveMax, saveWarn, saveTruncate
+	}()
+	maxResultBytes = 3
+	warnResultBytes = 2
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	small := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "varchar"), "a", "b")
+	large := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "varchar"), "a", "b", "c", "d")
+
+	initialWarnings := warnings.Counts()["ResultBytesExceeded"]
+	sbclookup.SetResults([]*sqltypes.Result{small})
+	_, err := executorExecSession(ctx, executor, session, "select * from main1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, initialWarnings, warnings.Counts()["ResultBytesExceeded"])
+
+	sbclookup.SetResults([]*sqltypes.Result{large})
+	_, err = executorExecSession(ctx, executor, session, "select * from main1", nil)
+	assert.EqualError(t, err, "result size of at least 4 bytes exceeded allowed limit of 3 bytes")
+
+	truncateResultBytes = true
+	sbclookup.SetResults([]*sqltypes.Result{large})
+	qr, err := executorExecSession(ctx, executor, session, "select * from main1", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 3)
+	require.NotEmpty(t, session.Warnings)
+	assert.Equal(t, "result truncated to 3 of 4 rows: result size of 4 bytes exceeded allowed limit of 3 bytes", session.Warnings[len(session.Warnings)-1].Message)
+
+	// The output of SHOW is subject to the limit as well.
+	maxResultBytes, warnResultBytes = 0, 0
+	qr, err = executorExecSession(ctx, executor, session, "show vitess_result_sizes like 'ap%'", nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{{
+		sqltypes.NewVarChar("app"),
+		sqltypes.NewInt64(3),
+		sqltypes.NewInt64(10),
+		sqltypes.NewInt64(4),
+		sqltypes.NewInt64(2),
+	}}, qr.Rows)
+}
+
+func TestShardResultsSize(t *testing.T) {
+	saveMax, saveTruncate := maxResultBytes, truncateResultBytes
+	defer func() {
+		maxResultBytes, truncateResultBytes = saveMax, saveTruncate
+	}()
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "varchar"), "ab", "cd")
+	route := &engine.Plan{Instructions: &engine.Route{}}
+	ctx := context.Background()
+
+	// Without a limit, the results are not accounted.
+	maxResultBytes = 0
+	size := newShardResultsSize(withShardResultsLimit(ctx, route))
+	assert.False(t, size.limited)
+	for range 3 {
+		assert.True(t, size.add(result))
+	}
+	assert.NoError(t, size.err())
+
+	// Once the limit is exceeded, the other results are dropped and the
+	// queries of the other shards are canceled.
+	maxResultBytes = 6
+	canceled := false
+	size = newShardResultsSize(withShardResultsLimit(ctx, route))
+	size.cancel = func() { canceled = true }
+	assert.True(t, size.add(result))
+	assert.True(t, size.add(nil))
+	assert.False(t, canceled)
+	assert.False(t, size.add(result))
+	assert.True(t, canceled)
+	assert.False(t, size.add(result))
+	assert.EqualError(t, size.err(), "result size of at least 8 bytes exceeded allowed limit of 6 bytes")
+
+	// The results of the shards of a plan which is not a route returning them
+	// as they are, or whose result is truncated, are never dropped.
+	for _, plan := range []*engine.Plan{
+		{Instructions: &engine.Limit{Input: &engine.Route{}}},
+		{Instructions: &engine.Route{TruncateColumnCount: 1}},
+	} {
+		size = newShardResultsSize(withShardResultsLimit(ctx, plan))
+		assert.False(t, size.limited)
+		for range 3 {
+			assert.True(t, size.add(result))
+		}
+		assert.NoError(t, size.err())
+	}
+	truncateResultBytes = true
+	size = newShardResultsSize(withShardResultsLimit(ctx, route))
+	assert.False(t, size.limited)
+	for range 3 {
+		assert.True(t, size.add(result))
+	}
+	assert.NoError(t, size.err())
+}
+
+func TestExecutorMaxResultBytesScatter(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+
+	saveMax, saveTruncate := maxResultBytes, truncateResultBytes
+	defer func() {
+		maxResultBytes, truncateResultBytes = saveMax, saveTruncate
+	}()
+	maxResultBytes = 10
+
+	// Each of the 8 shards returns a row of 4 bytes: the query fails as the
+	// results of the shards are accumulated.
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	_, err := executorExecSession(ctx, executor, session, "select id, value from user", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "result size of at least 12 bytes exceeded allowed limit of 10 bytes")
+
+	// In a transaction, the queries of the shards are not canceled.
+	session = econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", InTransaction: true})
+	_, err = executorExecSession(ctx, executor, session, "select id, value from user", nil)
+	assert.ErrorContains(t, err, "exceeded allowed limit of 10 bytes")
+
+	truncateResultBytes = true
+	session = econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	qr, err := executorExecSession(ctx, executor, session, "select id, value from user", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 2)
+	require.NotEmpty(t, session.Warnings)
+	assert.Equal(t, "result truncated to 2 of 8 rows: result size of 32 bytes exceeded allowed limit of 10 bytes", session.Warnings[len(session.Warnings)-1].Message)
+}
+
+func TestExecutorMaxResultBytesScatterAggregate(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for _, shard := range shards {
+		conns = append(conns, hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil))
+	}
+	// Each query consumes the results of the shards.
+	setResults := func() {
+		for i, sbc := range conns {
+			sbc.SetResults([]*sqltypes.Result{{
+				Fields: []*querypb.Field{
+					{Name: "col", Type: sqltypes.Int32, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
+					{Name: "sum(foo)", Type: sqltypes.Int32, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
+					{Name: "weight_string(col)", Type: sqltypes.VarBinary, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_BINARY_FLAG)},
+				},
+				Rows: [][]sqltypes.Value{{
+					sqltypes.NewInt32(int32(i % 4)),
+					sqltypes.NewInt32(int32(i)),
+					sqltypes.NULL,
+				}},
+			}})
+		}
+	}
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	saveMax, saveTruncate := maxResultBytes, truncateResultBytes
+	defer func() {
+		maxResultBytes, truncateResultBytes = saveMax, saveTruncate
+	}()
+	truncateResultBytes = true
+
+	// The 8 shards return 16 bytes, which vtgate aggregates into a result of
+	// 9 bytes: all the results of the shards are aggregated.
+	maxResultBytes = 10
+	query := "select col, sum(foo) from user group by col"
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	setResults()
+	qr, err := executorExecSession(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[INT32(0) DECIMAL(4)] [INT32(1) DECIMAL(6)] [INT32(2) DECIMAL(8)] [INT32(3) DECIMAL(10)]]`, fmt.Sprintf("%v", qr.Rows))
+	for _, warning := range session.Warnings {
+		assert.NotContains(t, warning.Message, "result truncated")
+	}
+
+	// The aggregated result is truncated.
+	maxResultBytes = 6
+	session = econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	setResults()
+	qr, err = executorExecSession(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[INT32(0) DECIMAL(4)] [INT32(1) DECIMAL(6)] [INT32(2) DECIMAL(8)]]`, fmt.Sprintf("%v", qr.Rows))
+	require.NotEmpty(t, session.Warnings)
+	assert.Equal(t, "result truncated to 3 of 4 rows: result size of 9 bytes exceeded allowed limit of 6 bytes", session.Warnings[len(session.Warnings)-1].Message)
+
+	// Without truncation, the query does not fail on the size of the results
+	// of the shards.
+	truncateResultBytes = false
+	maxResultBytes = 10
+	session = econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	setResults()
+	qr, err = executorExecSession(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 4)
+}
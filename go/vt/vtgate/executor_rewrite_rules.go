@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+var (
+	rewriteRuleHits = stats.NewCountersWithMultiLabels("QueryRewriteRuleHits", "Number of queries matched by the query rewrite rules, by rule and by whether the query was rewritten, would have been rewritten by a rule in dry-run mode, or could not be parsed once rewritten", []string{"Rule", "Result"})
+
+	logRewriteRules = logutil.NewThrottledLogger("RewriteRule", 5*time.Second)
+)
+
+// applyRewriteRules applies the query rewrite rules of the vschema, in order,
+// to the query, whose statement is stmt. It returns the rewritten query with
+// its statement and reserved vars, which are the given ones if no rule
+// rewrote the query.
+func (e *Executor) applyRewriteRules(
+	vschema *vindexes.VSchema,
+	query string,
+	stmt sqlparser.Statement,
+	reservedVars *sqlparser.ReservedVars,
+) (string, sqlparser.Statement, *sqlparser.ReservedVars) {
+	if vschema == nil {
+		return query, stmt, reservedVars
+	}
+	for _, rule := range vschema.RewriteRules {
+		rewritten, matched := rule.Rewrite(query, stmt)
+		if !matched {
+			continue
+		}
+		if rule.DryRun {
+			rewriteRuleHits.Add([]string{rule.Name, "DryRun"}, 1)
+			logRewriteRules.Infof("Rewrite rule %s would rewrite the query %q to %q", rule.Name, query, rewritten)
+			continue
+		}
+		rewrittenStmt, rewrittenVars, err := parseAndValidateQuery(rewritten, e.env.Parser())
+		if err != nil {
+			rewriteRuleHits.Add([]string{rule.Name, "Failed"}, 1)
+			logRewriteRules.Warningf("Rewrite rule %s rewrote the query %q to %q, which is ignored as it is invalid: %v", rule.Name, query, rewritten, err)
+			continue
+		}
+		rewriteRuleHits.Add([]string{rule.Name, "Rewritten"}, 1)
+		query, stmt, reservedVars = rewritten, rewrittenStmt, rewrittenVars
+	}
+	return query, stmt, reservedVars
+}
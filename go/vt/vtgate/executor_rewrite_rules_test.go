@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestExecutorRewriteRules(t *testing.T) {
+	executor, sbc1, _, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	rewriteRuleHits.ResetAll()
+
+	var rules []*vindexes.RewriteRule
+	for _, source := range []*vschemapb.RewriteRule{{
+		Name:        "rename",
+		Regex:       `\blegacy_user\b`,
+		Replacement: "user",
+	}, {
+		Name:        "hint",
+		Pattern:     "select id from user where id = :id",
+		Replacement: "select id from user use index (primary) where id = :id",
+	}, {
+		Name:        "strip",
+		Regex:       ` order by null$`,
+		Replacement: "",
+		DryRun:      true,
+	}, {
+		Name:        "broken",
+		Regex:       `^select name from`,
+		Replacement: "select name frm",
+	}} {
+		rule, err := vindexes.BuildRewriteRule(source, executor.env.Parser())
+		require.NoError(t, err)
+		rules = append(rules, rule)
+	}
+	executor.VSchema().RewriteRules = rules
+
+	_, err := executorExecSession(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Equal(t, "select id from `user` use index (`primary`) where id = 1", sbc1.Queries[0].Sql)
+
+	// The rules apply to the query rewritten by the previous rules.
+	sbc1.Queries = nil
+	_, err = executorExecSession(ctx, executor, session, "select id from legacy_user where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Equal(t, "select id from `user` use index (`primary`) where id = 1", sbc1.Queries[0].Sql)
+
+	// A rule in dry-run mode does not rewrite the query.
+	sbc1.Queries = nil
+	_, err = executorExecSession(ctx, executor, session, "select id from user where id = 1 order by null", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Equal(t, "select id from `user` where id = 1 order by null", sbc1.Queries[0].Sql)
+
+	// A rewritten query which is invalid is ignored.
+	sbc1.Queries = nil
+	_, err = executorExecSession(ctx, executor, session, "select name from user where id = 1", nil)
+	require.NoError(t, err)
+	require.Len(t, sbc1.Queries, 1)
+	assert.Equal(t, "select `name` from `user` where id = 1", sbc1.Queries[0].Sql)
+
+	assert.Equal(t, map[string]int64{
+		"hint.Rewritten":   2,
+		"rename.Rewritten": 1,
+		"strip.DryRun":     1,
+		"broken.Failed":    1,
+	}, rewriteRuleHits.Counts())
+}
@@ -18,6 +18,7 @@ package vtgate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -34,6 +35,7 @@ import (
 
 	_flag "vitess.io/vitess/go/internal/flag"
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/test/utils"
@@ -789,6 +791,31 @@ func TestSelectLastInsertId(t *testing.T) {
 	utils.MustMatch(t, wantResult, result, "Mismatch")
 }
 
+func TestSelectReplicationFreshness(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+	hc := executor.scatterConn.gateway.hc.(*discovery.FakeHealthCheck)
+	replica := hc.AddTestTablet("aa", "-20-replica", 1, KsTestSharded, "-20", topodatapb.TabletType_REPLICA, true, 1, nil)
+	th, err := hc.GetTabletHealthByAlias(replica.Tablet().Alias)
+	require.NoError(t, err)
+	th.Stats = &querypb.RealtimeStats{ReplicationStalenessMs: 1500}
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	sql := "select vitess_replication_freshness('TestExecutor', '-20')"
+	result, err := executorExec(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, "FLOAT64(1.5)", result.Rows[0][0].String())
+
+	// The freshness is measured when the query is executed, not planned.
+	th.Stats = &querypb.RealtimeStats{ReplicationStalenessMs: 250}
+	result, err = executorExec(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "FLOAT64(0.25)", result.Rows[0][0].String())
+
+	_, err = executorExec(ctx, executor, session, "select vitess_replication_freshness('TestExecutor', '40-60')", nil)
+	require.ErrorContains(t, err, "no healthy tablet available")
+}
+
 func TestSelectSystemVariables(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 
@@ -799,13 +826,16 @@ func TestSelectSystemVariables(t *testing.T) {
 			ReadAfterWriteTimeout: 13,
 			SessionTrackGtids:     true,
 		},
+		TabletTags:     map[string]string{"zone": "a", "analytics": "true"},
+		MaxStalenessMs: 30000,
 	}
 	logChan := executor.queryLogger.Subscribe("Test")
 	defer executor.queryLogger.Unsubscribe(logChan)
 
 	sql := "select @@autocommit, @@client_found_rows, @@skip_query_plan_cache, @@enable_system_settings, " +
 		"@@sql_select_limit, @@transaction_mode, @@workload, @@read_after_write_gtid, " +
-		"@@read_after_write_timeout, @@session_track_gtids, @@ddl_strategy, @@migration_context, @@socket, @@query_timeout"
+		"@@read_after_write_timeout, @@session_track_gtids, @@ddl_strategy, @@migration_context, @@socket, @@query_timeout, @@vitess_tablet_tags, " +
+		"@@vitess_max_staleness"
 
 	result, err := executorExec(ctx, executor, session, sql, map[string]*querypb.BindVariable{})
 	wantResult := &sqltypes.Result{
@@ -824,6 +854,8 @@ func TestSelectSystemVariables(t *testing.T) {
 			{Name: "@@migration_context", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
 			{Name: "@@socket", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
 			{Name: "@@query_timeout", Type: sqltypes.Int64, Charset: collations.CollationBinaryID, Flags: uint32(querypb.MySqlFlag_NUM_FLAG)},
+			{Name: "@@vitess_tablet_tags", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
+			{Name: "@@vitess_max_staleness", Type: sqltypes.VarChar, Charset: uint32(collations.MySQL8().DefaultConnectionCharset())},
 		},
 		Rows: [][]sqltypes.Value{{
 			// the following are the uninitialised session values
@@ -842,6 +874,8 @@ func TestSelectSystemVariables(t *testing.T) {
 			sqltypes.NewVarChar(""),
 			sqltypes.NewVarChar(""),
 			sqltypes.NewInt64(0),
+			sqltypes.NewVarChar("analytics=true,zone=a"),
+			sqltypes.NewVarChar("30s"),
 		}},
 	}
 	require.NoError(t, err)
@@ -1680,6 +1714,182 @@ func TestSelectScatter(t *testing.T) {
 	testQueryLog(t, executor, logChan, "TestExecute", "SELECT", sql, 8)
 }
 
+func TestSelectScatterConsistentReads(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Special setup: Don't use createExecutorEnv.
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for i, shard := range shards {
+		sbc := hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		// The position is read when the snapshot is taken, and again as
+		// soon as the last snapshot is taken to check that they are aligned.
+		position := sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("@@global.gtid_executed", "varchar"),
+			fmt.Sprintf("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-%d", i+1),
+		)
+		sbc.SetResults([]*sqltypes.Result{position, position})
+		conns = append(conns, sbc)
+	}
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	_, err := executorExecSession(ctx, executor, session, "set @@vitess_consistent_reads = 1", nil)
+	require.NoError(t, err)
+
+	sql := "select id from `user`"
+	qr, err := executorExecSession(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 8)
+	assert.False(t, session.InTransaction())
+	wantQueries := []*querypb.BoundQuery{{
+		Sql: "select @@global.gtid_executed",
+	}, {
+		Sql: "select @@global.gtid_executed",
+	}, {
+		Sql:           "select id from `user`",
+		BindVariables: map[string]*querypb.BindVariable{},
+	}}
+	for _, conn := range conns {
+		utils.MustMatch(t, wantQueries, conn.Queries)
+		assert.EqualValues(t, 1, conn.BeginCount.Load())
+		assert.EqualValues(t, 1, conn.RollbackCount.Load())
+		assert.Equal(t, querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY, conn.Options[0].TransactionIsolation)
+	}
+
+	qr, err = executorExecSession(ctx, executor, session, "select @@vitess_consistent_read_positions from dual", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	positions := qr.Rows[0][0].ToString()
+	assert.Contains(t, positions, `"shard":"-20","gtid":"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1"`)
+	assert.Contains(t, positions, `"shard":"e0-","gtid":"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-8"`)
+
+	// Single shard reads and reads in a transaction are not affected.
+	for _, conn := range conns {
+		conn.Queries = nil
+	}
+	_, err = executorExecSession(ctx, executor, session, "select id from `user` where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, conns[0].BeginCount.Load())
+	_, err = executorExecSession(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, sql, nil)
+	require.NoError(t, err)
+	for _, conn := range conns {
+		assert.EqualValues(t, 2, conn.BeginCount.Load())
+	}
+}
+
+func TestSelectScatterConsistentReadsNotAligned(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Special setup: Don't use createExecutorEnv.
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for i, shard := range shards {
+		sbc := hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		var results []*sqltypes.Result
+		for attempt := range consistentReadSnapshotAttempts {
+			// The first shard executes a transaction after each of its
+			// snapshots, the others are not written to.
+			snapshot, current := 1, 1
+			if i == 0 {
+				snapshot, current = attempt+1, attempt+2
+			}
+			for _, sequence := range []int{snapshot, current} {
+				results = append(results, sqltypes.MakeTestResult(
+					sqltypes.MakeTestFields("@@global.gtid_executed", "varchar"),
+					fmt.Sprintf("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-%d", sequence),
+				))
+			}
+		}
+		sbc.SetResults(results)
+		conns = append(conns, sbc)
+	}
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	_, err := executorExecSession(ctx, executor, session, "set @@vitess_consistent_reads = 1", nil)
+	require.NoError(t, err)
+
+	_, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.ErrorContains(t, err, "cannot align the snapshots of the consistent read after 3 attempts: shard TestExecutor/-20 executed transactions while the snapshots were taken")
+	assert.Equal(t, vtrpcpb.Code_ABORTED, vterrors.Code(err))
+	for _, conn := range conns {
+		// No shard was read, and all the snapshots were closed.
+		for _, query := range conn.Queries {
+			assert.Equal(t, "select @@global.gtid_executed", query.Sql)
+		}
+		assert.EqualValues(t, consistentReadSnapshotAttempts, conn.BeginCount.Load())
+		assert.EqualValues(t, consistentReadSnapshotAttempts, conn.RollbackCount.Load())
+	}
+}
+
+func TestSelectScatterConsistentReadsContinuousWrites(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Special setup: Don't use createExecutorEnv.
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for _, shard := range shards {
+		sbc := hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		// All the shards are written to continuously: each of them executes
+		// a transaction between its first snapshot and the time its position
+		// is read again, and the second snapshots happen to be aligned.
+		var results []*sqltypes.Result
+		for _, sequence := range []int{1, 2, 3, 3} {
+			results = append(results, sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields("@@global.gtid_executed", "varchar"),
+				fmt.Sprintf("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-%d", sequence),
+			))
+		}
+		sbc.SetResults(results)
+		conns = append(conns, sbc)
+	}
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	_, err := executorExecSession(ctx, executor, session, "set @@vitess_consistent_reads = 1", nil)
+	require.NoError(t, err)
+
+	// The snapshots are taken again, and the read succeeds from the second
+	// ones.
+	_, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.NoError(t, err)
+	for _, conn := range conns {
+		assert.EqualValues(t, 2, conn.BeginCount.Load())
+		assert.EqualValues(t, 2, conn.RollbackCount.Load())
+	}
+	assert.Contains(t, session.GetConsistentReadPositions(), `"shard":"-20","gtid":"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-3"`)
+}
+
 func TestSelectScatterPartial(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -1747,6 +1957,122 @@ func TestSelectScatterPartial(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSelectScatterShardErrors(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Special setup: Don't use createExecutorEnv.
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for _, shard := range shards {
+		sbc := hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		conns = append(conns, sbc)
+	}
+
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	conns[2].MustFailCodes[vtrpcpb.Code_RESOURCE_EXHAUSTED] = 1
+	conns[5].MustFailCodes[vtrpcpb.Code_ABORTED] = 1
+	_, err := executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.Contains(t, err.Error(), "ABORTED error (2 shards failed: TestExecutor/40-60: errno 1203, RESOURCE_EXHAUSTED, not retryable; TestExecutor/a0-c0: errno 1317, ABORTED, retryable)")
+
+	qr, err := executorExecSession(ctx, executor, session, "select @@vitess_shard_errors from dual", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	var shardErrors []*econtext.ShardError
+	require.NoError(t, json.Unmarshal([]byte(qr.Rows[0][0].ToString()), &shardErrors))
+	require.Len(t, shardErrors, 2)
+	assert.Equal(t, "40-60", shardErrors[0].Shard)
+	assert.Equal(t, "RESOURCE_EXHAUSTED", shardErrors[0].Code)
+	assert.False(t, shardErrors[0].Retryable)
+	assert.Equal(t, "a0-c0", shardErrors[1].Shard)
+	assert.True(t, shardErrors[1].Retryable)
+
+	// The errors are cleared by the next query executed on shards.
+	_, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.GetShardErrors())
+}
+
+func TestSelectPartialScatterReads(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	// Special setup: Don't use createExecutorEnv.
+	cell := "aa"
+	hc := discovery.NewFakeHealthCheck(nil)
+	u := createSandbox(KsTestUnsharded)
+	s := createSandbox(KsTestSharded)
+	s.VSchema = executorVSchema
+	u.VSchema = unshardedVSchema
+	serv := newSandboxForCells(ctx, []string{cell})
+	resolver := newTestResolver(ctx, hc, serv, cell)
+	shards := []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"}
+	var conns []*sandboxconn.SandboxConn
+	for _, shard := range shards {
+		sbc := hc.AddTestTablet(cell, shard, 1, "TestExecutor", shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		conns = append(conns, sbc)
+	}
+
+	executor := createExecutor(ctx, serv, cell, resolver)
+	defer executor.Close()
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+	_, err := executorExecSession(ctx, executor, session, "set @@vitess_partial_scatter_reads = 1", nil)
+	require.NoError(t, err)
+	qr, err := executorExecSession(ctx, executor, session, "select @@vitess_partial_scatter_reads from dual", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[INT64(1)]]`, fmt.Sprintf("%v", qr.Rows))
+
+	// The results of the shards which could be reached are returned, with a
+	// warning listing the unreachable shards.
+	conns[2].MustFailCodes[vtrpcpb.Code_UNAVAILABLE] = 1000
+	wantWarning := &querypb.QueryWarning{
+		Code:    uint32(sqlerror.ERUnknownError),
+		Message: "partial results, 1 shard failed: TestExecutor/40-60: errno 1105, UNAVAILABLE, retryable",
+	}
+	qr, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.NoError(t, err)
+	assert.Len(t, qr.Rows, 7)
+	utils.MustMatch(t, []*querypb.QueryWarning{wantWarning}, session.GetWarnings())
+	assert.Contains(t, session.GetShardErrors(), `"shard":"40-60"`)
+
+	// The rows of the shards are merged when streamed in order.
+	session.ClearWarnings()
+	var rows int
+	err = executor.StreamExecute(ctx, nil, "TestExecuteStream", session, "select id from `user` order by id", nil, func(qr *sqltypes.Result) error {
+		rows += len(qr.Rows)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, rows)
+	warnings := session.GetWarnings()
+	require.NotEmpty(t, warnings)
+	utils.MustMatch(t, wantWarning, warnings[len(warnings)-1])
+
+	// The errors of the queries still fail them.
+	conns[3].MustFailCodes[vtrpcpb.Code_RESOURCE_EXHAUSTED] = 1000
+	_, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.ErrorContains(t, err, "RESOURCE_EXHAUSTED error")
+	conns[3].MustFailCodes[vtrpcpb.Code_RESOURCE_EXHAUSTED] = 0
+
+	// The reads of a transaction are never partial.
+	_, err = executorExecSession(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from `user`", nil)
+	require.ErrorContains(t, err, "UNAVAILABLE error")
+}
+
 func TestSelectScatterPartialOLAP(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -22,7 +22,9 @@ import (
 
 	"vitess.io/vitess/go/mysql/sqlerror"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 
 	"vitess.io/vitess/go/test/utils"
 
@@ -153,6 +155,57 @@ func TestExecutorSet(t *testing.T) {
 	}, {
 		in:  "set workload = 1",
 		err: "incorrect argument type to variable 'workload': INT64",
+	}, {
+		in:  "set @@vitess_tablet_tags = 'analytics=true, zone = a'",
+		out: &vtgatepb.Session{Autocommit: true, TabletTags: map[string]string{"analytics": "true", "zone": "a"}},
+	}, {
+		in:  "set @@vitess_tenant_id = '42'",
+		out: &vtgatepb.Session{Autocommit: true, TenantId: "42"},
+	}, {
+		in:  "set @@vitess_tenant_id = ''",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set @@vitess_planner_flags = 'hash_join=off, subquery_pushdown = correlated'",
+		out: &vtgatepb.Session{Autocommit: true, PlannerFlags: map[string]string{"hash_join": "off", "subquery_pushdown": "correlated"}},
+	}, {
+		in:  "set @@vitess_planner_flags = 'hash_join=maybe'",
+		err: "invalid value 'maybe' for planner flag 'hash_join', expected one of: on, off",
+	}, {
+		in:  "set @@vitess_consistent_reads = 1",
+		out: &vtgatepb.Session{Autocommit: true, ConsistentReads: true},
+	}, {
+		in:  "set @@vitess_consistent_read_positions = 'x'",
+		err: "VT03010: variable 'vitess_consistent_read_positions' is a read only variable",
+	}, {
+		in:  "set @@vitess_partial_scatter_reads = 1",
+		out: &vtgatepb.Session{Autocommit: true, PartialScatterReads: true},
+	}, {
+		in:  "set @@vitess_max_staleness = '30s'",
+		out: &vtgatepb.Session{Autocommit: true, MaxStalenessMs: 30000},
+	}, {
+		in:  "set @@vitess_max_staleness = ''",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set @@vitess_max_staleness = '30'",
+		err: "invalid max staleness '30': the staleness is a duration, e.g. 30s",
+	}, {
+		in:  "set @@vitess_shard_errors = 'x'",
+		err: "VT03010: variable 'vitess_shard_errors' is a read only variable",
+	}, {
+		in:  "set @@ddl_in_transaction = 'error'",
+		out: &vtgatepb.Session{Autocommit: true, DdlInTransaction: vtgatepb.DDLInTransaction_ERROR},
+	}, {
+		in:  "set @@ddl_in_transaction = implicit_commit",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set @@ddl_in_transaction = 'rollback'",
+		err: "invalid ddl_in_transaction: rollback",
+	}, {
+		in:  "set @@vitess_tablet_tags = ''",
+		out: &vtgatepb.Session{Autocommit: true},
+	}, {
+		in:  "set @@vitess_tablet_tags = 'analytics'",
+		err: "invalid tablet tag 'analytics': tablet tags are key=value pairs",
 	}, {
 		in:  "set tx_isolation = 'read-committed'",
 		out: &vtgatepb.Session{Autocommit: true},
@@ -644,3 +697,97 @@ func TestExecutorTimeZone(t *testing.T) {
 
 	assert.False(t, qr.Rows[0][0].Equal(qrWith.Rows[0][0]), "%v vs %v", qr.Rows[0][0].ToString(), qrWith.Rows[0][0].ToString())
 }
+
+func TestExecutorTabletTags(t *testing.T) {
+	var replica *sandboxconn.SandboxConn
+	executor, ctx := createExecutorEnvCallback(t, createExecutorConfig(), func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks == KsTestUnsharded && tabletType == topodatapb.TabletType_REPLICA {
+			replica = conn
+		}
+	})
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded + "@replica"})
+
+	_, err := executorExecSession(ctx, executor, session, "set @@vitess_tablet_tags = 'analytics=true'", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from music_user_map where id = 1", nil)
+	require.ErrorContains(t, err, "no healthy tablet with tags analytics=true available")
+	assert.EqualValues(t, 0, replica.ExecCount.Load())
+
+	replica.Tablet().Tags = map[string]string{"analytics": "true"}
+	_, err = executorExecSession(ctx, executor, session, "select id from music_user_map where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+}
+
+func TestExecutorTenantRouting(t *testing.T) {
+	executor, sbc1, _, sbclookup, ctx := createExecutorEnv(t)
+	executor.vschema.TenantRoutingRules = map[string]string{
+		KsTestUnsharded + ".42": KsTestSharded,
+	}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded})
+
+	_, err := executorExecSession(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbclookup.ExecCount.Load())
+	assert.EqualValues(t, 0, sbc1.ExecCount.Load())
+
+	_, err = executorExecSession(ctx, executor, session, "set @@vitess_tenant_id = '42'", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, sbclookup.ExecCount.Load())
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+}
+
+func TestExecutorPlannerFlags(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	executor.vschema.Keyspaces[KsTestSharded].PlannerFlags = map[string]string{"hash_join": "off"}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestSharded})
+	query := "select id from user left join (select col from user_extra limit 10) ue on user.col = ue.col"
+
+	_, err := executorExecSession(ctx, executor, session, query, nil)
+	require.ErrorContains(t, err, "join requiring a hash join when the hash_join planner flag is off")
+
+	_, err = executorExecSession(ctx, executor, session, "set @@vitess_planner_flags = 'hash_join=on'", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+
+	qr, err := executorExecSession(ctx, executor, session, "show vitess_planner_flags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARCHAR("approximate_count_distinct") VARCHAR("off") VARCHAR("default")] [VARCHAR("hash_join") VARCHAR("on") VARCHAR("session")] [VARCHAR("predicate_rewrite_retry") VARCHAR("on") VARCHAR("default")] [VARCHAR("subquery_pushdown") VARCHAR("all") VARCHAR("default")]]`, fmt.Sprintf("%v", qr.Rows))
+
+	qr, err = executorExecSession(ctx, executor, session, "select @@vitess_planner_flags from dual", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARCHAR("hash_join=on")]]`, fmt.Sprintf("%v", qr.Rows))
+}
+
+func TestExecutorMaxStaleness(t *testing.T) {
+	var replica *sandboxconn.SandboxConn
+	executor, ctx := createExecutorEnvCallback(t, createExecutorConfig(), func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks == KsTestUnsharded && tabletType == topodatapb.TabletType_REPLICA {
+			replica = conn
+		}
+	})
+	th, err := executor.scatterConn.gateway.hc.GetTabletHealthByAlias(replica.Tablet().Alias)
+	require.NoError(t, err)
+	th.Stats = &querypb.RealtimeStats{ReplicationStalenessMs: 45000}
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded + "@replica"})
+
+	_, err = executorExecSession(ctx, executor, session, "set @@vitess_max_staleness = '30s'", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from music_user_map where id = 1", nil)
+	require.ErrorContains(t, err, "no serving tablet with a staleness within 30s available")
+	assert.EqualValues(t, 0, replica.ExecCount.Load())
+
+	// The MAX_STALENESS directive overrides the bound of the session.
+	_, err = executorExecSession(ctx, executor, session, "select /*vt+ MAX_STALENESS=1m */ id from music_user_map where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, replica.ExecCount.Load())
+
+	_, err = executorExecSession(ctx, executor, session, "set @@vitess_max_staleness = '1m'", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from music_user_map where id = 1", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, replica.ExecCount.Load())
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -47,15 +48,19 @@ import (
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/discovery"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
@@ -74,6 +79,10 @@ func (f *fakeResolver) GetGateway() srvtopo.Gateway {
 	return f.gw
 }
 
+func (f *fakeResolver) GetKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, error) {
+	return keyspace, &topodatapb.SrvKeyspace{}, nil, nil
+}
+
 func (f *fakeResolver) ResolveDestinations(ctx context.Context, keyspace string, tabletType topodatapb.TabletType, ids []*querypb.Value, destinations []key.ShardDestination) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
 	return f.resolveShards, nil, nil
 }
@@ -100,26 +109,30 @@ func TestPlanKey(t *testing.T) {
 		expectedPlanPrefixKey string
 		resolvedShard         []*srvtopo.ResolvedShard
 		setVarComment         string
+		plannerFlags          map[string]string
 	}
 
 	tests := []testCase{{
 		targetString:          "",
-		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: , Query: SELECT 1, SetVarComment: , Collation: 255",
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: , Query: SELECT 1, SetVarComment: , Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all",
 	}, {
 		setVarComment:         "sEtVaRcOmMeNt",
-		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: , Query: SELECT 1, SetVarComment: sEtVaRcOmMeNt, Collation: 255",
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: , Query: SELECT 1, SetVarComment: sEtVaRcOmMeNt, Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all",
+	}, {
+		plannerFlags:          map[string]string{"hash_join": "off"},
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: , Query: SELECT 1, SetVarComment: , Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=off,predicate_rewrite_retry=on,subquery_pushdown=all",
 	}, {
 		targetString:          "ks1@replica",
-		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: REPLICA, Destination: , Query: SELECT 1, SetVarComment: , Collation: 255",
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: REPLICA, Destination: , Query: SELECT 1, SetVarComment: , Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all",
 	}, {
 		targetString:          "ks1:-80",
-		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: DestinationShard(-80), Query: SELECT 1, SetVarComment: , Collation: 255",
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: DestinationShard(-80), Query: SELECT 1, SetVarComment: , Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all",
 	}, {
 		targetString: "ks1[deadbeef]",
 		resolvedShard: []*srvtopo.ResolvedShard{
 			{Target: &querypb.Target{Keyspace: "ks1", Shard: "-66"}},
 			{Target: &querypb.Target{Keyspace: "ks1", Shard: "66-"}}},
-		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: -66,66-, Query: SELECT 1, SetVarComment: , Collation: 255",
+		expectedPlanPrefixKey: "CurrentKeyspace: ks1, TabletType: PRIMARY, Destination: -66,66-, Query: SELECT 1, SetVarComment: , Collation: 255, PlannerFlags: approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all",
 	}}
 	cfg := econtext.VCursorConfig{
 		Collation:         collations.CollationUtf8mb4ID,
@@ -130,7 +143,7 @@ func TestPlanKey(t *testing.T) {
 	e.vschema = vschemaWith1KS
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d#%s", i, tc.targetString), func(t *testing.T) {
-			ss := econtext.NewSafeSession(&vtgatepb.Session{TargetString: tc.targetString})
+			ss := econtext.NewSafeSession(&vtgatepb.Session{TargetString: tc.targetString, PlannerFlags: tc.plannerFlags})
 			resolver := &fakeResolver{resolveShards: tc.resolvedShard}
 			vc, _ := econtext.NewVCursorImpl(ss, makeComments(""), e, nil, e.vm, e.VSchema(), resolver, nil, nullResultsObserver{}, cfg, nil)
 			key := buildPlanKey(ctx, vc, "SELECT 1", tc.setVarComment)
@@ -1176,6 +1189,56 @@ func TestExecutorShowTargeted(t *testing.T) {
 	}
 }
 
+func TestExecutorShowGrants(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	ctx = callerid.NewContext(ctx, &vtrpcpb.CallerID{}, &querypb.VTGateCallerID{Username: "redUser", Groups: []string{"analyst"}})
+
+	// Without a table ACL, the tables are not restricted.
+	qr, err := executorExecSession(ctx, executor, session, "show grants", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Grants for redUser@%", qr.Fields[0].Name)
+	assert.Equal(t, "[[VARCHAR(\"GRANT USAGE ON *.* TO `redUser`@`%`\")] [VARCHAR(\"GRANT ALL PRIVILEGES ON *.* TO `redUser`@`%`\")]]", fmt.Sprintf("%v", qr.Rows))
+
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	err = tableacl.InitFromProto(&tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group01",
+			TableNamesOrPrefixes: []string{"music%"},
+			Readers:              []string{"readers"},
+			Writers:              []string{"redUser"},
+		}, {
+			Name:                 "group02",
+			TableNamesOrPrefixes: []string{"user"},
+			Readers:              []string{"blueUser"},
+		}},
+		RoleMappings: []*tableaclpb.RoleMapping{{
+			Role:   "analyst",
+			Groups: []string{"readers"},
+		}},
+	})
+	require.NoError(t, err)
+	vschemaacl.AuthorizedDDLUsers.Set(vschemaacl.NewAuthorizedDDLUsers("readers"))
+	defer vschemaacl.AuthorizedDDLUsers.Set(vschemaacl.NewAuthorizedDDLUsers(""))
+
+	// The grants of the table ACL, including the ones of the roles of the caller, are reported.
+	qr, err = executorExecSession(ctx, executor, session, "show grants for current_user()", nil)
+	require.NoError(t, err)
+	want := []string{
+		"GRANT USAGE ON *.* TO `redUser`@`%`",
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON *.`music%` TO `redUser`@`%`",
+		"GRANT ALTER VSCHEMA ON *.* TO `redUser`@`%`",
+		"GRANT `analyst`@`%` TO `redUser`@`%`",
+	}
+	var got []string
+	for _, row := range qr.Rows {
+		got = append(got, row[0].ToString())
+	}
+	assert.Equal(t, want, got)
+}
+
 func TestExecutorShowFromSystemSchema(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnv(t)
 
@@ -1359,6 +1422,43 @@ func TestExecutorDDL(t *testing.T) {
 	}
 }
 
+func TestExecutorDDLInTransaction(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true})
+
+	// The transaction is committed before the DDL, with a warning.
+	_, err := executorExecSession(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from main1", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "create table t2(id bigint primary key)", nil)
+	require.NoError(t, err)
+	assert.False(t, session.InTransaction())
+	assert.EqualValues(t, 1, sbclookup.CommitCount.Load())
+	require.Len(t, session.Warnings, 1)
+	assert.Equal(t, "the open transaction was implicitly committed before the DDL statement", session.Warnings[0].Message)
+
+	// The DDL fails, and the transaction is left open.
+	_, err = executorExecSession(ctx, executor, session, "set @@ddl_in_transaction = 'error'", nil)
+	require.NoError(t, err)
+	assert.Equal(t, vtgatepb.DDLInTransaction_ERROR, session.GetDDLInTransaction())
+	_, err = executorExecSession(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "select id from main1", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "create table t2(id bigint primary key)", nil)
+	require.ErrorContains(t, err, "VT09033: DDL statements are not allowed in a transaction when ddl_in_transaction is 'error'")
+	assert.True(t, session.InTransaction())
+	assert.EqualValues(t, 1, sbclookup.CommitCount.Load())
+
+	// Without an open transaction, the DDL is executed.
+	_, err = executorExecSession(ctx, executor, session, "commit", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, executor, session, "create table t2(id bigint primary key)", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.Warnings)
+}
+
 func TestExecutorDDLFk(t *testing.T) {
 	stmts := []string{
 		"create table t1(id bigint primary key, foreign key (id) references t2(id))",
@@ -1727,6 +1827,47 @@ func TestGetPlanCacheUnnormalized(t *testing.T) {
 	})
 }
 
+func TestSaveVSchemaWarmsUpPlans(t *testing.T) {
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.PlanWarmupCount = 1
+	r, _, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	hot, _ := getPlanCached(t, ctx, r, session, "select * from music_user_map where id = 1", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	cold, _ := getPlanCached(t, ctx, r, session, "select * from user where id = 1", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	hot.AddStats(10, time.Second, 10, 0, 10, 0)
+	cold.AddStats(1, time.Second, 1, 0, 1, 0)
+	assertCacheSize(t, r.plans, 2)
+
+	r.SaveVSchema(r.VSchema(), r.vschemaStats)
+	time.Sleep(100 * time.Millisecond)
+
+	// Only the most executed plan was built again, with its statistics.
+	plans := cachedPlans(r)
+	require.Len(t, plans, 1)
+	assert.NotSame(t, hot, plans[0])
+	assert.Equal(t, hot.Source.Key, plans[0].Source.Key)
+	execCount, _, _, _, _, _ := plans[0].Stats()
+	assert.EqualValues(t, 10, execCount)
+
+	_, logStats := getPlanCached(t, ctx, r, session, "select * from music_user_map where id = 2", makeComments(""), map[string]*querypb.BindVariable{}, false)
+	assert.True(t, logStats.CachedPlan)
+
+	// Without warmup, the plan cache is cleared.
+	r.config.PlanWarmupCount = 0
+	r.SaveVSchema(r.VSchema(), r.vschemaStats)
+	assert.Empty(t, cachedPlans(r))
+}
+
+func cachedPlans(e *Executor) []*engine.Plan {
+	var plans []*engine.Plan
+	e.ForEachPlan(func(plan *engine.Plan) bool {
+		plans = append(plans, plan)
+		return true
+	})
+	return plans
+}
+
 func TestGetPlanCacheNormalized(t *testing.T) {
 	t.Run("Cache", func(t *testing.T) {
 		r, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
@@ -1796,12 +1937,15 @@ func TestGetPlanNormalized(t *testing.T) {
 
 	var logStats5 *logstats.LogStats
 	plan3, logStats5 = getPlanCached(t, ctx, r, unshardedvc.SafeSession, query1, makeComments(" /* comment 5 */"), map[string]*querypb.BindVariable{}, false)
-	assert.Equal(t, plan1, plan3)
+	// The plans cached for another keyspace are the same, but for their source.
+	assert.Equal(t, plan1.Instructions, plan3.Instructions)
+	assert.Equal(t, plan1.Original, plan3.Original)
+	assert.Equal(t, KsTestUnsharded, plan3.Source.Key.CurrentKeyspace)
 	wantSQL = normalized + " /* comment 5 */"
 	assert.Equal(t, wantSQL, logStats5.SQL)
 
 	plan4, _ := getPlanCached(t, ctx, r, unshardedvc.SafeSession, query1, makeComments(" /* comment 6 */"), map[string]*querypb.BindVariable{}, false)
-	assert.Equal(t, plan1, plan4)
+	assert.Equal(t, plan3, plan4)
 	assertCacheContains(t, r, emptyvc, normalized)
 	assertCacheContains(t, r, unshardedvc, normalized)
 }
@@ -1983,6 +2127,44 @@ func TestParseTargetSingleKeyspace(t *testing.T) {
 	}
 }
 
+func TestParseTargetKeyspaceDefaultTabletType(t *testing.T) {
+	r, _, _, _, _ := createExecutorEnv(t)
+
+	ks := *r.vschema.Keyspaces[KsTestUnsharded]
+	ks.DefaultTabletType = topodatapb.TabletType_REPLICA
+	r.vschema = &vindexes.VSchema{
+		Keyspaces: map[string]*vindexes.KeyspaceSchema{
+			KsTestUnsharded: &ks,
+			KsTestSharded:   r.vschema.Keyspaces[KsTestSharded],
+		},
+	}
+
+	tcases := []struct {
+		target         string
+		wantTabletType topodatapb.TabletType
+	}{{
+		target:         KsTestUnsharded,
+		wantTabletType: topodatapb.TabletType_REPLICA,
+	}, {
+		// The tablet type of the target takes precedence.
+		target:         KsTestUnsharded + "@primary",
+		wantTabletType: topodatapb.TabletType_PRIMARY,
+	}, {
+		target:         KsTestSharded,
+		wantTabletType: topodatapb.TabletType_PRIMARY,
+	}, {
+		target:         "",
+		wantTabletType: topodatapb.TabletType_PRIMARY,
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.target, func(t *testing.T) {
+			_, tabletType, _, err := r.ParseDestinationTarget(tcase.target)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.wantTabletType, tabletType)
+		})
+	}
+}
+
 func TestDebugVSchema(t *testing.T) {
 	executor, _, _, _, _ := createExecutorEnv(t)
 
@@ -2618,16 +2800,29 @@ func TestExecutorCallProc(t *testing.T) {
 
 		hasNoKeyspaceErr bool
 		unshardedOnlyErr bool
+		multiShardErr    bool
 		wantCnts         cnts
 	}{{
 		name:             "simple call with no keyspace set",
 		targetStr:        "",
 		hasNoKeyspaceErr: true,
 	}, {
-		name:      "keyrange targeted keyspace",
-		targetStr: "TestExecutor[-]",
+		name:          "keyrange targeted keyspace",
+		targetStr:     "TestExecutor[-]",
+		multiShardErr: true,
+	}, {
+		name:      "keyrange of a single shard targeted keyspace",
+		targetStr: "TestExecutor[-20]",
 		wantCnts: cnts{
 			Sbc1Cnt:      1,
+			Sbc2Cnt:      0,
+			SbcUnsharded: 0,
+		},
+	}, {
+		name:      "shard targeted keyspace",
+		targetStr: "TestExecutor/40-60",
+		wantCnts: cnts{
+			Sbc1Cnt:      0,
 			Sbc2Cnt:      1,
 			SbcUnsharded: 0,
 		},
@@ -2651,13 +2846,18 @@ func TestExecutorCallProc(t *testing.T) {
 			sbc2.ExecCount.Store(0)
 			sbcUnsharded.ExecCount.Store(0)
 
-			_, err := executorExec(context.Background(), executor, &vtgatepb.Session{TargetString: tc.targetStr}, "CALL proc()", nil)
+			session := &vtgatepb.Session{TargetString: tc.targetStr}
+			_, err := executorExec(context.Background(), executor, session, "CALL proc()", nil)
 			if tc.hasNoKeyspaceErr {
 				assert.EqualError(t, err, econtext.ErrNoKeyspace.Error())
 			} else if tc.unshardedOnlyErr {
-				require.EqualError(t, err, "CALL is not supported for sharded keyspace")
+				require.EqualError(t, err, "CALL is not supported for sharded keyspace unless the session targets a single shard")
+			} else if tc.multiShardErr {
+				require.ErrorContains(t, err, "mapping to multiple shards")
 			} else {
 				assert.NoError(t, err)
+				// The procedure runs on a reserved connection.
+				assert.True(t, session.InReservedConn)
 			}
 
 			utils.MustMatch(t, tc.wantCnts, cnts{
@@ -2669,6 +2869,44 @@ func TestExecutorCallProc(t *testing.T) {
 	}
 }
 
+func TestExecutorCallProcMoreResults(t *testing.T) {
+	executor, _, _, sbcUnsharded, ctx := createExecutorEnv(t)
+
+	want := &sqltypes.Result{
+		Fields: sqltypes.MakeTestFields("id", "int64"),
+		Rows:   sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1").Rows,
+		MoreResults: []*sqltypes.Result{
+			sqltypes.MakeTestResult(sqltypes.MakeTestFields("name", "varchar"), "foo"),
+			{RowsAffected: 1},
+		},
+	}
+	sbcUnsharded.SetResults([]*sqltypes.Result{want})
+
+	session := &vtgatepb.Session{TargetString: KsTestUnsharded}
+	got, err := executorExec(ctx, executor, session, "CALL proc()", nil)
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got), "got: %v, want: %v", got, want)
+
+	// When streamed, the boundaries of the result sets are kept.
+	sbcUnsharded.SetResults(want.ResultSets())
+	var streamed []*sqltypes.Result
+	err = executor.StreamExecute(ctx, nil, "TestExecutorCallProcMoreResults", econtext.NewSafeSession(session), "CALL proc()", nil, func(qr *sqltypes.Result) error {
+		streamed = append(streamed, qr)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 5)
+	assert.Equal(t, want.Fields, streamed[0].Fields)
+	assert.Equal(t, want.Rows, streamed[1].Rows)
+	assert.True(t, streamed[1].IsMoreResultsExists())
+	assert.Equal(t, want.MoreResults[0].Fields, streamed[2].Fields)
+	assert.Equal(t, want.MoreResults[0].Rows, streamed[3].Rows)
+	assert.True(t, streamed[3].IsMoreResultsExists())
+	assert.Empty(t, streamed[4].Fields)
+	assert.EqualValues(t, 1, streamed[4].RowsAffected)
+	assert.False(t, streamed[4].IsMoreResultsExists())
+}
+
 func TestExecutorTempTable(t *testing.T) {
 	executor, _, _, sbcUnsharded, ctx := createExecutorEnv(t)
 
@@ -3377,3 +3615,21 @@ func TestExecutorShowShards(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutorUpdateTableTimings(t *testing.T) {
+	saveEnable := enableTableTimings
+	defer func() {
+		enableTableTimings = saveEnable
+	}()
+	enableTableTimings = true
+
+	executor := &Executor{tableLabels: stats.NewLabelLimiter([]string{"timings_ks.t1"}, 0)}
+	timings := queryTimingsByTable.Counts()
+	errs := queryErrorsByTable.Counts()
+	executor.updateTableTimings("SELECT", []string{"timings_ks.t1", "timings_ks.t2"}, time.Millisecond, true)
+
+	assert.Equal(t, timings["timings_ks.t1.SELECT"]+1, queryTimingsByTable.Counts()["timings_ks.t1.SELECT"])
+	assert.Equal(t, errs["timings_ks.t1.SELECT"]+1, queryErrorsByTable.Counts()["timings_ks.t1.SELECT"])
+	assert.Zero(t, queryTimingsByTable.Counts()["timings_ks.t2.SELECT"])
+	assert.Equal(t, timings["other..SELECT"]+1, queryTimingsByTable.Counts()["other..SELECT"])
+}
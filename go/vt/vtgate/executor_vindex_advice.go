@@ -0,0 +1,354 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// maxVindexAdviceColumns bounds the number of columns tracked by the
+	// vindex advice. The columns seen once the bound is reached are ignored.
+	maxVindexAdviceColumns = 1000
+	// maxVindexAdviceDigests is the number of example digests kept per column.
+	maxVindexAdviceDigests = 5
+)
+
+// vindexAdvice holds the scatter queries seen filtering on a column without vindex.
+type vindexAdvice struct {
+	Keyspace  string
+	Table     string
+	Column    string
+	Queries   int64
+	Digests   []string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// vindexAdviceTracker counts, per column without vindex, the scatter queries
+// filtering on the column. The columns most often filtered on are the best
+// candidates for a new vindex.
+type vindexAdviceTracker struct {
+	mu      sync.Mutex
+	columns map[engine.VindexCandidate]*vindexAdvice
+}
+
+func newVindexAdviceTracker() *vindexAdviceTracker {
+	return &vindexAdviceTracker{
+		columns: make(map[engine.VindexCandidate]*vindexAdvice),
+	}
+}
+
+// record accounts a query of the digest, which filtered on the candidates.
+func (t *vindexAdviceTracker) record(now time.Time, digest string, candidates []engine.VindexCandidate) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, candidate := range candidates {
+		advice, ok := t.columns[candidate]
+		if !ok {
+			if len(t.columns) >= maxVindexAdviceColumns {
+				continue
+			}
+			advice = &vindexAdvice{
+				Keyspace:  candidate.Keyspace,
+				Table:     candidate.Table,
+				Column:    candidate.Column,
+				FirstSeen: now,
+			}
+			t.columns[candidate] = advice
+		}
+		advice.Queries++
+		advice.LastSeen = now
+		if digest != "" && len(advice.Digests) < maxVindexAdviceDigests && !slices.Contains(advice.Digests, digest) {
+			advice.Digests = append(advice.Digests, digest)
+		}
+	}
+}
+
+// list returns a copy of the advice of all the columns, the columns filtered
+// on by the most queries first.
+func (t *vindexAdviceTracker) list() []vindexAdvice {
+	t.mu.Lock()
+	all := make([]vindexAdvice, 0, len(t.columns))
+	for _, advice := range t.columns {
+		c := *advice
+		c.Digests = slices.Clone(advice.Digests)
+		all = append(all, c)
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b vindexAdvice) int {
+		return cmp.Or(
+			cmp.Compare(b.Queries, a.Queries),
+			cmp.Compare(a.Keyspace, b.Keyspace),
+			cmp.Compare(a.Table, b.Table),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+	return all
+}
+
+// prune forgets the columns which are no longer candidates in the vschema:
+// their table is gone, or a vindex was added on them.
+func (t *vindexAdviceTracker) prune(vschema *vindexes.VSchema) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for candidate := range t.columns {
+		ks := vschema.Keyspaces[candidate.Keyspace]
+		if ks == nil || ks.Keyspace == nil || !ks.Keyspace.Sharded {
+			delete(t.columns, candidate)
+			continue
+		}
+		table := ks.Tables[candidate.Table]
+		if table == nil || hasVindex(table, sqlparser.NewIdentifierCI(candidate.Column)) {
+			delete(t.columns, candidate)
+		}
+	}
+}
+
+// vindexCandidates returns the columns without vindex which the scatter
+// routes of the plan filter on, with an equality or an IN predicate on
+// values. A vindex on one of these columns would let the route target
+// only the shards holding the matching rows.
+func vindexCandidates(parser *sqlparser.Parser, vschema *vindexes.VSchema, plan *engine.Plan) []engine.VindexCandidate {
+	if vschema == nil || plan.Instructions == nil {
+		return nil
+	}
+	var candidates []engine.VindexCandidate
+	engine.Visit(plan.Instructions, func(node engine.Primitive) {
+		var (
+			params *engine.RoutingParameters
+			query  string
+		)
+		switch node := node.(type) {
+		case *engine.Route:
+			params, query = node.RoutingParameters, node.Query
+		case *engine.Update:
+			params, query = node.RoutingParameters, node.Query
+		case *engine.Delete:
+			params, query = node.RoutingParameters, node.Query
+		default:
+			return
+		}
+		if params == nil || params.Opcode != engine.Scatter || params.Keyspace == nil {
+			return
+		}
+		ks := vschema.Keyspaces[params.Keyspace.Name]
+		if ks == nil || ks.Keyspace == nil || !ks.Keyspace.Sharded {
+			return
+		}
+		stmt, err := parser.Parse(query)
+		if err != nil {
+			return
+		}
+		candidates = append(candidates, filterCandidates(ks, stmt)...)
+	})
+	slices.SortFunc(candidates, func(a, b engine.VindexCandidate) int {
+		return cmp.Or(
+			cmp.Compare(a.Keyspace, b.Keyspace),
+			cmp.Compare(a.Table, b.Table),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+	return slices.Compact(candidates)
+}
+
+// filterCandidates returns the columns without vindex of the tables of the
+// keyspace, which the WHERE clauses of the statement filter on.
+func filterCandidates(ks *vindexes.KeyspaceSchema, stmt sqlparser.Statement) []engine.VindexCandidate {
+	var candidates []engine.VindexCandidate
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		var (
+			from  []sqlparser.TableExpr
+			where *sqlparser.Where
+		)
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			from, where = node.From, node.Where
+		case *sqlparser.Update:
+			from, where = node.TableExprs, node.Where
+		case *sqlparser.Delete:
+			from, where = node.TableExprs, node.Where
+		default:
+			return true, nil
+		}
+		if where == nil {
+			return true, nil
+		}
+		tables := filterTables(ks, from)
+		for _, expr := range sqlparser.SplitAndExpression(nil, where.Expr) {
+			col := filteredColumn(expr)
+			if col == nil {
+				continue
+			}
+			table, ok := tables[col.Qualifier.Name.String()]
+			if !ok || len(table.ColumnVindexes) == 0 || hasVindex(table, col.Name) {
+				continue
+			}
+			candidates = append(candidates, engine.VindexCandidate{
+				Keyspace: ks.Keyspace.Name,
+				Table:    table.Name.String(),
+				Column:   col.Name.Lowered(),
+			})
+		}
+		return true, nil
+	}, stmt)
+	return candidates
+}
+
+// filterTables returns the tables of the keyspace in the FROM clause by their
+// alias, or by their name when they have none. The only table of the FROM
+// clause is also returned under the empty name, for the unqualified columns.
+func filterTables(ks *vindexes.KeyspaceSchema, from []sqlparser.TableExpr) map[string]*vindexes.BaseTable {
+	tables := make(map[string]*vindexes.BaseTable)
+	count := 0
+	for _, tableExpr := range from {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.AliasedTableExpr:
+				count++
+				tableName, ok := node.Expr.(sqlparser.TableName)
+				if !ok {
+					return false, nil
+				}
+				table := ks.Tables[tableName.Name.String()]
+				if table == nil {
+					return false, nil
+				}
+				alias := tableName.Name.String()
+				if !node.As.IsEmpty() {
+					alias = node.As.String()
+				}
+				tables[alias] = table
+				return false, nil
+			case *sqlparser.JoinCondition:
+				return false, nil
+			}
+			return true, nil
+		}, tableExpr)
+	}
+	if count == 1 {
+		for _, table := range tables {
+			tables[""] = table
+		}
+	}
+	return tables
+}
+
+// filteredColumn returns the column the predicate compares to values, with an
+// equality or an IN predicate, nil if the predicate is not such a comparison.
+func filteredColumn(expr sqlparser.Expr) *sqlparser.ColName {
+	comparison, ok := expr.(*sqlparser.ComparisonExpr)
+	if !ok {
+		return nil
+	}
+	col, isCol := comparison.Left.(*sqlparser.ColName)
+	value := comparison.Right
+	if !isCol && comparison.Operator == sqlparser.EqualOp {
+		col, isCol = comparison.Right.(*sqlparser.ColName)
+		value = comparison.Left
+	}
+	if !isCol {
+		return nil
+	}
+	switch comparison.Operator {
+	case sqlparser.EqualOp:
+		if sqlparser.IsValue(value) {
+			return col
+		}
+	case sqlparser.InOp:
+		if sqlparser.IsSimpleTuple(value) {
+			return col
+		}
+	}
+	return nil
+}
+
+// hasVindex tells whether the column is the first column of a vindex of the table.
+func hasVindex(table *vindexes.BaseTable, column sqlparser.IdentifierCI) bool {
+	for _, cv := range table.ColumnVindexes {
+		if len(cv.Columns) > 0 && cv.Columns[0].Equal(column) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordVindexCandidates accounts an execution of the plan in the vindex
+// advice, if its scatter routes filter on columns without vindex.
+func (e *Executor) recordVindexCandidates(plan *engine.Plan) {
+	if len(plan.VindexCandidates) == 0 {
+		return
+	}
+	var digest string
+	if plan.Source != nil {
+		digest = plan.Source.Digest
+	}
+	e.vindexAdvice.record(time.Now(), digest, plan.VindexCandidates)
+}
+
+// ShowVindexAdvice returns the columns without vindex which scatter queries
+// filtered on, the columns filtered on by the most queries first. The LIKE
+// filter applies to the table names.
+func (e *Executor) ShowVindexAdvice(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	var likeFilter func(string) bool
+	if filter != nil && filter.Like != "" {
+		tableRegexp := sqlparser.LikeToRegexp(filter.Like)
+		likeFilter = tableRegexp.MatchString
+	}
+
+	rows := [][]sqltypes.Value{}
+	for _, advice := range e.vindexAdvice.list() {
+		if likeFilter != nil && !likeFilter(advice.Table) {
+			continue
+		}
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.NewVarChar(advice.Keyspace),
+			sqltypes.NewVarChar(advice.Table),
+			sqltypes.NewVarChar(advice.Column),
+			sqltypes.NewInt64(advice.Queries),
+			sqltypes.NewVarChar(strings.Join(advice.Digests, ",")),
+			sqltypes.NewVarChar(advice.FirstSeen.UTC().Format(time.DateTime)),
+			sqltypes.NewVarChar(advice.LastSeen.UTC().Format(time.DateTime)),
+		})
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "Keyspace", Type: sqltypes.VarChar},
+			{Name: "Table", Type: sqltypes.VarChar},
+			{Name: "Column", Type: sqltypes.VarChar},
+			{Name: "Queries", Type: sqltypes.Int64},
+			{Name: "Digests", Type: sqltypes.VarChar},
+			{Name: "FirstSeen", Type: sqltypes.VarChar},
+			{Name: "LastSeen", Type: sqltypes.VarChar},
+		},
+		Rows: rows,
+	}, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestVindexAdviceTracker(t *testing.T) {
+	r, _, _, _, _ := createExecutorEnv(t)
+	tracker := newVindexAdviceTracker()
+	now := time.Now()
+	textcol := engine.VindexCandidate{Keyspace: KsTestSharded, Table: "user", Column: "textcol"}
+	extra := engine.VindexCandidate{Keyspace: KsTestSharded, Table: "user_extra", Column: "extra"}
+
+	tracker.record(now, "d1", []engine.VindexCandidate{textcol})
+	tracker.record(now.Add(time.Minute), "d2", []engine.VindexCandidate{textcol, extra})
+	tracker.record(now.Add(time.Minute), "d1", []engine.VindexCandidate{textcol})
+	for i := range maxVindexAdviceDigests + 1 {
+		tracker.record(now, string(rune('a'+i)), []engine.VindexCandidate{extra})
+	}
+
+	advice := tracker.list()
+	require.Len(t, advice, 2)
+	assert.Equal(t, "extra", advice[0].Column)
+	assert.EqualValues(t, maxVindexAdviceDigests+2, advice[0].Queries)
+	assert.Len(t, advice[0].Digests, maxVindexAdviceDigests)
+	assert.Equal(t, "textcol", advice[1].Column)
+	assert.EqualValues(t, 3, advice[1].Queries)
+	assert.Equal(t, []string{"d1", "d2"}, advice[1].Digests)
+	assert.Equal(t, now, advice[1].FirstSeen)
+	assert.Equal(t, now.Add(time.Minute), advice[1].LastSeen)
+
+	// The columns which got a vindex, or whose table is gone, are forgotten.
+	vschema := r.VSchema()
+	tracker.record(now, "d3", []engine.VindexCandidate{{Keyspace: KsTestSharded, Table: "user", Column: "name"}})
+	tracker.record(now, "d4", []engine.VindexCandidate{{Keyspace: KsTestSharded, Table: "dropped", Column: "c"}})
+	require.Len(t, tracker.list(), 4)
+	tracker.prune(vschema)
+	assert.Len(t, tracker.list(), 2)
+}
+
+func TestVindexCandidates(t *testing.T) {
+	r, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	tests := []struct {
+		query string
+		want  []engine.VindexCandidate
+	}{{
+		query: "select * from user where textcol = 'a'",
+		want:  []engine.VindexCandidate{{Keyspace: KsTestSharded, Table: "user", Column: "textcol"}},
+	}, {
+		query: "select * from user as u, user_extra as ue where u.id = ue.user_id and u.textcol in ('a', 'b') and ue.extra = 1 and u.predef1 > 1",
+		want: []engine.VindexCandidate{
+			{Keyspace: KsTestSharded, Table: "user", Column: "textcol"},
+			{Keyspace: KsTestSharded, Table: "user_extra", Column: "extra"},
+		},
+	}, {
+		query: "delete from user_extra where extra = 1",
+		want:  []engine.VindexCandidate{{Keyspace: KsTestSharded, Table: "user_extra", Column: "extra"}},
+	}, {
+		// The route targets a single shard.
+		query: "select * from user where id = 1 and textcol = 'a'",
+	}, {
+		// The column has a vindex, which the route can't use.
+		query: "select * from user where name = textcol",
+	}, {
+		query: "select * from user where textcol like 'a%'",
+	}, {
+		query: "select * from main1 where id = 1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			plan, _ := getPlanCached(t, ctx, r, session, tt.query, makeComments(""), map[string]*querypb.BindVariable{}, false)
+			assert.Equal(t, tt.want, plan.VindexCandidates)
+			if tt.want != nil {
+				assert.NotEmpty(t, plan.Source.Digest)
+			}
+		})
+	}
+}
+
+func TestExecutorVindexAdvice(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+	for _, query := range []string{
+		"select * from user where textcol = 'a'",
+		"select * from user where textcol = 'b'",
+		"select id from user where textcol in ('a', 'b')",
+		"select * from user_extra where extra = 1",
+	} {
+		_, err := executorExecSession(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+	}
+
+	qr, err := executor.ShowVindexAdvice(&sqlparser.ShowFilter{Like: "user"})
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	assert.Equal(t, KsTestSharded, qr.Rows[0][0].ToString())
+	assert.Equal(t, "user", qr.Rows[0][1].ToString())
+	assert.Equal(t, "textcol", qr.Rows[0][2].ToString())
+	assert.Equal(t, "3", qr.Rows[0][3].ToString())
+	assert.Len(t, qr.Rows[0][4].ToString(), 2*16+1)
+
+	qr, err = executorExecSession(ctx, executor, session, "show vitess_vindex_advice", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 2)
+	assert.Equal(t, "user_extra", qr.Rows[1][1].ToString())
+	assert.Equal(t, "extra", qr.Rows[1][2].ToString())
+}
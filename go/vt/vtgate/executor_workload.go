@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	queriesByWorkload  = stats.NewCountersWithSingleLabel("QueriesByWorkload", "Counts queries executed at VTGate by session workload.", "Workload")
+	olapQueriesActive  = stats.NewGauge("OlapQueriesActive", "Number of queries of OLAP sessions running at VTGate")
+	olapQueriesWaiting = stats.NewGauge("OlapQueriesWaiting", "Number of queries of OLAP sessions waiting for --olap-max-concurrency")
+)
+
+// olapLimiter limits the number of concurrent queries of OLAP sessions, so
+// that batch jobs cannot starve the OLTP sessions.
+type olapLimiter struct {
+	// slots is nil if the number of queries is not limited.
+	slots chan struct{}
+}
+
+func newOlapLimiter(maxConcurrency int) *olapLimiter {
+	if maxConcurrency <= 0 {
+		return &olapLimiter{}
+	}
+	return &olapLimiter{slots: make(chan struct{}, maxConcurrency)}
+}
+
+// acquire waits until the query can run, or ctx is done. The returned
+// function must be called once the query finished.
+func (l *olapLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			olapQueriesWaiting.Add(1)
+			select {
+			case l.slots <- struct{}{}:
+				olapQueriesWaiting.Add(-1)
+			case <-ctx.Done():
+				olapQueriesWaiting.Add(-1)
+				return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "waiting for one of the %d concurrent OLAP queries to finish: %v", cap(l.slots), ctx.Err())
+			}
+		}
+	}
+	olapQueriesActive.Add(1)
+	return func() {
+		olapQueriesActive.Add(-1)
+		if l.slots != nil {
+			<-l.slots
+		}
+	}, nil
+}
+
+// streamSize returns the number of bytes sent for each stream call of a
+// query of the workload.
+func (e *Executor) streamSize(workload querypb.ExecuteOptions_Workload) int {
+	if workload == querypb.ExecuteOptions_OLAP && olapStreamBufferSize > 0 {
+		return olapStreamBufferSize
+	}
+	return e.config.StreamSize
+}
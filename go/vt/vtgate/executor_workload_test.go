@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestOlapLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	limiter := newOlapLimiter(0)
+	release, err := limiter.acquire(ctx)
+	require.NoError(t, err)
+	release()
+
+	limiter = newOlapLimiter(1)
+	release, err = limiter.acquire(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, olapQueriesActive.Get())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.acquire(timeoutCtx)
+	assert.ErrorContains(t, err, "waiting for one of the 1 concurrent OLAP queries to finish")
+	assert.EqualValues(t, 0, olapQueriesWaiting.Get())
+
+	release()
+	assert.EqualValues(t, 0, olapQueriesActive.Get())
+	release, err = limiter.acquire(ctx)
+	require.NoError(t, err)
+	release()
+}
+
+func TestExecutorOlapWorkload(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+
+	saveMaxRows, saveStreamBufferSize := olapMaxRows, olapStreamBufferSize
+	defer func() {
+		olapMaxRows, olapStreamBufferSize = saveMaxRows, saveStreamBufferSize
+	}()
+	olapMaxRows = 3
+
+	stream := func(workload querypb.ExecuteOptions_Workload) (int, error) {
+		session := econtext.NewSafeSession(&vtgatepb.Session{
+			TargetString: "@primary",
+			Options:      &querypb.ExecuteOptions{Workload: workload},
+		})
+		rows := 0
+		err := executor.StreamExecute(ctx, nil, "TestExecutorOlapWorkload", session, "select * from main1", nil, func(qr *sqltypes.Result) error {
+			rows += len(qr.Rows)
+			return nil
+		})
+		return rows, err
+	}
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("col", "varchar"), "a", "b", "c", "d")
+
+	initialOlap := queriesByWorkload.Counts()["OLAP"]
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	_, err := stream(querypb.ExecuteOptions_OLAP)
+	assert.ErrorContains(t, err, "OLAP query returned more than 3 rows")
+	assert.Equal(t, initialOlap+1, queriesByWorkload.Counts()["OLAP"])
+
+	// The limit does not apply to the other workloads.
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	rows, err := stream(querypb.ExecuteOptions_OLTP)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rows)
+
+	olapStreamBufferSize = 10
+	assert.Equal(t, 10, executor.streamSize(querypb.ExecuteOptions_OLAP))
+	assert.Equal(t, executor.config.StreamSize, executor.streamSize(querypb.ExecuteOptions_OLTP))
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"sync"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/srvtopo"
+	topoprotopb "vitess.io/vitess/go/vt/topo/topoproto"
+)
+
+// canaryExecution limits the shards a query executes on to a single canary shard,
+// or, when continuing a canary execution, to all the shards but the canary shard.
+type canaryExecution struct {
+	mu sync.Mutex
+	// shard is the canary shard. When the query does not name one, it is the
+	// first shard the query executes on.
+	shard        string
+	continuation bool
+	// skipped holds the keyspace/shard targets the query was not executed on.
+	skipped map[string]bool
+}
+
+// filter returns the shards, and their queries, the query should execute on.
+func (c *canaryExecution) filter(rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery) ([]*srvtopo.ResolvedShard, []*querypb.BoundQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		filteredRss     []*srvtopo.ResolvedShard
+		filteredQueries []*querypb.BoundQuery
+	)
+	for i, rs := range rss {
+		if c.shard == "" && !c.continuation {
+			c.shard = rs.Target.Shard
+		}
+		if (rs.Target.Shard == c.shard) != c.continuation {
+			filteredRss = append(filteredRss, rs)
+			filteredQueries = append(filteredQueries, queries[i])
+			continue
+		}
+		c.skipped[topoprotopb.KeyspaceShardString(rs.Target.Keyspace, rs.Target.Shard)] = true
+	}
+	return filteredRss, filteredQueries
+}
+
+// SetCanary limits the execution of the query to the given canary shard. An empty shard
+// stands for the first shard the query executes on. With canaryContinue, the execution is
+// instead limited to all the shards but the given canary shard.
+func (vc *VCursorImpl) SetCanary(canaryShard *string, canaryContinue string) {
+	switch {
+	case canaryShard != nil:
+		vc.canary = &canaryExecution{shard: *canaryShard, skipped: map[string]bool{}}
+	case canaryContinue != "":
+		vc.canary = &canaryExecution{shard: canaryContinue, continuation: true, skipped: map[string]bool{}}
+	default:
+		vc.canary = nil
+	}
+}
+
+// CanaryShard returns the canary shard a canary execution of the query executed on, and the
+// number of shards it skipped. It returns false if the query is not a canary execution.
+func (vc *VCursorImpl) CanaryShard() (shard string, skipped int, ok bool) {
+	if vc.canary == nil || vc.canary.continuation {
+		return "", 0, false
+	}
+	vc.canary.mu.Lock()
+	defer vc.canary.mu.Unlock()
+	return vc.canary.shard, len(vc.canary.skipped), true
+}
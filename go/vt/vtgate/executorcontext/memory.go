@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	memoryLimitQuery  = "Query"
+	memoryLimitGlobal = "Global"
+)
+
+var (
+	queryMemoryInUse         = stats.NewGauge("QueryMemoryInUse", "Number of bytes of the rows buffered at VTGate by the in-flight queries, for their joins, sorts and aggregations")
+	queryMemoryLimitExceeded = stats.NewCountersWithSingleLabel("QueryMemoryLimitExceeded", "Number of queries aborted for buffering more rows at VTGate than allowed, by limit", "Limit", memoryLimitQuery, memoryLimitGlobal)
+)
+
+// MemoryBudget bounds the number of bytes of the rows buffered at vtgate by
+// all the in-flight queries.
+type MemoryBudget struct {
+	// limit is the maximum number of bytes, 0 means no limit.
+	limit int64
+	used  atomic.Int64
+}
+
+// NewMemoryBudget returns a budget of limit bytes, 0 meaning no limit.
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{limit: limit}
+}
+
+// Used returns the number of bytes buffered by the in-flight queries.
+func (b *MemoryBudget) Used() int64 {
+	return b.used.Load()
+}
+
+// reserve accounts for size more bytes, unless they would exceed the limit.
+func (b *MemoryBudget) reserve(size int64) bool {
+	used := b.used.Add(size)
+	if b.limit > 0 && used > b.limit {
+		b.used.Add(-size)
+		return false
+	}
+	return true
+}
+
+// MemoryTracker accounts for the rows a query buffers at vtgate, for its
+// joins, sorts and aggregations, against the memory limit of the query and
+// the budget shared by all the queries. The rows are accounted for until the
+// query finishes, or until they are spilled to disk.
+// A nil MemoryTracker accounts for nothing.
+type MemoryTracker struct {
+	// limit is the maximum number of bytes of the query, 0 means no limit.
+	limit  int64
+	budget *MemoryBudget
+
+	mu   sync.Mutex
+	used int64
+	peak int64
+}
+
+// NewMemoryTracker returns a tracker for a query limited to limit bytes,
+// 0 meaning no limit, and to the budget, when it is not nil.
+func NewMemoryTracker(limit int64, budget *MemoryBudget) *MemoryTracker {
+	return &MemoryTracker{limit: limit, budget: budget}
+}
+
+// Grow accounts for size more bytes buffered by the query. It fails, without
+// accounting for them, when the query or all the queries would buffer more
+// than their limit.
+func (t *MemoryTracker) Grow(size int64) error {
+	if t == nil || size <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limit > 0 && t.used+size > t.limit {
+		queryMemoryLimitExceeded.Add(memoryLimitQuery, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "query memory limit exceeded: the query buffers more than %d bytes at vtgate", t.limit)
+	}
+	if t.budget != nil && !t.budget.reserve(size) {
+		queryMemoryLimitExceeded.Add(memoryLimitGlobal, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "global query memory limit exceeded: the in-flight queries buffer more than %d bytes at vtgate", t.budget.limit)
+	}
+	t.used += size
+	t.peak = max(t.peak, t.used)
+	queryMemoryInUse.Add(size)
+	return nil
+}
+
+// Shrink returns size bytes no longer buffered by the query to the budget.
+func (t *MemoryTracker) Shrink(size int64) {
+	if t == nil || size <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size = min(size, t.used)
+	t.used -= size
+	if t.budget != nil {
+		t.budget.used.Add(-size)
+	}
+	queryMemoryInUse.Add(-size)
+}
+
+// Release returns the bytes buffered by the query to the budget. It returns
+// the peak memory of the query.
+func (t *MemoryTracker) Release() int64 {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	used, peak := t.used, t.peak
+	t.used, t.peak = 0, 0
+	if t.budget != nil {
+		t.budget.used.Add(-used)
+	}
+	queryMemoryInUse.Add(-used)
+	return peak
+}
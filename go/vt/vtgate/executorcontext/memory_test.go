@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTracker(t *testing.T) {
+	budget := NewMemoryBudget(150)
+	exceeded := queryMemoryLimitExceeded.Counts()
+
+	first := NewMemoryTracker(100, budget)
+	require.NoError(t, first.Grow(60))
+	require.NoError(t, first.Grow(40))
+	assert.EqualError(t, first.Grow(1), "query memory limit exceeded: the query buffers more than 100 bytes at vtgate")
+
+	second := NewMemoryTracker(0, budget)
+	require.NoError(t, second.Grow(50))
+	assert.EqualError(t, second.Grow(1), "global query memory limit exceeded: the in-flight queries buffer more than 150 bytes at vtgate")
+	assert.EqualValues(t, 150, budget.Used())
+
+	// The bytes of a query are returned to the budget once it finished.
+	assert.EqualValues(t, 100, first.Release())
+	assert.EqualValues(t, 50, budget.Used())
+	require.NoError(t, second.Grow(100))
+	assert.EqualValues(t, 150, second.Release())
+	assert.Zero(t, budget.Used())
+
+	assert.Equal(t, exceeded[memoryLimitQuery]+1, queryMemoryLimitExceeded.Counts()[memoryLimitQuery])
+	assert.Equal(t, exceeded[memoryLimitGlobal]+1, queryMemoryLimitExceeded.Counts()[memoryLimitGlobal])
+
+	// The bytes spilled to disk are returned to the budget, but still count
+	// for the peak memory of the query.
+	third := NewMemoryTracker(100, budget)
+	require.NoError(t, third.Grow(80))
+	third.Shrink(80)
+	assert.Zero(t, budget.Used())
+	require.NoError(t, third.Grow(90))
+	assert.EqualValues(t, 90, third.Release())
+	assert.Zero(t, budget.Used())
+
+	// A nil tracker accounts for nothing.
+	var tracker *MemoryTracker
+	require.NoError(t, tracker.Grow(1000))
+	tracker.Shrink(1000)
+	assert.Zero(t, tracker.Release())
+}
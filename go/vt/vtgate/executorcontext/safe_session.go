@@ -17,16 +17,21 @@ limitations under the License.
 package executorcontext
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/sqltypes"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -63,6 +68,14 @@ type (
 		// as the query that started a new transaction on the shard belong to a vindex.
 		queryFromVindex bool
 
+		// committedGtids are the GTIDs of the transactions committed on each
+		// shard since they were last taken, when the session tracks its GTIDs.
+		committedGtids []*binlogdatapb.ShardGtid
+
+		// shardErrors are the errors of the shards which failed the query being
+		// executed, since they were last taken.
+		shardErrors []*ShardError
+
 		logging *ExecuteLogger
 
 		*vtgatepb.Session
@@ -853,6 +866,141 @@ func (session *SafeSession) GetDDLStrategy() string {
 	return session.DDLStrategy
 }
 
+// SetTabletTags sets the tags which the tablets must have to serve the
+// queries of the session which do not run on the primary.
+func (session *SafeSession) SetTabletTags(tags map[string]string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.TabletTags = tags
+}
+
+// GetTabletTags returns the TabletTags value.
+func (session *SafeSession) GetTabletTags() map[string]string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.TabletTags
+}
+
+// SetDDLInTransaction sets the ddl_in_transaction setting.
+func (session *SafeSession) SetDDLInTransaction(mode vtgatepb.DDLInTransaction) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.DdlInTransaction = mode
+}
+
+// GetDDLInTransaction returns the ddl_in_transaction setting.
+func (session *SafeSession) GetDDLInTransaction() vtgatepb.DDLInTransaction {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.DdlInTransaction
+}
+
+// SetTenantID sets the tenant of the session, which selects the keyspace
+// its queries are routed to through the tenant routing rules.
+func (session *SafeSession) SetTenantID(tenantID string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.TenantId = tenantID
+}
+
+// GetTenantID returns the TenantId value.
+func (session *SafeSession) GetTenantID() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.TenantId
+}
+
+// SetPlannerFlags sets the planner flags of the session, which take
+// precedence over the planner flags of its keyspace.
+func (session *SafeSession) SetPlannerFlags(flags map[string]string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.PlannerFlags = flags
+}
+
+// GetPlannerFlags returns the PlannerFlags value.
+func (session *SafeSession) GetPlannerFlags() map[string]string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.PlannerFlags
+}
+
+// SetConsistentReads sets whether the SELECT statements of the session outside
+// of a transaction read the shards from consistent snapshots.
+func (session *SafeSession) SetConsistentReads(enable bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.ConsistentReads = enable
+}
+
+// GetConsistentReads returns the ConsistentReads value.
+func (session *SafeSession) GetConsistentReads() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ConsistentReads
+}
+
+// SetPartialScatterReads sets whether the SELECT statements of the session
+// outside of a transaction return the results of the shards they could reach
+// when some of the shards they target are unreachable.
+func (session *SafeSession) SetPartialScatterReads(enable bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.PartialScatterReads = enable
+}
+
+// GetPartialScatterReads returns the PartialScatterReads value.
+func (session *SafeSession) GetPartialScatterReads() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.PartialScatterReads
+}
+
+// SetMaxStaleness sets the staleness of the data of the tablets which may
+// serve the queries of the session which do not run on the primary.
+func (session *SafeSession) SetMaxStaleness(maxStaleness time.Duration) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.MaxStalenessMs = maxStaleness.Milliseconds()
+}
+
+// GetMaxStaleness returns the MaxStalenessMs value, as a duration.
+func (session *SafeSession) GetMaxStaleness() time.Duration {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return time.Duration(session.MaxStalenessMs) * time.Millisecond
+}
+
+// SetConsistentReadPositions records the VGTID of the snapshots read by the
+// last consistent read of the session.
+func (session *SafeSession) SetConsistentReadPositions(vgtid string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.ConsistentReadPositions = vgtid
+}
+
+// GetConsistentReadPositions returns the ConsistentReadPositions value.
+func (session *SafeSession) GetConsistentReadPositions() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ConsistentReadPositions
+}
+
+// SetShardErrors records the errors, in JSON, of the shards which failed the
+// last query of the session executed on shards.
+func (session *SafeSession) SetShardErrors(shardErrors string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.ShardErrors = shardErrors
+}
+
+// GetShardErrors returns the ShardErrors value.
+func (session *SafeSession) GetShardErrors() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ShardErrors
+}
+
 // SetMigrationContext set the migration_context setting.
 func (session *SafeSession) SetMigrationContext(migrationContext string) {
 	session.mu.Lock()
@@ -908,7 +1056,9 @@ func (session *SafeSession) SetReadAfterWriteTimeout(timeout float64) {
 	session.ReadAfterWrite.ReadAfterWriteTimeout = timeout
 }
 
-// SetSessionTrackGtids set the SessionTrackGtids setting.
+// SetSessionTrackGtids set the SessionTrackGtids setting. The tablets are
+// asked to track the GTIDs of the transactions of the session through its
+// options.
 func (session *SafeSession) SetSessionTrackGtids(enable bool) {
 	session.mu.Lock()
 	defer session.mu.Unlock()
@@ -916,6 +1066,97 @@ func (session *SafeSession) SetSessionTrackGtids(enable bool) {
 		session.ReadAfterWrite = &vtgatepb.ReadAfterWrite{}
 	}
 	session.ReadAfterWrite.SessionTrackGtids = enable
+	session.GetOrCreateOptions().SessionTrackGtids = enable
+}
+
+// RecordCommittedGtid records the GTID of a transaction committed on a shard,
+// as returned by the session state changes of the commit, if the session
+// tracks its GTIDs. The GTIDs of the transactions committed on the same shard
+// are merged.
+func (session *SafeSession) RecordCommittedGtid(target *querypb.Target, gtid string) {
+	if gtid == "" {
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.ReadAfterWrite.GetSessionTrackGtids() {
+		return
+	}
+	for _, shardGtid := range session.committedGtids {
+		if shardGtid.Keyspace == target.Keyspace && shardGtid.Shard == target.Shard {
+			shardGtid.Gtid = mergeGtids(shardGtid.Gtid, gtid)
+			return
+		}
+	}
+	session.committedGtids = append(session.committedGtids, &binlogdatapb.ShardGtid{
+		Keyspace: target.Keyspace,
+		Shard:    target.Shard,
+		Gtid:     gtid,
+	})
+}
+
+// TakeCommittedGtids returns the VGTID, in JSON, of the transactions committed
+// since the last call, and forgets them. The VGTID has the GTIDs committed on
+// each shard, in the same format as the positions a VStream starts from. It
+// returns an empty string if no transaction was committed.
+func (session *SafeSession) TakeCommittedGtids() string {
+	session.mu.Lock()
+	shardGtids := session.committedGtids
+	session.committedGtids = nil
+	session.mu.Unlock()
+	if len(shardGtids) == 0 {
+		return ""
+	}
+
+	slices.SortFunc(shardGtids, func(a, b *binlogdatapb.ShardGtid) int {
+		return cmp.Or(cmp.Compare(a.Keyspace, b.Keyspace), cmp.Compare(a.Shard, b.Shard))
+	})
+	for _, shardGtid := range shardGtids {
+		if gtidSet, err := replication.ParseMysql56GTIDSet(shardGtid.Gtid); err == nil {
+			shardGtid.Gtid = replication.EncodePosition(replication.Position{GTIDSet: gtidSet})
+		}
+	}
+	data, err := protojson.Marshal(&binlogdatapb.VGtid{ShardGtids: shardGtids})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// RecordShardError records the error of a query on a shard, if any.
+func (session *SafeSession) RecordShardError(target *querypb.Target, err error) {
+	if err == nil || target == nil {
+		return
+	}
+	shardErr := NewShardError(target, err)
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.shardErrors = append(session.shardErrors, shardErr)
+}
+
+// TakeShardErrors returns the errors of the shards recorded since the last
+// call, sorted by keyspace and shard, and forgets them.
+func (session *SafeSession) TakeShardErrors() []*ShardError {
+	session.mu.Lock()
+	shardErrors := session.shardErrors
+	session.shardErrors = nil
+	session.mu.Unlock()
+	sortShardErrors(shardErrors)
+	return shardErrors
+}
+
+// mergeGtids returns the union of two GTID sets. If they can't be parsed, the
+// latest one is returned.
+func mergeGtids(gtids, latest string) string {
+	gtidSet, err := replication.ParseMysql56GTIDSet(gtids)
+	if err != nil {
+		return latest
+	}
+	latestSet, err := replication.ParseMysql56GTIDSet(latest)
+	if err != nil {
+		return latest
+	}
+	return gtidSet.Union(latestSet).String()
 }
 
 func removeShard(tabletAlias *topodatapb.TabletAlias, sessions []*vtgatepb.Session_ShardSession) ([]*vtgatepb.Session_ShardSession, error) {
@@ -23,7 +23,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -168,6 +172,39 @@ func TestPrequeries(t *testing.T) {
 	}
 }
 
+func TestCommittedGtids(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	ks0 := &querypb.Target{Keyspace: "ks", Shard: "-80"}
+	ks1 := &querypb.Target{Keyspace: "ks", Shard: "80-"}
+
+	// The GTIDs are only recorded when the session tracks them.
+	session.RecordCommittedGtid(ks0, "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:1")
+	assert.Empty(t, session.TakeCommittedGtids())
+
+	session.SetSessionTrackGtids(true)
+	assert.True(t, session.GetOptions().GetSessionTrackGtids())
+	session.RecordCommittedGtid(ks1, "9f0ed4a4-6b1e-11ee-a6d4-0242ac120003:7")
+	session.RecordCommittedGtid(ks0, "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:3")
+	session.RecordCommittedGtid(ks0, "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:4")
+	session.RecordCommittedGtid(ks1, "")
+
+	var vgtid binlogdatapb.VGtid
+	require.NoError(t, protojson.Unmarshal([]byte(session.TakeCommittedGtids()), &vgtid))
+	want := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: "ks",
+		Shard:    "-80",
+		Gtid:     "MySQL56/e2f7c9a0-6b1d-11ee-8c99-0242ac120002:3-4",
+	}, {
+		Keyspace: "ks",
+		Shard:    "80-",
+		Gtid:     "MySQL56/9f0ed4a4-6b1e-11ee-a6d4-0242ac120003:7",
+	}}}
+	utils.MustMatch(t, want, &vgtid)
+
+	// The GTIDs are forgotten once taken.
+	assert.Empty(t, session.TakeCommittedGtids())
+}
+
 func TestTimeZone(t *testing.T) {
 	testCases := []struct {
 		tz   string
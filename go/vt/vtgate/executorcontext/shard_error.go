@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ShardError is the error of a query on a shard, described so that the clients
+// can tell which shards failed a query, and whether it can be retried.
+type ShardError struct {
+	Keyspace   string `json:"keyspace"`
+	Shard      string `json:"shard"`
+	TabletType string `json:"tablet_type"`
+	// Code is the vtrpc code of the error.
+	Code string `json:"code"`
+	// Errno and SQLState are the MySQL error number and state of the error.
+	Errno    int    `json:"errno"`
+	SQLState string `json:"sqlstate"`
+	// Retryable tells whether the query can be retried as is, because the error
+	// is transient, for example while the shard is reparented.
+	Retryable bool   `json:"retryable"`
+	Message   string `json:"message"`
+}
+
+// NewShardError returns the ShardError of the error of a query on a target.
+func NewShardError(target *querypb.Target, err error) *ShardError {
+	code := vterrors.Code(err)
+	sqlErr, _ := sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError)
+	shardErr := &ShardError{
+		Keyspace:   target.Keyspace,
+		Shard:      target.Shard,
+		TabletType: topoproto.TabletTypeLString(target.TabletType),
+		Code:       code.String(),
+		Message:    err.Error(),
+	}
+	if sqlErr != nil {
+		shardErr.Errno = int(sqlErr.Num)
+		shardErr.SQLState = sqlErr.State
+	}
+	// The query can be retried when the shard was unavailable, for example
+	// while it was reparented, or when its transaction was rolled back, for
+	// example because of a deadlock.
+	switch {
+	case code == vtrpcpb.Code_UNAVAILABLE, code == vtrpcpb.Code_CLUSTER_EVENT, code == vtrpcpb.Code_ABORTED:
+		shardErr.Retryable = true
+	case sqlErr != nil:
+		shardErr.Retryable = sqlErr.Num == sqlerror.ERLockDeadlock || sqlErr.Num == sqlerror.ERLockWaitTimeout
+	}
+	return shardErr
+}
+
+// String returns a short description of the error, without its message.
+func (e *ShardError) String() string {
+	retryable := "not retryable"
+	if e.Retryable {
+		retryable = "retryable"
+	}
+	return fmt.Sprintf("%s: errno %d, %s, %s", topoproto.KeyspaceShardString(e.Keyspace, e.Shard), e.Errno, e.Code, retryable)
+}
+
+// sortShardErrors sorts the errors by keyspace and shard.
+func sortShardErrors(shardErrors []*ShardError) {
+	slices.SortFunc(shardErrors, func(a, b *ShardError) int {
+		return cmp.Or(cmp.Compare(a.Keyspace, b.Keyspace), cmp.Compare(a.Shard, b.Shard))
+	})
+}
+
+// ShardErrorsJSON returns the errors in JSON, or an empty string if there are
+// none.
+func ShardErrorsJSON(shardErrors []*ShardError) string {
+	if len(shardErrors) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(shardErrors)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ShardErrorsSummary returns a short description of the errors of the shards,
+// to add to the error of a query which failed on several shards.
+func ShardErrorsSummary(shardErrors []*ShardError) string {
+	descriptions := make([]string, 0, len(shardErrors))
+	for _, shardErr := range shardErrors {
+		descriptions = append(descriptions, shardErr.String())
+	}
+	if len(shardErrors) == 1 {
+		return "1 shard failed: " + descriptions[0]
+	}
+	return fmt.Sprintf("%d shards failed: %s", len(shardErrors), strings.Join(descriptions, "; "))
+}
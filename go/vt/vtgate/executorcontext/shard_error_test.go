@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestNewShardError(t *testing.T) {
+	target := &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	testCases := []struct {
+		name string
+		err  error
+		want *ShardError
+	}{{
+		name: "duplicate entry",
+		err:  vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "Duplicate entry '1' for key 'PRIMARY' (errno 1062) (sqlstate 23000)"),
+		want: &ShardError{
+			Keyspace:   "ks",
+			Shard:      "-80",
+			TabletType: "primary",
+			Code:       "ALREADY_EXISTS",
+			Errno:      1062,
+			SQLState:   "23000",
+			Message:    "Duplicate entry '1' for key 'PRIMARY' (errno 1062) (sqlstate 23000)",
+		},
+	}, {
+		name: "deadlock",
+		err:  vterrors.Errorf(vtrpcpb.Code_ABORTED, "Deadlock found when trying to get lock (errno 1213) (sqlstate 40001)"),
+		want: &ShardError{
+			Keyspace:   "ks",
+			Shard:      "-80",
+			TabletType: "primary",
+			Code:       "ABORTED",
+			Errno:      1213,
+			SQLState:   "40001",
+			Retryable:  true,
+			Message:    "Deadlock found when trying to get lock (errno 1213) (sqlstate 40001)",
+		},
+	}, {
+		name: "unavailable",
+		err:  vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available"),
+		want: &ShardError{
+			Keyspace:   "ks",
+			Shard:      "-80",
+			TabletType: "primary",
+			Code:       "UNAVAILABLE",
+			Errno:      1105,
+			SQLState:   "HY000",
+			Retryable:  true,
+			Message:    "no healthy tablet available",
+		},
+	}, {
+		name: "internal",
+		err:  vterrors.Errorf(vtrpcpb.Code_INTERNAL, "something went wrong"),
+		want: &ShardError{
+			Keyspace:   "ks",
+			Shard:      "-80",
+			TabletType: "primary",
+			Code:       "INTERNAL",
+			Errno:      1815,
+			SQLState:   "HY000",
+			Message:    "something went wrong",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			utils.MustMatch(t, tc.want, NewShardError(target, tc.err))
+		})
+	}
+}
+
+func TestShardErrors(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	ks0 := &querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	ks1 := &querypb.Target{Keyspace: "ks", Shard: "80-", TabletType: topodatapb.TabletType_PRIMARY}
+
+	session.RecordShardError(ks1, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "Lost connection to MySQL server during query (errno 2013) (sqlstate HY000)"))
+	session.RecordShardError(ks0, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "Duplicate entry '1' for key 'PRIMARY' (errno 1062) (sqlstate 23000)"))
+	session.RecordShardError(ks0, nil)
+
+	shardErrors := session.TakeShardErrors()
+	require.Len(t, shardErrors, 2)
+	assert.Equal(t, "-80", shardErrors[0].Shard)
+	assert.Equal(t, "80-", shardErrors[1].Shard)
+	assert.Equal(t, "2 shards failed: ks/-80: errno 1062, ALREADY_EXISTS, not retryable; ks/80-: errno 2013, UNAVAILABLE, retryable", ShardErrorsSummary(shardErrors))
+	assert.Equal(t, "1 shard failed: ks/80-: errno 2013, UNAVAILABLE, retryable", ShardErrorsSummary(shardErrors[1:]))
+
+	var decoded []*ShardError
+	require.NoError(t, json.Unmarshal([]byte(ShardErrorsJSON(shardErrors)), &decoded))
+	utils.MustMatch(t, shardErrors, decoded)
+
+	// The errors are forgotten once taken.
+	assert.Empty(t, session.TakeShardErrors())
+	assert.Empty(t, ShardErrorsJSON(nil))
+}
@@ -32,6 +32,7 @@ import (
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/discovery"
@@ -46,6 +47,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/sysvars"
+	"vitess.io/vitess/go/vt/tableacl"
 	"vitess.io/vitess/go/vt/topo"
 	topoprotopb "vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
@@ -55,6 +57,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
@@ -91,6 +94,22 @@ type (
 		WarmingReadsPercent int
 		WarmingReadsTimeout time.Duration
 		WarmingReadsChannel chan bool
+
+		MirrorCompareResults  bool
+		MirrorMismatchLogRate float64
+
+		MaxCrossShardCascadeRows int
+		LoadDataChunkRows        int
+
+		// QueryMemoryLimit is the maximum number of bytes of the rows a query
+		// buffers, 0 meaning no limit. MemoryBudget bounds the bytes buffered
+		// by all the queries.
+		QueryMemoryLimit int64
+		MemoryBudget     *MemoryBudget
+
+		// Spill configures the spilling to disk of the rows of the sorts and
+		// hash joins of the streaming queries, nil meaning they are never spilled.
+		Spill *engine.SpillConfig
 	}
 
 	// vcursor_impl needs these facilities to be able to be able to execute queries for vindexes
@@ -98,6 +117,8 @@ type (
 		Execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConnection, method string, session *SafeSession, s string, vars map[string]*querypb.BindVariable, prepared bool) (*sqltypes.Result, error)
 		ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, autocommit bool, ignoreMaxMemoryRows bool, resultsObserver ResultsObserver, fetchLastInsertID bool) (qr *sqltypes.Result, errs []error)
 		StreamExecuteMulti(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, vars []map[string]*querypb.BindVariable, session *SafeSession, autocommit bool, callback func(reply *sqltypes.Result) error, observer ResultsObserver, fetchLastInsertID bool) []error
+		ExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, ignoreMaxMemoryRows bool, resultsObserver ResultsObserver) (*sqltypes.Result, []error)
+		StreamExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, vars []map[string]*querypb.BindVariable, session *SafeSession, callback func(reply *sqltypes.Result) error, observer ResultsObserver) []error
 		ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *SafeSession, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error)
 		Commit(ctx context.Context, safeSession *SafeSession) error
 		ExecuteMessageStream(ctx context.Context, rss []*srvtopo.ResolvedShard, name string, callback func(*sqltypes.Result) error) error
@@ -105,9 +126,16 @@ type (
 		ReleaseLock(ctx context.Context, session *SafeSession) error
 
 		ShowVitessReplicationStatus(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ReplicationStaleness(target *querypb.Target) (time.Duration, error)
+		FilteredReplicationLag(target *querypb.Target) (time.Duration, error)
+		NextIDs(ctx context.Context, sequence string, count int64, kOrdered bool, fetch func(ctx context.Context, blockSize int64) (int64, error)) (int64, error)
 		ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error)
 		ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowResultSizes(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowVindexAdvice(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowProcesslist(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		SetVitessMetadata(ctx context.Context, name, value string) error
 
 		// TODO: remove when resolver is gone
@@ -128,6 +156,7 @@ type (
 
 	Resolver interface {
 		GetGateway() srvtopo.Gateway
+		GetKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, error)
 		ResolveDestinations(
 			ctx context.Context,
 			keyspace string,
@@ -174,6 +203,12 @@ type (
 		semTable            *semantics.SemTable
 		queryTimeout        time.Duration
 
+		// canary limits the shards the query executes on, see SetCanary.
+		canary *canaryExecution
+
+		// memory accounts for the rows the query buffers, see TrackMemory.
+		memory *MemoryTracker
+
 		warnings []*querypb.QueryWarning // any warnings that are accumulated during the planning phase are stored here
 
 		observer ResultsObserver
@@ -211,6 +246,10 @@ func NewVCursorImpl(
 	if err != nil {
 		return nil, err
 	}
+	if destination == nil {
+		// The tenant of the session selects the keyspace its queries are routed to.
+		keyspace = vschema.FindTenantKeyspace(keyspace, safeSession.GetTenantID())
+	}
 
 	var ts *topo.Server
 	// We don't have access to the underlying TopoServer if this vtgate is
@@ -238,6 +277,7 @@ func NewVCursorImpl(
 		vm:         vm,
 		topoServer: ts,
 		observer:   observer,
+		memory:     NewMemoryTracker(cfg.QueryMemoryLimit, cfg.MemoryBudget),
 	}, nil
 }
 
@@ -344,6 +384,7 @@ func (vc *VCursorImpl) cloneWithAutocommitSession() *VCursorImpl {
 		vm:         vc.vm,
 		topoServer: vc.topoServer,
 		observer:   vc.observer,
+		memory:     vc.memory,
 	}
 }
 
@@ -400,6 +441,43 @@ func (vc *VCursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
 	return !vc.ignoreMaxMemoryRows && numRows > vc.config.MaxMemoryRows
 }
 
+// MaxCrossShardCascadeRows returns the maximum number of parent rows a cross-shard foreign key cascade can modify.
+func (vc *VCursorImpl) MaxCrossShardCascadeRows() int {
+	return vc.config.MaxCrossShardCascadeRows
+}
+
+// LoadDataChunkRows returns the number of rows of LOAD DATA LOCAL INFILE inserted by each INSERT.
+func (vc *VCursorImpl) LoadDataChunkRows() int {
+	return vc.config.LoadDataChunkRows
+}
+
+// TrackMemory accounts for size bytes of rows buffered by the query. It fails
+// when the query, or all the queries, would buffer more than their limit.
+func (vc *VCursorImpl) TrackMemory(size int64) error {
+	return vc.memory.Grow(size)
+}
+
+// UntrackMemory returns size bytes of rows the query no longer buffers, as
+// they were spilled to disk, to the budget of all the queries.
+func (vc *VCursorImpl) UntrackMemory(size int64) {
+	vc.memory.Shrink(size)
+}
+
+// SpillConfig implements the VCursor interface.
+func (vc *VCursorImpl) SpillConfig() *engine.SpillConfig {
+	return vc.config.Spill
+}
+
+// ReleaseMemory returns the memory of the rows buffered by the query to the
+// budget of all the queries, and records the peak memory of the query in its
+// log stats. It must be called once the query finished.
+func (vc *VCursorImpl) ReleaseMemory() {
+	peak := vc.memory.Release()
+	if vc.logStats != nil {
+		vc.logStats.PeakMemory = max(vc.logStats.PeakMemory, peak)
+	}
+}
+
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
 func (vc *VCursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
@@ -544,6 +622,11 @@ func ParseDestinationTarget(targetString string, tablet topodatapb.TabletType, v
 			destKeyspace = k
 		}
 	}
+	// The default tablet type of the keyspace applies when the target does
+	// not set one.
+	if ks := vschema.Keyspaces[destKeyspace]; ks != nil && ks.DefaultTabletType != topodatapb.TabletType_UNKNOWN && !strings.Contains(targetString, "@") {
+		destTabletType = ks.DefaultTabletType
+	}
 	return destKeyspace, destTabletType, dest, err
 }
 
@@ -694,6 +777,94 @@ func (vc *VCursorImpl) Planner() plancontext.PlannerVersion {
 	return vc.config.PlannerVersion
 }
 
+// PlannerFlags implements the ContextVSchema interface
+func (vc *VCursorImpl) PlannerFlags() plannerflags.Flags {
+	return plannerflags.Resolve(vc.keyspacePlannerFlags(), vc.SafeSession.GetPlannerFlags())
+}
+
+// keyspacePlannerFlags returns the planner flags of the keyspace targeted by
+// the session, if any.
+func (vc *VCursorImpl) keyspacePlannerFlags() map[string]string {
+	if ks := vc.vschema.Keyspaces[vc.keyspace]; ks != nil {
+		return ks.PlannerFlags
+	}
+	return nil
+}
+
+// showPlannerFlags returns the planner flags in effect for the session, with
+// where their value comes from.
+func (vc *VCursorImpl) showPlannerFlags(filter *sqlparser.ShowFilter) *sqltypes.Result {
+	var likeFilter func(string) bool
+	if filter != nil && filter.Like != "" {
+		likeFilter = sqlparser.LikeToRegexp(filter.Like).MatchString
+	}
+
+	rows := [][]sqltypes.Value{}
+	for _, setting := range plannerflags.Describe(vc.keyspacePlannerFlags(), vc.SafeSession.GetPlannerFlags()) {
+		if likeFilter != nil && !likeFilter(setting.Name) {
+			continue
+		}
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.NewVarChar(setting.Name),
+			sqltypes.NewVarChar(setting.Value),
+			sqltypes.NewVarChar(string(setting.Source)),
+		})
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "Flag", Type: sqltypes.VarChar},
+			{Name: "Value", Type: sqltypes.VarChar},
+			{Name: "Source", Type: sqltypes.VarChar},
+		},
+		Rows: rows,
+	}
+}
+
+// tableACLPrivileges are the MySQL privileges matching the roles of the table ACL.
+var tableACLPrivileges = map[tableacl.Role][]string{
+	tableacl.READER: {"SELECT"},
+	tableacl.WRITER: {"INSERT", "UPDATE", "DELETE"},
+	tableacl.ADMIN:  {"CREATE", "ALTER", "DROP"},
+}
+
+// showGrants emulates SHOW GRANTS for the caller, reporting its Vitess-level
+// permissions rather than the ones of the MySQL user of the vttablets: its
+// grants in the table ACL, whether it can alter the VSchema, and its roles.
+func (vc *VCursorImpl) showGrants(ctx context.Context) *sqltypes.Result {
+	caller := callerid.ImmediateCallerIDFromContext(ctx)
+	user := sqlescape.EscapeID(caller.GetUsername()) + "@`%`"
+
+	var rows [][]sqltypes.Value
+	addGrant := func(format string, args ...any) {
+		rows = append(rows, []sqltypes.Value{sqltypes.NewVarChar(fmt.Sprintf(format, args...) + " TO " + user)})
+	}
+	addGrant("GRANT USAGE ON *.*")
+	if tableacl.GetCurrentConfig() == nil {
+		// Without a table ACL, the tables are not restricted.
+		addGrant("GRANT ALL PRIVILEGES ON *.*")
+	} else {
+		for _, grant := range tableacl.Grants(caller) {
+			var privileges []string
+			for _, role := range grant.Roles {
+				privileges = append(privileges, tableACLPrivileges[role]...)
+			}
+			addGrant("GRANT %s ON *.%s", strings.Join(privileges, ", "), sqlescape.EscapeID(grant.TableNameOrPrefix))
+		}
+	}
+	if vschemaacl.Authorized(caller) {
+		addGrant("GRANT ALTER VSCHEMA ON *.*")
+	}
+	for _, role := range tableacl.Roles(caller) {
+		addGrant("GRANT %s@`%%`", sqlescape.EscapeID(role))
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: fmt.Sprintf("Grants for %s@%%", caller.GetUsername()), Type: sqltypes.VarChar},
+		},
+		Rows: rows,
+	}
+}
+
 // GetSemTable implements the ContextVSchema interface
 func (vc *VCursorImpl) GetSemTable() *semantics.SemTable {
 	return vc.semTable
@@ -850,8 +1021,23 @@ func (vc *VCursorImpl) markSavepoint(ctx context.Context, needsRollbackOnParialE
 
 // ExecuteMultiShard is part of the engine.VCursor interface.
 func (vc *VCursorImpl) ExecuteMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit, fetchLastInsertID bool) (*sqltypes.Result, []error) {
+	if vc.canary != nil {
+		rss, queries = vc.canary.filter(rss, queries)
+	}
+	if err := vc.checkWriteFences(ctx, rss); err != nil {
+		return nil, []error{err}
+	}
+	return vc.executeMultiShard(ctx, primitive, rss, queries, rollbackOnError, canAutocommit, fetchLastInsertID)
+}
+
+func (vc *VCursorImpl) executeMultiShard(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, rollbackOnError, canAutocommit, fetchLastInsertID bool) (*sqltypes.Result, []error) {
 	noOfShards := len(rss)
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(noOfShards))
+	if vc.consistentRead(rss) {
+		qr, errs := vc.executor.ExecuteConsistentRead(ctx, primitive, rss, commentedShardQueries(queries, vc.marginComments), vc.SafeSession, vc.ignoreMaxMemoryRows, vc.observer)
+		vc.logShardsQueried(primitive, len(rss))
+		return qr, errs
+	}
 	err := vc.markSavepoint(ctx, rollbackOnError && (noOfShards > 1), map[string]*querypb.BindVariable{})
 	if err != nil {
 		return nil, []error{err}
@@ -866,12 +1052,33 @@ func (vc *VCursorImpl) ExecuteMultiShard(ctx context.Context, primitive engine.P
 	return qr, errs
 }
 
+// ExecuteShardDML executes a DML of a batch grouped by shard, in which the DMLs
+// of the different shards are executed concurrently. Unlike ExecuteMultiShard,
+// it does not update the last insert id of the session, which is updated in the
+// order of the batch once it is executed.
+func (vc *VCursorImpl) ExecuteShardDML(ctx context.Context, primitive engine.Primitive, dml *engine.ShardDML) (*sqltypes.Result, error) {
+	rss, queries := []*srvtopo.ResolvedShard{dml.Shard}, []*querypb.BoundQuery{dml.Query}
+	if vc.canary != nil {
+		rss, queries = vc.canary.filter(rss, queries)
+	}
+	if err := vc.checkWriteFences(ctx, rss); err != nil {
+		return nil, err
+	}
+	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(len(rss)))
+	qr, errs := vc.executor.ExecuteMultiShard(ctx, primitive, rss, commentedShardQueries(queries, vc.marginComments), vc.SafeSession, false /* autocommit */, vc.ignoreMaxMemoryRows, vc.observer, dml.FetchLastInsertID)
+	vc.logShardsQueried(primitive, len(rss))
+	return qr, vterrors.Aggregate(errs)
+}
+
 // StreamExecuteMulti is the streaming version of ExecuteMultiShard.
 func (vc *VCursorImpl) StreamExecuteMulti(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, bindVars []map[string]*querypb.BindVariable, rollbackOnError, autocommit, fetchLastInsertID bool, callback func(reply *sqltypes.Result) error) []error {
 	callback = vc.wrapCallback(callback, primitive)
 
 	noOfShards := len(rss)
 	atomic.AddUint64(&vc.logStats.ShardQueries, uint64(noOfShards))
+	if vc.consistentRead(rss) {
+		return vc.executor.StreamExecuteConsistentRead(ctx, primitive, vc.marginComments.Leading+query+vc.marginComments.Trailing, rss, bindVars, vc.SafeSession, callback, vc.observer)
+	}
 	err := vc.markSavepoint(ctx, rollbackOnError && (noOfShards > 1), map[string]*querypb.BindVariable{})
 	if err != nil {
 		return []error{err}
@@ -931,10 +1138,23 @@ func (vc *VCursorImpl) ExecuteKeyspaceID(ctx context.Context, keyspace string, k
 			vc.SafeSession.SetQueryFromVindex(false)
 		}()
 	}
-	qr, errs := vc.ExecuteMultiShard(ctx, nil, rss, queries, rollbackOnError, autocommit, false)
+	// Vindex queries are not subject to canary executions: they must reach the shard of the keyspace id.
+	qr, errs := vc.executeMultiShard(ctx, nil, rss, queries, rollbackOnError, autocommit, false)
 	return qr, vterrors.Aggregate(errs)
 }
 
+// consistentRead tells whether the query reads the shards from consistent
+// snapshots: it is a SELECT of a session with @@vitess_consistent_reads set,
+// outside of a transaction and of a reserved connection, targeting several
+// shards.
+func (vc *VCursorImpl) consistentRead(rss []*srvtopo.ResolvedShard) bool {
+	return len(rss) > 1 &&
+		vc.logStats.StmtType == "SELECT" &&
+		vc.SafeSession.GetConsistentReads() &&
+		!vc.SafeSession.InTransaction() &&
+		!vc.SafeSession.InReservedConn()
+}
+
 func (vc *VCursorImpl) InTransactionAndIsDML() bool {
 	if !vc.SafeSession.InTransaction() {
 		return false
@@ -1252,6 +1472,53 @@ func (vc *VCursorImpl) SetInDMLExecution(inDMLExec bool) {
 	vc.SafeSession.SetInDMLExecution(inDMLExec)
 }
 
+// SetTabletTags implements the SessionActions interface
+func (vc *VCursorImpl) SetTabletTags(tags map[string]string) {
+	vc.SafeSession.SetTabletTags(tags)
+}
+
+// SetDDLInTransaction implements the SessionActions interface
+func (vc *VCursorImpl) SetDDLInTransaction(mode vtgatepb.DDLInTransaction) {
+	vc.SafeSession.SetDDLInTransaction(mode)
+}
+
+// GetDDLInTransaction implements the SessionActions interface
+func (vc *VCursorImpl) GetDDLInTransaction() vtgatepb.DDLInTransaction {
+	return vc.SafeSession.GetDDLInTransaction()
+}
+
+// SetTenantID implements the SessionActions interface
+func (vc *VCursorImpl) SetTenantID(tenantID string) {
+	vc.SafeSession.SetTenantID(tenantID)
+}
+
+// SetConsistentReads implements the SessionActions interface
+func (vc *VCursorImpl) SetConsistentReads(_ context.Context, enable bool) error {
+	vc.SafeSession.SetConsistentReads(enable)
+	return nil
+}
+
+// SetPartialScatterReads implements the SessionActions interface
+func (vc *VCursorImpl) SetPartialScatterReads(_ context.Context, enable bool) error {
+	vc.SafeSession.SetPartialScatterReads(enable)
+	return nil
+}
+
+// SetMaxStaleness implements the SessionActions interface
+func (vc *VCursorImpl) SetMaxStaleness(maxStaleness time.Duration) {
+	vc.SafeSession.SetMaxStaleness(maxStaleness)
+}
+
+// GetPartialScatterReads implements the SessionActions interface
+func (vc *VCursorImpl) GetPartialScatterReads() bool {
+	return vc.SafeSession.GetPartialScatterReads()
+}
+
+// SetPlannerFlags implements the SessionActions interface
+func (vc *VCursorImpl) SetPlannerFlags(flags map[string]string) {
+	vc.SafeSession.SetPlannerFlags(flags)
+}
+
 // SetDDLStrategy implements the SessionActions interface
 func (vc *VCursorImpl) SetDDLStrategy(strategy string) {
 	vc.SafeSession.SetDDLStrategy(strategy)
@@ -1344,6 +1611,11 @@ func (vc *VCursorImpl) InTransaction() bool {
 	return vc.SafeSession.InTransaction()
 }
 
+// IsTxOpen implements the SessionActions interface
+func (vc *VCursorImpl) IsTxOpen() bool {
+	return vc.SafeSession.IsTxOpen()
+}
+
 func (vc *VCursorImpl) Commit(ctx context.Context) error {
 	return vc.executor.Commit(ctx, vc.SafeSession)
 }
@@ -1498,6 +1770,18 @@ func (vc *VCursorImpl) ShowExec(ctx context.Context, command sqlparser.ShowComma
 		return vc.executor.ShowTablets(filter)
 	case sqlparser.VitessVariables:
 		return vc.executor.ShowVitessMetadata(ctx, filter)
+	case sqlparser.VitessResultSizes:
+		return vc.executor.ShowResultSizes(filter)
+	case sqlparser.VitessQueryDigests:
+		return vc.executor.ShowQueryDigests(filter)
+	case sqlparser.VitessVindexAdvice:
+		return vc.executor.ShowVindexAdvice(filter)
+	case sqlparser.VitessPlannerFlags:
+		return vc.showPlannerFlags(filter), nil
+	case sqlparser.VitessProcesslist:
+		return vc.executor.ShowProcesslist(filter)
+	case sqlparser.Grants:
+		return vc.showGrants(ctx), nil
 	default:
 		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "bug: unexpected show command: %v", command)
 	}
@@ -1623,6 +1907,14 @@ func (vc *VCursorImpl) GetWarmingReadsChannel() chan bool {
 	return vc.config.WarmingReadsChannel
 }
 
+func (vc *VCursorImpl) GetMirrorCompareResults() bool {
+	return vc.config.MirrorCompareResults
+}
+
+func (vc *VCursorImpl) GetMirrorMismatchLogRate() float64 {
+	return vc.config.MirrorMismatchLogRate
+}
+
 // SetForeignKeyCheckState updates the foreign key checks state of the vcursor.
 func (vc *VCursorImpl) SetForeignKeyCheckState(fkChecksState *bool) {
 	vc.fkChecksState = fkChecksState
@@ -1664,3 +1956,38 @@ func (vc *VCursorImpl) SetLastInsertID(id uint64) {
 	defer vc.SafeSession.mu.Unlock()
 	vc.SafeSession.LastInsertId = id
 }
+
+// ReplicationStaleness implements the evalengine.VCursor interface. It
+// reports the staleness of the replicas of the shard, or of its rdonly
+// tablets when the session targets them.
+func (vc *VCursorImpl) ReplicationStaleness(keyspace, shard string) (time.Duration, error) {
+	tabletType := topodatapb.TabletType_REPLICA
+	if vc.tabletType == topodatapb.TabletType_RDONLY {
+		tabletType = topodatapb.TabletType_RDONLY
+	}
+	return vc.executor.ReplicationStaleness(&querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: tabletType})
+}
+
+// FilteredReplicationLag implements the engine.VCursor interface. It reports
+// the lag of the VReplication workflows of the primaries of all the shards of
+// the keyspace.
+func (vc *VCursorImpl) FilteredReplicationLag(ctx context.Context, keyspace string) (time.Duration, error) {
+	rss, _, err := vc.resolver.ResolveDestinations(ctx, keyspace, topodatapb.TabletType_PRIMARY, nil, []key.ShardDestination{key.DestinationAllShards{}})
+	if err != nil {
+		return 0, err
+	}
+	var lag time.Duration
+	for _, rs := range rss {
+		shardLag, err := vc.executor.FilteredReplicationLag(rs.Target)
+		if err != nil {
+			return 0, err
+		}
+		lag = max(lag, shardLag)
+	}
+	return lag, nil
+}
+
+// NextIDs is part of the engine.VCursor interface.
+func (vc *VCursorImpl) NextIDs(ctx context.Context, sequence string, count int64, kOrdered bool, fetch func(ctx context.Context, blockSize int64) (int64, error)) (int64, error) {
+	return vc.executor.NextIDs(ctx, sequence, count, kOrdered, fetch)
+}
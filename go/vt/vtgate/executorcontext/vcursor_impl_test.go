@@ -343,6 +343,16 @@ func (f fakeExecutor) StreamExecuteMulti(ctx context.Context, primitive engine.P
 	panic("implement me")
 }
 
+func (f fakeExecutor) ExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, rss []*srvtopo.ResolvedShard, queries []*querypb.BoundQuery, session *SafeSession, ignoreMaxMemoryRows bool, resultsObserver ResultsObserver) (*sqltypes.Result, []error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) StreamExecuteConsistentRead(ctx context.Context, primitive engine.Primitive, query string, rss []*srvtopo.ResolvedShard, vars []map[string]*querypb.BindVariable, session *SafeSession, callback func(reply *sqltypes.Result) error, observer ResultsObserver) []error {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) ExecuteLock(ctx context.Context, rs *srvtopo.ResolvedShard, query *querypb.BoundQuery, session *SafeSession, lockFuncType sqlparser.LockingFuncType) (*sqltypes.Result, error) {
 	// TODO implement me
 	panic("implement me")
@@ -373,6 +383,21 @@ func (f fakeExecutor) ShowVitessReplicationStatus(ctx context.Context, filter *s
 	panic("implement me")
 }
 
+func (f fakeExecutor) ReplicationStaleness(target *querypb.Target) (time.Duration, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) FilteredReplicationLag(target *querypb.Target) (time.Duration, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) NextIDs(context.Context, string, int64, bool, func(context.Context, int64) (int64, error)) (int64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error) {
 	// TODO implement me
 	panic("implement me")
@@ -388,6 +413,26 @@ func (f fakeExecutor) ShowVitessMetadata(ctx context.Context, filter *sqlparser.
 	panic("implement me")
 }
 
+func (f fakeExecutor) ShowResultSizes(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) ShowQueryDigests(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) ShowVindexAdvice(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (f fakeExecutor) ShowProcesslist(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) SetVitessMetadata(ctx context.Context, name, value string) error {
 	// TODO implement me
 	panic("implement me")
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// writeFenceCheckInterval is how often the write fence of a shard is checked
+// again while a write to the shard is buffered.
+var writeFenceCheckInterval = 100 * time.Millisecond
+
+// isWrite tells whether the query is a write, which is rejected on the shards
+// whose writes are fenced.
+func (vc *VCursorImpl) isWrite() bool {
+	switch vc.logStats.StmtType {
+	case "INSERT", "REPLACE", "UPDATE", "DELETE":
+		return true
+	}
+	return false
+}
+
+// checkWriteFences returns an error if the query is a write and the writes to
+// one of the shards are fenced. If the fence of the shard has a buffer
+// duration, it first waits for up to that duration for the fence to be
+// removed. The SrvKeyspace of each keyspace is only looked up once.
+func (vc *VCursorImpl) checkWriteFences(ctx context.Context, rss []*srvtopo.ResolvedShard) error {
+	if !vc.isWrite() {
+		return nil
+	}
+	srvKeyspaces := make(map[string]*topodatapb.SrvKeyspace)
+	for _, rs := range rss {
+		srvKeyspace, ok := srvKeyspaces[rs.Target.Keyspace]
+		if !ok {
+			var err error
+			if srvKeyspace, err = vc.srvKeyspace(ctx, rs.Target); err != nil {
+				return err
+			}
+			srvKeyspaces[rs.Target.Keyspace] = srvKeyspace
+		}
+		fence := srvKeyspace.GetWriteFences()[rs.Target.Shard]
+		if fence == nil {
+			continue
+		}
+		if err := vc.waitForWriteFence(ctx, rs.Target, fence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vc *VCursorImpl) waitForWriteFence(ctx context.Context, target *querypb.Target, fence *topodatapb.WriteFence) error {
+	bufferDuration, _, _ := protoutil.DurationFromProto(fence.BufferDuration)
+	if bufferDuration > 0 {
+		timer := time.NewTimer(bufferDuration)
+		defer timer.Stop()
+		ticker := time.NewTicker(writeFenceCheckInterval)
+		defer ticker.Stop()
+	buffer:
+		for {
+			select {
+			case <-ctx.Done():
+				break buffer
+			case <-timer.C:
+				break buffer
+			case <-ticker.C:
+				srvKeyspace, err := vc.srvKeyspace(ctx, target)
+				if err != nil {
+					return err
+				}
+				if fence = srvKeyspace.GetWriteFences()[target.Shard]; fence == nil {
+					return nil
+				}
+			}
+		}
+	}
+	return vterrors.VT09034(topoproto.KeyspaceShardString(target.Keyspace, target.Shard), fence.Reason)
+}
+
+// srvKeyspace returns the SrvKeyspace of the keyspace of the target in the
+// cell, which holds the write fences of its shards.
+func (vc *VCursorImpl) srvKeyspace(ctx context.Context, target *querypb.Target) (*topodatapb.SrvKeyspace, error) {
+	_, srvKeyspace, _, err := vc.resolver.GetKeyspaceShards(ctx, target.Keyspace, target.TabletType)
+	return srvKeyspace, err
+}
@@ -176,7 +176,8 @@ func (a *streamExecuteAdapter) Recv() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, vterrors.FromGRPC(err)
 	}
-	if a.fields == nil {
+	// A packet with fields starts a new result set.
+	if qr.Fields != nil {
 		a.fields = qr.Fields
 	}
 	return sqltypes.CustomProto3ToResult(a.fields, qr), nil
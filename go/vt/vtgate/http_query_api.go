@@ -0,0 +1,417 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// httpQueryPath is the path of the HTTP query API.
+const httpQueryPath = "/query"
+
+var (
+	enableHTTPQueryAPI      bool
+	httpQueryAPIMaxBodySize int64 = 16 * 1024 * 1024
+
+	httpQueryRequests = stats.NewCountersWithSingleLabel("HttpQueryApiRequests", "Number of requests of the HTTP query API, by response code", "Code")
+)
+
+func registerHTTPQueryAPIFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&enableHTTPQueryAPI, "enable-http-query-api", enableHTTPQueryAPI, "If set, vtgate accepts queries as JSON on POST "+httpQueryPath+" of its HTTP port. Users are authenticated with HTTP basic authentication by the --mysql_auth_server_impl auth server.")
+	fs.Int64Var(&httpQueryAPIMaxBodySize, "http-query-api-max-body-size", httpQueryAPIMaxBodySize, "Maximum size in bytes of the requests of the HTTP query API.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerHTTPQueryAPIFlags)
+	servenv.OnParseFor("vtcombo", registerHTTPQueryAPIFlags)
+}
+
+// httpQueryRequest is the body of a request of the HTTP query API.
+type httpQueryRequest struct {
+	SQL string `json:"sql"`
+	// BindVariables are the values of the :name bind variables of the query.
+	BindVariables map[string]any `json:"bind_variables,omitempty"`
+	// Params are the values of the ? placeholders of the query, which is
+	// then executed as a prepared statement.
+	Params []any `json:"params,omitempty"`
+	// Target is the keyspace, with an optional shard and tablet type, e.g.
+	// ks@replica. It defaults to the default keyspace.
+	Target  string                   `json:"target,omitempty"`
+	Options *httpQueryRequestOptions `json:"options,omitempty"`
+}
+
+type httpQueryRequestOptions struct {
+	// Workload is the workload of the query, OLTP or OLAP.
+	Workload string `json:"workload,omitempty"`
+	// Timeout is the timeout of the query, e.g. 5s.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// httpQueryResponse is the body of a response of the HTTP query API. Rows
+// contain the values as JSON numbers for numeric types except decimals, as
+// JSON documents for the JSON type, as base64 strings for binary types, and
+// as strings for all the other types.
+type httpQueryResponse struct {
+	Fields       []httpQueryField    `json:"fields,omitempty"`
+	Rows         [][]json.RawMessage `json:"rows,omitempty"`
+	RowsAffected uint64              `json:"rows_affected"`
+	InsertID     uint64              `json:"insert_id,omitempty"`
+	Warnings     []httpQueryWarning  `json:"warnings,omitempty"`
+	Error        *httpQueryError     `json:"error,omitempty"`
+}
+
+type httpQueryField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type httpQueryWarning struct {
+	Code    uint32 `json:"code"`
+	Message string `json:"message"`
+}
+
+type httpQueryError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Errno    int    `json:"errno,omitempty"`
+	SQLState string `json:"sql_state,omitempty"`
+}
+
+// httpQueryAPI serves the HTTP query API, which lets clients which cannot
+// hold MySQL connections, e.g. serverless functions, execute queries. Each
+// request is executed in its own autocommit session, so transactions are
+// not supported.
+type httpQueryAPI struct {
+	vtg *VTGate
+	// authServer verifies the credentials of the users. If it is nil,
+	// users are not authenticated.
+	authServer mysql.PlainTextStorage
+}
+
+func initHTTPQueryAPI(vtg *VTGate) {
+	if !enableHTTPQueryAPI || vtg == nil {
+		return
+	}
+	api := &httpQueryAPI{
+		vtg:        vtg,
+		authServer: plainTextAuthServer("--enable-http-query-api"),
+	}
+	servenv.HTTPHandle(httpQueryPath, api)
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (api *httpQueryAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		api.writeError(w, http.StatusMethodNotAllowed, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "method %s not allowed", r.Method))
+		return
+	}
+
+	ctx, err := api.authenticate(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="vtgate"`)
+		api.writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var req httpQueryRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, httpQueryAPIMaxBodySize))
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		api.writeError(w, http.StatusBadRequest, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid request: %v", err))
+		return
+	}
+
+	resp, err := api.execute(ctx, &req)
+	if err != nil {
+		api.writeError(w, httpStatusFromError(err), err)
+		return
+	}
+	api.writeResponse(w, http.StatusOK, resp)
+}
+
+// authenticate verifies the basic auth credentials of the request, and
+// returns the context of the request with the caller ids of the user.
+func (api *httpQueryAPI) authenticate(r *http.Request) (context.Context, error) {
+	user, password, ok := r.BasicAuth()
+	im := callerid.NewImmediateCallerID(user)
+	if api.authServer != nil {
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "basic authentication is required")
+		}
+		remoteAddr, _ := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+		userData, err := api.authServer.UserEntryWithPassword(nil, user, password, remoteAddr)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "access denied for user '%v'", user)
+		}
+		im = userData.Get()
+	}
+
+	// See vtgateHandler.ComQuery for how the caller ids are filled in.
+	ef := callerid.NewEffectiveCallerID(
+		user,         /* principal: who */
+		r.RemoteAddr, /* component: running client process */
+		"VTGate HTTP Query API" /* subcomponent: part of the client */)
+	return callerid.NewContext(r.Context(), ef, im), nil
+}
+
+func (api *httpQueryAPI) execute(ctx context.Context, req *httpQueryRequest) (*httpQueryResponse, error) {
+	if strings.TrimSpace(req.SQL) == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "sql is required")
+	}
+	if len(req.BindVariables) > 0 && len(req.Params) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "bind_variables and params cannot be used together")
+	}
+
+	bindVars := make(map[string]*querypb.BindVariable, len(req.BindVariables)+len(req.Params))
+	for name, value := range req.BindVariables {
+		bv, err := bindVariableFromJSON(value)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "bind variable %s: %v", name, err)
+		}
+		bindVars[name] = bv
+	}
+	// The parser names the ? placeholders v1, v2, etc.
+	for i, value := range req.Params {
+		bv, err := bindVariableFromJSON(value)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "param %d: %v", i+1, err)
+		}
+		bindVars["v"+strconv.Itoa(i+1)] = bv
+	}
+
+	u, _ := uuid.NewUUID()
+	session := &vtgatepb.Session{
+		TargetString: req.Target,
+		Autocommit:   true,
+		SessionUUID:  u.String(),
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+			Workload:       querypb.ExecuteOptions_OLTP,
+		},
+	}
+	if opts := req.Options; opts != nil {
+		if opts.Workload != "" {
+			workload, ok := querypb.ExecuteOptions_Workload_value[strings.ToUpper(opts.Workload)]
+			if !ok {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid workload: %s", opts.Workload)
+			}
+			session.Options.Workload = querypb.ExecuteOptions_Workload(workload)
+		}
+		if opts.Timeout != "" {
+			timeout, err := time.ParseDuration(opts.Timeout)
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid timeout: %v", err)
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	var (
+		qr  *sqltypes.Result
+		err error
+	)
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+		qr = &sqltypes.Result{}
+		session, err = api.vtg.StreamExecute(ctx, api, session, req.SQL, bindVars, func(chunk *sqltypes.Result) error {
+			if qr.Fields == nil {
+				qr.Fields = chunk.Fields
+			}
+			qr.Rows = append(qr.Rows, chunk.Rows...)
+			qr.RowsAffected += chunk.RowsAffected
+			return nil
+		})
+	} else {
+		session, qr, err = api.vtg.Execute(ctx, api, session, req.SQL, bindVars, len(req.Params) > 0)
+	}
+	if session != nil && (session.InTransaction || session.InReservedConn) {
+		// The session is not kept between requests, so anything it holds
+		// is released right away.
+		_ = api.vtg.CloseSession(context.Background(), session)
+		if err == nil {
+			err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "transactions and reserved connections are not supported by the HTTP query API")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &httpQueryResponse{
+		RowsAffected: qr.RowsAffected,
+		InsertID:     qr.InsertID,
+	}
+	for _, field := range qr.Fields {
+		resp.Fields = append(resp.Fields, httpQueryField{Name: field.Name, Type: field.Type.String()})
+	}
+	for _, row := range qr.Rows {
+		values := make([]json.RawMessage, len(row))
+		for i, value := range row {
+			values[i] = jsonFromValue(value)
+		}
+		resp.Rows = append(resp.Rows, values)
+	}
+	for _, warning := range session.GetWarnings() {
+		resp.Warnings = append(resp.Warnings, httpQueryWarning{Code: warning.Code, Message: warning.Message})
+	}
+	return resp, nil
+}
+
+// KillConnection is part of the vtgateservice.MySQLConnection interface.
+func (api *httpQueryAPI) KillConnection(ctx context.Context, connectionID uint32) error {
+	return vterrors.VT12001("KILL over the HTTP query API")
+}
+
+// KillQuery is part of the vtgateservice.MySQLConnection interface.
+func (api *httpQueryAPI) KillQuery(connectionID uint32) error {
+	return vterrors.VT12001("KILL over the HTTP query API")
+}
+
+func (api *httpQueryAPI) writeError(w http.ResponseWriter, status int, err error) {
+	qerr := &httpQueryError{
+		Code:    vterrors.Code(err).String(),
+		Message: err.Error(),
+	}
+	var sqlErr *sqlerror.SQLError
+	if errors.As(sqlerror.NewSQLErrorFromError(err), &sqlErr) && sqlErr.Number() != sqlerror.ERUnknownError {
+		qerr.Errno = int(sqlErr.Number())
+		qerr.SQLState = sqlErr.SQLState()
+	}
+	api.writeResponse(w, status, &httpQueryResponse{Error: qerr})
+}
+
+func (api *httpQueryAPI) writeResponse(w http.ResponseWriter, status int, resp *httpQueryResponse) {
+	httpQueryRequests.Add(strconv.Itoa(status), 1)
+	data, err := json.Marshal(resp)
+	if err != nil {
+		log.Errorf("cannot marshal HTTP query API response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// httpStatusFromError returns the HTTP status of the response for an error
+// returned by the execution of a query.
+func httpStatusFromError(err error) int {
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_INVALID_ARGUMENT, vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_OUT_OF_RANGE:
+		return http.StatusBadRequest
+	case vtrpcpb.Code_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case vtrpcpb.Code_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case vtrpcpb.Code_NOT_FOUND:
+		return http.StatusNotFound
+	case vtrpcpb.Code_ALREADY_EXISTS, vtrpcpb.Code_ABORTED:
+		return http.StatusConflict
+	case vtrpcpb.Code_RESOURCE_EXHAUSTED:
+		return http.StatusTooManyRequests
+	case vtrpcpb.Code_UNIMPLEMENTED:
+		return http.StatusNotImplemented
+	case vtrpcpb.Code_UNAVAILABLE:
+		return http.StatusServiceUnavailable
+	case vtrpcpb.Code_DEADLINE_EXCEEDED, vtrpcpb.Code_CANCELED:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// bindVariableFromJSON converts a value decoded with json.Decoder.UseNumber
+// into a bind variable. Integers are bound as INT64 (or UINT64 if they do
+// not fit), other numbers as FLOAT64, and arrays as tuples.
+func bindVariableFromJSON(value any) (*querypb.BindVariable, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			return sqltypes.Int64BindVariable(i), nil
+		}
+		if u, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return sqltypes.Uint64BindVariable(u), nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return sqltypes.Float64BindVariable(f), nil
+	case []any:
+		bv := &querypb.BindVariable{Type: querypb.Type_TUPLE}
+		for _, v := range value {
+			element, err := bindVariableFromJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			if element.Type == querypb.Type_TUPLE {
+				return nil, fmt.Errorf("nested arrays are not supported")
+			}
+			bv.Values = append(bv.Values, &querypb.Value{Type: element.Type, Value: element.Value})
+		}
+		return bv, nil
+	case map[string]any:
+		return nil, fmt.Errorf("objects are not supported")
+	default:
+		return sqltypes.BuildBindVariable(value)
+	}
+}
+
+// jsonFromValue returns the JSON encoding of a value of a result.
+func jsonFromValue(value sqltypes.Value) json.RawMessage {
+	typ := value.Type()
+	switch {
+	case value.IsNull():
+		return json.RawMessage("null")
+	case sqltypes.IsIntegral(typ) || sqltypes.IsFloat(typ), typ == sqltypes.TypeJSON:
+		if raw := value.Raw(); json.Valid(raw) {
+			return bytes.Clone(raw)
+		}
+	case typ == sqltypes.Blob || typ == sqltypes.Binary || typ == sqltypes.VarBinary ||
+		typ == sqltypes.Bit || typ == sqltypes.Geometry:
+		data, _ := json.Marshal(value.Raw())
+		return data
+	}
+	data, _ := json.Marshal(value.ToString())
+	return data
+}
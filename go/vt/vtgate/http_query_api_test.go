@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func serveHTTPQuery(t *testing.T, api *httpQueryAPI, body string, setup func(r *http.Request)) (int, *httpQueryResponse) {
+	r := httptest.NewRequest(http.MethodPost, httpQueryPath, strings.NewReader(body))
+	if setup != nil {
+		setup(r)
+	}
+	w := httptest.NewRecorder()
+	api.ServeHTTP(w, r)
+
+	var resp httpQueryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+	return w.Code, &resp
+}
+
+func TestHTTPQueryAPI(t *testing.T) {
+	vtg, sbc, _ := createVtgateEnv(t)
+	api := &httpQueryAPI{vtg: vtg}
+
+	sbc.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("id|name|price|doc|data", "int64|varchar|decimal|json|varbinary"),
+		`1|alice|1.50|{"a": 1}|ab`,
+		"2|null|null|null|null",
+	)})
+	code, resp := serveHTTPQuery(t, api, `{"sql": "select * from t1 where id = :id", "bind_variables": {"id": 1}, "target": "TestUnsharded@primary"}`, nil)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Equal(t, []httpQueryField{
+		{Name: "id", Type: "INT64"},
+		{Name: "name", Type: "VARCHAR"},
+		{Name: "price", Type: "DECIMAL"},
+		{Name: "doc", Type: "JSON"},
+		{Name: "data", Type: "VARBINARY"},
+	}, resp.Fields)
+	rows, err := json.Marshal(resp.Rows)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[[1, "alice", "1.50", {"a": 1}, "YWI="], [2, null, null, null, null]]`, string(rows))
+	assert.Equal(t, sqltypes.Int64BindVariable(1), sbc.Queries[0].BindVariables["id"])
+
+	// The ? placeholders are bound to params.
+	sbc.Queries = nil
+	code, resp = serveHTTPQuery(t, api, `{"sql": "select * from t1 where id = ? and name = ?", "params": [18446744073709551615, "bob"], "target": "TestUnsharded@primary"}`, nil)
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	require.Len(t, sbc.Queries, 1)
+	assert.Equal(t, sqltypes.Uint64BindVariable(18446744073709551615), sbc.Queries[0].BindVariables["v1"])
+	assert.Equal(t, sqltypes.StringBindVariable("bob"), sbc.Queries[0].BindVariables["v2"])
+}
+
+func TestHTTPQueryAPIErrors(t *testing.T) {
+	vtg, _, _ := createVtgateEnv(t)
+	api := &httpQueryAPI{vtg: vtg}
+
+	tests := []struct {
+		name string
+		body string
+		code int
+		err  string
+	}{{
+		name: "invalid json",
+		body: `{"sql": `,
+		code: http.StatusBadRequest,
+		err:  "invalid request",
+	}, {
+		name: "no sql",
+		body: `{}`,
+		code: http.StatusBadRequest,
+		err:  "sql is required",
+	}, {
+		name: "bind variables and params",
+		body: `{"sql": "select 1", "bind_variables": {"a": 1}, "params": [1]}`,
+		code: http.StatusBadRequest,
+		err:  "cannot be used together",
+	}, {
+		name: "object bind variable",
+		body: `{"sql": "select :a", "bind_variables": {"a": {}}}`,
+		code: http.StatusBadRequest,
+		err:  "objects are not supported",
+	}, {
+		name: "invalid workload",
+		body: `{"sql": "select 1", "options": {"workload": "batch"}}`,
+		code: http.StatusBadRequest,
+		err:  "invalid workload",
+	}, {
+		name: "syntax error",
+		body: `{"sql": "selec 1", "target": "TestUnsharded@replica"}`,
+		code: http.StatusBadRequest,
+		err:  "syntax error",
+	}, {
+		name: "transaction",
+		body: `{"sql": "begin", "target": "TestUnsharded@primary"}`,
+		code: http.StatusBadRequest,
+		err:  "transactions and reserved connections are not supported",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp := serveHTTPQuery(t, api, tt.body, nil)
+			assert.Equal(t, tt.code, code)
+			require.NotNil(t, resp.Error)
+			assert.Contains(t, resp.Error.Message, tt.err)
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodGet, httpQueryPath, nil)
+	w := httptest.NewRecorder()
+	api.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHTTPQueryAPIAuthentication(t *testing.T) {
+	vtg, _, _ := createVtgateEnv(t)
+	api := &httpQueryAPI{
+		vtg:        vtg,
+		authServer: mysql.NewAuthServerStatic("", `{"user1": [{"Password": "secret", "UserData": "vtuser1"}]}`, 0),
+	}
+	body := `{"sql": "select id from t1", "target": "TestUnsharded@primary", "options": {"workload": "olap"}}`
+
+	code, resp := serveHTTPQuery(t, api, body, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, "UNAUTHENTICATED", resp.Error.Code)
+
+	code, _ = serveHTTPQuery(t, api, body, func(r *http.Request) { r.SetBasicAuth("user1", "wrong") })
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, resp = serveHTTPQuery(t, api, body, func(r *http.Request) { r.SetBasicAuth("user1", "secret") })
+	require.Equal(t, http.StatusOK, code, resp.Error)
+	assert.Len(t, resp.Rows, 1)
+}
+
+func TestBindVariableFromJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want *querypb.BindVariable
+		err  string
+	}{
+		{json: `1`, want: sqltypes.Int64BindVariable(1)},
+		{json: `-1`, want: sqltypes.Int64BindVariable(-1)},
+		{json: `18446744073709551615`, want: sqltypes.Uint64BindVariable(18446744073709551615)},
+		{json: `1.5`, want: sqltypes.Float64BindVariable(1.5)},
+		{json: `"a"`, want: sqltypes.StringBindVariable("a")},
+		{json: `true`, want: sqltypes.Int8BindVariable(1)},
+		{json: `null`, want: sqltypes.NullBindVariable},
+		{json: `[1, "a"]`, want: &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{
+			{Type: querypb.Type_INT64, Value: []byte("1")},
+			{Type: querypb.Type_VARCHAR, Value: []byte("a")},
+		}}},
+		{json: `[[1]]`, err: "nested arrays are not supported"},
+		{json: `{"a": 1}`, err: "objects are not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			decoder := json.NewDecoder(strings.NewReader(tt.json))
+			decoder.UseNumber()
+			var value any
+			require.NoError(t, decoder.Decode(&value))
+
+			got, err := bindVariableFromJSON(value)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
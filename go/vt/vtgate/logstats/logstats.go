@@ -20,6 +20,8 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/safehtml"
@@ -57,6 +59,60 @@ type LogStats struct {
 	MirrorSourceExecuteTime time.Duration
 	MirrorTargetExecuteTime time.Duration
 	MirrorTargetError       error
+	// QueryAttributes are the query attributes sent by the client.
+	QueryAttributes map[string]string
+	// ConnectAttributes are the connection attributes sent by the client
+	// when connecting, like program_name.
+	ConnectAttributes map[string]string
+	// PeakMemory is the number of bytes of the rows the query buffered at
+	// VTGate, for its joins, sorts and aggregations.
+	PeakMemory int64
+
+	shardExecutionsMu sync.Mutex
+	shardExecutions   []ShardExecution
+}
+
+// ShardExecution is the execution of a query of a plan on a shard.
+type ShardExecution struct {
+	Operation  string
+	Keyspace   string
+	Shard      string
+	TabletType string
+	// Start is the time the query was sent to the shard.
+	Start time.Time
+	Time  time.Duration
+	// Rows is the number of rows returned by the shard.
+	Rows  uint64
+	Error error
+}
+
+type logStatsKey struct{}
+
+// NewContext returns a context which carries the log stats, so that the
+// executions on the shards are recorded in them.
+func NewContext(ctx context.Context, stats *LogStats) context.Context {
+	return context.WithValue(ctx, logStatsKey{}, stats)
+}
+
+// FromContext returns the log stats carried by the context, or nil.
+func FromContext(ctx context.Context) *LogStats {
+	stats, _ := ctx.Value(logStatsKey{}).(*LogStats)
+	return stats
+}
+
+// RecordShardExecution records the execution of a query on a shard. It is
+// safe to call concurrently.
+func (stats *LogStats) RecordShardExecution(execution ShardExecution) {
+	stats.shardExecutionsMu.Lock()
+	defer stats.shardExecutionsMu.Unlock()
+	stats.shardExecutions = append(stats.shardExecutions, execution)
+}
+
+// ShardExecutions returns a copy of the executions on the shards recorded so far.
+func (stats *LogStats) ShardExecutions() []ShardExecution {
+	stats.shardExecutionsMu.Lock()
+	defer stats.shardExecutionsMu.Unlock()
+	return slices.Clone(stats.shardExecutions)
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
@@ -196,6 +252,10 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	log.Duration(stats.MirrorTargetExecuteTime)
 	log.Key("MirrorTargetError")
 	log.String(stats.MirrorTargetErrorStr())
+	log.Key("QueryAttributes")
+	log.StringMap(stats.QueryAttributes)
+	log.Key("ConnectAttributes")
+	log.StringMap(stats.ConnectAttributes)
 
 	return log.Flush(w)
 }
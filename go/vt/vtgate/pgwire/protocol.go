@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Startup request codes, sent instead of a protocol version in the first
+// message of a connection.
+const (
+	protocolVersion3 = 3 << 16
+	sslRequestCode   = 80877103
+	gssEncRequest    = 80877104
+	cancelRequest    = 80877102
+)
+
+// Frontend message types.
+const (
+	msgQuery     = 'Q'
+	msgTerminate = 'X'
+	msgPassword  = 'p'
+	msgParse     = 'P'
+	msgBind      = 'B'
+	msgDescribe  = 'D'
+	msgExecute   = 'E'
+	msgClose     = 'C'
+	msgFlush     = 'H'
+	msgSync      = 'S'
+	msgFunction  = 'F'
+)
+
+// Backend message types.
+const (
+	msgAuthentication  = 'R'
+	msgParameterStatus = 'S'
+	msgBackendKeyData  = 'K'
+	msgReadyForQuery   = 'Z'
+	msgRowDescription  = 'T'
+	msgDataRow         = 'D'
+	msgCommandComplete = 'C'
+	msgEmptyQuery      = 'I'
+	msgErrorResponse   = 'E'
+)
+
+// Authentication request codes.
+const (
+	authOK                = 0
+	authCleartextPassword = 3
+)
+
+// Transaction status indicators of ReadyForQuery.
+const (
+	txIdle          = 'I'
+	txInTransaction = 'T'
+)
+
+// maxMessageSize is the maximum size of a frontend message.
+const maxMessageSize = 16 << 20
+
+// readStartupMessage reads a message without type byte, as sent by the
+// client when it connects.
+func readStartupMessage(r *bufio.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	return readMessageBody(r, binary.BigEndian.Uint32(header[:]))
+}
+
+// readMessage reads a typed frontend message.
+func readMessage(r *bufio.Reader) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	body, err := readMessageBody(r, binary.BigEndian.Uint32(header[1:]))
+	return header[0], body, err
+}
+
+func readMessageBody(r *bufio.Reader, length uint32) ([]byte, error) {
+	if length < 4 || length > maxMessageSize {
+		return nil, fmt.Errorf("invalid message length %d", length)
+	}
+	body := make([]byte, length-4)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// messageReader decodes the fields of a message body.
+type messageReader struct {
+	buf []byte
+	err error
+}
+
+func (r *messageReader) int32() int32 {
+	if len(r.buf) < 4 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	v := int32(binary.BigEndian.Uint32(r.buf))
+	r.buf = r.buf[4:]
+	return v
+}
+
+func (r *messageReader) string() string {
+	for i, b := range r.buf {
+		if b == 0 {
+			s := string(r.buf[:i])
+			r.buf = r.buf[i+1:]
+			return s
+		}
+	}
+	r.err = io.ErrUnexpectedEOF
+	return ""
+}
+
+// messageWriter encodes a backend message.
+type messageWriter struct {
+	buf []byte
+}
+
+func (w *messageWriter) start(typ byte) {
+	w.buf = append(w.buf[:0], typ, 0, 0, 0, 0)
+}
+
+func (w *messageWriter) byte(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+func (w *messageWriter) int16(v int16) {
+	w.buf = binary.BigEndian.AppendUint16(w.buf, uint16(v))
+}
+
+func (w *messageWriter) int32(v int32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(v))
+}
+
+func (w *messageWriter) string(s string) {
+	w.buf = append(w.buf, s...)
+	w.buf = append(w.buf, 0)
+}
+
+// finish fills in the length of the message and returns it.
+func (w *messageWriter) finish() []byte {
+	binary.BigEndian.PutUint32(w.buf[1:], uint32(len(w.buf)-1))
+	return w.buf
+}
@@ -0,0 +1,628 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pgwire implements an experimental server for the PostgreSQL wire
+// protocol, which lets clients that only speak PostgreSQL run read-only
+// queries against Vitess.
+//
+// Only the simple query protocol is supported. Queries are translated into
+// the MySQL dialect by Translate, and statements which only affect the
+// PostgreSQL session, such as SET, BEGIN or SHOW server_version, are
+// answered by the server itself. TLS is not supported.
+package pgwire
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// ServerVersion is the PostgreSQL version reported to clients.
+const ServerVersion = "14.0"
+
+// SQLSTATE codes of the errors raised by the server itself.
+const (
+	codeProtocolViolation   = "08P01"
+	codeFeatureNotSupported = "0A000"
+	codeInvalidPassword     = "28P01"
+	codeInternalError       = "XX000"
+)
+
+// Handler executes the queries received by a Listener.
+type Handler interface {
+	// NewConnection is called when a client connects, after it has been
+	// authenticated.
+	NewConnection(c *Conn)
+
+	// ConnectionClosed is called when a connection is closed.
+	ConnectionClosed(c *Conn)
+
+	// ComQuery executes a single statement, translated into the MySQL dialect.
+	// The result can be sent through the callback in several chunks; only
+	// the fields of the first chunk are used. ctx is canceled if the client
+	// cancels the query.
+	ComQuery(ctx context.Context, c *Conn, query string, callback func(*sqltypes.Result) error) error
+}
+
+// Listener accepts PostgreSQL connections.
+type Listener struct {
+	listener net.Listener
+	handler  Handler
+	// authServer verifies the clear text password of the users. If it is
+	// nil, clients are not authenticated.
+	authServer mysql.PlainTextStorage
+
+	connectionID atomic.Uint32
+
+	mu    sync.Mutex
+	conns map[uint32]*Conn
+}
+
+// NewListener creates a Listener on the given address. If authServer is not
+// nil, clients have to authenticate with a clear text password verified by it.
+func NewListener(protocol, address string, authServer mysql.PlainTextStorage, handler Handler) (*Listener, error) {
+	l, err := net.Listen(protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	return &Listener{
+		listener:   l,
+		handler:    handler,
+		authServer: authServer,
+		conns:      make(map[uint32]*Conn),
+	}, nil
+}
+
+// Addr returns the address the listener is listening on.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Accept accepts connections until the listener is closed.
+func (l *Listener) Accept() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Errorf("pgwire: accept failed: %v", err)
+			continue
+		}
+		go l.handle(conn)
+	}
+}
+
+// Close stops accepting connections, and closes the open ones.
+func (l *Listener) Close() {
+	l.listener.Close()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range l.conns {
+		c.conn.Close()
+	}
+}
+
+// Conn is a PostgreSQL client connection.
+type Conn struct {
+	// ConnectionID is the process id reported to the client.
+	ConnectionID uint32
+	// User is the user name sent by the client.
+	User string
+	// Database is the database name sent by the client.
+	Database string
+	// UserData is the user data returned by the auth server.
+	UserData mysql.Getter
+	// ClientData can be used by the Handler to keep state.
+	ClientData any
+
+	listener  *Listener
+	conn      net.Conn
+	reader    *bufio.Reader
+	writer    *bufio.Writer
+	msg       messageWriter
+	secretKey int32
+
+	inTransaction bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// RemoteAddr returns the address of the client.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close closes the connection.
+func (c *Conn) Close() {
+	c.conn.Close()
+}
+
+// CancelQuery cancels the query being executed on the connection, if any.
+func (c *Conn) CancelQuery() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+func (l *Listener) handle(conn net.Conn) {
+	c := &Conn{
+		listener: l,
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		writer:   bufio.NewWriter(conn),
+	}
+	defer conn.Close()
+
+	ok, err := c.startup()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			log.Warningf("pgwire: startup of connection from %v failed: %v", conn.RemoteAddr(), err)
+		}
+		return
+	}
+	if !ok {
+		return
+	}
+
+	c.ConnectionID = l.connectionID.Add(1)
+	var secret [4]byte
+	_, _ = rand.Read(secret[:])
+	c.secretKey = int32(binary.BigEndian.Uint32(secret[:]))
+
+	l.mu.Lock()
+	l.conns[c.ConnectionID] = c
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		delete(l.conns, c.ConnectionID)
+		l.mu.Unlock()
+	}()
+
+	l.handler.NewConnection(c)
+	defer l.handler.ConnectionClosed(c)
+
+	if err := c.sendStartupResponse(); err != nil {
+		return
+	}
+	if err := c.serve(); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+		log.Warningf("pgwire: connection %d from %v failed: %v", c.ConnectionID, conn.RemoteAddr(), err)
+	}
+}
+
+// startup reads the startup message and authenticates the client. It
+// returns false if the connection has to be closed without error, e.g.
+// after a cancel request.
+func (c *Conn) startup() (bool, error) {
+	for {
+		body, err := readStartupMessage(c.reader)
+		if err != nil {
+			return false, err
+		}
+		r := &messageReader{buf: body}
+		code := r.int32()
+		switch code {
+		case sslRequestCode, gssEncRequest:
+			// Encryption is not supported, the client may continue in clear text.
+			if _, err := c.conn.Write([]byte{'N'}); err != nil {
+				return false, err
+			}
+			continue
+		case cancelRequest:
+			c.listener.cancel(r.int32(), r.int32())
+			return false, nil
+		case protocolVersion3:
+		default:
+			return false, c.sendFatal(codeFeatureNotSupported, fmt.Sprintf("unsupported frontend protocol %d.%d", code>>16, code&0xffff))
+		}
+
+		params := make(map[string]string)
+		for r.err == nil && len(r.buf) > 1 {
+			name := r.string()
+			params[name] = r.string()
+		}
+		if r.err != nil {
+			return false, c.sendFatal(codeProtocolViolation, "invalid startup packet layout")
+		}
+		c.User = params["user"]
+		c.Database = params["database"]
+		if c.User == "" {
+			return false, c.sendFatal(codeProtocolViolation, "no PostgreSQL user name specified in startup packet")
+		}
+		return c.authenticate()
+	}
+}
+
+func (c *Conn) authenticate() (bool, error) {
+	if c.listener.authServer == nil {
+		return true, nil
+	}
+
+	c.msg.start(msgAuthentication)
+	c.msg.int32(authCleartextPassword)
+	if err := c.writeMessage(); err != nil {
+		return false, err
+	}
+	if err := c.writer.Flush(); err != nil {
+		return false, err
+	}
+
+	typ, body, err := readMessage(c.reader)
+	if err != nil {
+		return false, err
+	}
+	if typ != msgPassword {
+		return false, c.sendFatal(codeProtocolViolation, fmt.Sprintf("expected password response, got message type %q", typ))
+	}
+	r := &messageReader{buf: body}
+	password := r.string()
+	userData, err := c.listener.authServer.UserEntryWithPassword(nil, c.User, password, c.conn.RemoteAddr())
+	if err != nil {
+		return false, c.sendFatal(codeInvalidPassword, fmt.Sprintf("password authentication failed for user %q", c.User))
+	}
+	c.UserData = userData
+	return true, nil
+}
+
+// cancel cancels the query running on the connection with the given
+// process id, if the secret key matches.
+func (l *Listener) cancel(processID, secretKey int32) {
+	l.mu.Lock()
+	c, ok := l.conns[uint32(processID)]
+	l.mu.Unlock()
+	if ok && c.secretKey == secretKey {
+		c.CancelQuery()
+	}
+}
+
+func (c *Conn) sendStartupResponse() error {
+	c.msg.start(msgAuthentication)
+	c.msg.int32(authOK)
+	if err := c.writeMessage(); err != nil {
+		return err
+	}
+	for _, param := range [][2]string{
+		{"server_version", ServerVersion},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO, MDY"},
+		{"TimeZone", "UTC"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+	} {
+		c.msg.start(msgParameterStatus)
+		c.msg.string(param[0])
+		c.msg.string(param[1])
+		if err := c.writeMessage(); err != nil {
+			return err
+		}
+	}
+	c.msg.start(msgBackendKeyData)
+	c.msg.int32(int32(c.ConnectionID))
+	c.msg.int32(c.secretKey)
+	if err := c.writeMessage(); err != nil {
+		return err
+	}
+	return c.sendReadyForQuery()
+}
+
+// serve processes the messages of the client until it disconnects.
+func (c *Conn) serve() error {
+	// skipUntilSync is set once an error is raised for a message of the
+	// extended query protocol: the following messages are ignored until
+	// the next Sync, as required by the protocol.
+	skipUntilSync := false
+	for {
+		typ, body, err := readMessage(c.reader)
+		if err != nil {
+			return err
+		}
+
+		switch typ {
+		case msgQuery:
+			r := &messageReader{buf: body}
+			query := r.string()
+			if r.err != nil {
+				return c.sendFatal(codeProtocolViolation, "invalid query message")
+			}
+			if err := c.execQuery(query); err != nil {
+				return err
+			}
+			if err := c.sendReadyForQuery(); err != nil {
+				return err
+			}
+		case msgTerminate:
+			return nil
+		case msgSync:
+			skipUntilSync = false
+			if err := c.sendReadyForQuery(); err != nil {
+				return err
+			}
+		case msgFlush:
+			if err := c.writer.Flush(); err != nil {
+				return err
+			}
+		case msgParse, msgBind, msgDescribe, msgExecute, msgClose, msgFunction:
+			if skipUntilSync {
+				continue
+			}
+			skipUntilSync = true
+			if err := c.sendError(codeFeatureNotSupported, "the extended query protocol is not supported"); err != nil {
+				return err
+			}
+			if err := c.writer.Flush(); err != nil {
+				return err
+			}
+		default:
+			return c.sendFatal(codeProtocolViolation, fmt.Sprintf("invalid frontend message type %q", typ))
+		}
+	}
+}
+
+// execQuery executes all the statements of a simple query message. An error
+// is only returned if the connection is broken; query errors are sent to
+// the client.
+func (c *Conn) execQuery(query string) error {
+	stmts, err := Translate(query)
+	if err != nil {
+		return c.sendError(codeProtocolViolation, err.Error())
+	}
+	if len(stmts) == 0 {
+		c.msg.start(msgEmptyQuery)
+		return c.writeMessage()
+	}
+
+	for _, stmt := range stmts {
+		handled, err := c.execLocal(stmt)
+		if err != nil {
+			return err
+		}
+		if handled {
+			continue
+		}
+
+		failed, err := c.execStatement(stmt)
+		if err != nil {
+			return err
+		}
+		if failed {
+			// The statements following an error are not executed.
+			return nil
+		}
+	}
+	return nil
+}
+
+// execStatement executes a statement through the Handler. It returns true
+// if an error was sent to the client, and an error only if the connection
+// is broken.
+func (c *Conn) execStatement(stmt string) (bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.cancel = nil
+		c.mu.Unlock()
+		cancel()
+	}()
+
+	var (
+		writeErr   error
+		sentFields bool
+		rows       int
+	)
+	err := c.listener.handler.ComQuery(ctx, c, stmt, func(qr *sqltypes.Result) error {
+		if !sentFields && qr.Fields != nil {
+			sentFields = true
+			if writeErr = c.sendRowDescription(qr); writeErr != nil {
+				return writeErr
+			}
+		}
+		for _, row := range qr.Rows {
+			if writeErr = c.sendDataRow(row); writeErr != nil {
+				return writeErr
+			}
+		}
+		rows += len(qr.Rows)
+		return nil
+	})
+	if writeErr != nil {
+		return false, writeErr
+	}
+	if err != nil {
+		return true, c.sendQueryError(err)
+	}
+
+	c.msg.start(msgCommandComplete)
+	if sentFields {
+		c.msg.string(fmt.Sprintf("SELECT %d", rows))
+	} else {
+		c.msg.string(strings.ToUpper(strings.Fields(stmt)[0]))
+	}
+	return false, c.writeMessage()
+}
+
+// execLocal answers the statements which only affect the PostgreSQL session,
+// and are hence not sent to the Handler. It returns false if the statement
+// has to be sent to the Handler.
+func (c *Conn) execLocal(stmt string) (bool, error) {
+	words := strings.Fields(strings.ToLower(strings.TrimRight(stmt, " \t\r\n")))
+	if len(words) == 0 {
+		return false, nil
+	}
+
+	tag := ""
+	switch words[0] {
+	case "set", "reset", "discard", "unlisten", "deallocate":
+		// Session parameters are ignored.
+		tag = strings.ToUpper(words[0])
+		if words[0] == "discard" && len(words) > 1 {
+			tag = "DISCARD " + strings.ToUpper(words[1])
+		}
+	case "begin", "start":
+		// Transactions are accepted so that clients which wrap their reads
+		// in one work, but have no effect since only reads are executed.
+		c.inTransaction = true
+		tag = "BEGIN"
+	case "commit", "end":
+		c.inTransaction = false
+		tag = "COMMIT"
+	case "rollback", "abort":
+		c.inTransaction = false
+		tag = "ROLLBACK"
+	case "show":
+		name := strings.Join(words[1:], " ")
+		value, ok := sessionParameters[name]
+		if !ok {
+			return false, nil
+		}
+		if err := c.sendRowDescription(&sqltypes.Result{Fields: sqltypes.MakeTestFields(strings.ReplaceAll(name, " ", "_"), "varchar")}); err != nil {
+			return true, err
+		}
+		if err := c.sendDataRow([]sqltypes.Value{sqltypes.NewVarChar(value)}); err != nil {
+			return true, err
+		}
+		tag = "SHOW"
+	default:
+		return false, nil
+	}
+
+	c.msg.start(msgCommandComplete)
+	c.msg.string(tag)
+	return true, c.writeMessage()
+}
+
+// sessionParameters are the values of the PostgreSQL session parameters
+// which can be read with SHOW.
+var sessionParameters = map[string]string{
+	"server_version":                ServerVersion,
+	"server_encoding":               "UTF8",
+	"client_encoding":               "UTF8",
+	"datestyle":                     "ISO, MDY",
+	"timezone":                      "UTC",
+	"integer_datetimes":             "on",
+	"standard_conforming_strings":   "on",
+	"search_path":                   `"$user", public`,
+	"transaction_isolation":         "repeatable read",
+	"transaction isolation level":   "repeatable read",
+	"default_transaction_read_only": "on",
+	"transaction_read_only":         "on",
+}
+
+func (c *Conn) sendRowDescription(qr *sqltypes.Result) error {
+	c.msg.start(msgRowDescription)
+	c.msg.int16(int16(len(qr.Fields)))
+	for _, field := range qr.Fields {
+		typ := typeOf(field.Type)
+		c.msg.string(field.Name)
+		c.msg.int32(0) // table oid
+		c.msg.int16(0) // column attribute number
+		c.msg.int32(typ.oid)
+		c.msg.int16(typ.size)
+		c.msg.int32(-1) // type modifier
+		c.msg.int16(0)  // text format
+	}
+	return c.writeMessage()
+}
+
+func (c *Conn) sendDataRow(row []sqltypes.Value) error {
+	c.msg.start(msgDataRow)
+	c.msg.int16(int16(len(row)))
+	for _, value := range row {
+		if value.IsNull() {
+			c.msg.int32(-1)
+			continue
+		}
+		lengthPos := len(c.msg.buf)
+		c.msg.int32(0)
+		c.msg.buf = appendValue(c.msg.buf, value)
+		binary.BigEndian.PutUint32(c.msg.buf[lengthPos:], uint32(len(c.msg.buf)-lengthPos-4))
+	}
+	return c.writeMessage()
+}
+
+func (c *Conn) sendReadyForQuery() error {
+	c.msg.start(msgReadyForQuery)
+	if c.inTransaction {
+		c.msg.byte(txInTransaction)
+	} else {
+		c.msg.byte(txIdle)
+	}
+	if err := c.writeMessage(); err != nil {
+		return err
+	}
+	return c.writer.Flush()
+}
+
+// sendQueryError sends an error returned by the Handler, with the SQLSTATE
+// of the corresponding MySQL error.
+func (c *Conn) sendQueryError(err error) error {
+	code := codeInternalError
+	var sqlErr *sqlerror.SQLError
+	if errors.As(sqlerror.NewSQLErrorFromError(err), &sqlErr) && sqlErr.SQLState() != sqlerror.SSUnknownSQLState {
+		code = sqlErr.SQLState()
+	}
+	return c.sendError(code, err.Error())
+}
+
+func (c *Conn) sendError(code, message string) error {
+	return c.sendErrorWithSeverity("ERROR", code, message)
+}
+
+// sendFatal sends an error after which the connection is closed.
+func (c *Conn) sendFatal(code, message string) error {
+	if err := c.sendErrorWithSeverity("FATAL", code, message); err != nil {
+		return err
+	}
+	return c.writer.Flush()
+}
+
+func (c *Conn) sendErrorWithSeverity(severity, code, message string) error {
+	c.msg.start(msgErrorResponse)
+	c.msg.byte('S')
+	c.msg.string(severity)
+	c.msg.byte('V')
+	c.msg.string(severity)
+	c.msg.byte('C')
+	c.msg.string(code)
+	c.msg.byte('M')
+	c.msg.string(message)
+	c.msg.byte(0)
+	return c.writeMessage()
+}
+
+func (c *Conn) writeMessage() error {
+	_, err := c.writer.Write(c.msg.finish())
+	return err
+}
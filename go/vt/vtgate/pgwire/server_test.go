@@ -0,0 +1,327 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+type testHandler struct {
+	mu      sync.Mutex
+	queries []string
+	closed  int
+}
+
+func (th *testHandler) NewConnection(c *Conn) {}
+
+func (th *testHandler) ConnectionClosed(c *Conn) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.closed++
+}
+
+func (th *testHandler) ComQuery(ctx context.Context, c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	th.mu.Lock()
+	th.queries = append(th.queries, query)
+	th.mu.Unlock()
+
+	switch query {
+	case "select missing":
+		return sqlerror.NewSQLErrorf(sqlerror.ERNoSuchTable, sqlerror.SSUnknownTable, "table missing not found")
+	case "use ks":
+		return nil
+	}
+	return callback(sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name|data", "int64|varchar|varbinary"),
+		"1|alice|ab",
+		"2|null|null",
+	))
+}
+
+func (th *testHandler) Queries() []string {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	return append([]string(nil), th.queries...)
+}
+
+// message is a backend message received by testClient.
+type message struct {
+	typ  byte
+	body []byte
+}
+
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newTestListener(t *testing.T, authServer mysql.PlainTextStorage) (*Listener, *testHandler) {
+	handler := &testHandler{}
+	l, err := NewListener("tcp", "127.0.0.1:0", authServer, handler)
+	require.NoError(t, err)
+	go l.Accept()
+	t.Cleanup(l.Close)
+	return l, handler
+}
+
+func dial(t *testing.T, l *Listener) *testClient {
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &testClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (tc *testClient) send(typ byte, body []byte) {
+	var buf []byte
+	if typ != 0 {
+		buf = append(buf, typ)
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(body)+4))
+	buf = append(buf, body...)
+	_, err := tc.conn.Write(buf)
+	require.NoError(tc.t, err)
+}
+
+func (tc *testClient) receive() message {
+	typ, body, err := readMessage(tc.r)
+	require.NoError(tc.t, err)
+	return message{typ: typ, body: body}
+}
+
+// receiveUntilReady returns the messages received up to ReadyForQuery, or
+// up to a fatal error.
+func (tc *testClient) receiveUntilReady() []message {
+	var msgs []message
+	for {
+		msg := tc.receive()
+		msgs = append(msgs, msg)
+		if msg.typ == msgReadyForQuery || msg.typ == msgErrorResponse && errorField(msg, 'S') == "FATAL" {
+			return msgs
+		}
+		if msg.typ == msgAuthentication && binary.BigEndian.Uint32(msg.body) == authCleartextPassword {
+			return msgs
+		}
+	}
+}
+
+func (tc *testClient) startup(params ...string) []message {
+	var w messageWriter
+	w.buf = binary.BigEndian.AppendUint32(nil, protocolVersion3)
+	for _, p := range params {
+		w.string(p)
+	}
+	w.byte(0)
+	tc.send(0, w.buf)
+	return tc.receiveUntilReady()
+}
+
+func (tc *testClient) query(query string) []message {
+	var w messageWriter
+	w.string(query)
+	tc.send(msgQuery, w.buf)
+	return tc.receiveUntilReady()
+}
+
+func types(msgs []message) string {
+	var s []byte
+	for _, msg := range msgs {
+		s = append(s, msg.typ)
+	}
+	return string(s)
+}
+
+func errorField(msg message, field byte) string {
+	r := &messageReader{buf: msg.body}
+	for len(r.buf) > 1 {
+		f := r.buf[0]
+		r.buf = r.buf[1:]
+		value := r.string()
+		if f == field {
+			return value
+		}
+	}
+	return ""
+}
+
+func dataRow(msg message) []*string {
+	r := &messageReader{buf: msg.body}
+	n := int(binary.BigEndian.Uint16(r.buf))
+	r.buf = r.buf[2:]
+	row := make([]*string, n)
+	for i := range row {
+		length := r.int32()
+		if length < 0 {
+			continue
+		}
+		value := string(r.buf[:length])
+		row[i] = &value
+		r.buf = r.buf[length:]
+	}
+	return row
+}
+
+func TestServerQuery(t *testing.T) {
+	l, handler := newTestListener(t, nil)
+	client := dial(t, l)
+
+	msgs := client.startup("user", "user1", "database", "ks")
+	// AuthenticationOk, the parameters, BackendKeyData and ReadyForQuery.
+	assert.Equal(t, "RSSSSSSSKZ", types(msgs))
+
+	msgs = client.query(`select * from "Users"`)
+	require.Equal(t, "TDDCZ", types(msgs))
+	r := &messageReader{buf: msgs[0].body[2:]}
+	assert.Equal(t, "id", r.string())
+	r.int32()
+	r.buf = r.buf[2:]
+	assert.EqualValues(t, oidInt8, r.int32())
+	alice, bob := dataRow(msgs[1]), dataRow(msgs[2])
+	assert.Equal(t, "1", *alice[0])
+	assert.Equal(t, "alice", *alice[1])
+	assert.Equal(t, `\x6162`, *alice[2])
+	assert.Nil(t, bob[1])
+	assert.Nil(t, bob[2])
+	assert.Equal(t, "SELECT 2\x00", string(msgs[3].body))
+	assert.Equal(t, []byte{txIdle}, msgs[4].body)
+	assert.Equal(t, []string{"select * from `Users`"}, handler.Queries())
+
+	msgs = client.query("use ks")
+	require.Equal(t, "CZ", types(msgs))
+	assert.Equal(t, "USE\x00", string(msgs[0].body))
+
+	msgs = client.query("")
+	assert.Equal(t, "IZ", types(msgs))
+}
+
+func TestServerLocalStatements(t *testing.T) {
+	l, handler := newTestListener(t, nil)
+	client := dial(t, l)
+	client.startup("user", "user1")
+
+	msgs := client.query("begin; set extra_float_digits = 3; show server_version")
+	require.Equal(t, "CCTDCZ", types(msgs))
+	assert.Equal(t, "BEGIN\x00", string(msgs[0].body))
+	assert.Equal(t, "SET\x00", string(msgs[1].body))
+	assert.Equal(t, ServerVersion, *dataRow(msgs[3])[0])
+	assert.Equal(t, []byte{txInTransaction}, msgs[5].body)
+
+	msgs = client.query("commit")
+	require.Equal(t, "CZ", types(msgs))
+	assert.Equal(t, []byte{txIdle}, msgs[1].body)
+	assert.Empty(t, handler.Queries())
+}
+
+func TestServerErrors(t *testing.T) {
+	l, handler := newTestListener(t, nil)
+	client := dial(t, l)
+	client.startup("user", "user1")
+
+	// The statements following an error are not executed.
+	msgs := client.query("select missing; select 1")
+	require.Equal(t, "EZ", types(msgs))
+	assert.Equal(t, "ERROR", errorField(msgs[0], 'S'))
+	assert.Equal(t, sqlerror.SSUnknownTable, errorField(msgs[0], 'C'))
+	assert.Contains(t, errorField(msgs[0], 'M'), "table missing not found")
+	assert.Equal(t, []string{"select missing"}, handler.Queries())
+
+	msgs = client.query("select 'unterminated")
+	require.Equal(t, "EZ", types(msgs))
+	assert.Equal(t, codeProtocolViolation, errorField(msgs[0], 'C'))
+
+	// The extended query protocol is rejected, and the following messages
+	// are ignored until Sync.
+	client.send(msgParse, []byte("\x00select 1\x00\x00\x00"))
+	client.send(msgBind, []byte("\x00\x00\x00\x00\x00\x00\x00\x00"))
+	client.send(msgSync, nil)
+	msgs = client.receiveUntilReady()
+	require.Equal(t, "EZ", types(msgs))
+	assert.Equal(t, codeFeatureNotSupported, errorField(msgs[0], 'C'))
+
+	// The connection is still usable.
+	msgs = client.query("select 1")
+	assert.Equal(t, "TDDCZ", types(msgs))
+}
+
+func TestServerStartupErrors(t *testing.T) {
+	l, handler := newTestListener(t, nil)
+
+	client := dial(t, l)
+	msgs := client.startup("database", "ks")
+	require.Equal(t, "E", types(msgs))
+	assert.Equal(t, codeProtocolViolation, errorField(msgs[0], 'C'))
+
+	// SSL is declined, and the client can continue in clear text.
+	client = dial(t, l)
+	client.send(0, binary.BigEndian.AppendUint32(nil, sslRequestCode))
+	b, err := client.r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('N'), b)
+	msgs = client.startup("user", "user1")
+	assert.Equal(t, byte(msgReadyForQuery), msgs[len(msgs)-1].typ)
+
+	client.send(msgTerminate, nil)
+	_, err = client.r.ReadByte()
+	require.Error(t, err)
+	assert.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return handler.closed == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	authServer := mysql.NewAuthServerStatic("", `{"user1": [{"Password": "secret"}]}`, 0)
+	l, _ := newTestListener(t, authServer)
+
+	for _, tt := range []struct {
+		user, password string
+		ok             bool
+	}{
+		{user: "user1", password: "secret", ok: true},
+		{user: "user1", password: "wrong"},
+		{user: "user2", password: "secret"},
+	} {
+		client := dial(t, l)
+		msgs := client.startup("user", tt.user)
+		require.Equal(t, "R", types(msgs))
+
+		var w messageWriter
+		w.string(tt.password)
+		client.send(msgPassword, w.buf)
+		msgs = client.receiveUntilReady()
+		if tt.ok {
+			assert.Equal(t, byte(msgReadyForQuery), msgs[len(msgs)-1].typ)
+			continue
+		}
+		require.Equal(t, "E", types(msgs))
+		assert.Equal(t, "FATAL", errorField(msgs[0], 'S'))
+		assert.Equal(t, codeInvalidPassword, errorField(msgs[0], 'C'))
+	}
+}
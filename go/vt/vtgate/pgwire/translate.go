@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Translate splits a PostgreSQL query string into its statements, and
+// rewrites each of them into the MySQL dialect understood by Vitess. Only
+// the lexical differences between the two dialects are translated:
+//
+//   - "quoted" identifiers become `quoted` identifiers;
+//   - backslashes are literal in 'standard' strings, and escapes in E'extended'
+//     strings, as with standard_conforming_strings=on;
+//   - statements are separated by semicolons, and empty statements are dropped.
+//
+// Everything else, including the syntax which is common to both dialects,
+// is passed through unchanged, and will fail to parse in Vitess if it is
+// PostgreSQL specific, e.g. dollar quoted strings or :: casts.
+func Translate(query string) ([]string, error) {
+	var (
+		stmts []string
+		stmt  strings.Builder
+		empty = true
+	)
+	flush := func() {
+		if !empty {
+			stmts = append(stmts, strings.TrimSpace(stmt.String()))
+		}
+		stmt.Reset()
+		empty = true
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ';':
+			flush()
+			continue
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			// MySQL requires a space after --, so line comments are dropped.
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			stmt.WriteByte(' ')
+			i += end - 1
+			continue
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			stmt.WriteString(query[i : i+2+end+2])
+			i += 2 + end + 1
+			continue
+		case c == '"':
+			end, ident, err := scanQuoted(query, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			stmt.WriteByte('`')
+			stmt.WriteString(strings.ReplaceAll(ident, "`", "``"))
+			stmt.WriteByte('`')
+			i = end
+		case c == '\'':
+			end, str, err := scanQuoted(query, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			writeString(&stmt, strings.ReplaceAll(str, `\`, `\\`))
+			i = end
+		case (c == 'E' || c == 'e') && i+1 < len(query) && query[i+1] == '\'' && (i == 0 || !isIdentChar(query[i-1])):
+			end, err := scanEscapeString(query, i+1)
+			if err != nil {
+				return nil, err
+			}
+			stmt.WriteString(query[i+1 : end+1])
+			i = end
+		default:
+			stmt.WriteByte(c)
+			if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				empty = false
+			}
+			continue
+		}
+		empty = false
+	}
+	flush()
+	return stmts, nil
+}
+
+// scanQuoted scans the quoted token starting at query[start], in which the
+// quote is escaped by doubling it. It returns the index of the closing quote
+// and the unescaped content of the token.
+func scanQuoted(query string, start int, quote byte) (int, string, error) {
+	var content strings.Builder
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			content.WriteByte(query[i])
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			content.WriteByte(quote)
+			i++
+			continue
+		}
+		return i, content.String(), nil
+	}
+	return 0, "", fmt.Errorf("unterminated quoted string")
+}
+
+// scanEscapeString scans the escape string whose opening quote is at
+// query[start], and returns the index of its closing quote.
+func scanEscapeString(query string, start int) (int, error) {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 < len(query) && query[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted string")
+}
+
+func writeString(b *strings.Builder, s string) {
+	b.WriteByte('\'')
+	b.WriteString(strings.ReplaceAll(s, "'", "''"))
+	b.WriteByte('\'')
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
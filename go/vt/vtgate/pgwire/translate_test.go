@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+		err   string
+	}{{
+		query: "select 1",
+		want:  []string{"select 1"},
+	}, {
+		query: "select 1; select 2;",
+		want:  []string{"select 1", "select 2"},
+	}, {
+		query: " ; ;",
+	}, {
+		query: `select "Id", "a""b" from "t"`,
+		want:  []string{"select `Id`, `a\"b` from `t`"},
+	}, {
+		query: `select "a` + "`" + `b"`,
+		want:  []string{"select `a``b`"},
+	}, {
+		query: `select 'it''s', 'C:\dir', ';'`,
+		want:  []string{`select 'it''s', 'C:\\dir', ';'`},
+	}, {
+		query: `select E'a\'b\\c', e'\n'`,
+		want:  []string{`select 'a\'b\\c', '\n'`},
+	}, {
+		query: "select type'x' from e",
+		want:  []string{"select type'x' from e"},
+	}, {
+		query: "select 1 -- comment; not a statement\n; select 2",
+		want:  []string{"select 1", "select 2"},
+	}, {
+		query: "-- only a comment",
+	}, {
+		query: "select /* ; */ 1",
+		want:  []string{"select /* ; */ 1"},
+	}, {
+		query: "select 'abc",
+		err:   "unterminated quoted string",
+	}, {
+		query: `select "abc`,
+		err:   "unterminated quoted string",
+	}, {
+		query: `select E'abc\'`,
+		err:   "unterminated quoted string",
+	}, {
+		query: "select /* 1",
+		err:   "unterminated comment",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := Translate(tt.query)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
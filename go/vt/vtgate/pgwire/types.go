@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"encoding/hex"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// PostgreSQL type OIDs, from pg_type.
+const (
+	oidBytea     = 17
+	oidInt8      = 20
+	oidInt2      = 21
+	oidInt4      = 23
+	oidText      = 25
+	oidJSON      = 114
+	oidFloat4    = 700
+	oidFloat8    = 701
+	oidDate      = 1082
+	oidTime      = 1083
+	oidTimestamp = 1114
+	oidNumeric   = 1700
+)
+
+// pgType is the PostgreSQL type a Vitess type is exposed as.
+type pgType struct {
+	oid int32
+	// size is the size of the type, or -1 for variable length types.
+	size int16
+}
+
+// typeOf returns the PostgreSQL type of a Vitess type. Unsigned types are
+// exposed as the next larger signed type, since PostgreSQL has none.
+func typeOf(typ querypb.Type) pgType {
+	switch typ {
+	case sqltypes.Int8, sqltypes.Uint8, sqltypes.Int16:
+		return pgType{oidInt2, 2}
+	case sqltypes.Uint16, sqltypes.Int24, sqltypes.Uint24, sqltypes.Int32, sqltypes.Year:
+		return pgType{oidInt4, 4}
+	case sqltypes.Uint32, sqltypes.Int64:
+		return pgType{oidInt8, 8}
+	case sqltypes.Uint64, sqltypes.Decimal:
+		return pgType{oidNumeric, -1}
+	case sqltypes.Float32:
+		return pgType{oidFloat4, 4}
+	case sqltypes.Float64:
+		return pgType{oidFloat8, 8}
+	case sqltypes.Date:
+		return pgType{oidDate, 4}
+	case sqltypes.Time:
+		return pgType{oidTime, 8}
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		return pgType{oidTimestamp, 8}
+	case sqltypes.TypeJSON:
+		return pgType{oidJSON, -1}
+	case sqltypes.Blob, sqltypes.Binary, sqltypes.VarBinary, sqltypes.Bit, sqltypes.Geometry:
+		return pgType{oidBytea, -1}
+	default:
+		return pgType{oidText, -1}
+	}
+}
+
+// appendValue appends the text format of the value, as expected by
+// PostgreSQL clients for its type.
+func appendValue(buf []byte, v sqltypes.Value) []byte {
+	if typeOf(v.Type()).oid == oidBytea {
+		raw := v.Raw()
+		buf = append(buf, `\x`...)
+		return hex.AppendEncode(buf, raw)
+	}
+	return append(buf, v.Raw()...)
+}
@@ -59,6 +59,33 @@ func waitForNewerVSchema(ctx context.Context, e *Executor, lastVSchemaCreated ti
 
 const MaxBufferingRetries = 3
 
+// maxStaleness returns the staleness bound of the tablets which may serve the
+// query of the plan, which its MAX_STALENESS directive sets for the query
+// instead of the session.
+func maxStaleness(plan *engine.Plan, safeSession *econtext.SafeSession) time.Duration {
+	if plan.QueryHints.MaxStaleness != nil {
+		return *plan.QueryHints.MaxStaleness
+	}
+	return safeSession.GetMaxStaleness()
+}
+
+// hedgeableRead tells whether the query of the plan is a read of a single shard
+// outside of a transaction, which may be sent to a second tablet.
+func hedgeableRead(plan *engine.Plan, safeSession *econtext.SafeSession) bool {
+	if plan.QueryType != sqlparser.StmtSelect || safeSession.InTransaction() {
+		return false
+	}
+	route, ok := plan.Instructions.(*engine.Route)
+	if !ok {
+		return false
+	}
+	switch route.Opcode {
+	case engine.Unsharded, engine.EqualUnique, engine.Reference:
+		return true
+	}
+	return false
+}
+
 func (e *Executor) newExecute(
 	ctx context.Context,
 	mysqlCtx vtgateservice.MySQLConnection,
@@ -70,6 +97,8 @@ func (e *Executor) newExecute(
 	execPlan planExec, // used when there is a plan to execute
 	recResult txResult, // used when it's something simple like begin/commit/rollback/savepoint
 ) (err error) {
+	ctx = withTabletTags(ctx, safeSession.GetTabletTags())
+
 	// Start an implicit transaction if necessary.
 	err = e.startTxIfNecessary(ctx, safeSession)
 	if err != nil {
@@ -129,6 +158,7 @@ func (e *Executor) newExecute(
 			safeSession.ClearWarnings()
 			return err
 		}
+		defer vcursor.ReleaseMemory()
 
 		if plan.QueryType != sqlparser.StmtShow {
 			safeSession.ClearWarnings()
@@ -142,6 +172,10 @@ func (e *Executor) newExecute(
 		// set the overall query timeout if it is not already set
 		ctx, cancel = vcursor.GetContextWithTimeOut(ctx)
 		defer cancel()
+		ctx = withMaxStaleness(ctx, maxStaleness(plan, safeSession))
+		if hedgeableRead(plan, safeSession) {
+			ctx = withHedgeableRead(ctx)
+		}
 
 		// If we have previously issued a VT15001 error, we block any new queries on this session until we receive a ROLLBACK or "show warnings".
 		if shouldBlockQueries(plan, safeSession) {
@@ -172,6 +206,9 @@ func (e *Executor) newExecute(
 		} else {
 			err = execPlan(ctx, plan, vcursor, bindVars, execStart)
 		}
+		if err == nil {
+			recordCanaryWarning(safeSession, vcursor, execStart)
+		}
 
 		if err == nil || safeSession.InTransaction() {
 			return err
@@ -326,7 +363,7 @@ func (e *Executor) executePlan(
 ) (*sqltypes.Result, error) {
 
 	// 4: Execute!
-	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	qr, err := vcursor.ExecutePrimitive(withShardResultsLimit(ctx, plan), plan.Instructions, bindVars, true)
 
 	// 5: Log and add statistics
 	e.setLogStats(logStats, plan, vcursor, execStart, err, qr)
@@ -410,12 +447,14 @@ func (e *Executor) setLogStats(logStats *logstats.LogStats, plan *engine.Plan, v
 	logStats.TabletType = vcursor.TabletType().String()
 	errCount := e.logExecutionEnd(logStats, execStart, plan, vcursor, err, qr)
 	plan.AddStats(1, time.Since(logStats.StartTime), logStats.ShardQueries, logStats.RowsAffected, logStats.RowsReturned, errCount)
+	e.recordVindexCandidates(plan)
 }
 
 func (e *Executor) logExecutionEnd(logStats *logstats.LogStats, execStart time.Time, plan *engine.Plan, vcursor *econtext.VCursorImpl, err error, qr *sqltypes.Result) uint64 {
 	logStats.ExecuteTime = time.Since(execStart)
 
 	e.updateQueryStats(plan.QueryType.String(), plan.Type.String(), vcursor.TabletType().String(), int64(logStats.ShardQueries), plan.TablesUsed)
+	e.updateTableTimings(plan.QueryType.String(), plan.TablesUsed, logStats.ExecuteTime, err != nil)
 
 	var errCount uint64
 	if err != nil {
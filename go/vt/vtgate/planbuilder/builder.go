@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/vschemawrapper"
 	"vitess.io/vitess/go/vt/key"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/dynamicconfig"
@@ -193,10 +195,17 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 	case *sqlparser.OtherAdmin:
 		return buildOtherReadAndAdmin(query, vschema)
 	case *sqlparser.Analyze:
-		return buildRoutePlan(stmt, reservedVars, vschema, buildAnalyzePlan)
+		return buildAnalyzePlan(stmt, reservedVars, vschema, cfg)
+	case *sqlparser.OptimizeTable:
+		return buildOptimizeTablePlan(query, stmt, vschema, cfg)
+	case *sqlparser.ChecksumTable:
+		return buildChecksumTablePlan(stmt, vschema)
 	case *sqlparser.Set:
 		return buildSetPlan(stmt, vschema)
 	case *sqlparser.Load:
+		if stmt.Local {
+			return buildLocalLoadPlan(stmt)
+		}
 		return buildLoadPlan(query, vschema)
 	case sqlparser.DBDDLStatement:
 		return buildRoutePlan(stmt, reservedVars, vschema, buildDBDDLPlan)
@@ -234,14 +243,19 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 	return nil, vterrors.VT13001(fmt.Sprintf("unexpected statement type: %T", stmt))
 }
 
-func buildAnalyzePlan(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vschema plancontext.VSchema) (*planResult, error) {
-	analyzeStmt := stmt.(*sqlparser.Analyze)
+// buildAnalyzePlan sends ANALYZE TABLE to all the shards of the table, either directly or
+// as an Online DDL migration, depending on the ddl_strategy of the session.
+func buildAnalyzePlan(analyzeStmt *sqlparser.Analyze, reservedVars *sqlparser.ReservedVars, vschema plancontext.VSchema, cfg dynamicconfig.DDL) (*planResult, error) {
+	if vschema.ShardDestination() != nil {
+		return buildPlanForBypass(analyzeStmt, reservedVars, vschema)
+	}
 
 	var ks *vindexes.Keyspace
 	var err error
 	dest := key.ShardDestination(key.DestinationAllShards{})
 
-	if analyzeStmt.Table.Qualifier.NotEmpty() && sqlparser.SystemSchema(analyzeStmt.Table.Qualifier.String()) {
+	isSystemSchema := analyzeStmt.Table.Qualifier.NotEmpty() && sqlparser.SystemSchema(analyzeStmt.Table.Qualifier.String())
+	if isSystemSchema {
 		ks, err = vschema.AnyKeyspace()
 		if err != nil {
 			return nil, err
@@ -263,14 +277,106 @@ func buildAnalyzePlan(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vsche
 	}
 	analyzeStmt.Table.Qualifier = sqlparser.NewIdentifierCS("")
 
-	prim := &engine.Send{
+	send := &engine.Send{
 		Keyspace:          ks,
 		TargetDestination: dest,
 		Query:             sqlparser.String(analyzeStmt),
 	}
+	if isSystemSchema {
+		// System schema tables are not subject to Online DDL.
+		return newPlanResult(send, sqlparser.String(analyzeStmt.Table)), nil
+	}
+	prim := &engine.TableMaintenance{
+		Keyspace:          ks,
+		TargetDestination: dest,
+		Statement:         analyzeStmt,
+		Direct:            send,
+		Config:            cfg,
+	}
 	return newPlanResult(prim, sqlparser.String(analyzeStmt.Table)), nil
 }
 
+// buildOptimizeTablePlan sends OPTIMIZE TABLE to a single shard of the target keyspace, like any
+// other admin statement. With an Online DDL strategy, the tables are rebuilt on all the shards instead.
+func buildOptimizeTablePlan(query string, stmt *sqlparser.OptimizeTable, vschema plancontext.VSchema, cfg dynamicconfig.DDL) (*planResult, error) {
+	destination, keyspace, _, err := vschema.TargetDestination("")
+	if err != nil {
+		return nil, err
+	}
+	send := &engine.Send{
+		Keyspace:          keyspace,
+		TargetDestination: destination,
+		Query:             query,
+		SingleShardOnly:   true,
+	}
+	if destination != nil {
+		// The statement targets specific shards, and is sent as is.
+		return newPlanResult(send), nil
+	}
+	send.TargetDestination = key.DestinationAnyShard{}
+
+	for i, table := range stmt.Tables {
+		if table.Qualifier.String() == keyspace.Name {
+			stmt.Tables[i].Qualifier = sqlparser.NewIdentifierCS("")
+		}
+	}
+	return newPlanResult(&engine.TableMaintenance{
+		Keyspace:          keyspace,
+		TargetDestination: key.DestinationAllShards{},
+		Statement:         stmt,
+		Direct:            send,
+		Config:            cfg,
+	}), nil
+}
+
+// buildChecksumTablePlan sends the CHECKSUM TABLE of every table to the shards
+// of its keyspace, and lets the engine combine the checksums of the shards.
+func buildChecksumTablePlan(stmt *sqlparser.ChecksumTable, vschema plancontext.VSchema) (*planResult, error) {
+	dest := vschema.ShardDestination()
+	if dest == nil {
+		dest = key.DestinationAllShards{}
+	}
+
+	tc := &tableCollector{}
+	prim := &engine.ChecksumTable{}
+	for _, tab := range stmt.Tables {
+		var ks *vindexes.Keyspace
+		tabDest := dest
+		if tab.Qualifier.NotEmpty() && sqlparser.SystemSchema(tab.Qualifier.String()) {
+			// System tables are the same on every shard, one of them is enough.
+			var err error
+			ks, err = vschema.AnyKeyspace()
+			if err != nil {
+				return nil, err
+			}
+			tabDest = key.DestinationAnyShard{}
+			prim.Tables = append(prim.Tables, sqlparser.String(tab))
+		} else {
+			tbl, _, _, _, destKs, err := vschema.FindTableOrVindex(tab)
+			if err != nil {
+				return nil, err
+			}
+			if tbl == nil {
+				return nil, vterrors.VT05004(sqlparser.String(tab))
+			}
+			ks = tbl.Keyspace
+			if destKs != nil {
+				tabDest = destKs
+			}
+			tab = sqlparser.TableName{Name: tbl.Name}
+			tc.addTable(ks.Name, tbl.Name.String())
+			prim.Tables = append(prim.Tables, singleTable(ks.Name, tbl.Name.String()))
+		}
+
+		prim.Sources = append(prim.Sources, &engine.Send{
+			Keyspace:          ks,
+			TargetDestination: tabDest,
+			Query:             sqlparser.String(&sqlparser.ChecksumTable{Tables: sqlparser.TableNames{tab}, Option: stmt.Option}),
+		})
+	}
+	return newPlanResult(prim, tc.getTables()...), nil
+}
+
 func buildDBDDLPlan(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vschema plancontext.VSchema) (*planResult, error) {
 	dbDDLstmt := stmt.(sqlparser.DBDDLStatement)
 	ksName := dbDDLstmt.GetDatabaseName()
@@ -304,11 +410,55 @@ func buildDBDDLPlan(stmt sqlparser.Statement, _ *sqlparser.ReservedVars, vschema
 		if !dbDDL.IfNotExists && ksExists {
 			return nil, vterrors.VT06001(ksName)
 		}
+		baseKeyspace, recoveryTime, err := snapshotDatabaseOptions(dbDDL)
+		if err != nil {
+			return nil, err
+		}
+		if baseKeyspace != "" {
+			if !vschema.KeyspaceExists(baseKeyspace) {
+				return nil, vterrors.VT05003(baseKeyspace)
+			}
+			return newPlanResult(engine.NewSnapshotDBDDL(ksName, baseKeyspace, recoveryTime, queryTimeout(dbDDL.Comments.Directives()))), nil
+		}
 		return newPlanResult(engine.NewDBDDL(ksName, true, queryTimeout(dbDDL.Comments.Directives()))), nil
 	}
 	return nil, vterrors.VT13001(fmt.Sprintf("database DDL not recognized: %s", sqlparser.String(dbDDLstmt)))
 }
 
+// snapshotDatabaseOptions returns the base keyspace and the recovery time of a
+// CREATE DATABASE creating a snapshot database, or an empty base keyspace.
+// The recovery time is either in RFC3339 format, or a UTC datetime.
+func snapshotDatabaseOptions(stmt *sqlparser.CreateDatabase) (baseKeyspace string, recoveryTime time.Time, err error) {
+	var recoveryTimeValue string
+	for _, option := range stmt.CreateOptions {
+		switch option.Type {
+		case sqlparser.BaseKeyspaceType:
+			baseKeyspace = option.Value
+		case sqlparser.RecoveryTimeType:
+			recoveryTimeValue = option.Value
+		}
+	}
+	switch {
+	case baseKeyspace == "" && recoveryTimeValue == "":
+		return "", time.Time{}, nil
+	case baseKeyspace == "":
+		return "", time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "recovery_time requires base_keyspace")
+	case recoveryTimeValue == "":
+		return "", time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "base_keyspace requires recovery_time")
+	}
+	recoveryTime, err = time.Parse(time.RFC3339, recoveryTimeValue)
+	if err != nil {
+		recoveryTime, err = time.ParseInLocation(time.DateTime, recoveryTimeValue, time.UTC)
+	}
+	if err != nil {
+		return "", time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid recovery_time '%s': expected an RFC3339 timestamp or a 'YYYY-MM-DD hh:mm:ss' UTC datetime", recoveryTimeValue)
+	}
+	if recoveryTime.After(time.Now()) {
+		return "", time.Time{}, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "recovery_time '%s' is in the future", recoveryTimeValue)
+	}
+	return baseKeyspace, recoveryTime, nil
+}
+
 func buildLoadPlan(query string, vschema plancontext.VSchema) (*planResult, error) {
 	keyspace, err := vschema.SelectedKeyspace()
 	if err != nil {
@@ -332,6 +482,70 @@ func buildLoadPlan(query string, vschema plancontext.VSchema) (*planResult, erro
 	}), nil
 }
 
+// buildLocalLoadPlan builds the plan of LOAD DATA LOCAL INFILE, which inserts
+// the rows of the file sent by the client with INSERT statements.
+func buildLocalLoadPlan(stmt *sqlparser.Load) (*planResult, error) {
+	if stmt.Charset.Name != "" {
+		return nil, vterrors.VT12001("CHARACTER SET in LOAD DATA LOCAL INFILE")
+	}
+	if len(stmt.SetExprs) > 0 {
+		return nil, vterrors.VT12001("SET in LOAD DATA LOCAL INFILE")
+	}
+
+	ins := &sqlparser.Insert{
+		Table:      sqlparser.NewAliasedTableExpr(stmt.Table, ""),
+		Partitions: stmt.Partitions,
+	}
+	// With LOCAL, the rows which duplicate a unique key are skipped like
+	// with IGNORE, as the client cannot be stopped from sending the file.
+	if stmt.Replace {
+		ins.Action = sqlparser.ReplaceAct
+	} else {
+		ins.Ignore = true
+	}
+	for _, col := range stmt.Columns {
+		colName, ok := col.(*sqlparser.ColName)
+		if !ok {
+			return nil, vterrors.VT12001("user variables in LOAD DATA LOCAL INFILE")
+		}
+		ins.Columns = append(ins.Columns, colName.Name)
+	}
+
+	format := engine.DefaultLoadFormat
+	if fields := stmt.Fields; fields != nil {
+		if fields.TerminatedBy != nil {
+			format.FieldsTerminatedBy = *fields.TerminatedBy
+		}
+		if fields.EnclosedBy != nil {
+			format.FieldsEnclosedBy = *fields.EnclosedBy
+		}
+		if fields.EscapedBy != nil {
+			format.FieldsEscapedBy = *fields.EscapedBy
+		}
+	}
+	if lines := stmt.Lines; lines != nil {
+		if lines.StartingBy != nil {
+			format.LinesStartingBy = *lines.StartingBy
+		}
+		if lines.TerminatedBy != nil {
+			format.LinesTerminatedBy = *lines.TerminatedBy
+		}
+	}
+	if format.FieldsTerminatedBy == "" || format.LinesTerminatedBy == "" {
+		return nil, vterrors.VT12001("empty FIELDS or LINES TERMINATED BY in LOAD DATA LOCAL INFILE")
+	}
+	if len(format.FieldsEnclosedBy) > 1 || len(format.FieldsEscapedBy) > 1 {
+		return nil, vterrors.VT12001("FIELDS ENCLOSED BY or ESCAPED BY of more than one character in LOAD DATA LOCAL INFILE")
+	}
+
+	return newPlanResult(&engine.Load{
+		FileName:    stmt.FileName,
+		Insert:      ins,
+		Format:      format,
+		IgnoreLines: stmt.IgnoreLines,
+	}), nil
+}
+
 func buildVSchemaDDLPlan(stmt *sqlparser.AlterVschema, vschema plancontext.VSchema) (*planResult, error) {
 	_, keyspace, _, err := vschema.TargetDestination(stmt.Table.Qualifier.String())
 	if err != nil {
@@ -43,11 +43,16 @@ func buildCallProcPlan(stmt *sqlparser.CallProc, vschema plancontext.VSchema) (*
 
 	stmt.Name.Qualifier = sqlparser.NewIdentifierCS("")
 
+	// The procedure runs on a single shard, on a reserved connection, so that
+	// all the result sets it returns are read on the same connection and the
+	// session state it changes is kept.
 	return newPlanResult(&engine.Send{
-		Keyspace:          keyspace,
-		TargetDestination: dest,
-		Query:             sqlparser.String(stmt),
+		Keyspace:                 keyspace,
+		TargetDestination:        dest,
+		Query:                    sqlparser.String(stmt),
+		SingleShardOnly:          true,
+		ReservedConnectionNeeded: true,
 	}), nil
 }
 
-const errNotAllowWhenSharded = "CALL is not supported for sharded keyspace"
+const errNotAllowWhenSharded = "CALL is not supported for sharded keyspace unless the session targets a single shard"
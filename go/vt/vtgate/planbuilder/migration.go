@@ -99,8 +99,12 @@ func buildAlterMigrationPlan(query string, alterMigration *sqlparser.AlterMigrat
 		sqlparser.ThrottleAllMigrationType,
 		sqlparser.UnthrottleMigrationType,
 		sqlparser.UnthrottleAllMigrationType:
-		// ALTER VITESS_MIGRATION ... THROTTLE ... queries go to topo (similarly to `vtctldclient UpdateThrottlerConfig`)
-		return buildAlterMigrationThrottleAppPlan(query, alterMigration, ks)
+		// ALTER VITESS_MIGRATION ... THROTTLE ... queries go to topo (similarly to `vtctldclient UpdateThrottlerConfig`).
+		// The exception is throttling by migration context: only the tablets know which migrations belong
+		// to the context, and so these queries are sent to the tablets.
+		if alterMigration.MigrationContext == "" {
+			return buildAlterMigrationThrottleAppPlan(query, alterMigration, ks)
+		}
 	}
 
 	if tabletType != topodatapb.TabletType_PRIMARY {
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+)
+
+const nextIDFunc = "vitess_next_id"
+
+// buildNextIDPrimitive plans `select vitess_next_id('seq', count[, variant])`, whose
+// ids are generated by vtgate. It returns nil if the select does not call vitess_next_id.
+func buildNextIDPrimitive(sel *sqlparser.Select, vschema plancontext.VSchema) (engine.Primitive, error) {
+	var (
+		nextID *sqlparser.FuncExpr
+		alias  string
+	)
+	columns := sel.GetColumns()
+	for _, col := range columns {
+		ae, ok := col.(*sqlparser.AliasedExpr)
+		if !ok {
+			continue
+		}
+		if fn, ok := ae.Expr.(*sqlparser.FuncExpr); ok && fn.Qualifier.IsEmpty() && fn.Name.EqualString(nextIDFunc) {
+			nextID = fn
+			alias = ae.As.String()
+			if alias == "" {
+				alias = sqlparser.String(ae.Expr)
+			}
+		}
+	}
+	if nextID == nil {
+		return nil, nil
+	}
+	if len(columns) != 1 {
+		return nil, vterrors.VT12001("vitess_next_id and other expressions in the same select")
+	}
+	if len(nextID.Exprs) != 2 && len(nextID.Exprs) != 3 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect parameter count in the call to native function '%s'", nextIDFunc)
+	}
+
+	cfg := &evalengine.Config{
+		Collation:   vschema.ConnCollation(),
+		Environment: vschema.Environment(),
+	}
+	args := make([]evalengine.Expr, len(nextID.Exprs))
+	for i, expr := range nextID.Exprs {
+		var err error
+		args[i], err = evalengine.Translate(expr, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	prim := &engine.NextID{
+		Sequence: args[0],
+		Count:    args[1],
+		Column:   alias,
+	}
+	if len(args) == 3 {
+		prim.Variant = args[2]
+	}
+	return prim, nil
+}
@@ -18,6 +18,7 @@ package planbuilder
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -250,6 +251,7 @@ func transformFkCascade(ctx *plancontext.PlanningContext, fkc *operators.FkCasca
 			Cols:           child.Cols,
 			NonLiteralInfo: child.NonLiteralInfo,
 			Exec:           childEngine,
+			CrossShard:     child.CrossShard,
 		})
 	}
 
@@ -586,6 +588,33 @@ func getHints(cmt *sqlparser.ParsedComments) *queryHints {
 	}
 }
 
+// partialAggregatesDirective returns the comment directive asking the tablets
+// to fold the rows of each group of the aggregation pushed under the route,
+// when the tablets compute the sketches of an approximate COUNT(DISTINCT).
+func partialAggregatesDirective(op *operators.Route) string {
+	src := op.Source
+	for {
+		ordering, ok := src.(*operators.Ordering)
+		if !ok {
+			break
+		}
+		src = ordering.Source
+	}
+	aggr, ok := src.(*operators.Aggregator)
+	if !ok || len(aggr.SketchColumns) == 0 {
+		return ""
+	}
+
+	var partials []string
+	for _, col := range aggr.SketchColumns {
+		partials = append(partials, fmt.Sprintf("%s:%d", opcode.AggregateCountDistinctApprox, col))
+	}
+	for _, aggregation := range aggr.Aggregations {
+		partials = append(partials, fmt.Sprintf("%s:%d", aggregation.OpCode, aggregation.ColOffset))
+	}
+	return fmt.Sprintf("/*vt+ %s=%s */", sqlparser.DirectivePartialAggregates, strings.Join(partials, ","))
+}
+
 func transformRoutePlan(ctx *plancontext.PlanningContext, op *operators.Route) (engine.Primitive, error) {
 	ctx.CollectConditions(op.Conditions)
 
@@ -594,9 +623,15 @@ func transformRoutePlan(ctx *plancontext.PlanningContext, op *operators.Route) (
 		return nil, err
 	}
 
-	if stmtWithComments, ok := stmt.(sqlparser.Commented); ok && op.Comments != nil {
+	if stmtWithComments, ok := stmt.(sqlparser.Commented); ok {
 		comments := op.Comments.GetComments()
-		stmtWithComments.SetComments(comments)
+		directive := partialAggregatesDirective(op)
+		if directive != "" {
+			comments = append(slices.Clone(comments), directive)
+		}
+		if op.Comments != nil || directive != "" {
+			stmtWithComments.SetComments(comments)
+		}
 	}
 
 	hints := getHints(op.Comments)
@@ -76,7 +76,7 @@ func (qb *queryBuilder) includeTable(op *Table) bool {
 	return cteTbl.Merged
 }
 
-func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.TableSet, hints sqlparser.IndexHints) {
+func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.TableSet, hints sqlparser.IndexHints, sample *sqlparser.TableSample) {
 	if tableID.NumberOfTables() == 1 && qb.ctx.SemTable != nil {
 		tblInfo, err := qb.ctx.SemTable.TableInfoFor(tableID)
 		if err != nil {
@@ -92,7 +92,8 @@ func (qb *queryBuilder) addTable(db, tableName, alias string, tableID semantics.
 		Name:      sqlparser.NewIdentifierCS(tableName),
 		Qualifier: sqlparser.NewIdentifierCS(db),
 	}
-	qb.addTableExpr(tableName, alias, tableID, tableExpr, hints, nil)
+	tbl := qb.addTableExpr(tableName, alias, tableID, tableExpr, hints, nil)
+	tbl.Sample = sample
 }
 
 func (qb *queryBuilder) addTableExpr(
@@ -101,7 +102,7 @@ func (qb *queryBuilder) addTableExpr(
 	tblExpr sqlparser.SimpleTableExpr,
 	hints sqlparser.IndexHints,
 	columnAliases sqlparser.Columns,
-) {
+) *sqlparser.AliasedTableExpr {
 	if qb.stmt == nil {
 		qb.stmt = &sqlparser.Select{}
 	}
@@ -115,6 +116,7 @@ func (qb *queryBuilder) addTableExpr(
 	qb.ctx.SemTable.ReplaceTableSetFor(tableID, tbl)
 	qb.stmt.(FromStatement).SetFrom(append(qb.stmt.(FromStatement).GetFrom(), tbl))
 	qb.tableNames = append(qb.tableNames, tableName)
+	return tbl
 }
 
 func (qb *queryBuilder) addPredicate(expr sqlparser.Expr) {
@@ -269,7 +271,7 @@ func (qb *queryBuilder) recursiveCteWith(other *queryBuilder, name, alias string
 		},
 	}
 
-	qb.addTable("", name, alias, "", nil)
+	qb.addTable("", name, alias, "", nil, nil)
 }
 
 type FromStatement interface {
@@ -575,7 +577,7 @@ func buildTable(op *Table, qb *queryBuilder) {
 	if op.QTable.IsInfSchema {
 		dbName = op.QTable.Table.Qualifier.String()
 	}
-	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Hints)
+	qb.addTable(dbName, op.QTable.Table.Name.String(), op.QTable.Alias.As.String(), TableID(op), op.QTable.Alias.Hints, op.QTable.Alias.Sample)
 	for _, pred := range op.QTable.Predicates {
 		qb.addPredicate(pred)
 	}
@@ -170,6 +170,7 @@ func pushAggregationThroughRoute(
 // pushAggregations splits aggregations between the original aggregator and the one we are pushing down
 func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator, aggrBelowRoute *Aggregator) {
 	canPushDistinctAggr, distinctExprs := checkIfWeCanPush(ctx, aggregator)
+	approximate := !canPushDistinctAggr && canApproximateDistinct(ctx, aggregator)
 
 	distinctAggrGroupByAdded := false
 
@@ -190,6 +191,13 @@ func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator,
 		aeDistinctExpr := aeWrap(distinctExprs[0])
 		aggrBelowRoute.Columns[aggr.ColOffset] = aeDistinctExpr
 
+		// When the count can be approximated, the tablets fold the distinct
+		// values of each group into a sketch, which vtgate merges.
+		if approximate {
+			aggregator.Aggregations[i].OpCode = opcode.AggregateCountDistinctApprox
+			aggrBelowRoute.SketchColumns = append(aggrBelowRoute.SketchColumns, aggr.ColOffset)
+		}
+
 		// We handle a distinct aggregation by turning it into a group by and
 		// doing the aggregating on the vtgate level instead
 		// Adding to group by can be done only once even though there are multiple distinct aggregation with same expression.
@@ -201,11 +209,36 @@ func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator,
 		}
 	}
 
-	if !canPushDistinctAggr {
+	if !canPushDistinctAggr && !approximate {
 		aggregator.DistinctExpr = distinctExprs[0]
 	}
 }
 
+// canApproximateDistinct returns true if the COUNT(DISTINCT) of the aggregator
+// can be approximated with HyperLogLog sketches computed by the tablets, which
+// is the case when it is an APPROX_COUNT_DISTINCT or when the session enables
+// the approximation of all of them. The tablets then fold the rows of each
+// group, so the other aggregations must be partial aggregates they can
+// aggregate further.
+func canApproximateDistinct(ctx *plancontext.PlanningContext, aggregator *Aggregator) bool {
+	if !aggregator.Original {
+		return false
+	}
+	for _, aggr := range aggregator.Aggregations {
+		switch aggr.OpCode {
+		case opcode.AggregateCountDistinct:
+			if !aggr.Approximate && !ctx.PlannerFlags.ApproximateCountDistinct {
+				return false
+			}
+		case opcode.AggregateCount, opcode.AggregateCountStar,
+			opcode.AggregateSum, opcode.AggregateMin, opcode.AggregateMax, opcode.AggregateAnyValue:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func checkIfWeCanPush(ctx *plancontext.PlanningContext, aggregator *Aggregator) (bool, []sqlparser.Expr) {
 	canPush := true
 	var distinctExprs []sqlparser.Expr
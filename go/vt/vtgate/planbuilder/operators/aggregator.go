@@ -46,6 +46,12 @@ type (
 		// this needs to be the last ORDER BY expression
 		DistinctExpr sqlparser.Expr
 
+		// SketchColumns are the offsets of the columns the tablets fold into
+		// HyperLogLog sketches for an approximate COUNT(DISTINCT), along with
+		// the partial aggregates of the other aggregations. It is only set on
+		// the aggregator pushed under a route.
+		SketchColumns []int
+
 		// Pushed will be set to true once this aggregation has been pushed deeper in the tree
 		Pushed        bool
 		offsetPlanned bool
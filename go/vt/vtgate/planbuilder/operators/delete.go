@@ -242,7 +242,7 @@ func createDeleteOperator(ctx *plancontext.PlanningContext, del *sqlparser.Delet
 	if vTbl.Keyspace.Sharded && vTbl.Type == vindexes.TypeTable {
 		primaryVindex := getVindexInformation(tblID, vTbl)
 		if len(vTbl.Owned) > 0 {
-			ovq = generateOwnedVindexQuery(del, targetTbl, primaryVindex.Columns)
+			ovq = generateOwnedVindexQuery(del, targetTbl, primaryVindex)
 		}
 	}
 
@@ -263,16 +263,16 @@ func createDeleteOperator(ctx *plancontext.PlanningContext, del *sqlparser.Delet
 	return sqc.getRootOperator(delOp, nil), vTbl
 }
 
-func generateOwnedVindexQuery(del *sqlparser.Delete, table TargetTable, ksidCols []sqlparser.IdentifierCI) *sqlparser.Select {
+func generateOwnedVindexQuery(del *sqlparser.Delete, table TargetTable, primaryVindex *vindexes.ColumnVindex) *sqlparser.Select {
 	var selExprs []sqlparser.SelectExpr
-	for _, col := range ksidCols {
+	for _, col := range primaryVindex.Columns {
 		colName := makeColName(col, table, sqlparser.MultiTable(del.TableExprs))
-		selExprs = append(selExprs, aeWrap(colName))
+		selExprs = append(selExprs, vindexColumnExpr(primaryVindex, colName))
 	}
 	for _, cv := range table.VTable.Owned {
 		for _, col := range cv.Columns {
 			colName := makeColName(col, table, sqlparser.MultiTable(del.TableExprs))
-			selExprs = append(selExprs, aeWrap(colName))
+			selExprs = append(selExprs, vindexColumnExpr(cv, colName))
 		}
 	}
 	sel := &sqlparser.Select{
@@ -356,7 +356,7 @@ func createFkCascadeOpForDelete(ctx *plancontext.PlanningContext, parentOp Opera
 		offsets, selectExprs = addColumns(ctx, fk.ParentColumns, selectExprs, tblName)
 
 		fkChildren = append(fkChildren,
-			createFkChildForDelete(ctx, fk, offsets))
+			createFkChildForDelete(ctx, fk, offsets, deletedTbl))
 	}
 	selectionOp := createSelectionOp(ctx, selectExprs, delStmt.TableExprs, delStmt.Where, nil, nil, getUpdateLock(deletedTbl))
 
@@ -367,7 +367,7 @@ func createFkCascadeOpForDelete(ctx *plancontext.PlanningContext, parentOp Opera
 	}
 }
 
-func createFkChildForDelete(ctx *plancontext.PlanningContext, fk vindexes.ChildFKInfo, cols []int) *FkChild {
+func createFkChildForDelete(ctx *plancontext.PlanningContext, fk vindexes.ChildFKInfo, cols []int, deletedTbl *vindexes.BaseTable) *FkChild {
 	bvName := ctx.ReservedVars.ReserveVariable(foreignKeyConstraintValues)
 	parsedComments := getParsedCommentsForFkChecks(ctx)
 	var childStmt sqlparser.Statement
@@ -412,8 +412,9 @@ func createFkChildForDelete(ctx *plancontext.PlanningContext, fk vindexes.ChildF
 	childOp := createOpFromStmt(ctx, childStmt, false /* verifyAllFKs */, "" /* fkToIgnore */)
 
 	return &FkChild{
-		BVName: bvName,
-		Cols:   cols,
-		Op:     childOp,
+		BVName:     bvName,
+		Cols:       cols,
+		Op:         childOp,
+		CrossShard: semantics.IsCrossShardChildFK(deletedTbl, fk),
 	}
 }
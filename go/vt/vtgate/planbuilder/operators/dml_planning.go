@@ -94,3 +94,12 @@ func getVindexInformation(id semantics.TableSet, table *vindexes.BaseTable) *vin
 	}
 	return table.ColumnVindexes[0]
 }
+
+// vindexColumnExpr returns the expression to select for a column of the vindex,
+// which is the expression of the column, named after it, for a functional vindex.
+func vindexColumnExpr(cv *vindexes.ColumnVindex, colName *sqlparser.ColName) *sqlparser.AliasedExpr {
+	if cv.Expression == nil {
+		return aeWrap(colName)
+	}
+	return &sqlparser.AliasedExpr{Expr: cv.ExpressionOf(colName), As: colName.Name}
+}
@@ -29,6 +29,8 @@ type FkChild struct {
 	Cols           []int // indexes
 	NonLiteralInfo []engine.NonLiteralUpdateInfo
 	Op             Operator
+	// CrossShard is true when the child rows can live on other shards than the parent rows.
+	CrossShard bool
 
 	noColumns
 	noPredicates
@@ -90,6 +92,7 @@ func (fkc *FkCascade) Clone(inputs []Operator) Operator {
 			Cols:           slices.Clone(fkc.Children[idx-2].Cols),
 			NonLiteralInfo: slices.Clone(fkc.Children[idx-2].NonLiteralInfo),
 			Op:             operator,
+			CrossShard:     fkc.Children[idx-2].CrossShard,
 		})
 	}
 	return newFkc
@@ -17,6 +17,8 @@ limitations under the License.
 package operators
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
@@ -381,6 +383,7 @@ func createInsertOperator(ctx *plancontext.PlanningContext, insStmt *sqlparser.I
 			panic(vterrors.VT09004())
 		}
 	}
+	validateInsertColumns(insStmt, vTbl)
 
 	// modify column list or values for autoincrement column.
 	autoIncGen := modifyForAutoinc(ctx, insStmt, vTbl)
@@ -403,6 +406,24 @@ func createInsertOperator(ctx *plancontext.PlanningContext, insStmt *sqlparser.I
 	return op
 }
 
+// validateInsertColumns checks the column list of the INSERT against the columns
+// of the table when they are known, from the tracked schema or an authoritative
+// column list in the vschema, so that unknown and repeated columns fail at plan
+// time like they do on MySQL, without a round trip to the shards.
+func validateInsertColumns(insStmt *sqlparser.Insert, vTbl *vindexes.BaseTable) {
+	if !vTbl.ColumnListAuthoritative {
+		return
+	}
+	for i, col := range insStmt.Columns {
+		if !slices.ContainsFunc(vTbl.Columns, func(column vindexes.Column) bool { return column.Name.Equal(col) }) {
+			panic(vterrors.VT03014(col.String(), "field list"))
+		}
+		if insStmt.Columns[:i].FindColumn(col) != -1 {
+			panic(vterrors.VT03034(col.String()))
+		}
+	}
+}
+
 func insertSelectPlan(
 	ctx *plancontext.PlanningContext,
 	insOp *Insert,
@@ -443,6 +464,9 @@ func insertSelectPlan(
 	colVindexes := insOp.ColVindexes
 	vv := make([][]int, len(colVindexes))
 	for idx, colVindex := range colVindexes {
+		if colVindex.Expression != nil {
+			panic(vterrors.VT12001(fmt.Sprintf("INSERT with a SELECT into a table with the functional vindex %s", colVindex.Name)))
+		}
 		for _, col := range colVindex.Columns {
 			checkAndErrIfVindexChanging(sqlparser.UpdateExprs(ins.OnDup), col)
 			colNum := findColumn(ins, col)
@@ -502,7 +526,11 @@ func insertRowsPlan(ctx *plancontext.PlanningContext, insOp *Insert, ins *sqlpar
 			routeValues[vIdx][colIdx] = make([]evalengine.Expr, len(rows))
 			colNum, _ := findOrAddColumn(ins, col)
 			for rowNum, row := range rows {
-				innerpv, err := evalengine.Translate(row[colNum], &evalengine.Config{
+				value := row[colNum]
+				if colVindex.Expression != nil {
+					value = colVindex.ExpressionOf(value)
+				}
+				innerpv, err := evalengine.Translate(value, &evalengine.Config{
 					ResolveType: ctx.TypeForExpr,
 					Collation:   ctx.SemTable.Collation,
 					Environment: ctx.VSchema.Environment(),
@@ -515,7 +543,12 @@ func insertRowsPlan(ctx *plancontext.PlanningContext, insOp *Insert, ins *sqlpar
 		}
 	}
 	// here we are replacing the row value with the argument.
+	// The column of a functional vindex keeps its value, as the vindex value
+	// is computed from it.
 	for _, colVindex := range colVindexes {
+		if colVindex.Expression != nil {
+			continue
+		}
 		for _, col := range colVindex.Columns {
 			colNum, _ := findOrAddColumn(ins, col)
 			for rowNum, row := range rows {
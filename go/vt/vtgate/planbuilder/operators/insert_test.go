@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+func TestValidateInsertColumns(t *testing.T) {
+	columns := []vindexes.Column{
+		{Name: sqlparser.NewIdentifierCI("id"), Type: querypb.Type_INT64},
+		{Name: sqlparser.NewIdentifierCI("Name"), Type: querypb.Type_VARCHAR},
+	}
+	tests := []struct {
+		name          string
+		insert        string
+		authoritative bool
+		wantErr       string
+	}{{
+		name:          "known columns",
+		insert:        "insert into t(id, name) values (1, 'a')",
+		authoritative: true,
+	}, {
+		name:          "columns are case insensitive",
+		insert:        "insert into t(ID, NAME) values (1, 'a')",
+		authoritative: true,
+	}, {
+		name:          "no column list",
+		insert:        "insert into t values (1, 'a')",
+		authoritative: true,
+	}, {
+		name:          "unknown column",
+		insert:        "insert into t(id, foo) values (1, 'a')",
+		authoritative: true,
+		wantErr:       "VT03014: unknown column 'foo' in 'field list'",
+	}, {
+		name:          "column specified twice",
+		insert:        "insert into t(id, name, Id) values (1, 'a', 2)",
+		authoritative: true,
+		wantErr:       "VT03034: Column 'Id' specified twice",
+	}, {
+		name:          "the first invalid column is reported",
+		insert:        "insert into t(id, id, foo) values (1, 2, 3)",
+		authoritative: true,
+		wantErr:       "VT03034: Column 'id' specified twice",
+	}, {
+		name:   "columns not known",
+		insert: "insert into t(id, foo, foo) values (1, 'a', 'b')",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tt.insert)
+			require.NoError(t, err)
+			vTbl := &vindexes.BaseTable{
+				Name:                    sqlparser.NewIdentifierCS("t"),
+				Columns:                 columns,
+				ColumnListAuthoritative: tt.authoritative,
+			}
+			validate := func() { validateInsertColumns(stmt.(*sqlparser.Insert), vTbl) }
+			if tt.wantErr == "" {
+				require.NotPanics(t, validate)
+				return
+			}
+			require.PanicsWithError(t, tt.wantErr, validate)
+		})
+	}
+}
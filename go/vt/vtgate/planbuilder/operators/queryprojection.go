@@ -76,7 +76,8 @@ type (
 
 		Alias string // The alias name for the aggregation result
 
-		Distinct bool // Whether the aggregation function is DISTINCT
+		Distinct    bool // Whether the aggregation function is DISTINCT
+		Approximate bool // Whether the COUNT(DISTINCT) may be approximated, as asked by APPROX_COUNT_DISTINCT
 
 		// Offsets pointing to columns within the same aggregator
 		ColOffset int // Offset for the column being aggregated
@@ -501,6 +502,12 @@ func createAggrFromAggrFunc(fnc sqlparser.AggrFunc, aliasedExpr *sqlparser.Alias
 	}
 
 	distinct := sqlparser.IsDistinct(fnc)
+	_, approximate := fnc.(*sqlparser.ApproxCountDistinct)
+	if approximate {
+		// APPROX_COUNT_DISTINCT is planned as a COUNT(DISTINCT), which is
+		// approximated when the distinct values come from several shards.
+		distinct = true
+	}
 	if distinct {
 		switch code {
 		case opcode.AggregateCount:
@@ -512,6 +519,7 @@ func createAggrFromAggrFunc(fnc sqlparser.AggrFunc, aliasedExpr *sqlparser.Alias
 
 	aggr := NewAggr(code, fnc, aliasedExpr, aliasedExpr.ColumnName())
 	aggr.Distinct = distinct
+	aggr.Approximate = approximate
 	return aggr
 }
 
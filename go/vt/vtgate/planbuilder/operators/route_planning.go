@@ -299,6 +299,9 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 	if len(joinPredicates) > 0 && requiresSwitchingSides(ctx, rhs) {
 		if !joinType.IsCommutative() || requiresSwitchingSides(ctx, lhs) {
 			// we can't switch sides, so let's see if we can use a HashJoin to solve it
+			if ctx.PlannerFlags.DisableHashJoin {
+				panic(vterrors.VT12001("join requiring a hash join when the hash_join planner flag is off"))
+			}
 			join := NewHashJoin(lhs, rhs, !joinType.IsInner())
 			for _, pred := range joinPredicates {
 				join.AddJoinPredicate(ctx, pred, true)
@@ -399,7 +402,8 @@ func findColumnVindex(ctx *plancontext.PlanningContext, a Operator, exp sqlparse
 				if vtable != nil {
 					for _, vindex := range vtable.ColumnVindexes {
 						sC, isSingle := vindex.Vindex.(vindexes.SingleColumn)
-						if isSingle && vindex.Columns[0].Equal(col.Name) {
+						// a functional vindex does not apply to the column itself
+						if isSingle && vindex.Expression == nil && vindex.Columns[0].Equal(col.Name) {
 							singCol = sC
 							return io.EOF
 						}
@@ -336,6 +336,14 @@ func (tr *ShardedRouting) planInOp(ctx *plancontext.PlanningContext, cmp *sqlpar
 		case sqlparser.ListArg:
 			return tr.planCompositeInOpArg(ctx, cmp, left, right)
 		}
+	default:
+		valTuple, isTuple := cmp.Right.(sqlparser.ValTuple)
+		if isTuple && len(valTuple) == 1 {
+			return tr.planEqualOp(ctx, &sqlparser.ComparisonExpr{Left: left, Right: valTuple[0], Operator: sqlparser.EqualOp})
+		}
+
+		opcode := func(*vindexes.ColumnVindex) engine.Opcode { return engine.IN }
+		return tr.haveMatchingFunctionalVindex(ctx, cmp, cmp.Right, left, opcode)
 	}
 	return false
 }
@@ -417,6 +425,10 @@ func (tr *ShardedRouting) haveMatchingVindex(
 		if !ctx.SemTable.DirectDeps(column).IsSolvedBy(v.TableID) {
 			continue
 		}
+		// A functional vindex only applies to its expression, never to the bare column.
+		if v.ColVindex.Expression != nil {
+			continue
+		}
 
 		switch v.ColVindex.Vindex.(type) {
 		case vindexes.SingleColumn:
@@ -537,8 +549,10 @@ func (tr *ShardedRouting) planEqualOp(ctx *plancontext.PlanningContext, node *sq
 	if !ok {
 		column, ok = node.Right.(*sqlparser.ColName)
 		if !ok {
-			// either the LHS or RHS have to be a column to be useful for the vindex
-			return false
+			// either the LHS or RHS have to be a column, or the expression of a
+			// functional vindex, to be useful for the vindex
+			found := tr.haveMatchingFunctionalVindex(ctx, node, node.Right, node.Left, equalOrEqualUnique)
+			return tr.haveMatchingFunctionalVindex(ctx, node, node.Left, node.Right, equalOrEqualUnique) || found
 		}
 		vdValue = node.Left
 	}
@@ -550,6 +564,48 @@ func (tr *ShardedRouting) planEqualOp(ctx *plancontext.PlanningContext, node *sq
 	return tr.haveMatchingVindex(ctx, node, vdValue, column, val, equalOrEqualUnique, justTheVindex)
 }
 
+// haveMatchingFunctionalVindex adds an option for each functional vindex that
+// applies to expr, which is compared with valueExpr by node.
+func (tr *ShardedRouting) haveMatchingFunctionalVindex(
+	ctx *plancontext.PlanningContext,
+	node sqlparser.Expr,
+	valueExpr sqlparser.Expr,
+	expr sqlparser.Expr,
+	opcode func(*vindexes.ColumnVindex) engine.Opcode,
+) bool {
+	var matching []*VindexPlusPredicates
+	for _, v := range tr.VindexPreds {
+		if v.ColVindex.Expression == nil || !ctx.SemTable.DirectDeps(expr).IsSolvedBy(v.TableID) {
+			continue
+		}
+		if v.ColVindex.MatchesExpression(expr) {
+			matching = append(matching, v)
+		}
+	}
+	if len(matching) == 0 {
+		return false
+	}
+
+	value := makeEvalEngineExpr(ctx, valueExpr)
+	if value == nil {
+		return false
+	}
+
+	for _, v := range matching {
+		routeOpcode := opcode(v.ColVindex)
+		v.Options = append(v.Options, &VindexOption{
+			Values:      []evalengine.Expr{value},
+			ValueExprs:  []sqlparser.Expr{valueExpr},
+			Predicates:  []sqlparser.Expr{node},
+			OpCode:      routeOpcode,
+			FoundVindex: v.ColVindex.Vindex,
+			Cost:        costFor(v.ColVindex, routeOpcode),
+			Ready:       true,
+		})
+	}
+	return true
+}
+
 func (tr *ShardedRouting) planCompositeInOpRecursive(
 	ctx *plancontext.PlanningContext,
 	cmp *sqlparser.ComparisonExpr,
@@ -632,6 +688,9 @@ func (tr *ShardedRouting) planCompositeInOpArg(
 
 func (tr *ShardedRouting) hasVindex(column *sqlparser.ColName) bool {
 	for _, v := range tr.VindexPreds {
+		if v.ColVindex.Expression != nil {
+			continue
+		}
 		for _, col := range v.ColVindex.Columns {
 			if column.Name.Equal(col) {
 				return true
@@ -313,7 +313,7 @@ func extractLHSExpr(
 
 // tryMergeWithRHS attempts to merge a subquery with the RHS of a join
 func tryMergeWithRHS(ctx *plancontext.PlanningContext, inner *SubQuery, outer *ApplyJoin) (Operator, *ApplyResult) {
-	if !outer.IsInner() {
+	if !outer.IsInner() || !canMergeSubQuery(ctx, inner) {
 		return nil, nil
 	}
 	// both sides need to be routes
@@ -489,6 +489,9 @@ func tryMergeSubQuery(
 	subQuery *SubQuery,
 	outer *Route,
 ) (newOuter Operator, result *ApplyResult) {
+	if !canMergeSubQuery(ctx, subQuery) {
+		return outer, NoRewrite
+	}
 	switch inner := subQuery.Subquery.(type) {
 	case *Route:
 		return tryMergeSubqueryWithOuter(ctx, subQuery, outer, inner)
@@ -498,6 +501,13 @@ func tryMergeSubQuery(
 	return outer, NoRewrite
 }
 
+// canMergeSubQuery returns false if the planner flags require the subquery to be
+// executed separately by vtgate, i.e. if it is uncorrelated and only correlated
+// subqueries are pushed down.
+func canMergeSubQuery(ctx *plancontext.PlanningContext, subQuery *SubQuery) bool {
+	return subQuery.correlated || !ctx.PlannerFlags.CorrelatedSubqueryPushdownOnly
+}
+
 // tryMergeSubqueriesRecursively attempts to merge a SubQueryContainer with the outer Route.
 func tryMergeSubqueriesRecursively(
 	ctx *plancontext.PlanningContext,
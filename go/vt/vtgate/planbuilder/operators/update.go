@@ -409,7 +409,7 @@ func getUpdateVindexInformation(
 	}
 
 	primaryVindex := getVindexInformation(table.ID, table.VTable)
-	changedVindexValues, ownedVindexQuery, subQueriesArgOnChangedVindex := buildChangedVindexesValues(ctx, updStmt, table.VTable, primaryVindex.Columns, assignments)
+	changedVindexValues, ownedVindexQuery, subQueriesArgOnChangedVindex := buildChangedVindexesValues(ctx, updStmt, table.VTable, primaryVindex, assignments)
 	return changedVindexValues, ownedVindexQuery, subQueriesArgOnChangedVindex
 }
 
@@ -653,6 +653,7 @@ func createFkChildForUpdate(ctx *plancontext.PlanningContext, fk vindexes.ChildF
 		Cols:           selectOffsets,
 		Op:             childOp,
 		NonLiteralInfo: nonLiteralUpdateInfo,
+		CrossShard:     semantics.IsCrossShardChildFK(updatedTable, fk),
 	}
 }
 
@@ -1032,17 +1033,17 @@ func buildChangedVindexesValues(
 	ctx *plancontext.PlanningContext,
 	update *sqlparser.Update,
 	table *vindexes.BaseTable,
-	ksidCols []sqlparser.IdentifierCI,
+	primaryVindex *vindexes.ColumnVindex,
 	assignments []SetExpr,
 ) (changedVindexes map[string]*engine.VindexValues, ovq *sqlparser.Select, subQueriesArgOnChangedVindex []string) {
 	changedVindexes = make(map[string]*engine.VindexValues)
-	selExprs, offset := initialQuery(ksidCols, table)
+	selExprs, offset := initialQuery(primaryVindex, table)
 	for i, vindex := range table.ColumnVindexes {
 		vindexValueMap := make(map[string]evalengine.Expr)
 		var compExprs []sqlparser.Expr
 		for _, vcol := range vindex.Columns {
 			subQueriesArgOnChangedVindex, compExprs =
-				createAssignmentExpressions(ctx, assignments, vcol, subQueriesArgOnChangedVindex, vindexValueMap, compExprs)
+				createAssignmentExpressions(ctx, assignments, vindex, vcol, subQueriesArgOnChangedVindex, vindexValueMap, compExprs)
 		}
 		if len(vindexValueMap) == 0 {
 			// Vindex not changing, continue
@@ -1076,16 +1077,16 @@ func buildChangedVindexesValues(
 	return changedVindexes, ovq, subQueriesArgOnChangedVindex
 }
 
-func initialQuery(ksidCols []sqlparser.IdentifierCI, table *vindexes.BaseTable) (*sqlparser.SelectExprs, int) {
+func initialQuery(primaryVindex *vindexes.ColumnVindex, table *vindexes.BaseTable) (*sqlparser.SelectExprs, int) {
 	selExprs := new(sqlparser.SelectExprs)
 	offset := 0
-	for _, col := range ksidCols {
-		selExprs.Exprs = append(selExprs.Exprs, aeWrap(sqlparser.NewColName(col.String())))
+	for _, col := range primaryVindex.Columns {
+		selExprs.Exprs = append(selExprs.Exprs, vindexColumnExpr(primaryVindex, sqlparser.NewColName(col.String())))
 		offset++
 	}
 	for _, cv := range table.Owned {
 		for _, column := range cv.Columns {
-			selExprs.Exprs = append(selExprs.Exprs, aeWrap(sqlparser.NewColName(column.String())))
+			selExprs.Exprs = append(selExprs.Exprs, vindexColumnExpr(cv, sqlparser.NewColName(column.String())))
 			offset++
 		}
 	}
@@ -1095,6 +1096,7 @@ func initialQuery(ksidCols []sqlparser.IdentifierCI, table *vindexes.BaseTable)
 func createAssignmentExpressions(
 	ctx *plancontext.PlanningContext,
 	assignments []SetExpr,
+	vindex *vindexes.ColumnVindex,
 	vcol sqlparser.IdentifierCI,
 	subQueriesArgOnChangedVindex []string,
 	vindexValueMap map[string]evalengine.Expr,
@@ -1110,7 +1112,11 @@ func createAssignmentExpressions(
 			panic(vterrors.VT03015(assignment.Name.Name))
 		}
 		found = true
-		pv, err := evalengine.Translate(assignment.Expr.EvalExpr, &evalengine.Config{
+		value := assignment.Expr.EvalExpr
+		if vindex.Expression != nil {
+			value = vindex.ExpressionOf(value)
+		}
+		pv, err := evalengine.Translate(value, &evalengine.Config{
 			ResolveType: ctx.TypeForExpr,
 			Collation:   ctx.SemTable.Collation,
 			Environment: ctx.VSchema.Environment(),
@@ -44,6 +44,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
@@ -102,6 +103,8 @@ func (s *planTestSuite) TestPlan() {
 	s.testFile("unsupported_cases.json", vw, false)
 	s.testFile("unknown_schema_cases.json", vw, false)
 	s.testFile("vindex_func_cases.json", vw, false)
+	s.testFile("functional_vindex_cases.json", vw, false)
+	s.testFile("approximate_query_cases.json", vw, false)
 	s.testFile("wireup_cases.json", vw, false)
 	s.testFile("memory_sort_cases.json", vw, false)
 	s.testFile("use_cases.json", vw, false)
@@ -601,6 +604,15 @@ func (s *planTestSuite) TestMirrorPlanning() {
 	s.testFile("mirror_cases.json", vw, false)
 }
 
+func (s *planTestSuite) TestRollupPlanning() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/rollup_schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+
+	s.testFile("rollup_cases.json", vw, false)
+}
+
 func (s *planTestSuite) TestOneMirror() {
 	reset := operators.EnableDebugPrinting()
 	defer reset()
@@ -613,6 +625,63 @@ func (s *planTestSuite) TestOneMirror() {
 	s.testFile("onecase.json", vw, false)
 }
 
+func (s *planTestSuite) TestPlannerFlags() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+
+	plan := func(query string) (engine.Primitive, error) {
+		p, err := TestBuilder(query, vw, vw.CurrentDb())
+		if err != nil {
+			return nil, err
+		}
+		return p.Instructions, nil
+	}
+
+	hashJoinQuery := "select id from user left join (select col from user_extra limit 10) ue on user.col = ue.col"
+	prim, err := plan(hashJoinQuery)
+	require.NoError(s.T(), err)
+	require.IsType(s.T(), &engine.HashJoin{}, prim)
+
+	subqueryQuery := "select col from user where id = 5 and id in (select user_id from user_extra where user_id = 5)"
+	prim, err = plan(subqueryQuery)
+	require.NoError(s.T(), err)
+	require.IsType(s.T(), &engine.Route{}, prim)
+
+	infoSchemaQuery := "select table_name from information_schema.tables where (table_schema = 'ks' and table_name = 'a') or (table_schema = 'ks' and table_name = 'b')"
+	prim, err = plan(infoSchemaQuery)
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), prim.(*engine.Route).SysTableTableSchema)
+
+	vw.Flags = plannerflags.Flags{DisableHashJoin: true, DisablePredicateRewriteRetry: true, CorrelatedSubqueryPushdownOnly: true}
+	_, err = plan(hashJoinQuery)
+	require.EqualError(s.T(), err, "VT12001: unsupported: join requiring a hash join when the hash_join planner flag is off")
+
+	prim, err = plan(subqueryQuery)
+	require.NoError(s.T(), err)
+	require.IsType(s.T(), &engine.UncorrelatedSubquery{}, prim)
+
+	prim, err = plan(infoSchemaQuery)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), prim.(*engine.Route).SysTableTableSchema)
+
+	// correlated subqueries are still merged
+	prim, err = plan("select col from user where id = 5 and exists (select 1 from user_extra where user_extra.user_id = user.id)")
+	require.NoError(s.T(), err)
+	require.IsType(s.T(), &engine.Route{}, prim)
+}
+
+func (s *planTestSuite) TestApproximateCountDistinct() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+	vw.Flags = plannerflags.Flags{ApproximateCountDistinct: true}
+
+	s.testFile("approximate_count_distinct_cases.json", vw, false)
+}
+
 func loadSchema(t testing.TB, filename string, setCollation bool) *vindexes.VSchema {
 	formal, err := vindexes.LoadFormal(locateFile(filename))
 	require.NoError(t, err)
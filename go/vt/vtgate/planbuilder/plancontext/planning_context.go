@@ -27,6 +27,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators/predicates"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 )
 
@@ -37,6 +38,9 @@ type PlanningContext struct {
 
 	PlannerVersion querypb.ExecuteOptions_PlannerVersion
 
+	// PlannerFlags toggle planner behaviors, see the plannerflags package.
+	PlannerFlags plannerflags.Flags
+
 	// If we during planning have turned this expression into an argument name,
 	// we can continue using the same argument name
 	ReservedArguments map[sqlparser.Expr]string
@@ -104,6 +108,7 @@ func CreatePlanningContext(stmt sqlparser.Statement,
 		SemTable:          semTable,
 		VSchema:           vschema,
 		PlannerVersion:    version,
+		PlannerFlags:      vschema.PlannerFlags(),
 		ReservedArguments: map[sqlparser.Expr]string{},
 		Statement:         stmt,
 		PredTracker:       predicates.NewTracker(),
@@ -356,6 +361,7 @@ func (ctx *PlanningContext) UseMirror() *PlanningContext {
 		SemTable:          ctx.SemTable,
 		VSchema:           ctx.VSchema,
 		PlannerVersion:    ctx.PlannerVersion,
+		PlannerFlags:      ctx.PlannerFlags,
 		ReservedArguments: map[sqlparser.Expr]string{},
 		VerifyAllFKs:      ctx.VerifyAllFKs,
 		MergedSubqueries:  ctx.MergedSubqueries,
@@ -36,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -301,6 +302,10 @@ func (v *vschema) PlannerWarning(message string) {
 	panic("implement me")
 }
 
+func (v *vschema) PlannerFlags() plannerflags.Flags {
+	return plannerflags.Flags{}
+}
+
 func (v *vschema) ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error) {
 	// TODO implement me
 	panic("implement me")
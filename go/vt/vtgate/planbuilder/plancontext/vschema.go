@@ -8,6 +8,7 @@ import (
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/vt/key"
@@ -60,6 +61,9 @@ type VSchema interface {
 	// PlannerWarning records warning created during planning.
 	PlannerWarning(message string)
 
+	// PlannerFlags returns the planner flags in effect for the session.
+	PlannerFlags() plannerflags.Flags
+
 	// ForeignKeyMode returns the foreign_key flag value
 	ForeignKeyMode(keyspace string) (vschemapb.Keyspace_ForeignKeyMode, error)
 
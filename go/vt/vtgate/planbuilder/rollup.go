@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// rewriteOnRollup returns the query rewritten to read a rollup of its table
+// instead of the table, if the table has a rollup whose aggregate query the
+// query can be answered from, along with the rollup table. The query can be
+// answered from a rollup when it aggregates its table, and only refers to
+// the grouping columns of the rollup and to aggregates which can be computed
+// from those of the rollup.
+func rewriteOnRollup(sel *sqlparser.Select, vschema plancontext.VSchema) (*sqlparser.Select, *vindexes.BaseTable) {
+	if sel.With != nil || sel.Distinct || sel.Into != nil || sel.Lock != sqlparser.NoLock || sel.Windows != nil ||
+		sel.SQLCalcFoundRows || len(sel.From) != 1 {
+		return nil, nil
+	}
+	if sel.GroupBy == nil && !sqlparser.ContainsAggregation(sel.SelectExprs) {
+		// The rows of the query are the rows of its table, not of the rollup.
+		return nil, nil
+	}
+	if sel.GroupBy != nil && sel.GroupBy.WithRollup {
+		return nil, nil
+	}
+	if hasWindowFunctions(sel) {
+		return nil, nil
+	}
+	tableExpr, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok || tableExpr.Partitions != nil || tableExpr.Hints != nil || tableExpr.Columns != nil || tableExpr.Sample != nil {
+		return nil, nil
+	}
+	tableName, ok := tableExpr.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, nil
+	}
+	table, _, _, dest, err := vschema.FindTable(tableName)
+	if err != nil || table == nil || dest != nil {
+		return nil, nil
+	}
+	for _, rollupTable := range table.Rollups {
+		if rewritten := rewriteOnRollupTable(sel, rollupTable); rewritten != nil {
+			return rewritten, rollupTable
+		}
+	}
+	return nil, nil
+}
+
+func hasWindowFunctions(sel *sqlparser.Select) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if _, ok := node.(*sqlparser.OverClause); ok {
+			found = true
+		}
+		return !found, nil
+	}, sel)
+	return found
+}
+
+// rewriteOnRollupTable returns the query rewritten to read the rollup table,
+// or nil if it can't be answered from the rollup.
+func rewriteOnRollupTable(sel *sqlparser.Select, rollupTable *vindexes.BaseTable) *sqlparser.Select {
+	rollup := rollupTable.Rollup
+	out := sqlparser.Clone(sel)
+
+	// The rollup is aliased as the table of the query, so that the columns
+	// qualified by the table still refer to it.
+	tableExpr := out.From[0].(*sqlparser.AliasedTableExpr)
+	alias := tableExpr.As
+	if alias.IsEmpty() {
+		alias = tableExpr.Expr.(sqlparser.TableName).Name
+	}
+	tableExpr.Expr = rollupTable.GetTableName()
+	tableExpr.As = alias
+	qualifier := sqlparser.NewTableName(alias.String())
+
+	aliases := make(map[string]bool)
+	for _, expr := range out.GetColumns() {
+		if ae, ok := expr.(*sqlparser.AliasedExpr); ok && !ae.As.IsEmpty() {
+			aliases[ae.As.Lowered()] = true
+		}
+	}
+
+	matches := true
+	rewrite := func(node sqlparser.SQLNode, allowAliases bool) sqlparser.SQLNode {
+		return sqlparser.Rewrite(node, func(cursor *sqlparser.Cursor) bool {
+			switch node := cursor.Node().(type) {
+			case *sqlparser.Subquery, *sqlparser.StarExpr:
+				matches = false
+			case sqlparser.AggrFunc:
+				expr, ok := rollupAggregate(node, rollup, qualifier)
+				if !ok {
+					matches = false
+					return false
+				}
+				cursor.Replace(expr)
+				return false
+			case *sqlparser.ColName:
+				if allowAliases && node.Qualifier.IsEmpty() && aliases[node.Name.Lowered()] {
+					return false
+				}
+				col, ok := rollupGroupColumn(node, rollup, qualifier)
+				if !ok {
+					matches = false
+					return false
+				}
+				cursor.Replace(col)
+			}
+			return matches
+		}, nil)
+	}
+
+	for _, expr := range out.GetColumns() {
+		ae, ok := expr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil
+		}
+		name := ae.ColumnName()
+		ae.Expr = rewrite(ae.Expr, false).(sqlparser.Expr)
+		// The columns of the result keep the names they have on the table.
+		if col, ok := ae.Expr.(*sqlparser.ColName); ae.As.IsEmpty() && (!ok || !col.Name.EqualString(name)) {
+			ae.As = sqlparser.NewIdentifierCI(name)
+		}
+	}
+	if out.Where != nil {
+		out.Where = rewrite(out.Where, false).(*sqlparser.Where)
+	}
+	if out.GroupBy != nil {
+		out.GroupBy = rewrite(out.GroupBy, true).(*sqlparser.GroupBy)
+	}
+	if out.Having != nil {
+		out.Having = rewrite(out.Having, true).(*sqlparser.Where)
+	}
+	if out.OrderBy != nil {
+		out.OrderBy = rewrite(out.OrderBy, true).(sqlparser.OrderBy)
+	}
+	if !matches {
+		return nil
+	}
+	return out
+}
+
+// rollupGroupColumn returns the column of the rollup the grouping column col
+// of its table is materialized in.
+func rollupGroupColumn(col *sqlparser.ColName, rollup *vindexes.Rollup, qualifier sqlparser.TableName) (*sqlparser.ColName, bool) {
+	column, ok := rollup.GroupColumn(col)
+	if !ok {
+		return nil, false
+	}
+	if col.Qualifier.IsEmpty() {
+		return sqlparser.NewColName(column.String()), true
+	}
+	return sqlparser.NewColNameWithQualifier(column.String(), qualifier), true
+}
+
+// rollupAggregate returns the expression computing the aggregate of the
+// table of a rollup from the rows of the rollup.
+func rollupAggregate(aggr sqlparser.AggrFunc, rollup *vindexes.Rollup, qualifier sqlparser.TableName) (sqlparser.Expr, bool) {
+	rollupColumn := func(column sqlparser.IdentifierCI) *sqlparser.ColName {
+		return sqlparser.NewColNameWithQualifier(column.String(), qualifier)
+	}
+	if column, ok := rollup.AggregateColumn(aggr); ok {
+		switch aggr.(type) {
+		case *sqlparser.CountStar:
+			// The counts of the rollup are summed, which is NULL rather
+			// than 0 when there are none, and is not an integer.
+			return &sqlparser.CastExpr{
+				Expr: &sqlparser.FuncExpr{
+					Name:  sqlparser.NewIdentifierCI("coalesce"),
+					Exprs: []sqlparser.Expr{&sqlparser.Sum{Arg: rollupColumn(column)}, sqlparser.NewIntLiteral("0")},
+				},
+				Type: &sqlparser.ConvertType{Type: "signed"},
+			}, true
+		case *sqlparser.Sum:
+			return &sqlparser.Sum{Arg: rollupColumn(column)}, true
+		}
+	}
+
+	// The aggregates which don't depend on the number of rows of each value
+	// are computed the same way on the rows of the rollup, as long as they
+	// only refer to its grouping columns.
+	switch aggr := aggr.(type) {
+	case *sqlparser.Min, *sqlparser.Max, *sqlparser.AnyValue:
+	case sqlparser.DistinctableAggr:
+		if !aggr.IsDistinct() {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	matches := true
+	clone := sqlparser.Clone(aggr)
+	out := sqlparser.Rewrite(clone, func(cursor *sqlparser.Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.Subquery:
+			matches = false
+		case sqlparser.AggrFunc:
+			// Nested aggregates are not valid.
+			if node != clone {
+				matches = false
+			}
+		case *sqlparser.ColName:
+			col, ok := rollupGroupColumn(node, rollup, qualifier)
+			if !ok {
+				matches = false
+				return false
+			}
+			cursor.Replace(col)
+		}
+		return matches
+	}, nil)
+	if !matches {
+		return nil, false
+	}
+	return out.(sqlparser.Expr), true
+}
+
+// planOnRollup returns a plan reading the rollup table instead of the base
+// table of the query when the rollup is fresh enough, given the plan of the
+// query on its base table, and the tables it uses. The plan on the base table
+// is returned as is if the rewritten query can't be planned.
+func planOnRollup(
+	base engine.Primitive,
+	tablesUsed []string,
+	rollupSel *sqlparser.Select,
+	rollupTable *vindexes.BaseTable,
+	reservedVars *sqlparser.ReservedVars,
+	vschema plancontext.VSchema,
+	plannerVersion querypb.ExecuteOptions_PlannerVersion,
+) (engine.Primitive, []string) {
+	plan, rollupTablesUsed, err := newBuildSelectPlan(rollupSel, reservedVars, vschema, plannerVersion)
+	if err != nil {
+		return base, tablesUsed
+	}
+	return &engine.Rollup{
+		Table:    rollupTable.String(),
+		Keyspace: rollupTable.Keyspace.Name,
+		MaxLag:   rollupTable.Rollup.MaxLag,
+		Rollup:   plan,
+		Base:     base,
+	}, append(tablesUsed, rollupTablesUsed...)
+}
@@ -27,6 +27,8 @@ import (
 
 // WireupRoute returns an engine primitive for the given route.
 func WireupRoute(ctx *plancontext.PlanningContext, eroute *engine.Route, sel sqlparser.SelectStatement) (engine.Primitive, error) {
+	sel = rewriteApproximations(sel)
+
 	// prepare the queries we will pass down
 	eroute.Query = sqlparser.String(sel)
 	buffer := sqlparser.NewTrackedBuffer(sqlparser.FormatImpossibleQuery)
@@ -87,3 +89,47 @@ func prepareTheAST(sel sqlparser.SelectStatement) {
 		return true, nil
 	}, sel)
 }
+
+// rewriteApproximations rewrites the approximate query processing functions
+// and clauses, which MySQL doesn't have, into a query MySQL can run. The
+// statement is copied, as its expressions may be shared with the plan.
+func rewriteApproximations(sel sqlparser.SelectStatement) sqlparser.SelectStatement {
+	return sqlparser.CopyOnRewrite(sel, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.ApproxCountDistinct:
+			// the distinct values counted by MySQL are counted exactly
+			cursor.Replace(&sqlparser.Count{Args: []sqlparser.Expr{node.Arg}, Distinct: true})
+		case *sqlparser.AliasedTableExpr:
+			if node.Sample != nil {
+				cursor.Replace(sampledTable(node))
+			}
+		}
+	}, nil).(sqlparser.SelectStatement)
+}
+
+// sampledTable returns the derived table reading the sample of the rows of a
+// table with a TABLESAMPLE clause. Each row is selected with the probability
+// of the sampled percentage, so each shard returns a sample of its own rows.
+func sampledTable(node *sqlparser.AliasedTableExpr) *sqlparser.AliasedTableExpr {
+	alias := node.As
+	if alias.IsEmpty() {
+		// the derived table takes the name of the table, so that the
+		// columns qualified with it still refer to its columns
+		alias = node.Expr.(sqlparser.TableName).Name
+	}
+	random := &sqlparser.BinaryExpr{
+		Operator: sqlparser.MultOp,
+		Left:     &sqlparser.FuncExpr{Name: sqlparser.NewIdentifierCI("rand")},
+		Right:    sqlparser.NewIntLiteral("100"),
+	}
+	sample := &sqlparser.Select{
+		SelectExprs: &sqlparser.SelectExprs{Exprs: []sqlparser.SelectExpr{&sqlparser.StarExpr{}}},
+		From:        []sqlparser.TableExpr{&sqlparser.AliasedTableExpr{Expr: node.Expr, Partitions: node.Partitions, Hints: node.Hints}},
+		Where:       sqlparser.NewWhere(sqlparser.WhereClause, &sqlparser.ComparisonExpr{Operator: sqlparser.LessThanOp, Left: random, Right: node.Sample.Percent}),
+	}
+	return &sqlparser.AliasedTableExpr{
+		Expr:    &sqlparser.DerivedTable{Select: sample},
+		As:      alias,
+		Columns: node.Columns,
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 func gen4SelectStmtPlanner(
@@ -62,6 +63,14 @@ func gen4SelectStmtPlanner(
 		sel.SQLCalcFoundRows = false
 	}
 
+	// The query is rewritten for its rollup before it is planned, as the
+	// planning modifies it.
+	var rollupSel *sqlparser.Select
+	var rollupTable *vindexes.BaseTable
+	if isSel {
+		rollupSel, rollupTable = rewriteOnRollup(sel, vschema)
+	}
+
 	getPlan := func(selStatement sqlparser.SelectStatement) (engine.Primitive, []string, error) {
 		return newBuildSelectPlan(selStatement, reservedVars, vschema, plannerVersion)
 	}
@@ -71,7 +80,7 @@ func gen4SelectStmtPlanner(
 		return nil, err
 	}
 
-	if shouldRetryAfterPredicateRewriting(plan) {
+	if !vschema.PlannerFlags().DisablePredicateRewriteRetry && shouldRetryAfterPredicateRewriting(plan) {
 		// by transforming the predicates to CNF, the planner will sometimes find better plans
 		// TODO: this should move to the operator side of planning
 		prim2, tablesUsed := gen4PredicateRewrite(stmt, getPlan)
@@ -88,17 +97,29 @@ func gen4SelectStmtPlanner(
 	// if it doesn't find a shard to send the query to.
 	// All other engine primitives can handle this, so we only need it when
 	// Route is the last (and only) instruction before the user sees a result
+	if rollupSel != nil {
+		plan, tablesUsed = planOnRollup(plan, tablesUsed, rollupSel, rollupTable, reservedVars, vschema, plannerVersion)
+	}
 	if isOnlyDual(sel) || (sel.GroupBy == nil && sel.SelectExprs.AllAggregation()) {
-		switch prim := plan.(type) {
-		case *engine.Route:
-			prim.NoRoutesSpecialHandling = true
-		case *engine.VindexLookup:
-			prim.SendTo.NoRoutesSpecialHandling = true
+		if prim, ok := plan.(*engine.Rollup); ok {
+			setNoRoutesSpecialHandling(prim.Rollup)
+			setNoRoutesSpecialHandling(prim.Base)
+		} else {
+			setNoRoutesSpecialHandling(plan)
 		}
 	}
 	return newPlanResult(plan, tablesUsed...), nil
 }
 
+func setNoRoutesSpecialHandling(plan engine.Primitive) {
+	switch prim := plan.(type) {
+	case *engine.Route:
+		prim.NoRoutesSpecialHandling = true
+	case *engine.VindexLookup:
+		prim.SendTo.NoRoutesSpecialHandling = true
+	}
+}
+
 func gen4planSQLCalcFoundRows(vschema plancontext.VSchema, sel *sqlparser.Select, query string, reservedVars *sqlparser.ReservedVars) (*planResult, error) {
 	ksName := ""
 	if ks, _ := vschema.SelectedKeyspace(); ks != nil {
@@ -292,6 +313,9 @@ func handleDualSelects(sel *sqlparser.Select, vschema plancontext.VSchema) (engi
 	if !isOnlyDual(sel) {
 		return nil, nil
 	}
+	if p, err := buildNextIDPrimitive(sel, vschema); p != nil || err != nil {
+		return p, err
+	}
 
 	columns := sel.GetColumns()
 	size := len(columns)
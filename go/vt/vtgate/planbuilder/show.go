@@ -118,7 +118,7 @@ func buildShowBasicPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema)
 		return buildPluginsPlan()
 	case sqlparser.Engines:
 		return buildEnginesPlan()
-	case sqlparser.VitessReplicationStatus, sqlparser.VitessShards, sqlparser.VitessTablets, sqlparser.VitessVariables:
+	case sqlparser.Grants, sqlparser.VitessPlannerFlags, sqlparser.VitessProcesslist, sqlparser.VitessQueryDigests, sqlparser.VitessReplicationStatus, sqlparser.VitessResultSizes, sqlparser.VitessShards, sqlparser.VitessTablets, sqlparser.VitessVariables, sqlparser.VitessVindexAdvice:
 		return &engine.ShowExec{
 			Command:    show.Command,
 			ShowFilter: show.Filter,
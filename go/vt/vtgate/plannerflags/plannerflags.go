@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plannerflags defines the flags toggling the behaviors of the vtgate
+// planner. They are set per keyspace in the vschema and per session with
+// @@vitess_planner_flags, so that new planner behaviors can be rolled out to
+// a subset of the applications first.
+package plannerflags
+
+import (
+	"slices"
+	"strings"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	// ApproximateCountDistinct allows computing the COUNT(DISTINCT) of the
+	// queries sent to several shards with HyperLogLog sketches computed by the
+	// shards, instead of sending all the distinct values to vtgate. The counts
+	// are then approximate, with an error of about 1%.
+	ApproximateCountDistinct = "approximate_count_distinct"
+	// HashJoin allows planning the joins which can't be executed as nested
+	// loop joins as hash joins.
+	HashJoin = "hash_join"
+	// PredicateRewriteRetry allows planning a SELECT again after rewriting its
+	// predicates, when its first plan sends an information_schema query to all
+	// the keyspaces.
+	PredicateRewriteRetry = "predicate_rewrite_retry"
+	// SubqueryPushdown controls which subqueries are merged into the route of
+	// their outer query instead of being executed separately by vtgate.
+	SubqueryPushdown = "subquery_pushdown"
+)
+
+const (
+	// On and Off are the values of the boolean flags.
+	On  = "on"
+	Off = "off"
+
+	// SubqueryPushdownAll merges all the subqueries which can be merged.
+	SubqueryPushdownAll = "all"
+	// SubqueryPushdownCorrelated only merges the correlated subqueries, the
+	// uncorrelated ones are executed once by vtgate and their results passed
+	// to the outer query.
+	SubqueryPushdownCorrelated = "correlated"
+)
+
+// Source tells where the value of a flag comes from.
+type Source string
+
+const (
+	SourceDefault  Source = "default"
+	SourceKeyspace Source = "keyspace"
+	SourceSession  Source = "session"
+)
+
+// definition is a flag with its default value and its valid values.
+type definition struct {
+	name   string
+	def    string
+	values []string
+}
+
+// definitions are the planner flags, sorted by name.
+var definitions = []definition{
+	{name: ApproximateCountDistinct, def: Off, values: []string{On, Off}},
+	{name: HashJoin, def: On, values: []string{On, Off}},
+	{name: PredicateRewriteRetry, def: On, values: []string{On, Off}},
+	{name: SubqueryPushdown, def: SubqueryPushdownAll, values: []string{SubqueryPushdownAll, SubqueryPushdownCorrelated}},
+}
+
+func findDefinition(name string) (definition, bool) {
+	for _, def := range definitions {
+		if def.name == name {
+			return def, true
+		}
+	}
+	return definition{}, false
+}
+
+// Flags are the planner flags in effect for a query. The zero value has all
+// the flags set to their default value.
+type Flags struct {
+	// ApproximateCountDistinct is set by approximate_count_distinct=on.
+	ApproximateCountDistinct bool
+	// DisableHashJoin is set by hash_join=off.
+	DisableHashJoin bool
+	// DisablePredicateRewriteRetry is set by predicate_rewrite_retry=off.
+	DisablePredicateRewriteRetry bool
+	// CorrelatedSubqueryPushdownOnly is set by subquery_pushdown=correlated.
+	CorrelatedSubqueryPushdownOnly bool
+}
+
+// String returns the flags as sorted flag=value pairs.
+func (f Flags) String() string {
+	subqueryPushdown := SubqueryPushdownAll
+	if f.CorrelatedSubqueryPushdownOnly {
+		subqueryPushdown = SubqueryPushdownCorrelated
+	}
+	return strings.Join([]string{
+		ApproximateCountDistinct + "=" + boolValue(f.ApproximateCountDistinct),
+		HashJoin + "=" + boolValue(!f.DisableHashJoin),
+		PredicateRewriteRetry + "=" + boolValue(!f.DisablePredicateRewriteRetry),
+		SubqueryPushdown + "=" + subqueryPushdown,
+	}, ",")
+}
+
+func boolValue(b bool) string {
+	if b {
+		return On
+	}
+	return Off
+}
+
+// Setting is the value of a planner flag along with where it comes from.
+type Setting struct {
+	Name   string
+	Value  string
+	Source Source
+}
+
+// Parse parses a comma-separated list of planner flags, as flag=value pairs.
+// An empty list clears the flags.
+func Parse(str string) (map[string]string, error) {
+	if strings.TrimSpace(str) == "" {
+		return nil, nil
+	}
+	flags := make(map[string]string)
+	for _, pair := range strings.Split(str, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid planner flag '%s': planner flags are flag=value pairs", pair)
+		}
+		flags[strings.ToLower(strings.TrimSpace(name))] = strings.ToLower(strings.TrimSpace(value))
+	}
+	if err := Validate(flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// Validate returns an error if one of the flags is unknown or has an invalid value.
+func Validate(flags map[string]string) error {
+	for name, value := range flags {
+		def, ok := findDefinition(name)
+		if !ok {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "unknown planner flag '%s'", name)
+		}
+		if !slices.Contains(def.values, value) {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid value '%s' for planner flag '%s', expected one of: %s", value, name, strings.Join(def.values, ", "))
+		}
+	}
+	return nil
+}
+
+// Resolve returns the planner flags in effect for a session, the flags set on
+// the session taking precedence over the flags set on its keyspace. Unknown
+// flags and invalid values are ignored.
+func Resolve(keyspaceFlags, sessionFlags map[string]string) Flags {
+	settings := Describe(keyspaceFlags, sessionFlags)
+	find := func(name string) string {
+		for _, setting := range settings {
+			if setting.Name == name {
+				return setting.Value
+			}
+		}
+		return ""
+	}
+	return Flags{
+		ApproximateCountDistinct:       find(ApproximateCountDistinct) == On,
+		DisableHashJoin:                find(HashJoin) == Off,
+		DisablePredicateRewriteRetry:   find(PredicateRewriteRetry) == Off,
+		CorrelatedSubqueryPushdownOnly: find(SubqueryPushdown) == SubqueryPushdownCorrelated,
+	}
+}
+
+// Describe returns all the planner flags, sorted by name, with their value in
+// effect for a session and where it comes from.
+func Describe(keyspaceFlags, sessionFlags map[string]string) []Setting {
+	settings := make([]Setting, 0, len(definitions))
+	for _, def := range definitions {
+		setting := Setting{Name: def.name, Value: def.def, Source: SourceDefault}
+		if value, ok := keyspaceFlags[def.name]; ok && slices.Contains(def.values, value) {
+			setting.Value, setting.Source = value, SourceKeyspace
+		}
+		if value, ok := sessionFlags[def.name]; ok && slices.Contains(def.values, value) {
+			setting.Value, setting.Source = value, SourceSession
+		}
+		settings = append(settings, setting)
+	}
+	return settings
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plannerflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		in      string
+		want    map[string]string
+		wantErr string
+	}{{
+		in: "",
+	}, {
+		in:   "hash_join=off",
+		want: map[string]string{HashJoin: Off},
+	}, {
+		in:   " HASH_JOIN = Off , subquery_pushdown=correlated",
+		want: map[string]string{HashJoin: Off, SubqueryPushdown: SubqueryPushdownCorrelated},
+	}, {
+		in:      "hash_join",
+		wantErr: "invalid planner flag 'hash_join': planner flags are flag=value pairs",
+	}, {
+		in:      "hash_joins=off",
+		wantErr: "unknown planner flag 'hash_joins'",
+	}, {
+		in:      "subquery_pushdown=none",
+		wantErr: "invalid value 'none' for planner flag 'subquery_pushdown', expected one of: all, correlated",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			flags, err := Parse(tc.in)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, flags)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, Flags{}, Resolve(nil, nil))
+	assert.Equal(t, "approximate_count_distinct=off,hash_join=on,predicate_rewrite_retry=on,subquery_pushdown=all", Flags{}.String())
+
+	keyspaceFlags := map[string]string{HashJoin: Off, SubqueryPushdown: SubqueryPushdownCorrelated}
+	sessionFlags := map[string]string{SubqueryPushdown: SubqueryPushdownAll, PredicateRewriteRetry: "invalid", ApproximateCountDistinct: On}
+	flags := Resolve(keyspaceFlags, sessionFlags)
+	assert.Equal(t, "approximate_count_distinct=on,hash_join=off,predicate_rewrite_retry=on,subquery_pushdown=all", flags.String())
+
+	assert.Equal(t, []Setting{
+		{Name: ApproximateCountDistinct, Value: On, Source: SourceSession},
+		{Name: HashJoin, Value: Off, Source: SourceKeyspace},
+		{Name: PredicateRewriteRetry, Value: On, Source: SourceDefault},
+		{Name: SubqueryPushdown, Value: SubqueryPushdownAll, Source: SourceSession},
+	}, Describe(keyspaceFlags, sessionFlags))
+}
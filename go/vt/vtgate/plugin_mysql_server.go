@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pires/go-proxyproto"
 	"github.com/spf13/pflag"
 
 	"vitess.io/vitess/go/mysql/replication"
@@ -47,6 +48,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vttls"
 )
 
@@ -72,11 +74,25 @@ var (
 	mysqlQueryTimeout             time.Duration
 	mysqlSlowConnectWarnThreshold time.Duration
 	mysqlConnBufferPooling        bool
+	mysqlMaxPreparedStatements    = 16382
+	mysqlServerQueryAttributes    bool
+	mysqlServerMultiplexSessions  bool
+	mysqlServerCompression        bool
+	mysqlServerLocalInfile        bool
+
+	mysqlServerCachingSha2PrivateKey string
+
+	mysqlServerIdleTimeout           time.Duration
+	mysqlServerMaxConnectionAge      time.Duration
+	mysqlServerMaxConnectionsPerUser int
 
 	mysqlDefaultWorkloadName = "OLTP"
 	mysqlDefaultWorkload     int32
 	mysqlDrainOnTerm         bool
 
+	mysqlServerHandoff        bool
+	mysqlServerHandoffTimeout = 30 * time.Second
+
 	mysqlServerFlushDelay = 100 * time.Millisecond
 )
 
@@ -101,13 +117,25 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&mysqlQueryTimeout, "mysql_server_query_timeout", mysqlQueryTimeout, "mysql query timeout")
 	fs.BoolVar(&mysqlConnBufferPooling, "mysql-server-pool-conn-read-buffers", mysqlConnBufferPooling, "If set, the server will pool incoming connection read buffers")
 	fs.DurationVar(&mysqlKeepAlivePeriod, "mysql-server-keepalive-period", mysqlKeepAlivePeriod, "TCP period between keep-alives")
+	fs.IntVar(&mysqlMaxPreparedStatements, "mysql-server-max-prepared-statements", mysqlMaxPreparedStatements, "Maximum number of prepared statements of a connection. The least recently used statement is evicted when a connection prepares one more. 0 means no limit.")
+	fs.BoolVar(&mysqlServerQueryAttributes, "mysql-server-query-attributes", mysqlServerQueryAttributes, "If set, the server accepts the query attributes of the clients, which are added to the query logs and as a comment to the queries sent to MySQL.")
+	fs.BoolVar(&mysqlServerMultiplexSessions, "mysql-server-multiplex-sessions", mysqlServerMultiplexSessions, "If set, the client connections share a pool of sessions, and hold one only while they execute a statement. A connection keeps its session while its statements leave state in it, like a transaction, variables or prepared statements.")
+	fs.DurationVar(&mysqlServerIdleTimeout, "mysql-server-idle-timeout", mysqlServerIdleTimeout, "Close the client connections which sent no command for longer than this. Their transactions are rolled back. 0 means no timeout.")
+	fs.DurationVar(&mysqlServerMaxConnectionAge, "mysql-server-max-connection-age", mysqlServerMaxConnectionAge, "Close the client connections older than this once they are outside of a transaction. Their statements fail with ER_SERVER_SHUTDOWN until they reconnect. 0 means no maximum age.")
+	fs.IntVar(&mysqlServerMaxConnectionsPerUser, "mysql-server-max-connections-per-user", mysqlServerMaxConnectionsPerUser, "Maximum number of client connections of a user. The connections of a user beyond it are refused with ER_TOO_MANY_USER_CONNECTIONS. 0 means no limit.")
+	fs.BoolVar(&mysqlServerCompression, "mysql-server-compression", mysqlServerCompression, "If set, the server supports the compressed protocol with zlib and zstd, negotiated by each client connection.")
+	fs.BoolVar(&mysqlServerLocalInfile, "mysql-server-local-infile", mysqlServerLocalInfile, "If set, the clients which enable it can load files with LOAD DATA LOCAL INFILE, whose rows are inserted in chunks of --load-data-chunk-rows rows.")
+	fs.StringVar(&mysqlServerCachingSha2PrivateKey, "mysql-server-caching-sha2-private-key", mysqlServerCachingSha2PrivateKey, "Path to an RSA private key in PEM format, used by caching_sha2_password to receive the password encrypted on connections without SSL.")
 	fs.DurationVar(&mysqlServerFlushDelay, "mysql_server_flush_delay", mysqlServerFlushDelay, "Delay after which buffered response will be flushed to the client.")
 	fs.StringVar(&mysqlDefaultWorkloadName, "mysql_default_workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
 	fs.BoolVar(&mysqlDrainOnTerm, "mysql-server-drain-onterm", mysqlDrainOnTerm, "If set, the server waits for --onterm_timeout for already connected clients to complete their in flight work")
+	fs.BoolVar(&mysqlServerHandoff, "mysql-server-handoff", mysqlServerHandoff, "If set, on SIGUSR2 the server starts a new process of its binary with the same arguments, hands its MySQL listening sockets over to it, and shuts down once the new process accepts connections. Requires --reuse-port.")
+	fs.DurationVar(&mysqlServerHandoffTimeout, "mysql-server-handoff-timeout", mysqlServerHandoffTimeout, "Time to wait for the new process started by a --mysql-server-handoff to accept MySQL connections, after which it is killed and the server keeps serving.")
 }
 
 // vtgateHandler implements the Listener interface.
-// It stores the Session in the ClientData of a Connection.
+// It stores the Session in the ClientData of a Connection, or a
+// multiplexedConn with --mysql-server-multiplex-sessions.
 type vtgateHandler struct {
 	mysql.UnimplementedHandler
 	mu sync.Mutex
@@ -116,12 +144,21 @@ type vtgateHandler struct {
 	connections map[uint32]*mysql.Conn
 
 	busyConnections atomic.Int32
+
+	// connectionStates are the states of the connections read by the reaper
+	// of the idle and old connections, by connection ID.
+	connectionStates map[uint32]*connectionState
+
+	// sessions is the pool of sessions of the client connections with
+	// --mysql-server-multiplex-sessions.
+	sessions sync.Pool
 }
 
 func newVtgateHandler(vtg *VTGate) *vtgateHandler {
 	return &vtgateHandler{
-		vtg:         vtg,
-		connections: make(map[uint32]*mysql.Conn),
+		vtg:              vtg,
+		connections:      make(map[uint32]*mysql.Conn),
+		connectionStates: make(map[uint32]*connectionState),
 	}
 }
 
@@ -129,6 +166,13 @@ func (vh *vtgateHandler) NewConnection(c *mysql.Conn) {
 	vh.mu.Lock()
 	defer vh.mu.Unlock()
 	vh.connections[c.ConnectionID] = c
+	vh.connectionStates[c.ConnectionID] = &connectionState{connected: time.Now()}
+}
+
+// ConnectionReady is part of the mysql.Handler interface.
+func (vh *vtgateHandler) ConnectionReady(c *mysql.Conn) {
+	vh.vtg.executor.processList.add(c)
+	vh.multiplexConnection(c)
 }
 
 func (vh *vtgateHandler) numConnections() int {
@@ -139,7 +183,10 @@ func (vh *vtgateHandler) numConnections() int {
 
 func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	ctx := context.Background()
-	session := vh.session(c)
+	session := vh.heldSession(c)
+	if session == nil {
+		return
+	}
 	if session.InTransaction {
 		defer vh.busyConnections.Add(-1)
 	}
@@ -147,6 +194,8 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	if err != nil {
 		log.Errorf("Error happened in transaction rollback: %v", err)
 	}
+	vh.setInTransaction(c, false)
+	vh.dropSession(c)
 }
 
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
@@ -154,7 +203,9 @@ func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 	defer func() {
 		vh.mu.Lock()
 		delete(vh.connections, c.ConnectionID)
+		delete(vh.connectionStates, c.ConnectionID)
 		vh.mu.Unlock()
+		vh.vtg.executor.processList.remove(c.ConnectionID)
 	}()
 
 	var ctx context.Context
@@ -165,7 +216,10 @@ func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 	} else {
 		ctx = context.Background()
 	}
-	session := vh.session(c)
+	session := vh.heldSession(c)
+	if session == nil {
+		return
+	}
 	if session.InTransaction {
 		defer vh.busyConnections.Add(-1)
 	}
@@ -207,8 +261,9 @@ func startSpan(ctx context.Context, query, label string) (trace.Span, context.Co
 }
 
 func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	defer vh.releaseSession(c)
 	session := vh.session(c)
-	if c.IsShuttingDown() && !session.InTransaction {
+	if !session.InTransaction && (c.IsShuttingDown() || vh.expireConnection(c)) {
 		c.MarkForClose()
 		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
 	}
@@ -240,6 +295,9 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		c.RemoteAddr().String(), /* component: running client process */
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
+	session.Options.QueryAttributes = c.QueryAttributes()
+	// The files of LOAD DATA LOCAL INFILE are sent by the client.
+	ctx = engine.WithLocalInfile(ctx, c.LocalInfile)
 
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
@@ -255,7 +313,7 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
-		fillInTxStatusFlags(c, session)
+		vh.fillInTxStatusFlags(c, session)
 		return nil
 	}
 	session, result, err := vh.vtg.Execute(ctx, vh, session, query, make(map[string]*querypb.BindVariable), false)
@@ -263,14 +321,15 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 	if err := sqlerror.NewSQLErrorFromError(err); err != nil {
 		return err
 	}
-	fillInTxStatusFlags(c, session)
+	vh.fillInTxStatusFlags(c, session)
 	return callback(result)
 }
 
 // ComQueryMulti is a newer version of ComQuery that supports running multiple queries in a single call.
 func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error) error {
+	defer vh.releaseSession(c)
 	session := vh.session(c)
-	if c.IsShuttingDown() && !session.InTransaction {
+	if !session.InTransaction && (c.IsShuttingDown() || vh.expireConnection(c)) {
 		c.MarkForClose()
 		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
 	}
@@ -297,6 +356,13 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		c.RemoteAddr().String(), /* component: running client process */
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
+	session.Options.QueryAttributes = c.QueryAttributes()
+	// The files of LOAD DATA LOCAL INFILE are sent by the client, which
+	// expects the request of a statement after the results of the previous
+	// ones: they are only loaded by the queries which are not split.
+	if c.Capabilities&mysql.CapabilityClientMultiStatements == 0 {
+		ctx = engine.WithLocalInfile(ctx, c.LocalInfile)
+	}
 
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
@@ -322,7 +388,7 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
-		fillInTxStatusFlags(c, session)
+		vh.fillInTxStatusFlags(c, session)
 		return nil
 	}
 	var results []*sqltypes.Result
@@ -341,7 +407,7 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		queryResults = append(queryResults, sqltypes.QueryResponse{QueryResult: result, QueryError: sqlerror.NewSQLErrorFromError(err)})
 	}
 
-	fillInTxStatusFlags(c, session)
+	vh.fillInTxStatusFlags(c, session)
 	for idx, res := range queryResults {
 		if callbackErr := callback(res, idx < len(queryResults)-1, true); callbackErr != nil {
 			return callbackErr
@@ -350,7 +416,8 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 	return nil
 }
 
-func fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
+func (vh *vtgateHandler) fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
+	vh.setInTransaction(c, session.InTransaction)
 	if session.InTransaction {
 		c.StatusFlags |= mysql.ServerStatusInTrans
 	} else {
@@ -388,6 +455,7 @@ func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Fie
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
 
+	defer vh.releaseSession(c)
 	session := vh.session(c)
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
@@ -429,7 +497,9 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
 
+	defer vh.releaseSession(c)
 	session := vh.session(c)
+	session.Options.QueryAttributes = prepare.QueryAttributes
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -439,25 +509,27 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 		}
 	}()
 
-	if session.Options.Workload == querypb.ExecuteOptions_OLAP {
+	// The rows of a cursor are streamed, and fetched by the client as it pages
+	// through them.
+	if session.Options.Workload == querypb.ExecuteOptions_OLAP || prepare.Cursor {
 		_, err := vh.vtg.StreamExecute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars, callback)
 		if err != nil {
 			return sqlerror.NewSQLErrorFromError(err)
 		}
-		fillInTxStatusFlags(c, session)
+		vh.fillInTxStatusFlags(c, session)
 		return nil
 	}
 	_, qr, err := vh.vtg.Execute(ctx, vh, session, prepare.PrepareStmt, prepare.BindVars, true)
 	if err != nil {
 		return sqlerror.NewSQLErrorFromError(err)
 	}
-	fillInTxStatusFlags(c, session)
+	vh.fillInTxStatusFlags(c, session)
 
 	return callback(qr)
 }
 
 func (vh *vtgateHandler) WarningCount(c *mysql.Conn) uint16 {
-	return uint16(len(vh.session(c).GetWarnings()))
+	return uint16(len(vh.heldSession(c).GetWarnings()))
 }
 
 // ComRegisterReplica is part of the mysql.Handler interface.
@@ -511,35 +583,69 @@ func (vh *vtgateHandler) Env() *vtenv.Environment {
 }
 
 func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
+	if mysqlServerMultiplexSessions {
+		return vh.multiplexedSession(c)
+	}
 	session, _ := c.ClientData.(*vtgatepb.Session)
 	if session == nil {
-		u, _ := uuid.NewUUID()
-		session = &vtgatepb.Session{
-			Options: &querypb.ExecuteOptions{
-				IncludedFields: querypb.ExecuteOptions_ALL,
-				Workload:       querypb.ExecuteOptions_Workload(mysqlDefaultWorkload),
-
-				// The collation field of ExecuteOption is set right before an execution.
-			},
-			Autocommit:           true,
-			DDLStrategy:          defaultDDLStrategy,
-			MigrationContext:     "",
-			SessionUUID:          u.String(),
-			EnableSystemSettings: sysVarSetEnabled,
-		}
-		if c.Capabilities&mysql.CapabilityClientFoundRows != 0 {
-			session.Options.ClientFoundRows = true
-		}
+		session = newSession(c)
 		c.ClientData = session
 	}
 	return session
 }
 
+// heldSession returns the session of the connection, or nil if the
+// connection does not hold one with --mysql-server-multiplex-sessions.
+func (vh *vtgateHandler) heldSession(c *mysql.Conn) *vtgatepb.Session {
+	if mysqlServerMultiplexSessions {
+		mc, _ := c.ClientData.(*multiplexedConn)
+		if mc == nil {
+			return nil
+		}
+		return mc.session
+	}
+	return vh.session(c)
+}
+
+// newSession returns the session of a new connection.
+func newSession(c *mysql.Conn) *vtgatepb.Session {
+	u, _ := uuid.NewUUID()
+	session := defaultSession()
+	session.SessionUUID = u.String()
+	if c.Capabilities&mysql.CapabilityClientFoundRows != 0 {
+		session.Options.ClientFoundRows = true
+	}
+	return session
+}
+
+// defaultSession returns the session of a new connection, without the fields
+// which depend on the connection.
+func defaultSession() *vtgatepb.Session {
+	return &vtgatepb.Session{
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+			Workload:       querypb.ExecuteOptions_Workload(mysqlDefaultWorkload),
+
+			// The collation field of ExecuteOption is set right before an execution.
+		},
+		Autocommit:           true,
+		DDLStrategy:          defaultDDLStrategy,
+		MigrationContext:     "",
+		EnableSystemSettings: sysVarSetEnabled,
+	}
+}
+
 type mysqlServer struct {
 	tcpListener  *mysql.Listener
 	unixListener *mysql.Listener
 	sigChan      chan os.Signal
 	vtgateHandle *vtgateHandler
+	// handoffChan receives the signals to hand the listeners over to a new
+	// process, with --mysql-server-handoff.
+	handoffChan chan os.Signal
+	// reaperDone stops the reaper of the idle and old connections once
+	// closed.
+	reaperDone chan struct{}
 }
 
 // initTLSConfig inits tls config for the given mysql listener
@@ -585,10 +691,7 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		return nil
 	}
 
-	// Initialize registered AuthServer implementations (or other plugins)
-	for _, initFn := range pluginInitializers {
-		initFn()
-	}
+	initPlugins()
 	authServer := mysql.GetAuthServer(mysqlAuthServerImpl)
 
 	// Check mysql_default_workload
@@ -604,19 +707,37 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		log.Exitf("-mysql_tcp_version must be one of [tcp, tcp4, tcp6]")
 	}
 
+	if mysqlServerHandoff && !servenv.ReusePort() {
+		log.Exitf("--mysql-server-handoff requires --reuse-port, for the new process to listen on the HTTP and gRPC ports")
+	}
+
+	// Use the listening sockets passed by systemd or by the vtgate handing
+	// its listeners over, if any.
+	inheritedTCP, inheritedUnix, err := inheritedListeners()
+	if err != nil {
+		log.Exitf("Cannot use the inherited MySQL listeners: %v", err)
+	}
+
 	// Create a Listener.
-	var err error
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
 	if mysqlServerPort >= 0 {
-		srv.tcpListener, err = mysql.NewListener(
-			mysqlTCPVersion,
-			net.JoinHostPort(mysqlServerBindAddress, fmt.Sprintf("%v", mysqlServerPort)),
+		listener := inheritedTCP
+		if listener == nil {
+			listener, err = servenv.Listen(mysqlTCPVersion, net.JoinHostPort(mysqlServerBindAddress, fmt.Sprintf("%v", mysqlServerPort)))
+			if err != nil {
+				log.Exitf("mysql.NewListener failed: %v", err)
+			}
+		}
+		if mysqlProxyProtocol {
+			listener = &proxyproto.Listener{Listener: listener}
+		}
+		srv.tcpListener, err = mysql.NewFromListener(
+			listener,
 			authServer,
 			srv.vtgateHandle,
 			mysqlConnReadTimeout,
 			mysqlConnWriteTimeout,
-			mysqlProxyProtocol,
 			mysqlConnBufferPooling,
 			mysqlKeepAlivePeriod,
 			mysqlServerFlushDelay,
@@ -633,6 +754,22 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
 		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		srv.tcpListener.MaxPreparedStatements.Store(int64(mysqlMaxPreparedStatements))
+		srv.tcpListener.QueryAttributes.Store(mysqlServerQueryAttributes)
+		srv.tcpListener.MaxConnectionsPerUser.Store(int64(mysqlServerMaxConnectionsPerUser))
+		srv.tcpListener.Compression.Store(mysqlServerCompression)
+		srv.tcpListener.LocalInfile.Store(mysqlServerLocalInfile)
+		if mysqlServerCachingSha2PrivateKey != "" {
+			data, err := os.ReadFile(mysqlServerCachingSha2PrivateKey)
+			if err != nil {
+				log.Exitf("mysql.NewListener failed: %v", err)
+			}
+			key, err := mysql.ParseCachingSha2PrivateKey(data)
+			if err != nil {
+				log.Exitf("mysql.NewListener failed: %v", err)
+			}
+			srv.tcpListener.CachingSha2PrivateKey.Store(key)
+		}
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
@@ -643,11 +780,21 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	}
 
 	if mysqlServerSocketPath != "" {
-		err = setupUnixSocket(srv, authServer, mysqlServerSocketPath)
+		err = setupUnixSocket(srv, authServer, mysqlServerSocketPath, inheritedUnix)
 		if err != nil {
 			log.Exitf("mysql.NewListener failed: %v", err)
 		}
 	}
+
+	if interval := connectionReapInterval(); interval > 0 {
+		srv.reaperDone = make(chan struct{})
+		go srv.vtgateHandle.reapConnections(interval, srv.reaperDone)
+	}
+
+	if mysqlServerHandoff {
+		srv.watchHandoff()
+	}
+	signalHandoffReady()
 	return srv
 }
 
@@ -709,6 +856,12 @@ func (srv *mysqlServer) shutdownMysqlProtocolAndDrain() {
 	if srv.sigChan != nil {
 		signal.Stop(srv.sigChan)
 	}
+	if srv.handoffChan != nil {
+		signal.Stop(srv.handoffChan)
+	}
+	if srv.reaperDone != nil {
+		defer close(srv.reaperDone)
+	}
 	setListenerToNil := func() {
 		srv.tcpListener = nil
 		srv.unixListener = nil
@@ -808,7 +961,37 @@ func init() {
 	servenv.OnParseFor("vtcombo", registerPluginFlags)
 }
 
-var pluginInitializers []func()
+var (
+	pluginInitializers []func()
+	initPluginsOnce    sync.Once
+)
+
+// initPlugins initializes the registered AuthServer implementations (or
+// other plugins). It is shared by the MySQL and PostgreSQL listeners, and
+// only runs the initializers once.
+func initPlugins() {
+	initPluginsOnce.Do(func() {
+		for _, initFn := range pluginInitializers {
+			initFn()
+		}
+	})
+}
+
+// plainTextAuthServer returns the auth server configured with
+// --mysql_auth_server_impl, for the protocols which authenticate users with
+// a clear text password. It returns nil if authentication is disabled, and
+// exits if the auth server does not support clear text passwords.
+func plainTextAuthServer(protocol string) mysql.PlainTextStorage {
+	initPlugins()
+	if mysqlAuthServerImpl == "none" {
+		return nil
+	}
+	authServer, ok := mysql.GetAuthServer(mysqlAuthServerImpl).(mysql.PlainTextStorage)
+	if !ok {
+		log.Exitf("%s requires an auth server supporting clear text passwords, %q does not", protocol, mysqlAuthServerImpl)
+	}
+	return authServer
+}
 
 // RegisterPluginInitializer lets plugins register themselves to be init'ed at servenv.OnRun-time
 func RegisterPluginInitializer(initializer func()) {
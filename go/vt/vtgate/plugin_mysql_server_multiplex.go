@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/stats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	sessionsReleased = stats.NewCounter("MysqlServerSessionsReleased", "Number of statements of the client connections after which their session was returned to the pool, with --mysql-server-multiplex-sessions")
+	sessionsKept     = stats.NewCountersWithSingleLabel("MysqlServerSessionsKept", "Number of statements of the client connections after which they kept their session, with --mysql-server-multiplex-sessions, by the state left in the session", "State")
+)
+
+// multiplexedConn is the ClientData of a client connection with
+// --mysql-server-multiplex-sessions. The connection holds a session of the
+// pool only while it executes a statement, and keeps it as long as its
+// statements leave state in it.
+type multiplexedConn struct {
+	// session is the session held by the connection, if any.
+	session *vtgatepb.Session
+	// target is the target string of the connection once it is ready, which
+	// is the database it connected to, if any.
+	target string
+	// ready is set once the handshake is done. Until then, the connection
+	// keeps its session, for the database it connects to to be its target
+	// rather than a change of its session.
+	ready bool
+	// sessionUUID is the SessionUUID of the connection, which the sessions it
+	// takes from the pool get.
+	sessionUUID string
+	// lastInsertID is the LAST_INSERT_ID() of the connection when it returned
+	// its last session to the pool, which the next session it takes gets.
+	lastInsertID uint64
+}
+
+func multiplexedConnOf(c *mysql.Conn) *multiplexedConn {
+	mc, _ := c.ClientData.(*multiplexedConn)
+	if mc == nil {
+		u, _ := uuid.NewUUID()
+		mc = &multiplexedConn{sessionUUID: u.String()}
+		c.ClientData = mc
+	}
+	return mc
+}
+
+// multiplexedSession returns the session held by the connection, or takes one
+// from the pool, which the connection holds until the end of the statement.
+func (vh *vtgateHandler) multiplexedSession(c *mysql.Conn) *vtgatepb.Session {
+	mc := multiplexedConnOf(c)
+	if mc.session != nil {
+		return mc.session
+	}
+	session, _ := vh.sessions.Get().(*vtgatepb.Session)
+	if session == nil {
+		session = defaultSession()
+	}
+	setConnectionState(session, c, mc)
+	mc.session = session
+	return session
+}
+
+// setConnectionState sets the fields of a session of the pool which belong
+// to the connection that takes it.
+func setConnectionState(session *vtgatepb.Session, c *mysql.Conn, mc *multiplexedConn) {
+	session.TargetString = mc.target
+	session.SessionUUID = mc.sessionUUID
+	session.LastInsertId = mc.lastInsertID
+	session.Options.ClientFoundRows = c.Capabilities&mysql.CapabilityClientFoundRows != 0
+}
+
+// resetStatementState clears the fields of a session which only hold the
+// results of the last statement, before the session is returned to the pool.
+func resetStatementState(session *vtgatepb.Session) {
+	session.FoundRows = 0
+	session.RowCount = 0
+	session.LastLockHeartbeat = 0
+	session.Options.QueryAttributes = nil
+}
+
+// multiplexConnection starts multiplexing the sessions of a connection which
+// is ready, with --mysql-server-multiplex-sessions.
+func (vh *vtgateHandler) multiplexConnection(c *mysql.Conn) {
+	if !mysqlServerMultiplexSessions {
+		return
+	}
+	mc := multiplexedConnOf(c)
+	if mc.session != nil {
+		mc.target = mc.session.TargetString
+	}
+	mc.ready = true
+	vh.releaseSession(c)
+}
+
+// releaseSession returns the session held by the connection to the pool at
+// the end of a statement, unless the statements of the connection left state
+// in it, like a transaction or variables. The connection then keeps its
+// session until its state is back to the one of a new session.
+//
+// The rows found and affected by the last statement are not state: the
+// FOUND_ROWS() and ROW_COUNT() of the first statement executed with a session
+// of the pool are 0. The LAST_INSERT_ID() of the connection is not state
+// either, the connection carries it from a session to the next.
+func (vh *vtgateHandler) releaseSession(c *mysql.Conn) {
+	if !mysqlServerMultiplexSessions {
+		return
+	}
+	mc, _ := c.ClientData.(*multiplexedConn)
+	if mc == nil || mc.session == nil || !mc.ready {
+		return
+	}
+	if state := sessionState(mc.session, mc.target); state != "" {
+		sessionsKept.Add(state, 1)
+		return
+	}
+	session := mc.session
+	mc.session = nil
+	mc.lastInsertID = session.LastInsertId
+	resetStatementState(session)
+	vh.sessions.Put(session)
+	sessionsReleased.Add(1)
+}
+
+// dropSession makes the connection stop holding its session, which is not
+// returned to the pool, with --mysql-server-multiplex-sessions.
+func (vh *vtgateHandler) dropSession(c *mysql.Conn) {
+	if !mysqlServerMultiplexSessions {
+		return
+	}
+	if mc, _ := c.ClientData.(*multiplexedConn); mc != nil {
+		mc.session = nil
+	}
+}
+
+// sessionState returns the kind of state left in the session by the
+// statements of a connection with the given target, or an empty string if the
+// session is in the state of a new session, and can be shared with other
+// connections.
+//
+// The session is compared as a whole with a new session, but for the fields
+// set by setConnectionState and reset by resetStatementState, so that any
+// other field is state.
+func sessionState(session *vtgatepb.Session, target string) string {
+	shared := session.CloneVT()
+	if shared.Options == nil {
+		shared.Options = &querypb.ExecuteOptions{}
+	}
+	resetStatementState(shared)
+	setConnectionState(shared, &mysql.Conn{}, &multiplexedConn{})
+	if session.TargetString == target && proto.Equal(shared, defaultSession()) {
+		return ""
+	}
+
+	switch {
+	case session.InTransaction || len(session.ShardSessions) > 0 || len(session.PreSessions) > 0 || len(session.PostSessions) > 0 ||
+		len(session.Savepoints) > 0 || session.ErrorUntilRollback:
+		return "Transaction"
+	case session.InReservedConn:
+		return "ReservedConnection"
+	case session.LockSession != nil || len(session.AdvisoryLock) > 0:
+		return "Lock"
+	case len(session.UserDefinedVariables) > 0 || len(session.SystemVariables) > 0:
+		return "Variables"
+	case len(session.PrepareStatement) > 0:
+		return "PreparedStatements"
+	case len(session.Warnings) > 0 || session.ShardErrors != "" || session.ConsistentReadPositions != "":
+		return "Warnings"
+	case session.TargetString != target:
+		return "Target"
+	}
+	return "Settings"
+}
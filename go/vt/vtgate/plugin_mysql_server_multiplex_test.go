@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestMultiplexedSessions(t *testing.T) {
+	defer func(multiplex bool) { mysqlServerMultiplexSessions = multiplex }(mysqlServerMultiplexSessions)
+	mysqlServerMultiplexSessions = true
+
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), &testHandler{}, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	newConn := func(id uint32, schema string) *mysql.Conn {
+		c := mysql.GetTestServerConn(listener)
+		c.ConnectionID = id
+		c.UserData = &mysql.StaticUserData{}
+		vh.NewConnection(c)
+		if schema != "" {
+			require.NoError(t, vh.ComQuery(c, "use "+schema, func(*sqltypes.Result) error { return nil }))
+		}
+		vh.ConnectionReady(c)
+		return c
+	}
+	exec := func(c *mysql.Conn, query string) {
+		t.Helper()
+		require.NoError(t, vh.ComQuery(c, query, func(*sqltypes.Result) error { return nil }))
+	}
+	held := func(c *mysql.Conn) *vtgatepb.Session {
+		return c.ClientData.(*multiplexedConn).session
+	}
+
+	c1 := newConn(1, "")
+	c2 := newConn(2, KsTestUnsharded)
+	assert.Nil(t, held(c1))
+	assert.Nil(t, held(c2), "the database the client connects to is not a change of the session")
+	assert.Zero(t, vh.WarningCount(c1))
+	assert.Nil(t, held(c1), "a connection without a session has no warnings")
+
+	initialReleased := sessionsReleased.Get()
+	exec(c1, "select id from user where id = 1")
+	exec(c2, "select id from main1")
+	assert.Nil(t, held(c1))
+	assert.Nil(t, held(c2))
+	assert.EqualValues(t, 2, sessionsReleased.Get()-initialReleased)
+	assert.Equal(t, KsTestUnsharded, vh.session(c2).TargetString, "the session of the pool has the target of the connection")
+	vh.releaseSession(c2)
+
+	// The session of the pool has the UUID of the connection which takes it,
+	// which does not change from a statement to the next.
+	uuid1, uuid2 := vh.session(c1).SessionUUID, vh.session(c2).SessionUUID
+	assert.NotEmpty(t, uuid1)
+	assert.NotEqual(t, uuid1, uuid2)
+	vh.releaseSession(c1)
+	vh.releaseSession(c2)
+	exec(c2, "select id from main1")
+	assert.Equal(t, uuid2, vh.session(c2).SessionUUID)
+	vh.releaseSession(c2)
+	assert.Equal(t, uuid1, vh.session(c1).SessionUUID)
+	vh.releaseSession(c1)
+
+	// The LAST_INSERT_ID() of the connection is carried from a session of the
+	// pool to the next, without keeping the session.
+	exec(c1, "select last_insert_id(5) from dual")
+	assert.Nil(t, held(c1))
+	exec(c2, "select id from main1")
+	assert.Zero(t, vh.session(c2).LastInsertId)
+	vh.releaseSession(c2)
+	var lastInsertID *sqltypes.Result
+	require.NoError(t, vh.ComQuery(c1, "select last_insert_id() from dual", func(qr *sqltypes.Result) error {
+		lastInsertID = qr
+		return nil
+	}))
+	assert.Nil(t, held(c1))
+	assert.Equal(t, `[[UINT64(5)]]`, fmt.Sprintf("%v", lastInsertID.Rows))
+
+	// The connection keeps its session during a transaction.
+	initialKept := sessionsKept.Counts()["Transaction"]
+	exec(c1, "begin")
+	session := held(c1)
+	require.NotNil(t, session)
+	exec(c1, "select id from user where id = 1")
+	assert.Same(t, session, held(c1))
+	assert.EqualValues(t, 2, sessionsKept.Counts()["Transaction"]-initialKept)
+	exec(c1, "commit")
+	assert.Nil(t, held(c1))
+
+	// The connection keeps its session with variables, or another database.
+	exec(c1, "set @foo = 1")
+	assert.NotNil(t, held(c1))
+	exec(c2, "use "+KsTestSharded)
+	assert.NotNil(t, held(c2))
+	exec(c2, "use "+KsTestUnsharded)
+	assert.Nil(t, held(c2))
+
+	// The connection which is reset does not keep its session.
+	vh.ComResetConnection(c1)
+	assert.Nil(t, held(c1))
+	vh.ConnectionClosed(c1)
+	vh.ConnectionClosed(c2)
+}
+
+func TestSessionState(t *testing.T) {
+	// The session of a connection is shared only in the state of a new
+	// session, but for the results of the last statement.
+	notState := map[protoreflect.Name]bool{
+		"found_rows":          true,
+		"row_count":           true,
+		"last_lock_heartbeat": true,
+		"SessionUUID":         true,
+		"last_insert_id":      true,
+	}
+	require.Empty(t, sessionState(newSession(&mysql.Conn{}), ""))
+
+	fields := (&vtgatepb.Session{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		t.Run(string(fd.Name()), func(t *testing.T) {
+			session := newSession(&mysql.Conn{})
+			msg := session.ProtoReflect()
+			switch {
+			case fd.IsList():
+				list := msg.Mutable(fd).List()
+				if fd.Kind() == protoreflect.MessageKind {
+					list.AppendMutable()
+				} else {
+					list.Append(protoreflect.ValueOfString("x"))
+				}
+			case fd.IsMap():
+				m := msg.Mutable(fd).Map()
+				key := protoreflect.ValueOfString("x").MapKey()
+				switch fd.MapValue().Kind() {
+				case protoreflect.MessageKind:
+					m.Mutable(key)
+				case protoreflect.StringKind:
+					m.Set(key, protoreflect.ValueOfString("x"))
+				default:
+					m.Set(key, protoreflect.ValueOfInt64(1))
+				}
+			case fd.Kind() == protoreflect.MessageKind:
+				msg.Set(fd, protoreflect.ValueOfMessage(msg.NewField(fd).Message()))
+			case fd.Kind() == protoreflect.BoolKind:
+				msg.Set(fd, protoreflect.ValueOfBool(!msg.Get(fd).Bool()))
+			case fd.Kind() == protoreflect.StringKind:
+				msg.Set(fd, protoreflect.ValueOfString(msg.Get(fd).String()+"x"))
+			case fd.Kind() == protoreflect.EnumKind:
+				msg.Set(fd, protoreflect.ValueOfEnum(1))
+			case fd.Kind() == protoreflect.Uint64Kind:
+				msg.Set(fd, protoreflect.ValueOfUint64(1))
+			default:
+				msg.Set(fd, protoreflect.ValueOfInt64(1))
+			}
+			if notState[fd.Name()] {
+				assert.Empty(t, sessionState(session, ""))
+			} else {
+				assert.NotEmpty(t, sessionState(session, ""), "a change of %s is state", fd.Name())
+			}
+			assertShared(t, session)
+		})
+	}
+
+	// The fields of the options which belong to a connection or a statement.
+	session := newSession(&mysql.Conn{})
+	session.Options.ClientFoundRows = true
+	session.Options.QueryAttributes = map[string]string{"x": "y"}
+	assert.Empty(t, sessionState(session, ""))
+	assertShared(t, session)
+}
+
+// assertShared asserts that a session which is not state, once returned to
+// the pool and taken by another connection, is the session of a new
+// connection, so that no field which is neither compared by sessionState nor
+// reset is shared between connections.
+func assertShared(t *testing.T, session *vtgatepb.Session) {
+	t.Helper()
+	if sessionState(session, "") != "" {
+		return
+	}
+	c := &mysql.Conn{Capabilities: mysql.CapabilityClientFoundRows}
+	want := newSession(c)
+	resetStatementState(session)
+	setConnectionState(session, c, &multiplexedConn{sessionUUID: want.SessionUUID})
+	assert.True(t, proto.Equal(want, session), "a field of the session is shared: %v", session)
+}
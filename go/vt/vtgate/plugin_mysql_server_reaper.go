@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+)
+
+var connReaped = stats.NewCountersWithSingleLabel("MysqlServerConnReaped", "Number of client connections closed by the server, with --mysql-server-idle-timeout or --mysql-server-max-connection-age, by policy", "Policy")
+
+const (
+	reapIdleTimeout      = "IdleTimeout"
+	reapMaxConnectionAge = "MaxConnectionAge"
+)
+
+// connectionState is the state of a client connection read by the reaper of
+// the idle and old connections, which does not run on the goroutine of the
+// connection.
+type connectionState struct {
+	connected     time.Time
+	inTransaction atomic.Bool
+}
+
+// setInTransaction records whether the connection is in a transaction after
+// its last statement.
+func (vh *vtgateHandler) setInTransaction(c *mysql.Conn, inTransaction bool) {
+	vh.mu.Lock()
+	state := vh.connectionStates[c.ConnectionID]
+	vh.mu.Unlock()
+	if state != nil {
+		state.inTransaction.Store(inTransaction)
+	}
+}
+
+// expireConnection returns whether the connection is older than
+// --mysql-server-max-connection-age. Its statements outside of a transaction
+// then fail with ER_SERVER_SHUTDOWN, and it is closed, like on the shutdown of
+// the server, for the client to connect again.
+func (vh *vtgateHandler) expireConnection(c *mysql.Conn) bool {
+	if mysqlServerMaxConnectionAge == 0 {
+		return false
+	}
+	vh.mu.Lock()
+	state := vh.connectionStates[c.ConnectionID]
+	vh.mu.Unlock()
+	if state == nil || time.Since(state.connected) < mysqlServerMaxConnectionAge {
+		return false
+	}
+	connReaped.Add(reapMaxConnectionAge, 1)
+	return true
+}
+
+// connectionReapInterval returns how often the connections are checked
+// against --mysql-server-idle-timeout and --mysql-server-max-connection-age,
+// or 0 if neither is set.
+func connectionReapInterval() time.Duration {
+	var interval time.Duration
+	for _, timeout := range []time.Duration{mysqlServerIdleTimeout, mysqlServerMaxConnectionAge} {
+		if timeout > 0 && (interval == 0 || timeout < interval) {
+			interval = timeout
+		}
+	}
+	if interval == 0 {
+		return 0
+	}
+	return min(max(interval/10, time.Millisecond), time.Minute)
+}
+
+// reapConnections closes the idle and old connections every interval, until
+// done is closed.
+func (vh *vtgateHandler) reapConnections(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			vh.reapIdleConnections()
+		}
+	}
+}
+
+// reapIdleConnections closes the connections waiting for the next command of
+// their client for longer than --mysql-server-idle-timeout, with
+// ER_CLIENT_INTERACTION_TIMEOUT, and those older than
+// --mysql-server-max-connection-age outside of a transaction, with
+// ER_SERVER_SHUTDOWN. The connections are closed by their own goroutine, so
+// that one which receives a command in the meantime answers it with the error
+// instead of being closed while it executes it. Closing a connection triggers
+// ConnectionClosed, which rolls back its transaction.
+func (vh *vtgateHandler) reapIdleConnections() {
+	vh.mu.Lock()
+	defer vh.mu.Unlock()
+	for id, c := range vh.connections {
+		state := vh.connectionStates[id]
+		c.CloseIfIdle(func(idle time.Duration) *sqlerror.SQLError {
+			var (
+				policy string
+				err    *sqlerror.SQLError
+			)
+			switch {
+			case mysqlServerIdleTimeout > 0 && idle >= mysqlServerIdleTimeout:
+				policy = reapIdleTimeout
+				err = sqlerror.NewSQLError(sqlerror.ERClientInteractionTimeout, sqlerror.SSUnknownSQLState, "The client was disconnected by the server because of inactivity. See --mysql-server-idle-timeout for configuring this behavior.")
+			case mysqlServerMaxConnectionAge > 0 && state != nil && !state.inTransaction.Load() && time.Since(state.connected) >= mysqlServerMaxConnectionAge:
+				policy = reapMaxConnectionAge
+				err = sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
+			default:
+				return nil
+			}
+			log.Infof("Closing connection %d of %s, idle for %v: %s", id, c.User, idle, policy)
+			connReaped.Add(policy, 1)
+			return err
+		})
+	}
+}
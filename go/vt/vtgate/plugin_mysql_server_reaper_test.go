@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestReapIdleConnections(t *testing.T) {
+	defer func(timeout time.Duration) { mysqlServerIdleTimeout = timeout }(mysqlServerIdleTimeout)
+
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), vh, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+	go listener.Accept()
+
+	host, portStr, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	client, err := mysql.Connect(context.Background(), &mysql.ConnParams{Host: host, Port: port, Uname: "user1"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.ExecuteFetch("begin", 1, false)
+	require.NoError(t, err)
+
+	// The connection is not closed before its idle timeout.
+	mysqlServerIdleTimeout = time.Hour
+	initialReaped := connReaped.Counts()[reapIdleTimeout]
+	vh.reapIdleConnections()
+	_, err = client.ExecuteFetch("select id from user where id = 1", 1, false)
+	require.NoError(t, err)
+
+	// The connection in a transaction is closed once idle for longer than
+	// its timeout, which rolls back its transaction. Its client is sent
+	// ER_CLIENT_INTERACTION_TIMEOUT first.
+	mysqlServerIdleTimeout = time.Millisecond
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		vh.reapIdleConnections()
+		assert.EqualValues(t, 1, connReaped.Counts()[reapIdleTimeout]-initialReaped)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return vh.numConnections() == 0 }, 5*time.Second, 10*time.Millisecond)
+	// The error is not the reply to a command, so it is read from the
+	// socket: its sequence number is 0, like in MySQL.
+	header := make([]byte, 4)
+	_, err = io.ReadFull(client.GetRawConn(), header)
+	require.NoError(t, err)
+	require.Zero(t, header[3])
+	data := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err = io.ReadFull(client.GetRawConn(), data)
+	require.NoError(t, err)
+	var sqlErr *sqlerror.SQLError
+	require.ErrorAs(t, mysql.ParseErrorPacket(data), &sqlErr)
+	assert.Equal(t, sqlerror.ERClientInteractionTimeout, sqlErr.Number())
+	_, err = client.ExecuteFetch("select id from user where id = 1", 1, false)
+	require.Error(t, err)
+}
+
+func TestExpireConnection(t *testing.T) {
+	defer func(age time.Duration) { mysqlServerMaxConnectionAge = age }(mysqlServerMaxConnectionAge)
+
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(newVTGate(executor, nil, nil, nil, nil))
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), &testHandler{}, 0, 0, false, false, 0, 0)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	c := mysql.GetTestServerConn(listener)
+	c.ConnectionID = 1
+	c.UserData = &mysql.StaticUserData{}
+	vh.NewConnection(c)
+	exec := func(query string) error {
+		return vh.ComQuery(c, query, func(*sqltypes.Result) error { return nil })
+	}
+
+	mysqlServerMaxConnectionAge = time.Hour
+	require.NoError(t, exec("begin"))
+	assert.True(t, vh.connectionStates[1].inTransaction.Load())
+
+	// The old connection finishes its transaction, and is closed after it.
+	mysqlServerMaxConnectionAge = time.Nanosecond
+	initialReaped := connReaped.Counts()[reapMaxConnectionAge]
+	require.NoError(t, exec("select id from user where id = 1"))
+	require.NoError(t, exec("commit"))
+	assert.False(t, vh.connectionStates[1].inTransaction.Load())
+	assert.False(t, c.IsMarkedForClose())
+
+	require.EqualError(t, exec("select id from user where id = 1"), "Server shutdown in progress (errno 1053) (sqlstate 08S01)")
+	assert.True(t, c.IsMarkedForClose())
+	assert.EqualValues(t, 1, connReaped.Counts()[reapMaxConnectionAge]-initialReaped)
+
+	vh.ConnectionClosed(c)
+	assert.Empty(t, vh.connectionStates)
+}
+
+func TestConnectionReapInterval(t *testing.T) {
+	defer func(timeout, age time.Duration) {
+		mysqlServerIdleTimeout, mysqlServerMaxConnectionAge = timeout, age
+	}(mysqlServerIdleTimeout, mysqlServerMaxConnectionAge)
+
+	tcases := []struct {
+		idleTimeout, maxAge time.Duration
+		want                time.Duration
+	}{
+		{0, 0, 0},
+		{10 * time.Second, 0, time.Second},
+		{0, 10 * time.Second, time.Second},
+		{10 * time.Second, 30 * time.Second, time.Second},
+		{time.Hour, 20 * time.Minute, time.Minute},
+		{time.Millisecond, 0, time.Millisecond},
+	}
+	for _, tcase := range tcases {
+		mysqlServerIdleTimeout, mysqlServerMaxConnectionAge = tcase.idleTimeout, tcase.maxAge
+		assert.Equal(t, tcase.want, connectionReapInterval(), "idle timeout %v, max connection age %v", tcase.idleTimeout, tcase.maxAge)
+	}
+}
@@ -19,22 +19,241 @@ limitations under the License.
 package vtgate
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	osExec "os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
 )
 
-func setupUnixSocket(srv *mysqlServer, authServer mysql.AuthServer, path string) error {
-	// Let's create this unix socket with permissions to all users. In this way,
-	// clients can connect to vtgate mysql server without being vtgate user
+const (
+	// listenFdsStart is the first file descriptor of the listening sockets
+	// passed with LISTEN_FDS, as in the systemd socket activation protocol.
+	listenFdsStart = 3
+
+	// handoffReadyFdEnv is the environment variable with the file descriptor
+	// on which a process started by a handoff writes once it accepts MySQL
+	// connections.
+	handoffReadyFdEnv = "VTGATE_HANDOFF_READY_FD"
+)
+
+func setupUnixSocket(srv *mysqlServer, authServer mysql.AuthServer, path string, inherited net.Listener) error {
 	var err error
-	oldMask := syscall.Umask(000)
-	srv.unixListener, err = newMysqlUnixSocket(path, authServer, srv.vtgateHandle)
-	_ = syscall.Umask(oldMask)
+	if inherited != nil {
+		srv.unixListener, err = mysql.NewFromListener(inherited, authServer, srv.vtgateHandle, mysqlConnReadTimeout, mysqlConnWriteTimeout, mysqlConnBufferPooling, mysqlKeepAlivePeriod, mysqlServerFlushDelay)
+	} else {
+		// Let's create this unix socket with permissions to all users. In this way,
+		// clients can connect to vtgate mysql server without being vtgate user
+		oldMask := syscall.Umask(000)
+		srv.unixListener, err = newMysqlUnixSocket(path, authServer, srv.vtgateHandle)
+		_ = syscall.Umask(oldMask)
+	}
 	if err != nil {
 		return err
 	}
+	srv.unixListener.MaxPreparedStatements.Store(int64(mysqlMaxPreparedStatements))
+	srv.unixListener.QueryAttributes.Store(mysqlServerQueryAttributes)
+	srv.unixListener.MaxConnectionsPerUser.Store(int64(mysqlServerMaxConnectionsPerUser))
+	srv.unixListener.Compression.Store(mysqlServerCompression)
+	srv.unixListener.LocalInfile.Store(mysqlServerLocalInfile)
 	// Listen for unix socket
 	go srv.unixListener.Accept()
 	return nil
 }
+
+// inheritedListeners returns the MySQL listening sockets passed to the
+// process with the systemd socket activation protocol, that is from file
+// descriptor 3 on, LISTEN_FDS of them. A vtgate handing its listeners over
+// passes them the same way. The first TCP socket is the one of the MySQL
+// port, and the first unix socket the one of --mysql_server_socket_path.
+func inheritedListeners() (tcp net.Listener, unix net.Listener, err error) {
+	fds := os.Getenv("LISTEN_FDS")
+	if fds == "" {
+		return nil, nil, nil
+	}
+	if pid := os.Getenv("LISTEN_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		// The sockets were passed to another process.
+		return nil, nil, nil
+	}
+	defer func() {
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 0 {
+		return nil, nil, fmt.Errorf("invalid LISTEN_FDS %q", fds)
+	}
+	for fd := listenFdsStart; fd < listenFdsStart+n; fd++ {
+		syscall.CloseOnExec(fd)
+		file := os.NewFile(uintptr(fd), fmt.Sprintf("listen-fd-%d", fd))
+		l, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("file descriptor %d is not a listening socket: %v", fd, err)
+		}
+		switch l.(type) {
+		case *net.TCPListener:
+			if tcp == nil {
+				tcp = l
+				continue
+			}
+		case *net.UnixListener:
+			if unix == nil {
+				unix = l
+				continue
+			}
+		}
+		log.Warningf("Closing the unused inherited listening socket on %v", l.Addr())
+		l.Close()
+	}
+	return tcp, unix, nil
+}
+
+// signalHandoffReady tells the vtgate which started this process with a
+// handoff that it accepts MySQL connections, so that it shuts down.
+func signalHandoffReady() {
+	fds := os.Getenv(handoffReadyFdEnv)
+	if fds == "" {
+		return
+	}
+	os.Unsetenv(handoffReadyFdEnv)
+	fd, err := strconv.Atoi(fds)
+	if err != nil {
+		log.Errorf("Invalid %s %q", handoffReadyFdEnv, fds)
+		return
+	}
+	if os.Getenv(servenv.PIDFileHandoffEnv) != "" {
+		if err := servenv.TakeOverPIDFile(); err != nil {
+			log.Errorf("Failed to take the pid file over: %v", err)
+		}
+	}
+	file := os.NewFile(uintptr(fd), "handoff-ready")
+	defer file.Close()
+	if _, err := file.WriteString("ready\n"); err != nil {
+		log.Errorf("Failed to signal the handoff readiness: %v", err)
+	}
+}
+
+// watchHandoff hands the listeners over to a new process on SIGUSR2.
+func (srv *mysqlServer) watchHandoff() {
+	srv.handoffChan = make(chan os.Signal, 1)
+	signal.Notify(srv.handoffChan, syscall.SIGUSR2)
+	go func() {
+		for range srv.handoffChan {
+			if err := srv.handoff(); err != nil {
+				log.Errorf("MySQL server handoff failed, still serving: %v", err)
+				continue
+			}
+			log.Infof("MySQL server handed over, shutting down")
+			// The shutdown stops accepting connections, and waits for the
+			// in flight queries and streams to complete.
+			_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+			return
+		}
+	}()
+}
+
+// handoff starts a new process of the binary with the same arguments, passes
+// it the MySQL listening sockets, and waits for it to accept connections. The
+// HTTP and gRPC ports are bound by the new process along with this one, with
+// --reuse-port. If the new process exits or isn't ready within
+// --mysql-server-handoff-timeout, it is killed.
+//
+// The new process runs in its own session, so that it outlives this one, and
+// writes its pid in the --pid_file once ready, which this process then leaves
+// on shutdown.
+func (srv *mysqlServer) handoff() error {
+	var files []*os.File
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+	for _, listener := range []*mysql.Listener{srv.tcpListener, srv.unixListener} {
+		if listener == nil {
+			continue
+		}
+		file, err := listener.File()
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyReader.Close()
+
+	path, err := osExec.LookPath(os.Args[0])
+	if err != nil {
+		readyWriter.Close()
+		return err
+	}
+	cmd := osExec.Command(path, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append(files, readyWriter)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	for _, env := range os.Environ() {
+		switch name, _, _ := strings.Cut(env, "="); name {
+		case "LISTEN_FDS", "LISTEN_PID", "LISTEN_FDNAMES", handoffReadyFdEnv, servenv.PIDFileHandoffEnv:
+		default:
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+	cmd.Env = append(cmd.Env,
+		fmt.Sprintf("LISTEN_FDS=%d", len(files)),
+		fmt.Sprintf("%s=%d", handoffReadyFdEnv, listenFdsStart+len(files)),
+		servenv.PIDFileHandoffEnv+"=1",
+	)
+
+	log.Infof("Handing the MySQL server over to a new process of %s", path)
+	err = cmd.Start()
+	readyWriter.Close()
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Warningf("The process started by the handoff exited: %v", err)
+		}
+	}()
+
+	ready := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(readyReader).ReadString('\n')
+		if err == nil && line != "ready\n" {
+			err = fmt.Errorf("unexpected readiness %q", line)
+		}
+		ready <- err
+	}()
+	select {
+	case err = <-ready:
+	case <-time.After(mysqlServerHandoffTimeout):
+		err = errors.New("timed out waiting for the new process to accept connections")
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		// The new process may have written its pid before failing.
+		if pidErr := servenv.TakeOverPIDFile(); pidErr != nil {
+			log.Errorf("Failed to write the pid file again: %v", pidErr)
+		}
+		return fmt.Errorf("new process %d failed to start: %v", cmd.Process.Pid, err)
+	}
+	servenv.HandOffPIDFile()
+	return nil
+}
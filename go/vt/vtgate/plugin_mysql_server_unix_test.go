@@ -0,0 +1,73 @@
+//go:build !windows
+
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritedListeners(t *testing.T) {
+	tcp, unix, err := inheritedListeners()
+	require.NoError(t, err)
+	assert.Nil(t, tcp)
+	assert.Nil(t, unix)
+
+	// The sockets were passed to another process.
+	t.Setenv("LISTEN_FDS", "1")
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	tcp, unix, err = inheritedListeners()
+	require.NoError(t, err)
+	assert.Nil(t, tcp)
+	assert.Nil(t, unix)
+
+	t.Setenv("LISTEN_FDS", "one")
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	_, _, err = inheritedListeners()
+	assert.EqualError(t, err, `invalid LISTEN_FDS "one"`)
+	_, ok := os.LookupEnv("LISTEN_FDS")
+	assert.False(t, ok)
+}
+
+func TestSignalHandoffReady(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+
+	// signalHandoffReady takes the ownership of the file descriptor.
+	fd, err := syscall.Dup(int(w.Fd()))
+	require.NoError(t, err)
+	w.Close()
+
+	t.Setenv(handoffReadyFdEnv, strconv.Itoa(fd))
+	signalHandoffReady()
+	_, ok := os.LookupEnv(handoffReadyFdEnv)
+	assert.False(t, ok)
+
+	// The write end is closed once the readiness is written.
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "ready\n", string(data))
+}
@@ -20,10 +20,22 @@ package vtgate
 
 import (
 	"errors"
+	"net"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/log"
 )
 
-func setupUnixSocket(srv *mysqlServer, authServer mysql.AuthServer, path string) error {
+func setupUnixSocket(srv *mysqlServer, authServer mysql.AuthServer, path string, inherited net.Listener) error {
 	return errors.New("unix sockets are not supported on windows")
 }
+
+func inheritedListeners() (tcp net.Listener, unix net.Listener, err error) {
+	return nil, nil, nil
+}
+
+func signalHandoffReady() {}
+
+func (srv *mysqlServer) watchHandoff() {
+	log.Exitf("--mysql-server-handoff is not supported on windows")
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/pgwire"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	pgServerPort        = -1
+	pgServerBindAddress = "localhost"
+)
+
+func registerPGServerFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&pgServerPort, "pg-server-port", pgServerPort, "If set, also listen for PostgreSQL wire protocol connections on this port. This is experimental, and only supports read-only queries with the simple query protocol.")
+	fs.StringVar(&pgServerBindAddress, "pg-server-bind-address", pgServerBindAddress, "Binds on this address when listening to the PostgreSQL wire protocol.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerPGServerFlags)
+	servenv.OnParseFor("vtcombo", registerPGServerFlags)
+}
+
+// pgHandler executes the queries of PostgreSQL clients. Only read-only
+// statements are accepted, and they are streamed as in the OLAP workload.
+type pgHandler struct {
+	vtg *VTGate
+}
+
+func newPGHandler(vtg *VTGate) *pgHandler {
+	return &pgHandler{vtg: vtg}
+}
+
+// NewConnection is part of the pgwire.Handler interface.
+func (ph *pgHandler) NewConnection(c *pgwire.Conn) {
+	u, _ := uuid.NewUUID()
+	c.ClientData = &vtgatepb.Session{
+		TargetString: c.Database,
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+			Workload:       querypb.ExecuteOptions_OLAP,
+		},
+		Autocommit:  true,
+		SessionUUID: u.String(),
+	}
+}
+
+// ConnectionClosed is part of the pgwire.Handler interface.
+func (ph *pgHandler) ConnectionClosed(c *pgwire.Conn) {
+	session, _ := c.ClientData.(*vtgatepb.Session)
+	if session == nil {
+		return
+	}
+	_ = ph.vtg.CloseSession(context.Background(), session)
+}
+
+// ComQuery is part of the pgwire.Handler interface.
+func (ph *pgHandler) ComQuery(ctx context.Context, c *pgwire.Conn, query string, callback func(*sqltypes.Result) error) error {
+	stmt, err := ph.vtg.executor.env.Parser().Parse(query)
+	if err != nil {
+		return err
+	}
+	if !isPGReadOnly(stmt) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "only read-only statements are supported over the PostgreSQL protocol: %s", sqlparser.String(stmt))
+	}
+
+	// See vtgateHandler.ComQuery for how the caller ids are filled in.
+	var im *querypb.VTGateCallerID
+	if c.UserData != nil {
+		im = c.UserData.Get()
+	} else {
+		im = callerid.NewImmediateCallerID(c.User)
+	}
+	ef := callerid.NewEffectiveCallerID(
+		c.User,                  /* principal: who */
+		c.RemoteAddr().String(), /* component: running client process */
+		"VTGate PostgreSQL Connector" /* subcomponent: part of the client */)
+	ctx = callerid.NewContext(ctx, ef, im)
+
+	session := c.ClientData.(*vtgatepb.Session)
+	session, err = ph.vtg.StreamExecute(ctx, ph, session, query, make(map[string]*querypb.BindVariable), callback)
+	c.ClientData = session
+	return err
+}
+
+// KillConnection is part of the vtgateservice.MySQLConnection interface.
+func (ph *pgHandler) KillConnection(ctx context.Context, connectionID uint32) error {
+	return vterrors.VT12001("KILL over the PostgreSQL protocol")
+}
+
+// KillQuery is part of the vtgateservice.MySQLConnection interface.
+func (ph *pgHandler) KillQuery(connectionID uint32) error {
+	return vterrors.VT12001("KILL over the PostgreSQL protocol")
+}
+
+// isPGReadOnly returns true if the statement can be run by PostgreSQL
+// clients, i.e. if it cannot modify any data.
+func isPGReadOnly(stmt sqlparser.Statement) bool {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		if stmt.Into != nil || stmt.Lock != sqlparser.NoLock {
+			return false
+		}
+		// SELECT NEXT VALUE allocates values of a sequence.
+		for _, expr := range stmt.GetColumns() {
+			if _, isNextval := expr.(*sqlparser.Nextval); isNextval {
+				return false
+			}
+		}
+		return true
+	case *sqlparser.Union:
+		return stmt.Into == nil && stmt.Lock == sqlparser.NoLock && isPGReadOnly(stmt.Left) && isPGReadOnly(stmt.Right)
+	case *sqlparser.Show, *sqlparser.ExplainTab:
+		return true
+	case *sqlparser.ExplainStmt:
+		// EXPLAIN ANALYZE executes the statement.
+		return isPGReadOnly(stmt.Statement)
+	}
+	return false
+}
+
+// initPGProtocol starts the PostgreSQL protocol listener, if it is enabled.
+// The users are authenticated by the auth server of the MySQL protocol,
+// which must support clear text passwords.
+func initPGProtocol(vtgate *VTGate) *pgwire.Listener {
+	if pgServerPort < 0 || vtgate == nil {
+		return nil
+	}
+
+	authServer := plainTextAuthServer("--pg-server-port")
+	address := net.JoinHostPort(pgServerBindAddress, strconv.Itoa(pgServerPort))
+	listener, err := pgwire.NewListener("tcp", address, authServer, newPGHandler(vtgate))
+	if err != nil {
+		log.Exitf("pgwire.NewListener failed: %v", err)
+	}
+	log.Infof("Listening for PostgreSQL protocol connections on %v", listener.Addr())
+	go listener.Accept()
+	return listener
+}
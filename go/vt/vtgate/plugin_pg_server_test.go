@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestIsPGReadOnly(t *testing.T) {
+	tests := []struct {
+		query    string
+		readOnly bool
+	}{
+		{query: "select * from t", readOnly: true},
+		{query: "select 1 union select 2", readOnly: true},
+		{query: "show tables", readOnly: true},
+		{query: "describe t", readOnly: true},
+		{query: "explain select * from t", readOnly: true},
+		{query: "explain analyze select * from t", readOnly: true},
+		{query: "explain analyze delete from t"},
+		{query: "select * from t for update"},
+		{query: "select * from t into outfile 'x'"},
+		{query: "(select 1) union (select 2) for update"},
+		{query: "select next value from seq"},
+		{query: "select next 10 values from seq"},
+		{query: "(select 1 for update) union (select 2)"},
+		{query: "insert into t values (1)"},
+		{query: "update t set a = 1"},
+		{query: "delete from t"},
+		{query: "create table t (a int)"},
+		{query: "set @a = 1"},
+		{query: "use ks"},
+		{query: "begin"},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.readOnly, isPGReadOnly(stmt))
+		})
+	}
+}
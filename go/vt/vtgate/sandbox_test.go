@@ -127,6 +127,9 @@ type sandbox struct {
 	// SrvKeyspaceCallback specifies the callback function in GetSrvKeyspace
 	SrvKeyspaceCallback func()
 
+	// WriteFences specifies the write fences of the shards
+	WriteFences map[string]*topodatapb.WriteFence
+
 	// VSchema specifies the vschema in JSON format.
 	VSchema string
 }
@@ -142,6 +145,7 @@ func (s *sandbox) Reset() {
 	s.KeyspaceServedFrom = ""
 	s.ShardSpec = DefaultShardSpec
 	s.SrvKeyspaceCallback = nil
+	s.WriteFences = nil
 }
 
 // DefaultShardSpec is the default sharding scheme for testing.
@@ -262,12 +266,21 @@ func (sct *sandboxTopo) GetSrvKeyspace(ctx context.Context, cell, keyspace strin
 		sand.SrvKeyspaceMustFail--
 		return nil, fmt.Errorf("topo error GetSrvKeyspace")
 	}
+	var (
+		srvKeyspace *topodatapb.SrvKeyspace
+		err         error
+	)
 	switch keyspace {
 	case KsTestUnsharded:
-		return createUnshardedKeyspace()
+		srvKeyspace, err = createUnshardedKeyspace()
 	default:
-		return createShardedSrvKeyspace(sand.ShardSpec, sand.KeyspaceServedFrom)
+		srvKeyspace, err = createShardedSrvKeyspace(sand.ShardSpec, sand.KeyspaceServedFrom)
+	}
+	if err != nil {
+		return nil, err
 	}
+	srvKeyspace.WriteFences = sand.WriteFences
+	return srvKeyspace, nil
 }
 
 func (sct *sandboxTopo) WatchSrvKeyspace(ctx context.Context, cell, keyspace string, callback func(*topodatapb.SrvKeyspace, error) bool) {
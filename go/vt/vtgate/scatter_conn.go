@@ -42,6 +42,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
 )
 
@@ -104,9 +105,10 @@ func (stc *ScatterConn) startAction(name string, target *querypb.Target) (time.T
 	return startTime, statsKey
 }
 
-func (stc *ScatterConn) endAction(startTime time.Time, allErrors *concurrency.AllErrorRecorder, statsKey []string, err *error, session *econtext.SafeSession) {
+func (stc *ScatterConn) endAction(startTime time.Time, allErrors *concurrency.AllErrorRecorder, statsKey []string, err *error, session *econtext.SafeSession, target *querypb.Target) {
 	if *err != nil {
 		allErrors.RecordError(*err)
+		session.RecordShardError(target, *err)
 		// Don't increment the error counter for duplicate
 		// keys or bad queries, as those errors are caused by
 		// client queries and are not VTGate's fault.
@@ -159,9 +161,14 @@ func (stc *ScatterConn) ExecuteMultiShard(
 		return nil, []error{vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] got mismatched number of queries and shards")}
 	}
 
-	// mu protects qr
+	// mu protects qr and size
 	var mu sync.Mutex
 	qr = new(sqltypes.Result)
+	size := newShardResultsSize(ctx)
+	if size.limited && !session.InTransaction() {
+		ctx, size.cancel = context.WithCancel(ctx)
+		defer size.cancel()
+	}
 
 	if session.InLockSession() && triggerLockHeartBeat(session) {
 		go stc.runLockQuery(ctx, session)
@@ -185,6 +192,7 @@ func (stc *ScatterConn) ExecuteMultiShard(
 				alias   *topodatapb.TabletAlias
 				qs      queryservice.QueryService
 			)
+			startTime := time.Now()
 			transactionID := info.transactionID
 			reservedID := info.reservedID
 
@@ -268,6 +276,11 @@ func (stc *ScatterConn) ExecuteMultiShard(
 				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] unexpected actionNeeded on query execution: %v", info.actionNeeded)
 			}
 			session.Log(primitive, rs.Target, rs.Gateway, queries[i].Sql, info.actionNeeded == begin || info.actionNeeded == reserveBegin, queries[i].BindVariables)
+			var rows uint64
+			if innerqr != nil {
+				rows = uint64(len(innerqr.Rows))
+			}
+			recordShardExecution(ctx, "Execute", rs.Target, startTime, rows, err)
 
 			// We need to new shard info irrespective of the error.
 			newInfo := info.updateTransactionAndReservedID(transactionID, reservedID, alias, innerqr)
@@ -279,10 +292,13 @@ func (stc *ScatterConn) ExecuteMultiShard(
 
 			if innerqr != nil {
 				resultsObserver.Observe(innerqr)
+				// The statements autocommitted by the shard return the GTIDs of
+				// their transactions.
+				session.RecordCommittedGtid(rs.Target, innerqr.SessionStateChanges)
 			}
 
-			// Don't append more rows if row count is exceeded.
-			if ignoreMaxMemoryRows || len(qr.Rows) <= maxMemoryRows {
+			// Don't append more rows if row count or result size is exceeded.
+			if (ignoreMaxMemoryRows || len(qr.Rows) <= maxMemoryRows) && size.add(innerqr) {
 				qr.AppendResult(innerqr)
 			}
 			return newInfo, nil
@@ -292,6 +308,9 @@ func (stc *ScatterConn) ExecuteMultiShard(
 	if !ignoreMaxMemoryRows && len(qr.Rows) > maxMemoryRows {
 		return nil, []error{vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "in-memory row count exceeded allowed limit of %d", maxMemoryRows)}
 	}
+	if err := size.err(); err != nil {
+		return nil, []error{err}
+	}
 
 	return qr, allErrors.GetErrors()
 }
@@ -416,6 +435,15 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				alias *topodatapb.TabletAlias
 				qs    queryservice.QueryService
 			)
+			startTime := time.Now()
+			// The replies of a shard are sent sequentially.
+			var rows uint64
+			shardCallback := func(reply *sqltypes.Result) error {
+				if reply != nil {
+					rows += uint64(len(reply.Rows))
+				}
+				return observedCallback(reply)
+			}
 			transactionID := info.transactionID
 			reservedID := info.reservedID
 
@@ -455,20 +483,20 @@ func (stc *ScatterConn) StreamExecuteMulti(
 
 			switch info.actionNeeded {
 			case nothing:
-				err = qs.StreamExecute(ctx, rs.Target, query, bindVars[i], transactionID, reservedID, opts, observedCallback)
+				err = qs.StreamExecute(ctx, rs.Target, query, bindVars[i], transactionID, reservedID, opts, shardCallback)
 				if err != nil {
 					retryRequest(func() {
 						// we seem to have lost our connection. it was a reserved connection, let's try to recreate it
 						info.actionNeeded = reserve
 						var state queryservice.ReservedState
-						state, err = qs.ReserveStreamExecute(ctx, rs.Target, session.SetPreQueries(), query, bindVars[i], 0 /*transactionId*/, opts, observedCallback)
+						state, err = qs.ReserveStreamExecute(ctx, rs.Target, session.SetPreQueries(), query, bindVars[i], 0 /*transactionId*/, opts, shardCallback)
 						reservedID = state.ReservedID
 						alias = state.TabletAlias
 					})
 				}
 			case begin:
 				var state queryservice.TransactionState
-				state, err = qs.BeginStreamExecute(ctx, rs.Target, session.SavePoints(), query, bindVars[i], reservedID, opts, observedCallback)
+				state, err = qs.BeginStreamExecute(ctx, rs.Target, session.SavePoints(), query, bindVars[i], reservedID, opts, shardCallback)
 				transactionID = state.TransactionID
 				alias = state.TabletAlias
 				if err != nil {
@@ -476,7 +504,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 						// we seem to have lost our connection. it was a reserved connection, let's try to recreate it
 						info.actionNeeded = reserveBegin
 						var state queryservice.ReservedTransactionState
-						state, err = qs.ReserveBeginStreamExecute(ctx, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, observedCallback)
+						state, err = qs.ReserveBeginStreamExecute(ctx, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, shardCallback)
 						transactionID = state.TransactionID
 						reservedID = state.ReservedID
 						alias = state.TabletAlias
@@ -484,12 +512,12 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				}
 			case reserve:
 				var state queryservice.ReservedState
-				state, err = qs.ReserveStreamExecute(ctx, rs.Target, session.SetPreQueries(), query, bindVars[i], transactionID, opts, observedCallback)
+				state, err = qs.ReserveStreamExecute(ctx, rs.Target, session.SetPreQueries(), query, bindVars[i], transactionID, opts, shardCallback)
 				reservedID = state.ReservedID
 				alias = state.TabletAlias
 			case reserveBegin:
 				var state queryservice.ReservedTransactionState
-				state, err = qs.ReserveBeginStreamExecute(ctx, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, observedCallback)
+				state, err = qs.ReserveBeginStreamExecute(ctx, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, shardCallback)
 				transactionID = state.TransactionID
 				reservedID = state.ReservedID
 				alias = state.TabletAlias
@@ -497,6 +525,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] unexpected actionNeeded on query execution: %v", info.actionNeeded)
 			}
 			session.Log(primitive, rs.Target, rs.Gateway, query, info.actionNeeded == begin || info.actionNeeded == reserveBegin, bindVars[i])
+			recordShardExecution(ctx, "StreamExecute", rs.Target, startTime, rows, err)
 
 			// We need the new shard info irrespective of the error.
 			newInfo := info.updateTransactionAndReservedID(transactionID, reservedID, alias, nil)
@@ -510,6 +539,25 @@ func (stc *ScatterConn) StreamExecuteMulti(
 	return allErrors.GetErrors()
 }
 
+// recordShardExecution records the execution of a query on a shard in the log
+// stats carried by the context, if any.
+func recordShardExecution(ctx context.Context, operation string, target *querypb.Target, startTime time.Time, rows uint64, err error) {
+	stats := logstats.FromContext(ctx)
+	if stats == nil {
+		return
+	}
+	stats.RecordShardExecution(logstats.ShardExecution{
+		Operation:  operation,
+		Keyspace:   target.Keyspace,
+		Shard:      target.Shard,
+		TabletType: topoproto.TabletTypeLString(target.TabletType),
+		Start:      startTime,
+		Time:       time.Since(startTime),
+		Rows:       rows,
+		Error:      err,
+	})
+}
+
 // timeTracker is a convenience wrapper used by MessageStream
 // to track how long a stream has been unavailable.
 type timeTracker struct {
@@ -631,7 +679,7 @@ func (stc *ScatterConn) multiGo(
 		startTime, statsKey := stc.startAction(name, rs.Target)
 		// Send a dummy session.
 		// TODO(sougou): plumb a real session through this call.
-		defer stc.endAction(startTime, allErrors, statsKey, &err, econtext.NewSafeSession(nil))
+		defer stc.endAction(startTime, allErrors, statsKey, &err, econtext.NewSafeSession(nil), rs.Target)
 		err = action(rs, i)
 	}
 
@@ -687,7 +735,7 @@ func (stc *ScatterConn) multiGoTransaction(
 	oneShard := func(rs *srvtopo.ResolvedShard, i int) {
 		var err error
 		startTime, statsKey := stc.startAction(name, rs.Target)
-		defer stc.endAction(startTime, allErrors, statsKey, &err, session)
+		defer stc.endAction(startTime, allErrors, statsKey, &err, session, rs.Target)
 
 		info, shardSession, err := actionInfo(ctx, rs.Target, session, autocommit, stc.txConn.txMode.TransactionMode())
 		if err != nil {
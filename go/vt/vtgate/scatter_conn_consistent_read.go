@@ -0,0 +1,350 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// consistentReadSnapshotAttempts is the number of times the snapshots of
+	// a consistent read are taken, until they are aligned on the GTID
+	// positions of the shards and taken within --consistent-reads-max-skew.
+	consistentReadSnapshotAttempts = 3
+
+	// consistentReadPositionQuery is executed as the first statement of the
+	// snapshot transaction of each shard, to get the GTID position of the
+	// snapshot.
+	consistentReadPositionQuery = "select @@global.gtid_executed"
+)
+
+// readSnapshot is a consistent snapshot transaction opened on a shard.
+type readSnapshot struct {
+	rs            *srvtopo.ResolvedShard
+	qs            queryservice.QueryService
+	transactionID int64
+	position      string
+}
+
+// ExecuteConsistentRead executes a SELECT on several shards, each shard
+// reading from a consistent snapshot transaction. All the snapshots are opened
+// and aligned on the GTID positions of the shards before any shard is read,
+// so that the shards are read as of the same point in time. The GTID positions
+// of the snapshots are recorded in the session as a VGTID.
+func (stc *ScatterConn) ExecuteConsistentRead(
+	ctx context.Context,
+	primitive engine.Primitive,
+	rss []*srvtopo.ResolvedShard,
+	queries []*querypb.BoundQuery,
+	session *econtext.SafeSession,
+	ignoreMaxMemoryRows bool,
+	resultsObserver econtext.ResultsObserver,
+) (*sqltypes.Result, []error) {
+	if len(rss) != len(queries) {
+		return nil, []error{vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] got mismatched number of queries and shards")}
+	}
+
+	snapshots, opts, err := stc.openReadSnapshots(ctx, rss, session)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer stc.closeReadSnapshots(ctx, snapshots)
+
+	// mu protects qr and size
+	var mu sync.Mutex
+	qr := new(sqltypes.Result)
+	size := newShardResultsSize(ctx)
+	if size.limited {
+		// The snapshots are still closed with the context of the caller.
+		ctx, size.cancel = context.WithCancel(ctx)
+		defer size.cancel()
+	}
+	allErrors := stc.multiGo("Execute", rss, func(rs *srvtopo.ResolvedShard, i int) error {
+		snapshot := snapshots[i]
+		startTime := time.Now()
+		innerqr, err := snapshot.qs.Execute(ctx, rs.Target, queries[i].Sql, queries[i].BindVariables, snapshot.transactionID, 0, opts)
+		session.Log(primitive, rs.Target, rs.Gateway, queries[i].Sql, false, queries[i].BindVariables)
+		var rows uint64
+		if innerqr != nil {
+			rows = uint64(len(innerqr.Rows))
+		}
+		recordShardExecution(ctx, "Execute", rs.Target, startTime, rows, err)
+		session.RecordShardError(rs.Target, err)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		resultsObserver.Observe(innerqr)
+		// Don't append more rows if row count or result size is exceeded.
+		if (ignoreMaxMemoryRows || len(qr.Rows) <= maxMemoryRows) && size.add(innerqr) {
+			qr.AppendResult(innerqr)
+		}
+		return nil
+	})
+
+	if !ignoreMaxMemoryRows && len(qr.Rows) > maxMemoryRows {
+		return nil, []error{vterrors.NewErrorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.NetPacketTooLarge, "in-memory row count exceeded allowed limit of %d", maxMemoryRows)}
+	}
+	if err := size.err(); err != nil {
+		return nil, []error{err}
+	}
+	return qr, allErrors.GetErrors()
+}
+
+// StreamExecuteConsistentRead is the streaming version of ExecuteConsistentRead.
+func (stc *ScatterConn) StreamExecuteConsistentRead(
+	ctx context.Context,
+	primitive engine.Primitive,
+	query string,
+	rss []*srvtopo.ResolvedShard,
+	bindVars []map[string]*querypb.BindVariable,
+	session *econtext.SafeSession,
+	callback func(reply *sqltypes.Result) error,
+	resultsObserver econtext.ResultsObserver,
+) []error {
+	snapshots, opts, err := stc.openReadSnapshots(ctx, rss, session)
+	if err != nil {
+		return []error{err}
+	}
+	defer stc.closeReadSnapshots(ctx, snapshots)
+
+	var mu sync.Mutex
+	fieldSent := false
+	allErrors := stc.multiGo("StreamExecute", rss, func(rs *srvtopo.ResolvedShard, i int) error {
+		snapshot := snapshots[i]
+		startTime := time.Now()
+		var rows uint64
+		err := snapshot.qs.StreamExecute(ctx, rs.Target, query, bindVars[i], snapshot.transactionID, 0, opts, func(reply *sqltypes.Result) error {
+			rows += uint64(len(reply.Rows))
+			resultsObserver.Observe(reply)
+			return stc.processOneStreamingResult(&mu, &fieldSent, reply, callback)
+		})
+		session.Log(primitive, rs.Target, rs.Gateway, query, false, bindVars[i])
+		recordShardExecution(ctx, "StreamExecute", rs.Target, startTime, rows, err)
+		session.RecordShardError(rs.Target, err)
+		return err
+	})
+	return allErrors.GetErrors()
+}
+
+// openReadSnapshots opens a consistent snapshot transaction on each shard, and
+// returns them along with the options to read them with. The snapshots are
+// taken again when they are not aligned, or further apart than
+// --consistent-reads-max-skew, up to consistentReadSnapshotAttempts times,
+// after which the read fails.
+func (stc *ScatterConn) openReadSnapshots(ctx context.Context, rss []*srvtopo.ResolvedShard, session *econtext.SafeSession) ([]*readSnapshot, *querypb.ExecuteOptions, error) {
+	opts := &querypb.ExecuteOptions{}
+	if sessionOpts := session.GetOptions(); sessionOpts != nil {
+		opts = proto.Clone(sessionOpts).(*querypb.ExecuteOptions)
+	}
+	opts.TransactionIsolation = querypb.ExecuteOptions_CONSISTENT_SNAPSHOT_READ_ONLY
+
+	for attempt := 1; ; attempt++ {
+		snapshots, misaligned, skew, err := stc.openReadSnapshotsOnce(ctx, rss, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if misaligned == nil && skew <= consistentReadsMaxSkew {
+			session.SetConsistentReadPositions(readSnapshotsVGTID(snapshots))
+			return snapshots, opts, nil
+		}
+		stc.closeReadSnapshots(ctx, snapshots)
+		if attempt < consistentReadSnapshotAttempts {
+			continue
+		}
+		if misaligned != nil {
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_ABORTED, "cannot align the snapshots of the consistent read after %d attempts: shard %s/%s executed transactions while the snapshots were taken", attempt, misaligned.rs.Target.Keyspace, misaligned.rs.Target.Shard)
+		}
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_ABORTED, "cannot align the snapshots of the consistent read after %d attempts: they were taken up to %v apart, above --consistent-reads-max-skew of %v", attempt, skew, consistentReadsMaxSkew)
+	}
+}
+
+// openReadSnapshotsOnce opens a consistent snapshot transaction on each shard
+// concurrently, and returns them along with a snapshot which is not aligned
+// with the others, if any, and the time between the earliest and the latest
+// possible snapshot. If a snapshot can't be opened, the opened ones are
+// closed.
+//
+// A shard which executed a transaction after its snapshot was taken but
+// before the snapshot of another shard was taken may have committed a
+// transaction which the later snapshot sees the effects of, so the snapshots
+// would not be a consistent view of the shards. To only catch such
+// transactions, the GTID position of each shard is read again as soon as the
+// last BeginExecute returns, and compared with the position of its snapshot.
+// Transactions committed between the last snapshot and the time the
+// positions are read, a round trip to the tablets, still misalign the
+// snapshots, so under continuous writes a read may have to take its snapshots
+// again, and fails with ABORTED after consistentReadSnapshotAttempts attempts.
+func (stc *ScatterConn) openReadSnapshotsOnce(ctx context.Context, rss []*srvtopo.ResolvedShard, opts *querypb.ExecuteOptions) ([]*readSnapshot, *readSnapshot, time.Duration, error) {
+	snapshots := make([]*readSnapshot, len(rss))
+	var (
+		mu         sync.Mutex
+		firstStart time.Time
+		lastEnd    time.Time
+		misaligned *readSnapshot
+		// taken is done once every BeginExecute returned, and failed is set
+		// if any of them failed, in which case the positions are not read.
+		taken  sync.WaitGroup
+		failed atomic.Bool
+	)
+	taken.Add(len(rss))
+	allErrors := stc.multiGo("BeginExecute", rss, func(rs *srvtopo.ResolvedShard, i int) error {
+		err := func() (err error) {
+			defer func() {
+				if err != nil {
+					failed.Store(true)
+				}
+				taken.Done()
+			}()
+			start := time.Now()
+			state, qr, err := rs.Gateway.BeginExecute(ctx, rs.Target, nil, consistentReadPositionQuery, nil, 0, opts)
+			end := time.Now()
+			if state.TransactionID != 0 {
+				// The transaction has to be closed even if reading its position failed.
+				snapshots[i] = &readSnapshot{rs: rs, qs: rs.Gateway, transactionID: state.TransactionID}
+			}
+			if err != nil {
+				return err
+			}
+			qs, err := rs.Gateway.QueryServiceByAlias(ctx, state.TabletAlias, rs.Target)
+			if err != nil {
+				return vterrors.VT15001(vterrors.Code(err), err.Error())
+			}
+			snapshots[i].qs = qs
+			if len(qr.Rows) == 1 && len(qr.Rows[0]) == 1 {
+				snapshots[i].position = qr.Rows[0][0].ToString()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if firstStart.IsZero() || start.Before(firstStart) {
+				firstStart = start
+			}
+			if end.After(lastEnd) {
+				lastEnd = end
+			}
+			return nil
+		}()
+		if err != nil {
+			return err
+		}
+
+		taken.Wait()
+		if failed.Load() {
+			return nil
+		}
+		aligned, err := readSnapshotAligned(ctx, snapshots[i])
+		if err != nil || aligned {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		misaligned = snapshots[i]
+		return nil
+	})
+	if err := allErrors.AggrError(vterrors.Aggregate); err != nil {
+		stc.closeReadSnapshots(ctx, snapshots)
+		return nil, nil, 0, err
+	}
+	return snapshots, misaligned, lastEnd.Sub(firstStart), nil
+}
+
+// readSnapshotAligned reads the GTID position of the shard of a snapshot again
+// from the tablet of the snapshot, and returns whether it is still the
+// position of the snapshot.
+func readSnapshotAligned(ctx context.Context, snapshot *readSnapshot) (bool, error) {
+	target := snapshot.rs.Target
+	qr, err := snapshot.qs.Execute(ctx, target, consistentReadPositionQuery, nil, 0, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return false, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "cannot read the GTID position of %s/%s", target.Keyspace, target.Shard)
+	}
+	current, err := replication.ParseMysql56GTIDSet(qr.Rows[0][0].ToString())
+	if err != nil {
+		return false, err
+	}
+	position, err := replication.ParseMysql56GTIDSet(snapshot.position)
+	if err != nil {
+		return false, err
+	}
+	return current.Equal(position), nil
+}
+
+// closeReadSnapshots rolls the snapshot transactions back.
+func (stc *ScatterConn) closeReadSnapshots(ctx context.Context, snapshots []*readSnapshot) {
+	// The snapshots are closed even if the query was canceled.
+	ctx = context.WithoutCancel(ctx)
+	var wg sync.WaitGroup
+	for _, snapshot := range snapshots {
+		if snapshot == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := snapshot.qs.Rollback(ctx, snapshot.rs.Target, snapshot.transactionID); err != nil {
+				log.Warningf("Failed to close the consistent read snapshot of %s/%s: %v", snapshot.rs.Target.Keyspace, snapshot.rs.Target.Shard, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// readSnapshotsVGTID returns the VGTID, in JSON, of the positions of the
+// snapshots, which a VStream can start from.
+func readSnapshotsVGTID(snapshots []*readSnapshot) string {
+	vgtid := &binlogdatapb.VGtid{}
+	for _, snapshot := range snapshots {
+		gtid := snapshot.position
+		if gtidSet, err := replication.ParseMysql56GTIDSet(gtid); err == nil {
+			gtid = replication.EncodePosition(replication.Position{GTIDSet: gtidSet})
+		}
+		vgtid.ShardGtids = append(vgtid.ShardGtids, &binlogdatapb.ShardGtid{
+			Keyspace: snapshot.rs.Target.Keyspace,
+			Shard:    snapshot.rs.Target.Shard,
+			Gtid:     gtid,
+		})
+	}
+	data, err := protojson.Marshal(vgtid)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
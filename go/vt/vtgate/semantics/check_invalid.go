@@ -17,6 +17,8 @@ limitations under the License.
 package semantics
 
 import (
+	"fmt"
+
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
@@ -94,6 +96,21 @@ func (a *analyzer) checkSubqueryColumns(parent sqlparser.SQLNode, subq *sqlparse
 	return nil
 }
 
+// checkTableSample checks that the percentage of the rows sampled is a
+// number, as it is compared with a random number by each shard.
+func checkTableSample(node *sqlparser.TableSample) error {
+	switch percent := node.Percent.(type) {
+	case *sqlparser.Argument:
+		return nil
+	case *sqlparser.Literal:
+		switch percent.Type {
+		case sqlparser.IntVal, sqlparser.DecimalVal, sqlparser.FloatVal:
+			return nil
+		}
+	}
+	return vterrors.VT12001(fmt.Sprintf("TABLESAMPLE with a percentage which is not a number: %s", sqlparser.String(node.Percent)))
+}
+
 func checkDerived(node *sqlparser.DerivedTable) error {
 	if node.Lateral {
 		return vterrors.VT12001("lateral derived tables")
@@ -183,6 +200,11 @@ func (a *analyzer) checkSelect(cursor *sqlparser.Cursor, node *sqlparser.Select)
 
 // checkAliasedTableExpr checks the validity of AliasedTableExpr.
 func checkAliasedTableExpr(node *sqlparser.AliasedTableExpr) error {
+	if node.Sample != nil {
+		if err := checkTableSample(node.Sample); err != nil {
+			return err
+		}
+	}
 	if len(node.Hints) == 0 {
 		return nil
 	}
@@ -44,6 +44,13 @@ func TestCheckAliasedTableExpr(t *testing.T) {
 			name:        "Invalid AliasedTable - mixed vindex hints",
 			tableString: "t.payment_pulls use vindex (lookup_vindex_name, x, t) ignore vindex (x)",
 			wantErr:     "VT09020: can not use multiple vindex hints for table t.payment_pulls",
+		}, {
+			name:        "Valid AliasedTable - TABLESAMPLE",
+			tableString: "payment_pulls as p tablesample bernoulli (2.5)",
+		}, {
+			name:        "Invalid AliasedTable - TABLESAMPLE of a column",
+			tableString: "payment_pulls tablesample bernoulli (id)",
+			wantErr:     "VT12001: unsupported: TABLESAMPLE with a percentage which is not a number: id",
 		},
 	}
 	for _, tt := range tests {
@@ -435,6 +435,12 @@ func (st *SemTable) HasNonLiteralForeignKeyUpdate(updExprs sqlparser.UpdateExprs
 	return false
 }
 
+// IsCrossShardChildFK checks if the rows of the child foreign key of the given parent table
+// can live on other shards than the parent rows they reference.
+func IsCrossShardChildFK(pTable *vindexes.BaseTable, fk vindexes.ChildFKInfo) bool {
+	return pTable.Keyspace.Name != fk.Table.Keyspace.Name || !isShardScoped(pTable, fk.Table, fk.ParentColumns, fk.ChildColumns)
+}
+
 // isShardScoped checks if the foreign key constraint is shard-scoped or not. It uses the vindex information to make this call.
 func isShardScoped(pTable *vindexes.BaseTable, cTable *vindexes.BaseTable, pCols sqlparser.Columns, cCols sqlparser.Columns) bool {
 	if !pTable.Keyspace.Sharded {
@@ -450,6 +456,12 @@ func isShardScoped(pTable *vindexes.BaseTable, cTable *vindexes.BaseTable, pCols
 		return false
 	}
 
+	// A functional vindex routes on an expression of its column, so both
+	// primary vindexes must apply to the same expression.
+	if !sqlparser.Equals.Expr(cPrimaryVdx.Expression, pPrimaryVdx.Expression) {
+		return false
+	}
+
 	childFkContatined, childFkIndexes := cCols.Indexes(cPrimaryVdx.Columns)
 	if !childFkContatined {
 		// PrimaryVindex is not part of the foreign key constraint on the children side.
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"time"
+
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+// SlowQueryLogHandler is the debug UI path for exposing the slow query log
+var SlowQueryLogHandler = "/debug/slow_querylog"
+
+// maxSlowQueryShards bounds the number of shard executions logged for a slow
+// query. The slowest ones are kept.
+const maxSlowQueryShards = 100
+
+// slowQuery is an entry of the slow query log. The times are in seconds.
+type slowQuery struct {
+	Start           time.Time
+	Method          string
+	ImmediateCaller string
+	EffectiveCaller string
+	RemoteAddr      string `json:",omitempty"`
+	// ConnectAttributes are the connection attributes of the MySQL client,
+	// like program_name, to tell which application sent the query.
+	ConnectAttributes map[string]string `json:",omitempty"`
+	SessionUUID       string
+	Digest            string
+	// SQL is the normalized text of the query, without its literals.
+	SQL            string
+	StmtType       string
+	ActiveKeyspace string
+	TabletType     string
+	TablesUsed     []string
+	Error          string `json:",omitempty"`
+
+	TotalTime   float64
+	PlanTime    float64
+	ExecuteTime float64
+	CommitTime  float64
+	// ShardTime is the time during which at least one shard was executing a
+	// query of the plan.
+	ShardTime float64
+	// VTGateTime is the execution time of the plan outside of the shards,
+	// i.e. merging, sorting, aggregating and joining the rows in vtgate.
+	VTGateTime float64
+
+	ShardQueries uint64
+	// ShardRows is the number of rows returned by the shards, and RowsReturned
+	// the number of rows returned to the client.
+	ShardRows    uint64
+	RowsReturned uint64
+	RowsAffected uint64
+
+	Shards        []slowQueryShard
+	ShardsOmitted int `json:",omitempty"`
+}
+
+// slowQueryShard is the execution of a query of a slow query on a shard.
+type slowQueryShard struct {
+	Operation  string
+	Keyspace   string
+	Shard      string
+	TabletType string
+	// StartOffset is the time between the start of the slow query and the
+	// start of the execution on the shard.
+	StartOffset float64
+	Time        float64
+	Rows        uint64
+	Error       string `json:",omitempty"`
+}
+
+// Logf formats the slow query as a line of JSON.
+func (sq *slowQuery) Logf(w io.Writer, _ url.Values) error {
+	b, err := json.Marshal(sq)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (e *Executor) defaultSlowQueryLogger() error {
+	slowQueryLogger := streamlog.New[*slowQuery]("VTGateSlowQueries", queryLogBufferSize)
+	slowQueryLogger.ServeLogs(SlowQueryLogHandler, streamlog.GetFormatter(slowQueryLogger))
+
+	if slowQueryLogToFile != "" {
+		_, err := slowQueryLogger.LogToFile(slowQueryLogToFile, streamlog.GetFormatter(slowQueryLogger))
+		if err != nil {
+			return err
+		}
+	}
+
+	e.slowQueryLogger = slowQueryLogger
+	return nil
+}
+
+// slowQueryLogContext returns the context with which a query is executed. The
+// executions on the shards are only recorded in the log stats when the slow
+// query log is enabled.
+func slowQueryLogContext(ctx context.Context, logStats *logstats.LogStats) context.Context {
+	if slowQueryLogThreshold <= 0 {
+		return ctx
+	}
+	return logstats.NewContext(ctx, logStats)
+}
+
+// logSlowQuery sends the query to the slow query log if it took longer than
+// --slow-query-log-threshold, according to --slow-query-log-sample-rate.
+func (e *Executor) logSlowQuery(sql string, logStats *logstats.LogStats, rowsReturned uint64) {
+	if e.slowQueryLogger == nil || slowQueryLogThreshold <= 0 || logStats.TotalTime() < slowQueryLogThreshold {
+		return
+	}
+	if rand.Float64() >= slowQueryLogSampleRate {
+		return
+	}
+	e.slowQueryLogger.Send(newSlowQuery(queryDigestText(e.env.Parser(), sql, logStats.StmtType), logStats, rowsReturned))
+}
+
+// newSlowQuery builds the slow query log entry of the query.
+func newSlowQuery(text string, logStats *logstats.LogStats, rowsReturned uint64) *slowQuery {
+	sq := &slowQuery{
+		Start:             logStats.StartTime,
+		Method:            logStats.Method,
+		ImmediateCaller:   logStats.ImmediateCaller(),
+		EffectiveCaller:   logStats.EffectiveCaller(),
+		ConnectAttributes: logStats.ConnectAttributes,
+		SessionUUID:       logStats.SessionUUID,
+		Digest:            queryDigest(text),
+		SQL:               text,
+		StmtType:          logStats.StmtType,
+		ActiveKeyspace:    logStats.ActiveKeyspace,
+		TabletType:        logStats.TabletType,
+		TablesUsed:        logStats.TablesUsed,
+		Error:             logStats.ErrorStr(),
+		TotalTime:         logStats.TotalTime().Seconds(),
+		PlanTime:          logStats.PlanTime.Seconds(),
+		ExecuteTime:       logStats.ExecuteTime.Seconds(),
+		CommitTime:        logStats.CommitTime.Seconds(),
+		ShardQueries:      logStats.ShardQueries,
+		RowsReturned:      rowsReturned,
+		RowsAffected:      logStats.RowsAffected,
+	}
+
+	sq.RemoteAddr, _ = logStats.RemoteAddrUsername()
+
+	executions := logStats.ShardExecutions()
+	slices.SortFunc(executions, func(a, b logstats.ShardExecution) int {
+		return a.Start.Compare(b.Start)
+	})
+	// The shard time is the union of the execution intervals on the shards.
+	var shardTime time.Duration
+	var end time.Time
+	for _, execution := range executions {
+		sq.ShardRows += execution.Rows
+		executionEnd := execution.Start.Add(execution.Time)
+		switch {
+		case !execution.Start.Before(end):
+			shardTime += execution.Time
+			end = executionEnd
+		case executionEnd.After(end):
+			shardTime += executionEnd.Sub(end)
+			end = executionEnd
+		}
+	}
+	sq.ShardTime = shardTime.Seconds()
+	sq.VTGateTime = max(logStats.ExecuteTime-shardTime, 0).Seconds()
+
+	if len(executions) > maxSlowQueryShards {
+		slices.SortStableFunc(executions, func(a, b logstats.ShardExecution) int {
+			return cmp.Compare(b.Time, a.Time)
+		})
+		sq.ShardsOmitted = len(executions) - maxSlowQueryShards
+		executions = executions[:maxSlowQueryShards]
+	}
+	for _, execution := range executions {
+		shard := slowQueryShard{
+			Operation:   execution.Operation,
+			Keyspace:    execution.Keyspace,
+			Shard:       execution.Shard,
+			TabletType:  execution.TabletType,
+			StartOffset: execution.Start.Sub(logStats.StartTime).Seconds(),
+			Time:        execution.Time.Seconds(),
+			Rows:        execution.Rows,
+		}
+		if execution.Error != nil {
+			shard.Error = execution.Error.Error()
+		}
+		sq.Shards = append(sq.Shards, shard)
+	}
+	return sq
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/streamlog"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestNewSlowQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	logStats := logstats.NewLogStats(context.Background(), "Execute", "select 1", "uuid", nil, streamlog.NewQueryLogConfigForTest())
+	logStats.StartTime = start
+	logStats.EndTime = start.Add(time.Second)
+	logStats.PlanTime = 100 * time.Millisecond
+	logStats.ExecuteTime = 800 * time.Millisecond
+	logStats.ShardQueries = 3
+	logStats.ConnectAttributes = map[string]string{"program_name": "orders"}
+
+	// The executions on -80 and 80- overlap, the one on 80- again starts after
+	// they are done.
+	logStats.RecordShardExecution(logstats.ShardExecution{Operation: "Execute", Keyspace: "ks", Shard: "80-", TabletType: "primary", Start: start.Add(200 * time.Millisecond), Time: 300 * time.Millisecond, Rows: 5})
+	logStats.RecordShardExecution(logstats.ShardExecution{Operation: "Execute", Keyspace: "ks", Shard: "-80", TabletType: "primary", Start: start.Add(100 * time.Millisecond), Time: 200 * time.Millisecond, Rows: 10})
+	logStats.RecordShardExecution(logstats.ShardExecution{Operation: "Execute", Keyspace: "ks", Shard: "80-", TabletType: "primary", Start: start.Add(600 * time.Millisecond), Time: 100 * time.Millisecond, Error: errors.New("failed")})
+
+	sq := newSlowQuery("select :vtg1 /* INT64 */", logStats, 15)
+	assert.Equal(t, start, sq.Start)
+	assert.Equal(t, queryDigest("select :vtg1 /* INT64 */"), sq.Digest)
+	assert.InDelta(t, 1, sq.TotalTime, 0.0001)
+	assert.InDelta(t, 0.5, sq.ShardTime, 0.0001)
+	assert.InDelta(t, 0.3, sq.VTGateTime, 0.0001)
+	assert.EqualValues(t, 15, sq.ShardRows)
+	assert.EqualValues(t, 15, sq.RowsReturned)
+	require.Len(t, sq.Shards, 3)
+	assert.Equal(t, slowQueryShard{Operation: "Execute", Keyspace: "ks", Shard: "-80", TabletType: "primary", StartOffset: 0.1, Time: 0.2, Rows: 10}, sq.Shards[0])
+	assert.Equal(t, "failed", sq.Shards[2].Error)
+	assert.Zero(t, sq.ShardsOmitted)
+
+	var buf bytes.Buffer
+	require.NoError(t, sq.Logf(&buf, nil))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "Execute", decoded["Method"])
+	assert.Equal(t, map[string]any{"program_name": "orders"}, decoded["ConnectAttributes"])
+	assert.NotContains(t, decoded, "RemoteAddr")
+	assert.NotContains(t, decoded, "Error")
+
+	// Only the slowest shard executions are logged.
+	for i := range maxSlowQueryShards {
+		logStats.RecordShardExecution(logstats.ShardExecution{Shard: fmt.Sprintf("%d", i), Start: start, Time: time.Millisecond})
+	}
+	sq = newSlowQuery("select :vtg1 /* INT64 */", logStats, 15)
+	require.Len(t, sq.Shards, maxSlowQueryShards)
+	assert.Equal(t, 3, sq.ShardsOmitted)
+	assert.Equal(t, "80-", sq.Shards[0].Shard)
+	assert.Equal(t, "-80", sq.Shards[1].Shard)
+}
+
+func TestExecutorSlowQueryLog(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+
+	saveThreshold := slowQueryLogThreshold
+	defer func() {
+		slowQueryLogThreshold = saveThreshold
+	}()
+	slowQueryLogThreshold = time.Nanosecond
+
+	executor.slowQueryLogger = streamlog.New[*slowQuery]("VTGateSlowQueries", 10)
+	logChan := executor.slowQueryLogger.Subscribe("Test")
+	defer executor.slowQueryLogger.Unsubscribe(logChan)
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+	_, err := executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+
+	var sq *slowQuery
+	select {
+	case sq = <-logChan:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "no slow query logged")
+	}
+	assert.Equal(t, "SELECT", sq.StmtType)
+	assert.EqualValues(t, 8, sq.ShardQueries)
+	assert.EqualValues(t, 8, sq.ShardRows)
+	assert.EqualValues(t, 8, sq.RowsReturned)
+	require.Len(t, sq.Shards, 8)
+	for _, shard := range sq.Shards {
+		assert.Equal(t, "Execute", shard.Operation)
+		assert.Equal(t, KsTestSharded, shard.Keyspace)
+		assert.EqualValues(t, 1, shard.Rows)
+	}
+
+	// Queries under the threshold are not logged.
+	slowQueryLogThreshold = time.Hour
+	_, err = executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	select {
+	case sq = <-logChan:
+		assert.Failf(t, "unexpected slow query", "%v", sq.SQL)
+	default:
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
@@ -54,6 +55,8 @@ var (
 	CellsToWatch string
 
 	initialTabletTimeout = 30 * time.Second
+	// cellDrainRefreshInterval is how often the drains of the cells are read from the topo
+	cellDrainRefreshInterval = 10 * time.Second
 	// retryCount is the number of times a query will be retried on error
 	retryCount = 2
 
@@ -62,7 +65,17 @@ var (
 	balancerVtgateCells []string
 	balancerKeyspaces   []string
 
+	// configuration flags for the hedged reads
+	hedgedReadsEnabled    bool
+	hedgedReadsPercentile = 95.0
+	hedgedReadsMinDelay   = 5 * time.Millisecond
+	// retryBudget is the percentage of the requests to each keyspace which
+	// may be retried or hedged, 0 not limiting them
+	retryBudget float64
+
 	logCollations = logutil.NewThrottledLogger("CollationInconsistent", 1*time.Minute)
+
+	tabletTypeFallbackActivations = stats.NewCountersWithMultiLabels("TabletTypeFallbacks", "Number of requests sent to another tablet type than theirs because theirs had no healthy tablet, per keyspace and tablet types", []string{"Keyspace", "From", "To"})
 )
 
 func init() {
@@ -70,9 +83,14 @@ func init() {
 		fs.StringVar(&CellsToWatch, "cells_to_watch", "", "comma-separated list of cells for watching tablets")
 		fs.DurationVar(&initialTabletTimeout, "gateway_initial_tablet_timeout", 30*time.Second, "At startup, the tabletGateway will wait up to this duration to get at least one tablet per keyspace/shard/tablet type")
 		fs.IntVar(&retryCount, "retry-count", 2, "retry count")
+		fs.DurationVar(&cellDrainRefreshInterval, "cell-drain-refresh-interval", 10*time.Second, "How often to read the drains of the cells from the topo, to shift reads away from the cells being drained")
 		fs.BoolVar(&balancerEnabled, "enable-balancer", false, "Enable the tablet balancer to evenly spread query load for a given tablet type")
 		fs.StringSliceVar(&balancerVtgateCells, "balancer-vtgate-cells", []string{}, "When in balanced mode, a comma-separated list of cells that contain vtgates (required)")
 		fs.StringSliceVar(&balancerKeyspaces, "balancer-keyspaces", []string{}, "When in balanced mode, a comma-separated list of keyspaces for which to use the balancer (optional)")
+		fs.BoolVar(&hedgedReadsEnabled, "enable-hedged-reads", false, "Send the single-shard reads outside of a transaction to a second replica when the first one takes longer than --hedged-reads-percentile of the reads of the keyspace, and use the first response")
+		fs.Float64Var(&hedgedReadsPercentile, "hedged-reads-percentile", 95.0, "Percentile of the latencies of the single-shard reads of a keyspace after which a read is hedged")
+		fs.DurationVar(&hedgedReadsMinDelay, "hedged-reads-min-delay", 5*time.Millisecond, "Minimum delay after which a single-shard read is hedged")
+		fs.Float64Var(&retryBudget, "retry-budget", 0, "Percentage of the requests to each keyspace which may be retried on another tablet or hedged, on top of a burst of 10. 0 does not limit them")
 	})
 }
 
@@ -92,12 +110,23 @@ type TabletGateway struct {
 	// statusAggregators is a map indexed by the key
 	// keyspace/shard/tablet_type.
 	statusAggregators map[string]*TabletStatusAggregator
+	// keyspaceReads is a map indexed by keyspace.
+	keyspaceReads map[string]*keyspaceReads
 
 	// buffer, if enabled, buffers requests during a detected PRIMARY failover.
 	buffer *buffer.Buffer
 
 	// balancer used for routing to tablets
 	balancer balancer.TabletBalancer
+
+	// cellDrains holds the read weights of the cells being drained, keyed
+	// by cell.
+	cellDrains       atomic.Pointer[map[string]int32]
+	cellDrainsCancel context.CancelFunc
+
+	// tabletTypeFallbacks holds the tablet type fallbacks of the keyspaces,
+	// keyed by keyspace.
+	tabletTypeFallbacks atomic.Pointer[map[string][]topodatapb.TabletType]
 }
 
 func createHealthCheck(ctx context.Context, retryDelay, timeout time.Duration, ts *topo.Server, cell, cellsToWatch string) discovery.HealthCheck {
@@ -128,11 +157,13 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 		localCell:         localCell,
 		retryCount:        retryCount,
 		statusAggregators: make(map[string]*TabletStatusAggregator),
+		keyspaceReads:     make(map[string]*keyspaceReads),
 	}
 	gw.setupBuffering(ctx)
 	if balancerEnabled {
 		gw.setupBalancer(ctx)
 	}
+	gw.setupCellDrains(ctx)
 	gw.QueryService = queryservice.Wrap(nil, gw.withRetry)
 	return gw
 }
@@ -173,6 +204,77 @@ func (gw *TabletGateway) setupBalancer(ctx context.Context) {
 	gw.balancer = balancer.NewTabletBalancer(gw.localCell, balancerVtgateCells)
 }
 
+func (gw *TabletGateway) setupCellDrains(ctx context.Context) {
+	if gw.srvTopoServer == nil {
+		return
+	}
+	ts, err := gw.srvTopoServer.GetTopoServer()
+	if err != nil || ts == nil {
+		return
+	}
+	ctx, gw.cellDrainsCancel = context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(cellDrainRefreshInterval)
+		defer ticker.Stop()
+		for {
+			gw.refreshCellDrains(ctx, ts)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// refreshCellDrains reads the drains of the cells from the topo. The previous
+// drains are kept if they cannot be read.
+func (gw *TabletGateway) refreshCellDrains(ctx context.Context, ts *topo.Server) {
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+	drains, err := ts.GetCellDrains(ctx)
+	if err != nil {
+		log.Warningf("Unable to read the drains of the cells: %v", err)
+		return
+	}
+	weights := make(map[string]int32, len(drains))
+	for cell, drain := range drains {
+		weights[cell] = drain.ReadWeight
+	}
+	gw.cellDrains.Store(&weights)
+}
+
+// SetTabletTypeFallbacks sets the tablet type fallbacks of the keyspaces,
+// keyed by keyspace.
+func (gw *TabletGateway) SetTabletTypeFallbacks(fallbacks map[string][]topodatapb.TabletType) {
+	gw.tabletTypeFallbacks.Store(&fallbacks)
+}
+
+// fallbackTablets returns the healthy tablets of the first tablet type with
+// some which follows the tablet type of the target in the tablet type
+// fallbacks of its keyspace, along with the target of that tablet type. The
+// requests on the primary do not fall back to other tablet types.
+func (gw *TabletGateway) fallbackTablets(target *querypb.Target) (*querypb.Target, []*discovery.TabletHealth) {
+	fallbacks := gw.tabletTypeFallbacks.Load()
+	if fallbacks == nil || target.TabletType == topodatapb.TabletType_PRIMARY {
+		return target, nil
+	}
+	chain := (*fallbacks)[target.Keyspace]
+	i := slices.Index(chain, target.TabletType)
+	if i < 0 {
+		return target, nil
+	}
+	for _, tabletType := range chain[i+1:] {
+		fallback := target.CloneVT()
+		fallback.TabletType = tabletType
+		if tablets := gw.hc.GetHealthyTabletStats(fallback); len(tablets) > 0 {
+			tabletTypeFallbackActivations.Add([]string{target.Keyspace, topoproto.TabletTypeLString(target.TabletType), topoproto.TabletTypeLString(tabletType)}, 1)
+			return fallback, tablets
+		}
+	}
+	return target, nil
+}
+
 // QueryServiceByAlias satisfies the Gateway interface
 func (gw *TabletGateway) QueryServiceByAlias(ctx context.Context, alias *topodatapb.TabletAlias, target *querypb.Target) (queryservice.QueryService, error) {
 	qs, err := gw.hc.TabletConnection(ctx, alias, target)
@@ -187,6 +289,51 @@ func (gw *TabletGateway) GetServingKeyspaces() []string {
 	return gw.kev.GetServingKeyspaces()
 }
 
+// ReplicationStaleness returns the staleness of the data of the tablets
+// the queries for the target are routed to, as reported by their health
+// checks. Those queries go to any healthy tablet of the local cell when
+// there is one, so the staleness of the most stale of them is returned.
+func (gw *TabletGateway) ReplicationStaleness(target *querypb.Target) (time.Duration, error) {
+	tablets := gw.hc.GetHealthyTabletStats(target)
+	if local := slices.DeleteFunc(slices.Clone(tablets), func(t *discovery.TabletHealth) bool {
+		return t.Tablet.Alias.Cell != gw.localCell
+	}); len(local) > 0 {
+		tablets = local
+	}
+	if len(tablets) == 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available for '%s'", target.String())
+	}
+	var staleness time.Duration
+	for _, th := range tablets {
+		// The healthy tablets are not refreshed by the updates of their
+		// health that do not change their eligibility, so their current
+		// stats are looked up.
+		if current, err := gw.hc.GetTabletHealth(discovery.KeyFromTarget(target), th.Tablet.Alias); err == nil {
+			th = current
+		}
+		staleness = max(staleness, time.Duration(th.Stats.GetReplicationStalenessMs())*time.Millisecond)
+	}
+	return staleness, nil
+}
+
+// FilteredReplicationLag returns the lag of the VReplication workflows of the
+// primary of the target's shard, as reported by its health checks. It fails if
+// the primary runs no workflow.
+func (gw *TabletGateway) FilteredReplicationLag(target *querypb.Target) (time.Duration, error) {
+	tablets := gw.hc.GetHealthyTabletStats(target)
+	if len(tablets) == 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet available for '%s'", target.String())
+	}
+	th := tablets[0]
+	if current, err := gw.hc.GetTabletHealth(discovery.KeyFromTarget(target), th.Tablet.Alias); err == nil {
+		th = current
+	}
+	if th.Stats.GetBinlogPlayersCount() == 0 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no VReplication workflow running on '%s'", target.String())
+	}
+	return time.Duration(th.Stats.GetFilteredReplicationLagSeconds()) * time.Second, nil
+}
+
 // RegisterStats registers the stats to export the lag since the last refresh
 // and the checksum of the topology
 func (gw *TabletGateway) RegisterStats() {
@@ -242,6 +389,9 @@ func (gw *TabletGateway) WaitForTablets(ctx context.Context, tabletTypesToWait [
 // Close shuts down underlying connections.
 // This function hides the inner implementation.
 func (gw *TabletGateway) Close(_ context.Context) error {
+	if gw.cellDrainsCancel != nil {
+		gw.cellDrainsCancel()
+	}
 	if gw.buffer != nil {
 		gw.buffer.Shutdown()
 	}
@@ -289,6 +439,12 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 	var err error
 	invalidTablets := make(map[string]bool)
 
+	// The hedges of a read do not add to the retry budget, the read does.
+	hedge := hedgedReadFromContext(ctx)
+	if hedge == nil {
+		gw.getKeyspaceReads(target.Keyspace).depositRetryBudget()
+	}
+
 	if len(discovery.AllowedTabletTypes) > 0 {
 		var match bool
 		for _, allowed := range discovery.AllowedTabletTypes {
@@ -328,7 +484,29 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 			}
 		}
 
-		tablets := gw.hc.GetHealthyTabletStats(target)
+		var tablets []*discovery.TabletHealth
+		if maxStaleness := maxStalenessFromContext(ctx); maxStaleness > 0 && target.TabletType != topodatapb.TabletType_PRIMARY {
+			tablets = gw.tabletsWithinStaleness(target, maxStaleness)
+			if len(tablets) == 0 {
+				err = vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no serving tablet with a staleness within %v available for '%s'", maxStaleness, target.String())
+				break
+			}
+		} else {
+			tablets = gw.hc.GetHealthyTabletStats(target)
+			if len(tablets) == 0 && !inTransaction {
+				target, tablets = gw.fallbackTablets(target)
+			}
+		}
+		if tags := tabletTagsFromContext(ctx); len(tags) > 0 && target.TabletType != topodatapb.TabletType_PRIMARY {
+			filter := discovery.NewFilterByTabletTags(tags)
+			tablets = slices.DeleteFunc(tablets, func(t *discovery.TabletHealth) bool {
+				return !filter.IsIncluded(t.Tablet)
+			})
+			if len(tablets) == 0 {
+				err = vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no healthy tablet with tags %s available for '%s'", sortedPairsString(tags), target.String())
+				break
+			}
+		}
 		if len(tablets) == 0 {
 			// if we have a keyspace event watcher, check if the reason why our primary is not available is that it's currently being resharded
 			// or if a reparent operation is in progress.
@@ -361,6 +539,11 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 
 		var th *discovery.TabletHealth
 
+		// the hedges of a read go to other tablets than the read
+		if hedge != nil {
+			hedge.skipTablets(invalidTablets)
+		}
+
 		useBalancer := balancerEnabled
 		if balancerEnabled && len(balancerKeyspaces) > 0 {
 			useBalancer = slices.Contains(balancerKeyspaces, target.Keyspace)
@@ -374,10 +557,16 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 				})
 			}
 
-			th = gw.balancer.Pick(target, tablets)
+			// prefer the tablets outside the cells being drained
+			n := gw.deprioritizeDrainedCells(tablets)
+			th = gw.balancer.Pick(target, tablets[:n])
+			if th == nil {
+				th = gw.balancer.Pick(target, tablets)
+			}
 
 		} else {
 			gw.shuffleTablets(gw.localCell, tablets)
+			gw.deprioritizeDrainedCells(tablets)
 
 			// skip tablets we tried before
 			for _, t := range tablets {
@@ -397,6 +586,9 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		}
 
 		tabletLastUsed = th.Tablet
+		if hedge != nil {
+			hedge.addTablet(tabletLastUsed.Alias)
+		}
 		// execute
 		if th.Conn == nil {
 			err = vterrors.VT14003(tabletLastUsed)
@@ -412,6 +604,10 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 		gw.updateStats(target, startTime, err)
 		if canRetry {
 			invalidTablets[topoproto.TabletAliasString(tabletLastUsed.Alias)] = true
+			if i < gw.retryCount && !gw.getKeyspaceReads(target.Keyspace).withdrawRetryBudget() {
+				retryBudgetExhausted.Add([]string{target.Keyspace, "Retry"}, 1)
+				break
+			}
 			continue
 		}
 		break
@@ -419,6 +615,56 @@ func (gw *TabletGateway) withRetry(ctx context.Context, target *querypb.Target,
 	return NewShardError(err, target)
 }
 
+type tabletTagsKey struct{}
+
+// withTabletTags returns a context in which the queries which do not run on
+// the primary only run on the tablets with the given tags.
+func withTabletTags(ctx context.Context, tags map[string]string) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, tabletTagsKey{}, tags)
+}
+
+func tabletTagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tabletTagsKey{}).(map[string]string)
+	return tags
+}
+
+// tabletsWithinStaleness returns the serving tablets of the target whose data
+// is at most maxStaleness stale. They are picked by the staleness they report
+// in their health checks rather than by the replication lag thresholds of the
+// health check, so the tablets it deems too lagging may be returned, and the
+// healthy tablets which are more stale than maxStaleness are not.
+func (gw *TabletGateway) tabletsWithinStaleness(target *querypb.Target, maxStaleness time.Duration) []*discovery.TabletHealth {
+	return slices.DeleteFunc(gw.hc.GetServingTabletStats(target), func(th *discovery.TabletHealth) bool {
+		return tabletStaleness(th) > maxStaleness
+	})
+}
+
+// tabletStaleness returns the staleness of the data of the tablet. The
+// replication lag bounds it for the tablets which do not report it.
+func tabletStaleness(th *discovery.TabletHealth) time.Duration {
+	staleness := time.Duration(th.Stats.GetReplicationStalenessMs()) * time.Millisecond
+	return max(staleness, time.Duration(th.Stats.GetReplicationLagSeconds())*time.Second)
+}
+
+type maxStalenessKey struct{}
+
+// withMaxStaleness returns a context in which the queries which do not run on
+// the primary only run on the tablets whose data is at most maxStaleness stale.
+func withMaxStaleness(ctx context.Context, maxStaleness time.Duration) context.Context {
+	if maxStaleness <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, maxStalenessKey{}, maxStaleness)
+}
+
+func maxStalenessFromContext(ctx context.Context) time.Duration {
+	maxStaleness, _ := ctx.Value(maxStalenessKey{}).(time.Duration)
+	return maxStaleness
+}
+
 // withShardError adds shard information to errors returned from the inner QueryService.
 func (gw *TabletGateway) withShardError(ctx context.Context, target *querypb.Target, conn queryservice.QueryService,
 	_ string, _ bool, inner func(ctx context.Context, target *querypb.Target, conn queryservice.QueryService) (bool, error)) error {
@@ -470,6 +716,34 @@ func (gw *TabletGateway) shuffleTablets(cell string, tablets []*discovery.Tablet
 	}
 }
 
+// deprioritizeDrainedCells moves the tablets of the cells being drained to the
+// back of the list, keeping the order of the tablets otherwise, and returns
+// the number of tablets left at the front. Each drained cell still keeps its
+// tablets at the front for the percentage of the calls given by its read
+// weight.
+func (gw *TabletGateway) deprioritizeDrainedCells(tablets []*discovery.TabletHealth) int {
+	drains := gw.cellDrains.Load()
+	if drains == nil || len(*drains) == 0 {
+		return len(tablets)
+	}
+	skipped := make(map[string]bool, len(*drains))
+	for cell, readWeight := range *drains {
+		skipped[cell] = rand.Int32N(100) >= readWeight
+	}
+	preferred := make([]*discovery.TabletHealth, 0, len(tablets))
+	var drained []*discovery.TabletHealth
+	for _, t := range tablets {
+		if skipped[t.Tablet.Alias.Cell] {
+			drained = append(drained, t)
+		} else {
+			preferred = append(preferred, t)
+		}
+	}
+	copy(tablets, preferred)
+	copy(tablets[len(preferred):], drained)
+	return len(preferred)
+}
+
 // TabletsCacheStatus returns a displayable version of the health check cache.
 func (gw *TabletGateway) TabletsCacheStatus() discovery.TabletsCacheStatusList {
 	return gw.hc.CacheStatus()
@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	// retryBudgetBurst is the number of retries and hedges the retry budget
+	// of a keyspace allows at once, and starts with.
+	retryBudgetBurst = 10
+
+	// hedgedReadsMinSamples is the number of latencies of the reads of a
+	// keyspace needed to know their percentile, before which the reads are
+	// not hedged.
+	hedgedReadsMinSamples = 100
+
+	// hedgedReadsLatencyWindow is how long the latencies of the reads of a
+	// keyspace are kept for. The percentile is taken over the latencies of the
+	// current and the previous windows.
+	hedgedReadsLatencyWindow = time.Minute
+)
+
+var (
+	hedgedReads          = stats.NewCountersWithMultiLabels("HedgedReads", "Number of single-shard reads sent to a second tablet, per keyspace, by whether the second tablet answered first (Won), the first one did (Lost) or both failed (Failed)", []string{"Keyspace", "Result"})
+	retryBudgetExhausted = stats.NewCountersWithMultiLabels("RetryBudgetExhausted", "Number of retries and hedges of requests not sent because the retry budget of their keyspace was exhausted, per keyspace and kind", []string{"Keyspace", "Kind"})
+)
+
+// keyspaceReads holds the latencies of the hedgeable reads of a keyspace and
+// its retry budget.
+type keyspaceReads struct {
+	mu sync.Mutex
+	// latencies and previousLatencies are the histograms of the latencies
+	// of the reads of the current and previous windows, bucketed like those
+	// of the query digests.
+	latencies, previousLatencies   []int64
+	maxLatency, previousMaxLatency time.Duration
+	windowStart                    time.Time
+	// tokens is the number of retries and hedges the retry budget allows.
+	tokens float64
+}
+
+func newKeyspaceReads() *keyspaceReads {
+	return &keyspaceReads{
+		latencies:         make([]int64, len(queryDigestLatencyBounds)+1),
+		previousLatencies: make([]int64, len(queryDigestLatencyBounds)+1),
+		windowStart:       time.Now(),
+		tokens:            retryBudgetBurst,
+	}
+}
+
+func (gw *TabletGateway) getKeyspaceReads(keyspace string) *keyspaceReads {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	reads, ok := gw.keyspaceReads[keyspace]
+	if !ok {
+		reads = newKeyspaceReads()
+		gw.keyspaceReads[keyspace] = reads
+	}
+	return reads
+}
+
+// depositRetryBudget adds the share of a request to the retry budget.
+func (r *keyspaceReads) depositRetryBudget() {
+	if retryBudget <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens = min(r.tokens+retryBudget/100, retryBudgetBurst)
+}
+
+// withdrawRetryBudget takes a retry or a hedge from the retry budget, and
+// returns false if the budget is exhausted.
+func (r *keyspaceReads) withdrawRetryBudget() bool {
+	if retryBudget <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// maybeRotate starts a new window of latencies if the current one is over.
+// The caller must hold mu.
+func (r *keyspaceReads) maybeRotate(now time.Time) {
+	if now.Sub(r.windowStart) < hedgedReadsLatencyWindow {
+		return
+	}
+	if now.Sub(r.windowStart) < 2*hedgedReadsLatencyWindow {
+		r.latencies, r.previousLatencies = r.previousLatencies, r.latencies
+		r.previousMaxLatency = r.maxLatency
+	} else {
+		r.previousMaxLatency = 0
+		clear(r.previousLatencies)
+	}
+	clear(r.latencies)
+	r.maxLatency = 0
+	r.windowStart = now
+}
+
+// recordLatency adds the latency of a read.
+func (r *keyspaceReads) recordLatency(elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeRotate(time.Now())
+	r.maxLatency = max(r.maxLatency, elapsed)
+	r.latencies[sort.Search(len(queryDigestLatencyBounds), func(i int) bool {
+		return queryDigestLatencyBounds[i] >= elapsed
+	})]++
+}
+
+// hedgeDelay returns how long a read waits for its tablet before it is
+// hedged, which is the --hedged-reads-percentile of the latencies of the
+// reads, and false if there are not enough latencies to know it.
+func (r *keyspaceReads) hedgeDelay() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeRotate(time.Now())
+	histogram := make([]int64, len(r.latencies))
+	var samples int64
+	for i := range histogram {
+		histogram[i] = r.latencies[i] + r.previousLatencies[i]
+		samples += histogram[i]
+	}
+	if samples < hedgedReadsMinSamples {
+		return 0, false
+	}
+	delay := queryDigestPercentile(histogram, hedgedReadsPercentile/100, max(r.maxLatency, r.previousMaxLatency))
+	return max(delay, hedgedReadsMinDelay), true
+}
+
+type hedgeableReadKey struct{}
+
+// withHedgeableRead returns a context in which the queries are reads of a
+// single shard, which may be sent again to another tablet.
+func withHedgeableRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hedgeableReadKey{}, true)
+}
+
+func isHedgeableRead(ctx context.Context) bool {
+	hedgeable, _ := ctx.Value(hedgeableReadKey{}).(bool)
+	return hedgeable
+}
+
+// hedgedRead records the tablets a read and its hedge were sent to, so that
+// they are sent to different tablets.
+type hedgedRead struct {
+	mu      sync.Mutex
+	tablets map[string]bool
+}
+
+func (h *hedgedRead) addTablet(alias *topodatapb.TabletAlias) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tablets[topoproto.TabletAliasString(alias)] = true
+}
+
+// skipTablets adds the tablets the read was sent to to invalidTablets.
+func (h *hedgedRead) skipTablets(invalidTablets map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for alias := range h.tablets {
+		invalidTablets[alias] = true
+	}
+}
+
+type hedgedReadKey struct{}
+
+func hedgedReadFromContext(ctx context.Context) *hedgedRead {
+	hedge, _ := ctx.Value(hedgedReadKey{}).(*hedgedRead)
+	return hedge
+}
+
+// Execute executes the query on a tablet of the target. With
+// --enable-hedged-reads, the reads of a single shard outside of a transaction
+// are hedged.
+func (gw *TabletGateway) Execute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	if !hedgedReadsEnabled || transactionID != 0 || reservedID != 0 || target.TabletType == topodatapb.TabletType_PRIMARY || !isHedgeableRead(ctx) {
+		return gw.QueryService.Execute(ctx, target, query, bindVars, transactionID, reservedID, options)
+	}
+	return gw.executeHedged(ctx, target, query, bindVars, options)
+}
+
+// executeHedged sends the read to a tablet of the target and, when it takes
+// longer than the hedge delay of the keyspace, sends it again to another
+// tablet if the retry budget allows it. The first successful response is
+// returned, and the other request is canceled. If both fail, the error of the
+// first request is returned.
+func (gw *TabletGateway) executeHedged(ctx context.Context, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	reads := gw.getKeyspaceReads(target.Keyspace)
+	reads.depositRetryBudget()
+	start := time.Now()
+	delay, ok := reads.hedgeDelay()
+	if !ok || len(gw.hc.GetHealthyTabletStats(target)) < 2 {
+		qr, err := gw.QueryService.Execute(ctx, target, query, bindVars, 0, 0, options)
+		if err == nil {
+			reads.recordLatency(time.Since(start))
+		}
+		return qr, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = context.WithValue(ctx, hedgedReadKey{}, &hedgedRead{tablets: make(map[string]bool)})
+
+	type response struct {
+		qr    *sqltypes.Result
+		err   error
+		hedge bool
+	}
+	// The channel is buffered for the request which is not waited for not
+	// to block.
+	responses := make(chan response, 2)
+	send := func(hedge bool) {
+		qr, err := gw.QueryService.Execute(ctx, target, query, bindVars, 0, 0, options)
+		responses <- response{qr: qr, err: err, hedge: hedge}
+	}
+	go send(false)
+	pending := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	var err error
+	hedged := false
+	for {
+		select {
+		case <-timer.C:
+			if !reads.withdrawRetryBudget() {
+				retryBudgetExhausted.Add([]string{target.Keyspace, "Hedge"}, 1)
+				continue
+			}
+			hedged = true
+			pending++
+			go send(true)
+		case r := <-responses:
+			pending--
+			if r.err == nil {
+				reads.recordLatency(time.Since(start))
+				if r.hedge {
+					hedgedReads.Add([]string{target.Keyspace, "Won"}, 1)
+				} else if hedged {
+					hedgedReads.Add([]string{target.Keyspace, "Lost"}, 1)
+				}
+				return r.qr, nil
+			}
+			if !r.hedge {
+				err = r.err
+			}
+			if pending == 0 {
+				if hedged {
+					hedgedReads.Add([]string{target.Keyspace, "Failed"}, 1)
+				}
+				return nil, err
+			}
+		}
+	}
+}
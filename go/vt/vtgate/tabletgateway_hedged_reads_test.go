@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestHedgeableRead(t *testing.T) {
+	tcases := []struct {
+		name      string
+		queryType sqlparser.StatementType
+		primitive engine.Primitive
+		inTx      bool
+		want      bool
+	}{{
+		name:      "unique vindex",
+		queryType: sqlparser.StmtSelect,
+		primitive: engine.NewRoute(engine.EqualUnique, nil, "select 1", ""),
+		want:      true,
+	}, {
+		name:      "unsharded",
+		queryType: sqlparser.StmtSelect,
+		primitive: engine.NewRoute(engine.Unsharded, nil, "select 1", ""),
+		want:      true,
+	}, {
+		name:      "scatter",
+		queryType: sqlparser.StmtSelect,
+		primitive: engine.NewRoute(engine.Scatter, nil, "select 1", ""),
+	}, {
+		name:      "sequence",
+		queryType: sqlparser.StmtSelect,
+		primitive: engine.NewRoute(engine.Next, nil, "select next value from seq", ""),
+	}, {
+		name:      "in transaction",
+		queryType: sqlparser.StmtSelect,
+		primitive: engine.NewRoute(engine.EqualUnique, nil, "select 1", ""),
+		inTx:      true,
+	}, {
+		name:      "update",
+		queryType: sqlparser.StmtUpdate,
+		primitive: engine.NewRoute(engine.EqualUnique, nil, "update t set a = 1", ""),
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: tcase.inTx})
+			plan := &engine.Plan{QueryType: tcase.queryType, Instructions: tcase.primitive}
+			assert.Equal(t, tcase.want, hedgeableRead(plan, session))
+		})
+	}
+}
+
+func TestKeyspaceReadsHedgeDelay(t *testing.T) {
+	defer func(percentile float64, minDelay time.Duration) {
+		hedgedReadsPercentile, hedgedReadsMinDelay = percentile, minDelay
+	}(hedgedReadsPercentile, hedgedReadsMinDelay)
+	hedgedReadsPercentile, hedgedReadsMinDelay = 95, 5*time.Millisecond
+
+	reads := newKeyspaceReads()
+	for i := 0; i < 96; i++ {
+		reads.recordLatency(500 * time.Microsecond)
+	}
+	_, ok := reads.hedgeDelay()
+	assert.False(t, ok, "the percentile is not known before enough reads")
+
+	for i := 0; i < 4; i++ {
+		reads.recordLatency(800 * time.Millisecond)
+	}
+	delay, ok := reads.hedgeDelay()
+	require.True(t, ok)
+	// The percentile is below the minimum delay.
+	assert.Equal(t, 5*time.Millisecond, delay)
+
+	hedgedReadsPercentile = 99
+	delay, ok = reads.hedgeDelay()
+	require.True(t, ok)
+	assert.Equal(t, 800*time.Millisecond, delay)
+
+	// The latencies of the previous window are kept, not the older ones.
+	reads.windowStart = reads.windowStart.Add(-hedgedReadsLatencyWindow)
+	_, ok = reads.hedgeDelay()
+	assert.True(t, ok)
+	reads.windowStart = reads.windowStart.Add(-hedgedReadsLatencyWindow)
+	_, ok = reads.hedgeDelay()
+	assert.False(t, ok)
+}
+
+func TestKeyspaceReadsRetryBudget(t *testing.T) {
+	defer func(budget float64) { retryBudget = budget }(retryBudget)
+
+	retryBudget = 0
+	reads := newKeyspaceReads()
+	for i := 0; i < 2*retryBudgetBurst; i++ {
+		assert.True(t, reads.withdrawRetryBudget(), "the retries are not limited without a budget")
+	}
+
+	retryBudget = 25
+	reads = newKeyspaceReads()
+	for i := 0; i < retryBudgetBurst; i++ {
+		assert.True(t, reads.withdrawRetryBudget())
+	}
+	assert.False(t, reads.withdrawRetryBudget())
+
+	// Each request adds a quarter of a retry.
+	for i := 0; i < 3; i++ {
+		reads.depositRetryBudget()
+	}
+	assert.False(t, reads.withdrawRetryBudget())
+	reads.depositRetryBudget()
+	assert.True(t, reads.withdrawRetryBudget())
+
+	// The budget does not grow beyond the burst.
+	for i := 0; i < 100; i++ {
+		reads.depositRetryBudget()
+	}
+	for i := 0; i < retryBudgetBurst; i++ {
+		assert.True(t, reads.withdrawRetryBudget())
+	}
+	assert.False(t, reads.withdrawRetryBudget())
+}
+
+func TestTabletGatewayHedgedReads(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	defer func(enabled bool, minDelay time.Duration, budget float64) {
+		hedgedReadsEnabled, hedgedReadsMinDelay, retryBudget = enabled, minDelay, budget
+	}(hedgedReadsEnabled, hedgedReadsMinDelay, retryBudget)
+	hedgedReadsEnabled, hedgedReadsMinDelay, retryBudget = true, 20*time.Millisecond, 0
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	slow := hc.AddTestTablet("cell", "1.1.1.1", 1001, "hedged", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	slow.ExecuteDelayResponse = 200 * time.Millisecond
+	fast := hc.AddTestTablet("cell", "1.1.1.2", 1001, "hedged", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	replica := &querypb.Target{Keyspace: "hedged", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+
+	reads := tg.getKeyspaceReads("hedged")
+	for i := 0; i < hedgedReadsMinSamples; i++ {
+		reads.recordLatency(time.Millisecond)
+	}
+
+	initialWon := hedgedReads.Counts()["hedged.Won"]
+	hedgeableCtx := withHedgeableRead(ctx)
+	for i := 0; i < 20; i++ {
+		_, err := tg.Execute(hedgeableCtx, replica, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	// The reads sent to the slow tablet are answered by the fast one.
+	assert.EqualValues(t, 20, fast.ExecCount.Load())
+	assert.Positive(t, hedgedReads.Counts()["hedged.Won"]-initialWon)
+
+	// The reads are not hedged once the retry budget is exhausted.
+	retryBudget = 1
+	reads.tokens = 0
+	initialExhausted := retryBudgetExhausted.Counts()["hedged.Hedge"]
+	for i := 0; i < 20; i++ {
+		_, err := tg.Execute(hedgeableCtx, replica, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.Positive(t, retryBudgetExhausted.Counts()["hedged.Hedge"]-initialExhausted)
+}
+
+func TestTabletGatewayHedgedReadsNotHedgeable(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	defer func(enabled bool, minDelay time.Duration) {
+		hedgedReadsEnabled, hedgedReadsMinDelay = enabled, minDelay
+	}(hedgedReadsEnabled, hedgedReadsMinDelay)
+	hedgedReadsEnabled, hedgedReadsMinDelay = true, 5*time.Millisecond
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	slow := hc.AddTestTablet("cell", "1.1.1.1", 1001, "unhedged", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	slow.ExecuteDelayResponse = 50 * time.Millisecond
+	fast := hc.AddTestTablet("cell", "1.1.1.2", 1001, "unhedged", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	replica := &querypb.Target{Keyspace: "unhedged", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+
+	reads := tg.getKeyspaceReads("unhedged")
+	for i := 0; i < hedgedReadsMinSamples; i++ {
+		reads.recordLatency(time.Millisecond)
+	}
+
+	// The queries which are not single-shard reads are sent to a single tablet.
+	for i := 0; i < 10; i++ {
+		_, err := tg.Execute(ctx, replica, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 10, slow.ExecCount.Load()+fast.ExecCount.Load())
+	assert.Zero(t, hedgedReads.Counts()["unhedged.Won"]+hedgedReads.Counts()["unhedged.Lost"])
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 
@@ -36,6 +37,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 )
@@ -82,7 +84,7 @@ func TestTabletGatewayBegin(t *testing.T) {
 func TestTabletGatewayCommit(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 	testTabletGatewayTransact(t, ctx, func(ctx context.Context, tg *TabletGateway, target *querypb.Target) error {
-		_, err := tg.Commit(ctx, target, 1)
+		_, _, err := tg.Commit(ctx, target, 1)
 		return err
 	})
 }
@@ -169,6 +171,52 @@ func TestTabletGatewayShuffleTablets(t *testing.T) {
 	}
 }
 
+func TestTabletGatewayDeprioritizeDrainedCells(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell1")
+	defer tg.Close(ctx)
+
+	ts := memorytopo.NewServer(ctx, "cell1", "cell2", "cell3")
+	defer ts.Close()
+
+	newTablet := func(uid uint32, cell string) *discovery.TabletHealth {
+		return &discovery.TabletHealth{
+			Tablet:  topo.NewTablet(uid, cell, fmt.Sprintf("host%d", uid)),
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Serving: true,
+		}
+	}
+	ts1 := newTablet(1, "cell1")
+	ts2 := newTablet(2, "cell2")
+	ts3 := newTablet(3, "cell3")
+
+	// No cell is drained.
+	tg.refreshCellDrains(ctx, ts)
+	tablets := []*discovery.TabletHealth{ts1, ts2, ts3}
+	assert.Equal(t, 3, tg.deprioritizeDrainedCells(tablets))
+	assert.Equal(t, []*discovery.TabletHealth{ts1, ts2, ts3}, tablets)
+
+	// cell1 is fully drained, while cell2 still gets all its reads.
+	require.NoError(t, ts.UpdateCellInfoFields(ctx, "cell1", func(ci *topodatapb.CellInfo) error {
+		ci.Drain = &topodatapb.CellDrain{ReadWeight: 0}
+		return nil
+	}))
+	require.NoError(t, ts.UpdateCellInfoFields(ctx, "cell2", func(ci *topodatapb.CellInfo) error {
+		ci.Drain = &topodatapb.CellDrain{ReadWeight: 100}
+		return nil
+	}))
+	tg.refreshCellDrains(ctx, ts)
+	for i := 0; i < 10; i++ {
+		tablets = []*discovery.TabletHealth{ts1, ts2, ts3}
+		tg.shuffleTablets("cell1", tablets)
+		assert.Equal(t, 2, tg.deprioritizeDrainedCells(tablets))
+		assert.ElementsMatch(t, []*discovery.TabletHealth{ts2, ts3}, tablets[:2], "tablets of the drained cell should be at the back, got %+v", tablets)
+		assert.Equal(t, ts1, tablets[2])
+	}
+}
+
 func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
@@ -401,3 +449,183 @@ func TestWithRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestTabletGatewayTabletTags(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	oltp := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	analytics := hc.AddTestTablet("cell", "1.1.1.2", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	analytics.Tablet().Tags = map[string]string{"analytics": "true", "zone": "a"}
+	primary := hc.AddTestTablet("cell", "1.1.1.3", 1001, "ks", "0", topodatapb.TabletType_PRIMARY, true, 10, nil)
+
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	tagsCtx := withTabletTags(ctx, map[string]string{"analytics": "true"})
+	for i := 0; i < 10; i++ {
+		_, err := tg.Execute(tagsCtx, replica, "query", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 0, oltp.ExecCount.Load())
+	assert.EqualValues(t, 10, analytics.ExecCount.Load())
+
+	// The queries on the primary ignore the tags.
+	_, err := tg.Execute(tagsCtx, &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_PRIMARY}, "query", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.ExecCount.Load())
+
+	_, err = tg.Execute(withTabletTags(ctx, map[string]string{"analytics": "false"}), replica, "query", nil, 0, 0, nil)
+	verifyContainsError(t, err, `no healthy tablet with tags analytics=false available for 'keyspace:"ks" shard:"0" tablet_type:REPLICA'`, vtrpcpb.Code_UNAVAILABLE)
+}
+
+func TestTabletGatewayReplicationStaleness(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	setStaleness := func(sbc *sandboxconn.SandboxConn, ms int64) {
+		th, err := hc.GetTabletHealthByAlias(sbc.Tablet().Alias)
+		require.NoError(t, err)
+		th.Stats = &querypb.RealtimeStats{ReplicationStalenessMs: ms}
+	}
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	_, err := tg.ReplicationStaleness(replica)
+	verifyContainsError(t, err, `no healthy tablet available for 'keyspace:"ks" shard:"0" tablet_type:REPLICA'`, vtrpcpb.Code_UNAVAILABLE)
+
+	// The most stale tablet of the local cell is reported.
+	setStaleness(hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil), 200)
+	setStaleness(hc.AddTestTablet("cell", "1.1.1.2", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil), 1500)
+	setStaleness(hc.AddTestTablet("remote", "1.1.1.3", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil), 60000)
+	staleness, err := tg.ReplicationStaleness(replica)
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, staleness)
+
+	// The tablets of other cells are only used without local ones.
+	rdonly := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_RDONLY}
+	setStaleness(hc.AddTestTablet("remote", "1.1.1.4", 1001, "ks", "0", topodatapb.TabletType_RDONLY, true, 10, nil), 3000)
+	staleness, err = tg.ReplicationStaleness(rdonly)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, staleness)
+}
+
+func TestTabletGatewayFilteredReplicationLag(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	primary := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_PRIMARY}
+	_, err := tg.FilteredReplicationLag(primary)
+	verifyContainsError(t, err, `no healthy tablet available for 'keyspace:"ks" shard:"0" tablet_type:PRIMARY'`, vtrpcpb.Code_UNAVAILABLE)
+
+	sbc := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_PRIMARY, true, 10, nil)
+	th, err := hc.GetTabletHealthByAlias(sbc.Tablet().Alias)
+	require.NoError(t, err)
+	th.Stats = &querypb.RealtimeStats{FilteredReplicationLagSeconds: 12}
+	_, err = tg.FilteredReplicationLag(primary)
+	verifyContainsError(t, err, `no VReplication workflow running on 'keyspace:"ks" shard:"0" tablet_type:PRIMARY'`, vtrpcpb.Code_UNAVAILABLE)
+
+	th.Stats = &querypb.RealtimeStats{FilteredReplicationLagSeconds: 12, BinlogPlayersCount: 1}
+	lag, err := tg.FilteredReplicationLag(primary)
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Second, lag)
+}
+
+func TestTabletGatewayMaxStaleness(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+
+	setStats := func(sbc *sandboxconn.SandboxConn, stats *querypb.RealtimeStats) {
+		th, err := hc.GetTabletHealthByAlias(sbc.Tablet().Alias)
+		require.NoError(t, err)
+		th.Stats = stats
+	}
+	fresh := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	setStats(fresh, &querypb.RealtimeStats{ReplicationStalenessMs: 500})
+	stale := hc.AddTestTablet("cell", "1.1.1.2", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	setStats(stale, &querypb.RealtimeStats{ReplicationStalenessMs: 20000})
+	// The replication lag bounds the staleness of the tablets which do not
+	// report it.
+	lagging := hc.AddTestTablet("cell", "1.1.1.3", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	setStats(lagging, &querypb.RealtimeStats{ReplicationLagSeconds: 40})
+	primary := hc.AddTestTablet("cell", "1.1.1.4", 1001, "ks", "0", topodatapb.TabletType_PRIMARY, true, 10, nil)
+	setStats(primary, &querypb.RealtimeStats{ReplicationLagSeconds: 60})
+
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	stalenessCtx := withMaxStaleness(ctx, 5*time.Second)
+	for i := 0; i < 10; i++ {
+		_, err := tg.Execute(stalenessCtx, replica, "query", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 10, fresh.ExecCount.Load())
+	assert.EqualValues(t, 0, stale.ExecCount.Load())
+	assert.EqualValues(t, 0, lagging.ExecCount.Load())
+
+	for i := 0; i < 10; i++ {
+		_, err := tg.Execute(withMaxStaleness(ctx, 30*time.Second), replica, "query", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 0, lagging.ExecCount.Load())
+	assert.EqualValues(t, 20, fresh.ExecCount.Load()+stale.ExecCount.Load())
+
+	// The queries on the primary ignore the bound.
+	_, err := tg.Execute(stalenessCtx, &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_PRIMARY}, "query", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.ExecCount.Load())
+
+	_, err = tg.Execute(withMaxStaleness(ctx, 100*time.Millisecond), replica, "query", nil, 0, 0, nil)
+	verifyContainsError(t, err, `no serving tablet with a staleness within 100ms available for 'keyspace:"ks" shard:"0" tablet_type:REPLICA'`, vtrpcpb.Code_UNAVAILABLE)
+}
+
+func TestTabletGatewayTabletTypeFallbacks(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+	tg.SetTabletTypeFallbacks(map[string][]topodatapb.TabletType{
+		"ks": {topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_PRIMARY},
+	})
+
+	setServing := func(sbc *sandboxconn.SandboxConn, serving bool) {
+		th, err := hc.GetTabletHealthByAlias(sbc.Tablet().Alias)
+		require.NoError(t, err)
+		th.Serving = serving
+	}
+	primary := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_PRIMARY, true, 10, nil)
+	rdonly := hc.AddTestTablet("cell", "1.1.1.2", 1001, "ks", "0", topodatapb.TabletType_RDONLY, true, 10, nil)
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+
+	initial := tabletTypeFallbackActivations.Counts()["ks.replica.rdonly"]
+	_, err := tg.Execute(ctx, replica, "query", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, rdonly.ExecCount.Load())
+	assert.EqualValues(t, 1, tabletTypeFallbackActivations.Counts()["ks.replica.rdonly"]-initial)
+
+	// The next tablet type of the list with healthy tablets is used.
+	setServing(rdonly, false)
+	_, err = tg.Execute(ctx, replica, "query", nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.ExecCount.Load())
+
+	// The tablet types which are not in the list do not fall back.
+	_, err = tg.Execute(ctx, &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_BACKUP}, "query", nil, 0, 0, nil)
+	verifyContainsError(t, err, "no healthy tablet available", vtrpcpb.Code_UNAVAILABLE)
+
+	// The requests on the primary do not fall back.
+	tg.SetTabletTypeFallbacks(map[string][]topodatapb.TabletType{
+		"ks": {topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA},
+	})
+	setServing(primary, false)
+	hc.AddTestTablet("cell", "1.1.1.3", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	_, err = tg.Execute(ctx, &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_PRIMARY}, "query", nil, 0, 0, nil)
+	verifyContainsError(t, err, "no healthy tablet available", vtrpcpb.Code_UNAVAILABLE)
+}
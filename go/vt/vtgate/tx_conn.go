@@ -120,7 +120,10 @@ func (txc *TxConn) Commit(ctx context.Context, session *econtext.SafeSession) er
 
 	defer recordCommitTime(session, twopc, time.Now())
 
-	err := txc.runSessions(ctx, session.PreSessions, session.GetLogger(), txc.commitShard)
+	commitShard := func(ctx context.Context, s *vtgatepb.Session_ShardSession, logging *econtext.ExecuteLogger) error {
+		return txc.commitShard(ctx, s, session, logging)
+	}
+	err := txc.runSessions(ctx, session.PreSessions, session.GetLogger(), commitShard)
 	if err != nil {
 		_ = txc.Release(ctx, session)
 		return err
@@ -137,7 +140,7 @@ func (txc *TxConn) Commit(ctx context.Context, session *econtext.SafeSession) er
 		return err
 	}
 
-	err = txc.runSessions(ctx, session.PostSessions, session.GetLogger(), txc.commitShard)
+	err = txc.runSessions(ctx, session.PostSessions, session.GetLogger(), commitShard)
 	if err != nil {
 		// If last commit fails, there will be nothing to rollback.
 		session.RecordWarning(&querypb.QueryWarning{Message: fmt.Sprintf("post-operation transaction had an error: %v", err)})
@@ -173,7 +176,9 @@ func (txc *TxConn) queryService(ctx context.Context, alias *topodatapb.TabletAli
 	return qs, nil
 }
 
-func (txc *TxConn) commitShard(ctx context.Context, s *vtgatepb.Session_ShardSession, logging *econtext.ExecuteLogger) error {
+// commitShard commits the transaction of a shard session, and records the GTID
+// of the transaction in the session if it tracks its GTIDs.
+func (txc *TxConn) commitShard(ctx context.Context, s *vtgatepb.Session_ShardSession, session *econtext.SafeSession, logging *econtext.ExecuteLogger) error {
 	if s.TransactionId == 0 {
 		return nil
 	}
@@ -183,12 +188,13 @@ func (txc *TxConn) commitShard(ctx context.Context, s *vtgatepb.Session_ShardSes
 	if err != nil {
 		return err
 	}
-	reservedID, err := qs.Commit(ctx, s.Target, s.TransactionId)
+	reservedID, sessionStateChanges, err := qs.Commit(ctx, s.Target, s.TransactionId)
 	if err != nil {
 		return err
 	}
 	s.TransactionId = 0
 	s.ReservedId = reservedID
+	session.RecordCommittedGtid(s.Target, sessionStateChanges)
 	logging.Log(nil, s.Target, nil, "commit", false, nil)
 	return nil
 }
@@ -196,7 +202,7 @@ func (txc *TxConn) commitShard(ctx context.Context, s *vtgatepb.Session_ShardSes
 func (txc *TxConn) commitNormal(ctx context.Context, session *econtext.SafeSession) error {
 	// Retain backward compatibility on commit order for the normal session.
 	for i, shardSession := range session.ShardSessions {
-		if err := txc.commitShard(ctx, shardSession, session.GetLogger()); err != nil {
+		if err := txc.commitShard(ctx, shardSession, session, session.GetLogger()); err != nil {
 			if i > 0 {
 				nShards := i
 				elipsis := false
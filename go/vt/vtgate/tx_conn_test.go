@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 
@@ -33,6 +34,7 @@ import (
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/key"
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -330,6 +332,34 @@ func TestTxConnCommitSuccess(t *testing.T) {
 	assert.EqualValues(t, 1, sbc1.CommitCount.Load(), "sbc1.CommitCount")
 }
 
+func TestTxConnCommitSessionTrackGtids(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	sc, sbc0, sbc1, _, _, rss01 := newTestTxConnEnv(t, ctx, "TestTxConn")
+	sc.txConn.txMode = &StaticConfig{TxMode: vtgatepb.TransactionMode_MULTI}
+	sbc0.CommitSessionStateChanges = "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:12"
+	sbc1.CommitSessionStateChanges = "9f0ed4a4-6b1e-11ee-a6d4-0242ac120003:5"
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{InTransaction: true})
+	session.SetSessionTrackGtids(true)
+	sc.ExecuteMultiShard(ctx, nil, rss01, twoQueries, session, false, false, nullResultsObserver{}, false)
+	require.NoError(t,
+		sc.txConn.Commit(ctx, session))
+
+	var vgtid binlogdatapb.VGtid
+	require.NoError(t, protojson.Unmarshal([]byte(session.TakeCommittedGtids()), &vgtid))
+	want := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+		Keyspace: "TestTxConn",
+		Shard:    "0",
+		Gtid:     "MySQL56/e2f7c9a0-6b1d-11ee-8c99-0242ac120002:12",
+	}, {
+		Keyspace: "TestTxConn",
+		Shard:    "1",
+		Gtid:     "MySQL56/9f0ed4a4-6b1e-11ee-a6d4-0242ac120003:5",
+	}}}
+	utils.MustMatch(t, want, &vgtid)
+}
+
 func TestTxConnReservedCommitSuccess(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
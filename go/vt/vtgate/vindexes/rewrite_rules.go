@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// RewriteRule represents one query rewrite rule.
+type RewriteRule struct {
+	Error  error
+	Name   string
+	DryRun bool
+
+	source *vschemapb.RewriteRule
+	// pattern and replacementStmt are set for the rules matching a pattern,
+	// regex for the rules matching a regular expression.
+	pattern         sqlparser.Statement
+	replacementStmt sqlparser.Statement
+	regex           *regexp.Regexp
+}
+
+// MarshalJSON returns a JSON representation of RewriteRule.
+func (rr *RewriteRule) MarshalJSON() ([]byte, error) {
+	if rr.Error != nil {
+		return json.Marshal(struct {
+			Name  string `json:"name"`
+			Error string `json:"error"`
+		}{
+			Name:  rr.Name,
+			Error: rr.Error.Error(),
+		})
+	}
+	return json.Marshal(struct {
+		Name        string `json:"name"`
+		Pattern     string `json:"pattern,omitempty"`
+		Regex       string `json:"regex,omitempty"`
+		Replacement string `json:"replacement"`
+		DryRun      bool   `json:"dry_run,omitempty"`
+	}{
+		Name:        rr.Name,
+		Pattern:     rr.source.Pattern,
+		Regex:       rr.source.Regex,
+		Replacement: rr.source.Replacement,
+		DryRun:      rr.DryRun,
+	})
+}
+
+// BuildRewriteRule compiles the query rewrite rule. It fails if the rule has
+// no name, does not have exactly one of a pattern and a regex, or if its
+// pattern, regex or replacement is invalid. The replacement of a regex may be
+// empty, to strip what the regex matches.
+func BuildRewriteRule(source *vschemapb.RewriteRule, parser *sqlparser.Parser) (*RewriteRule, error) {
+	if source.Name == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule must have a name")
+	}
+	if (source.Pattern == "") == (source.Regex == "") {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s must have either a pattern or a regex", source.Name)
+	}
+	if source.Pattern != "" && source.Replacement == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s must have a replacement", source.Name)
+	}
+
+	rule := &RewriteRule{
+		Name:   source.Name,
+		DryRun: source.DryRun,
+		source: source,
+	}
+	if source.Regex != "" {
+		regex, err := regexp.Compile(source.Regex)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s: invalid regex: %v", source.Name, err)
+		}
+		rule.regex = regex
+		return rule, nil
+	}
+
+	pattern, err := parser.Parse(source.Pattern)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s: invalid pattern: %v", source.Name, err)
+	}
+	replacement, err := parser.Parse(source.Replacement)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s: invalid replacement: %v", source.Name, err)
+	}
+	placeholders := rewritePlaceholders(pattern)
+	for name := range rewritePlaceholders(replacement) {
+		if !placeholders[name] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rewrite rule %s: placeholder :%s of the replacement is not in the pattern", source.Name, name)
+		}
+	}
+	rule.pattern = pattern
+	rule.replacementStmt = replacement
+	return rule, nil
+}
+
+// rewritePlaceholders returns the names of the placeholders of the statement.
+func rewritePlaceholders(stmt sqlparser.Statement) map[string]bool {
+	placeholders := make(map[string]bool)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if arg, ok := node.(*sqlparser.Argument); ok {
+			placeholders[arg.Name] = true
+		}
+		return true, nil
+	}, stmt)
+	return placeholders
+}
+
+// Rewrite rewrites the query, whose statement is stmt, if it matches the rule.
+// It returns the rewritten query, and whether the query matched.
+func (rr *RewriteRule) Rewrite(query string, stmt sqlparser.Statement) (string, bool) {
+	if rr.Error != nil {
+		return query, false
+	}
+	if rr.regex != nil {
+		if !rr.regex.MatchString(query) {
+			return query, false
+		}
+		return rr.regex.ReplaceAllString(query, rr.source.Replacement), true
+	}
+
+	captures := make(map[string]sqlparser.Expr)
+	if !matchRewritePattern(reflect.ValueOf(&rr.pattern).Elem(), reflect.ValueOf(&stmt).Elem(), captures) {
+		return query, false
+	}
+	rewritten := sqlparser.Rewrite(sqlparser.CloneStatement(rr.replacementStmt), nil, func(cursor *sqlparser.Cursor) bool {
+		if arg, ok := cursor.Node().(*sqlparser.Argument); ok {
+			cursor.Replace(sqlparser.CloneExpr(captures[arg.Name]))
+		}
+		return true
+	}).(sqlparser.Statement)
+
+	// Keep the comments of the query, which may hold query hints.
+	if commented, ok := stmt.(sqlparser.Commented); ok {
+		if out, ok := rewritten.(sqlparser.Commented); ok && len(out.GetParsedComments().GetComments()) == 0 {
+			out.SetComments(commented.GetParsedComments().GetComments())
+		}
+	}
+	return sqlparser.String(rewritten), true
+}
+
+var (
+	exprType           = reflect.TypeFor[sqlparser.Expr]()
+	argumentType       = reflect.TypeFor[*sqlparser.Argument]()
+	identifierCIType   = reflect.TypeFor[sqlparser.IdentifierCI]()
+	parsedCommentsType = reflect.TypeFor[*sqlparser.ParsedComments]()
+)
+
+// matchRewritePattern reports whether the node of a query matches the node of
+// a pattern. The placeholders of the pattern match any expression, and the
+// expressions they match are added to captures. A placeholder appearing more
+// than once in the pattern must match the same expression each time.
+func matchRewritePattern(pattern, node reflect.Value, captures map[string]sqlparser.Expr) bool {
+	if pattern.Kind() == reflect.Interface && pattern.Type().Implements(exprType) &&
+		!pattern.IsNil() && pattern.Elem().Type() == argumentType && pattern.CanInterface() {
+		if node.IsNil() || !node.CanInterface() {
+			return false
+		}
+		expr, ok := node.Interface().(sqlparser.Expr)
+		if !ok {
+			return false
+		}
+		name := pattern.Elem().Interface().(*sqlparser.Argument).Name
+		if captured, ok := captures[name]; ok {
+			return sqlparser.Equals.Expr(captured, expr)
+		}
+		captures[name] = expr
+		return true
+	}
+
+	if pattern.Type() != node.Type() {
+		return false
+	}
+	switch pattern.Type() {
+	case parsedCommentsType:
+		return true
+	case identifierCIType:
+		if pattern.CanInterface() && node.CanInterface() {
+			return pattern.Interface().(sqlparser.IdentifierCI).Equal(node.Interface().(sqlparser.IdentifierCI))
+		}
+	}
+
+	switch pattern.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if pattern.IsNil() || node.IsNil() {
+			return pattern.IsNil() == node.IsNil()
+		}
+		if pattern.Kind() == reflect.Interface && pattern.Elem().Type() != node.Elem().Type() {
+			return false
+		}
+		return matchRewritePattern(pattern.Elem(), node.Elem(), captures)
+	case reflect.Struct:
+		for i := range pattern.NumField() {
+			if !matchRewritePattern(pattern.Field(i), node.Field(i), captures) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if pattern.Len() != node.Len() {
+			return false
+		}
+		for i := range pattern.Len() {
+			if !matchRewritePattern(pattern.Index(i), node.Index(i), captures) {
+				return false
+			}
+		}
+		return true
+	case reflect.Bool:
+		return pattern.Bool() == node.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return pattern.Int() == node.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return pattern.Uint() == node.Uint()
+	case reflect.Float32, reflect.Float64:
+		return pattern.Float() == node.Float()
+	case reflect.String:
+		return pattern.String() == node.String()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestBuildRewriteRule(t *testing.T) {
+	tcases := []struct {
+		name string
+		rule *vschemapb.RewriteRule
+		err  string
+	}{{
+		name: "no name",
+		rule: &vschemapb.RewriteRule{Regex: "a", Replacement: "b"},
+		err:  "rewrite rule must have a name",
+	}, {
+		name: "no pattern nor regex",
+		rule: &vschemapb.RewriteRule{Name: "r1", Replacement: "b"},
+		err:  "rewrite rule r1 must have either a pattern or a regex",
+	}, {
+		name: "pattern and regex",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select 1", Regex: "a", Replacement: "b"},
+		err:  "rewrite rule r1 must have either a pattern or a regex",
+	}, {
+		name: "no replacement",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select 1"},
+		err:  "rewrite rule r1 must have a replacement",
+	}, {
+		name: "invalid regex",
+		rule: &vschemapb.RewriteRule{Name: "r1", Regex: "(a", Replacement: "b"},
+		err:  "rewrite rule r1: invalid regex",
+	}, {
+		name: "invalid pattern",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select from", Replacement: "select 1"},
+		err:  "rewrite rule r1: invalid pattern",
+	}, {
+		name: "invalid replacement",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select 1", Replacement: "select from"},
+		err:  "rewrite rule r1: invalid replacement",
+	}, {
+		name: "unknown placeholder",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select * from t where a = :a", Replacement: "select * from t where a = :b"},
+		err:  "rewrite rule r1: placeholder :b of the replacement is not in the pattern",
+	}, {
+		name: "pattern",
+		rule: &vschemapb.RewriteRule{Name: "r1", Pattern: "select * from t where a = :a", Replacement: "select * from t force index (a) where a = :a"},
+	}, {
+		name: "regex",
+		rule: &vschemapb.RewriteRule{Name: "r1", Regex: `\bt_old\b`, Replacement: "t_new", DryRun: true},
+	}, {
+		name: "regex stripping what it matches",
+		rule: &vschemapb.RewriteRule{Name: "r1", Regex: ` order by null$`},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			rule, err := BuildRewriteRule(tcase.rule, sqlparser.NewTestParser())
+			if tcase.err != "" {
+				assert.ErrorContains(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.rule.Name, rule.Name)
+			assert.Equal(t, tcase.rule.DryRun, rule.DryRun)
+		})
+	}
+}
+
+func TestRewriteRule(t *testing.T) {
+	tcases := []struct {
+		name        string
+		rule        *vschemapb.RewriteRule
+		query       string
+		rewritten   string
+		notMatching bool
+	}{{
+		name:      "pattern with placeholders",
+		rule:      &vschemapb.RewriteRule{Pattern: "select * from orders where customer_id = :id and status = :status", Replacement: "select * from orders force index (customer_status) where customer_id = :id and status = :status"},
+		query:     "SELECT * FROM orders WHERE Customer_ID = 42 AND status = 'open'",
+		rewritten: "select * from orders force index (customer_status) where customer_id = 42 and `status` = 'open'",
+	}, {
+		name:      "placeholder matching an expression",
+		rule:      &vschemapb.RewriteRule{Pattern: "select * from orders where customer_id = :id", Replacement: "select * from orders force index (customer) where customer_id = :id"},
+		query:     "select * from orders where customer_id = 40 + 2",
+		rewritten: "select * from orders force index (customer) where customer_id = 40 + 2",
+	}, {
+		name:      "comments are kept",
+		rule:      &vschemapb.RewriteRule{Pattern: "select * from orders where customer_id = :id", Replacement: "select * from orders force index (customer) where customer_id = :id"},
+		query:     "select /*vt+ PLANNER=gen4 */ * from orders where customer_id = 42",
+		rewritten: "select /*vt+ PLANNER=gen4 */ * from orders force index (customer) where customer_id = 42",
+	}, {
+		name:        "literals must be equal",
+		rule:        &vschemapb.RewriteRule{Pattern: "select * from orders where status = 'open'", Replacement: "select * from open_orders"},
+		query:       "select * from orders where status = 'closed'",
+		notMatching: true,
+	}, {
+		name:        "other statement",
+		rule:        &vschemapb.RewriteRule{Pattern: "select * from orders where customer_id = :id", Replacement: "select * from orders force index (customer) where customer_id = :id"},
+		query:       "select * from orders where customer_id = 42 limit 1",
+		notMatching: true,
+	}, {
+		name:      "repeated placeholder",
+		rule:      &vschemapb.RewriteRule{Pattern: "select * from t where a = :x or b = :x", Replacement: "select * from t where :x in (a, b)"},
+		query:     "select * from t where a = 1 or b = 1",
+		rewritten: "select * from t where 1 in (a, b)",
+	}, {
+		name:        "repeated placeholder matching different expressions",
+		rule:        &vschemapb.RewriteRule{Pattern: "select * from t where a = :x or b = :x", Replacement: "select * from t where :x in (a, b)"},
+		query:       "select * from t where a = 1 or b = 2",
+		notMatching: true,
+	}, {
+		name:      "regex",
+		rule:      &vschemapb.RewriteRule{Regex: `\blegacy_users\b`, Replacement: "users"},
+		query:     "select id from legacy_users where legacy_users.id = 1",
+		rewritten: "select id from users where users.id = 1",
+	}, {
+		name:      "regex with submatches",
+		rule:      &vschemapb.RewriteRule{Regex: `(?i)^(select .*) order by null$`, Replacement: "$1"},
+		query:     "select a, count(*) from t group by a ORDER BY NULL",
+		rewritten: "select a, count(*) from t group by a",
+	}, {
+		name:        "regex not matching",
+		rule:        &vschemapb.RewriteRule{Regex: `\blegacy_users\b`, Replacement: "users"},
+		query:       "select id from users",
+		notMatching: true,
+	}}
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			tcase.rule.Name = "r1"
+			rule, err := BuildRewriteRule(tcase.rule, parser)
+			require.NoError(t, err)
+			stmt, err := parser.Parse(tcase.query)
+			require.NoError(t, err)
+
+			rewritten, matched := rule.Rewrite(tcase.query, stmt)
+			if tcase.notMatching {
+				assert.False(t, matched)
+				assert.Equal(t, tcase.query, rewritten)
+				return
+			}
+			assert.True(t, matched)
+			assert.Equal(t, tcase.rewritten, rewritten)
+		})
+	}
+}
+
+func TestBuildVSchemaRewriteRules(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		RewriteRules: &vschemapb.RewriteRules{
+			Rules: []*vschemapb.RewriteRule{{
+				Name:        "rename",
+				Regex:       `\blegacy_users\b`,
+				Replacement: "users",
+			}, {
+				Name:        "rename",
+				Regex:       `\blegacy_orders\b`,
+				Replacement: "orders",
+			}, {
+				Name:    "invalid",
+				Pattern: "select * from t",
+			}},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	require.Len(t, vschema.RewriteRules, 3)
+	assert.NoError(t, vschema.RewriteRules[0].Error)
+	assert.EqualError(t, vschema.RewriteRules[1].Error, "duplicate rewrite rule rename")
+	assert.EqualError(t, vschema.RewriteRules[2].Error, "rewrite rule invalid must have a replacement")
+
+	data, err := json.Marshal(vschema.RewriteRules)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "rename", "regex": "\\blegacy_users\\b", "replacement": "users"},
+		{"name": "rename", "error": "duplicate rewrite rule rename"},
+		{"name": "invalid", "error": "rewrite rule invalid must have a replacement"}
+	]`, string(data))
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"time"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Rollup describes the aggregate query materialized by a rollup table.
+type Rollup struct {
+	// Table is the keyspace-qualified table the query aggregates.
+	Table sqlparser.TableName
+	// MaxLag bounds the lag of the workflow maintaining the rollup for the
+	// queries to read it. Zero means the lag is not bounded.
+	MaxLag time.Duration
+
+	query string
+	// groupBy maps the lowered names of the grouping columns of the query to
+	// the columns of the rollup they are materialized in.
+	groupBy map[string]sqlparser.IdentifierCI
+	// aggregates are the aggregates of the query, with their columns in
+	// canonical form, and the columns of the rollup they are materialized in.
+	aggregates []rollupAggregate
+}
+
+type rollupAggregate struct {
+	aggr   sqlparser.AggrFunc
+	column sqlparser.IdentifierCI
+}
+
+// MarshalJSON returns a JSON representation of Rollup.
+func (r *Rollup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Query  string `json:"query"`
+		MaxLag string `json:"max_lag,omitempty"`
+	}{
+		Query:  r.query,
+		MaxLag: r.maxLagString(),
+	})
+}
+
+func (r *Rollup) maxLagString() string {
+	if r.MaxLag == 0 {
+		return ""
+	}
+	return r.MaxLag.String()
+}
+
+// BuildRollup parses the aggregate query of a rollup. It fails if the query
+// is not a grouped aggregation of a single keyspace-qualified table, whose
+// select expressions are its grouping columns and aliased aggregates of the
+// columns of the table.
+func BuildRollup(source *vschemapb.Rollup, parser *sqlparser.Parser) (*Rollup, error) {
+	stmt, err := parser.Parse(source.Query)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid rollup query %q: %v", source.Query, err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.With != nil || sel.Distinct || sel.Where != nil || sel.Having != nil || sel.OrderBy != nil ||
+		sel.Limit != nil || sel.Into != nil || sel.Lock != sqlparser.NoLock || sel.Windows != nil ||
+		sel.GroupBy == nil || sel.GroupBy.WithRollup {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must be a SELECT with a GROUP BY and no other clause: %s", source.Query)
+	}
+	if len(sel.From) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select from a single table: %s", source.Query)
+	}
+	tableExpr, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select from a single table: %s", source.Query)
+	}
+	table, ok := tableExpr.Expr.(sqlparser.TableName)
+	if !ok || table.Qualifier.IsEmpty() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select from a table qualified by its keyspace: %s", source.Query)
+	}
+
+	r := &Rollup{
+		Table:   table,
+		MaxLag:  time.Duration(source.MaxLagSeconds) * time.Second,
+		query:   sqlparser.String(sel),
+		groupBy: make(map[string]sqlparser.IdentifierCI),
+	}
+	grouping := make(map[string]bool)
+	for _, expr := range sel.GroupBy.Exprs {
+		col, ok := expr.(*sqlparser.ColName)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must group by columns: %s", sqlparser.String(expr))
+		}
+		grouping[col.Name.Lowered()] = true
+	}
+	for _, expr := range sel.GetColumns() {
+		aliased, ok := expr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select its grouping columns and aggregates: %s", sqlparser.String(expr))
+		}
+		column := aliased.ColumnName()
+		switch e := aliased.Expr.(type) {
+		case *sqlparser.ColName:
+			if !grouping[e.Name.Lowered()] {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query selects column %s which it does not group by", sqlparser.String(e))
+			}
+			r.groupBy[e.Name.Lowered()] = sqlparser.NewIdentifierCI(column)
+		case sqlparser.AggrFunc:
+			if !isRollupAggregate(e) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported aggregate in rollup query: %s", sqlparser.String(e))
+			}
+			if aliased.As.IsEmpty() {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must alias aggregate %s with the name of its column", sqlparser.String(e))
+			}
+			r.aggregates = append(r.aggregates, rollupAggregate{
+				aggr:   canonicalVindexExpression(e).(sqlparser.AggrFunc),
+				column: aliased.As,
+			})
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select its grouping columns and aggregates: %s", sqlparser.String(expr))
+		}
+	}
+	for _, expr := range sel.GroupBy.Exprs {
+		if col := expr.(*sqlparser.ColName); r.groupBy[col.Name.Lowered()].IsEmpty() {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "rollup query must select its grouping column %s", sqlparser.String(col))
+		}
+	}
+	return r, nil
+}
+
+// isRollupAggregate reports whether the aggregate can be maintained by a
+// Materialize workflow, which is the case of count(*) and of the sum of a
+// column.
+func isRollupAggregate(aggr sqlparser.AggrFunc) bool {
+	switch aggr := aggr.(type) {
+	case *sqlparser.CountStar:
+		return true
+	case *sqlparser.Sum:
+		_, ok := aggr.Arg.(*sqlparser.ColName)
+		return ok && !aggr.Distinct
+	default:
+		return false
+	}
+}
+
+// GroupColumn returns the column of the rollup the grouping column col is
+// materialized in.
+func (r *Rollup) GroupColumn(col *sqlparser.ColName) (sqlparser.IdentifierCI, bool) {
+	column, ok := r.groupBy[col.Name.Lowered()]
+	return column, ok
+}
+
+// AggregateColumn returns the column of the rollup the aggregate is
+// materialized in. The columns the aggregates refer to are compared
+// regardless of their qualifier and case.
+func (r *Rollup) AggregateColumn(aggr sqlparser.AggrFunc) (sqlparser.IdentifierCI, bool) {
+	canonical := canonicalVindexExpression(aggr)
+	for _, ra := range r.aggregates {
+		if sqlparser.Equals.Expr(ra.aggr, canonical) {
+			return ra.column, true
+		}
+	}
+	return sqlparser.IdentifierCI{}, false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestBuildRollup(t *testing.T) {
+	tcases := []struct {
+		query string
+		err   string
+	}{{
+		query: "select region, count(*) as orders, sum(total) as total, sum(discount) as discount from commerce.orders group by region",
+	}, {
+		query: "select from",
+		err:   "invalid rollup query \"select from\"",
+	}, {
+		query: "select region, count(*) as orders from commerce.orders",
+		err:   "rollup query must be a SELECT with a GROUP BY and no other clause",
+	}, {
+		query: "select region, count(*) as orders from commerce.orders where total > 0 group by region",
+		err:   "rollup query must be a SELECT with a GROUP BY and no other clause",
+	}, {
+		query: "select region, count(*) as orders from commerce.orders, commerce.customer group by region",
+		err:   "rollup query must select from a single table",
+	}, {
+		query: "select region, count(*) as orders from orders group by region",
+		err:   "rollup query must select from a table qualified by its keyspace",
+	}, {
+		query: "select region, count(*) as orders from commerce.orders group by lower(region)",
+		err:   "rollup query must group by columns: lower(region)",
+	}, {
+		query: "select region, customer_id, count(*) as orders from commerce.orders group by region",
+		err:   "rollup query selects column customer_id which it does not group by",
+	}, {
+		query: "select region, count(*) from commerce.orders group by region",
+		err:   "rollup query must alias aggregate count(*) with the name of its column",
+	}, {
+		query: "select region, avg(total) as total from commerce.orders group by region",
+		err:   "unsupported aggregate in rollup query: avg(total)",
+	}, {
+		query: "select region, max(total) as highest from commerce.orders group by region",
+		err:   "unsupported aggregate in rollup query: max(total)",
+	}, {
+		query: "select region, count(total) as totals from commerce.orders group by region",
+		err:   "unsupported aggregate in rollup query: count(total)",
+	}, {
+		query: "select region, sum(distinct total) as totals from commerce.orders group by region",
+		err:   "unsupported aggregate in rollup query: sum(distinct total)",
+	}, {
+		query: "select region, sum(total * 2) as total from commerce.orders group by region",
+		err:   "unsupported aggregate in rollup query: sum(total * 2)",
+	}, {
+		query: "select count(*) as orders from commerce.orders group by region",
+		err:   "rollup query must select its grouping column region",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			rollup, err := BuildRollup(&vschemapb.Rollup{Query: tcase.query, MaxLagSeconds: 30}, sqlparser.NewTestParser())
+			if tcase.err != "" {
+				require.ErrorContains(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "commerce.orders", sqlparser.String(rollup.Table))
+			assert.Equal(t, 30*time.Second, rollup.MaxLag)
+		})
+	}
+}
+
+func TestRollupColumns(t *testing.T) {
+	rollup, err := BuildRollup(&vschemapb.Rollup{
+		Query: "select Region as r, count(*) as orders, sum(Total) as total from commerce.orders group by region",
+	}, sqlparser.NewTestParser())
+	require.NoError(t, err)
+
+	column, ok := rollup.GroupColumn(sqlparser.NewColNameWithQualifier("REGION", sqlparser.NewTableName("o")))
+	require.True(t, ok)
+	assert.Equal(t, "r", column.String())
+	_, ok = rollup.GroupColumn(sqlparser.NewColName("total"))
+	assert.False(t, ok)
+
+	for expr, want := range map[string]string{
+		"count(*)":        "orders",
+		"sum(o.total)":    "total",
+		"SUM(TOTAL)":      "total",
+		"sum(region)":     "",
+		"max(total)":      "",
+		"count(total)":    "",
+		"sum(distinct t)": "",
+	} {
+		parsed, err := sqlparser.NewTestParser().ParseExpr(expr)
+		require.NoError(t, err)
+		column, ok := rollup.AggregateColumn(parsed.(sqlparser.AggrFunc))
+		assert.Equal(t, want != "", ok, expr)
+		assert.Equal(t, want, column.String(), expr)
+	}
+
+	out, err := json.Marshal(rollup)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"query":"select Region as r, count(*) as orders, sum(Total) as total from commerce.orders group by region"}`, string(out))
+}
+
+func TestBuildVSchemaRollup(t *testing.T) {
+	rollup := func(query string) *vschemapb.Table {
+		return &vschemapb.Table{Rollup: &vschemapb.Rollup{Query: query, MaxLagSeconds: 60}}
+	}
+	tcases := []struct {
+		name   string
+		tables map[string]*vschemapb.Table
+		err    string
+	}{{
+		name: "rollups",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region":   rollup("select region, count(*) as orders from commerce.orders group by region"),
+			"orders_by_customer": rollup("select customer_id, sum(total) as total from commerce.orders group by customer_id"),
+			"by_customer_region": rollup("select customer_id, region, sum(total) as total from commerce.orders group by customer_id, region"),
+		},
+	}, {
+		name: "unknown table",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region": rollup("select region, count(*) as orders from commerce.missing group by region"),
+		},
+		err: "rollup table orders_by_region aggregates table commerce.missing which is not present in the VSchema",
+	}, {
+		name: "unknown keyspace",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region": rollup("select region, count(*) as orders from unknown.orders group by region"),
+		},
+		err: "rollup table orders_by_region: VT05003: unknown database 'unknown' in vschema",
+	}, {
+		name: "same keyspace",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region": rollup("select region, count(*) as orders from rollups.orders_by_customer group by region"),
+		},
+		err: "rollup table orders_by_region must be in another keyspace than its table rollups.orders_by_customer",
+	}, {
+		name: "invalid query",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region": rollup("select region from commerce.orders"),
+		},
+		err: "rollup table orders_by_region: rollup query must be a SELECT with a GROUP BY and no other clause: select region from commerce.orders",
+	}, {
+		name: "sequence",
+		tables: map[string]*vschemapb.Table{
+			"orders_by_region": {
+				Type:   TypeSequence,
+				Rollup: &vschemapb.Rollup{Query: "select region, count(*) as orders from commerce.orders group by region"},
+			},
+		},
+		err: "table of type sequence cannot be a rollup: orders_by_region",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			input := vschemapb.SrvVSchema{
+				Keyspaces: map[string]*vschemapb.Keyspace{
+					"commerce": {
+						Tables: map[string]*vschemapb.Table{
+							"orders": {},
+						},
+					},
+					"rollups": {
+						Tables: tcase.tables,
+					},
+				},
+			}
+			got := BuildVSchema(&input, sqlparser.NewTestParser())
+			err := got.Keyspaces["rollups"].Error
+			if tcase.err != "" {
+				require.EqualError(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+
+			orders := got.Keyspaces["commerce"].Tables["orders"]
+			require.Len(t, orders.Rollups, 3)
+			// The rollups are sorted by number of grouping columns, then by name.
+			assert.Equal(t, "rollups.orders_by_customer", orders.Rollups[0].String())
+			assert.Equal(t, "rollups.orders_by_region", orders.Rollups[1].String())
+			assert.Equal(t, "rollups.by_customer_region", orders.Rollups[2].String())
+			assert.Equal(t, time.Minute, orders.Rollups[1].Rollup.MaxLag)
+		})
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -37,6 +39,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/plannerflags"
 )
 
 // TabletTypeSuffix maps the tablet type to its suffix string.
@@ -74,6 +77,13 @@ type VSchema struct {
 	Keyspaces            map[string]*KeyspaceSchema `json:"keyspaces"`
 	ShardRoutingRules    map[string]string          `json:"shard_routing_rules"`
 	KeyspaceRoutingRules map[string]string          `json:"keyspace_routing_rules"`
+	// TenantRoutingRules maps keyspace.tenant_id to the keyspace the queries
+	// of the tenant are routed to.
+	TenantRoutingRules map[string]string `json:"tenant_routing_rules"`
+	// PlanPins maps a query digest to the JSON description of its pinned plan.
+	PlanPins map[string]string `json:"plan_pins"`
+	// RewriteRules are the query rewrite rules, applied in order.
+	RewriteRules []*RewriteRule `json:"rewrite_rules"`
 	// created is the time when the VSchema object was created. Used to detect if a cached
 	// copy of the vschema is stale.
 	created time.Time
@@ -147,6 +157,12 @@ type BaseTable struct {
 	// Source is a keyspace-qualified table name that points to the source of a
 	// reference table. Only applicable for tables with Type set to "reference".
 	Source *Source `json:"source,omitempty"`
+	// Rollup describes the aggregate query the table materializes, if the
+	// table is a rollup.
+	Rollup *Rollup `json:"rollup,omitempty"`
+	// Rollups are the rollup tables of other keyspaces which materialize
+	// aggregate queries on this table.
+	Rollups []*BaseTable `json:"-"`
 
 	ChildForeignKeys  []ChildFKInfo  `json:"child_foreign_keys,omitempty"`
 	ParentForeignKeys []ParentFKInfo `json:"parent_foreign_keys,omitempty"`
@@ -180,6 +196,10 @@ type ColumnVindex struct {
 	cost     int
 	partial  bool
 	backfill bool
+
+	// Expression is set for a functional vindex, which applies to this
+	// expression of its column instead of the column itself.
+	Expression sqlparser.Expr `json:"-"`
 }
 
 // TableInfo contains column and foreign key info for a table.
@@ -212,6 +232,93 @@ func (c *ColumnVindex) IsBackfilling() bool {
 	return c.backfill
 }
 
+// MarshalJSON returns a JSON representation of ColumnVindex.
+func (c *ColumnVindex) MarshalJSON() ([]byte, error) {
+	type columnVindex ColumnVindex
+	cj := struct {
+		*columnVindex
+		Expression string `json:"expression,omitempty"`
+	}{
+		columnVindex: (*columnVindex)(c),
+	}
+	if c.Expression != nil {
+		cj.Expression = sqlparser.String(c.Expression)
+	}
+	return json.Marshal(cj)
+}
+
+// MatchesExpression reports whether expr is the expression of the functional
+// vindex, whatever the qualifier of its column and the case of its identifiers.
+func (c *ColumnVindex) MatchesExpression(expr sqlparser.Expr) bool {
+	return c.Expression != nil && sqlparser.Equals.Expr(c.Expression, canonicalVindexExpression(expr))
+}
+
+// ExpressionOf returns the expression of the functional vindex, with expr in
+// place of its column.
+func (c *ColumnVindex) ExpressionOf(expr sqlparser.Expr) sqlparser.Expr {
+	return sqlparser.Rewrite(sqlparser.CloneExpr(c.Expression), nil, func(cursor *sqlparser.Cursor) bool {
+		if _, ok := cursor.Node().(*sqlparser.ColName); ok {
+			cursor.Replace(sqlparser.CloneExpr(expr))
+		}
+		return true
+	}).(sqlparser.Expr)
+}
+
+// buildVindexExpression parses the expression of a functional vindex. The
+// vindex must have a single column, which is the only one the expression
+// references. The expression can't hold literals either, as the literals of
+// the queries are normalized into bind variables, which would not match them.
+func buildVindexExpression(expression string, vindex Vindex, columns []sqlparser.IdentifierCI, parser *sqlparser.Parser) (sqlparser.Expr, error) {
+	if _, ok := vindex.(SingleColumn); !ok || len(columns) != 1 {
+		return nil, fmt.Errorf("a functional vindex must have a single column")
+	}
+	expr, err := parser.ParseExpr(expression)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := expr.(*sqlparser.ColName); ok {
+		return nil, fmt.Errorf("the expression must be a function of column %s", columns[0].String())
+	}
+
+	found := false
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() || !node.Name.Equal(columns[0]) {
+				return false, fmt.Errorf("the expression can only reference column %s", columns[0].String())
+			}
+			found = true
+		case *sqlparser.Literal, *sqlparser.NullVal, sqlparser.BoolVal:
+			return false, fmt.Errorf("the expression can't hold literals")
+		case *sqlparser.Argument, sqlparser.ListArg, *sqlparser.Variable, *sqlparser.Subquery, sqlparser.AggrFunc:
+			return false, fmt.Errorf("the expression can only be a function of column %s", columns[0].String())
+		}
+		return true, nil
+	}, expr)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("the expression must reference column %s", columns[0].String())
+	}
+	return canonicalVindexExpression(expr), nil
+}
+
+// canonicalVindexExpression returns a copy of the expression with unqualified
+// column names and lowered identifiers, to compare it with the expression of a
+// functional vindex.
+func canonicalVindexExpression(expr sqlparser.Expr) sqlparser.Expr {
+	return sqlparser.Rewrite(sqlparser.CloneExpr(expr), nil, func(cursor *sqlparser.Cursor) bool {
+		switch node := cursor.Node().(type) {
+		case *sqlparser.ColName:
+			cursor.Replace(sqlparser.NewColName(node.Name.Lowered()))
+		case *sqlparser.FuncExpr:
+			node.Name = sqlparser.NewIdentifierCI(node.Name.Lowered())
+		}
+		return true
+	}).(sqlparser.Expr)
+}
+
 // Column describes a column.
 type Column struct {
 	Name          sqlparser.IdentifierCI `json:"name"`
@@ -273,6 +380,14 @@ type KeyspaceSchema struct {
 	Views           map[string]*View
 	Error           error
 	MultiTenantSpec *vschemapb.MultiTenantSpec
+	// PlannerFlags toggle planner behaviors for the sessions targeting the keyspace.
+	PlannerFlags map[string]string
+	// DefaultTabletType is the tablet type of the sessions targeting the
+	// keyspace without a tablet type, UNKNOWN for the default of vtgate.
+	DefaultTabletType topodatapb.TabletType
+	// TabletTypeFallbacks are the tablet types the reads of the keyspace fall
+	// back to, in order, when their tablet type has no healthy tablet.
+	TabletTypeFallbacks []topodatapb.TabletType
 
 	// These are the UDFs that exist in the schema and are aggregations
 	AggregateUDFs []string
@@ -286,6 +401,10 @@ type ksJSON struct {
 	Views           map[string]string          `json:"views,omitempty"`
 	Error           string                     `json:"error,omitempty"`
 	MultiTenantSpec *vschemapb.MultiTenantSpec `json:"multi_tenant_spec,omitempty"`
+	PlannerFlags    map[string]string          `json:"planner_flags,omitempty"`
+
+	DefaultTabletType   string   `json:"default_tablet_type,omitempty"`
+	TabletTypeFallbacks []string `json:"tablet_type_fallbacks,omitempty"`
 }
 
 // findTable looks for the table with the requested tablename in the keyspace.
@@ -319,6 +438,13 @@ func (ks *KeyspaceSchema) MarshalJSON() ([]byte, error) {
 		ForeignKeyMode:  ks.ForeignKeyMode.String(),
 		Vindexes:        ks.Vindexes,
 		MultiTenantSpec: ks.MultiTenantSpec,
+		PlannerFlags:    ks.PlannerFlags,
+	}
+	if ks.DefaultTabletType != topodatapb.TabletType_UNKNOWN {
+		ksJ.DefaultTabletType = ks.DefaultTabletType.String()
+	}
+	for _, tabletType := range ks.TabletTypeFallbacks {
+		ksJ.TabletTypeFallbacks = append(ksJ.TabletTypeFallbacks, tabletType.String())
 	}
 	if ks.Error != nil {
 		ksJ.Error = ks.Error.Error()
@@ -364,9 +490,13 @@ func BuildVSchema(source *vschemapb.SrvVSchema, parser *sqlparser.Parser) (vsche
 	// resolve sources which reference global tables.
 	buildGlobalTables(source, vschema)
 	buildReferences(source, vschema)
+	buildRollups(source, vschema)
 	buildRoutingRule(source, vschema, parser)
 	buildShardRoutingRule(source, vschema)
 	buildKeyspaceRoutingRule(source, vschema)
+	buildTenantRoutingRule(source, vschema)
+	buildPlanPins(source, vschema)
+	buildRewriteRules(source, vschema, parser)
 	buildMirrorRule(source, vschema, parser)
 	// Resolve auto-increments after routing rules are built since sequence tables also obey routing rules.
 	resolveAutoIncrement(source, vschema, parser)
@@ -412,10 +542,46 @@ func buildKeyspaces(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sqlp
 			Tables:          make(map[string]*BaseTable),
 			Vindexes:        make(map[string]Vindex),
 			MultiTenantSpec: ks.MultiTenantSpec,
+			PlannerFlags:    ks.PlannerFlags,
+
+			DefaultTabletType:   ks.DefaultTabletType,
+			TabletTypeFallbacks: ks.TabletTypeFallbacks,
 		}
 		vschema.Keyspaces[ksname] = ksvschema
 		ksvschema.Error = buildTables(ks, vschema, ksvschema, parser)
+		if err := plannerflags.Validate(ks.PlannerFlags); err != nil && ksvschema.Error == nil {
+			ksvschema.Error = err
+		}
+		if err := validateTabletTypes(ks); err != nil && ksvschema.Error == nil {
+			ksvschema.Error = err
+		}
+	}
+}
+
+// validateTabletTypes checks that the default tablet type and the tablet
+// type fallbacks of the keyspace are tablet types which serve queries, and
+// that no tablet type is listed twice in the fallbacks.
+func validateTabletTypes(ks *vschemapb.Keyspace) error {
+	if tabletType := ks.DefaultTabletType; tabletType != topodatapb.TabletType_UNKNOWN && !isServingTabletType(tabletType) {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid default tablet type %s: must be PRIMARY, REPLICA or RDONLY", tabletType)
+	}
+	for i, tabletType := range ks.TabletTypeFallbacks {
+		if !isServingTabletType(tabletType) {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid tablet type fallback %s: must be PRIMARY, REPLICA or RDONLY", tabletType)
+		}
+		if slices.Contains(ks.TabletTypeFallbacks[:i], tabletType) {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tablet type %s is listed twice in the tablet type fallbacks", tabletType)
+		}
+	}
+	return nil
+}
+
+func isServingTabletType(tabletType topodatapb.TabletType) bool {
+	switch tabletType {
+	case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+		return true
 	}
+	return false
 }
 
 // replaceUnspecifiedForeignKeyMode replaces the default value of the foreign key mode enum with the default we want to keep.
@@ -665,6 +831,60 @@ func buildKeyspaceReferences(vschema *VSchema, ksvschema *KeyspaceSchema) error
 	return nil
 }
 
+func buildRollups(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	// The keyspaces and tables are sorted so that the rollups of a table
+	// are always in the same order, and are planned the same way.
+	for _, ksname := range slices.Sorted(maps.Keys(source.Keyspaces)) {
+		ksvschema := vschema.Keyspaces[ksname]
+		if err := buildKeyspaceRollups(vschema, ksvschema); err != nil && ksvschema.Error == nil {
+			ksvschema.Error = err
+		}
+	}
+	// The rollups with the fewest grouping columns, which have the fewest
+	// rows, come first so that they are preferred by the planner.
+	for _, ksvschema := range vschema.Keyspaces {
+		for _, t := range ksvschema.Tables {
+			slices.SortStableFunc(t.Rollups, func(a, b *BaseTable) int {
+				return len(a.Rollup.groupBy) - len(b.Rollup.groupBy)
+			})
+		}
+	}
+}
+
+// buildKeyspaceRollups adds the rollup tables of the keyspace to the rollups
+// of the tables their queries aggregate.
+func buildKeyspaceRollups(vschema *VSchema, ksvschema *KeyspaceSchema) error {
+	for _, tname := range slices.Sorted(maps.Keys(ksvschema.Tables)) {
+		t := ksvschema.Tables[tname]
+		if t.Rollup == nil {
+			continue
+		}
+		table := t.Rollup.Table
+		base, err := vschema.findTable(table.Qualifier.String(), table.Name.String(), false /* constructTableIfNotFound */)
+		if err != nil {
+			return vterrors.Wrapf(err, "rollup table %s", tname)
+		}
+		if base == nil {
+			return vterrors.Errorf(
+				vtrpcpb.Code_NOT_FOUND,
+				"rollup table %s aggregates table %s which is not present in the VSchema",
+				tname,
+				sqlparser.String(table),
+			)
+		}
+		if base.Rollup != nil {
+			return vterrors.Errorf(
+				vtrpcpb.Code_UNIMPLEMENTED,
+				"rollup table %s may not aggregate the rollup table %s",
+				tname,
+				base,
+			)
+		}
+		base.Rollups = append(base.Rollups, t)
+	}
+	return nil
+}
+
 func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSchema, parser *sqlparser.Parser) error {
 	keyspace := ksvschema.Keyspace
 	for vname, vindexInfo := range ks.Vindexes {
@@ -735,6 +955,29 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 			}
 			t.Pinned = decoded
 		}
+		if table.Rollup != nil {
+			if t.Type != "" {
+				return vterrors.Errorf(
+					vtrpcpb.Code_INVALID_ARGUMENT,
+					"table of type %s cannot be a rollup: %s",
+					t.Type,
+					tname,
+				)
+			}
+			rollup, err := BuildRollup(table.Rollup, parser)
+			if err != nil {
+				return vterrors.Wrapf(err, "rollup table %s", tname)
+			}
+			if rollup.Table.Qualifier.String() == keyspace.Name {
+				return vterrors.Errorf(
+					vtrpcpb.Code_INVALID_ARGUMENT,
+					"rollup table %s must be in another keyspace than its table %s",
+					tname,
+					sqlparser.String(rollup.Table),
+				)
+			}
+			t.Rollup = rollup
+		}
 
 		// If keyspace is sharded, then any table that's not a reference or pinned must have vindexes.
 		if keyspace.Sharded && t.Type != TypeReference && table.Pinned == "" && len(table.ColumnVindexes) == 0 {
@@ -824,19 +1067,35 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 					columns = append(columns, sqlparser.NewIdentifierCI(indCol))
 				}
 			}
+			var expression sqlparser.Expr
+			if ind.Expression != "" {
+				var err error
+				expression, err = buildVindexExpression(ind.Expression, vindex, columns, parser)
+				if err != nil {
+					return vterrors.Errorf(
+						vtrpcpb.Code_INVALID_ARGUMENT,
+						"invalid expression %s for vindex (%s) and table (%s): %v",
+						ind.Expression,
+						ind.Name,
+						tname,
+						err,
+					)
+				}
+			}
 			backfill := false
 			if lkpBackfill, ok := vindex.(LookupBackfill); ok {
 				backfill = lkpBackfill.IsBackfilling()
 			}
 			columnVindex := &ColumnVindex{
-				Columns:  columns,
-				Type:     vindexInfo.Type,
-				Name:     ind.Name,
-				Owned:    owned,
-				Vindex:   vindex,
-				isUnique: vindex.IsUnique(),
-				cost:     vindex.Cost(),
-				backfill: backfill,
+				Columns:    columns,
+				Type:       vindexInfo.Type,
+				Name:       ind.Name,
+				Owned:      owned,
+				Vindex:     vindex,
+				Expression: expression,
+				isUnique:   vindex.IsUnique(),
+				cost:       vindex.Cost(),
+				backfill:   backfill,
 			}
 			if i == 0 {
 				// Perform Primary vindex check.
@@ -1105,6 +1364,47 @@ func buildKeyspaceRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
 	vschema.KeyspaceRoutingRules = rulesMap
 }
 
+func buildTenantRoutingRule(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	sourceRules := source.GetTenantRoutingRules().GetRules()
+	if len(sourceRules) == 0 {
+		return
+	}
+	vschema.TenantRoutingRules = make(map[string]string, len(sourceRules))
+	for _, rule := range sourceRules {
+		vschema.TenantRoutingRules[getTenantRoutingRulesKey(rule.Keyspace, rule.TenantId)] = rule.ToKeyspace
+	}
+}
+
+func buildPlanPins(source *vschemapb.SrvVSchema, vschema *VSchema) {
+	pins := source.GetPlanPins().GetPins()
+	if len(pins) == 0 {
+		return
+	}
+	vschema.PlanPins = make(map[string]string, len(pins))
+	for _, pin := range pins {
+		vschema.PlanPins[pin.Digest] = pin.Plan
+	}
+}
+
+func buildRewriteRules(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sqlparser.Parser) {
+	names := make(map[string]bool)
+	for _, rule := range source.GetRewriteRules().GetRules() {
+		if names[rule.Name] {
+			vschema.RewriteRules = append(vschema.RewriteRules, &RewriteRule{
+				Name:  rule.Name,
+				Error: vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "duplicate rewrite rule %s", rule.Name),
+			})
+			continue
+		}
+		names[rule.Name] = true
+		rr, err := BuildRewriteRule(rule, parser)
+		if err != nil {
+			rr = &RewriteRule{Name: rule.Name, Error: err}
+		}
+		vschema.RewriteRules = append(vschema.RewriteRules, rr)
+	}
+}
+
 func buildMirrorRule(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sqlparser.Parser) {
 	if source.MirrorRules == nil {
 		return
@@ -1582,6 +1882,29 @@ func (vschema *VSchema) FindRoutedShard(keyspace, shard string) (string, error)
 	return keyspace, nil
 }
 
+func getTenantRoutingRulesKey(keyspace, tenantID string) string {
+	return keyspace + "." + tenantID
+}
+
+// FindTenantKeyspace looks up the tenant routing rules and returns the keyspace the queries
+// of the given tenant targeting the given keyspace are routed to.
+func (vschema *VSchema) FindTenantKeyspace(keyspace, tenantID string) string {
+	if len(vschema.TenantRoutingRules) == 0 || tenantID == "" {
+		return keyspace
+	}
+	if ks, ok := vschema.TenantRoutingRules[getTenantRoutingRulesKey(keyspace, tenantID)]; ok {
+		return ks
+	}
+	return keyspace
+}
+
+// FindPlanPin returns the JSON description of the plan pinned for the query
+// digest, if any.
+func (vschema *VSchema) FindPlanPin(digest string) (string, bool) {
+	plan, ok := vschema.PlanPins[digest]
+	return plan, ok
+}
+
 // GetCreated returns the time when the VSchema was created.
 func (vschema *VSchema) GetCreated() time.Time {
 	return vschema.created
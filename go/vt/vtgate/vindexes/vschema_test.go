@@ -300,6 +300,44 @@ func TestMultiTenantAttribute(t *testing.T) {
 	}
 }
 
+func TestPlannerFlags(t *testing.T) {
+	ksSchema, err := BuildKeyspace(&vschemapb.Keyspace{
+		PlannerFlags: map[string]string{"hash_join": "off"},
+	}, sqlparser.NewTestParser())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"hash_join": "off"}, ksSchema.PlannerFlags)
+
+	_, err = BuildKeyspace(&vschemapb.Keyspace{
+		PlannerFlags: map[string]string{"hash_join": "maybe"},
+	}, sqlparser.NewTestParser())
+	require.EqualError(t, err, "invalid value 'maybe' for planner flag 'hash_join', expected one of: on, off")
+}
+
+func TestKeyspaceTabletTypes(t *testing.T) {
+	ksSchema, err := BuildKeyspace(&vschemapb.Keyspace{
+		DefaultTabletType:   topodatapb.TabletType_REPLICA,
+		TabletTypeFallbacks: []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_PRIMARY},
+	}, sqlparser.NewTestParser())
+	require.NoError(t, err)
+	require.Equal(t, topodatapb.TabletType_REPLICA, ksSchema.DefaultTabletType)
+	require.Equal(t, []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_PRIMARY}, ksSchema.TabletTypeFallbacks)
+
+	_, err = BuildKeyspace(&vschemapb.Keyspace{
+		DefaultTabletType: topodatapb.TabletType_BACKUP,
+	}, sqlparser.NewTestParser())
+	require.EqualError(t, err, "invalid default tablet type BACKUP: must be PRIMARY, REPLICA or RDONLY")
+
+	_, err = BuildKeyspace(&vschemapb.Keyspace{
+		TabletTypeFallbacks: []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_DRAINED},
+	}, sqlparser.NewTestParser())
+	require.EqualError(t, err, "invalid tablet type fallback DRAINED: must be PRIMARY, REPLICA or RDONLY")
+
+	_, err = BuildKeyspace(&vschemapb.Keyspace{
+		TabletTypeFallbacks: []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY, topodatapb.TabletType_REPLICA},
+	}, sqlparser.NewTestParser())
+	require.EqualError(t, err, "tablet type REPLICA is listed twice in the tablet type fallbacks")
+}
+
 func TestForeignKeyMode(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1913,6 +1951,94 @@ func TestBuildVSchemaNotUniqueFail(t *testing.T) {
 	}
 }
 
+func TestBuildVSchemaFunctionalVindex(t *testing.T) {
+	tcases := []struct {
+		expression string
+		vindex     string
+		err        string
+	}{{
+		expression: "LOWER(t1.C1)",
+		err:        "invalid expression LOWER(t1.C1) for vindex (stfu) and table (t1): the expression can only reference column c1",
+	}, {
+		expression: "lower(C1)",
+	}, {
+		expression: "lower(trim(c1))",
+	}, {
+		expression: "c1",
+		err:        "invalid expression c1 for vindex (stfu) and table (t1): the expression must be a function of column c1",
+	}, {
+		expression: "concat(c1, c2)",
+		err:        "invalid expression concat(c1, c2) for vindex (stfu) and table (t1): the expression can only reference column c1",
+	}, {
+		expression: "substr(c1, 1, 3)",
+		err:        "invalid expression substr(c1, 1, 3) for vindex (stfu) and table (t1): the expression can't hold literals",
+	}, {
+		expression: "now()",
+		err:        "invalid expression now() for vindex (stfu) and table (t1): the expression must reference column c1",
+	}, {
+		expression: "lower(c1)",
+		vindex:     "mcfu",
+		err:        "invalid expression lower(c1) for vindex (mcfu) and table (t1): a functional vindex must have a single column",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.expression, func(t *testing.T) {
+			vindex := tcase.vindex
+			if vindex == "" {
+				vindex = "stfu"
+			}
+			input := vschemapb.SrvVSchema{
+				Keyspaces: map[string]*vschemapb.Keyspace{
+					"sharded": {
+						Sharded: true,
+						Vindexes: map[string]*vschemapb.Vindex{
+							vindex: {
+								Type: vindex,
+							},
+						},
+						Tables: map[string]*vschemapb.Table{
+							"t1": {
+								ColumnVindexes: []*vschemapb.ColumnVindex{{
+									Column:     "c1",
+									Name:       vindex,
+									Expression: tcase.expression,
+								}},
+							},
+						},
+					},
+				},
+			}
+			got := BuildVSchema(&input, sqlparser.NewTestParser())
+			err := got.Keyspaces["sharded"].Error
+			if tcase.err != "" {
+				require.EqualError(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+
+			colVindex := got.Keyspaces["sharded"].Tables["t1"].ColumnVindexes[0]
+			for expr, matches := range map[string]bool{
+				tcase.expression:              true,
+				"LOWER(t1.c1)":                tcase.expression == "lower(C1)",
+				"lower(c2)":                   false,
+				"c1":                          false,
+				"lower(trim(other_table.c1))": tcase.expression == "lower(trim(c1))",
+				"upper(c1)":                   false,
+			} {
+				parsed, err := sqlparser.NewTestParser().ParseExpr(expr)
+				require.NoError(t, err)
+				assert.Equal(t, matches, colVindex.MatchesExpression(parsed), expr)
+			}
+
+			value := colVindex.ExpressionOf(sqlparser.NewStrLiteral("Foo"))
+			assert.Equal(t, strings.Replace(strings.ToLower(tcase.expression), "c1", "'Foo'", 1), sqlparser.String(value))
+
+			out, err := json.Marshal(colVindex)
+			require.NoError(t, err)
+			assert.Contains(t, string(out), fmt.Sprintf(`"expression":%q`, sqlparser.String(colVindex.Expression)))
+		})
+	}
+}
+
 func TestBuildVSchemaPrimaryCannotBeOwned(t *testing.T) {
 	bad := vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -3503,6 +3629,49 @@ func TestAddingAdditionalTablesToGlobalRouting(t *testing.T) {
 		vs.FindView("sharded", "v3"))
 }
 
+func TestFindTenantKeyspace(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"customers":    {},
+			"customers_42": {},
+		},
+		TenantRoutingRules: &vschemapb.TenantRoutingRules{
+			Rules: []*vschemapb.TenantRoutingRule{{
+				Keyspace:   "customers",
+				TenantId:   "42",
+				ToKeyspace: "customers_42",
+			}},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	assert.Equal(t, "customers_42", vschema.FindTenantKeyspace("customers", "42"))
+	assert.Equal(t, "customers", vschema.FindTenantKeyspace("customers", "43"))
+	assert.Equal(t, "customers", vschema.FindTenantKeyspace("customers", ""))
+	assert.Equal(t, "customers_42", vschema.FindTenantKeyspace("customers_42", "42"))
+}
+
+func TestFindPlanPin(t *testing.T) {
+	input := vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"customers": {},
+		},
+		PlanPins: &vschemapb.PlanPins{
+			Pins: []*vschemapb.PlanPin{{
+				Digest: "8c3e4c7a5b6d2f10",
+				Plan:   `{"OperatorType": "Route"}`,
+			}},
+		},
+	}
+	vschema := BuildVSchema(&input, sqlparser.NewTestParser())
+
+	plan, ok := vschema.FindPlanPin("8c3e4c7a5b6d2f10")
+	assert.True(t, ok)
+	assert.Equal(t, `{"OperatorType": "Route"}`, plan)
+	_, ok = vschema.FindPlanPin("0000000000000000")
+	assert.False(t, ok)
+}
+
 func vindexNames(vindexes []*ColumnVindex) (result []string) {
 	for _, vindex := range vindexes {
 		result = append(result, vindex.Name)
@@ -25,6 +25,7 @@ import (
 	"vitess.io/vitess/go/viperutil"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/tableacl"
 )
 
 type authorizedDDLUsers struct {
@@ -87,7 +88,7 @@ var (
 // calls this function, or call this function directly before parsing
 // command-line arguments.
 func RegisterSchemaACLFlags(fs *pflag.FlagSet) {
-	fs.String("vschema_ddl_authorized_users", "", "List of users authorized to execute vschema ddl operations, or '%' to allow all users.")
+	fs.String("vschema_ddl_authorized_users", "", "List of users or groups authorized to execute vschema ddl operations, or '%' to allow all users.")
 	viperutil.BindFlags(fs, AuthorizedDDLUsers)
 }
 
@@ -97,7 +98,7 @@ func init() {
 	}
 }
 
-// Authorized returns true if the given caller is allowed to execute vschema operations
+// Authorized returns true if the given caller, or one of its groups, is allowed to execute vschema operations
 func Authorized(caller *querypb.VTGateCallerID) bool {
 	users := AuthorizedDDLUsers.Get()
 	if users.allowAll {
@@ -105,6 +106,15 @@ func Authorized(caller *querypb.VTGateCallerID) bool {
 	}
 
 	user := caller.GetUsername()
-	_, ok := users.acl[user]
-	return ok
+	if _, ok := users.acl[user]; ok {
+		return true
+	}
+	// The groups of the caller, including the groups granted by its roles,
+	// are authorized the same way as users.
+	for _, group := range tableacl.ResolveRoles(caller).GetGroups() {
+		if _, ok := users.acl[group]; ok {
+			return true
+		}
+	}
+	return false
 }
@@ -19,7 +19,13 @@ package vschemaacl
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
+
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	tableaclpb "vitess.io/vitess/go/vt/proto/tableacl"
 )
 
 func TestVschemaAcl(t *testing.T) {
@@ -65,3 +71,27 @@ func TestVschemaAcl(t *testing.T) {
 		t.Errorf("user should not be authorized")
 	}
 }
+
+func TestVschemaAclGroups(t *testing.T) {
+	tableacl.Register("simpleacl", &simpleacl.Factory{})
+	err := tableacl.InitFromProto(&tableaclpb.Config{
+		RoleMappings: []*tableaclpb.RoleMapping{{
+			Role:   "dba",
+			Groups: []string{"vschemaAdmins"},
+		}},
+	})
+	require.NoError(t, err)
+	defer func() {
+		err := tableacl.InitFromProto(&tableaclpb.Config{})
+		require.NoError(t, err)
+	}()
+
+	AuthorizedDDLUsers.Set(NewAuthorizedDDLUsers("oneUser, vschemaAdmins"))
+	defer AuthorizedDDLUsers.Set(NewAuthorizedDDLUsers(""))
+
+	// The groups of the caller are authorized like users.
+	require.True(t, Authorized(&querypb.VTGateCallerID{Username: "redUser", Groups: []string{"vschemaAdmins"}}))
+	// So are the groups granted by the roles of the caller.
+	require.True(t, Authorized(&querypb.VTGateCallerID{Username: "redUser", Groups: []string{"dba"}}))
+	require.False(t, Authorized(&querypb.VTGateCallerID{Username: "redUser", Groups: []string{"analyst"}}))
+}
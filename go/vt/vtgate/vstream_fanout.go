@@ -0,0 +1,346 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// fanoutStreamIdleTimeout is how long the upstream stream of a shard is kept
+// once it has no subscriber, so that the clients reconnecting to vtgate can
+// resume from the events it retained.
+const fanoutStreamIdleTimeout = time.Minute
+
+// errFanoutUnavailable is returned to the subscribers of a fan-out stream that
+// can no longer serve them, which then fall back to a stream of their own.
+var errFanoutUnavailable = errors.New("the fan-out stream cannot serve the subscriber")
+
+// vstreamFanout multiplexes the events of a single upstream VStream per shard
+// to the VStreams of many subscribers, instead of each of them streaming from
+// a tablet of the shard. Each upstream stream retains its last batches of
+// events, so that the subscribers can stream from independent positions as
+// long as they are retained.
+type vstreamFanout struct {
+	vsm         *vstreamManager
+	bufferSize  int
+	idleTimeout time.Duration
+
+	mu      sync.Mutex
+	streams map[fanoutKey]*fanoutStream
+}
+
+type fanoutKey struct {
+	keyspace   string
+	shard      string
+	tabletType topodatapb.TabletType
+}
+
+// fanoutStream is the upstream stream of a shard along with the batches of
+// events it retained.
+type fanoutStream struct {
+	key    fanoutKey
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+	// batches are the retained batches of events, the first one being the
+	// batch of sequence number firstSeq.
+	batches  []fanoutBatch
+	firstSeq int
+	// basePos is the position before the first retained batch.
+	basePos string
+	// notify is closed, and replaced, when a batch is added or the stream ends.
+	notify      chan struct{}
+	done        bool
+	subscribers int
+	idleTimer   *time.Timer
+}
+
+// fanoutBatch is a batch of events sent together by the upstream stream, and
+// the position of the shard after it.
+type fanoutBatch struct {
+	pos    string
+	events []*binlogdatapb.VEvent
+}
+
+// fanoutSubscription is the position of a subscriber in a fan-out stream.
+type fanoutSubscription struct {
+	stream *fanoutStream
+	seq    int
+	once   sync.Once
+}
+
+func newVStreamFanout(vsm *vstreamManager, bufferSize int) *vstreamFanout {
+	return &vstreamFanout{
+		vsm:         vsm,
+		bufferSize:  bufferSize,
+		idleTimeout: fanoutStreamIdleTimeout,
+		streams:     make(map[fanoutKey]*fanoutStream),
+	}
+}
+
+// canFanout returns true if the events of a VStream request can be served by
+// the fan-out streams. These stream all the tables of the shards without any
+// option, so the requests with a filter or flags of their own, other than the
+// heartbeat interval of the client, get streams of their own.
+func canFanout(filter *binlogdatapb.Filter, flags *vtgatepb.VStreamFlags) bool {
+	defaultFilter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}
+	defaultFlags := &vtgatepb.VStreamFlags{HeartbeatInterval: flags.GetHeartbeatInterval()}
+	return proto.Equal(filter, defaultFilter) && proto.Equal(flags, defaultFlags)
+}
+
+// subscribe returns the subscription of a VStream to the fan-out stream of a
+// shard from the position of sgtid, starting the stream if there is none. It
+// returns nil if the position is no longer retained by the stream.
+func (f *vstreamFanout) subscribe(tabletType topodatapb.TabletType, sgtid *binlogdatapb.ShardGtid) *fanoutSubscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fanoutKey{keyspace: sgtid.Keyspace, shard: sgtid.Shard, tabletType: tabletType}
+	fs := f.streams[key]
+	if fs == nil {
+		fs = f.startStream(key, sgtid.Gtid)
+		f.streams[key] = fs
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	seq, ok := fs.seek(sgtid.Gtid)
+	if !ok {
+		return nil
+	}
+	fs.subscribers++
+	if fs.idleTimer != nil {
+		fs.idleTimer.Stop()
+		fs.idleTimer = nil
+	}
+	f.vsm.vstreamFanoutSubscribers.Add(fs.labelValues(), 1)
+	return &fanoutSubscription{stream: fs, seq: seq}
+}
+
+// startStream starts the upstream stream of a shard from pos. The stream stops
+// on a reshard, without retaining the journal, so that the subscribers fall
+// back to streams of their own that handle it as they requested.
+func (f *vstreamFanout) startStream(key fanoutKey, pos string) *fanoutStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	fs := &fanoutStream{
+		key:     key,
+		cancel:  cancel,
+		basePos: pos,
+		notify:  make(chan struct{}),
+	}
+	vgtid := &binlogdatapb.VGtid{
+		ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: key.keyspace,
+			Shard:    key.shard,
+			Gtid:     pos,
+		}},
+	}
+	log.Infof("Starting the fan-out stream of %s/%s from %s", key.keyspace, key.shard, pos)
+	go func() {
+		err := f.vsm.VStream(ctx, key.tabletType, vgtid, nil, &vtgatepb.VStreamFlags{StopOnReshard: true}, func(events []*binlogdatapb.VEvent) error {
+			fs.add(events, f.bufferSize)
+			return nil
+		})
+		log.Infof("The fan-out stream of %s/%s ended: %v", key.keyspace, key.shard, err)
+
+		f.mu.Lock()
+		if f.streams[key] == fs {
+			delete(f.streams, key)
+		}
+		f.mu.Unlock()
+		fs.end()
+	}()
+	return fs
+}
+
+func (fs *fanoutStream) labelValues() []string {
+	return []string{fs.key.keyspace, fs.key.shard, fs.key.tabletType.String()}
+}
+
+// seek returns the sequence number of the batch following the position pos,
+// or false if the position is not retained.
+func (fs *fanoutStream) seek(pos string) (int, bool) {
+	end := fs.firstSeq + len(fs.batches)
+	switch {
+	case fs.done:
+		return 0, false
+	case pos == "current":
+		return end, true
+	case pos == fs.basePos:
+		return fs.firstSeq, true
+	}
+	for i := len(fs.batches) - 1; i >= 0; i-- {
+		if fs.batches[i].pos == pos {
+			return fs.firstSeq + i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// add retains a batch of events sent by the upstream stream, evicting the
+// oldest batch if the buffer is full.
+func (fs *fanoutStream) add(events []*binlogdatapb.VEvent, bufferSize int) {
+	batch := fanoutBatch{events: make([]*binlogdatapb.VEvent, 0, len(events))}
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_VGTID:
+			// The VGTIDs are turned back into the GTIDs of the shard, which
+			// each subscriber turns into a VGTID of its own.
+			batch.pos = event.Vgtid.ShardGtids[0].Gtid
+			batch.events = append(batch.events, &binlogdatapb.VEvent{
+				Type:     binlogdatapb.VEventType_GTID,
+				Gtid:     batch.pos,
+				Keyspace: event.Keyspace,
+				Shard:    event.Shard,
+			})
+		case binlogdatapb.VEventType_JOURNAL:
+			return
+		case binlogdatapb.VEventType_HEARTBEAT:
+		default:
+			batch.events = append(batch.events, event)
+		}
+	}
+	if len(batch.events) == 0 {
+		return
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.batches = append(fs.batches, batch)
+	if len(fs.batches) > bufferSize {
+		if fs.batches[0].pos != "" {
+			fs.basePos = fs.batches[0].pos
+		}
+		fs.batches[0] = fanoutBatch{}
+		fs.batches = fs.batches[1:]
+		fs.firstSeq++
+	}
+	close(fs.notify)
+	fs.notify = make(chan struct{})
+}
+
+// end marks the upstream stream as ended, once it returned.
+func (fs *fanoutStream) end() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.done = true
+	close(fs.notify)
+	fs.notify = make(chan struct{})
+}
+
+// next waits for the batches following the position of the subscriber and
+// returns them. It returns errFanoutUnavailable if the subscriber fell behind
+// the retained batches or if the upstream stream ended.
+func (sub *fanoutSubscription) next(ctx context.Context) ([]fanoutBatch, error) {
+	fs := sub.stream
+	for {
+		fs.mu.Lock()
+		if sub.seq < fs.firstSeq {
+			fs.mu.Unlock()
+			return nil, fmt.Errorf("%w: the subscriber fell behind the %d retained batches of events", errFanoutUnavailable, len(fs.batches))
+		}
+		if end := fs.firstSeq + len(fs.batches); sub.seq < end {
+			batches := slices.Clone(fs.batches[sub.seq-fs.firstSeq:])
+			sub.seq = end
+			fs.mu.Unlock()
+			return batches, nil
+		}
+		if fs.done {
+			fs.mu.Unlock()
+			return nil, fmt.Errorf("%w: the upstream stream ended", errFanoutUnavailable)
+		}
+		notify := fs.notify
+		fs.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// close ends the subscription. The upstream stream is stopped once it has had
+// no subscriber for the idle timeout of the fan-out.
+func (sub *fanoutSubscription) close(f *vstreamFanout) {
+	sub.once.Do(func() {
+		fs := sub.stream
+		fs.mu.Lock()
+		defer fs.mu.Unlock()
+		fs.subscribers--
+		f.vsm.vstreamFanoutSubscribers.Add(fs.labelValues(), -1)
+		if fs.subscribers == 0 && !fs.done {
+			fs.idleTimer = time.AfterFunc(f.idleTimeout, fs.cancel)
+		}
+	})
+}
+
+// streamFromFanout streams the events of a shard from its fan-out stream, and
+// falls back to streaming from a tablet of its own if the fan-out stream
+// cannot serve them, such as when its position is not retained.
+func (vs *vstream) streamFromFanout(ctx context.Context, sgtid *binlogdatapb.ShardGtid) error {
+	// The copy phase is not served by the fan-out streams. It is safe to
+	// access sgtid here as it can't change until streaming begins.
+	if sgtid.Gtid == "" || len(sgtid.TablePKs) != 0 {
+		return vs.streamFromTablet(ctx, sgtid)
+	}
+	labelValues := []string{sgtid.Keyspace, sgtid.Shard, vs.tabletType.String()}
+	fanout := vs.vsm.fanout
+	sub := fanout.subscribe(vs.tabletType, sgtid)
+	if sub == nil {
+		log.Infof("Position %s of %s/%s is not retained by its fan-out stream, streaming from a tablet", sgtid.Gtid, sgtid.Keyspace, sgtid.Shard)
+		vs.vsm.vstreamFanoutFallbacks.Add(labelValues, 1)
+		return vs.streamFromTablet(ctx, sgtid)
+	}
+	defer sub.close(fanout)
+
+	for {
+		batches, err := sub.next(ctx)
+		if errors.Is(err, errFanoutUnavailable) {
+			log.Infof("Streaming %s/%s from a tablet: %v", sgtid.Keyspace, sgtid.Shard, err)
+			vs.vsm.vstreamFanoutFallbacks.Add(labelValues, 1)
+			sub.close(fanout)
+			return vs.streamFromTablet(ctx, sgtid)
+		}
+		if err != nil {
+			return vterrors.Wrapf(err, "context ended while streaming from the fan-out stream of %s/%s", sgtid.Keyspace, sgtid.Shard)
+		}
+		// The events are shared by the subscribers, but sendAll replaces the
+		// GTIDs of the batches it is given.
+		eventss := make([][]*binlogdatapb.VEvent, 0, len(batches))
+		for _, batch := range batches {
+			eventss = append(eventss, slices.Clone(batch.events))
+		}
+		if err := vs.sendAll(ctx, sgtid, eventss); err != nil {
+			return vterrors.Wrapf(err, "error sending the events of the fan-out stream of %s/%s", sgtid.Keyspace, sgtid.Shard)
+		}
+	}
+}
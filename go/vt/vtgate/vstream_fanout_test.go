@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/discovery"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+// TestVStreamFanout tests that the VStreams of a shard share its fan-out
+// stream, each from its own position.
+func TestVStreamFanout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cell := "aa"
+	ks := "TestVStream"
+	_ = createSandbox(ks)
+	hc := discovery.NewFakeHealthCheck(nil)
+	st := getSandboxTopo(ctx, cell, ks, []string{"-20"})
+
+	vsm := newTestVStreamManager(ctx, hc, st, cell)
+	vsm.fanout = newVStreamFanout(vsm, 10)
+	vsm.fanout.idleTimeout = 0
+	sbc0 := hc.AddTestTablet(cell, "1.1.1.1", 1001, ks, "-20", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	addTabletToSandboxTopo(t, ctx, st, ks, "-20", sbc0.Tablet())
+
+	// The events are only sent once by the tablet, to the fan-out stream.
+	sbc0.AddVStreamEvents([]*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01"},
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "t0"}},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}, nil)
+	sbc0.AddVStreamEvents([]*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid02"},
+		{Type: binlogdatapb.VEventType_DDL},
+	}, nil)
+	wantVgtid := func(gtid string) *binlogdatapb.VEvent {
+		return &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{
+			ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: ks, Shard: "-20", Gtid: gtid}},
+		}}
+	}
+	want1 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		wantVgtid("gtid01"),
+		{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "TestVStream.t0"}},
+		{Type: binlogdatapb.VEventType_COMMIT},
+	}}
+	want2 := &binlogdatapb.VStreamResponse{Events: []*binlogdatapb.VEvent{
+		wantVgtid("gtid02"),
+		{Type: binlogdatapb.VEventType_DDL},
+	}}
+	vgtid := func(gtid string) *binlogdatapb.VGtid {
+		return &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: ks, Shard: "-20", Gtid: gtid}}}
+	}
+
+	verifyEvents(t, startVStream(ctx, t, vsm, vgtid("pos"), nil), want1, want2)
+	verifyEvents(t, startVStream(ctx, t, vsm, vgtid("pos"), nil), want1, want2)
+	verifyEvents(t, startVStream(ctx, t, vsm, vgtid("gtid01"), &vtgatepb.VStreamFlags{HeartbeatInterval: 60}), want2)
+	labels := "TestVStream.-20.PRIMARY"
+	assert.EqualValues(t, 3, vsm.vstreamFanoutSubscribers.Counts()[labels])
+	assert.EqualValues(t, 0, vsm.vstreamFanoutFallbacks.Counts()[labels])
+
+	// The fan-out stream stops once it has no subscriber left.
+	cancel()
+	require.Eventually(t, func() bool {
+		vsm.fanout.mu.Lock()
+		defer vsm.fanout.mu.Unlock()
+		return len(vsm.fanout.streams) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestCanFanout(t *testing.T) {
+	defaultFilter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "/.*"}}}
+	assert.True(t, canFanout(defaultFilter, &vtgatepb.VStreamFlags{}))
+	assert.True(t, canFanout(defaultFilter, &vtgatepb.VStreamFlags{HeartbeatInterval: 10}))
+	assert.False(t, canFanout(defaultFilter, &vtgatepb.VStreamFlags{MinimizeSkew: true}))
+	assert.False(t, canFanout(defaultFilter, &vtgatepb.VStreamFlags{StopOnReshard: true}))
+	assert.False(t, canFanout(&binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "t1"}}}, &vtgatepb.VStreamFlags{}))
+}
+
+// TestFanoutStreamBuffer tests the retention of the batches of events of a
+// fan-out stream, and the positions its subscribers can stream from.
+func TestFanoutStreamBuffer(t *testing.T) {
+	ctx := context.Background()
+	batch := func(gtid string) []*binlogdatapb.VEvent {
+		return []*binlogdatapb.VEvent{
+			{Type: binlogdatapb.VEventType_VGTID, Vgtid: &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Gtid: gtid}}}},
+			{Type: binlogdatapb.VEventType_COMMIT},
+		}
+	}
+	fs := &fanoutStream{basePos: "pos", notify: make(chan struct{})}
+	fs.add(batch("gtid01"), 2)
+	fs.add(batch("gtid02"), 2)
+	// The heartbeats and the journals are not retained.
+	fs.add([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_HEARTBEAT}}, 2)
+	fs.add(append([]*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_JOURNAL}}, batch("gtid03")...), 2)
+	require.Len(t, fs.batches, 2)
+	assert.Equal(t, &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_GTID, Gtid: "gtid01"}, fs.batches[0].events[0])
+
+	seq, ok := fs.seek("pos")
+	require.True(t, ok)
+	assert.Equal(t, 0, seq)
+	seq, ok = fs.seek("gtid02")
+	require.True(t, ok)
+	assert.Equal(t, 2, seq)
+	seq, ok = fs.seek("current")
+	require.True(t, ok)
+	assert.Equal(t, 2, seq)
+	_, ok = fs.seek("unknown")
+	assert.False(t, ok)
+
+	sub := &fanoutSubscription{stream: fs}
+	batches, err := sub.next(ctx)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Equal(t, "gtid02", batches[1].pos)
+
+	// The oldest batch is evicted once the buffer is full, which makes the
+	// subscribers still at its position fall behind.
+	fs.add(batch("gtid04"), 2)
+	assert.Equal(t, "gtid01", fs.basePos)
+	_, ok = fs.seek("pos")
+	assert.False(t, ok)
+	behind := &fanoutSubscription{stream: fs}
+	_, err = behind.next(ctx)
+	assert.ErrorIs(t, err, errFanoutUnavailable)
+	batches, err = sub.next(ctx)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, "gtid04", batches[0].pos)
+
+	// The subscribers fall back to streams of their own once they consumed
+	// the batches of an ended stream.
+	fs.end()
+	_, err = sub.next(ctx)
+	assert.ErrorIs(t, err, errFanoutUnavailable)
+	_, ok = fs.seek("gtid04")
+	assert.False(t, ok)
+}
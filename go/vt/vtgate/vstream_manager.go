@@ -57,6 +57,12 @@ type vstreamManager struct {
 	vstreamsCount           *stats.CountersWithMultiLabels
 	vstreamsEventsStreamed  *stats.CountersWithMultiLabels
 	vstreamsEndedWithErrors *stats.CountersWithMultiLabels
+
+	// fanout serves the events of the shards to the VStreams from a single
+	// stream per shard. It is nil if the fan-out is disabled.
+	fanout                   *vstreamFanout
+	vstreamFanoutSubscribers *stats.GaugesWithMultiLabels
+	vstreamFanoutFallbacks   *stats.CountersWithMultiLabels
 }
 
 // maxSkewTimeoutSeconds is the maximum allowed skew between two streams when the MinimizeSkew flag is set
@@ -137,6 +143,10 @@ type vstream struct {
 	tabletPickerOptions discovery.TabletPickerOptions
 
 	flags *vtgatepb.VStreamFlags
+
+	// useFanout is set if the shards are streamed from the fan-out streams
+	// of the vstreamManager.
+	useFanout bool
 }
 
 type journalEvent struct {
@@ -149,7 +159,7 @@ func newVStreamManager(resolver *srvtopo.Resolver, serv srvtopo.Server, cell str
 	exporter := servenv.NewExporter(cell, "VStreamManager")
 	labels := []string{"Keyspace", "ShardName", "TabletType"}
 
-	return &vstreamManager{
+	vsm := &vstreamManager{
 		resolver: resolver,
 		toposerv: serv,
 		cell:     cell,
@@ -173,7 +183,19 @@ func newVStreamManager(resolver *srvtopo.Resolver, serv srvtopo.Server, cell str
 			"VStreamsEndedWithErrors",
 			"Number of vstreams that ended with errors",
 			labels),
+		vstreamFanoutSubscribers: exporter.NewGaugesWithMultiLabels(
+			"VStreamFanoutSubscribers",
+			"Number of vstreams streaming a shard from its fan-out stream",
+			labels),
+		vstreamFanoutFallbacks: exporter.NewCountersWithMultiLabels(
+			"VStreamFanoutFallbacks",
+			"Number of vstreams that could not stream a shard from its fan-out stream and streamed from a tablet instead",
+			labels),
 	}
+	if vstreamFanoutBufferSize > 0 {
+		vsm.fanout = newVStreamFanout(vsm, vstreamFanoutBufferSize)
+	}
+	return vsm
 }
 
 func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.TabletType, vgtid *binlogdatapb.VGtid,
@@ -216,7 +238,8 @@ func (vsm *vstreamManager) VStream(ctx context.Context, tabletType topodatapb.Ta
 			// health stream.
 			ExcludeTabletsWithMaxReplicationLag: discovery.GetLowReplicationLag(),
 		},
-		flags: flags,
+		flags:     flags,
+		useFanout: vsm.fanout != nil && canFanout(filter, flags),
 	}
 	return vs.stream(ctx)
 }
@@ -406,7 +429,12 @@ func (vs *vstream) startOneStream(ctx context.Context, sgtid *binlogdatapb.Shard
 		vs.vsm.vstreamsCreated.Add(labelValues, 1)
 		vs.vsm.vstreamsCount.Add(labelValues, 1)
 
-		err := vs.streamFromTablet(ctx, sgtid)
+		var err error
+		if vs.useFanout {
+			err = vs.streamFromFanout(ctx, sgtid)
+		} else {
+			err = vs.streamFromTablet(ctx, sgtid)
+		}
 
 		// Set the error on exit. First one wins.
 		if err != nil {
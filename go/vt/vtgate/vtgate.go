@@ -32,12 +32,14 @@ import (
 	"github.com/spf13/viper"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/flagutil"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/tb"
 	"vitess.io/vitess/go/viperutil"
 	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/dynamicflags"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
@@ -51,6 +53,8 @@ import (
 	"vitess.io/vitess/go/vt/sidecardb"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/tableacl"
+	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
@@ -74,12 +78,49 @@ var (
 
 	// plan cache related flag
 	queryPlanCacheMemory int64 = 32 * 1024 * 1024 // 32mb
+	planWarmupCount      int
+	planWarmupTimeout    = 5 * time.Second
 
 	maxMemoryRows   = 300000
 	warnMemoryRows  = 30000
 	maxPayloadSize  int
 	warnPayloadSize int
 
+	// result size circuit breaker related flags
+	maxResultBytes       int64
+	warnResultBytes      int64
+	truncateResultBytes  bool
+	resultSizeMaxCallers = 1000
+
+	// maxResultRowsByPlanType are the maximum numbers of rows of the results, by plan type.
+	maxResultRowsByPlanType flagutil.StringMapValue
+
+	// query memory related flags
+	queryMemoryLimit       int64
+	globalQueryMemoryLimit int64
+	querySpillDir          string
+	querySpillBufferSize   int64 = 64 * 1024 * 1024
+	querySpillEncryption   bool
+
+	// vitess_next_id related flags
+	idGenerationBlockSize int64 = 1000
+	idGenerationNodeID    int64
+
+	// query digest related flags
+	queryDigestSampleRate    float64
+	queryDigestMaxEntries    = 1000
+	queryDigestResetInterval = time.Hour
+
+	// per-table metrics related flags
+	tableMetricsAllowlist []string
+	tableMetricsMaxTables int
+	enableTableTimings    bool
+
+	// OLAP workload isolation related flags
+	olapMaxConcurrency   int
+	olapStreamBufferSize int
+	olapMaxRows          int
+
 	noScatter          bool
 	enableShardRouting bool
 
@@ -92,6 +133,14 @@ var (
 	sysVarSetEnabled = true
 	setVarEnabled    = true
 
+	// consistentReadsMaxSkew is the maximum time between the snapshots of the
+	// shards read by a consistent read.
+	consistentReadsMaxSkew = 100 * time.Millisecond
+
+	// groupDMLBatchesByShard enables the grouping by shard of the single-shard
+	// DMLs of the multi-statement queries of the transactions.
+	groupDMLBatchesByShard bool
+
 	// lockHeartbeatTime is used to set the next heartbeat time.
 	lockHeartbeatTime = 5 * time.Second
 	warnShardedOnly   bool
@@ -101,6 +150,12 @@ var (
 	dbDDLPlugin        = "fail"
 	defaultDDLStrategy = string(schema.DDLStrategyDirect)
 
+	// maxCrossShardCascadeRows is the maximum number of parent rows a cross-shard foreign key cascade can modify.
+	maxCrossShardCascadeRows = 10000
+
+	// loadDataChunkRows is the number of rows of LOAD DATA LOCAL INFILE inserted by each INSERT.
+	loadDataChunkRows = 1000
+
 	enableOnlineDDL = viperutil.Configure(
 		"enable_online_ddl",
 		viperutil.Options[bool]{
@@ -159,6 +214,11 @@ var (
 	// queryLogBufferSize controls how many query logs will be buffered before dropping them if logging is not fast enough
 	queryLogBufferSize = 10
 
+	// slow query log related flags
+	slowQueryLogThreshold  time.Duration
+	slowQueryLogSampleRate = 1.0
+	slowQueryLogToFile     string
+
 	messageStreamGracePeriod = 30 * time.Second
 
 	// allowKillStmt to allow execution of kill statement.
@@ -167,6 +227,17 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	mirrorCompareResults  bool
+	mirrorMismatchLogRate = 0.01
+
+	// vstreamFanoutBufferSize is the number of batches of events of each shard
+	// retained by the fan-out streams. 0 disables the fan-out.
+	vstreamFanoutBufferSize int
+
+	// tableACLConfig is the table ACL config of the vttablets, which vtgate
+	// uses to resolve the roles of the callers and to report their grants.
+	tableACLConfig string
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -176,6 +247,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&truncateErrorLen, "truncate-error-len", truncateErrorLen, "truncate errors sent to client if they are longer than this value (0 means do not truncate)")
 	fs.IntVar(&streamBufferSize, "stream_buffer_size", streamBufferSize, "the number of bytes sent from vtgate for each stream call. It's recommended to keep this value in sync with vttablet's query-server-config-stream-buffer-size.")
 	fs.Int64Var(&queryPlanCacheMemory, "gate_query_cache_memory", queryPlanCacheMemory, "gate server query cache size in bytes, maximum amount of memory to be cached. vtgate analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
+	fs.IntVar(&planWarmupCount, "vschema-plan-warmup-count", planWarmupCount, "Number of the most executed cached plans built again against a new vschema before it is applied, so that their queries are not planned again after the plan cache is cleared. 0 disables the warmup.")
+	fs.DurationVar(&planWarmupTimeout, "vschema-plan-warmup-timeout", planWarmupTimeout, "Maximum time spent building the cached plans against a new vschema before it is applied. The plans not built by then are dropped from the plan cache.")
 	fs.IntVar(&maxMemoryRows, "max_memory_rows", maxMemoryRows, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
 	fs.IntVar(&warnMemoryRows, "warn_memory_rows", warnMemoryRows, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
 	fs.StringVar(&defaultDDLStrategy, "ddl_strategy", defaultDDLStrategy, "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
@@ -186,17 +259,45 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&healthCheckTimeout, "healthcheck_timeout", healthCheckTimeout, "the health check timeout period")
 	fs.IntVar(&maxPayloadSize, "max_payload_size", maxPayloadSize, "The threshold for query payloads in bytes. A payload greater than this threshold will result in a failure to handle the query.")
 	fs.IntVar(&warnPayloadSize, "warn_payload_size", warnPayloadSize, "The warning threshold for query payloads in bytes. A payload greater than this threshold will cause the VtGateWarnings.WarnPayloadSizeExceeded counter to be incremented.")
+	fs.Int64Var(&maxResultBytes, "max-result-bytes", maxResultBytes, "Maximum size in bytes of a non-streaming query result. A result greater than this threshold is rejected, or truncated if --truncate-result-bytes is set. 0 means no limit.")
+	fs.IntVar(&loadDataChunkRows, "load-data-chunk-rows", loadDataChunkRows, "Number of rows of the file of a LOAD DATA LOCAL INFILE inserted by each INSERT. Outside of a transaction, each chunk of rows is inserted in its own transaction across the shards they belong to.")
+	fs.Int64Var(&warnResultBytes, "warn-result-bytes", warnResultBytes, "Warning threshold in bytes for non-streaming query results. A result greater than this threshold will cause the VtGateWarnings.ResultBytesExceeded counter to be incremented. 0 means no warning.")
+	fs.BoolVar(&truncateResultBytes, "truncate-result-bytes", truncateResultBytes, "If set, results greater than --max-result-bytes are truncated with a warning instead of being rejected.")
+	fs.Var(&maxResultRowsByPlanType, "max-result-rows-by-plan-type", "Comma-separated list of <plan type>:<rows> pairs, such as Scatter:10000, limiting the number of rows of the non-streaming results of each plan type. A result above the limit is truncated with a warning. A plan type can be prefixed with a keyspace, such as commerce.Scatter:1000, to limit the plans using the tables of that keyspace. The MAX_RESULT_ROWS query directive overrides the limit.")
+	fs.Int64Var(&queryMemoryLimit, "query-memory-limit", queryMemoryLimit, "Maximum number of bytes of the rows a query buffers at vtgate for its joins, sorts and aggregations. A query going above the limit is aborted. 0 means no limit.")
+	fs.Int64Var(&globalQueryMemoryLimit, "global-query-memory-limit", globalQueryMemoryLimit, "Maximum number of bytes of the rows buffered at vtgate by all the in-flight queries for their joins, sorts and aggregations. A query going above the limit is aborted. 0 means no limit.")
+	fs.StringVar(&querySpillDir, "query-spill-dir", querySpillDir, "Directory of the temporary files the sorts and hash joins of the streaming queries spill their rows to, once they buffer more than --query-spill-buffer-size bytes at vtgate. Empty means the rows are never spilled.")
+	fs.Int64Var(&querySpillBufferSize, "query-spill-buffer-size", querySpillBufferSize, "Number of bytes of rows a sort or a hash join of a streaming query buffers at vtgate before spilling them to --query-spill-dir.")
+	fs.BoolVar(&querySpillEncryption, "query-spill-encryption", querySpillEncryption, "If set, the rows spilled to --query-spill-dir are encrypted with a key generated for each file, which is only kept in memory.")
+	fs.IntVar(&olapMaxConcurrency, "olap-max-concurrency", olapMaxConcurrency, "Maximum number of concurrent queries of OLAP sessions. The queries above this limit wait for one to finish. 0 means no limit.")
+	fs.IntVar(&olapStreamBufferSize, "olap-stream-buffer-size", olapStreamBufferSize, "The number of bytes sent from vtgate for each stream call of an OLAP session. 0 means --stream_buffer_size.")
+	fs.IntVar(&olapMaxRows, "olap-max-rows", olapMaxRows, "Maximum number of rows streamed by a query of an OLAP session. 0 means no limit.")
+	fs.Int64Var(&idGenerationBlockSize, "id-generation-block-size", idGenerationBlockSize, "Number of ids fetched at once from a sequence table by vitess_next_id, and served by vtgate until exhausted.")
+	fs.Int64Var(&idGenerationNodeID, "id-generation-node-id", idGenerationNodeID, "Node id of this vtgate in the k-ordered ids generated by vitess_next_id, between 0 and 1023. The vtgates must have distinct node ids for their k-ordered ids to be unique.")
+	fs.Float64Var(&queryDigestSampleRate, "query-digest-sample-rate", queryDigestSampleRate, "Fraction of the queries sampled into the query digest statistics of SHOW VITESS_QUERY_DIGESTS and /debug/query_digests, between 0.0 (disabled) and 1.0 (all queries).")
+	fs.IntVar(&queryDigestMaxEntries, "query-digest-max-entries", queryDigestMaxEntries, "Maximum number of distinct query digests tracked. Additional digests are accounted as 'other'.")
+	fs.DurationVar(&queryDigestResetInterval, "query-digest-reset-interval", queryDigestResetInterval, "Interval at which the query digest statistics are reset. 0 means they are never reset.")
+	fs.StringSliceVar(&tableMetricsAllowlist, "table-metrics-allowlist", tableMetricsAllowlist, "Tables labeling the per-table metrics, as keyspace.table, or keyspace.* for all the tables of a keyspace. The other tables are accounted as 'other'. If empty, --table-metrics-max-tables applies.")
+	fs.IntVar(&tableMetricsMaxTables, "table-metrics-max-tables", tableMetricsMaxTables, "Maximum number of distinct tables labeling the per-table metrics when --table-metrics-allowlist is empty. The tables seen after this limit is reached are accounted as 'other'. 0 means no limit.")
+	fs.BoolVar(&enableTableTimings, "enable-table-timings", enableTableTimings, "If set, the QueryTimingsByTable and QueryErrorsByTable metrics record the execution time and the errors of the queries per keyspace and table.")
+	fs.IntVar(&resultSizeMaxCallers, "result-size-max-callers", resultSizeMaxCallers, "Maximum number of distinct callers tracked for SHOW VITESS_RESULT_SIZES. Additional callers are accounted as 'other'.")
 	fs.BoolVar(&sysVarSetEnabled, "enable_system_settings", sysVarSetEnabled, "This will enable the system settings to be changed per session at the database connection level")
 	fs.BoolVar(&setVarEnabled, "enable_set_var", setVarEnabled, "This will enable the use of MySQL's SET_VAR query hint for certain system variables instead of using reserved connections")
+	fs.DurationVar(&consistentReadsMaxSkew, "consistent-reads-max-skew", consistentReadsMaxSkew, "Maximum time between the snapshots of the shards read by a SELECT of a session with @@vitess_consistent_reads set. The snapshots are taken again, up to 3 times, when they are further apart or not aligned on the GTID positions of the shards, after which the SELECT fails.")
+	fs.BoolVar(&groupDMLBatchesByShard, "group-dml-batches-by-shard", groupDMLBatchesByShard, "If set, the consecutive single-shard DMLs of the multi-statement queries of a transaction are grouped by shard: the DMLs of each shard are sent in order, while the shards are executed concurrently. If a DML fails after the following DMLs were executed on other shards, the transaction is rolled back.")
 	fs.DurationVar(&lockHeartbeatTime, "lock_heartbeat_time", lockHeartbeatTime, "If there is lock function used. This will keep the lock connection active by using this heartbeat")
 	fs.BoolVar(&warnShardedOnly, "warn_sharded_only", warnShardedOnly, "If any features that are only available in unsharded mode are used, query execution warnings will be added to the session")
 	fs.StringVar(&foreignKeyMode, "foreign_key_mode", foreignKeyMode, "This is to provide how to handle foreign key constraint in create/alter table. Valid values are: allow, disallow")
+	fs.IntVar(&maxCrossShardCascadeRows, "max-cross-shard-cascade-rows", maxCrossShardCascadeRows, "Maximum number of parent rows a DML can cascade to the children of a cross-shard foreign key in a managed foreign key keyspace. 0 means no limit.")
 	fs.Bool("enable_online_ddl", enableOnlineDDL.Default(), "Allow users to submit, review and control Online DDL")
 	fs.Bool("enable_direct_ddl", enableDirectDDL.Default(), "Allow users to submit direct DDL statements")
 	fs.BoolVar(&enableSchemaChangeSignal, "schema_change_signal", enableSchemaChangeSignal, "Enable the schema tracker; requires queryserver-config-schema-change-signal to be enabled on the underlying vttablets for this to work")
 	fs.IntVar(&queryTimeout, "query-timeout", queryTimeout, "Sets the default query timeout (in ms). Can be overridden by session variable (query_timeout) or comment directive (QUERY_TIMEOUT_MS)")
 	fs.StringVar(&queryLogToFile, "log_queries_to_file", queryLogToFile, "Enable query logging to the specified file")
 	fs.IntVar(&queryLogBufferSize, "querylog-buffer-size", queryLogBufferSize, "Maximum number of buffered query logs before throttling log output")
+	fs.DurationVar(&slowQueryLogThreshold, "slow-query-log-threshold", slowQueryLogThreshold, "Queries taking longer than this threshold are sent to the slow query log at /debug/slow_querylog, with the breakdown of their execution time and rows by phase and by shard. 0 disables the slow query log.")
+	fs.Float64Var(&slowQueryLogSampleRate, "slow-query-log-sample-rate", slowQueryLogSampleRate, "Sample rate of the slow query log, between 0.0 (no logging) and 1.0 (all slow queries).")
+	fs.StringVar(&slowQueryLogToFile, "slow-query-log-file", slowQueryLogToFile, "If set, the slow query log is also written to the specified file, as one JSON object per line.")
 	fs.DurationVar(&messageStreamGracePeriod, "message_stream_grace_period", messageStreamGracePeriod, "the amount of time to give for a vttablet to resume if it ends a message stream, usually because of a reparent.")
 	fs.BoolVar(&enableViews, "enable-views", enableViews, "Enable views support in vtgate.")
 	fs.BoolVar(&enableUdfs, "track-udfs", enableUdfs, "Track UDFs in vtgate.")
@@ -204,6 +305,10 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.BoolVar(&mirrorCompareResults, "mirror-compare-results", mirrorCompareResults, "Compare the results of queries mirrored by mirror rules with the results of the source keyspace. Mismatches are counted in the MirrorResultComparisons metric.")
+	fs.Float64Var(&mirrorMismatchLogRate, "mirror-mismatch-log-rate", mirrorMismatchLogRate, "Fraction of mirror result mismatches to log, between 0.0 (no logging) and 1.0 (all mismatches). Only used with --mirror-compare-results.")
+	fs.IntVar(&vstreamFanoutBufferSize, "vstream-fanout-buffer-size", vstreamFanoutBufferSize, "If set, the VStreams streaming all the tables of the shards from a position share a single stream per shard and tablet type instead of streaming from the tablets each, and this is the number of batches of events of each shard retained for them. The VStreams starting from, or falling behind, a position that is no longer retained stream from the tablets. 0 disables the fan-out.")
+	fs.StringVar(&tableACLConfig, "table-acl-config", tableACLConfig, "Path to the table ACL config file of the vttablets. VTGate does not enforce it, but uses its role mappings to resolve the roles of the callers, and its table groups to report their grants in SHOW GRANTS.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -215,6 +320,21 @@ func registerFlags(fs *pflag.FlagSet) {
 func init() {
 	servenv.OnParseFor("vtgate", registerFlags)
 	servenv.OnParseFor("vtcombo", registerFlags)
+
+	dynamicflags.Register("enable_online_ddl", enableOnlineDDL.Get, func(enable bool) error {
+		enableOnlineDDL.Set(enable)
+		return nil
+	})
+	dynamicflags.Register("enable_direct_ddl", enableDirectDDL.Get, func(enable bool) error {
+		enableDirectDDL.Set(enable)
+		return nil
+	})
+	dynamicflags.Register("transaction_mode", func() string {
+		return strings.ToLower(transactionMode.Get().String())
+	}, func(mode string) error {
+		transactionMode.Set(vtgatepb.TransactionMode(vtgatepb.TransactionMode_value[strings.ToUpper(mode)]))
+		return nil
+	})
 }
 
 var (
@@ -225,7 +345,7 @@ var (
 	// Error counters should be global so they can be set from anywhere
 	errorCounts = stats.NewCountersWithMultiLabels("VtgateApiErrorCounts", "Vtgate API error counts per error type", []string{"Operation", "Keyspace", "DbType", "Code"})
 
-	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "IgnoredSet", "NonAtomicCommit", "ResultsExceeded", "WarnPayloadSizeExceeded", "WarnUnshardedOnly")
+	warnings = stats.NewCountersWithSingleLabel("VtGateWarnings", "Vtgate warnings", "type", "CanaryExecution", "IgnoredSet", "NonAtomicCommit", "ResultsExceeded", "ResultBytesExceeded", "ResultBytesTruncated", "ResultRowsTruncated", "WarnPayloadSizeExceeded", "WarnUnshardedOnly")
 
 	vstreamSkewDelayCount = stats.NewCounter("VStreamEventsDelayedBySkewAlignment",
 		"Number of events that had to wait because the skew across shards was too high")
@@ -341,6 +461,26 @@ func Init(
 	if _, err := schema.ParseDDLStrategy(defaultDDLStrategy); err != nil {
 		log.Fatalf("Invalid value for -ddl_strategy: %v", err.Error())
 	}
+	maxResultRows, err := parseMaxResultRows(maxResultRowsByPlanType)
+	if err != nil {
+		log.Fatalf("Invalid value for --max-result-rows-by-plan-type: %v", err)
+	}
+	if querySpillBufferSize < 1 {
+		log.Fatalf("Invalid value for --query-spill-buffer-size: %d, must be at least 1", querySpillBufferSize)
+	}
+	if idGenerationBlockSize < 1 {
+		log.Fatalf("Invalid value for --id-generation-block-size: %d, must be at least 1", idGenerationBlockSize)
+	}
+	if idGenerationNodeID < 0 || idGenerationNodeID > maxKOrderedIDNodeID {
+		log.Fatalf("Invalid value for --id-generation-node-id: %d, must be between 0 and %d", idGenerationNodeID, maxKOrderedIDNodeID)
+	}
+	if tableACLConfig != "" {
+		// To override default simpleacl, other ACL plugins must set themselves to be default ACL factory
+		tableacl.Register("simpleacl", &simpleacl.Factory{})
+		if err := tableacl.Init(tableACLConfig, nil); err != nil {
+			log.Fatalf("Unable to load --table-acl-config %s: %v", tableACLConfig, err)
+		}
+	}
 	tc := NewTxConn(gw, dynamicConfig)
 	// ScatterConn depends on TxConn to perform forced rollbacks.
 	sc := NewScatterConn("VttabletCall", tc, gw)
@@ -380,6 +520,9 @@ func Init(
 		AllowScatter:        !noScatter,
 		WarmingReadsPercent: warmingReadsPercent,
 		QueryLogToFile:      queryLogToFile,
+		PlanWarmupCount:     planWarmupCount,
+		PlanWarmupTimeout:   planWarmupTimeout,
+		MaxResultRows:       maxResultRows,
 	}
 
 	executor := NewExecutor(ctx, env, serv, cell, resolver, eConfig, warnShardedOnly, plans, si, pv, dynamicConfig)
@@ -387,6 +530,9 @@ func Init(
 	if err := executor.defaultQueryLogger(); err != nil {
 		log.Fatalf("error initializing query logger: %v", err)
 	}
+	if err := executor.defaultSlowQueryLogger(); err != nil {
+		log.Fatalf("error initializing slow query logger: %v", err)
+	}
 
 	// connect the schema tracker with the vschema manager
 	if enableSchemaChangeSignal {
@@ -416,6 +562,10 @@ func Init(
 			servenv.OnTermSync(srv.shutdownMysqlProtocolAndDrain)
 			servenv.OnClose(srv.rollbackAtShutdown)
 		}
+		if pgListener := initPGProtocol(vtgateInst); pgListener != nil {
+			servenv.OnTermSync(pgListener.Close)
+		}
+		initHTTPQueryAPI(vtgateInst)
 	})
 	servenv.OnTerm(func() {
 		if st != nil && enableSchemaChangeSignal {
@@ -600,22 +750,68 @@ func (vtg *VTGate) ExecuteMulti(
 	}
 	var qr *sqltypes.Result
 	var cancel context.CancelFunc
-	for _, query := range queries {
+	for len(queries) > 0 {
+		if groupDMLBatchesByShard && len(queries) > 1 {
+			var batch []*sqltypes.Result
+			func() {
+				if mysqlQueryTimeout != 0 {
+					ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+					defer cancel()
+				}
+				session, batch, err = vtg.executeDMLBatch(ctx, session, queries)
+			}()
+			qrs = append(qrs, batch...)
+			if err != nil {
+				return session, qrs, err
+			}
+			if len(batch) > 0 {
+				queries = queries[len(batch):]
+				continue
+			}
+		}
 		func() {
 			if mysqlQueryTimeout != 0 {
 				ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
 				defer cancel()
 			}
-			session, qr, err = vtg.Execute(ctx, mysqlCtx, session, query, make(map[string]*querypb.BindVariable), false)
+			session, qr, err = vtg.Execute(ctx, mysqlCtx, session, queries[0], make(map[string]*querypb.BindVariable), false)
 		}()
 		if err != nil {
 			return session, qrs, err
 		}
 		qrs = append(qrs, qr)
+		queries = queries[1:]
 	}
 	return session, qrs, nil
 }
 
+// executeDMLBatch executes the single-shard DMLs starting the queries grouped
+// by shard, see Executor.ExecuteDMLBatch.
+func (vtg *VTGate) executeDMLBatch(ctx context.Context, session *vtgatepb.Session, queries []string) (*vtgatepb.Session, []*sqltypes.Result, error) {
+	// In this context, we don't care if we can't fully parse destination
+	destKeyspace, destTabletType, _, _ := vtg.executor.ParseDestinationTarget(session.TargetString)
+	statsKey := []string{"Execute", destKeyspace, topoproto.TabletTypeLString(destTabletType)}
+	defer vtg.timings.Record(statsKey, time.Now())
+
+	safeSession := econtext.NewSafeSession(session)
+	qrs, err := vtg.executor.ExecuteDMLBatch(ctx, "Execute", safeSession, queries)
+	safeSession.RemoveInternalSavepoint()
+	for i, qr := range qrs {
+		vtg.rowsAffected.Add(statsKey, int64(qr.RowsAffected))
+		vtg.queryTextCharsProcessed.Add(statsKey, int64(len(queries[i])))
+	}
+	if err == nil {
+		return session, qrs, nil
+	}
+
+	query := map[string]any{
+		"Sql":     queries[len(qrs)],
+		"Session": session,
+	}
+	err = recordAndAnnotateError(err, statsKey, query, vtg.logExecute, vtg.executor.vm.parser)
+	return session, qrs, err
+}
+
 // ExecuteBatch executes a batch of queries.
 func (vtg *VTGate) ExecuteBatch(ctx context.Context, session *vtgatepb.Session, sqlList []string, bindVariablesList []map[string]*querypb.BindVariable) (*vtgatepb.Session, []sqltypes.QueryResponse, error) {
 	// In this context, we don't care if we can't fully parse destination
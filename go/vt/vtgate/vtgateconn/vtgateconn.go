@@ -171,6 +171,68 @@ func (sn *VTGateSession) Prepare(ctx context.Context, query string) ([]*querypb.
 	return fields, paramsCount, err
 }
 
+// Begin starts a transaction. The following queries of the session are
+// executed in the transaction, until Commit or Rollback is called.
+func (sn *VTGateSession) Begin(ctx context.Context) error {
+	_, err := sn.Execute(ctx, "begin", nil, false)
+	return err
+}
+
+// Commit commits the transaction of the session.
+func (sn *VTGateSession) Commit(ctx context.Context) error {
+	_, err := sn.Execute(ctx, "commit", nil, false)
+	return err
+}
+
+// Rollback rolls back the transaction of the session.
+func (sn *VTGateSession) Rollback(ctx context.Context) error {
+	_, err := sn.Execute(ctx, "rollback", nil, false)
+	return err
+}
+
+// InTransaction returns true if a transaction is open in the session.
+func (sn *VTGateSession) InTransaction() bool {
+	return sn.session.GetInTransaction()
+}
+
+// Close rolls back the transaction of the session, if any, and releases its
+// reserved connections. The session can be used again after being closed,
+// with the same target and options.
+func (sn *VTGateSession) Close(ctx context.Context) error {
+	err := sn.impl.CloseSession(ctx, sn.session)
+	sn.session = &vtgatepb.Session{
+		TargetString: sn.session.GetTargetString(),
+		Options:      sn.session.GetOptions(),
+		Autocommit:   sn.session.GetAutocommit(),
+	}
+	return err
+}
+
+// Transaction runs fn in a transaction on a new session. The transaction is
+// committed if fn returns nil, and rolled back if it returns an error or
+// panics. As with any VTGate transaction, a transaction which spans several
+// shards is not atomic unless the transaction mode of vtgate is TWOPC.
+func (conn *VTGateConn) Transaction(ctx context.Context, targetString string, options *querypb.ExecuteOptions, fn func(ctx context.Context, session *VTGateSession) error) (err error) {
+	session := conn.Session(targetString, options)
+	if err := session.Begin(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if x := recover(); x != nil {
+			_ = session.Close(ctx)
+			panic(x)
+		}
+		if err != nil {
+			_ = session.Close(ctx)
+		}
+	}()
+
+	if err = fn(ctx, session); err != nil {
+		return err
+	}
+	return session.Commit(ctx)
+}
+
 //
 // The rest of this file is for the protocol implementations.
 //
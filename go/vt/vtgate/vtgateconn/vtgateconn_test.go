@@ -18,7 +18,16 @@ package vtgateconn
 
 import (
 	"context"
+	"errors"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 )
 
 func TestRegisterDialer(t *testing.T) {
@@ -58,3 +67,83 @@ func TestDeregisterDialer(t *testing.T) {
 		t.Fatalf("protocol: %s is not registered, should return error: %v", protocol, err)
 	}
 }
+
+// fakeImpl records the queries it executes, and tracks the transaction
+// state of the session as vtgate would.
+type fakeImpl struct {
+	Impl
+	queries []string
+	closed  int
+}
+
+func (f *fakeImpl) Execute(ctx context.Context, session *vtgatepb.Session, query string, bindVars map[string]*querypb.BindVariable, prepared bool) (*vtgatepb.Session, *sqltypes.Result, error) {
+	f.queries = append(f.queries, query)
+	session = session.CloneVT()
+	switch query {
+	case "begin":
+		session.InTransaction = true
+	case "commit", "rollback":
+		session.InTransaction = false
+	case "fail":
+		return session, nil, errors.New("query failed")
+	}
+	return session, &sqltypes.Result{}, nil
+}
+
+func (f *fakeImpl) CloseSession(ctx context.Context, session *vtgatepb.Session) error {
+	f.closed++
+	return nil
+}
+
+func TestSessionTransaction(t *testing.T) {
+	ctx := context.Background()
+	impl := &fakeImpl{}
+	conn := &VTGateConn{impl: impl}
+
+	session := conn.Session("ks@primary", nil)
+	require.NoError(t, session.Begin(ctx))
+	assert.True(t, session.InTransaction())
+	require.NoError(t, session.Commit(ctx))
+	assert.False(t, session.InTransaction())
+
+	require.NoError(t, session.Begin(ctx))
+	require.NoError(t, session.Close(ctx))
+	assert.False(t, session.InTransaction())
+	assert.Equal(t, "ks@primary", session.SessionPb().TargetString)
+	assert.True(t, session.SessionPb().Autocommit)
+	assert.Equal(t, 1, impl.closed)
+	assert.Equal(t, []string{"begin", "commit", "begin"}, impl.queries)
+}
+
+func TestConnTransaction(t *testing.T) {
+	ctx := context.Background()
+	impl := &fakeImpl{}
+	conn := &VTGateConn{impl: impl}
+
+	err := conn.Transaction(ctx, "ks", nil, func(ctx context.Context, session *VTGateSession) error {
+		assert.True(t, session.InTransaction())
+		_, err := session.Execute(ctx, "insert", nil, false)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "insert", "commit"}, impl.queries)
+	assert.Equal(t, 0, impl.closed)
+
+	// The transaction is rolled back if fn fails.
+	impl.queries = nil
+	err = conn.Transaction(ctx, "ks", nil, func(ctx context.Context, session *VTGateSession) error {
+		_, err := session.Execute(ctx, "fail", nil, false)
+		return err
+	})
+	require.EqualError(t, err, "query failed")
+	assert.Equal(t, []string{"begin", "fail"}, impl.queries)
+	assert.Equal(t, 1, impl.closed)
+
+	// Or if it panics.
+	assert.Panics(t, func() {
+		_ = conn.Transaction(ctx, "ks", nil, func(ctx context.Context, session *VTGateSession) error {
+			panic("boom")
+		})
+	})
+	assert.Equal(t, 2, impl.closed)
+}
@@ -201,6 +201,42 @@ var (
 		},
 	)
 
+	injectEmptyTransactionsForErrantGTIDs = viperutil.Configure(
+		"inject-empty-transactions-for-errant-gtids",
+		viperutil.Options[bool]{
+			FlagName: "inject-empty-transactions-for-errant-gtids",
+			Default:  false,
+			Dynamic:  true,
+		},
+	)
+
+	replicationLagSLOThreshold = viperutil.Configure(
+		"replication-lag-slo-threshold",
+		viperutil.Options[time.Duration]{
+			FlagName: "replication-lag-slo-threshold",
+			Default:  1 * time.Second,
+			Dynamic:  true,
+		},
+	)
+
+	replicationLagSLOTarget = viperutil.Configure(
+		"replication-lag-slo-target",
+		viperutil.Options[float64]{
+			FlagName: "replication-lag-slo-target",
+			Default:  0.99,
+			Dynamic:  true,
+		},
+	)
+
+	replicationLagSLOWindow = viperutil.Configure(
+		"replication-lag-slo-window",
+		viperutil.Options[time.Duration]{
+			FlagName: "replication-lag-slo-window",
+			Default:  24 * time.Hour,
+			Dynamic:  true,
+		},
+	)
+
 	enablePrimaryDiskStalledRecovery = viperutil.Configure(
 		"enable-primary-disk-stalled-recovery",
 		viperutil.Options[bool]{
@@ -209,6 +245,15 @@ var (
 			Dynamic:  true,
 		},
 	)
+
+	maintenanceWindowRecoveries = viperutil.Configure(
+		"maintenance-window-recoveries",
+		viperutil.Options[[]string]{
+			FlagName: "maintenance-window-recoveries",
+			Default:  nil,
+			Dynamic:  true,
+		},
+	)
 )
 
 func init() {
@@ -235,7 +280,12 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.Duration("recovery-poll-duration", recoveryPollDuration.Default(), "Timer duration on which VTOrc polls its database to run a recovery")
 	fs.Bool("allow-emergency-reparent", ersEnabled.Default(), "Whether VTOrc should be allowed to run emergency reparent operation when it detects a dead primary")
 	fs.Bool("change-tablets-with-errant-gtid-to-drained", convertTabletsWithErrantGTIDs.Default(), "Whether VTOrc should be changing the type of tablets with errant GTIDs to DRAINED")
+	fs.Bool("inject-empty-transactions-for-errant-gtids", injectEmptyTransactionsForErrantGTIDs.Default(), "Whether VTOrc should repair errant GTIDs of replicas by injecting empty transactions for them on the primary, instead of changing the type of the replicas to DRAINED. The changes of the errant transactions stay on the replicas")
+	fs.Duration("replication-lag-slo-threshold", replicationLagSLOThreshold.Default(), "Replication lag which the replicas of a shard should stay under to meet the replication lag SLO")
+	fs.Float64("replication-lag-slo-target", replicationLagSLOTarget.Default(), "Fraction of the replication lag samples of the replicas of a shard which should be under --replication-lag-slo-threshold to meet the replication lag SLO")
+	fs.Duration("replication-lag-slo-window", replicationLagSLOWindow.Default(), "Duration over which the replication lag SLO attainment of a shard is computed")
 	fs.Bool("enable-primary-disk-stalled-recovery", enablePrimaryDiskStalledRecovery.Default(), "Whether VTOrc should detect a stalled disk on the primary and failover")
+	fs.StringSlice("maintenance-window-recoveries", maintenanceWindowRecoveries.Default(), "Recoveries which VTOrc only runs while a maintenance window is open for the keyspace, e.g. FixReplica,RecoverErrantGTIDDetected. Recoveries of a dead or missing primary are never held")
 
 	viperutil.BindFlags(fs,
 		instancePollTime,
@@ -256,7 +306,12 @@ func registerFlags(fs *pflag.FlagSet) {
 		recoveryPollDuration,
 		ersEnabled,
 		convertTabletsWithErrantGTIDs,
+		injectEmptyTransactionsForErrantGTIDs,
+		replicationLagSLOThreshold,
+		replicationLagSLOTarget,
+		replicationLagSLOWindow,
 		enablePrimaryDiskStalledRecovery,
+		maintenanceWindowRecoveries,
 	)
 }
 
@@ -390,11 +445,51 @@ func SetConvertTabletWithErrantGTIDs(val bool) {
 	convertTabletsWithErrantGTIDs.Set(val)
 }
 
+// InjectEmptyTransactionsForErrantGTIDs reports whether VTOrc is allowed to repair errant GTIDs by injecting empty transactions for them on the primary.
+func InjectEmptyTransactionsForErrantGTIDs() bool {
+	return injectEmptyTransactionsForErrantGTIDs.Get()
+}
+
+// SetInjectEmptyTransactionsForErrantGTIDs sets the value for the injectEmptyTransactionsForErrantGTIDs variable. This should only be used from tests.
+func SetInjectEmptyTransactionsForErrantGTIDs(val bool) {
+	injectEmptyTransactionsForErrantGTIDs.Set(val)
+}
+
+// GetReplicationLagSLOThreshold is a getter function.
+func GetReplicationLagSLOThreshold() time.Duration {
+	return replicationLagSLOThreshold.Get()
+}
+
+// GetReplicationLagSLOTarget is a getter function.
+func GetReplicationLagSLOTarget() float64 {
+	return replicationLagSLOTarget.Get()
+}
+
+// GetReplicationLagSLOWindow is a getter function.
+func GetReplicationLagSLOWindow() time.Duration {
+	return replicationLagSLOWindow.Get()
+}
+
+// SetReplicationLagSLOWindow sets the value for the replicationLagSLOWindow variable. This should only be used from tests.
+func SetReplicationLagSLOWindow(window time.Duration) {
+	replicationLagSLOWindow.Set(window)
+}
+
 // GetStalledDiskPrimaryRecovery reports whether VTOrc is allowed to check for and recovery stalled disk problems.
 func GetStalledDiskPrimaryRecovery() bool {
 	return enablePrimaryDiskStalledRecovery.Get()
 }
 
+// GetMaintenanceWindowRecoveries returns the recoveries which are held outside of maintenance windows.
+func GetMaintenanceWindowRecoveries() []string {
+	return maintenanceWindowRecoveries.Get()
+}
+
+// SetMaintenanceWindowRecoveries sets the value for the maintenanceWindowRecoveries variable. This should only be used from tests.
+func SetMaintenanceWindowRecoveries(recoveries []string) {
+	maintenanceWindowRecoveries.Set(recoveries)
+}
+
 // MarkConfigurationLoaded is called once configuration has first been loaded.
 // Listeners on ConfigurationLoaded will get a notification
 func MarkConfigurationLoaded() {
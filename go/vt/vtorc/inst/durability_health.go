@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/external/golib/sqlutils"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
+	"vitess.io/vitess/go/vt/vtorc/db"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// DurabilityStatus is the health of the semi-sync ack topology of a shard.
+type DurabilityStatus string
+
+const (
+	// DurabilityHealthy means the ack topology matches the durability policy,
+	// with at least one potential acker to spare.
+	DurabilityHealthy DurabilityStatus = "Healthy"
+	// DurabilityDegraded means the writes are acknowledged as the durability
+	// policy requires, but some settings do not match it, or losing one more
+	// acker would block the writes.
+	DurabilityDegraded DurabilityStatus = "Degraded"
+	// DurabilityCritical means the writes are blocked, or are not acknowledged
+	// as the durability policy requires.
+	DurabilityCritical DurabilityStatus = "Critical"
+)
+
+// durabilityStatusValues are the values of the ShardDurabilityStatus metric.
+var durabilityStatusValues = map[DurabilityStatus]int64{
+	DurabilityHealthy:  0,
+	DurabilityDegraded: 1,
+	DurabilityCritical: 2,
+}
+
+func init() {
+	stats.NewGaugesFuncWithMultiLabels("ShardDurabilityStatus", "Health of the semi-sync ack topology of a shard: 0 for healthy, 1 for degraded and 2 for critical", []string{"Keyspace", "Shard"}, func() map[string]int64 {
+		healths, err := ReadShardDurabilityHealth("", "")
+		if err != nil {
+			return nil
+		}
+		values := make(map[string]int64, len(healths))
+		for _, health := range healths {
+			values[health.Keyspace+"."+health.Shard] = durabilityStatusValues[health.Status]
+		}
+		return values
+	})
+}
+
+// ShardDurabilityHealth is the state of the semi-sync ack topology of a shard,
+// compared to what its durability policy requires.
+type ShardDurabilityHealth struct {
+	Keyspace         string
+	Shard            string
+	DurabilityPolicy string
+	PrimaryAlias     string
+	// RequiredAckers is the number of semi-sync acks the durability policy
+	// requires for the writes of the primary.
+	RequiredAckers int
+	// PotentialAckers are the replicas which replicate from the primary, may
+	// send semi-sync acks according to the durability policy, and have
+	// semi-sync enabled.
+	PotentialAckers []string
+	// MisconfiguredReplicas are the replicas whose semi-sync setting does
+	// not match the durability policy. VTOrc repairs them.
+	MisconfiguredReplicas      []string
+	PrimarySemiSyncEnabled     bool
+	PrimaryWaitForReplicaCount uint
+	PrimarySemiSyncClients     uint
+	PrimarySemiSyncBlocked     bool
+	Status                     DurabilityStatus
+	Problems                   []string
+}
+
+// tabletInstance is a tablet with the instance VTOrc last read from it. The
+// instance is nil if VTOrc could not read it yet.
+type tabletInstance struct {
+	tablet   *topodatapb.Tablet
+	instance *Instance
+}
+
+// computeShardDurabilityHealth compares the semi-sync settings of the primary
+// and the replicas of a shard with its durability policy.
+func computeShardDurabilityHealth(durability policy.Durabler, primary tabletInstance, replicas []tabletInstance) *ShardDurabilityHealth {
+	health := &ShardDurabilityHealth{
+		Keyspace:       primary.tablet.Keyspace,
+		Shard:          primary.tablet.Shard,
+		PrimaryAlias:   topoproto.TabletAliasString(primary.tablet.Alias),
+		RequiredAckers: policy.SemiSyncAckers(durability, primary.tablet),
+		Status:         DurabilityHealthy,
+	}
+	problem := func(status DurabilityStatus, format string, args ...any) {
+		health.Problems = append(health.Problems, fmt.Sprintf(format, args...))
+		if durabilityStatusValues[status] > durabilityStatusValues[health.Status] {
+			health.Status = status
+		}
+	}
+
+	if primary.instance == nil {
+		problem(DurabilityCritical, "primary %v has not been read yet", health.PrimaryAlias)
+		return health
+	}
+	health.PrimarySemiSyncEnabled = primary.instance.SemiSyncPrimaryEnabled
+	health.PrimaryWaitForReplicaCount = primary.instance.SemiSyncPrimaryWaitForReplicaCount
+	health.PrimarySemiSyncClients = primary.instance.SemiSyncPrimaryClients
+	health.PrimarySemiSyncBlocked = primary.instance.SemiSyncBlocked
+
+	for _, replica := range replicas {
+		alias := topoproto.TabletAliasString(replica.tablet.Alias)
+		if replica.instance == nil {
+			continue
+		}
+		shouldAck := policy.IsReplicaSemiSync(durability, primary.tablet, replica.tablet)
+		if shouldAck != replica.instance.SemiSyncReplicaEnabled {
+			health.MisconfiguredReplicas = append(health.MisconfiguredReplicas, alias)
+		}
+		replicating := replica.instance.ReplicationIOThreadRuning && replica.instance.ReplicationSQLThreadRuning &&
+			replica.instance.SourceHost == primary.instance.Hostname && replica.instance.SourcePort == primary.instance.Port
+		if shouldAck && replica.instance.SemiSyncReplicaEnabled && replicating {
+			health.PotentialAckers = append(health.PotentialAckers, alias)
+		}
+	}
+	sort.Strings(health.PotentialAckers)
+	sort.Strings(health.MisconfiguredReplicas)
+
+	if health.RequiredAckers == 0 {
+		if health.PrimarySemiSyncEnabled {
+			problem(DurabilityDegraded, "semi-sync is enabled on primary %v, but the durability policy does not require it", health.PrimaryAlias)
+		}
+	} else {
+		switch {
+		case !health.PrimarySemiSyncEnabled:
+			problem(DurabilityCritical, "semi-sync is not enabled on primary %v", health.PrimaryAlias)
+		case health.PrimaryWaitForReplicaCount < uint(health.RequiredAckers):
+			problem(DurabilityCritical, "primary %v waits for %d semi-sync acks, but the durability policy requires %d", health.PrimaryAlias, health.PrimaryWaitForReplicaCount, health.RequiredAckers)
+		}
+		switch {
+		case health.PrimarySemiSyncBlocked:
+			problem(DurabilityCritical, "the writes of primary %v are blocked waiting for semi-sync acks", health.PrimaryAlias)
+		case len(health.PotentialAckers) < health.RequiredAckers:
+			problem(DurabilityCritical, "%d replicas can send semi-sync acks, but the durability policy requires %d", len(health.PotentialAckers), health.RequiredAckers)
+		case len(health.PotentialAckers) == health.RequiredAckers:
+			problem(DurabilityDegraded, "%d replicas can send semi-sync acks: the writes block if one of them fails", len(health.PotentialAckers))
+		}
+	}
+	if len(health.MisconfiguredReplicas) > 0 {
+		problem(DurabilityDegraded, "the semi-sync setting of replicas %v does not match the durability policy", health.MisconfiguredReplicas)
+	}
+	return health
+}
+
+// ReadShardDurabilityHealth returns the durability health of each shard with
+// a primary, filtered by keyspace and shard if they are not empty.
+func ReadShardDurabilityHealth(keyspace, shard string) ([]*ShardDurabilityHealth, error) {
+	query := `SELECT
+		info
+	FROM
+		vitess_tablet
+	WHERE
+		? IN ('', keyspace)
+		AND ? IN ('', shard)
+	`
+	shards := make(map[string][]*topodatapb.Tablet)
+	opts := prototext.UnmarshalOptions{DiscardUnknown: true}
+	err := db.QueryVTOrc(query, sqlutils.Args(keyspace, shard), func(row sqlutils.RowMap) error {
+		tablet := &topodatapb.Tablet{}
+		if err := opts.Unmarshal([]byte(row.GetString("info")), tablet); err != nil {
+			log.Errorf("could not read tablet %v: %v", row.GetString("info"), err)
+			return nil
+		}
+		key := topoproto.KeyspaceShardString(tablet.Keyspace, tablet.Shard)
+		shards[key] = append(shards[key], tablet)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := readInstancesByCondition(`? IN ('', keyspace) AND ? IN ('', shard)`, sqlutils.Args(keyspace, shard), "")
+	if err != nil {
+		return nil, err
+	}
+	instancesByAlias := make(map[string]*Instance, len(instances))
+	for _, instance := range instances {
+		// Tablets which were never read have no database_instance row.
+		if instance.ServerID != 0 {
+			instancesByAlias[instance.InstanceAlias] = instance
+		}
+	}
+
+	var healths []*ShardDurabilityHealth
+	for _, tablets := range shards {
+		var primary *tabletInstance
+		var replicas []tabletInstance
+		for _, tablet := range tablets {
+			ti := tabletInstance{tablet: tablet, instance: instancesByAlias[topoproto.TabletAliasString(tablet.Alias)]}
+			switch {
+			case tablet.Type == topodatapb.TabletType_PRIMARY:
+				primary = &ti
+			case topo.IsReplicaType(tablet.Type):
+				replicas = append(replicas, ti)
+			}
+		}
+		if primary == nil {
+			continue
+		}
+		ki, err := ReadKeyspace(primary.tablet.Keyspace)
+		if err != nil {
+			return nil, err
+		}
+		durability, err := policy.GetDurabilityPolicy(ki.DurabilityPolicy)
+		if err != nil {
+			return nil, err
+		}
+		health := computeShardDurabilityHealth(durability, *primary, replicas)
+		health.DurabilityPolicy = ki.DurabilityPolicy
+		healths = append(healths, health)
+	}
+	sort.Slice(healths, func(i, j int) bool {
+		if healths[i].Keyspace != healths[j].Keyspace {
+			return healths[i].Keyspace < healths[j].Keyspace
+		}
+		return healths[i].Shard < healths[j].Shard
+	})
+	return healths, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestComputeShardDurabilityHealth(t *testing.T) {
+	newTabletInstance := func(uid uint32, tabletType topodatapb.TabletType, semiSync bool) tabletInstance {
+		return tabletInstance{
+			tablet: &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+				Keyspace: "ks",
+				Shard:    "0",
+				Type:     tabletType,
+			},
+			instance: &Instance{
+				Hostname:                   "localhost",
+				Port:                       int(uid),
+				SourceHost:                 "localhost",
+				SourcePort:                 100,
+				ReplicationIOThreadRuning:  true,
+				ReplicationSQLThreadRuning: true,
+				SemiSyncReplicaEnabled:     semiSync,
+			},
+		}
+	}
+	newPrimary := func(semiSync bool, waitCount uint, blocked bool) tabletInstance {
+		primary := newTabletInstance(100, topodatapb.TabletType_PRIMARY, false)
+		primary.instance.SourceHost = ""
+		primary.instance.SourcePort = 0
+		primary.instance.SemiSyncPrimaryEnabled = semiSync
+		primary.instance.SemiSyncPrimaryWaitForReplicaCount = waitCount
+		primary.instance.SemiSyncBlocked = blocked
+		return primary
+	}
+	stopped := newTabletInstance(104, topodatapb.TabletType_REPLICA, true)
+	stopped.instance.ReplicationIOThreadRuning = false
+
+	semiSync, err := policy.GetDurabilityPolicy(policy.DurabilitySemiSync)
+	require.NoError(t, err)
+	none, err := policy.GetDurabilityPolicy(policy.DurabilityNone)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                      string
+		durability                policy.Durabler
+		primary                   tabletInstance
+		replicas                  []tabletInstance
+		wantStatus                DurabilityStatus
+		wantPotentialAckers       []string
+		wantMisconfiguredReplicas []string
+		wantProblems              []string
+	}{
+		{
+			name:       "healthy",
+			durability: semiSync,
+			primary:    newPrimary(true, 1, false),
+			replicas: []tabletInstance{
+				newTabletInstance(101, topodatapb.TabletType_REPLICA, true),
+				newTabletInstance(102, topodatapb.TabletType_REPLICA, true),
+				newTabletInstance(103, topodatapb.TabletType_RDONLY, false),
+			},
+			wantStatus:          DurabilityHealthy,
+			wantPotentialAckers: []string{"zone1-0000000101", "zone1-0000000102"},
+		}, {
+			name:       "no spare acker and misconfigured replica",
+			durability: semiSync,
+			primary:    newPrimary(true, 1, false),
+			replicas: []tabletInstance{
+				newTabletInstance(101, topodatapb.TabletType_REPLICA, true),
+				newTabletInstance(103, topodatapb.TabletType_RDONLY, true),
+				stopped,
+			},
+			wantStatus:                DurabilityDegraded,
+			wantPotentialAckers:       []string{"zone1-0000000101"},
+			wantMisconfiguredReplicas: []string{"zone1-0000000103"},
+			wantProblems: []string{
+				"1 replicas can send semi-sync acks: the writes block if one of them fails",
+				"the semi-sync setting of replicas [zone1-0000000103] does not match the durability policy",
+			},
+		}, {
+			name:       "blocked primary",
+			durability: semiSync,
+			primary:    newPrimary(true, 1, true),
+			replicas:   []tabletInstance{stopped},
+			wantStatus: DurabilityCritical,
+			wantProblems: []string{
+				"the writes of primary zone1-0000000100 are blocked waiting for semi-sync acks",
+			},
+		}, {
+			name:       "semi-sync not enabled on primary",
+			durability: semiSync,
+			primary:    newPrimary(false, 0, false),
+			replicas:   []tabletInstance{newTabletInstance(101, topodatapb.TabletType_REPLICA, false)},
+			wantStatus: DurabilityCritical,
+			wantProblems: []string{
+				"semi-sync is not enabled on primary zone1-0000000100",
+				"0 replicas can send semi-sync acks, but the durability policy requires 1",
+				"the semi-sync setting of replicas [zone1-0000000101] does not match the durability policy",
+			},
+			wantMisconfiguredReplicas: []string{"zone1-0000000101"},
+		}, {
+			name:       "semi-sync not required",
+			durability: none,
+			primary:    newPrimary(true, 1, false),
+			replicas:   []tabletInstance{newTabletInstance(101, topodatapb.TabletType_REPLICA, false)},
+			wantStatus: DurabilityDegraded,
+			wantProblems: []string{
+				"semi-sync is enabled on primary zone1-0000000100, but the durability policy does not require it",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := computeShardDurabilityHealth(tt.durability, tt.primary, tt.replicas)
+			assert.Equal(t, "ks", health.Keyspace)
+			assert.Equal(t, "zone1-0000000100", health.PrimaryAlias)
+			assert.Equal(t, tt.wantStatus, health.Status)
+			assert.Equal(t, tt.wantPotentialAckers, health.PotentialAckers)
+			assert.Equal(t, tt.wantMisconfiguredReplicas, health.MisconfiguredReplicas)
+			assert.Equal(t, tt.wantProblems, health.Problems)
+		})
+	}
+
+	health := computeShardDurabilityHealth(semiSync, tabletInstance{tablet: newPrimary(true, 1, false).tablet}, nil)
+	assert.Equal(t, DurabilityCritical, health.Status)
+	assert.Equal(t, []string{"primary zone1-0000000100 has not been read yet"}, health.Problems)
+}
@@ -38,6 +38,7 @@ import (
 	"vitess.io/vitess/go/tb"
 	"vitess.io/vitess/go/vt/external/golib/sqlutils"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtorc/collection"
 	"vitess.io/vitess/go/vt/vtorc/config"
@@ -384,6 +385,9 @@ Cleanup:
 		instance.IsLastCheckValid = true
 		instance.IsRecentlyChecked = true
 		instance.IsUpToDate = true
+		if topo.IsReplicaType(tablet.Type) {
+			recordReplicationLagSample(tablet.Keyspace, tablet.Shard, instance)
+		}
 		latency.Start("backend")
 		_ = WriteInstance(instance, instanceFound, err)
 		lastAttemptedCheckTimer.Stop()
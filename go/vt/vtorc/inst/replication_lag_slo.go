@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtorc/config"
+)
+
+// lagSLOBucketDuration is the time span of the samples aggregated in a bucket.
+const lagSLOBucketDuration = time.Minute
+
+// lagSLOHistogramBounds are the upper bounds, in seconds, of the buckets of the
+// replication lag histogram. Lags above the last bound go to an overflow bucket.
+var lagSLOHistogramBounds = []int64{0, 1, 2, 5, 10, 30, 60, 300, 900, 3600}
+
+var (
+	replicationLagSLOSamples  = stats.NewCountersWithMultiLabels("ReplicationLagSLOSamples", "Number of replication lag samples taken from the replicas of a shard", []string{"Keyspace", "Shard"})
+	replicationLagSLOBreaches = stats.NewCountersWithMultiLabels("ReplicationLagSLOBreaches", "Number of replication lag samples of the replicas of a shard above the replication lag SLO threshold, or with an unknown lag", []string{"Keyspace", "Shard"})
+
+	lagSLOTracker = newReplicationLagSLOTracker()
+)
+
+// lagSLOBucket aggregates the replication lag samples of the replicas of a
+// shard taken during lagSLOBucketDuration.
+type lagSLOBucket struct {
+	start time.Time
+	// histogram counts the samples with a known lag, by lagSLOHistogramBounds.
+	histogram []int64
+	// unknown counts the samples of replicas whose lag is unknown, e.g.
+	// because replication is stopped.
+	unknown int64
+	maxLag  int64
+}
+
+// replicationLagSLOTracker keeps the replication lag samples of the replicas
+// of each shard for the duration of the SLO window.
+type replicationLagSLOTracker struct {
+	mu sync.Mutex
+	// buckets are keyed by keyspace/shard, and sorted by start time.
+	buckets map[string][]*lagSLOBucket
+}
+
+func newReplicationLagSLOTracker() *replicationLagSLOTracker {
+	return &replicationLagSLOTracker{buckets: make(map[string][]*lagSLOBucket)}
+}
+
+// record adds a replication lag sample of a replica of the shard. A negative
+// lag means the lag is unknown.
+func (t *replicationLagSLOTracker) record(now time.Time, keyspace, shard string, lagSeconds int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := topoproto.KeyspaceShardString(keyspace, shard)
+	buckets := t.prune(key, now)
+	start := now.Truncate(lagSLOBucketDuration)
+	if len(buckets) == 0 || buckets[len(buckets)-1].start.Before(start) {
+		buckets = append(buckets, &lagSLOBucket{
+			start:     start,
+			histogram: make([]int64, len(lagSLOHistogramBounds)+1),
+		})
+	}
+	t.buckets[key] = buckets
+
+	bucket := buckets[len(buckets)-1]
+	if lagSeconds < 0 {
+		bucket.unknown++
+		return
+	}
+	bucket.histogram[sort.Search(len(lagSLOHistogramBounds), func(i int) bool {
+		return lagSLOHistogramBounds[i] >= lagSeconds
+	})]++
+	bucket.maxLag = max(bucket.maxLag, lagSeconds)
+}
+
+// prune drops the buckets of the shard which are older than the SLO window,
+// and returns the remaining ones.
+func (t *replicationLagSLOTracker) prune(key string, now time.Time) []*lagSLOBucket {
+	buckets := t.buckets[key]
+	cutoff := now.Add(-config.GetReplicationLagSLOWindow())
+	i := 0
+	for i < len(buckets) && !buckets[i].start.Add(lagSLOBucketDuration).After(cutoff) {
+		i++
+	}
+	return buckets[i:]
+}
+
+// ReplicationLagSLOReport is the replication lag SLO attainment of a shard
+// over the SLO window.
+type ReplicationLagSLOReport struct {
+	Keyspace string
+	Shard    string
+	// ThresholdSeconds is the replication lag the replicas should stay under,
+	// and Target is the fraction of the samples which should be under it.
+	ThresholdSeconds int64
+	Target           float64
+	WindowStart      time.Time
+	WindowEnd        time.Time
+	// Samples is the number of replication lag samples taken in the window,
+	// and UnknownLagSamples the number of them whose lag was unknown.
+	Samples           int64
+	UnknownLagSamples int64
+	// Attainment is the fraction of the samples under the threshold. Samples
+	// whose lag was unknown count as above it.
+	Attainment float64
+	Met        bool
+	// The percentiles are upper bounds: the lags are aggregated in buckets.
+	P50LagSeconds int64
+	P90LagSeconds int64
+	P99LagSeconds int64
+	MaxLagSeconds int64
+}
+
+// report computes the SLO report of each shard which has samples in the SLO
+// window, filtered by keyspace and shard if they are not empty.
+func (t *replicationLagSLOTracker) report(now time.Time, keyspace, shard string) []*ReplicationLagSLOReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	threshold := int64(config.GetReplicationLagSLOThreshold().Seconds())
+	target := config.GetReplicationLagSLOTarget()
+
+	var reports []*ReplicationLagSLOReport
+	for key := range t.buckets {
+		ks, sh, err := topoproto.ParseKeyspaceShard(key)
+		if err != nil || (keyspace != "" && ks != keyspace) || (shard != "" && sh != shard) {
+			continue
+		}
+		buckets := t.prune(key, now)
+		t.buckets[key] = buckets
+		if len(buckets) == 0 {
+			delete(t.buckets, key)
+			continue
+		}
+
+		report := &ReplicationLagSLOReport{
+			Keyspace:         ks,
+			Shard:            sh,
+			ThresholdSeconds: threshold,
+			Target:           target,
+			WindowStart:      buckets[0].start,
+			WindowEnd:        now,
+		}
+		histogram := make([]int64, len(lagSLOHistogramBounds)+1)
+		var withinThreshold int64
+		for _, bucket := range buckets {
+			report.UnknownLagSamples += bucket.unknown
+			report.Samples += bucket.unknown
+			report.MaxLagSeconds = max(report.MaxLagSeconds, bucket.maxLag)
+			for i, count := range bucket.histogram {
+				histogram[i] += count
+				report.Samples += count
+				if i < len(lagSLOHistogramBounds) && lagSLOHistogramBounds[i] <= threshold {
+					withinThreshold += count
+				}
+			}
+		}
+		if report.Samples > 0 {
+			report.Attainment = float64(withinThreshold) / float64(report.Samples)
+		}
+		report.Met = report.Attainment >= target
+		report.P50LagSeconds = lagPercentile(histogram, 0.5, report.MaxLagSeconds)
+		report.P90LagSeconds = lagPercentile(histogram, 0.9, report.MaxLagSeconds)
+		report.P99LagSeconds = lagPercentile(histogram, 0.99, report.MaxLagSeconds)
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Keyspace != reports[j].Keyspace {
+			return reports[i].Keyspace < reports[j].Keyspace
+		}
+		return reports[i].Shard < reports[j].Shard
+	})
+	return reports
+}
+
+// lagPercentile returns the upper bound of the histogram bucket of the
+// percentile, capped by the maximum lag seen.
+func lagPercentile(histogram []int64, percentile float64, maxLag int64) int64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := int64(percentile * float64(total))
+	var seen int64
+	for i, count := range histogram {
+		seen += count
+		if seen > rank && i < len(lagSLOHistogramBounds) {
+			return min(lagSLOHistogramBounds[i], maxLag)
+		}
+	}
+	return maxLag
+}
+
+// recordReplicationLagSample records the replication lag of a replica for the
+// replication lag SLO of its shard.
+func recordReplicationLagSample(keyspace, shard string, instance *Instance) {
+	lag := int64(-1)
+	if instance.ReplicationLagSeconds.Valid && instance.ReplicationSQLThreadRuning {
+		lag = instance.ReplicationLagSeconds.Int64
+	}
+	lagSLOTracker.record(time.Now(), keyspace, shard, lag)
+
+	labels := []string{keyspace, shard}
+	replicationLagSLOSamples.Add(labels, 1)
+	if lag < 0 || lag > int64(config.GetReplicationLagSLOThreshold().Seconds()) {
+		replicationLagSLOBreaches.Add(labels, 1)
+	}
+}
+
+// ReadReplicationLagSLOReports returns the replication lag SLO report of each
+// shard, filtered by keyspace and shard if they are not empty.
+func ReadReplicationLagSLOReports(keyspace, shard string) []*ReplicationLagSLOReport {
+	return lagSLOTracker.report(time.Now(), keyspace, shard)
+}
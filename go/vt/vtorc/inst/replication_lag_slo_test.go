@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+)
+
+func TestReplicationLagSLOReport(t *testing.T) {
+	oldWindow := config.GetReplicationLagSLOWindow()
+	config.SetReplicationLagSLOWindow(time.Hour)
+	defer config.SetReplicationLagSLOWindow(oldWindow)
+
+	tracker := newReplicationLagSLOTracker()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Samples older than the window are not part of the report.
+	tracker.record(now.Add(-2*time.Hour), "ks", "0", 3600)
+	for i := range 96 {
+		tracker.record(now.Add(-time.Duration(95-i)*time.Second), "ks", "0", int64(i%2))
+	}
+	tracker.record(now, "ks", "0", 4)
+	tracker.record(now, "ks", "0", 40)
+	tracker.record(now, "ks", "0", 20)
+	tracker.record(now, "ks", "0", -1)
+	tracker.record(now, "ks", "-80", 0)
+
+	reports := tracker.report(now, "ks", "0")
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, "ks", report.Keyspace)
+	assert.Equal(t, "0", report.Shard)
+	assert.EqualValues(t, 100, report.Samples)
+	assert.EqualValues(t, 1, report.UnknownLagSamples)
+	assert.InDelta(t, 0.96, report.Attainment, 0.0001)
+	assert.False(t, report.Met)
+	assert.EqualValues(t, 1, report.P50LagSeconds)
+	assert.EqualValues(t, 1, report.P90LagSeconds)
+	assert.EqualValues(t, 40, report.P99LagSeconds)
+	assert.EqualValues(t, 40, report.MaxLagSeconds)
+	assert.Equal(t, now.Add(-2*time.Minute), report.WindowStart)
+
+	reports = tracker.report(now, "ks", "")
+	require.Len(t, reports, 2)
+	assert.Equal(t, "-80", reports[0].Shard)
+	assert.True(t, reports[0].Met)
+
+	// Once all the samples of a shard are out of the window, it has no report.
+	assert.Empty(t, tracker.report(now.Add(2*time.Hour), "", ""))
+}
@@ -30,13 +30,16 @@ import (
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/external/golib/sqlutils"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vtorc/config"
 	"vitess.io/vitess/go/vt/vtorc/db"
 	"vitess.io/vitess/go/vt/vtorc/inst"
@@ -368,6 +371,30 @@ func changeTabletType(ctx context.Context, tablet *topodatapb.Tablet, tabletType
 	return tmc.ChangeType(tmcCtx, tablet, tabletType, semiSync)
 }
 
+// maxErrantGTIDsToInject is the maximum number of errant GTIDs of a replica that VTOrc
+// injects empty transactions for. Beyond it, the errant GTIDs have to be repaired by hand.
+const maxErrantGTIDsToInject = 1000
+
+// injectEmptyTransactions commits an empty transaction for each of the given GTIDs on the primary,
+// so that they are no longer errant on the replicas which have them.
+func injectEmptyTransactions(ctx context.Context, primary *topodatapb.Tablet, gtids string) error {
+	gtidSet, err := replication.ParseMysql56GTIDSet(gtids)
+	if err != nil {
+		return err
+	}
+	queries, err := reparentutil.EmptyTransactionQueries(gtidSet, maxErrantGTIDsToInject)
+	if err != nil {
+		return err
+	}
+	tmcCtx, tmcCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer tmcCancel()
+	_, err = tmc.ExecuteMultiFetchAsDba(tmcCtx, primary, false, &tabletmanagerdatapb.ExecuteMultiFetchAsDbaRequest{
+		Sql:     []byte(strings.Join(queries, ";")),
+		MaxRows: 1,
+	})
+	return err
+}
+
 // resetReplicationParameters resets the replication parameters on the given tablet.
 func resetReplicationParameters(ctx context.Context, tablet *topodatapb.Tablet) error {
 	tmcCtx, tmcCancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
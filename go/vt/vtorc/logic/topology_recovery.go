@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"sync/atomic"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/logutil"
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
@@ -361,7 +363,7 @@ func getCheckAndRecoverFunctionCode(analysisCode inst.AnalysisCode, tabletAlias
 		}
 		return recoverPrimaryTabletDeletedFunc
 	case inst.ErrantGTIDDetected:
-		if !config.ConvertTabletWithErrantGTIDs() {
+		if !config.ConvertTabletWithErrantGTIDs() && !config.InjectEmptyTransactionsForErrantGTIDs() {
 			log.Infof("VTOrc not configured to do anything on detecting errant GTIDs, skipping recovering %v", analysisCode)
 			return noRecoveryFunc
 		}
@@ -495,6 +497,25 @@ func isClusterWideRecovery(recoveryFunctionCode recoveryFunction) bool {
 	}
 }
 
+// isRecoveryHeldByMaintenanceWindow returns whether the given recovery has to wait for a maintenance window
+// of the keyspace to open. Only the recoveries listed in --maintenance-window-recoveries are ever held, and
+// cluster-wide recoveries never are, since a shard without a serving primary cannot wait.
+func isRecoveryHeldByMaintenanceWindow(recoveryFunctionCode recoveryFunction, keyspace string, now time.Time) (bool, error) {
+	if isClusterWideRecovery(recoveryFunctionCode) {
+		return false, nil
+	}
+	if !slices.Contains(config.GetMaintenanceWindowRecoveries(), getRecoverFunctionName(recoveryFunctionCode)) {
+		return false, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	inWindow, err := ts.InMaintenanceWindow(ctx, keyspace, now)
+	if err != nil {
+		return false, err
+	}
+	return !inWindow, nil
+}
+
 // analysisEntriesHaveSameRecovery tells whether the two analysis entries have the same recovery function or not
 func analysisEntriesHaveSameRecovery(prevAnalysis, newAnalysis *inst.ReplicationAnalysis) bool {
 	prevRecoveryFunctionCode := getCheckAndRecoverFunctionCode(prevAnalysis.Analysis, prevAnalysis.AnalyzedInstanceAlias)
@@ -551,6 +572,16 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.ReplicationAnalysis) (er
 		return err
 	}
 
+	// Check for recovery being held until a maintenance window opens
+	if held, err := isRecoveryHeldByMaintenanceWindow(checkAndRecoverFunctionCode, analysisEntry.AnalyzedKeyspace, time.Now()); err != nil {
+		logger.Errorf("Unable to determine if a maintenance window is open, still attempting to recover: %v", err)
+	} else if held {
+		logger.Infof("CheckAndRecover: Tablet: %+v: NOT Recovering host (outside of a maintenance window)",
+			analysisEntry.AnalyzedInstanceAlias)
+
+		return nil
+	}
+
 	// We lock the shard here and then refresh the tablets information
 	ctx, unlock, err := LockShard(context.Background(), analysisEntry.AnalyzedKeyspace, analysisEntry.AnalyzedShard,
 		getLockAction(analysisEntry.AnalyzedInstanceAlias, analysisEntry.Analysis),
@@ -876,7 +907,8 @@ func fixReplica(ctx context.Context, analysisEntry *inst.ReplicationAnalysis, lo
 	return true, topologyRecovery, err
 }
 
-// recoverErrantGTIDDetected changes the tablet type of a replica tablet that has errant GTIDs.
+// recoverErrantGTIDDetected changes the tablet type of a replica tablet that has errant GTIDs,
+// or injects empty transactions for the errant GTIDs on the primary.
 func recoverErrantGTIDDetected(ctx context.Context, analysisEntry *inst.ReplicationAnalysis, logger *log.PrefixedLogger) (recoveryAttempted bool, topologyRecovery *TopologyRecovery, err error) {
 	topologyRecovery, err = AttemptRecoveryRegistration(analysisEntry)
 	if topologyRecovery == nil {
@@ -903,6 +935,12 @@ func recoverErrantGTIDDetected(ctx context.Context, analysisEntry *inst.Replicat
 		return false, topologyRecovery, err
 	}
 
+	if config.InjectEmptyTransactionsForErrantGTIDs() {
+		_ = AuditTopologyRecovery(topologyRecovery, fmt.Sprintf("injecting empty transactions for errant GTIDs %v of %v on primary %v", analysisEntry.ErrantGTID, analysisEntry.AnalyzedInstanceAlias, topoproto.TabletAliasString(primaryTablet.Alias)))
+		err = injectEmptyTransactions(ctx, primaryTablet, analysisEntry.ErrantGTID)
+		return true, topologyRecovery, err
+	}
+
 	durabilityPolicy, err := inst.GetDurabilityPolicy(analyzedTablet.Keyspace)
 	if err != nil {
 		logger.Info("Could not read the durability policy for %v/%v", analyzedTablet.Keyspace, analyzedTablet.Shard)
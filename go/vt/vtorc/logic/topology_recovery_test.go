@@ -19,7 +19,9 @@ package logic
 import (
 	"context"
 	"testing"
+	"time"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/log"
 
 	"github.com/stretchr/testify/require"
@@ -215,11 +217,12 @@ func TestRecoveryRegistration(t *testing.T) {
 
 func TestGetCheckAndRecoverFunctionCode(t *testing.T) {
 	tests := []struct {
-		name                         string
-		ersEnabled                   bool
-		convertTabletWithErrantGTIDs bool
-		analysisCode                 inst.AnalysisCode
-		wantRecoveryFunction         recoveryFunction
+		name                                  string
+		ersEnabled                            bool
+		convertTabletWithErrantGTIDs          bool
+		injectEmptyTransactionsForErrantGTIDs bool
+		analysisCode                          inst.AnalysisCode
+		wantRecoveryFunction                  recoveryFunction
 	}{
 		{
 			name:                 "DeadPrimary with ERS enabled",
@@ -293,6 +296,12 @@ func TestGetCheckAndRecoverFunctionCode(t *testing.T) {
 			convertTabletWithErrantGTIDs: false,
 			analysisCode:                 inst.ErrantGTIDDetected,
 			wantRecoveryFunction:         noRecoveryFunc,
+		}, {
+			name:                                  "ErrantGTIDDetected with --inject-empty-transactions-for-errant-gtids",
+			ersEnabled:                            false,
+			injectEmptyTransactionsForErrantGTIDs: true,
+			analysisCode:                          inst.ErrantGTIDDetected,
+			wantRecoveryFunction:                  recoverErrantGTIDDetectedFunc,
 		},
 	}
 
@@ -306,8 +315,77 @@ func TestGetCheckAndRecoverFunctionCode(t *testing.T) {
 			config.SetConvertTabletWithErrantGTIDs(tt.convertTabletWithErrantGTIDs)
 			defer config.SetConvertTabletWithErrantGTIDs(convertErrantVal)
 
+			injectErrantVal := config.InjectEmptyTransactionsForErrantGTIDs()
+			config.SetInjectEmptyTransactionsForErrantGTIDs(tt.injectEmptyTransactionsForErrantGTIDs)
+			defer config.SetInjectEmptyTransactionsForErrantGTIDs(injectErrantVal)
+
 			gotFunc := getCheckAndRecoverFunctionCode(tt.analysisCode, "")
 			require.EqualValues(t, tt.wantRecoveryFunction, gotFunc)
 		})
 	}
 }
+
+func TestIsRecoveryHeldByMaintenanceWindow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	oldTs := ts
+	defer func() {
+		ts = oldTs
+	}()
+	ts = memorytopo.NewServer(ctx, "zone1")
+
+	prevVal := config.GetMaintenanceWindowRecoveries()
+	config.SetMaintenanceWindowRecoveries([]string{FixReplicaRecoveryName, RecoverDeadPrimaryRecoveryName})
+	defer config.SetMaintenanceWindowRecoveries(prevVal)
+
+	// The window opens at 02:00 UTC every day, for two hours.
+	err := ts.SaveMaintenanceWindows(ctx, &topodatapb.MaintenanceWindows{
+		Windows: []*topodatapb.MaintenanceWindow{{
+			Name:     "nightly",
+			Schedule: "0 2 * * *",
+			Duration: protoutil.DurationToProto(2 * time.Hour),
+		}},
+	})
+	require.NoError(t, err)
+
+	inWindow := time.Date(2025, time.March, 3, 3, 0, 0, 0, time.UTC)
+	outsideWindow := time.Date(2025, time.March, 3, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		recoveryFunctionCode recoveryFunction
+		now                  time.Time
+		wantHeld             bool
+	}{
+		{
+			name:                 "listed recovery outside of the window",
+			recoveryFunctionCode: fixReplicaFunc,
+			now:                  outsideWindow,
+			wantHeld:             true,
+		}, {
+			name:                 "listed recovery inside the window",
+			recoveryFunctionCode: fixReplicaFunc,
+			now:                  inWindow,
+			wantHeld:             false,
+		}, {
+			name:                 "recovery not listed",
+			recoveryFunctionCode: fixPrimaryFunc,
+			now:                  outsideWindow,
+			wantHeld:             false,
+		}, {
+			name:                 "cluster-wide recoveries are never held",
+			recoveryFunctionCode: recoverDeadPrimaryFunc,
+			now:                  outsideWindow,
+			wantHeld:             false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			held, err := isRecoveryHeldByMaintenanceWindow(tt.recoveryFunctionCode, "ks", tt.now)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHeld, held)
+		})
+	}
+}
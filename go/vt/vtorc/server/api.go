@@ -43,6 +43,8 @@ type vtorcAPI struct{}
 const (
 	problemsAPI                   = "/api/problems"
 	errantGTIDsAPI                = "/api/errant-gtids"
+	replicationLagSLOAPI          = "/api/replication-lag-slo"
+	durabilityHealthAPI           = "/api/durability-health"
 	disableGlobalRecoveriesAPI    = "/api/disable-global-recoveries"
 	enableGlobalRecoveriesAPI     = "/api/enable-global-recoveries"
 	replicationAnalysisAPI        = "/api/replication-analysis"
@@ -60,6 +62,8 @@ var (
 	vtorcAPIPaths = []string{
 		problemsAPI,
 		errantGTIDsAPI,
+		replicationLagSLOAPI,
+		durabilityHealthAPI,
 		disableGlobalRecoveriesAPI,
 		enableGlobalRecoveriesAPI,
 		replicationAnalysisAPI,
@@ -89,6 +93,10 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 		problemsAPIHandler(response, request)
 	case errantGTIDsAPI:
 		errantGTIDsAPIHandler(response, request)
+	case replicationLagSLOAPI:
+		replicationLagSLOAPIHandler(response, request)
+	case durabilityHealthAPI:
+		durabilityHealthAPIHandler(response, request)
 	case replicationAnalysisAPI:
 		replicationAnalysisAPIHandler(response, request)
 	case databaseStateAPI:
@@ -107,7 +115,7 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 // getACLPermissionLevelForAPI returns the acl permission level that is required to run a given API
 func getACLPermissionLevelForAPI(apiEndpoint string) string {
 	switch apiEndpoint {
-	case problemsAPI, errantGTIDsAPI:
+	case problemsAPI, errantGTIDsAPI, replicationLagSLOAPI, durabilityHealthAPI:
 		return acl.MONITORING
 	case disableGlobalRecoveriesAPI, enableGlobalRecoveriesAPI:
 		return acl.ADMIN
@@ -175,6 +183,36 @@ func errantGTIDsAPIHandler(response http.ResponseWriter, request *http.Request)
 	returnAsJSON(response, http.StatusOK, instances)
 }
 
+// replicationLagSLOAPIHandler is the handler for the replicationLagSLOAPI endpoint
+func replicationLagSLOAPIHandler(response http.ResponseWriter, request *http.Request) {
+	// This api also supports filtering by shard and keyspace provided.
+	shard := request.URL.Query().Get("shard")
+	keyspace := request.URL.Query().Get("keyspace")
+	if shard != "" && keyspace == "" {
+		http.Error(response, shardWithoutKeyspaceFilteringErrorStr, http.StatusBadRequest)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, inst.ReadReplicationLagSLOReports(keyspace, shard))
+}
+
+// durabilityHealthAPIHandler is the handler for the durabilityHealthAPI endpoint
+func durabilityHealthAPIHandler(response http.ResponseWriter, request *http.Request) {
+	// This api also supports filtering by shard and keyspace provided.
+	shard := request.URL.Query().Get("shard")
+	keyspace := request.URL.Query().Get("keyspace")
+	if shard != "" && keyspace == "" {
+		http.Error(response, shardWithoutKeyspaceFilteringErrorStr, http.StatusBadRequest)
+		return
+	}
+
+	healths, err := inst.ReadShardDurabilityHealth(keyspace, shard)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, healths)
+}
+
 // databaseStateAPIHandler is the handler for the databaseStateAPI endpoint
 func databaseStateAPIHandler(response http.ResponseWriter) {
 	ds, err := inst.GetDatabaseState()
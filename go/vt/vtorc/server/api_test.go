@@ -19,6 +19,12 @@ func TestGetACLPermissionLevelForAPI(t *testing.T) {
 		}, {
 			apiEndpoint: errantGTIDsAPI,
 			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: replicationLagSLOAPI,
+			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: durabilityHealthAPI,
+			want:        acl.MONITORING,
 		}, {
 			apiEndpoint: disableGlobalRecoveriesAPI,
 			want:        acl.ADMIN,
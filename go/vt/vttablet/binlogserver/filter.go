@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"strings"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// eventFilter filters the binlog events of mysqld, so that a replica only
+// sees the changes to the served databases. Since the replica positions
+// itself with the GTIDs of the source, the GTID of every transaction is
+// sent: a transaction which does not change any of the databases becomes
+// an empty transaction, as MySQL does for transactions filtered out on a
+// replica.
+//
+// Row events are filtered by the database of their table. Statements are
+// filtered by their default database, like --binlog-do-db does in MySQL.
+type eventFilter struct {
+	databases map[string]bool
+
+	format mysql.BinlogFormat
+	// excluded holds the IDs of the tables of the current TABLE_MAP events
+	// which are not in the served databases.
+	excluded map[uint64]bool
+}
+
+func newEventFilter(databases map[string]bool) *eventFilter {
+	return &eventFilter{
+		databases: databases,
+		excluded:  make(map[uint64]bool),
+	}
+}
+
+// filter returns the events to send to the replica in place of ev.
+func (f *eventFilter) filter(ev mysql.BinlogEvent) ([]mysql.BinlogEvent, error) {
+	if !ev.IsValid() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid binlog event: %v", ev.Bytes())
+	}
+	if ev.IsFormatDescription() {
+		format, err := ev.Format()
+		if err != nil {
+			return nil, err
+		}
+		f.format = format
+		return []mysql.BinlogEvent{ev}, nil
+	}
+	// The fake ROTATE event which starts the stream comes before the
+	// FORMAT_DESCRIPTION event.
+	if f.format.IsZero() {
+		return []mysql.BinlogEvent{ev}, nil
+	}
+
+	switch {
+	case ev.IsTransactionPayload():
+		// Compressed transactions would have to be decompressed, filtered
+		// and compressed again.
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "binlog transaction compression is not supported by the binlog server")
+	case ev.IsTableMap():
+		stripped, _, err := ev.StripChecksum(f.format)
+		if err != nil {
+			return nil, err
+		}
+		tm, err := stripped.TableMap(f.format)
+		if err != nil {
+			return nil, err
+		}
+		tableID := stripped.TableID(f.format)
+		if !f.databases[tm.Database] {
+			f.excluded[tableID] = true
+			return nil, nil
+		}
+		delete(f.excluded, tableID)
+	case ev.IsWriteRows() || ev.IsUpdateRows() || ev.IsPartialUpdateRows() || ev.IsDeleteRows():
+		// Note the last ROWS event of a statement ends it. A statement
+		// which changes tables of served and non served databases may thus
+		// not be ended on the replica, until its next statement.
+		stripped, _, err := ev.StripChecksum(f.format)
+		if err != nil {
+			return nil, err
+		}
+		if f.excluded[stripped.TableID(f.format)] {
+			return nil, nil
+		}
+	case ev.IsQuery():
+		stripped, _, err := ev.StripChecksum(f.format)
+		if err != nil {
+			return nil, err
+		}
+		q, err := stripped.Query(f.format)
+		if err != nil {
+			return nil, err
+		}
+		if isTransactionStatement(q.SQL) || f.databases[q.Database] {
+			break
+		}
+		// The statement is a transaction on its own, e.g. a DDL: it is
+		// replaced by an empty transaction.
+		return f.emptyTransaction(ev), nil
+	}
+	return []mysql.BinlogEvent{ev}, nil
+}
+
+// emptyTransaction returns the events of an empty transaction, which take
+// the place of ev in the stream.
+func (f *eventFilter) emptyTransaction(ev mysql.BinlogEvent) []mysql.BinlogEvent {
+	s := &mysql.FakeBinlogStream{
+		ServerID:    ev.ServerID(),
+		LogPosition: uint32(ev.NextPosition()),
+		Timestamp:   ev.Timestamp(),
+	}
+	return []mysql.BinlogEvent{
+		mysql.NewQueryEvent(f.format, s, mysql.Query{SQL: "BEGIN"}),
+		mysql.NewQueryEvent(f.format, s, mysql.Query{SQL: "COMMIT"}),
+	}
+}
+
+// isTransactionStatement returns true if sql is one of the statements
+// which delimit the transactions in the binlogs.
+func isTransactionStatement(sql string) bool {
+	sql = strings.ToUpper(strings.TrimSpace(sql))
+	switch sql {
+	case "BEGIN", "COMMIT", "ROLLBACK":
+		return true
+	}
+	return strings.HasPrefix(sql, "XA ")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/binlog"
+)
+
+func TestEventFilter(t *testing.T) {
+	f := mysql.NewMySQL56BinlogFormat()
+	s := mysql.NewFakeBinlogStream()
+
+	tableMap := func(db string) *mysql.TableMap {
+		tm := &mysql.TableMap{
+			Database:  db,
+			Name:      "t1",
+			Types:     []byte{binlog.TypeLong},
+			CanBeNull: mysql.NewServerBitmap(1),
+			Metadata:  []uint16{0},
+		}
+		return tm
+	}
+	rows := mysql.Rows{
+		DataColumns: mysql.NewServerBitmap(1),
+		Rows: []mysql.Row{{
+			NullColumns: mysql.NewServerBitmap(1),
+			Data:        []byte{0x01, 0x00, 0x00, 0x00},
+		}},
+	}
+	rows.DataColumns.Set(0, true)
+
+	rotate := mysql.NewRotateEvent(f, s, 0, "")
+	format := mysql.NewFormatDescriptionEvent(f, s)
+	begin := mysql.NewQueryEvent(f, s, mysql.Query{Database: "other", SQL: "BEGIN"})
+	servedTableMap := mysql.NewTableMapEvent(f, s, 1, tableMap("vt_ks"))
+	servedRows := mysql.NewWriteRowsEvent(f, s, 1, rows)
+	otherTableMap := mysql.NewTableMapEvent(f, s, 2, tableMap("_vt"))
+	otherRows := mysql.NewUpdateRowsEvent(f, s, 2, rows)
+	servedDDL := mysql.NewQueryEvent(f, s, mysql.Query{Database: "vt_ks", SQL: "create table t2(id int)"})
+	otherDDL := mysql.NewQueryEvent(f, s, mysql.Query{Database: "_vt", SQL: "create table t2(id int)"})
+	xid := mysql.NewXIDEvent(f, s)
+
+	filter := newEventFilter(map[string]bool{"vt_ks": true})
+	for _, ev := range []mysql.BinlogEvent{rotate, format, begin, servedTableMap, servedRows, xid, servedDDL} {
+		sent, err := filter.filter(ev)
+		require.NoError(t, err)
+		assert.Equal(t, []mysql.BinlogEvent{ev}, sent)
+	}
+	for _, ev := range []mysql.BinlogEvent{otherTableMap, otherRows} {
+		sent, err := filter.filter(ev)
+		require.NoError(t, err)
+		assert.Empty(t, sent)
+	}
+
+	// A table ID is reused by another table.
+	sent, err := filter.filter(mysql.NewTableMapEvent(f, s, 2, tableMap("vt_ks")))
+	require.NoError(t, err)
+	assert.Len(t, sent, 1)
+	sent, err = filter.filter(otherRows)
+	require.NoError(t, err)
+	assert.Equal(t, []mysql.BinlogEvent{otherRows}, sent)
+
+	// A DDL of another database is replaced by an empty transaction.
+	sent, err = filter.filter(otherDDL)
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	var statements []string
+	for _, ev := range sent {
+		require.True(t, ev.IsQuery())
+		assert.Equal(t, otherDDL.NextPosition(), ev.NextPosition())
+		ev, _, err := ev.StripChecksum(f)
+		require.NoError(t, err)
+		q, err := ev.Query(f)
+		require.NoError(t, err)
+		statements = append(statements, q.SQL)
+	}
+	assert.Equal(t, []string{"BEGIN", "COMMIT"}, statements)
+
+	_, err = filter.filter(mysql.NewInvalidEvent())
+	assert.Error(t, err)
+}
+
+func TestIsTransactionStatement(t *testing.T) {
+	for _, sql := range []string{"BEGIN", "commit", " ROLLBACK ", "XA START X'31',X'',1", "XA COMMIT X'31',X'',1"} {
+		assert.True(t, isTransactionStatement(sql), sql)
+	}
+	for _, sql := range []string{"create table t1(id int)", "XACT", "drop table _vt.t1"} {
+		assert.False(t, isTransactionStatement(sql), sql)
+	}
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package binlogserver serves the MySQL replication protocol on behalf of
+// the mysqld of a tablet, so that MySQL replicas outside of Vitess can
+// follow the databases of a shard without any access to its mysqld.
+//
+// Every replica gets its own replication connection to mysqld, whose
+// events are filtered and relayed to the replica. Only GTID
+// auto-positioning (SOURCE_AUTO_POSITION=1) is supported.
+package binlogserver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/binlog"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var (
+	binlogServerPort        = -1
+	binlogServerBindAddress string
+	binlogServerAuthFile    string
+	binlogServerDatabases   []string
+
+	replicaCount = stats.NewGauge("BinlogServerReplicas", "Number of replicas streaming from the binlog server")
+	eventCount   = stats.NewCountersWithSingleLabel("BinlogServerEvents", "Binlog events read by the binlog server, by whether they were sent or filtered out", "Result")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&binlogServerPort, "binlog-server-port", binlogServerPort, "If set, serve the MySQL replication protocol on this port, so that MySQL replicas can replicate the databases of the tablet. Replicas must use GTID auto-positioning.")
+	fs.StringVar(&binlogServerBindAddress, "binlog-server-bind-address", binlogServerBindAddress, "Binds on this address when listening to binlog server connections. Empty means listen on all addresses.")
+	fs.StringVar(&binlogServerAuthFile, "binlog-server-auth-static-file", binlogServerAuthFile, "JSON file of the credentials of the replicas of the binlog server, in the format of --mysql-auth-server-static-file. Required with --binlog-server-port.")
+	fs.StringSliceVar(&binlogServerDatabases, "binlog-server-databases", binlogServerDatabases, "The databases whose changes are sent by the binlog server. Defaults to the database of the tablet.")
+}
+
+func init() {
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
+// Server is a binlog server. It implements mysql.Handler.
+type Server struct {
+	mysql.UnimplementedHandler
+
+	env       *vtenv.Environment
+	cp        dbconfigs.Connector
+	databases map[string]bool
+
+	listener *mysql.Listener
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// Init starts the binlog server of the tablet if --binlog-server-port is
+// set, and returns it. It must be called once the name of the database of
+// the tablet is known.
+func Init(env *vtenv.Environment, dbcfgs *dbconfigs.DBConfigs) *Server {
+	if binlogServerPort < 0 {
+		return nil
+	}
+	if binlogServerAuthFile == "" {
+		log.Exitf("--binlog-server-auth-static-file is required with --binlog-server-port")
+	}
+
+	databases := binlogServerDatabases
+	if len(databases) == 0 {
+		databases = []string{dbcfgs.DBName}
+	}
+	s := NewServer(env, dbcfgs.FilteredWithDB(), databases)
+	authServer := mysql.NewAuthServerStatic(binlogServerAuthFile, "", 0)
+	address := net.JoinHostPort(binlogServerBindAddress, strconv.Itoa(binlogServerPort))
+	if err := s.Listen("tcp", address, authServer); err != nil {
+		log.Exitf("binlog server failed to listen on %v: %v", address, err)
+	}
+	log.Infof("Binlog server listening on %v, for databases %v", s.listener.Addr(), databases)
+	return s
+}
+
+// NewServer returns a binlog server which streams the changes to the given
+// databases from the mysqld of cp.
+func NewServer(env *vtenv.Environment, cp dbconfigs.Connector, databases []string) *Server {
+	s := &Server{
+		env:       env,
+		cp:        cp,
+		databases: make(map[string]bool, len(databases)),
+	}
+	for _, db := range databases {
+		s.databases[db] = true
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+// Listen starts accepting the connections of replicas.
+func (s *Server) Listen(protocol, address string, authServer mysql.AuthServer) error {
+	listener, err := mysql.NewListener(protocol, address, authServer, s, 0, 0, false, false, 0, 0)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	go listener.Accept()
+	return nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Close stops accepting connections, and ends the streams of the replicas.
+func (s *Server) Close() {
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.cancel()
+}
+
+// upstream returns the replication connection to mysqld of the replica
+// connection c, which is opened on first use.
+func (s *Server) upstream(c *mysql.Conn) (*binlog.BinlogConnection, error) {
+	if bc, ok := c.ClientData.(*binlog.BinlogConnection); ok {
+		return bc, nil
+	}
+	bc, err := binlog.NewBinlogConnection(s.cp)
+	if err != nil {
+		return nil, err
+	}
+	c.ClientData = bc
+	return bc, nil
+}
+
+// ConnectionClosed is part of the mysql.Handler interface.
+func (s *Server) ConnectionClosed(c *mysql.Conn) {
+	if bc, ok := c.ClientData.(*binlog.BinlogConnection); ok {
+		bc.Close()
+		c.ClientData = nil
+	}
+}
+
+// ComQuery is part of the mysql.Handler interface. A replica runs a few
+// queries before it starts streaming, to check the configuration of its
+// source and to set up the stream. These queries are run on the upstream
+// connection, and any other query is rejected.
+func (s *Server) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	stmt, err := s.env.Parser().Parse(query)
+	if err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.ERSyntaxError, sqlerror.SSClientError, "%v", err)
+	}
+	if !isReplicaQuery(stmt) {
+		return sqlerror.NewSQLErrorf(sqlerror.ERNotSupportedYet, sqlerror.SSClientError, "query not supported by the binlog server: %s", query)
+	}
+	bc, err := s.upstream(c)
+	if err != nil {
+		return err
+	}
+	qr, err := bc.ExecuteFetch(query, 10000, true)
+	if err != nil {
+		return err
+	}
+	if _, ok := stmt.(*sqlparser.Show); ok {
+		qr.Rows = withoutSemiSyncVariables(qr.Rows)
+	}
+	return callback(qr)
+}
+
+// WarningCount is part of the mysql.Handler interface.
+func (s *Server) WarningCount(c *mysql.Conn) uint16 {
+	return 0
+}
+
+// Env is part of the mysql.Handler interface.
+func (s *Server) Env() *vtenv.Environment {
+	return s.env
+}
+
+// ComQueryMulti is part of the mysql.Handler interface.
+func (s *Server) ComQueryMulti(c *mysql.Conn, sql string, callback func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERNotSupportedYet, sqlerror.SSClientError, "multi statements are not supported by the binlog server")
+}
+
+// ComPrepare is part of the mysql.Handler interface.
+func (s *Server) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Field, uint16, error) {
+	return nil, 0, sqlerror.NewSQLErrorf(sqlerror.ERNotSupportedYet, sqlerror.SSClientError, "prepared statements are not supported by the binlog server")
+}
+
+// ComStmtExecute is part of the mysql.Handler interface.
+func (s *Server) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERNotSupportedYet, sqlerror.SSClientError, "prepared statements are not supported by the binlog server")
+}
+
+// ComRegisterReplica is part of the mysql.Handler interface.
+func (s *Server) ComRegisterReplica(c *mysql.Conn, replicaHost string, replicaPort uint16, replicaUser string, replicaPassword string) error {
+	log.Infof("binlog server: replica %v:%v registered as %v", replicaHost, replicaPort, c.User)
+	return nil
+}
+
+// ComBinlogDump is part of the mysql.Handler interface.
+func (s *Server) ComBinlogDump(c *mysql.Conn, logFile string, binlogPos uint32) error {
+	return sqlerror.NewSQLErrorf(sqlerror.ERNotSupportedYet, sqlerror.SSClientError, "the binlog server only supports GTID auto-positioning")
+}
+
+// ComBinlogDumpGTID is part of the mysql.Handler interface. It streams the
+// events of mysqld which follow gtidSet until either the replica or mysqld
+// closes its connection, or the server is closed.
+func (s *Server) ComBinlogDumpGTID(c *mysql.Conn, logFile string, logPos uint64, gtidSet replication.GTIDSet) error {
+	bc, err := s.upstream(c)
+	if err != nil {
+		return err
+	}
+	if gtidSet == nil {
+		gtidSet = replication.Mysql56GTIDSet{}
+	}
+	events, errs, err := bc.StartBinlogDumpFromPosition(s.ctx, "", replication.Position{GTIDSet: gtidSet})
+	if err != nil {
+		return err
+	}
+
+	replicaCount.Add(1)
+	defer replicaCount.Add(-1)
+	log.Infof("binlog server: streaming to %v from %v", c, gtidSet)
+
+	filter := newEventFilter(s.databases)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return s.ctx.Err()
+			}
+			sent, err := filter.filter(ev)
+			if err != nil {
+				return err
+			}
+			if len(sent) == 0 {
+				eventCount.Add("Filtered", 1)
+			}
+			for _, ev := range sent {
+				eventCount.Add("Sent", 1)
+				if err := c.WriteBinlogEvent(ev, false); err != nil {
+					return err
+				}
+			}
+		case err := <-errs:
+			return err
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
+// isReplicaQuery returns true if stmt is one of the queries a replica may
+// run on its source before streaming: a SELECT of variables and constants,
+// a SET of user variables, or a SHOW VARIABLES.
+func isReplicaQuery(stmt sqlparser.Statement) bool {
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		if len(stmt.From) != 1 || sqlparser.ToString(stmt.From) != "dual" || stmt.Where != nil || stmt.Into != nil {
+			return false
+		}
+		allowed := true
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.Subquery, *sqlparser.ColName:
+				allowed = false
+			case *sqlparser.FuncExpr:
+				switch node.Name.Lowered() {
+				case "unix_timestamp", "version":
+				default:
+					allowed = false
+				}
+			}
+			return allowed, nil
+		}, stmt.SelectExprs)
+		return allowed
+	case *sqlparser.Set:
+		for _, expr := range stmt.Exprs {
+			if expr.Var.Scope != sqlparser.VariableScope {
+				return false
+			}
+			if _, ok := expr.Expr.(*sqlparser.Subquery); ok {
+				return false
+			}
+		}
+		return true
+	case *sqlparser.Show:
+		show, ok := stmt.Internal.(*sqlparser.ShowBasic)
+		return ok && (show.Command == sqlparser.VariableGlobal || show.Command == sqlparser.VariableSession)
+	}
+	return false
+}
+
+// withoutSemiSyncVariables removes the semi-sync variables from the rows of
+// a SHOW VARIABLES, so that replicas do not try to use semi-sync
+// replication, which the binlog server does not support.
+func withoutSemiSyncVariables(rows [][]sqltypes.Value) [][]sqltypes.Value {
+	result := rows[:0]
+	for _, row := range rows {
+		if len(row) > 0 && strings.HasPrefix(strings.ToLower(row[0].ToString()), "rpl_semi_sync_") {
+			continue
+		}
+		result = append(result, row)
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlogserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+)
+
+func TestIsReplicaQuery(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	testcases := []struct {
+		query string
+		want  bool
+	}{
+		// The queries of a MySQL 8.0 replica.
+		{"SELECT UNIX_TIMESTAMP()", true},
+		{"SELECT @@GLOBAL.SERVER_ID", true},
+		{"SET @source_heartbeat_period= 30000001024", true},
+		{"SET @source_binlog_checksum= @@global.binlog_checksum, @master_binlog_checksum= @@global.binlog_checksum", true},
+		{"SELECT @source_binlog_checksum", true},
+		{"SELECT @@GLOBAL.GTID_MODE", true},
+		{"SELECT @@GLOBAL.SERVER_UUID", true},
+		{"SET @replica_uuid= '8d3f5c9e-1b8e-11ef-9a5e-0242ac120002'", true},
+		{"SHOW VARIABLES LIKE 'rpl_semi_sync_source_enabled'", true},
+		{"show global variables like 'binlog_checksum'", true},
+		{"SELECT VERSION()", true},
+		{"select 1", true},
+
+		{"select * from t1", false},
+		{"select @@version from dual where 1 != (select 1 from t1)", false},
+		{"select (select id from t1)", false},
+		{"select sleep(10)", false},
+		{"select 1 into outfile '/tmp/x'", false},
+		{"set @@global.read_only = 0", false},
+		{"set session sql_log_bin = 0", false},
+		{"set @a = (select id from t1)", false},
+		{"show tables", false},
+		{"insert into t1 values (1)", false},
+		{"drop table t1", false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, isReplicaQuery(stmt))
+		})
+	}
+}
+
+func TestWithoutSemiSyncVariables(t *testing.T) {
+	rows := [][]sqltypes.Value{
+		{sqltypes.NewVarChar("rpl_semi_sync_source_enabled"), sqltypes.NewVarChar("ON")},
+		{sqltypes.NewVarChar("binlog_checksum"), sqltypes.NewVarChar("CRC32")},
+		{sqltypes.NewVarChar("RPL_SEMI_SYNC_MASTER_ENABLED"), sqltypes.NewVarChar("ON")},
+	}
+	assert.Equal(t, [][]sqltypes.Value{
+		{sqltypes.NewVarChar("binlog_checksum"), sqltypes.NewVarChar("CRC32")},
+	}, withoutSemiSyncVariables(rows))
+}
+
+func TestServerRejectsQueries(t *testing.T) {
+	dbcfgs := dbconfigs.NewTestDBConfigs(mysql.ConnParams{}, mysql.ConnParams{}, "vt_ks")
+	s := NewServer(vtenv.NewTestEnv(), dbcfgs.FilteredWithDB(), []string{"vt_ks"})
+	authServer := mysql.NewAuthServerStatic("", `{"replica": [{"Password": "secret"}]}`, 0)
+	require.NoError(t, s.Listen("tcp", "127.0.0.1:0", authServer))
+	defer s.Close()
+
+	ctx := context.Background()
+	params := &mysql.ConnParams{
+		Host:  "127.0.0.1",
+		Port:  s.Addr().(*net.TCPAddr).Port,
+		Uname: "replica",
+		Pass:  "secret",
+	}
+	conn, err := mysql.Connect(ctx, params)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, query := range []string{"select * from t1", "set @@global.read_only = 0"} {
+		_, err = conn.ExecuteFetch(query, 10, false)
+		assert.ErrorContains(t, err, "not supported by the binlog server", query)
+	}
+	_, err = conn.ExecuteFetch("select", 10, false)
+	assert.Equal(t, sqlerror.ERSyntaxError, sqlerror.NewSQLErrorFromError(err).(*sqlerror.SQLError).Num)
+
+	params.Pass = "wrong"
+	_, err = mysql.Connect(ctx, params)
+	assert.ErrorContains(t, err, "Access denied")
+}
@@ -52,6 +52,11 @@ type VReplicationConfig struct {
 	TabletTypesStr          string
 	EnableHttpLog           bool // Enable the /debug/vrlog endpoint
 
+	// ParallelApplyWorkers is the number of connections applying the row events of a stream in the
+	// replication phase. ParallelApplyRelaxedOrdering lets them split the transactions spanning several tables.
+	ParallelApplyWorkers         int
+	ParallelApplyRelaxedOrdering bool
+
 	// Config parameters applicable to the source side (vstreamer)
 	// The coresponding Override fields are used to determine if the user has provided a value for the parameter so
 	// that they can be sent in the VStreamer API calls to the source.
@@ -61,6 +66,11 @@ type VReplicationConfig struct {
 	VStreamDynamicPacketSizeOverride       bool
 	VStreamBinlogRotationThreshold         int64
 	VStreamBinlogRotationThresholdOverride bool
+	// TypeFidelityMode is VReplicationTypeFidelityStrict or VReplicationTypeFidelityPermissive.
+	TypeFidelityMode string
+
+	// ReverseLagAlarmThreshold is the lag above which the streams of a reverse workflow raise an alarm.
+	ReverseLagAlarmThreshold time.Duration
 
 	// Overrides is a map of user-provided configuration values that override the default configuration.
 	Overrides map[string]string
@@ -97,12 +107,17 @@ func GetVReplicationConfigDefaults(useCached bool) *VReplicationConfig {
 		TabletTypesStr:          vreplicationTabletTypesStr,
 		EnableHttpLog:           vreplicationEnableHttpLog,
 
+		ParallelApplyWorkers:         vreplicationParallelApplyWorkers,
+		ParallelApplyRelaxedOrdering: vreplicationParallelApplyRelaxedOrdering,
+
 		VStreamPacketSizeOverride:              false,
 		VStreamPacketSize:                      VStreamerDefaultPacketSize,
 		VStreamDynamicPacketSizeOverride:       false,
 		VStreamDynamicPacketSize:               VStreamerUseDynamicPacketSize,
 		VStreamBinlogRotationThresholdOverride: false,
 		VStreamBinlogRotationThreshold:         VStreamerBinlogRotationThreshold,
+		TypeFidelityMode:                       vreplicationTypeFidelityMode,
+		ReverseLagAlarmThreshold:               vreplicationReverseLagAlarmThreshold,
 
 		Overrides: make(map[string]string),
 	}
@@ -220,6 +235,20 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 			} else {
 				c.ParallelInsertWorkers = value
 			}
+		case "vreplication-parallel-apply-workers":
+			value, err := strconv.Atoi(v)
+			if err != nil {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.ParallelApplyWorkers = value
+			}
+		case "vreplication-parallel-apply-relaxed-ordering":
+			value, err := strconv.ParseBool(v)
+			if err != nil {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.ParallelApplyRelaxedOrdering = value
+			}
 		case "vstream_packet_size":
 			value, err := strconv.Atoi(v)
 			if err != nil {
@@ -244,6 +273,20 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 				c.VStreamBinlogRotationThresholdOverride = true
 				c.VStreamBinlogRotationThreshold = value
 			}
+		case "vreplication-type-fidelity-mode":
+			switch v {
+			case VReplicationTypeFidelityStrict, VReplicationTypeFidelityPermissive:
+				c.TypeFidelityMode = v
+			default:
+				errors = append(errors, getError(k, v))
+			}
+		case "vreplication-reverse-lag-alarm-threshold":
+			value, err := time.ParseDuration(v)
+			if err != nil {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.ReverseLagAlarmThreshold = value
+			}
 		default:
 			errors = append(errors, fmt.Sprintf("unknown vreplication config flag: %s", k))
 		}
@@ -259,21 +302,25 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 // keys are one of those that are supported.
 func (c VReplicationConfig) Map() map[string]string {
 	return map[string]string{
-		"vreplication_experimental_flags":         strconv.FormatInt(c.ExperimentalFlags, 10),
-		"vreplication_net_read_timeout":           strconv.Itoa(c.NetReadTimeout),
-		"vreplication_net_write_timeout":          strconv.Itoa(c.NetWriteTimeout),
-		"vreplication_copy_phase_duration":        c.CopyPhaseDuration.String(),
-		"vreplication_retry_delay":                c.RetryDelay.String(),
-		"vreplication_max_time_to_retry_on_error": c.MaxTimeToRetryError.String(),
-		"relay_log_max_size":                      strconv.Itoa(c.RelayLogMaxSize),
-		"relay_log_max_items":                     strconv.Itoa(c.RelayLogMaxItems),
-		"vreplication_replica_lag_tolerance":      c.ReplicaLagTolerance.String(),
-		"vreplication_heartbeat_update_interval":  strconv.Itoa(c.HeartbeatUpdateInterval),
-		"vreplication_store_compressed_gtid":      strconv.FormatBool(c.StoreCompressedGTID),
-		"vreplication-parallel-insert-workers":    strconv.Itoa(c.ParallelInsertWorkers),
-		"vstream_packet_size":                     strconv.Itoa(c.VStreamPacketSize),
-		"vstream_dynamic_packet_size":             strconv.FormatBool(c.VStreamDynamicPacketSize),
-		"vstream_binlog_rotation_threshold":       strconv.FormatInt(c.VStreamBinlogRotationThreshold, 10),
+		"vreplication_experimental_flags":              strconv.FormatInt(c.ExperimentalFlags, 10),
+		"vreplication_net_read_timeout":                strconv.Itoa(c.NetReadTimeout),
+		"vreplication_net_write_timeout":               strconv.Itoa(c.NetWriteTimeout),
+		"vreplication_copy_phase_duration":             c.CopyPhaseDuration.String(),
+		"vreplication_retry_delay":                     c.RetryDelay.String(),
+		"vreplication_max_time_to_retry_on_error":      c.MaxTimeToRetryError.String(),
+		"relay_log_max_size":                           strconv.Itoa(c.RelayLogMaxSize),
+		"relay_log_max_items":                          strconv.Itoa(c.RelayLogMaxItems),
+		"vreplication_replica_lag_tolerance":           c.ReplicaLagTolerance.String(),
+		"vreplication_heartbeat_update_interval":       strconv.Itoa(c.HeartbeatUpdateInterval),
+		"vreplication_store_compressed_gtid":           strconv.FormatBool(c.StoreCompressedGTID),
+		"vreplication-parallel-insert-workers":         strconv.Itoa(c.ParallelInsertWorkers),
+		"vreplication-parallel-apply-workers":          strconv.Itoa(c.ParallelApplyWorkers),
+		"vreplication-parallel-apply-relaxed-ordering": strconv.FormatBool(c.ParallelApplyRelaxedOrdering),
+		"vstream_packet_size":                          strconv.Itoa(c.VStreamPacketSize),
+		"vstream_dynamic_packet_size":                  strconv.FormatBool(c.VStreamDynamicPacketSize),
+		"vstream_binlog_rotation_threshold":            strconv.FormatInt(c.VStreamBinlogRotationThreshold, 10),
+		"vreplication-type-fidelity-mode":              c.TypeFidelityMode,
+		"vreplication-reverse-lag-alarm-threshold":     c.ReverseLagAlarmThreshold.String(),
 	}
 }
 
@@ -36,21 +36,25 @@ func TestNewVReplicationConfig(t *testing.T) {
 		{
 			name: "Valid values",
 			config: map[string]string{
-				"vreplication_experimental_flags":         "3",
-				"vreplication_net_read_timeout":           "100",
-				"vreplication_net_write_timeout":          "200",
-				"vreplication_copy_phase_duration":        "2h",
-				"vreplication_retry_delay":                "10s",
-				"vreplication_max_time_to_retry_on_error": "1h",
-				"relay_log_max_size":                      "500000",
-				"relay_log_max_items":                     "10000",
-				"vreplication_replica_lag_tolerance":      "2m",
-				"vreplication_heartbeat_update_interval":  "2",
-				"vreplication_store_compressed_gtid":      "true",
-				"vreplication-parallel-insert-workers":    "4",
-				"vstream_packet_size":                     "1024",
-				"vstream_dynamic_packet_size":             "false",
-				"vstream_binlog_rotation_threshold":       "2048",
+				"vreplication_experimental_flags":              "3",
+				"vreplication_net_read_timeout":                "100",
+				"vreplication_net_write_timeout":               "200",
+				"vreplication_copy_phase_duration":             "2h",
+				"vreplication_retry_delay":                     "10s",
+				"vreplication_max_time_to_retry_on_error":      "1h",
+				"relay_log_max_size":                           "500000",
+				"relay_log_max_items":                          "10000",
+				"vreplication_replica_lag_tolerance":           "2m",
+				"vreplication_heartbeat_update_interval":       "2",
+				"vreplication_store_compressed_gtid":           "true",
+				"vreplication-parallel-insert-workers":         "4",
+				"vreplication-parallel-apply-workers":          "8",
+				"vreplication-parallel-apply-relaxed-ordering": "true",
+				"vstream_packet_size":                          "1024",
+				"vstream_dynamic_packet_size":                  "false",
+				"vstream_binlog_rotation_threshold":            "2048",
+				"vreplication-type-fidelity-mode":              "permissive",
+				"vreplication-reverse-lag-alarm-threshold":     "1m",
 			},
 			wantErr: 0,
 			want: &VReplicationConfig{
@@ -66,6 +70,8 @@ func TestNewVReplicationConfig(t *testing.T) {
 				HeartbeatUpdateInterval:                2,
 				StoreCompressedGTID:                    true,
 				ParallelInsertWorkers:                  4,
+				ParallelApplyWorkers:                   8,
+				ParallelApplyRelaxedOrdering:           true,
 				VStreamPacketSize:                      1024,
 				VStreamDynamicPacketSize:               false,
 				VStreamBinlogRotationThreshold:         2048,
@@ -73,28 +79,34 @@ func TestNewVReplicationConfig(t *testing.T) {
 				VStreamPacketSizeOverride:              true,
 				VStreamDynamicPacketSizeOverride:       true,
 				VStreamBinlogRotationThresholdOverride: true,
+				TypeFidelityMode:                       VReplicationTypeFidelityPermissive,
+				ReverseLagAlarmThreshold:               time.Minute,
 			},
 		},
 		{
 			name: "Invalid values",
 			config: map[string]string{
-				"vreplication_experimental_flags":         "invalid",
-				"vreplication_net_read_timeout":           "100.0",
-				"vreplication_net_write_timeout":          "invalid",
-				"vreplication_copy_phase_duration":        "invalid",
-				"vreplication_retry_delay":                "invalid",
-				"vreplication_max_time_to_retry_on_error": "invalid",
-				"relay_log_max_size":                      "invalid",
-				"relay_log_max_items":                     "invalid",
-				"vreplication_replica_lag_tolerance":      "invalid",
-				"vreplication_heartbeat_update_interval":  "invalid",
-				"vreplication_store_compressed_gtid":      "nottrue",
-				"vreplication-parallel-insert-workers":    "invalid",
-				"vstream_packet_size":                     "invalid",
-				"vstream_dynamic_packet_size":             "waar",
-				"vstream_binlog_rotation_threshold":       "invalid",
+				"vreplication_experimental_flags":              "invalid",
+				"vreplication_net_read_timeout":                "100.0",
+				"vreplication_net_write_timeout":               "invalid",
+				"vreplication_copy_phase_duration":             "invalid",
+				"vreplication_retry_delay":                     "invalid",
+				"vreplication_max_time_to_retry_on_error":      "invalid",
+				"relay_log_max_size":                           "invalid",
+				"relay_log_max_items":                          "invalid",
+				"vreplication_replica_lag_tolerance":           "invalid",
+				"vreplication_heartbeat_update_interval":       "invalid",
+				"vreplication_store_compressed_gtid":           "nottrue",
+				"vreplication-parallel-insert-workers":         "invalid",
+				"vreplication-parallel-apply-workers":          "invalid",
+				"vreplication-parallel-apply-relaxed-ordering": "maybe",
+				"vstream_packet_size":                          "invalid",
+				"vstream_dynamic_packet_size":                  "waar",
+				"vstream_binlog_rotation_threshold":            "invalid",
+				"vreplication-type-fidelity-mode":              "lenient",
+				"vreplication-reverse-lag-alarm-threshold":     "invalid",
 			},
-			wantErr: 15,
+			wantErr: 19,
 		},
 		{
 			name: "Partial values",
@@ -118,11 +130,15 @@ func TestNewVReplicationConfig(t *testing.T) {
 				HeartbeatUpdateInterval:          DefaultVReplicationConfig.HeartbeatUpdateInterval,
 				StoreCompressedGTID:              !DefaultVReplicationConfig.StoreCompressedGTID,
 				ParallelInsertWorkers:            DefaultVReplicationConfig.ParallelInsertWorkers,
+				ParallelApplyWorkers:             DefaultVReplicationConfig.ParallelApplyWorkers,
+				ParallelApplyRelaxedOrdering:     DefaultVReplicationConfig.ParallelApplyRelaxedOrdering,
 				VStreamPacketSize:                DefaultVReplicationConfig.VStreamPacketSize,
 				VStreamDynamicPacketSize:         !DefaultVReplicationConfig.VStreamDynamicPacketSize,
 				VStreamBinlogRotationThreshold:   DefaultVReplicationConfig.VStreamBinlogRotationThreshold,
 				VStreamDynamicPacketSizeOverride: true,
 				TabletTypesStr:                   DefaultVReplicationConfig.TabletTypesStr,
+				TypeFidelityMode:                 DefaultVReplicationConfig.TypeFidelityMode,
+				ReverseLagAlarmThreshold:         DefaultVReplicationConfig.ReverseLagAlarmThreshold,
 			},
 		},
 	}
@@ -32,6 +32,14 @@ const (
 	VReplicationExperimentalFlagVPlayerBatching           = int64(4)
 )
 
+const (
+	// VReplicationTypeFidelityStrict fails the stream when a value of a row
+	// event cannot be decoded without losing information.
+	VReplicationTypeFidelityStrict = "strict"
+	// VReplicationTypeFidelityPermissive logs and coerces such values instead.
+	VReplicationTypeFidelityPermissive = "permissive"
+)
+
 var (
 	vreplicationExperimentalFlags   = VReplicationExperimentalFlagOptimizeInserts | VReplicationExperimentalFlagAllowNoBlobBinlogRowImage | VReplicationExperimentalFlagVPlayerBatching
 	vreplicationNetReadTimeout      = 300
@@ -52,6 +60,9 @@ var (
 	vreplicationStoreCompressedGTID   = false
 	vreplicationParallelInsertWorkers = 1
 
+	vreplicationParallelApplyWorkers         = 1
+	vreplicationParallelApplyRelaxedOrdering = false
+
 	// VStreamerBinlogRotationThreshold is the threshold, above which we rotate binlogs, before taking a GTID snapshot
 	VStreamerBinlogRotationThreshold = int64(64 * 1024 * 1024) // 64MiB
 	VStreamerDefaultPacketSize       = 250000
@@ -59,6 +70,10 @@ var (
 
 	// Enable the /debug/vrlog HTTP endpoint.
 	vreplicationEnableHttpLog = false
+
+	vreplicationTypeFidelityMode = VReplicationTypeFidelityStrict
+
+	vreplicationReverseLagAlarmThreshold = 30 * time.Second
 )
 
 func GetVReplicationNetReadTimeout() int {
@@ -98,7 +113,12 @@ func registerFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&vreplicationParallelInsertWorkers, "vreplication-parallel-insert-workers", vreplicationParallelInsertWorkers, "Number of parallel insertion workers to use during copy phase. Set <= 1 to disable parallelism, or > 1 to enable concurrent insertion during copy phase.")
 
+	fs.IntVar(&vreplicationParallelApplyWorkers, "vreplication-parallel-apply-workers", vreplicationParallelApplyWorkers, "Number of connections applying the row events of a stream in the replication phase. The tables changed by a same transaction of a batch are applied by the same connection, and batches that cannot be split, such as those with DDLs, are applied serially. Set <= 1 to disable parallelism.")
+	fs.BoolVar(&vreplicationParallelApplyRelaxedOrdering, "vreplication-parallel-apply-relaxed-ordering", vreplicationParallelApplyRelaxedOrdering, "When applying the row events of a stream in parallel, apply the changes of each table on its own, splitting the transactions that span several tables across the connections.")
+
 	fs.Uint64Var(&mysql.ZstdInMemoryDecompressorMaxSize, "binlog-in-memory-decompressor-max-size", mysql.ZstdInMemoryDecompressorMaxSize, "This value sets the uncompressed transaction payload size at which we switch from in-memory buffer based decompression to the slower streaming mode.")
 
+	fs.DurationVar(&vreplicationReverseLagAlarmThreshold, "vreplication-reverse-lag-alarm-threshold", vreplicationReverseLagAlarmThreshold, "Lag above which the streams of the reverse workflows created by SwitchTraffic raise the VReplicationReverseLagAlarm alarm, which they also raise when in error. Set to 0 to only raise it on errors.")
+	fs.StringVar(&vreplicationTypeFidelityMode, "vreplication-type-fidelity-mode", vreplicationTypeFidelityMode, "How vstreamers handle the values of row events that cannot be decoded without losing information, such as an ENUM or SET value missing from the column definition: 'strict' fails the stream, 'permissive' logs and coerces the value.")
 	fs.BoolVar(&vreplicationEnableHttpLog, "vreplication-enable-http-log", vreplicationEnableHttpLog, "Enable the /debug/vrlog HTTP endpoint, which will produce a log of the events replicated on primary tablets in the target keyspace by all VReplication workflows that are in the running/replicating phase.")
 }
@@ -17,6 +17,10 @@ limitations under the License.
 /*
 Package topocustomrule implements a topo service backed listener for query rules.
 One usage is to allow fast propagation of table denylists.
+
+It applies the rules of the file given by --topocustomrule_path, and the rules
+of the keyspace of the tablet, managed with the AddQueryRule and
+DeleteQueryRule vtctld RPCs.
 */
 package topocustomrule
 
@@ -40,29 +44,46 @@ var (
 	// Commandline flag to specify rule cell and path.
 	ruleCell = "global"
 	rulePath string
+
+	// keyspaceRules enables the query rules of the keyspace of the tablet.
+	keyspaceRules = true
 )
 
 func registerFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&ruleCell, "topocustomrule_cell", ruleCell, "topo cell for customrules file.")
 	fs.StringVar(&rulePath, "topocustomrule_path", rulePath, "path for customrules file. Disabled if empty.")
+	fs.BoolVar(&keyspaceRules, "keyspace-query-rules", keyspaceRules, "Apply the query rules of the keyspace of the tablet, managed with the AddQueryRule and DeleteQueryRule vtctld commands.")
 }
 
 func init() {
 	servenv.OnParseFor("vttablet", registerFlags)
 }
 
-// topoCustomRuleSource is topo based custom rule source name
-const topoCustomRuleSource string = "TOPO_CUSTOM_RULE"
+const (
+	// topoCustomRuleSource is topo based custom rule source name
+	topoCustomRuleSource string = "TOPO_CUSTOM_RULE"
+
+	// keyspaceQueryRuleSource is the name of the source of the query rules of
+	// the keyspace of the tablet.
+	keyspaceQueryRuleSource string = "KEYSPACE_QUERY_RULE"
+)
 
 // sleepDuringTopoFailure is how long to sleep before retrying in case of error.
 // (it's a var not a const so the test can change the value).
 var sleepDuringTopoFailure = 30 * time.Second
 
+// sleepWithoutRules is how long to sleep before watching the rules again when
+// they don't exist.
+var sleepWithoutRules = 10 * time.Second
+
 // topoCustomRule is the topo backed implementation.
 type topoCustomRule struct {
 	// qsc is set at construction time.
 	qsc tabletserver.Controller
 
+	// source is the name of the rule source. Set at construction time.
+	source string
+
 	// conn is the topo connection. Set at construction time.
 	conn topo.Conn
 
@@ -82,13 +103,14 @@ type topoCustomRule struct {
 	stopped bool
 }
 
-func newTopoCustomRule(qsc tabletserver.Controller, cell, filePath string) (*topoCustomRule, error) {
+func newTopoCustomRule(qsc tabletserver.Controller, source, cell, filePath string) (*topoCustomRule, error) {
 	conn, err := qsc.TopoServer().ConnForCell(context.Background(), cell)
 	if err != nil {
 		return nil, err
 	}
 	return &topoCustomRule{
 		qsc:      qsc,
+		source:   source,
 		conn:     conn,
 		filePath: filePath,
 	}, nil
@@ -97,7 +119,12 @@ func newTopoCustomRule(qsc tabletserver.Controller, cell, filePath string) (*top
 func (cr *topoCustomRule) start() {
 	go func() {
 		for {
-			if err := cr.oneWatch(); err != nil {
+			err := cr.oneWatch()
+			noRules := topo.IsErrType(err, topo.NoNode)
+			if noRules {
+				// The rules don't exist, or were deleted.
+				cr.clear()
+			} else if err != nil {
 				log.Warningf("Background watch of topo custom rule failed: %v", err)
 			}
 
@@ -110,6 +137,10 @@ func (cr *topoCustomRule) start() {
 				return
 			}
 
+			if noRules {
+				time.Sleep(sleepWithoutRules)
+				continue
+			}
 			log.Warningf("Sleeping for %v before trying again", sleepDuringTopoFailure)
 			time.Sleep(sleepDuringTopoFailure)
 		}
@@ -133,13 +164,23 @@ func (cr *topoCustomRule) apply(wd *topo.WatchData) error {
 
 	if !reflect.DeepEqual(cr.qrs, qrs) {
 		cr.qrs = qrs.Copy()
-		cr.qsc.SetQueryRules(topoCustomRuleSource, qrs)
+		cr.qsc.SetQueryRules(cr.source, qrs)
 		log.Infof("Custom rule version %v fetched from topo and applied to vttablet", wd.Version)
 	}
 
 	return nil
 }
 
+// clear removes the rules applied to vttablet, if any.
+func (cr *topoCustomRule) clear() {
+	if cr.qrs == nil {
+		return
+	}
+	cr.qrs = nil
+	cr.qsc.SetQueryRules(cr.source, rules.New())
+	log.Infof("Custom rule %s was deleted from topo and removed from vttablet", cr.filePath)
+}
+
 func (cr *topoCustomRule) oneWatch() error {
 	defer func() {
 		// Whatever happens, cancel() won't be valid after this function exits.
@@ -201,7 +242,7 @@ func activateTopoCustomRules(qsc tabletserver.Controller) {
 	if rulePath != "" {
 		qsc.RegisterQueryRuleSource(topoCustomRuleSource)
 
-		cr, err := newTopoCustomRule(qsc, ruleCell, rulePath)
+		cr, err := newTopoCustomRule(qsc, topoCustomRuleSource, ruleCell, rulePath)
 		if err != nil {
 			log.Fatalf("cannot start TopoCustomRule: %v", err)
 		}
@@ -209,6 +250,18 @@ func activateTopoCustomRules(qsc tabletserver.Controller) {
 
 		servenv.OnTerm(cr.stop)
 	}
+
+	if target := qsc.CurrentTarget(); keyspaceRules && target.GetKeyspace() != "" {
+		qsc.RegisterQueryRuleSource(keyspaceQueryRuleSource)
+
+		cr, err := newTopoCustomRule(qsc, keyspaceQueryRuleSource, topo.GlobalCell, topo.QueryRulesPath(target.Keyspace))
+		if err != nil {
+			log.Fatalf("cannot start the query rules of keyspace %s: %v", target.Keyspace, err)
+		}
+		cr.start()
+
+		servenv.OnTerm(cr.stop)
+	}
 }
 
 func init() {
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletservermock"
@@ -49,10 +50,10 @@ var customRule2 = `
   }
 ]`
 
-func waitForValue(t *testing.T, qsc *tabletservermock.Controller, expected *rules.Rules) {
+func waitForValue(t *testing.T, qsc *tabletservermock.Controller, source string, expected *rules.Rules) {
 	start := time.Now()
 	for {
-		val := qsc.GetQueryRules(topoCustomRuleSource)
+		val := qsc.GetQueryRules(source)
 		if val != nil {
 			if val.Equal(expected) {
 				return
@@ -86,7 +87,7 @@ func TestUpdate(t *testing.T) {
 	qsc.TS = ts
 	sleepDuringTopoFailure = time.Millisecond
 
-	cr, err := newTopoCustomRule(qsc, cell, filePath)
+	cr, err := newTopoCustomRule(qsc, topoCustomRuleSource, cell, filePath)
 	if err != nil {
 		t.Fatalf("newTopoCustomRule failed: %v", err)
 	}
@@ -101,11 +102,45 @@ func TestUpdate(t *testing.T) {
 	if _, err := conn.Create(ctx, filePath, []byte(customRule1)); err != nil {
 		t.Fatalf("conn.Create failed: %v", err)
 	}
-	waitForValue(t, qsc, custom1)
+	waitForValue(t, qsc, topoCustomRuleSource, custom1)
 
 	// update the value, wait until we get it.
 	if _, err := conn.Update(ctx, filePath, []byte(customRule2), nil); err != nil {
 		t.Fatalf("conn.Update failed: %v", err)
 	}
-	waitForValue(t, qsc, custom2)
+	waitForValue(t, qsc, topoCustomRuleSource, custom2)
+}
+
+func TestKeyspaceRules(t *testing.T) {
+	custom1 := rules.New()
+	if err := custom1.UnmarshalJSON([]byte(customRule1)); err != nil {
+		t.Fatalf("error unmarshaling customRule1: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "cell1")
+	qsc := tabletservermock.NewController()
+	qsc.TS = ts
+	sleepWithoutRules = time.Millisecond
+
+	cr, err := newTopoCustomRule(qsc, keyspaceQueryRuleSource, topo.GlobalCell, topo.QueryRulesPath("ks1"))
+	if err != nil {
+		t.Fatalf("newTopoCustomRule failed: %v", err)
+	}
+	cr.start()
+	defer cr.stop()
+
+	// The rules are applied once they are saved, and removed once they are
+	// deleted.
+	if err := ts.SaveQueryRules(ctx, "ks1", []byte(customRule1)); err != nil {
+		t.Fatalf("SaveQueryRules failed: %v", err)
+	}
+	waitForValue(t, qsc, keyspaceQueryRuleSource, custom1)
+
+	if err := ts.SaveQueryRules(ctx, "ks1", nil); err != nil {
+		t.Fatalf("SaveQueryRules failed: %v", err)
+	}
+	waitForValue(t, qsc, keyspaceQueryRuleSource, rules.New())
 }
@@ -70,17 +70,18 @@ var procSQL = []string{
 func TestCallProcedure(t *testing.T) {
 	client := framework.NewClient()
 	type testcases struct {
-		query   string
-		wantErr bool
+		query       string
+		moreResults int
 	}
 	tcases := []testcases{{
 		query: "call proc_dml()",
 	}, {
-		query:   "call proc_select1()",
-		wantErr: true,
+		// The result of the select is followed by the status of the call.
+		query:       "call proc_select1()",
+		moreResults: 1,
 	}, {
-		query:   "call proc_select4()",
-		wantErr: true,
+		query:       "call proc_select4()",
+		moreResults: 4,
 	}, {
 		// Again, make sure the connection isn't dirty and does not contain leftover
 		// result sets from previous tests.
@@ -89,12 +90,9 @@ func TestCallProcedure(t *testing.T) {
 
 	for _, tc := range tcases {
 		t.Run(tc.query, func(t *testing.T) {
-			_, err := client.Execute(tc.query, nil)
-			if tc.wantErr {
-				require.EqualError(t, err, "Multi-Resultset not supported in stored procedure (CallerID: dev)")
-				return
-			}
+			qr, err := client.Execute(tc.query, nil)
 			require.NoError(t, err)
+			assert.Len(t, qr.MoreResults, tc.moreResults)
 		})
 	}
 }
@@ -115,7 +115,7 @@ func (client *QueryClient) Begin(clientFoundRows bool) error {
 // Commit commits the current transaction.
 func (client *QueryClient) Commit() error {
 	defer func() { client.transactionID = 0 }()
-	rID, err := client.server.Commit(client.ctx, client.target, client.transactionID)
+	rID, _, err := client.server.Commit(client.ctx, client.target, client.transactionID)
 	client.reservedID = rID
 	if err != nil {
 		return err
@@ -141,6 +141,11 @@ func (client *FakeTabletManagerClient) GetGlobalStatusVars(ctx context.Context,
 	return make(map[string]string), nil
 }
 
+// GetAuditLog is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetAuditLog(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error) {
+	return &tabletmanagerdatapb.GetAuditLogResponse{}, nil
+}
+
 // LockTables is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) LockTables(ctx context.Context, tablet *topodatapb.Tablet) error {
 	return nil
@@ -417,6 +422,11 @@ func (client *FakeTabletManagerClient) RestoreFromBackup(ctx context.Context, ta
 	return &eofEventStream{}, nil
 }
 
+// RestoreFromClone is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) RestoreFromClone(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	return &tabletmanagerdatapb.RestoreFromCloneResponse{}, nil
+}
+
 // Throttler related methods
 
 func (client *FakeTabletManagerClient) CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
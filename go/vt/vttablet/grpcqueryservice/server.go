@@ -98,11 +98,11 @@ func (q *query) Commit(ctx context.Context, request *querypb.CommitRequest) (res
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
-	rID, err := q.server.Commit(ctx, request.Target, request.TransactionId)
+	rID, sessionStateChanges, err := q.server.Commit(ctx, request.Target, request.TransactionId)
 	if err != nil {
 		return nil, vterrors.ToGRPC(err)
 	}
-	return &querypb.CommitResponse{ReservedId: rID}, nil
+	return &querypb.CommitResponse{ReservedId: rID, SessionStateChanges: sessionStateChanges}, nil
 }
 
 // Rollback is part of the queryservice.QueryServer interface
@@ -184,7 +184,9 @@ func (conn *gRPCQueryClient) StreamExecute(ctx context.Context, target *querypb.
 		if err != nil {
 			return tabletconn.ErrorFromGRPC(err)
 		}
-		if fields == nil {
+		// A packet with fields starts a new result set, such as the next
+		// result set of a CALL returning several of them.
+		if ser.Result.Fields != nil {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -221,11 +223,11 @@ func (conn *gRPCQueryClient) Begin(ctx context.Context, target *querypb.Target,
 }
 
 // Commit commits the ongoing transaction.
-func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
+func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
 	conn.mu.RLock()
 	defer conn.mu.RUnlock()
 	if conn.cc == nil {
-		return 0, tabletconn.ConnClosed
+		return 0, "", tabletconn.ConnClosed
 	}
 
 	req := &querypb.CommitRequest{
@@ -236,9 +238,9 @@ func (conn *gRPCQueryClient) Commit(ctx context.Context, target *querypb.Target,
 	}
 	resp, err := conn.c.Commit(ctx, req)
 	if err != nil {
-		return 0, tabletconn.ErrorFromGRPC(err)
+		return 0, "", tabletconn.ErrorFromGRPC(err)
 	}
-	return resp.ReservedId, nil
+	return resp.ReservedId, resp.SessionStateChanges, nil
 }
 
 // Rollback rolls back the ongoing transaction.
@@ -560,7 +562,7 @@ func (conn *gRPCQueryClient) BeginStreamExecute(ctx context.Context, target *que
 			return state, nil
 		}
 
-		if fields == nil {
+		if ser.Result.Fields != nil {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -963,7 +965,7 @@ func (conn *gRPCQueryClient) ReserveBeginStreamExecute(ctx context.Context, targ
 			return state, nil
 		}
 
-		if fields == nil {
+		if ser.Result.Fields != nil {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
@@ -1071,7 +1073,7 @@ func (conn *gRPCQueryClient) ReserveStreamExecute(ctx context.Context, target *q
 			return state, nil
 		}
 
-		if fields == nil {
+		if ser.Result.Fields != nil {
 			fields = ser.Result.Fields
 		}
 		if err := callback(sqltypes.CustomProto3ToResult(fields, ser.Result)); err != nil {
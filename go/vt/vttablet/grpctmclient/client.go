@@ -358,6 +358,16 @@ func (client *Client) GetGlobalStatusVars(ctx context.Context, tablet *topodatap
 	return response.GetStatusValues(), nil
 }
 
+// GetAuditLog is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetAuditLog(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return c.GetAuditLog(ctx, req)
+}
+
 //
 // Various read-write methods
 //
@@ -1406,6 +1416,21 @@ func (client *Client) RestoreFromBackup(ctx context.Context, tablet *topodatapb.
 	}, nil
 }
 
+// RestoreFromClone is part of the tmclient.TabletManagerClient interface.
+func (client *Client) RestoreFromClone(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	response, err := c.RestoreFromClone(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // Close is part of the tmclient.TabletManagerClient interface.
 func (client *Client) Close() {
 	client.dialer.Close()
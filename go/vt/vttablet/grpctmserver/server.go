@@ -109,6 +109,12 @@ func (s *server) GetGlobalStatusVars(ctx context.Context, request *tabletmanager
 	return response, err
 }
 
+func (s *server) GetAuditLog(ctx context.Context, request *tabletmanagerdatapb.GetAuditLogRequest) (response *tabletmanagerdatapb.GetAuditLogResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetAuditLog", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.GetAuditLog(ctx, request)
+}
+
 //
 // Various read-write methods
 //
@@ -702,6 +708,12 @@ func (s *server) RestoreFromBackup(request *tabletmanagerdatapb.RestoreFromBacku
 	return s.tm.RestoreFromBackup(ctx, logger, request)
 }
 
+func (s *server) RestoreFromClone(ctx context.Context, request *tabletmanagerdatapb.RestoreFromCloneRequest) (response *tabletmanagerdatapb.RestoreFromCloneResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "RestoreFromClone", request, response, true /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+	return s.tm.RestoreFromClone(ctx, request)
+}
+
 func (s *server) CheckThrottler(ctx context.Context, request *tabletmanagerdatapb.CheckThrottlerRequest) (response *tabletmanagerdatapb.CheckThrottlerResponse, err error) {
 	defer s.tm.HandleRPCPanic(ctx, "CheckThrottler", request, response, false /*verbose*/, &err)
 	ctx = callinfo.GRPCCallInfo(ctx)
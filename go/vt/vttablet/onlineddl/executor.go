@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -43,6 +44,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/textutil"
 	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/audit"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/dbconnpool"
 	"vitess.io/vitess/go/vt/log"
@@ -61,6 +63,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 )
@@ -158,6 +161,9 @@ type Executor struct {
 	tickReentranceFlag            int64
 	reviewedRunningMigrationsFlag bool
 
+	// shadowReadValidations maps table names to the *ShadowReadValidation of the migration running on them
+	shadowReadValidations sync.Map
+
 	ticks  *timer.Timer
 	isOpen int64
 
@@ -315,6 +321,7 @@ func (e *Executor) Close() {
 
 	e.ticks.Stop()
 	e.pool.Close()
+	e.stopShadowReadValidations(map[string]bool{})
 	atomic.StoreInt64(&e.isOpen, 0)
 }
 
@@ -368,8 +375,8 @@ func (e *Executor) allowConcurrentMigration(onlineDDL *schema.OnlineDDL) (action
 		return action, false
 	}
 	switch action {
-	case sqlparser.CreateDDLAction, sqlparser.DropDDLAction:
-		// CREATE TABLE, DROP TABLE are allowed to run concurrently.
+	case sqlparser.CreateDDLAction, sqlparser.DropDDLAction, sqlparser.AnalyzeDDLAction:
+		// CREATE TABLE, DROP TABLE and ANALYZE TABLE are allowed to run concurrently.
 		return action, true
 	case sqlparser.AlterDDLAction:
 		// ALTER is only allowed concurrent execution if this is a Vitess migration
@@ -728,6 +735,9 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 	if err := e.incrementCutoverAttempts(ctx, s.workflow); err != nil {
 		return vterrors.Wrapf(err, "cutover: failed incrementing cutover attempts")
 	}
+	// Stop mirroring reads onto the shadow table, which is about to be swapped with the original table.
+	// Mirroring resumes at the next review should the cut-over fail.
+	e.stopShadowReadValidation(s.workflow)
 
 	tmClient := e.tabletManagerClient()
 	defer tmClient.Close()
@@ -1486,6 +1496,23 @@ func (e *Executor) readPendingMigrationsUUIDs(ctx context.Context) (uuids []stri
 	return uuids, err
 }
 
+// readPendingMigrationsUUIDsInContext returns UUIDs for migrations in pending state (queued/ready/running)
+// within the given migration context. An empty context matches all pending migrations.
+func (e *Executor) readPendingMigrationsUUIDsInContext(ctx context.Context, migrationContext string) (uuids []string, err error) {
+	r, err := e.execQuery(ctx, sqlSelectPendingMigrations)
+	if err != nil {
+		return uuids, err
+	}
+	for _, row := range r.Named().Rows {
+		if migrationContext != "" && row["migration_context"].ToString() != migrationContext {
+			continue
+		}
+		uuid := row["migration_uuid"].ToString()
+		uuids = append(uuids, uuid)
+	}
+	return uuids, err
+}
+
 // terminateMigration attempts to interrupt and hard-stop a running migration
 func (e *Executor) terminateMigration(ctx context.Context, onlineDDL *schema.OnlineDDL) (foundRunning bool, err error) {
 	log.Infof("terminateMigration: request to terminate %s", onlineDDL.UUID)
@@ -1577,13 +1604,13 @@ func (e *Executor) cancelMigrations(ctx context.Context, cancellable []*cancella
 }
 
 // CancelPendingMigrations cancels all pending migrations (that are expected to run or are running)
-// for this keyspace
-func (e *Executor) CancelPendingMigrations(ctx context.Context, message string, issuedByUser bool) (result *sqltypes.Result, err error) {
+// for this keyspace. A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) CancelPendingMigrations(ctx context.Context, migrationContext string, message string, issuedByUser bool) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
 
-	uuids, err := e.readPendingMigrationsUUIDs(ctx)
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
 	if err != nil {
 		return result, err
 	}
@@ -1633,8 +1660,9 @@ func (e *Executor) ThrottleMigration(ctx context.Context, uuid string, expireStr
 	return emptyResult, nil
 }
 
-// ThrottleAllMigrations
-func (e *Executor) ThrottleAllMigrations(ctx context.Context, expireString string, ratioLiteral *sqlparser.Literal) (result *sqltypes.Result, err error) {
+// ThrottleAllMigrations throttles all migrations. A non-empty migration context limits throttling
+// to the pending migrations in that context, each throttled by its own UUID.
+func (e *Executor) ThrottleAllMigrations(ctx context.Context, migrationContext string, expireString string, ratioLiteral *sqlparser.Literal) (result *sqltypes.Result, err error) {
 	duration, ratio, err := e.validateThrottleParams(ctx, expireString, ratioLiteral)
 	if err != nil {
 		return nil, err
@@ -1642,8 +1670,18 @@ func (e *Executor) ThrottleAllMigrations(ctx context.Context, expireString strin
 	if err := e.lagThrottler.CheckIsOpen(); err != nil {
 		return nil, err
 	}
-	_ = e.lagThrottler.ThrottleApp(throttlerapp.OnlineDDLName.String(), time.Now().Add(duration), ratio, false)
-	return emptyResult, nil
+	if migrationContext == "" {
+		_ = e.lagThrottler.ThrottleApp(throttlerapp.OnlineDDLName.String(), time.Now().Add(duration), ratio, false)
+		return emptyResult, nil
+	}
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, uuid := range uuids {
+		_ = e.lagThrottler.ThrottleApp(uuid, time.Now().Add(duration), ratio, false)
+	}
+	return &sqltypes.Result{RowsAffected: uint64(len(uuids))}, nil
 }
 
 // UnthrottleMigration
@@ -1656,14 +1694,25 @@ func (e *Executor) UnthrottleMigration(ctx context.Context, uuid string) (result
 	return emptyResult, nil
 }
 
-// UnthrottleAllMigrations
-func (e *Executor) UnthrottleAllMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// UnthrottleAllMigrations unthrottles all migrations. A non-empty migration context limits unthrottling
+// to the pending migrations in that context.
+func (e *Executor) UnthrottleAllMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if err := e.lagThrottler.CheckIsOpen(); err != nil {
 		return nil, err
 	}
 	defer e.triggerNextCheckInterval()
-	_ = e.lagThrottler.UnthrottleApp(throttlerapp.OnlineDDLName.String())
-	return emptyResult, nil
+	if migrationContext == "" {
+		_ = e.lagThrottler.UnthrottleApp(throttlerapp.OnlineDDLName.String())
+		return emptyResult, nil
+	}
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, uuid := range uuids {
+		_ = e.lagThrottler.UnthrottleApp(uuid)
+	}
+	return &sqltypes.Result{RowsAffected: uint64(len(uuids))}, nil
 }
 
 // scheduleNextMigration attempts to schedule a single migration to run next.
@@ -2226,6 +2275,25 @@ func (e *Executor) validateInOrderMigration(ctx context.Context, onlineDDL *sche
 	return true, e.failMigration(ctx, onlineDDL, fmt.Errorf("migration %v cannot run because prior migration %v in same context has failed/was cancelled", onlineDDL.UUID, uuids[0]))
 }
 
+// validateMigrationDependency checks whether the migration this migration depends on is complete.
+// If the dependency has failed, was cancelled, or does not exist, then this migration is failed.
+func (e *Executor) validateMigrationDependency(ctx context.Context, onlineDDL *schema.OnlineDDL, dependsOnUUID string) (satisfied bool, err error) {
+	dependency, _, err := e.readMigration(ctx, dependsOnUUID)
+	if err == ErrMigrationNotFound {
+		return false, e.failMigration(ctx, onlineDDL, fmt.Errorf("migration %v cannot run because it depends on migration %v, which does not exist", onlineDDL.UUID, dependsOnUUID))
+	}
+	if err != nil {
+		return false, err
+	}
+	switch dependency.Status {
+	case schema.OnlineDDLStatusComplete:
+		return true, nil
+	case schema.OnlineDDLStatusFailed, schema.OnlineDDLStatusCancelled:
+		return false, e.failMigration(ctx, onlineDDL, fmt.Errorf("migration %v cannot run because migration %v it depends on has failed/was cancelled", onlineDDL.UUID, dependsOnUUID))
+	}
+	return false, nil
+}
+
 // analyzeDropDDLActionMigration analyzes a DROP <TABLE|VIEW> migration.
 func (e *Executor) analyzeDropDDLActionMigration(ctx context.Context, onlineDDL *schema.OnlineDDL) error {
 	// Schema analysis:
@@ -2267,6 +2335,63 @@ func (e *Executor) analyzeDropDDLActionMigration(ctx context.Context, onlineDDL
 	return nil
 }
 
+// executeAnalyzeDDLActionMigration runs an ANALYZE TABLE migration. The statement itself is short lived, but it
+// still competes with production traffic, so it only runs once the throttler is satisfied, like any other
+// background operation. The migration may be throttled or cancelled while waiting for the throttler.
+func (e *Executor) executeAnalyzeDDLActionMigration(ctx context.Context, onlineDDL *schema.OnlineDDL) error {
+	failMigration := func(err error) error {
+		return e.failMigration(ctx, onlineDDL, err)
+	}
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusRunning, false, progressPctStarted, etaSecondsUnknown, rowsCopiedUnknown, emptyHint)
+
+	// We do not hold migrationMutex while waiting on the throttler, so that the migration can be cancelled meanwhile.
+	throttlerClient := throttle.NewBackgroundClient(e.lagThrottler, throttlerapp.OnlineDDLName, base.UndefinedScope)
+	throttlerAppName := throttlerapp.Name(throttlerapp.Concatenate(onlineDDL.UUID, throttlerapp.OnlineDDLName.String()))
+	for {
+		checkResult, ok := throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, throttlerAppName)
+		if ok {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return failMigration(err)
+		}
+		_ = e.updateMigrationLastThrottled(ctx, onlineDDL.UUID, time.Now(), throttlerAppName.String(), checkResult.Summary())
+		// Keep the migration alive, so that it is not considered stale while throttled.
+		_ = e.updateMigrationTimestamp(ctx, "liveness_timestamp", onlineDDL.UUID)
+
+		storedMigration, _, err := e.readMigration(ctx, onlineDDL.UUID)
+		if err != nil {
+			return failMigration(err)
+		}
+		if storedMigration.Status != schema.OnlineDDLStatusRunning {
+			// The migration was cancelled while throttled.
+			return nil
+		}
+	}
+
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	conn, err := dbconnpool.NewDBConnection(ctx, e.env.Config().DB.DbaWithDB())
+	if err != nil {
+		return failMigration(err)
+	}
+	defer conn.Close()
+
+	// Unlike DDL statements, ANALYZE TABLE returns a result set, which reports errors as rows rather than as MySQL errors.
+	rs, err := conn.ExecuteFetch(onlineDDL.SQL, math.MaxInt32, true)
+	if err != nil {
+		return failMigration(err)
+	}
+	for _, row := range rs.Named().Rows {
+		if strings.EqualFold(row.AsString("Msg_type", ""), "error") {
+			return failMigration(vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "%s: %s", row.AsString("Table", ""), row.AsString("Msg_text", "")))
+		}
+	}
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusComplete, false, progressPctFull, etaSecondsNow, rowsCopiedUnknown, emptyHint)
+	return nil
+}
+
 func (e *Executor) executeDropDDLActionMigration(ctx context.Context, onlineDDL *schema.OnlineDDL) error {
 	failMigration := func(err error) error {
 		return e.failMigration(ctx, onlineDDL, err)
@@ -2641,7 +2766,7 @@ func (e *Executor) executeAlterDDLActionMigration(ctx context.Context, onlineDDL
 
 // executeMigration executes a single migration. It analyzes the migration type:
 // - is it declarative?
-// - is it CREATE / DROP / ALTER?
+// - is it CREATE / DROP / ALTER / ANALYZE?
 // - it is a Revert request?
 // - what's the migration strategy?
 // The function invokes the appropriate handlers for each of those cases.
@@ -2774,6 +2899,10 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 		}()
 	case sqlparser.AlterDDLAction:
 		return e.executeAlterDDLActionMigration(ctx, onlineDDL)
+	case sqlparser.AnalyzeDDLAction:
+		go func() error {
+			return e.executeAnalyzeDDLActionMigration(ctx, onlineDDL)
+		}()
 	case sqlparser.RevertDDLAction:
 		if err := e.executeRevert(ctx, onlineDDL); err != nil {
 			failMigration(err)
@@ -2803,6 +2932,18 @@ func (e *Executor) getNonConflictingMigration(ctx context.Context) (*schema.Onli
 		}
 		isImmediateOperation := migrationRow.AsBool("is_immediate_operation", false)
 
+		if window, err := schema.ParseScheduleWindow(migrationRow.AsString("schedule_window", "")); err == nil && !window.Contains(time.Now()) {
+			continue // outside this migration's schedule window
+		}
+		if dependsOnUUID := migrationRow.AsString("depends_on_uuid", ""); dependsOnUUID != "" {
+			satisfied, err := e.validateMigrationDependency(ctx, onlineDDL, dependsOnUUID)
+			if err != nil {
+				return nil, err
+			}
+			if !satisfied {
+				continue // dependency not yet complete
+			}
+		}
 		if conflictFound, _ := e.isAnyConflictingMigrationRunning(onlineDDL); conflictFound {
 			continue // this migration conflicts with a running one
 		}
@@ -3085,6 +3226,8 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 		if errForceCutOverAfter != nil {
 			forceCutOverAfter = 0
 		}
+		// --shadow-reads-percent is likewise validated when DDL strategy is first parsed.
+		shadowReadsPercent, _ := strategySetting.ShadowReadsPercent()
 
 		uuidsFoundRunning[uuid] = true
 
@@ -3182,6 +3325,7 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 				// In the case of a postponed migration, we will not complete it, but the user will
 				// understand whether "now is a good time" or "not there yet"
 				_ = e.updateMigrationReadyToComplete(ctx, uuid, isReady)
+				e.reviewShadowReadValidation(ctx, uuid, onlineDDL.Table, s, shadowReadsPercent, isReady, migrationRow)
 				if !isReady {
 					return nil
 				}
@@ -3195,6 +3339,18 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 						return nil
 					}
 				}
+				if !shouldForceCutOver {
+					// An explicit forced cut-over is the user's say-so; otherwise, we only cut over
+					// while a maintenance window is open for this keyspace.
+					inWindow, err := e.ts.InMaintenanceWindow(ctx, e.keyspace, time.Now())
+					if err != nil {
+						return err
+					}
+					if !inWindow {
+						_ = e.updateMigrationMessage(ctx, uuid, "waiting for a maintenance window to cut over")
+						return nil
+					}
+				}
 				shouldCutOver, shouldForceCutOver := shouldCutOverAccordingToBackoff(
 					shouldForceCutOver, forceCutOverAfter, sinceReadyToComplete, sinceLastCutoverAttempt, cutoverAttempts,
 				)
@@ -3244,6 +3400,8 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 			}
 			return true
 		})
+		// Stop mirroring reads for migrations which are no longer running
+		e.stopShadowReadValidations(uuidsFoundRunning)
 	}
 
 	e.reviewedRunningMigrationsFlag = true
@@ -3663,8 +3821,11 @@ func (e *Executor) updateMigrationStatusFailedOrCancelled(ctx context.Context, u
 	if err != nil {
 		return err
 	}
-	_, err = e.execQuery(ctx, query)
-	return err
+	if _, err := e.execQuery(ctx, query); err != nil {
+		return err
+	}
+	e.auditMigrationStatus(ctx, uuid)
+	return nil
 }
 
 func (e *Executor) updateMigrationStatus(ctx context.Context, uuid string, status schema.OnlineDDLStatus) error {
@@ -3679,8 +3840,32 @@ func (e *Executor) updateMigrationStatus(ctx context.Context, uuid string, statu
 	_, err = e.execQuery(ctx, query)
 	if err != nil {
 		log.Errorf("FAIL updateMigrationStatus: uuid=%s, query=%v, error=%v", uuid, query, err)
+		return err
 	}
-	return err
+	e.auditMigrationStatus(ctx, uuid)
+	return nil
+}
+
+// auditMigrationStatus emits an audit event with the current status of the migration.
+// A failed migration is reported with its message as error.
+func (e *Executor) auditMigrationStatus(ctx context.Context, uuid string) {
+	onlineDDL, row, err := e.readMigration(ctx, uuid)
+	if err != nil {
+		log.Errorf("auditMigrationStatus: cannot read migration %s: %v", uuid, err)
+		return
+	}
+	var migrationErr error
+	if onlineDDL.Status == schema.OnlineDDLStatusFailed {
+		migrationErr = errors.New(row["message"].ToString())
+	}
+	audit.Emit(ctx, &audit.Event{
+		Type:            audit.OnlineDDL,
+		Keyspace:        e.keyspace,
+		Shard:           e.shard,
+		MigrationUUIDs:  []string{uuid},
+		MigrationStatus: string(onlineDDL.Status),
+		Diff:            onlineDDL.SQL,
+	}, migrationErr)
 }
 
 func (e *Executor) updateDDLAction(ctx context.Context, uuid string, actionStr string) error {
@@ -3948,6 +4133,19 @@ func (e *Executor) updateMigrationUserThrottleRatio(ctx context.Context, uuid st
 	return err
 }
 
+func (e *Executor) updateMigrationShadowReads(ctx context.Context, uuid string, compared int64, mismatched int64) error {
+	query, err := sqlparser.ParseAndBind(sqlUpdateMigrationShadowReads,
+		sqltypes.Int64BindVariable(compared),
+		sqltypes.Int64BindVariable(mismatched),
+		sqltypes.StringBindVariable(uuid),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = e.execQuery(ctx, query)
+	return err
+}
+
 // retryMigrationWhere retries a migration based on a given WHERE clause
 func (e *Executor) retryMigrationWhere(ctx context.Context, whereExpr string) (result *sqltypes.Result, err error) {
 	e.migrationMutex.Lock()
@@ -4017,16 +4215,26 @@ func (e *Executor) CleanupMigration(ctx context.Context, uuid string) (result *s
 
 // CleanupMigration sets migration is ready for artifact cleanup. Artifacts are not immediately deleted:
 // all we do is set retain_artifacts_seconds to a very small number (it's actually a negative) so that the
-// next iteration of gcArtifacts() picks up the migration's artifacts and schedules them for deletion
-func (e *Executor) CleanupAllMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// next iteration of gcArtifacts() picks up the migration's artifacts and schedules them for deletion.
+// A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) CleanupAllMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
-	log.Infof("CleanupMigration: request to cleanup all terminal migrations")
+	log.Infof("CleanupMigration: request to cleanup all terminal migrations, context: %q", migrationContext)
 	e.migrationMutex.Lock()
 	defer e.migrationMutex.Unlock()
 
-	rs, err := e.execQuery(ctx, sqlUpdateReadyForCleanupAll)
+	query := sqlUpdateReadyForCleanupAll
+	if migrationContext != "" {
+		query, err = sqlparser.ParseAndBind(sqlUpdateReadyForCleanupAllInContext,
+			sqltypes.StringBindVariable(migrationContext),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rs, err := e.execQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -4069,13 +4277,14 @@ func (e *Executor) ForceCutOverMigration(ctx context.Context, uuid string) (resu
 	return rs, nil
 }
 
-// ForceCutOverPendingMigrations sets force_cutover flag for all pending migrations
-func (e *Executor) ForceCutOverPendingMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// ForceCutOverPendingMigrations sets force_cutover flag for all pending migrations.
+// A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) ForceCutOverPendingMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
 
-	uuids, err := e.readPendingMigrationsUUIDs(ctx)
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
 	if err != nil {
 		return result, err
 	}
@@ -4130,6 +4339,86 @@ func (e *Executor) SetMigrationCutOverThreshold(ctx context.Context, uuid string
 	return rs, nil
 }
 
+// SetMigrationScheduleWindow sets the daily UTC time window within which a pending migration may start running.
+// An empty window clears any previously set window.
+func (e *Executor) SetMigrationScheduleWindow(ctx context.Context, uuid string, window string) (result *sqltypes.Result, err error) {
+	if atomic.LoadInt64(&e.isOpen) == 0 {
+		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
+	}
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "Not a valid migration ID in SCHEDULE WINDOW: %s", uuid)
+	}
+	if _, err := schema.ParseScheduleWindow(window); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", err)
+	}
+
+	log.Infof("SetMigrationScheduleWindow: request to set schedule window to %q on migration %s", window, uuid)
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	query, err := sqlparser.ParseAndBind(sqlUpdateScheduleWindow,
+		sqltypes.StringBindVariable(window),
+		sqltypes.StringBindVariable(uuid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := e.execQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	e.triggerNextCheckInterval()
+	log.Infof("SetMigrationScheduleWindow: migration %s schedule window was set to %q", uuid, window)
+	return rs, nil
+}
+
+// SetMigrationDependency declares that a pending migration may only start running once another migration
+// is complete. If that other migration fails or is cancelled, the dependent migration fails.
+// An empty dependency clears any previously declared dependency.
+func (e *Executor) SetMigrationDependency(ctx context.Context, uuid string, dependsOnUUID string) (result *sqltypes.Result, err error) {
+	if atomic.LoadInt64(&e.isOpen) == 0 {
+		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
+	}
+	if !schema.IsOnlineDDLUUID(uuid) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "Not a valid migration ID in DEPENDS ON: %s", uuid)
+	}
+	if dependsOnUUID != "" {
+		if !schema.IsOnlineDDLUUID(dependsOnUUID) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Not a valid migration ID in DEPENDS ON: %s", dependsOnUUID)
+		}
+		if dependsOnUUID == uuid {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "migration %s cannot depend on itself", uuid)
+		}
+	}
+
+	log.Infof("SetMigrationDependency: request to make migration %s depend on %q", uuid, dependsOnUUID)
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	if dependsOnUUID != "" {
+		if _, _, err := e.readMigration(ctx, dependsOnUUID); err != nil {
+			if err == ErrMigrationNotFound {
+				return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "migration %s not found", dependsOnUUID)
+			}
+			return nil, err
+		}
+	}
+	query, err := sqlparser.ParseAndBind(sqlUpdateDependsOnUUID,
+		sqltypes.StringBindVariable(dependsOnUUID),
+		sqltypes.StringBindVariable(uuid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := e.execQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	e.triggerNextCheckInterval()
+	log.Infof("SetMigrationDependency: migration %s now depends on %q", uuid, dependsOnUUID)
+	return rs, nil
+}
+
 // CompleteMigration clears the postpone_completion flag for a given migration, assuming it was set in the first place
 func (e *Executor) CompleteMigration(ctx context.Context, uuid string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
@@ -4159,13 +4448,13 @@ func (e *Executor) CompleteMigration(ctx context.Context, uuid string) (result *
 }
 
 // CompletePendingMigrations completes all pending migrations (that are expected to run or are running)
-// for this keyspace
-func (e *Executor) CompletePendingMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// for this keyspace. A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) CompletePendingMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
 
-	uuids, err := e.readPendingMigrationsUUIDs(ctx)
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
 	if err != nil {
 		return result, err
 	}
@@ -4213,13 +4502,13 @@ func (e *Executor) PostponeCompleteMigration(ctx context.Context, uuid string) (
 }
 
 // PostponeCompletePendingMigrations sets postpone_completion for all pending migrations (that are expected to run or are running)
-// for this keyspace
-func (e *Executor) PostponeCompletePendingMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// for this keyspace. A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) PostponeCompletePendingMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
 
-	uuids, err := e.readPendingMigrationsUUIDs(ctx)
+	uuids, err := e.readPendingMigrationsUUIDsInContext(ctx, migrationContext)
 	if err != nil {
 		return result, err
 	}
@@ -4270,8 +4559,9 @@ func (e *Executor) LaunchMigration(ctx context.Context, uuid string, shardsArg s
 	return rs, nil
 }
 
-// LaunchMigrations launches all launch-postponed queued migrations for this keyspace
-func (e *Executor) LaunchMigrations(ctx context.Context) (result *sqltypes.Result, err error) {
+// LaunchMigrations launches all launch-postponed queued migrations for this keyspace.
+// A non-empty migration context limits the operation to migrations in that context.
+func (e *Executor) LaunchMigrations(ctx context.Context, migrationContext string) (result *sqltypes.Result, err error) {
 	if atomic.LoadInt64(&e.isOpen) == 0 {
 		return nil, vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, schema.ErrOnlineDDLDisabled.Error())
 	}
@@ -4288,6 +4578,9 @@ func (e *Executor) LaunchMigrations(ctx context.Context) (result *sqltypes.Resul
 	log.Infof("LaunchMigrations: iterating %v migrations %s", len(rows))
 	result = &sqltypes.Result{}
 	for _, row := range rows {
+		if migrationContext != "" && row["migration_context"].ToString() != migrationContext {
+			continue
+		}
 		uuid := row["migration_uuid"].ToString()
 		log.Infof("LaunchMigrations: unpostponing %s", uuid)
 		res, err := e.LaunchMigration(ctx, uuid, "")
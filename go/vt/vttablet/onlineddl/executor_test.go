@@ -26,6 +26,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 )
 
 func TestShouldCutOverAccordingToBackoff(t *testing.T) {
@@ -221,3 +226,55 @@ func TestSafeMigrationCutOverThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowConcurrentMigration(t *testing.T) {
+	e := &Executor{
+		env: tabletenv.NewEnv(vtenv.NewTestEnv(), tabletenv.NewDefaultConfig(), "AllowConcurrentMigrationTest"),
+	}
+	tcases := []struct {
+		sql           string
+		strategy      schema.DDLStrategy
+		options       string
+		expectAction  sqlparser.DDLAction
+		expectAllowed bool
+	}{
+		{
+			sql:           "create table t (id int primary key)",
+			strategy:      schema.DDLStrategyVitess,
+			options:       "--allow-concurrent",
+			expectAction:  sqlparser.CreateDDLAction,
+			expectAllowed: true,
+		},
+		{
+			sql:           "alter table t force",
+			strategy:      schema.DDLStrategyMySQL,
+			options:       "--allow-concurrent",
+			expectAction:  sqlparser.AlterDDLAction,
+			expectAllowed: false,
+		},
+		{
+			sql:           "analyze table t",
+			strategy:      schema.DDLStrategyVitess,
+			options:       "--allow-concurrent",
+			expectAction:  sqlparser.AnalyzeDDLAction,
+			expectAllowed: true,
+		},
+		{
+			sql:           "analyze table t",
+			strategy:      schema.DDLStrategyVitess,
+			expectAllowed: false,
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.sql+" "+tcase.options, func(t *testing.T) {
+			onlineDDL := &schema.OnlineDDL{
+				SQL:      tcase.sql,
+				Strategy: tcase.strategy,
+				Options:  tcase.options,
+			}
+			action, allowed := e.allowConcurrentMigration(onlineDDL)
+			assert.Equal(t, tcase.expectAction, action)
+			assert.Equal(t, tcase.expectAllowed, allowed)
+		})
+	}
+}
@@ -44,6 +44,7 @@ const (
 
 	sqlSelectQueuedMigrations = `SELECT
 			migration_uuid,
+			migration_context,
 			ddl_action,
 			is_view,
 			is_immediate_operation,
@@ -118,6 +119,11 @@ const (
 		WHERE
 			migration_uuid=%a
 	`
+	sqlUpdateMigrationShadowReads = `UPDATE _vt.schema_migrations
+			SET shadow_reads_compared=%a, shadow_reads_mismatched=%a
+		WHERE
+			migration_uuid=%a
+	`
 	sqlUpdateMigrationStartedTimestamp = `UPDATE _vt.schema_migrations SET
 			started_timestamp =IFNULL(started_timestamp,  NOW(6)),
 			liveness_timestamp=IFNULL(liveness_timestamp, NOW(6))
@@ -182,6 +188,14 @@ const (
 			AND cleanup_timestamp IS NULL
 			AND retain_artifacts_seconds > 0
 	`
+	sqlUpdateReadyForCleanupAllInContext = `UPDATE _vt.schema_migrations
+			SET retain_artifacts_seconds=-1
+		WHERE
+			migration_status IN ('complete', 'cancelled', 'failed')
+			AND cleanup_timestamp IS NULL
+			AND retain_artifacts_seconds > 0
+			AND migration_context=%a
+	`
 	sqlUpdateForceCutOver = `UPDATE _vt.schema_migrations
 			SET force_cutover=1
 		WHERE
@@ -192,6 +206,18 @@ const (
 		WHERE
 			migration_uuid=%a
 	`
+	sqlUpdateScheduleWindow = `UPDATE _vt.schema_migrations
+			SET schedule_window=%a
+		WHERE
+			migration_uuid=%a
+			AND migration_status IN ('queued', 'ready')
+	`
+	sqlUpdateDependsOnUUID = `UPDATE _vt.schema_migrations
+			SET depends_on_uuid=%a
+		WHERE
+			migration_uuid=%a
+			AND migration_status IN ('queued', 'ready')
+	`
 	sqlUpdateLaunchMigration = `UPDATE _vt.schema_migrations
 			SET postpone_launch=0
 		WHERE
@@ -447,6 +473,10 @@ const (
 			migration_context,
 			retain_artifacts_seconds,
 			cutover_threshold_seconds,
+			schedule_window,
+			depends_on_uuid,
+			shadow_reads_compared,
+			shadow_reads_mismatched,
 			is_view,
 			ready_to_complete,
 			ready_to_complete_timestamp is not null as was_ready_to_complete,
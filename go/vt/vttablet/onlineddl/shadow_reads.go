@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+const (
+	shadowReadMatch            = "Match"
+	shadowReadRowCountMismatch = "RowCountMismatch"
+	shadowReadRowsMismatch     = "RowsMismatch"
+	shadowReadError            = "Error"
+)
+
+var shadowReadComparisons = stats.NewCountersWithSingleLabel(
+	"OnlineDDLShadowReadComparisons",
+	"Number of reads mirrored onto the shadow table of a migration, by the outcome of comparing their results",
+	"Result")
+
+// ShadowReadValidation mirrors a sample of the reads on the table of a migration onto its shadow table,
+// once the shadow table is in sync, and compares the results. The number of compared reads and the number
+// of mismatching reads make up a confidence report for the migration, ahead of its cut-over.
+type ShadowReadValidation struct {
+	UUID        string
+	Table       string
+	ShadowTable string
+	Percent     float64
+
+	compared   atomic.Int64
+	mismatched atomic.Int64
+}
+
+func newShadowReadValidation(uuid, table, shadowTable string, percent float64, compared, mismatched int64) *ShadowReadValidation {
+	v := &ShadowReadValidation{
+		UUID:        uuid,
+		Table:       table,
+		ShadowTable: shadowTable,
+		Percent:     percent,
+	}
+	v.compared.Store(compared)
+	v.mismatched.Store(mismatched)
+	return v
+}
+
+// Sample rolls the dice on whether a read should be mirrored onto the shadow table.
+func (v *ShadowReadValidation) Sample() bool {
+	return v.Percent >= rand.Float64()*100.0
+}
+
+// ShadowStatement returns a copy of the given SELECT statement which reads from the shadow table
+// instead of the migrated table. The shadow table is aliased with the name of the migrated table,
+// so that qualified column names still resolve.
+func (v *ShadowReadValidation) ShadowStatement(sel *sqlparser.Select) *sqlparser.Select {
+	shadowSel := sqlparser.Clone(sel)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		aliased, ok := node.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return true, nil
+		}
+		tableName, ok := aliased.Expr.(sqlparser.TableName)
+		if !ok || tableName.Name.String() != v.Table {
+			return true, nil
+		}
+		if aliased.As.IsEmpty() {
+			aliased.As = tableName.Name
+		}
+		aliased.Expr = sqlparser.TableName{Name: sqlparser.NewIdentifierCS(v.ShadowTable), Qualifier: tableName.Qualifier}
+		return true, nil
+	}, shadowSel)
+	return shadowSel
+}
+
+// Record compares the result of a read with the result of the same read on the shadow table.
+func (v *ShadowReadValidation) Record(source, target *sqltypes.Result, targetErr error) {
+	var outcome string
+	if targetErr != nil {
+		outcome = shadowReadError
+	} else {
+		var sourceDigest, targetDigest sqltypes.ResultDigest
+		sourceDigest.Add(source)
+		targetDigest.Add(target)
+		switch {
+		case sourceDigest.Rows != targetDigest.Rows:
+			outcome = shadowReadRowCountMismatch
+		case sourceDigest.Sum != targetDigest.Sum:
+			outcome = shadowReadRowsMismatch
+		default:
+			outcome = shadowReadMatch
+		}
+	}
+	shadowReadComparisons.Add(outcome, 1)
+	v.compared.Add(1)
+	if outcome != shadowReadMatch {
+		v.mismatched.Add(1)
+	}
+}
+
+// Compared returns the number of reads compared so far.
+func (v *ShadowReadValidation) Compared() int64 {
+	return v.compared.Load()
+}
+
+// Mismatched returns the number of compared reads whose results did not match, or which failed on the shadow table.
+func (v *ShadowReadValidation) Mismatched() int64 {
+	return v.mismatched.Load()
+}
+
+// ShadowReadValidation returns the shadow read validation of the migration running on the given table,
+// or nil if there is none.
+func (e *Executor) ShadowReadValidation(table string) *ShadowReadValidation {
+	v, ok := e.shadowReadValidations.Load(table)
+	if !ok {
+		return nil
+	}
+	return v.(*ShadowReadValidation)
+}
+
+// reviewShadowReadValidation starts or stops mirroring reads onto the shadow table of a running vreplication
+// migration with --shadow-reads-percent, according to whether the shadow table is in sync, and persists the
+// confidence report of the migration.
+func (e *Executor) reviewShadowReadValidation(ctx context.Context, uuid string, table string, s *VReplStream, percent float64, isReady bool, migrationRow sqltypes.RowNamedValues) {
+	if percent <= 0 {
+		return
+	}
+	v := e.ShadowReadValidation(table)
+	if v != nil && v.UUID == uuid {
+		_ = e.updateMigrationShadowReads(ctx, uuid, v.Compared(), v.Mismatched())
+		if !isReady {
+			// The shadow table fell behind; its results are not comparable until it catches up.
+			e.shadowReadValidations.Delete(table)
+		}
+		return
+	}
+	if !isReady {
+		return
+	}
+	shadowTable, err := getVreplTable(s)
+	if err != nil {
+		return
+	}
+	v = newShadowReadValidation(uuid, table, shadowTable, percent,
+		migrationRow.AsInt64("shadow_reads_compared", 0),
+		migrationRow.AsInt64("shadow_reads_mismatched", 0),
+	)
+	e.shadowReadValidations.Store(table, v)
+	log.Infof("migration %s: mirroring %v%% of reads on %s onto shadow table %s", uuid, percent, table, shadowTable)
+}
+
+// stopShadowReadValidations stops mirroring reads for any migration not listed in the given running migrations.
+// With an empty list, it stops mirroring reads for all migrations.
+func (e *Executor) stopShadowReadValidations(runningUUIDs map[string]bool) {
+	e.shadowReadValidations.Range(func(k, val any) bool {
+		if v := val.(*ShadowReadValidation); !runningUUIDs[v.UUID] {
+			e.shadowReadValidations.Delete(k)
+		}
+		return true
+	})
+}
+
+// stopShadowReadValidation stops mirroring reads for the given migration.
+func (e *Executor) stopShadowReadValidation(uuid string) {
+	e.shadowReadValidations.Range(func(k, val any) bool {
+		if v := val.(*ShadowReadValidation); v.UUID == uuid {
+			e.shadowReadValidations.Delete(k)
+		}
+		return true
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestShadowReadValidationShadowStatement(t *testing.T) {
+	v := newShadowReadValidation("uuid", "t", "_vt_vrp_shadow", 100, 0, 0)
+	tcases := []struct {
+		query  string
+		expect string
+	}{
+		{
+			query:  "select id, t.name from t where id = 1",
+			expect: "select id, t.`name` from _vt_vrp_shadow as t where id = 1",
+		},
+		{
+			query:  "select x.id from t as x where x.id in (select id from t where val > 0)",
+			expect: "select x.id from _vt_vrp_shadow as x where x.id in (select id from _vt_vrp_shadow as t where val > 0)",
+		},
+		{
+			query:  "select t.id from t join u on t.id = u.id",
+			expect: "select t.id from _vt_vrp_shadow as t join u on t.id = u.id",
+		},
+		{
+			query:  "select id from u",
+			expect: "select id from u",
+		},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range tcases {
+		t.Run(tcase.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tcase.query)
+			require.NoError(t, err)
+			sel, ok := stmt.(*sqlparser.Select)
+			require.True(t, ok)
+
+			shadowSel := v.ShadowStatement(sel)
+			assert.Equal(t, tcase.expect, sqlparser.String(shadowSel))
+			// The original statement is untouched
+			assert.Equal(t, sqlparser.String(stmt), sqlparser.String(sel))
+		})
+	}
+}
+
+func TestShadowReadValidationRecord(t *testing.T) {
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	source := sqltypes.MakeTestResult(fields, "1|a", "2|b")
+
+	v := newShadowReadValidation("uuid", "t", "_vt_vrp_shadow", 100, 10, 1)
+	v.Record(source, sqltypes.MakeTestResult(fields, "2|b", "1|a"), nil)
+	assert.EqualValues(t, 11, v.Compared())
+	assert.EqualValues(t, 1, v.Mismatched())
+
+	v.Record(source, sqltypes.MakeTestResult(fields, "1|a"), nil)
+	v.Record(source, sqltypes.MakeTestResult(fields, "1|a", "2|c"), nil)
+	v.Record(source, nil, errors.New("unknown column"))
+	assert.EqualValues(t, 14, v.Compared())
+	assert.EqualValues(t, 4, v.Mismatched())
+}
+
+func TestShadowReadValidationSample(t *testing.T) {
+	assert.True(t, newShadowReadValidation("uuid", "t", "_vt_vrp_shadow", 100, 0, 0).Sample())
+	assert.False(t, newShadowReadValidation("uuid", "t", "_vt_vrp_shadow", 0, 0, 0).Sample())
+}
+
+func TestStopShadowReadValidations(t *testing.T) {
+	e := &Executor{}
+	e.shadowReadValidations.Store("t1", newShadowReadValidation("uuid1", "t1", "_vt_vrp_shadow1", 10, 0, 0))
+	e.shadowReadValidations.Store("t2", newShadowReadValidation("uuid2", "t2", "_vt_vrp_shadow2", 10, 0, 0))
+	e.shadowReadValidations.Store("t3", newShadowReadValidation("uuid3", "t3", "_vt_vrp_shadow3", 10, 0, 0))
+
+	require.NotNil(t, e.ShadowReadValidation("t1"))
+	assert.Nil(t, e.ShadowReadValidation("t4"))
+
+	e.stopShadowReadValidations(map[string]bool{"uuid1": true, "uuid2": true})
+	assert.NotNil(t, e.ShadowReadValidation("t1"))
+	assert.NotNil(t, e.ShadowReadValidation("t2"))
+	assert.Nil(t, e.ShadowReadValidation("t3"))
+
+	e.stopShadowReadValidation("uuid2")
+	assert.NotNil(t, e.ShadowReadValidation("t1"))
+	assert.Nil(t, e.ShadowReadValidation("t2"))
+}
@@ -43,8 +43,11 @@ type QueryService interface {
 	// Begin returns the transaction id to use for further operations
 	Begin(ctx context.Context, target *querypb.Target, options *querypb.ExecuteOptions) (TransactionState, error)
 
-	// Commit commits the current transaction
-	Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error)
+	// Commit commits the current transaction. It returns the new reserved id
+	// of the connection, and the session state changes of the commit, which
+	// are the GTID of the transaction if it was begun with the
+	// session_track_gtids option.
+	Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error)
 
 	// Rollback aborts the current transaction
 	Rollback(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error)
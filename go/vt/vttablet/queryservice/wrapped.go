@@ -118,17 +118,18 @@ func (ws *wrappedService) Begin(ctx context.Context, target *querypb.Target, opt
 	return state, wrapFatalTxErrorInVTError(err, true, vterrors.VT15001)
 }
 
-func (ws *wrappedService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
+func (ws *wrappedService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
 	var rID int64
+	var sessionStateChanges string
 	err := ws.wrapper(ctx, target, ws.impl, "Commit", true, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
-		rID, innerErr = conn.Commit(ctx, target, transactionID)
+		rID, sessionStateChanges, innerErr = conn.Commit(ctx, target, transactionID)
 		return canRetry(ctx, innerErr), innerErr
 	})
 	if err != nil {
-		return 0, wrapFatalTxErrorInVTError(err, transactionID != 0, vterrors.VT15001)
+		return 0, "", wrapFatalTxErrorInVTError(err, transactionID != 0, vterrors.VT15001)
 	}
-	return rID, nil
+	return rID, sessionStateChanges, nil
 }
 
 func (ws *wrappedService) Rollback(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
@@ -82,6 +82,9 @@ type SandboxConn struct {
 	ReleaseCount                atomic.Int64
 	GetSchemaCount              atomic.Int64
 	GetSchemaDelayResponse      time.Duration
+	// ExecuteDelayResponse delays the responses of Execute, until the
+	// context is done.
+	ExecuteDelayResponse time.Duration
 
 	queriesRequireLocking bool
 	queriesMu             sync.Mutex
@@ -106,6 +109,10 @@ type SandboxConn struct {
 	// UnresolvedTransactionsResult is used for returning results for UnresolvedTransactions.
 	UnresolvedTransactionsResult []*querypb.TransactionMetadata
 
+	// CommitSessionStateChanges is returned by Commit as the session state
+	// changes of the commit.
+	CommitSessionStateChanges string
+
 	MessageIDs []*querypb.Value
 
 	// vstream expectations.
@@ -264,6 +271,12 @@ func (sbc *SandboxConn) SetSchemaResult(r []SchemaResult) {
 // Execute is part of the QueryService interface.
 func (sbc *SandboxConn) Execute(ctx context.Context, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
 	sbc.panicIfNeeded()
+	if sbc.ExecuteDelayResponse > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(sbc.ExecuteDelayResponse):
+		}
+	}
 	sbc.execMu.Lock()
 	defer sbc.execMu.Unlock()
 	sbc.ExecCount.Add(1)
@@ -362,14 +375,17 @@ func (sbc *SandboxConn) begin(ctx context.Context, target *querypb.Target, preQu
 }
 
 // Commit is part of the QueryService interface.
-func (sbc *SandboxConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
+func (sbc *SandboxConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
 	sbc.panicIfNeeded()
 	sbc.CommitCount.Add(1)
 	reservedID := sbc.getTxReservedID(transactionID)
 	if reservedID != 0 {
 		reservedID = sbc.ReserveID.Add(1)
 	}
-	return reservedID, sbc.getError()
+	if err := sbc.getError(); err != nil {
+		return reservedID, "", err
+	}
+	return reservedID, sbc.CommitSessionStateChanges, nil
 }
 
 // Rollback is part of the QueryService interface.
@@ -799,7 +815,8 @@ func (sbc *SandboxConn) getNextResult(stmt sqlparser.Statement) *sqltypes.Result
 		sqlparser.DDLStatement,
 		*sqlparser.AlterVschema,
 		*sqlparser.Use,
-		*sqlparser.OtherAdmin:
+		*sqlparser.OtherAdmin,
+		*sqlparser.OptimizeTable:
 		return &sqltypes.Result{}
 	}
 
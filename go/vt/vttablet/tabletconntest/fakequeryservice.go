@@ -158,10 +158,13 @@ func (f *FakeQueryService) Begin(ctx context.Context, target *querypb.Target, op
 // commitTransactionID is a test transaction id for Commit.
 const commitTransactionID int64 = 999044
 
+// commitSessionStateChanges is a test session state change for Commit.
+const commitSessionStateChanges = "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:1-44"
+
 // Commit is part of the queryservice.QueryService interface
-func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
+func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
 	if f.HasError {
-		return 0, f.TabletError
+		return 0, "", f.TabletError
 	}
 	if f.Panics {
 		panic(fmt.Errorf("test-triggered panic"))
@@ -170,7 +173,7 @@ func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, t
 	if transactionID != commitTransactionID {
 		f.t.Errorf("Commit: invalid TransactionId: got %v expected %v", transactionID, commitTransactionID)
 	}
-	return 0, nil
+	return 0, commitSessionStateChanges, nil
 }
 
 // rollbackTransactionID is a test transaction id for Rollback.
@@ -0,0 +1,320 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// AuditLogHandler is the debug UI path for streaming the audit log.
+var AuditLogHandler = "/debug/audit_log"
+
+// maxAuditLogArgumentsLength bounds the length of the arguments of an audit
+// log entry, as some RPCs like ApplySchema carry full schema definitions.
+const maxAuditLogArgumentsLength = 4096
+
+var (
+	auditLogFile string
+	auditLogSize = 1000
+
+	// auditLogger streams the entries of the audit log of all the tablets of
+	// the process to /debug/audit_log and to --audit-log-file.
+	auditLogger        = streamlog.New[*auditLogEntry]("TabletAuditLog", 100)
+	auditLoggerOnce    sync.Once
+	auditLoggerInitErr error
+)
+
+func registerAuditLogFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&auditLogFile, "audit-log-file", auditLogFile, "If set, the audit log of the tablet manager RPCs is also written to the specified file, as one JSON object per line. The most recent entries of the file are loaded back when the tablet starts.")
+	fs.IntVar(&auditLogSize, "audit-log-size", auditLogSize, "Number of the most recent entries of the audit log of the tablet manager RPCs kept in memory and returned by the GetAuditLog RPC.")
+}
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerAuditLogFlags)
+	servenv.OnParseFor("vttablet", registerAuditLogFlags)
+}
+
+// readOnlyRPCs are the tablet manager RPCs that are not recorded in the audit
+// log. They do not change the tablet, and most of them are continuously
+// called by vtctld, vtorc and the other tablets.
+var readOnlyRPCs = map[string]bool{
+	"Ping":                            true,
+	"GetSchema":                       true,
+	"GetPermissions":                  true,
+	"GetGlobalStatusVars":             true,
+	"GetAuditLog":                     true,
+	"RunHealthCheck":                  true,
+	"GetUnresolvedTransactions":       true,
+	"ReadTransaction":                 true,
+	"GetTransactionInfo":              true,
+	"MysqlHostMetrics":                true,
+	"ReplicationStatus":               true,
+	"FullStatus":                      true,
+	"PrimaryStatus":                   true,
+	"PrimaryPosition":                 true,
+	"WaitForPosition":                 true,
+	"GetReplicas":                     true,
+	"HasVReplicationWorkflows":        true,
+	"ReadVReplicationWorkflows":       true,
+	"ReadVReplicationWorkflow":        true,
+	"ValidateVReplicationPermissions": true,
+	"VReplicationWaitForPos":          true,
+	"GetMaxValueForSequences":         true,
+	"ReadReparentJournalInfo":         true,
+	"CheckThrottler":                  true,
+	"GetThrottlerStatus":              true,
+}
+
+// auditLogEntry is an entry of the audit log: a tablet manager RPC executed
+// by a tablet.
+type auditLogEntry struct {
+	Time   time.Time
+	Tablet string
+	RPC    string
+	// Caller is the remote address and the method of the RPC, and Username
+	// the user authenticated by the gRPC static auth plugin, if any.
+	Caller   string
+	Username string `json:",omitempty"`
+	// Arguments are the arguments of the RPC, with the literals of the
+	// queries redacted.
+	Arguments string
+	Error     string `json:",omitempty"`
+}
+
+// Logf formats the entry as a line of JSON.
+func (e *auditLogEntry) Logf(w io.Writer, _ url.Values) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (e *auditLogEntry) toProto() *tabletmanagerdatapb.AuditLogEntry {
+	return &tabletmanagerdatapb.AuditLogEntry{
+		Time:      protoutil.TimeToProto(e.Time),
+		Rpc:       e.RPC,
+		Caller:    e.Caller,
+		Username:  e.Username,
+		Arguments: e.Arguments,
+		Error:     e.Error,
+	}
+}
+
+// auditLog keeps the most recent audit log entries of a tablet.
+type auditLog struct {
+	tablet string
+	size   int
+
+	mu      sync.Mutex
+	entries []*auditLogEntry
+}
+
+// newAuditLog returns the audit log of the tablet. The first call also starts
+// streaming the audit log to /debug/audit_log and --audit-log-file.
+func newAuditLog(tablet string) (*auditLog, error) {
+	al := &auditLog{
+		tablet: tablet,
+		size:   max(auditLogSize, 0),
+	}
+	if auditLogFile != "" {
+		if err := al.load(auditLogFile); err != nil {
+			return nil, err
+		}
+	}
+	auditLoggerOnce.Do(func() {
+		auditLogger.ServeLogs(AuditLogHandler, streamlog.GetFormatter(auditLogger))
+		if auditLogFile != "" {
+			_, auditLoggerInitErr = auditLogger.LogToFile(auditLogFile, streamlog.GetFormatter(auditLogger))
+		}
+	})
+	if auditLoggerInitErr != nil {
+		return nil, auditLoggerInitErr
+	}
+	return al, nil
+}
+
+// load reads the most recent entries of the tablet back from the audit log
+// file written by a previous run.
+func (al *auditLog) load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry auditLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip the entries that can't be parsed, like a last line that
+			// was partially written before a crash.
+			continue
+		}
+		if entry.Tablet == al.tablet {
+			al.add(&entry)
+		}
+	}
+	return scanner.Err()
+}
+
+func (al *auditLog) add(entry *auditLogEntry) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	al.entries = append(al.entries, entry)
+	if len(al.entries) > al.size {
+		al.entries = al.entries[len(al.entries)-al.size:]
+	}
+}
+
+// record adds the entry to the audit log and sends it to the log streams.
+func (al *auditLog) record(entry *auditLogEntry) {
+	al.add(entry)
+	auditLogger.Send(entry)
+}
+
+// get returns the limit most recent entries of the RPC, or of all the RPCs if
+// rpc is empty. A limit of 0 returns all the entries.
+func (al *auditLog) get(rpc string, limit int) []*auditLogEntry {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	var entries []*auditLogEntry
+	for i := len(al.entries) - 1; i >= 0 && (limit <= 0 || len(entries) < limit); i-- {
+		if rpc == "" || al.entries[i].RPC == rpc {
+			entries = append(entries, al.entries[i])
+		}
+	}
+	// The entries were collected from the most recent one.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries
+}
+
+// GetAuditLog is part of the RPCTM interface.
+func (tm *TabletManager) GetAuditLog(ctx context.Context, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error) {
+	resp := &tabletmanagerdatapb.GetAuditLogResponse{}
+	if tm.auditLog == nil {
+		return resp, nil
+	}
+	for _, entry := range tm.auditLog.get(req.Rpc, int(req.Limit)) {
+		resp.Entries = append(resp.Entries, entry.toProto())
+	}
+	return resp, nil
+}
+
+// recordAuditLog records the RPC in the audit log of the tablet, unless it is
+// a read-only RPC.
+func (tm *TabletManager) recordAuditLog(ctx context.Context, name string, args any, err error) {
+	if tm.auditLog == nil || readOnlyRPCs[name] {
+		return
+	}
+	// The RPC handlers defer HandleRPCPanic before they add the gRPC call
+	// info to their context.
+	if _, ok := callinfo.FromContext(ctx); !ok {
+		ctx = callinfo.GRPCCallInfo(ctx)
+	}
+	entry := &auditLogEntry{
+		Time:      time.Now(),
+		Tablet:    tm.auditLog.tablet,
+		RPC:       name,
+		Username:  servenv.StaticAuthUsernameFromContext(ctx),
+		Arguments: tm.auditLogArguments(args),
+	}
+	if ci, ok := callinfo.FromContext(ctx); ok {
+		entry.Caller = ci.Text()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	tm.auditLog.record(entry)
+}
+
+// auditLogArguments formats the arguments of an RPC for the audit log. The
+// literals of the queries are redacted, as they can hold passwords and
+// personal data.
+func (tm *TabletManager) auditLogArguments(args any) string {
+	var text string
+	if msg, ok := args.(proto.Message); ok && msg.ProtoReflect().IsValid() {
+		msg = proto.Clone(msg)
+		m := msg.ProtoReflect()
+		for _, name := range []protoreflect.Name{"query", "sql"} {
+			fd := m.Descriptor().Fields().ByName(name)
+			if fd == nil || !m.Has(fd) {
+				continue
+			}
+			switch fd.Kind() {
+			case protoreflect.StringKind:
+				m.Set(fd, protoreflect.ValueOfString(tm.redactAuditLogSQL(m.Get(fd).String())))
+			case protoreflect.BytesKind:
+				m.Set(fd, protoreflect.ValueOfBytes([]byte(tm.redactAuditLogSQL(string(m.Get(fd).Bytes())))))
+			}
+		}
+		text = fmt.Sprintf("%v", msg)
+	} else if args != nil {
+		text = fmt.Sprintf("%v", args)
+	}
+	if len(text) > maxAuditLogArgumentsLength {
+		text = text[:maxAuditLogArgumentsLength-len(sqlparser.TruncationText)-1] + " " + sqlparser.TruncationText
+	}
+	return text
+}
+
+// redactAuditLogSQL redacts the literals of the statements of sql.
+func (tm *TabletManager) redactAuditLogSQL(sql string) string {
+	parser := tm.Env.Parser()
+	pieces, err := parser.SplitStatementToPieces(sql)
+	if err != nil {
+		return "[REDACTED]"
+	}
+	for i, piece := range pieces {
+		redacted, err := parser.RedactSQLQuery(piece)
+		if err != nil {
+			redacted = "[REDACTED]"
+		}
+		pieces[i] = redacted
+	}
+	return strings.Join(pieces, ";")
+}
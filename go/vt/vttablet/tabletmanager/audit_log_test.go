@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtenv"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestAuditLogRecord(t *testing.T) {
+	ctx := context.Background()
+	tm := &TabletManager{
+		Env:      vtenv.NewTestEnv(),
+		auditLog: &auditLog{tablet: "zone1-0000000100", size: 3},
+	}
+	callRPC := func(name string, args any, rpcErr error) {
+		err := rpcErr
+		func() {
+			defer tm.HandleRPCPanic(ctx, name, args, nil, false /*verbose*/, &err)
+		}()
+	}
+
+	callRPC("Ping", &tabletmanagerdatapb.PingRequest{Payload: "payload"}, nil)
+	callRPC("ExecuteFetchAsDba", &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:  []byte("update users set password = 'secret' where id = 1"),
+		DbName: "vt_commerce",
+	}, nil)
+	callRPC("ChangeType", &tabletmanagerdatapb.ChangeTypeRequest{TabletType: topodatapb.TabletType_RDONLY}, errors.New("cannot change type"))
+
+	resp, err := tm.GetAuditLog(ctx, &tabletmanagerdatapb.GetAuditLogRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+
+	entry := resp.Entries[0]
+	assert.Equal(t, "ExecuteFetchAsDba", entry.Rpc)
+	assert.Contains(t, entry.Arguments, "vt_commerce")
+	assert.Contains(t, entry.Arguments, "update users set")
+	assert.NotContains(t, entry.Arguments, "secret")
+	assert.Empty(t, entry.Error)
+	assert.NotNil(t, entry.Time)
+
+	entry = resp.Entries[1]
+	assert.Equal(t, "ChangeType", entry.Rpc)
+	assert.Contains(t, entry.Arguments, "RDONLY")
+	assert.Equal(t, "cannot change type", entry.Error)
+
+	// A panicking RPC is recorded with its error.
+	func() {
+		var err error
+		defer tm.HandleRPCPanic(ctx, "SetReadOnly", &tabletmanagerdatapb.SetReadOnlyRequest{}, nil, true /*verbose*/, &err)
+		panic("test-triggered panic")
+	}()
+	callRPC("ReloadSchema", &tabletmanagerdatapb.ReloadSchemaRequest{}, nil)
+
+	// Only the 3 most recent entries are kept.
+	resp, err = tm.GetAuditLog(ctx, &tabletmanagerdatapb.GetAuditLogRequest{})
+	require.NoError(t, err)
+	var rpcs []string
+	for _, entry := range resp.Entries {
+		rpcs = append(rpcs, entry.Rpc)
+	}
+	assert.Equal(t, []string{"ChangeType", "SetReadOnly", "ReloadSchema"}, rpcs)
+	assert.Contains(t, resp.Entries[1].Error, "test-triggered panic")
+
+	resp, err = tm.GetAuditLog(ctx, &tabletmanagerdatapb.GetAuditLogRequest{Rpc: "SetReadOnly"})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "SetReadOnly", resp.Entries[0].Rpc)
+
+	resp, err = tm.GetAuditLog(ctx, &tabletmanagerdatapb.GetAuditLogRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "ReloadSchema", resp.Entries[0].Rpc)
+}
+
+func TestAuditLogArgumentsTruncated(t *testing.T) {
+	tm := &TabletManager{Env: vtenv.NewTestEnv()}
+	args := tm.auditLogArguments(&tabletmanagerdatapb.PreflightSchemaRequest{
+		Changes: []string{strings.Repeat("x", 2*maxAuditLogArgumentsLength)},
+	})
+	assert.Len(t, args, maxAuditLogArgumentsLength)
+	assert.True(t, strings.HasSuffix(args, "[TRUNCATED]"), args)
+}
+
+func TestAuditLogLoad(t *testing.T) {
+	var lines []string
+	for i, tablet := range []string{"zone1-0000000100", "zone1-0000000101", "zone1-0000000100", "zone1-0000000100"} {
+		entry := &auditLogEntry{
+			Time:      time.Date(2025, 1, 15, 10, i, 0, 0, time.UTC),
+			Tablet:    tablet,
+			RPC:       "ChangeType",
+			Arguments: "tablet_type:RDONLY",
+		}
+		var b strings.Builder
+		require.NoError(t, entry.Logf(&b, nil))
+		lines = append(lines, b.String())
+	}
+	// The last entry was only partially written.
+	lines = append(lines, `{"Time":"2025-01-15T10:`)
+	file := path.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(file, []byte(strings.Join(lines, "")), 0o644))
+
+	al := &auditLog{tablet: "zone1-0000000100", size: 2}
+	require.NoError(t, al.load(file))
+	entries := al.get("", 0)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 2, entries[0].Time.Minute())
+	assert.Equal(t, 3, entries[1].Time.Minute())
+
+	// A missing file is an empty audit log.
+	al = &auditLog{tablet: "zone1-0000000100", size: 2}
+	require.NoError(t, al.load(path.Join(t.TempDir(), "missing.log")))
+	assert.Empty(t, al.get("", 0))
+}
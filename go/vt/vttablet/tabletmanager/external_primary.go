@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	unmanagedEndpointCheckInterval = 5 * time.Second
+
+	statsEndpointSwitches = stats.NewCounter("UnmanagedEndpointSwitches", "Number of times the endpoint of the external MySQL of an unmanaged tablet started pointing to another server")
+)
+
+func registerExternalPrimaryFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&unmanagedEndpointCheckInterval, "unmanaged-endpoint-check-interval", unmanagedEndpointCheckInterval, "How often an unmanaged tablet checks which MySQL server its endpoint points to, and whether it is writable, to follow the failovers of an external primary. 0 disables the check.")
+}
+
+func init() {
+	servenv.OnParseFor("vttablet", registerExternalPrimaryFlags)
+}
+
+// endpointState is the state of the MySQL server behind the endpoint of an
+// unmanaged tablet.
+type endpointState struct {
+	serverUUID string
+	readOnly   bool
+}
+
+// startEndpointWatch starts following the MySQL server behind the endpoint
+// of an unmanaged tablet.
+//
+// Managed databases such as RDS fail over by pointing their endpoint, usually
+// a DNS name, to another server, which the tablet neither controls nor is
+// told about. So the tablet checks the server behind its endpoint
+// periodically, on a new connection:
+//   - A primary tablet stops serving while its server is read-only, e.g.
+//     while the previous primary is demoted, so that vtgate buffers the
+//     requests. Once the server is writable again, a new primary term starts.
+//   - When the endpoint points to another server, all the connections to
+//     the previous one are closed.
+func (tm *TabletManager) startEndpointWatch() {
+	if !tm.unmanaged || unmanagedEndpointCheckInterval <= 0 {
+		return
+	}
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	tm._endpointWatchCancel = cancel
+	tm._endpointWatchDone = make(chan struct{})
+	go tm.endpointWatchLoop(ctx, unmanagedEndpointCheckInterval, tm._endpointWatchDone)
+}
+
+func (tm *TabletManager) stopEndpointWatch() {
+	tm.mutex.Lock()
+	if tm._endpointWatchCancel != nil {
+		tm._endpointWatchCancel()
+	}
+	doneChan := tm._endpointWatchDone
+	tm.mutex.Unlock()
+
+	if doneChan != nil {
+		<-doneChan
+	}
+}
+
+func (tm *TabletManager) endpointWatchLoop(ctx context.Context, interval time.Duration, doneChan chan<- struct{}) {
+	defer close(doneChan)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last endpointState
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := tm.readEndpointState(ctx)
+		if err != nil {
+			// The tabletserver itself notices when MySQL is unreachable.
+			log.Warningf("Cannot check the MySQL server of the endpoint: %v", err)
+			continue
+		}
+		last = tm.followEndpoint(ctx, last, current)
+	}
+}
+
+// readEndpointState returns the state of the server behind the endpoint.
+// It uses a new connection, so that the endpoint is resolved again.
+func (tm *TabletManager) readEndpointState(ctx context.Context) (endpointState, error) {
+	cp := tm.DBConfigs.AppWithDB()
+	conn, err := cp.Connect(ctx)
+	if err != nil {
+		return endpointState{}, err
+	}
+	defer conn.Close()
+
+	qr, err := conn.ExecuteFetch("select @@global.server_uuid, @@global.read_only", 1, false)
+	if err != nil {
+		return endpointState{}, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 2 {
+		return endpointState{}, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for server_uuid and read_only: %v", qr.Rows)
+	}
+	readOnly, err := qr.Rows[0][1].ToBool()
+	if err != nil {
+		return endpointState{}, err
+	}
+	return endpointState{
+		serverUUID: qr.Rows[0][0].ToString(),
+		readOnly:   readOnly,
+	}, nil
+}
+
+// followEndpoint reacts to the change of the endpoint state from last to
+// current, and returns the state the next check should be compared with.
+func (tm *TabletManager) followEndpoint(ctx context.Context, last, current endpointState) endpointState {
+	switched := last.serverUUID != "" && last.serverUUID != current.serverUUID
+	isPrimary := tm.Tablet().Type == topodatapb.TabletType_PRIMARY
+
+	if isPrimary && (switched || current.readOnly) {
+		if err := tm.tmState.SetExternalPrimaryAvailable(ctx, false); err != nil {
+			log.Errorf("Cannot stop serving while the external primary is not writable: %v", err)
+		}
+	}
+
+	if switched {
+		reason := fmt.Sprintf("the endpoint now points to MySQL server %v instead of %v", current.serverUUID, last.serverUUID)
+		log.Infof("Reconnecting to the external MySQL: %v", reason)
+		statsEndpointSwitches.Add(1)
+		if err := tm.QueryServiceControl.ReconnectMySQL(reason); err != nil {
+			// Keep the previous state, so that the next check retries.
+			log.Errorf("Cannot reconnect to the external MySQL: %v", err)
+			return last
+		}
+	}
+
+	if isPrimary && !current.readOnly && !tm.tmState.IsExternalPrimaryAvailable() {
+		if err := tm.startExternalPrimaryTerm(ctx); err != nil {
+			log.Errorf("Cannot start a new term for the external primary: %v", err)
+			return last
+		}
+	}
+	return current
+}
+
+// startExternalPrimaryTerm starts a new primary term once the external
+// primary is writable again, and serves again. The new term start time lets
+// vtgate know that the failover is over.
+func (tm *TabletManager) startExternalPrimaryTerm(ctx context.Context) error {
+	if err := tm.lock(ctx); err != nil {
+		return err
+	}
+	defer tm.unlock()
+
+	if tm.Tablet().Type != topodatapb.TabletType_PRIMARY {
+		// The tablet was demoted in the meantime.
+		return tm.tmState.SetExternalPrimaryAvailable(ctx, true)
+	}
+	log.Infof("External primary is writable, starting a new primary term")
+	if err := tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_PRIMARY, DBActionNone); err != nil {
+		return err
+	}
+	return tm.tmState.SetExternalPrimaryAvailable(ctx, true)
+}
+
+// checkManaged returns an error if the tablet is unmanaged, for the
+// operations which need to control its MySQL, e.g. its replication.
+func (tm *TabletManager) checkManaged(operation string) error {
+	if tm.unmanaged {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%s is not supported by unmanaged tablets", operation)
+	}
+	return nil
+}
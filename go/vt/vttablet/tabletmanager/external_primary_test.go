@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletservermock"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestFollowEndpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 1, "ks", "0", nil)
+	defer tm.Stop()
+	tm.unmanaged = true
+
+	err := tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_PRIMARY, DBActionNone)
+	require.NoError(t, err)
+	qsc := tm.QueryServiceControl.(*tabletservermock.Controller)
+	require.True(t, qsc.IsServing())
+	primaryTermStartTime := func() int64 {
+		return protoutil.TimeFromProto(tm.Tablet().PrimaryTermStartTime).UnixNano()
+	}
+	termStart := primaryTermStartTime()
+
+	primary := endpointState{serverUUID: "uuid1"}
+	last := tm.followEndpoint(ctx, endpointState{}, primary)
+	assert.Equal(t, primary, last)
+	assert.True(t, qsc.IsServing())
+	assert.False(t, qsc.MethodCalled["ReconnectMySQL"])
+
+	// The endpoint points to the new primary, which is still read-only.
+	demoting := endpointState{serverUUID: "uuid2", readOnly: true}
+	last = tm.followEndpoint(ctx, last, demoting)
+	assert.Equal(t, demoting, last)
+	assert.False(t, qsc.IsServing())
+	assert.False(t, tm.tmState.IsExternalPrimaryAvailable())
+	assert.True(t, qsc.MethodCalled["ReconnectMySQL"])
+	assert.Equal(t, termStart, primaryTermStartTime())
+
+	// The new primary is writable: a new term starts.
+	promoted := endpointState{serverUUID: "uuid2"}
+	last = tm.followEndpoint(ctx, last, promoted)
+	assert.Equal(t, promoted, last)
+	assert.True(t, qsc.IsServing())
+	assert.True(t, tm.tmState.IsExternalPrimaryAvailable())
+	assert.Greater(t, primaryTermStartTime(), termStart)
+	assert.Equal(t, topodatapb.TabletType_PRIMARY, tm.Tablet().Type)
+
+	// A replica does not stop serving when its server is read-only.
+	err = tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_REPLICA, DBActionNone)
+	require.NoError(t, err)
+	tm.followEndpoint(ctx, last, endpointState{serverUUID: "uuid2", readOnly: true})
+	assert.True(t, qsc.IsServing())
+	assert.True(t, tm.tmState.IsExternalPrimaryAvailable())
+}
+
+func TestCheckManaged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	tm := newTestTM(t, ts, 1, "ks", "0", nil)
+	defer tm.Stop()
+	tm.unmanaged = true
+
+	err := tm.StopReplication(ctx)
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+	assert.ErrorContains(t, err, "StopReplication is not supported by unmanaged tablets")
+
+	err = tm.SetReadOnly(ctx, false)
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+
+	tm.unmanaged = false
+	assert.NoError(t, tm.checkManaged("StopReplication"))
+}
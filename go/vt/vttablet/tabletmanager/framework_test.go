@@ -224,8 +224,8 @@ func (ftc *fakeTabletConn) Begin(ctx context.Context, target *querypb.Target, op
 }
 
 // fakeTabletConn implements the QueryService interface.
-func (ftc *fakeTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, error) {
-	return 0, nil
+func (ftc *fakeTabletConn) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (int64, string, error) {
+	return 0, "", nil
 }
 
 // fakeTabletConn implements the QueryService interface.
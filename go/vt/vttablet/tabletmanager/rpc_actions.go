@@ -74,6 +74,9 @@ func (tm *TabletManager) GetGlobalStatusVars(ctx context.Context, variables []st
 
 // SetReadOnly makes the mysql instance read-only or read-write.
 func (tm *TabletManager) SetReadOnly(ctx context.Context, rdonly bool) error {
+	if err := tm.checkManaged("SetReadOnly"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -47,6 +47,9 @@ type RPCTM interface {
 	// An empty/nil variable name parameter slice means you want all of them.
 	GetGlobalStatusVars(ctx context.Context, variables []string) (map[string]string, error)
 
+	// GetAuditLog returns the audit log of the tablet manager RPCs.
+	GetAuditLog(ctx context.Context, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error)
+
 	// Various read-write methods
 
 	SetReadOnly(ctx context.Context, rdonly bool) error
@@ -168,6 +171,8 @@ type RPCTM interface {
 
 	RestoreFromBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.RestoreFromBackupRequest) error
 
+	RestoreFromClone(ctx context.Context, request *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error)
+
 	IsBackupRunning() bool
 
 	// HandleRPCPanic is to be called in a defer statement in each
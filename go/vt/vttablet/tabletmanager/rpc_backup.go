@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"time"
 
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/proto/vttime"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
 
+	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstats"
@@ -43,6 +45,9 @@ const (
 
 // Backup takes a db backup and sends it to the BackupStorage.
 func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req *tabletmanagerdatapb.BackupRequest) error {
+	if err := tm.checkManaged("Backup"); err != nil {
+		return err
+	}
 	if tm.Cnf == nil {
 		return fmt.Errorf("cannot perform backup without my.cnf, please restart vttablet with a my.cnf file specified")
 	}
@@ -182,6 +187,9 @@ func (tm *TabletManager) Backup(ctx context.Context, logger logutil.Logger, req
 // RestoreFromBackup deletes all local data and then restores the data from the latest backup [at
 // or before the backupTime value if specified]
 func (tm *TabletManager) RestoreFromBackup(ctx context.Context, logger logutil.Logger, request *tabletmanagerdatapb.RestoreFromBackupRequest) error {
+	if err := tm.checkManaged("RestoreFromBackup"); err != nil {
+		return err
+	}
 	if err := tm.lock(ctx); err != nil {
 		return err
 	}
@@ -207,6 +215,93 @@ func (tm *TabletManager) RestoreFromBackup(ctx context.Context, logger logutil.L
 	return err
 }
 
+// RestoreFromClone deletes all local data and replaces it with a copy of the
+// data of the donor tablet, made with the MySQL clone plugin, then starts
+// replicating from the position of the copy.
+func (tm *TabletManager) RestoreFromClone(ctx context.Context, request *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	if err := tm.checkManaged("RestoreFromClone"); err != nil {
+		return nil, err
+	}
+	if tm.Cnf == nil {
+		return nil, fmt.Errorf("cannot restore from clone without my.cnf, please restart vttablet with a my.cnf file specified")
+	}
+	if err := tm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer tm.unlock()
+
+	tablet, err := tm.TopoServer.GetTablet(ctx, tm.tabletAlias)
+	if err != nil {
+		return nil, err
+	}
+	if tablet.Type == topodatapb.TabletType_PRIMARY {
+		return nil, fmt.Errorf("type PRIMARY cannot restore from clone, if you really need to do this, restart vttablet in replica mode")
+	}
+	if request.DonorAlias == nil || topoproto.TabletAliasEqual(request.DonorAlias, tablet.Alias) {
+		return nil, fmt.Errorf("a donor other than the tablet itself is required to restore from clone")
+	}
+	donor, err := tm.TopoServer.GetTablet(ctx, request.DonorAlias)
+	if err != nil {
+		return nil, err
+	}
+	if donor.Keyspace != tablet.Keyspace || donor.Shard != tablet.Shard {
+		return nil, fmt.Errorf("donor %v is in shard %v/%v, not in the shard of the tablet %v/%v", topoproto.TabletAliasString(donor.Alias), donor.Keyspace, donor.Shard, tablet.Keyspace, tablet.Shard)
+	}
+
+	pos, err := tm.restoreFromCloneLocked(ctx, donor.Tablet, request.MaxBandwidth)
+
+	// Re-run health check to be sure to capture any replication delay.
+	tm.QueryServiceControl.BroadcastHealth()
+
+	if err != nil {
+		return nil, err
+	}
+	return &tabletmanagerdatapb.RestoreFromCloneResponse{Position: replication.EncodePosition(pos)}, nil
+}
+
+func (tm *TabletManager) restoreFromCloneLocked(ctx context.Context, donor *topodatapb.Tablet, maxBandwidth int64) (replication.Position, error) {
+	logger := logutil.NewConsoleLogger()
+	originalType := tm.Tablet().Type
+	logger.Infof("RestoreFromClone: original tablet type=%v", originalType)
+	if err := tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_RESTORE, DBActionNone); err != nil {
+		return replication.Position{}, err
+	}
+
+	params := mysqlctl.CloneParams{
+		Cnf:                  tm.Cnf,
+		Mysqld:               tm.MysqlDaemon,
+		Logger:               logger,
+		DonorHost:            donor.MysqlHostname,
+		DonorPort:            donor.MysqlPort,
+		MaxBandwidth:         maxBandwidth,
+		MysqlShutdownTimeout: mysqlShutdownTimeout,
+	}
+	// Replication must not apply events while the data is replaced.
+	err := tm.MysqlDaemon.StopReplication(ctx, tm.hookExtraEnv())
+	var pos replication.Position
+	if err == nil {
+		pos, err = mysqlctl.CloneFromDonor(ctx, params)
+	}
+	if err != nil {
+		// If anything failed, we should reset the original tablet type
+		if err := tm.tmState.ChangeTabletType(context.Background(), originalType, DBActionNone); err != nil {
+			log.Errorf("Could not change back to original tablet type %v: %v", originalType, err)
+		}
+		return replication.Position{}, vterrors.Wrap(err, "Can't restore from clone")
+	}
+	statsRestoreBackupPosition.Set(replication.EncodePosition(pos))
+
+	logger.Infof("RestoreFromClone: starting replication at position %v", pos)
+	if err := tm.startReplication(ctx, pos, originalType); err != nil {
+		return replication.Position{}, err
+	}
+	logger.Infof("RestoreFromClone: changing tablet type to %v for %s", originalType, tm.tabletAlias.String())
+	// Starting from here we won't be able to recover if we get stopped by a
+	// cancelled context. Thus we use the background context to get through to
+	// the finish.
+	return pos, tm.tmState.ChangeTabletType(context.Background(), originalType, DBActionNone)
+}
+
 func (tm *TabletManager) IsBackupRunning() bool {
 	return tm._isBackupRunning
 }
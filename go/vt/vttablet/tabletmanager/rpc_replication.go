@@ -235,6 +235,9 @@ func (tm *TabletManager) WaitForPosition(ctx context.Context, pos string) error
 // replication or not (using hook if not).
 func (tm *TabletManager) StopReplication(ctx context.Context) error {
 	log.Infof("StopReplication")
+	if err := tm.checkManaged("StopReplication"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -259,6 +262,9 @@ func (tm *TabletManager) stopIOThreadLocked(ctx context.Context) error {
 // replication or not (using hook if not).
 func (tm *TabletManager) StopReplicationMinimum(ctx context.Context, position string, waitTime time.Duration) (string, error) {
 	log.Infof("StopReplicationMinimum: position: %v waitTime: %v", position, waitTime)
+	if err := tm.checkManaged("StopReplicationMinimum"); err != nil {
+		return "", err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return "", err
 	}
@@ -290,6 +296,9 @@ func (tm *TabletManager) StopReplicationMinimum(ctx context.Context, position st
 // replication or not (using hook if not).
 func (tm *TabletManager) StartReplication(ctx context.Context, semiSync bool) error {
 	log.Infof("StartReplication")
+	if err := tm.checkManaged("StartReplication"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -313,6 +322,9 @@ func (tm *TabletManager) StartReplication(ctx context.Context, semiSync bool) er
 // until and including the transactions in `position`
 func (tm *TabletManager) StartReplicationUntilAfter(ctx context.Context, position string, waitTime time.Duration) error {
 	log.Infof("StartReplicationUntilAfter: position: %v waitTime: %v", position, waitTime)
+	if err := tm.checkManaged("StartReplicationUntilAfter"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -344,6 +356,9 @@ func (tm *TabletManager) GetReplicas(ctx context.Context) ([]string, error) {
 // All binary and relay logs are flushed. All replication positions are reset.
 func (tm *TabletManager) ResetReplication(ctx context.Context) error {
 	log.Infof("ResetReplication")
+	if err := tm.checkManaged("ResetReplication"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -358,6 +373,9 @@ func (tm *TabletManager) ResetReplication(ctx context.Context) error {
 // InitPrimary enables writes and returns the replication position.
 func (tm *TabletManager) InitPrimary(ctx context.Context, semiSync bool) (string, error) {
 	log.Infof("InitPrimary with semiSync as %t", semiSync)
+	if err := tm.checkManaged("InitPrimary"); err != nil {
+		return "", err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return "", err
 	}
@@ -451,6 +469,9 @@ func (tm *TabletManager) ReadReparentJournalInfo(ctx context.Context) (int32, er
 // reparent_journal table entry up to context timeout
 func (tm *TabletManager) InitReplica(ctx context.Context, parent *topodatapb.TabletAlias, position string, timeCreatedNS int64, semiSync bool) error {
 	log.Infof("InitReplica: parent: %v  position: %v  timeCreatedNS: %d  semisync: %t", parent, position, timeCreatedNS, semiSync)
+	if err := tm.checkManaged("InitReplica"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -521,6 +542,9 @@ func (tm *TabletManager) InitReplica(ctx context.Context, parent *topodatapb.Tab
 // If a step fails in the middle, it will try to undo any changes it made.
 func (tm *TabletManager) DemotePrimary(ctx context.Context) (*replicationdatapb.PrimaryStatus, error) {
 	log.Infof("DemotePrimary")
+	if err := tm.checkManaged("DemotePrimary"); err != nil {
+		return nil, err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return nil, err
 	}
@@ -652,6 +676,9 @@ func (tm *TabletManager) demotePrimary(ctx context.Context, revertPartialFailure
 // and returns its primary position.
 func (tm *TabletManager) UndoDemotePrimary(ctx context.Context, semiSync bool) error {
 	log.Infof("UndoDemotePrimary")
+	if err := tm.checkManaged("UndoDemotePrimary"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -717,6 +744,9 @@ func (tm *TabletManager) ReplicaWasPromoted(ctx context.Context) error {
 // ResetReplicationParameters resets the replica replication parameters
 func (tm *TabletManager) ResetReplicationParameters(ctx context.Context) error {
 	log.Infof("ResetReplicationParameters")
+	if err := tm.checkManaged("ResetReplicationParameters"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -741,6 +771,9 @@ func (tm *TabletManager) ResetReplicationParameters(ctx context.Context) error {
 // reparent_journal table entry up to context timeout
 func (tm *TabletManager) SetReplicationSource(ctx context.Context, parentAlias *topodatapb.TabletAlias, timeCreatedNS int64, waitPosition string, forceStartReplication bool, semiSync bool, heartbeatInterval float64) error {
 	log.Infof("SetReplicationSource: parent: %v  position: %s force: %v semiSync: %v timeCreatedNS: %d", parentAlias, waitPosition, forceStartReplication, semiSync, timeCreatedNS)
+	if err := tm.checkManaged("SetReplicationSource"); err != nil {
+		return err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return err
 	}
@@ -938,6 +971,9 @@ func (tm *TabletManager) ReplicaWasRestarted(ctx context.Context, parent *topoda
 // current status.
 func (tm *TabletManager) StopReplicationAndGetStatus(ctx context.Context, stopReplicationMode replicationdatapb.StopReplicationMode) (StopReplicationAndGetStatusResponse, error) {
 	log.Infof("StopReplicationAndGetStatus: mode: %v", stopReplicationMode)
+	if err := tm.checkManaged("StopReplicationAndGetStatus"); err != nil {
+		return StopReplicationAndGetStatusResponse{}, err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return StopReplicationAndGetStatusResponse{}, err
 	}
@@ -1026,6 +1062,9 @@ type StopReplicationAndGetStatusResponse struct {
 // PromoteReplica makes the current tablet the primary
 func (tm *TabletManager) PromoteReplica(ctx context.Context, semiSync bool) (string, error) {
 	log.Infof("PromoteReplica")
+	if err := tm.checkManaged("PromoteReplica"); err != nil {
+		return "", err
+	}
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
 		return "", err
 	}
@@ -52,9 +52,12 @@ func (tm *TabletManager) HandleRPCPanic(ctx context.Context, name string, args,
 	if x := recover(); x != nil {
 		log.Errorf("TabletManager.%v(%v) on %v panic: %v\n%s", name, args, topoproto.TabletAliasString(tm.tabletAlias), x, tb.Stack(4))
 		*err = fmt.Errorf("caught panic during %v: %v", name, x)
+		tm.recordAuditLog(ctx, name, args, *err)
 		return
 	}
 
+	tm.recordAuditLog(ctx, name, args, *err)
+
 	// quick check for fast path
 	if !verbose && *err == nil {
 		return
@@ -173,6 +173,9 @@ type TabletManager struct {
 	// tmState manages the TabletManager state.
 	tmState *tmState
 
+	// auditLog records the tablet manager RPCs that change the tablet.
+	auditLog *auditLog
+
 	// tabletAlias is saved away from tablet for read-only access
 	tabletAlias *topodatapb.TabletAlias
 
@@ -180,6 +183,10 @@ type TabletManager struct {
 	// when we transition back from something like PRIMARY.
 	baseTabletType topodatapb.TabletType
 
+	// unmanaged is true if the tablet uses an external MySQL, whose
+	// replication and failovers it does not control.
+	unmanaged bool
+
 	// actionSema is there to run only one action at a time.
 	// This semaphore can be held for long periods of time (hours),
 	// like in the case of a restore. This semaphore must be obtained
@@ -208,6 +215,12 @@ type TabletManager struct {
 	// _shardSyncCancel is the function to stop the background shard sync goroutine.
 	_shardSyncCancel context.CancelFunc
 
+	// _endpointWatchCancel is the function to stop the background goroutine
+	// which follows the MySQL server behind the endpoint of an unmanaged
+	// tablet, and _endpointWatchDone is closed once it has stopped.
+	_endpointWatchCancel context.CancelFunc
+	_endpointWatchDone   chan struct{}
+
 	// _rebuildKeyspaceDone is a channel for waiting until the current keyspace
 	// has been rebuilt
 	_rebuildKeyspaceDone chan struct{}
@@ -376,7 +389,14 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, config *tabletenv.Tabl
 	tm.actionSema = semaphore.NewWeighted(1)
 	tm._waitForGrantsComplete = make(chan struct{})
 
+	al, err := newAuditLog(topoproto.TabletAliasString(tablet.Alias))
+	if err != nil {
+		return vterrors.Wrap(err, "failed to initialize the audit log")
+	}
+	tm.auditLog = al
+
 	tm.baseTabletType = tablet.Type
+	tm.unmanaged = config != nil && config.Unmanaged
 
 	ctx, cancel := context.WithTimeout(tm.BatchCtx, initTimeout)
 	defer cancel()
@@ -449,6 +469,7 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, config *tabletenv.Tabl
 		return err
 	}
 	tm.tmState.Open()
+	tm.startEndpointWatch()
 	return nil
 }
 
@@ -461,6 +482,7 @@ func (tm *TabletManager) Close() {
 	// running during lame duck.
 	tm.stopShardSync()
 	tm.stopRebuildKeyspace()
+	tm.stopEndpointWatch()
 
 	// cleanup initialized fields in the tablet entry
 	f := func(tablet *topodatapb.Tablet) error {
@@ -492,6 +514,7 @@ func (tm *TabletManager) Stop() {
 	// here in addition to in Close() because tests do not call Close().
 	tm.stopShardSync()
 	tm.stopRebuildKeyspace()
+	tm.stopEndpointWatch()
 
 	if tm.QueryServiceControl != nil {
 		tm.QueryServiceControl.Stats().Stop()
@@ -80,6 +80,9 @@ type tmState struct {
 	deniedTables    map[topodatapb.TabletType][]string
 	tablet          *topodatapb.Tablet
 	isPublishing    bool
+	// isExternalPrimaryUnavailable is set while the external MySQL of an
+	// unmanaged primary tablet cannot take writes, e.g. during a failover.
+	isExternalPrimaryUnavailable bool
 
 	// displayState contains the current snapshot of the internal state
 	// and has its own mutex.
@@ -267,6 +270,28 @@ func (ts *tmState) updateTypeAndPublish(ctx context.Context, tabletType topodata
 	return err
 }
 
+// SetExternalPrimaryAvailable changes whether the external MySQL of an
+// unmanaged tablet can take writes. A primary tablet does not serve while
+// it cannot.
+func (ts *tmState) SetExternalPrimaryAvailable(ctx context.Context, available bool) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.isExternalPrimaryUnavailable == !available {
+		return nil
+	}
+	log.Infof("External primary available: %v", available)
+	ts.isExternalPrimaryUnavailable = !available
+	return ts.updateLocked(ctx)
+}
+
+// IsExternalPrimaryAvailable returns false if the external MySQL of an
+// unmanaged tablet was found unable to take writes.
+func (ts *tmState) IsExternalPrimaryAvailable() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return !ts.isExternalPrimaryUnavailable
+}
+
 func (ts *tmState) ChangeTabletTags(ctx context.Context, tabletTags map[string]string) {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
@@ -385,6 +410,9 @@ func (ts *tmState) canServe(tabletType topodatapb.TabletType) string {
 	if tabletType == topodatapb.TabletType_PRIMARY && ts.isResharding {
 		return "primary tablet with filtered replication on"
 	}
+	if tabletType == topodatapb.TabletType_PRIMARY && ts.isExternalPrimaryUnavailable {
+		return "external primary is not writable"
+	}
 	return ""
 }
 
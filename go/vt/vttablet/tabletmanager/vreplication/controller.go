@@ -285,7 +285,10 @@ func (ct *controller) runBlp(ctx context.Context) (err error) {
 		var vsClient VStreamerClient
 		var err error
 		if name := ct.source.GetExternalMysql(); name != "" {
-			vsClient, err = ct.vre.ec.Get(name)
+			vsClient, err = ct.vre.ec.GetStreamClient(name, ExternalStream{
+				Workflow: ct.workflow,
+				Key:      fmt.Sprintf("%s/%s/%d", dbClient.DBName(), ct.workflow, ct.id),
+			})
 			if err != nil {
 				return err
 			}
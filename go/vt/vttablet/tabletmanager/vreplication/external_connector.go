@@ -18,6 +18,7 @@ package vreplication
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -60,11 +61,45 @@ type VStreamerClient interface {
 		send func(*binlogdatapb.VStreamTablesResponse) error, options *binlogdatapb.VStreamOptions) error
 }
 
+// ExternalSourceAdapter streams from an external database which is not
+// MySQL. It is used for the external connections whose flavor it is
+// registered for.
+type ExternalSourceAdapter interface {
+	// NewStreamClient returns the client of a vreplication stream.
+	NewStreamClient(stream ExternalStream) (VStreamerClient, error)
+	// Close releases the resources of the adapter.
+	Close()
+}
+
+// ExternalStream identifies a vreplication stream of an external source.
+type ExternalStream struct {
+	Workflow string
+	// Key is unique to the stream, and does not change when it restarts.
+	// Adapters can use it to name the state they keep on the source, e.g.
+	// replication slots.
+	Key string
+}
+
+// ExternalSourceAdapterFactory creates the adapter of an external connection.
+type ExternalSourceAdapterFactory func(env *vtenv.Environment, name string, dbcfgs *dbconfigs.DBConfigs) (ExternalSourceAdapter, error)
+
+var externalSourceAdapterFactories = make(map[string]ExternalSourceAdapterFactory)
+
+// RegisterExternalSourceAdapter registers the adapter of the external
+// connections with the given flavor. It must be called from an init function.
+func RegisterExternalSourceAdapter(flavor string, factory ExternalSourceAdapterFactory) {
+	if _, ok := externalSourceAdapterFactories[flavor]; ok {
+		panic(fmt.Sprintf("external source adapter %s is already registered", flavor))
+	}
+	externalSourceAdapterFactories[flavor] = factory
+}
+
 type externalConnector struct {
 	env        *vtenv.Environment
 	mu         sync.Mutex
 	dbconfigs  map[string]*dbconfigs.DBConfigs
 	connectors map[string]*mysqlConnector
+	adapters   map[string]ExternalSourceAdapter
 }
 
 func newExternalConnector(env *vtenv.Environment, dbcfgs map[string]*dbconfigs.DBConfigs) *externalConnector {
@@ -72,6 +107,7 @@ func newExternalConnector(env *vtenv.Environment, dbcfgs map[string]*dbconfigs.D
 		env:        env,
 		dbconfigs:  dbcfgs,
 		connectors: make(map[string]*mysqlConnector),
+		adapters:   make(map[string]ExternalSourceAdapter),
 	}
 }
 
@@ -80,6 +116,39 @@ func (ec *externalConnector) Close() {
 		c.shutdown()
 	}
 	ec.connectors = make(map[string]*mysqlConnector)
+	for _, a := range ec.adapters {
+		a.Close()
+	}
+	ec.adapters = make(map[string]ExternalSourceAdapter)
+}
+
+// GetStreamClient returns the client of a stream of the external connection.
+// The connections to MySQL are shared by all their streams.
+func (ec *externalConnector) GetStreamClient(name string, stream ExternalStream) (VStreamerClient, error) {
+	dbcfgs := ec.dbconfigs[name]
+	if dbcfgs == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "external mysqlConnector %v not found", name)
+	}
+	factory, ok := externalSourceAdapterFactories[dbcfgs.Flavor]
+	if !ok {
+		c, err := ec.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	a, ok := ec.adapters[name]
+	if !ok {
+		var err error
+		if a, err = factory(ec.env, name, dbcfgs); err != nil {
+			return nil, vterrors.Wrapf(err, "external connection %v", name)
+		}
+		ec.adapters[name] = a
+	}
+	return a.NewStreamClient(stream)
 }
 
 func (ec *externalConnector) Get(name string) (*mysqlConnector, error) {
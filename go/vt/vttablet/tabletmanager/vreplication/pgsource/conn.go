@@ -0,0 +1,652 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const protocolVersion3 = 3 << 16
+
+// sslRequestCode is sent instead of a protocol version to start TLS.
+const sslRequestCode = 80877103
+
+// Frontend message types.
+const (
+	msgQuery     = 'Q'
+	msgTerminate = 'X'
+	msgPassword  = 'p'
+	msgCopyData  = 'd'
+)
+
+// Backend message types.
+const (
+	msgAuthentication   = 'R'
+	msgParameterStatus  = 'S'
+	msgBackendKeyData   = 'K'
+	msgReadyForQuery    = 'Z'
+	msgRowDescription   = 'T'
+	msgDataRow          = 'D'
+	msgCommandComplete  = 'C'
+	msgEmptyQuery       = 'I'
+	msgErrorResponse    = 'E'
+	msgNoticeResponse   = 'N'
+	msgCopyBothResponse = 'W'
+	msgCopyDone         = 'c'
+)
+
+// Authentication request codes.
+const (
+	authOK                = 0
+	authCleartextPassword = 3
+	authMD5Password       = 5
+	authSASL              = 10
+	authSASLContinue      = 11
+	authSASLFinal         = 12
+)
+
+// maxMessageSize is the maximum size of a backend message.
+const maxMessageSize = 1 << 30
+
+// connParams are the parameters of a connection to PostgreSQL.
+type connParams struct {
+	host     string
+	port     int
+	user     string
+	password string
+	database string
+	// replication opens a logical replication connection, which also
+	// accepts SQL queries.
+	replication    bool
+	tlsConfig      *tls.Config
+	connectTimeout time.Duration
+}
+
+// pgError is an ErrorResponse of PostgreSQL.
+type pgError struct {
+	severity string
+	code     string
+	message  string
+	detail   string
+}
+
+func (e *pgError) Error() string {
+	if e.detail != "" {
+		return fmt.Sprintf("%s: %s (SQLSTATE %s): %s", e.severity, e.message, e.code, e.detail)
+	}
+	return fmt.Sprintf("%s: %s (SQLSTATE %s)", e.severity, e.message, e.code)
+}
+
+// conn is a connection to PostgreSQL. It only implements the simple query
+// protocol, and the streaming of logical replication.
+type conn struct {
+	netConn net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+}
+
+// connect opens a connection and authenticates it.
+func connect(ctx context.Context, params *connParams) (*conn, error) {
+	dialer := &net.Dialer{Timeout: params.connectTimeout}
+	network, address := "tcp", net.JoinHostPort(params.host, strconv.Itoa(params.port))
+	if strings.HasPrefix(params.host, "/") {
+		network, address = "unix", fmt.Sprintf("%s/.s.PGSQL.%d", params.host, params.port)
+	}
+	netConn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	c := &conn{netConn: netConn}
+	if err := c.startup(ctx, params); err != nil {
+		c.netConn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *conn) startup(ctx context.Context, params *connParams) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.netConn.SetDeadline(deadline)
+		defer c.netConn.SetDeadline(time.Time{})
+	}
+	if params.tlsConfig != nil {
+		if err := c.startTLS(ctx, params.tlsConfig); err != nil {
+			return err
+		}
+	}
+	c.r = bufio.NewReader(c.netConn)
+	c.w = bufio.NewWriter(c.netConn)
+
+	w := &messageWriter{}
+	w.int32(protocolVersion3)
+	startupParams := []string{
+		"user", params.user,
+		"database", params.database,
+		"application_name", "vitess",
+		"client_encoding", "UTF8",
+		"DateStyle", "ISO",
+		// The values of timestamptz columns are sent in UTC.
+		"TimeZone", "UTC",
+		"extra_float_digits", "3",
+		"bytea_output", "hex",
+		// Backslashes are not escapes in the literals built by quoteLiteral.
+		"standard_conforming_strings", "on",
+	}
+	if params.replication {
+		startupParams = append(startupParams, "replication", "database")
+	}
+	for _, param := range startupParams {
+		w.string(param)
+	}
+	w.byte(0)
+	if err := c.write(w.finishStartup()); err != nil {
+		return err
+	}
+	if err := c.authenticate(params); err != nil {
+		return err
+	}
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case msgParameterStatus, msgBackendKeyData, msgNoticeResponse:
+		case msgErrorResponse:
+			return parseError(body)
+		case msgReadyForQuery:
+			return nil
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected message %q during startup", typ)
+		}
+	}
+}
+
+func (c *conn) startTLS(ctx context.Context, config *tls.Config) error {
+	w := &messageWriter{}
+	w.int32(sslRequestCode)
+	if _, err := c.netConn.Write(w.finishStartup()); err != nil {
+		return err
+	}
+	var answer [1]byte
+	if _, err := io.ReadFull(c.netConn, answer[:]); err != nil {
+		return err
+	}
+	if answer[0] != 'S' {
+		return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "the PostgreSQL server does not support TLS")
+	}
+	tlsConn := tls.Client(c.netConn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return err
+	}
+	c.netConn = tlsConn
+	return nil
+}
+
+func (c *conn) authenticate(params *connParams) error {
+	var scram *scramClient
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case msgErrorResponse:
+			return parseError(body)
+		case msgAuthentication:
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected message %q during authentication", typ)
+		}
+		r := &messageReader{data: body}
+		switch code := r.int32(); code {
+		case authOK:
+			return nil
+		case authCleartextPassword:
+			err = c.sendPassword(params.password)
+		case authMD5Password:
+			salt := r.bytes(4)
+			inner := md5.Sum([]byte(params.password + params.user))
+			outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), salt...))
+			err = c.sendPassword("md5" + hex.EncodeToString(outer[:]))
+		case authSASL:
+			var mechanisms []string
+			for r.err == nil && len(r.data) > 1 {
+				mechanisms = append(mechanisms, r.string())
+			}
+			if !contains(mechanisms, scramSHA256) {
+				return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported SASL mechanisms: %v", mechanisms)
+			}
+			scram = newScramClient(params.password)
+			w := &messageWriter{}
+			w.start(msgPassword)
+			w.string(scramSHA256)
+			first := scram.clientFirstMessage()
+			w.int32(int32(len(first)))
+			w.raw([]byte(first))
+			err = c.write(w.finish())
+		case authSASLContinue:
+			if scram == nil {
+				return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected SASL continue message")
+			}
+			var final string
+			final, err = scram.clientFinalMessage(string(r.data))
+			if err == nil {
+				w := &messageWriter{}
+				w.start(msgPassword)
+				w.raw([]byte(final))
+				err = c.write(w.finish())
+			}
+		case authSASLFinal:
+			if scram == nil {
+				return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected SASL final message")
+			}
+			err = scram.verifyServerFinalMessage(string(r.data))
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported authentication method %d", code)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *conn) sendPassword(password string) error {
+	w := &messageWriter{}
+	w.start(msgPassword)
+	w.string(password)
+	return c.write(w.finish())
+}
+
+// Close terminates the connection.
+func (c *conn) Close() {
+	w := &messageWriter{}
+	w.start(msgTerminate)
+	_ = c.write(w.finish())
+	c.netConn.Close()
+}
+
+// closeOnDone closes the connection when ctx is done, to interrupt the
+// reads in progress. The returned function must be called once the
+// connection is not used anymore.
+func (c *conn) closeOnDone(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.netConn.Close()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
+}
+
+// field is a column of the RowDescription of a result.
+type field struct {
+	name    string
+	typeOID uint32
+	typmod  int32
+}
+
+// query runs sql with the simple query protocol, and calls onRow for every
+// row of its results. The values of the row are in text format, and nil for
+// NULL; they are only valid during the call.
+func (c *conn) query(sql string, onRow func(fields []field, row [][]byte) error) error {
+	if err := c.sendQuery(sql); err != nil {
+		return err
+	}
+	var fields []field
+	var row [][]byte
+	var queryErr error
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case msgRowDescription:
+			fields = parseRowDescription(body)
+		case msgDataRow:
+			if queryErr != nil {
+				continue
+			}
+			r := &messageReader{data: body}
+			n := int(r.int16())
+			row = row[:0]
+			for i := 0; i < n; i++ {
+				length := r.int32()
+				if length < 0 {
+					row = append(row, nil)
+					continue
+				}
+				row = append(row, r.bytes(int(length)))
+			}
+			if r.err != nil {
+				return r.err
+			}
+			if onRow != nil {
+				queryErr = onRow(fields, row)
+			}
+		case msgErrorResponse:
+			if queryErr == nil {
+				queryErr = parseError(body)
+			}
+		case msgCommandComplete, msgEmptyQuery, msgNoticeResponse, msgParameterStatus:
+		case msgReadyForQuery:
+			return queryErr
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected message %q in the results of %s", typ, sql)
+		}
+	}
+}
+
+// fetch runs sql and returns the rows of its results, with NULL values
+// as nil.
+func (c *conn) fetch(sql string) ([][]*string, error) {
+	var rows [][]*string
+	err := c.query(sql, func(fields []field, row [][]byte) error {
+		values := make([]*string, len(row))
+		for i, v := range row {
+			if v != nil {
+				s := string(v)
+				values[i] = &s
+			}
+		}
+		rows = append(rows, values)
+		return nil
+	})
+	return rows, err
+}
+
+// exec runs sql and ignores its results.
+func (c *conn) exec(sql string) error {
+	return c.query(sql, nil)
+}
+
+// startCopyBoth runs a command which switches the connection to the copy
+// both mode, e.g. START_REPLICATION.
+func (c *conn) startCopyBoth(sql string) error {
+	if err := c.sendQuery(sql); err != nil {
+		return err
+	}
+	var queryErr error
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case msgCopyBothResponse:
+			return nil
+		case msgErrorResponse:
+			queryErr = parseError(body)
+		case msgNoticeResponse, msgParameterStatus:
+		case msgReadyForQuery:
+			if queryErr == nil {
+				queryErr = vterrors.Errorf(vtrpcpb.Code_INTERNAL, "%s did not start a copy", sql)
+			}
+			return queryErr
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected message %q in the response of %s", typ, sql)
+		}
+	}
+}
+
+// readCopyData returns the payload of the next CopyData message. It returns
+// io.EOF when the server ends the copy.
+func (c *conn) readCopyData() ([]byte, error) {
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case msgCopyData:
+			return body, nil
+		case msgCopyDone:
+			return nil, io.EOF
+		case msgErrorResponse:
+			return nil, parseError(body)
+		case msgNoticeResponse, msgParameterStatus:
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected message %q during replication", typ)
+		}
+	}
+}
+
+// sendCopyData sends a CopyData message.
+func (c *conn) sendCopyData(data []byte) error {
+	w := &messageWriter{}
+	w.start(msgCopyData)
+	w.raw(data)
+	return c.write(w.finish())
+}
+
+func (c *conn) sendQuery(sql string) error {
+	w := &messageWriter{}
+	w.start(msgQuery)
+	w.string(sql)
+	return c.write(w.finish())
+}
+
+func (c *conn) write(data []byte) error {
+	if _, err := c.w.Write(data); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// readMessage returns the type and the body of the next message. The body
+// is only valid until the next read.
+func (c *conn) readMessage() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length < 4 || length > maxMessageSize {
+		return 0, nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid message length %d", length)
+	}
+	body := make([]byte, length-4)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return 0, nil, err
+	}
+	return header[0], body, nil
+}
+
+func parseRowDescription(body []byte) []field {
+	r := &messageReader{data: body}
+	n := int(r.int16())
+	fields := make([]field, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		f := field{name: r.string()}
+		r.int32() // table OID
+		r.int16() // column number
+		f.typeOID = uint32(r.int32())
+		r.int16() // type size
+		f.typmod = r.int32()
+		r.int16() // format
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func parseError(body []byte) error {
+	e := &pgError{}
+	r := &messageReader{data: body}
+	for r.err == nil && len(r.data) > 0 {
+		fieldType := r.byte()
+		if fieldType == 0 {
+			break
+		}
+		value := r.string()
+		switch fieldType {
+		case 'S':
+			e.severity = value
+		case 'C':
+			e.code = value
+		case 'M':
+			e.message = value
+		case 'D':
+			e.detail = value
+		}
+	}
+	return e
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// messageReader reads the fields of a message. Once a read fails, err is
+// set and the following reads return zero values.
+type messageReader struct {
+	data []byte
+	err  error
+}
+
+func (r *messageReader) fail() {
+	if r.err == nil {
+		r.err = vterrors.Errorf(vtrpcpb.Code_INTERNAL, "message is too short")
+	}
+	r.data = nil
+}
+
+func (r *messageReader) byte() byte {
+	if len(r.data) < 1 {
+		r.fail()
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *messageReader) int16() int16 {
+	if len(r.data) < 2 {
+		r.fail()
+		return 0
+	}
+	v := int16(binary.BigEndian.Uint16(r.data))
+	r.data = r.data[2:]
+	return v
+}
+
+func (r *messageReader) int32() int32 {
+	if len(r.data) < 4 {
+		r.fail()
+		return 0
+	}
+	v := int32(binary.BigEndian.Uint32(r.data))
+	r.data = r.data[4:]
+	return v
+}
+
+func (r *messageReader) int64() int64 {
+	if len(r.data) < 8 {
+		r.fail()
+		return 0
+	}
+	v := int64(binary.BigEndian.Uint64(r.data))
+	r.data = r.data[8:]
+	return v
+}
+
+func (r *messageReader) bytes(n int) []byte {
+	if n < 0 || len(r.data) < n {
+		r.fail()
+		return nil
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// string reads a null terminated string.
+func (r *messageReader) string() string {
+	for i, b := range r.data {
+		if b == 0 {
+			s := string(r.data[:i])
+			r.data = r.data[i+1:]
+			return s
+		}
+	}
+	r.fail()
+	return ""
+}
+
+// messageWriter builds a message.
+type messageWriter struct {
+	buf []byte
+}
+
+// start starts a message of type typ. The length is set by finish.
+func (w *messageWriter) start(typ byte) {
+	w.buf = append(w.buf, typ, 0, 0, 0, 0)
+}
+
+func (w *messageWriter) byte(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+func (w *messageWriter) int32(v int32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(v))
+}
+
+func (w *messageWriter) int64(v int64) {
+	w.buf = binary.BigEndian.AppendUint64(w.buf, uint64(v))
+}
+
+func (w *messageWriter) raw(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+func (w *messageWriter) string(s string) {
+	w.buf = append(w.buf, s...)
+	w.buf = append(w.buf, 0)
+}
+
+// finish sets the length of a message started with start.
+func (w *messageWriter) finish() []byte {
+	binary.BigEndian.PutUint32(w.buf[1:], uint32(len(w.buf)-1))
+	return w.buf
+}
+
+// finishStartup prefixes the message, which has no type, with its length.
+func (w *messageWriter) finishStartup() []byte {
+	msg := binary.BigEndian.AppendUint32(make([]byte, 0, len(w.buf)+4), uint32(len(w.buf)+4))
+	return append(msg, w.buf...)
+}
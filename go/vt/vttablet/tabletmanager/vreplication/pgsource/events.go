@@ -0,0 +1,308 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// table is a table of the source, with the plan of the stream for it.
+type table struct {
+	rel    *relation
+	fields []*querypb.Field
+	// plan is nil if the stream does not replicate the table.
+	plan *vstreamer.ExternalPlan
+}
+
+// eventBuilder converts the messages of pgoutput to vreplication events.
+type eventBuilder struct {
+	env *vtenv.Environment
+	// schema is the schema of the tables of the stream. The tables of the
+	// other schemas are not replicated.
+	schema string
+	filter *binlogdatapb.Filter
+	tables map[uint32]*table
+	// commitTime is the commit time of the current transaction.
+	commitTime time.Time
+}
+
+func newEventBuilder(env *vtenv.Environment, schema string, filter *binlogdatapb.Filter) *eventBuilder {
+	return &eventBuilder{
+		env:    env,
+		schema: schema,
+		filter: filter,
+		tables: make(map[uint32]*table),
+	}
+}
+
+// build returns the events of a pgoutput message.
+func (b *eventBuilder) build(msg []byte) ([]*binlogdatapb.VEvent, error) {
+	r := &messageReader{data: msg}
+	var events []*binlogdatapb.VEvent
+	var err error
+	switch typ := r.byte(); typ {
+	case pgoutputBegin:
+		b.commitTime = parseBegin(r).commitTime
+		events = append(events, &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_BEGIN})
+	case pgoutputCommit:
+		c := parseCommit(r)
+		events = append(events, &binlogdatapb.VEvent{
+			Type: binlogdatapb.VEventType_GTID,
+			Gtid: replication.EncodePosition(replication.Position{GTIDSet: replication.PostgresLSN(c.endLSN)}),
+		}, &binlogdatapb.VEvent{Type: binlogdatapb.VEventType_COMMIT})
+	case pgoutputRelation:
+		rel := parseRelation(r)
+		if r.err != nil {
+			break
+		}
+		var t *table
+		if t, err = b.addTable(rel); err == nil && t.plan != nil {
+			events = append(events, &binlogdatapb.VEvent{
+				Type: binlogdatapb.VEventType_FIELD,
+				FieldEvent: &binlogdatapb.FieldEvent{
+					TableName: rel.name,
+					Fields:    t.plan.Fields(),
+				},
+			})
+		}
+	case pgoutputInsert, pgoutputUpdate, pgoutputDelete:
+		c := parseChange(typ, r)
+		if r.err != nil {
+			break
+		}
+		var ev *binlogdatapb.VEvent
+		if ev, err = b.rowEvent(c); ev != nil {
+			events = append(events, ev)
+		}
+	case pgoutputTruncate:
+		for _, id := range parseTruncate(r) {
+			if t := b.tables[id]; t != nil && t.plan != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "TRUNCATE of table %s cannot be replicated", t.rel.name)
+			}
+		}
+	case pgoutputOrigin, pgoutputType, pgoutputMessage:
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected pgoutput message %q", typ)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, ev := range events {
+		ev.Timestamp = b.commitTime.Unix()
+		ev.CurrentTime = now.UnixNano()
+	}
+	return events, nil
+}
+
+// addTable adds the table of a Relation message, which is sent before the
+// first change to the table, and again when its schema changes.
+func (b *eventBuilder) addTable(rel *relation) (*table, error) {
+	t := &table{rel: rel}
+	b.tables[rel.id] = t
+	if rel.namespace != b.schema || !matchesRule(rel.name, b.filter) {
+		return t, nil
+	}
+	if err := validateReplicaIdentity(b.env.Parser(), rel, b.filter); err != nil {
+		return nil, err
+	}
+	for _, col := range rel.columns {
+		// The columns of the replica identity are the primary key, unless it
+		// is FULL or uses another unique index.
+		isPK := col.key && rel.replicaIdentity == replicaIdentityDefault
+		field, err := columnField(rel.name, col.name, col.typeOID, col.typmod, isPK)
+		if err != nil {
+			return nil, err
+		}
+		t.fields = append(t.fields, field)
+	}
+	var err error
+	t.plan, err = vstreamer.NewExternalPlan(b.env, &vstreamer.Table{Name: rel.name, Fields: t.fields}, b.filter)
+	return t, err
+}
+
+// rowEvent returns the ROW event of an Insert, Update or Delete message, or
+// nil if the row is not replicated.
+func (b *eventBuilder) rowEvent(c changeMessage) (*binlogdatapb.VEvent, error) {
+	t := b.tables[c.relationID]
+	if t == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "change of unknown relation %d", c.relationID)
+	}
+	if t.plan == nil {
+		return nil, nil
+	}
+
+	var before, after []sqltypes.Value
+	var err error
+	if c.old != nil {
+		if before, err = t.values(c.old, nil); err != nil {
+			return nil, err
+		}
+	}
+	if c.new != nil {
+		if after, err = t.values(c.new, before); err != nil {
+			return nil, err
+		}
+		switch {
+		case c.typ == pgoutputUpdate && c.old == nil:
+			// The replica identity of the row did not change, so PostgreSQL
+			// does not send the old tuple.
+			before = after
+		case c.oldKind == 'K':
+			// The old tuple only has the values of the replica identity.
+			for i, col := range t.rel.columns {
+				if !col.key {
+					before[i] = after[i]
+				}
+			}
+		}
+	}
+
+	rowChange := &binlogdatapb.RowChange{}
+	if before != nil {
+		values, ok, err := t.plan.Filter(before)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rowChange.Before = sqltypes.RowToProto3(values)
+		}
+	}
+	if after != nil {
+		values, ok, err := t.plan.Filter(after)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rowChange.After = sqltypes.RowToProto3(values)
+		}
+	}
+	if rowChange.Before == nil && rowChange.After == nil {
+		return nil, nil
+	}
+	return &binlogdatapb.VEvent{
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{
+			TableName:  t.rel.name,
+			RowChanges: []*binlogdatapb.RowChange{rowChange},
+		},
+	}, nil
+}
+
+// values returns the values of a tuple of the table. The unchanged TOAST
+// values of an update are taken from old.
+func (t *table) values(tuple []tupleValue, old []sqltypes.Value) ([]sqltypes.Value, error) {
+	if len(tuple) != len(t.fields) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "table %s has %d columns, but a tuple has %d values", t.rel.name, len(t.fields), len(tuple))
+	}
+	values := make([]sqltypes.Value, len(tuple))
+	for i, v := range tuple {
+		col := t.rel.columns[i]
+		switch v.kind {
+		case tupleNull:
+			values[i] = sqltypes.NULL
+		case tupleUnchanged:
+			if old == nil || (t.rel.replicaIdentity != replicaIdentityFull && !col.key) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the unchanged value of column %s of table %s is not sent by PostgreSQL: the table must use REPLICA IDENTITY FULL", col.name, t.rel.name)
+			}
+			values[i] = old[i]
+		default:
+			var err error
+			if values[i], err = convertValue(col.typeOID, t.fields[i], v.value); err != nil {
+				return nil, vterrors.Wrapf(err, "table %s", t.rel.name)
+			}
+		}
+	}
+	return values, nil
+}
+
+// matchesRule returns true if a rule of filter matches the table.
+func matchesRule(tableName string, filter *binlogdatapb.Filter) bool {
+	for _, rule := range filter.Rules {
+		if strings.HasPrefix(rule.Match, "/") {
+			if ok, _ := regexp.MatchString(strings.Trim(rule.Match, "/"), tableName); ok {
+				return true
+			}
+			continue
+		}
+		if rule.Match == tableName {
+			return true
+		}
+	}
+	return false
+}
+
+// validateReplicaIdentity checks that the changes of the table can be
+// replicated: PostgreSQL only sends the old values of the columns of the
+// replica identity of the table, so the updates and deletes need one, and
+// the filter cannot use other columns unless all of them are sent.
+func validateReplicaIdentity(parser *sqlparser.Parser, rel *relation, filter *binlogdatapb.Filter) error {
+	hasKey := false
+	keys := make(map[string]bool)
+	for _, col := range rel.columns {
+		if col.key {
+			hasKey = true
+			keys[strings.ToLower(col.name)] = true
+		}
+	}
+	if rel.replicaIdentity == replicaIdentityNothing || (rel.replicaIdentity == replicaIdentityDefault && !hasKey) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table %s has no replica identity: it needs a primary key", rel.name)
+	}
+	if rel.replicaIdentity == replicaIdentityFull {
+		return nil
+	}
+	for _, rule := range filter.Rules {
+		if rule.Match != rel.name {
+			continue
+		}
+		stmt, err := parser.Parse(rule.Filter)
+		if err != nil {
+			return err
+		}
+		sel, ok := stmt.(*sqlparser.Select)
+		if !ok || sel.Where == nil {
+			continue
+		}
+		var column string
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if col, ok := node.(*sqlparser.ColName); ok && column == "" && !keys[col.Name.Lowered()] {
+				column = col.Name.String()
+			}
+			return true, nil
+		}, sel.Where)
+		if column != "" {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the filter of table %s uses column %s, which is not in its replica identity: the table must use REPLICA IDENTITY FULL", rel.name, column)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+)
+
+func relationMessage(id int32, namespace, name string, replicaIdentity byte, columns ...relationColumn) []byte {
+	w := &messageWriter{}
+	w.byte(pgoutputRelation)
+	w.int32(id)
+	w.string(namespace)
+	w.string(name)
+	w.byte(replicaIdentity)
+	w.buf = append(w.buf, byte(len(columns)>>8), byte(len(columns)))
+	for _, col := range columns {
+		if col.key {
+			w.byte(1)
+		} else {
+			w.byte(0)
+		}
+		w.string(col.name)
+		w.int32(int32(col.typeOID))
+		w.int32(col.typmod)
+	}
+	return w.buf
+}
+
+// tuple writes a TupleData: nil values are NULL, and "\x00" is unchanged.
+func tuple(w *messageWriter, values ...*string) {
+	w.buf = append(w.buf, byte(len(values)>>8), byte(len(values)))
+	for _, v := range values {
+		switch {
+		case v == nil:
+			w.byte(tupleNull)
+		case *v == "\x00":
+			w.byte(tupleUnchanged)
+		default:
+			w.byte(tupleText)
+			w.int32(int32(len(*v)))
+			w.raw([]byte(*v))
+		}
+	}
+}
+
+func changeMsg(typ byte, id int32, oldKind byte, old []*string, new []*string) []byte {
+	w := &messageWriter{}
+	w.byte(typ)
+	w.int32(id)
+	if oldKind != 0 {
+		w.byte(oldKind)
+		tuple(w, old...)
+	}
+	if new != nil {
+		w.byte('N')
+		tuple(w, new...)
+	}
+	return w.buf
+}
+
+func str(s string) *string {
+	return &s
+}
+
+func TestEventBuilder(t *testing.T) {
+	commitTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	filter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{
+		Match:  "t1",
+		Filter: "select id, val from t1 where in_keyrange(id, 'hash', '-80')",
+	}}}
+	b := newEventBuilder(vtenv.NewTestEnv(), "public", filter)
+
+	w := &messageWriter{}
+	w.byte(pgoutputBegin)
+	w.int64(0x16B3748)
+	w.int64(commitTime.Sub(pgEpoch).Microseconds())
+	w.int32(1000)
+	events, err := b.build(w.buf)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, binlogdatapb.VEventType_BEGIN, events[0].Type)
+	assert.Equal(t, commitTime.Unix(), events[0].Timestamp)
+
+	// Tables of other schemas, or without a rule, are not replicated.
+	events, err = b.build(relationMessage(1, "other", "t1", replicaIdentityNothing, relationColumn{name: "id", typeOID: 23, typmod: -1}))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	events, err = b.build(relationMessage(2, "public", "t2", replicaIdentityNothing, relationColumn{name: "id", typeOID: 23, typmod: -1}))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	events, err = b.build(changeMsg(pgoutputInsert, 2, 0, nil, []*string{str("1")}))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = b.build(relationMessage(3, "public", "t1", replicaIdentityDefault,
+		relationColumn{name: "id", key: true, typeOID: 20, typmod: -1},
+		relationColumn{name: "val", typeOID: 1043, typmod: 36},
+		relationColumn{name: "extra", typeOID: 25, typmod: -1}))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, binlogdatapb.VEventType_FIELD, events[0].Type)
+	require.Len(t, events[0].FieldEvent.Fields, 2)
+	assert.Equal(t, "id", events[0].FieldEvent.Fields[0].Name)
+	assert.Equal(t, sqltypes.Int64, events[0].FieldEvent.Fields[0].Type)
+	assert.Equal(t, "varchar(32)", events[0].FieldEvent.Fields[1].ColumnType)
+
+	rowChange := func(events []*binlogdatapb.VEvent) *binlogdatapb.RowChange {
+		require.Len(t, events, 1)
+		require.Equal(t, binlogdatapb.VEventType_ROW, events[0].Type)
+		assert.Equal(t, "t1", events[0].RowEvent.TableName)
+		require.Len(t, events[0].RowEvent.RowChanges, 1)
+		return events[0].RowEvent.RowChanges[0]
+	}
+
+	// hash(1) is in -80, and hash(4) is not.
+	events, err = b.build(changeMsg(pgoutputInsert, 3, 0, nil, []*string{str("1"), str("a"), nil}))
+	require.NoError(t, err)
+	rc := rowChange(events)
+	assert.Nil(t, rc.Before)
+	assert.Equal(t, sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")}), rc.After)
+	events, err = b.build(changeMsg(pgoutputInsert, 3, 0, nil, []*string{str("4"), str("a"), nil}))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// An update without old tuple did not change the key.
+	events, err = b.build(changeMsg(pgoutputUpdate, 3, 0, nil, []*string{str("1"), str("b"), str("\x00")}))
+	require.ErrorContains(t, err, "REPLICA IDENTITY FULL")
+	events, err = b.build(changeMsg(pgoutputUpdate, 3, 0, nil, []*string{str("1"), str("b"), nil}))
+	require.NoError(t, err)
+	rc = rowChange(events)
+	assert.Equal(t, rc.After, rc.Before)
+
+	// An update of the key sends the old key, and a delete only the key.
+	events, err = b.build(changeMsg(pgoutputUpdate, 3, 'K', []*string{str("1"), nil, nil}, []*string{str("4"), str("b"), nil}))
+	require.NoError(t, err)
+	rc = rowChange(events)
+	assert.Equal(t, "1b", string(rc.Before.Values))
+	assert.Nil(t, rc.After)
+	events, err = b.build(changeMsg(pgoutputDelete, 3, 'K', []*string{str("1"), nil, nil}, nil))
+	require.NoError(t, err)
+	rc = rowChange(events)
+	assert.Equal(t, []int64{1, -1}, rc.Before.Lengths)
+	assert.Nil(t, rc.After)
+
+	w = &messageWriter{}
+	w.byte(pgoutputCommit)
+	w.byte(0)
+	w.int64(0x16B3748)
+	w.int64(0x16B3778)
+	w.int64(commitTime.Sub(pgEpoch).Microseconds())
+	events, err = b.build(w.buf)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, binlogdatapb.VEventType_GTID, events[0].Type)
+	assert.Equal(t, "PostgresLSN/0/16B3778", events[0].Gtid)
+	assert.Equal(t, binlogdatapb.VEventType_COMMIT, events[1].Type)
+
+	w = &messageWriter{}
+	w.byte(pgoutputTruncate)
+	w.int32(1)
+	w.byte(0)
+	w.int32(3)
+	_, err = b.build(w.buf)
+	assert.ErrorContains(t, err, "TRUNCATE of table t1")
+
+	_, err = b.build(changeMsg(pgoutputInsert, 9, 0, nil, []*string{str("1")}))
+	assert.ErrorContains(t, err, "unknown relation 9")
+}
+
+func TestValidateReplicaIdentity(t *testing.T) {
+	parser := vtenv.NewTestEnv().Parser()
+	columns := []relationColumn{{name: "id", key: true}, {name: "region"}}
+	filter := func(query string) *binlogdatapb.Filter {
+		return &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: query}}}
+	}
+	testcases := []struct {
+		replicaIdentity byte
+		columns         []relationColumn
+		query           string
+		err             string
+	}{{
+		replicaIdentity: replicaIdentityDefault,
+		columns:         columns,
+		query:           "select * from t1 where in_keyrange(id, 'hash', '-80')",
+	}, {
+		replicaIdentity: replicaIdentityDefault,
+		columns:         columns,
+		query:           "select * from t1 where region = 'eu'",
+		err:             "uses column region",
+	}, {
+		replicaIdentity: replicaIdentityFull,
+		columns:         columns,
+		query:           "select * from t1 where region = 'eu'",
+	}, {
+		replicaIdentity: replicaIdentityNothing,
+		columns:         columns,
+		query:           "select * from t1",
+		err:             "has no replica identity",
+	}, {
+		replicaIdentity: replicaIdentityDefault,
+		columns:         []relationColumn{{name: "id"}},
+		query:           "select * from t1",
+		err:             "has no replica identity",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			rel := &relation{name: "t1", replicaIdentity: tc.replicaIdentity, columns: tc.columns}
+			err := validateReplicaIdentity(parser, rel, filter(tc.query))
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestParseReplicationMessages(t *testing.T) {
+	w := &messageWriter{}
+	w.int64(0x100)
+	w.int64(0x200)
+	w.int64(0)
+	w.raw([]byte("payload"))
+	x, err := parseXLogData(w.buf)
+	require.NoError(t, err)
+	assert.Equal(t, xlogData{walStart: 0x100, walEnd: 0x200, data: []byte("payload")}, x)
+
+	w = &messageWriter{}
+	w.int64(0x300)
+	w.int64(0)
+	w.byte(1)
+	walEnd, replyRequested, err := parseKeepalive(w.buf)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x300), walEnd)
+	assert.True(t, replyRequested)
+
+	_, _, err = parseKeepalive([]byte{1, 2})
+	assert.Error(t, err)
+
+	now := pgEpoch.Add(time.Second)
+	status := standbyStatusUpdate(0x300, 0x200, now)
+	r := &messageReader{data: status}
+	assert.Equal(t, byte(msgStandbyStatusUpdate), r.byte())
+	assert.EqualValues(t, 0x300, r.int64())
+	assert.EqualValues(t, 0x200, r.int64())
+	assert.EqualValues(t, 0x200, r.int64())
+	assert.EqualValues(t, 1000000, r.int64())
+	assert.Equal(t, byte(0), r.byte())
+	assert.NoError(t, r.err)
+}
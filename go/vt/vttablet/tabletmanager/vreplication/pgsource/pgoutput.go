@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"time"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The messages of the streaming replication protocol, sent in CopyData
+// messages.
+const (
+	msgXLogData            = 'w'
+	msgPrimaryKeepalive    = 'k'
+	msgStandbyStatusUpdate = 'r'
+)
+
+// The messages of version 1 of the pgoutput plugin.
+const (
+	pgoutputBegin    = 'B'
+	pgoutputCommit   = 'C'
+	pgoutputOrigin   = 'O'
+	pgoutputRelation = 'R'
+	pgoutputType     = 'Y'
+	pgoutputInsert   = 'I'
+	pgoutputUpdate   = 'U'
+	pgoutputDelete   = 'D'
+	pgoutputTruncate = 'T'
+	pgoutputMessage  = 'M'
+)
+
+// The kinds of the values of a TupleData.
+const (
+	tupleNull      = 'n'
+	tupleUnchanged = 'u'
+	tupleText      = 't'
+)
+
+// The replica identities of a relation.
+const (
+	replicaIdentityDefault = 'd'
+	replicaIdentityNothing = 'n'
+	replicaIdentityFull    = 'f'
+	replicaIdentityIndex   = 'i'
+)
+
+// pgEpoch is the origin of the timestamps of PostgreSQL.
+var pgEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// pgTime returns the time of a timestamp of PostgreSQL, in microseconds
+// since pgEpoch.
+func pgTime(us int64) time.Time {
+	return pgEpoch.Add(time.Duration(us) * time.Microsecond)
+}
+
+// relation is a table, as described by a Relation message.
+type relation struct {
+	id              uint32
+	namespace       string
+	name            string
+	replicaIdentity byte
+	columns         []relationColumn
+}
+
+type relationColumn struct {
+	name string
+	// key is true if the column is part of the replica identity.
+	key     bool
+	typeOID uint32
+	typmod  int32
+}
+
+// tupleValue is a column of a TupleData.
+type tupleValue struct {
+	kind  byte
+	value []byte
+}
+
+// xlogData is the header of an XLogData message.
+type xlogData struct {
+	walStart uint64
+	walEnd   uint64
+	data     []byte
+}
+
+func parseXLogData(msg []byte) (xlogData, error) {
+	r := &messageReader{data: msg}
+	x := xlogData{
+		walStart: uint64(r.int64()),
+		walEnd:   uint64(r.int64()),
+	}
+	r.int64() // send time
+	x.data = r.data
+	return x, r.err
+}
+
+// parseKeepalive returns the end of the WAL on the server, and whether it
+// requests a status update.
+func parseKeepalive(msg []byte) (walEnd uint64, replyRequested bool, err error) {
+	r := &messageReader{data: msg}
+	walEnd = uint64(r.int64())
+	r.int64() // send time
+	replyRequested = r.byte() == 1
+	return walEnd, replyRequested, r.err
+}
+
+// standbyStatusUpdate returns a Standby Status Update message, which
+// confirms that the changes before flushed are durable on the client. The
+// server can then recycle the WAL before it.
+func standbyStatusUpdate(received, flushed uint64, now time.Time) []byte {
+	w := &messageWriter{}
+	w.byte(msgStandbyStatusUpdate)
+	w.int64(int64(received))
+	w.int64(int64(flushed))
+	w.int64(int64(flushed))
+	w.int64(now.Sub(pgEpoch).Microseconds())
+	w.byte(0)
+	return w.buf
+}
+
+// beginMessage is a Begin message.
+type beginMessage struct {
+	finalLSN   uint64
+	commitTime time.Time
+}
+
+func parseBegin(r *messageReader) beginMessage {
+	b := beginMessage{finalLSN: uint64(r.int64())}
+	b.commitTime = pgTime(r.int64())
+	r.int32() // xid
+	return b
+}
+
+// commitMessage is a Commit message.
+type commitMessage struct {
+	commitLSN uint64
+	// endLSN is the end of the transaction in the WAL, from which the
+	// replication resumes after it.
+	endLSN     uint64
+	commitTime time.Time
+}
+
+func parseCommit(r *messageReader) commitMessage {
+	r.byte() // flags
+	c := commitMessage{
+		commitLSN: uint64(r.int64()),
+		endLSN:    uint64(r.int64()),
+	}
+	c.commitTime = pgTime(r.int64())
+	return c
+}
+
+func parseRelation(r *messageReader) *relation {
+	rel := &relation{
+		id:              uint32(r.int32()),
+		namespace:       r.string(),
+		name:            r.string(),
+		replicaIdentity: r.byte(),
+	}
+	n := int(r.int16())
+	for i := 0; i < n && r.err == nil; i++ {
+		flags := r.byte()
+		rel.columns = append(rel.columns, relationColumn{
+			key:     flags&1 == 1,
+			name:    r.string(),
+			typeOID: uint32(r.int32()),
+			typmod:  r.int32(),
+		})
+	}
+	return rel
+}
+
+func parseTuple(r *messageReader) []tupleValue {
+	n := int(r.int16())
+	tuple := make([]tupleValue, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		v := tupleValue{kind: r.byte()}
+		switch v.kind {
+		case tupleNull, tupleUnchanged:
+		case tupleText:
+			v.value = r.bytes(int(r.int32()))
+		default:
+			r.err = vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected kind %q of tuple value", v.kind)
+		}
+		tuple = append(tuple, v)
+	}
+	return tuple
+}
+
+// changeMessage is an Insert, Update or Delete message.
+type changeMessage struct {
+	typ        byte
+	relationID uint32
+	// oldKind is 'K' if old only has the values of the replica identity,
+	// and 'O' if it has all the values. It is 0 if there is no old tuple.
+	oldKind byte
+	old     []tupleValue
+	new     []tupleValue
+}
+
+func parseChange(typ byte, r *messageReader) changeMessage {
+	c := changeMessage{typ: typ, relationID: uint32(r.int32())}
+	kind := r.byte()
+	if kind == 'K' || kind == 'O' {
+		c.oldKind = kind
+		c.old = parseTuple(r)
+		if typ == pgoutputDelete {
+			return c
+		}
+		kind = r.byte()
+	}
+	if kind != 'N' {
+		r.err = vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected tuple %q in change message %q", kind, typ)
+		return c
+	}
+	c.new = parseTuple(r)
+	return c
+}
+
+// parseTruncate returns the relations of a Truncate message.
+func parseTruncate(r *messageReader) []uint32 {
+	n := int(r.int32())
+	r.byte() // options
+	var ids []uint32
+	for i := 0; i < n && r.err == nil; i++ {
+		ids = append(ids, uint32(r.int32()))
+	}
+	return ids
+}
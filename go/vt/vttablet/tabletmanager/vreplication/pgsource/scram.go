@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const scramSHA256 = "SCRAM-SHA-256"
+
+// scramClient implements the client side of SCRAM-SHA-256, as described in
+// RFC 5802 and RFC 7677, without channel binding.
+type scramClient struct {
+	// user is empty for PostgreSQL, which uses the user of the startup
+	// message.
+	user        string
+	password    string
+	clientNonce string
+
+	clientFirstBare string
+	authMessage     string
+	saltedPassword  []byte
+}
+
+func newScramClient(password string) *scramClient {
+	nonce := make([]byte, 18)
+	_, _ = rand.Read(nonce)
+	return &scramClient{
+		password:    password,
+		clientNonce: base64.RawStdEncoding.EncodeToString(nonce),
+	}
+}
+
+func (s *scramClient) clientFirstMessage() string {
+	s.clientFirstBare = "n=" + s.user + ",r=" + s.clientNonce
+	return "n,," + s.clientFirstBare
+}
+
+func (s *scramClient) clientFinalMessage(serverFirst string) (string, error) {
+	var nonce, salt string
+	iterations := 0
+	for _, attr := range strings.Split(serverFirst, ",") {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "r":
+			nonce = value
+		case "s":
+			salt = value
+		case "i":
+			iterations, _ = strconv.Atoi(value)
+		}
+	}
+	if !strings.HasPrefix(nonce, s.clientNonce) || len(nonce) == len(s.clientNonce) || iterations <= 0 {
+		return "", vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "invalid SCRAM server first message: %s", serverFirst)
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return "", vterrors.Wrapf(err, "invalid SCRAM salt")
+	}
+	s.saltedPassword, err = pbkdf2.Key(sha256.New, s.password, saltBytes, iterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+
+	finalWithoutProof := "c=biws,r=" + nonce
+	s.authMessage = s.clientFirstBare + "," + serverFirst + "," + finalWithoutProof
+	clientKey := scramHMAC(s.saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	proof := scramHMAC(storedKey[:], s.authMessage)
+	for i := range proof {
+		proof[i] ^= clientKey[i]
+	}
+	return finalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (s *scramClient) verifyServerFinalMessage(serverFinal string) error {
+	signature, ok := strings.CutPrefix(serverFinal, "v=")
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "SCRAM authentication failed: %s", serverFinal)
+	}
+	serverKey := scramHMAC(s.saltedPassword, "Server Key")
+	expected := base64.StdEncoding.EncodeToString(scramHMAC(serverKey, s.authMessage))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "invalid SCRAM server signature")
+	}
+	return nil
+}
+
+func scramHMAC(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScramClient uses the example of RFC 7677.
+func TestScramClient(t *testing.T) {
+	s := &scramClient{
+		user:        "user",
+		password:    "pencil",
+		clientNonce: "rOprNGfwEbeRWgbNEkqO",
+	}
+	assert.Equal(t, "n,,n=user,r=rOprNGfwEbeRWgbNEkqO", s.clientFirstMessage())
+
+	final, err := s.clientFinalMessage("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	require.NoError(t, err)
+	assert.Equal(t, "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=", final)
+
+	assert.NoError(t, s.verifyServerFinalMessage("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="))
+	assert.Error(t, s.verifyServerFinalMessage("v=AAAATRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="))
+	assert.Error(t, s.verifyServerFinalMessage("e=invalid-proof"))
+
+	// The server nonce must extend the one of the client.
+	_, err = s.clientFinalMessage("r=other,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	assert.Error(t, err)
+}
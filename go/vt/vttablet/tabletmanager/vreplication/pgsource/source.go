@@ -0,0 +1,658 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pgsource implements a vreplication source for PostgreSQL, using
+// its logical replication. It is used by the external connections of
+// vttablet with the flavor "postgres":
+//
+//	"externalConnections": {
+//	  "pg": {
+//	    "flavor": "postgres",
+//	    "host": "pg.example.com",
+//	    "port": 5432,
+//	    "dbName": "app",
+//	    "filtered": {"user": "vitess", "password": "..."}
+//	  }
+//	}
+//
+// The user needs the REPLICATION attribute, and the database a publication
+// of the tables, named after the external connection, e.g. "pg". Every
+// vreplication stream creates a replication slot named after its workflow,
+// which must be dropped once the workflow is deleted.
+package pgsource
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer"
+	"vitess.io/vitess/go/vt/vttls"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Flavor is the flavor of the external connections to PostgreSQL.
+const Flavor = "postgres"
+
+const defaultPort = 5432
+
+var (
+	// heartbeatInterval is the interval of the heartbeats sent while the
+	// stream is idle.
+	heartbeatInterval = 1 * time.Second
+	// statusInterval is the interval of the status updates sent to the
+	// server, which also keep the connection alive.
+	statusInterval = 10 * time.Second
+)
+
+func init() {
+	vreplication.RegisterExternalSourceAdapter(Flavor, newAdapter)
+}
+
+// adapter is the source adapter of an external connection to PostgreSQL.
+type adapter struct {
+	env    *vtenv.Environment
+	params connParams
+	// publication is the publication of the tables of the source.
+	publication string
+}
+
+func newAdapter(env *vtenv.Environment, name string, dbcfgs *dbconfigs.DBConfigs) (vreplication.ExternalSourceAdapter, error) {
+	a := &adapter{
+		env: env,
+		params: connParams{
+			host:           dbcfgs.Host,
+			port:           dbcfgs.Port,
+			user:           dbcfgs.Filtered.User,
+			password:       dbcfgs.Filtered.Password,
+			database:       dbcfgs.DBName,
+			connectTimeout: time.Duration(dbcfgs.ConnectTimeoutMilliseconds) * time.Millisecond,
+		},
+		publication: name,
+	}
+	if dbcfgs.Socket != "" {
+		// The socket is the directory of the socket file, as for libpq.
+		a.params.host = dbcfgs.Socket
+	}
+	if a.params.port == 0 {
+		a.params.port = defaultPort
+	}
+	if a.params.host == "" || a.params.user == "" || a.params.database == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the host, dbName and filtered user of PostgreSQL external connection %s are required", name)
+	}
+	if dbcfgs.SslMode != "" && dbcfgs.SslMode != vttls.Disabled {
+		minVersion := uint16(tls.VersionTLS12)
+		if dbcfgs.TLSMinVersion != "" {
+			var err error
+			if minVersion, err = vttls.TLSVersionToNumber(dbcfgs.TLSMinVersion); err != nil {
+				return nil, err
+			}
+		}
+		serverName := dbcfgs.ServerName
+		if serverName == "" {
+			serverName = dbcfgs.Host
+		}
+		var err error
+		a.params.tlsConfig, err = vttls.ClientConfig(dbcfgs.SslMode, dbcfgs.SslCert, dbcfgs.SslKey, dbcfgs.SslCa, "", serverName, minVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// NewStreamClient is part of the vreplication.ExternalSourceAdapter interface.
+func (a *adapter) NewStreamClient(stream vreplication.ExternalStream) (vreplication.VStreamerClient, error) {
+	return &streamClient{a: a, slot: slotName(stream)}, nil
+}
+
+// Close is part of the vreplication.ExternalSourceAdapter interface.
+func (a *adapter) Close() {}
+
+func (a *adapter) connect(ctx context.Context, replication bool) (*conn, error) {
+	params := a.params
+	params.replication = replication
+	c, err := connect(ctx, &params)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot connect to PostgreSQL %s:%d", a.params.host, a.params.port)
+	}
+	return c, nil
+}
+
+// slotName returns the name of the replication slot of a stream. The names
+// of slots are limited to 63 lower case letters, digits and underscores.
+func slotName(stream vreplication.ExternalStream) string {
+	var workflow strings.Builder
+	for _, r := range strings.ToLower(stream.Workflow) {
+		if workflow.Len() == 32 {
+			break
+		}
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			workflow.WriteRune(r)
+		} else {
+			workflow.WriteByte('_')
+		}
+	}
+	sum := sha256.Sum256([]byte(stream.Key))
+	return fmt.Sprintf("vitess_%s_%s", workflow.String(), hex.EncodeToString(sum[:8]))
+}
+
+// streamClient is the client of a vreplication stream. The changes are read
+// from the replication slot of the stream, which is created by the copy of
+// the first table.
+type streamClient struct {
+	a    *adapter
+	slot string
+}
+
+var _ vreplication.VStreamerClient = (*streamClient)(nil)
+
+// Open checks that the source can be replicated.
+func (sc *streamClient) Open(ctx context.Context) error {
+	c, err := sc.a.connect(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	defer c.closeOnDone(ctx)()
+
+	rows, err := c.fetch("SHOW wal_level")
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 || rows[0][0] == nil || *rows[0][0] != "logical" {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the wal_level of PostgreSQL must be logical")
+	}
+	rows, err = c.fetch("SELECT 1 FROM pg_publication WHERE pubname = " + quoteLiteral(sc.a.publication))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "publication %s does not exist in PostgreSQL", sc.a.publication)
+	}
+	return nil
+}
+
+// Close is part of the vreplication.VStreamerClient interface.
+func (sc *streamClient) Close(ctx context.Context) error {
+	return nil
+}
+
+// VStreamTables is part of the vreplication.VStreamerClient interface.
+func (sc *streamClient) VStreamTables(ctx context.Context, send func(*binlogdatapb.VStreamTablesResponse) error, options *binlogdatapb.VStreamOptions) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "atomic copy is not supported by the PostgreSQL source")
+}
+
+// VStreamRows copies the rows of a table after lastpk. The rows are read
+// from a snapshot exported by a temporary replication slot, whose position
+// is sent with the fields.
+func (sc *streamClient) VStreamRows(ctx context.Context, query string, lastpk *querypb.QueryResult,
+	send func(*binlogdatapb.VStreamRowsResponse) error, options *binlogdatapb.VStreamOptions) error {
+	config, err := vstreamer.GetVReplicationConfig(options)
+	if err != nil {
+		return err
+	}
+	tableName, err := selectTable(sc.a.env.Parser(), query)
+	if err != nil {
+		return err
+	}
+
+	c, err := sc.a.connect(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	defer c.closeOnDone(ctx)()
+
+	rel, pk, err := loadTable(c, tableName)
+	if err != nil {
+		return err
+	}
+	filter := &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: tableName, Filter: query}}}
+	if rel.replicaIdentity != replicaIdentityIndex {
+		if err := validateReplicaIdentity(sc.a.env.Parser(), rel, filter); err != nil {
+			return err
+		}
+	}
+	fields := make([]*querypb.Field, len(rel.columns))
+	for i, col := range rel.columns {
+		if fields[i], err = columnField(rel.name, col.name, col.typeOID, col.typmod, col.key); err != nil {
+			return err
+		}
+	}
+	plan, err := vstreamer.NewExternalPlan(sc.a.env, &vstreamer.Table{Name: rel.name, Fields: fields}, filter)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid query %s", query)
+	}
+
+	rc, err := sc.a.connect(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	defer rc.closeOnDone(ctx)()
+	if err := sc.createSlot(rc); err != nil {
+		return err
+	}
+	// The snapshot is valid until the next command on rc.
+	rows, err := rc.fetch(fmt.Sprintf("CREATE_REPLICATION_SLOT %s TEMPORARY LOGICAL pgoutput EXPORT_SNAPSHOT", quoteIdentifier(sc.slot+"_c")))
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 || len(rows[0]) < 3 || rows[0][1] == nil || rows[0][2] == nil {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result of CREATE_REPLICATION_SLOT: %v", rows)
+	}
+	lsn, err := replication.ParsePostgresLSNSet(*rows[0][1])
+	if err != nil {
+		return err
+	}
+	if err := c.exec("BEGIN ISOLATION LEVEL REPEATABLE READ READ ONLY"); err != nil {
+		return err
+	}
+	if err := c.exec("SET TRANSACTION SNAPSHOT " + quoteLiteral(*rows[0][2])); err != nil {
+		return err
+	}
+
+	pkfields := make([]*querypb.Field, len(pk))
+	for i, col := range pk {
+		pkfields[i] = &querypb.Field{
+			Name:    fields[col].Name,
+			Type:    fields[col].Type,
+			Charset: fields[col].Charset,
+			Flags:   fields[col].Flags,
+		}
+	}
+	if err := send(&binlogdatapb.VStreamRowsResponse{
+		Fields:   plan.Fields(),
+		Pkfields: pkfields,
+		Gtid:     replication.EncodePosition(replication.Position{GTIDSet: lsn}),
+	}); err != nil {
+		return err
+	}
+
+	selectQuery, err := copyQuery(rel, pk, lastpk)
+	if err != nil {
+		return err
+	}
+	pktsize := vstreamer.DefaultPacketSizer(config.VStreamDynamicPacketSize, config.VStreamPacketSize)
+	var response binlogdatapb.VStreamRowsResponse
+	var lastpkRow []sqltypes.Value
+	byteCount := 0
+	flush := func() error {
+		response.Lastpk = sqltypes.RowToProto3(lastpkRow)
+		start := time.Now()
+		if err := send(&response); err != nil {
+			return err
+		}
+		pktsize.Record(byteCount, time.Since(start))
+		response.Rows = nil
+		byteCount = 0
+		return nil
+	}
+	err = c.query(selectQuery, func(_ []field, row [][]byte) error {
+		values := make([]sqltypes.Value, len(row))
+		for i, v := range row {
+			var err error
+			if values[i], err = convertValue(rel.columns[i].typeOID, fields[i], v); err != nil {
+				return vterrors.Wrapf(err, "table %s", rel.name)
+			}
+		}
+		lastpkRow = make([]sqltypes.Value, len(pk))
+		for i, col := range pk {
+			lastpkRow[i] = values[col]
+		}
+		filtered, ok, err := plan.Filter(values)
+		if err != nil {
+			return err
+		}
+		if ok {
+			r := sqltypes.RowToProto3(filtered)
+			response.Rows = append(response.Rows, r)
+			byteCount += len(r.Values)
+		}
+		if pktsize.ShouldSend(byteCount) {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.Rows) > 0 {
+		return flush()
+	}
+	return nil
+}
+
+// createSlot creates the replication slot of the stream if it does not
+// exist yet. It must exist before the snapshot of the copy is taken, so that
+// it keeps the changes made after it.
+func (sc *streamClient) createSlot(rc *conn) error {
+	rows, err := rc.fetch("SELECT 1 FROM pg_replication_slots WHERE slot_name = " + quoteLiteral(sc.slot))
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		return nil
+	}
+	return rc.exec(fmt.Sprintf("CREATE_REPLICATION_SLOT %s LOGICAL pgoutput NOEXPORT_SNAPSHOT", quoteIdentifier(sc.slot)))
+}
+
+// VStream streams the changes of the tables of filter from the replication
+// slot of the stream, starting after startPos. The slot is advanced to
+// startPos, which the target has applied, when the stream starts.
+func (sc *streamClient) VStream(ctx context.Context, startPos string, tablePKs []*binlogdatapb.TableLastPK, filter *binlogdatapb.Filter,
+	send func([]*binlogdatapb.VEvent) error, options *binlogdatapb.VStreamOptions) error {
+	if len(tablePKs) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "the PostgreSQL source cannot stream tables being copied")
+	}
+	config, err := vstreamer.GetVReplicationConfig(options)
+	if err != nil {
+		return err
+	}
+	pos, err := replication.DecodePosition(startPos)
+	if err != nil {
+		return err
+	}
+	lsn, ok := pos.GTIDSet.(replication.PostgresLSN)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "position %s is not a position of PostgreSQL", startPos)
+	}
+
+	c, err := sc.a.connect(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	defer c.closeOnDone(ctx)()
+
+	rows, err := c.fetch("SELECT current_schema()")
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 || rows[0][0] == nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the search_path of the PostgreSQL user has no schema")
+	}
+	schema := *rows[0][0]
+	rows, err = c.fetch("SELECT confirmed_flush_lsn FROM pg_replication_slots WHERE slot_name = " + quoteLiteral(sc.slot))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "replication slot %s does not exist in PostgreSQL", sc.slot)
+	}
+	if rows[0][0] != nil {
+		confirmed, err := replication.ParsePostgresLSNSet(*rows[0][0])
+		if err != nil {
+			return err
+		}
+		if !lsn.Contains(confirmed) {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "replication slot %s is at %s, after the position %s of the stream", sc.slot, confirmed, lsn)
+		}
+	}
+	if err := c.startCopyBoth(fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names %s)",
+		quoteIdentifier(sc.slot), lsn, quoteLiteral(quoteIdentifier(sc.a.publication)))); err != nil {
+		return err
+	}
+
+	msgs := make(chan []byte)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			data, err := c.readCopyData()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case msgs <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	received := uint64(lsn)
+	sendStatus := func() error {
+		return c.sendCopyData(standbyStatusUpdate(received, uint64(lsn), time.Now()))
+	}
+
+	var buffered []*binlogdatapb.VEvent
+	bufferedSize := 0
+	lastSend := time.Now()
+	transmit := func() error {
+		events := buffered
+		buffered = nil
+		bufferedSize = 0
+		lastSend = time.Now()
+		return send(events)
+	}
+	b := newEventBuilder(sc.a.env, schema, filter)
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	status := time.NewTicker(statusInterval)
+	defer status.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err == io.EOF {
+				return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "PostgreSQL stopped the replication")
+			}
+			return err
+		case data := <-msgs:
+			if len(data) == 0 {
+				continue
+			}
+			switch data[0] {
+			case msgXLogData:
+				x, err := parseXLogData(data[1:])
+				if err != nil {
+					return err
+				}
+				received = max(received, x.walEnd)
+				events, err := b.build(x.data)
+				if err != nil {
+					return err
+				}
+				for _, ev := range events {
+					if ev.Type == binlogdatapb.VEventType_ROW && len(buffered) > 0 && bufferedSize+ev.SizeVT() > config.VStreamPacketSize {
+						if err := transmit(); err != nil {
+							return err
+						}
+					}
+					buffered = append(buffered, ev)
+					bufferedSize += ev.SizeVT()
+					if ev.Type == binlogdatapb.VEventType_COMMIT {
+						if err := transmit(); err != nil {
+							return err
+						}
+					}
+				}
+			case msgPrimaryKeepalive:
+				walEnd, replyRequested, err := parseKeepalive(data[1:])
+				if err != nil {
+					return err
+				}
+				received = max(received, walEnd)
+				if replyRequested {
+					if err := sendStatus(); err != nil {
+						return err
+					}
+				}
+			}
+		case <-heartbeat.C:
+			if time.Since(lastSend) < heartbeatInterval {
+				continue
+			}
+			now := time.Now()
+			buffered = append(buffered, &binlogdatapb.VEvent{
+				Type:        binlogdatapb.VEventType_HEARTBEAT,
+				Timestamp:   now.Unix(),
+				CurrentTime: now.UnixNano(),
+			})
+			if err := transmit(); err != nil {
+				return err
+			}
+		case <-status.C:
+			if err := sendStatus(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// selectTable returns the table of the query of a copy.
+func selectTable(parser *sqlparser.Parser, query string) (string, error) {
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if ok && len(sel.From) == 1 {
+		if ate, ok := sel.From[0].(*sqlparser.AliasedTableExpr); ok {
+			if name := sqlparser.GetTableName(ate.Expr); !name.IsEmpty() {
+				return name.String(), nil
+			}
+		}
+	}
+	return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected query %s: it must select from a single table", query)
+}
+
+// loadTable loads the columns of a table of the current schema from the
+// catalog, and the positions of the columns of its primary key. The columns
+// of the primary key are the key columns of the relation.
+func loadTable(c *conn, name string) (*relation, []int, error) {
+	rows, err := c.fetch(fmt.Sprintf("SELECT c.oid, c.relreplident FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace "+
+		"WHERE c.relname = %s AND n.nspname = current_schema() AND c.relkind IN ('r', 'p')", quoteLiteral(name)))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "table %s not found in PostgreSQL", name)
+	}
+	oid := *rows[0][0]
+	rel := &relation{name: name, replicaIdentity: (*rows[0][1])[0]}
+
+	rows, err = c.fetch("SELECT attname, atttypid, atttypmod FROM pg_attribute " +
+		"WHERE attrelid = " + oid + " AND attnum > 0 AND NOT attisdropped ORDER BY attnum")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range rows {
+		var typeOID uint32
+		var typmod int32
+		if _, err := fmt.Sscan(*row[1], &typeOID); err != nil {
+			return nil, nil, err
+		}
+		if _, err := fmt.Sscan(*row[2], &typmod); err != nil {
+			return nil, nil, err
+		}
+		rel.columns = append(rel.columns, relationColumn{name: *row[0], typeOID: typeOID, typmod: typmod})
+	}
+
+	rows, err = c.fetch("SELECT a.attname FROM pg_index i CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, n) " +
+		"JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum " +
+		"WHERE i.indrelid = " + oid + " AND i.indisprimary ORDER BY k.n")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "table %s has no primary key", name)
+	}
+	var pk []int
+	for _, row := range rows {
+		for i := range rel.columns {
+			if rel.columns[i].name == *row[0] {
+				rel.columns[i].key = true
+				pk = append(pk, i)
+			}
+		}
+	}
+	return rel, pk, nil
+}
+
+// copyQuery returns the query which selects the rows of the table after
+// lastpk, in the order of the primary key.
+func copyQuery(rel *relation, pk []int, lastpk *querypb.QueryResult) (string, error) {
+	columns := make([]string, len(rel.columns))
+	for i, col := range rel.columns {
+		columns[i] = quoteIdentifier(col.name)
+	}
+	pkColumns := make([]string, len(pk))
+	for i, col := range pk {
+		pkColumns[i] = columns[col]
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "SELECT %s FROM %s", strings.Join(columns, ", "), quoteIdentifier(rel.name))
+	if lastpk != nil {
+		r := sqltypes.Proto3ToResult(lastpk)
+		if len(r.Rows) != 1 || len(r.Rows[0]) != len(pk) {
+			return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected lastpk input: %v", lastpk)
+		}
+		values := make([]string, len(pk))
+		for i, v := range r.Rows[0] {
+			if v.IsNull() {
+				return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected NULL in lastpk: %v", lastpk)
+			}
+			values[i] = pkLiteral(rel.columns[pk[i]].typeOID, v)
+		}
+		fmt.Fprintf(&buf, " WHERE (%s) > (%s)", strings.Join(pkColumns, ", "), strings.Join(values, ", "))
+	}
+	fmt.Fprintf(&buf, " ORDER BY %s", strings.Join(pkColumns, ", "))
+	return buf.String(), nil
+}
+
+// pkLiteral returns the literal of a value of the primary key. The type of
+// the literal is inferred from the column it is compared with.
+func pkLiteral(typeOID uint32, v sqltypes.Value) string {
+	if typeOID == 17 {
+		return quoteLiteral(`\x` + hex.EncodeToString(v.Raw()))
+	}
+	return quoteLiteral(v.ToString())
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteLiteral quotes a string literal. The connections set
+// standard_conforming_strings, so backslashes do not need to be escaped.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
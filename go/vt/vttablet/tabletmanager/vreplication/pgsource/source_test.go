@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
+)
+
+func TestSlotName(t *testing.T) {
+	name := slotName(vreplication.ExternalStream{Workflow: "Import-Orders", Key: "vt_commerce/Import-Orders/1"})
+	assert.Regexp(t, `^vitess_import_orders_[0-9a-f]{16}$`, name)
+	assert.NotEqual(t, name, slotName(vreplication.ExternalStream{Workflow: "Import-Orders", Key: "vt_commerce/Import-Orders/2"}))
+
+	// Long workflow names are truncated, so that the temporary slot of the
+	// copy also fits in 63 characters.
+	name = slotName(vreplication.ExternalStream{Workflow: "a_very_long_workflow_name_which_is_truncated", Key: "k"})
+	assert.LessOrEqual(t, len(name+"_c"), 63)
+}
+
+func TestCopyQuery(t *testing.T) {
+	rel := &relation{
+		name: `my"table`,
+		columns: []relationColumn{
+			{name: "tenant", typeOID: 25},
+			{name: "val", typeOID: 25},
+			{name: "id", typeOID: 17},
+		},
+	}
+	pk := []int{0, 2}
+	query, err := copyQuery(rel, pk, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "tenant", "val", "id" FROM "my""table" ORDER BY "tenant", "id"`, query)
+
+	lastpk := sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields("tenant|id", "varchar|varbinary"), "o'reilly|\x01\xff"))
+	query, err = copyQuery(rel, pk, lastpk)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "tenant", "val", "id" FROM "my""table" WHERE ("tenant", "id") > ('o''reilly', '\x01ff') ORDER BY "tenant", "id"`, query)
+
+	_, err = copyQuery(rel, []int{0}, lastpk)
+	assert.ErrorContains(t, err, "unexpected lastpk")
+}
+
+func TestSelectTable(t *testing.T) {
+	parser := vtenv.NewTestEnv().Parser()
+	name, err := selectTable(parser, "select id, val from t1 where in_keyrange(id, 'hash', '-80')")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", name)
+
+	_, err = selectTable(parser, "select * from t1 join t2")
+	assert.ErrorContains(t, err, "single table")
+}
+
+func TestNewAdapter(t *testing.T) {
+	env := vtenv.NewTestEnv()
+	a, err := newAdapter(env, "pg", &dbconfigs.DBConfigs{
+		Host:     "pg.example.com",
+		DBName:   "app",
+		Filtered: dbconfigs.UserConfig{User: "vitess", Password: "secret"},
+	})
+	require.NoError(t, err)
+	pa := a.(*adapter)
+	assert.Equal(t, "pg", pa.publication)
+	assert.Equal(t, defaultPort, pa.params.port)
+	assert.Nil(t, pa.params.tlsConfig)
+
+	_, err = newAdapter(env, "pg", &dbconfigs.DBConfigs{Host: "pg.example.com", DBName: "app"})
+	assert.ErrorContains(t, err, "filtered user")
+}
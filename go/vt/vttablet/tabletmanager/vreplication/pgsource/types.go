@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// pgType is the mapping of a PostgreSQL type to a MySQL type.
+type pgType struct {
+	name string
+	typ  querypb.Type
+	// columnType returns the MySQL column type for the type modifier of
+	// the PostgreSQL column.
+	columnType func(typmod int32) string
+	// convert converts a value from the text format of PostgreSQL to the
+	// format of MySQL. It is nil if both are the same.
+	convert func(v []byte) ([]byte, error)
+}
+
+func fixedColumnType(columnType string) func(int32) string {
+	return func(int32) string { return columnType }
+}
+
+// pgTypes maps the OIDs of the supported PostgreSQL types to their MySQL
+// types. The values of other types, e.g. arrays, ranges, enums or intervals,
+// cannot be replicated.
+var pgTypes = map[uint32]pgType{
+	16:   {name: "boolean", typ: sqltypes.Int8, columnType: fixedColumnType("tinyint(1)"), convert: convertBool},
+	17:   {name: "bytea", typ: sqltypes.Blob, columnType: fixedColumnType("longblob"), convert: convertBytea},
+	18:   {name: `"char"`, typ: sqltypes.Char, columnType: fixedColumnType("char(1)")},
+	19:   {name: "name", typ: sqltypes.VarChar, columnType: fixedColumnType("varchar(63)")},
+	20:   {name: "bigint", typ: sqltypes.Int64, columnType: fixedColumnType("bigint")},
+	21:   {name: "smallint", typ: sqltypes.Int16, columnType: fixedColumnType("smallint")},
+	23:   {name: "integer", typ: sqltypes.Int32, columnType: fixedColumnType("int")},
+	25:   {name: "text", typ: sqltypes.Text, columnType: fixedColumnType("longtext")},
+	26:   {name: "oid", typ: sqltypes.Uint32, columnType: fixedColumnType("int unsigned")},
+	114:  {name: "json", typ: sqltypes.TypeJSON, columnType: fixedColumnType("json")},
+	700:  {name: "real", typ: sqltypes.Float32, columnType: fixedColumnType("float"), convert: convertFloat},
+	701:  {name: "double precision", typ: sqltypes.Float64, columnType: fixedColumnType("double"), convert: convertFloat},
+	1042: {name: "character", typ: sqltypes.Char, columnType: lengthColumnType("char")},
+	1043: {name: "character varying", typ: sqltypes.VarChar, columnType: lengthColumnType("varchar")},
+	1082: {name: "date", typ: sqltypes.Date, columnType: fixedColumnType("date"), convert: convertDate},
+	1083: {name: "time without time zone", typ: sqltypes.Time, columnType: fixedColumnType("time(6)")},
+	1114: {name: "timestamp without time zone", typ: sqltypes.Datetime, columnType: fixedColumnType("datetime(6)"), convert: convertTimestamp},
+	1184: {name: "timestamp with time zone", typ: sqltypes.Datetime, columnType: fixedColumnType("datetime(6)"), convert: convertTimestamptz},
+	1700: {name: "numeric", typ: sqltypes.Decimal, columnType: numericColumnType, convert: convertNumeric},
+	2950: {name: "uuid", typ: sqltypes.Char, columnType: fixedColumnType("char(36)")},
+	3802: {name: "jsonb", typ: sqltypes.TypeJSON, columnType: fixedColumnType("json")},
+}
+
+// lengthColumnType returns the column type of the character types, whose
+// type modifier is their length plus 4, or -1 if it is not limited.
+func lengthColumnType(mysqlType string) func(int32) string {
+	return func(typmod int32) string {
+		if typmod < 4 {
+			return "longtext"
+		}
+		return fmt.Sprintf("%s(%d)", mysqlType, typmod-4)
+	}
+}
+
+// numericColumnType returns the column type of numeric, whose type
+// modifier holds its precision and scale.
+func numericColumnType(typmod int32) string {
+	if typmod < 4 {
+		return "decimal(65,30)"
+	}
+	typmod -= 4
+	return fmt.Sprintf("decimal(%d,%d)", (typmod>>16)&0xffff, typmod&0xffff)
+}
+
+// columnField returns the field of a column of a PostgreSQL table. It fails
+// if the type of the column cannot be replicated.
+func columnField(table, column string, typeOID uint32, typmod int32, key bool) (*querypb.Field, error) {
+	t, ok := pgTypes[typeOID]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "column %s of table %s has the type with OID %d, which is not supported by the PostgreSQL source", column, table, typeOID)
+	}
+	field := &querypb.Field{
+		Name:       column,
+		Type:       t.typ,
+		Table:      table,
+		OrgTable:   table,
+		OrgName:    column,
+		ColumnType: t.columnType(typmod),
+		Charset:    collations.CollationBinaryID,
+	}
+	if sqltypes.IsText(t.typ) || t.typ == sqltypes.TypeJSON {
+		field.Charset = collations.CollationUtf8mb4ID
+	} else {
+		field.Flags |= uint32(querypb.MySqlFlag_BINARY_FLAG)
+	}
+	if sqltypes.IsNumber(t.typ) {
+		field.Flags |= uint32(querypb.MySqlFlag_NUM_FLAG)
+	}
+	if key {
+		field.Flags |= uint32(querypb.MySqlFlag_PRI_KEY_FLAG | querypb.MySqlFlag_NOT_NULL_FLAG)
+	}
+	return field, nil
+}
+
+// convertValue converts a value in the text format of PostgreSQL, or nil for
+// NULL, to the value of field.
+func convertValue(typeOID uint32, field *querypb.Field, v []byte) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+	t := pgTypes[typeOID]
+	if t.convert != nil {
+		var err error
+		if v, err = t.convert(v); err != nil {
+			return sqltypes.NULL, vterrors.Wrapf(err, "column %s", field.Name)
+		}
+	} else {
+		v = bytes.Clone(v)
+	}
+	return sqltypes.MakeTrusted(field.Type, v), nil
+}
+
+func convertBool(v []byte) ([]byte, error) {
+	switch string(v) {
+	case "t":
+		return []byte("1"), nil
+	case "f":
+		return []byte("0"), nil
+	}
+	return nil, fmt.Errorf("invalid boolean %q", v)
+}
+
+func convertBytea(v []byte) ([]byte, error) {
+	hexValue, ok := bytes.CutPrefix(v, []byte(`\x`))
+	if !ok {
+		return nil, fmt.Errorf("bytea value is not in the hex format")
+	}
+	decoded := make([]byte, hex.DecodedLen(len(hexValue)))
+	if _, err := hex.Decode(decoded, hexValue); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func convertFloat(v []byte) ([]byte, error) {
+	switch string(v) {
+	case "NaN", "Infinity", "-Infinity":
+		return nil, fmt.Errorf("%s cannot be stored in MySQL", v)
+	}
+	return bytes.Clone(v), nil
+}
+
+func convertNumeric(v []byte) ([]byte, error) {
+	return convertFloat(v)
+}
+
+// checkDate fails for the dates which MySQL cannot store: infinity, the
+// dates before Christ, and the years after 9999.
+func checkDate(v []byte) error {
+	if len(v) < 10 || v[4] != '-' || bytes.HasSuffix(v, []byte(" BC")) {
+		return fmt.Errorf("%s cannot be stored in MySQL", v)
+	}
+	return nil
+}
+
+func convertDate(v []byte) ([]byte, error) {
+	if err := checkDate(v); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(v), nil
+}
+
+func convertTimestamp(v []byte) ([]byte, error) {
+	return convertDate(v)
+}
+
+// convertTimestamptz converts a timestamp with time zone to UTC. The
+// session of the source uses UTC, so the offset of the value is +00.
+func convertTimestamptz(v []byte) ([]byte, error) {
+	if err := checkDate(v); err != nil {
+		return nil, err
+	}
+	s := string(v)
+	i := strings.LastIndexAny(s, "+-")
+	if i <= 10 {
+		return nil, fmt.Errorf("invalid timestamp with time zone %q", s)
+	}
+	local, offset := s[:i], s[i:]
+	if offset == "+00" {
+		return []byte(local), nil
+	}
+	layouts := map[int]string{3: "-07", 6: "-07:00", 9: "-07:00:00"}
+	layout, ok := layouts[len(offset)]
+	if !ok {
+		return nil, fmt.Errorf("invalid timestamp with time zone %q", s)
+	}
+	t, err := time.Parse("2006-01-02 15:04:05.999999"+layout, s)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(t.UTC().Format("2006-01-02 15:04:05.999999")), nil
+}
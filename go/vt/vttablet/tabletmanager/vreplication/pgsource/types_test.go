@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgsource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestColumnField(t *testing.T) {
+	field, err := columnField("t1", "id", 20, -1, true)
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.Int64, field.Type)
+	assert.Equal(t, "bigint", field.ColumnType)
+	assert.Equal(t, uint32(collations.CollationBinaryID), field.Charset)
+	assert.Equal(t, uint32(querypb.MySqlFlag_BINARY_FLAG|querypb.MySqlFlag_NUM_FLAG|querypb.MySqlFlag_PRI_KEY_FLAG|querypb.MySqlFlag_NOT_NULL_FLAG), field.Flags)
+
+	field, err = columnField("t1", "name", 1043, 104, false)
+	require.NoError(t, err)
+	assert.Equal(t, "varchar(100)", field.ColumnType)
+	assert.Equal(t, uint32(collations.CollationUtf8mb4ID), field.Charset)
+	assert.Zero(t, field.Flags)
+
+	field, err = columnField("t1", "price", 1700, (10<<16|2)+4, false)
+	require.NoError(t, err)
+	assert.Equal(t, "decimal(10,2)", field.ColumnType)
+
+	// int4[] is not supported.
+	_, err = columnField("t1", "tags", 1007, -1, false)
+	assert.ErrorContains(t, err, "column tags of table t1 has the type with OID 1007")
+}
+
+func TestConvertValue(t *testing.T) {
+	testcases := []struct {
+		typeOID uint32
+		in      string
+		out     string
+		err     bool
+	}{
+		{typeOID: 16, in: "t", out: "1"},
+		{typeOID: 16, in: "f", out: "0"},
+		{typeOID: 17, in: `\x00ff41`, out: "\x00\xffA"},
+		{typeOID: 17, in: `\000`, err: true},
+		{typeOID: 701, in: "1.5e+300", out: "1.5e+300"},
+		{typeOID: 701, in: "NaN", err: true},
+		{typeOID: 1700, in: "12.30", out: "12.30"},
+		{typeOID: 1700, in: "Infinity", err: true},
+		{typeOID: 1082, in: "2025-03-01", out: "2025-03-01"},
+		{typeOID: 1082, in: "0044-03-15 BC", err: true},
+		{typeOID: 1082, in: "infinity", err: true},
+		{typeOID: 1114, in: "2025-03-01 12:00:00.5", out: "2025-03-01 12:00:00.5"},
+		{typeOID: 1184, in: "2025-03-01 12:00:00.5+00", out: "2025-03-01 12:00:00.5"},
+		{typeOID: 1184, in: "2025-03-01 01:00:00-05:30", out: "2025-03-01 06:30:00"},
+		{typeOID: 1184, in: "12345-03-01 12:00:00+00", err: true},
+		{typeOID: 2950, in: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", out: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			field, err := columnField("t1", "c", tc.typeOID, -1, false)
+			require.NoError(t, err)
+			v, err := convertValue(tc.typeOID, field, []byte(tc.in))
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, field.Type, v.Type())
+			assert.Equal(t, tc.out, v.ToString())
+		})
+	}
+
+	v, err := convertValue(23, &querypb.Field{Name: "c", Type: sqltypes.Int32}, nil)
+	require.NoError(t, err)
+	assert.True(t, v.IsNull())
+}
@@ -228,6 +228,15 @@ type TablePlan struct {
 
 	CollationEnv   *collations.Environment
 	WorkflowConfig *vttablet.VReplicationConfig
+
+	// Upsert and UpsertOnDup replace Insert and BulkInsertOnDup when
+	// the stream applies its row events in parallel: the transactions
+	// of a batch that some of the workers already committed are then
+	// applied again after a failure, which must not fail on the rows
+	// they inserted. They are only set in the replication phase of
+	// the tables without grouping.
+	Upsert      *sqlparser.ParsedQuery
+	UpsertOnDup *sqlparser.ParsedQuery
 }
 
 // MarshalJSON performs a custom JSON Marshalling.
@@ -365,6 +374,20 @@ func (tp *TablePlan) bindFieldVal(field *querypb.Field, val *sqltypes.Value) (*q
 	return sqltypes.ValueBindVariable(*val), nil
 }
 
+// upsertInserts returns true if the inserts of the plan must update the rows
+// that already exist.
+func (tp *TablePlan) upsertInserts() bool {
+	return tp.Upsert != nil && tp.WorkflowConfig != nil && tp.WorkflowConfig.ParallelApplyWorkers > 1
+}
+
+// insertQuery returns the statement inserting the full image of a row.
+func (tp *TablePlan) insertQuery() *sqlparser.ParsedQuery {
+	if tp.upsertInserts() {
+		return tp.Upsert
+	}
+	return tp.Insert
+}
+
 func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor func(string) (*sqltypes.Result, error)) (*sqltypes.Result, error) {
 	// MakeRowTrusted is needed here because Proto3ToResult is not convenient.
 	var (
@@ -447,7 +470,7 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 			tp.Stats.PartialQueryCount.Add([]string{"insert"}, 1)
 			return execParsedQuery(ins, bindvars, executor)
 		} else {
-			return execParsedQuery(tp.Insert, bindvars, executor)
+			return execParsedQuery(tp.insertQuery(), bindvars, executor)
 		}
 	case before && !after:
 		if tp.Delete == nil {
@@ -530,7 +553,7 @@ func (tp *TablePlan) applyChange(rowChange *binlogdatapb.RowChange, executor fun
 				}
 			}
 		}
-		return execParsedQuery(tp.Insert, bindvars, executor)
+		return execParsedQuery(tp.insertQuery(), bindvars, executor)
 	}
 	// Unreachable.
 	return nil, nil
@@ -615,10 +638,14 @@ func (tp *TablePlan) applyBulkInsertChanges(rowInserts []*binlogdatapb.RowChange
 	insertPrefix := prefix.String()
 	maxQuerySize -= int64(len(insertPrefix))
 	values := &strings.Builder{}
+	onDup := tp.BulkInsertOnDup
+	if tp.upsertInserts() {
+		onDup = tp.UpsertOnDup
+	}
 
 	execQuery := func(vals *strings.Builder) (*sqltypes.Result, error) {
-		if tp.BulkInsertOnDup != nil {
-			vals.WriteString(tp.BulkInsertOnDup.Query)
+		if onDup != nil {
+			vals.WriteString(onDup.Query)
 		}
 		tp.TablePlanBuilder.stats.BulkQueryCount.Add("insert", 1)
 		return executor(insertPrefix + vals.String())
@@ -699,6 +726,22 @@ func (tp *TablePlan) pkChanged(bindvars map[string]*querypb.BindVariable) bool {
 	return false
 }
 
+// changesPK returns true if the row change is an update which changes the
+// primary key of the row.
+func (tp *TablePlan) changesPK(rowChange *binlogdatapb.RowChange) bool {
+	if rowChange.Before == nil || rowChange.After == nil {
+		return false
+	}
+	before := sqltypes.MakeRowTrusted(tp.Fields, rowChange.Before)
+	after := sqltypes.MakeRowTrusted(tp.Fields, rowChange.After)
+	for i, field := range tp.Fields {
+		if slices.Contains(tp.PKReferences, field.Name) && !valsEqual(before[i], after[i]) {
+			return true
+		}
+	}
+	return false
+}
+
 func valsEqual(v1, v2 sqltypes.Value) bool {
 	if v1.IsNull() && v2.IsNull() {
 		return true
@@ -831,6 +831,78 @@ func TestBuildPlayerPlanExclude(t *testing.T) {
 	assert.Equal(t, string(gotPlan), string(wantPlan))
 }
 
+func TestBuildPlayerPlanUpsert(t *testing.T) {
+	PrimaryKeyInfos := map[string][]*ColumnInfo{
+		"t1": {&ColumnInfo{Name: "c1", IsPK: true}},
+	}
+	copyState := map[string]*sqltypes.Result{
+		"t1": sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"pk1|pk2",
+				"int64|varchar",
+			),
+			"1|aaa",
+		),
+	}
+
+	testCases := []struct {
+		name         string
+		filter       string
+		copyState    map[string]*sqltypes.Result
+		wantUpsert   string
+		wantOnDup    string
+		wantNoUpsert bool
+	}{
+		{
+			name:       "columns",
+			filter:     "select c1, c2, c3 from t2",
+			wantUpsert: "insert into t1(c1,c2,c3) values (:a_c1,:a_c2,:a_c3) on duplicate key update c2=values(c2), c3=values(c3)",
+			wantOnDup:  " on duplicate key update c2=values(c2), c3=values(c3)",
+		},
+		{
+			name:       "pk only",
+			filter:     "select c1 from t2",
+			wantUpsert: "insert into t1(c1) values (:a_c1) on duplicate key update c1=c1",
+			wantOnDup:  " on duplicate key update c1=c1",
+		},
+		{
+			name:         "group by",
+			filter:       "select c1, c2, c3 from t2 group by c3, c1",
+			wantNoUpsert: true,
+		},
+		{
+			name:         "copy phase",
+			filter:       "select c1, c2 from t2",
+			copyState:    copyState,
+			wantNoUpsert: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t1",
+					Filter: tc.filter,
+				}},
+			}
+			vr := &vreplicator{
+				workflowConfig: vttablet.DefaultVReplicationConfig,
+			}
+			plan, err := vr.buildReplicatorPlan(getSource(input), PrimaryKeyInfos, tc.copyState, binlogplayer.NewStats(), collations.MySQL8(), sqlparser.NewTestParser())
+			require.NoError(t, err)
+			tp := plan.TablePlans["t2"]
+			require.NotNil(t, tp)
+			if tc.wantNoUpsert {
+				require.Nil(t, tp.Upsert)
+				require.Nil(t, tp.UpsertOnDup)
+				return
+			}
+			require.Equal(t, tc.wantUpsert, tp.Upsert.Query)
+			require.Equal(t, tc.wantOnDup, tp.UpsertOnDup.Query)
+		})
+	}
+}
+
 func TestAppendFromRow(t *testing.T) {
 	testCases := []struct {
 		name    string
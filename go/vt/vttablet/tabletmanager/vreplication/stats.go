@@ -28,9 +28,14 @@ import (
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/servenv"
 
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
+// reverseWorkflowSuffix is the suffix of the names of the reverse workflows
+// created by SwitchTraffic.
+const reverseWorkflowSuffix = "_reverse"
+
 var (
 	globalStats = &vrStats{}
 )
@@ -99,6 +104,12 @@ func (st *vrStats) register() {
 			return result
 		})
 
+	stats.NewGaugesFuncWithMultiLabels(
+		"VReplicationReverseLagAlarm",
+		"Whether the streams of the reverse workflows are in error or lagging, per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts"},
+		st.reverseLagAlarms)
+
 	stats.NewCounterFunc(
 		"VReplicationLagSecondsTotal",
 		"vreplication seconds behind primary aggregated across all streams",
@@ -554,6 +565,34 @@ func (st *vrStats) register() {
 	}))
 }
 
+// reverseLagAlarms returns 1 for the streams of the reverse workflows that
+// are in error or lag by more than their alarm threshold, and 0 for the other
+// streams of the reverse workflows. A reverse workflow replicates the writes
+// switched by SwitchTraffic back to the original source, so that the traffic
+// can only be switched back safely while it is healthy.
+func (st *vrStats) reverseLagAlarms() map[string]int64 {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	result := make(map[string]int64)
+	for _, ct := range st.controllers {
+		if !strings.HasSuffix(ct.workflow, reverseWorkflowSuffix) {
+			continue
+		}
+		var threshold time.Duration
+		if ct.WorkflowConfig != nil {
+			threshold = ct.WorkflowConfig.ReverseLagAlarmThreshold
+		}
+		lag := time.Duration(ct.blpStats.ReplicationLagSeconds.Load()) * time.Second
+		state, _ := ct.blpStats.State.Load().(string)
+		alarm := int64(0)
+		if state == binlogdatapb.VReplicationWorkflowState_Error.String() || (threshold > 0 && lag > threshold) {
+			alarm = 1
+		}
+		result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+fmt.Sprintf("%v", ct.id)] = alarm
+	}
+	return result
+}
+
 func (st *vrStats) numControllers() int64 {
 	st.mu.Lock()
 	defer st.mu.Unlock()
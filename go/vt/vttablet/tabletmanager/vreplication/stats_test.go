@@ -28,6 +28,7 @@ import (
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -207,3 +208,35 @@ func TestVReplicationStats(t *testing.T) {
 	blpStats.RecordHeartbeat(tm)
 	require.Equal(t, tm, blpStats.Heartbeat())
 }
+
+func TestReverseLagAlarms(t *testing.T) {
+	newStats := func(state binlogdatapb.VReplicationWorkflowState, lagSeconds int64) *binlogplayer.Stats {
+		blpStats := binlogplayer.NewStats()
+		blpStats.State.Store(state.String())
+		blpStats.ReplicationLagSeconds.Store(lagSeconds)
+		return blpStats
+	}
+	config := &vttablet.VReplicationConfig{ReverseLagAlarmThreshold: 30 * time.Second}
+	testStats := &vrStats{
+		controllers: map[int32]*controller{
+			1: {id: 1, workflow: "wf1", WorkflowConfig: config, blpStats: newStats(binlogdatapb.VReplicationWorkflowState_Error, 0)},
+			2: {id: 2, workflow: "wf1_reverse", WorkflowConfig: config, blpStats: newStats(binlogdatapb.VReplicationWorkflowState_Running, 10)},
+			3: {id: 3, workflow: "wf2_reverse", WorkflowConfig: config, blpStats: newStats(binlogdatapb.VReplicationWorkflowState_Running, 60)},
+			4: {id: 4, workflow: "wf3_reverse", WorkflowConfig: config, blpStats: newStats(binlogdatapb.VReplicationWorkflowState_Error, 0)},
+		},
+	}
+	for _, ct := range testStats.controllers {
+		ct.source = &binlogdatapb.BinlogSource{Keyspace: "ks", Shard: "0"}
+		defer ct.blpStats.Stop()
+	}
+
+	require.Equal(t, map[string]int64{
+		"ks.0.wf1_reverse.2": 0,
+		"ks.0.wf2_reverse.3": 1,
+		"ks.0.wf3_reverse.4": 1,
+	}, testStats.reverseLagAlarms())
+
+	// The lag of a stream only raises the alarm above a threshold.
+	config.ReverseLagAlarmThreshold = 0
+	require.Equal(t, int64(0), testStats.reverseLagAlarms()["ks.0.wf2_reverse.3"])
+}
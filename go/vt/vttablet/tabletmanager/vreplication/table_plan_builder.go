@@ -369,6 +369,8 @@ func (tpb *tablePlanBuilder) generate() *TablePlan {
 		BulkInsertValues:        tpb.generateValuesPart(sqlparser.NewTrackedBuffer(bvf.formatter), bvf),
 		BulkInsertOnDup:         tpb.generateOnDupPart(sqlparser.NewTrackedBuffer(bvf.formatter)),
 		Insert:                  tpb.generateInsertStatement(),
+		Upsert:                  tpb.generateUpsertStatement(),
+		UpsertOnDup:             tpb.generateUpsertOnDupPart(sqlparser.NewTrackedBuffer(bvf.formatter)),
 		Update:                  tpb.generateUpdateStatement(),
 		Delete:                  tpb.generateDeleteStatement(),
 		MultiDelete:             tpb.generateMultiDeleteStatement(),
@@ -686,6 +688,23 @@ func (tpb *tablePlanBuilder) generateInsertStatement() *sqlparser.ParsedQuery {
 	return buf.ParsedQuery()
 }
 
+// generateUpsertStatement generates the insert statement of the replication
+// phase of a table without grouping that updates the row if it already exists.
+func (tpb *tablePlanBuilder) generateUpsertStatement() *sqlparser.ParsedQuery {
+	if tpb.onInsert != insertNormal || tpb.lastpk != nil {
+		return nil
+	}
+	bvf := &bindvarFormatter{}
+	buf := sqlparser.NewTrackedBuffer(bvf.formatter)
+
+	tpb.generateInsertPart(buf)
+	buf.Myprintf(" values ", tpb.name)
+	tpb.generateValuesPart(buf, bvf)
+	tpb.generateUpsertOnDupPart(buf)
+
+	return buf.ParsedQuery()
+}
+
 func (tpb *tablePlanBuilder) generateInsertPart(buf *sqlparser.TrackedBuffer) *sqlparser.ParsedQuery {
 	if tpb.onInsert == insertIgnore {
 		buf.Myprintf("insert ignore into %v(", tpb.name)
@@ -799,6 +818,33 @@ func (tpb *tablePlanBuilder) generateOnDupPart(buf *sqlparser.TrackedBuffer) *sq
 	return buf.ParsedQuery()
 }
 
+// generateUpsertOnDupPart generates the on duplicate key clause of the upsert
+// statement, which sets the columns that are not part of the pk to their new
+// values. The first pk column is set to itself if there are no such columns.
+func (tpb *tablePlanBuilder) generateUpsertOnDupPart(buf *sqlparser.TrackedBuffer) *sqlparser.ParsedQuery {
+	if tpb.onInsert != insertNormal || tpb.lastpk != nil {
+		return nil
+	}
+	buf.Myprintf(" on duplicate key update ")
+	separator := ""
+	for _, cexpr := range tpb.colExprs {
+		if cexpr.isPK || cexpr.isGenerated {
+			continue
+		}
+		buf.Myprintf("%s%v=values(%v)", separator, cexpr.colName, cexpr.colName)
+		separator = ", "
+	}
+	if separator == "" {
+		for _, cexpr := range tpb.colExprs {
+			if cexpr.isPK && !cexpr.isGenerated {
+				buf.Myprintf("%v=%v", cexpr.colName, cexpr.colName)
+				break
+			}
+		}
+	}
+	return buf.ParsedQuery()
+}
+
 func (tpb *tablePlanBuilder) generateUpdateStatement() *sqlparser.ParsedQuery {
 	if tpb.onInsert == insertIgnore {
 		return tpb.generateInsertStatement()
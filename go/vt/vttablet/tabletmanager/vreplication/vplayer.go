@@ -390,6 +390,12 @@ func (vp *vplayer) updatePos(ctx context.Context, ts int64) (posReached bool, er
 	if _, err := vp.query(ctx, update); err != nil {
 		return false, fmt.Errorf("error %v updating position", err)
 	}
+	return vp.positionSaved()
+}
+
+// positionSaved records that vp.pos was saved and checks it against the stop
+// position.
+func (vp *vplayer) positionSaved() (posReached bool, err error) {
 	vp.numAccumulatedHeartbeats = 0
 	vp.unsavedEvent = nil
 	vp.timeLastSaved = time.Now()
@@ -491,6 +497,16 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 	// can estimate this value more accurately.
 	defer vp.vr.stats.ReplicationLagSeconds.Store(math.MaxInt64)
 	defer vp.vr.stats.VReplicationLags.Add(strconv.Itoa(int(vp.vr.id)), math.MaxInt64)
+
+	var parallelWorkers []*parallelApplyWorker
+	if vp.canApplyInParallel() {
+		var err error
+		if parallelWorkers, err = vp.newParallelApplyWorkers(ctx); err != nil {
+			return err
+		}
+		defer closeParallelApplyWorkers(parallelWorkers)
+	}
+
 	var lagSecs int64
 	for {
 		if ctx.Err() != nil {
@@ -525,6 +541,15 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 			}
 		}
 
+		// The batch is applied by the parallel apply workers if it allows
+		// it, in which case only the lag is computed from its events below.
+		appliedInParallel, err := vp.applyInParallel(ctx, parallelWorkers, items)
+		if err != nil {
+			vp.vr.stats.ErrorCounts.Add([]string{"Apply"}, 1)
+			log.Errorf("Error applying events in parallel: %s", err.Error())
+			return err
+		}
+
 		lagSecs = -1
 		for i, events := range items {
 			for j, event := range events {
@@ -540,6 +565,9 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 						lagSecs = event.CurrentTime/1e9 - event.Timestamp
 					}
 				}
+				if appliedInParallel {
+					continue
+				}
 				mustSave := false
 				switch event.Type {
 				case binlogdatapb.VEventType_COMMIT:
@@ -0,0 +1,299 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// beforeSavingParallelApplyPosition is called once all the parallel apply
+// workers but the one saving the position of a batch committed their
+// transactions. Tests use it to fail the batch in between.
+var beforeSavingParallelApplyPosition = func() error { return nil }
+
+// parallelApplyWorker applies the row events of a batch for a subset of the
+// tables of the workflow on its own connection.
+type parallelApplyWorker struct {
+	dbClient *vdbClient
+	tasks    []*parallelApplyTask
+}
+
+// parallelApplyTask is a row event along with the plan of its table at the
+// time of the event.
+type parallelApplyTask struct {
+	tplan    *TablePlan
+	rowEvent *binlogdatapb.RowEvent
+}
+
+// canApplyInParallel returns true if the workflow is configured to apply
+// the events of a stream with more than one worker. Only the replication
+// phase is applied in parallel, and never up to a stop position as each
+// commit must then be checked against it.
+func (vp *vplayer) canApplyInParallel() bool {
+	return vp.vr.workflowConfig.ParallelApplyWorkers > 1 && len(vp.copyState) == 0 && vp.stopPos.IsZero()
+}
+
+// newParallelApplyWorkers opens the connections of the parallel apply
+// workers. Their foreign key checks stay disabled as the rows of different
+// tables are no longer applied in the order of the source.
+func (vp *vplayer) newParallelApplyWorkers(ctx context.Context) ([]*parallelApplyWorker, error) {
+	workers := make([]*parallelApplyWorker, 0, vp.vr.workflowConfig.ParallelApplyWorkers)
+	for range vp.vr.workflowConfig.ParallelApplyWorkers {
+		dbClient, err := vp.vr.newClientConnection(ctx)
+		if err != nil {
+			closeParallelApplyWorkers(workers)
+			return nil, vterrors.Wrap(err, "failed to create the connection of a parallel apply worker")
+		}
+		workers = append(workers, &parallelApplyWorker{dbClient: dbClient})
+	}
+	return workers, nil
+}
+
+func closeParallelApplyWorkers(workers []*parallelApplyWorker) {
+	for _, w := range workers {
+		_ = w.dbClient.Rollback()
+		w.dbClient.Close()
+	}
+}
+
+// assignParallelApplyWorkers returns the index of the worker applying the row
+// events of each table of a batch of events fetched from the relay log, or the
+// reason why the batch must be applied serially.
+//
+// Only batches of complete transactions made of row events can be applied in
+// parallel. The tables changed by a same transaction are assigned to the same
+// worker, which keeps the transaction atomic and the changes of each table in
+// order. With relaxedOrdering, each table is assigned on its own instead: the
+// transactions spanning several tables are then split across the workers.
+func assignParallelApplyWorkers(items [][]*binlogdatapb.VEvent, workers int, relaxedOrdering bool) (map[string]int, string) {
+	// groups links each table to another table of its group, up to the
+	// table of the group that is linked to itself.
+	groups := make(map[string]string)
+	var tables []string
+	find := func(table string) string {
+		for groups[table] != table {
+			table = groups[table]
+		}
+		return table
+	}
+	var trxTables []string
+	complete := true
+	for _, events := range items {
+		for _, event := range events {
+			switch event.Type {
+			case binlogdatapb.VEventType_GTID, binlogdatapb.VEventType_FIELD, binlogdatapb.VEventType_HEARTBEAT:
+			case binlogdatapb.VEventType_BEGIN:
+				complete = false
+			case binlogdatapb.VEventType_ROW:
+				complete = false
+				table := event.RowEvent.TableName
+				if _, ok := groups[table]; !ok {
+					groups[table] = table
+					tables = append(tables, table)
+				}
+				trxTables = append(trxTables, table)
+			case binlogdatapb.VEventType_COMMIT:
+				complete = true
+				if !relaxedOrdering {
+					for _, table := range trxTables {
+						if root, first := find(table), find(trxTables[0]); root != first {
+							groups[root] = first
+						}
+					}
+				}
+				trxTables = trxTables[:0]
+			default:
+				return nil, fmt.Sprintf("the batch has a %s event", event.Type)
+			}
+		}
+	}
+	if !complete {
+		return nil, "the last transaction of the batch is partial"
+	}
+
+	assignments := make(map[string]int, len(tables))
+	groupWorkers := make(map[string]int)
+	for _, table := range tables {
+		root := find(table)
+		worker, ok := groupWorkers[root]
+		if !ok {
+			worker = len(groupWorkers) % workers
+			groupWorkers[root] = worker
+		}
+		assignments[table] = worker
+	}
+	if len(groupWorkers) < 2 {
+		return nil, "the transactions of the batch change a single group of tables"
+	}
+	return assignments, ""
+}
+
+// applyInParallel applies the events of the batch fetched from the relay log
+// with the parallel apply workers if the batch allows it, and then saves the
+// position of its last transaction. It returns false if the batch must
+// instead be applied serially.
+//
+// Each worker applies its row events in a transaction of its own. Once every
+// worker succeeded, the workers commit their transactions one after the other
+// and the position is saved in the transaction of the last one. A failure in
+// between makes the stream apply the transactions of the batch again when it
+// restarts, on top of the rows already committed by some of the workers. The
+// batch is thus only applied in parallel if its row events can be applied
+// again: inserts are then upserts, and partial row images and updates of the
+// primary key of a row are not allowed. The latter move the row from a key to
+// another, which is not idempotent once the rows under either key were
+// committed by a worker.
+func (vp *vplayer) applyInParallel(ctx context.Context, workers []*parallelApplyWorker, items [][]*binlogdatapb.VEvent) (bool, error) {
+	if len(workers) == 0 || vp.vr.dbClient.InTransaction {
+		return false, nil
+	}
+	serially := func(reason string) (bool, error) {
+		log.V(2).Infof("Applying the batch of stream %d serially: %s", vp.vr.id, reason)
+		return false, nil
+	}
+	assignments, reason := assignParallelApplyWorkers(items, len(workers), vp.vr.workflowConfig.ParallelApplyRelaxedOrdering)
+	if reason != "" {
+		return serially(reason)
+	}
+
+	pos := vp.pos
+	var timestamp int64
+	for _, w := range workers {
+		w.tasks = w.tasks[:0]
+	}
+	for _, events := range items {
+		for _, event := range events {
+			switch event.Type {
+			case binlogdatapb.VEventType_GTID:
+				var err error
+				if pos, err = binlogplayer.DecodePosition(event.Gtid); err != nil {
+					return true, err
+				}
+			case binlogdatapb.VEventType_FIELD:
+				tplan, err := vp.replicatorPlan.buildExecutionPlan(event.FieldEvent)
+				if err != nil {
+					return true, err
+				}
+				vp.tablePlans[event.FieldEvent.TableName] = tplan
+			case binlogdatapb.VEventType_ROW:
+				tplan := vp.tablePlans[event.RowEvent.TableName]
+				if tplan == nil {
+					return true, fmt.Errorf("unexpected event on table %s", event.RowEvent.TableName)
+				}
+				if !tplan.upsertInserts() {
+					return serially(fmt.Sprintf("the inserts into table %s cannot be applied again", event.RowEvent.TableName))
+				}
+				for _, change := range event.RowEvent.RowChanges {
+					if tplan.isPartial(change) {
+						return serially(fmt.Sprintf("the batch has a partial row image of table %s", event.RowEvent.TableName))
+					}
+					if tplan.changesPK(change) {
+						return serially(fmt.Sprintf("the batch changes the primary key of a row of table %s", event.RowEvent.TableName))
+					}
+				}
+				w := workers[assignments[event.RowEvent.TableName]]
+				w.tasks = append(w.tasks, &parallelApplyTask{tplan: tplan, rowEvent: event.RowEvent})
+			case binlogdatapb.VEventType_COMMIT:
+				timestamp = event.Timestamp
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(workers))
+	for i, w := range workers {
+		if len(w.tasks) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = vp.applyParallelApplyTasks(ctx, w)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			for _, w := range workers {
+				_ = w.dbClient.Rollback()
+			}
+			return true, err
+		}
+	}
+	var last *vdbClient
+	for _, w := range workers {
+		if !w.dbClient.InTransaction {
+			continue
+		}
+		if last != nil {
+			if err := last.Commit(); err != nil {
+				return true, vterrors.Wrap(err, "failed to commit the transaction of a parallel apply worker")
+			}
+		}
+		last = w.dbClient
+	}
+	if last == nil {
+		return true, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no parallel apply worker applied the batch of stream %d", vp.vr.id)
+	}
+	if err := beforeSavingParallelApplyPosition(); err != nil {
+		return true, err
+	}
+	update := binlogplayer.GenerateUpdatePos(vp.vr.id, pos, time.Now().Unix(), timestamp, vp.vr.stats.CopyRowCount.Get(), vp.vr.workflowConfig.StoreCompressedGTID)
+	if _, err := last.ExecuteWithRetry(ctx, update); err != nil {
+		return true, fmt.Errorf("error %v updating position", err)
+	}
+	if err := last.Commit(); err != nil {
+		return true, vterrors.Wrap(err, "failed to commit the position of a batch applied in parallel")
+	}
+	vp.pos = pos
+	_, err := vp.positionSaved()
+	return true, err
+}
+
+// applyParallelApplyTasks applies the row events assigned to a worker in a
+// transaction that is left open.
+func (vp *vplayer) applyParallelApplyTasks(ctx context.Context, w *parallelApplyWorker) error {
+	if err := w.dbClient.Begin(); err != nil {
+		return err
+	}
+	applyFunc := func(sql string) (*sqltypes.Result, error) {
+		start := time.Now()
+		qr, err := w.dbClient.ExecuteWithRetry(ctx, sql)
+		vp.vr.stats.QueryCount.Add(vp.phase, 1)
+		vp.vr.stats.QueryTimings.Record(vp.phase, start)
+		return qr, err
+	}
+	for _, task := range w.tasks {
+		for _, change := range task.rowEvent.RowChanges {
+			if _, err := task.tplan.applyChange(change, applyFunc); err != nil {
+				return vterrors.Wrapf(err, "error applying event for table %s", task.rowEvent.TableName)
+			}
+		}
+	}
+	return nil
+}
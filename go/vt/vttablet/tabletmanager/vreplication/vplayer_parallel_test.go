@@ -0,0 +1,299 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vttablet "vitess.io/vitess/go/vt/vttablet/common"
+)
+
+func TestAssignParallelApplyWorkers(t *testing.T) {
+	event := func(typ binlogdatapb.VEventType) *binlogdatapb.VEvent {
+		return &binlogdatapb.VEvent{Type: typ}
+	}
+	trx := func(tables ...string) []*binlogdatapb.VEvent {
+		events := []*binlogdatapb.VEvent{event(binlogdatapb.VEventType_GTID), event(binlogdatapb.VEventType_BEGIN)}
+		for _, table := range tables {
+			events = append(events, &binlogdatapb.VEvent{
+				Type:     binlogdatapb.VEventType_ROW,
+				RowEvent: &binlogdatapb.RowEvent{TableName: table},
+			})
+		}
+		return append(events, event(binlogdatapb.VEventType_COMMIT))
+	}
+
+	testCases := []struct {
+		name            string
+		items           [][]*binlogdatapb.VEvent
+		workers         int
+		relaxedOrdering bool
+		want            map[string]int
+		wantReason      string
+	}{
+		{
+			name:    "single table transactions",
+			items:   [][]*binlogdatapb.VEvent{trx("t1"), trx("t2"), trx("t3"), trx("t1")},
+			workers: 2,
+			want:    map[string]int{"t1": 0, "t2": 1, "t3": 0},
+		},
+		{
+			name:    "transaction spanning tables",
+			items:   [][]*binlogdatapb.VEvent{trx("t1"), trx("t2"), trx("t3"), trx("t3", "t1")},
+			workers: 4,
+			want:    map[string]int{"t1": 0, "t2": 1, "t3": 0},
+		},
+		{
+			name:            "transaction spanning tables with relaxed ordering",
+			items:           [][]*binlogdatapb.VEvent{trx("t1"), trx("t2"), trx("t3"), trx("t3", "t1")},
+			workers:         4,
+			relaxedOrdering: true,
+			want:            map[string]int{"t1": 0, "t2": 1, "t3": 2},
+		},
+		{
+			name:       "transactions linking all tables",
+			items:      [][]*binlogdatapb.VEvent{trx("t1", "t2"), trx("t2", "t3")},
+			workers:    2,
+			wantReason: "the transactions of the batch change a single group of tables",
+		},
+		{
+			name:       "partial transaction",
+			items:      [][]*binlogdatapb.VEvent{trx("t1"), trx("t2")[:3]},
+			workers:    2,
+			wantReason: "the last transaction of the batch is partial",
+		},
+		{
+			name:       "ddl",
+			items:      [][]*binlogdatapb.VEvent{trx("t1"), {event(binlogdatapb.VEventType_GTID), event(binlogdatapb.VEventType_DDL)}, trx("t2")},
+			workers:    2,
+			wantReason: "the batch has a DDL event",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, reason := assignParallelApplyWorkers(tc.items, tc.workers, tc.relaxedOrdering)
+			require.Equal(t, tc.wantReason, reason)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTablePlanChangesPK(t *testing.T) {
+	tp := &TablePlan{
+		Fields:       sqltypes.MakeTestFields("id|val", "int64|varchar"),
+		PKReferences: []string{"id"},
+	}
+	row := func(id int64, val string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewInt64(id), sqltypes.NewVarChar(val)})
+	}
+
+	require.False(t, tp.changesPK(&binlogdatapb.RowChange{After: row(1, "a")}))
+	require.False(t, tp.changesPK(&binlogdatapb.RowChange{Before: row(1, "a")}))
+	require.False(t, tp.changesPK(&binlogdatapb.RowChange{Before: row(1, "a"), After: row(1, "b")}))
+	require.True(t, tp.changesPK(&binlogdatapb.RowChange{Before: row(1, "a"), After: row(2, "a")}))
+}
+
+// setupParallelApply creates the tables t1 and t2 and returns the binlog
+// source of a stream replicating them with two parallel apply workers.
+func setupParallelApply(t *testing.T) *binlogdatapb.BinlogSource {
+	doNotLogDBQueries = true
+	savedWorkers := vttablet.DefaultVReplicationConfig.ParallelApplyWorkers
+	savedDelay := vttablet.DefaultVReplicationConfig.RetryDelay
+	vttablet.DefaultVReplicationConfig.ParallelApplyWorkers = 2
+	vttablet.DefaultVReplicationConfig.RetryDelay = 1 * time.Millisecond
+	t.Cleanup(func() {
+		vttablet.DefaultVReplicationConfig.ParallelApplyWorkers = savedWorkers
+		vttablet.DefaultVReplicationConfig.RetryDelay = savedDelay
+		doNotLogDBQueries = false
+	})
+
+	execStatements(t, []string{
+		"create table t1(id int, val varchar(128), primary key(id))",
+		fmt.Sprintf("create table %s.t1(id int, val varchar(128), primary key(id))", vrepldb),
+		"create table t2(id int, val varchar(128), primary key(id))",
+		fmt.Sprintf("create table %s.t2(id int, val varchar(128), primary key(id))", vrepldb),
+	})
+	t.Cleanup(func() {
+		execStatements(t, []string{
+			"drop table t1",
+			fmt.Sprintf("drop table %s.t1", vrepldb),
+			"drop table t2",
+			fmt.Sprintf("drop table %s.t2", vrepldb),
+		})
+	})
+
+	return &binlogdatapb.BinlogSource{
+		Keyspace: env.KeyspaceName,
+		Shard:    env.ShardName,
+		Filter: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match: "/.*",
+			}},
+		},
+		OnDdl: binlogdatapb.OnDDLAction_IGNORE,
+	}
+}
+
+// parallelApplyStatements are the transactions replicated by the parallel
+// apply tests, which are executed before the stream starts so that they are
+// fetched from the relay log as a single batch.
+var parallelApplyStatements = []string{
+	"insert into t1 values(1, 'aaa'), (2, 'bbb')",
+	"insert into t2 values(1, 'ccc')",
+	"begin",
+	"update t1 set val = 'ddd' where id = 1",
+	"insert into t1 values(3, 'eee')",
+	"commit",
+	"insert into t2 values(2, 'fff'), (3, 'ggg')",
+	"delete from t2 where id = 1",
+	"delete from t1 where id = 2",
+}
+
+func expectParallelApplyData(t *testing.T) {
+	t.Helper()
+	expectData(t, "t1", [][]string{
+		{"1", "ddd"},
+		{"3", "eee"},
+	})
+	expectData(t, "t2", [][]string{
+		{"2", "fff"},
+		{"3", "ggg"},
+	})
+}
+
+func TestPlayerParallelApply(t *testing.T) {
+	defer deleteTablet(addTablet(100))
+	bls := setupParallelApply(t)
+
+	var batches atomic.Int32
+	savedHook := beforeSavingParallelApplyPosition
+	defer func() { beforeSavingParallelApplyPosition = savedHook }()
+	beforeSavingParallelApplyPosition = func() error {
+		batches.Add(1)
+		return nil
+	}
+
+	pos := primaryPosition(t)
+	execStatements(t, parallelApplyStatements)
+	endPos, err := binlogplayer.DecodePosition(primaryPosition(t))
+	require.NoError(t, err)
+	cancel, id := startVReplication(t, bls, pos)
+	defer cancel()
+
+	expectParallelApplyData(t)
+	require.Positive(t, batches.Load(), "no batch was applied in parallel")
+	require.Eventually(t, func() bool {
+		qr, err := env.Mysqld.FetchSuperQuery(context.Background(), fmt.Sprintf("select pos from _vt.vreplication where id = %d", id))
+		if err != nil || len(qr.Rows) != 1 {
+			return false
+		}
+		savedPos, err := binlogplayer.DecodePosition(qr.Rows[0][0].ToString())
+		return err == nil && savedPos.AtLeast(endPos)
+	}, 10*time.Second, 100*time.Millisecond, "the position of the batch was not saved")
+}
+
+// TestPlayerParallelApplyRestart fails a batch applied in parallel after the
+// first worker committed its transaction and before the position is saved,
+// and checks that the stream applies the batch again when it restarts.
+func TestPlayerParallelApplyRestart(t *testing.T) {
+	defer deleteTablet(addTablet(100))
+	bls := setupParallelApply(t)
+
+	var batches atomic.Int32
+	var committedRows, uncommittedRows atomic.Int32
+	savedHook := beforeSavingParallelApplyPosition
+	defer func() { beforeSavingParallelApplyPosition = savedHook }()
+	beforeSavingParallelApplyPosition = func() error {
+		if batches.Add(1) > 1 {
+			return nil
+		}
+		// The tables are assigned to the workers in the order of their first
+		// row event: the worker of t1 committed, the one of t2 did not.
+		for table, rows := range map[string]*atomic.Int32{"t1": &committedRows, "t2": &uncommittedRows} {
+			qr, err := env.Mysqld.FetchSuperQuery(context.Background(), fmt.Sprintf("select count(*) from %s.%s", vrepldb, table))
+			if err != nil {
+				return err
+			}
+			n, err := qr.Rows[0][0].ToInt32()
+			if err != nil {
+				return err
+			}
+			rows.Store(n)
+		}
+		return errors.New("injected failure before saving the position")
+	}
+
+	pos := primaryPosition(t)
+	execStatements(t, parallelApplyStatements)
+	cancel, _ := startVReplication(t, bls, pos)
+	defer cancel()
+
+	expectParallelApplyData(t)
+	require.GreaterOrEqual(t, batches.Load(), int32(2), "the batch was not applied again")
+	require.Positive(t, committedRows.Load())
+	require.Zero(t, uncommittedRows.Load())
+}
+
+// TestPlayerParallelApplyPKChange fails the first batch applied in parallel
+// before its position is saved, and checks that a batch which changes the
+// primary key of a row is applied serially, so that it is not applied again on
+// top of the rows already committed.
+func TestPlayerParallelApplyPKChange(t *testing.T) {
+	defer deleteTablet(addTablet(100))
+	bls := setupParallelApply(t)
+
+	var batches atomic.Int32
+	savedHook := beforeSavingParallelApplyPosition
+	defer func() { beforeSavingParallelApplyPosition = savedHook }()
+	beforeSavingParallelApplyPosition = func() error {
+		if batches.Add(1) > 1 {
+			return nil
+		}
+		return errors.New("injected failure before saving the position")
+	}
+
+	pos := primaryPosition(t)
+	execStatements(t, []string{
+		"insert into t1 values(1, 'aaa')",
+		"insert into t2 values(1, 'bbb')",
+		"update t1 set id = 2 where id = 1",
+		"insert into t2 values(2, 'ccc')",
+	})
+	cancel, _ := startVReplication(t, bls, pos)
+	defer cancel()
+
+	expectData(t, "t1", [][]string{
+		{"2", "aaa"},
+	})
+	expectData(t, "t2", [][]string{
+		{"1", "bbb"},
+		{"2", "ccc"},
+	})
+	require.Zero(t, batches.Load(), "the batch was applied in parallel")
+}
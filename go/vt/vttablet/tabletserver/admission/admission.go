@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission provides priority based admission control for vttablet
+// requests. See the Controller struct for details.
+package admission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Class is the admission class of a request. Classes are declared in
+// priority order: when MySQL is saturated, queued requests of a lower
+// Class value are admitted before requests of a higher one.
+type Class int
+
+const (
+	// OLTP is the class for regular, latency sensitive queries.
+	OLTP Class = iota
+	// VReplication is the class for vstreamer requests.
+	VReplication
+	// OLAP is the class for queries executed with the OLAP workload.
+	OLAP
+	// DDL is the class for schema changes.
+	DDL
+
+	numClasses
+)
+
+var classNames = [numClasses]string{
+	OLTP:         "oltp",
+	VReplication: "vreplication",
+	OLAP:         "olap",
+	DDL:          "ddl",
+}
+
+func (c Class) String() string {
+	if c < 0 || c >= numClasses {
+		return "unknown"
+	}
+	return classNames[c]
+}
+
+// Classify returns the admission class of a query request.
+func Classify(sql string, options *querypb.ExecuteOptions) Class {
+	switch sqlparser.Preview(sql) {
+	case sqlparser.StmtDDL, sqlparser.StmtMigration:
+		return DDL
+	}
+	if options.GetWorkload() == querypb.ExecuteOptions_OLAP {
+		return OLAP
+	}
+	return OLTP
+}
+
+// DoneFunc is returned by Admit() and must be called by the caller once
+// the request has finished.
+type DoneFunc func()
+
+// SaturationFunc reports whether MySQL is currently saturated.
+type SaturationFunc func() bool
+
+// Controller queues incoming requests when MySQL is saturated.
+//
+// As long as MySQL is not saturated, all requests are admitted immediately
+// and only accounted for. Once saturation is detected, every class is limited
+// to its configured number of concurrent requests, and requests never overtake
+// queued requests of the same or a higher priority class (see Class). Whenever
+// a request finishes, the freed up slot is handed to the highest priority
+// queued request whose class is below its limit. The total number of queued
+// requests is limited to avoid that queued requests consume the full capacity
+// of vttablet.
+type Controller struct {
+	// Immutable fields.
+	enabled      bool
+	dryRun       bool
+	maxQueueSize int
+	limits       [numClasses]int
+	saturated    SaturationFunc
+
+	// waits counts per class how many requests were queued.
+	// waitsDryRun counts per class how many requests would have been queued.
+	// queueExceeded counts per class how many requests were rejected because
+	// the queue was full.
+	waits, waitsDryRun, queueExceeded *stats.CountersWithSingleLabel
+	waitTimings                       *servenv.TimingsWrapper
+
+	logDryRun *logutil.ThrottledLogger
+
+	mu       sync.Mutex
+	inFlight [numClasses]int
+	queues   [numClasses][]chan struct{}
+	queued   int
+}
+
+// New returns a Controller. saturated is consulted for every request and
+// must be cheap.
+func New(env tabletenv.Env, saturated SaturationFunc) *Controller {
+	config := env.Config().AdmissionControl
+	c := &Controller{
+		enabled:      config.Mode != tabletenv.Disable,
+		dryRun:       config.Mode == tabletenv.Dryrun,
+		maxQueueSize: config.MaxQueueSize,
+		saturated:    saturated,
+		waits: env.Exporter().NewCountersWithSingleLabel(
+			"AdmissionControlWaits",
+			"Number of requests that were queued because MySQL was saturated",
+			"class"),
+		waitsDryRun: env.Exporter().NewCountersWithSingleLabel(
+			"AdmissionControlWaitsDryRun",
+			"Dry run number of requests that would have been queued",
+			"class"),
+		queueExceeded: env.Exporter().NewCountersWithSingleLabel(
+			"AdmissionControlQueueExceeded",
+			"Number of requests that were rejected because the admission queue was full",
+			"class"),
+		waitTimings: env.Exporter().NewTimings(
+			"AdmissionControlWaitTime",
+			"Time spent by requests in the admission queue",
+			"class"),
+		logDryRun: logutil.NewThrottledLogger("AdmissionControl DryRun", 5*time.Second),
+	}
+	c.limits[OLTP] = config.OltpConcurrency
+	c.limits[OLAP] = config.OlapConcurrency
+	c.limits[DDL] = config.DDLConcurrency
+	c.limits[VReplication] = config.VReplicationConcurrency
+
+	env.Exporter().NewGaugesFuncWithMultiLabels(
+		"AdmissionControlInFlight",
+		"Number of admitted requests per class",
+		[]string{"class"},
+		func() map[string]int64 { return c.counts(func(class Class) int { return c.inFlight[class] }) })
+	env.Exporter().NewGaugesFuncWithMultiLabels(
+		"AdmissionControlQueued",
+		"Number of queued requests per class",
+		[]string{"class"},
+		func() map[string]int64 { return c.counts(func(class Class) int { return len(c.queues[class]) }) })
+	return c
+}
+
+// Admit blocks while MySQL is saturated and the given class has reached its
+// concurrency limit. "done" is != nil if err == nil and must be called once
+// the request has finished.
+// "err" is not nil if a) the context is done or b) the queue is full.
+func (c *Controller) Admit(ctx context.Context, class Class) (done DoneFunc, err error) {
+	if !c.enabled {
+		return func() {}, nil
+	}
+
+	c.mu.Lock()
+	if !c.mustWaitLocked(class) {
+		c.inFlight[class]++
+		c.mu.Unlock()
+		return func() { c.release(class) }, nil
+	}
+
+	if c.dryRun {
+		c.inFlight[class]++
+		c.mu.Unlock()
+		c.waitsDryRun.Add(class.String(), 1)
+		c.logDryRun.Warningf("Would have queued %v request because MySQL is saturated", class)
+		return func() { c.release(class) }, nil
+	}
+
+	if c.queued >= c.maxQueueSize {
+		c.mu.Unlock()
+		c.queueExceeded.Add(class.String(), 1)
+		return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED,
+			"admission control: too many queued requests (%d >= %d)", c.queued, c.maxQueueSize)
+	}
+
+	ready := make(chan struct{})
+	c.queues[class] = append(c.queues[class], ready)
+	c.queued++
+	c.mu.Unlock()
+
+	c.waits.Add(class.String(), 1)
+	defer c.waitTimings.Record(class.String(), time.Now())
+
+	select {
+	case <-ready:
+		return func() { c.release(class) }, nil
+	case <-ctx.Done():
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.removeLocked(class, ready) {
+		return nil, ctx.Err()
+	}
+	// We were admitted concurrently with the context being done. Give the slot
+	// back to the next waiting request.
+	c.inFlight[class]--
+	c.dispatchLocked()
+	return nil, ctx.Err()
+}
+
+// mustWaitLocked returns true if a new request of the given class cannot be
+// admitted right away.
+func (c *Controller) mustWaitLocked(class Class) bool {
+	if c.queued == 0 && !c.atLimitLocked(class) {
+		return false
+	}
+	if !c.saturated() {
+		return false
+	}
+	// Do not overtake requests of the same or a higher priority class.
+	for higher := OLTP; higher <= class; higher++ {
+		if len(c.queues[higher]) > 0 {
+			return true
+		}
+	}
+	return c.atLimitLocked(class)
+}
+
+func (c *Controller) atLimitLocked(class Class) bool {
+	return c.limits[class] > 0 && c.inFlight[class] >= c.limits[class]
+}
+
+func (c *Controller) release(class Class) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inFlight[class]--
+	c.dispatchLocked()
+}
+
+// dispatchLocked admits queued requests in priority order. While MySQL is
+// saturated, only the slot which was just freed up is handed out. Otherwise,
+// all queued requests are admitted.
+func (c *Controller) dispatchLocked() {
+	if c.queued == 0 {
+		return
+	}
+	saturated := c.saturated()
+	for class := range numClasses {
+		for len(c.queues[class]) > 0 {
+			if saturated && c.atLimitLocked(class) {
+				break
+			}
+			ready := c.queues[class][0]
+			c.queues[class] = c.queues[class][1:]
+			c.queued--
+			c.inFlight[class]++
+			close(ready)
+			if saturated {
+				return
+			}
+		}
+	}
+}
+
+// removeLocked removes a waiting request from its queue. It returns false if
+// the request was not queued anymore i.e. it was admitted in the meantime.
+func (c *Controller) removeLocked(class Class, ready chan struct{}) bool {
+	for i, ch := range c.queues[class] {
+		if ch == ready {
+			c.queues[class] = append(c.queues[class][:i], c.queues[class][i+1:]...)
+			c.queued--
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) counts(value func(Class) int) map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int64, numClasses)
+	for class := range numClasses {
+		counts[class.String()] = int64(value(class))
+	}
+	return counts
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func newTestController(t *testing.T, mode string, saturated *atomic.Bool) *Controller {
+	t.Helper()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.AdmissionControl.Mode = mode
+	cfg.AdmissionControl.MaxQueueSize = 2
+	cfg.AdmissionControl.OltpConcurrency = 1
+	cfg.AdmissionControl.OlapConcurrency = 1
+	c := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "AdmissionControlTest"), saturated.Load)
+	c.waits.ResetAll()
+	c.waitsDryRun.ResetAll()
+	c.queueExceeded.ResetAll()
+	return c
+}
+
+func TestClassify(t *testing.T) {
+	olap := &querypb.ExecuteOptions{Workload: querypb.ExecuteOptions_OLAP}
+	assert.Equal(t, OLTP, Classify("select * from t", nil))
+	assert.Equal(t, OLAP, Classify("select * from t", olap))
+	assert.Equal(t, DDL, Classify("alter table t add column c int", nil))
+	assert.Equal(t, DDL, Classify("alter table t add column c int", olap))
+	assert.Equal(t, DDL, Classify("alter vitess_migration complete all", nil))
+	assert.Equal(t, "vreplication", VReplication.String())
+}
+
+func TestAdmitNotSaturated(t *testing.T) {
+	var saturated atomic.Bool
+	c := newTestController(t, tabletenv.Enable, &saturated)
+
+	done1, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+	done2, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"oltp": 2, "olap": 0, "ddl": 0, "vreplication": 0}, c.counts(func(class Class) int { return c.inFlight[class] }))
+
+	done1()
+	done2()
+	assert.Zero(t, c.waits.Counts()["oltp"])
+}
+
+func TestAdmitSaturatedPriority(t *testing.T) {
+	var saturated atomic.Bool
+	saturated.Store(true)
+	c := newTestController(t, tabletenv.Enable, &saturated)
+
+	oltpDone, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+
+	admitted := make(chan Class, 3)
+	release := make(chan struct{})
+	admit := func(class Class) {
+		done, err := c.Admit(context.Background(), class)
+		if !assert.NoError(t, err) {
+			return
+		}
+		admitted <- class
+		<-release
+		done()
+	}
+	go admit(OLTP)
+	require.Eventually(t, func() bool { return c.waits.Counts()["oltp"] == 1 }, 5*time.Second, time.Millisecond)
+	// DDL is below its limit but must not overtake the queued OLTP request.
+	go admit(DDL)
+	require.Eventually(t, func() bool { return c.waits.Counts()["ddl"] == 1 }, 5*time.Second, time.Millisecond)
+
+	// The queue is full.
+	_, err = c.Admit(context.Background(), OLAP)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualValues(t, 1, c.queueExceeded.Counts()["olap"])
+
+	// Releasing a slot while saturated admits the highest priority request only.
+	oltpDone()
+	assert.Equal(t, OLTP, <-admitted)
+	assert.Len(t, c.queues[DDL], 1)
+
+	// Saturation is gone: the next release drains the queue.
+	saturated.Store(false)
+	release <- struct{}{}
+	assert.Equal(t, DDL, <-admitted)
+	close(release)
+}
+
+func TestAdmitContextDone(t *testing.T) {
+	var saturated atomic.Bool
+	saturated.Store(true)
+	c := newTestController(t, tabletenv.Enable, &saturated)
+
+	done, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Admit(ctx, OLTP)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, c.queued)
+
+	done()
+	assert.Zero(t, c.inFlight[OLTP])
+}
+
+func TestAdmitDryRun(t *testing.T) {
+	var saturated atomic.Bool
+	saturated.Store(true)
+	c := newTestController(t, tabletenv.Dryrun, &saturated)
+
+	done1, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+	done2, err := c.Admit(context.Background(), OLTP)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, c.waitsDryRun.Counts()["oltp"])
+	assert.Zero(t, c.waits.Counts()["oltp"])
+
+	done1()
+	done2()
+}
+
+func TestAdmitDisabled(t *testing.T) {
+	var saturated atomic.Bool
+	saturated.Store(true)
+	c := newTestController(t, tabletenv.Disable, &saturated)
+
+	for range 3 {
+		done, err := c.Admit(context.Background(), OLTP)
+		require.NoError(t, err)
+		defer done()
+	}
+	assert.Zero(t, c.inFlight[OLTP])
+}
@@ -160,6 +160,14 @@ func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields
 }
 
 func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfields bool, insideTxn bool) (*sqltypes.Result, error) {
+	return dbc.execOnceWith(ctx, query, insideTxn, func() (*sqltypes.Result, error) {
+		return dbc.conn.ExecuteFetch(query, maxrows, wantfields)
+	})
+}
+
+// execOnceWith runs exec, which executes the query on the connection, while
+// watching the context.
+func (dbc *Conn) execOnceWith(ctx context.Context, query string, insideTxn bool, exec func() (*sqltypes.Result, error)) (*sqltypes.Result, error) {
 	dbc.current.Store(&query)
 	defer dbc.current.Store(nil)
 
@@ -179,7 +187,7 @@ func (dbc *Conn) execOnce(ctx context.Context, query string, maxrows int, wantfi
 
 	ch := make(chan execResult)
 	go func() {
-		result, err := dbc.conn.ExecuteFetch(query, maxrows, wantfields)
+		result, err := exec()
 		ch <- execResult{result, err}
 		close(ch)
 	}()
@@ -230,6 +238,19 @@ func (dbc *Conn) ExecOnce(ctx context.Context, query string, maxrows int, wantfi
 	return dbc.execOnce(ctx, query, maxrows, wantfields, true /* Once means we are in a txn*/)
 }
 
+// ExecMulti executes the specified query, which may return several result
+// sets such as a CALL, and returns the first one. If it has the
+// SERVER_MORE_RESULTS_EXISTS flag set, the following result sets must be read
+// with FetchNext. It does not retry on connection errors, and kills the
+// connection rather than the query if the context expires, so that the
+// connection cannot be reused with result sets left to read.
+func (dbc *Conn) ExecMulti(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
+	return dbc.execOnceWith(ctx, query, true, func() (*sqltypes.Result, error) {
+		result, _, err := dbc.conn.ExecuteFetchMulti(query, maxrows, wantfields)
+		return result, err
+	})
+}
+
 // FetchNext returns the next result set.
 func (dbc *Conn) FetchNext(ctx context.Context, maxrows int, wantfields bool) (*sqltypes.Result, error) {
 	// Check if the context is already past its deadline before
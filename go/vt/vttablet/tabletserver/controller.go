@@ -55,6 +55,9 @@ type Controller interface {
 	// Returns true if the state of QueryService or the tablet type changed.
 	SetServingType(tabletType topodatapb.TabletType, ptsTimestamp time.Time, serving bool, reason string) error
 
+	// ReconnectMySQL closes all the connections to MySQL and opens new ones.
+	ReconnectMySQL(reason string) error
+
 	// EnterLameduck causes tabletserver to enter the lameduck state.
 	EnterLameduck()
 
@@ -82,6 +85,9 @@ type Controller interface {
 	// QueryService returns the QueryService object used by this Controller
 	QueryService() queryservice.QueryService
 
+	// CurrentTarget returns the target of the tablet, as set by InitDBConfig.
+	CurrentTarget() *querypb.Target
+
 	// SchemaEngine returns the SchemaEngine object used by this Controller
 	SchemaEngine() *schema.Engine
 
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/vt/dynamicflags"
+)
+
+// dynamicConfigPoolResizeTimeout bounds the time spent waiting for the
+// connections in use to be returned when a pool is shrunk.
+const dynamicConfigPoolResizeTimeout = 30 * time.Second
+
+// RegisterDynamicConfig binds the vttablet flags that can be overridden by
+// the dynamic config in the topo to the settings of tsv.
+func RegisterDynamicConfig(tsv *TabletServer) {
+	dynamicflags.Register("queryserver-config-query-timeout", tsv.loadQueryTimeout, func(d time.Duration) error {
+		tsv.QueryTimeout.Store(d.Nanoseconds())
+		return nil
+	})
+	dynamicflags.Register("queryserver-config-pool-size", tsv.PoolSize, setPoolSizeFunc(tsv.SetPoolSize))
+	dynamicflags.Register("queryserver-config-stream-pool-size", tsv.StreamPoolSize, setPoolSizeFunc(tsv.SetStreamPoolSize))
+	dynamicflags.Register("queryserver-config-transaction-cap", tsv.TxPoolSize, setPoolSizeFunc(tsv.SetTxPoolSize))
+	dynamicflags.Register("queryserver-config-max-result-size", tsv.MaxResultSize, func(size int) error {
+		tsv.SetMaxResultSize(size)
+		return nil
+	})
+	dynamicflags.Register("queryserver-config-warn-result-size", tsv.WarnResultSize, func(size int) error {
+		tsv.SetWarnResultSize(size)
+		return nil
+	})
+}
+
+func setPoolSizeFunc(setPoolSize func(context.Context, int) error) func(int) error {
+	return func(size int) error {
+		ctx, cancel := context.WithTimeout(context.Background(), dynamicConfigPoolResizeTimeout)
+		defer cancel()
+		return setPoolSize(ctx, size)
+	}
+}
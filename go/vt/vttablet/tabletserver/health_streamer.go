@@ -190,7 +190,7 @@ func (hs *healthStreamer) unregister(ch chan *querypb.StreamHealthResponse) {
 	delete(hs.clients, ch)
 }
 
-func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimestamp time.Time, lag time.Duration, err error, serving bool) {
+func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimestamp time.Time, lag, staleness time.Duration, err error, serving bool) {
 	hs.fieldsMu.Lock()
 	defer hs.fieldsMu.Unlock()
 
@@ -206,6 +206,7 @@ func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, ptsTimes
 		hs.state.RealtimeStats.HealthError = ""
 	}
 	hs.state.RealtimeStats.ReplicationLagSeconds = uint32(lag.Seconds())
+	hs.state.RealtimeStats.ReplicationStalenessMs = staleness.Milliseconds()
 	hs.state.Serving = serving
 
 	hs.state.RealtimeStats.FilteredReplicationLagSeconds, hs.state.RealtimeStats.BinlogPlayersCount = blpFunc()
@@ -116,7 +116,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 	}
 	assert.Truef(t, proto.Equal(want, shr), "want: %v, got: %v", want, shr)
 
-	hs.ChangeState(topodatapb.TabletType_REPLICA, time.Time{}, 0, nil, false)
+	hs.ChangeState(topodatapb.TabletType_REPLICA, time.Time{}, 0, 0, nil, false)
 	shr = <-ch
 	want = &querypb.StreamHealthResponse{
 		Target: &querypb.Target{
@@ -132,7 +132,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 
 	// Test primary and timestamp.
 	now := time.Now()
-	hs.ChangeState(topodatapb.TabletType_PRIMARY, now, 0, nil, true)
+	hs.ChangeState(topodatapb.TabletType_PRIMARY, now, 0, 0, nil, true)
 	shr = <-ch
 	want = &querypb.StreamHealthResponse{
 		Target: &querypb.Target{
@@ -149,7 +149,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 	assert.Truef(t, proto.Equal(want, shr), "want: %v, got: %v", want, shr)
 
 	// Test non-serving, and 0 timestamp for non-primary.
-	hs.ChangeState(topodatapb.TabletType_REPLICA, now, 1*time.Second, nil, false)
+	hs.ChangeState(topodatapb.TabletType_REPLICA, now, 1*time.Second, 1500*time.Millisecond, nil, false)
 	shr = <-ch
 	want = &querypb.StreamHealthResponse{
 		Target: &querypb.Target{
@@ -158,6 +158,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 		TabletAlias: alias,
 		RealtimeStats: &querypb.RealtimeStats{
 			ReplicationLagSeconds:         1,
+			ReplicationStalenessMs:        1500,
 			FilteredReplicationLagSeconds: 1,
 			BinlogPlayersCount:            2,
 		},
@@ -165,7 +166,7 @@ func TestHealthStreamerBroadcast(t *testing.T) {
 	assert.Truef(t, proto.Equal(want, shr), "want: %v, got: %v", want, shr)
 
 	// Test Health error.
-	hs.ChangeState(topodatapb.TabletType_REPLICA, now, 0, errors.New("repl err"), false)
+	hs.ChangeState(topodatapb.TabletType_REPLICA, now, 0, 0, errors.New("repl err"), false)
 	shr = <-ch
 	want = &querypb.StreamHealthResponse{
 		Target: &querypb.Target{
@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"bytes"
+
+	"vitess.io/vitess/go/hll"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vthash"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// partialAggregator folds the consecutive rows of the result of a SELECT
+// which are equal in all their columns but its partial aggregates, and
+// aggregates these columns further. vtgate orders the rows of the SELECT by
+// their group, so that each shard returns a single row per group, where the
+// distinct values of an approximate COUNT(DISTINCT) are folded into their
+// HyperLogLog sketch.
+type partialAggregator struct {
+	partials     []planbuilder.PartialAggregate
+	collationEnv *collations.Environment
+
+	// isPartial tells which columns are partial aggregates.
+	isPartial []bool
+	aggrs     []partialAggregate
+	fields    []*querypb.Field
+
+	// current is the first row of the group being folded, nil if none.
+	current sqltypes.Row
+}
+
+// partialAggregate aggregates the values of a column of the rows of a group.
+type partialAggregate interface {
+	Add(value sqltypes.Value) error
+	Result() sqltypes.Value
+	Reset()
+}
+
+func newPartialAggregator(partials []planbuilder.PartialAggregate, collationEnv *collations.Environment) *partialAggregator {
+	return &partialAggregator{partials: partials, collationEnv: collationEnv}
+}
+
+// init prepares the aggregation of the columns of the fields, and returns the
+// fields of the folded rows.
+func (pa *partialAggregator) init(fields []*querypb.Field) ([]*querypb.Field, error) {
+	pa.isPartial = make([]bool, len(fields))
+	pa.aggrs = make([]partialAggregate, 0, len(pa.partials))
+	pa.fields = make([]*querypb.Field, len(fields))
+	copy(pa.fields, fields)
+
+	for _, partial := range pa.partials {
+		if partial.Col >= len(fields) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "partial aggregate of column %d out of the %d columns of the result", partial.Col, len(fields))
+		}
+		field := fields[partial.Col]
+		coll := collations.ID(field.Charset)
+
+		var aggr partialAggregate
+		switch partial.Opcode {
+		case opcode.AggregateCount, opcode.AggregateCountStar:
+			aggr = evalengine.NewSumOfCounts()
+		case opcode.AggregateSum:
+			aggr = evalengine.NewAggregationSum(field.Type)
+		case opcode.AggregateMin:
+			aggr = &partialMin{evalengine.NewAggregationMinMax(field.Type, pa.collationEnv, coll, nil)}
+		case opcode.AggregateMax:
+			aggr = &partialMax{evalengine.NewAggregationMinMax(field.Type, pa.collationEnv, coll, nil)}
+		case opcode.AggregateAnyValue:
+			aggr = &partialAnyValue{}
+		case opcode.AggregateCountDistinctApprox:
+			aggr = &partialSketch{typ: field.Type, coll: coll, hasher: vthash.New()}
+			sketchField := field.CloneVT()
+			sketchField.Type = sqltypes.VarBinary
+			sketchField.Charset = collations.CollationBinaryID
+			sketchField.Flags = uint32(querypb.MySqlFlag_BINARY_FLAG)
+			pa.fields[partial.Col] = sketchField
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported partial aggregate %s", partial.Opcode)
+		}
+		pa.isPartial[partial.Col] = true
+		pa.aggrs = append(pa.aggrs, aggr)
+	}
+	return pa.fields, nil
+}
+
+// add folds the row into the current group, after returning the folded row
+// of the previous group if the row starts a new group.
+func (pa *partialAggregator) add(row sqltypes.Row) (folded sqltypes.Row, err error) {
+	if pa.current != nil && !pa.sameGroup(row) {
+		folded = pa.finish()
+	}
+	if pa.current == nil {
+		pa.current = row
+	}
+	for i, partial := range pa.partials {
+		if err := pa.aggrs[i].Add(row[partial.Col]); err != nil {
+			return nil, err
+		}
+	}
+	return folded, nil
+}
+
+func (pa *partialAggregator) sameGroup(row sqltypes.Row) bool {
+	for i, value := range row {
+		if pa.isPartial[i] {
+			continue
+		}
+		current := pa.current[i]
+		if current.Type() != value.Type() || current.IsNull() != value.IsNull() || !bytes.Equal(current.Raw(), value.Raw()) {
+			return false
+		}
+	}
+	return true
+}
+
+// finish returns the folded row of the current group, and resets the group.
+func (pa *partialAggregator) finish() sqltypes.Row {
+	if pa.current == nil {
+		return nil
+	}
+	folded := sqltypes.CopyRow(pa.current)
+	for i, partial := range pa.partials {
+		folded[partial.Col] = pa.aggrs[i].Result()
+		pa.aggrs[i].Reset()
+	}
+	pa.current = nil
+	return folded
+}
+
+// fold returns the result with its rows folded. The result is not modified,
+// as it may be shared with the other queries it was consolidated with.
+func (pa *partialAggregator) fold(qr *sqltypes.Result) (*sqltypes.Result, error) {
+	if qr.Fields == nil {
+		if len(qr.Rows) > 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "partial aggregates require the fields of the result")
+		}
+		return qr, nil
+	}
+	fields, err := pa.init(qr.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	out := qr.ShallowCopy()
+	out.Fields = fields
+	out.Rows = nil
+	for _, row := range qr.Rows {
+		folded, err := pa.add(row)
+		if err != nil {
+			return nil, err
+		}
+		if folded != nil {
+			out.Rows = append(out.Rows, folded)
+		}
+	}
+	if folded := pa.finish(); folded != nil {
+		out.Rows = append(out.Rows, folded)
+	}
+	out.RowsAffected = uint64(len(out.Rows))
+	return out, nil
+}
+
+// stream returns a callback folding the rows of the streamed results before
+// sending them to callback. The last group is only sent by flush, once the
+// stream ended.
+func (pa *partialAggregator) stream(callback StreamCallback) StreamCallback {
+	return func(result *sqltypes.Result) error {
+		out := &sqltypes.Result{}
+		if result.Fields != nil {
+			fields, err := pa.init(result.Fields)
+			if err != nil {
+				return err
+			}
+			out.Fields = fields
+		}
+		if len(result.Rows) > 0 && pa.isPartial == nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "partial aggregates require the fields of the result")
+		}
+		for _, row := range result.Rows {
+			// The streamed results are reused once sent, so the rows
+			// which are kept until their group is folded are copied.
+			folded, err := pa.add(cloneRow(row))
+			if err != nil {
+				return err
+			}
+			if folded != nil {
+				out.Rows = append(out.Rows, folded)
+			}
+		}
+		if out.Fields == nil && len(out.Rows) == 0 {
+			return nil
+		}
+		return callback(out)
+	}
+}
+
+// flush sends the folded row of the last group of a stream to callback.
+func (pa *partialAggregator) flush(callback StreamCallback) error {
+	if folded := pa.finish(); folded != nil {
+		return callback(&sqltypes.Result{Rows: []sqltypes.Row{folded}})
+	}
+	return nil
+}
+
+func cloneRow(row sqltypes.Row) sqltypes.Row {
+	clone := make(sqltypes.Row, len(row))
+	for i, value := range row {
+		if value.IsNull() {
+			clone[i] = value
+			continue
+		}
+		clone[i] = sqltypes.MakeTrusted(value.Type(), bytes.Clone(value.Raw()))
+	}
+	return clone
+}
+
+type partialMin struct {
+	evalengine.MinMax
+}
+
+func (p *partialMin) Add(value sqltypes.Value) error {
+	return p.Min(value)
+}
+
+type partialMax struct {
+	evalengine.MinMax
+}
+
+func (p *partialMax) Add(value sqltypes.Value) error {
+	return p.Max(value)
+}
+
+type partialAnyValue struct {
+	value sqltypes.Value
+	init  bool
+}
+
+func (p *partialAnyValue) Add(value sqltypes.Value) error {
+	if !p.init {
+		p.value, p.init = value, true
+	}
+	return nil
+}
+
+func (p *partialAnyValue) Result() sqltypes.Value {
+	return p.value
+}
+
+func (p *partialAnyValue) Reset() {
+	p.value, p.init = sqltypes.NULL, false
+}
+
+// partialSketch computes the HyperLogLog sketch of the distinct non-NULL
+// values of a column. The values are hashed so that the values equal for
+// their collation have the same hash.
+type partialSketch struct {
+	typ    sqltypes.Type
+	coll   collations.ID
+	hasher vthash.Hasher
+	sketch hll.Sketch
+}
+
+func (p *partialSketch) Add(value sqltypes.Value) error {
+	if value.IsNull() {
+		return nil
+	}
+	p.hasher.Reset()
+	if err := evalengine.NullsafeHashcode128(&p.hasher, value, p.coll, p.typ, 0, nil); err != nil {
+		return err
+	}
+	p.sketch.Add(p.hasher.Sum64())
+	return nil
+}
+
+func (p *partialSketch) Result() sqltypes.Value {
+	return sqltypes.MakeTrusted(sqltypes.VarBinary, p.sketch.Marshal())
+}
+
+func (p *partialSketch) Reset() {
+	p.sketch.Reset()
+}
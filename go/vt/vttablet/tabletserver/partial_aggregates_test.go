@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/hll"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+)
+
+func partialAggregatesResult() *sqltypes.Result {
+	fields := sqltypes.MakeTestFields("grp|val|cnt|total|lowest|any", "int64|varchar|int64|decimal|int64|varchar")
+	// The values are compared with a case-insensitive collation.
+	fields[1].Charset = uint32(collations.MySQL8().LookupByName("utf8mb4_0900_ai_ci"))
+	return sqltypes.MakeTestResult(fields,
+		"1|a|1|1.5|3|x",
+		"1|A|2|2.5|1|y",
+		"1|b|3|null|2|z",
+		"1|null|4|1|5|z",
+		"2|c|1|1|1|x",
+		"3|null|1|1|1|x",
+	)
+}
+
+func estimate(t *testing.T, sketch sqltypes.Value) uint64 {
+	require.Equal(t, sqltypes.VarBinary, sketch.Type())
+	s, err := hll.Unmarshal(sketch.Raw())
+	require.NoError(t, err)
+	return s.Estimate()
+}
+
+func TestPartialAggregatorFold(t *testing.T) {
+	partials := []planbuilder.PartialAggregate{
+		{Opcode: opcode.AggregateCountDistinctApprox, Col: 1},
+		{Opcode: opcode.AggregateCountStar, Col: 2},
+		{Opcode: opcode.AggregateSum, Col: 3},
+		{Opcode: opcode.AggregateMin, Col: 4},
+		{Opcode: opcode.AggregateAnyValue, Col: 5},
+	}
+	in := partialAggregatesResult()
+	qr, err := newPartialAggregator(partials, collations.MySQL8()).fold(in)
+	require.NoError(t, err)
+
+	// The result is not modified, as it may be shared by consolidated queries.
+	assert.Equal(t, partialAggregatesResult(), in)
+
+	assert.Equal(t, sqltypes.VarBinary, qr.Fields[1].Type)
+	assert.Equal(t, in.Fields[2:], qr.Fields[2:])
+	require.Len(t, qr.Rows, 3)
+	assert.EqualValues(t, 3, qr.RowsAffected)
+	assert.Equal(t, `[INT64(1) INT64(10) DECIMAL(5.0) INT64(1) VARCHAR("x")]`, fmt.Sprintf("%v", sqltypes.Row{qr.Rows[0][0], qr.Rows[0][2], qr.Rows[0][3], qr.Rows[0][4], qr.Rows[0][5]}))
+	assert.EqualValues(t, 2, estimate(t, qr.Rows[0][1]))
+	assert.Equal(t, "INT64(2)", qr.Rows[1][0].String())
+	assert.EqualValues(t, 1, estimate(t, qr.Rows[1][1]))
+	assert.Equal(t, "INT64(3)", qr.Rows[2][0].String())
+	assert.EqualValues(t, 0, estimate(t, qr.Rows[2][1]))
+
+	_, err = newPartialAggregator([]planbuilder.PartialAggregate{{Opcode: opcode.AggregateSum, Col: 6}}, collations.MySQL8()).fold(in)
+	assert.EqualError(t, err, "partial aggregate of column 6 out of the 6 columns of the result")
+}
+
+func TestPartialAggregatorStream(t *testing.T) {
+	partials := []planbuilder.PartialAggregate{
+		{Opcode: opcode.AggregateCountDistinctApprox, Col: 1},
+		{Opcode: opcode.AggregateCount, Col: 2},
+		{Opcode: opcode.AggregateSum, Col: 3},
+		{Opcode: opcode.AggregateMax, Col: 4},
+		{Opcode: opcode.AggregateAnyValue, Col: 5},
+	}
+	in := partialAggregatesResult()
+
+	var results []*sqltypes.Result
+	send := func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	}
+	aggregator := newPartialAggregator(partials, collations.MySQL8())
+	callback := aggregator.stream(send)
+	require.NoError(t, callback(&sqltypes.Result{Fields: in.Fields}))
+	// The groups span several streamed results.
+	require.NoError(t, callback(&sqltypes.Result{Rows: in.Rows[:2]}))
+	require.NoError(t, callback(&sqltypes.Result{Rows: in.Rows[2:5]}))
+	require.NoError(t, callback(&sqltypes.Result{Rows: in.Rows[5:]}))
+	require.NoError(t, aggregator.flush(send))
+
+	require.Len(t, results, 4)
+	assert.Equal(t, sqltypes.VarBinary, results[0].Fields[1].Type)
+	assert.Empty(t, results[0].Rows)
+	var rows []sqltypes.Row
+	for _, result := range results[1:] {
+		assert.Nil(t, result.Fields)
+		rows = append(rows, result.Rows...)
+	}
+	require.Len(t, rows, 3)
+	assert.Equal(t, `[INT64(1) INT64(10) DECIMAL(5.0) INT64(5) VARCHAR("x")]`, fmt.Sprintf("%v", sqltypes.Row{rows[0][0], rows[0][2], rows[0][3], rows[0][4], rows[0][5]}))
+	assert.EqualValues(t, 2, estimate(t, rows[0][1]))
+	assert.Equal(t, `[INT64(2) INT64(1)]`, fmt.Sprintf("%v", sqltypes.Row{rows[1][0], rows[1][2]}))
+	assert.Equal(t, `[INT64(3) INT64(1)]`, fmt.Sprintf("%v", sqltypes.Row{rows[2][0], rows[2][2]}))
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package planbuilder
 
 import (
+	"strconv"
 	"strings"
 
+	vtschema "vitess.io/vitess/go/vt/schema"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 
@@ -47,6 +50,9 @@ func analyzeSelect(env *vtenv.Environment, sel *sqlparser.Select, tables map[str
 	}
 
 	plan.Table = lookupTables(sel.From, tables)
+	if plan.PartialAggregates, err = analyzePartialAggregates(sel); err != nil {
+		return nil, err
+	}
 
 	if sel.Where != nil {
 		comp, ok := sel.Where.Expr.(*sqlparser.ComparisonExpr)
@@ -80,6 +86,37 @@ func analyzeSelect(env *vtenv.Environment, sel *sqlparser.Select, tables map[str
 	return plan, nil
 }
 
+// analyzePartialAggregates returns the partial aggregates of the PARTIAL_AGGREGATES
+// directive of a SELECT, a comma-separated list of opcode:column pairs.
+func analyzePartialAggregates(sel *sqlparser.Select) ([]PartialAggregate, error) {
+	directive, _ := sel.Comments.Directives().GetString(sqlparser.DirectivePartialAggregates, "")
+	if directive == "" {
+		return nil, nil
+	}
+	var partials []PartialAggregate
+	for _, pair := range strings.Split(directive, ",") {
+		name, col, _ := strings.Cut(pair, ":")
+		code := opcode.SupportedAggregates[name]
+		offset, err := strconv.Atoi(col)
+		if !isPartialAggregate(code) || err != nil || offset < 0 || offset >= len(sel.GetColumns()) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid partial aggregate '%s' in the %s directive", pair, sqlparser.DirectivePartialAggregates)
+		}
+		partials = append(partials, PartialAggregate{Opcode: code, Col: offset})
+	}
+	return partials, nil
+}
+
+// isPartialAggregate returns true if vttablet can aggregate the partial
+// aggregates of the opcode further.
+func isPartialAggregate(code opcode.AggregateOpcode) bool {
+	switch code {
+	case opcode.AggregateCount, opcode.AggregateCountStar, opcode.AggregateSum, opcode.AggregateMin,
+		opcode.AggregateMax, opcode.AggregateAnyValue, opcode.AggregateCountDistinctApprox:
+		return true
+	}
+	return false
+}
+
 // analyzeUpdate code is almost identical to analyzeDelete.
 func analyzeUpdate(upd *sqlparser.Update, tables map[string]*schema.Table) (plan *Plan, err error) {
 	plan = &Plan{
@@ -243,6 +280,16 @@ func analyzeDDL(stmt sqlparser.DDLStatement) (*Plan, error) {
 	return &Plan{PlanID: PlanDDL, FullQuery: fullQuery, FullStmt: stmt, NeedsReservedConn: stmt.IsTemporary()}, nil
 }
 
+func analyzeAnalyze(stmt *sqlparser.Analyze) *Plan {
+	if _, err := vtschema.OnlineDDLFromCommentedStatement(stmt); err == nil {
+		// This is an Online DDL migration, which is submitted like any other DDL.
+		return &Plan{PlanID: PlanDDL, FullQuery: GenerateFullQuery(stmt), FullStmt: stmt}
+	}
+	// Analyze is otherwise treated as a read-only query.
+	// We send down a string, and get a table result back.
+	return &Plan{PlanID: PlanSelect, FullQuery: GenerateFullQuery(stmt)}
+}
+
 func analyzeFlush(stmt *sqlparser.Flush, tables map[string]*schema.Table) (*Plan, error) {
 	plan := &Plan{PlanID: PlanFlush, FullQuery: GenerateFullQuery(stmt)}
 
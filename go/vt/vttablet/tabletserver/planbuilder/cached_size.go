@@ -41,7 +41,7 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(128)
+		size += int64(144)
 	}
 	// field Table *vitess.io/vitess/go/vt/vttablet/tabletserver/schema.Table
 	size += cached.Table.CachedSize(true)
@@ -71,5 +71,9 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	if cc, ok := cached.FullStmt.(cachedObject); ok {
 		size += cc.CachedSize(true)
 	}
+	// field PartialAggregates []vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder.PartialAggregate
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.PartialAggregates)) * int64(16))
+	}
 	return size
 }
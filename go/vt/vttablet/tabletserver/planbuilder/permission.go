@@ -70,6 +70,14 @@ func BuildPermissions(stmt sqlparser.Statement) []Permission {
 		}
 	case *sqlparser.Analyze:
 		permissions = buildTableNamePermissions(node.Table, tableacl.WRITER, nil, permissions)
+	case *sqlparser.OptimizeTable:
+		for _, t := range node.Tables {
+			permissions = buildTableNamePermissions(t, tableacl.WRITER, nil, permissions)
+		}
+	case *sqlparser.ChecksumTable:
+		for _, t := range node.Tables {
+			permissions = buildTableNamePermissions(t, tableacl.READER, nil, permissions)
+		}
 	case *sqlparser.OtherAdmin, *sqlparser.CallProc, *sqlparser.Begin, *sqlparser.Commit, *sqlparser.Rollback,
 		*sqlparser.Load, *sqlparser.Savepoint, *sqlparser.Release, *sqlparser.SRollback, *sqlparser.Set, *sqlparser.Show, sqlparser.Explain,
 		*sqlparser.UnlockTables:
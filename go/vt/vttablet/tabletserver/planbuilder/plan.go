@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/vt/tableacl"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 
@@ -178,6 +179,18 @@ type Plan struct {
 
 	// NeedsReservedConn indicates at a reserved connection is needed to execute this plan
 	NeedsReservedConn bool
+
+	// PartialAggregates are the columns of the rows of a SELECT which are
+	// aggregated further by folding the consecutive rows equal in all their
+	// other columns, as asked by vtgate.
+	PartialAggregates []PartialAggregate
+}
+
+// PartialAggregate is a column of the rows of a SELECT holding a partial
+// aggregate, and the opcode aggregating it further.
+type PartialAggregate struct {
+	Opcode opcode.AggregateOpcode
+	Col    int
 }
 
 // TableName returns the table name for the plan.
@@ -230,14 +243,16 @@ func Build(env *vtenv.Environment, statement sqlparser.Statement, tables map[str
 		plan = &Plan{PlanID: PlanShowThrottlerStatus, FullStmt: stmt}
 	case *sqlparser.Show:
 		plan, err = analyzeShow(stmt, dbName)
-	case *sqlparser.Analyze, sqlparser.Explain:
-		// Analyze and Explain are treated as read-only queries.
+	case *sqlparser.Analyze:
+		plan = analyzeAnalyze(stmt)
+	case *sqlparser.ChecksumTable, sqlparser.Explain:
+		// Checksum Table and Explain are treated as read-only queries.
 		// We send down a string, and get a table result back.
 		plan = &Plan{
 			PlanID:    PlanSelect,
 			FullQuery: GenerateFullQuery(stmt),
 		}
-	case *sqlparser.OtherAdmin:
+	case *sqlparser.OtherAdmin, *sqlparser.OptimizeTable:
 		plan = &Plan{PlanID: PlanOtherAdmin}
 	case *sqlparser.Savepoint:
 		plan = &Plan{PlanID: PlanSavepoint, FullStmt: stmt}
@@ -278,8 +293,16 @@ func BuildStreaming(statement sqlparser.Statement, tables map[string]*schema.Tab
 			plan.NeedsReservedConn = true
 		}
 		plan.Table = lookupTables(stmt.From, tables)
-	case *sqlparser.Show, *sqlparser.Union, *sqlparser.CallProc, sqlparser.Explain:
-	case *sqlparser.Analyze:
+		partials, err := analyzePartialAggregates(stmt)
+		if err != nil {
+			return nil, err
+		}
+		plan.PartialAggregates = partials
+	case *sqlparser.CallProc:
+		// The result sets of a CALL are streamed one after the other.
+		plan.PlanID = PlanCallProc
+	case *sqlparser.Show, *sqlparser.Union, sqlparser.Explain:
+	case *sqlparser.Analyze, *sqlparser.ChecksumTable:
 		plan.PlanID = PlanOtherRead
 	default:
 		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%s not allowed for streaming", sqlparser.ASTToStatementType(statement))
@@ -197,6 +197,9 @@ type QueryEngine struct {
 	accessCheckerLogger *logutil.ThrottledLogger
 
 	redactUIQuery bool
+
+	// tableLabels bounds the number of tables labeling the per-table metrics.
+	tableLabels *stats.LabelLimiter
 }
 
 // NewQueryEngine creates a new QueryEngine.
@@ -211,6 +214,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 		queryRuleSources:              rules.NewMap(),
 		enablePerWorkloadTableMetrics: config.EnablePerWorkloadTableMetrics,
 		redactUIQuery:                 streamlog.NewQueryLogConfigForTest().RedactDebugUIQueries,
+		tableLabels:                   stats.NewLabelLimiter(config.TableMetricsAllowlist, config.TableMetricsMaxTables),
 	}
 
 	// Cache for query plans: user configured size with a doorkeeper by default to prevent one-off queries
@@ -547,6 +551,16 @@ func (qe *QueryEngine) IsMySQLReachable() error {
 	return nil
 }
 
+// IsSaturated returns true if the fraction of busy connections in the query
+// pool has reached the admission control saturation threshold.
+func (qe *QueryEngine) IsSaturated() bool {
+	capacity := qe.conns.Capacity()
+	if capacity <= 0 {
+		return false
+	}
+	return float64(qe.conns.InUse()) >= qe.env.Config().AdmissionControl.SaturationThreshold*float64(capacity)
+}
+
 func (qe *QueryEngine) schemaChanged(tables map[string]*schema.Table, created, altered, dropped []*schema.Table, _ bool) {
 	qe.schemaMu.Lock()
 	defer qe.schemaMu.Unlock()
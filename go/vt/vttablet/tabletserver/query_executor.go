@@ -18,9 +18,11 @@ package tabletserver
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -74,6 +76,8 @@ const (
 	resetLastIDQuery  = "select last_insert_id(18446744073709547416)"
 	resetLastIDValue  = 18446744073709547416
 	userLabelDisabled = "UserLabelDisabled"
+	// shadowReadTimeout limits how long a read mirrored onto an Online DDL shadow table may run
+	shadowReadTimeout = 5 * time.Second
 )
 
 var (
@@ -129,10 +133,11 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		duration := time.Since(start)
 		qre.tsv.stats.QueryTimings.Add(planName, duration)
 		qre.tsv.stats.QueryTimingsByTabletType.Add(qre.targetTabletType.String(), duration)
+		qre.tsv.stats.QueryTimingsByWorkload.Add(qre.options.GetWorkload().String(), duration)
 		qre.recordUserQuery("Execute", int64(duration))
 
 		mysqlTime := qre.logStats.MysqlResponseTime
-		tableName := qre.plan.TableName().String()
+		tableName := qre.tsv.qe.tableLabels.Value(qre.plan.TableName().String())
 		if tableName == "" {
 			tableName = "Join"
 		}
@@ -198,6 +203,12 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
+		if qr, err = qre.foldPartialAggregates(qr); err != nil {
+			return nil, err
+		}
+		if qre.plan.PlanID == p.PlanSelect {
+			qre.mirrorShadowRead(qr)
+		}
 		return qr, nil
 	case p.PlanOtherRead, p.PlanOtherAdmin, p.PlanFlush, p.PlanSavepoint, p.PlanRelease, p.PlanSRollback:
 		return qre.execOther()
@@ -280,9 +291,13 @@ func (qre *QueryExecutor) execAsTransaction(f func(conn *StatefulConnection) (*s
 	}
 
 	defer qre.logStats.AddRewrittenSQL("commit", time.Now())
-	if _, err := qre.tsv.te.txPool.Commit(qre.ctx, conn); err != nil {
+	_, sessionStateChanges, err := qre.tsv.te.txPool.Commit(qre.ctx, conn)
+	if err != nil {
 		return nil, err
 	}
+	if sessionStateChanges != "" {
+		result.SessionStateChanges = sessionStateChanges
+	}
 	return result, nil
 }
 
@@ -309,7 +324,11 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
 			qre.bindVars[sqltypes.BvSchemaName] = sqltypes.StringBindVariable(qre.tsv.config.DB.DBName)
 		}
-		return qre.txFetch(conn, false)
+		qr, err := qre.txFetch(conn, false)
+		if err != nil {
+			return nil, err
+		}
+		return qre.foldPartialAggregates(qr)
 	case p.PlanSelect, p.PlanSelectImpossible, p.PlanShow, p.PlanSelectLockFunc:
 		maxrows := qre.getSelectLimit()
 		qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
@@ -323,7 +342,7 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		if err := qre.verifyRowCount(int64(len(qr.Rows)), maxrows); err != nil {
 			return nil, err
 		}
-		return qr, nil
+		return qre.foldPartialAggregates(qr)
 	case p.PlanDDL:
 		return qre.execDDL(conn)
 	case p.PlanLoad:
@@ -337,12 +356,13 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 }
 
 // Stream performs a streaming query execution.
-func (qre *QueryExecutor) Stream(callback StreamCallback) error {
+func (qre *QueryExecutor) Stream(callback StreamCallback) (err error) {
 	qre.logStats.PlanType = qre.plan.PlanID.String()
 
 	defer func(start time.Time) {
 		qre.tsv.stats.QueryTimings.Record(qre.plan.PlanID.String(), start)
 		qre.tsv.stats.QueryTimingsByTabletType.Record(qre.targetTabletType.String(), start)
+		qre.tsv.stats.QueryTimingsByWorkload.Record(qre.options.GetWorkload().String(), start)
 		qre.recordUserQuery("Stream", int64(time.Since(start)))
 	}(time.Now())
 
@@ -367,6 +387,25 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		replaceKeyspace = qre.tsv.sm.target.Keyspace
 	}
 
+	if maxRows := qre.olapMaxRows(); maxRows > 0 {
+		callback = qre.limitRows(callback, maxRows)
+	}
+
+	if partials := qre.plan.PartialAggregates; len(partials) > 0 {
+		aggregator := newPartialAggregator(partials, qre.tsv.env.CollationEnv())
+		send := callback
+		callback = aggregator.stream(send)
+		defer func() {
+			if err == nil {
+				err = aggregator.flush(send)
+			}
+		}()
+	}
+
+	if qre.plan.PlanID == p.PlanCallProc {
+		return qre.streamCallProc(replaceKeyspace, callback)
+	}
+
 	if consolidator := qre.tsv.qe.streamConsolidator; consolidator != nil {
 		if qre.connID == 0 && qre.plan.PlanID == p.PlanSelectStream && qre.shouldConsolidate() {
 			return consolidator.Consolidate(qre.tsv.stats.WaitTimings, qre.logStats, sqlWithoutComments, callback,
@@ -515,6 +554,8 @@ func (qre *QueryExecutor) checkPermissions() error {
 		}
 		return nil
 	}
+	// The roles of the caller grant it the ACL groups they are mapped to.
+	callerID = tableacl.ResolveRoles(callerID)
 
 	// Skip the ACL check if the caller id is an exempted superuser.
 	if qre.tsv.qe.exemptACL != nil && qre.tsv.qe.exemptACL.IsMember(callerID) {
@@ -775,6 +816,38 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 	return res, nil
 }
 
+// mirrorShadowRead mirrors a sample of the reads on a table which is being migrated by Online DDL with
+// --shadow-reads-percent onto the shadow table of the migration, and compares the results out of band.
+func (qre *QueryExecutor) mirrorShadowRead(result *sqltypes.Result) {
+	if qre.plan.Table == nil {
+		return
+	}
+	validation := qre.tsv.onlineDDLExecutor.ShadowReadValidation(qre.plan.Table.Name.String())
+	if validation == nil || !validation.Sample() {
+		return
+	}
+	sel, ok := qre.plan.FullStmt.(*sqlparser.Select)
+	if !ok {
+		return
+	}
+	sql, err := p.GenerateLimitQuery(validation.ShadowStatement(sel)).GenerateQuery(qre.bindVars, nil)
+	if err != nil {
+		return
+	}
+	maxrows := int(qre.tsv.qe.maxResultSize.Load())
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shadowReadTimeout)
+		defer cancel()
+		conn, err := qre.tsv.qe.conns.Get(ctx, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Recycle()
+		target, err := conn.Conn.Exec(ctx, sql, maxrows+1, false)
+		validation.Record(result, target, err)
+	}()
+}
+
 func (qre *QueryExecutor) execDMLLimit(conn *StatefulConnection) (*sqltypes.Result, error) {
 	maxrows := qre.tsv.qe.maxResultSize.Load()
 	qre.bindVars["#maxLimit"] = sqltypes.Int64BindVariable(maxrows + 1)
@@ -820,9 +893,55 @@ func (qre *QueryExecutor) getConn() (*connpool.PooledConn, error) {
 	defer func(start time.Time) {
 		qre.logStats.WaitingForConnection += time.Since(start)
 	}(time.Now())
+	// The queries of OLAP sessions use the stream pool, so that they cannot
+	// starve the OLTP queries of connections.
+	if qre.options.GetWorkload() == querypb.ExecuteOptions_OLAP {
+		return qre.tsv.qe.streamConns.Get(ctx, qre.setting)
+	}
 	return qre.tsv.qe.conns.Get(ctx, qre.setting)
 }
 
+// olapMaxRows returns the maximum number of rows streamed by the query, or
+// 0 if it is not limited.
+func (qre *QueryExecutor) olapMaxRows() int {
+	if qre.options.GetWorkload() != querypb.ExecuteOptions_OLAP {
+		return 0
+	}
+	return qre.tsv.config.Olap.MaxRows
+}
+
+// foldPartialAggregates folds the rows of the result of a SELECT on their
+// partial aggregates, when vtgate asked for it.
+func (qre *QueryExecutor) foldPartialAggregates(qr *sqltypes.Result) (*sqltypes.Result, error) {
+	if len(qre.plan.PartialAggregates) == 0 {
+		return qr, nil
+	}
+	return newPartialAggregator(qre.plan.PartialAggregates, qre.tsv.env.CollationEnv()).fold(qr)
+}
+
+// limitRows returns a callback which fails the stream once it sent more than
+// maxRows rows.
+func (qre *QueryExecutor) limitRows(callback StreamCallback, maxRows int) StreamCallback {
+	var rows int
+	return func(result *sqltypes.Result) error {
+		rows += len(result.Rows)
+		if rows > maxRows {
+			callerID := callerid.ImmediateCallerIDFromContext(qre.ctx)
+			return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "caller id: %s: OLAP row count exceeded %d", callerID.GetUsername(), maxRows)
+		}
+		return callback(result)
+	}
+}
+
+// streamBufferSize returns the number of bytes sent for each stream call of
+// the query.
+func (qre *QueryExecutor) streamBufferSize() int {
+	if size := qre.tsv.config.Olap.StreamBufferSize; size > 0 && qre.options.GetWorkload() == querypb.ExecuteOptions_OLAP {
+		return size
+	}
+	return int(qre.tsv.qe.streamBufferSize.Load())
+}
+
 func (qre *QueryExecutor) getStreamConn() (*connpool.PooledConn, error) {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.getStreamConn")
 	defer span.Finish()
@@ -911,18 +1030,46 @@ func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, b
 		qre.marginComments.Leading = buf.String()
 	}
 
-	if qre.marginComments.Leading == "" && qre.marginComments.Trailing == "" {
+	// The query attributes lead the query, so that they are not truncated
+	// in the process list of MySQL.
+	attributes := queryAttributesComment(qre.options.GetQueryAttributes())
+	if attributes == "" && qre.marginComments.Leading == "" && qre.marginComments.Trailing == "" {
 		return query, query, nil
 	}
 
 	var buf strings.Builder
-	buf.Grow(len(qre.marginComments.Leading) + len(query) + len(qre.marginComments.Trailing))
+	buf.Grow(len(attributes) + len(qre.marginComments.Leading) + len(query) + len(qre.marginComments.Trailing))
+	buf.WriteString(attributes)
 	buf.WriteString(qre.marginComments.Leading)
 	buf.WriteString(query)
 	buf.WriteString(qre.marginComments.Trailing)
 	return buf.String(), query, nil
 }
 
+// queryAttributesComment returns the comment with the query attributes that
+// is added to the queries sent to MySQL, or "" if there are none. It follows
+// the sqlcommenter format: the keys and values are URL-encoded, so that they
+// cannot end the comment, and sorted by key.
+func queryAttributesComment(attributes map[string]string) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString("/*")
+	for i, key := range slices.Sorted(maps.Keys(attributes)) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(url.PathEscape(key))
+		buf.WriteString("='")
+		buf.WriteString(url.PathEscape(attributes[key]))
+		buf.WriteByte('\'')
+	}
+	buf.WriteString("*/ ")
+	return buf.String()
+}
+
 func rewriteOUTParamError(err error) error {
 	sqlErr, ok := err.(*sqlerror.SQLError)
 	if !ok {
@@ -945,25 +1092,15 @@ func (qre *QueryExecutor) execCallProc() (*sqltypes.Result, error) {
 		return nil, err
 	}
 
-	qr, err := qre.execDBConn(conn.Conn, sql, true)
-	if errors.Is(err, mysql.ErrExecuteFetchMultipleResults) {
-		return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
-	}
+	qr, last, err := qre.execCallOnConn(conn.Conn, conn.Conn, qre.tsv.statelessql, sql)
 	if err != nil {
 		return nil, rewriteOUTParamError(err)
 	}
-	if !qr.IsMoreResultsExists() {
-		if qr.IsInTransaction() {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
-		}
-		return qr, nil
+	if last.IsInTransaction() {
+		conn.Close()
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction not concluded inside the stored procedure, leaking transaction from stored procedure is not allowed")
 	}
-	err = qre.drainResultSetOnConn(conn.Conn)
-	if err != nil {
-		return nil, err
-	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	return qr, nil
 }
 
 func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result, error) {
@@ -972,23 +1109,82 @@ func (qre *QueryExecutor) execProc(conn *StatefulConnection) (*sqltypes.Result,
 	if err != nil {
 		return nil, err
 	}
-	qr, err := qre.execStatefulConn(conn, sql, true)
+	qr, last, err := qre.execCallOnConn(conn, conn.UnderlyingDBConn().Conn, qre.tsv.statefulql, sql)
 	if err != nil {
 		return nil, rewriteOUTParamError(err)
 	}
-	if !qr.IsMoreResultsExists() {
-		afterInTx := qr.IsInTransaction()
-		if beforeInTx != afterInTx {
-			conn.Close()
-			return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
+	afterInTx := last.IsInTransaction()
+	if beforeInTx != afterInTx {
+		conn.Close()
+		return nil, vterrors.New(vtrpcpb.Code_CANCELED, "Transaction state change inside the stored procedure is not allowed")
+	}
+	return qr, nil
+}
+
+// streamCallProc executes the CALL and streams the result sets it returns one
+// after the other, each but the last ending with SERVER_MORE_RESULTS_EXISTS.
+func (qre *QueryExecutor) streamCallProc(replaceKeyspace string, callback StreamCallback) error {
+	var qr *sqltypes.Result
+	if qre.connID != 0 {
+		txConn, err := qre.tsv.te.txPool.GetAndLock(qre.connID, "for streaming call")
+		if err != nil {
+			return err
+		}
+		defer txConn.Unlock()
+		if qre.setting != nil {
+			if _, err = txConn.ApplySetting(qre.ctx, qre.setting); err != nil {
+				return vterrors.Wrap(err, "failed to execute system setting on the connection")
+			}
+		}
+		if qr, err = qre.execProc(txConn); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if qr, err = qre.execCallProc(); err != nil {
+			return err
+		}
+	}
+
+	for _, result := range qr.ResultSets() {
+		if replaceKeyspace != "" {
+			result.ReplaceKeyspace(replaceKeyspace)
+		}
+		if err := callback(result); err != nil {
+			return err
 		}
-		return qr, nil
 	}
-	err = qre.drainResultSetOnConn(conn.UnderlyingDBConn().Conn)
+	return nil
+}
+
+// execCallOnConn executes the CALL on the connection and reads all the result
+// sets it returns. The first one is returned with the following ones in its
+// MoreResults, along with the last one, which holds the status of the CALL.
+func (qre *QueryExecutor) execCallOnConn(k killable, conn *connpool.Conn, ql *QueryList, sql string) (qr *sqltypes.Result, last *sqltypes.Result, err error) {
+	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.execCallOnConn")
+	defer span.Finish()
+	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
+
+	qd := NewQueryDetail(qre.logStats.Ctx, k)
+	if err := ql.Add(qd); err != nil {
+		return nil, nil, err
+	}
+	defer ql.Remove(qd)
+
+	maxrows := int(qre.getSelectLimit())
+	qr, err = conn.ExecMulti(ctx, sql, maxrows, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "Multi-Resultset not supported in stored procedure")
+	last = qr
+	for last.IsMoreResultsExists() {
+		last, err = conn.FetchNext(ctx, maxrows, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		qr.MoreResults = append(qr.MoreResults, last)
+	}
+	return qr, last, nil
 }
 
 func (qre *QueryExecutor) execAlterMigration() (*sqltypes.Result, error) {
@@ -1003,37 +1199,41 @@ func (qre *QueryExecutor) execAlterMigration() (*sqltypes.Result, error) {
 	case sqlparser.CleanupMigrationType:
 		return qre.tsv.onlineDDLExecutor.CleanupMigration(qre.ctx, alterMigration.UUID)
 	case sqlparser.CleanupAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.CleanupAllMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.CleanupAllMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.LaunchMigrationType:
 		return qre.tsv.onlineDDLExecutor.LaunchMigration(qre.ctx, alterMigration.UUID, alterMigration.Shards)
 	case sqlparser.LaunchAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.LaunchMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.LaunchMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.CompleteMigrationType:
 		return qre.tsv.onlineDDLExecutor.CompleteMigration(qre.ctx, alterMigration.UUID)
 	case sqlparser.CompleteAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.CompletePendingMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.CompletePendingMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.PostponeCompleteMigrationType:
 		return qre.tsv.onlineDDLExecutor.PostponeCompleteMigration(qre.ctx, alterMigration.UUID)
 	case sqlparser.PostponeCompleteAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.PostponeCompletePendingMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.PostponeCompletePendingMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.CancelMigrationType:
 		return qre.tsv.onlineDDLExecutor.CancelMigration(qre.ctx, alterMigration.UUID, "CANCEL issued by user", true)
 	case sqlparser.CancelAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.CancelPendingMigrations(qre.ctx, "CANCEL ALL issued by user", true)
+		return qre.tsv.onlineDDLExecutor.CancelPendingMigrations(qre.ctx, alterMigration.MigrationContext, "CANCEL ALL issued by user", true)
 	case sqlparser.ThrottleMigrationType:
 		return qre.tsv.onlineDDLExecutor.ThrottleMigration(qre.ctx, alterMigration.UUID, alterMigration.Expire, alterMigration.Ratio)
 	case sqlparser.ThrottleAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.ThrottleAllMigrations(qre.ctx, alterMigration.Expire, alterMigration.Ratio)
+		return qre.tsv.onlineDDLExecutor.ThrottleAllMigrations(qre.ctx, alterMigration.MigrationContext, alterMigration.Expire, alterMigration.Ratio)
 	case sqlparser.UnthrottleMigrationType:
 		return qre.tsv.onlineDDLExecutor.UnthrottleMigration(qre.ctx, alterMigration.UUID)
 	case sqlparser.UnthrottleAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.UnthrottleAllMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.UnthrottleAllMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.ForceCutOverMigrationType:
 		return qre.tsv.onlineDDLExecutor.ForceCutOverMigration(qre.ctx, alterMigration.UUID)
 	case sqlparser.ForceCutOverAllMigrationType:
-		return qre.tsv.onlineDDLExecutor.ForceCutOverPendingMigrations(qre.ctx)
+		return qre.tsv.onlineDDLExecutor.ForceCutOverPendingMigrations(qre.ctx, alterMigration.MigrationContext)
 	case sqlparser.SetCutOverThresholdMigrationType:
 		return qre.tsv.onlineDDLExecutor.SetMigrationCutOverThreshold(qre.ctx, alterMigration.UUID, alterMigration.Threshold)
+	case sqlparser.SetScheduleWindowMigrationType:
+		return qre.tsv.onlineDDLExecutor.SetMigrationScheduleWindow(qre.ctx, alterMigration.UUID, alterMigration.ScheduleWindow)
+	case sqlparser.SetDependencyMigrationType:
+		return qre.tsv.onlineDDLExecutor.SetMigrationDependency(qre.ctx, alterMigration.UUID, alterMigration.DependsOn)
 	}
 	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "ALTER VITESS_MIGRATION not implemented")
 }
@@ -1133,18 +1333,6 @@ func (qre *QueryExecutor) execShowThrottlerStatus() (*sqltypes.Result, error) {
 	return result, nil
 }
 
-func (qre *QueryExecutor) drainResultSetOnConn(conn *connpool.Conn) error {
-	more := true
-	for more {
-		qr, err := conn.FetchNext(qre.ctx, int(qre.getSelectLimit()), true)
-		if err != nil {
-			return err
-		}
-		more = qr.IsMoreResultsExists()
-	}
-	return nil
-}
-
 func (qre *QueryExecutor) getSelectLimit() int64 {
 	return qre.tsv.qe.maxResultSize.Load()
 }
@@ -1280,14 +1468,14 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 			return err
 		}
 		defer qre.tsv.statefulql.Remove(qd)
-		err = conn.Conn.StreamOnce(ctx, sql, cb, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+		err = conn.Conn.StreamOnce(ctx, sql, cb, allocStreamResult, qre.streamBufferSize(), sqltypes.IncludeFieldsOrDefault(qre.options))
 	} else {
 		err = qre.tsv.olapql.Add(qd)
 		if err != nil {
 			return err
 		}
 		defer qre.tsv.olapql.Remove(qd)
-		err = conn.Conn.Stream(ctx, sql, cb, allocStreamResult, int(qre.tsv.qe.streamBufferSize.Load()), sqltypes.IncludeFieldsOrDefault(qre.options))
+		err = conn.Conn.Stream(ctx, sql, cb, allocStreamResult, qre.streamBufferSize(), sqltypes.IncludeFieldsOrDefault(qre.options))
 	}
 
 	if err != nil || lastInsertIDSet || !qre.options.GetFetchLastInsertId() {
@@ -1313,7 +1501,7 @@ func (qre *QueryExecutor) recordUserQuery(queryType string, duration int64) {
 			username = callerid.GetUsername(callerid.ImmediateCallerIDFromContext(qre.ctx))
 		}
 	}
-	tableName := qre.plan.TableName().String()
+	tableName := qre.tsv.qe.tableLabels.Value(qre.plan.TableName().String())
 	qre.tsv.Stats().UserTableQueryCount.Add([]string{tableName, username, queryType}, 1)
 	qre.tsv.Stats().UserTableQueryTimesNs.Add([]string{tableName, username, queryType}, duration)
 }
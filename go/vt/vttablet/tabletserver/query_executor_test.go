@@ -469,6 +469,28 @@ func TestQueryExecutorQueryAnnotation(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorQueryAttributes(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	fields := sqltypes.MakeTestFields("a|b", "int64|varchar")
+	selectResult := sqltypes.MakeTestResult(fields, "1|aaa")
+	db.AddQuery("select * from t limit 10001", selectResult)
+	db.AddQuery("/*service='orders',trace_id='a%2A%2F%20b%27'*/ select * from t limit 10001", selectResult)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, "select * from t", 0)
+	qre.options = &querypb.ExecuteOptions{
+		QueryAttributes: map[string]string{"trace_id": "a*/ b'", "service": "orders"},
+	}
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, selectResult, got)
+	assert.Equal(t, "/*service='orders',trace_id='a%2A%2F%20b%27'*/ select * from t limit 10001", qre.logStats.RewrittenSQL())
+}
+
 // TestQueryExecutorSelectImpossible is separate because it's a special case
 // because the "in transaction" case is a no-op.
 func TestQueryExecutorSelectImpossible(t *testing.T) {
@@ -698,6 +720,93 @@ func TestQueryExecutorPlanPassSelectWithLockOutsideATransaction(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestQueryExecutorCallProc(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "call proc()"
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{{sqltypes.NewInt32(1), sqltypes.NewInt32(2), sqltypes.NewInt32(3)}},
+		MoreResults: []*sqltypes.Result{{
+			Fields: getTestTableFields(),
+			Rows:   [][]sqltypes.Value{{sqltypes.NewInt32(4), sqltypes.NewInt32(5), sqltypes.NewInt32(6)}},
+		}, {
+			RowsAffected: 1,
+		}},
+	}
+	db.AddQuery(query, want)
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	// All the result sets of the procedure are returned.
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	assert.Equal(t, planbuilder.PlanCallProc, qre.plan.PlanID)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got), "got: %v, want: %v", got, want)
+
+	// The connection is left clean for the following queries.
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err = qre.Execute()
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got), "got: %v, want: %v", got, want)
+
+	// When streamed, the result sets are sent one after the other, all but
+	// the last ending with SERVER_MORE_RESULTS_EXISTS.
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	assert.Equal(t, planbuilder.PlanCallProc, qre.plan.PlanID)
+	var streamed []*sqltypes.Result
+	err = qre.Stream(func(qr *sqltypes.Result) error {
+		streamed = append(streamed, qr)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 3)
+	assert.True(t, streamed[0].IsMoreResultsExists())
+	assert.Equal(t, want.Rows, streamed[0].Rows)
+	assert.True(t, streamed[1].IsMoreResultsExists())
+	assert.Equal(t, want.MoreResults[0].Rows, streamed[1].Rows)
+	assert.False(t, streamed[2].IsMoreResultsExists())
+	assert.EqualValues(t, 1, streamed[2].RowsAffected)
+}
+
+func TestQueryExecutorPartialAggregates(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select /*vt+ PARTIAL_AGGREGATES=count_distinct_approx:1,count_star:2 */ a, b, count(*) from t group by a, b order by a asc"
+	fields := sqltypes.MakeTestFields("a|b|count(*)", "int64|int64|int64")
+	result := sqltypes.MakeTestResult(fields, "1|10|1", "1|11|2", "1|12|3", "2|20|4")
+	db.AddQuery(query+" limit 10001", result)
+	db.AddQuery(query, result)
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	// The rows of each group are folded, their values into a sketch.
+	check := func(rows []sqltypes.Row) {
+		require.Len(t, rows, 2)
+		assert.Equal(t, `[[INT64(1) INT64(6)] [INT64(2) INT64(4)]]`, fmt.Sprintf("%v", []sqltypes.Row{{rows[0][0], rows[0][2]}, {rows[1][0], rows[1][2]}}))
+		assert.EqualValues(t, 3, estimate(t, rows[0][1]))
+		assert.EqualValues(t, 1, estimate(t, rows[1][1]))
+	}
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.VarBinary, got.Fields[1].Type)
+	check(got.Rows)
+
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	var rows []sqltypes.Row
+	err = qre.Stream(func(qr *sqltypes.Result) error {
+		rows = append(rows, qr.Rows...)
+		return nil
+	})
+	require.NoError(t, err)
+	check(rows)
+}
+
 func TestQueryExecutorPlanNextval(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -929,6 +1038,57 @@ func TestQueryExecutorTableAcl(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorTableAclRoleMapping(t *testing.T) {
+	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
+	tableacl.Register(aclName, &simpleacl.Factory{})
+	tableacl.SetDefaultACL(aclName)
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table limit 1000"
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery(query, want)
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	config := &tableaclpb.Config{
+		TableGroups: []*tableaclpb.TableGroupSpec{{
+			Name:                 "group01",
+			TableNamesOrPrefixes: []string{"test_table"},
+			Readers:              []string{"readers"},
+		}},
+		RoleMappings: []*tableaclpb.RoleMapping{{
+			Role:   "analyst",
+			Groups: []string{"readers"},
+		}},
+	}
+	err := tableacl.InitFromProto(config)
+	require.NoError(t, err)
+
+	// The analyst role of the caller grants it the readers group.
+	ctx := callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{
+		Username: "u2",
+		Groups:   []string{"analyst"},
+	})
+	tsv := newTestTabletServer(ctx, enableStrictTableACL, db)
+	defer tsv.StopService()
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	require.True(t, got.Equal(want), "qre.Execute() = %v, want: %v", got, want)
+
+	// Without the role, the caller cannot read the table.
+	ctx = callerid.NewContext(context.Background(), nil, &querypb.VTGateCallerID{
+		Username: "u2",
+		Groups:   []string{"sales"},
+	})
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	_, err = qre.Execute()
+	require.Equal(t, vtrpcpb.Code_PERMISSION_DENIED, vterrors.Code(err))
+}
+
 func TestQueryExecutorTableAclNoPermission(t *testing.T) {
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
 	tableacl.Register(aclName, &simpleacl.Factory{})
@@ -1504,6 +1664,43 @@ func TestGetConnectionLogStats(t *testing.T) {
 	assert.True(t, qre.logStats.WaitingForConnection > 0)
 }
 
+func TestQueryExecutorOlapWorkload(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.config.Olap.MaxRows = 1
+	tsv.config.Olap.StreamBufferSize = 16
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "id", Type: sqltypes.Int32}},
+		Rows:   [][]sqltypes.Value{{sqltypes.NewInt32(1)}, {sqltypes.NewInt32(2)}},
+	})
+	olap := &querypb.ExecuteOptions{Workload: querypb.ExecuteOptions_OLAP}
+
+	// The queries of OLAP sessions use the stream pool.
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.options = olap
+	conn, err := qre.getConn()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, tsv.qe.streamConns.Active())
+	conn.Recycle()
+	assert.Equal(t, 16, qre.streamBufferSize())
+
+	// The rows streamed by OLAP sessions are limited.
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	qre.options = olap
+	err = qre.Stream(func(*sqltypes.Result) error { return nil })
+	assert.ErrorContains(t, err, "OLAP row count exceeded 1")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+
+	qre = newTestQueryExecutorStreaming(ctx, tsv, query, 0)
+	require.NoError(t, qre.Stream(func(*sqltypes.Result) error { return nil }))
+	assert.EqualValues(t, tsv.config.StreamBufferSize, qre.streamBufferSize())
+}
+
 type executorFlags int64
 
 const (
@@ -58,9 +58,10 @@ type heartbeatReader struct {
 	pool   *connpool.Pool
 	ticks  *timer.Timer
 
-	lagMu          sync.Mutex
-	lastKnownLag   time.Duration
-	lastKnownError error
+	lagMu              sync.Mutex
+	lastKnownLag       time.Duration
+	lastKnownHeartbeat time.Time
+	lastKnownError     error
 }
 
 // newHeartbeatReader returns a new heartbeatReader.
@@ -136,6 +137,21 @@ func (r *heartbeatReader) Status() (time.Duration, error) {
 	return r.lastKnownLag, nil
 }
 
+// Staleness returns the time elapsed since the primary wrote the most
+// recently read heartbeat. Unlike the lag, which is only measured at
+// every read, it keeps growing while the heartbeats stop replicating.
+func (r *heartbeatReader) Staleness() (time.Duration, error) {
+	r.lagMu.Lock()
+	defer r.lagMu.Unlock()
+	if r.lastKnownError != nil {
+		return 0, r.lastKnownError
+	}
+	if r.lastKnownHeartbeat.IsZero() {
+		return r.lastKnownLag, nil
+	}
+	return r.now().Sub(r.lastKnownHeartbeat), nil
+}
+
 // readHeartbeat reads from the heartbeat table exactly once, updating
 // the last known lag and/or error, and incrementing counters.
 func (r *heartbeatReader) readHeartbeat() {
@@ -155,7 +171,8 @@ func (r *heartbeatReader) readHeartbeat() {
 		return
 	}
 
-	lag := r.now().Sub(time.Unix(0, ts))
+	heartbeat := time.Unix(0, ts)
+	lag := r.now().Sub(heartbeat)
 	cumulativeLagNs.Add(lag.Nanoseconds())
 	currentLagNs.Set(lag.Nanoseconds())
 	heartbeatLagNsHistogram.Add(lag.Nanoseconds())
@@ -163,6 +180,7 @@ func (r *heartbeatReader) readHeartbeat() {
 
 	r.lagMu.Lock()
 	r.lastKnownLag = lag
+	r.lastKnownHeartbeat = heartbeat
 	r.lastKnownError = nil
 	r.lagMu.Unlock()
 }
@@ -83,6 +83,38 @@ func TestReaderReadHeartbeat(t *testing.T) {
 	utils.MustMatch(t, expectedHisto, heartbeatLagNsHistogram.Counts(), "wrong counts in histogram")
 }
 
+// TestReaderStaleness tests that the staleness keeps growing after the last
+// read of the heartbeat, while the lag is only updated by the reads.
+func TestReaderStaleness(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+
+	now := time.Now()
+	tr := newReader(db, &now)
+	defer tr.Close()
+
+	tr.pool.Open(tr.env.Config().DB.AppWithDB(), tr.env.Config().DB.DbaWithDB(), tr.env.Config().DB.AppDebugWithDB())
+
+	db.AddQuery(fmt.Sprintf("SELECT ts FROM %s.heartbeat WHERE keyspaceShard='%s'", "_vt", tr.keyspaceShard), &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "ts", Type: sqltypes.Int64},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt64(now.Add(-10 * time.Second).UnixNano()),
+		}},
+	})
+
+	tr.readHeartbeat()
+	now = now.Add(5 * time.Second)
+
+	lag, err := tr.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, lag)
+	staleness, err := tr.Staleness()
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Second, staleness)
+}
+
 // TestReaderCloseSetsCurrentLagToZero tests that when closing the heartbeat reader, the current lag is
 // set to zero.
 func TestReaderCloseSetsCurrentLagToZero(t *testing.T) {
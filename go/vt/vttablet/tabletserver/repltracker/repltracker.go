@@ -138,6 +138,22 @@ func (rt *ReplTracker) Status() (time.Duration, error) {
 	return rt.poller.Status()
 }
 
+// Staleness reports how stale the data of the tablet is. It is measured
+// with the heartbeats of the primary when they are tracked, and falls back
+// to the replication lag otherwise.
+func (rt *ReplTracker) Staleness() (time.Duration, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	switch {
+	case rt.isPrimary || rt.mode == tabletenv.Disable:
+		return 0, nil
+	case rt.mode == tabletenv.Heartbeat:
+		return rt.hr.Staleness()
+	}
+	return rt.poller.Status()
+}
+
 // EnableHeartbeat enables or disables writes of heartbeat. This functionality
 // is only used by tests.
 func (rt *ReplTracker) EnableHeartbeat(enable bool) {
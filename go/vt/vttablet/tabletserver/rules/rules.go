@@ -30,8 +30,10 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 )
 
 // -----------------------------------------------
@@ -142,6 +144,17 @@ func (qrs *Rules) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// UnmarshalRule unmarshals the JSON definition of a single Rule.
+func UnmarshalRule(data []byte) (*Rule, error) {
+	var ruleInfo map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&ruleInfo); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%v", err)
+	}
+	return BuildQueryRule(ruleInfo)
+}
+
 // MarshalJSON marshals to JSON.
 func (qrs *Rules) MarshalJSON() ([]byte, error) {
 	b := bytes.NewBuffer(nil)
@@ -169,6 +182,30 @@ func (qrs *Rules) FilterByPlan(query string, planid planbuilder.PlanType, tableN
 	return &Rules{newrules}
 }
 
+// Match plans the query as the tablets do, with its tables assumed to exist as
+// regular tables, and returns its plan type, its tables, and the rules whose
+// query, plan and table conditions it matches.
+func (qrs *Rules) Match(env *vtenv.Environment, query string) (planbuilder.PlanType, []string, *Rules, error) {
+	stmt, err := env.Parser().Parse(query)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	tables := make(map[string]*schema.Table)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if tableName, ok := node.(sqlparser.TableName); ok && !tableName.Name.IsEmpty() {
+			name := tableName.Name.String()
+			tables[name] = schema.NewTable(name, schema.NoType)
+		}
+		return true, nil
+	}, stmt)
+	plan, err := planbuilder.Build(env, stmt, tables, "", false)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	tableNames := plan.TableNames()
+	return plan.PlanID, tableNames, qrs.FilterByPlan(query, plan.PlanID, tableNames...), nil
+}
+
 // GetAction runs the input against the rules engine and returns the action to be performed.
 func (qrs *Rules) GetAction(
 	ip,
@@ -154,6 +154,7 @@ type (
 		MakeNonPrimary()
 		Close()
 		Status() (time.Duration, error)
+		Staleness() (time.Duration, error)
 	}
 
 	queryEngine interface {
@@ -357,6 +358,31 @@ func (sm *stateManager) checkMySQL() {
 	}()
 }
 
+// Reconnect closes all the connections to MySQL, and then transitions back
+// to the wanted state, which opens new ones. It is used when the endpoint of
+// an external MySQL starts pointing to another server: the connections to
+// the previous server would otherwise remain in the pools.
+func (sm *stateManager) Reconnect(reason string) error {
+	if err := sm.transitioning.Acquire(context.Background(), 1); err != nil {
+		return err
+	}
+	sm.mu.Lock()
+	tabletType, state := sm.wantTabletType, sm.wantState
+	sm.mu.Unlock()
+	if state == StateNotConnected {
+		sm.transitioning.Release(1)
+		return nil
+	}
+
+	log.Infof("Reconnecting to MySQL: %v", reason)
+	// This is required to prevent new queries from running in StartRequest
+	// unless they are part of a running transaction.
+	sm.setWantState(StateNotConnected)
+	sm.closeAll()
+	sm.setWantState(state)
+	return sm.execTransition(tabletType, state)
+}
+
 func (sm *stateManager) setWantState(stateWanted servingState) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -717,11 +743,17 @@ func (sm *stateManager) Broadcast() {
 	defer sm.mu.Unlock()
 
 	lag, err := sm.refreshReplHealthLocked()
+	var staleness time.Duration
+	if err == nil && sm.target.TabletType != topodatapb.TabletType_PRIMARY {
+		// The staleness is not reported along with a replication error, so
+		// its own error can be ignored.
+		staleness, _ = sm.rt.Staleness()
+	}
 	if sm.demotePrimaryStalled {
 		// If we are stalled while demoting primary, we should send an error for it.
 		err = vterrors.VT09031()
 	}
-	sm.hs.ChangeState(sm.target.TabletType, sm.ptsTimestamp, lag, err, sm.isServingLocked())
+	sm.hs.ChangeState(sm.target.TabletType, sm.ptsTimestamp, lag, staleness, err, sm.isServingLocked())
 }
 
 func (sm *stateManager) refreshReplHealthLocked() (time.Duration, error) {
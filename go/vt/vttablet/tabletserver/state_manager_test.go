@@ -554,6 +554,34 @@ func TestStateManagerCheckMySQL(t *testing.T) {
 	}
 }
 
+func TestStateManagerReconnect(t *testing.T) {
+	sm := newTestStateManager()
+	defer sm.StopService()
+	err := sm.SetServingType(topodatapb.TabletType_PRIMARY, testNow, StateServing, "")
+	require.NoError(t, err)
+
+	order.Store(0)
+	err = sm.Reconnect("test")
+	require.NoError(t, err)
+
+	// All the components were closed before being opened again.
+	verifySubcomponent(t, 14, sm.se, testStateOpen)
+	verifySubcomponent(t, 20, sm.te, testStatePrimary)
+	verifySubcomponent(t, 24, sm.ddle, testStateOpen)
+	assert.True(t, sm.IsServing())
+	assert.Equal(t, topodatapb.TabletType_PRIMARY, sm.Target().TabletType)
+	assert.Equal(t, StateServing, sm.State())
+
+	// A tablet which is not connected stays so.
+	err = sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateNotConnected, "")
+	require.NoError(t, err)
+	order.Store(0)
+	err = sm.Reconnect("test")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, order.Load())
+	assert.Equal(t, StateNotConnected, sm.State())
+}
+
 func TestStateManagerValidations(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -681,9 +709,11 @@ func TestStateManagerNotify(t *testing.T) {
 	}()
 	defer wg.Wait()
 
+	sm.rt.(*testReplTracker).staleness = 1500 * time.Millisecond
 	sm.Broadcast()
 
 	gotshr := <-ch
+	assert.EqualValues(t, 1500, gotshr.RealtimeStats.ReplicationStalenessMs)
 	// Remove things we don't care about:
 	gotshr.RealtimeStats = nil
 	wantshr := &querypb.StreamHealthResponse{
@@ -908,8 +938,9 @@ func (te *testSchemaEngine) Close() {
 
 type testReplTracker struct {
 	testOrderState
-	lag time.Duration
-	err error
+	lag       time.Duration
+	staleness time.Duration
+	err       error
 }
 
 func (te *testReplTracker) MakePrimary() {
@@ -931,6 +962,10 @@ func (te *testReplTracker) Status() (time.Duration, error) {
 	return te.lag, te.err
 }
 
+func (te *testReplTracker) Staleness() (time.Duration, error) {
+	return te.staleness, te.err
+}
+
 type testQueryEngine struct {
 	testOrderState
 
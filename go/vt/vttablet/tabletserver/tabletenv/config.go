@@ -69,6 +69,8 @@ var (
 	// The following vars are used for custom initialization of Tabletconfig.
 	enableHotRowProtection       bool
 	enableHotRowProtectionDryRun bool
+	enableAdmissionControl       bool
+	enableAdmissionControlDryRun bool
 	enableConsolidator           bool
 	enableConsolidatorReplicas   bool
 	enableHeartbeat              bool
@@ -140,6 +142,8 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&currentConfig.SchemaChangeReloadTimeout, "schema-change-reload-timeout", defaultConfig.SchemaChangeReloadTimeout, "query server schema change reload timeout, this is how long to wait for the signaled schema reload operation to complete before giving up")
 	fs.BoolVar(&currentConfig.SignalWhenSchemaChange, "queryserver-config-schema-change-signal", defaultConfig.SignalWhenSchemaChange, "query server schema signal, will signal connected vtgates that schema has changed whenever this is detected. VTGates will need to have -schema_change_signal enabled for this to work")
 	fs.DurationVar(&currentConfig.Olap.TxTimeout, "queryserver-config-olap-transaction-timeout", defaultConfig.Olap.TxTimeout, "query server transaction timeout (in seconds), after which a transaction in an OLAP session will be killed")
+	fs.IntVar(&currentConfig.Olap.MaxRows, "queryserver-config-olap-max-result-size", defaultConfig.Olap.MaxRows, "query server OLAP max result size, maximum number of rows allowed to stream from vttablet for a query of an OLAP session. 0 means no limit.")
+	fs.IntVar(&currentConfig.Olap.StreamBufferSize, "queryserver-config-olap-stream-buffer-size", defaultConfig.Olap.StreamBufferSize, "query server OLAP stream buffer size, the maximum number of bytes sent from vttablet for each stream call of an OLAP session. 0 means --queryserver-config-stream-buffer-size.")
 	fs.DurationVar(&currentConfig.Oltp.QueryTimeout, "queryserver-config-query-timeout", defaultConfig.Oltp.QueryTimeout, "query server query timeout, this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
 	fs.DurationVar(&currentConfig.OltpReadPool.Timeout, "queryserver-config-query-pool-timeout", defaultConfig.OltpReadPool.Timeout, "query server query pool timeout, it is how long vttablet waits for a connection from the query pool. If set to 0 (default) then the overall query timeout is used instead.")
 	fs.DurationVar(&currentConfig.OlapReadPool.Timeout, "queryserver-config-stream-pool-timeout", defaultConfig.OlapReadPool.Timeout, "query server stream pool timeout, it is how long vttablet waits for a connection from the stream pool. If set to 0 (default) then there is no timeout.")
@@ -181,6 +185,15 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&currentConfig.HotRowProtection.MaxGlobalQueueSize, "hot_row_protection_max_global_queue_size", defaultConfig.HotRowProtection.MaxGlobalQueueSize, "Global queue limit across all row (ranges). Useful to prevent that the queue can grow unbounded.")
 	fs.IntVar(&currentConfig.HotRowProtection.MaxConcurrency, "hot_row_protection_concurrent_transactions", defaultConfig.HotRowProtection.MaxConcurrency, "Number of concurrent transactions let through to the txpool/MySQL for the same hot row. Should be > 1 to have enough 'ready' transactions in MySQL and benefit from a pipelining effect.")
 
+	fs.BoolVar(&enableAdmissionControl, "enable-admission-control", false, "If true, requests are queued by priority class (oltp, vreplication, olap, ddl) once MySQL is saturated, and each class is limited to its configured concurrency.")
+	fs.BoolVar(&enableAdmissionControlDryRun, "enable-admission-control-dry-run", false, "If true, admission control is not enforced but logs if requests would have been queued.")
+	fs.Float64Var(&currentConfig.AdmissionControl.SaturationThreshold, "admission-control-saturation-threshold", defaultConfig.AdmissionControl.SaturationThreshold, "Fraction of the query pool (--queryserver-config-pool-size) that must be in use for MySQL to be considered saturated by admission control.")
+	fs.IntVar(&currentConfig.AdmissionControl.MaxQueueSize, "admission-control-max-queue-size", defaultConfig.AdmissionControl.MaxQueueSize, "Maximum number of requests which will be queued by admission control across all classes.")
+	fs.IntVar(&currentConfig.AdmissionControl.OltpConcurrency, "admission-control-oltp-concurrency", defaultConfig.AdmissionControl.OltpConcurrency, "Maximum number of concurrent OLTP requests while MySQL is saturated. 0 means unlimited.")
+	fs.IntVar(&currentConfig.AdmissionControl.OlapConcurrency, "admission-control-olap-concurrency", defaultConfig.AdmissionControl.OlapConcurrency, "Maximum number of concurrent OLAP requests while MySQL is saturated. 0 means unlimited.")
+	fs.IntVar(&currentConfig.AdmissionControl.DDLConcurrency, "admission-control-ddl-concurrency", defaultConfig.AdmissionControl.DDLConcurrency, "Maximum number of concurrent DDL requests while MySQL is saturated. 0 means unlimited.")
+	fs.IntVar(&currentConfig.AdmissionControl.VReplicationConcurrency, "admission-control-vreplication-concurrency", defaultConfig.AdmissionControl.VReplicationConcurrency, "Maximum number of vstreamer batches sent concurrently while MySQL is saturated. 0 means unlimited.")
+
 	fs.BoolVar(&currentConfig.EnableTransactionLimit, "enable_transaction_limit", defaultConfig.EnableTransactionLimit, "If true, limit on number of transactions open at the same time will be enforced for all users. User trying to open a new transaction after exhausting their limit will receive an error immediately, regardless of whether there are available slots or not.")
 	fs.BoolVar(&currentConfig.EnableTransactionLimitDryRun, "enable_transaction_limit_dry_run", defaultConfig.EnableTransactionLimitDryRun, "If true, limit on number of transactions open at the same time will be tracked for all users, but not enforced.")
 	fs.Float64Var(&currentConfig.TransactionLimitPerUser, "transaction_limit_per_user", defaultConfig.TransactionLimitPerUser, "Maximum number of transactions a single user is allowed to use at any time, represented as fraction of -transaction_cap.")
@@ -217,6 +230,8 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 
 	fs.BoolVar(&currentConfig.EnablePerWorkloadTableMetrics, "enable-per-workload-table-metrics", defaultConfig.EnablePerWorkloadTableMetrics, "If true, query counts and query error metrics include a label that identifies the workload")
 	fs.BoolVar(&currentConfig.SkipUserMetrics, "skip-user-metrics", defaultConfig.SkipUserMetrics, "If true, user based stats are not recorded.")
+	fs.StringSliceVar(&currentConfig.TableMetricsAllowlist, "queryserver-config-table-metrics-allowlist", defaultConfig.TableMetricsAllowlist, "Tables labeling the per-table query metrics. The other tables are accounted as 'other'. If empty, --queryserver-config-table-metrics-max-tables applies.")
+	fs.IntVar(&currentConfig.TableMetricsMaxTables, "queryserver-config-table-metrics-max-tables", defaultConfig.TableMetricsMaxTables, "Maximum number of distinct tables labeling the per-table query metrics when --queryserver-config-table-metrics-allowlist is empty. The tables seen after this limit is reached are accounted as 'other'. 0 means no limit.")
 
 	fs.BoolVar(&currentConfig.Unmanaged, "unmanaged", false, "Indicates an unmanaged tablet, i.e. using an external mysql-compatible database")
 }
@@ -245,6 +260,16 @@ func Init() {
 		currentConfig.HotRowProtection.Mode = Disable
 	}
 
+	if enableAdmissionControl {
+		if enableAdmissionControlDryRun {
+			currentConfig.AdmissionControl.Mode = Dryrun
+		} else {
+			currentConfig.AdmissionControl.Mode = Enable
+		}
+	} else {
+		currentConfig.AdmissionControl.Mode = Disable
+	}
+
 	switch {
 	case enableConsolidatorReplicas:
 		currentConfig.Consolidator = NotOnPrimary
@@ -315,6 +340,7 @@ type TabletConfig struct {
 	Olap             OlapConfig             `json:"olap,omitempty"`
 	Oltp             OltpConfig             `json:"oltp,omitempty"`
 	HotRowProtection HotRowProtectionConfig `json:"hotRowProtection,omitempty"`
+	AdmissionControl AdmissionControlConfig `json:"admissionControl,omitempty"`
 
 	Healthcheck  HealthcheckConfig  `json:"healthcheck,omitempty"`
 	GracePeriods GracePeriodsConfig `json:"gracePeriods,omitempty"`
@@ -372,6 +398,12 @@ type TabletConfig struct {
 
 	EnablePerWorkloadTableMetrics bool `json:"-"`
 	SkipUserMetrics               bool `json:"-"`
+
+	// TableMetricsAllowlist are the tables labeling the per-table metrics.
+	// If it is empty, the first TableMetricsMaxTables tables label them.
+	// The other tables are accounted as "other".
+	TableMetricsAllowlist []string `json:"-"`
+	TableMetricsMaxTables int      `json:"-"`
 }
 
 func (cfg *TabletConfig) MarshalJSON() ([]byte, error) {
@@ -513,6 +545,12 @@ func (cfg *ConnPoolConfig) UnmarshalJSON(data []byte) (err error) {
 // OlapConfig contains the config for olap settings.
 type OlapConfig struct {
 	TxTimeout time.Duration `json:"txTimeoutSeconds,omitempty"`
+	// MaxRows is the maximum number of rows streamed by a query of an OLAP
+	// session. 0 means no limit.
+	MaxRows int `json:"maxRows,omitempty"`
+	// StreamBufferSize is the number of bytes sent for each stream call of
+	// an OLAP session. 0 means StreamBufferSize of TabletConfig.
+	StreamBufferSize int `json:"streamBufferSize,omitempty"`
 }
 
 func (cfg *OlapConfig) MarshalJSON() ([]byte, error) {
@@ -618,6 +656,18 @@ type HotRowProtectionConfig struct {
 	MaxConcurrency     int    `json:"maxConcurrency,omitempty"`
 }
 
+// AdmissionControlConfig contains the config for request admission control.
+type AdmissionControlConfig struct {
+	// Mode can be disable, dryRun or enable. Default is disable.
+	Mode                    string  `json:"mode,omitempty"`
+	SaturationThreshold     float64 `json:"saturationThreshold,omitempty"`
+	MaxQueueSize            int     `json:"maxQueueSize,omitempty"`
+	OltpConcurrency         int     `json:"oltpConcurrency,omitempty"`
+	OlapConcurrency         int     `json:"olapConcurrency,omitempty"`
+	DDLConcurrency          int     `json:"ddlConcurrency,omitempty"`
+	VReplicationConcurrency int     `json:"vreplicationConcurrency,omitempty"`
+}
+
 // SemiSyncMonitorConfig contains the config for the semi-sync monitor.
 type SemiSyncMonitorConfig struct {
 	Interval time.Duration
@@ -913,6 +963,28 @@ func (c *TabletConfig) Verify() error {
 	if v := c.HotRowProtection.MaxConcurrency; v <= 0 {
 		return fmt.Errorf("--hot_row_protection_concurrent_transactions must be > 0 (specified value: %v)", v)
 	}
+	if err := c.verifyAdmissionControlConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyAdmissionControlConfig checks AdmissionControlConfig for sanity.
+func (c *TabletConfig) verifyAdmissionControlConfig() error {
+	if c.AdmissionControl.Mode == Disable {
+		return nil
+	}
+	if v := c.AdmissionControl.SaturationThreshold; v <= 0 || v > 1 {
+		return fmt.Errorf("--admission-control-saturation-threshold should be a fraction within range (0, 1] (specified value: %v)", v)
+	}
+	if v := c.AdmissionControl.MaxQueueSize; v <= 0 {
+		return fmt.Errorf("--admission-control-max-queue-size must be > 0 (specified value: %v)", v)
+	}
+	for _, v := range []int{c.AdmissionControl.OltpConcurrency, c.AdmissionControl.OlapConcurrency, c.AdmissionControl.DDLConcurrency, c.AdmissionControl.VReplicationConcurrency} {
+		if v < 0 {
+			return fmt.Errorf("admission control concurrency limits must be >= 0 (specified value: %v)", v)
+		}
+	}
 	return nil
 }
 
@@ -1073,6 +1145,17 @@ var defaultConfig = TabletConfig{
 		// of them ready in MySQL and profit from a pipelining effect.
 		MaxConcurrency: 5,
 	},
+	AdmissionControl: AdmissionControlConfig{
+		Mode:                Disable,
+		SaturationThreshold: 0.9,
+		MaxQueueSize:        1000,
+		// By default only the background classes are limited, so that regular
+		// OLTP traffic gets to use the capacity freed up by them.
+		OltpConcurrency:         0,
+		OlapConcurrency:         4,
+		DDLConcurrency:          1,
+		VReplicationConcurrency: 4,
+	},
 	Consolidator:                Enable,
 	ConsolidatorStreamTotalSize: 128 * 1024 * 1024,
 	ConsolidatorStreamQuerySize: 2 * 1024 * 1024,
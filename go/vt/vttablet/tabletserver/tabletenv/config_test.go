@@ -64,7 +64,8 @@ func TestConfigParse(t *testing.T) {
 
 	gotBytes, err := yaml2.Marshal(&cfg)
 	require.NoError(t, err)
-	wantBytes := `db:
+	wantBytes := `admissionControl: {}
+db:
   allprivs:
     password: '****'
   app:
@@ -128,7 +129,14 @@ oltpReadPool:
 func TestDefaultConfig(t *testing.T) {
 	gotBytes, err := yaml2.Marshal(NewDefaultConfig())
 	require.NoError(t, err)
-	want := `consolidator: enable
+	want := `admissionControl:
+  ddlConcurrency: 1
+  maxQueueSize: 1000
+  mode: disable
+  olapConcurrency: 4
+  saturationThreshold: 0.9
+  vreplicationConcurrency: 4
+consolidator: enable
 consolidatorStreamQuerySize: 2097152
 consolidatorStreamTotalSize: 134217728
 gracePeriods:
@@ -213,6 +221,7 @@ func TestFlags(t *testing.T) {
 	want.OlapReadPool.IdleTimeout = 30 * time.Minute
 	want.TxPool.IdleTimeout = 30 * time.Minute
 	want.HotRowProtection.Mode = Disable
+	want.AdmissionControl.Mode = Disable
 	want.Consolidator = Enable
 	want.Healthcheck.Interval = 20 * time.Second
 	want.Healthcheck.DegradedThreshold = 30 * time.Second
@@ -246,6 +255,24 @@ func TestFlags(t *testing.T) {
 	want.HotRowProtection.Mode = Disable
 	assert.Equal(t, want, currentConfig)
 
+	enableAdmissionControl = true
+	enableAdmissionControlDryRun = true
+	Init()
+	want.AdmissionControl.Mode = Dryrun
+	assert.Equal(t, want, currentConfig)
+
+	enableAdmissionControl = true
+	enableAdmissionControlDryRun = false
+	Init()
+	want.AdmissionControl.Mode = Enable
+	assert.Equal(t, want, currentConfig)
+
+	enableAdmissionControl = false
+	enableAdmissionControlDryRun = false
+	Init()
+	want.AdmissionControl.Mode = Disable
+	assert.Equal(t, want, currentConfig)
+
 	enableConsolidator = true
 	enableConsolidatorReplicas = true
 	Init()
@@ -503,3 +530,26 @@ func TestVerifyUnmanagedTabletConfig(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "testPassword", config.DB.App.Password)
 }
+
+func TestVerifyAdmissionControlConfig(t *testing.T) {
+	config := defaultConfig
+
+	// Disabled admission control is not verified.
+	config.AdmissionControl.SaturationThreshold = 0
+	assert.NoError(t, config.verifyAdmissionControlConfig())
+
+	config.AdmissionControl = defaultConfig.AdmissionControl
+	config.AdmissionControl.Mode = Enable
+	assert.NoError(t, config.verifyAdmissionControlConfig())
+
+	config.AdmissionControl.SaturationThreshold = 1.5
+	assert.EqualError(t, config.verifyAdmissionControlConfig(), "--admission-control-saturation-threshold should be a fraction within range (0, 1] (specified value: 1.5)")
+
+	config.AdmissionControl.SaturationThreshold = 1
+	config.AdmissionControl.MaxQueueSize = 0
+	assert.EqualError(t, config.verifyAdmissionControlConfig(), "--admission-control-max-queue-size must be > 0 (specified value: 0)")
+
+	config.AdmissionControl.MaxQueueSize = 10
+	config.AdmissionControl.DDLConcurrency = -1
+	assert.EqualError(t, config.verifyAdmissionControlConfig(), "admission control concurrency limits must be >= 0 (specified value: -1)")
+}
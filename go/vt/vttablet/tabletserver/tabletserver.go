@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -60,6 +61,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/onlineddl"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/admission"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/gc"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/messager"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
@@ -114,6 +116,7 @@ type TabletServer struct {
 	watcher      *BinlogWatcher
 	qe           *QueryEngine
 	txThrottler  txthrottler.TxThrottler
+	admission    *admission.Controller
 	te           *TxEngine
 	messager     *messager.Engine
 	hs           *healthStreamer
@@ -183,6 +186,7 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.watcher = NewBinlogWatcher(tsv, tsv.vstreamer, tsv.config)
 	tsv.qe = NewQueryEngine(tsv, tsv.se)
 	tsv.txThrottler = txthrottler.NewTxThrottler(tsv, topoServer)
+	tsv.admission = admission.New(tsv, tsv.qe.IsSaturated)
 	tsv.te = NewTxEngine(tsv, tsv.hs.sendUnresolvedTransactionSignal)
 	tsv.messager = messager.NewEngine(tsv, tsv.se, tsv.vstreamer)
 
@@ -411,6 +415,12 @@ func (tsv *TabletServer) SetServingType(tabletType topodatapb.TabletType, ptsTim
 	return tsv.sm.SetServingType(tabletType, ptsTimestamp, state, reason)
 }
 
+// ReconnectMySQL closes all the connections to MySQL and opens new ones.
+// The tabletserver does not serve queries in the meantime.
+func (tsv *TabletServer) ReconnectMySQL(reason string) error {
+	return tsv.sm.Reconnect(reason)
+}
+
 // StartService is a convenience function for InitDBConfig->SetServingType
 // with serving=true.
 func (tsv *TabletServer) StartService(target *querypb.Target, dbcfgs *dbconfigs.DBConfigs, mysqld mysqlctl.MysqlDaemon) error {
@@ -493,6 +503,11 @@ func (tsv *TabletServer) QueryService() queryservice.QueryService {
 	return tsv
 }
 
+// CurrentTarget returns the current target of the TabletServer.
+func (tsv *TabletServer) CurrentTarget() *querypb.Target {
+	return tsv.sm.Target()
+}
+
 // LagThrottler returns the throttle.Throttler part of TabletServer.
 func (tsv *TabletServer) LagThrottler() *throttle.Throttler {
 	return tsv.lagThrottler
@@ -531,6 +546,9 @@ func (tsv *TabletServer) begin(
 			if tsv.txThrottler.Throttle(tsv.getPriorityFromOptions(options), options.GetWorkloadName()) {
 				return errTxThrottled
 			}
+			if reservedID == 0 {
+				settings = withSessionTrackGtids(settings, options)
+			}
 			var connSetting *smartconnpool.Setting
 			if len(settings) > 0 {
 				connSetting, err = tsv.qe.GetConnSetting(ctx, settings)
@@ -639,7 +657,7 @@ func (tsv *TabletServer) resolveTargetType(ctx context.Context, target *querypb.
 }
 
 // Commit commits the specified transaction.
-func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (newReservedID int64, err error) {
+func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, transactionID int64) (newReservedID int64, sessionStateChanges string, err error) {
 	err = tsv.execRequest(
 		ctx, tsv.loadQueryTimeout(),
 		"Commit", "commit", nil,
@@ -649,7 +667,7 @@ func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, tra
 			logStats.TransactionID = transactionID
 
 			var commitSQL string
-			newReservedID, commitSQL, err = tsv.te.Commit(ctx, transactionID)
+			newReservedID, commitSQL, sessionStateChanges, err = tsv.te.Commit(ctx, transactionID)
 			if newReservedID > 0 {
 				// commit executed on old reserved id.
 				logStats.ReservedID = transactionID
@@ -671,7 +689,7 @@ func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, tra
 			return err
 		},
 	)
-	return newReservedID, err
+	return newReservedID, sessionStateChanges, err
 }
 
 // Rollback rollsback the specified transaction.
@@ -917,6 +935,15 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 			logStats.ReservedID = reservedID
 			logStats.TransactionID = transactionID
 
+			if connID == 0 {
+				admitted, err := tsv.admission.Admit(ctx, admission.Classify(query, options))
+				if err != nil {
+					return err
+				}
+				defer admitted()
+				settings = withSessionTrackGtids(settings, options)
+			}
+
 			var connSetting *smartconnpool.Setting
 			if len(settings) > 0 {
 				connSetting, err = tsv.qe.GetConnSetting(ctx, settings)
@@ -1023,6 +1050,14 @@ func (tsv *TabletServer) streamExecute(ctx context.Context, target *querypb.Targ
 			logStats.ReservedID = reservedID
 			logStats.TransactionID = transactionID
 
+			if connID == 0 {
+				admitted, err := tsv.admission.Admit(ctx, admission.Classify(query, options))
+				if err != nil {
+					return err
+				}
+				defer admitted()
+			}
+
 			var connSetting *smartconnpool.Setting
 			if len(settings) > 0 {
 				connSetting, err = tsv.qe.GetConnSetting(ctx, settings)
@@ -1259,7 +1294,7 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 	if err != nil {
 		return 0, err
 	}
-	if _, err = tsv.Commit(ctx, target, state.TransactionID); err != nil {
+	if _, _, err = tsv.Commit(ctx, target, state.TransactionID); err != nil {
 		state.TransactionID = 0
 		return 0, err
 	}
@@ -1272,7 +1307,7 @@ func (tsv *TabletServer) VStream(ctx context.Context, request *binlogdatapb.VStr
 	if err := tsv.sm.VerifyTarget(ctx, request.Target); err != nil {
 		return err
 	}
-	return tsv.vstreamer.Stream(ctx, request.Position, request.TableLastPKs, request.Filter, throttlerapp.VStreamerName, send, request.Options)
+	return tsv.vstreamer.Stream(ctx, request.Position, request.TableLastPKs, request.Filter, throttlerapp.VStreamerName, admitBatches(ctx, tsv.admission, send), request.Options)
 }
 
 // VStreamRows streams rows from the specified starting point.
@@ -1288,7 +1323,23 @@ func (tsv *TabletServer) VStreamRows(ctx context.Context, request *binlogdatapb.
 		}
 		row = r.Rows[0]
 	}
-	return tsv.vstreamer.StreamRows(ctx, request.Query, row, send, request.Options)
+	return tsv.vstreamer.StreamRows(ctx, request.Query, row, admitBatches(ctx, tsv.admission, send), request.Options)
+}
+
+// admitBatches returns the send function of a vstreamer stream which admits
+// each of its batches as a VReplication request. Streams can last for hours:
+// admitting them as a whole would hold an admission slot for as long, and
+// make the streams beyond the limit of the class queue until others end.
+// While a batch waits to be admitted, the stream stops reading from MySQL.
+func admitBatches[T any](ctx context.Context, controller *admission.Controller, send func(T) error) func(T) error {
+	return func(batch T) error {
+		admitted, err := controller.Admit(ctx, admission.VReplication)
+		if err != nil {
+			return err
+		}
+		defer admitted()
+		return send(batch)
+	}
 }
 
 // VStreamTables streams all tables.
@@ -1337,7 +1388,7 @@ func (tsv *TabletServer) ReserveBeginExecute(ctx context.Context, target *queryp
 				return err
 			}
 			defer tsv.stats.QueryTimingsByTabletType.Record(targetType.String(), time.Now())
-			connID, sessionStateChanges, err = tsv.te.ReserveBegin(ctx, options, settings)
+			connID, sessionStateChanges, err = tsv.te.ReserveBegin(ctx, options, withSessionTrackGtids(settings, options))
 			logStats.TransactionID = connID
 			logStats.ReservedID = connID
 			if err != nil {
@@ -1429,7 +1480,7 @@ func (tsv *TabletServer) ReserveExecute(ctx context.Context, target *querypb.Tar
 				return err
 			}
 			defer tsv.stats.QueryTimingsByTabletType.Record(targetType.String(), time.Now())
-			state.ReservedID, err = tsv.te.Reserve(ctx, options, transactionID, settings)
+			state.ReservedID, err = tsv.te.Reserve(ctx, options, transactionID, withSessionTrackGtids(settings, options))
 			if err != nil {
 				return err
 			}
@@ -1516,6 +1567,20 @@ func txToReserveState(state queryservice.TransactionState) queryservice.Reserved
 	}
 }
 
+// sessionTrackGtidsSetting is the setting of the connections whose queries
+// ask for the GTIDs of their transactions, which MySQL then returns in the OK
+// packets of the commits.
+const sessionTrackGtidsSetting = "set @@session_track_gtids = 'own_gtid'"
+
+// withSessionTrackGtids returns the settings of a connection, along with the
+// setting tracking the GTIDs of its transactions if the options ask for it.
+func withSessionTrackGtids(settings []string, options *querypb.ExecuteOptions) []string {
+	if !options.GetSessionTrackGtids() {
+		return settings
+	}
+	return append(slices.Clip(settings), sessionTrackGtidsSetting)
+}
+
 // GetSchema returns table definitions for the specified tables.
 func (tsv *TabletServer) GetSchema(ctx context.Context, target *querypb.Target, tableType querypb.SchemaTableType, tableNames []string, callback func(schemaRes *querypb.GetSchemaResponse) error) (err error) {
 	err = tsv.execRequest(
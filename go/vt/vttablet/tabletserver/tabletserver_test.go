@@ -26,6 +26,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -54,6 +55,7 @@ import (
 	"vitess.io/vitess/go/vt/tableacl/simpleacl"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/admission"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -501,10 +503,31 @@ func TestTabletServerCommitTransaction(t *testing.T) {
 	require.NoError(t, err)
 	_, err = tsv.Execute(ctx, &target, executeSQL, nil, state.TransactionID, 0, nil)
 	require.NoError(t, err)
-	_, err = tsv.Commit(ctx, &target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, &target, state.TransactionID)
 	require.NoError(t, err)
 }
 
+func TestTabletServerCommitSessionTrackGtids(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	gtid := "e2f7c9a0-6b1d-11ee-8c99-0242ac120002:44"
+	db.AddQuery("set @@session_track_gtids = 'own_gtid'", &sqltypes.Result{})
+	db.AddQuery("commit", &sqltypes.Result{SessionStateChanges: gtid})
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{SessionTrackGtids: true}
+	state, err := tsv.Begin(ctx, &target, options)
+	require.NoError(t, err)
+	_, sessionStateChanges, err := tsv.Commit(ctx, &target, state.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, gtid, sessionStateChanges)
+	assert.Equal(t, 1, db.GetQueryCalledNum("set @@session_track_gtids = 'own_gtid'"))
+}
+
 func TestTabletServerCommiRollbacktFail(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -513,7 +536,7 @@ func TestTabletServerCommiRollbacktFail(t *testing.T) {
 	defer db.Close()
 
 	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
-	_, err := tsv.Commit(ctx, &target, -1)
+	_, _, err := tsv.Commit(ctx, &target, -1)
 	want := "transaction -1: not found (potential transaction timeout)"
 	require.Equal(t, want, err.Error())
 	_, err = tsv.Rollback(ctx, &target, -1)
@@ -642,7 +665,7 @@ func TestTabletServerWithNilTarget(t *testing.T) {
 	expectedCount++
 	require.Equal(t, expectedCount, tsv.stats.QueryTimingsByTabletType.Counts()[fullKey])
 
-	_, err = tsv.Commit(ctx, target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, target, state.TransactionID)
 	require.NoError(t, err)
 	expectedCount++
 	require.Equal(t, expectedCount, tsv.stats.QueryTimingsByTabletType.Counts()[fullKey])
@@ -861,7 +884,7 @@ func TestTabletServerReserveAndBeginCommit(t *testing.T) {
 	require.Error(t, err)
 
 	// commit
-	newRID, err := tsv.Commit(ctx, &target, state.TransactionID)
+	newRID, _, err := tsv.Commit(ctx, &target, state.TransactionID)
 	require.NoError(t, err)
 	assert.NotEqual(t, state.ReservedID, newRID)
 	rID := newRID
@@ -995,7 +1018,7 @@ func TestTabletServerBeginStreamExecute(t *testing.T) {
 			executeSQL, err)
 	}
 	require.NoError(t, err)
-	_, err = tsv.Commit(ctx, &target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, &target, state.TransactionID)
 	require.NoError(t, err)
 }
 
@@ -1093,7 +1116,7 @@ func TestSerializeTransactionsSameRow(t *testing.T) {
 		if err != nil {
 			t.Errorf("failed to execute query: %s: %s", q1, err)
 		}
-		if _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1113,7 +1136,7 @@ func TestSerializeTransactionsSameRow(t *testing.T) {
 		// open a second connection while the request of the first connection is
 		// still pending.
 		<-tx3Finished
-		if _, err := tsv.Commit(ctx, &target, state2.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state2.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1128,7 +1151,7 @@ func TestSerializeTransactionsSameRow(t *testing.T) {
 		if err != nil {
 			t.Errorf("failed to execute query: %s: %s", q3, err)
 		}
-		if _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 		close(tx3Finished)
@@ -1161,7 +1184,7 @@ func TestDMLQueryWithoutWhereClause(t *testing.T) {
 
 	state, _, err := tsv.BeginExecute(ctx, &target, nil, q, nil, 0, nil)
 	require.NoError(t, err)
-	_, err = tsv.Commit(ctx, &target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, &target, state.TransactionID)
 	require.NoError(t, err)
 }
 
@@ -1238,7 +1261,7 @@ func TestSerializeTransactionsSameRow_ConcurrentTransactions(t *testing.T) {
 			t.Errorf("failed to execute query: %s: %s", q1, err)
 		}
 
-		if _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1257,7 +1280,7 @@ func TestSerializeTransactionsSameRow_ConcurrentTransactions(t *testing.T) {
 			t.Errorf("failed to execute query: %s: %s", q2, err)
 		}
 
-		if _, err := tsv.Commit(ctx, &target, state2.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state2.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1276,7 +1299,7 @@ func TestSerializeTransactionsSameRow_ConcurrentTransactions(t *testing.T) {
 			t.Errorf("failed to execute query: %s: %s", q3, err)
 		}
 
-		if _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1373,7 +1396,7 @@ func TestSerializeTransactionsSameRow_TooManyPendingRequests(t *testing.T) {
 		if err != nil {
 			t.Errorf("failed to execute query: %s: %s", q1, err)
 		}
-		if _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1463,7 +1486,7 @@ func TestSerializeTransactionsSameRow_RequestCanceled(t *testing.T) {
 			t.Errorf("failed to execute query: %s: %s", q1, err)
 		}
 
-		if _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state1.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1500,7 +1523,7 @@ func TestSerializeTransactionsSameRow_RequestCanceled(t *testing.T) {
 			t.Errorf("failed to execute query: %s: %s", q3, err)
 		}
 
-		if _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
+		if _, _, err := tsv.Commit(ctx, &target, state3.TransactionID); err != nil {
 			t.Errorf("call TabletServer.Commit failed: %v", err)
 		}
 	}()
@@ -1520,6 +1543,51 @@ func TestSerializeTransactionsSameRow_RequestCanceled(t *testing.T) {
 	}
 }
 
+func TestAdmitBatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.AdmissionControl.Mode = tabletenv.Enable
+	cfg.AdmissionControl.MaxQueueSize = 2
+	cfg.AdmissionControl.VReplicationConcurrency = 1
+	var saturated atomic.Bool
+	saturated.Store(true)
+	controller := admission.New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TestAdmitBatches"), saturated.Load)
+
+	sending := make(chan string)
+	release := make(chan struct{})
+	send := func(batch string) error {
+		sending <- batch
+		<-release
+		return nil
+	}
+	stream1 := admitBatches(ctx, controller, send)
+	stream2 := admitBatches(ctx, controller, send)
+
+	errs := make(chan error, 2)
+	go func() { errs <- stream1("batch1") }()
+	assert.Equal(t, "batch1", <-sending)
+
+	// The batch of the other stream waits for the batch being sent.
+	go func() { errs <- stream2("batch2") }()
+	select {
+	case batch := <-sending:
+		require.FailNow(t, "batch admitted over the limit", batch)
+	case <-time.After(50 * time.Millisecond):
+	}
+	release <- struct{}{}
+	require.NoError(t, <-errs)
+	assert.Equal(t, "batch2", <-sending)
+	release <- struct{}{}
+	require.NoError(t, <-errs)
+
+	// The streams hold no admission slot between their batches.
+	go func() { errs <- stream1("batch3") }()
+	assert.Equal(t, "batch3", <-sending)
+	release <- struct{}{}
+	require.NoError(t, <-errs)
+}
+
 func TestMessageStream(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -2554,7 +2622,7 @@ func TestDatabaseNameReplaceByKeyspaceNameExecuteMethod(t *testing.T) {
 	for _, field := range res.Fields {
 		require.Equal(t, "keyspaceName", field.Database)
 	}
-	_, err = tsv.Commit(ctx, target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, target, state.TransactionID)
 	require.NoError(t, err)
 }
 
@@ -2629,7 +2697,7 @@ func TestDatabaseNameReplaceByKeyspaceNameBeginExecuteMethod(t *testing.T) {
 	for _, field := range res.Fields {
 		require.Equal(t, "keyspaceName", field.Database)
 	}
-	_, err = tsv.Commit(ctx, target, state.TransactionID)
+	_, _, err = tsv.Commit(ctx, target, state.TransactionID)
 	require.NoError(t, err)
 }
 
@@ -293,17 +293,18 @@ func (te *TxEngine) Begin(ctx context.Context, reservedID int64, setting *smartc
 }
 
 // Commit commits the specified transaction and renews connection id if one exists.
-func (te *TxEngine) Commit(ctx context.Context, transactionID int64) (int64, string, error) {
+// It also returns the commit query and the session state changes of the commit.
+func (te *TxEngine) Commit(ctx context.Context, transactionID int64) (int64, string, string, error) {
 	span, ctx := trace.NewSpan(ctx, "TxEngine.Commit")
 	defer span.Finish()
-	var query string
+	var query, sessionStateChanges string
 	var err error
 	connID, err := te.txFinish(transactionID, tx.TxCommit, func(conn *StatefulConnection) error {
-		query, err = te.txPool.Commit(ctx, conn)
+		query, sessionStateChanges, err = te.txPool.Commit(ctx, conn)
 		return err
 	})
 
-	return connID, query, err
+	return connID, query, sessionStateChanges, err
 }
 
 // Rollback rolls back the specified transaction.
@@ -542,7 +543,7 @@ func (te *TxEngine) checkErrorAndMarkFailed(ctx context.Context, dtid string, re
 		return
 	}
 
-	if _, err = te.txPool.Commit(ctx, conn); err != nil {
+	if _, _, err = te.txPool.Commit(ctx, conn); err != nil {
 		log.Errorf("markFailed: Commit failed for dtid %s: %v", dtid, err)
 	}
 	return
@@ -178,23 +178,26 @@ func (tp *TxPool) GetAndLock(connID tx.ConnID, reason string) (*StatefulConnecti
 	return conn, nil
 }
 
-// Commit commits the transaction on the connection.
-func (tp *TxPool) Commit(ctx context.Context, txConn *StatefulConnection) (string, error) {
+// Commit commits the transaction on the connection. It returns the commit
+// query, and the session state changes of the commit, which are the GTID of
+// the transaction if it was begun with the session_track_gtids option.
+func (tp *TxPool) Commit(ctx context.Context, txConn *StatefulConnection) (string, string, error) {
 	if !txConn.IsInTransaction() {
-		return "", vterrors.New(vtrpcpb.Code_INTERNAL, "not in a transaction")
+		return "", "", vterrors.New(vtrpcpb.Code_INTERNAL, "not in a transaction")
 	}
 	span, ctx := trace.NewSpan(ctx, "TxPool.Commit")
 	defer span.Finish()
 	defer tp.txComplete(txConn, tx.TxCommit)
 	if txConn.TxProperties().Autocommit {
-		return "", nil
+		return "", "", nil
 	}
 
-	if _, err := txConn.Exec(ctx, "commit", 1, false); err != nil {
+	qr, err := txConn.Exec(ctx, "commit", 1, false)
+	if err != nil {
 		txConn.Close()
-		return "", err
+		return "", "", err
 	}
-	return "commit", nil
+	return "commit", qr.SessionStateChanges, nil
 }
 
 // RollbackAndRelease rolls back the transaction on the specified connection, and releases the connection when done
@@ -128,6 +128,20 @@ func (mr *MockHealthCheckMockRecorder) GetLoadTabletsTrigger() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadTabletsTrigger", reflect.TypeOf((*MockHealthCheck)(nil).GetLoadTabletsTrigger))
 }
 
+// GetServingTabletStats mocks base method.
+func (m *MockHealthCheck) GetServingTabletStats(target *query.Target) []*discovery.TabletHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServingTabletStats", target)
+	ret0, _ := ret[0].([]*discovery.TabletHealth)
+	return ret0
+}
+
+// GetServingTabletStats indicates an expected call of GetServingTabletStats.
+func (mr *MockHealthCheckMockRecorder) GetServingTabletStats(target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServingTabletStats", reflect.TypeOf((*MockHealthCheck)(nil).GetServingTabletStats), target)
+}
+
 // GetTabletHealth mocks base method.
 func (m *MockHealthCheck) GetTabletHealth(kst discovery.KeyspaceShardTabletType, alias *topodata.TabletAlias) (*discovery.TabletHealth, error) {
 	m.ctrl.T.Helper()
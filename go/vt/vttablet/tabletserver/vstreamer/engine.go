@@ -102,6 +102,7 @@ type Engine struct {
 	vstreamersEndedWithErrors              *stats.Counter
 	vstreamerFlushedBinlogs                *stats.Counter
 	tableStreamerNumTables                 *stats.Counter
+	typeCoercions                          *stats.CountersWithSingleLabel
 
 	throttlerClient *throttle.Client
 }
@@ -145,6 +146,7 @@ func NewEngine(env tabletenv.Env, ts srvtopo.Server, se *schema.Engine, lagThrot
 		vstreamersEndedWithErrors:              env.Exporter().NewCounter("VStreamersEndedWithErrors", "Count of vstreamers that ended with errors"),
 		errorCounts:                            env.Exporter().NewCountersWithSingleLabel("VStreamerErrors", "Tracks errors in vstreamer", "type", "Catchup", "Copy", "Send", "TablePlan"),
 		vstreamerFlushedBinlogs:                env.Exporter().NewCounter("VStreamerFlushedBinlogs", "Number of times we've successfully executed a FLUSH BINARY LOGS statement when starting a vstream"),
+		typeCoercions:                          env.Exporter().NewCountersWithSingleLabel("VStreamerTypeCoercions", "Count of row event values coerced in the permissive type fidelity mode", "type", "Enum", "Set"),
 	}
 	env.Exporter().NewGaugeFunc("RowStreamerMaxInnoDBTrxHistLen", "", func() int64 { return env.Config().RowStreamer.MaxInnoDBTrxHistLen })
 	env.Exporter().NewGaugeFunc("RowStreamerMaxMySQLReplLagSecs", "", func() int64 { return env.Config().RowStreamer.MaxMySQLReplLagSecs })
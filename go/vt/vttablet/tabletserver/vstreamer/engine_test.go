@@ -143,7 +143,10 @@ func TestUpdateVSchema(t *testing.T) {
     }
   },
   "shard_routing_rules": null,
-  "keyspace_routing_rules": null
+  "keyspace_routing_rules": null,
+  "tenant_routing_rules": null,
+  "plan_pins": null,
+  "rewrite_rules": null
 }`
 	b, err := json.MarshalIndent(engine.vschema(), "", "  ")
 	if err != nil {
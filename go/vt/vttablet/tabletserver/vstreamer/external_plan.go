@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamer
+
+import (
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// ExternalPlan applies the filter of a vreplication stream to the rows of a
+// table of a source which is not MySQL, e.g. PostgreSQL, the same way the
+// vstreamer does. Since such a source has no vschema, in_keyrange() must
+// name its vindex, e.g. in_keyrange(id, 'hash', '-80').
+type ExternalPlan struct {
+	plan     *Plan
+	charsets []collations.ID
+}
+
+// NewExternalPlan builds the plan of the table ti for filter. It returns
+// nil if no rule of filter matches the table.
+func NewExternalPlan(env *vtenv.Environment, ti *Table, filter *binlogdatapb.Filter) (*ExternalPlan, error) {
+	plan, err := buildPlan(env, ti, &localVSchema{vschema: &vindexes.VSchema{}}, filter)
+	if err != nil || plan == nil {
+		return nil, err
+	}
+	charsets := make([]collations.ID, len(ti.Fields))
+	for i, field := range ti.Fields {
+		charsets[i] = collations.ID(field.Charset)
+	}
+	return &ExternalPlan{plan: plan, charsets: charsets}, nil
+}
+
+// Fields returns the fields of the filtered rows.
+func (p *ExternalPlan) Fields() []*querypb.Field {
+	return p.plan.fields()
+}
+
+// Filter returns the values to send for a row of the table, or false if
+// the row does not match the filter.
+func (p *ExternalPlan) Filter(values []sqltypes.Value) ([]sqltypes.Value, bool, error) {
+	result := make([]sqltypes.Value, len(p.plan.ColExprs))
+	ok, err := p.plan.filter(values, result, p.charsets)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return result, true, nil
+}
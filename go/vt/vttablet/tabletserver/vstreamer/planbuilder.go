@@ -73,6 +73,10 @@ type Plan struct {
 	// the value being the map of ordinal values to string values.
 	EnumSetValuesMap map[int](map[int]string)
 
+	// RowImage is the image of the rows sent for the table, as requested by
+	// the rule of the filter matching the table.
+	RowImage binlogdatapb.Rule_RowImage
+
 	env *vtenv.Environment
 
 	// IsInternal is set to true if the plan is for a sidecar table.
@@ -247,6 +251,49 @@ func (plan *Plan) filter(values, result []sqltypes.Value, charsets []collations.
 	if len(result) != len(plan.ColExprs) {
 		return false, fmt.Errorf("expected %d values in result slice", len(plan.ColExprs))
 	}
+	ok, err := plan.matches(values, charsets)
+	if !ok || err != nil {
+		return false, err
+	}
+	if err := plan.project(values, result); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// columnUsage returns, for each of the n columns of the table, whether the
+// column is used by the filters of the plan and whether it is used by its
+// column expressions. The values of the columns used by neither do not need
+// to be decoded.
+func (plan *Plan) columnUsage(n int) (filterColumns, projectColumns []bool) {
+	filterColumns = make([]bool, n)
+	projectColumns = make([]bool, n)
+	mark := func(columns []bool, colNum int) {
+		if colNum >= 0 && colNum < n {
+			columns[colNum] = true
+		}
+	}
+	for _, filter := range plan.Filters {
+		if filter.Opcode == VindexMatch {
+			for _, col := range filter.VindexColumns {
+				mark(filterColumns, col)
+			}
+			continue
+		}
+		mark(filterColumns, filter.ColNum)
+	}
+	for _, colExpr := range plan.ColExprs {
+		mark(projectColumns, colExpr.ColNum)
+		for _, col := range colExpr.VindexColumns {
+			mark(projectColumns, col)
+		}
+	}
+	return filterColumns, projectColumns
+}
+
+// matches returns true if the row matches the filters of the plan. Only the
+// values of the columns used by the filters need to be set.
+func (plan *Plan) matches(values []sqltypes.Value, charsets []collations.ID) (bool, error) {
 	for _, filter := range plan.Filters {
 		switch filter.Opcode {
 		case VindexMatch:
@@ -313,25 +360,32 @@ func (plan *Plan) filter(values, result []sqltypes.Value, charsets []collations.
 			}
 		}
 	}
+	return true, nil
+}
+
+// project stores the values of the column expressions of the plan for the row
+// in result, which must be a slice of length equal to ColExprs. Only the values
+// of the columns used by the column expressions need to be set.
+func (plan *Plan) project(values, result []sqltypes.Value) error {
 	for i, colExpr := range plan.ColExprs {
 		if colExpr.ColNum == -1 {
 			result[i] = colExpr.FixedValue
 			continue
 		}
 		if colExpr.ColNum >= len(values) {
-			return false, fmt.Errorf("index out of range, colExpr.ColNum: %d, len(values): %d", colExpr.ColNum, len(values))
+			return fmt.Errorf("index out of range, colExpr.ColNum: %d, len(values): %d", colExpr.ColNum, len(values))
 		}
 		if colExpr.Vindex == nil {
 			result[i] = values[colExpr.ColNum]
 		} else {
 			ksid, err := getKeyspaceID(values, colExpr.Vindex, colExpr.VindexColumns, plan.Table.Fields)
 			if err != nil {
-				return false, err
+				return err
 			}
 			result[i] = sqltypes.MakeTrusted(sqltypes.VarBinary, []byte(ksid))
 		}
 	}
-	return true, nil
+	return nil
 }
 
 func getKeyspaceID(values []sqltypes.Value, vindex vindexes.Vindex, vindexColumns []int, fields []*querypb.Field) (key.DestinationKeyspaceID, error) {
@@ -422,24 +476,63 @@ func tableMatches(table sqlparser.TableName, dbname string, filter *binlogdatapb
 
 func buildPlan(env *vtenv.Environment, ti *Table, vschema *localVSchema, filter *binlogdatapb.Filter) (*Plan, error) {
 	for _, rule := range filter.Rules {
+		var plan *Plan
+		var err error
 		switch {
 		case strings.HasPrefix(rule.Match, "/"):
 			expr := strings.Trim(rule.Match, "/")
-			result, err := regexp.MatchString(expr, ti.Name)
-			if err != nil {
-				return nil, err
+			result, reErr := regexp.MatchString(expr, ti.Name)
+			if reErr != nil {
+				return nil, reErr
 			}
 			if !result {
 				continue
 			}
-			return buildREPlan(env, ti, vschema, rule.Filter)
+			plan, err = buildREPlan(env, ti, vschema, rule.Filter)
 		case rule.Match == ti.Name:
-			return buildTablePlan(env, ti, vschema, rule.Filter)
+			plan, err = buildTablePlan(env, ti, vschema, rule.Filter)
+		default:
+			continue
+		}
+		if plan == nil || err != nil {
+			return plan, err
 		}
+		plan.setRowImage(rule.RowImage)
+		return plan, nil
 	}
 	return nil, nil
 }
 
+// setRowImage sets the image of the rows sent for the table. The tables
+// without a primary key in the columns of the plan are always sent with full
+// images, as their rows could not be identified otherwise. With PK_ONLY
+// images, only the primary key columns are kept in the column expressions.
+func (plan *Plan) setRowImage(rowImage binlogdatapb.Rule_RowImage) {
+	var pkColExprs []ColExpr
+	for i, colExpr := range plan.ColExprs {
+		if plan.isPKColumn(i) {
+			pkColExprs = append(pkColExprs, colExpr)
+		}
+	}
+	if len(pkColExprs) == 0 {
+		return
+	}
+	plan.RowImage = rowImage
+	if rowImage == binlogdatapb.Rule_PK_ONLY {
+		plan.ColExprs = pkColExprs
+	}
+}
+
+// isPKColumn returns true if the column expression i of the plan is a column
+// of the primary key of the table.
+func (plan *Plan) isPKColumn(i int) bool {
+	colExpr := plan.ColExprs[i]
+	if colExpr.ColNum < 0 || colExpr.ColNum >= len(plan.Table.Fields) || colExpr.Vindex != nil {
+		return false
+	}
+	return plan.Table.Fields[colExpr.ColNum].Flags&uint32(querypb.MySqlFlag_PRI_KEY_FLAG) != 0
+}
+
 // buildREPlan handles cases where Match has a regular expression.
 // If so, the Filter can be an empty string or a keyrange, like "-80".
 func buildREPlan(env *vtenv.Environment, ti *Table, vschema *localVSchema, filter string) (*Plan, error) {
@@ -895,6 +895,103 @@ func TestPlanBuilderFilterComparison(t *testing.T) {
 	}
 }
 
+func TestExternalPlan(t *testing.T) {
+	t1 := &Table{
+		Name: "t1",
+		Fields: []*querypb.Field{{
+			Name:    "id",
+			Type:    sqltypes.Int64,
+			Charset: collations.CollationBinaryID,
+		}, {
+			Name:    "val",
+			Type:    sqltypes.VarChar,
+			Charset: uint32(collations.MySQL8().DefaultConnectionCharset()),
+		}},
+	}
+	filter := &binlogdatapb.Filter{
+		Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: "select val, id from t1 where in_keyrange(id, 'hash', '-80')"}},
+	}
+
+	plan, err := NewExternalPlan(vtenv.NewTestEnv(), t1, filter)
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	fields := plan.Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "val", fields[0].Name)
+	assert.Equal(t, "id", fields[1].Name)
+
+	// The keyspace ID of 1 is 166b40b44aba4bd6, and the one of 4 is d2fd8867d50d2dfe.
+	values, ok, err := plan.Filter([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("a")})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []sqltypes.Value{sqltypes.NewVarChar("a"), sqltypes.NewInt64(1)}, values)
+	_, ok, err = plan.Filter([]sqltypes.Value{sqltypes.NewInt64(4), sqltypes.NewVarChar("b")})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Without a vschema, in_keyrange() must name its vindex.
+	filter.Rules[0].Filter = "select * from t1 where in_keyrange('-80')"
+	_, err = NewExternalPlan(vtenv.NewTestEnv(), t1, filter)
+	assert.Error(t, err)
+
+	filter.Rules[0].Match = "t2"
+	plan, err = NewExternalPlan(vtenv.NewTestEnv(), t1, filter)
+	require.NoError(t, err)
+	assert.Nil(t, plan)
+}
+
+func TestPlanRowImage(t *testing.T) {
+	t1 := &Table{
+		Name: "t1",
+		Fields: []*querypb.Field{{
+			Name:  "id",
+			Type:  sqltypes.Int64,
+			Flags: uint32(querypb.MySqlFlag_NUM_FLAG | querypb.MySqlFlag_PRI_KEY_FLAG),
+		}, {
+			Name: "val",
+			Type: sqltypes.VarBinary,
+		}},
+	}
+	testcases := []struct {
+		name         string
+		rule         *binlogdatapb.Rule
+		wantRowImage binlogdatapb.Rule_RowImage
+		wantColumns  []string
+	}{{
+		name:         "full",
+		rule:         &binlogdatapb.Rule{Match: "/.*"},
+		wantRowImage: binlogdatapb.Rule_FULL,
+		wantColumns:  []string{"id", "val"},
+	}, {
+		name:         "changed columns",
+		rule:         &binlogdatapb.Rule{Match: "t1", Filter: "select val, id from t1", RowImage: binlogdatapb.Rule_CHANGED_COLUMNS},
+		wantRowImage: binlogdatapb.Rule_CHANGED_COLUMNS,
+		wantColumns:  []string{"val", "id"},
+	}, {
+		name:         "pk only",
+		rule:         &binlogdatapb.Rule{Match: "/.*", RowImage: binlogdatapb.Rule_PK_ONLY},
+		wantRowImage: binlogdatapb.Rule_PK_ONLY,
+		wantColumns:  []string{"id"},
+	}, {
+		name:         "pk only without the primary key",
+		rule:         &binlogdatapb.Rule{Match: "t1", Filter: "select val from t1", RowImage: binlogdatapb.Rule_PK_ONLY},
+		wantRowImage: binlogdatapb.Rule_FULL,
+		wantColumns:  []string{"val"},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			plan, err := buildPlan(vtenv.NewTestEnv(), t1, testLocalVSchema, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{tcase.rule}})
+			require.NoError(t, err)
+			assert.Equal(t, tcase.wantRowImage, plan.RowImage)
+			var columns []string
+			for _, field := range plan.fields() {
+				columns = append(columns, field.Name)
+			}
+			assert.Equal(t, tcase.wantColumns, columns)
+		})
+	}
+}
+
 func TestCompare(t *testing.T) {
 	type testcase struct {
 		opcode                   Opcode
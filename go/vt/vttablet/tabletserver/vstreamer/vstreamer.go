@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -90,12 +91,52 @@ type vstreamer struct {
 	config  *vttablet.VReplicationConfig
 }
 
+var typeCoercionLogger = logutil.NewThrottledLogger("VStreamerTypeCoercion", throttledLoggerInterval)
+
 // streamerPlan extends the original plan to also include
 // the TableMap, which comes from the binlog. It's used
 // to extract values from the ROW events.
 type streamerPlan struct {
 	*Plan
 	TableMap *mysql.TableMap
+
+	// rowImage holds the buffers reused to extract the row images of the
+	// events of the table.
+	rowImage *rowImageBuffer
+}
+
+// rowImageBuffer holds the buffers used to decode a row image, which are
+// reused from one row to the next as long as the number of columns of the
+// row images does not change.
+type rowImageBuffer struct {
+	values   []sqltypes.Value
+	charsets []collations.ID
+	// offsets is the position of the value of each column in the row image,
+	// or -1 if the column is NULL or missing from the image.
+	offsets     []int
+	partialJSON []bool
+	filtered    []sqltypes.Value
+	// filterColumns and projectColumns tell whether each column is used by
+	// the filters and by the column expressions of the plan.
+	filterColumns  []bool
+	projectColumns []bool
+}
+
+// rowBuffer returns the buffers used to decode the row images of n columns.
+func (plan *streamerPlan) rowBuffer(n int) *rowImageBuffer {
+	if plan.rowImage == nil || len(plan.rowImage.values) != n {
+		filterColumns, projectColumns := plan.columnUsage(n)
+		plan.rowImage = &rowImageBuffer{
+			values:         make([]sqltypes.Value, n),
+			charsets:       make([]collations.ID, n),
+			offsets:        make([]int, n),
+			partialJSON:    make([]bool, n),
+			filtered:       make([]sqltypes.Value, len(plan.ColExprs)),
+			filterColumns:  filterColumns,
+			projectColumns: projectColumns,
+		}
+	}
+	return plan.rowImage
 }
 
 // newVStreamer creates a new vstreamer.
@@ -814,7 +855,7 @@ func (vs *vstreamer) buildTablePlan(id uint64, tm *mysql.TableMap) (*binlogdatap
 		vs.plans[id] = nil
 		return nil, nil
 	}
-	if err := addEnumAndSetMappingstoPlan(vs.se.Environment(), plan, cols, tm.Metadata); err != nil {
+	if err := addEnumAndSetMappingstoPlan(vs.se.Environment(), plan, cols, tm); err != nil {
 		return nil, vterrors.Wrapf(err, "failed to build ENUM and SET column integer to string mappings")
 	}
 	vs.plans[id] = &streamerPlan{
@@ -1020,6 +1061,16 @@ func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *strea
 		if err != nil {
 			return nil, vterrors.Wrap(err, "failed to extract row's before values from binlog event and apply filters")
 		}
+		// The values are converted before the AFTER image is extracted, as it
+		// reuses their buffer, unless they are needed to reduce the images.
+		var before *querypb.Row
+		if beforeOK {
+			if plan.RowImage == binlogdatapb.Rule_FULL {
+				before = sqltypes.RowToProto3(beforeValues)
+			} else {
+				beforeValues = slices.Clone(beforeValues)
+			}
+		}
 		// The AFTER image is where we may have partial JSON values, as reflected in the
 		// row's JSONPartialValues bitmap.
 		afterOK, afterValues, partial, err := vs.extractRowAndFilter(plan, row.Data, rows.DataColumns, row.NullColumns, row.JSONPartialValues)
@@ -1029,10 +1080,20 @@ func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *strea
 		if !beforeOK && !afterOK {
 			continue
 		}
-		rowChange := &binlogdatapb.RowChange{}
-		if beforeOK {
-			rowChange.Before = sqltypes.RowToProto3(beforeValues)
+		var imageColumns *binlogdatapb.RowChange_Bitmap
+		if plan.RowImage != binlogdatapb.Rule_FULL {
+			if !beforeOK {
+				beforeValues = nil
+			}
+			if !afterOK {
+				afterValues = nil
+			}
+			imageColumns = plan.reduceRowImages(beforeValues, afterValues, rows.IdentifyColumns, rows.DataColumns)
+			if beforeOK {
+				before = sqltypes.RowToProto3(beforeValues)
+			}
 		}
+		rowChange := &binlogdatapb.RowChange{Before: before}
 		if afterOK {
 			rowChange.After = sqltypes.RowToProto3(afterValues)
 			if ((vs.config.ExperimentalFlags /**/ & /**/ vttablet.VReplicationExperimentalFlagAllowNoBlobBinlogRowImage != 0) && partial) ||
@@ -1050,6 +1111,9 @@ func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *strea
 				}
 			}
 		}
+		if imageColumns != nil {
+			rowChange.DataColumns = imageColumns
+		}
 		rowChanges = append(rowChanges, rowChange)
 	}
 	if len(rowChanges) != 0 {
@@ -1068,6 +1132,80 @@ func (vs *vstreamer) processRowEvent(vevents []*binlogdatapb.VEvent, plan *strea
 	return vevents, nil
 }
 
+// reduceRowImages reduces the BEFORE and AFTER images of a row change, either
+// of which can be nil, to the columns of the row image of the plan. It returns
+// the bitmap of the columns of the stream left in the images, or nil if they
+// were not reduced. The values of the other columns are set to NULL.
+//
+// The primary key columns are always kept, with the values of the BEFORE image
+// if they are missing from the AFTER image of a partial binlog_row_image. The
+// other columns are kept if they are in the images and, with CHANGED_COLUMNS
+// images of updates, if their value changed.
+func (plan *streamerPlan) reduceRowImages(before, after []sqltypes.Value, beforeColumns, afterColumns mysql.Bitmap) *binlogdatapb.RowChange_Bitmap {
+	columns := mysql.NewServerBitmap(len(plan.ColExprs))
+	reduced := false
+	for i := range plan.ColExprs {
+		inBefore := before != nil && plan.imageHasColumn(beforeColumns, i)
+		inAfter := after != nil && plan.imageHasColumn(afterColumns, i)
+		var keep bool
+		switch {
+		case plan.isPKColumn(i):
+			if after != nil && !inAfter && inBefore {
+				after[i] = before[i]
+			}
+			keep = true
+		case after == nil:
+			keep = inBefore
+		case !inAfter:
+			keep = false
+		case inBefore && plan.RowImage == binlogdatapb.Rule_CHANGED_COLUMNS:
+			keep = before[i].IsNull() != after[i].IsNull() || !bytes.Equal(before[i].Raw(), after[i].Raw())
+		default:
+			keep = true
+		}
+		if keep {
+			columns.Set(i, true)
+			continue
+		}
+		reduced = true
+		if before != nil {
+			before[i] = sqltypes.NULL
+		}
+		if after != nil {
+			after[i] = sqltypes.NULL
+		}
+	}
+	if !reduced {
+		return nil
+	}
+	return &binlogdatapb.RowChange_Bitmap{
+		Count: int64(columns.Count()),
+		Cols:  columns.Bits(),
+	}
+}
+
+// imageHasColumn returns true if the columns of a row image have the value of
+// the column expression i of the plan.
+func (plan *streamerPlan) imageHasColumn(imageColumns mysql.Bitmap, i int) bool {
+	hasColumn := func(colNum int) bool {
+		return colNum < imageColumns.Count() && imageColumns.Bit(colNum)
+	}
+	colExpr := plan.ColExprs[i]
+	switch {
+	case colExpr.ColNum == -1:
+		return true
+	case colExpr.Vindex != nil:
+		for _, colNum := range colExpr.VindexColumns {
+			if !hasColumn(colNum) {
+				return false
+			}
+		}
+		return true
+	default:
+		return hasColumn(colExpr.ColNum)
+	}
+}
+
 func (vs *vstreamer) rebuildPlans() error {
 	for id, plan := range vs.plans {
 		if plan == nil {
@@ -1094,19 +1232,31 @@ func (vs *vstreamer) rebuildPlans() error {
 //   - true, if row needs to be skipped because of workflow filter rules
 //   - data values, array of one value per column
 //   - true, if the row image was partial (i.e. binlog_row_image=noblob and dml doesn't update one or more blob/text columns)
+//
+// Only the columns used by the filters are decoded before the row is filtered,
+// and only the columns used by the column expressions are decoded after, the
+// others being skipped. The returned values are held in buffers of the plan
+// that are reused, so they are only valid until the next call for the plan.
 func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataColumns, nullColumns mysql.Bitmap, jsonPartialValues mysql.Bitmap) (bool, []sqltypes.Value, bool, error) {
 	if len(data) == 0 {
 		return false, nil, false, nil
 	}
-	values := make([]sqltypes.Value, dataColumns.Count())
-	charsets := make([]collations.ID, len(values))
+	buf := plan.rowBuffer(dataColumns.Count())
+	values, charsets := buf.values, buf.charsets
+	clear(values)
+	clear(charsets)
 	valueIndex := 0
 	jsonIndex := 0
 	pos := 0
 	partial := false
 	for colNum := 0; colNum < dataColumns.Count(); colNum++ {
+		buf.offsets[colNum] = -1
+		buf.partialJSON[colNum] = false
 		if !dataColumns.Bit(colNum) {
-			if vs.config.ExperimentalFlags /**/ & /**/ vttablet.VReplicationExperimentalFlagAllowNoBlobBinlogRowImage == 0 {
+			// The partial images of a binlog_row_image other than FULL are
+			// supported if the images sent for the table are not full either.
+			if vs.config.ExperimentalFlags /**/ & /**/ vttablet.VReplicationExperimentalFlagAllowNoBlobBinlogRowImage == 0 &&
+				plan.RowImage == binlogdatapb.Rule_FULL {
 				return false, nil, false, fmt.Errorf("partial row image encountered: ensure binlog_row_image is set to 'full'")
 			} else {
 				partial = true
@@ -1120,12 +1270,18 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 			}
 			continue
 		}
-		partialJSON := false
 		if jsonPartialValues.Count() > 0 && plan.Table.Fields[colNum].Type == querypb.Type_JSON {
-			partialJSON = jsonPartialValues.Bit(jsonIndex)
+			buf.partialJSON[colNum] = jsonPartialValues.Bit(jsonIndex)
 			jsonIndex++
 		}
-		value, l, err := mysqlbinlog.CellValue(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], plan.Table.Fields[colNum], partialJSON)
+		buf.offsets[colNum] = pos
+		var l int
+		var err error
+		if buf.filterColumns[colNum] {
+			l, err = vs.decodeValue(plan, buf, data, colNum)
+		} else {
+			l, err = mysqlbinlog.CellLength(data, pos, plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum])
+		}
 		if err != nil {
 			log.Errorf("extractRowAndFilter: %s, table: %s, colNum: %d, fields: %+v, current values: %+v",
 				err, plan.Table.Name, colNum, plan.Table.Fields, values)
@@ -1133,42 +1289,86 @@ func (vs *vstreamer) extractRowAndFilter(plan *streamerPlan, data []byte, dataCo
 				plan.Table.Fields[colNum].Name)
 		}
 		pos += l
+		valueIndex++
+	}
+	ok, err := plan.matches(values, charsets)
+	if !ok || err != nil {
+		return false, nil, partial, err
+	}
+	for colNum, offset := range buf.offsets {
+		if offset < 0 || buf.filterColumns[colNum] || !buf.projectColumns[colNum] {
+			continue
+		}
+		if _, err := vs.decodeValue(plan, buf, data, colNum); err != nil {
+			return false, nil, false, vterrors.Wrapf(err, "failed to extract row's value for column %s from binlog event",
+				plan.Table.Fields[colNum].Name)
+		}
+	}
+	if err := plan.project(values, buf.filtered); err != nil {
+		return false, nil, partial, err
+	}
+	return true, buf.filtered, partial, nil
+}
 
-		if !value.IsNull() { // ENUMs and SETs require no special handling if they are NULL
-			// If the column is a CHAR based type with a binary collation (e.g. utf8mb4_bin) then the
-			// actual column type is included in the second byte of the event metadata while the
-			// event's type for the field is BINARY. This is true for ENUM and SET types.
-			var mysqlType uint16
-			if sqltypes.IsQuoted(plan.Table.Fields[colNum].Type) {
-				mysqlType = plan.TableMap.Metadata[colNum] >> 8
-			}
-			// Convert the integer values in the binlog event for any SET and ENUM fields into their
-			// string representations.
-			if plan.Table.Fields[colNum].Type == querypb.Type_ENUM || mysqlType == mysqlbinlog.TypeEnum {
-				value, err = buildEnumStringValue(vs.se.Environment(), plan, colNum, value)
-				if err != nil {
-					return false, nil, false, vterrors.Wrapf(err, "failed to perform ENUM column integer to string value mapping")
-				}
+// decodeValue decodes the value of a column of a row image, at the offset
+// found by extractRowAndFilter, into the buffer. It returns the length of the
+// value in the row image. The values of strings and blobs are not copied and
+// reference the data of the event.
+func (vs *vstreamer) decodeValue(plan *streamerPlan, buf *rowImageBuffer, data []byte, colNum int) (int, error) {
+	field := plan.Table.Fields[colNum]
+	value, l, err := mysqlbinlog.CellValue(data, buf.offsets[colNum], plan.TableMap.Types[colNum], plan.TableMap.Metadata[colNum], field, buf.partialJSON[colNum])
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsNull() { // ENUMs and SETs require no special handling if they are NULL
+		// If the column is a CHAR based type with a binary collation (e.g. utf8mb4_bin) then the
+		// actual column type is included in the second byte of the event metadata while the
+		// event's type for the field is BINARY. This is true for ENUM and SET types.
+		var mysqlType uint16
+		if sqltypes.IsQuoted(field.Type) {
+			mysqlType = plan.TableMap.Metadata[colNum] >> 8
+		}
+		// Convert the integer values in the binlog event for any SET and ENUM fields into their
+		// string representations.
+		if field.Type == querypb.Type_ENUM || mysqlType == mysqlbinlog.TypeEnum {
+			value, err = buildEnumStringValue(vs.se.Environment(), plan, colNum, value, vs.fidelityLoss("Enum"))
+			if err != nil {
+				return 0, vterrors.Wrapf(err, "failed to perform ENUM column integer to string value mapping")
 			}
-			if plan.Table.Fields[colNum].Type == querypb.Type_SET || mysqlType == mysqlbinlog.TypeSet {
-				value, err = buildSetStringValue(vs.se.Environment(), plan, colNum, value)
-				if err != nil {
-					return false, nil, false, vterrors.Wrapf(err, "failed to perform SET column integer to string value mapping")
-				}
+		}
+		if field.Type == querypb.Type_SET || mysqlType == mysqlbinlog.TypeSet {
+			value, err = buildSetStringValue(vs.se.Environment(), plan, colNum, value, vs.fidelityLoss("Set"))
+			if err != nil {
+				return 0, vterrors.Wrapf(err, "failed to perform SET column integer to string value mapping")
 			}
 		}
+	}
+	buf.charsets[colNum] = collations.ID(field.Charset)
+	buf.values[colNum] = value
+	return l, nil
+}
 
-		charsets[colNum] = collations.ID(plan.Table.Fields[colNum].Charset)
-		values[colNum] = value
-		valueIndex++
+// fidelityLoss returns the handler of the values of row events of the given
+// type that cannot be decoded without losing information. The handler returns
+// the error failing the stream in the strict type fidelity mode. In the
+// permissive mode, it logs and counts the loss, and returns nil so that the
+// value is coerced.
+func (vs *vstreamer) fidelityLoss(typ string) func(error) error {
+	return func(err error) error {
+		if vs.config.TypeFidelityMode != vttablet.VReplicationTypeFidelityPermissive {
+			return vterrors.Wrapf(err, "the value cannot be streamed without losing information, use the %s type fidelity mode to coerce it", vttablet.VReplicationTypeFidelityPermissive)
+		}
+		vs.vse.typeCoercions.Add(typ, 1)
+		typeCoercionLogger.Warningf("Coercing a value in the %s type fidelity mode: %v", vttablet.VReplicationTypeFidelityPermissive, err)
+		return nil
 	}
-	filtered := make([]sqltypes.Value, len(plan.ColExprs))
-	ok, err := plan.filter(values, filtered, charsets)
-	return ok, filtered, partial, err
 }
 
 // addEnumAndSetMappingstoPlan sets up any necessary ENUM and SET integer to string mappings.
-func addEnumAndSetMappingstoPlan(env *vtenv.Environment, plan *Plan, cols []*querypb.Field, metadata []uint16) error {
+// The string values of the columns in the table map, when the binlog_row_metadata is FULL,
+// take precedence over the ones in the schema, since they are the values of the columns
+// when the event was written, even if they were changed since.
+func addEnumAndSetMappingstoPlan(env *vtenv.Environment, plan *Plan, cols []*querypb.Field, tm *mysql.TableMap) error {
 	plan.EnumSetValuesMap = make(map[int]map[int]string)
 	for i, col := range cols {
 		// If the column is a CHAR based type with a binary collation (e.g. utf8mb4_bin) then
@@ -1176,10 +1376,18 @@ func addEnumAndSetMappingstoPlan(env *vtenv.Environment, plan *Plan, cols []*que
 		// the event's type for the field is BINARY. This is true for ENUM and SET types.
 		var mysqlType uint16
 		if sqltypes.IsQuoted(col.Type) {
-			mysqlType = metadata[i] >> 8
+			mysqlType = tm.Metadata[i] >> 8
 		}
 		if col.Type == querypb.Type_ENUM || mysqlType == mysqlbinlog.TypeEnum ||
 			col.Type == querypb.Type_SET || mysqlType == mysqlbinlog.TypeSet {
+			if values, ok := tm.EnumSetValues[i]; ok {
+				// SET and ENUM values are 1 indexed.
+				plan.EnumSetValuesMap[i] = make(map[int]string, len(values))
+				for j, value := range values {
+					plan.EnumSetValuesMap[i][j+1] = value
+				}
+				continue
+			}
 			// Strip the enum() / set() parts out.
 			begin := strings.Index(col.ColumnType, "(")
 			end := strings.LastIndex(col.ColumnType, ")")
@@ -1198,14 +1406,16 @@ func addEnumAndSetMappingstoPlan(env *vtenv.Environment, plan *Plan, cols []*que
 }
 
 // buildEnumStringValue takes the integer value of an ENUM column and returns the string value.
-func buildEnumStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int, value sqltypes.Value) (sqltypes.Value, error) {
+// An integer value without string value is handled by fidelityLoss, and coerced to the empty
+// string, as MySQL does for invalid ENUM values, if it returns nil.
+func buildEnumStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int, value sqltypes.Value, fidelityLoss func(error) error) (sqltypes.Value, error) {
 	if value.IsNull() { // No work is needed
 		return value, nil
 	}
 	// Add the mappings just-in-time in case we haven't properly received and processed a
 	// table map event to initialize it.
 	if plan.EnumSetValuesMap == nil {
-		if err := addEnumAndSetMappingstoPlan(env, plan.Plan, plan.Table.Fields, plan.TableMap.Metadata); err != nil {
+		if err := addEnumAndSetMappingstoPlan(env, plan.Plan, plan.Table.Fields, plan.TableMap); err != nil {
 			return sqltypes.Value{}, vterrors.Wrap(err, "failed to build ENUM column integer to string mappings")
 		}
 	}
@@ -1224,23 +1434,30 @@ func buildEnumStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int
 		var ok bool
 		strVal, ok = plan.EnumSetValuesMap[colNum][int(iv)]
 		if !ok {
-			// The integer value was NOT 0 yet we found no mapping. This should never happen.
-			return sqltypes.Value{}, fmt.Errorf("no string value found for ENUM column %s in table %s -- with available values being: %v -- using the found integer value: %d",
+			// The integer value was NOT 0 yet we found no mapping. This only happens when the
+			// values of the column were changed since the event was written, and the event does
+			// not include them (binlog_row_metadata is not FULL).
+			err := fmt.Errorf("no string value found for ENUM column %s in table %s -- with available values being: %v -- using the found integer value: %d",
 				plan.Table.Fields[colNum].Name, plan.Table.Name, plan.EnumSetValuesMap[colNum], iv)
+			if err := fidelityLoss(err); err != nil {
+				return sqltypes.Value{}, err
+			}
 		}
 	}
 	return sqltypes.MakeTrusted(plan.Table.Fields[colNum].Type, []byte(strVal)), nil
 }
 
 // buildSetStringValue takes the integer value of a SET column and returns the string value.
-func buildSetStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int, value sqltypes.Value) (sqltypes.Value, error) {
+// A bit of the integer value without string value is handled by fidelityLoss, and dropped
+// from the string value if it returns nil.
+func buildSetStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int, value sqltypes.Value, fidelityLoss func(error) error) (sqltypes.Value, error) {
 	if value.IsNull() { // No work is needed
 		return value, nil
 	}
 	// Add the mappings just-in-time in case we haven't properly received and processed a
 	// table map event to initialize it.
 	if plan.EnumSetValuesMap == nil {
-		if err := addEnumAndSetMappingstoPlan(env, plan.Plan, plan.Table.Fields, plan.TableMap.Metadata); err != nil {
+		if err := addEnumAndSetMappingstoPlan(env, plan.Plan, plan.Table.Fields, plan.TableMap); err != nil {
 			return sqltypes.Value{}, vterrors.Wrap(err, "failed to build SET column integer to string mappings")
 		}
 	}
@@ -1253,23 +1470,27 @@ func buildSetStringValue(env *vtenv.Environment, plan *streamerPlan, colNum int,
 		return value, vterrors.Wrapf(err, "no valid integer value found for column %s in table %s, bytes: %b",
 			plan.Table.Fields[colNum].Name, plan.Table.Name, iv)
 	}
-	idx := 1
-	// See what bits are set in the bitmap using bitmasks.
-	for b := uint64(1); b < 1<<63; b <<= 1 {
-		if iv&b > 0 { // This bit is set and the SET's string value needs to be provided.
+	// See what bits are set in the bitmap using bitmasks, up to the 64th one.
+	for idx := 1; idx <= 64; idx++ {
+		if iv&(1<<(idx-1)) > 0 { // This bit is set and the SET's string value needs to be provided.
 			strVal, ok := plan.EnumSetValuesMap[colNum][idx]
 			// When you insert values not found in the SET (which requires disabling STRICT mode) then
-			// they are effectively pruned and ignored (not actually saved). So this should never happen.
+			// they are effectively pruned and ignored (not actually saved). So this only happens when
+			// the values of the column were changed since the event was written, and the event does
+			// not include them (binlog_row_metadata is not FULL).
 			if !ok {
-				return sqltypes.Value{}, fmt.Errorf("no valid integer value found for SET column %s in table %s, bytes: %b",
-					plan.Table.Fields[colNum].Name, plan.Table.Name, iv)
+				err := fmt.Errorf("no string value found for SET column %s in table %s -- with available values being: %v -- using the found integer value: %d",
+					plan.Table.Fields[colNum].Name, plan.Table.Name, plan.EnumSetValuesMap[colNum], iv)
+				if err := fidelityLoss(err); err != nil {
+					return sqltypes.Value{}, err
+				}
+				continue
 			}
 			if val.Len() > 0 {
 				val.WriteByte(',')
 			}
 			val.WriteString(strVal)
 		}
-		idx++
 	}
 	return sqltypes.MakeTrusted(plan.Table.Fields[colNum].Type, val.Bytes()), nil
 }
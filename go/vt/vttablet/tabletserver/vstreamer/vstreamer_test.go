@@ -18,6 +18,7 @@ package vstreamer
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"slices"
@@ -34,10 +35,13 @@ import (
 
 	"vitess.io/vitess/go/bytes2"
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/binlog"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer/testenv"
 
@@ -193,6 +197,215 @@ func TestSetAndEnum(t *testing.T) {
 	ts.Run()
 }
 
+// TestEnumSetStringValues tests the mapping of the integer values of the ENUM and SET
+// columns to their string values, in the strict and permissive type fidelity modes.
+func TestEnumSetStringValues(t *testing.T) {
+	strict := func(err error) error { return err }
+	permissive := func(error) error { return nil }
+	setValues := make([]string, 64)
+	for i := range setValues {
+		setValues[i] = fmt.Sprintf("v%d", i+1)
+	}
+	newPlan := func(enumSetValues map[int][]string) *streamerPlan {
+		return &streamerPlan{
+			Plan: &Plan{
+				Table: &Table{
+					Name: "t1",
+					Fields: []*querypb.Field{
+						{Name: "id", Type: querypb.Type_INT64},
+						{Name: "size", Type: querypb.Type_ENUM, ColumnType: "enum('S','M')"},
+						{Name: "color", Type: querypb.Type_SET, ColumnType: "set('red','green')"},
+					},
+				},
+			},
+			TableMap: &mysql.TableMap{
+				Types:         []byte{binlog.TypeLongLong, binlog.TypeString, binlog.TypeString},
+				Metadata:      []uint16{0, uint16(binlog.TypeEnum)<<8 | 1, uint16(binlog.TypeSet)<<8 | 8},
+				EnumSetValues: enumSetValues,
+			},
+		}
+	}
+	venv := vtenv.NewTestEnv()
+
+	plan := newPlan(nil)
+	value, err := buildEnumStringValue(venv, plan, 1, sqltypes.NewUint64(2), strict)
+	require.NoError(t, err)
+	assert.Equal(t, "M", value.ToString())
+	value, err = buildSetStringValue(venv, plan, 2, sqltypes.NewUint64(3), strict)
+	require.NoError(t, err)
+	assert.Equal(t, "red,green", value.ToString())
+
+	// The values missing from the schema fail in the strict mode, and are coerced in
+	// the permissive mode.
+	_, err = buildEnumStringValue(venv, plan, 1, sqltypes.NewUint64(3), strict)
+	assert.ErrorContains(t, err, "no string value found for ENUM column size in table t1")
+	value, err = buildEnumStringValue(venv, plan, 1, sqltypes.NewUint64(3), permissive)
+	require.NoError(t, err)
+	assert.Equal(t, "", value.ToString())
+	_, err = buildSetStringValue(venv, plan, 2, sqltypes.NewUint64(5), strict)
+	assert.ErrorContains(t, err, "no string value found for SET column color in table t1")
+	value, err = buildSetStringValue(venv, plan, 2, sqltypes.NewUint64(5), permissive)
+	require.NoError(t, err)
+	assert.Equal(t, "red", value.ToString())
+
+	// The values of the table map take precedence over the ones of the schema, up to
+	// the 64th value of a SET.
+	plan = newPlan(map[int][]string{1: {"S", "M", "L"}, 2: setValues})
+	value, err = buildEnumStringValue(venv, plan, 1, sqltypes.NewUint64(3), strict)
+	require.NoError(t, err)
+	assert.Equal(t, "L", value.ToString())
+	value, err = buildSetStringValue(venv, plan, 2, sqltypes.NewUint64(1<<63|1), strict)
+	require.NoError(t, err)
+	assert.Equal(t, "v1,v64", value.ToString())
+}
+
+// newRowDecodeTestPlan returns the plan of a table with a BIGINT, a VARCHAR and
+// a BLOB column, streaming the first two columns of the rows with an id > 10.
+func newRowDecodeTestPlan() *streamerPlan {
+	fields := []*querypb.Field{
+		{Name: "id", Type: querypb.Type_INT64},
+		{Name: "name", Type: querypb.Type_VARCHAR},
+		{Name: "payload", Type: querypb.Type_BLOB},
+	}
+	return &streamerPlan{
+		Plan: &Plan{
+			Table:    &Table{Name: "t1", Fields: fields},
+			ColExprs: []ColExpr{{ColNum: 0, Field: fields[0]}, {ColNum: 1, Field: fields[1]}},
+			Filters:  []Filter{{Opcode: GreaterThan, ColNum: 0, Value: sqltypes.NewInt64(10)}},
+			env:      vtenv.NewTestEnv(),
+		},
+		TableMap: &mysql.TableMap{
+			Types:    []byte{binlog.TypeLongLong, binlog.TypeVarchar, binlog.TypeBlob},
+			Metadata: []uint16{0, 255, 2},
+		},
+	}
+}
+
+// encodeRowDecodeTestRow returns the row image of a row of the table of
+// newRowDecodeTestPlan.
+func encodeRowDecodeTestRow(id uint64, name string, payload []byte) []byte {
+	data := binary.LittleEndian.AppendUint64(nil, id)
+	data = append(data, byte(len(name)))
+	data = append(data, name...)
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(payload)))
+	return append(data, payload...)
+}
+
+// TestExtractRowAndFilter tests that only the columns used by the filters are
+// decoded for the rows that are filtered out, and that only the columns used
+// by the column expressions are decoded for the others.
+func TestExtractRowAndFilter(t *testing.T) {
+	vs := &vstreamer{config: vttablet.InitVReplicationConfigDefaults()}
+	plan := newRowDecodeTestPlan()
+	allColumns := mysql.NewServerBitmap(3)
+	for i := range 3 {
+		allColumns.Set(i, true)
+	}
+	noNulls := mysql.NewServerBitmap(3)
+
+	ok, _, _, err := vs.extractRowAndFilter(plan, encodeRowDecodeTestRow(5, "abc", []byte("payload")), allColumns, noNulls, mysql.Bitmap{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, sqltypes.NewInt64(5), plan.rowImage.values[0])
+	assert.True(t, plan.rowImage.values[1].IsNull())
+	assert.True(t, plan.rowImage.values[2].IsNull())
+
+	ok, values, partial, err := vs.extractRowAndFilter(plan, encodeRowDecodeTestRow(20, "abc", []byte("payload")), allColumns, noNulls, mysql.Bitmap{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, partial)
+	assert.Equal(t, []sqltypes.Value{sqltypes.NewInt64(20), sqltypes.NewVarChar("abc")}, values)
+	assert.True(t, plan.rowImage.values[2].IsNull())
+
+	// The buffers are reused by the next row, whose NULL value is not
+	// decoded.
+	nullName := mysql.NewServerBitmap(3)
+	nullName.Set(1, true)
+	data := binary.LittleEndian.AppendUint64(nil, 30)
+	data = binary.LittleEndian.AppendUint16(data, 0)
+	ok, next, _, err := vs.extractRowAndFilter(plan, data, allColumns, nullName, mysql.Bitmap{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []sqltypes.Value{sqltypes.NewInt64(30), sqltypes.NULL}, next)
+	assert.Same(t, &values[0], &next[0])
+}
+
+// TestReduceRowImages tests the reduction of the images of the row changes to
+// the CHANGED_COLUMNS images, with full and partial binlog row images.
+func TestReduceRowImages(t *testing.T) {
+	fields := []*querypb.Field{
+		{Name: "id", Type: querypb.Type_INT64, Flags: uint32(querypb.MySqlFlag_PRI_KEY_FLAG)},
+		{Name: "name", Type: querypb.Type_VARCHAR},
+		{Name: "payload", Type: querypb.Type_BLOB},
+	}
+	plan := &streamerPlan{Plan: &Plan{
+		Table:    &Table{Name: "t1", Fields: fields},
+		ColExprs: []ColExpr{{ColNum: 0, Field: fields[0]}, {ColNum: 1, Field: fields[1]}, {ColNum: 2, Field: fields[2]}},
+		RowImage: binlogdatapb.Rule_CHANGED_COLUMNS,
+	}}
+	bitmap := func(cols ...int) mysql.Bitmap {
+		b := mysql.NewServerBitmap(3)
+		for _, col := range cols {
+			b.Set(col, true)
+		}
+		return b
+	}
+	row := func(values ...sqltypes.Value) []sqltypes.Value {
+		return values
+	}
+	id, name, payload := sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.NewVarBinary("p")
+
+	// Only the primary key and the changed columns of an update are kept.
+	before, after := row(id, name, payload), row(id, sqltypes.NewVarChar("b"), payload)
+	columns := plan.reduceRowImages(before, after, bitmap(0, 1, 2), bitmap(0, 1, 2))
+	assert.Equal(t, &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0b011}}, columns)
+	assert.Equal(t, row(id, name, sqltypes.NULL), before)
+	assert.Equal(t, row(id, sqltypes.NewVarChar("b"), sqltypes.NULL), after)
+
+	// With a minimal binlog_row_image, the columns missing from the AFTER image
+	// are unchanged, and the primary key is the one of the BEFORE image.
+	before, after = row(id, sqltypes.NULL, sqltypes.NULL), row(sqltypes.NULL, sqltypes.NewVarChar("b"), sqltypes.NULL)
+	columns = plan.reduceRowImages(before, after, bitmap(0), bitmap(1))
+	assert.Equal(t, &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0b011}}, columns)
+	assert.Equal(t, row(id, sqltypes.NewVarChar("b"), sqltypes.NULL), after)
+
+	// The full images of inserts and deletes are not reduced.
+	assert.Nil(t, plan.reduceRowImages(nil, row(id, name, payload), mysql.Bitmap{}, bitmap(0, 1, 2)))
+	assert.Nil(t, plan.reduceRowImages(row(id, name, payload), nil, bitmap(0, 1, 2), mysql.Bitmap{}))
+	columns = plan.reduceRowImages(row(id, sqltypes.NULL, sqltypes.NULL), nil, bitmap(0), mysql.Bitmap{})
+	assert.Equal(t, &binlogdatapb.RowChange_Bitmap{Count: 3, Cols: []byte{0b001}}, columns)
+}
+
+// BenchmarkExtractRowAndFilter measures the decoding of the row images of the
+// rows streamed and filtered out by a plan.
+func BenchmarkExtractRowAndFilter(b *testing.B) {
+	vs := &vstreamer{config: vttablet.InitVReplicationConfigDefaults()}
+	allColumns := mysql.NewServerBitmap(3)
+	for i := range 3 {
+		allColumns.Set(i, true)
+	}
+	noNulls := mysql.NewServerBitmap(3)
+	payload := []byte(strings.Repeat("x", 4096))
+	for _, bc := range []struct {
+		name string
+		id   uint64
+	}{
+		{name: "streamed", id: 20},
+		{name: "filtered out", id: 5},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			plan := newRowDecodeTestPlan()
+			data := encodeRowDecodeTestRow(bc.id, "abc", payload)
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, _, _, err := vs.extractRowAndFilter(plan, data, allColumns, noNulls, mysql.Bitmap{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // TestCellValuePadding tests that the events are correctly padded for binary columns.
 func TestCellValuePadding(t *testing.T) {
 	ts := &TestSpec{
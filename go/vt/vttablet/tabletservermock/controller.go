@@ -150,6 +150,15 @@ func (tqsc *Controller) SetServingType(tabletType topodatapb.TabletType, ptsTime
 	return tqsc.SetServingTypeError
 }
 
+// ReconnectMySQL is part of the tabletserver.Controller interface
+func (tqsc *Controller) ReconnectMySQL(reason string) error {
+	tqsc.mu.Lock()
+	defer tqsc.mu.Unlock()
+
+	tqsc.MethodCalled["ReconnectMySQL"] = true
+	return nil
+}
+
 // IsServing is part of the tabletserver.Controller interface
 func (tqsc *Controller) IsServing() bool {
 	tqsc.mu.Lock()
@@ -158,7 +167,7 @@ func (tqsc *Controller) IsServing() bool {
 	return tqsc.queryServiceEnabled
 }
 
-// CurrentTarget returns the current target.
+// CurrentTarget is part of the tabletserver.Controller interface.
 func (tqsc *Controller) CurrentTarget() *querypb.Target {
 	tqsc.mu.Lock()
 	defer tqsc.mu.Unlock()
@@ -78,6 +78,10 @@ type TabletManagerClient interface {
 	// An empty/nil variable name parameter slice means you want all of them.
 	GetGlobalStatusVars(ctx context.Context, tablet *topodatapb.Tablet, variables []string) (map[string]string, error)
 
+	// GetAuditLog returns the audit log of the tablet manager RPCs executed
+	// by the tablet.
+	GetAuditLog(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error)
+
 	//
 	// Various read-write methods
 	//
@@ -287,6 +291,10 @@ type TabletManagerClient interface {
 	// RestoreFromBackup deletes local data and restores database from backup
 	RestoreFromBackup(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromBackupRequest) (logutil.EventStream, error)
 
+	// RestoreFromClone deletes local data and replaces it with a copy of the
+	// data of a donor tablet, made with the MySQL clone plugin.
+	RestoreFromClone(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error)
+
 	// Throttler
 	CheckThrottler(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error)
 	GetThrottlerStatus(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.GetThrottlerStatusRequest) (*tabletmanagerdatapb.GetThrottlerStatusResponse, error)
@@ -430,6 +430,37 @@ func tmRPCTestGetGlobalStatusVarsPanic(ctx context.Context, t *testing.T, client
 	expectHandleRPCPanic(t, "GetGlobalStatusVars", false /*verbose*/, err)
 }
 
+var testGetAuditLogRequest = &tabletmanagerdatapb.GetAuditLogRequest{
+	Rpc:   "ChangeType",
+	Limit: 10,
+}
+
+var testGetAuditLogResponse = &tabletmanagerdatapb.GetAuditLogResponse{
+	Entries: []*tabletmanagerdatapb.AuditLogEntry{{
+		Rpc:       "ChangeType",
+		Caller:    "127.0.0.1:52000:/tabletmanagerservice.TabletManager/ChangeType(gRPC)",
+		Arguments: "tablet_type:RDONLY",
+	}},
+}
+
+func (fra *fakeRPCTM) GetAuditLog(ctx context.Context, req *tabletmanagerdatapb.GetAuditLogRequest) (*tabletmanagerdatapb.GetAuditLogResponse, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "GetAuditLog request", req, testGetAuditLogRequest)
+	return testGetAuditLogResponse, nil
+}
+
+func tmRPCTestGetAuditLog(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	resp, err := client.GetAuditLog(ctx, tablet, testGetAuditLogRequest)
+	compareError(t, "GetAuditLog", err, resp, testGetAuditLogResponse)
+}
+
+func tmRPCTestGetAuditLogPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetAuditLog(ctx, tablet, testGetAuditLogRequest)
+	expectHandleRPCPanic(t, "GetAuditLog", false /*verbose*/, err)
+}
+
 func tmRPCTestGetUnresolvedTransactions(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
 	_, err := client.GetUnresolvedTransactions(ctx, tablet, 0)
 	require.NoError(t, err)
@@ -1474,6 +1505,22 @@ func (fra *fakeRPCTM) RestoreFromBackup(ctx context.Context, logger logutil.Logg
 	return nil
 }
 
+var testRestoreFromCloneRequest = &tabletmanagerdatapb.RestoreFromCloneRequest{
+	DonorAlias: &topodatapb.TabletAlias{
+		Cell: "donor",
+		Uid:  3,
+	},
+	MaxBandwidth: 100,
+}
+
+func (fra *fakeRPCTM) RestoreFromClone(ctx context.Context, request *tabletmanagerdatapb.RestoreFromCloneRequest) (*tabletmanagerdatapb.RestoreFromCloneResponse, error) {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	compare(fra.t, "RestoreFromClone request", request, testRestoreFromCloneRequest)
+	return &tabletmanagerdatapb.RestoreFromCloneResponse{Position: testReplicationPosition}, nil
+}
+
 func (fra *fakeRPCTM) CheckThrottler(ctx context.Context, req *tabletmanagerdatapb.CheckThrottlerRequest) (*tabletmanagerdatapb.CheckThrottlerResponse, error) {
 	if fra.panics {
 		panic(fmt.Errorf("test-triggered panic"))
@@ -1513,6 +1560,16 @@ func tmRPCTestRestoreFromBackupPanic(ctx context.Context, t *testing.T, client t
 	expectHandleRPCPanic(t, "RestoreFromBackup", true /*verbose*/, err)
 }
 
+func tmRPCTestRestoreFromClone(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	resp, err := client.RestoreFromClone(ctx, tablet, testRestoreFromCloneRequest)
+	compareError(t, "RestoreFromClone", err, resp.GetPosition(), testReplicationPosition)
+}
+
+func tmRPCTestRestoreFromClonePanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.RestoreFromClone(ctx, tablet, testRestoreFromCloneRequest)
+	expectHandleRPCPanic(t, "RestoreFromClone", true /*verbose*/, err)
+}
+
 func tmRPCTestCheckThrottler(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.CheckThrottlerRequest) {
 	_, err := client.CheckThrottler(ctx, tablet, req)
 	expectHandleRPCPanic(t, "CheckThrottler", false /*verbose*/, err)
@@ -1555,6 +1612,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestGetSchema(ctx, t, client, tablet)
 	tmRPCTestGetPermissions(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVars(ctx, t, client, tablet)
+	tmRPCTestGetAuditLog(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactions(ctx, t, client, tablet)
 	tmRPCTestReadTransaction(ctx, t, client, tablet)
 	tmRPCTestGetTransactionInfo(ctx, t, client, tablet)
@@ -1606,6 +1664,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	// Backup / restore related methods
 	tmRPCTestBackup(ctx, t, client, tablet)
 	tmRPCTestRestoreFromBackup(ctx, t, client, tablet, restoreFromBackupRequest)
+	tmRPCTestRestoreFromClone(ctx, t, client, tablet)
 
 	// Throttler related methods
 	tmRPCTestCheckThrottler(ctx, t, client, tablet, checkThrottlerRequest)
@@ -1620,6 +1679,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestGetSchemaPanic(ctx, t, client, tablet)
 	tmRPCTestGetPermissionsPanic(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVarsPanic(ctx, t, client, tablet)
+	tmRPCTestGetAuditLogPanic(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactionsPanic(ctx, t, client, tablet)
 	tmRPCTestReadTransactionPanic(ctx, t, client, tablet)
 	tmRPCTestGetTransactionInfoPanic(ctx, t, client, tablet)
@@ -1667,6 +1727,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	// Backup / restore related methods
 	tmRPCTestBackupPanic(ctx, t, client, tablet)
 	tmRPCTestRestoreFromBackupPanic(ctx, t, client, tablet, restoreFromBackupRequest)
+	tmRPCTestRestoreFromClonePanic(ctx, t, client, tablet)
 
 	client.Close()
 }
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
@@ -159,6 +160,14 @@ type Config struct {
 
 	// Set the planner to fail on scatter queries
 	NoScatter bool
+
+	// CellLatencies is the latency added to every request from vtgate to the
+	// tablets of each cell, to simulate remote cells.
+	CellLatencies map[string]time.Duration
+
+	// EnableFaultInjectionAPI enables the vtcombo HTTP API which is used by
+	// KillPrimary, PartitionCell, StallReplication and friends.
+	EnableFaultInjectionAPI bool
 }
 
 // InitSchemas is a shortcut for tests that just want to setup a single
@@ -795,6 +804,67 @@ func (db *LocalCluster) VTProcess() *VtProcess {
 	return db.vt
 }
 
+// KillPrimary makes the primary of the shard unreachable and promotes one of
+// its replicas. The killed primary can be restarted with RestoreTablet.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) KillPrimary(keyspace, shard string) error {
+	return db.vt.injectFault("kill_primary", url.Values{"keyspace": {keyspace}, "shard": {shard}})
+}
+
+// RestoreTablet restarts a tablet killed by KillPrimary. If the shard has a
+// new primary, the tablet rejoins the shard as a replica.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) RestoreTablet(tabletAlias string) error {
+	return db.vt.injectFault("restore_tablet", url.Values{"tablet": {tabletAlias}})
+}
+
+// PartitionCell makes all tablets of the cell unreachable from vtgate.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) PartitionCell(cell string) error {
+	return db.vt.injectFault("partition_cell", url.Values{"cell": {cell}})
+}
+
+// HealCell heals a cell partitioned by PartitionCell.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) HealCell(cell string) error {
+	return db.vt.injectFault("heal_cell", url.Values{"cell": {cell}})
+}
+
+// SetCellLatency changes the latency of the requests to the tablets of the
+// cell. A zero latency removes it.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) SetCellLatency(cell string, latency time.Duration) error {
+	return db.vt.injectFault("cell_latency", url.Values{"cell": {cell}, "latency": {latency.String()}})
+}
+
+// StallReplication makes the replicas of the shard report a replication lag
+// which starts at lag and grows until ResumeReplication is called.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) StallReplication(keyspace, shard string, lag time.Duration) error {
+	return db.vt.injectFault("stall_replication", url.Values{"keyspace": {keyspace}, "shard": {shard}, "lag": {lag.String()}})
+}
+
+// ResumeReplication resumes the replication stalled by StallReplication.
+// Requires EnableFaultInjectionAPI.
+func (db *LocalCluster) ResumeReplication(keyspace, shard string) error {
+	return db.vt.injectFault("resume_replication", url.Values{"keyspace": {keyspace}, "shard": {shard}})
+}
+
+// injectFault calls the fault injection API of vtcombo.
+func (vt *VtProcess) injectFault(action string, params url.Values) error {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.PostForm(fmt.Sprintf("http://%s:%d/debug/vttest/faults/%s", vt.BindAddress, vt.Port, action), params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s failed: %s", action, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
 // ReadVSchema reads the vschema from the vtgate endpoint for it and returns
 // a pointer to the interface. To read this vschema, the caller must convert it to a map
 func (vt *VtProcess) ReadVSchema() (*interface{}, error) {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"slices"
 	"strings"
 	"syscall"
 	"testing"
@@ -309,6 +310,18 @@ func VtcomboProcess(environment Environment, args *Config, mysql MySQLManager) (
 		"--mysql_server_bind_address", vtcomboMysqlBindAddress,
 	}...)
 
+	if len(args.CellLatencies) > 0 {
+		cellLatencies := make([]string, 0, len(args.CellLatencies))
+		for cell, latency := range args.CellLatencies {
+			cellLatencies = append(cellLatencies, fmt.Sprintf("%s:%v", cell, latency))
+		}
+		slices.Sort(cellLatencies)
+		vt.ExtraArgs = append(vt.ExtraArgs, "--cell-latencies", strings.Join(cellLatencies, ","))
+	}
+	if args.EnableFaultInjectionAPI {
+		vt.ExtraArgs = append(vt.ExtraArgs, "--enable-fault-injection-api")
+	}
+
 	if args.ExternalTopoImplementation != "" {
 		vt.ExtraArgs = append(vt.ExtraArgs, []string{
 			"--external_topo_server",
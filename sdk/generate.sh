@@ -0,0 +1,94 @@
+#!/bin/bash
+
+# Copyright 2025 The Vitess Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# This script generates the Python and TypeScript stubs of the vtgate gRPC
+# API from the Vitess .proto files, and sets the version of the SDK packages
+# to the Vitess version.
+#
+# Requirements:
+#   - protoc, installed by `make minimaltools`;
+#   - python3 with grpcio-tools for the Python SDK;
+#   - node and npm for the TypeScript SDK.
+
+set -euo pipefail
+
+vtroot="${VTROOT:-$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)}"
+proto_root="$vtroot/proto"
+sdk_root="$vtroot/sdk"
+protoc_bin="${PROTOC:-$vtroot/bin/protoc}"
+
+version=$(sed -n 's/^const versionName = "\(.*\)"$/\1/p' "$vtroot/go/vt/servenv/version.go")
+if [[ -z "$version" ]]; then
+	echo "ERROR: cannot find the Vitess version in go/vt/servenv/version.go"
+	exit 1
+fi
+
+# The vtgate service and the protos it depends on.
+proto_files=(
+	binlogdata.proto
+	query.proto
+	topodata.proto
+	vschema.proto
+	vtgate.proto
+	vtgateservice.proto
+	vtrpc.proto
+	vttime.proto
+)
+
+generate_python() {
+	local out="$sdk_root/python/vitess/proto"
+	rm -rf "$out"
+	mkdir -p "$out"
+
+	python3 -m grpc_tools.protoc \
+		-I "$proto_root" \
+		--python_out="$out" \
+		--pyi_out="$out" \
+		--grpc_python_out="$out" \
+		"${proto_files[@]}"
+
+	# protoc generates absolute imports, which do not work inside a package.
+	sed -i.bak -E 's/^import ([a-z_]+_pb2)/from . import \1/' "$out"/*.py "$out"/*.pyi
+	rm -f "$out"/*.bak
+	touch "$out/__init__.py"
+
+	# PEP 440 versions have no -SNAPSHOT suffix.
+	local py_version="${version/-SNAPSHOT/.dev0}"
+	sed -i.bak -E "s/^version = \".*\"$/version = \"$py_version\"/" "$sdk_root/python/pyproject.toml"
+	rm -f "$sdk_root/python/pyproject.toml.bak"
+}
+
+generate_typescript() {
+	local out="$sdk_root/typescript/src/proto"
+	rm -rf "$out"
+	mkdir -p "$out"
+
+	(cd "$sdk_root/typescript" && npm install --no-audit --no-fund)
+
+	"$protoc_bin" \
+		-I "$proto_root" \
+		--plugin=protoc-gen-ts_proto="$sdk_root/typescript/node_modules/.bin/protoc-gen-ts_proto" \
+		--ts_proto_out="$out" \
+		--ts_proto_opt=outputServices=grpc-js,esModuleInterop=true,forceLong=string,useOptionals=messages \
+		vtgateservice.proto
+
+	(cd "$sdk_root/typescript" && npm version --no-git-tag-version --allow-same-version "$version" >/dev/null)
+}
+
+generate_python
+generate_typescript
+
+echo "Generated the vtgate SDKs for Vitess $version"
@@ -0,0 +1,24 @@
+# Copyright 2025 The Vitess Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Client for the Vitess vtgate gRPC API.
+
+The stubs generated from the Vitess protos are in vitess.proto, and
+vitess.vtgate provides a session which keeps the state of the vtgate
+session between calls, as the Go vtgateconn package does.
+"""
+
+from vitess.vtgate import Connection, Session, VitessError, bind_variable
+
+__all__ = ["Connection", "Session", "VitessError", "bind_variable"]
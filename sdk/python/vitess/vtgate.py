@@ -0,0 +1,233 @@
+# Copyright 2025 The Vitess Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Sessions of the vtgate gRPC API.
+
+vtgate does not keep any state between calls: the state of a session,
+e.g. its open transaction, is returned by every call and has to be sent
+with the next one. Session does this, so that it can be used like a
+MySQL connection:
+
+    with Connection("localhost:15991") as conn:
+        session = conn.session("commerce@primary")
+        session.begin()
+        session.execute("insert into customer(email) values (:email)",
+                        {"email": "alice@example.com"})
+        session.commit()
+
+A session must not be used concurrently, but any number of sessions can
+share a connection.
+"""
+
+import contextlib
+
+import grpc
+
+from vitess.proto import query_pb2, vtgate_pb2, vtgateservice_pb2_grpc, vtrpc_pb2
+
+
+class VitessError(Exception):
+    """An error returned by vtgate."""
+
+    def __init__(self, code, message):
+        super().__init__(message)
+        self.code = code
+        self.message = message
+
+    def __str__(self):
+        return "%s: %s" % (vtrpc_pb2.Code.Name(self.code), self.message)
+
+
+def bind_variable(value):
+    """Converts a Python value into a query.BindVariable.
+
+    Integers are bound as INT64 (or UINT64 if they do not fit), floats as
+    FLOAT64, strings as VARCHAR, bytes as VARBINARY, None as NULL, and lists
+    and tuples as tuples.
+    """
+    if isinstance(value, query_pb2.BindVariable):
+        return value
+    if isinstance(value, (list, tuple)):
+        values = []
+        for v in value:
+            bv = bind_variable(v)
+            values.append(query_pb2.Value(type=bv.type, value=bv.value))
+        return query_pb2.BindVariable(type=query_pb2.TUPLE, values=values)
+    typ, raw = _encode_value(value)
+    return query_pb2.BindVariable(type=typ, value=raw)
+
+
+def _encode_value(value):
+    if value is None:
+        return query_pb2.NULL_TYPE, b""
+    if isinstance(value, bool):
+        return query_pb2.INT64, b"1" if value else b"0"
+    if isinstance(value, int):
+        if value >= 1 << 63:
+            return query_pb2.UINT64, str(value).encode()
+        return query_pb2.INT64, str(value).encode()
+    if isinstance(value, float):
+        return query_pb2.FLOAT64, repr(value).encode()
+    if isinstance(value, str):
+        return query_pb2.VARCHAR, value.encode()
+    if isinstance(value, (bytes, bytearray)):
+        return query_pb2.VARBINARY, bytes(value)
+    raise TypeError("unsupported bind variable type: %s" % type(value).__name__)
+
+
+class Connection:
+    """A connection to vtgate, shared by any number of sessions."""
+
+    def __init__(self, address, credentials=None, caller_id=None, options=None):
+        """Dials vtgate.
+
+        Args:
+          address: the host:port of the gRPC port of vtgate.
+          credentials: grpc.ChannelCredentials, for a secure channel.
+          caller_id: vtrpc_pb2.CallerID sent with every call.
+          options: the options of the gRPC channel.
+        """
+        if credentials is None:
+            self._channel = grpc.insecure_channel(address, options=options)
+        else:
+            self._channel = grpc.secure_channel(address, credentials, options=options)
+        self._stub = vtgateservice_pb2_grpc.VitessStub(self._channel)
+        self.caller_id = caller_id
+
+    def session(self, target="", options=None):
+        """Returns a new autocommit session on the target, e.g. ks@replica."""
+        return Session(self, target, options)
+
+    @contextlib.contextmanager
+    def transaction(self, target="", options=None):
+        """Runs the block in a transaction on a new session.
+
+        The transaction is committed if the block succeeds, and rolled back
+        if it raises. As with any vtgate transaction, a transaction which
+        spans several shards is not atomic unless the transaction mode of
+        vtgate is TWOPC.
+        """
+        session = self.session(target, options)
+        session.begin()
+        try:
+            yield session
+        except BaseException:
+            session.close()
+            raise
+        session.commit()
+
+    def close(self):
+        self._channel.close()
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc_info):
+        self.close()
+
+
+class Session:
+    """A vtgate session, which is comparable to a MySQL connection."""
+
+    def __init__(self, conn, target="", options=None):
+        self._conn = conn
+        self._session = vtgate_pb2.Session(
+            target_string=target, options=options, autocommit=True
+        )
+
+    @property
+    def in_transaction(self):
+        """True if a transaction is open in the session."""
+        return self._session.in_transaction
+
+    @property
+    def session_pb(self):
+        """The vtgate.Session proto of the session."""
+        return self._session
+
+    def execute(self, sql, bind_variables=None, prepared=False, timeout=None):
+        """Executes a query, and returns its query.QueryResult."""
+        request = vtgate_pb2.ExecuteRequest(
+            caller_id=self._conn.caller_id,
+            session=self._session,
+            query=_bound_query(sql, bind_variables),
+            prepared=prepared,
+        )
+        response = self._conn._stub.Execute(request, timeout=timeout)
+        if response.HasField("session"):
+            self._session = response.session
+        _raise_for_error(response.error)
+        return response.result
+
+    def stream_execute(self, sql, bind_variables=None, timeout=None):
+        """Executes a streaming query, and yields its query.QueryResult
+        chunks. Only the first chunk has the fields of the result."""
+        request = vtgate_pb2.StreamExecuteRequest(
+            caller_id=self._conn.caller_id,
+            session=self._session,
+            query=_bound_query(sql, bind_variables),
+        )
+        try:
+            for response in self._conn._stub.StreamExecute(request, timeout=timeout):
+                if response.HasField("session"):
+                    self._session = response.session
+                if response.HasField("result"):
+                    yield response.result
+        except grpc.RpcError as e:
+            raise VitessError(_code_from_status(e.code()), e.details()) from e
+
+    def begin(self):
+        """Starts a transaction. The following queries of the session are
+        executed in the transaction until commit or rollback is called."""
+        self.execute("begin")
+
+    def commit(self):
+        """Commits the transaction of the session."""
+        self.execute("commit")
+
+    def rollback(self):
+        """Rolls back the transaction of the session."""
+        self.execute("rollback")
+
+    def close(self, timeout=None):
+        """Rolls back the transaction of the session, if any, and releases
+        its reserved connections. The session can be used again after being
+        closed."""
+        request = vtgate_pb2.CloseSessionRequest(
+            caller_id=self._conn.caller_id, session=self._session
+        )
+        response = self._conn._stub.CloseSession(request, timeout=timeout)
+        self._session = vtgate_pb2.Session(
+            target_string=self._session.target_string,
+            options=self._session.options,
+            autocommit=self._session.autocommit,
+        )
+        _raise_for_error(response.error)
+
+
+def _bound_query(sql, bind_variables):
+    return query_pb2.BoundQuery(
+        sql=sql,
+        bind_variables={k: bind_variable(v) for k, v in (bind_variables or {}).items()},
+    )
+
+
+def _raise_for_error(error):
+    if error.code != vtrpc_pb2.OK:
+        raise VitessError(error.code, error.message)
+
+
+def _code_from_status(status):
+    # The vtrpc codes are the same as the gRPC codes.
+    return status.value[0]
@@ -0,0 +1,259 @@
+/**
+ * Copyright 2025 The Vitess Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import * as grpc from '@grpc/grpc-js';
+
+import { BindVariable, BoundQuery, ExecuteOptions, QueryResult, Type } from './proto/query';
+import { CloseSessionRequest, ExecuteRequest, Session as SessionPb, StreamExecuteRequest } from './proto/vtgate';
+import { VitessClient } from './proto/vtgateservice';
+import { CallerID, Code, RPCError } from './proto/vtrpc';
+
+/** An error returned by vtgate. */
+export class VitessError extends Error {
+    constructor(public readonly code: Code, message: string) {
+        super(message);
+        this.name = 'VitessError';
+    }
+}
+
+export type BindValue = null | boolean | number | bigint | string | Uint8Array | BindValue[];
+
+/**
+ * Converts a JavaScript value into a query.BindVariable. Integers are bound
+ * as INT64 (bigints as UINT64 if they do not fit), other numbers as FLOAT64,
+ * strings as VARCHAR, byte arrays as VARBINARY, null as NULL, and arrays as
+ * tuples.
+ */
+export function bindVariable(value: BindValue): BindVariable {
+    if (Array.isArray(value)) {
+        return BindVariable.fromPartial({
+            type: Type.TUPLE,
+            values: value.map((v) => {
+                const bv = bindVariable(v);
+                return { type: bv.type, value: bv.value };
+            }),
+        });
+    }
+    const [type, raw] = encodeValue(value);
+    return BindVariable.fromPartial({ type, value: raw });
+}
+
+const encoder = new TextEncoder();
+
+function encodeValue(value: Exclude<BindValue, BindValue[]>): [Type, Uint8Array] {
+    if (value === null) {
+        return [Type.NULL_TYPE, new Uint8Array()];
+    }
+    switch (typeof value) {
+        case 'boolean':
+            return [Type.INT64, encoder.encode(value ? '1' : '0')];
+        case 'number':
+            return [Number.isInteger(value) ? Type.INT64 : Type.FLOAT64, encoder.encode(String(value))];
+        case 'bigint':
+            return [value >= 1n << 63n ? Type.UINT64 : Type.INT64, encoder.encode(value.toString())];
+        case 'string':
+            return [Type.VARCHAR, encoder.encode(value)];
+    }
+    return [Type.VARBINARY, value];
+}
+
+function boundQuery(sql: string, bindVariables: Record<string, BindValue> = {}): BoundQuery {
+    const bvs: Record<string, BindVariable> = {};
+    for (const [name, value] of Object.entries(bindVariables)) {
+        bvs[name] = bindVariable(value);
+    }
+    return BoundQuery.fromPartial({ sql, bindVariables: bvs });
+}
+
+function checkError(error: RPCError | undefined) {
+    if (error && error.code !== Code.OK) {
+        throw new VitessError(error.code, error.message);
+    }
+}
+
+export interface ConnectionOptions {
+    /** The credentials of the channel; insecure by default. */
+    credentials?: grpc.ChannelCredentials;
+    /** The caller id sent with every call. */
+    callerId?: CallerID;
+    channelOptions?: grpc.ChannelOptions;
+}
+
+/** A connection to vtgate, shared by any number of sessions. */
+export class Connection {
+    readonly client: VitessClient;
+    readonly callerId?: CallerID;
+
+    /** Dials the gRPC port of vtgate, e.g. localhost:15991. */
+    constructor(address: string, options: ConnectionOptions = {}) {
+        this.client = new VitessClient(
+            address,
+            options.credentials ?? grpc.credentials.createInsecure(),
+            options.channelOptions
+        );
+        this.callerId = options.callerId;
+    }
+
+    /** Returns a new autocommit session on the target, e.g. ks@replica. */
+    session(target = '', options?: ExecuteOptions): Session {
+        return new Session(this, target, options);
+    }
+
+    /**
+     * Runs fn in a transaction on a new session. The transaction is
+     * committed if fn resolves, and rolled back if it rejects. As with any
+     * vtgate transaction, a transaction which spans several shards is not
+     * atomic unless the transaction mode of vtgate is TWOPC.
+     */
+    async transaction<T>(target: string, fn: (session: Session) => Promise<T>, options?: ExecuteOptions): Promise<T> {
+        const session = this.session(target, options);
+        await session.begin();
+        let result: T;
+        try {
+            result = await fn(session);
+        } catch (e) {
+            await session.close().catch(() => undefined);
+            throw e;
+        }
+        await session.commit();
+        return result;
+    }
+
+    close() {
+        this.client.close();
+    }
+}
+
+/**
+ * A vtgate session, which is comparable to a MySQL connection. vtgate does
+ * not keep any state between calls: the state of the session, e.g. its open
+ * transaction, is returned by every call and sent with the next one. A
+ * session must not be used concurrently.
+ */
+export class Session {
+    private session: SessionPb;
+
+    constructor(private readonly conn: Connection, target = '', options?: ExecuteOptions) {
+        this.session = SessionPb.fromPartial({ targetString: target, options, autocommit: true });
+    }
+
+    /** True if a transaction is open in the session. */
+    get inTransaction(): boolean {
+        return this.session.inTransaction;
+    }
+
+    /** The vtgate.Session proto of the session. */
+    get sessionPb(): SessionPb {
+        return this.session;
+    }
+
+    /** Executes a query. */
+    execute(sql: string, bindVariables?: Record<string, BindValue>, prepared = false): Promise<QueryResult> {
+        const request = ExecuteRequest.fromPartial({
+            callerId: this.conn.callerId,
+            session: this.session,
+            query: boundQuery(sql, bindVariables),
+            prepared,
+        });
+        return new Promise((resolve, reject) => {
+            this.conn.client.execute(request, (err, response) => {
+                if (err) {
+                    reject(new VitessError(err.code as number as Code, err.details));
+                    return;
+                }
+                if (response.session) {
+                    this.session = response.session;
+                }
+                try {
+                    checkError(response.error);
+                } catch (e) {
+                    reject(e);
+                    return;
+                }
+                resolve(response.result ?? QueryResult.fromPartial({}));
+            });
+        });
+    }
+
+    /**
+     * Executes a streaming query, and yields its chunks. Only the first
+     * chunk has the fields of the result.
+     */
+    async *streamExecute(sql: string, bindVariables?: Record<string, BindValue>): AsyncGenerator<QueryResult> {
+        const request = StreamExecuteRequest.fromPartial({
+            callerId: this.conn.callerId,
+            session: this.session,
+            query: boundQuery(sql, bindVariables),
+        });
+        try {
+            for await (const response of this.conn.client.streamExecute(request)) {
+                if (response.session) {
+                    this.session = response.session;
+                }
+                if (response.result) {
+                    yield response.result;
+                }
+            }
+        } catch (e) {
+            const err = e as grpc.ServiceError;
+            throw new VitessError(err.code as number as Code, err.details);
+        }
+    }
+
+    /**
+     * Starts a transaction. The following queries of the session are
+     * executed in the transaction until commit or rollback is called.
+     */
+    async begin(): Promise<void> {
+        await this.execute('begin');
+    }
+
+    /** Commits the transaction of the session. */
+    async commit(): Promise<void> {
+        await this.execute('commit');
+    }
+
+    /** Rolls back the transaction of the session. */
+    async rollback(): Promise<void> {
+        await this.execute('rollback');
+    }
+
+    /**
+     * Rolls back the transaction of the session, if any, and releases its
+     * reserved connections. The session can be used again after being
+     * closed.
+     */
+    close(): Promise<void> {
+        const request = CloseSessionRequest.fromPartial({ callerId: this.conn.callerId, session: this.session });
+        const { targetString, options, autocommit } = this.session;
+        return new Promise((resolve, reject) => {
+            this.conn.client.closeSession(request, (err, response) => {
+                this.session = SessionPb.fromPartial({ targetString, options, autocommit });
+                if (err) {
+                    reject(new VitessError(err.code as number as Code, err.details));
+                    return;
+                }
+                try {
+                    checkError(response.error);
+                } catch (e) {
+                    reject(e);
+                    return;
+                }
+                resolve();
+            });
+        });
+    }
+}